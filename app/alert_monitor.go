@@ -0,0 +1,228 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/alerts"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/os/diskspace"
+	"github.com/pkg/errors"
+)
+
+const (
+	// alertMonitorInterval is how often the alert monitor checks the
+	// state of the node
+	alertMonitorInterval = 30 * time.Second
+
+	bytesInMiB = 1024 * 1024
+)
+
+// startAlerts enables alerting if any alert sinks are configured
+func startAlerts(cfg *config.Config) (isEnabled bool, err error) {
+	var sinks []alerts.Sink
+	for _, url := range cfg.AlertWebhooks {
+		sink, err := alerts.NewWebhookSink(url)
+		if err != nil {
+			return false, errors.Wrapf(err, "invalid --alertwebhook")
+		}
+		sinks = append(sinks, sink)
+	}
+	for _, url := range cfg.AlertSlackWebhooks {
+		sink, err := alerts.NewSlackSink(url)
+		if err != nil {
+			return false, errors.Wrapf(err, "invalid --alertslackwebhook")
+		}
+		sinks = append(sinks, sink)
+	}
+	if len(sinks) == 0 {
+		return false, nil
+	}
+
+	source := cfg.ActiveNetParams.Name
+	hostname, err := os.Hostname()
+	if err == nil {
+		source = fmt.Sprintf("%s (%s)", hostname, cfg.ActiveNetParams.Name)
+	}
+	err = alerts.Start(&alerts.Config{
+		Sinks:     sinks,
+		Source:    source,
+		RateLimit: cfg.AlertRateLimit,
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// alertMonitor periodically checks the sync state, the peer count and the
+// free disk space of the node, and sends an alert whenever one of them
+// becomes a problem, and another once it's resolved
+type alertMonitor struct {
+	cfg              *config.Config
+	componentManager *ComponentManager
+	quit             chan struct{}
+	done             chan struct{}
+
+	hasBeenSynced          bool
+	isUnsynced             bool
+	hasHadEnoughPeers      bool
+	hasTooFewPeers         bool
+	isDiskSpaceLow         bool
+	isDiskSpaceUnsupported bool
+}
+
+// startAlertMonitor starts checking the state of the node every
+// alertMonitorInterval
+func startAlertMonitor(cfg *config.Config, componentManager *ComponentManager) *alertMonitor {
+	monitor := &alertMonitor{
+		cfg:              cfg,
+		componentManager: componentManager,
+		quit:             make(chan struct{}),
+		done:             make(chan struct{}),
+	}
+	spawn("alertMonitor.monitorLoop", monitor.monitorLoop)
+	return monitor
+}
+
+func (monitor *alertMonitor) monitorLoop() {
+	defer close(monitor.done)
+
+	ticker := time.NewTicker(alertMonitorInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			monitor.checkSyncState()
+			monitor.checkPeerCount()
+			monitor.checkDiskSpace()
+		case <-monitor.quit:
+			return
+		}
+	}
+}
+
+// stop stops the checks. It must be called before the database is closed.
+func (monitor *alertMonitor) stop() error {
+	close(monitor.quit)
+	<-monitor.done
+	return nil
+}
+
+// checkSyncState alerts when the node falls out of sync. A node that hasn't
+// been synced since it started, such as during its initial sync, isn't
+// alerted about.
+func (monitor *alertMonitor) checkSyncState() {
+	isNearlySynced, err := monitor.componentManager.Domain().Consensus().IsNearlySynced()
+	if err != nil {
+		log.Warnf("Couldn't check whether the node is synced for alerts: %s", err)
+		return
+	}
+	if isNearlySynced {
+		monitor.hasBeenSynced = true
+		if monitor.isUnsynced {
+			monitor.isUnsynced = false
+			alerts.Send(&alerts.Event{
+				Kind:     alerts.KindNodeUnsynced,
+				Severity: alerts.SeverityResolved,
+				Message:  "The node is synced again",
+			})
+		}
+		return
+	}
+	if !monitor.hasBeenSynced || monitor.isUnsynced {
+		return
+	}
+	monitor.isUnsynced = true
+	alerts.Send(&alerts.Event{
+		Kind:     alerts.KindNodeUnsynced,
+		Severity: alerts.SeverityCritical,
+		Message:  "The node fell out of sync with the network",
+	})
+}
+
+// checkPeerCount alerts when the number of connected peers falls below
+// --healthminpeers, after having reached it since the node started
+func (monitor *alertMonitor) checkPeerCount() {
+	minPeers := monitor.cfg.HealthMinPeers
+	if minPeers <= 0 {
+		return
+	}
+	peerCount := len(monitor.componentManager.ProtocolManager().Peers())
+	fields := map[string]string{
+		"peers":    strconv.Itoa(peerCount),
+		"minPeers": strconv.Itoa(minPeers),
+	}
+	if peerCount >= minPeers {
+		monitor.hasHadEnoughPeers = true
+		if monitor.hasTooFewPeers {
+			monitor.hasTooFewPeers = false
+			alerts.Send(&alerts.Event{
+				Kind:     alerts.KindPeerCountCollapse,
+				Severity: alerts.SeverityResolved,
+				Message:  fmt.Sprintf("%d peers are connected again", peerCount),
+				Fields:   fields,
+			})
+		}
+		return
+	}
+	if !monitor.hasHadEnoughPeers || monitor.hasTooFewPeers {
+		return
+	}
+	monitor.hasTooFewPeers = true
+	alerts.Send(&alerts.Event{
+		Kind:     alerts.KindPeerCountCollapse,
+		Severity: alerts.SeverityWarning,
+		Message:  fmt.Sprintf("Only %d peers are connected, fewer than the minimum of %d", peerCount, minPeers),
+		Fields:   fields,
+	})
+}
+
+// checkDiskSpace alerts when the free disk space of the data directory
+// falls below --alertmindiskspace
+func (monitor *alertMonitor) checkDiskSpace() {
+	if monitor.cfg.AlertMinDiskSpace == 0 || monitor.isDiskSpaceUnsupported {
+		return
+	}
+	available, err := diskspace.Available(monitor.cfg.AppDir)
+	if err != nil {
+		if errors.Is(err, diskspace.ErrUnsupported) {
+			log.Warnf("Not alerting about low disk space: %s", err)
+			monitor.isDiskSpaceUnsupported = true
+			return
+		}
+		log.Warnf("Couldn't check the free disk space for alerts: %s", err)
+		return
+	}
+	availableMiB := available / bytesInMiB
+	fields := map[string]string{
+		"path":            monitor.cfg.AppDir,
+		"availableMiB":    strconv.FormatUint(availableMiB, 10),
+		"minAvailableMiB": strconv.FormatUint(monitor.cfg.AlertMinDiskSpace, 10),
+	}
+	if availableMiB >= monitor.cfg.AlertMinDiskSpace {
+		if monitor.isDiskSpaceLow {
+			monitor.isDiskSpaceLow = false
+			alerts.Send(&alerts.Event{
+				Kind:     alerts.KindDiskSpaceLow,
+				Severity: alerts.SeverityResolved,
+				Message:  fmt.Sprintf("%d MiB of disk space are free again", availableMiB),
+				Fields:   fields,
+			})
+		}
+		return
+	}
+	if monitor.isDiskSpaceLow {
+		return
+	}
+	monitor.isDiskSpaceLow = true
+	alerts.Send(&alerts.Event{
+		Kind:     alerts.KindDiskSpaceLow,
+		Severity: alerts.SeverityCritical,
+		Message: fmt.Sprintf("Only %d MiB of disk space are free in %s, fewer than the minimum of %d MiB",
+			availableMiB, monitor.cfg.AppDir, monitor.cfg.AlertMinDiskSpace),
+		Fields: fields,
+	})
+}
@@ -7,22 +7,37 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/kaspanet/kaspad/infrastructure/alerts"
 	"github.com/kaspanet/kaspad/infrastructure/config"
 	"github.com/kaspanet/kaspad/infrastructure/db/database"
-	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/metricsdb"
 	"github.com/kaspanet/kaspad/infrastructure/logger"
 	"github.com/kaspanet/kaspad/infrastructure/os/execenv"
 	"github.com/kaspanet/kaspad/infrastructure/os/limits"
+	"github.com/kaspanet/kaspad/infrastructure/os/shutdown"
 	"github.com/kaspanet/kaspad/infrastructure/os/signal"
 	"github.com/kaspanet/kaspad/infrastructure/os/winservice"
+	"github.com/kaspanet/kaspad/infrastructure/tracing"
 	"github.com/kaspanet/kaspad/util/panics"
 	"github.com/kaspanet/kaspad/util/profiling"
 	"github.com/kaspanet/kaspad/version"
+	"github.com/pkg/errors"
 )
 
 const (
-	leveldbCacheSizeMiB = 256
-	defaultDataDirname  = "datadir2"
+	defaultDataDirname = "datadir2"
+
+	// databaseCloseTimeout is how long closing the database may take
+	// during shutdown, before kaspad exits without waiting for it
+	databaseCloseTimeout = 30 * time.Second
+
+	// tracingStopTimeout is how long exporting the remaining spans may
+	// take during shutdown
+	tracingStopTimeout = 5 * time.Second
+
+	// alertsStopTimeout is how long delivering the remaining alerts may
+	// take during shutdown
+	alertsStopTimeout = 10 * time.Second
 )
 
 var desiredLimits = &limits.DesiredLimits{
@@ -102,20 +117,71 @@ func (app *kaspadApp) main(startedChan chan<- struct{}) error {
 		}
 	}
 
+	// The components register their shutdown hooks as they're created,
+	// and the hooks run in phases once main returns, such that the database
+	// is closed last
+	shutdownCoordinator := shutdown.NewCoordinator()
+	defer func() {
+		log.Infof("Gracefully shutting down kaspad...")
+		err := shutdownCoordinator.Run()
+		if err != nil {
+			log.Errorf("Kaspad didn't shut down cleanly: %s", err)
+		}
+	}()
+
+	if app.cfg.TracingEndpoint != "" {
+		err := tracing.Start(&tracing.Config{
+			Endpoint:       app.cfg.TracingEndpoint,
+			SampleRate:     app.cfg.TracingSampleRate,
+			ServiceName:    "kaspad",
+			ServiceVersion: version.Version(),
+		})
+		if err != nil {
+			log.Errorf("Starting tracing failed: %+v", err)
+			return err
+		}
+		// The spans of the work that's drained end before the exporter
+		// is stopped
+		shutdownCoordinator.Register(shutdown.PhaseCloseDatabase, "the trace exporter", tracingStopTimeout,
+			func() error { return tracing.Stop(tracingStopTimeout) })
+	}
+
+	isAlertingEnabled, err := startAlerts(app.cfg)
+	if err != nil {
+		log.Errorf("Starting alerts failed: %+v", err)
+		return err
+	}
+	if isAlertingEnabled {
+		shutdownCoordinator.Register(shutdown.PhaseCloseDatabase, "the alert delivery", alertsStopTimeout,
+			func() error { return alerts.Stop(alertsStopTimeout) })
+	}
+
 	// Open the database
 	databaseContext, err := openDB(app.cfg)
 	if err != nil {
 		log.Errorf("Loading database failed: %+v", err)
 		return err
 	}
-
-	defer func() {
-		log.Infof("Gracefully shutting down the database...")
-		err := databaseContext.Close()
-		if err != nil {
-			log.Errorf("Failed to close the database: %s", err)
-		}
-	}()
+	shutdownCoordinator.Register(shutdown.PhaseCloseDatabase, "the database", databaseCloseTimeout,
+		databaseContext.Close)
+
+	// The service manager is told kaspad is stopping as soon as shutdown
+	// starts, since the watchdog isn't pinged from then on
+	serviceNotifier := startServiceNotifier(databaseContext)
+	shutdownCoordinator.Register(shutdown.PhaseStopAcceptingWork, "the service manager notifications", stopTimeout,
+		func() error {
+			serviceNotifier.notifyStopping()
+			return serviceNotifier.stop()
+		})
+
+	if app.cfg.SchemaMigrationDryRun {
+		return logPendingSchemaMigrations(databaseContext)
+	}
+	err = migrateDatabaseSchema(app.cfg, databaseContext, databasePath(app.cfg), app.cfg.DbType)
+	if err != nil {
+		log.Errorf("Migrating the database failed: %+v", err)
+		return err
+	}
 
 	// Return now if an interrupt signal was triggered.
 	if signal.InterruptRequested(interrupt) {
@@ -129,36 +195,38 @@ func (app *kaspadApp) main(startedChan chan<- struct{}) error {
 		return err
 	}
 
-	defer func() {
-		log.Infof("Gracefully shutting down kaspad...")
-
-		shutdownDone := make(chan struct{})
-		go func() {
-			componentManager.Stop()
-			shutdownDone <- struct{}{}
-		}()
-
-		const shutdownTimeout = 2 * time.Minute
-
-		select {
-		case <-shutdownDone:
-		case <-time.After(shutdownTimeout):
-			log.Criticalf("Graceful shutdown timed out %s. Terminating...", shutdownTimeout)
-		}
-		log.Infof("Kaspad shutdown complete")
-	}()
+	componentManager.RegisterShutdownHooks(shutdownCoordinator)
 
 	componentManager.Start()
+	serviceNotifier.notifyReady()
+
+	if isAlertingEnabled {
+		alertMonitor := startAlertMonitor(app.cfg, componentManager)
+		shutdownCoordinator.Register(shutdown.PhaseStopAcceptingWork, "the alert monitor", stopTimeout,
+			alertMonitor.stop)
+	}
 
 	if startedChan != nil {
 		startedChan <- struct{}{}
 	}
 
-	// Wait until the interrupt signal is received from an OS signal or
+	// Reload the config whenever it's requested with an OS signal such as
+	// SIGHUP, until the interrupt signal is received from an OS signal or
 	// shutdown is requested through one of the subsystems such as the RPC
 	// server.
-	<-interrupt
-	return nil
+	reloadRequested, stopReloadListener := signal.ReloadListener()
+	defer stopReloadListener()
+	for {
+		select {
+		case <-reloadRequested:
+			_, err := componentManager.ReloadConfig()
+			if err != nil {
+				log.Errorf("Reloading the configuration failed. Keeping the current configuration: %s", err)
+			}
+		case <-interrupt:
+			return nil
+		}
+	}
 }
 
 // dbPath returns the path to the block database given a database type.
@@ -174,16 +242,52 @@ func removeDatabase(cfg *config.Config) error {
 func openDB(cfg *config.Config) (database.Database, error) {
 	dbPath := databasePath(cfg)
 
-	err := checkDatabaseVersion(dbPath)
+	// The backend is read before the version file is created, since databases
+	// that have a version file but no backend file are leveldb databases
+	backend, err := databaseBackend(dbPath, cfg.DbType)
 	if err != nil {
 		return nil, err
 	}
 
-	log.Infof("Loading database from '%s'", dbPath)
-	db, err := ldb.NewLevelDB(dbPath, leveldbCacheSizeMiB)
+	err = checkDatabaseVersion(dbPath)
 	if err != nil {
 		return nil, err
 	}
+	if backend != cfg.DbType {
+		if !cfg.MigrateDatabase {
+			return nil, errors.Errorf("The database in '%s' is a %s database, but --dbtype is %s. "+
+				"Run with --migrate-db to migrate it, or with --dbtype=%s to keep using it",
+				dbPath, backend, cfg.DbType, backend)
+		}
+		err := migrateDatabase(dbPath, backend, cfg.DbType)
+		if err != nil {
+			return nil, err
+		}
+		backend = cfg.DbType
+	}
+	err = createDatabaseBackendFile(dbPath, backend)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Loading %s database from '%s' with %s durability", backend, dbPath, cfg.DbDurability)
+	db, err := openDatabaseBackendWithDurability(dbPath, backend,
+		database.Durability(cfg.DbDurability), cfg.DbSyncInterval)
+	if err != nil {
+		return nil, err
+	}
+	wrappedDB, err := openDatabaseEncryption(cfg, db, dbPath)
+	if err != nil {
+		closeErr := db.Close()
+		if closeErr != nil {
+			log.Errorf("Failed to close the database: %s", closeErr)
+		}
+		return nil, err
+	}
+	if cfg.DbMetrics {
+		log.Infof("Recording database operation metrics")
+		wrappedDB = metricsdb.New(wrappedDB, databaseMetricsBucketDepth)
+	}
 
-	return db, nil
+	return wrappedDB, nil
 }
@@ -3,8 +3,9 @@ package appmessage
 import "time"
 
 type baseMessage struct {
-	messageNumber uint64
-	receivedAt    time.Time
+	messageNumber        uint64
+	receivedAt           time.Time
+	notificationSequence uint64
 }
 
 func (b *baseMessage) MessageNumber() uint64 {
@@ -22,3 +23,11 @@ func (b *baseMessage) ReceivedAt() time.Time {
 func (b *baseMessage) SetReceivedAt(receivedAt time.Time) {
 	b.receivedAt = receivedAt
 }
+
+func (b *baseMessage) NotificationSequence() uint64 {
+	return b.notificationSequence
+}
+
+func (b *baseMessage) SetNotificationSequence(notificationSequence uint64) {
+	b.notificationSequence = notificationSequence
+}
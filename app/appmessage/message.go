@@ -163,6 +163,102 @@ const (
 	CmdGetMempoolEntriesByAddressesResponseMessage
 	CmdGetCoinSupplyRequestMessage
 	CmdGetCoinSupplyResponseMessage
+	CmdGetWorkRequestMessage
+	CmdGetWorkResponseMessage
+	CmdSubmitWorkRequestMessage
+	CmdSubmitWorkResponseMessage
+	CmdNotifyWorkRequestMessage
+	CmdNotifyWorkResponseMessage
+	CmdWorkNotificationMessage
+	CmdGetBlockSubmissionStatusRequestMessage
+	CmdGetBlockSubmissionStatusResponseMessage
+	CmdGenerateBlocksRequestMessage
+	CmdGenerateBlocksResponseMessage
+	CmdGetAuxCommitmentProofRequestMessage
+	CmdGetAuxCommitmentProofResponseMessage
+	CmdNotifyNewTipRequestMessage
+	CmdNotifyNewTipResponseMessage
+	CmdNewTipNotificationMessage
+	CmdStopNotifyingNewTipRequestMessage
+	CmdStopNotifyingNewTipResponseMessage
+	CmdNotifyFinalityPointAdvancedRequestMessage
+	CmdNotifyFinalityPointAdvancedResponseMessage
+	CmdFinalityPointAdvancedNotificationMessage
+	CmdStopNotifyingFinalityPointAdvancedRequestMessage
+	CmdStopNotifyingFinalityPointAdvancedResponseMessage
+	CmdStopNotifyingVirtualSelectedParentChainChangedRequestMessage
+	CmdStopNotifyingVirtualSelectedParentChainChangedResponseMessage
+	CmdSearchRawTransactionsRequestMessage
+	CmdSearchRawTransactionsResponseMessage
+	CmdHealthCheckRequestMessage
+	CmdHealthCheckResponseMessage
+	CmdSignRawTransactionRequestMessage
+	CmdSignRawTransactionResponseMessage
+	CmdNotifyNewTransactionsRequestMessage
+	CmdNotifyNewTransactionsResponseMessage
+	CmdNewTransactionsNotificationMessage
+	CmdStopNotifyingNewTransactionsRequestMessage
+	CmdStopNotifyingNewTransactionsResponseMessage
+	CmdSetBanRequestMessage
+	CmdSetBanResponseMessage
+	CmdListBannedRequestMessage
+	CmdListBannedResponseMessage
+	CmdClearBannedRequestMessage
+	CmdClearBannedResponseMessage
+	CmdDumpUTXOSetRequestMessage
+	CmdDumpUTXOSetResponseMessage
+	CmdImportUTXOSetRequestMessage
+	CmdImportUTXOSetResponseMessage
+	CmdStartNotificationSessionRequestMessage
+	CmdStartNotificationSessionResponseMessage
+	CmdResumeNotificationSessionRequestMessage
+	CmdResumeNotificationSessionResponseMessage
+	CmdGetFinalityPointRequestMessage
+	CmdGetFinalityPointResponseMessage
+	CmdGetDatabaseStatsRequestMessage
+	CmdGetDatabaseStatsResponseMessage
+	CmdCompactDatabaseRequestMessage
+	CmdCompactDatabaseResponseMessage
+	CmdBackupDatabaseRequestMessage
+	CmdBackupDatabaseResponseMessage
+	CmdGetIndexStatusRequestMessage
+	CmdGetIndexStatusResponseMessage
+	CmdRebuildIndexRequestMessage
+	CmdRebuildIndexResponseMessage
+	CmdDropIndexRequestMessage
+	CmdDropIndexResponseMessage
+	CmdExportBlocksRequestMessage
+	CmdExportBlocksResponseMessage
+	CmdGetAddressManagerInfoRequestMessage
+	CmdGetAddressManagerInfoResponseMessage
+	CmdReloadConfigRequestMessage
+	CmdReloadConfigResponseMessage
+	CmdCaptureProfileRequestMessage
+	CmdCaptureProfileResponseMessage
+	CmdDebugLevelRequestMessage
+	CmdDebugLevelResponseMessage
+	CmdGetChainAnalysisRequestMessage
+	CmdGetChainAnalysisResponseMessage
+	CmdRescanRequestMessage
+	CmdRescanResponseMessage
+	CmdRescanProgressNotificationMessage
+	CmdStopRescanRequestMessage
+	CmdStopRescanResponseMessage
+	CmdAddTransactionBlocklistEntriesRequestMessage
+	CmdAddTransactionBlocklistEntriesResponseMessage
+	CmdRemoveTransactionBlocklistEntriesRequestMessage
+	CmdRemoveTransactionBlocklistEntriesResponseMessage
+	CmdGetTransactionBlocklistRequestMessage
+	CmdGetTransactionBlocklistResponseMessage
+	CmdGetJobsRequestMessage
+	CmdGetJobsResponseMessage
+	CmdCancelJobRequestMessage
+	CmdCancelJobResponseMessage
+	CmdNotifyJobProgressRequestMessage
+	CmdNotifyJobProgressResponseMessage
+	CmdJobProgressNotificationMessage
+	CmdStopNotifyingJobProgressRequestMessage
+	CmdStopNotifyingJobProgressResponseMessage
 )
 
 // ProtocolMessageCommandToString maps all MessageCommands to their string representation
@@ -214,92 +310,190 @@ var ProtocolMessageCommandToString = map[MessageCommand]string{
 
 // RPCMessageCommandToString maps all MessageCommands to their string representation
 var RPCMessageCommandToString = map[MessageCommand]string{
-	CmdGetCurrentNetworkRequestMessage:                            "GetCurrentNetworkRequest",
-	CmdGetCurrentNetworkResponseMessage:                           "GetCurrentNetworkResponse",
-	CmdSubmitBlockRequestMessage:                                  "SubmitBlockRequest",
-	CmdSubmitBlockResponseMessage:                                 "SubmitBlockResponse",
-	CmdGetBlockTemplateRequestMessage:                             "GetBlockTemplateRequest",
-	CmdGetBlockTemplateResponseMessage:                            "GetBlockTemplateResponse",
-	CmdGetBlockTemplateTransactionMessage:                         "CmdGetBlockTemplateTransaction",
-	CmdNotifyBlockAddedRequestMessage:                             "NotifyBlockAddedRequest",
-	CmdNotifyBlockAddedResponseMessage:                            "NotifyBlockAddedResponse",
-	CmdBlockAddedNotificationMessage:                              "BlockAddedNotification",
-	CmdGetPeerAddressesRequestMessage:                             "GetPeerAddressesRequest",
-	CmdGetPeerAddressesResponseMessage:                            "GetPeerAddressesResponse",
-	CmdGetSelectedTipHashRequestMessage:                           "GetSelectedTipHashRequest",
-	CmdGetSelectedTipHashResponseMessage:                          "GetSelectedTipHashResponse",
-	CmdGetMempoolEntryRequestMessage:                              "GetMempoolEntryRequest",
-	CmdGetMempoolEntryResponseMessage:                             "GetMempoolEntryResponse",
-	CmdGetConnectedPeerInfoRequestMessage:                         "GetConnectedPeerInfoRequest",
-	CmdGetConnectedPeerInfoResponseMessage:                        "GetConnectedPeerInfoResponse",
-	CmdAddPeerRequestMessage:                                      "AddPeerRequest",
-	CmdAddPeerResponseMessage:                                     "AddPeerResponse",
-	CmdSubmitTransactionRequestMessage:                            "SubmitTransactionRequest",
-	CmdSubmitTransactionResponseMessage:                           "SubmitTransactionResponse",
-	CmdNotifyVirtualSelectedParentChainChangedRequestMessage:      "NotifyVirtualSelectedParentChainChangedRequest",
-	CmdNotifyVirtualSelectedParentChainChangedResponseMessage:     "NotifyVirtualSelectedParentChainChangedResponse",
-	CmdVirtualSelectedParentChainChangedNotificationMessage:       "VirtualSelectedParentChainChangedNotification",
-	CmdGetBlockRequestMessage:                                     "GetBlockRequest",
-	CmdGetBlockResponseMessage:                                    "GetBlockResponse",
-	CmdGetSubnetworkRequestMessage:                                "GetSubnetworkRequest",
-	CmdGetSubnetworkResponseMessage:                               "GetSubnetworkResponse",
-	CmdGetVirtualSelectedParentChainFromBlockRequestMessage:       "GetVirtualSelectedParentChainFromBlockRequest",
-	CmdGetVirtualSelectedParentChainFromBlockResponseMessage:      "GetVirtualSelectedParentChainFromBlockResponse",
-	CmdGetBlocksRequestMessage:                                    "GetBlocksRequest",
-	CmdGetBlocksResponseMessage:                                   "GetBlocksResponse",
-	CmdGetBlockCountRequestMessage:                                "GetBlockCountRequest",
-	CmdGetBlockCountResponseMessage:                               "GetBlockCountResponse",
-	CmdGetBlockDAGInfoRequestMessage:                              "GetBlockDAGInfoRequest",
-	CmdGetBlockDAGInfoResponseMessage:                             "GetBlockDAGInfoResponse",
-	CmdResolveFinalityConflictRequestMessage:                      "ResolveFinalityConflictRequest",
-	CmdResolveFinalityConflictResponseMessage:                     "ResolveFinalityConflictResponse",
-	CmdNotifyFinalityConflictsRequestMessage:                      "NotifyFinalityConflictsRequest",
-	CmdNotifyFinalityConflictsResponseMessage:                     "NotifyFinalityConflictsResponse",
-	CmdFinalityConflictNotificationMessage:                        "FinalityConflictNotification",
-	CmdFinalityConflictResolvedNotificationMessage:                "FinalityConflictResolvedNotification",
-	CmdGetMempoolEntriesRequestMessage:                            "GetMempoolEntriesRequest",
-	CmdGetMempoolEntriesResponseMessage:                           "GetMempoolEntriesResponse",
-	CmdGetHeadersRequestMessage:                                   "GetHeadersRequest",
-	CmdGetHeadersResponseMessage:                                  "GetHeadersResponse",
-	CmdNotifyUTXOsChangedRequestMessage:                           "NotifyUTXOsChangedRequest",
-	CmdNotifyUTXOsChangedResponseMessage:                          "NotifyUTXOsChangedResponse",
-	CmdUTXOsChangedNotificationMessage:                            "UTXOsChangedNotification",
-	CmdStopNotifyingUTXOsChangedRequestMessage:                    "StopNotifyingUTXOsChangedRequest",
-	CmdStopNotifyingUTXOsChangedResponseMessage:                   "StopNotifyingUTXOsChangedResponse",
-	CmdGetUTXOsByAddressesRequestMessage:                          "GetUTXOsByAddressesRequest",
-	CmdGetUTXOsByAddressesResponseMessage:                         "GetUTXOsByAddressesResponse",
-	CmdGetBalanceByAddressRequestMessage:                          "GetBalanceByAddressRequest",
-	CmdGetBalanceByAddressResponseMessage:                         "GetBalancesByAddressResponse",
-	CmdGetVirtualSelectedParentBlueScoreRequestMessage:            "GetVirtualSelectedParentBlueScoreRequest",
-	CmdGetVirtualSelectedParentBlueScoreResponseMessage:           "GetVirtualSelectedParentBlueScoreResponse",
-	CmdNotifyVirtualSelectedParentBlueScoreChangedRequestMessage:  "NotifyVirtualSelectedParentBlueScoreChangedRequest",
-	CmdNotifyVirtualSelectedParentBlueScoreChangedResponseMessage: "NotifyVirtualSelectedParentBlueScoreChangedResponse",
-	CmdVirtualSelectedParentBlueScoreChangedNotificationMessage:   "VirtualSelectedParentBlueScoreChangedNotification",
-	CmdBanRequestMessage:                                          "BanRequest",
-	CmdBanResponseMessage:                                         "BanResponse",
-	CmdUnbanRequestMessage:                                        "UnbanRequest",
-	CmdUnbanResponseMessage:                                       "UnbanResponse",
-	CmdGetInfoRequestMessage:                                      "GetInfoRequest",
-	CmdGetInfoResponseMessage:                                     "GeInfoResponse",
-	CmdNotifyPruningPointUTXOSetOverrideRequestMessage:            "NotifyPruningPointUTXOSetOverrideRequest",
-	CmdNotifyPruningPointUTXOSetOverrideResponseMessage:           "NotifyPruningPointUTXOSetOverrideResponse",
-	CmdPruningPointUTXOSetOverrideNotificationMessage:             "PruningPointUTXOSetOverrideNotification",
-	CmdStopNotifyingPruningPointUTXOSetOverrideRequestMessage:     "StopNotifyingPruningPointUTXOSetOverrideRequest",
-	CmdStopNotifyingPruningPointUTXOSetOverrideResponseMessage:    "StopNotifyingPruningPointUTXOSetOverrideResponse",
-	CmdEstimateNetworkHashesPerSecondRequestMessage:               "EstimateNetworkHashesPerSecondRequest",
-	CmdEstimateNetworkHashesPerSecondResponseMessage:              "EstimateNetworkHashesPerSecondResponse",
-	CmdNotifyVirtualDaaScoreChangedRequestMessage:                 "NotifyVirtualDaaScoreChangedRequest",
-	CmdNotifyVirtualDaaScoreChangedResponseMessage:                "NotifyVirtualDaaScoreChangedResponse",
-	CmdVirtualDaaScoreChangedNotificationMessage:                  "VirtualDaaScoreChangedNotification",
-	CmdGetBalancesByAddressesRequestMessage:                       "GetBalancesByAddressesRequest",
-	CmdGetBalancesByAddressesResponseMessage:                      "GetBalancesByAddressesResponse",
-	CmdNotifyNewBlockTemplateRequestMessage:                       "NotifyNewBlockTemplateRequest",
-	CmdNotifyNewBlockTemplateResponseMessage:                      "NotifyNewBlockTemplateResponse",
-	CmdNewBlockTemplateNotificationMessage:                        "NewBlockTemplateNotification",
-	CmdGetMempoolEntriesByAddressesRequestMessage:                 "GetMempoolEntriesByAddressesRequest",
-	CmdGetMempoolEntriesByAddressesResponseMessage:                "GetMempoolEntriesByAddressesResponse",
-	CmdGetCoinSupplyRequestMessage:                                "GetCoinSupplyRequest",
-	CmdGetCoinSupplyResponseMessage:                               "GetCoinSupplyResponse",
+	CmdGetCurrentNetworkRequestMessage:                               "GetCurrentNetworkRequest",
+	CmdGetCurrentNetworkResponseMessage:                              "GetCurrentNetworkResponse",
+	CmdSubmitBlockRequestMessage:                                     "SubmitBlockRequest",
+	CmdSubmitBlockResponseMessage:                                    "SubmitBlockResponse",
+	CmdGetBlockTemplateRequestMessage:                                "GetBlockTemplateRequest",
+	CmdGetBlockTemplateResponseMessage:                               "GetBlockTemplateResponse",
+	CmdGetBlockTemplateTransactionMessage:                            "CmdGetBlockTemplateTransaction",
+	CmdNotifyBlockAddedRequestMessage:                                "NotifyBlockAddedRequest",
+	CmdNotifyBlockAddedResponseMessage:                               "NotifyBlockAddedResponse",
+	CmdBlockAddedNotificationMessage:                                 "BlockAddedNotification",
+	CmdGetPeerAddressesRequestMessage:                                "GetPeerAddressesRequest",
+	CmdGetPeerAddressesResponseMessage:                               "GetPeerAddressesResponse",
+	CmdGetSelectedTipHashRequestMessage:                              "GetSelectedTipHashRequest",
+	CmdGetSelectedTipHashResponseMessage:                             "GetSelectedTipHashResponse",
+	CmdGetMempoolEntryRequestMessage:                                 "GetMempoolEntryRequest",
+	CmdGetMempoolEntryResponseMessage:                                "GetMempoolEntryResponse",
+	CmdGetConnectedPeerInfoRequestMessage:                            "GetConnectedPeerInfoRequest",
+	CmdGetConnectedPeerInfoResponseMessage:                           "GetConnectedPeerInfoResponse",
+	CmdAddPeerRequestMessage:                                         "AddPeerRequest",
+	CmdAddPeerResponseMessage:                                        "AddPeerResponse",
+	CmdSubmitTransactionRequestMessage:                               "SubmitTransactionRequest",
+	CmdSubmitTransactionResponseMessage:                              "SubmitTransactionResponse",
+	CmdNotifyVirtualSelectedParentChainChangedRequestMessage:         "NotifyVirtualSelectedParentChainChangedRequest",
+	CmdNotifyVirtualSelectedParentChainChangedResponseMessage:        "NotifyVirtualSelectedParentChainChangedResponse",
+	CmdVirtualSelectedParentChainChangedNotificationMessage:          "VirtualSelectedParentChainChangedNotification",
+	CmdGetBlockRequestMessage:                                        "GetBlockRequest",
+	CmdGetBlockResponseMessage:                                       "GetBlockResponse",
+	CmdGetSubnetworkRequestMessage:                                   "GetSubnetworkRequest",
+	CmdGetSubnetworkResponseMessage:                                  "GetSubnetworkResponse",
+	CmdGetVirtualSelectedParentChainFromBlockRequestMessage:          "GetVirtualSelectedParentChainFromBlockRequest",
+	CmdGetVirtualSelectedParentChainFromBlockResponseMessage:         "GetVirtualSelectedParentChainFromBlockResponse",
+	CmdGetBlocksRequestMessage:                                       "GetBlocksRequest",
+	CmdGetBlocksResponseMessage:                                      "GetBlocksResponse",
+	CmdGetBlockCountRequestMessage:                                   "GetBlockCountRequest",
+	CmdGetBlockCountResponseMessage:                                  "GetBlockCountResponse",
+	CmdGetBlockDAGInfoRequestMessage:                                 "GetBlockDAGInfoRequest",
+	CmdGetBlockDAGInfoResponseMessage:                                "GetBlockDAGInfoResponse",
+	CmdResolveFinalityConflictRequestMessage:                         "ResolveFinalityConflictRequest",
+	CmdResolveFinalityConflictResponseMessage:                        "ResolveFinalityConflictResponse",
+	CmdNotifyFinalityConflictsRequestMessage:                         "NotifyFinalityConflictsRequest",
+	CmdNotifyFinalityConflictsResponseMessage:                        "NotifyFinalityConflictsResponse",
+	CmdFinalityConflictNotificationMessage:                           "FinalityConflictNotification",
+	CmdFinalityConflictResolvedNotificationMessage:                   "FinalityConflictResolvedNotification",
+	CmdGetMempoolEntriesRequestMessage:                               "GetMempoolEntriesRequest",
+	CmdGetMempoolEntriesResponseMessage:                              "GetMempoolEntriesResponse",
+	CmdShutDownRequestMessage:                                        "ShutDownRequest",
+	CmdShutDownResponseMessage:                                       "ShutDownResponse",
+	CmdGetHeadersRequestMessage:                                      "GetHeadersRequest",
+	CmdGetHeadersResponseMessage:                                     "GetHeadersResponse",
+	CmdNotifyUTXOsChangedRequestMessage:                              "NotifyUTXOsChangedRequest",
+	CmdNotifyUTXOsChangedResponseMessage:                             "NotifyUTXOsChangedResponse",
+	CmdUTXOsChangedNotificationMessage:                               "UTXOsChangedNotification",
+	CmdStopNotifyingUTXOsChangedRequestMessage:                       "StopNotifyingUTXOsChangedRequest",
+	CmdStopNotifyingUTXOsChangedResponseMessage:                      "StopNotifyingUTXOsChangedResponse",
+	CmdGetUTXOsByAddressesRequestMessage:                             "GetUTXOsByAddressesRequest",
+	CmdGetUTXOsByAddressesResponseMessage:                            "GetUTXOsByAddressesResponse",
+	CmdGetBalanceByAddressRequestMessage:                             "GetBalanceByAddressRequest",
+	CmdGetBalanceByAddressResponseMessage:                            "GetBalancesByAddressResponse",
+	CmdGetVirtualSelectedParentBlueScoreRequestMessage:               "GetVirtualSelectedParentBlueScoreRequest",
+	CmdGetVirtualSelectedParentBlueScoreResponseMessage:              "GetVirtualSelectedParentBlueScoreResponse",
+	CmdNotifyVirtualSelectedParentBlueScoreChangedRequestMessage:     "NotifyVirtualSelectedParentBlueScoreChangedRequest",
+	CmdNotifyVirtualSelectedParentBlueScoreChangedResponseMessage:    "NotifyVirtualSelectedParentBlueScoreChangedResponse",
+	CmdVirtualSelectedParentBlueScoreChangedNotificationMessage:      "VirtualSelectedParentBlueScoreChangedNotification",
+	CmdBanRequestMessage:                                             "BanRequest",
+	CmdBanResponseMessage:                                            "BanResponse",
+	CmdUnbanRequestMessage:                                           "UnbanRequest",
+	CmdUnbanResponseMessage:                                          "UnbanResponse",
+	CmdGetInfoRequestMessage:                                         "GetInfoRequest",
+	CmdGetInfoResponseMessage:                                        "GeInfoResponse",
+	CmdNotifyPruningPointUTXOSetOverrideRequestMessage:               "NotifyPruningPointUTXOSetOverrideRequest",
+	CmdNotifyPruningPointUTXOSetOverrideResponseMessage:              "NotifyPruningPointUTXOSetOverrideResponse",
+	CmdPruningPointUTXOSetOverrideNotificationMessage:                "PruningPointUTXOSetOverrideNotification",
+	CmdStopNotifyingPruningPointUTXOSetOverrideRequestMessage:        "StopNotifyingPruningPointUTXOSetOverrideRequest",
+	CmdStopNotifyingPruningPointUTXOSetOverrideResponseMessage:       "StopNotifyingPruningPointUTXOSetOverrideResponse",
+	CmdEstimateNetworkHashesPerSecondRequestMessage:                  "EstimateNetworkHashesPerSecondRequest",
+	CmdEstimateNetworkHashesPerSecondResponseMessage:                 "EstimateNetworkHashesPerSecondResponse",
+	CmdNotifyVirtualDaaScoreChangedRequestMessage:                    "NotifyVirtualDaaScoreChangedRequest",
+	CmdNotifyVirtualDaaScoreChangedResponseMessage:                   "NotifyVirtualDaaScoreChangedResponse",
+	CmdVirtualDaaScoreChangedNotificationMessage:                     "VirtualDaaScoreChangedNotification",
+	CmdGetBalancesByAddressesRequestMessage:                          "GetBalancesByAddressesRequest",
+	CmdGetBalancesByAddressesResponseMessage:                         "GetBalancesByAddressesResponse",
+	CmdNotifyNewBlockTemplateRequestMessage:                          "NotifyNewBlockTemplateRequest",
+	CmdNotifyNewBlockTemplateResponseMessage:                         "NotifyNewBlockTemplateResponse",
+	CmdNewBlockTemplateNotificationMessage:                           "NewBlockTemplateNotification",
+	CmdGetMempoolEntriesByAddressesRequestMessage:                    "GetMempoolEntriesByAddressesRequest",
+	CmdGetMempoolEntriesByAddressesResponseMessage:                   "GetMempoolEntriesByAddressesResponse",
+	CmdGetCoinSupplyRequestMessage:                                   "GetCoinSupplyRequest",
+	CmdGetCoinSupplyResponseMessage:                                  "GetCoinSupplyResponse",
+	CmdGetWorkRequestMessage:                                         "GetWorkRequest",
+	CmdGetWorkResponseMessage:                                        "GetWorkResponse",
+	CmdSubmitWorkRequestMessage:                                      "SubmitWorkRequest",
+	CmdSubmitWorkResponseMessage:                                     "SubmitWorkResponse",
+	CmdNotifyWorkRequestMessage:                                      "NotifyWorkRequest",
+	CmdNotifyWorkResponseMessage:                                     "NotifyWorkResponse",
+	CmdWorkNotificationMessage:                                       "WorkNotification",
+	CmdGetBlockSubmissionStatusRequestMessage:                        "GetBlockSubmissionStatusRequest",
+	CmdGetBlockSubmissionStatusResponseMessage:                       "GetBlockSubmissionStatusResponse",
+	CmdGenerateBlocksRequestMessage:                                  "GenerateBlocksRequest",
+	CmdGenerateBlocksResponseMessage:                                 "GenerateBlocksResponse",
+	CmdGetAuxCommitmentProofRequestMessage:                           "GetAuxCommitmentProofRequest",
+	CmdGetAuxCommitmentProofResponseMessage:                          "GetAuxCommitmentProofResponse",
+	CmdNotifyNewTipRequestMessage:                                    "NotifyNewTipRequest",
+	CmdNotifyNewTipResponseMessage:                                   "NotifyNewTipResponse",
+	CmdNewTipNotificationMessage:                                     "NewTipNotification",
+	CmdStopNotifyingNewTipRequestMessage:                             "StopNotifyingNewTipRequest",
+	CmdStopNotifyingNewTipResponseMessage:                            "StopNotifyingNewTipResponse",
+	CmdNotifyFinalityPointAdvancedRequestMessage:                     "NotifyFinalityPointAdvancedRequest",
+	CmdNotifyFinalityPointAdvancedResponseMessage:                    "NotifyFinalityPointAdvancedResponse",
+	CmdFinalityPointAdvancedNotificationMessage:                      "FinalityPointAdvancedNotification",
+	CmdStopNotifyingFinalityPointAdvancedRequestMessage:              "StopNotifyingFinalityPointAdvancedRequest",
+	CmdStopNotifyingFinalityPointAdvancedResponseMessage:             "StopNotifyingFinalityPointAdvancedResponse",
+	CmdStopNotifyingVirtualSelectedParentChainChangedRequestMessage:  "StopNotifyingVirtualSelectedParentChainChangedRequest",
+	CmdStopNotifyingVirtualSelectedParentChainChangedResponseMessage: "StopNotifyingVirtualSelectedParentChainChangedResponse",
+	CmdSearchRawTransactionsRequestMessage:                           "SearchRawTransactionsRequest",
+	CmdSearchRawTransactionsResponseMessage:                          "SearchRawTransactionsResponse",
+	CmdHealthCheckRequestMessage:                                     "HealthCheckRequest",
+	CmdHealthCheckResponseMessage:                                    "HealthCheckResponse",
+	CmdSignRawTransactionRequestMessage:                              "SignRawTransactionRequest",
+	CmdSignRawTransactionResponseMessage:                             "SignRawTransactionResponse",
+	CmdNotifyNewTransactionsRequestMessage:                           "NotifyNewTransactionsRequest",
+	CmdNotifyNewTransactionsResponseMessage:                          "NotifyNewTransactionsResponse",
+	CmdNewTransactionsNotificationMessage:                            "NewTransactionsNotification",
+	CmdStopNotifyingNewTransactionsRequestMessage:                    "StopNotifyingNewTransactionsRequest",
+	CmdStopNotifyingNewTransactionsResponseMessage:                   "StopNotifyingNewTransactionsResponse",
+	CmdSetBanRequestMessage:                                          "SetBanRequest",
+	CmdSetBanResponseMessage:                                         "SetBanResponse",
+	CmdListBannedRequestMessage:                                      "ListBannedRequest",
+	CmdListBannedResponseMessage:                                     "ListBannedResponse",
+	CmdClearBannedRequestMessage:                                     "ClearBannedRequest",
+	CmdClearBannedResponseMessage:                                    "ClearBannedResponse",
+	CmdDumpUTXOSetRequestMessage:                                     "DumpUTXOSetRequest",
+	CmdDumpUTXOSetResponseMessage:                                    "DumpUTXOSetResponse",
+	CmdImportUTXOSetRequestMessage:                                   "ImportUTXOSetRequest",
+	CmdImportUTXOSetResponseMessage:                                  "ImportUTXOSetResponse",
+	CmdStartNotificationSessionRequestMessage:                        "StartNotificationSessionRequest",
+	CmdStartNotificationSessionResponseMessage:                       "StartNotificationSessionResponse",
+	CmdResumeNotificationSessionRequestMessage:                       "ResumeNotificationSessionRequest",
+	CmdResumeNotificationSessionResponseMessage:                      "ResumeNotificationSessionResponse",
+	CmdGetFinalityPointRequestMessage:                                "GetFinalityPointRequest",
+	CmdGetFinalityPointResponseMessage:                               "GetFinalityPointResponse",
+	CmdGetDatabaseStatsRequestMessage:                                "GetDatabaseStatsRequest",
+	CmdGetDatabaseStatsResponseMessage:                               "GetDatabaseStatsResponse",
+	CmdCompactDatabaseRequestMessage:                                 "CompactDatabaseRequest",
+	CmdCompactDatabaseResponseMessage:                                "CompactDatabaseResponse",
+	CmdBackupDatabaseRequestMessage:                                  "BackupDatabaseRequest",
+	CmdBackupDatabaseResponseMessage:                                 "BackupDatabaseResponse",
+	CmdGetIndexStatusRequestMessage:                                  "GetIndexStatusRequest",
+	CmdGetIndexStatusResponseMessage:                                 "GetIndexStatusResponse",
+	CmdRebuildIndexRequestMessage:                                    "RebuildIndexRequest",
+	CmdRebuildIndexResponseMessage:                                   "RebuildIndexResponse",
+	CmdDropIndexRequestMessage:                                       "DropIndexRequest",
+	CmdDropIndexResponseMessage:                                      "DropIndexResponse",
+	CmdExportBlocksRequestMessage:                                    "ExportBlocksRequest",
+	CmdExportBlocksResponseMessage:                                   "ExportBlocksResponse",
+	CmdGetAddressManagerInfoRequestMessage:                           "GetAddressManagerInfoRequest",
+	CmdGetAddressManagerInfoResponseMessage:                          "GetAddressManagerInfoResponse",
+	CmdReloadConfigRequestMessage:                                    "ReloadConfigRequest",
+	CmdReloadConfigResponseMessage:                                   "ReloadConfigResponse",
+	CmdCaptureProfileRequestMessage:                                  "CaptureProfileRequest",
+	CmdCaptureProfileResponseMessage:                                 "CaptureProfileResponse",
+	CmdDebugLevelRequestMessage:                                      "DebugLevelRequest",
+	CmdDebugLevelResponseMessage:                                     "DebugLevelResponse",
+	CmdGetChainAnalysisRequestMessage:                                "GetChainAnalysisRequest",
+	CmdGetChainAnalysisResponseMessage:                               "GetChainAnalysisResponse",
+	CmdRescanRequestMessage:                                          "RescanRequest",
+	CmdRescanResponseMessage:                                         "RescanResponse",
+	CmdRescanProgressNotificationMessage:                             "RescanProgressNotification",
+	CmdStopRescanRequestMessage:                                      "StopRescanRequest",
+	CmdStopRescanResponseMessage:                                     "StopRescanResponse",
+	CmdAddTransactionBlocklistEntriesRequestMessage:                  "AddTransactionBlocklistEntriesRequest",
+	CmdAddTransactionBlocklistEntriesResponseMessage:                 "AddTransactionBlocklistEntriesResponse",
+	CmdRemoveTransactionBlocklistEntriesRequestMessage:               "RemoveTransactionBlocklistEntriesRequest",
+	CmdRemoveTransactionBlocklistEntriesResponseMessage:              "RemoveTransactionBlocklistEntriesResponse",
+	CmdGetTransactionBlocklistRequestMessage:                         "GetTransactionBlocklistRequest",
+	CmdGetTransactionBlocklistResponseMessage:                        "GetTransactionBlocklistResponse",
+	CmdGetJobsRequestMessage:                                         "GetJobsRequest",
+	CmdGetJobsResponseMessage:                                        "GetJobsResponse",
+	CmdCancelJobRequestMessage:                                       "CancelJobRequest",
+	CmdCancelJobResponseMessage:                                      "CancelJobResponse",
+	CmdNotifyJobProgressRequestMessage:                               "NotifyJobProgressRequest",
+	CmdNotifyJobProgressResponseMessage:                              "NotifyJobProgressResponse",
+	CmdJobProgressNotificationMessage:                                "JobProgressNotification",
+	CmdStopNotifyingJobProgressRequestMessage:                        "StopNotifyingJobProgressRequest",
+	CmdStopNotifyingJobProgressResponseMessage:                       "StopNotifyingJobProgressResponse",
 }
 
 // Message is an interface that describes a kaspa message. A type that
@@ -312,4 +506,6 @@ type Message interface {
 	SetMessageNumber(index uint64)
 	ReceivedAt() time.Time
 	SetReceivedAt(receivedAt time.Time)
+	NotificationSequence() uint64
+	SetNotificationSequence(notificationSequence uint64)
 }
@@ -58,6 +58,19 @@ type MsgVersion struct {
 
 	// The subnetwork of the generator of the version message. Should be nil in full nodes
 	SubnetworkID *externalapi.DomainSubnetworkID
+
+	// The subnetworks whose transactions the generator of the version message
+	// validates, indexes and relays. Nil in nodes that serve every subnetwork
+	SubnetworkRoles *SubnetworkRoles
+}
+
+// SubnetworkRoles lists the subnetworks whose transactions a node validates,
+// indexes and relays. An empty list means every subnetwork. The native and
+// built-in subnetworks are always served, even when they aren't listed.
+type SubnetworkRoles struct {
+	ValidatedSubnetworkIDs []*externalapi.DomainSubnetworkID
+	IndexedSubnetworkIDs   []*externalapi.DomainSubnetworkID
+	RelayedSubnetworkIDs   []*externalapi.DomainSubnetworkID
 }
 
 // HasService returns whether the specified service is supported by the peer
@@ -0,0 +1,50 @@
+package appmessage
+
+// AddTransactionBlocklistEntriesRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type AddTransactionBlocklistEntriesRequestMessage struct {
+	baseMessage
+	Entries []*TransactionBlocklistEntry
+}
+
+// TransactionBlocklistEntry is a transaction ID or a script public key that
+// the mempool refuses to accept and relay transactions by
+type TransactionBlocklistEntry struct {
+	TransactionID   string
+	ScriptPublicKey *RPCScriptPublicKey
+	Reason          string
+	AddedAt         int64
+}
+
+// Command returns the protocol command string for the message
+func (msg *AddTransactionBlocklistEntriesRequestMessage) Command() MessageCommand {
+	return CmdAddTransactionBlocklistEntriesRequestMessage
+}
+
+// NewAddTransactionBlocklistEntriesRequestMessage returns an instance of the message
+func NewAddTransactionBlocklistEntriesRequestMessage(entries []*TransactionBlocklistEntry) *AddTransactionBlocklistEntriesRequestMessage {
+	return &AddTransactionBlocklistEntriesRequestMessage{
+		Entries: entries,
+	}
+}
+
+// AddTransactionBlocklistEntriesResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type AddTransactionBlocklistEntriesResponseMessage struct {
+	baseMessage
+	RemovedTransactionIDs []string
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *AddTransactionBlocklistEntriesResponseMessage) Command() MessageCommand {
+	return CmdAddTransactionBlocklistEntriesResponseMessage
+}
+
+// NewAddTransactionBlocklistEntriesResponseMessage returns an instance of the message
+func NewAddTransactionBlocklistEntriesResponseMessage(removedTransactionIDs []string) *AddTransactionBlocklistEntriesResponseMessage {
+	return &AddTransactionBlocklistEntriesResponseMessage{
+		RemovedTransactionIDs: removedTransactionIDs,
+	}
+}
@@ -0,0 +1,37 @@
+package appmessage
+
+// BackupDatabaseRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type BackupDatabaseRequestMessage struct {
+	baseMessage
+	TargetDirectory string
+}
+
+// Command returns the protocol command string for the message
+func (msg *BackupDatabaseRequestMessage) Command() MessageCommand {
+	return CmdBackupDatabaseRequestMessage
+}
+
+// NewBackupDatabaseRequestMessage returns a instance of the message
+func NewBackupDatabaseRequestMessage(targetDirectory string) *BackupDatabaseRequestMessage {
+	return &BackupDatabaseRequestMessage{
+		TargetDirectory: targetDirectory,
+	}
+}
+
+// BackupDatabaseResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type BackupDatabaseResponseMessage struct {
+	baseMessage
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *BackupDatabaseResponseMessage) Command() MessageCommand {
+	return CmdBackupDatabaseResponseMessage
+}
+
+// NewBackupDatabaseResponseMessage returns a instance of the message
+func NewBackupDatabaseResponseMessage() *BackupDatabaseResponseMessage {
+	return &BackupDatabaseResponseMessage{}
+}
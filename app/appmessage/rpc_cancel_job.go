@@ -0,0 +1,37 @@
+package appmessage
+
+// CancelJobRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type CancelJobRequestMessage struct {
+	baseMessage
+	JobID uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *CancelJobRequestMessage) Command() MessageCommand {
+	return CmdCancelJobRequestMessage
+}
+
+// NewCancelJobRequestMessage returns a instance of the message
+func NewCancelJobRequestMessage(jobID uint64) *CancelJobRequestMessage {
+	return &CancelJobRequestMessage{
+		JobID: jobID,
+	}
+}
+
+// CancelJobResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type CancelJobResponseMessage struct {
+	baseMessage
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *CancelJobResponseMessage) Command() MessageCommand {
+	return CmdCancelJobResponseMessage
+}
+
+// NewCancelJobResponseMessage returns a instance of the message
+func NewCancelJobResponseMessage() *CancelJobResponseMessage {
+	return &CancelJobResponseMessage{}
+}
@@ -0,0 +1,49 @@
+package appmessage
+
+// CaptureProfileRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type CaptureProfileRequestMessage struct {
+	baseMessage
+	ProfileType     string
+	DurationSeconds uint32
+	IncludeData     bool
+}
+
+// Command returns the protocol command string for the message
+func (msg *CaptureProfileRequestMessage) Command() MessageCommand {
+	return CmdCaptureProfileRequestMessage
+}
+
+// NewCaptureProfileRequestMessage returns a instance of the message
+func NewCaptureProfileRequestMessage(profileType string, durationSeconds uint32,
+	includeData bool) *CaptureProfileRequestMessage {
+
+	return &CaptureProfileRequestMessage{
+		ProfileType:     profileType,
+		DurationSeconds: durationSeconds,
+		IncludeData:     includeData,
+	}
+}
+
+// CaptureProfileResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type CaptureProfileResponseMessage struct {
+	baseMessage
+	FilePath string
+	Data     []byte
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *CaptureProfileResponseMessage) Command() MessageCommand {
+	return CmdCaptureProfileResponseMessage
+}
+
+// NewCaptureProfileResponseMessage returns a instance of the message
+func NewCaptureProfileResponseMessage(filePath string, data []byte) *CaptureProfileResponseMessage {
+	return &CaptureProfileResponseMessage{
+		FilePath: filePath,
+		Data:     data,
+	}
+}
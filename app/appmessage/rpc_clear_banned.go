@@ -0,0 +1,35 @@
+package appmessage
+
+// ClearBannedRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type ClearBannedRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *ClearBannedRequestMessage) Command() MessageCommand {
+	return CmdClearBannedRequestMessage
+}
+
+// NewClearBannedRequestMessage returns an instance of the message
+func NewClearBannedRequestMessage() *ClearBannedRequestMessage {
+	return &ClearBannedRequestMessage{}
+}
+
+// ClearBannedResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type ClearBannedResponseMessage struct {
+	baseMessage
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *ClearBannedResponseMessage) Command() MessageCommand {
+	return CmdClearBannedResponseMessage
+}
+
+// NewClearBannedResponseMessage returns a instance of the message
+func NewClearBannedResponseMessage() *ClearBannedResponseMessage {
+	return &ClearBannedResponseMessage{}
+}
@@ -0,0 +1,47 @@
+package appmessage
+
+// DebugLevelRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type DebugLevelRequestMessage struct {
+	baseMessage
+	LevelSpec string
+}
+
+// Command returns the protocol command string for the message
+func (msg *DebugLevelRequestMessage) Command() MessageCommand {
+	return CmdDebugLevelRequestMessage
+}
+
+// NewDebugLevelRequestMessage returns a instance of the message
+func NewDebugLevelRequestMessage(levelSpec string) *DebugLevelRequestMessage {
+	return &DebugLevelRequestMessage{
+		LevelSpec: levelSpec,
+	}
+}
+
+// DebugLevelResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type DebugLevelResponseMessage struct {
+	baseMessage
+	SubsystemLogLevels []*SubsystemLogLevel
+
+	Error *RPCError
+}
+
+// SubsystemLogLevel is the logging level of a single subsystem
+type SubsystemLogLevel struct {
+	Subsystem string
+	Level     string
+}
+
+// Command returns the protocol command string for the message
+func (msg *DebugLevelResponseMessage) Command() MessageCommand {
+	return CmdDebugLevelResponseMessage
+}
+
+// NewDebugLevelResponseMessage returns a instance of the message
+func NewDebugLevelResponseMessage(subsystemLogLevels []*SubsystemLogLevel) *DebugLevelResponseMessage {
+	return &DebugLevelResponseMessage{
+		SubsystemLogLevels: subsystemLogLevels,
+	}
+}
@@ -0,0 +1,37 @@
+package appmessage
+
+// DropIndexRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type DropIndexRequestMessage struct {
+	baseMessage
+	IndexName string
+}
+
+// Command returns the protocol command string for the message
+func (msg *DropIndexRequestMessage) Command() MessageCommand {
+	return CmdDropIndexRequestMessage
+}
+
+// NewDropIndexRequestMessage returns a instance of the message
+func NewDropIndexRequestMessage(indexName string) *DropIndexRequestMessage {
+	return &DropIndexRequestMessage{
+		IndexName: indexName,
+	}
+}
+
+// DropIndexResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type DropIndexResponseMessage struct {
+	baseMessage
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *DropIndexResponseMessage) Command() MessageCommand {
+	return CmdDropIndexResponseMessage
+}
+
+// NewDropIndexResponseMessage returns a instance of the message
+func NewDropIndexResponseMessage() *DropIndexResponseMessage {
+	return &DropIndexResponseMessage{}
+}
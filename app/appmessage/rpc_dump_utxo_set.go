@@ -0,0 +1,51 @@
+package appmessage
+
+// DumpUTXOSetRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type DumpUTXOSetRequestMessage struct {
+	baseMessage
+	FilePath string
+	RunAsJob bool
+}
+
+// Command returns the protocol command string for the message
+func (msg *DumpUTXOSetRequestMessage) Command() MessageCommand {
+	return CmdDumpUTXOSetRequestMessage
+}
+
+// NewDumpUTXOSetRequestMessage returns an instance of the message
+func NewDumpUTXOSetRequestMessage(filePath string, runAsJob bool) *DumpUTXOSetRequestMessage {
+	return &DumpUTXOSetRequestMessage{
+		FilePath: filePath,
+		RunAsJob: runAsJob,
+	}
+}
+
+// DumpUTXOSetResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type DumpUTXOSetResponseMessage struct {
+	baseMessage
+	PruningPointHash string
+	UTXOCommitment   string
+	UTXOCount        uint64
+	JobID            uint64
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *DumpUTXOSetResponseMessage) Command() MessageCommand {
+	return CmdDumpUTXOSetResponseMessage
+}
+
+// NewDumpUTXOSetResponseMessage returns a instance of the message
+func NewDumpUTXOSetResponseMessage(pruningPointHash string, utxoCommitment string, utxoCount uint64,
+	jobID uint64) *DumpUTXOSetResponseMessage {
+
+	return &DumpUTXOSetResponseMessage{
+		PruningPointHash: pruningPointHash,
+		UTXOCommitment:   utxoCommitment,
+		UTXOCount:        utxoCount,
+		JobID:            jobID,
+	}
+}
@@ -0,0 +1,49 @@
+package appmessage
+
+// ExportBlocksRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type ExportBlocksRequestMessage struct {
+	baseMessage
+	FilePath string
+	LowHash  string
+	HighHash string
+}
+
+// Command returns the protocol command string for the message
+func (msg *ExportBlocksRequestMessage) Command() MessageCommand {
+	return CmdExportBlocksRequestMessage
+}
+
+// NewExportBlocksRequestMessage returns an instance of the message
+func NewExportBlocksRequestMessage(filePath string, lowHash string, highHash string) *ExportBlocksRequestMessage {
+	return &ExportBlocksRequestMessage{
+		FilePath: filePath,
+		LowHash:  lowHash,
+		HighHash: highHash,
+	}
+}
+
+// ExportBlocksResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type ExportBlocksResponseMessage struct {
+	baseMessage
+	LowHash    string
+	HighHash   string
+	BlockCount uint64
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *ExportBlocksResponseMessage) Command() MessageCommand {
+	return CmdExportBlocksResponseMessage
+}
+
+// NewExportBlocksResponseMessage returns a instance of the message
+func NewExportBlocksResponseMessage(lowHash string, highHash string, blockCount uint64) *ExportBlocksResponseMessage {
+	return &ExportBlocksResponseMessage{
+		LowHash:    lowHash,
+		HighHash:   highHash,
+		BlockCount: blockCount,
+	}
+}
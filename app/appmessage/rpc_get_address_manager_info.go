@@ -0,0 +1,54 @@
+package appmessage
+
+// GetAddressManagerInfoRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetAddressManagerInfoRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetAddressManagerInfoRequestMessage) Command() MessageCommand {
+	return CmdGetAddressManagerInfoRequestMessage
+}
+
+// NewGetAddressManagerInfoRequestMessage returns a instance of the message
+func NewGetAddressManagerInfoRequestMessage() *GetAddressManagerInfoRequestMessage {
+	return &GetAddressManagerInfoRequestMessage{}
+}
+
+// GetAddressManagerInfoResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetAddressManagerInfoResponseMessage struct {
+	baseMessage
+	AddressCount       uint32
+	BannedAddressCount uint32
+	TriedCount         uint32
+	NewCount           uint32
+	BucketOccupancy    []uint32
+	BucketSize         uint32
+	Sources            []*AddressSourceInfo
+	AgeDistribution    []*AddressAgeBucket
+
+	Error *RPCError
+}
+
+// AddressSourceInfo describes the addresses that were sent by peers of a
+// single network group
+type AddressSourceInfo struct {
+	Group               string
+	AddressCount        uint32
+	ConnectionAttempts  uint64
+	ConnectionSuccesses uint64
+}
+
+// AddressAgeBucket counts the addresses that were last seen up to MaxAge
+// milliseconds ago. A zero MaxAge has no limit.
+type AddressAgeBucket struct {
+	MaxAge int64
+	Count  uint32
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetAddressManagerInfoResponseMessage) Command() MessageCommand {
+	return CmdGetAddressManagerInfoResponseMessage
+}
@@ -0,0 +1,49 @@
+package appmessage
+
+// GetAuxCommitmentProofRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetAuxCommitmentProofRequestMessage struct {
+	baseMessage
+	BlockHash string
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetAuxCommitmentProofRequestMessage) Command() MessageCommand {
+	return CmdGetAuxCommitmentProofRequestMessage
+}
+
+// NewGetAuxCommitmentProofRequestMessage returns a instance of the message
+func NewGetAuxCommitmentProofRequestMessage(blockHash string) *GetAuxCommitmentProofRequestMessage {
+	return &GetAuxCommitmentProofRequestMessage{
+		BlockHash: blockHash,
+	}
+}
+
+// GetAuxCommitmentProofResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetAuxCommitmentProofResponseMessage struct {
+	baseMessage
+	AuxCommitment       string
+	Header              *RPCBlockHeader
+	CoinbaseTransaction *RPCTransaction
+	MerkleBranch        []string
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetAuxCommitmentProofResponseMessage) Command() MessageCommand {
+	return CmdGetAuxCommitmentProofResponseMessage
+}
+
+// NewGetAuxCommitmentProofResponseMessage returns a instance of the message
+func NewGetAuxCommitmentProofResponseMessage(auxCommitment string, header *RPCBlockHeader,
+	coinbaseTransaction *RPCTransaction, merkleBranch []string) *GetAuxCommitmentProofResponseMessage {
+
+	return &GetAuxCommitmentProofResponseMessage{
+		AuxCommitment:       auxCommitment,
+		Header:              header,
+		CoinbaseTransaction: coinbaseTransaction,
+		MerkleBranch:        merkleBranch,
+	}
+}
@@ -30,6 +30,19 @@ type GetBlockDAGInfoResponseMessage struct {
 	PruningPointHash    string
 	VirtualDAAScore     uint64
 
+	VirtualBlueScore               uint64
+	VirtualSelectedParentHash      string
+	VirtualSelectedParentTimestamp int64
+	HeadersSelectedTipHash         string
+	FinalityPointHash              string
+	PruningPointDAAScore           uint64
+	IsArchival                     bool
+	IsSynced                       bool
+	IsIBDRunning                   bool
+	SyncProgress                   float64
+	ConnectedPeerCount             uint32
+	MempoolSize                    uint64
+
 	Error *RPCError
 }
 
@@ -0,0 +1,53 @@
+package appmessage
+
+// Statuses of blocks submitted asynchronously, as returned from GetBlockSubmissionStatus
+const (
+	BlockSubmissionStatusPending  = "pending"
+	BlockSubmissionStatusAccepted = "accepted"
+	BlockSubmissionStatusOrphan   = "orphan"
+	BlockSubmissionStatusRejected = "rejected"
+)
+
+// GetBlockSubmissionStatusRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetBlockSubmissionStatusRequestMessage struct {
+	baseMessage
+	SubmissionID string
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetBlockSubmissionStatusRequestMessage) Command() MessageCommand {
+	return CmdGetBlockSubmissionStatusRequestMessage
+}
+
+// NewGetBlockSubmissionStatusRequestMessage returns a instance of the message
+func NewGetBlockSubmissionStatusRequestMessage(submissionID string) *GetBlockSubmissionStatusRequestMessage {
+	return &GetBlockSubmissionStatusRequestMessage{
+		SubmissionID: submissionID,
+	}
+}
+
+// GetBlockSubmissionStatusResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetBlockSubmissionStatusResponseMessage struct {
+	baseMessage
+	BlockHash    string
+	Status       string
+	RejectReason string
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetBlockSubmissionStatusResponseMessage) Command() MessageCommand {
+	return CmdGetBlockSubmissionStatusResponseMessage
+}
+
+// NewGetBlockSubmissionStatusResponseMessage returns a instance of the message
+func NewGetBlockSubmissionStatusResponseMessage(blockHash string, status string, rejectReason string) *GetBlockSubmissionStatusResponseMessage {
+	return &GetBlockSubmissionStatusResponseMessage{
+		BlockHash:    blockHash,
+		Status:       status,
+		RejectReason: rejectReason,
+	}
+}
@@ -1,11 +1,25 @@
 package appmessage
 
+// These constants are the modes a GetBlockTemplateRequestMessage may be sent with
+const (
+	// BlockTemplateModeTemplate requests a new block template. This is the default mode
+	BlockTemplateModeTemplate = "template"
+
+	// BlockTemplateModeProposal requests to validate a proposal block without adding it
+	BlockTemplateModeProposal = "proposal"
+)
+
 // GetBlockTemplateRequestMessage is an appmessage corresponding to
 // its respective RPC message
 type GetBlockTemplateRequestMessage struct {
 	baseMessage
-	PayAddress string
-	ExtraData  string
+	PayAddress             string
+	ExtraData              string
+	MinFees                uint64
+	MaxFeeWaitMilliseconds uint32
+	Mode                   string
+	Proposal               *RPCBlock
+	AuxCommitment          string
 }
 
 // Command returns the protocol command string for the message
@@ -21,12 +35,26 @@ func NewGetBlockTemplateRequestMessage(payAddress, extraData string) *GetBlockTe
 	}
 }
 
+// NewGetBlockTemplateProposalRequestMessage returns a instance of the message
+// that requests to validate the given proposal block
+func NewGetBlockTemplateProposalRequestMessage(proposal *RPCBlock) *GetBlockTemplateRequestMessage {
+	return &GetBlockTemplateRequestMessage{
+		Mode:     BlockTemplateModeProposal,
+		Proposal: proposal,
+	}
+}
+
 // GetBlockTemplateResponseMessage is an appmessage corresponding to
 // its respective RPC message
 type GetBlockTemplateResponseMessage struct {
 	baseMessage
-	Block    *RPCBlock
-	IsSynced bool
+	Block                     *RPCBlock
+	IsSynced                  bool
+	TemplateVersion           uint64
+	TemplateAgeInMilliseconds int64
+	ProposalAccepted          bool
+	ProposalRejectReason      string
+	ProposalRejectMessage     string
 
 	Error *RPCError
 }
@@ -37,9 +65,25 @@ func (msg *GetBlockTemplateResponseMessage) Command() MessageCommand {
 }
 
 // NewGetBlockTemplateResponseMessage returns a instance of the message
-func NewGetBlockTemplateResponseMessage(block *RPCBlock, isSynced bool) *GetBlockTemplateResponseMessage {
+func NewGetBlockTemplateResponseMessage(block *RPCBlock, isSynced bool, templateVersion uint64,
+	templateAgeInMilliseconds int64) *GetBlockTemplateResponseMessage {
+
+	return &GetBlockTemplateResponseMessage{
+		Block:                     block,
+		IsSynced:                  isSynced,
+		TemplateVersion:           templateVersion,
+		TemplateAgeInMilliseconds: templateAgeInMilliseconds,
+	}
+}
+
+// NewGetBlockTemplateProposalResponseMessage returns a instance of the message
+// that reports the outcome of a proposal block validation
+func NewGetBlockTemplateProposalResponseMessage(accepted bool, rejectReason string,
+	rejectMessage string) *GetBlockTemplateResponseMessage {
+
 	return &GetBlockTemplateResponseMessage{
-		Block:    block,
-		IsSynced: isSynced,
+		ProposalAccepted:      accepted,
+		ProposalRejectReason:  rejectReason,
+		ProposalRejectMessage: rejectMessage,
 	}
 }
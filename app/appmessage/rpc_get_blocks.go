@@ -7,6 +7,7 @@ type GetBlocksRequestMessage struct {
 	LowHash             string
 	IncludeBlocks       bool
 	IncludeTransactions bool
+	Limit               uint32
 }
 
 // Command returns the protocol command string for the message
@@ -30,6 +31,7 @@ type GetBlocksResponseMessage struct {
 	baseMessage
 	BlockHashes []string
 	Blocks      []*RPCBlock
+	NextLowHash string
 
 	Error *RPCError
 }
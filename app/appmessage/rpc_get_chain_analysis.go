@@ -0,0 +1,56 @@
+package appmessage
+
+// GetChainAnalysisRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetChainAnalysisRequestMessage struct {
+	baseMessage
+	LowBlueScore  uint64
+	HighBlueScore uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetChainAnalysisRequestMessage) Command() MessageCommand {
+	return CmdGetChainAnalysisRequestMessage
+}
+
+// NewGetChainAnalysisRequestMessage returns a instance of the message
+func NewGetChainAnalysisRequestMessage(lowBlueScore uint64, highBlueScore uint64) *GetChainAnalysisRequestMessage {
+	return &GetChainAnalysisRequestMessage{
+		LowBlueScore:  lowBlueScore,
+		HighBlueScore: highBlueScore,
+	}
+}
+
+// GetChainAnalysisResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetChainAnalysisResponseMessage struct {
+	baseMessage
+	LowBlueScore       uint64
+	HighBlueScore      uint64
+	ChainBlockCount    uint64
+	BlockCount         uint64
+	RedBlockCount      uint64
+	ParallelismFactor  float64
+	AverageParentCount float64
+	RedRate            float64
+	OrphanLatency      *OrphanLatencyDistribution
+
+	Error *RPCError
+}
+
+// OrphanLatencyDistribution describes how long orphan blocks waited for
+// their missing ancestors
+type OrphanLatencyDistribution struct {
+	Count              uint64
+	MinMilliseconds    uint64
+	MedianMilliseconds uint64
+	P90Milliseconds    uint64
+	P99Milliseconds    uint64
+	MaxMilliseconds    uint64
+	MeanMilliseconds   uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetChainAnalysisResponseMessage) Command() MessageCommand {
+	return CmdGetChainAnalysisResponseMessage
+}
@@ -4,6 +4,8 @@ package appmessage
 // its respective RPC message
 type GetConnectedPeerInfoRequestMessage struct {
 	baseMessage
+	Limit  uint32
+	Cursor string
 }
 
 // Command returns the protocol command string for the message
@@ -20,8 +22,10 @@ func NewGetConnectedPeerInfoRequestMessage() *GetConnectedPeerInfoRequestMessage
 // its respective RPC message
 type GetConnectedPeerInfoResponseMessage struct {
 	baseMessage
-	Infos []*GetConnectedPeerInfoMessage
-	Error *RPCError
+	Infos             []*GetConnectedPeerInfoMessage
+	NextCursor        string
+	OutboundDiversity *OutboundDiversityStatistics
+	Error             *RPCError
 }
 
 // Command returns the protocol command string for the message
@@ -47,4 +51,37 @@ type GetConnectedPeerInfoMessage struct {
 	AdvertisedProtocolVersion uint32
 	TimeConnected             int64
 	IsIBDPeer                 bool
+	NetGroup                  string
+	Services                  uint64
+	ProtocolVersion           uint32
+	IsRelayingTransactions    bool
+	SubnetworkID              string
+	MessageStatistics         []*PeerMessageStatistics
+	ASN                       uint32
+	Country                   string
+
+	// SubnetworkRoles is nil if the peer serves every subnetwork
+	SubnetworkRoles *RPCSubnetworkRoles
+}
+
+// OutboundDiversityStatistics describes the limits on how many outbound
+// peers may share an ASN or a country, and how many addresses they kept
+// from being selected
+type OutboundDiversityStatistics struct {
+	IPMapFile             string
+	IPMapLoadTime         int64
+	MaxOutboundPerASN     uint32
+	MaxOutboundPerCountry uint32
+	SkippedByASN          uint64
+	SkippedByCountry      uint64
+}
+
+// PeerMessageStatistics counts the messages of a single command that were
+// sent to and received from a peer
+type PeerMessageStatistics struct {
+	Command          string
+	MessagesSent     uint64
+	BytesSent        uint64
+	MessagesReceived uint64
+	BytesReceived    uint64
 }
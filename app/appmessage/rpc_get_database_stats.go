@@ -0,0 +1,113 @@
+package appmessage
+
+// GetDatabaseStatsRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetDatabaseStatsRequestMessage struct {
+	baseMessage
+	IncludeBuckets          bool
+	BucketDepth             uint32
+	IncludeOperationMetrics bool
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetDatabaseStatsRequestMessage) Command() MessageCommand {
+	return CmdGetDatabaseStatsRequestMessage
+}
+
+// NewGetDatabaseStatsRequestMessage returns a instance of the message
+func NewGetDatabaseStatsRequestMessage(includeBuckets bool, bucketDepth uint32,
+	includeOperationMetrics bool) *GetDatabaseStatsRequestMessage {
+
+	return &GetDatabaseStatsRequestMessage{
+		IncludeBuckets:          includeBuckets,
+		BucketDepth:             bucketDepth,
+		IncludeOperationMetrics: includeOperationMetrics,
+	}
+}
+
+// GetDatabaseStatsResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetDatabaseStatsResponseMessage struct {
+	baseMessage
+	Backend                string
+	DiskSize               uint64
+	PendingCompactionBytes uint64
+	Levels                 []*DatabaseLevelStats
+	Buckets                []*DatabaseBucketStats
+	IsCompacting           bool
+	LastCompactionTime     int64
+	LastCompactionDuration int64
+	IsBackingUp            bool
+	LastBackupTime         int64
+	LastBackupDirectory    string
+	LastBackupError        string
+	Caches                 []*DatabaseCacheStats
+	Operations             []*DatabaseOperationMetrics
+	LatencyHistogramBounds []uint64
+
+	Error *RPCError
+}
+
+// DatabaseLevelStats are the storage statistics of a single database level
+type DatabaseLevelStats struct {
+	FileCount uint64
+	Size      uint64
+}
+
+// DatabaseBucketStats are the statistics of the keys under a database bucket
+type DatabaseBucketStats struct {
+	Bucket    string
+	KeyCount  uint64
+	KeySize   uint64
+	ValueSize uint64
+}
+
+// DatabaseCacheStats are the statistics of a cache of recently read database entries
+type DatabaseCacheStats struct {
+	Name     string
+	Capacity uint64
+	Hits     uint64
+	Misses   uint64
+}
+
+// DatabaseOperationMetrics are the metrics of a single database
+// operation type over the keys under a database bucket
+type DatabaseOperationMetrics struct {
+	Bucket                   string
+	Operation                string
+	Count                    uint64
+	Bytes                    uint64
+	TotalLatencyMicroseconds uint64
+	LatencyHistogram         []uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetDatabaseStatsResponseMessage) Command() MessageCommand {
+	return CmdGetDatabaseStatsResponseMessage
+}
+
+// NewGetDatabaseStatsResponseMessage returns a instance of the message
+func NewGetDatabaseStatsResponseMessage(backend string, diskSize uint64, pendingCompactionBytes uint64,
+	levels []*DatabaseLevelStats, buckets []*DatabaseBucketStats, isCompacting bool,
+	lastCompactionTime int64, lastCompactionDuration int64, isBackingUp bool, lastBackupTime int64,
+	lastBackupDirectory string, lastBackupError string, caches []*DatabaseCacheStats,
+	operations []*DatabaseOperationMetrics, latencyHistogramBounds []uint64) *GetDatabaseStatsResponseMessage {
+
+	return &GetDatabaseStatsResponseMessage{
+		Backend:                backend,
+		DiskSize:               diskSize,
+		PendingCompactionBytes: pendingCompactionBytes,
+		Levels:                 levels,
+		Buckets:                buckets,
+		IsCompacting:           isCompacting,
+		LastCompactionTime:     lastCompactionTime,
+		LastCompactionDuration: lastCompactionDuration,
+		IsBackingUp:            isBackingUp,
+		LastBackupTime:         lastBackupTime,
+		LastBackupDirectory:    lastBackupDirectory,
+		LastBackupError:        lastBackupError,
+		Caches:                 caches,
+		Operations:             operations,
+		LatencyHistogramBounds: latencyHistogramBounds,
+	}
+}
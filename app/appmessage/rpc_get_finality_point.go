@@ -0,0 +1,40 @@
+package appmessage
+
+// GetFinalityPointRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetFinalityPointRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetFinalityPointRequestMessage) Command() MessageCommand {
+	return CmdGetFinalityPointRequestMessage
+}
+
+// NewGetFinalityPointRequestMessage returns a instance of the message
+func NewGetFinalityPointRequestMessage() *GetFinalityPointRequestMessage {
+	return &GetFinalityPointRequestMessage{}
+}
+
+// GetFinalityPointResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetFinalityPointResponseMessage struct {
+	baseMessage
+	FinalityPointHash string
+	BlueScore         uint64
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetFinalityPointResponseMessage) Command() MessageCommand {
+	return CmdGetFinalityPointResponseMessage
+}
+
+// NewGetFinalityPointResponseMessage returns a instance of the message
+func NewGetFinalityPointResponseMessage(finalityPointHash string, blueScore uint64) *GetFinalityPointResponseMessage {
+	return &GetFinalityPointResponseMessage{
+		FinalityPointHash: finalityPointHash,
+		BlueScore:         blueScore,
+	}
+}
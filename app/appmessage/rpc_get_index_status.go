@@ -0,0 +1,52 @@
+package appmessage
+
+// GetIndexStatusRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetIndexStatusRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetIndexStatusRequestMessage) Command() MessageCommand {
+	return CmdGetIndexStatusRequestMessage
+}
+
+// NewGetIndexStatusRequestMessage returns a instance of the message
+func NewGetIndexStatusRequestMessage() *GetIndexStatusRequestMessage {
+	return &GetIndexStatusRequestMessage{}
+}
+
+// GetIndexStatusResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetIndexStatusResponseMessage struct {
+	baseMessage
+	Indexes []*IndexStatus
+
+	Error *RPCError
+}
+
+// IndexStatus is the status of an optional index, and of the
+// last operation that was applied to it
+type IndexStatus struct {
+	Name           string
+	IsEnabled      bool
+	Operation      string
+	IsRunning      bool
+	Processed      uint64
+	Total          uint64
+	StartTime      int64
+	EndTime        int64
+	OperationError string
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetIndexStatusResponseMessage) Command() MessageCommand {
+	return CmdGetIndexStatusResponseMessage
+}
+
+// NewGetIndexStatusResponseMessage returns a instance of the message
+func NewGetIndexStatusResponseMessage(indexes []*IndexStatus) *GetIndexStatusResponseMessage {
+	return &GetIndexStatusResponseMessage{
+		Indexes: indexes,
+	}
+}
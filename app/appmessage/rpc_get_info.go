@@ -26,9 +26,22 @@ type GetInfoResponseMessage struct {
 	IsUtxoIndexed bool
 	IsSynced      bool
 
+	// SubnetworkRoles is nil if the node serves every subnetwork
+	SubnetworkRoles *RPCSubnetworkRoles
+
 	Error *RPCError
 }
 
+// RPCSubnetworkRoles lists the hex-encoded subnetwork IDs whose transactions a
+// node validates, indexes and relays. An empty list means every subnetwork.
+// The native and built-in subnetworks are always served, even when they aren't
+// listed.
+type RPCSubnetworkRoles struct {
+	ValidatedSubnetworkIDs []string
+	IndexedSubnetworkIDs   []string
+	RelayedSubnetworkIDs   []string
+}
+
 // Command returns the protocol command string for the message
 func (msg *GetInfoResponseMessage) Command() MessageCommand {
 	return CmdGetInfoResponseMessage
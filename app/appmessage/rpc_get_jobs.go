@@ -0,0 +1,85 @@
+package appmessage
+
+// The kinds of the jobs, which are named after the calls that start them
+const (
+	RPCJobKindDumpUTXOSet   = "dumpUtxoSet"
+	RPCJobKindImportUTXOSet = "importUtxoSet"
+	RPCJobKindRescan        = "rescan"
+)
+
+// The states of a job
+const (
+	RPCJobStateRunning   = "running"
+	RPCJobStateCompleted = "completed"
+	RPCJobStateFailed    = "failed"
+	RPCJobStateCancelled = "cancelled"
+)
+
+// GetJobsRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetJobsRequestMessage struct {
+	baseMessage
+	JobIDs []uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetJobsRequestMessage) Command() MessageCommand {
+	return CmdGetJobsRequestMessage
+}
+
+// NewGetJobsRequestMessage returns a instance of the message
+func NewGetJobsRequestMessage(jobIDs []uint64) *GetJobsRequestMessage {
+	return &GetJobsRequestMessage{
+		JobIDs: jobIDs,
+	}
+}
+
+// GetJobsResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetJobsResponseMessage struct {
+	baseMessage
+	Jobs []*RPCJob
+
+	Error *RPCError
+}
+
+// RPCJob is the state of an expensive call that runs in
+// the background on the node
+type RPCJob struct {
+	ID        uint64
+	Kind      string
+	State     string
+	Processed uint64
+	Total     uint64
+	StartTime int64
+	EndTime   int64
+	Error     string
+
+	UTXOSetResult *RPCJobUTXOSetResult
+	RescanResult  *RPCJobRescanResult
+}
+
+// RPCJobUTXOSetResult is the result of a dumpUtxoSet or an importUtxoSet job
+type RPCJobUTXOSetResult struct {
+	PruningPointHash string
+	UTXOCommitment   string
+	UTXOCount        uint64
+}
+
+// RPCJobRescanResult is the result of a rescan job
+type RPCJobRescanResult struct {
+	CheckpointBlueScore uint64
+	CheckpointBlockHash string
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetJobsResponseMessage) Command() MessageCommand {
+	return CmdGetJobsResponseMessage
+}
+
+// NewGetJobsResponseMessage returns a instance of the message
+func NewGetJobsResponseMessage(jobs []*RPCJob) *GetJobsResponseMessage {
+	return &GetJobsResponseMessage{
+		Jobs: jobs,
+	}
+}
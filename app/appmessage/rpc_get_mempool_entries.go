@@ -6,6 +6,8 @@ type GetMempoolEntriesRequestMessage struct {
 	baseMessage
 	IncludeOrphanPool     bool
 	FilterTransactionPool bool
+	Limit                 uint32
+	Cursor                string
 }
 
 // Command returns the protocol command string for the message
@@ -25,7 +27,8 @@ func NewGetMempoolEntriesRequestMessage(includeOrphanPool bool, filterTransactio
 // its respective RPC message
 type GetMempoolEntriesResponseMessage struct {
 	baseMessage
-	Entries []*MempoolEntry
+	Entries    []*MempoolEntry
+	NextCursor string
 
 	Error *RPCError
 }
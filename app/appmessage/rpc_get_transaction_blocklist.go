@@ -0,0 +1,38 @@
+package appmessage
+
+// GetTransactionBlocklistRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetTransactionBlocklistRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetTransactionBlocklistRequestMessage) Command() MessageCommand {
+	return CmdGetTransactionBlocklistRequestMessage
+}
+
+// NewGetTransactionBlocklistRequestMessage returns an instance of the message
+func NewGetTransactionBlocklistRequestMessage() *GetTransactionBlocklistRequestMessage {
+	return &GetTransactionBlocklistRequestMessage{}
+}
+
+// GetTransactionBlocklistResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetTransactionBlocklistResponseMessage struct {
+	baseMessage
+	Entries []*TransactionBlocklistEntry
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetTransactionBlocklistResponseMessage) Command() MessageCommand {
+	return CmdGetTransactionBlocklistResponseMessage
+}
+
+// NewGetTransactionBlocklistResponseMessage returns an instance of the message
+func NewGetTransactionBlocklistResponseMessage(entries []*TransactionBlocklistEntry) *GetTransactionBlocklistResponseMessage {
+	return &GetTransactionBlocklistResponseMessage{
+		Entries: entries,
+	}
+}
@@ -6,6 +6,8 @@ type GetVirtualSelectedParentChainFromBlockRequestMessage struct {
 	baseMessage
 	StartHash                     string
 	IncludeAcceptedTransactionIDs bool
+	IncludeAcceptanceData         bool
+	Limit                         uint32
 }
 
 // Command returns the protocol command string for the message
@@ -37,6 +39,9 @@ type GetVirtualSelectedParentChainFromBlockResponseMessage struct {
 	RemovedChainBlockHashes []string
 	AddedChainBlockHashes   []string
 	AcceptedTransactionIDs  []*AcceptedTransactionIDs
+	AcceptanceData          []*ChainBlockAcceptanceData
+	RemovedAcceptanceData   []*ChainBlockAcceptanceData
+	NextStartHash           string
 
 	Error *RPCError
 }
@@ -0,0 +1,57 @@
+package appmessage
+
+// GetWorkRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type GetWorkRequestMessage struct {
+	baseMessage
+	PayAddress string
+	ExtraData  string
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetWorkRequestMessage) Command() MessageCommand {
+	return CmdGetWorkRequestMessage
+}
+
+// NewGetWorkRequestMessage returns a instance of the message
+func NewGetWorkRequestMessage(payAddress string, extraData string) *GetWorkRequestMessage {
+	return &GetWorkRequestMessage{
+		PayAddress: payAddress,
+		ExtraData:  extraData,
+	}
+}
+
+// GetWorkResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type GetWorkResponseMessage struct {
+	baseMessage
+	Work     *RPCWork
+	IsSynced bool
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *GetWorkResponseMessage) Command() MessageCommand {
+	return CmdGetWorkResponseMessage
+}
+
+// NewGetWorkResponseMessage returns a instance of the message
+func NewGetWorkResponseMessage(work *RPCWork, isSynced bool) *GetWorkResponseMessage {
+	return &GetWorkResponseMessage{
+		Work:     work,
+		IsSynced: isSynced,
+	}
+}
+
+// RPCWork is a block header that is ready for hashing, along with
+// everything a miner needs in order to search for a nonce
+type RPCWork struct {
+	ID         string
+	PrePowHash string
+	Timestamp  int64
+	Bits       uint32
+	Target     string
+	DAAScore   uint64
+	BlueScore  uint64
+}
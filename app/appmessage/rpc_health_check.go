@@ -0,0 +1,42 @@
+package appmessage
+
+// HealthCheckRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type HealthCheckRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *HealthCheckRequestMessage) Command() MessageCommand {
+	return CmdHealthCheckRequestMessage
+}
+
+// NewHealthCheckRequestMessage returns a instance of the message
+func NewHealthCheckRequestMessage() *HealthCheckRequestMessage {
+	return &HealthCheckRequestMessage{}
+}
+
+// HealthCheckResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type HealthCheckResponseMessage struct {
+	baseMessage
+	IsHealthy            bool
+	IsReady              bool
+	IsDatabaseResponsive bool
+	IsSynced             bool
+	SyncAge              int64
+	ConnectedPeerCount   uint32
+	FailedChecks         []string
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *HealthCheckResponseMessage) Command() MessageCommand {
+	return CmdHealthCheckResponseMessage
+}
+
+// NewHealthCheckResponseMessage returns a instance of the message
+func NewHealthCheckResponseMessage() *HealthCheckResponseMessage {
+	return &HealthCheckResponseMessage{}
+}
@@ -0,0 +1,51 @@
+package appmessage
+
+// ImportUTXOSetRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type ImportUTXOSetRequestMessage struct {
+	baseMessage
+	FilePath string
+	RunAsJob bool
+}
+
+// Command returns the protocol command string for the message
+func (msg *ImportUTXOSetRequestMessage) Command() MessageCommand {
+	return CmdImportUTXOSetRequestMessage
+}
+
+// NewImportUTXOSetRequestMessage returns an instance of the message
+func NewImportUTXOSetRequestMessage(filePath string, runAsJob bool) *ImportUTXOSetRequestMessage {
+	return &ImportUTXOSetRequestMessage{
+		FilePath: filePath,
+		RunAsJob: runAsJob,
+	}
+}
+
+// ImportUTXOSetResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type ImportUTXOSetResponseMessage struct {
+	baseMessage
+	PruningPointHash string
+	UTXOCommitment   string
+	UTXOCount        uint64
+	JobID            uint64
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *ImportUTXOSetResponseMessage) Command() MessageCommand {
+	return CmdImportUTXOSetResponseMessage
+}
+
+// NewImportUTXOSetResponseMessage returns a instance of the message
+func NewImportUTXOSetResponseMessage(pruningPointHash string, utxoCommitment string, utxoCount uint64,
+	jobID uint64) *ImportUTXOSetResponseMessage {
+
+	return &ImportUTXOSetResponseMessage{
+		PruningPointHash: pruningPointHash,
+		UTXOCommitment:   utxoCommitment,
+		UTXOCount:        utxoCount,
+		JobID:            jobID,
+	}
+}
@@ -0,0 +1,45 @@
+package appmessage
+
+// ListBannedRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type ListBannedRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *ListBannedRequestMessage) Command() MessageCommand {
+	return CmdListBannedRequestMessage
+}
+
+// NewListBannedRequestMessage returns an instance of the message
+func NewListBannedRequestMessage() *ListBannedRequestMessage {
+	return &ListBannedRequestMessage{}
+}
+
+// ListBannedResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type ListBannedResponseMessage struct {
+	baseMessage
+	BannedSubnets []*BannedSubnet
+
+	Error *RPCError
+}
+
+// BannedSubnet is a banned IP address or subnet
+type BannedSubnet struct {
+	Subnet      string
+	BannedAt    int64
+	BannedUntil int64
+}
+
+// Command returns the protocol command string for the message
+func (msg *ListBannedResponseMessage) Command() MessageCommand {
+	return CmdListBannedResponseMessage
+}
+
+// NewListBannedResponseMessage returns a instance of the message
+func NewListBannedResponseMessage(bannedSubnets []*BannedSubnet) *ListBannedResponseMessage {
+	return &ListBannedResponseMessage{
+		BannedSubnets: bannedSubnets,
+	}
+}
@@ -0,0 +1,108 @@
+package appmessage
+
+// StartNotificationSessionRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type StartNotificationSessionRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *StartNotificationSessionRequestMessage) Command() MessageCommand {
+	return CmdStartNotificationSessionRequestMessage
+}
+
+// NewStartNotificationSessionRequestMessage returns an instance of the message
+func NewStartNotificationSessionRequestMessage() *StartNotificationSessionRequestMessage {
+	return &StartNotificationSessionRequestMessage{}
+}
+
+// StartNotificationSessionResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type StartNotificationSessionResponseMessage struct {
+	baseMessage
+	SessionID          string
+	HistorySize        uint32
+	ExpiryMilliseconds uint64
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *StartNotificationSessionResponseMessage) Command() MessageCommand {
+	return CmdStartNotificationSessionResponseMessage
+}
+
+// NewStartNotificationSessionResponseMessage returns an instance of the message
+func NewStartNotificationSessionResponseMessage(sessionID string, historySize uint32,
+	expiryMilliseconds uint64) *StartNotificationSessionResponseMessage {
+
+	return &StartNotificationSessionResponseMessage{
+		SessionID:          sessionID,
+		HistorySize:        historySize,
+		ExpiryMilliseconds: expiryMilliseconds,
+	}
+}
+
+// ResumeNotificationSessionRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type ResumeNotificationSessionRequestMessage struct {
+	baseMessage
+	SessionID    string
+	LastSequence uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *ResumeNotificationSessionRequestMessage) Command() MessageCommand {
+	return CmdResumeNotificationSessionRequestMessage
+}
+
+// NewResumeNotificationSessionRequestMessage returns an instance of the message
+func NewResumeNotificationSessionRequestMessage(sessionID string, lastSequence uint64) *ResumeNotificationSessionRequestMessage {
+	return &ResumeNotificationSessionRequestMessage{
+		SessionID:    sessionID,
+		LastSequence: lastSequence,
+	}
+}
+
+// ResumeNotificationSessionResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type ResumeNotificationSessionResponseMessage struct {
+	baseMessage
+	ReplayedCount uint64
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *ResumeNotificationSessionResponseMessage) Command() MessageCommand {
+	return CmdResumeNotificationSessionResponseMessage
+}
+
+// NewResumeNotificationSessionResponseMessage returns an instance of the message
+func NewResumeNotificationSessionResponseMessage(replayedCount uint64) *ResumeNotificationSessionResponseMessage {
+	return &ResumeNotificationSessionResponseMessage{
+		ReplayedCount: replayedCount,
+	}
+}
+
+// SequencedNotificationMessage wraps a notification that is sent as part of a
+// notification session. Notifications are shared between the listeners they're
+// sent to, so their sequence in each session is carried by a wrapper of their
+// own rather than by the notification itself. Received notifications carry
+// their sequence in NotificationSequence.
+type SequencedNotificationMessage struct {
+	baseMessage
+	Notification Message
+}
+
+// Command returns the protocol command string of the wrapped notification
+func (msg *SequencedNotificationMessage) Command() MessageCommand {
+	return msg.Notification.Command()
+}
+
+// NewSequencedNotificationMessage returns an instance of the message
+func NewSequencedNotificationMessage(notification Message, sequence uint64) *SequencedNotificationMessage {
+	message := &SequencedNotificationMessage{Notification: notification}
+	message.SetNotificationSequence(sequence)
+	return message
+}
@@ -0,0 +1,55 @@
+package appmessage
+
+// NotifyFinalityPointAdvancedRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyFinalityPointAdvancedRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyFinalityPointAdvancedRequestMessage) Command() MessageCommand {
+	return CmdNotifyFinalityPointAdvancedRequestMessage
+}
+
+// NewNotifyFinalityPointAdvancedRequestMessage returns a instance of the message
+func NewNotifyFinalityPointAdvancedRequestMessage() *NotifyFinalityPointAdvancedRequestMessage {
+	return &NotifyFinalityPointAdvancedRequestMessage{}
+}
+
+// NotifyFinalityPointAdvancedResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyFinalityPointAdvancedResponseMessage struct {
+	baseMessage
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyFinalityPointAdvancedResponseMessage) Command() MessageCommand {
+	return CmdNotifyFinalityPointAdvancedResponseMessage
+}
+
+// NewNotifyFinalityPointAdvancedResponseMessage returns a instance of the message
+func NewNotifyFinalityPointAdvancedResponseMessage() *NotifyFinalityPointAdvancedResponseMessage {
+	return &NotifyFinalityPointAdvancedResponseMessage{}
+}
+
+// FinalityPointAdvancedNotificationMessage is an appmessage corresponding to
+// its respective RPC message
+type FinalityPointAdvancedNotificationMessage struct {
+	baseMessage
+	FinalityPointHash         string
+	PreviousFinalityPointHash string
+}
+
+// Command returns the protocol command string for the message
+func (msg *FinalityPointAdvancedNotificationMessage) Command() MessageCommand {
+	return CmdFinalityPointAdvancedNotificationMessage
+}
+
+// NewFinalityPointAdvancedNotificationMessage returns a instance of the message
+func NewFinalityPointAdvancedNotificationMessage(finalityPointHash string, previousFinalityPointHash string) *FinalityPointAdvancedNotificationMessage {
+	return &FinalityPointAdvancedNotificationMessage{
+		FinalityPointHash:         finalityPointHash,
+		PreviousFinalityPointHash: previousFinalityPointHash,
+	}
+}
@@ -0,0 +1,53 @@
+package appmessage
+
+// NotifyJobProgressRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyJobProgressRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyJobProgressRequestMessage) Command() MessageCommand {
+	return CmdNotifyJobProgressRequestMessage
+}
+
+// NewNotifyJobProgressRequestMessage returns a instance of the message
+func NewNotifyJobProgressRequestMessage() *NotifyJobProgressRequestMessage {
+	return &NotifyJobProgressRequestMessage{}
+}
+
+// NotifyJobProgressResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyJobProgressResponseMessage struct {
+	baseMessage
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyJobProgressResponseMessage) Command() MessageCommand {
+	return CmdNotifyJobProgressResponseMessage
+}
+
+// NewNotifyJobProgressResponseMessage returns a instance of the message
+func NewNotifyJobProgressResponseMessage() *NotifyJobProgressResponseMessage {
+	return &NotifyJobProgressResponseMessage{}
+}
+
+// JobProgressNotificationMessage is an appmessage corresponding to
+// its respective RPC message
+type JobProgressNotificationMessage struct {
+	baseMessage
+	Job *RPCJob
+}
+
+// Command returns the protocol command string for the message
+func (msg *JobProgressNotificationMessage) Command() MessageCommand {
+	return CmdJobProgressNotificationMessage
+}
+
+// NewJobProgressNotificationMessage returns a instance of the message
+func NewJobProgressNotificationMessage(job *RPCJob) *JobProgressNotificationMessage {
+	return &JobProgressNotificationMessage{
+		Job: job,
+	}
+}
@@ -0,0 +1,55 @@
+package appmessage
+
+// NotifyNewTipRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyNewTipRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyNewTipRequestMessage) Command() MessageCommand {
+	return CmdNotifyNewTipRequestMessage
+}
+
+// NewNotifyNewTipRequestMessage returns a instance of the message
+func NewNotifyNewTipRequestMessage() *NotifyNewTipRequestMessage {
+	return &NotifyNewTipRequestMessage{}
+}
+
+// NotifyNewTipResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyNewTipResponseMessage struct {
+	baseMessage
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyNewTipResponseMessage) Command() MessageCommand {
+	return CmdNotifyNewTipResponseMessage
+}
+
+// NewNotifyNewTipResponseMessage returns a instance of the message
+func NewNotifyNewTipResponseMessage() *NotifyNewTipResponseMessage {
+	return &NotifyNewTipResponseMessage{}
+}
+
+// NewTipNotificationMessage is an appmessage corresponding to
+// its respective RPC message
+type NewTipNotificationMessage struct {
+	baseMessage
+	TipHash   string
+	TipHashes []string
+}
+
+// Command returns the protocol command string for the message
+func (msg *NewTipNotificationMessage) Command() MessageCommand {
+	return CmdNewTipNotificationMessage
+}
+
+// NewNewTipNotificationMessage returns a instance of the message
+func NewNewTipNotificationMessage(tipHash string, tipHashes []string) *NewTipNotificationMessage {
+	return &NewTipNotificationMessage{
+		TipHash:   tipHash,
+		TipHashes: tipHashes,
+	}
+}
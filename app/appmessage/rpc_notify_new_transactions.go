@@ -0,0 +1,62 @@
+package appmessage
+
+// NotifyNewTransactionsRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyNewTransactionsRequestMessage struct {
+	baseMessage
+	Addresses     []string
+	SubnetworkID  string
+	MinimumAmount uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyNewTransactionsRequestMessage) Command() MessageCommand {
+	return CmdNotifyNewTransactionsRequestMessage
+}
+
+// NewNotifyNewTransactionsRequestMessage returns a instance of the message
+func NewNotifyNewTransactionsRequestMessage(addresses []string, subnetworkID string,
+	minimumAmount uint64) *NotifyNewTransactionsRequestMessage {
+
+	return &NotifyNewTransactionsRequestMessage{
+		Addresses:     addresses,
+		SubnetworkID:  subnetworkID,
+		MinimumAmount: minimumAmount,
+	}
+}
+
+// NotifyNewTransactionsResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyNewTransactionsResponseMessage struct {
+	baseMessage
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyNewTransactionsResponseMessage) Command() MessageCommand {
+	return CmdNotifyNewTransactionsResponseMessage
+}
+
+// NewNotifyNewTransactionsResponseMessage returns a instance of the message
+func NewNotifyNewTransactionsResponseMessage() *NotifyNewTransactionsResponseMessage {
+	return &NotifyNewTransactionsResponseMessage{}
+}
+
+// NewTransactionsNotificationMessage is an appmessage corresponding to
+// its respective RPC message
+type NewTransactionsNotificationMessage struct {
+	baseMessage
+	Transactions []*RPCTransaction
+}
+
+// Command returns the protocol command string for the message
+func (msg *NewTransactionsNotificationMessage) Command() MessageCommand {
+	return CmdNewTransactionsNotificationMessage
+}
+
+// NewNewTransactionsNotificationMessage returns a instance of the message
+func NewNewTransactionsNotificationMessage(transactions []*RPCTransaction) *NewTransactionsNotificationMessage {
+	return &NewTransactionsNotificationMessage{
+		Transactions: transactions,
+	}
+}
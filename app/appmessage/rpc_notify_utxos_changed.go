@@ -4,7 +4,8 @@ package appmessage
 // its respective RPC message
 type NotifyUTXOsChangedRequestMessage struct {
 	baseMessage
-	Addresses []string
+	Addresses      []string
+	IncludeMempool bool
 }
 
 // Command returns the protocol command string for the message
@@ -40,8 +41,10 @@ func NewNotifyUTXOsChangedResponseMessage() *NotifyUTXOsChangedResponseMessage {
 // its respective RPC message
 type UTXOsChangedNotificationMessage struct {
 	baseMessage
-	Added   []*UTXOsByAddressesEntry
-	Removed []*UTXOsByAddressesEntry
+	Added          []*UTXOsByAddressesEntry
+	Removed        []*UTXOsByAddressesEntry
+	MempoolAdded   []*UTXOsByAddressesEntry
+	MempoolRemoved []*UTXOsByAddressesEntry
 }
 
 // UTXOsByAddressesEntry represents a UTXO of some address
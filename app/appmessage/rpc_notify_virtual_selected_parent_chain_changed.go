@@ -5,6 +5,7 @@ package appmessage
 type NotifyVirtualSelectedParentChainChangedRequestMessage struct {
 	baseMessage
 	IncludeAcceptedTransactionIDs bool
+	IncludeAcceptanceData         bool
 }
 
 // Command returns the protocol command string for the message
@@ -45,6 +46,22 @@ type VirtualSelectedParentChainChangedNotificationMessage struct {
 	RemovedChainBlockHashes []string
 	AddedChainBlockHashes   []string
 	AcceptedTransactionIDs  []*AcceptedTransactionIDs
+	AcceptanceData          []*ChainBlockAcceptanceData
+}
+
+// ChainBlockAcceptanceData describes the blocks merged by a chain block.
+// It is a part of the VirtualSelectedParentChainChangedNotificationMessage appmessage
+type ChainBlockAcceptanceData struct {
+	AcceptingBlockHash string
+	MergedBlocks       []*MergedBlockAcceptanceData
+}
+
+// MergedBlockAcceptanceData describes which of the transactions of a merged
+// block were accepted by the merging chain block
+type MergedBlockAcceptanceData struct {
+	BlockHash              string
+	AcceptedTransactionIDs []string
+	RejectedTransactionIDs []string
 }
 
 // Command returns the protocol command string for the message
@@ -0,0 +1,60 @@
+package appmessage
+
+// NotifyWorkRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyWorkRequestMessage struct {
+	baseMessage
+	PayAddress string
+	ExtraData  string
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyWorkRequestMessage) Command() MessageCommand {
+	return CmdNotifyWorkRequestMessage
+}
+
+// NewNotifyWorkRequestMessage returns a instance of the message
+func NewNotifyWorkRequestMessage(payAddress string, extraData string) *NotifyWorkRequestMessage {
+	return &NotifyWorkRequestMessage{
+		PayAddress: payAddress,
+		ExtraData:  extraData,
+	}
+}
+
+// NotifyWorkResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type NotifyWorkResponseMessage struct {
+	baseMessage
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *NotifyWorkResponseMessage) Command() MessageCommand {
+	return CmdNotifyWorkResponseMessage
+}
+
+// NewNotifyWorkResponseMessage returns a instance of the message
+func NewNotifyWorkResponseMessage() *NotifyWorkResponseMessage {
+	return &NotifyWorkResponseMessage{}
+}
+
+// WorkNotificationMessage is an appmessage corresponding to
+// its respective RPC message
+type WorkNotificationMessage struct {
+	baseMessage
+	Work     *RPCWork
+	IsSynced bool
+}
+
+// Command returns the protocol command string for the message
+func (msg *WorkNotificationMessage) Command() MessageCommand {
+	return CmdWorkNotificationMessage
+}
+
+// NewWorkNotificationMessage returns a instance of the message
+func NewWorkNotificationMessage(work *RPCWork, isSynced bool) *WorkNotificationMessage {
+	return &WorkNotificationMessage{
+		Work:     work,
+		IsSynced: isSynced,
+	}
+}
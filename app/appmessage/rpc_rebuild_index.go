@@ -0,0 +1,37 @@
+package appmessage
+
+// RebuildIndexRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type RebuildIndexRequestMessage struct {
+	baseMessage
+	IndexName string
+}
+
+// Command returns the protocol command string for the message
+func (msg *RebuildIndexRequestMessage) Command() MessageCommand {
+	return CmdRebuildIndexRequestMessage
+}
+
+// NewRebuildIndexRequestMessage returns a instance of the message
+func NewRebuildIndexRequestMessage(indexName string) *RebuildIndexRequestMessage {
+	return &RebuildIndexRequestMessage{
+		IndexName: indexName,
+	}
+}
+
+// RebuildIndexResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type RebuildIndexResponseMessage struct {
+	baseMessage
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *RebuildIndexResponseMessage) Command() MessageCommand {
+	return CmdRebuildIndexResponseMessage
+}
+
+// NewRebuildIndexResponseMessage returns a instance of the message
+func NewRebuildIndexResponseMessage() *RebuildIndexResponseMessage {
+	return &RebuildIndexResponseMessage{}
+}
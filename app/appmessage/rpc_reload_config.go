@@ -0,0 +1,38 @@
+package appmessage
+
+// ReloadConfigRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type ReloadConfigRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *ReloadConfigRequestMessage) Command() MessageCommand {
+	return CmdReloadConfigRequestMessage
+}
+
+// NewReloadConfigRequestMessage returns a instance of the message
+func NewReloadConfigRequestMessage() *ReloadConfigRequestMessage {
+	return &ReloadConfigRequestMessage{}
+}
+
+// ReloadConfigResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type ReloadConfigResponseMessage struct {
+	baseMessage
+	ChangedOptions []string
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *ReloadConfigResponseMessage) Command() MessageCommand {
+	return CmdReloadConfigResponseMessage
+}
+
+// NewReloadConfigResponseMessage returns a instance of the message
+func NewReloadConfigResponseMessage(changedOptions []string) *ReloadConfigResponseMessage {
+	return &ReloadConfigResponseMessage{
+		ChangedOptions: changedOptions,
+	}
+}
@@ -0,0 +1,41 @@
+package appmessage
+
+// RemoveTransactionBlocklistEntriesRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type RemoveTransactionBlocklistEntriesRequestMessage struct {
+	baseMessage
+	Entries []*TransactionBlocklistEntry
+}
+
+// Command returns the protocol command string for the message
+func (msg *RemoveTransactionBlocklistEntriesRequestMessage) Command() MessageCommand {
+	return CmdRemoveTransactionBlocklistEntriesRequestMessage
+}
+
+// NewRemoveTransactionBlocklistEntriesRequestMessage returns an instance of the message
+func NewRemoveTransactionBlocklistEntriesRequestMessage(entries []*TransactionBlocklistEntry) *RemoveTransactionBlocklistEntriesRequestMessage {
+	return &RemoveTransactionBlocklistEntriesRequestMessage{
+		Entries: entries,
+	}
+}
+
+// RemoveTransactionBlocklistEntriesResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type RemoveTransactionBlocklistEntriesResponseMessage struct {
+	baseMessage
+	RemovedCount uint32
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *RemoveTransactionBlocklistEntriesResponseMessage) Command() MessageCommand {
+	return CmdRemoveTransactionBlocklistEntriesResponseMessage
+}
+
+// NewRemoveTransactionBlocklistEntriesResponseMessage returns an instance of the message
+func NewRemoveTransactionBlocklistEntriesResponseMessage(removedCount uint32) *RemoveTransactionBlocklistEntriesResponseMessage {
+	return &RemoveTransactionBlocklistEntriesResponseMessage{
+		RemovedCount: removedCount,
+	}
+}
@@ -0,0 +1,118 @@
+package appmessage
+
+// RescanRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type RescanRequestMessage struct {
+	baseMessage
+	StartBlueScore uint64
+	Addresses      []string
+	Outpoints      []*RPCOutpoint
+}
+
+// Command returns the protocol command string for the message
+func (msg *RescanRequestMessage) Command() MessageCommand {
+	return CmdRescanRequestMessage
+}
+
+// NewRescanRequestMessage returns a instance of the message
+func NewRescanRequestMessage(startBlueScore uint64, addresses []string, outpoints []*RPCOutpoint) *RescanRequestMessage {
+	return &RescanRequestMessage{
+		StartBlueScore: startBlueScore,
+		Addresses:      addresses,
+		Outpoints:      outpoints,
+	}
+}
+
+// RescanResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type RescanResponseMessage struct {
+	baseMessage
+	StartBlueScore uint64
+	JobID          uint64
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *RescanResponseMessage) Command() MessageCommand {
+	return CmdRescanResponseMessage
+}
+
+// NewRescanResponseMessage returns a instance of the message
+func NewRescanResponseMessage(startBlueScore uint64, jobID uint64) *RescanResponseMessage {
+	return &RescanResponseMessage{
+		StartBlueScore: startBlueScore,
+		JobID:          jobID,
+	}
+}
+
+// RescanProgressNotificationMessage is an appmessage corresponding to
+// its respective RPC message
+type RescanProgressNotificationMessage struct {
+	baseMessage
+	Matches                 []*RescanMatch
+	CheckpointBlueScore     uint64
+	CheckpointBlockHash     string
+	RemovedChainBlockHashes []string
+	IsFinished              bool
+
+	Error *RPCError
+}
+
+// RescanMatch is a transaction that matched a rescan, and the
+// chain block that accepted it
+type RescanMatch struct {
+	AcceptingBlockHash string
+	Transaction        *RPCTransaction
+}
+
+// Command returns the protocol command string for the message
+func (msg *RescanProgressNotificationMessage) Command() MessageCommand {
+	return CmdRescanProgressNotificationMessage
+}
+
+// NewRescanProgressNotificationMessage returns a instance of the message
+func NewRescanProgressNotificationMessage(matches []*RescanMatch, checkpointBlueScore uint64,
+	checkpointBlockHash string, removedChainBlockHashes []string, isFinished bool) *RescanProgressNotificationMessage {
+
+	return &RescanProgressNotificationMessage{
+		Matches:                 matches,
+		CheckpointBlueScore:     checkpointBlueScore,
+		CheckpointBlockHash:     checkpointBlockHash,
+		RemovedChainBlockHashes: removedChainBlockHashes,
+		IsFinished:              isFinished,
+	}
+}
+
+// StopRescanRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type StopRescanRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *StopRescanRequestMessage) Command() MessageCommand {
+	return CmdStopRescanRequestMessage
+}
+
+// NewStopRescanRequestMessage returns a instance of the message
+func NewStopRescanRequestMessage() *StopRescanRequestMessage {
+	return &StopRescanRequestMessage{}
+}
+
+// StopRescanResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type StopRescanResponseMessage struct {
+	baseMessage
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *StopRescanResponseMessage) Command() MessageCommand {
+	return CmdStopRescanResponseMessage
+}
+
+// NewStopRescanResponseMessage returns a instance of the message
+func NewStopRescanResponseMessage() *StopRescanResponseMessage {
+	return &StopRescanResponseMessage{}
+}
@@ -0,0 +1,61 @@
+package appmessage
+
+// SearchRawTransactionsRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type SearchRawTransactionsRequestMessage struct {
+	baseMessage
+	Address        string
+	Limit          uint32
+	Cursor         string
+	IncludeMempool bool
+}
+
+// Command returns the protocol command string for the message
+func (msg *SearchRawTransactionsRequestMessage) Command() MessageCommand {
+	return CmdSearchRawTransactionsRequestMessage
+}
+
+// NewSearchRawTransactionsRequestMessage returns a instance of the message
+func NewSearchRawTransactionsRequestMessage(address string, limit uint32, cursor string,
+	includeMempool bool) *SearchRawTransactionsRequestMessage {
+
+	return &SearchRawTransactionsRequestMessage{
+		Address:        address,
+		Limit:          limit,
+		Cursor:         cursor,
+		IncludeMempool: includeMempool,
+	}
+}
+
+// SearchRawTransactionsResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type SearchRawTransactionsResponseMessage struct {
+	baseMessage
+	Entries    []*AddressTransactionEntry
+	NextCursor string
+
+	Error *RPCError
+}
+
+// AddressTransactionEntry represents a transaction that credits or
+// debits an address
+type AddressTransactionEntry struct {
+	Transaction        *RPCTransaction
+	AcceptingBlockHash string
+	AcceptingDAAScore  uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *SearchRawTransactionsResponseMessage) Command() MessageCommand {
+	return CmdSearchRawTransactionsResponseMessage
+}
+
+// NewSearchRawTransactionsResponseMessage returns a instance of the message
+func NewSearchRawTransactionsResponseMessage(entries []*AddressTransactionEntry,
+	nextCursor string) *SearchRawTransactionsResponseMessage {
+
+	return &SearchRawTransactionsResponseMessage{
+		Entries:    entries,
+		NextCursor: nextCursor,
+	}
+}
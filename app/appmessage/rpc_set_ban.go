@@ -0,0 +1,42 @@
+package appmessage
+
+// SetBanRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type SetBanRequestMessage struct {
+	baseMessage
+	Subnet          string
+	DurationSeconds uint64
+	Remove          bool
+}
+
+// Command returns the protocol command string for the message
+func (msg *SetBanRequestMessage) Command() MessageCommand {
+	return CmdSetBanRequestMessage
+}
+
+// NewSetBanRequestMessage returns an instance of the message
+func NewSetBanRequestMessage(subnet string, durationSeconds uint64, remove bool) *SetBanRequestMessage {
+	return &SetBanRequestMessage{
+		Subnet:          subnet,
+		DurationSeconds: durationSeconds,
+		Remove:          remove,
+	}
+}
+
+// SetBanResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type SetBanResponseMessage struct {
+	baseMessage
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *SetBanResponseMessage) Command() MessageCommand {
+	return CmdSetBanResponseMessage
+}
+
+// NewSetBanResponseMessage returns a instance of the message
+func NewSetBanResponseMessage() *SetBanResponseMessage {
+	return &SetBanResponseMessage{}
+}
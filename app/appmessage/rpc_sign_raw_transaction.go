@@ -0,0 +1,68 @@
+package appmessage
+
+// SignRawTransactionRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type SignRawTransactionRequestMessage struct {
+	baseMessage
+	Transaction     *RPCTransaction
+	PrivateKeys     []string
+	PreviousOutputs []*SignRawTransactionPreviousOutput
+}
+
+// Command returns the protocol command string for the message
+func (msg *SignRawTransactionRequestMessage) Command() MessageCommand {
+	return CmdSignRawTransactionRequestMessage
+}
+
+// NewSignRawTransactionRequestMessage returns a instance of the message
+func NewSignRawTransactionRequestMessage(transaction *RPCTransaction, privateKeys []string,
+	previousOutputs []*SignRawTransactionPreviousOutput) *SignRawTransactionRequestMessage {
+
+	return &SignRawTransactionRequestMessage{
+		Transaction:     transaction,
+		PrivateKeys:     privateKeys,
+		PreviousOutputs: previousOutputs,
+	}
+}
+
+// SignRawTransactionPreviousOutput is an output that's spent by the
+// transaction of a SignRawTransactionRequestMessage
+type SignRawTransactionPreviousOutput struct {
+	Outpoint     *RPCOutpoint
+	UTXOEntry    *RPCUTXOEntry
+	RedeemScript string
+}
+
+// SignRawTransactionResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type SignRawTransactionResponseMessage struct {
+	baseMessage
+	Transaction *RPCTransaction
+	IsComplete  bool
+	InputErrors []*SignRawTransactionInputError
+
+	Error *RPCError
+}
+
+// SignRawTransactionInputError describes why an input of a
+// SignRawTransactionResponseMessage isn't validly signed
+type SignRawTransactionInputError struct {
+	InputIndex uint32
+	Message    string
+}
+
+// Command returns the protocol command string for the message
+func (msg *SignRawTransactionResponseMessage) Command() MessageCommand {
+	return CmdSignRawTransactionResponseMessage
+}
+
+// NewSignRawTransactionResponseMessage returns a instance of the message
+func NewSignRawTransactionResponseMessage(transaction *RPCTransaction, isComplete bool,
+	inputErrors []*SignRawTransactionInputError) *SignRawTransactionResponseMessage {
+
+	return &SignRawTransactionResponseMessage{
+		Transaction: transaction,
+		IsComplete:  isComplete,
+		InputErrors: inputErrors,
+	}
+}
@@ -0,0 +1,34 @@
+package appmessage
+
+// StopNotifyingFinalityPointAdvancedRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type StopNotifyingFinalityPointAdvancedRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *StopNotifyingFinalityPointAdvancedRequestMessage) Command() MessageCommand {
+	return CmdStopNotifyingFinalityPointAdvancedRequestMessage
+}
+
+// NewStopNotifyingFinalityPointAdvancedRequestMessage returns a instance of the message
+func NewStopNotifyingFinalityPointAdvancedRequestMessage() *StopNotifyingFinalityPointAdvancedRequestMessage {
+	return &StopNotifyingFinalityPointAdvancedRequestMessage{}
+}
+
+// StopNotifyingFinalityPointAdvancedResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type StopNotifyingFinalityPointAdvancedResponseMessage struct {
+	baseMessage
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *StopNotifyingFinalityPointAdvancedResponseMessage) Command() MessageCommand {
+	return CmdStopNotifyingFinalityPointAdvancedResponseMessage
+}
+
+// NewStopNotifyingFinalityPointAdvancedResponseMessage returns a instance of the message
+func NewStopNotifyingFinalityPointAdvancedResponseMessage() *StopNotifyingFinalityPointAdvancedResponseMessage {
+	return &StopNotifyingFinalityPointAdvancedResponseMessage{}
+}
@@ -0,0 +1,34 @@
+package appmessage
+
+// StopNotifyingJobProgressRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type StopNotifyingJobProgressRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *StopNotifyingJobProgressRequestMessage) Command() MessageCommand {
+	return CmdStopNotifyingJobProgressRequestMessage
+}
+
+// NewStopNotifyingJobProgressRequestMessage returns a instance of the message
+func NewStopNotifyingJobProgressRequestMessage() *StopNotifyingJobProgressRequestMessage {
+	return &StopNotifyingJobProgressRequestMessage{}
+}
+
+// StopNotifyingJobProgressResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type StopNotifyingJobProgressResponseMessage struct {
+	baseMessage
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *StopNotifyingJobProgressResponseMessage) Command() MessageCommand {
+	return CmdStopNotifyingJobProgressResponseMessage
+}
+
+// NewStopNotifyingJobProgressResponseMessage returns a instance of the message
+func NewStopNotifyingJobProgressResponseMessage() *StopNotifyingJobProgressResponseMessage {
+	return &StopNotifyingJobProgressResponseMessage{}
+}
@@ -0,0 +1,34 @@
+package appmessage
+
+// StopNotifyingNewTipRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type StopNotifyingNewTipRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *StopNotifyingNewTipRequestMessage) Command() MessageCommand {
+	return CmdStopNotifyingNewTipRequestMessage
+}
+
+// NewStopNotifyingNewTipRequestMessage returns a instance of the message
+func NewStopNotifyingNewTipRequestMessage() *StopNotifyingNewTipRequestMessage {
+	return &StopNotifyingNewTipRequestMessage{}
+}
+
+// StopNotifyingNewTipResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type StopNotifyingNewTipResponseMessage struct {
+	baseMessage
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *StopNotifyingNewTipResponseMessage) Command() MessageCommand {
+	return CmdStopNotifyingNewTipResponseMessage
+}
+
+// NewStopNotifyingNewTipResponseMessage returns a instance of the message
+func NewStopNotifyingNewTipResponseMessage() *StopNotifyingNewTipResponseMessage {
+	return &StopNotifyingNewTipResponseMessage{}
+}
@@ -0,0 +1,34 @@
+package appmessage
+
+// StopNotifyingNewTransactionsRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type StopNotifyingNewTransactionsRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *StopNotifyingNewTransactionsRequestMessage) Command() MessageCommand {
+	return CmdStopNotifyingNewTransactionsRequestMessage
+}
+
+// NewStopNotifyingNewTransactionsRequestMessage returns a instance of the message
+func NewStopNotifyingNewTransactionsRequestMessage() *StopNotifyingNewTransactionsRequestMessage {
+	return &StopNotifyingNewTransactionsRequestMessage{}
+}
+
+// StopNotifyingNewTransactionsResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type StopNotifyingNewTransactionsResponseMessage struct {
+	baseMessage
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *StopNotifyingNewTransactionsResponseMessage) Command() MessageCommand {
+	return CmdStopNotifyingNewTransactionsResponseMessage
+}
+
+// NewStopNotifyingNewTransactionsResponseMessage returns a instance of the message
+func NewStopNotifyingNewTransactionsResponseMessage() *StopNotifyingNewTransactionsResponseMessage {
+	return &StopNotifyingNewTransactionsResponseMessage{}
+}
@@ -0,0 +1,34 @@
+package appmessage
+
+// StopNotifyingVirtualSelectedParentChainChangedRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type StopNotifyingVirtualSelectedParentChainChangedRequestMessage struct {
+	baseMessage
+}
+
+// Command returns the protocol command string for the message
+func (msg *StopNotifyingVirtualSelectedParentChainChangedRequestMessage) Command() MessageCommand {
+	return CmdStopNotifyingVirtualSelectedParentChainChangedRequestMessage
+}
+
+// NewStopNotifyingVirtualSelectedParentChainChangedRequestMessage returns a instance of the message
+func NewStopNotifyingVirtualSelectedParentChainChangedRequestMessage() *StopNotifyingVirtualSelectedParentChainChangedRequestMessage {
+	return &StopNotifyingVirtualSelectedParentChainChangedRequestMessage{}
+}
+
+// StopNotifyingVirtualSelectedParentChainChangedResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type StopNotifyingVirtualSelectedParentChainChangedResponseMessage struct {
+	baseMessage
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *StopNotifyingVirtualSelectedParentChainChangedResponseMessage) Command() MessageCommand {
+	return CmdStopNotifyingVirtualSelectedParentChainChangedResponseMessage
+}
+
+// NewStopNotifyingVirtualSelectedParentChainChangedResponseMessage returns a instance of the message
+func NewStopNotifyingVirtualSelectedParentChainChangedResponseMessage() *StopNotifyingVirtualSelectedParentChainChangedResponseMessage {
+	return &StopNotifyingVirtualSelectedParentChainChangedResponseMessage{}
+}
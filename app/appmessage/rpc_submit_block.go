@@ -6,6 +6,7 @@ type SubmitBlockRequestMessage struct {
 	baseMessage
 	Block             *RPCBlock
 	AllowNonDAABlocks bool
+	Async             bool
 }
 
 // Command returns the protocol command string for the message
@@ -47,6 +48,7 @@ func (rr RejectReason) String() string {
 type SubmitBlockResponseMessage struct {
 	baseMessage
 	RejectReason RejectReason
+	SubmissionID string
 	Error        *RPCError
 }
 
@@ -0,0 +1,43 @@
+package appmessage
+
+// SubmitWorkRequestMessage is an appmessage corresponding to
+// its respective RPC message
+type SubmitWorkRequestMessage struct {
+	baseMessage
+	WorkID string
+	Nonce  uint64
+}
+
+// Command returns the protocol command string for the message
+func (msg *SubmitWorkRequestMessage) Command() MessageCommand {
+	return CmdSubmitWorkRequestMessage
+}
+
+// NewSubmitWorkRequestMessage returns a instance of the message
+func NewSubmitWorkRequestMessage(workID string, nonce uint64) *SubmitWorkRequestMessage {
+	return &SubmitWorkRequestMessage{
+		WorkID: workID,
+		Nonce:  nonce,
+	}
+}
+
+// SubmitWorkResponseMessage is an appmessage corresponding to
+// its respective RPC message
+type SubmitWorkResponseMessage struct {
+	baseMessage
+	BlockHash string
+
+	Error *RPCError
+}
+
+// Command returns the protocol command string for the message
+func (msg *SubmitWorkResponseMessage) Command() MessageCommand {
+	return CmdSubmitWorkResponseMessage
+}
+
+// NewSubmitWorkResponseMessage returns a instance of the message
+func NewSubmitWorkResponseMessage(blockHash string) *SubmitWorkResponseMessage {
+	return &SubmitWorkResponseMessage{
+		BlockHash: blockHash,
+	}
+}
@@ -2,15 +2,21 @@ package app
 
 import (
 	"fmt"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 
 	"github.com/kaspanet/kaspad/domain/miningmanager/mempool"
+	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
 
 	"github.com/kaspanet/kaspad/app/protocol"
+	"github.com/kaspanet/kaspad/app/rest"
 	"github.com/kaspanet/kaspad/app/rpc"
+	"github.com/kaspanet/kaspad/app/stratum"
 	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/addressindex"
 	"github.com/kaspanet/kaspad/domain/consensus"
 	"github.com/kaspanet/kaspad/domain/utxoindex"
 	"github.com/kaspanet/kaspad/infrastructure/config"
@@ -19,18 +25,37 @@ import (
 	"github.com/kaspanet/kaspad/infrastructure/network/connmanager"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/id"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
+	"github.com/kaspanet/kaspad/infrastructure/os/shutdown"
 	"github.com/kaspanet/kaspad/util/panics"
 )
 
+const (
+	// stopTimeout is how long stopping each of the listeners and the
+	// connections may take during shutdown
+	stopTimeout = 10 * time.Second
+
+	// drainTimeout is how long waiting for each kind of work in progress
+	// may take during shutdown
+	drainTimeout = 30 * time.Second
+)
+
 // ComponentManager is a wrapper for all the kaspad services
 type ComponentManager struct {
 	cfg               *config.Config
+	domain            domain.Domain
 	addressManager    *addressmanager.AddressManager
 	protocolManager   *protocol.Manager
 	rpcManager        *rpc.Manager
+	stratumServer     *stratum.Server
+	restServer        *rest.Server
 	connectionManager *connmanager.ConnectionManager
 	netAdapter        *netadapter.NetAdapter
 
+	// reloadableOptions are the options that the last ReloadConfig applied
+	reloadableOptions *config.ReloadableOptions
+	reloadLock        sync.Mutex
+
 	started, shutdown int32
 }
 
@@ -48,10 +73,25 @@ func (a *ComponentManager) Start() {
 		panics.Exit(log, fmt.Sprintf("Error starting the net adapter: %+v", err))
 	}
 
+	if a.stratumServer != nil {
+		err = a.stratumServer.Start()
+		if err != nil {
+			panics.Exit(log, fmt.Sprintf("Error starting the stratum server: %+v", err))
+		}
+	}
+
+	if a.restServer != nil {
+		err = a.restServer.Start()
+		if err != nil {
+			panics.Exit(log, fmt.Sprintf("Error starting the REST server: %+v", err))
+		}
+	}
+
 	a.connectionManager.Start()
 }
 
-// Stop gracefully shuts down all the kaspad services.
+// Stop gracefully shuts down all the kaspad services. It doesn't close
+// the database.
 func (a *ComponentManager) Stop() {
 	// Make sure this only happens once.
 	if atomic.AddInt32(&a.shutdown, 1) != 1 {
@@ -61,17 +101,55 @@ func (a *ComponentManager) Stop() {
 
 	log.Warnf("Kaspad shutting down")
 
-	a.connectionManager.Stop()
-
-	err := a.netAdapter.Stop()
+	coordinator := shutdown.NewCoordinator()
+	a.RegisterShutdownHooks(coordinator)
+	err := coordinator.Run()
 	if err != nil {
-		log.Errorf("Error stopping the net adapter: %+v", err)
+		log.Errorf("Error shutting down kaspad: %s", err)
 	}
+}
 
-	a.protocolManager.Close()
-	close(a.protocolManager.Context().Domain().ConsensusEventsChannel())
+// RegisterShutdownHooks registers the hooks that shut down the kaspad
+// services with the given coordinator. First the listeners and the
+// connections are stopped, so that no new work is accepted, and then the
+// work in progress is drained, so that nothing writes to the database
+// once it's closed.
+func (a *ComponentManager) RegisterShutdownHooks(coordinator *shutdown.Coordinator) {
+	if a.stratumServer != nil {
+		coordinator.Register(shutdown.PhaseStopAcceptingWork, "the stratum server", stopTimeout, func() error {
+			a.stratumServer.Stop()
+			return nil
+		})
+	}
+	if a.restServer != nil {
+		coordinator.Register(shutdown.PhaseStopAcceptingWork, "the REST server", stopTimeout, func() error {
+			a.restServer.Stop()
+			return nil
+		})
+	}
+	coordinator.Register(shutdown.PhaseStopAcceptingWork, "the connection manager", stopTimeout, func() error {
+		a.connectionManager.Stop()
+		return nil
+	})
+	coordinator.Register(shutdown.PhaseStopAcceptingWork, "the net adapter", stopTimeout, a.netAdapter.Stop)
 
-	return
+	coordinator.Register(shutdown.PhaseDrain, "the p2p flows", drainTimeout, func() error {
+		a.protocolManager.Close()
+		return nil
+	})
+	coordinator.Register(shutdown.PhaseDrain, "the consensus events handler", drainTimeout, func() error {
+		close(a.protocolManager.Context().Domain().ConsensusEventsChannel())
+		a.rpcManager.WaitForConsensusEventsHandler()
+		return nil
+	})
+	coordinator.Register(shutdown.PhaseDrain, "the RPC jobs", drainTimeout, func() error {
+		a.rpcManager.StopJobs()
+		return nil
+	})
+	coordinator.Register(shutdown.PhaseDrain, "the database jobs", drainTimeout, func() error {
+		a.rpcManager.WaitForDatabaseJobs()
+		return nil
+	})
 }
 
 // NewComponentManager returns a new ComponentManager instance.
@@ -79,21 +157,57 @@ func (a *ComponentManager) Stop() {
 func NewComponentManager(cfg *config.Config, db infrastructuredatabase.Database, interrupt chan<- struct{}) (
 	*ComponentManager, error) {
 
+	return NewComponentManagerWithTransport(cfg, db, interrupt, nil, nil)
+}
+
+// NewComponentManagerWithTransport returns a new ComponentManager instance
+// whose P2P connections are dialed with dialer and accepted from listeners
+// that are opened with listenerFactory. A nil dialer or listenerFactory uses
+// plain TCP. This allows running several nodes in one process over an
+// in-memory network.
+func NewComponentManagerWithTransport(cfg *config.Config, db infrastructuredatabase.Database,
+	interrupt chan<- struct{}, dialer server.Dialer, listenerFactory server.ListenerFactory) (
+	*ComponentManager, error) {
+
 	consensusConfig := consensus.Config{
 		Params:                          *cfg.ActiveNetParams,
 		IsArchival:                      cfg.IsArchivalNode,
 		EnableSanityCheckPruningUTXOSet: cfg.EnableSanityCheckPruningUTXOSet,
+		BlockCacheSize:                  int(cfg.BlockCacheSize),
+		HeaderCacheSize:                 int(cfg.HeaderCacheSize),
+		BatchSyncPolicy:                 databaseBatchSyncPolicy(cfg),
 	}
 	mempoolConfig := mempool.DefaultConfig(&consensusConfig.Params)
 	mempoolConfig.MaximumOrphanTransactionCount = cfg.MaxOrphanTxs
 	mempoolConfig.MinimumRelayTransactionFee = cfg.MinRelayTxFee
+	mempoolConfig.ValidatedSubnetworks = cfg.ValidatedSubnetworks
+	for _, transactionID := range cfg.BlocklistedTransactionIDs {
+		mempoolConfig.TransactionBlocklist = append(mempoolConfig.TransactionBlocklist,
+			&miningmanagermodel.TransactionBlocklistEntry{TransactionID: transactionID, Reason: "blocklisttx option"})
+	}
+	for _, scriptPublicKey := range cfg.BlocklistedScriptPublicKeys {
+		mempoolConfig.TransactionBlocklist = append(mempoolConfig.TransactionBlocklist,
+			&miningmanagermodel.TransactionBlocklistEntry{ScriptPublicKey: scriptPublicKey, Reason: "blocklistscript option"})
+	}
+
+	if cfg.ValidatedSubnetworks != nil || cfg.IndexedSubnetworks != nil || cfg.RelayedSubnetworks != nil {
+		log.Infof("Serving the transactions of the subnetworks: validated: %s, indexed: %s, relayed: %s",
+			cfg.ValidatedSubnetworks, cfg.IndexedSubnetworks, cfg.RelayedSubnetworks)
+	}
 
 	domain, err := domain.New(&consensusConfig, mempoolConfig, db)
 	if err != nil {
 		return nil, err
 	}
 
-	netAdapter, err := netadapter.NewNetAdapter(cfg)
+	if cfg.ImportBlocks != "" {
+		err = importBlocks(cfg, domain)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	netAdapter, err := netadapter.NewNetAdapterWithTransport(cfg, dialer, listenerFactory)
 	if err != nil {
 		return nil, err
 	}
@@ -113,7 +227,17 @@ func NewComponentManager(cfg *config.Config, db infrastructuredatabase.Database,
 		log.Infof("UTXO index started")
 	}
 
-	connectionManager, err := connmanager.New(cfg, netAdapter, addressManager)
+	var addressIndex *addressindex.AddressIndex
+	if cfg.AddressIndex {
+		addressIndex, err = addressindex.New(domain, db, cfg.IndexedSubnetworks)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Infof("Address index started")
+	}
+
+	connectionManager, err := connmanager.NewWithPolicy(cfg, netAdapter, addressManager, connectionPolicy(cfg))
 	if err != nil {
 		return nil, err
 	}
@@ -121,16 +245,37 @@ func NewComponentManager(cfg *config.Config, db infrastructuredatabase.Database,
 	if err != nil {
 		return nil, err
 	}
-	rpcManager := setupRPC(cfg, domain, netAdapter, protocolManager, connectionManager, addressManager, utxoIndex, domain.ConsensusEventsChannel(), interrupt)
+	rpcManager := setupRPC(cfg, domain, netAdapter, protocolManager, connectionManager, addressManager, utxoIndex, addressIndex,
+		db, domain.ConsensusEventsChannel(), interrupt)
 
-	return &ComponentManager{
+	var stratumServer *stratum.Server
+	if len(cfg.StratumListeners) > 0 {
+		stratumServer, err = setupStratum(cfg, domain, protocolManager, rpcManager)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var restServer *rest.Server
+	if len(cfg.RESTListeners) > 0 {
+		restServer = rest.New(cfg, rpcManager)
+	}
+
+	componentManager := &ComponentManager{
 		cfg:               cfg,
+		domain:            domain,
 		protocolManager:   protocolManager,
 		rpcManager:        rpcManager,
+		stratumServer:     stratumServer,
+		restServer:        restServer,
 		connectionManager: connectionManager,
 		netAdapter:        netAdapter,
 		addressManager:    addressManager,
-	}, nil
+		reloadableOptions: cfg.ReloadableOptions(),
+	}
+	rpcManager.SetConfigReloader(componentManager.ReloadConfig)
+
+	return componentManager, nil
 
 }
 
@@ -142,6 +287,8 @@ func setupRPC(
 	connectionManager *connmanager.ConnectionManager,
 	addressManager *addressmanager.AddressManager,
 	utxoIndex *utxoindex.UTXOIndex,
+	addressIndex *addressindex.AddressIndex,
+	db infrastructuredatabase.Database,
 	consensusEventsChan chan externalapi.ConsensusEvent,
 	shutDownChan chan<- struct{},
 ) *rpc.Manager {
@@ -154,15 +301,58 @@ func setupRPC(
 		connectionManager,
 		addressManager,
 		utxoIndex,
+		addressIndex,
+		db,
 		consensusEventsChan,
 		shutDownChan,
 	)
 	protocolManager.SetOnNewBlockTemplateHandler(rpcManager.NotifyNewBlockTemplate)
 	protocolManager.SetOnPruningPointUTXOSetOverrideHandler(rpcManager.NotifyPruningPointUTXOSetOverride)
+	protocolManager.SetOnTransactionAddedToMempoolHandler(rpcManager.NotifyTransactionsAddedToMempool)
 
 	return rpcManager
 }
 
+func setupStratum(
+	cfg *config.Config,
+	domain domain.Domain,
+	protocolManager *protocol.Manager,
+	rpcManager *rpc.Manager,
+) (*stratum.Server, error) {
+
+	stratumServer, err := stratum.New(cfg, domain, rpcManager)
+	if err != nil {
+		return nil, err
+	}
+	protocolManager.SetOnNewBlockTemplateHandler(func() error {
+		err := rpcManager.NotifyNewBlockTemplate()
+		if err != nil {
+			return err
+		}
+		return stratumServer.NotifyNewBlockTemplate()
+	})
+
+	return stratumServer, nil
+}
+
+// connectionPolicy builds the connection manager's policy from the
+// defaults and the --connretry* flags that are set
+func connectionPolicy(cfg *config.Config) *connmanager.Policy {
+	policy := connmanager.DefaultPolicy()
+	if cfg.ConnectionRetryDelay != 0 {
+		policy.Default.InitialDelay = cfg.ConnectionRetryDelay
+		if cfg.ConnectionRetryMaxDelay == 0 && policy.Default.MaxDelay < cfg.ConnectionRetryDelay {
+			policy.Default.MaxDelay = cfg.ConnectionRetryDelay
+		}
+	}
+	if cfg.ConnectionRetryMaxDelay != 0 {
+		policy.Default.MaxDelay = cfg.ConnectionRetryMaxDelay
+	}
+	policy.Default.Jitter = cfg.ConnectionRetryJitter
+	policy.Default.MaxFailures = cfg.ConnectionRetryMaxFailures
+	return policy
+}
+
 // P2PNodeID returns the network ID associated with this ComponentManager
 func (a *ComponentManager) P2PNodeID() *id.ID {
 	return a.netAdapter.ID()
@@ -172,3 +362,18 @@ func (a *ComponentManager) P2PNodeID() *id.ID {
 func (a *ComponentManager) AddressManager() *addressmanager.AddressManager {
 	return a.addressManager
 }
+
+// Domain returns the Domain associated with this ComponentManager
+func (a *ComponentManager) Domain() domain.Domain {
+	return a.domain
+}
+
+// ProtocolManager returns the protocol.Manager associated with this ComponentManager
+func (a *ComponentManager) ProtocolManager() *protocol.Manager {
+	return a.protocolManager
+}
+
+// ConnectionManager returns the ConnectionManager associated with this ComponentManager
+func (a *ComponentManager) ConnectionManager() *connmanager.ConnectionManager {
+	return a.connectionManager
+}
@@ -0,0 +1,82 @@
+package app
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+// ReloadConfig parses the configuration file and the command line again,
+// and applies the options that can be changed while kaspad is running:
+// the log levels, the ban duration, the minimum relay fee and the RPC
+// users and their allowed methods. It also loads the RPC certificate, its
+// key and the client CAs from their files again, so that they can be
+// rotated without disconnecting the RPC clients. It returns the names of
+// the options that changed. If any of these options is invalid, nothing is
+// changed.
+func (a *ComponentManager) ReloadConfig() (changedOptions []string, err error) {
+	a.reloadLock.Lock()
+	defer a.reloadLock.Unlock()
+
+	options, err := a.cfg.LoadReloadableOptions()
+	if err != nil {
+		return nil, err
+	}
+	rpcTLSConfig, isRPCTLSConfigChanged, err := a.netAdapter.LoadRPCTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	// The log levels are set first, since they're the only options that
+	// may fail to be set. That way a failure doesn't leave the other
+	// options half reloaded.
+	current := a.reloadableOptions
+	if options.LogLevel != current.LogLevel {
+		err := logger.ReplaceLogLevels(options.LogLevel)
+		if err != nil {
+			return nil, err
+		}
+		changedOptions = append(changedOptions, "loglevel")
+	}
+	if options.BanDuration != current.BanDuration {
+		a.addressManager.SetBanDuration(options.BanDuration)
+		changedOptions = append(changedOptions, "banduration")
+	}
+	if options.MinRelayTxFee != current.MinRelayTxFee {
+		a.domain.MiningManager().SetMinimumRelayTransactionFee(options.MinRelayTxFee)
+		changedOptions = append(changedOptions, "minrelaytxfee")
+	}
+	isRPCAuthChanged := !stringSlicesEqual(options.RPCAuth, current.RPCAuth)
+	isRPCCertAuthChanged := !stringSlicesEqual(options.RPCCertAuth, current.RPCCertAuth)
+	if isRPCAuthChanged || isRPCCertAuthChanged {
+		a.netAdapter.SetRPCCredentials(options.RPCCredentials, options.RPCCertificateCredentials)
+		if isRPCAuthChanged {
+			changedOptions = append(changedOptions, "rpcauth")
+		}
+		if isRPCCertAuthChanged {
+			changedOptions = append(changedOptions, "rpccertauth")
+		}
+	}
+	if isRPCTLSConfigChanged {
+		a.netAdapter.UseRPCTLSConfig(rpcTLSConfig)
+		changedOptions = append(changedOptions, "rpccert")
+	}
+	a.reloadableOptions = options
+
+	if len(changedOptions) == 0 {
+		log.Infof("Reloaded the configuration. No reloadable option changed")
+	} else {
+		log.Infof("Reloaded the configuration. Changed options: %s", changedOptions)
+	}
+	return changedOptions, nil
+}
+
+func stringSlicesEqual(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
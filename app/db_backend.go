@@ -0,0 +1,168 @@
+package app
+
+import (
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/pebbledb"
+	"github.com/pkg/errors"
+)
+
+const (
+	databaseCacheSizeMiB = 256
+
+	// migrationBatchSize is the amount of entries copied in every
+	// transaction while migrating between database backends
+	migrationBatchSize = 10_000
+
+	// databaseMetricsBucketDepth records the metrics of every store of
+	// every consensus instance separately, since their buckets are
+	// nested in a prefix bucket
+	databaseMetricsBucketDepth = 2
+)
+
+// databaseBackend returns the backend of the database in dbPath. Databases that
+// were created before the backend file was introduced are always leveldb databases
+func databaseBackend(dbPath string, defaultBackend string) (string, error) {
+	backendBytes, err := os.ReadFile(backendFilePath(dbPath))
+	if err == nil {
+		return strings.TrimSpace(string(backendBytes)), nil
+	}
+	if !os.IsNotExist(err) {
+		return "", err
+	}
+
+	_, err = os.Stat(versionFilePath(dbPath))
+	if err == nil {
+		return config.DBTypeLevelDB, nil
+	}
+	if !os.IsNotExist(err) {
+		return "", err
+	}
+	return defaultBackend, nil
+}
+
+func createDatabaseBackendFile(dbPath string, backend string) error {
+	err := os.MkdirAll(dbPath, 0700)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(backendFilePath(dbPath), []byte(backend), 0600)
+}
+
+func backendFilePath(dbPath string) string {
+	return path.Join(dbPath, config.DBTypeFilename)
+}
+
+func openDatabaseBackend(dbPath string, backend string) (database.Database, error) {
+	return openDatabaseBackendWithDurability(dbPath, backend, database.DurabilityNone, 0)
+}
+
+func openDatabaseBackendWithDurability(dbPath string, backend string,
+	durability database.Durability, syncInterval time.Duration) (database.Database, error) {
+
+	switch backend {
+	case config.DBTypeLevelDB:
+		return ldb.NewLevelDBWithDurability(dbPath, databaseCacheSizeMiB, durability, syncInterval)
+	case config.DBTypePebble:
+		return pebbledb.NewPebbleDBWithDurability(dbPath, databaseCacheSizeMiB, durability, syncInterval)
+	default:
+		return nil, errors.Errorf("unknown database backend %s", backend)
+	}
+}
+
+// databaseBatchSyncPolicy returns the policy by which the database
+// transactions of consensus are synced to disk. With batch durability, they're
+// synced by time as well as by the database's syncer, since a sync that's done
+// as part of a commit spares the syncer's next sync
+func databaseBatchSyncPolicy(cfg *config.Config) database.BatchSyncPolicy {
+	switch database.Durability(cfg.DbDurability) {
+	case database.DurabilityFull:
+		return database.BatchSyncPolicy{EveryBatches: 1}
+	case database.DurabilityBatch:
+		return database.BatchSyncPolicy{
+			EveryBatches: cfg.DbSyncBatches,
+			Interval:     cfg.DbSyncInterval,
+		}
+	default:
+		return database.BatchSyncPolicy{}
+	}
+}
+
+// migrateDatabase copies the database in dbPath into a new database of the
+// given backend, and replaces the old database with it. The old database
+// is kept next to the new one as a backup
+func migrateDatabase(dbPath string, fromBackend string, toBackend string) error {
+	log.Infof("Migrating the database in '%s' from %s to %s. This might take a while",
+		dbPath, fromBackend, toBackend)
+
+	migratingPath := dbPath + "-migrating"
+	err := os.RemoveAll(migratingPath)
+	if err != nil {
+		return err
+	}
+
+	copiedCount, err := copyDatabase(dbPath, fromBackend, migratingPath, toBackend)
+	if err != nil {
+		return err
+	}
+	err = createDatabaseVersionFile(migratingPath, versionFilePath(migratingPath))
+	if err != nil {
+		return err
+	}
+	err = createDatabaseBackendFile(migratingPath, toBackend)
+	if err != nil {
+		return err
+	}
+
+	backupPath := dbPath + "-" + fromBackend + "-backup"
+	err = os.RemoveAll(backupPath)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(dbPath, backupPath)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(migratingPath, dbPath)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Migrated %d database entries to %s. The %s database was moved to '%s', "+
+		"and may be deleted once the node runs as expected", copiedCount, toBackend, fromBackend, backupPath)
+	return nil
+}
+
+func copyDatabase(sourcePath string, sourceBackend string,
+	destinationPath string, destinationBackend string) (copiedCount int, err error) {
+
+	source, err := openDatabaseBackend(sourcePath, sourceBackend)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		closeErr := source.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}()
+
+	destination, err := openDatabaseBackend(destinationPath, destinationBackend)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		closeErr := destination.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}()
+
+	return database.Copy(destination, source, migrationBatchSize)
+}
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/encrypteddb"
+	"github.com/pkg/errors"
+)
+
+// openDatabaseEncryption wraps the given database with encryption, if
+// either --dbencryptionkeyfile or --dbencryptionpassphrase is set. An
+// encrypted database can't be opened without either of them
+func openDatabaseEncryption(cfg *config.Config, db database.Database, dbPath string) (database.Database, error) {
+	credentials := &encrypteddb.Credentials{}
+	switch {
+	case cfg.DbEncryptionKeyFile != "":
+		key, err := encrypteddb.ReadKeyFile(cfg.DbEncryptionKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		credentials.Key = key
+	case cfg.DbEncryptionPassphrase != "":
+		credentials.Passphrase = []byte(cfg.DbEncryptionPassphrase)
+	default:
+		isEncrypted, err := encrypteddb.IsEncrypted(db)
+		if err != nil {
+			return nil, err
+		}
+		if isEncrypted {
+			return nil, errors.Errorf("The database in '%s' is encrypted. Run with either "+
+				"--dbencryptionkeyfile or --dbencryptionpassphrase to open it", dbPath)
+		}
+		return db, nil
+	}
+
+	encryptedDB, err := encrypteddb.Open(db, credentials)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed opening the encrypted database in '%s'", dbPath)
+	}
+	log.Infof("The database is encrypted")
+	return encryptedDB, nil
+}
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/kaspanet/kaspad/domain/addressindex"
+	"github.com/kaspanet/kaspad/domain/utxoindex"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/migration"
+)
+
+// schemaMigrations are the migrations of the database schema, in the order
+// they're applied. Changes to the layout of the data in the database add a
+// migration here, instead of requiring the database to be deleted
+var schemaMigrations = []*migration.Migration{
+	{
+		Version:     1,
+		Description: "Move the UTXO index and the address index into their namespaces",
+		Migrate: func(db database.Database) error {
+			err := utxoindex.MigrateToNamespace(db)
+			if err != nil {
+				return err
+			}
+			return addressindex.MigrateToNamespace(db)
+		},
+	},
+}
+
+// migrateDatabaseSchema applies the schema migrations that weren't applied to
+// the database yet. If --schema-migration-backup is set, the database is backed
+// up before any migration is applied
+func migrateDatabaseSchema(cfg *config.Config, db database.Database, dbPath string, backend string) error {
+	pendingMigrations, err := migration.Pending(db, schemaMigrations)
+	if err != nil {
+		return err
+	}
+	if len(pendingMigrations) > 0 && cfg.SchemaMigrationBackup {
+		schemaVersion, err := migration.SchemaVersion(db)
+		if err != nil {
+			return err
+		}
+		backupPath := fmt.Sprintf("%s-schema-%d-backup", dbPath, schemaVersion)
+		log.Infof("Backing the database up into '%s' before migrating it", backupPath)
+		err = db.Backup(backupPath)
+		if err != nil {
+			return err
+		}
+		err = createDatabaseBackendFile(backupPath, backend)
+		if err != nil {
+			return err
+		}
+	}
+
+	appliedMigrations, err := migration.Run(db, schemaMigrations)
+	if err != nil {
+		return err
+	}
+	if len(appliedMigrations) > 0 {
+		log.Infof("Applied %d database migrations. The database schema version is now %d",
+			len(appliedMigrations), migration.LatestVersion(schemaMigrations))
+	}
+	return nil
+}
+
+// logPendingSchemaMigrations logs the schema migrations that would be applied
+// to the database, without applying them
+func logPendingSchemaMigrations(db database.Database) error {
+	schemaVersion, err := migration.SchemaVersion(db)
+	if err != nil {
+		return err
+	}
+	pendingMigrations, err := migration.Pending(db, schemaMigrations)
+	if err != nil {
+		return err
+	}
+	if len(pendingMigrations) == 0 {
+		log.Infof("The database schema version is %d, and there are no migrations to apply", schemaVersion)
+		return nil
+	}
+	log.Infof("The database schema version is %d. Running kaspad would apply %d migrations:",
+		schemaVersion, len(pendingMigrations))
+	for _, pendingMigration := range pendingMigrations {
+		log.Infof("Migration %d: %s", pendingMigration.Version, pendingMigration.Description)
+	}
+	return nil
+}
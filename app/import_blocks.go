@@ -0,0 +1,35 @@
+package app
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/blockexport"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+)
+
+// importBlocks imports the block export file given by --importblocks. It's
+// called before the node connects to any peer, so the blocks are validated
+// and inserted without competing with IBD or block relay.
+func importBlocks(cfg *config.Config, domain domain.Domain) error {
+	workerCount := cfg.ImportBlocksWorkers
+	if workerCount == 0 {
+		workerCount = runtime.NumCPU()
+	}
+	log.Infof("Importing the blocks in %s with %d workers per stage", cfg.ImportBlocks, workerCount)
+
+	startTime := time.Now()
+	importedFile, insertedBlockCount, err := blockexport.Import(domain.Consensus(),
+		cfg.ActiveNetParams.GenesisHash, cfg.ImportBlocks, workerCount, func(progress *blockexport.ImportProgress) {
+			log.Infof("Read %d blocks and inserted %d of them (%.0f blocks/s)", progress.ReadBlockCount,
+				progress.InsertedBlockCount, float64(progress.ReadBlockCount)/time.Since(startTime).Seconds())
+		})
+	if err != nil {
+		return err
+	}
+	log.Infof("Imported %d of the %d blocks between %s and %s in %s in %s. The rest were already known",
+		insertedBlockCount, importedFile.BlockCount, importedFile.LowHash, importedFile.HighHash, cfg.ImportBlocks,
+		time.Since(startTime).Round(time.Second))
+	return nil
+}
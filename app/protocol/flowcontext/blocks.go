@@ -42,6 +42,11 @@ func (f *FlowContext) OnNewBlock(block *externalapi.DomainBlock) error {
 		allAcceptedTransactions = append(allAcceptedTransactions, acceptedTransactions...)
 	}
 
+	err = f.OnTransactionAddedToMempool(allAcceptedTransactions)
+	if err != nil {
+		return err
+	}
+
 	return f.broadcastTransactionsAfterBlockAdded(newBlocks, allAcceptedTransactions)
 }
 
@@ -74,25 +79,20 @@ func (f *FlowContext) broadcastTransactionsAfterBlockAdded(
 		return nil
 	}
 
-	var txIDsToRebroadcast []*externalapi.DomainTransactionID
+	var txsToRebroadcast []*externalapi.DomainTransaction
 	if f.shouldRebroadcastTransactions() {
-		txsToRebroadcast, err := f.Domain().MiningManager().RevalidateHighPriorityTransactions()
+		var err error
+		txsToRebroadcast, err = f.Domain().MiningManager().RevalidateHighPriorityTransactions()
 		if err != nil {
 			return err
 		}
-		txIDsToRebroadcast = consensushashing.TransactionIDs(txsToRebroadcast)
 		f.lastRebroadcastTime = time.Now()
 	}
 
-	txIDsToBroadcast := make([]*externalapi.DomainTransactionID, len(transactionsAcceptedToMempool)+len(txIDsToRebroadcast))
-	for i, tx := range transactionsAcceptedToMempool {
-		txIDsToBroadcast[i] = consensushashing.TransactionID(tx)
-	}
-	offset := len(transactionsAcceptedToMempool)
-	for i, txID := range txIDsToRebroadcast {
-		txIDsToBroadcast[offset+i] = txID
-	}
-	return f.EnqueueTransactionIDsForPropagation(txIDsToBroadcast)
+	txsToBroadcast := make([]*externalapi.DomainTransaction, 0, len(transactionsAcceptedToMempool)+len(txsToRebroadcast))
+	txsToBroadcast = append(txsToBroadcast, transactionsAcceptedToMempool...)
+	txsToBroadcast = append(txsToBroadcast, txsToRebroadcast...)
+	return f.EnqueueTransactionsForPropagation(txsToBroadcast)
 }
 
 // SharedRequestedBlocks returns a *blockrelay.SharedRequestedBlocks for sharing
@@ -143,7 +143,7 @@ func (f *FlowContext) TrySetIBDRunning(ibdPeer *peerpkg.Peer) bool {
 		return false
 	}
 	f.ibdPeer = ibdPeer
-	log.Infof("IBD started with peer %s", ibdPeer)
+	log.WithField("peer", ibdPeer).Infof("IBD started with peer %s", ibdPeer)
 
 	return true
 }
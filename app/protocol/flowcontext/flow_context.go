@@ -9,8 +9,11 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 
 	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/utxosnapshot"
 
+	"github.com/kaspanet/kaspad/app/protocol/networktime"
 	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
 	"github.com/kaspanet/kaspad/infrastructure/config"
 	"github.com/kaspanet/kaspad/infrastructure/network/addressmanager"
 	"github.com/kaspanet/kaspad/infrastructure/network/connmanager"
@@ -26,8 +29,8 @@ type OnNewBlockTemplateHandler func() error
 type OnPruningPointUTXOSetOverrideHandler func() error
 
 // OnTransactionAddedToMempoolHandler is a handler function that's triggered
-// when a transaction is added to the mempool
-type OnTransactionAddedToMempoolHandler func()
+// when transactions are added to the mempool
+type OnTransactionAddedToMempoolHandler func(transactions []*externalapi.DomainTransaction) error
 
 // FlowContext holds state that is relevant to more than one flow or one peer, and allows communication between
 // different flows that can be associated to different peers.
@@ -55,13 +58,19 @@ type FlowContext struct {
 	peers      map[id.ID]*peerpkg.Peer
 	peersMutex sync.RWMutex
 
-	orphans      map[externalapi.DomainHash]*externalapi.DomainBlock
-	orphansMutex sync.RWMutex
+	networkTimeChecker *networktime.Checker
 
-	transactionIDsToPropagate        []*externalapi.DomainTransactionID
+	orphans         map[externalapi.DomainHash]*orphanEntry
+	orphansMutex    sync.RWMutex
+	orphanLatencies *orphanLatencies
+
+	transactionsToPropagate          []*externalapi.DomainTransaction
 	lastTransactionIDPropagationTime time.Time
 	transactionIDPropagationLock     sync.Mutex
 
+	importedUTXOSet      *utxosnapshot.Snapshot
+	importedUTXOSetMutex sync.Mutex
+
 	shutdownChan chan struct{}
 }
 
@@ -78,9 +87,11 @@ func New(cfg *config.Config, domain domain.Domain, addressManager *addressmanage
 		sharedRequestedTransactions:      NewSharedRequestedTransactions(),
 		sharedRequestedBlocks:            NewSharedRequestedBlocks(),
 		peers:                            make(map[id.ID]*peerpkg.Peer),
-		orphans:                          make(map[externalapi.DomainHash]*externalapi.DomainBlock),
+		networkTimeChecker:               networktime.New(blockTimestampTolerance(cfg.NetParams())),
+		orphans:                          make(map[externalapi.DomainHash]*orphanEntry),
+		orphanLatencies:                  newOrphanLatencies(),
 		timeStarted:                      mstime.Now().UnixMilliseconds(),
-		transactionIDsToPropagate:        []*externalapi.DomainTransactionID{},
+		transactionsToPropagate:          []*externalapi.DomainTransaction{},
 		lastTransactionIDPropagationTime: time.Now(),
 		shutdownChan:                     make(chan struct{}),
 	}
@@ -97,6 +108,18 @@ func (f *FlowContext) ShutdownChan() <-chan struct{} {
 	return f.shutdownChan
 }
 
+// NetworkTimeChecker returns the checker that compares the local clock with
+// the clocks of the peers
+func (f *FlowContext) NetworkTimeChecker() *networktime.Checker {
+	return f.networkTimeChecker
+}
+
+// blockTimestampTolerance returns how far in the future block timestamps may
+// be, which is also how far the local clock may drift from the network's
+func blockTimestampTolerance(params *dagconfig.Params) time.Duration {
+	return time.Duration(params.TimestampDeviationTolerance) * params.TargetTimePerBlock
+}
+
 // IsNearlySynced returns whether current consensus is considered synced or close to being synced.
 func (f *FlowContext) IsNearlySynced() (bool, error) {
 	return f.Domain().Consensus().IsNearlySynced()
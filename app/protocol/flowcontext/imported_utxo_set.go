@@ -0,0 +1,30 @@
+package flowcontext
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/utxosnapshot"
+)
+
+// SetImportedUTXOSet sets the UTXO set snapshot that IBD uses instead of
+// downloading the pruning point UTXO set from the syncer, if the syncer's
+// pruning point is the snapshot's. It replaces any previously set snapshot.
+func (f *FlowContext) SetImportedUTXOSet(snapshot *utxosnapshot.Snapshot) {
+	f.importedUTXOSetMutex.Lock()
+	defer f.importedUTXOSetMutex.Unlock()
+
+	f.importedUTXOSet = snapshot
+}
+
+// TakeImportedUTXOSet returns the imported UTXO set snapshot of the given
+// pruning point, if there is one, and unsets it so that it's used only once
+func (f *FlowContext) TakeImportedUTXOSet(pruningPointHash *externalapi.DomainHash) *utxosnapshot.Snapshot {
+	f.importedUTXOSetMutex.Lock()
+	defer f.importedUTXOSetMutex.Unlock()
+
+	if f.importedUTXOSet == nil || !f.importedUTXOSet.PruningPointHash.Equal(pruningPointHash) {
+		return nil
+	}
+	snapshot := f.importedUTXOSet
+	f.importedUTXOSet = nil
+	return snapshot
+}
@@ -29,6 +29,7 @@ func (f *FlowContext) AddToPeers(peer *peerpkg.Peer) error {
 	}
 
 	f.peers[*peer.ID()] = peer
+	f.networkTimeChecker.AddSample(peer.ID(), peer.TimeOffset())
 
 	return nil
 }
@@ -39,6 +40,7 @@ func (f *FlowContext) RemoveFromPeers(peer *peerpkg.Peer) {
 	defer f.peersMutex.Unlock()
 
 	delete(f.peers, *peer.ID())
+	f.networkTimeChecker.RemoveSample(peer.ID())
 }
 
 // readyPeerConnections returns the NetConnections of all the ready peers.
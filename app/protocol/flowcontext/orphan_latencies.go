@@ -0,0 +1,62 @@
+package flowcontext
+
+import (
+	"sync"
+	"time"
+)
+
+// maxOrphanLatencySamples is how many of the most recently unorphaned
+// blocks the latencies are kept of
+const maxOrphanLatencySamples = 10000
+
+// orphanLatencies keeps how long the most recently unorphaned blocks waited
+// for their missing ancestors, along with their blue scores
+type orphanLatencies struct {
+	lock       sync.Mutex
+	blueScores []uint64
+	latencies  []time.Duration
+	next       int
+}
+
+func newOrphanLatencies() *orphanLatencies {
+	return &orphanLatencies{
+		blueScores: make([]uint64, 0, maxOrphanLatencySamples),
+		latencies:  make([]time.Duration, 0, maxOrphanLatencySamples),
+	}
+}
+
+func (ol *orphanLatencies) add(blueScore uint64, latency time.Duration) {
+	ol.lock.Lock()
+	defer ol.lock.Unlock()
+
+	if len(ol.latencies) < maxOrphanLatencySamples {
+		ol.blueScores = append(ol.blueScores, blueScore)
+		ol.latencies = append(ol.latencies, latency)
+		return
+	}
+	// Once full, the oldest sample is overwritten
+	ol.blueScores[ol.next] = blueScore
+	ol.latencies[ol.next] = latency
+	ol.next = (ol.next + 1) % maxOrphanLatencySamples
+}
+
+func (ol *orphanLatencies) inBlueScoreRange(lowBlueScore uint64, highBlueScore uint64) []time.Duration {
+	ol.lock.Lock()
+	defer ol.lock.Unlock()
+
+	var latencies []time.Duration
+	for i, blueScore := range ol.blueScores {
+		if blueScore >= lowBlueScore && blueScore <= highBlueScore {
+			latencies = append(latencies, ol.latencies[i])
+		}
+	}
+	return latencies
+}
+
+// OrphanLatencies returns how long the blocks with blue scores between
+// lowBlueScore and highBlueScore, inclusive, that were received as orphans
+// waited for their missing ancestors. Only the latest
+// maxOrphanLatencySamples unorphaned blocks are kept.
+func (f *FlowContext) OrphanLatencies(lowBlueScore uint64, highBlueScore uint64) []time.Duration {
+	return f.orphanLatencies.inBlueScoreRange(lowBlueScore, highBlueScore)
+}
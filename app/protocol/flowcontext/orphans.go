@@ -1,6 +1,8 @@
 package flowcontext
 
 import (
+	"time"
+
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
@@ -15,13 +17,22 @@ import (
 // on: 2^orphanResolutionRange * PHANTOM K.
 const maxOrphans = 600
 
+// orphanEntry is a block in the orphan set, along with when it was first
+// received
+type orphanEntry struct {
+	block        *externalapi.DomainBlock
+	timeReceived time.Time
+}
+
 // AddOrphan adds the block to the orphan set
-func (f *FlowContext) AddOrphan(orphanBlock *externalapi.DomainBlock) {
+func (f *FlowContext) AddOrphan(block *externalapi.DomainBlock) {
 	f.orphansMutex.Lock()
 	defer f.orphansMutex.Unlock()
 
-	orphanHash := consensushashing.BlockHash(orphanBlock)
-	f.orphans[*orphanHash] = orphanBlock
+	orphanHash := consensushashing.BlockHash(block)
+	if _, ok := f.orphans[*orphanHash]; !ok {
+		f.orphans[*orphanHash] = &orphanEntry{block: block, timeReceived: time.Now()}
+	}
 
 	if len(f.orphans) > maxOrphans {
 		log.Debugf("Orphan collection size exceeded. Evicting a random orphan")
@@ -64,7 +75,7 @@ func (f *FlowContext) UnorphanBlocks(rootBlock *externalapi.DomainBlock) ([]*ext
 	for len(processQueue) > 0 {
 		var orphanHash externalapi.DomainHash
 		orphanHash, processQueue = processQueue[0], processQueue[1:]
-		orphanBlock := f.orphans[orphanHash]
+		orphanBlock := f.orphans[orphanHash].block
 
 		log.Debugf("Considering to unorphan block %s with parents %s",
 			orphanHash, orphanBlock.Header.DirectParents())
@@ -123,8 +134,8 @@ func (f *FlowContext) addChildOrphansToProcessQueue(blockHash *externalapi.Domai
 
 func (f *FlowContext) findChildOrphansOfBlock(blockHash *externalapi.DomainHash) []externalapi.DomainHash {
 	var childOrphans []externalapi.DomainHash
-	for orphanHash, orphanBlock := range f.orphans {
-		for _, orphanBlockParentHash := range orphanBlock.Header.DirectParents() {
+	for orphanHash, orphan := range f.orphans {
+		for _, orphanBlockParentHash := range orphan.block.Header.DirectParents() {
 			if orphanBlockParentHash.Equal(blockHash) {
 				childOrphans = append(childOrphans, orphanHash)
 				break
@@ -135,13 +146,13 @@ func (f *FlowContext) findChildOrphansOfBlock(blockHash *externalapi.DomainHash)
 }
 
 func (f *FlowContext) unorphanBlock(orphanHash externalapi.DomainHash) (bool, error) {
-	orphanBlock, ok := f.orphans[orphanHash]
+	orphan, ok := f.orphans[orphanHash]
 	if !ok {
 		return false, errors.Errorf("attempted to unorphan a non-orphan block %s", orphanHash)
 	}
 	delete(f.orphans, orphanHash)
 
-	err := f.domain.Consensus().ValidateAndInsertBlock(orphanBlock, true)
+	err := f.domain.Consensus().ValidateAndInsertBlock(orphan.block, true)
 	if err != nil {
 		if errors.As(err, &ruleerrors.RuleError{}) {
 			log.Warnf("Validation failed for orphan block %s: %s", orphanHash, err)
@@ -150,6 +161,12 @@ func (f *FlowContext) unorphanBlock(orphanHash externalapi.DomainHash) (bool, er
 		return false, err
 	}
 
+	blockInfo, err := f.domain.Consensus().GetBlockInfo(&orphanHash)
+	if err != nil {
+		return false, err
+	}
+	f.orphanLatencies.add(blockInfo.BlueScore, time.Since(orphan.timeReceived))
+
 	log.Infof("Unorphaned block %s", orphanHash)
 	return true, nil
 }
@@ -176,7 +193,7 @@ func (f *FlowContext) GetOrphanRoots(orphan *externalapi.DomainHash) ([]*externa
 		var current *externalapi.DomainHash
 		current, queue = queue[0], queue[1:]
 
-		block, ok := f.orphans[*current]
+		orphan, ok := f.orphans[*current]
 		if !ok {
 			blockInfo, err := f.domain.Consensus().GetBlockInfo(current)
 			if err != nil {
@@ -192,7 +209,7 @@ func (f *FlowContext) GetOrphanRoots(orphan *externalapi.DomainHash) ([]*externa
 			continue
 		}
 
-		for _, parent := range block.Header.DirectParents() {
+		for _, parent := range orphan.block.Header.DirectParents() {
 			if !addedToQueueSet.Contains(parent) {
 				queue = append(queue, parent)
 				addedToQueueSet.Add(parent)
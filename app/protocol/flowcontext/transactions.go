@@ -6,6 +6,7 @@ import (
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
 )
 
 // TransactionIDPropagationInterval is the interval between transaction IDs propagations
@@ -18,8 +19,12 @@ func (f *FlowContext) AddTransaction(tx *externalapi.DomainTransaction, allowOrp
 		return err
 	}
 
-	acceptedTransactionIDs := consensushashing.TransactionIDs(acceptedTransactions)
-	return f.EnqueueTransactionIDsForPropagation(acceptedTransactionIDs)
+	err = f.OnTransactionAddedToMempool(acceptedTransactions)
+	if err != nil {
+		return err
+	}
+
+	return f.EnqueueTransactionsForPropagation(acceptedTransactions)
 }
 
 func (f *FlowContext) shouldRebroadcastTransactions() bool {
@@ -33,49 +38,85 @@ func (f *FlowContext) SharedRequestedTransactions() *SharedRequestedTransactions
 	return f.sharedRequestedTransactions
 }
 
-// OnTransactionAddedToMempool notifies the handler function that a transaction
-// has been added to the mempool
-func (f *FlowContext) OnTransactionAddedToMempool() {
-	if f.onTransactionAddedToMempoolHandler != nil {
-		f.onTransactionAddedToMempoolHandler()
+// OnTransactionAddedToMempool notifies the handler function that transactions
+// have been added to the mempool
+func (f *FlowContext) OnTransactionAddedToMempool(transactions []*externalapi.DomainTransaction) error {
+	if f.onTransactionAddedToMempoolHandler != nil && len(transactions) > 0 {
+		return f.onTransactionAddedToMempoolHandler(transactions)
 	}
+	return nil
 }
 
-// EnqueueTransactionIDsForPropagation add the given transactions IDs to a set of IDs to
+// EnqueueTransactionsForPropagation adds the IDs of the given transactions to a set of IDs to
 // propagate. The IDs will be broadcast to all peers within a single transaction Inv message.
-// The broadcast itself may happen only during a subsequent call to this method
-func (f *FlowContext) EnqueueTransactionIDsForPropagation(transactionIDs []*externalapi.DomainTransactionID) error {
+// The broadcast itself may happen only during a subsequent call to this method.
+// Transactions of subnetworks that the node doesn't relay are not propagated
+func (f *FlowContext) EnqueueTransactionsForPropagation(transactions []*externalapi.DomainTransaction) error {
 	f.transactionIDPropagationLock.Lock()
 	defer f.transactionIDPropagationLock.Unlock()
 
-	f.transactionIDsToPropagate = append(f.transactionIDsToPropagate, transactionIDs...)
+	for _, transaction := range transactions {
+		if !f.Config().RelayedSubnetworks.Passes(transaction.SubnetworkID) {
+			continue
+		}
+		f.transactionsToPropagate = append(f.transactionsToPropagate, transaction)
+	}
 
 	return f.maybePropagateTransactions()
 }
 
 func (f *FlowContext) maybePropagateTransactions() error {
 	if time.Since(f.lastTransactionIDPropagationTime) < TransactionIDPropagationInterval &&
-		len(f.transactionIDsToPropagate) < appmessage.MaxInvPerTxInvMsg {
+		len(f.transactionsToPropagate) < appmessage.MaxInvPerTxInvMsg {
 		return nil
 	}
 
-	for len(f.transactionIDsToPropagate) > 0 {
-		transactionIDsToBroadcast := f.transactionIDsToPropagate
-		if len(transactionIDsToBroadcast) > appmessage.MaxInvPerTxInvMsg {
-			transactionIDsToBroadcast = f.transactionIDsToPropagate[:len(transactionIDsToBroadcast)]
+	for len(f.transactionsToPropagate) > 0 {
+		transactionsToBroadcast := f.transactionsToPropagate
+		if len(transactionsToBroadcast) > appmessage.MaxInvPerTxInvMsg {
+			transactionsToBroadcast = f.transactionsToPropagate[:appmessage.MaxInvPerTxInvMsg]
 		}
-		log.Debugf("Transaction propagation: broadcasting %d transactions", len(transactionIDsToBroadcast))
+		log.Debugf("Transaction propagation: broadcasting %d transactions", len(transactionsToBroadcast))
 
-		inv := appmessage.NewMsgInvTransaction(transactionIDsToBroadcast)
-		err := f.Broadcast(inv)
+		err := f.broadcastTransactionIDs(transactionsToBroadcast)
 		if err != nil {
 			return err
 		}
 
-		f.transactionIDsToPropagate = f.transactionIDsToPropagate[len(transactionIDsToBroadcast):]
+		f.transactionsToPropagate = f.transactionsToPropagate[len(transactionsToBroadcast):]
 	}
 
 	f.lastTransactionIDPropagationTime = time.Now()
 
 	return nil
 }
+
+// broadcastTransactionIDs broadcasts the IDs of the given transactions to all the
+// ready peers, except for the IDs of transactions of subnetworks that a peer doesn't
+// validate. The peers that validate all of them share a single Inv message
+func (f *FlowContext) broadcastTransactionIDs(transactions []*externalapi.DomainTransaction) error {
+	inv := appmessage.NewMsgInvTransaction(consensushashing.TransactionIDs(transactions))
+
+	var fullInvPeerConnections []*netadapter.NetConnection
+	for _, peer := range f.Peers() {
+		var peerTransactionIDs []*externalapi.DomainTransactionID
+		for i, transaction := range transactions {
+			if peer.ValidatesSubnetwork(transaction.SubnetworkID) {
+				peerTransactionIDs = append(peerTransactionIDs, inv.TxIDs[i])
+			}
+		}
+		if len(peerTransactionIDs) == len(transactions) {
+			fullInvPeerConnections = append(fullInvPeerConnections, peer.Connection())
+			continue
+		}
+		if len(peerTransactionIDs) == 0 {
+			continue
+		}
+		err := f.netAdapter.P2PBroadcast([]*netadapter.NetConnection{peer.Connection()},
+			appmessage.NewMsgInvTransaction(peerTransactionIDs))
+		if err != nil {
+			return err
+		}
+	}
+	return f.netAdapter.P2PBroadcast(fullInvPeerConnections, inv)
+}
@@ -93,6 +93,10 @@ func HandleHandshake(context HandleHandshakeContext, netConnection *netadapter.N
 		if err != nil {
 			return nil, err
 		}
+		err = context.AddressManager().UpdateServices(peerAddress, peer.Services())
+		if err != nil {
+			return nil, err
+		}
 	}
 	return peer, nil
 }
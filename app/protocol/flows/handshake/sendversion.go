@@ -73,6 +73,9 @@ func (flow *sendVersionFlow) start() error {
 	// Advertise if inv messages for transactions are desired.
 	msg.DisableRelayTx = flow.Config().BlocksOnly
 
+	// Advertise the subnetworks whose transactions are served
+	msg.SubnetworkRoles = flow.subnetworkRoles()
+
 	err := flow.outgoingRoute.Enqueue(msg)
 	if err != nil {
 		return err
@@ -87,3 +90,17 @@ func (flow *sendVersionFlow) start() error {
 	log.Debugf("Got verack")
 	return nil
 }
+
+// subnetworkRoles returns the subnetwork roles of the node, or nil
+// if it serves every subnetwork
+func (flow *sendVersionFlow) subnetworkRoles() *appmessage.SubnetworkRoles {
+	config := flow.Config()
+	if config.ValidatedSubnetworks == nil && config.IndexedSubnetworks == nil && config.RelayedSubnetworks == nil {
+		return nil
+	}
+	return &appmessage.SubnetworkRoles{
+		ValidatedSubnetworkIDs: config.ValidatedSubnetworks.SubnetworkIDs(),
+		IndexedSubnetworkIDs:   config.IndexedSubnetworks.SubnetworkIDs(),
+		RelayedSubnetworkIDs:   config.RelayedSubnetworks.SubnetworkIDs(),
+	}
+}
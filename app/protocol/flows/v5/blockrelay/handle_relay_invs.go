@@ -1,6 +1,8 @@
 package blockrelay
 
 import (
+	"fmt"
+
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/app/protocol/common"
 	"github.com/kaspanet/kaspad/app/protocol/flowcontext"
@@ -12,8 +14,10 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/hashset"
+	"github.com/kaspanet/kaspad/infrastructure/alerts"
 	"github.com/kaspanet/kaspad/infrastructure/config"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/infrastructure/tracing"
 	"github.com/pkg/errors"
 )
 
@@ -69,8 +73,15 @@ func HandleRelayInvs(context RelayInvsContext, incomingRoute *router.Route, outg
 	return err
 }
 
-func (flow *handleRelayInvsFlow) start() error {
+func (flow *handleRelayInvsFlow) start() (err error) {
+	// span traces the handling of the current inv, from its receipt through
+	// the validation of its block to its relay. It's ended when the next inv
+	// is read, or when the flow returns.
+	var span *tracing.Span
+	defer func() { span.EndWithError(err) }()
+
 	for {
+		span.End()
 		log.Debugf("Waiting for inv")
 		inv, err := flow.readInv()
 		if err != nil {
@@ -78,6 +89,9 @@ func (flow *handleRelayInvsFlow) start() error {
 		}
 
 		log.Debugf("Got relay inv for block %s", inv.Hash)
+		span = tracing.StartSpan("blockrelay.handleRelayInv",
+			tracing.Stringer("block.hash", inv.Hash), tracing.Stringer("peer", flow.peer),
+			tracing.Bool("block.isOrphanRoot", inv.IsOrphanRoot))
 
 		blockInfo, err := flow.Domain().Consensus().GetBlockInfo(inv.Hash)
 		if err != nil {
@@ -125,7 +139,9 @@ func (flow *handleRelayInvsFlow) start() error {
 		}
 
 		log.Debugf("Requesting block %s", inv.Hash)
+		requestSpan := span.StartChild("blockrelay.requestBlock")
 		block, exists, err := flow.requestBlock(inv.Hash)
+		requestSpan.EndWithError(err)
 		if err != nil {
 			return err
 		}
@@ -173,7 +189,10 @@ func (flow *handleRelayInvsFlow) start() error {
 		if err != nil {
 			return err
 		}
+		processSpan := span.StartChild("consensus.validateAndInsertBlock",
+			tracing.Int("block.transactionCount", int64(len(block.Transactions))))
 		missingParents, err := flow.processBlock(block)
+		processSpan.EndWithError(err)
 		if err != nil {
 			if errors.Is(err, ruleerrors.ErrPrunedBlock) {
 				log.Infof("Ignoring pruned block %s", inv.Hash)
@@ -188,6 +207,7 @@ func (flow *handleRelayInvsFlow) start() error {
 		}
 		if len(missingParents) > 0 {
 			log.Debugf("Block %s is orphan and has missing parents: %s", inv.Hash, missingParents)
+			span.SetAttributes(tracing.Bool("block.isOrphan", true))
 			err := flow.processOrphan(block)
 			if err != nil {
 				return err
@@ -221,7 +241,9 @@ func (flow *handleRelayInvsFlow) start() error {
 			}
 			blockHash := consensushashing.BlockHash(block)
 			log.Debugf("Relaying block %s", blockHash)
+			relaySpan := span.StartChild("blockrelay.relayBlock", tracing.Stringer("block.hash", blockHash))
 			err = flow.relayBlock(block)
+			relaySpan.EndWithError(err)
 			if err != nil {
 				return err
 			}
@@ -235,8 +257,10 @@ func (flow *handleRelayInvsFlow) start() error {
 			}
 		}
 
-		log.Infof("Accepted block %s via relay", inv.Hash)
+		log.WithField("block", inv.Hash).WithField("peer", flow.peer).Infof("Accepted block %s via relay", inv.Hash)
+		onNewBlockSpan := span.StartChild("flowcontext.onNewBlock")
 		err = flow.OnNewBlock(block)
+		onNewBlockSpan.EndWithError(err)
 		if err != nil {
 			return err
 		}
@@ -337,13 +361,40 @@ func (flow *handleRelayInvsFlow) processBlock(block *externalapi.DomainBlock) ([
 		}
 		// A duplicate block should not appear to the user as a warning and is already reported in the calling function
 		if !errors.Is(err, ruleerrors.ErrDuplicateBlock) {
-			log.Warnf("Rejected block %s from %s: %s", blockHash, flow.peer, err)
+			log.WithField("block", blockHash).WithField("peer", flow.peer).
+				Warnf("Rejected block %s from %s: %s", blockHash, flow.peer, err)
+			flow.alertIfWhitelisted(blockHash, err)
 		}
 		return nil, protocolerrors.Wrapf(true, err, "got invalid block %s from relay", blockHash)
 	}
 	return nil, nil
 }
 
+// alertIfWhitelisted sends an alert if the peer that relayed the invalid
+// block is whitelisted, since a trusted peer relaying invalid blocks may be
+// misconfigured or compromised
+func (flow *handleRelayInvsFlow) alertIfWhitelisted(blockHash *externalapi.DomainHash, err error) {
+	if !alerts.IsEnabled() {
+		return
+	}
+	ip := flow.peer.Connection().NetAddress().IP
+	for _, whitelist := range flow.Config().Whitelists {
+		if whitelist.Contains(ip) {
+			alerts.Send(&alerts.Event{
+				Kind:     alerts.KindInvalidBlockFromWhitelistedPeer,
+				Severity: alerts.SeverityWarning,
+				Message:  fmt.Sprintf("The whitelisted peer %s relayed the invalid block %s", flow.peer, blockHash),
+				Fields: map[string]string{
+					"peer":  flow.peer.Address(),
+					"block": blockHash.String(),
+					"error": err.Error(),
+				},
+			})
+			return
+		}
+	}
+}
+
 func (flow *handleRelayInvsFlow) relayBlock(block *externalapi.DomainBlock) error {
 	blockHash := consensushashing.BlockHash(block)
 	return flow.Broadcast(appmessage.NewMsgInvBlock(blockHash))
@@ -394,6 +394,14 @@ func (flow *handleIBDFlow) syncPruningPointUTXOSet(consensus externalapi.Consens
 		return false, protocolerrors.Errorf(true, "invalid pruning point %s", pruningPoint)
 	}
 
+	isImported, err := flow.importUTXOSetSnapshot(consensus, pruningPoint)
+	if err != nil {
+		return false, err
+	}
+	if isImported {
+		return true, nil
+	}
+
 	log.Info("Fetching the pruning point UTXO set")
 	isSuccessful, err := flow.fetchMissingUTXOSet(consensus, pruningPoint)
 	if err != nil {
@@ -410,6 +418,40 @@ func (flow *handleIBDFlow) syncPruningPointUTXOSet(consensus externalapi.Consens
 	return true, nil
 }
 
+// importUTXOSetSnapshot imports the pruning point UTXO set from the snapshot
+// that was imported with the importUTXOSet RPC, if it's of the given pruning
+// point. It returns false if there's no such snapshot or if it doesn't match
+// the pruning point's UTXO commitment, in which case the UTXO set should be
+// fetched from the peer instead.
+func (flow *handleIBDFlow) importUTXOSetSnapshot(consensus externalapi.Consensus,
+	pruningPointHash *externalapi.DomainHash) (bool, error) {
+
+	snapshot := flow.TakeImportedUTXOSet(pruningPointHash)
+	if snapshot == nil {
+		return false, nil
+	}
+	defer func() {
+		err := flow.Domain().StagingConsensus().ClearImportedPruningPointData()
+		if err != nil {
+			panic(fmt.Sprintf("failed to clear imported pruning point data: %s", err))
+		}
+	}()
+
+	log.Infof("Importing the pruning point UTXO set from %s", snapshot.FilePath)
+	err := snapshot.Import(consensus)
+	if err != nil {
+		log.Warnf("Could not import the pruning point UTXO set from %s: %s", snapshot.FilePath, err)
+		return false, nil
+	}
+	err = flow.Domain().StagingConsensus().ValidateAndInsertImportedPruningPoint(pruningPointHash)
+	if err != nil {
+		log.Warnf("The pruning point UTXO set in %s is invalid: %s", snapshot.FilePath, err)
+		return false, nil
+	}
+	log.Infof("Imported the pruning point UTXO set from %s", snapshot.FilePath)
+	return true, nil
+}
+
 func (flow *handleIBDFlow) fetchMissingUTXOSet(consensus externalapi.Consensus, pruningPointHash *externalapi.DomainHash) (succeed bool, err error) {
 	defer func() {
 		err := flow.Domain().StagingConsensus().ClearImportedPruningPointData()
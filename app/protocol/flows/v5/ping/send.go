@@ -39,14 +39,25 @@ func SendPings(context SendPingsContext, incomingRoute *router.Route, outgoingRo
 
 func (flow *sendPingsFlow) start() error {
 	const pingInterval = 2 * time.Minute
-	ticker := time.NewTicker(pingInterval)
-	defer ticker.Stop()
 
 	for {
+		// Waiting on the incoming route, rather than on a ticker, ends the
+		// flow as soon as the connection is closed. Otherwise the peer would
+		// linger in the peers list until the next ping.
+		message, err := flow.incomingRoute.DequeueWithTimeout(pingInterval)
+		if err == nil {
+			return protocolerrors.Errorf(true, "unexpected %s before a ping was sent", message.Command())
+		}
+		if errors.Is(err, router.ErrRouteClosed) {
+			return nil
+		}
+		if !errors.Is(err, router.ErrTimeout) {
+			return err
+		}
 		select {
 		case <-flow.ShutdownChan():
 			return nil
-		case <-ticker.C:
+		default:
 		}
 
 		nonce, err := random.Uint64()
@@ -61,7 +72,7 @@ func (flow *sendPingsFlow) start() error {
 			return err
 		}
 
-		message, err := flow.incomingRoute.DequeueWithTimeout(common.DefaultTimeout)
+		message, err = flow.incomingRoute.DequeueWithTimeout(common.DefaultTimeout)
 		if err != nil {
 			if errors.Is(err, router.ErrTimeout) {
 				return errors.Wrapf(flowcontext.ErrPingTimeout, err.Error())
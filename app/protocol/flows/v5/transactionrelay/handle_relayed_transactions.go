@@ -11,6 +11,7 @@ import (
 	"github.com/kaspanet/kaspad/domain/miningmanager/mempool"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/infrastructure/tracing"
 	"github.com/pkg/errors"
 )
 
@@ -20,8 +21,8 @@ type TransactionsRelayContext interface {
 	NetAdapter() *netadapter.NetAdapter
 	Domain() domain.Domain
 	SharedRequestedTransactions() *flowcontext.SharedRequestedTransactions
-	OnTransactionAddedToMempool()
-	EnqueueTransactionIDsForPropagation(transactionIDs []*externalapi.DomainTransactionID) error
+	OnTransactionAddedToMempool(transactions []*externalapi.DomainTransaction) error
+	EnqueueTransactionsForPropagation(transactions []*externalapi.DomainTransaction) error
 	IsNearlySynced() (bool, error)
 }
 
@@ -129,8 +130,8 @@ func (flow *handleRelayedTransactionsFlow) readInv() (*appmessage.MsgInvTransact
 	return inv, nil
 }
 
-func (flow *handleRelayedTransactionsFlow) broadcastAcceptedTransactions(acceptedTxIDs []*externalapi.DomainTransactionID) error {
-	return flow.EnqueueTransactionIDsForPropagation(acceptedTxIDs)
+func (flow *handleRelayedTransactionsFlow) broadcastAcceptedTransactions(acceptedTxs []*externalapi.DomainTransaction) error {
+	return flow.EnqueueTransactionsForPropagation(acceptedTxs)
 }
 
 // readMsgTxOrNotFound returns the next msgTx or msgTransactionNotFound in incomingRoute,
@@ -183,32 +184,54 @@ func (flow *handleRelayedTransactionsFlow) receiveTransactions(requestedTransact
 				expectedID, txID)
 		}
 
-		acceptedTransactions, err :=
-			flow.Domain().MiningManager().ValidateAndInsertTransaction(tx, false, true)
+		span := tracing.StartSpan("transactionrelay.handleRelayedTransaction",
+			tracing.Stringer("transaction.id", txID))
+		err = flow.processTransaction(span, tx, txID)
+		span.EndWithError(err)
 		if err != nil {
-			ruleErr := &mempool.RuleError{}
-			if !errors.As(err, ruleErr) {
-				return errors.Wrapf(err, "failed to process transaction %s", txID)
-			}
+			return err
+		}
+	}
+	return nil
+}
 
-			shouldBan := false
-			if txRuleErr := (&mempool.TxRuleError{}); errors.As(ruleErr.Err, txRuleErr) {
-				if txRuleErr.RejectCode == mempool.RejectInvalid {
-					shouldBan = true
-				}
-			}
+// processTransaction adds tx to the mempool and propagates it, along with
+// the orphans it made acceptable. Transactions that the mempool rejects are
+// only recorded on span, unless they're invalid.
+func (flow *handleRelayedTransactionsFlow) processTransaction(span *tracing.Span,
+	tx *externalapi.DomainTransaction, txID *externalapi.DomainTransactionID) error {
 
-			if !shouldBan {
-				continue
-			}
+	validateSpan := span.StartChild("mempool.validateAndInsertTransaction")
+	acceptedTransactions, err :=
+		flow.Domain().MiningManager().ValidateAndInsertTransaction(tx, false, true)
+	validateSpan.EndWithError(err)
+	if err != nil {
+		ruleErr := &mempool.RuleError{}
+		if !errors.As(err, ruleErr) {
+			return errors.Wrapf(err, "failed to process transaction %s", txID)
+		}
 
-			return protocolerrors.Errorf(true, "rejected transaction %s: %s", txID, ruleErr)
+		shouldBan := false
+		if txRuleErr := (&mempool.TxRuleError{}); errors.As(ruleErr.Err, txRuleErr) {
+			if txRuleErr.RejectCode == mempool.RejectInvalid {
+				shouldBan = true
+			}
 		}
-		err = flow.broadcastAcceptedTransactions(consensushashing.TransactionIDs(acceptedTransactions))
-		if err != nil {
-			return err
+
+		if !shouldBan {
+			span.RecordError(ruleErr)
+			return nil
 		}
-		flow.OnTransactionAddedToMempool()
+
+		return protocolerrors.Errorf(true, "rejected transaction %s: %s", txID, ruleErr)
 	}
-	return nil
+	span.SetAttributes(tracing.Int("transaction.acceptedCount", int64(len(acceptedTransactions))))
+
+	propagateSpan := span.StartChild("transactionrelay.propagateTransactions")
+	err = flow.broadcastAcceptedTransactions(acceptedTransactions)
+	propagateSpan.EndWithError(err)
+	if err != nil {
+		return err
+	}
+	return flow.OnTransactionAddedToMempool(acceptedTransactions)
 }
@@ -40,11 +40,12 @@ func (m *mocTransactionsRelayContext) SharedRequestedTransactions() *flowcontext
 	return m.sharedRequestedTransactions
 }
 
-func (m *mocTransactionsRelayContext) EnqueueTransactionIDsForPropagation(transactionIDs []*externalapi.DomainTransactionID) error {
+func (m *mocTransactionsRelayContext) EnqueueTransactionsForPropagation(transactions []*externalapi.DomainTransaction) error {
 	return nil
 }
 
-func (m *mocTransactionsRelayContext) OnTransactionAddedToMempool() {
+func (m *mocTransactionsRelayContext) OnTransactionAddedToMempool(_ []*externalapi.DomainTransaction) error {
+	return nil
 }
 
 func (m *mocTransactionsRelayContext) IsNearlySynced() (bool, error) {
@@ -12,6 +12,7 @@ import (
 	"github.com/kaspanet/kaspad/domain"
 
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/utxosnapshot"
 
 	"github.com/kaspanet/kaspad/app/protocol/flowcontext"
 	peerpkg "github.com/kaspanet/kaspad/app/protocol/peer"
@@ -106,6 +107,12 @@ func (m *Manager) SetOnTransactionAddedToMempoolHandler(onTransactionAddedToMemp
 	m.context.SetOnTransactionAddedToMempoolHandler(onTransactionAddedToMempoolHandler)
 }
 
+// SetImportedUTXOSet sets the UTXO set snapshot that the next IBD from a
+// syncer with the snapshot's pruning point uses
+func (m *Manager) SetImportedUTXOSet(snapshot *utxosnapshot.Snapshot) {
+	m.context.SetImportedUTXOSet(snapshot)
+}
+
 // IsIBDRunning returns true if IBD is currently marked as running
 func (m *Manager) IsIBDRunning() bool {
 	return m.context.IsIBDRunning()
@@ -0,0 +1,7 @@
+package networktime
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+var log = logger.RegisterSubSystem("PROT")
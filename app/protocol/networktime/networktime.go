@@ -0,0 +1,146 @@
+// Package networktime compares the local clock with the clocks of the peers,
+// as they report them in their version messages, and warns when the local
+// clock drifts from theirs by more than block timestamps may deviate.
+//
+// kaspad never adjusts its clock to the peers' time, since that would let
+// the peers shift it. A drifting clock only makes kaspad reject valid blocks
+// as being in the future, or have the blocks it mines rejected, so the
+// operator is warned to fix it.
+package networktime
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/alerts"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/id"
+)
+
+// minSamples is how many peers must have reported their time before the
+// local clock is considered drifting, so that a few peers with wrong clocks
+// can't cause a warning
+const minSamples = 5
+
+// Checker keeps the offsets of the local clock from the clocks of the
+// connected peers, and logs a warning when their median exceeds the
+// tolerance
+type Checker struct {
+	tolerance time.Duration
+
+	lock       sync.Mutex
+	offsets    map[id.ID]time.Duration
+	isDrifting bool
+}
+
+// New returns a Checker that warns when the local clock drifts from the
+// peers' clocks by more than tolerance
+func New(tolerance time.Duration) *Checker {
+	return &Checker{
+		tolerance: tolerance,
+		offsets:   make(map[id.ID]time.Duration),
+	}
+}
+
+// AddSample records the offset of the local clock from the clock of the
+// given peer, which is positive if the local clock is ahead
+func (c *Checker) AddSample(peerID *id.ID, offset time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.offsets[*peerID] = offset
+	c.check()
+}
+
+// RemoveSample removes the offset of the given peer, once it disconnects
+func (c *Checker) RemoveSample(peerID *id.ID) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.offsets, *peerID)
+	c.check()
+}
+
+// MedianOffset returns the median of the offsets of the local clock from
+// the clocks of the peers, and the number of peers it's the median of
+func (c *Checker) MedianOffset() (offset time.Duration, sampleCount int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.medianOffset(), len(c.offsets)
+}
+
+// IsDrifting returns whether the local clock drifts from the clocks of the
+// peers by more than the tolerance
+func (c *Checker) IsDrifting() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.isDrifting
+}
+
+func (c *Checker) medianOffset() time.Duration {
+	if len(c.offsets) == 0 {
+		return 0
+	}
+	offsets := make([]time.Duration, 0, len(c.offsets))
+	for _, offset := range c.offsets {
+		offsets = append(offsets, offset)
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+
+	middle := len(offsets) / 2
+	if len(offsets)%2 == 0 {
+		return (offsets[middle-1] + offsets[middle]) / 2
+	}
+	return offsets[middle]
+}
+
+// check logs a warning when the local clock starts drifting, and a notice
+// once it stops. While fewer than minSamples peers are connected, the last
+// state is kept.
+func (c *Checker) check() {
+	if len(c.offsets) < minSamples {
+		return
+	}
+	medianOffset := c.medianOffset()
+	isDrifting := medianOffset > c.tolerance || medianOffset < -c.tolerance
+	if isDrifting == c.isDrifting {
+		return
+	}
+	c.isDrifting = isDrifting
+
+	fields := map[string]string{
+		"medianOffset": medianOffset.Round(time.Millisecond).String(),
+		"tolerance":    c.tolerance.String(),
+		"peers":        fmt.Sprint(len(c.offsets)),
+	}
+	if !isDrifting {
+		log.Infof("The local clock is back within %s of the median time of %d peers", c.tolerance, len(c.offsets))
+		alerts.Send(&alerts.Event{
+			Kind:     alerts.KindClockDrift,
+			Severity: alerts.SeverityResolved,
+			Message:  fmt.Sprintf("The local clock is back within %s of the peers' median time", c.tolerance),
+			Fields:   fields,
+		})
+		return
+	}
+	direction := "ahead of"
+	drift := medianOffset
+	if medianOffset < 0 {
+		direction = "behind"
+		drift = -medianOffset
+	}
+	log.Warnf("The local clock is %s %s the median time of %d peers, more than the %s that block "+
+		"timestamps may deviate. Valid blocks may be rejected, or mined blocks may be rejected by "+
+		"the network, until the clock is corrected", drift.Round(time.Millisecond), direction,
+		len(c.offsets), c.tolerance)
+	alerts.Send(&alerts.Event{
+		Kind:     alerts.KindClockDrift,
+		Severity: alerts.SeverityWarning,
+		Message: fmt.Sprintf("The local clock is %s %s the peers' median time, more than the tolerated %s",
+			drift.Round(time.Millisecond), direction, c.tolerance),
+		Fields: fields,
+	})
+}
@@ -0,0 +1,95 @@
+package networktime
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/id"
+)
+
+func generateIDs(t *testing.T, count int) []*id.ID {
+	ids := make([]*id.ID, count)
+	for i := range ids {
+		var err error
+		ids[i], err = id.GenerateID()
+		if err != nil {
+			t.Fatalf("GenerateID: %s", err)
+		}
+	}
+	return ids
+}
+
+func TestMedianOffset(t *testing.T) {
+	tests := []struct {
+		name     string
+		offsets  []time.Duration
+		expected time.Duration
+	}{
+		{name: "no peers", offsets: nil, expected: 0},
+		{name: "odd count", offsets: []time.Duration{3 * time.Second, -time.Second, time.Second}, expected: time.Second},
+		{name: "even count", offsets: []time.Duration{4 * time.Second, -time.Second, 2 * time.Second, 10 * time.Second},
+			expected: 3 * time.Second},
+	}
+	for _, test := range tests {
+		checker := New(time.Minute)
+		for i, peerID := range generateIDs(t, len(test.offsets)) {
+			checker.AddSample(peerID, test.offsets[i])
+		}
+		offset, sampleCount := checker.MedianOffset()
+		if offset != test.expected {
+			t.Errorf("%s: expected a median offset of %s, but got %s", test.name, test.expected, offset)
+		}
+		if sampleCount != len(test.offsets) {
+			t.Errorf("%s: expected %d samples, but got %d", test.name, len(test.offsets), sampleCount)
+		}
+	}
+}
+
+func TestIsDrifting(t *testing.T) {
+	checker := New(time.Minute)
+	peerIDs := generateIDs(t, minSamples+1)
+
+	// Fewer than minSamples peers never make the clock drift
+	for _, peerID := range peerIDs[:minSamples-1] {
+		checker.AddSample(peerID, 2*time.Minute)
+	}
+	if checker.IsDrifting() {
+		t.Fatalf("Expected the clock not to drift with fewer than %d samples", minSamples)
+	}
+
+	checker.AddSample(peerIDs[minSamples-1], -time.Hour)
+	if !checker.IsDrifting() {
+		t.Fatalf("Expected the clock to drift once the median offset is beyond the tolerance")
+	}
+
+	// A peer whose clock is in line with the local one isn't enough to
+	// bring the median back within the tolerance
+	checker.AddSample(peerIDs[minSamples], 0)
+	if !checker.IsDrifting() {
+		t.Fatalf("Expected the clock to still drift")
+	}
+
+	// Once the peers' clocks agree with the local one, it stops drifting
+	for _, peerID := range peerIDs[:3] {
+		checker.AddSample(peerID, time.Second)
+	}
+	if checker.IsDrifting() {
+		t.Fatalf("Expected the clock to stop drifting once the median offset is within the tolerance")
+	}
+
+	// A new sample of a peer replaces its previous one
+	for _, peerID := range peerIDs[:3] {
+		checker.AddSample(peerID, 2*time.Minute)
+	}
+	if !checker.IsDrifting() {
+		t.Fatalf("Expected the clock to drift again once the peers disagree")
+	}
+
+	// With fewer than minSamples peers, the last state is kept
+	for _, peerID := range peerIDs[:3] {
+		checker.RemoveSample(peerID)
+	}
+	if !checker.IsDrifting() {
+		t.Fatalf("Expected the clock to still drift with fewer than %d samples", minSamples)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
 
 	"github.com/kaspanet/kaspad/app/appmessage"
@@ -23,6 +24,8 @@ type Peer struct {
 	protocolVersion          uint32 // negotiated protocol version
 	disableRelayTx           bool
 	subnetworkID             *externalapi.DomainSubnetworkID
+	subnetworkRoles          *appmessage.SubnetworkRoles
+	validatedSubnetworks     subnetworks.Filter
 
 	timeOffset        time.Duration
 	connectionStarted time.Time
@@ -55,6 +58,18 @@ func (p *Peer) SubnetworkID() *externalapi.DomainSubnetworkID {
 	return p.subnetworkID
 }
 
+// SubnetworkRoles returns the subnetworks whose transactions the peer
+// validates, indexes and relays. It is nil in peers that serve every subnetwork.
+func (p *Peer) SubnetworkRoles() *appmessage.SubnetworkRoles {
+	return p.subnetworkRoles
+}
+
+// ValidatesSubnetwork returns whether the peer validates the transactions of
+// the given subnetwork, and so may accept them to its mempool
+func (p *Peer) ValidatesSubnetwork(subnetworkID externalapi.DomainSubnetworkID) bool {
+	return p.validatedSubnetworks.Passes(subnetworkID)
+}
+
 // ID returns the peer ID.
 func (p *Peer) ID() *id.ID {
 	return p.connection.ID()
@@ -80,6 +95,16 @@ func (p *Peer) ProtocolVersion() uint32 {
 	return p.protocolVersion
 }
 
+// Services returns the services the peer advertised.
+func (p *Peer) Services() appmessage.ServiceFlag {
+	return p.services
+}
+
+// IsRelayingTransactions returns whether the peer asked to be relayed transactions.
+func (p *Peer) IsRelayingTransactions() bool {
+	return !p.disableRelayTx
+}
+
 // TimeConnected returns the time since the connection to this been has been started.
 func (p *Peer) TimeConnected() time.Duration {
 	return time.Since(p.connectionStarted)
@@ -107,6 +132,10 @@ func (p *Peer) UpdateFieldsFromMsgVersion(msg *appmessage.MsgVersion, maxProtoco
 
 	p.disableRelayTx = msg.DisableRelayTx
 	p.subnetworkID = msg.SubnetworkID
+	p.subnetworkRoles = msg.SubnetworkRoles
+	if msg.SubnetworkRoles != nil {
+		p.validatedSubnetworks = subnetworks.NewFilter(msg.SubnetworkRoles.ValidatedSubnetworkIDs)
+	}
 
 	p.timeOffset = mstime.Since(msg.Timestamp)
 }
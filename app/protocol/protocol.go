@@ -107,7 +107,7 @@ func (m *Manager) routerInitializer(router *routerpkg.Router, netConnection *net
 func (m *Manager) handleError(err error, netConnection *netadapter.NetConnection, outgoingRoute *routerpkg.Route) {
 	if protocolErr := (protocolerrors.ProtocolError{}); errors.As(err, &protocolErr) {
 		if m.context.Config().EnableBanning && protocolErr.ShouldBan {
-			log.Warnf("Banning %s (reason: %s)", netConnection, protocolErr.Cause)
+			log.WithField("peer", netConnection).Warnf("Banning %s (reason: %s)", netConnection, protocolErr.Cause)
 
 			err := m.context.ConnectionManager().Ban(netConnection)
 			if err != nil && !errors.Is(err, connmanager.ErrCannotBanPermanent) {
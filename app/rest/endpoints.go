@@ -0,0 +1,367 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionid"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultHeadersCount = 100
+	maxHeadersCount     = 1000
+)
+
+// handleBlock serves GET /block/<hash>
+func handleBlock(s *Server, _ *http.Request, resource string, format responseFormat) (*response, error) {
+	hash, err := externalapi.NewDomainHashFromString(resource)
+	if err != nil {
+		return nil, newHTTPError(http.StatusBadRequest, "Hash could not be parsed: %s", err)
+	}
+	block, err := s.getBlock(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	// The body of a header-only block may still arrive later
+	cacheControl := immutableCacheControl
+	if block.VerboseData.IsHeaderOnly {
+		cacheControl = noCacheControl
+	}
+
+	var body []byte
+	if format == formatBinary {
+		domainBlock, err := appmessage.RPCBlockToDomainBlock(block)
+		if err != nil {
+			return nil, err
+		}
+		body, err = marshalBinary(appmessage.DomainBlockToMsgBlock(domainBlock),
+			func(message *protowire.KaspadMessage) proto.Message { return message.GetBlock() })
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		body, err = marshalJSON(&appmessage.GetBlockResponseMessage{Block: block},
+			func(message *protowire.KaspadMessage) proto.Message { return message.GetGetBlockResponse().Block })
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &response{
+		body:         body,
+		format:       format,
+		cacheControl: cacheControl,
+		etag:         hash.String() + "." + string(format),
+	}, nil
+}
+
+// handleTransaction serves GET /tx/<id>. Transactions are looked up in the
+// mempool, unless the block that contains them is given with ?block=<hash>
+func handleTransaction(s *Server, request *http.Request, resource string, format responseFormat) (*response, error) {
+	transactionID, err := transactionid.FromString(resource)
+	if err != nil {
+		return nil, newHTTPError(http.StatusBadRequest, "Transaction ID could not be parsed: %s", err)
+	}
+
+	var transaction *appmessage.RPCTransaction
+	cacheControl := noCacheControl
+	etag := ""
+	blockHashString := request.URL.Query().Get("block")
+	if blockHashString != "" {
+		blockHash, err := externalapi.NewDomainHashFromString(blockHashString)
+		if err != nil {
+			return nil, newHTTPError(http.StatusBadRequest, "Block hash could not be parsed: %s", err)
+		}
+		block, err := s.getBlock(blockHash)
+		if err != nil {
+			return nil, err
+		}
+		for _, blockTransaction := range block.Transactions {
+			if blockTransaction.VerboseData.TransactionID == transactionID.String() {
+				transaction = blockTransaction
+				break
+			}
+		}
+		if transaction == nil {
+			return nil, newHTTPError(http.StatusNotFound, "Transaction %s was not found in block %s",
+				transactionID, blockHash)
+		}
+		cacheControl = immutableCacheControl
+		etag = blockHash.String() + "-" + transactionID.String() + "." + string(format)
+	} else {
+		getMempoolEntryResponse, err := s.rpcManager.HandleRequest(
+			appmessage.NewGetMempoolEntryRequestMessage(transactionID.String(), false, false))
+		if err != nil {
+			return nil, err
+		}
+		mempoolEntry := getMempoolEntryResponse.(*appmessage.GetMempoolEntryResponseMessage)
+		if mempoolEntry.Error != nil {
+			return nil, newHTTPError(http.StatusNotFound, "%s", mempoolEntry.Error.Message)
+		}
+		transaction = mempoolEntry.Entry.Transaction
+	}
+
+	var body []byte
+	if format == formatBinary {
+		domainTransaction, err := appmessage.RPCTransactionToDomainTransaction(transaction)
+		if err != nil {
+			return nil, err
+		}
+		body, err = marshalBinary(appmessage.DomainTransactionToMsgTx(domainTransaction),
+			func(message *protowire.KaspadMessage) proto.Message { return message.GetTransaction() })
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		body, err = marshalJSON(&appmessage.GetMempoolEntryResponseMessage{Entry: &appmessage.MempoolEntry{Transaction: transaction}},
+			func(message *protowire.KaspadMessage) proto.Message {
+				return message.GetGetMempoolEntryResponse().Entry.Transaction
+			})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &response{
+		body:         body,
+		format:       format,
+		cacheControl: cacheControl,
+		etag:         etag,
+	}, nil
+}
+
+// handleUTXOs serves GET /utxos/<address>. It requires the UTXO index
+func handleUTXOs(s *Server, _ *http.Request, resource string, format responseFormat) (*response, error) {
+	if format != formatJSON {
+		return nil, newHTTPError(http.StatusBadRequest, "UTXOs are only available as JSON")
+	}
+	_, err := util.DecodeAddress(resource, s.cfg.ActiveNetParams.Prefix)
+	if err != nil {
+		return nil, newHTTPError(http.StatusBadRequest, "Could not decode address %s: %s", resource, err)
+	}
+
+	getUTXOsByAddressesResponse, err := s.rpcManager.HandleRequest(
+		appmessage.NewGetUTXOsByAddressesRequestMessage([]string{resource}))
+	if err != nil {
+		return nil, err
+	}
+	utxos := getUTXOsByAddressesResponse.(*appmessage.GetUTXOsByAddressesResponseMessage)
+	if utxos.Error != nil {
+		return nil, newHTTPError(http.StatusServiceUnavailable, "%s", utxos.Error.Message)
+	}
+
+	body, err := marshalJSON(utxos, func(message *protowire.KaspadMessage) proto.Message {
+		return message.GetGetUtxosByAddressesResponse()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &response{
+		body:         body,
+		format:       format,
+		cacheControl: noCacheControl,
+	}, nil
+}
+
+// handleTip serves GET /tip. The binary representation is the raw selected tip hash
+func handleTip(s *Server, _ *http.Request, resource string, format responseFormat) (*response, error) {
+	if resource != "" {
+		return nil, newHTTPError(http.StatusNotFound, "Unknown resource %s", resource)
+	}
+
+	getSelectedTipHashResponse, err := s.rpcManager.HandleRequest(appmessage.NewGetSelectedTipHashRequestMessage())
+	if err != nil {
+		return nil, err
+	}
+	selectedTipHashResponse := getSelectedTipHashResponse.(*appmessage.GetSelectedTipHashResponseMessage)
+	if selectedTipHashResponse.Error != nil {
+		return nil, errors.New(selectedTipHashResponse.Error.Message)
+	}
+
+	var body []byte
+	if format == formatBinary {
+		selectedTipHash, err := externalapi.NewDomainHashFromString(selectedTipHashResponse.SelectedTipHash)
+		if err != nil {
+			return nil, err
+		}
+		body = selectedTipHash.ByteSlice()
+	} else {
+		body, err = marshalJSON(selectedTipHashResponse, func(message *protowire.KaspadMessage) proto.Message {
+			return message.GetGetSelectedTipHashResponse()
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &response{
+		body:         body,
+		format:       format,
+		cacheControl: noCacheControl,
+		etag:         selectedTipHashResponse.SelectedTipHash + "." + string(format),
+	}, nil
+}
+
+// handleHeaders serves GET /headers?start=<hash>[&count=<n>]. It returns up to
+// count headers, starting with the header of start and continuing towards
+// the virtual selected parent
+func handleHeaders(s *Server, request *http.Request, resource string, format responseFormat) (*response, error) {
+	if resource != "" {
+		return nil, newHTTPError(http.StatusNotFound, "Unknown resource %s", resource)
+	}
+
+	query := request.URL.Query()
+	start, err := externalapi.NewDomainHashFromString(query.Get("start"))
+	if err != nil {
+		return nil, newHTTPError(http.StatusBadRequest, "Start hash could not be parsed: %s", err)
+	}
+	count := defaultHeadersCount
+	if countString := query.Get("count"); countString != "" {
+		count, err = strconv.Atoi(countString)
+		if err != nil || count <= 0 || count > maxHeadersCount {
+			return nil, newHTTPError(http.StatusBadRequest,
+				"Count must be a number between 1 and %d", maxHeadersCount)
+		}
+	}
+
+	getBlocksRequest := appmessage.NewGetBlocksRequestMessage(start.String(), true, false)
+	getBlocksRequest.Limit = uint32(count)
+	getBlocksResponse, err := s.rpcManager.HandleRequest(getBlocksRequest)
+	if err != nil {
+		return nil, err
+	}
+	blocks := getBlocksResponse.(*appmessage.GetBlocksResponseMessage)
+	if blocks.Error != nil {
+		return nil, newHTTPError(http.StatusNotFound, "%s", blocks.Error.Message)
+	}
+
+	var body []byte
+	if format == formatBinary {
+		headers := make([]*appmessage.MsgBlockHeader, len(blocks.Blocks))
+		for i, block := range blocks.Blocks {
+			domainBlock, err := appmessage.RPCBlockToDomainBlock(block)
+			if err != nil {
+				return nil, err
+			}
+			headers[i] = appmessage.DomainBlockHeaderToBlockHeader(domainBlock.Header)
+		}
+		body, err = marshalBinary(appmessage.NewBlockHeadersMessage(headers),
+			func(message *protowire.KaspadMessage) proto.Message { return message.GetBlockHeaders() })
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		body, err = marshalJSON(blocks, func(message *protowire.KaspadMessage) proto.Message {
+			return message.GetGetBlocksResponse()
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &response{
+		body:         body,
+		format:       format,
+		cacheControl: noCacheControl,
+	}, nil
+}
+
+// handleHealth serves GET /health, which responds with 200 while the node is
+// alive and its database is responsive, and with 503 otherwise
+func handleHealth(s *Server, _ *http.Request, resource string, format responseFormat) (*response, error) {
+	return s.healthCheck(resource, format, func(healthCheck *appmessage.HealthCheckResponseMessage) bool {
+		return healthCheck.IsHealthy
+	})
+}
+
+// handleReady serves GET /ready, which responds with 200 while the node is
+// healthy, synced and connected to enough peers, and with 503 otherwise
+func handleReady(s *Server, _ *http.Request, resource string, format responseFormat) (*response, error) {
+	return s.healthCheck(resource, format, func(healthCheck *appmessage.HealthCheckResponseMessage) bool {
+		return healthCheck.IsReady
+	})
+}
+
+// healthCheck responds with the result of the healthCheck RPC, with a status
+// code that's selected by isPassing
+func (s *Server) healthCheck(resource string, format responseFormat,
+	isPassing func(*appmessage.HealthCheckResponseMessage) bool) (*response, error) {
+
+	if resource != "" {
+		return nil, newHTTPError(http.StatusNotFound, "Unknown resource %s", resource)
+	}
+	if format != formatJSON {
+		return nil, newHTTPError(http.StatusBadRequest, "Health checks are only available as JSON")
+	}
+
+	healthCheckResponse, err := s.rpcManager.HandleRequest(appmessage.NewHealthCheckRequestMessage())
+	if err != nil {
+		return nil, err
+	}
+	healthCheck := healthCheckResponse.(*appmessage.HealthCheckResponseMessage)
+	if healthCheck.Error != nil {
+		return nil, errors.New(healthCheck.Error.Message)
+	}
+
+	body, err := marshalJSON(healthCheck, func(message *protowire.KaspadMessage) proto.Message {
+		return message.GetHealthCheckResponse()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	statusCode := http.StatusOK
+	if !isPassing(healthCheck) {
+		statusCode = http.StatusServiceUnavailable
+	}
+	return &response{
+		body:         body,
+		format:       format,
+		cacheControl: noCacheControl,
+		statusCode:   statusCode,
+	}, nil
+}
+
+// getBlock returns the block with the given hash, including its transactions
+func (s *Server) getBlock(hash *externalapi.DomainHash) (*appmessage.RPCBlock, error) {
+	getBlockResponse, err := s.rpcManager.HandleRequest(appmessage.NewGetBlockRequestMessage(hash.String(), true))
+	if err != nil {
+		return nil, err
+	}
+	blockResponse := getBlockResponse.(*appmessage.GetBlockResponseMessage)
+	if blockResponse.Error != nil {
+		return nil, newHTTPError(http.StatusNotFound, "%s", blockResponse.Error.Message)
+	}
+	return blockResponse.Block, nil
+}
+
+// marshalJSON converts the given message to its protowire representation, and
+// marshals the part of it that's selected by selectPart into JSON, with the
+// same field names as in the RPC API
+func marshalJSON(message appmessage.Message, selectPart func(*protowire.KaspadMessage) proto.Message) ([]byte, error) {
+	kaspadMessage, err := protowire.FromAppMessage(message)
+	if err != nil {
+		return nil, err
+	}
+	return protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(selectPart(kaspadMessage))
+}
+
+// marshalBinary converts the given message to its protowire representation, and
+// marshals the part of it that's selected by selectPart into the protobuf
+// encoding used by the P2P protocol
+func marshalBinary(message appmessage.Message, selectPart func(*protowire.KaspadMessage) proto.Message) ([]byte, error) {
+	kaspadMessage, err := protowire.FromAppMessage(message)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(selectPart(kaspadMessage))
+}
@@ -0,0 +1,9 @@
+package rest
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/util/panics"
+)
+
+var log = logger.RegisterSubSystem("REST")
+var spawn = panics.GoroutineWrapperFunc(log)
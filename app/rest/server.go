@@ -0,0 +1,318 @@
+package rest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/rpc"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/pkg/errors"
+)
+
+const (
+	readHeaderTimeout = 10 * time.Second
+	writeTimeout      = 30 * time.Second
+
+	// immutableCacheControl is used for responses that can never change,
+	// such as a block that's requested by its hash
+	immutableCacheControl = "public, max-age=31536000, immutable"
+
+	// noCacheControl is used for responses that may change at any moment,
+	// such as the selected tip. Clients may still revalidate them using
+	// their ETag, if they have one
+	noCacheControl = "no-cache"
+
+	// minGzipBodySize is the smallest body that is gzipped for clients that
+	// accept it. Smaller bodies barely shrink
+	minGzipBodySize = 1024
+)
+
+// Server is a read-only REST server that exposes blocks, transactions,
+// UTXOs and headers over plain HTTP, so that simple integrations don't need
+// RPC credentials or a persistent connection
+type Server struct {
+	cfg        *config.Config
+	rpcManager *rpc.Manager
+
+	httpServers []*http.Server
+	isStopped   uint32
+}
+
+// New creates a new REST server. Start must be called for it to start
+// serving requests.
+func New(cfg *config.Config, rpcManager *rpc.Manager) *Server {
+	return &Server{
+		cfg:        cfg,
+		rpcManager: rpcManager,
+	}
+}
+
+// Start starts listening on all the configured REST listeners
+func (s *Server) Start() error {
+	for _, listenAddress := range s.cfg.RESTListeners {
+		listener, err := net.Listen("tcp", listenAddress)
+		if err != nil {
+			return errors.Wrapf(err, "error listening for REST requests on %s", listenAddress)
+		}
+		httpServer := &http.Server{
+			Handler:           s,
+			ReadHeaderTimeout: readHeaderTimeout,
+			WriteTimeout:      writeTimeout,
+		}
+		s.httpServers = append(s.httpServers, httpServer)
+		log.Infof("REST server listening on %s", listener.Addr())
+
+		spawn("rest.Server.serve", func() {
+			err := httpServer.Serve(listener)
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Warnf("Error serving REST requests on %s: %s", listener.Addr(), err)
+			}
+		})
+	}
+	return nil
+}
+
+// Stop closes all listeners and active connections
+func (s *Server) Stop() {
+	if !atomic.CompareAndSwapUint32(&s.isStopped, 0, 1) {
+		return
+	}
+
+	for _, httpServer := range s.httpServers {
+		err := httpServer.Close()
+		if err != nil {
+			log.Warnf("Error closing REST server: %s", err)
+		}
+	}
+}
+
+// responseFormat is the representation of a response, as selected by
+// the extension of the requested path
+type responseFormat string
+
+const (
+	formatJSON   responseFormat = "json"
+	formatBinary responseFormat = "bin"
+)
+
+// response is a successful REST response
+type response struct {
+	body         []byte
+	format       responseFormat
+	cacheControl string
+
+	// etag is optional. If it's set, the response is only sent to clients
+	// that don't already have it
+	etag string
+
+	// statusCode is optional, and defaults to http.StatusOK
+	statusCode int
+}
+
+// httpError is an error that is reported to the client with the given status code
+type httpError struct {
+	statusCode int
+	message    string
+}
+
+func (e *httpError) Error() string {
+	return e.message
+}
+
+func newHTTPError(statusCode int, format string, args ...interface{}) *httpError {
+	return &httpError{
+		statusCode: statusCode,
+		message:    errors.Errorf(format, args...).Error(),
+	}
+}
+
+type endpointHandler func(s *Server, request *http.Request, resource string, format responseFormat) (*response, error)
+
+var endpoints = map[string]endpointHandler{
+	"block":   handleBlock,
+	"tx":      handleTransaction,
+	"utxos":   handleUTXOs,
+	"tip":     handleTip,
+	"headers": handleHeaders,
+	"health":  handleHealth,
+	"ready":   handleReady,
+}
+
+// ServeHTTP routes requests of the form /<endpoint>[/<resource>][.json|.bin]
+// to their endpoint handlers
+func (s *Server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet && request.Method != http.MethodHead {
+		writer.Header().Set("Allow", "GET, HEAD")
+		writeError(writer, newHTTPError(http.StatusMethodNotAllowed, "Method %s is not allowed", request.Method))
+		return
+	}
+
+	path, format := splitFormat(strings.TrimPrefix(request.URL.Path, "/"))
+	endpoint, resource := path, ""
+	if separatorIndex := strings.Index(path, "/"); separatorIndex >= 0 {
+		endpoint, resource = path[:separatorIndex], path[separatorIndex+1:]
+	}
+
+	handler, ok := endpoints[endpoint]
+	if !ok {
+		writeError(writer, newHTTPError(http.StatusNotFound, "Unknown endpoint %s", request.URL.Path))
+		return
+	}
+
+	response, err := handler(s, request, resource, format)
+	if err != nil {
+		var httpErr *httpError
+		if !errors.As(err, &httpErr) {
+			log.Errorf("Error handling REST request %s: %+v", request.URL, err)
+			httpErr = newHTTPError(http.StatusInternalServerError, "Internal error")
+		}
+		writeError(writer, httpErr)
+		return
+	}
+
+	writeResponse(writer, request, response)
+}
+
+func splitFormat(path string) (string, responseFormat) {
+	for _, format := range []responseFormat{formatJSON, formatBinary} {
+		extension := "." + string(format)
+		if strings.HasSuffix(path, extension) {
+			return strings.TrimSuffix(path, extension), format
+		}
+	}
+	return path, formatJSON
+}
+
+func writeResponse(writer http.ResponseWriter, request *http.Request, response *response) {
+	header := writer.Header()
+	header.Set("Cache-Control", response.cacheControl)
+	if response.format == formatBinary {
+		header.Set("Content-Type", "application/octet-stream")
+	} else {
+		header.Set("Content-Type", "application/json")
+	}
+
+	body := response.body
+	etag := response.etag
+	isGzipped := false
+	if len(body) >= minGzipBodySize {
+		header.Set("Vary", "Accept-Encoding")
+		if acceptsGzip(request) {
+			gzippedBody, err := gzipBody(body)
+			if err != nil {
+				log.Warnf("Error gzipping REST response: %s", err)
+			} else {
+				body = gzippedBody
+				isGzipped = true
+			}
+		}
+	}
+	if isGzipped {
+		header.Set("Content-Encoding", "gzip")
+		// The gzipped representation is a different sequence of bytes,
+		// so it must not share its ETag with the identity representation
+		if etag != "" {
+			etag += "-gzip"
+		}
+	}
+
+	if etag != "" {
+		etag = `"` + etag + `"`
+		header.Set("ETag", etag)
+		if request.Header.Get("If-None-Match") == etag {
+			writer.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+
+	statusCode := http.StatusOK
+	if response.statusCode != 0 {
+		statusCode = response.statusCode
+	}
+	writer.WriteHeader(statusCode)
+	if request.Method == http.MethodHead {
+		return
+	}
+	_, err := writer.Write(body)
+	if err != nil {
+		log.Debugf("Error writing REST response: %s", err)
+	}
+}
+
+// acceptsGzip returns whether the Accept-Encoding header of the given
+// request allows a gzipped response. An explicit gzip coding takes
+// precedence over the * wildcard
+func acceptsGzip(request *http.Request) bool {
+	isGzipListed, isGzipAccepted := false, false
+	isWildcardAccepted := false
+	for _, acceptEncoding := range request.Header.Values("Accept-Encoding") {
+		for _, coding := range strings.Split(acceptEncoding, ",") {
+			parameters := strings.Split(coding, ";")
+			switch strings.ToLower(strings.TrimSpace(parameters[0])) {
+			case "gzip":
+				isGzipListed = true
+				isGzipAccepted = !isRefusedCoding(parameters[1:])
+			case "*":
+				isWildcardAccepted = !isRefusedCoding(parameters[1:])
+			}
+		}
+	}
+	if isGzipListed {
+		return isGzipAccepted
+	}
+	return isWildcardAccepted
+}
+
+// isRefusedCoding returns whether the given parameters of an Accept-Encoding
+// coding have a quality value of zero
+func isRefusedCoding(parameters []string) bool {
+	for _, parameter := range parameters {
+		parameter = strings.TrimSpace(parameter)
+		if !strings.HasPrefix(parameter, "q=") {
+			continue
+		}
+		quality, err := strconv.ParseFloat(strings.TrimPrefix(parameter, "q="), 64)
+		return err == nil && quality == 0
+	}
+	return false
+}
+
+func gzipBody(body []byte) ([]byte, error) {
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	_, err := gzipWriter.Write(body)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	err = gzipWriter.Close()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return buffer.Bytes(), nil
+}
+
+func writeError(writer http.ResponseWriter, httpErr *httpError) {
+	body, err := json.Marshal(struct {
+		Error string `json:"error"`
+	}{Error: httpErr.message})
+	if err != nil {
+		// Marshalling a string never fails
+		panic(err)
+	}
+
+	header := writer.Header()
+	header.Set("Cache-Control", noCacheControl)
+	header.Set("Content-Type", "application/json")
+	writer.WriteHeader(httpErr.statusCode)
+	_, err = writer.Write(body)
+	if err != nil {
+		log.Debugf("Error writing REST error response: %s", err)
+	}
+}
@@ -5,19 +5,39 @@ import (
 	"github.com/kaspanet/kaspad/app/protocol"
 	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
 	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/addressindex"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/hashes"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/utxo"
 	"github.com/kaspanet/kaspad/domain/utxoindex"
 	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
 	"github.com/kaspanet/kaspad/infrastructure/logger"
 	"github.com/kaspanet/kaspad/infrastructure/network/addressmanager"
 	"github.com/kaspanet/kaspad/infrastructure/network/connmanager"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
+	"github.com/kaspanet/kaspad/util"
 	"github.com/pkg/errors"
 )
 
 // Manager is an RPC manager
 type Manager struct {
 	context *rpccontext.Context
+
+	// lastFinalityPoint is the last virtual finality point that was observed
+	// while there were FinalityPointAdvanced listeners. It's only accessed
+	// from the consensus events handler.
+	lastFinalityPoint *externalapi.DomainHash
+
+	// lastPruningPoint is the last pruning point that was observed when
+	// --compactafterpruning is set. It's only accessed from the consensus
+	// events handler.
+	lastPruningPoint *externalapi.DomainHash
+
+	// consensusEventsHandlerDone is closed once the consensus events
+	// handler returns, after the consensus events channel is closed
+	consensusEventsHandlerDone chan struct{}
 }
 
 // NewManager creates a new RPC Manager
@@ -29,10 +49,13 @@ func NewManager(
 	connectionManager *connmanager.ConnectionManager,
 	addressManager *addressmanager.AddressManager,
 	utxoIndex *utxoindex.UTXOIndex,
+	addressIndex *addressindex.AddressIndex,
+	database database.Database,
 	consensusEventsChan chan externalapi.ConsensusEvent,
 	shutDownChan chan<- struct{}) *Manager {
 
 	manager := Manager{
+		consensusEventsHandlerDone: make(chan struct{}),
 		context: rpccontext.NewContext(
 			cfg,
 			domain,
@@ -41,6 +64,8 @@ func NewManager(
 			connectionManager,
 			addressManager,
 			utxoIndex,
+			addressIndex,
+			database,
 			shutDownChan,
 		),
 	}
@@ -51,8 +76,15 @@ func NewManager(
 	return &manager
 }
 
+// SetConfigReloader sets the function that the reloadConfig RPC reloads the
+// config with. It must be called before the RPC server is started.
+func (m *Manager) SetConfigReloader(configReloader rpccontext.ConfigReloader) {
+	m.context.ConfigReloader = configReloader
+}
+
 func (m *Manager) initConsensusEventsHandler(consensusEventsChan chan externalapi.ConsensusEvent) {
 	spawn("consensusEventsHandler", func() {
+		defer close(m.consensusEventsHandlerDone)
 		for {
 			consensusEvent, ok := <-consensusEventsChan
 			if !ok {
@@ -76,11 +108,47 @@ func (m *Manager) initConsensusEventsHandler(consensusEventsChan chan externalap
 	})
 }
 
+// WaitForConsensusEventsHandler blocks until the consensus events handler
+// handles all the events in the consensus events channel and returns, which
+// happens once the channel is closed. Until then, the handler may still
+// write to the indexes.
+func (m *Manager) WaitForConsensusEventsHandler() {
+	<-m.consensusEventsHandlerDone
+}
+
+// WaitForDatabaseJobs blocks until the database backup and compaction that
+// run in the background, if there are any, finish
+func (m *Manager) WaitForDatabaseJobs() {
+	m.context.DatabaseBackupManager.Wait()
+	m.context.DatabaseCompactionManager.Wait()
+}
+
+// StopJobs cancels the RPC jobs that are running, such as UTXO set dumps
+// and rescans, and blocks until they stop
+func (m *Manager) StopJobs() {
+	m.context.JobManager.Stop()
+}
+
+// SubmitBlock adds a block found by a miner outside of any RPC connection to
+// the DAG and relays it, as the SubmitBlock RPC command does. A block that is
+// not accepted results in a response that describes the reason for its
+// rejection.
+func (m *Manager) SubmitBlock(block *externalapi.DomainBlock, submittedVia string) (
+	*appmessage.SubmitBlockResponseMessage, error) {
+
+	return m.context.SubmitBlock(block, false, submittedVia)
+}
+
 // notifyBlockAddedToDAG notifies the manager that a block has been added to the DAG
 func (m *Manager) notifyBlockAddedToDAG(block *externalapi.DomainBlock) error {
 	onEnd := logger.LogAndMeasureExecutionTime(log, "RPCManager.notifyBlockAddedToDAG")
 	defer onEnd()
 
+	err := m.notifyNewTip(block)
+	if err != nil {
+		return err
+	}
+
 	// Before converting the block and populating it, we check if any listeners are interested.
 	// This is done since most nodes do not use this event.
 	if !m.context.NotificationManager.HasBlockAddedListeners() {
@@ -88,7 +156,7 @@ func (m *Manager) notifyBlockAddedToDAG(block *externalapi.DomainBlock) error {
 	}
 
 	rpcBlock := appmessage.DomainBlockToRPCBlock(block)
-	err := m.context.PopulateBlockWithVerboseData(rpcBlock, block.Header, block, true)
+	err = m.context.PopulateBlockWithVerboseData(rpcBlock, block.Header, block, true)
 	if err != nil {
 		return err
 	}
@@ -113,6 +181,13 @@ func (m *Manager) notifyVirtualChange(virtualChangeSet *externalapi.VirtualChang
 		}
 	}
 
+	if m.context.Config.AddressIndex {
+		err := m.context.AddressIndex.Update(virtualChangeSet)
+		if err != nil {
+			return err
+		}
+	}
+
 	err := m.notifyVirtualSelectedParentBlueScoreChanged(virtualChangeSet.VirtualSelectedParentBlueScore)
 	if err != nil {
 		return err
@@ -123,6 +198,16 @@ func (m *Manager) notifyVirtualChange(virtualChangeSet *externalapi.VirtualChang
 		return err
 	}
 
+	err = m.notifyFinalityPointAdvanced()
+	if err != nil {
+		return err
+	}
+
+	err = m.compactAfterPruning()
+	if err != nil {
+		return err
+	}
+
 	if virtualChangeSet.VirtualSelectedParentChainChanges == nil ||
 		(len(virtualChangeSet.VirtualSelectedParentChainChanges.Added) == 0 &&
 			len(virtualChangeSet.VirtualSelectedParentChainChanges.Removed) == 0) {
@@ -142,7 +227,31 @@ func (m *Manager) notifyVirtualChange(virtualChangeSet *externalapi.VirtualChang
 // block template is available for miners
 func (m *Manager) NotifyNewBlockTemplate() error {
 	notification := appmessage.NewNewBlockTemplateNotificationMessage()
-	return m.context.NotificationManager.NotifyNewBlockTemplate(notification)
+	err := m.context.NotificationManager.NotifyNewBlockTemplate(notification)
+	if err != nil {
+		return err
+	}
+
+	return m.notifyWork()
+}
+
+func (m *Manager) notifyWork() error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "RPCManager.notifyWork")
+	defer onEnd()
+
+	// Building work requires building a block template, so we check if any listeners are interested
+	if !m.context.NotificationManager.HasWorkListeners() {
+		return nil
+	}
+
+	return m.context.NotificationManager.NotifyWork(
+		func(payAddress util.Address, extraData string) (*appmessage.WorkNotificationMessage, error) {
+			work, isSynced, err := m.context.BuildWork(payAddress, extraData)
+			if err != nil {
+				return nil, err
+			}
+			return appmessage.NewWorkNotificationMessage(work, isSynced), nil
+		})
 }
 
 // NotifyPruningPointUTXOSetOverride notifies the manager whenever the UTXO index
@@ -158,6 +267,14 @@ func (m *Manager) NotifyPruningPointUTXOSetOverride() error {
 		}
 	}
 
+	// The selected chain that the address index follows starts over from the new pruning point
+	if m.context.Config.AddressIndex {
+		err := m.context.AddressIndex.Reset()
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -191,6 +308,76 @@ func (m *Manager) notifyUTXOsChanged(virtualChangeSet *externalapi.VirtualChange
 	return m.context.NotificationManager.NotifyUTXOsChanged(utxoIndexChanges)
 }
 
+// NotifyTransactionsAddedToMempool notifies the manager that transactions have
+// been added to the mempool
+func (m *Manager) NotifyTransactionsAddedToMempool(transactions []*externalapi.DomainTransaction) error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "RPCManager.NotifyTransactionsAddedToMempool")
+	defer onEnd()
+
+	if m.context.NotificationManager.HasNewTransactionsListeners() {
+		err := m.context.NotificationManager.NotifyNewTransactions(transactions, m.transactionWithVerboseData)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !m.context.NotificationManager.HasMempoolUTXOsChangedListeners() {
+		return nil
+	}
+
+	return m.context.NotificationManager.NotifyMempoolUTXOsChanged(mempoolUTXOChanges(transactions))
+}
+
+// transactionWithVerboseData converts the given transaction to an RPCTransaction,
+// including its verbose data
+func (m *Manager) transactionWithVerboseData(transaction *externalapi.DomainTransaction) (*appmessage.RPCTransaction, error) {
+	rpcTransaction := appmessage.DomainTransactionToRPCTransaction(transaction)
+	err := m.context.PopulateTransactionWithVerboseData(rpcTransaction, nil)
+	if err != nil {
+		return nil, err
+	}
+	return rpcTransaction, nil
+}
+
+// mempoolUTXOChanges returns the UTXOs that the given mempool transactions create
+// and spend. The blockDAAScore of the created UTXOs is 0, since they're not in any block yet
+func mempoolUTXOChanges(transactions []*externalapi.DomainTransaction) *utxoindex.UTXOChanges {
+	utxoChanges := &utxoindex.UTXOChanges{
+		Added:   make(map[utxoindex.ScriptPublicKeyString]utxoindex.UTXOOutpointEntryPairs),
+		Removed: make(map[utxoindex.ScriptPublicKeyString]utxoindex.UTXOOutpointEntryPairs),
+	}
+	addPair := func(pairsByScriptPublicKey map[utxoindex.ScriptPublicKeyString]utxoindex.UTXOOutpointEntryPairs,
+		outpoint externalapi.DomainOutpoint, entry externalapi.UTXOEntry) {
+
+		key := utxoindex.ScriptPublicKeyString(entry.ScriptPublicKey().String())
+		pairs, ok := pairsByScriptPublicKey[key]
+		if !ok {
+			pairs = make(utxoindex.UTXOOutpointEntryPairs)
+			pairsByScriptPublicKey[key] = pairs
+		}
+		pairs[outpoint] = entry
+	}
+
+	for _, transaction := range transactions {
+		for _, input := range transaction.Inputs {
+			// Transactions in the mempool have all their UTXO entries populated,
+			// but we don't want to rely on it
+			if input.UTXOEntry == nil {
+				continue
+			}
+			addPair(utxoChanges.Removed, input.PreviousOutpoint, input.UTXOEntry)
+		}
+
+		transactionID := consensushashing.TransactionID(transaction)
+		for i, output := range transaction.Outputs {
+			outpoint := externalapi.DomainOutpoint{TransactionID: *transactionID, Index: uint32(i)}
+			entry := utxo.NewUTXOEntry(output.Value, output.ScriptPublicKey, false, 0)
+			addPair(utxoChanges.Added, outpoint, entry)
+		}
+	}
+	return utxoChanges
+}
+
 func (m *Manager) notifyPruningPointUTXOSetOverride() error {
 	onEnd := logger.LogAndMeasureExecutionTime(log, "RPCManager.notifyPruningPointUTXOSetOverride")
 	defer onEnd()
@@ -223,11 +410,12 @@ func (m *Manager) notifyVirtualSelectedParentChainChanged(virtualChangeSet *exte
 	onEnd := logger.LogAndMeasureExecutionTime(log, "RPCManager.NotifyVirtualSelectedParentChainChanged")
 	defer onEnd()
 
-	hasListeners, includeAcceptedTransactionIDs := m.context.NotificationManager.HasListenersThatPropagateVirtualSelectedParentChainChanged()
+	hasListeners, includeAcceptedTransactionIDs, includeAcceptanceData :=
+		m.context.NotificationManager.HasListenersThatPropagateVirtualSelectedParentChainChanged()
 
 	if hasListeners {
 		notification, err := m.context.ConvertVirtualSelectedParentChainChangesToChainChangedNotificationMessage(
-			virtualChangeSet.VirtualSelectedParentChainChanges, includeAcceptedTransactionIDs)
+			virtualChangeSet.VirtualSelectedParentChainChanges, includeAcceptedTransactionIDs, includeAcceptanceData)
 		if err != nil {
 			return err
 		}
@@ -236,3 +424,92 @@ func (m *Manager) notifyVirtualSelectedParentChainChanged(virtualChangeSet *exte
 
 	return nil
 }
+
+func (m *Manager) notifyNewTip(block *externalapi.DomainBlock) error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "RPCManager.notifyNewTip")
+	defer onEnd()
+
+	if !m.context.NotificationManager.HasNewTipListeners() {
+		return nil
+	}
+
+	tips, err := m.context.Domain.Consensus().Tips()
+	if err != nil {
+		return err
+	}
+
+	// The block might already have children by the time this event is handled,
+	// in which case it's not a new tip anymore
+	blockHash := consensushashing.BlockHash(block)
+	isTip := false
+	for _, tip := range tips {
+		if tip.Equal(blockHash) {
+			isTip = true
+			break
+		}
+	}
+	if !isTip {
+		return nil
+	}
+
+	notification := appmessage.NewNewTipNotificationMessage(blockHash.String(), hashes.ToStrings(tips))
+	return m.context.NotificationManager.NotifyNewTip(notification)
+}
+
+func (m *Manager) notifyFinalityPointAdvanced() error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "RPCManager.notifyFinalityPointAdvanced")
+	defer onEnd()
+
+	// Nobody tracks the finality point while there are no listeners, so the
+	// next listener will start from the finality point at the time it arrived
+	if !m.context.NotificationManager.HasFinalityPointAdvancedListeners() {
+		m.lastFinalityPoint = nil
+		return nil
+	}
+
+	finalityPoint, err := m.context.Domain.Consensus().VirtualFinalityPoint()
+	if err != nil {
+		return err
+	}
+
+	previousFinalityPoint := m.lastFinalityPoint
+	m.lastFinalityPoint = finalityPoint
+	if previousFinalityPoint == nil || previousFinalityPoint.Equal(finalityPoint) {
+		return nil
+	}
+
+	notification := appmessage.NewFinalityPointAdvancedNotificationMessage(
+		finalityPoint.String(), previousFinalityPoint.String())
+	return m.context.NotificationManager.NotifyFinalityPointAdvanced(notification)
+}
+
+// compactAfterPruning starts compacting the database in the background once
+// the pruning point moves, so that the space of the pruned block data is
+// reclaimed right away, rather than whenever the database compacts the
+// deleted keys on its own
+func (m *Manager) compactAfterPruning() error {
+	if !m.context.Config.CompactAfterPruning || m.context.Config.IsArchivalNode {
+		return nil
+	}
+
+	pruningPoint, err := m.context.Domain.Consensus().PruningPoint()
+	if err != nil {
+		return err
+	}
+
+	previousPruningPoint := m.lastPruningPoint
+	m.lastPruningPoint = pruningPoint
+	if previousPruningPoint == nil || previousPruningPoint.Equal(pruningPoint) {
+		return nil
+	}
+
+	log.Infof("The pruning point moved from %s to %s. Compacting the database to reclaim "+
+		"the space of the pruned blocks", previousPruningPoint, pruningPoint)
+	err = m.context.DatabaseCompactionManager.Start()
+	if errors.Is(err, rpccontext.ErrDatabaseCompactionInProgress) {
+		log.Infof("A database compaction is already in progress, so the space of the " +
+			"pruned blocks will be reclaimed by it")
+		return nil
+	}
+	return err
+}
@@ -6,51 +6,97 @@ import (
 	"github.com/kaspanet/kaspad/app/rpc/rpchandlers"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/infrastructure/tracing"
 	"github.com/pkg/errors"
 )
 
 type handler func(context *rpccontext.Context, router *router.Router, request appmessage.Message) (appmessage.Message, error)
 
 var handlers = map[appmessage.MessageCommand]handler{
-	appmessage.CmdGetCurrentNetworkRequestMessage:                           rpchandlers.HandleGetCurrentNetwork,
-	appmessage.CmdSubmitBlockRequestMessage:                                 rpchandlers.HandleSubmitBlock,
-	appmessage.CmdGetBlockTemplateRequestMessage:                            rpchandlers.HandleGetBlockTemplate,
-	appmessage.CmdNotifyBlockAddedRequestMessage:                            rpchandlers.HandleNotifyBlockAdded,
-	appmessage.CmdGetPeerAddressesRequestMessage:                            rpchandlers.HandleGetPeerAddresses,
-	appmessage.CmdGetSelectedTipHashRequestMessage:                          rpchandlers.HandleGetSelectedTipHash,
-	appmessage.CmdGetMempoolEntryRequestMessage:                             rpchandlers.HandleGetMempoolEntry,
-	appmessage.CmdGetConnectedPeerInfoRequestMessage:                        rpchandlers.HandleGetConnectedPeerInfo,
-	appmessage.CmdAddPeerRequestMessage:                                     rpchandlers.HandleAddPeer,
-	appmessage.CmdSubmitTransactionRequestMessage:                           rpchandlers.HandleSubmitTransaction,
-	appmessage.CmdNotifyVirtualSelectedParentChainChangedRequestMessage:     rpchandlers.HandleNotifyVirtualSelectedParentChainChanged,
-	appmessage.CmdGetBlockRequestMessage:                                    rpchandlers.HandleGetBlock,
-	appmessage.CmdGetSubnetworkRequestMessage:                               rpchandlers.HandleGetSubnetwork,
-	appmessage.CmdGetVirtualSelectedParentChainFromBlockRequestMessage:      rpchandlers.HandleGetVirtualSelectedParentChainFromBlock,
-	appmessage.CmdGetBlocksRequestMessage:                                   rpchandlers.HandleGetBlocks,
-	appmessage.CmdGetBlockCountRequestMessage:                               rpchandlers.HandleGetBlockCount,
-	appmessage.CmdGetBalanceByAddressRequestMessage:                         rpchandlers.HandleGetBalanceByAddress,
-	appmessage.CmdGetBlockDAGInfoRequestMessage:                             rpchandlers.HandleGetBlockDAGInfo,
-	appmessage.CmdResolveFinalityConflictRequestMessage:                     rpchandlers.HandleResolveFinalityConflict,
-	appmessage.CmdNotifyFinalityConflictsRequestMessage:                     rpchandlers.HandleNotifyFinalityConflicts,
-	appmessage.CmdGetMempoolEntriesRequestMessage:                           rpchandlers.HandleGetMempoolEntries,
-	appmessage.CmdShutDownRequestMessage:                                    rpchandlers.HandleShutDown,
-	appmessage.CmdGetHeadersRequestMessage:                                  rpchandlers.HandleGetHeaders,
-	appmessage.CmdNotifyUTXOsChangedRequestMessage:                          rpchandlers.HandleNotifyUTXOsChanged,
-	appmessage.CmdStopNotifyingUTXOsChangedRequestMessage:                   rpchandlers.HandleStopNotifyingUTXOsChanged,
-	appmessage.CmdGetUTXOsByAddressesRequestMessage:                         rpchandlers.HandleGetUTXOsByAddresses,
-	appmessage.CmdGetBalancesByAddressesRequestMessage:                      rpchandlers.HandleGetBalancesByAddresses,
-	appmessage.CmdGetVirtualSelectedParentBlueScoreRequestMessage:           rpchandlers.HandleGetVirtualSelectedParentBlueScore,
-	appmessage.CmdNotifyVirtualSelectedParentBlueScoreChangedRequestMessage: rpchandlers.HandleNotifyVirtualSelectedParentBlueScoreChanged,
-	appmessage.CmdBanRequestMessage:                                         rpchandlers.HandleBan,
-	appmessage.CmdUnbanRequestMessage:                                       rpchandlers.HandleUnban,
-	appmessage.CmdGetInfoRequestMessage:                                     rpchandlers.HandleGetInfo,
-	appmessage.CmdNotifyPruningPointUTXOSetOverrideRequestMessage:           rpchandlers.HandleNotifyPruningPointUTXOSetOverrideRequest,
-	appmessage.CmdStopNotifyingPruningPointUTXOSetOverrideRequestMessage:    rpchandlers.HandleStopNotifyingPruningPointUTXOSetOverrideRequest,
-	appmessage.CmdEstimateNetworkHashesPerSecondRequestMessage:              rpchandlers.HandleEstimateNetworkHashesPerSecond,
-	appmessage.CmdNotifyVirtualDaaScoreChangedRequestMessage:                rpchandlers.HandleNotifyVirtualDaaScoreChanged,
-	appmessage.CmdNotifyNewBlockTemplateRequestMessage:                      rpchandlers.HandleNotifyNewBlockTemplate,
-	appmessage.CmdGetCoinSupplyRequestMessage:                               rpchandlers.HandleGetCoinSupply,
-	appmessage.CmdGetMempoolEntriesByAddressesRequestMessage:                rpchandlers.HandleGetMempoolEntriesByAddresses,
+	appmessage.CmdGetCurrentNetworkRequestMessage:                              rpchandlers.HandleGetCurrentNetwork,
+	appmessage.CmdSubmitBlockRequestMessage:                                    rpchandlers.HandleSubmitBlock,
+	appmessage.CmdGetBlockTemplateRequestMessage:                               rpchandlers.HandleGetBlockTemplate,
+	appmessage.CmdNotifyBlockAddedRequestMessage:                               rpchandlers.HandleNotifyBlockAdded,
+	appmessage.CmdGetPeerAddressesRequestMessage:                               rpchandlers.HandleGetPeerAddresses,
+	appmessage.CmdGetSelectedTipHashRequestMessage:                             rpchandlers.HandleGetSelectedTipHash,
+	appmessage.CmdGetMempoolEntryRequestMessage:                                rpchandlers.HandleGetMempoolEntry,
+	appmessage.CmdGetConnectedPeerInfoRequestMessage:                           rpchandlers.HandleGetConnectedPeerInfo,
+	appmessage.CmdAddPeerRequestMessage:                                        rpchandlers.HandleAddPeer,
+	appmessage.CmdSubmitTransactionRequestMessage:                              rpchandlers.HandleSubmitTransaction,
+	appmessage.CmdNotifyVirtualSelectedParentChainChangedRequestMessage:        rpchandlers.HandleNotifyVirtualSelectedParentChainChanged,
+	appmessage.CmdGetBlockRequestMessage:                                       rpchandlers.HandleGetBlock,
+	appmessage.CmdGetSubnetworkRequestMessage:                                  rpchandlers.HandleGetSubnetwork,
+	appmessage.CmdGetVirtualSelectedParentChainFromBlockRequestMessage:         rpchandlers.HandleGetVirtualSelectedParentChainFromBlock,
+	appmessage.CmdGetBlocksRequestMessage:                                      rpchandlers.HandleGetBlocks,
+	appmessage.CmdGetBlockCountRequestMessage:                                  rpchandlers.HandleGetBlockCount,
+	appmessage.CmdGetBalanceByAddressRequestMessage:                            rpchandlers.HandleGetBalanceByAddress,
+	appmessage.CmdGetBlockDAGInfoRequestMessage:                                rpchandlers.HandleGetBlockDAGInfo,
+	appmessage.CmdResolveFinalityConflictRequestMessage:                        rpchandlers.HandleResolveFinalityConflict,
+	appmessage.CmdNotifyFinalityConflictsRequestMessage:                        rpchandlers.HandleNotifyFinalityConflicts,
+	appmessage.CmdGetMempoolEntriesRequestMessage:                              rpchandlers.HandleGetMempoolEntries,
+	appmessage.CmdShutDownRequestMessage:                                       rpchandlers.HandleShutDown,
+	appmessage.CmdGetHeadersRequestMessage:                                     rpchandlers.HandleGetHeaders,
+	appmessage.CmdNotifyUTXOsChangedRequestMessage:                             rpchandlers.HandleNotifyUTXOsChanged,
+	appmessage.CmdStopNotifyingUTXOsChangedRequestMessage:                      rpchandlers.HandleStopNotifyingUTXOsChanged,
+	appmessage.CmdGetUTXOsByAddressesRequestMessage:                            rpchandlers.HandleGetUTXOsByAddresses,
+	appmessage.CmdGetBalancesByAddressesRequestMessage:                         rpchandlers.HandleGetBalancesByAddresses,
+	appmessage.CmdGetVirtualSelectedParentBlueScoreRequestMessage:              rpchandlers.HandleGetVirtualSelectedParentBlueScore,
+	appmessage.CmdNotifyVirtualSelectedParentBlueScoreChangedRequestMessage:    rpchandlers.HandleNotifyVirtualSelectedParentBlueScoreChanged,
+	appmessage.CmdBanRequestMessage:                                            rpchandlers.HandleBan,
+	appmessage.CmdUnbanRequestMessage:                                          rpchandlers.HandleUnban,
+	appmessage.CmdGetInfoRequestMessage:                                        rpchandlers.HandleGetInfo,
+	appmessage.CmdNotifyPruningPointUTXOSetOverrideRequestMessage:              rpchandlers.HandleNotifyPruningPointUTXOSetOverrideRequest,
+	appmessage.CmdStopNotifyingPruningPointUTXOSetOverrideRequestMessage:       rpchandlers.HandleStopNotifyingPruningPointUTXOSetOverrideRequest,
+	appmessage.CmdEstimateNetworkHashesPerSecondRequestMessage:                 rpchandlers.HandleEstimateNetworkHashesPerSecond,
+	appmessage.CmdNotifyVirtualDaaScoreChangedRequestMessage:                   rpchandlers.HandleNotifyVirtualDaaScoreChanged,
+	appmessage.CmdNotifyNewBlockTemplateRequestMessage:                         rpchandlers.HandleNotifyNewBlockTemplate,
+	appmessage.CmdGetCoinSupplyRequestMessage:                                  rpchandlers.HandleGetCoinSupply,
+	appmessage.CmdGetMempoolEntriesByAddressesRequestMessage:                   rpchandlers.HandleGetMempoolEntriesByAddresses,
+	appmessage.CmdGetWorkRequestMessage:                                        rpchandlers.HandleGetWork,
+	appmessage.CmdSubmitWorkRequestMessage:                                     rpchandlers.HandleSubmitWork,
+	appmessage.CmdNotifyWorkRequestMessage:                                     rpchandlers.HandleNotifyWork,
+	appmessage.CmdGetBlockSubmissionStatusRequestMessage:                       rpchandlers.HandleGetBlockSubmissionStatus,
+	appmessage.CmdGenerateBlocksRequestMessage:                                 rpchandlers.HandleGenerateBlocks,
+	appmessage.CmdGetAuxCommitmentProofRequestMessage:                          rpchandlers.HandleGetAuxCommitmentProof,
+	appmessage.CmdNotifyNewTipRequestMessage:                                   rpchandlers.HandleNotifyNewTip,
+	appmessage.CmdStopNotifyingNewTipRequestMessage:                            rpchandlers.HandleStopNotifyingNewTip,
+	appmessage.CmdNotifyFinalityPointAdvancedRequestMessage:                    rpchandlers.HandleNotifyFinalityPointAdvanced,
+	appmessage.CmdStopNotifyingFinalityPointAdvancedRequestMessage:             rpchandlers.HandleStopNotifyingFinalityPointAdvanced,
+	appmessage.CmdStopNotifyingVirtualSelectedParentChainChangedRequestMessage: rpchandlers.HandleStopNotifyingVirtualSelectedParentChainChanged,
+	appmessage.CmdSearchRawTransactionsRequestMessage:                          rpchandlers.HandleSearchRawTransactions,
+	appmessage.CmdHealthCheckRequestMessage:                                    rpchandlers.HandleHealthCheck,
+	appmessage.CmdSignRawTransactionRequestMessage:                             rpchandlers.HandleSignRawTransaction,
+	appmessage.CmdNotifyNewTransactionsRequestMessage:                          rpchandlers.HandleNotifyNewTransactions,
+	appmessage.CmdStopNotifyingNewTransactionsRequestMessage:                   rpchandlers.HandleStopNotifyingNewTransactions,
+	appmessage.CmdSetBanRequestMessage:                                         rpchandlers.HandleSetBan,
+	appmessage.CmdListBannedRequestMessage:                                     rpchandlers.HandleListBanned,
+	appmessage.CmdClearBannedRequestMessage:                                    rpchandlers.HandleClearBanned,
+	appmessage.CmdDumpUTXOSetRequestMessage:                                    rpchandlers.HandleDumpUTXOSet,
+	appmessage.CmdImportUTXOSetRequestMessage:                                  rpchandlers.HandleImportUTXOSet,
+	appmessage.CmdStartNotificationSessionRequestMessage:                       rpchandlers.HandleStartNotificationSession,
+	appmessage.CmdResumeNotificationSessionRequestMessage:                      rpchandlers.HandleResumeNotificationSession,
+	appmessage.CmdGetFinalityPointRequestMessage:                               rpchandlers.HandleGetFinalityPoint,
+	appmessage.CmdGetDatabaseStatsRequestMessage:                               rpchandlers.HandleGetDatabaseStats,
+	appmessage.CmdCompactDatabaseRequestMessage:                                rpchandlers.HandleCompactDatabase,
+	appmessage.CmdBackupDatabaseRequestMessage:                                 rpchandlers.HandleBackupDatabase,
+	appmessage.CmdGetIndexStatusRequestMessage:                                 rpchandlers.HandleGetIndexStatus,
+	appmessage.CmdRebuildIndexRequestMessage:                                   rpchandlers.HandleRebuildIndex,
+	appmessage.CmdDropIndexRequestMessage:                                      rpchandlers.HandleDropIndex,
+	appmessage.CmdExportBlocksRequestMessage:                                   rpchandlers.HandleExportBlocks,
+	appmessage.CmdGetAddressManagerInfoRequestMessage:                          rpchandlers.HandleGetAddressManagerInfo,
+	appmessage.CmdReloadConfigRequestMessage:                                   rpchandlers.HandleReloadConfig,
+	appmessage.CmdCaptureProfileRequestMessage:                                 rpchandlers.HandleCaptureProfile,
+	appmessage.CmdDebugLevelRequestMessage:                                     rpchandlers.HandleDebugLevel,
+	appmessage.CmdGetChainAnalysisRequestMessage:                               rpchandlers.HandleGetChainAnalysis,
+	appmessage.CmdRescanRequestMessage:                                         rpchandlers.HandleRescan,
+	appmessage.CmdStopRescanRequestMessage:                                     rpchandlers.HandleStopRescan,
+	appmessage.CmdAddTransactionBlocklistEntriesRequestMessage:                 rpchandlers.HandleAddTransactionBlocklistEntries,
+	appmessage.CmdRemoveTransactionBlocklistEntriesRequestMessage:              rpchandlers.HandleRemoveTransactionBlocklistEntries,
+	appmessage.CmdGetTransactionBlocklistRequestMessage:                        rpchandlers.HandleGetTransactionBlocklist,
+	appmessage.CmdGetJobsRequestMessage:                                        rpchandlers.HandleGetJobs,
+	appmessage.CmdCancelJobRequestMessage:                                      rpchandlers.HandleCancelJob,
+	appmessage.CmdNotifyJobProgressRequestMessage:                              rpchandlers.HandleNotifyJobProgress,
+	appmessage.CmdStopNotifyingJobProgressRequestMessage:                       rpchandlers.HandleStopNotifyingJobProgress,
 }
 
 func (m *Manager) routerInitializer(router *router.Router, netConnection *netadapter.NetConnection) {
@@ -66,6 +112,7 @@ func (m *Manager) routerInitializer(router *router.Router, netConnection *netada
 
 	spawn("routerInitializer-handleIncomingMessages", func() {
 		defer m.context.NotificationManager.RemoveListener(router)
+		defer m.context.RescanManager.Stop(router)
 
 		err := m.handleIncomingMessages(router, incomingRoute)
 		m.handleError(err, netConnection)
@@ -83,7 +130,7 @@ func (m *Manager) handleIncomingMessages(router *router.Router, incomingRoute *r
 		if !ok {
 			return err
 		}
-		response, err := handler(m.context, router, request)
+		response, err := m.handle(handler, router, request)
 		if err != nil {
 			return err
 		}
@@ -94,6 +141,28 @@ func (m *Manager) handleIncomingMessages(router *router.Router, incomingRoute *r
 	}
 }
 
+// HandleRequest handles a single request outside of any RPC connection.
+// Since there's no router to deliver notifications to, it must not be
+// used for notification requests.
+func (m *Manager) HandleRequest(request appmessage.Message) (appmessage.Message, error) {
+	handler, ok := handlers[request.Command()]
+	if !ok {
+		return nil, errors.Errorf("no handler for command %s", request.Command())
+	}
+	return m.handle(handler, nil, request)
+}
+
+// handle handles request with handler, in a span that's named after its
+// command
+func (m *Manager) handle(handler handler, router *router.Router,
+	request appmessage.Message) (appmessage.Message, error) {
+
+	span := tracing.StartSpan("rpc." + request.Command().String())
+	response, err := handler(m.context, router, request)
+	span.EndWithError(err)
+	return response, err
+}
+
 func (m *Manager) handleError(err error, netConnection *netadapter.NetConnection) {
 	if errors.Is(err, router.ErrTimeout) {
 		log.Warnf("Got timeout from %s. Disconnecting...", netConnection)
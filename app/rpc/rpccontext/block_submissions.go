@@ -0,0 +1,126 @@
+package rpccontext
+
+import (
+	"sync"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+// maxStoredBlockSubmissions is the maximum amount of async block submissions whose
+// outcome is kept by the BlockSubmissionManager
+const maxStoredBlockSubmissions = 1024
+
+// maxPendingBlockSubmissions is the maximum amount of async block submissions that
+// may wait to be processed at any given time
+const maxPendingBlockSubmissions = 128
+
+// ErrTooManyPendingBlockSubmissions indicates that an async block submission was
+// refused because too many submissions are already waiting to be processed
+var ErrTooManyPendingBlockSubmissions = errors.New("too many pending block submissions")
+
+// BlockSubmission describes the state of a block submitted asynchronously
+type BlockSubmission struct {
+	BlockHash    *externalapi.DomainHash
+	Status       string
+	RejectReason string
+}
+
+// BlockSubmissionProcessor validates a submitted block and returns the outcome of its submission
+type BlockSubmissionProcessor func() (status string, rejectReason string, err error)
+
+type blockSubmissionJob struct {
+	id      string
+	process BlockSubmissionProcessor
+}
+
+// BlockSubmissionManager processes blocks submitted asynchronously, one at a time and in
+// the order they were submitted, and keeps the outcome of their submission
+type BlockSubmissionManager struct {
+	sync.RWMutex
+	submissions map[string]*BlockSubmission
+	order       []string
+	jobs        chan *blockSubmissionJob
+}
+
+// NewBlockSubmissionManager creates a new BlockSubmissionManager and starts processing submissions
+func NewBlockSubmissionManager() *BlockSubmissionManager {
+	bsm := &BlockSubmissionManager{
+		submissions: make(map[string]*BlockSubmission),
+		jobs:        make(chan *blockSubmissionJob, maxPendingBlockSubmissions),
+	}
+	spawn("BlockSubmissionManager.processLoop", bsm.processLoop)
+	return bsm
+}
+
+// Submit schedules the given block submission for processing and returns its ID.
+// Submitting a block that is already pending, accepted or orphaned returns the ID of
+// the existing submission without processing the block again.
+func (bsm *BlockSubmissionManager) Submit(blockHash *externalapi.DomainHash, process BlockSubmissionProcessor) (string, error) {
+	bsm.Lock()
+	defer bsm.Unlock()
+
+	id := blockHash.String()
+	if submission, ok := bsm.submissions[id]; ok && submission.Status != appmessage.BlockSubmissionStatusRejected {
+		return id, nil
+	}
+
+	select {
+	case bsm.jobs <- &blockSubmissionJob{id: id, process: process}:
+	default:
+		return "", ErrTooManyPendingBlockSubmissions
+	}
+
+	if _, ok := bsm.submissions[id]; !ok {
+		if len(bsm.order) == maxStoredBlockSubmissions {
+			delete(bsm.submissions, bsm.order[0])
+			bsm.order = bsm.order[1:]
+		}
+		bsm.order = append(bsm.order, id)
+	}
+	bsm.submissions[id] = &BlockSubmission{
+		BlockHash: blockHash,
+		Status:    appmessage.BlockSubmissionStatusPending,
+	}
+	return id, nil
+}
+
+// Get returns a copy of the block submission with the given ID
+func (bsm *BlockSubmissionManager) Get(id string) (*BlockSubmission, bool) {
+	bsm.RLock()
+	defer bsm.RUnlock()
+
+	submission, ok := bsm.submissions[id]
+	if !ok {
+		return nil, false
+	}
+	submissionCopy := *submission
+	return &submissionCopy, true
+}
+
+// SetOutcome sets the status of the block submission with the given ID. It's a no-op
+// if the submission has already been evicted.
+func (bsm *BlockSubmissionManager) SetOutcome(id string, status string, rejectReason string) {
+	bsm.Lock()
+	defer bsm.Unlock()
+
+	submission, ok := bsm.submissions[id]
+	if !ok {
+		return
+	}
+	submission.Status = status
+	submission.RejectReason = rejectReason
+}
+
+func (bsm *BlockSubmissionManager) processLoop() {
+	for job := range bsm.jobs {
+		status, rejectReason, err := job.process()
+		if err != nil {
+			log.Errorf("Error processing block submission %s: %s", job.id, err)
+			status = appmessage.BlockSubmissionStatusRejected
+			rejectReason = "internal error while processing the block"
+		}
+		bsm.SetOutcome(job.id, status, rejectReason)
+	}
+}
@@ -0,0 +1,101 @@
+package rpccontext
+
+import (
+	"time"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// ChainAnalysis is the shape of the DAG in a window of blue scores
+type ChainAnalysis struct {
+	// LowBlueScore and HighBlueScore are the window that was analyzed,
+	// after limiting it to the blocks the node has
+	LowBlueScore  uint64
+	HighBlueScore uint64
+
+	ChainBlockCount uint64
+	BlockCount      uint64
+	RedBlockCount   uint64
+	ParentCount     uint64
+
+	// OrphanLatencies are how long the blocks in the window that were
+	// received as orphans waited for their missing ancestors
+	OrphanLatencies []time.Duration
+}
+
+// AnalyzeChain analyzes the blocks merged by the selected chain blocks whose
+// blue scores are between lowBlueScore and highBlueScore, inclusive. The
+// part of the window that's above the virtual's selected parent, or not
+// above the pruning point, is left out, since the blocks merged by the
+// pruning point may already be pruned.
+func (ctx *Context) AnalyzeChain(lowBlueScore uint64, highBlueScore uint64) (*ChainAnalysis, error) {
+	consensus := ctx.Domain.Consensus()
+
+	pruningPoint, err := consensus.PruningPoint()
+	if err != nil {
+		return nil, err
+	}
+	pruningPointInfo, err := consensus.GetBlockInfo(pruningPoint)
+	if err != nil {
+		return nil, err
+	}
+	if lowBlueScore <= pruningPointInfo.BlueScore {
+		lowBlueScore = pruningPointInfo.BlueScore + 1
+	}
+
+	current, err := consensus.GetVirtualSelectedParent()
+	if err != nil {
+		return nil, err
+	}
+	currentInfo, err := consensus.GetBlockInfo(current)
+	if err != nil {
+		return nil, err
+	}
+	if highBlueScore > currentInfo.BlueScore {
+		highBlueScore = currentInfo.BlueScore
+	}
+
+	analysis := &ChainAnalysis{
+		LowBlueScore:  lowBlueScore,
+		HighBlueScore: highBlueScore,
+	}
+	if lowBlueScore > highBlueScore {
+		return analysis, nil
+	}
+
+	// The selected chain is walked down from the virtual's selected parent
+	// until it leaves the window
+	for currentInfo.BlueScore >= lowBlueScore {
+		if currentInfo.BlueScore <= highBlueScore {
+			err := ctx.analyzeMergeSet(analysis, currentInfo)
+			if err != nil {
+				return nil, err
+			}
+		}
+		current = currentInfo.SelectedParent
+		currentInfo, err = consensus.GetBlockInfo(current)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	analysis.OrphanLatencies = ctx.ProtocolManager.Context().OrphanLatencies(lowBlueScore, highBlueScore)
+	return analysis, nil
+}
+
+func (ctx *Context) analyzeMergeSet(analysis *ChainAnalysis, chainBlockInfo *externalapi.BlockInfo) error {
+	analysis.ChainBlockCount++
+	analysis.BlockCount += uint64(len(chainBlockInfo.MergeSetBlues) + len(chainBlockInfo.MergeSetReds))
+	analysis.RedBlockCount += uint64(len(chainBlockInfo.MergeSetReds))
+
+	for _, mergeSet := range [][]*externalapi.DomainHash{chainBlockInfo.MergeSetBlues, chainBlockInfo.MergeSetReds} {
+		for _, blockHash := range mergeSet {
+			header, err := ctx.Domain.Consensus().GetBlockHeader(blockHash)
+			if err != nil {
+				return err
+			}
+			analysis.ParentCount += uint64(len(header.DirectParents()))
+		}
+	}
+	return nil
+}
@@ -9,8 +9,8 @@ import (
 // ConvertVirtualSelectedParentChainChangesToChainChangedNotificationMessage converts
 // VirtualSelectedParentChainChanges to VirtualSelectedParentChainChangedNotificationMessage
 func (ctx *Context) ConvertVirtualSelectedParentChainChangesToChainChangedNotificationMessage(
-	selectedParentChainChanges *externalapi.SelectedChainPath, includeAcceptedTransactionIDs bool) (
-	*appmessage.VirtualSelectedParentChainChangedNotificationMessage, error) {
+	selectedParentChainChanges *externalapi.SelectedChainPath, includeAcceptedTransactionIDs bool,
+	includeAcceptanceData bool) (*appmessage.VirtualSelectedParentChainChangedNotificationMessage, error) {
 
 	removedChainBlockHashes := make([]string, len(selectedParentChainChanges.Removed))
 	for i, removed := range selectedParentChainChanges.Removed {
@@ -22,58 +22,118 @@ func (ctx *Context) ConvertVirtualSelectedParentChainChangesToChainChangedNotifi
 		addedChainBlocks[i] = added.String()
 	}
 
-	var acceptedTransactionIDs []*appmessage.AcceptedTransactionIDs
+	notification := appmessage.NewVirtualSelectedParentChainChangedNotificationMessage(
+		removedChainBlockHashes, addedChainBlocks, nil)
+	if !includeAcceptedTransactionIDs && !includeAcceptanceData {
+		return notification, nil
+	}
+
 	if includeAcceptedTransactionIDs {
-		var err error
-		acceptedTransactionIDs, err = ctx.getAndConvertAcceptedTransactionIDs(selectedParentChainChanges)
-		if err != nil {
-			return nil, err
-		}
+		notification.AcceptedTransactionIDs = make([]*appmessage.AcceptedTransactionIDs, len(selectedParentChainChanges.Added))
+	}
+	if includeAcceptanceData {
+		notification.AcceptanceData = make([]*appmessage.ChainBlockAcceptanceData, len(selectedParentChainChanges.Added))
+	}
+	err := ctx.forEachChainBlockAcceptanceData(selectedParentChainChanges.Added,
+		func(i int, chainBlock *externalapi.DomainHash, chainBlockAcceptanceData externalapi.AcceptanceData) {
+			if includeAcceptedTransactionIDs {
+				notification.AcceptedTransactionIDs[i] = convertAcceptedTransactionIDs(chainBlock, chainBlockAcceptanceData)
+			}
+			if includeAcceptanceData {
+				notification.AcceptanceData[i] = convertChainBlockAcceptanceData(chainBlock, chainBlockAcceptanceData)
+			}
+		})
+	if err != nil {
+		return nil, err
 	}
 
-	return appmessage.NewVirtualSelectedParentChainChangedNotificationMessage(
-		removedChainBlockHashes, addedChainBlocks, acceptedTransactionIDs), nil
+	return notification, nil
 }
 
-func (ctx *Context) getAndConvertAcceptedTransactionIDs(selectedParentChainChanges *externalapi.SelectedChainPath) (
-	[]*appmessage.AcceptedTransactionIDs, error) {
+// ConvertChainBlocksAcceptanceData returns the acceptance data of each of the
+// given chain blocks, in order
+func (ctx *Context) ConvertChainBlocksAcceptanceData(
+	chainBlocks []*externalapi.DomainHash) ([]*appmessage.ChainBlockAcceptanceData, error) {
+
+	acceptanceData := make([]*appmessage.ChainBlockAcceptanceData, len(chainBlocks))
+	err := ctx.forEachChainBlockAcceptanceData(chainBlocks,
+		func(i int, chainBlock *externalapi.DomainHash, chainBlockAcceptanceData externalapi.AcceptanceData) {
+			acceptanceData[i] = convertChainBlockAcceptanceData(chainBlock, chainBlockAcceptanceData)
+		})
+	if err != nil {
+		return nil, err
+	}
+	return acceptanceData, nil
+}
 
-	acceptedTransactionIDs := make([]*appmessage.AcceptedTransactionIDs, len(selectedParentChainChanges.Added))
+// forEachChainBlockAcceptanceData calls the given function with the acceptance data
+// of every one of the given chain blocks, in order
+func (ctx *Context) forEachChainBlockAcceptanceData(chainBlocks []*externalapi.DomainHash,
+	f func(i int, chainBlock *externalapi.DomainHash, chainBlockAcceptanceData externalapi.AcceptanceData)) error {
 
 	const chunk = 1000
 	position := 0
 
-	for position < len(selectedParentChainChanges.Added) {
+	for position < len(chainBlocks) {
 		var chainBlocksChunk []*externalapi.DomainHash
-		if position+chunk > len(selectedParentChainChanges.Added) {
-			chainBlocksChunk = selectedParentChainChanges.Added[position:]
+		if position+chunk > len(chainBlocks) {
+			chainBlocksChunk = chainBlocks[position:]
 		} else {
-			chainBlocksChunk = selectedParentChainChanges.Added[position : position+chunk]
+			chainBlocksChunk = chainBlocks[position : position+chunk]
 		}
 		// We use chunks in order to avoid blocking consensus for too long
 		chainBlocksAcceptanceData, err := ctx.Domain.Consensus().GetBlocksAcceptanceData(chainBlocksChunk)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		for i, addedChainBlock := range chainBlocksChunk {
-			chainBlockAcceptanceData := chainBlocksAcceptanceData[i]
-			acceptedTransactionIDs[position+i] = &appmessage.AcceptedTransactionIDs{
-				AcceptingBlockHash:     addedChainBlock.String(),
-				AcceptedTransactionIDs: nil,
-			}
-			for _, blockAcceptanceData := range chainBlockAcceptanceData {
-				for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
-					if transactionAcceptanceData.IsAccepted {
-						acceptedTransactionIDs[position+i].AcceptedTransactionIDs =
-							append(acceptedTransactionIDs[position+i].AcceptedTransactionIDs,
-								consensushashing.TransactionID(transactionAcceptanceData.Transaction).String())
-					}
-				}
-			}
+		for i, chainBlock := range chainBlocksChunk {
+			f(position+i, chainBlock, chainBlocksAcceptanceData[i])
 		}
 		position += chunk
 	}
 
-	return acceptedTransactionIDs, nil
+	return nil
+}
+
+func convertAcceptedTransactionIDs(chainBlock *externalapi.DomainHash,
+	chainBlockAcceptanceData externalapi.AcceptanceData) *appmessage.AcceptedTransactionIDs {
+
+	acceptedTransactionIDs := &appmessage.AcceptedTransactionIDs{
+		AcceptingBlockHash:     chainBlock.String(),
+		AcceptedTransactionIDs: nil,
+	}
+	for _, blockAcceptanceData := range chainBlockAcceptanceData {
+		for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+			if transactionAcceptanceData.IsAccepted {
+				acceptedTransactionIDs.AcceptedTransactionIDs = append(acceptedTransactionIDs.AcceptedTransactionIDs,
+					consensushashing.TransactionID(transactionAcceptanceData.Transaction).String())
+			}
+		}
+	}
+	return acceptedTransactionIDs
+}
+
+func convertChainBlockAcceptanceData(chainBlock *externalapi.DomainHash,
+	chainBlockAcceptanceData externalapi.AcceptanceData) *appmessage.ChainBlockAcceptanceData {
+
+	mergedBlocks := make([]*appmessage.MergedBlockAcceptanceData, len(chainBlockAcceptanceData))
+	for i, blockAcceptanceData := range chainBlockAcceptanceData {
+		mergedBlock := &appmessage.MergedBlockAcceptanceData{
+			BlockHash: blockAcceptanceData.BlockHash.String(),
+		}
+		for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+			transactionID := consensushashing.TransactionID(transactionAcceptanceData.Transaction).String()
+			if transactionAcceptanceData.IsAccepted {
+				mergedBlock.AcceptedTransactionIDs = append(mergedBlock.AcceptedTransactionIDs, transactionID)
+			} else {
+				mergedBlock.RejectedTransactionIDs = append(mergedBlock.RejectedTransactionIDs, transactionID)
+			}
+		}
+		mergedBlocks[i] = mergedBlock
+	}
+	return &appmessage.ChainBlockAcceptanceData{
+		AcceptingBlockHash: chainBlock.String(),
+		MergedBlocks:       mergedBlocks,
+	}
 }
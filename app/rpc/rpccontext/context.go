@@ -3,13 +3,19 @@ package rpccontext
 import (
 	"github.com/kaspanet/kaspad/app/protocol"
 	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/addressindex"
 	"github.com/kaspanet/kaspad/domain/utxoindex"
 	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
 	"github.com/kaspanet/kaspad/infrastructure/network/addressmanager"
 	"github.com/kaspanet/kaspad/infrastructure/network/connmanager"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter"
 )
 
+// ConfigReloader reloads the options of the config that can be changed
+// while kaspad is running, and returns the names of the ones that changed
+type ConfigReloader func() (changedOptions []string, err error)
+
 // Context represents the RPC context
 type Context struct {
 	Config            *config.Config
@@ -19,9 +25,21 @@ type Context struct {
 	ConnectionManager *connmanager.ConnectionManager
 	AddressManager    *addressmanager.AddressManager
 	UTXOIndex         *utxoindex.UTXOIndex
+	AddressIndex      *addressindex.AddressIndex
+	Database          database.Database
 	ShutDownChan      chan<- struct{}
 
-	NotificationManager *NotificationManager
+	// ConfigReloader is nil if the config can't be reloaded
+	ConfigReloader ConfigReloader
+
+	NotificationManager       *NotificationManager
+	WorkManager               *WorkManager
+	BlockSubmissionManager    *BlockSubmissionManager
+	DatabaseCompactionManager *DatabaseCompactionManager
+	DatabaseBackupManager     *DatabaseBackupManager
+	IndexManager              *IndexManager
+	JobManager                *JobManager
+	RescanManager             *RescanManager
 }
 
 // NewContext creates a new RPC context
@@ -32,6 +50,8 @@ func NewContext(cfg *config.Config,
 	connectionManager *connmanager.ConnectionManager,
 	addressManager *addressmanager.AddressManager,
 	utxoIndex *utxoindex.UTXOIndex,
+	addressIndex *addressindex.AddressIndex,
+	database database.Database,
 	shutDownChan chan<- struct{}) *Context {
 
 	context := &Context{
@@ -42,9 +62,18 @@ func NewContext(cfg *config.Config,
 		ConnectionManager: connectionManager,
 		AddressManager:    addressManager,
 		UTXOIndex:         utxoIndex,
+		AddressIndex:      addressIndex,
+		Database:          database,
 		ShutDownChan:      shutDownChan,
 	}
 	context.NotificationManager = NewNotificationManager(cfg.ActiveNetParams)
+	context.WorkManager = NewWorkManager()
+	context.BlockSubmissionManager = NewBlockSubmissionManager()
+	context.DatabaseCompactionManager = NewDatabaseCompactionManager(database)
+	context.DatabaseBackupManager = NewDatabaseBackupManager(database, cfg.DbType)
+	context.IndexManager = NewIndexManager(database, utxoIndex, addressIndex)
+	context.JobManager = NewJobManager(context.NotificationManager)
+	context.RescanManager = NewRescanManager(context)
 
 	return context
 }
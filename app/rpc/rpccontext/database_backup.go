@@ -0,0 +1,124 @@
+package rpccontext
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+// ErrDatabaseBackupInProgress indicates that a database backup was
+// refused because another one is already running
+var ErrDatabaseBackupInProgress = errors.New("a database backup is already in progress")
+
+// DatabaseBackupStatus describes the backups started by the DatabaseBackupManager
+type DatabaseBackupStatus struct {
+	IsBackingUp bool
+
+	// LastBackupTime is the time the last backup finished at, and
+	// is zero if no backup finished since the node started
+	LastBackupTime      time.Time
+	LastBackupDirectory string
+	LastBackupError     error
+}
+
+// DatabaseBackupManager backs the database up in the background, one backup at a time
+type DatabaseBackupManager struct {
+	database database.Database
+	dbType   string
+
+	sync.Mutex
+	status DatabaseBackupStatus
+
+	// done is closed once the last backup that was started finishes
+	done chan struct{}
+}
+
+// NewDatabaseBackupManager creates a new DatabaseBackupManager
+func NewDatabaseBackupManager(database database.Database, dbType string) *DatabaseBackupManager {
+	return &DatabaseBackupManager{
+		database: database,
+		dbType:   dbType,
+	}
+}
+
+// Start starts backing the database up into the given directory in the
+// background. The directory must not exist. Once the backup is done, the
+// directory may replace the database directory of a stopped node.
+func (dbm *DatabaseBackupManager) Start(directory string) error {
+	dbm.Lock()
+	defer dbm.Unlock()
+
+	if dbm.status.IsBackingUp {
+		return ErrDatabaseBackupInProgress
+	}
+	directory, err := filepath.Abs(directory)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stat(directory)
+	if err == nil {
+		return errors.Errorf("%s already exists", directory)
+	}
+	if !os.IsNotExist(err) {
+		return err
+	}
+	dbm.status.IsBackingUp = true
+	done := make(chan struct{})
+	dbm.done = done
+
+	spawn("DatabaseBackupManager.Start-backup", func() {
+		log.Infof("Backing the database up into %s", directory)
+		start := time.Now()
+		err := dbm.backup(directory)
+		if err != nil {
+			log.Errorf("Error backing the database up into %s: %s", directory, err)
+		} else {
+			log.Infof("Backed the database up into %s in %s", directory, time.Since(start))
+		}
+
+		dbm.Lock()
+		defer dbm.Unlock()
+		dbm.status = DatabaseBackupStatus{
+			IsBackingUp:         false,
+			LastBackupTime:      time.Now(),
+			LastBackupDirectory: directory,
+			LastBackupError:     err,
+		}
+		close(done)
+	})
+	return nil
+}
+
+func (dbm *DatabaseBackupManager) backup(directory string) error {
+	err := dbm.database.Backup(directory)
+	if err != nil {
+		return err
+	}
+	// The backup records its backend, like the database directory does,
+	// so that it's opened with the right backend once it's restored
+	return os.WriteFile(filepath.Join(directory, config.DBTypeFilename), []byte(dbm.dbType), 0600)
+}
+
+// Wait blocks until the running backup, if there is one, finishes
+func (dbm *DatabaseBackupManager) Wait() {
+	dbm.Lock()
+	done := dbm.done
+	dbm.Unlock()
+
+	if done != nil {
+		<-done
+	}
+}
+
+// Status returns the status of the backups started by the manager
+func (dbm *DatabaseBackupManager) Status() DatabaseBackupStatus {
+	dbm.Lock()
+	defer dbm.Unlock()
+
+	return dbm.status
+}
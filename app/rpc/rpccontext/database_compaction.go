@@ -0,0 +1,95 @@
+package rpccontext
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+// ErrDatabaseCompactionInProgress indicates that a database compaction was
+// refused because another one is already running
+var ErrDatabaseCompactionInProgress = errors.New("a database compaction is already in progress")
+
+// DatabaseCompactionStatus describes the compactions started by the DatabaseCompactionManager
+type DatabaseCompactionStatus struct {
+	IsCompacting bool
+
+	// LastCompactionTime is the time the last compaction finished at, and
+	// is zero if no compaction finished since the node started
+	LastCompactionTime     time.Time
+	LastCompactionDuration time.Duration
+}
+
+// DatabaseCompactionManager runs manual compactions of the database in the
+// background, one at a time
+type DatabaseCompactionManager struct {
+	database database.Database
+
+	sync.Mutex
+	status DatabaseCompactionStatus
+
+	// done is closed once the last compaction that was started finishes
+	done chan struct{}
+}
+
+// NewDatabaseCompactionManager creates a new DatabaseCompactionManager
+func NewDatabaseCompactionManager(database database.Database) *DatabaseCompactionManager {
+	return &DatabaseCompactionManager{database: database}
+}
+
+// Start starts compacting the database in the background. It returns
+// ErrDatabaseCompactionInProgress if a compaction is already running
+func (dcm *DatabaseCompactionManager) Start() error {
+	dcm.Lock()
+	defer dcm.Unlock()
+
+	if dcm.status.IsCompacting {
+		return ErrDatabaseCompactionInProgress
+	}
+	dcm.status.IsCompacting = true
+	done := make(chan struct{})
+	dcm.done = done
+
+	spawn("DatabaseCompactionManager.Start-compact", func() {
+		log.Infof("Compacting the database")
+		start := time.Now()
+		err := dcm.database.Compact()
+		duration := time.Since(start)
+		if err != nil {
+			log.Errorf("Error compacting the database: %s", err)
+		} else {
+			log.Infof("Compacted the database in %s", duration)
+		}
+
+		dcm.Lock()
+		defer dcm.Unlock()
+		dcm.status = DatabaseCompactionStatus{
+			IsCompacting:           false,
+			LastCompactionTime:     time.Now(),
+			LastCompactionDuration: duration,
+		}
+		close(done)
+	})
+	return nil
+}
+
+// Wait blocks until the running compaction, if there is one, finishes
+func (dcm *DatabaseCompactionManager) Wait() {
+	dcm.Lock()
+	done := dcm.done
+	dcm.Unlock()
+
+	if done != nil {
+		<-done
+	}
+}
+
+// Status returns the status of the compactions started by the manager
+func (dcm *DatabaseCompactionManager) Status() DatabaseCompactionStatus {
+	dcm.Lock()
+	defer dcm.Unlock()
+
+	return dcm.status
+}
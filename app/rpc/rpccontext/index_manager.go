@@ -0,0 +1,158 @@
+package rpccontext
+
+import (
+	"sync"
+
+	"github.com/kaspanet/kaspad/domain/addressindex"
+	"github.com/kaspanet/kaspad/domain/indexes"
+	"github.com/kaspanet/kaspad/domain/utxoindex"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+// ErrIndexOperationInProgress indicates that an operation on an index was
+// refused because another operation is already running on it
+var ErrIndexOperationInProgress = errors.New("an operation is already running on the index")
+
+// IndexStatus describes an optional index, and the last operation that was applied to it
+type IndexStatus struct {
+	Name      string
+	IsEnabled bool
+	Progress  indexes.ProgressStatus
+}
+
+type managedIndex struct {
+	name      string
+	isEnabled bool
+	progress  *indexes.Progress
+
+	// rebuild deletes and rebuilds the index. It's nil for indexes
+	// that can't be rebuilt while the node is running
+	rebuild func() error
+}
+
+// IndexManager rebuilds and drops the optional indexes while the node is running
+type IndexManager struct {
+	database database.Database
+
+	sync.Mutex
+	indexes []*managedIndex
+}
+
+// NewIndexManager creates a new IndexManager. The indexes that
+// aren't enabled are nil
+func NewIndexManager(database database.Database,
+	utxoIndex *utxoindex.UTXOIndex, addressIndex *addressindex.AddressIndex) *IndexManager {
+
+	// The UTXO index is built from the virtual UTXO set, which fails if the
+	// virtual changes during the build. It's rebuilt when the node starts instead
+	managedUTXOIndex := &managedIndex{name: indexes.UTXOIndexName, progress: indexes.NewProgress()}
+	if utxoIndex != nil {
+		managedUTXOIndex.isEnabled = true
+		managedUTXOIndex.progress = utxoIndex.Progress()
+	}
+
+	managedAddressIndex := &managedIndex{name: indexes.AddressIndexName, progress: indexes.NewProgress()}
+	if addressIndex != nil {
+		managedAddressIndex.isEnabled = true
+		managedAddressIndex.progress = addressIndex.Progress()
+		managedAddressIndex.rebuild = addressIndex.Reset
+	}
+
+	return &IndexManager{
+		database: database,
+		indexes:  []*managedIndex{managedUTXOIndex, managedAddressIndex},
+	}
+}
+
+// Statuses returns the statuses of all the optional indexes
+func (im *IndexManager) Statuses() []*IndexStatus {
+	im.Lock()
+	defer im.Unlock()
+
+	statuses := make([]*IndexStatus, len(im.indexes))
+	for i, index := range im.indexes {
+		statuses[i] = &IndexStatus{
+			Name:      index.name,
+			IsEnabled: index.isEnabled,
+			Progress:  index.progress.Status(),
+		}
+	}
+	return statuses
+}
+
+// Rebuild starts deleting and rebuilding the given enabled index in the background.
+// The index keeps serving requests while it's rebuilt, but its data is incomplete
+// until the rebuild is done
+func (im *IndexManager) Rebuild(name string) error {
+	im.Lock()
+	defer im.Unlock()
+
+	index, err := im.index(name)
+	if err != nil {
+		return err
+	}
+	if !index.isEnabled {
+		return errors.Errorf("the %s index is not enabled", name)
+	}
+	if index.rebuild == nil {
+		return errors.Errorf("the %s index can't be rebuilt while the node is running. "+
+			"It's rebuilt when the node starts if it's dropped while it's not enabled", name)
+	}
+	if index.progress.Status().IsRunning {
+		return ErrIndexOperationInProgress
+	}
+	index.progress.Start(indexes.OperationRebuild)
+
+	spawn("IndexManager.Rebuild-rebuild", func() {
+		log.Infof("Rebuilding the %s index", name)
+		err := index.rebuild()
+		if err != nil {
+			log.Errorf("Error rebuilding the %s index: %s", name, err)
+			return
+		}
+		log.Infof("Rebuilt the %s index", name)
+	})
+	return nil
+}
+
+// Drop starts deleting the data of the given index in the background. Only
+// indexes that aren't enabled may be dropped
+func (im *IndexManager) Drop(name string) error {
+	im.Lock()
+	defer im.Unlock()
+
+	index, err := im.index(name)
+	if err != nil {
+		return err
+	}
+	if index.isEnabled {
+		return errors.Errorf("the %s index is enabled. Restart the node without it to drop it", name)
+	}
+	if index.progress.Status().IsRunning {
+		return ErrIndexOperationInProgress
+	}
+	index.progress.Start(indexes.OperationDrop)
+
+	spawn("IndexManager.Drop-drop", func() {
+		log.Infof("Dropping the %s index", name)
+		deletedCount, err := indexes.NewNamespace(name).Drop(im.database)
+		index.progress.Advance(uint64(deletedCount))
+		index.progress.Finish(err)
+		if err != nil {
+			log.Errorf("Error dropping the %s index: %s", name, err)
+			return
+		}
+		log.Infof("Dropped the %s index, which deleted %d keys", name, deletedCount)
+	})
+	return nil
+}
+
+func (im *IndexManager) index(name string) (*managedIndex, error) {
+	for _, index := range im.indexes {
+		if index.name == name {
+			return index, nil
+		}
+	}
+	return nil, errors.Errorf("unknown index %s", name)
+}
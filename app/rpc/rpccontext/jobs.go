@@ -0,0 +1,259 @@
+package rpccontext
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxStoppedJobs is the number of stopped jobs whose states the
+	// JobManager keeps, so that their clients can retrieve their results
+	maxStoppedJobs = 100
+
+	// jobProgressNotificationInterval is the minimal interval between two
+	// progress notifications of a running job
+	jobProgressNotificationInterval = time.Second
+)
+
+// ErrJobCancelled indicates that a job stopped because it was cancelled
+var ErrJobCancelled = errors.New("the job was cancelled")
+
+// JobResult is the result of a job. Only the field of the job's kind is set
+type JobResult struct {
+	UTXOSet *appmessage.RPCJobUTXOSetResult
+	Rescan  *appmessage.RPCJobRescanResult
+}
+
+// JobFunc runs a job, and reports its progress with job.SetProgress. It
+// returns ErrJobCancelled soon after job.Quit() is closed. A JobFunc may
+// return a result along with an error, such as where a failed rescan stopped.
+type JobFunc func(job *Job) (*JobResult, error)
+
+// Job is an expensive RPC call that runs in the background
+type Job struct {
+	id      uint64
+	kind    string
+	manager *JobManager
+
+	quit       chan struct{}
+	cancelOnce sync.Once
+	done       chan struct{}
+
+	lock                 sync.Mutex
+	state                string
+	processed            uint64
+	total                uint64
+	startTime            time.Time
+	endTime              time.Time
+	result               *JobResult
+	err                  error
+	lastNotificationTime time.Time
+}
+
+// ID returns the ID of the job
+func (j *Job) ID() uint64 {
+	return j.id
+}
+
+// Quit returns a channel that's closed once the job is cancelled
+func (j *Job) Quit() <-chan struct{} {
+	return j.quit
+}
+
+// IsCancelled returns whether the job was cancelled
+func (j *Job) IsCancelled() bool {
+	select {
+	case <-j.quit:
+		return true
+	default:
+		return false
+	}
+}
+
+// Cancel makes the job stop at its next checkpoint. It doesn't wait
+// for the job to stop
+func (j *Job) Cancel() {
+	j.cancelOnce.Do(func() {
+		close(j.quit)
+	})
+}
+
+// SetProgress sets the amount of items the job processed so far, and the
+// amount of items it processes, which is 0 if it isn't known
+func (j *Job) SetProgress(processed uint64, total uint64) {
+	j.lock.Lock()
+	j.processed = processed
+	j.total = total
+	if time.Since(j.lastNotificationTime) < jobProgressNotificationInterval {
+		j.lock.Unlock()
+		return
+	}
+	j.lastNotificationTime = time.Now()
+	rpcJob := j.rpcJobNoLock()
+	j.lock.Unlock()
+
+	j.manager.notify(rpcJob, true)
+}
+
+// Wait blocks until the job stops, and returns its result and error
+func (j *Job) Wait() (*JobResult, error) {
+	<-j.done
+
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	return j.result, j.err
+}
+
+// RPCJob returns the state of the job as an RPCJob
+func (j *Job) RPCJob() *appmessage.RPCJob {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	return j.rpcJobNoLock()
+}
+
+func (j *Job) rpcJobNoLock() *appmessage.RPCJob {
+	rpcJob := &appmessage.RPCJob{
+		ID:        j.id,
+		Kind:      j.kind,
+		State:     j.state,
+		Processed: j.processed,
+		Total:     j.total,
+		StartTime: j.startTime.UnixMilli(),
+	}
+	if !j.endTime.IsZero() {
+		rpcJob.EndTime = j.endTime.UnixMilli()
+	}
+	if j.state == appmessage.RPCJobStateFailed {
+		rpcJob.Error = j.err.Error()
+	}
+	if j.result != nil {
+		rpcJob.UTXOSetResult = j.result.UTXOSet
+		rpcJob.RescanResult = j.result.Rescan
+	}
+	return rpcJob
+}
+
+// JobManager runs expensive RPC calls, such as dumping the UTXO set or
+// rescanning the DAG, as jobs in the background, so that their clients don't
+// have to wait for them. It keeps the states of the running jobs and of the
+// last jobs that stopped, and notifies the listeners of their progress.
+type JobManager struct {
+	notificationManager *NotificationManager
+
+	sync.Mutex
+	lastJobID     uint64
+	jobs          map[uint64]*Job
+	stoppedJobIDs []uint64
+}
+
+// NewJobManager creates a new JobManager
+func NewJobManager(notificationManager *NotificationManager) *JobManager {
+	return &JobManager{
+		notificationManager: notificationManager,
+		jobs:                make(map[uint64]*Job),
+	}
+}
+
+// Start starts a job of the given kind, which is run by the given function
+// in the background
+func (jm *JobManager) Start(kind string, run JobFunc) *Job {
+	jm.Lock()
+	jm.lastJobID++
+	job := &Job{
+		id:        jm.lastJobID,
+		kind:      kind,
+		manager:   jm,
+		quit:      make(chan struct{}),
+		done:      make(chan struct{}),
+		state:     appmessage.RPCJobStateRunning,
+		startTime: time.Now(),
+	}
+	job.lastNotificationTime = job.startTime
+	jm.jobs[job.id] = job
+	jm.Unlock()
+
+	log.Debugf("Starting %s job %d", kind, job.id)
+	jm.notify(job.RPCJob(), false)
+
+	spawn("JobManager.Start-run", func() {
+		result, err := run(job)
+		jm.stop(job, result, err)
+	})
+	return job
+}
+
+func (jm *JobManager) stop(job *Job, result *JobResult, err error) {
+	job.lock.Lock()
+	job.endTime = time.Now()
+	job.result = result
+	job.err = err
+	switch {
+	case err == nil:
+		job.state = appmessage.RPCJobStateCompleted
+		log.Debugf("Completed %s job %d", job.kind, job.id)
+	case errors.Is(err, ErrJobCancelled):
+		job.state = appmessage.RPCJobStateCancelled
+		log.Debugf("Cancelled %s job %d", job.kind, job.id)
+	default:
+		job.state = appmessage.RPCJobStateFailed
+		log.Warnf("Error running %s job %d: %s", job.kind, job.id, err)
+	}
+	rpcJob := job.rpcJobNoLock()
+	job.lock.Unlock()
+	close(job.done)
+
+	jm.Lock()
+	jm.stoppedJobIDs = append(jm.stoppedJobIDs, job.id)
+	if len(jm.stoppedJobIDs) > maxStoppedJobs {
+		delete(jm.jobs, jm.stoppedJobIDs[0])
+		jm.stoppedJobIDs = jm.stoppedJobIDs[1:]
+	}
+	jm.Unlock()
+
+	jm.notify(rpcJob, false)
+}
+
+func (jm *JobManager) notify(rpcJob *appmessage.RPCJob, mayDrop bool) {
+	err := jm.notificationManager.NotifyJobProgress(appmessage.NewJobProgressNotificationMessage(rpcJob), mayDrop)
+	if err != nil {
+		log.Debugf("Error notifying the progress of job %d: %s", rpcJob.ID, err)
+	}
+}
+
+// Job returns the job of the given ID, if the manager keeps it
+func (jm *JobManager) Job(id uint64) (job *Job, ok bool) {
+	jm.Lock()
+	defer jm.Unlock()
+
+	job, ok = jm.jobs[id]
+	return job, ok
+}
+
+// Jobs returns all the jobs the manager keeps, ordered by their IDs
+func (jm *JobManager) Jobs() []*Job {
+	jm.Lock()
+	defer jm.Unlock()
+
+	jobs := make([]*Job, 0, len(jm.jobs))
+	for _, job := range jm.jobs {
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].id < jobs[j].id
+	})
+	return jobs
+}
+
+// Stop cancels all the running jobs and waits for them to stop
+func (jm *JobManager) Stop() {
+	for _, job := range jm.Jobs() {
+		job.Cancel()
+		_, _ = job.Wait()
+	}
+}
@@ -2,6 +2,8 @@ package rpccontext
 
 import (
 	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/util/panics"
 )
 
 var log = logger.RegisterSubSystem("RPCS")
+var spawn = panics.GoroutineWrapperFunc(log)
@@ -0,0 +1,156 @@
+package rpccontext
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+const (
+	// NotificationSessionHistorySize is the amount of notifications a session keeps
+	// for its client to resume from
+	NotificationSessionHistorySize = 1000
+
+	// NotificationSessionExpiry is how long a session is kept after its client disconnects
+	NotificationSessionExpiry = 2 * time.Minute
+)
+
+// notificationSession numbers the notifications sent to a listener and keeps
+// the last ones, so that a client that reconnects can be sent the ones it missed
+type notificationSession struct {
+	// The session is locked by the listener while adding notifications, since
+	// listeners are notified while the notification manager is only read-locked
+	sync.Mutex
+
+	id           string
+	lastSequence uint64
+
+	// history holds the last notifications, where the last one's sequence is lastSequence
+	history []appmessage.Message
+
+	detachedAt time.Time
+}
+
+// add numbers the given notification and adds it to the session's history
+func (s *notificationSession) add(notification appmessage.Message) *appmessage.SequencedNotificationMessage {
+	s.lastSequence++
+	s.history = append(s.history, notification)
+	if len(s.history) > NotificationSessionHistorySize {
+		s.history = s.history[len(s.history)-NotificationSessionHistorySize:]
+	}
+	return appmessage.NewSequencedNotificationMessage(notification, s.lastSequence)
+}
+
+// notificationsAfter returns the notifications of the session that came after lastSequence
+func (s *notificationSession) notificationsAfter(lastSequence uint64) ([]*appmessage.SequencedNotificationMessage, error) {
+	if lastSequence > s.lastSequence {
+		return nil, errors.Errorf("notification %d was never sent in this session", lastSequence)
+	}
+	firstKeptSequence := s.lastSequence - uint64(len(s.history)) + 1
+	if lastSequence+1 < firstKeptSequence {
+		return nil, errors.Errorf("notifications %d to %d are no longer kept", lastSequence+1, firstKeptSequence-1)
+	}
+
+	missedNotifications := s.history[lastSequence+1-firstKeptSequence:]
+	sequencedNotifications := make([]*appmessage.SequencedNotificationMessage, len(missedNotifications))
+	for i, notification := range missedNotifications {
+		sequencedNotifications[i] = appmessage.NewSequencedNotificationMessage(notification, lastSequence+1+uint64(i))
+	}
+	return sequencedNotifications, nil
+}
+
+// StartNotificationSession starts a new notification session for the listener of
+// the given router and returns its ID. A previous session of the listener is discarded
+func (nm *NotificationManager) StartNotificationSession(router *routerpkg.Router) (string, error) {
+	nm.Lock()
+	defer nm.Unlock()
+
+	listener, ok := nm.listeners[router]
+	if !ok {
+		return "", errors.Errorf("listener not found")
+	}
+
+	idBytes := make([]byte, 16)
+	_, err := rand.Read(idBytes)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	id := hex.EncodeToString(idBytes)
+
+	if listener.session != nil {
+		delete(nm.sessions, listener.session.id)
+	}
+	listener.session = &notificationSession{id: id}
+	nm.sessions[id] = listener
+	return id, nil
+}
+
+// ResumeNotificationSession makes the listener of the given session the listener of
+// the given router, and sends the router every notification of the session that came
+// after lastSequence. It returns the amount of notifications that were sent again.
+//
+// If the session is still attached to another router, whose disconnection wasn't
+// noticed yet, the session is moved over from that router.
+func (nm *NotificationManager) ResumeNotificationSession(router *routerpkg.Router,
+	sessionID string, lastSequence uint64) (int, error) {
+
+	nm.Lock()
+	defer nm.Unlock()
+
+	listener, ok := nm.sessions[sessionID]
+	if !ok {
+		return 0, errors.Errorf("session %s is unknown or has expired", sessionID)
+	}
+	if _, ok := nm.listeners[router]; !ok {
+		return 0, errors.Errorf("listener not found")
+	}
+
+	// No notification is added to the session while the notification manager is
+	// write-locked, so no notification is missed between the replay and the
+	// switch to the new router
+	missedNotifications, err := listener.session.notificationsAfter(lastSequence)
+	if err != nil {
+		return 0, err
+	}
+
+	if listener.router != nil && listener.router != router {
+		nm.listeners[listener.router] = newNotificationListener(nm.params, listener.router)
+	}
+	if previousListener := nm.listeners[router]; previousListener != listener && previousListener.session != nil {
+		delete(nm.sessions, previousListener.session.id)
+	}
+	listener.router = router
+	nm.listeners[router] = listener
+
+	for _, notification := range missedNotifications {
+		err := router.OutgoingRoute().Enqueue(notification)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(missedNotifications), nil
+}
+
+// detachSession keeps the session of the given listener, whose client has
+// disconnected, until it's resumed or until NotificationSessionExpiry passes
+func (nm *NotificationManager) detachSession(listener *NotificationListener) {
+	listener.router = nil
+	listener.session.detachedAt = time.Now()
+
+	sessionID := listener.session.id
+	time.AfterFunc(NotificationSessionExpiry, func() {
+		nm.Lock()
+		defer nm.Unlock()
+
+		// The session may have been resumed, and even detached again, since
+		listener, ok := nm.sessions[sessionID]
+		if ok && listener.router == nil && time.Since(listener.session.detachedAt) >= NotificationSessionExpiry {
+			delete(nm.sessions, sessionID)
+		}
+	})
+}
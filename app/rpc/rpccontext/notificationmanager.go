@@ -11,6 +11,7 @@ import (
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/domain/utxoindex"
 	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/util"
 	"github.com/pkg/errors"
 )
 
@@ -19,6 +20,10 @@ type NotificationManager struct {
 	sync.RWMutex
 	listeners map[*routerpkg.Router]*NotificationListener
 	params    *dagconfig.Params
+
+	// sessions holds the listeners that have a notification session, by
+	// session ID, including the ones whose client is disconnected
+	sessions map[string]*NotificationListener
 }
 
 // UTXOsChangedNotificationAddress represents a kaspad address.
@@ -28,10 +33,51 @@ type UTXOsChangedNotificationAddress struct {
 	ScriptPublicKeyString utxoindex.ScriptPublicKeyString
 }
 
+// NewTransactionsFilter selects the transactions that a listener is notified about
+// in NewTransactions notifications. Empty fields don't filter out any transaction.
+type NewTransactionsFilter struct {
+	// ScriptPublicKeys are the scripts that at least one output must pay
+	ScriptPublicKeys map[utxoindex.ScriptPublicKeyString]struct{}
+
+	// SubnetworkID is the subnetwork the transaction must belong to
+	SubnetworkID *externalapi.DomainSubnetworkID
+
+	// MinimumAmount is the minimum value, in sompi, of an output that
+	// pays one of ScriptPublicKeys
+	MinimumAmount uint64
+}
+
+// Matches returns whether the given transaction passes the filter
+func (f *NewTransactionsFilter) Matches(transaction *externalapi.DomainTransaction) bool {
+	if f.SubnetworkID != nil && !transaction.SubnetworkID.Equal(f.SubnetworkID) {
+		return false
+	}
+	if len(f.ScriptPublicKeys) == 0 && f.MinimumAmount == 0 {
+		return true
+	}
+	for _, output := range transaction.Outputs {
+		if output.Value < f.MinimumAmount {
+			continue
+		}
+		if len(f.ScriptPublicKeys) > 0 {
+			scriptPublicKeyString := utxoindex.ScriptPublicKeyString(output.ScriptPublicKey.String())
+			if _, ok := f.ScriptPublicKeys[scriptPublicKeyString]; !ok {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
 // NotificationListener represents a registered RPC notification listener
 type NotificationListener struct {
 	params *dagconfig.Params
 
+	// router is nil while the client of the listener's session is disconnected
+	router  *routerpkg.Router
+	session *notificationSession
+
 	propagateBlockAddedNotifications                            bool
 	propagateVirtualSelectedParentChainChangedNotifications     bool
 	propagateFinalityConflictNotifications                      bool
@@ -41,9 +87,19 @@ type NotificationListener struct {
 	propagateVirtualDaaScoreChangedNotifications                bool
 	propagatePruningPointUTXOSetOverrideNotifications           bool
 	propagateNewBlockTemplateNotifications                      bool
+	propagateWorkNotifications                                  bool
+	propagateNewTipNotifications                                bool
+	propagateFinalityPointAdvancedNotifications                 bool
+	propagateNewTransactionsNotifications                       bool
+	propagateJobProgressNotifications                           bool
 
 	propagateUTXOsChangedNotificationAddresses                                    map[utxoindex.ScriptPublicKeyString]*UTXOsChangedNotificationAddress
+	includeMempoolInUTXOsChangedNotifications                                     bool
 	includeAcceptedTransactionIDsInVirtualSelectedParentChainChangedNotifications bool
+	includeAcceptanceDataInVirtualSelectedParentChainChangedNotifications         bool
+	workPayAddress                                                                util.Address
+	workExtraData                                                                 string
+	newTransactionsFilter                                                         *NewTransactionsFilter
 }
 
 // NewNotificationManager creates a new NotificationManager
@@ -51,6 +107,7 @@ func NewNotificationManager(params *dagconfig.Params) *NotificationManager {
 	return &NotificationManager{
 		params:    params,
 		listeners: make(map[*routerpkg.Router]*NotificationListener),
+		sessions:  make(map[string]*NotificationListener),
 	}
 }
 
@@ -59,16 +116,40 @@ func (nm *NotificationManager) AddListener(router *routerpkg.Router) {
 	nm.Lock()
 	defer nm.Unlock()
 
-	listener := newNotificationListener(nm.params)
+	listener := newNotificationListener(nm.params, router)
 	nm.listeners[router] = listener
 }
 
-// RemoveListener unregisters the given router
+// RemoveListener unregisters the given router. If its listener has a notification
+// session, the session is kept for notificationSessionExpiry so that the client
+// can resume it
 func (nm *NotificationManager) RemoveListener(router *routerpkg.Router) {
 	nm.Lock()
 	defer nm.Unlock()
 
+	listener, ok := nm.listeners[router]
+	if !ok {
+		return
+	}
 	delete(nm.listeners, router)
+	if listener.session != nil {
+		nm.detachSession(listener)
+	}
+}
+
+// notifiedListeners returns all the listeners that should be notified, including
+// the ones of disconnected sessions, which keep the notifications their clients miss
+func (nm *NotificationManager) notifiedListeners() []*NotificationListener {
+	listeners := make([]*NotificationListener, 0, len(nm.listeners)+len(nm.sessions))
+	for _, listener := range nm.listeners {
+		listeners = append(listeners, listener)
+	}
+	for _, listener := range nm.sessions {
+		if listener.router == nil {
+			listeners = append(listeners, listener)
+		}
+	}
+	return listeners
 }
 
 // Listener retrieves the listener registered with the given router
@@ -88,7 +169,7 @@ func (nm *NotificationManager) HasBlockAddedListeners() bool {
 	nm.RLock()
 	defer nm.RUnlock()
 
-	for _, listener := range nm.listeners {
+	for _, listener := range nm.notifiedListeners() {
 		if listener.propagateBlockAddedNotifications {
 			return true
 		}
@@ -101,9 +182,9 @@ func (nm *NotificationManager) NotifyBlockAdded(notification *appmessage.BlockAd
 	nm.RLock()
 	defer nm.RUnlock()
 
-	for router, listener := range nm.listeners {
+	for _, listener := range nm.notifiedListeners() {
 		if listener.propagateBlockAddedNotifications {
-			err := router.OutgoingRoute().MaybeEnqueue(notification)
+			err := listener.maybeEnqueue(notification)
 			if err != nil {
 				return err
 			}
@@ -119,21 +200,21 @@ func (nm *NotificationManager) NotifyVirtualSelectedParentChainChanged(
 	nm.RLock()
 	defer nm.RUnlock()
 
-	notificationWithoutAcceptedTransactionIDs := &appmessage.VirtualSelectedParentChainChangedNotificationMessage{
-		RemovedChainBlockHashes: notification.RemovedChainBlockHashes,
-		AddedChainBlockHashes:   notification.AddedChainBlockHashes,
-	}
-
-	for router, listener := range nm.listeners {
+	for _, listener := range nm.notifiedListeners() {
 		if listener.propagateVirtualSelectedParentChainChangedNotifications {
-			var err error
-
+			// Strip the parts of the notification the listener did not ask for
+			listenerNotification := &appmessage.VirtualSelectedParentChainChangedNotificationMessage{
+				RemovedChainBlockHashes: notification.RemovedChainBlockHashes,
+				AddedChainBlockHashes:   notification.AddedChainBlockHashes,
+			}
 			if listener.includeAcceptedTransactionIDsInVirtualSelectedParentChainChangedNotifications {
-				err = router.OutgoingRoute().MaybeEnqueue(notification)
-			} else {
-				err = router.OutgoingRoute().MaybeEnqueue(notificationWithoutAcceptedTransactionIDs)
+				listenerNotification.AcceptedTransactionIDs = notification.AcceptedTransactionIDs
+			}
+			if listener.includeAcceptanceDataInVirtualSelectedParentChainChangedNotifications {
+				listenerNotification.AcceptanceData = notification.AcceptanceData
 			}
 
+			err := listener.maybeEnqueue(listenerNotification)
 			if err != nil {
 				return err
 			}
@@ -144,27 +225,87 @@ func (nm *NotificationManager) NotifyVirtualSelectedParentChainChanged(
 
 // HasListenersThatPropagateVirtualSelectedParentChainChanged returns whether there's any listener that is
 // subscribed to VirtualSelectedParentChainChanged notifications as well as checks if any such listener requested
-// to include AcceptedTransactionIDs.
-func (nm *NotificationManager) HasListenersThatPropagateVirtualSelectedParentChainChanged() (hasListeners, hasListenersThatRequireAcceptedTransactionIDs bool) {
+// to include AcceptedTransactionIDs or AcceptanceData.
+func (nm *NotificationManager) HasListenersThatPropagateVirtualSelectedParentChainChanged() (hasListeners,
+	hasListenersThatRequireAcceptedTransactionIDs, hasListenersThatRequireAcceptanceData bool) {
 
 	nm.RLock()
 	defer nm.RUnlock()
 
-	hasListeners = false
-	hasListenersThatRequireAcceptedTransactionIDs = false
-
-	for _, listener := range nm.listeners {
+	for _, listener := range nm.notifiedListeners() {
 		if listener.propagateVirtualSelectedParentChainChangedNotifications {
 			hasListeners = true
-			// Generating acceptedTransactionIDs is a heavy operation, so we check if it's needed by any listener.
+			// Generating acceptedTransactionIDs and acceptanceData are heavy operations,
+			// so we check if they're needed by any listener.
 			if listener.includeAcceptedTransactionIDsInVirtualSelectedParentChainChangedNotifications {
 				hasListenersThatRequireAcceptedTransactionIDs = true
-				break
+			}
+			if listener.includeAcceptanceDataInVirtualSelectedParentChainChangedNotifications {
+				hasListenersThatRequireAcceptanceData = true
+			}
+		}
+	}
+
+	return hasListeners, hasListenersThatRequireAcceptedTransactionIDs, hasListenersThatRequireAcceptanceData
+}
+
+// HasNewTipListeners indicates if the notification manager has any listeners for `NewTip` events
+func (nm *NotificationManager) HasNewTipListeners() bool {
+	nm.RLock()
+	defer nm.RUnlock()
+
+	for _, listener := range nm.notifiedListeners() {
+		if listener.propagateNewTipNotifications {
+			return true
+		}
+	}
+	return false
+}
+
+// NotifyNewTip notifies the notification manager that a new tip has been added to the DAG
+func (nm *NotificationManager) NotifyNewTip(notification *appmessage.NewTipNotificationMessage) error {
+	nm.RLock()
+	defer nm.RUnlock()
+
+	for _, listener := range nm.notifiedListeners() {
+		if listener.propagateNewTipNotifications {
+			err := listener.maybeEnqueue(notification)
+			if err != nil {
+				return err
 			}
 		}
 	}
+	return nil
+}
+
+// HasFinalityPointAdvancedListeners indicates if the notification manager has any listeners
+// for `FinalityPointAdvanced` events
+func (nm *NotificationManager) HasFinalityPointAdvancedListeners() bool {
+	nm.RLock()
+	defer nm.RUnlock()
 
-	return hasListeners, hasListenersThatRequireAcceptedTransactionIDs
+	for _, listener := range nm.notifiedListeners() {
+		if listener.propagateFinalityPointAdvancedNotifications {
+			return true
+		}
+	}
+	return false
+}
+
+// NotifyFinalityPointAdvanced notifies the notification manager that the virtual's finality point has advanced
+func (nm *NotificationManager) NotifyFinalityPointAdvanced(notification *appmessage.FinalityPointAdvancedNotificationMessage) error {
+	nm.RLock()
+	defer nm.RUnlock()
+
+	for _, listener := range nm.notifiedListeners() {
+		if listener.propagateFinalityPointAdvancedNotifications {
+			err := listener.maybeEnqueue(notification)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 // NotifyFinalityConflict notifies the notification manager that there's a finality conflict in the DAG
@@ -172,9 +313,9 @@ func (nm *NotificationManager) NotifyFinalityConflict(notification *appmessage.F
 	nm.RLock()
 	defer nm.RUnlock()
 
-	for router, listener := range nm.listeners {
+	for _, listener := range nm.notifiedListeners() {
 		if listener.propagateFinalityConflictNotifications {
-			err := router.OutgoingRoute().Enqueue(notification)
+			err := listener.enqueue(notification)
 			if err != nil {
 				return err
 			}
@@ -188,9 +329,9 @@ func (nm *NotificationManager) NotifyFinalityConflictResolved(notification *appm
 	nm.RLock()
 	defer nm.RUnlock()
 
-	for router, listener := range nm.listeners {
+	for _, listener := range nm.notifiedListeners() {
 		if listener.propagateFinalityConflictResolvedNotifications {
-			err := router.OutgoingRoute().Enqueue(notification)
+			err := listener.enqueue(notification)
 			if err != nil {
 				return err
 			}
@@ -204,7 +345,7 @@ func (nm *NotificationManager) NotifyUTXOsChanged(utxoChanges *utxoindex.UTXOCha
 	nm.RLock()
 	defer nm.RUnlock()
 
-	for router, listener := range nm.listeners {
+	for _, listener := range nm.notifiedListeners() {
 		if listener.propagateUTXOsChangedNotifications {
 			// Filter utxoChanges and create a notification
 			notification, err := listener.convertUTXOChangesToUTXOsChangedNotification(utxoChanges)
@@ -218,7 +359,7 @@ func (nm *NotificationManager) NotifyUTXOsChanged(utxoChanges *utxoindex.UTXOCha
 			}
 
 			// Enqueue the notification
-			err = router.OutgoingRoute().MaybeEnqueue(notification)
+			err = listener.maybeEnqueue(notification)
 			if err != nil {
 				return err
 			}
@@ -227,6 +368,108 @@ func (nm *NotificationManager) NotifyUTXOsChanged(utxoChanges *utxoindex.UTXOCha
 	return nil
 }
 
+// HasMempoolUTXOsChangedListeners returns true if there's any listener that is
+// currently interested in UTXOs changed by transactions entering the mempool
+func (nm *NotificationManager) HasMempoolUTXOsChangedListeners() bool {
+	nm.RLock()
+	defer nm.RUnlock()
+
+	for _, listener := range nm.notifiedListeners() {
+		if listener.propagateUTXOsChangedNotifications && listener.includeMempoolInUTXOsChangedNotifications {
+			return true
+		}
+	}
+	return false
+}
+
+// NotifyMempoolUTXOsChanged notifies the notification manager that transactions entering
+// the mempool created and spent the given UTXOs
+func (nm *NotificationManager) NotifyMempoolUTXOsChanged(utxoChanges *utxoindex.UTXOChanges) error {
+	nm.RLock()
+	defer nm.RUnlock()
+
+	for _, listener := range nm.notifiedListeners() {
+		if listener.propagateUTXOsChangedNotifications && listener.includeMempoolInUTXOsChangedNotifications {
+			// Filter utxoChanges and create a notification
+			filtered, err := listener.convertUTXOChangesToUTXOsChangedNotification(utxoChanges)
+			if err != nil {
+				return err
+			}
+
+			// Don't send the notification if it's empty
+			if len(filtered.Added) == 0 && len(filtered.Removed) == 0 {
+				continue
+			}
+
+			notification := appmessage.NewUTXOsChangedNotificationMessage()
+			notification.MempoolAdded = filtered.Added
+			notification.MempoolRemoved = filtered.Removed
+			err = listener.maybeEnqueue(notification)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// HasNewTransactionsListeners indicates if the notification manager has any listeners
+// for `NewTransactions` events
+func (nm *NotificationManager) HasNewTransactionsListeners() bool {
+	nm.RLock()
+	defer nm.RUnlock()
+
+	for _, listener := range nm.notifiedListeners() {
+		if listener.propagateNewTransactionsNotifications {
+			return true
+		}
+	}
+	return false
+}
+
+// NotifyNewTransactions notifies the notification manager that the given transactions
+// have entered the mempool. Every listener is only sent the transactions that pass its
+// filter. toRPCTransaction is called at most once for every transaction.
+func (nm *NotificationManager) NotifyNewTransactions(transactions []*externalapi.DomainTransaction,
+	toRPCTransaction func(transaction *externalapi.DomainTransaction) (*appmessage.RPCTransaction, error)) error {
+
+	nm.RLock()
+	defer nm.RUnlock()
+
+	rpcTransactions := make([]*appmessage.RPCTransaction, len(transactions))
+	for _, listener := range nm.notifiedListeners() {
+		if !listener.propagateNewTransactionsNotifications {
+			continue
+		}
+
+		var matchingTransactions []*appmessage.RPCTransaction
+		for i, transaction := range transactions {
+			if !listener.newTransactionsFilter.Matches(transaction) {
+				continue
+			}
+			if rpcTransactions[i] == nil {
+				rpcTransaction, err := toRPCTransaction(transaction)
+				if err != nil {
+					return err
+				}
+				rpcTransactions[i] = rpcTransaction
+			}
+			matchingTransactions = append(matchingTransactions, rpcTransactions[i])
+		}
+
+		// Don't send the notification if it's empty
+		if len(matchingTransactions) == 0 {
+			continue
+		}
+
+		err := listener.maybeEnqueue(appmessage.NewNewTransactionsNotificationMessage(matchingTransactions))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // NotifyVirtualSelectedParentBlueScoreChanged notifies the notification manager that the DAG's
 // virtual selected parent blue score has changed
 func (nm *NotificationManager) NotifyVirtualSelectedParentBlueScoreChanged(
@@ -235,9 +478,9 @@ func (nm *NotificationManager) NotifyVirtualSelectedParentBlueScoreChanged(
 	nm.RLock()
 	defer nm.RUnlock()
 
-	for router, listener := range nm.listeners {
+	for _, listener := range nm.notifiedListeners() {
 		if listener.propagateVirtualSelectedParentBlueScoreChangedNotifications {
-			err := router.OutgoingRoute().MaybeEnqueue(notification)
+			err := listener.maybeEnqueue(notification)
 			if err != nil {
 				return err
 			}
@@ -254,9 +497,9 @@ func (nm *NotificationManager) NotifyVirtualDaaScoreChanged(
 	nm.RLock()
 	defer nm.RUnlock()
 
-	for router, listener := range nm.listeners {
+	for _, listener := range nm.notifiedListeners() {
 		if listener.propagateVirtualDaaScoreChangedNotifications {
-			err := router.OutgoingRoute().MaybeEnqueue(notification)
+			err := listener.maybeEnqueue(notification)
 			if err != nil {
 				return err
 			}
@@ -273,9 +516,45 @@ func (nm *NotificationManager) NotifyNewBlockTemplate(
 	nm.RLock()
 	defer nm.RUnlock()
 
-	for router, listener := range nm.listeners {
+	for _, listener := range nm.notifiedListeners() {
 		if listener.propagateNewBlockTemplateNotifications {
-			err := router.OutgoingRoute().Enqueue(notification)
+			err := listener.enqueue(notification)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// HasWorkListeners indicates if the notification manager has any listeners for `Work` events
+func (nm *NotificationManager) HasWorkListeners() bool {
+	nm.RLock()
+	defer nm.RUnlock()
+
+	for _, listener := range nm.notifiedListeners() {
+		if listener.propagateWorkNotifications {
+			return true
+		}
+	}
+	return false
+}
+
+// NotifyWork sends new work to all the listeners registered for Work notifications.
+// buildWork is called for every listener with its requested pay address and extra data.
+func (nm *NotificationManager) NotifyWork(
+	buildWork func(payAddress util.Address, extraData string) (*appmessage.WorkNotificationMessage, error)) error {
+
+	nm.RLock()
+	defer nm.RUnlock()
+
+	for _, listener := range nm.notifiedListeners() {
+		if listener.propagateWorkNotifications {
+			notification, err := buildWork(listener.workPayAddress, listener.workExtraData)
+			if err != nil {
+				return err
+			}
+			err = listener.maybeEnqueue(notification)
 			if err != nil {
 				return err
 			}
@@ -290,9 +569,27 @@ func (nm *NotificationManager) NotifyPruningPointUTXOSetOverride() error {
 	nm.RLock()
 	defer nm.RUnlock()
 
-	for router, listener := range nm.listeners {
+	for _, listener := range nm.notifiedListeners() {
 		if listener.propagatePruningPointUTXOSetOverrideNotifications {
-			err := router.OutgoingRoute().Enqueue(appmessage.NewPruningPointUTXOSetOverrideNotificationMessage())
+			err := listener.enqueue(appmessage.NewPruningPointUTXOSetOverrideNotificationMessage())
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// NotifyJobProgress notifies the notification manager that a job started,
+// progressed or stopped. Progress notifications may be dropped if a
+// listener's route is full, since a later one supersedes them
+func (nm *NotificationManager) NotifyJobProgress(notification *appmessage.JobProgressNotificationMessage, mayDrop bool) error {
+	nm.RLock()
+	defer nm.RUnlock()
+
+	for _, listener := range nm.notifiedListeners() {
+		if listener.propagateJobProgressNotifications {
+			err := listener.send(notification, mayDrop)
 			if err != nil {
 				return err
 			}
@@ -301,9 +598,10 @@ func (nm *NotificationManager) NotifyPruningPointUTXOSetOverride() error {
 	return nil
 }
 
-func newNotificationListener(params *dagconfig.Params) *NotificationListener {
+func newNotificationListener(params *dagconfig.Params, router *routerpkg.Router) *NotificationListener {
 	return &NotificationListener{
 		params: params,
+		router: router,
 
 		propagateBlockAddedNotifications:                            false,
 		propagateVirtualSelectedParentChainChangedNotifications:     false,
@@ -313,9 +611,42 @@ func newNotificationListener(params *dagconfig.Params) *NotificationListener {
 		propagateVirtualSelectedParentBlueScoreChangedNotifications: false,
 		propagateNewBlockTemplateNotifications:                      false,
 		propagatePruningPointUTXOSetOverrideNotifications:           false,
+		propagateNewTipNotifications:                                false,
+		propagateFinalityPointAdvancedNotifications:                 false,
+		propagateNewTransactionsNotifications:                       false,
+		propagateJobProgressNotifications:                           false,
 	}
 }
 
+// enqueue sends the given notification to the listener's client
+func (nl *NotificationListener) enqueue(notification appmessage.Message) error {
+	return nl.send(notification, false)
+}
+
+// maybeEnqueue sends the given notification to the listener's client, unless
+// the client's route is closed or full
+func (nl *NotificationListener) maybeEnqueue(notification appmessage.Message) error {
+	return nl.send(notification, true)
+}
+
+func (nl *NotificationListener) send(notification appmessage.Message, mayDrop bool) error {
+	if nl.session != nil {
+		// The session is locked until the notification is enqueued, so that
+		// notifications are enqueued in the order of their sequence
+		nl.session.Lock()
+		defer nl.session.Unlock()
+
+		notification = nl.session.add(notification)
+		if nl.router == nil {
+			return nil
+		}
+	}
+	if mayDrop {
+		return nl.router.OutgoingRoute().MaybeEnqueue(notification)
+	}
+	return nl.router.OutgoingRoute().Enqueue(notification)
+}
+
 // IncludeAcceptedTransactionIDsInVirtualSelectedParentChainChangedNotifications returns true if this listener
 // includes accepted transaction IDs in it's virtual-selected-parent-chain-changed notifications
 func (nl *NotificationListener) IncludeAcceptedTransactionIDsInVirtualSelectedParentChainChangedNotifications() bool {
@@ -328,11 +659,64 @@ func (nl *NotificationListener) PropagateBlockAddedNotifications() {
 	nl.propagateBlockAddedNotifications = true
 }
 
+// IncludeAcceptanceDataInVirtualSelectedParentChainChangedNotifications returns true if this listener
+// includes acceptance data in it's virtual-selected-parent-chain-changed notifications
+func (nl *NotificationListener) IncludeAcceptanceDataInVirtualSelectedParentChainChangedNotifications() bool {
+	return nl.includeAcceptanceDataInVirtualSelectedParentChainChangedNotifications
+}
+
 // PropagateVirtualSelectedParentChainChangedNotifications instructs the listener to send chain changed notifications
 // to the remote listener
-func (nl *NotificationListener) PropagateVirtualSelectedParentChainChangedNotifications(includeAcceptedTransactionIDs bool) {
+func (nl *NotificationListener) PropagateVirtualSelectedParentChainChangedNotifications(
+	includeAcceptedTransactionIDs bool, includeAcceptanceData bool) {
+
 	nl.propagateVirtualSelectedParentChainChangedNotifications = true
 	nl.includeAcceptedTransactionIDsInVirtualSelectedParentChainChangedNotifications = includeAcceptedTransactionIDs
+	nl.includeAcceptanceDataInVirtualSelectedParentChainChangedNotifications = includeAcceptanceData
+}
+
+// StopPropagatingVirtualSelectedParentChainChangedNotifications instructs the listener to stop sending
+// chain changed notifications to the remote listener
+func (nl *NotificationListener) StopPropagatingVirtualSelectedParentChainChangedNotifications() {
+	nl.propagateVirtualSelectedParentChainChangedNotifications = false
+	nl.includeAcceptedTransactionIDsInVirtualSelectedParentChainChangedNotifications = false
+	nl.includeAcceptanceDataInVirtualSelectedParentChainChangedNotifications = false
+}
+
+// PropagateNewTipNotifications instructs the listener to send new tip notifications
+// to the remote listener
+func (nl *NotificationListener) PropagateNewTipNotifications() {
+	nl.propagateNewTipNotifications = true
+}
+
+// StopPropagatingNewTipNotifications instructs the listener to stop sending new tip
+// notifications to the remote listener
+func (nl *NotificationListener) StopPropagatingNewTipNotifications() {
+	nl.propagateNewTipNotifications = false
+}
+
+// PropagateFinalityPointAdvancedNotifications instructs the listener to send finality point
+// advanced notifications to the remote listener
+func (nl *NotificationListener) PropagateFinalityPointAdvancedNotifications() {
+	nl.propagateFinalityPointAdvancedNotifications = true
+}
+
+// StopPropagatingFinalityPointAdvancedNotifications instructs the listener to stop sending
+// finality point advanced notifications to the remote listener
+func (nl *NotificationListener) StopPropagatingFinalityPointAdvancedNotifications() {
+	nl.propagateFinalityPointAdvancedNotifications = false
+}
+
+// PropagateJobProgressNotifications instructs the listener to send job progress
+// notifications to the remote listener
+func (nl *NotificationListener) PropagateJobProgressNotifications() {
+	nl.propagateJobProgressNotifications = true
+}
+
+// StopPropagatingJobProgressNotifications instructs the listener to stop sending
+// job progress notifications to the remote listener
+func (nl *NotificationListener) StopPropagatingJobProgressNotifications() {
+	nl.propagateJobProgressNotifications = false
 }
 
 // PropagateFinalityConflictNotifications instructs the listener to send finality conflict notifications
@@ -350,8 +734,11 @@ func (nl *NotificationListener) PropagateFinalityConflictResolvedNotifications()
 // PropagateUTXOsChangedNotifications instructs the listener to send UTXOs changed notifications
 // to the remote listener for the given addresses. Subsequent calls instruct the listener to
 // send UTXOs changed notifications for those addresses along with the old ones. Duplicate addresses
-// are ignored.
-func (nm *NotificationManager) PropagateUTXOsChangedNotifications(nl *NotificationListener, addresses []*UTXOsChangedNotificationAddress) {
+// are ignored. includeMempool determines whether UTXOs changed by transactions entering the mempool
+// are sent as well, and replaces the value of previous calls.
+func (nm *NotificationManager) PropagateUTXOsChangedNotifications(nl *NotificationListener,
+	addresses []*UTXOsChangedNotificationAddress, includeMempool bool) {
+
 	// Apply a write-lock since the internal listener address map is modified
 	nm.Lock()
 	defer nm.Unlock()
@@ -361,6 +748,7 @@ func (nm *NotificationManager) PropagateUTXOsChangedNotifications(nl *Notificati
 		nl.propagateUTXOsChangedNotificationAddresses =
 			make(map[utxoindex.ScriptPublicKeyString]*UTXOsChangedNotificationAddress, len(addresses))
 	}
+	nl.includeMempoolInUTXOsChangedNotifications = includeMempool
 
 	for _, address := range addresses {
 		nl.propagateUTXOsChangedNotificationAddresses[address.ScriptPublicKeyString] = address
@@ -369,7 +757,8 @@ func (nm *NotificationManager) PropagateUTXOsChangedNotifications(nl *Notificati
 
 // StopPropagatingUTXOsChangedNotifications instructs the listener to stop sending UTXOs
 // changed notifications to the remote listener for the given addresses. Addresses for which
-// notifications are not currently sent are ignored.
+// notifications are not currently sent are ignored. If no addresses are given, or if the last
+// address is removed, the listener stops sending UTXOs changed notifications entirely.
 func (nm *NotificationManager) StopPropagatingUTXOsChangedNotifications(nl *NotificationListener, addresses []*UTXOsChangedNotificationAddress) {
 	// Apply a write-lock since the internal listener address map is modified
 	nm.Lock()
@@ -379,9 +768,40 @@ func (nm *NotificationManager) StopPropagatingUTXOsChangedNotifications(nl *Noti
 		return
 	}
 
+	// An empty address set means all addresses, so removing the last
+	// address must not widen the subscription
+	hadAddresses := len(nl.propagateUTXOsChangedNotificationAddresses) > 0
 	for _, address := range addresses {
 		delete(nl.propagateUTXOsChangedNotificationAddresses, address.ScriptPublicKeyString)
 	}
+	if len(addresses) == 0 || (hadAddresses && len(nl.propagateUTXOsChangedNotificationAddresses) == 0) {
+		nl.propagateUTXOsChangedNotifications = false
+		nl.propagateUTXOsChangedNotificationAddresses = nil
+		nl.includeMempoolInUTXOsChangedNotifications = false
+	}
+}
+
+// PropagateNewTransactionsNotifications instructs the listener to send new transactions
+// notifications, about the transactions that pass the given filter, to the remote listener.
+// Subsequent calls replace the filter of previous calls.
+func (nm *NotificationManager) PropagateNewTransactionsNotifications(nl *NotificationListener, filter *NewTransactionsFilter) {
+	// Apply a write-lock since the listener's filter is modified
+	nm.Lock()
+	defer nm.Unlock()
+
+	nl.propagateNewTransactionsNotifications = true
+	nl.newTransactionsFilter = filter
+}
+
+// StopPropagatingNewTransactionsNotifications instructs the listener to stop sending
+// new transactions notifications to the remote listener
+func (nm *NotificationManager) StopPropagatingNewTransactionsNotifications(nl *NotificationListener) {
+	// Apply a write-lock since the listener's filter is modified
+	nm.Lock()
+	defer nm.Unlock()
+
+	nl.propagateNewTransactionsNotifications = false
+	nl.newTransactionsFilter = nil
 }
 
 func (nl *NotificationListener) convertUTXOChangesToUTXOsChangedNotification(
@@ -478,6 +898,19 @@ func (nl *NotificationListener) PropagateNewBlockTemplateNotifications() {
 	nl.propagateNewBlockTemplateNotifications = true
 }
 
+// PropagateWorkNotifications instructs the listener to send work notifications,
+// paying to payAddress, to the remote listener. Subsequent calls replace the
+// pay address and extra data of previous calls.
+func (nm *NotificationManager) PropagateWorkNotifications(nl *NotificationListener, payAddress util.Address, extraData string) {
+	// Apply a write-lock since the listener's work parameters are modified
+	nm.Lock()
+	defer nm.Unlock()
+
+	nl.propagateWorkNotifications = true
+	nl.workPayAddress = payAddress
+	nl.workExtraData = extraData
+}
+
 // PropagatePruningPointUTXOSetOverrideNotifications instructs the listener to send pruning point UTXO set override notifications
 // to the remote listener.
 func (nl *NotificationListener) PropagatePruningPointUTXOSetOverrideNotifications() {
@@ -0,0 +1,391 @@
+package rpccontext
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/utxoindex"
+	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+const (
+	// rescanChunkSize is the number of chain blocks a rescan scans
+	// between two of its progress notifications
+	rescanChunkSize = 100
+
+	// rescanEnqueueRetryInterval is how long a rescan waits before it
+	// retries to send a notification to a client whose route is full
+	rescanEnqueueRetryInterval = 100 * time.Millisecond
+)
+
+// RescanFilter selects the accepted transactions that a rescan matches
+type RescanFilter struct {
+	// ScriptPublicKeys are the scripts that a matching transaction
+	// pays, or spends from
+	ScriptPublicKeys map[utxoindex.ScriptPublicKeyString]struct{}
+
+	// Outpoints are the outpoints that a matching transaction spends
+	Outpoints map[externalapi.DomainOutpoint]struct{}
+}
+
+// match returns whether the given accepted transaction passes the filter. The
+// outputs of a matching transaction that pay one of ScriptPublicKeys are added
+// to Outpoints, so that the transactions spending them match as well.
+func (f *RescanFilter) match(transactionAcceptanceData *externalapi.TransactionAcceptanceData) bool {
+	transaction := transactionAcceptanceData.Transaction
+	isMatch := false
+	for i, input := range transaction.Inputs {
+		if _, ok := f.Outpoints[input.PreviousOutpoint]; ok {
+			isMatch = true
+			break
+		}
+		if i < len(transactionAcceptanceData.TransactionInputUTXOEntries) {
+			scriptPublicKey := transactionAcceptanceData.TransactionInputUTXOEntries[i].ScriptPublicKey()
+			if _, ok := f.ScriptPublicKeys[utxoindex.ScriptPublicKeyString(scriptPublicKey.String())]; ok {
+				isMatch = true
+				break
+			}
+		}
+	}
+
+	var transactionID *externalapi.DomainTransactionID
+	for i, output := range transaction.Outputs {
+		if _, ok := f.ScriptPublicKeys[utxoindex.ScriptPublicKeyString(output.ScriptPublicKey.String())]; !ok {
+			continue
+		}
+		isMatch = true
+		if transactionID == nil {
+			transactionID = consensushashing.TransactionID(transaction)
+		}
+		f.Outpoints[externalapi.DomainOutpoint{TransactionID: *transactionID, Index: uint32(i)}] = struct{}{}
+	}
+	return isMatch
+}
+
+type rescan struct {
+	router         *routerpkg.Router
+	startBlueScore uint64
+	filter         *RescanFilter
+	job            *Job
+
+	// The fields below are only accessed by the rescan's job
+	targetBlueScore     uint64
+	checkpointBlueScore uint64
+	checkpointBlockHash string
+}
+
+// RescanManager runs the rescans of the RPC clients as jobs, one rescan per
+// client. A rescan walks the transactions accepted by the selected chain and
+// sends the ones that match its filter to its client, which allows importing
+// existing wallets on nodes that run without any index.
+type RescanManager struct {
+	context *Context
+
+	sync.Mutex
+	rescans map[*routerpkg.Router]*rescan
+}
+
+// NewRescanManager creates a new RescanManager
+func NewRescanManager(context *Context) *RescanManager {
+	return &RescanManager{
+		context: context,
+		rescans: make(map[*routerpkg.Router]*rescan),
+	}
+}
+
+// Start starts a rescan of the transactions accepted by the selected chain
+// blocks from the first one whose blue score is at least startBlueScore, and
+// sends the ones that match the given filter to the given router's client in
+// RescanProgress notifications. The rescan that's already running for the
+// router, if there is one, is stopped first. Start returns the blue score the
+// rescan starts from, which is above the pruning point's, and the rescan's job.
+func (rm *RescanManager) Start(router *routerpkg.Router, startBlueScore uint64,
+	filter *RescanFilter) (uint64, *Job, error) {
+
+	rm.Stop(router)
+
+	consensus := rm.context.Domain.Consensus()
+	pruningPoint, err := consensus.PruningPoint()
+	if err != nil {
+		return 0, nil, err
+	}
+	pruningPointInfo, err := consensus.GetBlockInfo(pruningPoint)
+	if err != nil {
+		return 0, nil, err
+	}
+	if startBlueScore <= pruningPointInfo.BlueScore {
+		startBlueScore = pruningPointInfo.BlueScore + 1
+	}
+
+	r := &rescan{
+		router:         router,
+		startBlueScore: startBlueScore,
+		filter:         filter,
+	}
+
+	// The manager stays locked until r.job is set, and the job waits
+	// for it before it uses r.job
+	rm.Lock()
+	rm.rescans[router] = r
+	r.job = rm.context.JobManager.Start(appmessage.RPCJobKindRescan, func(job *Job) (*JobResult, error) {
+		rm.Lock()
+		rm.Unlock()
+
+		log.Debugf("Rescanning from blue score %d", startBlueScore)
+		err := rm.run(r)
+		if errors.Is(err, routerpkg.ErrRouteClosed) {
+			err = ErrJobCancelled
+		}
+		isStoppedByClient := !rm.remove(r)
+		result := &JobResult{Rescan: &appmessage.RPCJobRescanResult{
+			CheckpointBlueScore: r.checkpointBlueScore,
+			CheckpointBlockHash: r.checkpointBlockHash,
+		}}
+		if err == nil {
+			log.Debugf("Finished rescanning from blue score %d", startBlueScore)
+			return result, nil
+		}
+		if errors.Is(err, ErrJobCancelled) {
+			log.Debugf("Stopped rescanning from blue score %d", startBlueScore)
+			if !isStoppedByClient {
+				// The rescan was cancelled by CancelJob, so its client
+				// is told that it won't get any more notifications
+				notification := &appmessage.RescanProgressNotificationMessage{
+					Error: appmessage.RPCErrorf("The rescan was cancelled"),
+				}
+				_ = r.router.OutgoingRoute().Enqueue(notification)
+			}
+			return result, err
+		}
+		log.Warnf("Error rescanning from blue score %d: %s", startBlueScore, err)
+		notification := &appmessage.RescanProgressNotificationMessage{
+			Error: appmessage.RPCErrorf("Rescan failed: %s", err),
+		}
+		_ = r.router.OutgoingRoute().Enqueue(notification)
+		return result, err
+	})
+	rm.Unlock()
+
+	return startBlueScore, r.job, nil
+}
+
+// Stop stops the rescan of the given router, if there is one, and
+// waits for it to exit
+func (rm *RescanManager) Stop(router *routerpkg.Router) {
+	rm.Lock()
+	r, ok := rm.rescans[router]
+	if ok {
+		delete(rm.rescans, router)
+	}
+	rm.Unlock()
+
+	if ok {
+		r.job.Cancel()
+		_, _ = r.job.Wait()
+	}
+}
+
+// remove removes the given rescan from the manager, and returns false if
+// it was already removed by Stop
+func (rm *RescanManager) remove(r *rescan) bool {
+	rm.Lock()
+	defer rm.Unlock()
+
+	if rm.rescans[r.router] != r {
+		return false
+	}
+	delete(rm.rescans, r.router)
+	return true
+}
+
+func (rm *RescanManager) run(r *rescan) error {
+	consensus := rm.context.Domain.Consensus()
+
+	// The first chain block to scan is found by walking the selected
+	// chain down from the virtual's selected parent
+	current, err := consensus.GetVirtualSelectedParent()
+	if err != nil {
+		return err
+	}
+	currentInfo, err := consensus.GetBlockInfo(current)
+	if err != nil {
+		return err
+	}
+	if currentInfo.BlueScore < r.startBlueScore {
+		r.checkpointBlueScore = currentInfo.BlueScore
+		r.checkpointBlockHash = current.String()
+		notification := appmessage.NewRescanProgressNotificationMessage(
+			nil, currentInfo.BlueScore, current.String(), nil, true)
+		return rm.enqueue(r, notification)
+	}
+	r.targetBlueScore = currentInfo.BlueScore
+	for {
+		if r.job.IsCancelled() {
+			return ErrJobCancelled
+		}
+		selectedParentInfo, err := consensus.GetBlockInfo(currentInfo.SelectedParent)
+		if err != nil {
+			return err
+		}
+		if selectedParentInfo.BlueScore < r.startBlueScore {
+			break
+		}
+		current = currentInfo.SelectedParent
+		currentInfo = selectedParentInfo
+	}
+
+	err = rm.scanChainBlocks(r, []*externalapi.DomainHash{current}, nil, false)
+	if err != nil {
+		return err
+	}
+
+	// The selected chain may change while it's scanned, so it's
+	// followed from the last scanned chain block until it's caught up
+	lastScanned := current
+	for {
+		if r.job.IsCancelled() {
+			return ErrJobCancelled
+		}
+		chainPath, err := consensus.GetVirtualSelectedParentChainFromBlock(lastScanned)
+		if err != nil {
+			return err
+		}
+		if len(chainPath.Added) == 0 {
+			return rm.scanChainBlocks(r, []*externalapi.DomainHash{lastScanned}, nil, true)
+		}
+
+		removed := chainPath.Removed
+		for position := 0; position < len(chainPath.Added); position += rescanChunkSize {
+			end := position + rescanChunkSize
+			if end > len(chainPath.Added) {
+				end = len(chainPath.Added)
+			}
+			err := rm.scanChainBlocks(r, chainPath.Added[position:end], removed, false)
+			if err != nil {
+				return err
+			}
+			removed = nil
+			lastScanned = chainPath.Added[end-1]
+		}
+	}
+}
+
+// scanChainBlocks scans the transactions accepted by the given chain blocks,
+// and sends the matching ones in a single notification whose checkpoint is
+// the last of the chain blocks. The chain blocks below the rescan's start
+// blue score are skipped. If isFinished is true, the transactions aren't
+// scanned again, and the notification marks the end of the rescan.
+func (rm *RescanManager) scanChainBlocks(r *rescan, chainBlocks []*externalapi.DomainHash,
+	removedChainBlocks []*externalapi.DomainHash, isFinished bool) error {
+
+	consensus := rm.context.Domain.Consensus()
+
+	removedChainBlockHashes := make([]string, 0, len(removedChainBlocks))
+	for _, removedChainBlock := range removedChainBlocks {
+		removedChainBlockInfo, err := consensus.GetBlockInfo(removedChainBlock)
+		if err != nil {
+			return err
+		}
+		if removedChainBlockInfo.BlueScore >= r.startBlueScore {
+			removedChainBlockHashes = append(removedChainBlockHashes, removedChainBlock.String())
+		}
+	}
+
+	scannedChainBlocks := make([]*externalapi.DomainHash, 0, len(chainBlocks))
+	var checkpointBlueScore uint64
+	for _, chainBlock := range chainBlocks {
+		chainBlockInfo, err := consensus.GetBlockInfo(chainBlock)
+		if err != nil {
+			return err
+		}
+		checkpointBlueScore = chainBlockInfo.BlueScore
+		if chainBlockInfo.BlueScore >= r.startBlueScore {
+			scannedChainBlocks = append(scannedChainBlocks, chainBlock)
+		}
+	}
+
+	var matches []*appmessage.RescanMatch
+	if !isFinished && len(scannedChainBlocks) > 0 {
+		chainBlocksAcceptanceData, err := consensus.GetBlocksAcceptanceData(scannedChainBlocks)
+		if err != nil {
+			return err
+		}
+		for i, chainBlockAcceptanceData := range chainBlocksAcceptanceData {
+			chainBlockMatches, err := rm.matchAcceptanceData(r.filter, scannedChainBlocks[i], chainBlockAcceptanceData)
+			if err != nil {
+				return err
+			}
+			matches = append(matches, chainBlockMatches...)
+		}
+	}
+
+	checkpoint := chainBlocks[len(chainBlocks)-1]
+	notification := appmessage.NewRescanProgressNotificationMessage(
+		matches, checkpointBlueScore, checkpoint.String(), removedChainBlockHashes, isFinished)
+	err := rm.enqueue(r, notification)
+	if err != nil {
+		return err
+	}
+
+	r.checkpointBlueScore = checkpointBlueScore
+	r.checkpointBlockHash = checkpoint.String()
+	if checkpointBlueScore > r.targetBlueScore {
+		r.targetBlueScore = checkpointBlueScore
+	}
+	if checkpointBlueScore >= r.startBlueScore {
+		r.job.SetProgress(checkpointBlueScore-r.startBlueScore, r.targetBlueScore-r.startBlueScore)
+	}
+	return nil
+}
+
+func (rm *RescanManager) matchAcceptanceData(filter *RescanFilter, chainBlock *externalapi.DomainHash,
+	chainBlockAcceptanceData externalapi.AcceptanceData) ([]*appmessage.RescanMatch, error) {
+
+	var matches []*appmessage.RescanMatch
+	for _, blockAcceptanceData := range chainBlockAcceptanceData {
+		var blockHeader externalapi.BlockHeader
+		for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+			if !transactionAcceptanceData.IsAccepted || !filter.match(transactionAcceptanceData) {
+				continue
+			}
+			if blockHeader == nil {
+				var err error
+				blockHeader, err = rm.context.Domain.Consensus().GetBlockHeader(blockAcceptanceData.BlockHash)
+				if err != nil {
+					return nil, err
+				}
+			}
+			rpcTransaction := appmessage.DomainTransactionToRPCTransaction(transactionAcceptanceData.Transaction)
+			err := rm.context.PopulateTransactionWithVerboseData(rpcTransaction, blockHeader)
+			if err != nil {
+				return nil, err
+			}
+			matches = append(matches, &appmessage.RescanMatch{
+				AcceptingBlockHash: chainBlock.String(),
+				Transaction:        rpcTransaction,
+			})
+		}
+	}
+	return matches, nil
+}
+
+// enqueue sends the given notification to the rescan's client. A rescan
+// produces notifications faster than most clients read them, so it waits
+// while the client's route is full instead of dropping them.
+func (rm *RescanManager) enqueue(r *rescan, notification appmessage.Message) error {
+	for {
+		err := r.router.OutgoingRoute().Enqueue(notification)
+		if !errors.Is(err, routerpkg.ErrRouteCapacityReached) {
+			return err
+		}
+		select {
+		case <-r.job.Quit():
+			return ErrJobCancelled
+		case <-time.After(rescanEnqueueRetryInterval):
+		}
+	}
+}
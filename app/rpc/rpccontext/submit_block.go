@@ -0,0 +1,77 @@
+package rpccontext
+
+import (
+	"encoding/json"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/protocol/protocolerrors"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/pkg/errors"
+)
+
+// SubmitBlock adds a block found by a miner to the DAG and relays it. A block that is not
+// accepted results in a response that describes the reason for its rejection.
+// submittedVia names the way the block was submitted, for the log.
+func (ctx *Context) SubmitBlock(domainBlock *externalapi.DomainBlock, allowNonDAABlocks bool,
+	submittedVia string) (*appmessage.SubmitBlockResponseMessage, error) {
+
+	var err error
+	isSynced := false
+	// The node is considered synced if it has peers and consensus state is nearly synced
+	if ctx.ProtocolManager.Context().HasPeers() {
+		isSynced, err = ctx.ProtocolManager.Context().IsNearlySynced()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !ctx.Config.AllowSubmitBlockWhenNotSynced && !isSynced {
+		return &appmessage.SubmitBlockResponseMessage{
+			Error:        appmessage.RPCErrorf("Block not submitted - node is not synced"),
+			RejectReason: appmessage.RejectReasonIsInIBD,
+		}, nil
+	}
+
+	if !allowNonDAABlocks {
+		virtualDAAScore, err := ctx.Domain.Consensus().GetVirtualDAAScore()
+		if err != nil {
+			return nil, err
+		}
+		// A simple heuristic check which signals that the mined block is out of date
+		// and should not be accepted unless user explicitly requests
+		daaWindowSize := uint64(ctx.Config.NetParams().DifficultyAdjustmentWindowSize)
+		if virtualDAAScore > daaWindowSize && domainBlock.Header.DAAScore() < virtualDAAScore-daaWindowSize {
+			return &appmessage.SubmitBlockResponseMessage{
+				Error: appmessage.RPCErrorf("Block rejected. Reason: block DAA score %d is too far "+
+					"behind virtual's DAA score %d", domainBlock.Header.DAAScore(), virtualDAAScore),
+				RejectReason: appmessage.RejectReasonBlockInvalid,
+			}, nil
+		}
+	}
+
+	err = ctx.ProtocolManager.AddBlock(domainBlock)
+	if err != nil {
+		isProtocolOrRuleError := errors.As(err, &ruleerrors.RuleError{}) || errors.As(err, &protocolerrors.ProtocolError{})
+		if !isProtocolOrRuleError {
+			return nil, err
+		}
+
+		jsonBytes, _ := json.MarshalIndent(appmessage.DomainBlockToRPCBlock(domainBlock).Header, "", "    ")
+		if jsonBytes != nil {
+			log.Warnf("The RPC submitted block triggered a rule/protocol error (%s), printing "+
+				"the full header for debug purposes: \n%s", err, string(jsonBytes))
+		}
+
+		return &appmessage.SubmitBlockResponseMessage{
+			Error:        appmessage.RPCErrorf("Block rejected. Reason: %s", err),
+			RejectReason: appmessage.RejectReasonBlockInvalid,
+		}, nil
+	}
+
+	log.Infof("Accepted block %s via %s", consensushashing.BlockHash(domainBlock), submittedVia)
+
+	response := appmessage.NewSubmitBlockResponseMessage()
+	return response, nil
+}
@@ -0,0 +1,93 @@
+package rpccontext
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/pow"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/domain/miningmanager"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/version"
+)
+
+// maxStoredWork is the maximum amount of work kept by the WorkManager. Solutions
+// to work that has been evicted are rejected as stale.
+const maxStoredWork = 256
+
+// WorkManager keeps the block templates behind work handed out to external
+// miners, so that solved work can be turned back into full blocks
+type WorkManager struct {
+	sync.Mutex
+	templates map[string]*externalapi.DomainBlock
+	order     []string
+}
+
+// NewWorkManager creates a new WorkManager
+func NewWorkManager() *WorkManager {
+	return &WorkManager{
+		templates: make(map[string]*externalapi.DomainBlock),
+	}
+}
+
+// Add stores the given block template and returns the ID of its work
+func (wm *WorkManager) Add(template *externalapi.DomainBlock, state *pow.State) string {
+	wm.Lock()
+	defer wm.Unlock()
+
+	id := state.PrePowHash().String()
+	if _, ok := wm.templates[id]; ok {
+		return id
+	}
+
+	if len(wm.order) == maxStoredWork {
+		delete(wm.templates, wm.order[0])
+		wm.order = wm.order[1:]
+	}
+	wm.templates[id] = template
+	wm.order = append(wm.order, id)
+	return id
+}
+
+// Get returns the block template behind the work with the given ID
+func (wm *WorkManager) Get(id string) (*externalapi.DomainBlock, bool) {
+	wm.Lock()
+	defer wm.Unlock()
+
+	template, ok := wm.templates[id]
+	return template, ok
+}
+
+// BuildWork builds a block template paying to payAddress, stores it in the
+// WorkManager, and returns it as work for external miners
+func (ctx *Context) BuildWork(payAddress util.Address, extraData string) (work *appmessage.RPCWork, isSynced bool, err error) {
+	scriptPublicKey, err := txscript.PayToAddrScript(payAddress)
+	if err != nil {
+		return nil, false, err
+	}
+	coinbaseData, _, err := miningmanager.NewCoinbaseData(ctx.Config.NetParams(), scriptPublicKey,
+		[]byte(version.Version()+"/"+extraData), 0)
+	if err != nil {
+		return nil, false, err
+	}
+
+	template, isNearlySynced, err := ctx.Domain.MiningManager().GetBlockTemplate(coinbaseData)
+	if err != nil {
+		return nil, false, err
+	}
+
+	state := pow.NewState(template.Header.ToMutable())
+	id := ctx.WorkManager.Add(template, state)
+	work = &appmessage.RPCWork{
+		ID:         id,
+		PrePowHash: state.PrePowHash().String(),
+		Timestamp:  template.Header.TimeInMilliseconds(),
+		Bits:       template.Header.Bits(),
+		Target:     fmt.Sprintf("%064x", &state.Target),
+		DAAScore:   template.Header.DAAScore(),
+		BlueScore:  template.Header.BlueScore(),
+	}
+	return work, ctx.ProtocolManager.Context().HasPeers() && isNearlySynced, nil
+}
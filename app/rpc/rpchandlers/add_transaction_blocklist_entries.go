@@ -0,0 +1,95 @@
+package rpchandlers
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionid"
+	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+// HandleAddTransactionBlocklistEntries handles the respectively named RPC command
+func HandleAddTransactionBlocklistEntries(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if context.Config.SafeRPC {
+		log.Warn("AddTransactionBlocklistEntries RPC command called while node in safe RPC mode -- ignoring.")
+		response := &appmessage.AddTransactionBlocklistEntriesResponseMessage{}
+		response.Error =
+			appmessage.RPCErrorf("AddTransactionBlocklistEntries RPC command called while node in safe RPC mode")
+		return response, nil
+	}
+
+	addTransactionBlocklistEntriesRequest := request.(*appmessage.AddTransactionBlocklistEntriesRequestMessage)
+	entries, err := toTransactionBlocklistEntries(addTransactionBlocklistEntriesRequest.Entries)
+	if err != nil {
+		errorMessage := &appmessage.AddTransactionBlocklistEntriesResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Could not parse the blocklist entries: %s", err)
+		return errorMessage, nil
+	}
+
+	removedTransactionIDs, err := context.Domain.MiningManager().AddToTransactionBlocklist(entries)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		log.Infof("Added %s to the transaction blocklist. Reason: %s", transactionBlocklistEntryString(entry), entry.Reason)
+	}
+	removedTransactionIDStrings := make([]string, len(removedTransactionIDs))
+	for i, transactionID := range removedTransactionIDs {
+		removedTransactionIDStrings[i] = transactionID.String()
+		log.Infof("Removed the blocklisted transaction %s from the mempool", transactionID)
+	}
+	return appmessage.NewAddTransactionBlocklistEntriesResponseMessage(removedTransactionIDStrings), nil
+}
+
+// toTransactionBlocklistEntries converts the blocklist entries of an RPC
+// request to the entries of the mempool's blocklist
+func toTransactionBlocklistEntries(rpcEntries []*appmessage.TransactionBlocklistEntry) (
+	[]*miningmanagermodel.TransactionBlocklistEntry, error) {
+
+	if len(rpcEntries) == 0 {
+		return nil, errors.New("no entries were given")
+	}
+	entries := make([]*miningmanagermodel.TransactionBlocklistEntry, len(rpcEntries))
+	for i, rpcEntry := range rpcEntries {
+		isTransactionIDSet := rpcEntry.TransactionID != ""
+		isScriptPublicKeySet := rpcEntry.ScriptPublicKey != nil
+		if isTransactionIDSet == isScriptPublicKeySet {
+			return nil, errors.Errorf("entry %d must have either a transaction ID or a script public key", i)
+		}
+		entry := &miningmanagermodel.TransactionBlocklistEntry{Reason: rpcEntry.Reason}
+		if isTransactionIDSet {
+			transactionID, err := transactionid.FromString(rpcEntry.TransactionID)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid transaction ID of entry %d", i)
+			}
+			entry.TransactionID = transactionID
+		} else {
+			script, err := hex.DecodeString(rpcEntry.ScriptPublicKey.Script)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid script public key of entry %d", i)
+			}
+			if len(script) == 0 {
+				return nil, errors.Errorf("the script public key of entry %d is empty", i)
+			}
+			entry.ScriptPublicKey = &externalapi.ScriptPublicKey{
+				Script:  script,
+				Version: rpcEntry.ScriptPublicKey.Version,
+			}
+		}
+		entries[i] = entry
+	}
+	return entries, nil
+}
+
+// transactionBlocklistEntryString describes what entry blocks, for the log
+func transactionBlocklistEntryString(entry *miningmanagermodel.TransactionBlocklistEntry) string {
+	if entry.TransactionID != nil {
+		return fmt.Sprintf("transaction %s", entry.TransactionID)
+	}
+	return fmt.Sprintf("script public key %x (version %d)", entry.ScriptPublicKey.Script, entry.ScriptPublicKey.Version)
+}
@@ -0,0 +1,34 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleBackupDatabase handles the respectively named RPC command
+func HandleBackupDatabase(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if context.Config.SafeRPC {
+		log.Warn("BackupDatabase RPC command called while node in safe RPC mode -- ignoring.")
+		response := appmessage.NewBackupDatabaseResponseMessage()
+		response.Error =
+			appmessage.RPCErrorf("BackupDatabase RPC command called while node in safe RPC mode")
+		return response, nil
+	}
+
+	backupDatabaseRequest := request.(*appmessage.BackupDatabaseRequestMessage)
+	if backupDatabaseRequest.TargetDirectory == "" {
+		response := appmessage.NewBackupDatabaseResponseMessage()
+		response.Error = appmessage.RPCErrorf("targetDirectory is required")
+		return response, nil
+	}
+
+	err := context.DatabaseBackupManager.Start(backupDatabaseRequest.TargetDirectory)
+	if err != nil {
+		response := appmessage.NewBackupDatabaseResponseMessage()
+		response.Error = appmessage.RPCErrorf("Could not back the database up: %s", err)
+		return response, nil
+	}
+
+	return appmessage.NewBackupDatabaseResponseMessage(), nil
+}
@@ -0,0 +1,30 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleCancelJob handles the respectively named RPC command
+func HandleCancelJob(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if context.Config.SafeRPC {
+		log.Warn("CancelJob RPC command called while node in safe RPC mode -- ignoring.")
+		response := appmessage.NewCancelJobResponseMessage()
+		response.Error =
+			appmessage.RPCErrorf("CancelJob RPC command called while node in safe RPC mode")
+		return response, nil
+	}
+
+	cancelJobRequest := request.(*appmessage.CancelJobRequestMessage)
+
+	job, ok := context.JobManager.Job(cancelJobRequest.JobID)
+	if !ok {
+		errorMessage := &appmessage.CancelJobResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Job %d is unknown", cancelJobRequest.JobID)
+		return errorMessage, nil
+	}
+	job.Cancel()
+
+	return appmessage.NewCancelJobResponseMessage(), nil
+}
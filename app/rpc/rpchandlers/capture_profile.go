@@ -0,0 +1,51 @@
+package rpchandlers
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/util/profiling"
+)
+
+// profilesDirectoryName is the name of the directory, inside the app
+// directory, that captured profiles are written to
+const profilesDirectoryName = "profiles"
+
+// HandleCaptureProfile handles the respectively named RPC command
+func HandleCaptureProfile(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if context.Config.SafeRPC {
+		log.Warn("CaptureProfile RPC command called while node in safe RPC mode -- ignoring.")
+		response := &appmessage.CaptureProfileResponseMessage{}
+		response.Error =
+			appmessage.RPCErrorf("CaptureProfile RPC command called while node in safe RPC mode")
+		return response, nil
+	}
+
+	captureProfileRequest := request.(*appmessage.CaptureProfileRequestMessage)
+	duration := time.Duration(captureProfileRequest.DurationSeconds) * time.Second
+	directory := filepath.Join(context.Config.AppDir, profilesDirectoryName)
+
+	log.Infof("Capturing a %s profile", captureProfileRequest.ProfileType)
+	filePath, err := profiling.Capture(captureProfileRequest.ProfileType, duration, directory)
+	if err != nil {
+		errorMessage := &appmessage.CaptureProfileResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Could not capture the profile: %s", err)
+		return errorMessage, nil
+	}
+	log.Infof("Captured a %s profile into %s", captureProfileRequest.ProfileType, filePath)
+
+	var data []byte
+	if captureProfileRequest.IncludeData {
+		data, err = ioutil.ReadFile(filePath)
+		if err != nil {
+			errorMessage := &appmessage.CaptureProfileResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Could not read the profile: %s", err)
+			return errorMessage, nil
+		}
+	}
+	return appmessage.NewCaptureProfileResponseMessage(filePath, data), nil
+}
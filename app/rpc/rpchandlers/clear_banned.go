@@ -0,0 +1,26 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleClearBanned handles the respectively named RPC command
+func HandleClearBanned(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	if context.Config.SafeRPC {
+		log.Warn("ClearBanned RPC command called while node in safe RPC mode -- ignoring.")
+		response := appmessage.NewClearBannedResponseMessage()
+		response.Error =
+			appmessage.RPCErrorf("ClearBanned RPC command called while node in safe RPC mode")
+		return response, nil
+	}
+
+	err := context.AddressManager.ClearBanned()
+	if err != nil {
+		errorMessage := &appmessage.ClearBannedResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Could not clear the bans: %s", err)
+		return errorMessage, nil
+	}
+	return appmessage.NewClearBannedResponseMessage(), nil
+}
@@ -0,0 +1,31 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+// HandleCompactDatabase handles the respectively named RPC command
+func HandleCompactDatabase(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	if context.Config.SafeRPC {
+		log.Warn("CompactDatabase RPC command called while node in safe RPC mode -- ignoring.")
+		response := appmessage.NewCompactDatabaseResponseMessage()
+		response.Error =
+			appmessage.RPCErrorf("CompactDatabase RPC command called while node in safe RPC mode")
+		return response, nil
+	}
+
+	err := context.DatabaseCompactionManager.Start()
+	if err != nil {
+		if !errors.Is(err, rpccontext.ErrDatabaseCompactionInProgress) {
+			return nil, err
+		}
+		response := appmessage.NewCompactDatabaseResponseMessage()
+		response.Error = appmessage.RPCErrorf("%s", err)
+		return response, nil
+	}
+
+	return appmessage.NewCompactDatabaseResponseMessage(), nil
+}
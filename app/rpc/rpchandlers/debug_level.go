@@ -0,0 +1,47 @@
+package rpchandlers
+
+import (
+	"sort"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleDebugLevel handles the respectively named RPC command
+func HandleDebugLevel(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if context.Config.SafeRPC {
+		log.Warn("DebugLevel RPC command called while node in safe RPC mode -- ignoring.")
+		response := &appmessage.DebugLevelResponseMessage{}
+		response.Error =
+			appmessage.RPCErrorf("DebugLevel RPC command called while node in safe RPC mode")
+		return response, nil
+	}
+
+	debugLevelRequest := request.(*appmessage.DebugLevelRequestMessage)
+	if debugLevelRequest.LevelSpec != "" {
+		err := logger.ParseAndSetLogLevels(debugLevelRequest.LevelSpec)
+		if err != nil {
+			errorMessage := &appmessage.DebugLevelResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Could not set the log levels: %s", err)
+			return errorMessage, nil
+		}
+		log.Infof("Log levels set to %s", debugLevelRequest.LevelSpec)
+	}
+
+	levels := logger.LogLevels()
+	subsystems := make([]string, 0, len(levels))
+	for subsystem := range levels {
+		subsystems = append(subsystems, subsystem)
+	}
+	sort.Strings(subsystems)
+	subsystemLogLevels := make([]*appmessage.SubsystemLogLevel, len(subsystems))
+	for i, subsystem := range subsystems {
+		subsystemLogLevels[i] = &appmessage.SubsystemLogLevel{
+			Subsystem: subsystem,
+			Level:     levels[subsystem].Name(),
+		}
+	}
+	return appmessage.NewDebugLevelResponseMessage(subsystemLogLevels), nil
+}
@@ -0,0 +1,28 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleDropIndex handles the respectively named RPC command
+func HandleDropIndex(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if context.Config.SafeRPC {
+		log.Warn("DropIndex RPC command called while node in safe RPC mode -- ignoring.")
+		response := appmessage.NewDropIndexResponseMessage()
+		response.Error =
+			appmessage.RPCErrorf("DropIndex RPC command called while node in safe RPC mode")
+		return response, nil
+	}
+
+	dropIndexRequest := request.(*appmessage.DropIndexRequestMessage)
+	err := context.IndexManager.Drop(dropIndexRequest.IndexName)
+	if err != nil {
+		response := appmessage.NewDropIndexResponseMessage()
+		response.Error = appmessage.RPCErrorf("Could not drop the index: %s", err)
+		return response, nil
+	}
+
+	return appmessage.NewDropIndexResponseMessage(), nil
+}
@@ -0,0 +1,61 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/utxosnapshot"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleDumpUTXOSet handles the respectively named RPC command
+func HandleDumpUTXOSet(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if context.Config.SafeRPC {
+		log.Warn("DumpUTXOSet RPC command called while node in safe RPC mode -- ignoring.")
+		response := &appmessage.DumpUTXOSetResponseMessage{}
+		response.Error =
+			appmessage.RPCErrorf("DumpUTXOSet RPC command called while node in safe RPC mode")
+		return response, nil
+	}
+
+	dumpUTXOSetRequest := request.(*appmessage.DumpUTXOSetRequestMessage)
+	if dumpUTXOSetRequest.FilePath == "" {
+		errorMessage := &appmessage.DumpUTXOSetResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("A file path is required")
+		return errorMessage, nil
+	}
+
+	filePath := dumpUTXOSetRequest.FilePath
+	job := context.JobManager.Start(appmessage.RPCJobKindDumpUTXOSet, func(job *rpccontext.Job) (*rpccontext.JobResult, error) {
+		log.Infof("Dumping the pruning point UTXO set to %s", filePath)
+		snapshot, err := utxosnapshot.Dump(context.Domain.Consensus(), filePath, func(processedUTXOs uint64) error {
+			if job.IsCancelled() {
+				return rpccontext.ErrJobCancelled
+			}
+			job.SetProgress(processedUTXOs, 0)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		log.Infof("Dumped %d UTXOs of pruning point %s to %s",
+			snapshot.UTXOCount, snapshot.PruningPointHash, snapshot.FilePath)
+
+		return &rpccontext.JobResult{UTXOSet: &appmessage.RPCJobUTXOSetResult{
+			PruningPointHash: snapshot.PruningPointHash.String(),
+			UTXOCommitment:   snapshot.UTXOCommitment.String(),
+			UTXOCount:        snapshot.UTXOCount,
+		}}, nil
+	})
+	if dumpUTXOSetRequest.RunAsJob {
+		return appmessage.NewDumpUTXOSetResponseMessage("", "", 0, job.ID()), nil
+	}
+
+	result, err := job.Wait()
+	if err != nil {
+		errorMessage := &appmessage.DumpUTXOSetResponseMessage{JobID: job.ID()}
+		errorMessage.Error = appmessage.RPCErrorf("Could not dump the UTXO set: %s", err)
+		return errorMessage, nil
+	}
+	return appmessage.NewDumpUTXOSetResponseMessage(result.UTXOSet.PruningPointHash,
+		result.UTXOSet.UTXOCommitment, result.UTXOSet.UTXOCount, job.ID()), nil
+}
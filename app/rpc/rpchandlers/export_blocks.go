@@ -0,0 +1,81 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/blockexport"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleExportBlocks handles the respectively named RPC command
+func HandleExportBlocks(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if context.Config.SafeRPC {
+		log.Warn("ExportBlocks RPC command called while node in safe RPC mode -- ignoring.")
+		response := &appmessage.ExportBlocksResponseMessage{}
+		response.Error =
+			appmessage.RPCErrorf("ExportBlocks RPC command called while node in safe RPC mode")
+		return response, nil
+	}
+
+	exportBlocksRequest := request.(*appmessage.ExportBlocksRequestMessage)
+	if exportBlocksRequest.FilePath == "" {
+		errorMessage := &appmessage.ExportBlocksResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("A file path is required")
+		return errorMessage, nil
+	}
+
+	// If lowHash is empty - use the pruning point instead.
+	// If highHash is empty - use the virtual selected parent instead.
+	consensus := context.Domain.Consensus()
+	lowHash, err := consensus.PruningPoint()
+	if err != nil {
+		return nil, err
+	}
+	highHash, err := consensus.GetVirtualSelectedParent()
+	if err != nil {
+		return nil, err
+	}
+	for _, requestHash := range []struct {
+		name  string
+		value string
+		hash  **externalapi.DomainHash
+	}{
+		{name: "lowHash", value: exportBlocksRequest.LowHash, hash: &lowHash},
+		{name: "highHash", value: exportBlocksRequest.HighHash, hash: &highHash},
+	} {
+		if requestHash.value == "" {
+			continue
+		}
+		hash, err := externalapi.NewDomainHashFromString(requestHash.value)
+		if err != nil {
+			errorMessage := &appmessage.ExportBlocksResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Could not decode %s %s: %s",
+				requestHash.name, requestHash.value, err)
+			return errorMessage, nil
+		}
+		blockInfo, err := consensus.GetBlockInfo(hash)
+		if err != nil {
+			return nil, err
+		}
+		if !blockInfo.HasBody() {
+			errorMessage := &appmessage.ExportBlocksResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Could not find %s %s", requestHash.name, hash)
+			return errorMessage, nil
+		}
+		*requestHash.hash = hash
+	}
+
+	log.Infof("Exporting the blocks between %s and %s to %s", lowHash, highHash, exportBlocksRequest.FilePath)
+	exportedFile, err := blockexport.Export(consensus, context.Config.ActiveNetParams.GenesisHash,
+		lowHash, highHash, exportBlocksRequest.FilePath)
+	if err != nil {
+		errorMessage := &appmessage.ExportBlocksResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Could not export the blocks: %s", err)
+		return errorMessage, nil
+	}
+	log.Infof("Exported %d blocks between %s and %s to %s",
+		exportedFile.BlockCount, lowHash, highHash, exportedFile.FilePath)
+
+	return appmessage.NewExportBlocksResponseMessage(lowHash.String(), highHash.String(), exportedFile.BlockCount), nil
+}
@@ -0,0 +1,125 @@
+package rpchandlers
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/protocol/protocolerrors"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/mining"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/domain/miningmanager"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/version"
+	"github.com/pkg/errors"
+)
+
+// maxGeneratedBlocks is the maximum amount of blocks a single GenerateBlocks request may generate
+const maxGeneratedBlocks = 1000
+
+// HandleGenerateBlocks handles the respectively named RPC command
+func HandleGenerateBlocks(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	generateBlocksRequest := request.(*appmessage.GenerateBlocksRequestMessage)
+
+	params := context.Config.NetParams()
+	if params.Net != appmessage.Simnet && params.Net != appmessage.Devnet {
+		errorMessage := &appmessage.GenerateBlocksResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Blocks may be generated only on simnet and devnet")
+		return errorMessage, nil
+	}
+
+	if generateBlocksRequest.Count == 0 || generateBlocksRequest.Count > maxGeneratedBlocks {
+		errorMessage := &appmessage.GenerateBlocksResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Count must be between 1 and %d", maxGeneratedBlocks)
+		return errorMessage, nil
+	}
+
+	payAddress, err := util.DecodeAddress(generateBlocksRequest.PayAddress, params.Prefix)
+	if err != nil {
+		errorMessage := &appmessage.GenerateBlocksResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Could not decode address: %s", err)
+		return errorMessage, nil
+	}
+
+	parentHashes := make([]*externalapi.DomainHash, len(generateBlocksRequest.ParentHashes))
+	for i, parentHashString := range generateBlocksRequest.ParentHashes {
+		parentHashes[i], err = externalapi.NewDomainHashFromString(parentHashString)
+		if err != nil {
+			errorMessage := &appmessage.GenerateBlocksResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Could not parse parent hash %s: %s", parentHashString, err)
+			return errorMessage, nil
+		}
+	}
+
+	scriptPublicKey, err := txscript.PayToAddrScript(payAddress)
+	if err != nil {
+		return nil, err
+	}
+	coinbaseData, _, err := miningmanager.NewCoinbaseData(params, scriptPublicKey, []byte(version.Version()), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	rd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	blockHashes := make([]string, 0, generateBlocksRequest.Count)
+	for i := 0; i < int(generateBlocksRequest.Count); i++ {
+		block, err := buildGeneratedBlock(context, coinbaseData, parentHashes)
+		if err != nil {
+			errorMessage := &appmessage.GenerateBlocksResponseMessage{BlockHashes: blockHashes}
+			errorMessage.Error = appmessage.RPCErrorf("Could not build block: %s", err)
+			return errorMessage, nil
+		}
+
+		if generateBlocksRequest.Timestamp != 0 {
+			header := block.Header.ToMutable()
+			header.SetTimeInMilliseconds(generateBlocksRequest.Timestamp + int64(i)*params.TargetTimePerBlock.Milliseconds())
+			block.Header = header.ToImmutable()
+		}
+		if !params.SkipProofOfWork {
+			mining.SolveBlock(block, rd)
+		}
+
+		err = context.ProtocolManager.AddBlock(block)
+		if err != nil {
+			isProtocolOrRuleError := errors.As(err, &ruleerrors.RuleError{}) || errors.As(err, &protocolerrors.ProtocolError{})
+			if !isProtocolOrRuleError {
+				return nil, err
+			}
+			errorMessage := &appmessage.GenerateBlocksResponseMessage{BlockHashes: blockHashes}
+			errorMessage.Error = appmessage.RPCErrorf("Generated block rejected. Reason: %s", err)
+			return errorMessage, nil
+		}
+
+		blockHash := consensushashing.BlockHash(block)
+		log.Infof("Generated block %s", blockHash)
+		blockHashes = append(blockHashes, blockHash.String())
+		if len(parentHashes) > 0 {
+			parentHashes = []*externalapi.DomainHash{blockHash}
+		}
+	}
+
+	return appmessage.NewGenerateBlocksResponseMessage(blockHashes), nil
+}
+
+// buildGeneratedBlock builds an unsolved block over the given parents, or over the
+// virtual, including mempool transactions, if no parents are given
+func buildGeneratedBlock(context *rpccontext.Context, coinbaseData *externalapi.DomainCoinbaseData,
+	parentHashes []*externalapi.DomainHash) (*externalapi.DomainBlock, error) {
+
+	if len(parentHashes) > 0 {
+		block, _, err := context.Domain.Consensus().BuildBlockWithParents(parentHashes, coinbaseData, nil)
+		return block, err
+	}
+
+	template, _, err := context.Domain.MiningManager().GetBlockTemplate(coinbaseData)
+	if err != nil {
+		return nil, err
+	}
+	// Block templates are cached and shared, so they must not be modified in place
+	return template.Clone(), nil
+}
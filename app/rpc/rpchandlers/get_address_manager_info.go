@@ -0,0 +1,41 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetAddressManagerInfo handles the respectively named RPC command
+func HandleGetAddressManagerInfo(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	info := context.AddressManager.Info()
+
+	response := &appmessage.GetAddressManagerInfoResponseMessage{
+		AddressCount:       uint32(info.AddressCount),
+		BannedAddressCount: uint32(info.BannedAddressCount),
+		TriedCount:         uint32(info.TriedCount),
+		NewCount:           uint32(info.NewCount),
+		BucketOccupancy:    make([]uint32, len(info.BucketOccupancy)),
+		BucketSize:         uint32(info.BucketSize),
+		Sources:            make([]*appmessage.AddressSourceInfo, len(info.Sources)),
+		AgeDistribution:    make([]*appmessage.AddressAgeBucket, len(info.AgeDistribution)),
+	}
+	for i, occupancy := range info.BucketOccupancy {
+		response.BucketOccupancy[i] = uint32(occupancy)
+	}
+	for i, source := range info.Sources {
+		response.Sources[i] = &appmessage.AddressSourceInfo{
+			Group:               source.Group,
+			AddressCount:        uint32(source.AddressCount),
+			ConnectionAttempts:  source.ConnectionAttempts,
+			ConnectionSuccesses: source.ConnectionSuccesses,
+		}
+	}
+	for i, ageBucket := range info.AgeDistribution {
+		response.AgeDistribution[i] = &appmessage.AddressAgeBucket{
+			MaxAge: ageBucket.MaxAge.Milliseconds(),
+			Count:  uint32(ageBucket.Count),
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,48 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/hashes"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/merkle"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+	"github.com/kaspanet/kaspad/domain/miningmanager"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetAuxCommitmentProof handles the respectively named RPC command
+func HandleGetAuxCommitmentProof(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	getAuxCommitmentProofRequest := request.(*appmessage.GetAuxCommitmentProofRequestMessage)
+
+	hash, err := externalapi.NewDomainHashFromString(getAuxCommitmentProofRequest.BlockHash)
+	if err != nil {
+		errorMessage := &appmessage.GetAuxCommitmentProofResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Hash could not be parsed: %s", err)
+		return errorMessage, nil
+	}
+
+	block, found, err := context.Domain.Consensus().GetBlock(hash)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		errorMessage := &appmessage.GetAuxCommitmentProofResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Block %s not found", hash)
+		return errorMessage, nil
+	}
+
+	coinbaseTransaction := block.Transactions[transactionhelper.CoinbaseTransactionIndex]
+	auxCommitment, ok := miningmanager.ExtractAuxCommitment(coinbaseTransaction.Payload)
+	if !ok {
+		errorMessage := &appmessage.GetAuxCommitmentProofResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("The coinbase of block %s has no aux commitment", hash)
+		return errorMessage, nil
+	}
+
+	merkleBranch := merkle.CalculateHashMerkleBranch(block.Transactions, transactionhelper.CoinbaseTransactionIndex)
+	rpcBlock := appmessage.DomainBlockToRPCBlock(&externalapi.DomainBlock{Header: block.Header})
+
+	return appmessage.NewGetAuxCommitmentProofResponseMessage(auxCommitment.String(), rpcBlock.Header,
+		appmessage.DomainTransactionToRPCTransaction(coinbaseTransaction), hashes.ToStrings(merkleBranch)), nil
+}
@@ -42,6 +42,60 @@ func HandleGetBlockDAGInfo(context *rpccontext.Context, _ *router.Router, _ appm
 		return nil, err
 	}
 	response.PruningPointHash = pruningPoint.String()
+	pruningPointHeader, err := consensus.GetBlockHeader(pruningPoint)
+	if err != nil {
+		return nil, err
+	}
+	response.PruningPointDAAScore = pruningPointHeader.DAAScore()
+	response.IsArchival = context.Config.IsArchivalNode
+
+	response.VirtualBlueScore = virtualInfo.BlueScore
+	virtualSelectedParent, err := consensus.GetVirtualSelectedParent()
+	if err != nil {
+		return nil, err
+	}
+	response.VirtualSelectedParentHash = virtualSelectedParent.String()
+	virtualSelectedParentHeader, err := consensus.GetBlockHeader(virtualSelectedParent)
+	if err != nil {
+		return nil, err
+	}
+	response.VirtualSelectedParentTimestamp = virtualSelectedParentHeader.TimeInMilliseconds()
+
+	finalityPoint, err := consensus.VirtualFinalityPoint()
+	if err != nil {
+		return nil, err
+	}
+	response.FinalityPointHash = finalityPoint.String()
+
+	headersSelectedTip, err := consensus.GetHeadersSelectedTip()
+	if err != nil {
+		return nil, err
+	}
+	response.HeadersSelectedTipHash = headersSelectedTip.String()
+	headersSelectedTipHeader, err := consensus.GetBlockHeader(headersSelectedTip)
+	if err != nil {
+		return nil, err
+	}
+
+	isNearlySynced, err := consensus.IsNearlySynced()
+	if err != nil {
+		return nil, err
+	}
+	response.IsSynced = context.ProtocolManager.Context().HasPeers() && isNearlySynced
+	response.IsIBDRunning = context.ProtocolManager.IsIBDRunning()
+	response.SyncProgress = syncProgress(isNearlySynced, virtualInfo.DAAScore, headersSelectedTipHeader.DAAScore())
+
+	response.ConnectedPeerCount = uint32(len(context.ProtocolManager.Peers()))
+	response.MempoolSize = uint64(context.Domain.MiningManager().TransactionCount(true, false))
 
 	return response, nil
 }
+
+// syncProgress estimates how far the virtual is from the headers selected tip,
+// as the ratio between their DAA scores
+func syncProgress(isNearlySynced bool, virtualDAAScore uint64, headersSelectedTipDAAScore uint64) float64 {
+	if isNearlySynced || virtualDAAScore >= headersSelectedTipDAAScore {
+		return 1
+	}
+	return float64(virtualDAAScore) / float64(headersSelectedTipDAAScore)
+}
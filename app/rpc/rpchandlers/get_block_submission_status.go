@@ -0,0 +1,60 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetBlockSubmissionStatus handles the respectively named RPC command
+func HandleGetBlockSubmissionStatus(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	getBlockSubmissionStatusRequest := request.(*appmessage.GetBlockSubmissionStatusRequestMessage)
+
+	submission, ok := context.BlockSubmissionManager.Get(getBlockSubmissionStatusRequest.SubmissionID)
+	if !ok {
+		errorMessage := &appmessage.GetBlockSubmissionStatusResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Block submission %s is unknown or has expired",
+			getBlockSubmissionStatusRequest.SubmissionID)
+		return errorMessage, nil
+	}
+
+	// Orphans are added to the DAG by the protocol once their parents arrive, so their
+	// status is resolved lazily
+	if submission.Status == appmessage.BlockSubmissionStatusOrphan {
+		status, rejectReason, err := resolveOrphanSubmission(context, submission.BlockHash)
+		if err != nil {
+			return nil, err
+		}
+		if status != submission.Status {
+			context.BlockSubmissionManager.SetOutcome(getBlockSubmissionStatusRequest.SubmissionID, status, rejectReason)
+			submission.Status = status
+			submission.RejectReason = rejectReason
+		}
+	}
+
+	return appmessage.NewGetBlockSubmissionStatusResponseMessage(
+		submission.BlockHash.String(), submission.Status, submission.RejectReason), nil
+}
+
+func resolveOrphanSubmission(context *rpccontext.Context, blockHash *externalapi.DomainHash) (
+	status string, rejectReason string, err error) {
+
+	// The orphan pool is checked first, since orphans are removed from it only after
+	// they're added to the DAG
+	if context.ProtocolManager.Context().IsOrphan(blockHash) {
+		return appmessage.BlockSubmissionStatusOrphan, "", nil
+	}
+
+	blockInfo, err := context.Domain.Consensus().GetBlockInfo(blockHash)
+	if err != nil {
+		return "", "", err
+	}
+	if !blockInfo.Exists || blockInfo.BlockStatus == externalapi.StatusHeaderOnly {
+		return appmessage.BlockSubmissionStatusRejected, "block was dropped from the orphan pool", nil
+	}
+	if blockInfo.BlockStatus == externalapi.StatusInvalid {
+		return appmessage.BlockSubmissionStatusRejected, "block is invalid", nil
+	}
+	return appmessage.BlockSubmissionStatusAccepted, "", nil
+}
@@ -1,20 +1,39 @@
 package rpchandlers
 
 import (
+	"time"
+
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
-	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/domain/miningmanager"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 	"github.com/kaspanet/kaspad/util"
 	"github.com/kaspanet/kaspad/version"
+	"github.com/pkg/errors"
 )
 
+// maxBlockTemplateFeeWaitMilliseconds is the maximum time a GetBlockTemplate request may
+// wait for the mempool fees to reach its minFees
+const maxBlockTemplateFeeWaitMilliseconds = 5000
+
 // HandleGetBlockTemplate handles the respectively named RPC command
 func HandleGetBlockTemplate(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
 	getBlockTemplateRequest := request.(*appmessage.GetBlockTemplateRequestMessage)
 
+	switch getBlockTemplateRequest.Mode {
+	case "", appmessage.BlockTemplateModeTemplate:
+	case appmessage.BlockTemplateModeProposal:
+		return handleBlockTemplateProposal(context, getBlockTemplateRequest.Proposal)
+	default:
+		errorMessage := &appmessage.GetBlockTemplateResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Unknown block template mode %s", getBlockTemplateRequest.Mode)
+		return errorMessage, nil
+	}
+
 	payAddress, err := util.DecodeAddress(getBlockTemplateRequest.PayAddress, context.Config.ActiveNetParams.Prefix)
 	if err != nil {
 		errorMessage := &appmessage.GetBlockTemplateResponseMessage{}
@@ -22,25 +41,79 @@ func HandleGetBlockTemplate(context *rpccontext.Context, _ *router.Router, reque
 		return errorMessage, nil
 	}
 
+	if getBlockTemplateRequest.MaxFeeWaitMilliseconds > maxBlockTemplateFeeWaitMilliseconds {
+		errorMessage := &appmessage.GetBlockTemplateResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Max fee wait %dms is above the limit of %dms",
+			getBlockTemplateRequest.MaxFeeWaitMilliseconds, maxBlockTemplateFeeWaitMilliseconds)
+		return errorMessage, nil
+	}
+
 	scriptPublicKey, err := txscript.PayToAddrScript(payAddress)
 	if err != nil {
 		return nil, err
 	}
 
-	coinbaseData := &externalapi.DomainCoinbaseData{ScriptPublicKey: scriptPublicKey, ExtraData: []byte(version.Version() + "/" + getBlockTemplateRequest.ExtraData)}
+	payload := []byte(version.Version() + "/" + getBlockTemplateRequest.ExtraData)
+	if getBlockTemplateRequest.AuxCommitment != "" {
+		auxCommitment, err := externalapi.NewDomainHashFromString(getBlockTemplateRequest.AuxCommitment)
+		if err != nil {
+			errorMessage := &appmessage.GetBlockTemplateResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Could not parse aux commitment: %s", err)
+			return errorMessage, nil
+		}
+		payload = miningmanager.AppendAuxCommitment(payload, auxCommitment)
+	}
+
+	coinbaseData, _, err := miningmanager.NewCoinbaseData(context.Config.NetParams(), scriptPublicKey, payload, 0)
+	if err != nil {
+		if errors.Is(err, miningmanager.ErrCoinbasePayloadTooLong) {
+			errorMessage := &appmessage.GetBlockTemplateResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Coinbase payload is above max length (%d). Try to shorten the extra data.", context.Config.NetParams().MaxCoinbasePayloadLength)
+			return errorMessage, nil
+		}
+		return nil, err
+	}
 
-	templateBlock, isNearlySynced, err := context.Domain.MiningManager().GetBlockTemplate(coinbaseData)
+	options := miningmanager.BlockTemplateOptions{
+		MinFees: getBlockTemplateRequest.MinFees,
+		MaxWait: time.Duration(getBlockTemplateRequest.MaxFeeWaitMilliseconds) * time.Millisecond,
+	}
+	templateBlock, isNearlySynced, templateInfo, err := context.Domain.MiningManager().GetBlockTemplateWithInfo(coinbaseData, options)
 	if err != nil {
 		return nil, err
 	}
 
-	if uint64(len(templateBlock.Transactions[transactionhelper.CoinbaseTransactionIndex].Payload)) > context.Config.NetParams().MaxCoinbasePayloadLength {
+	rpcBlock := appmessage.DomainBlockToRPCBlock(templateBlock)
+
+	return appmessage.NewGetBlockTemplateResponseMessage(rpcBlock, context.ProtocolManager.Context().HasPeers() && isNearlySynced,
+		templateInfo.Version, templateInfo.Age.Milliseconds()), nil
+}
+
+// handleBlockTemplateProposal fully validates the given proposal block, except for its
+// proof of work, without adding it to the DAG or broadcasting it
+func handleBlockTemplateProposal(context *rpccontext.Context, proposal *appmessage.RPCBlock) (appmessage.Message, error) {
+	if proposal == nil {
 		errorMessage := &appmessage.GetBlockTemplateResponseMessage{}
-		errorMessage.Error = appmessage.RPCErrorf("Coinbase payload is above max length (%d). Try to shorten the extra data.", context.Config.NetParams().MaxCoinbasePayloadLength)
+		errorMessage.Error = appmessage.RPCErrorf("A proposal block is required in proposal mode")
 		return errorMessage, nil
 	}
 
-	rpcBlock := appmessage.DomainBlockToRPCBlock(templateBlock)
+	domainBlock, err := appmessage.RPCBlockToDomainBlock(proposal)
+	if err != nil {
+		errorMessage := &appmessage.GetBlockTemplateResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Could not parse proposal block: %s", err)
+		return errorMessage, nil
+	}
+
+	err = context.Domain.Consensus().ValidateBlockProposal(domainBlock)
+	if err != nil {
+		ruleError := ruleerrors.RuleError{}
+		if !errors.As(err, &ruleError) {
+			return nil, err
+		}
+		log.Debugf("Rejected block proposal %s: %s", consensushashing.BlockHash(domainBlock), err)
+		return appmessage.NewGetBlockTemplateProposalResponseMessage(false, ruleError.Name(), err.Error()), nil
+	}
 
-	return appmessage.NewGetBlockTemplateResponseMessage(rpcBlock, context.ProtocolManager.Context().HasPeers() && isNearlySynced), nil
+	return appmessage.NewGetBlockTemplateProposalResponseMessage(true, "", ""), nil
 }
@@ -64,7 +64,8 @@ func HandleGetBlocks(context *rpccontext.Context, _ *router.Router, request appm
 	// If the high hash is equal to virtualSelectedParent it means GetHashesBetween didn't skip any hashes, and
 	// there's space to add the virtualSelectedParent's anticone, otherwise you can't add the anticone because
 	// there's no guarantee that all of the anticone root ancestors will be present.
-	if highHash.Equal(virtualSelectedParent) {
+	reachedVirtual := highHash.Equal(virtualSelectedParent)
+	if reachedVirtual {
 		virtualSelectedParentAnticone, err := context.Domain.Consensus().Anticone(virtualSelectedParent)
 		if err != nil {
 			return nil, err
@@ -72,9 +73,20 @@ func HandleGetBlocks(context *rpccontext.Context, _ *router.Router, request appm
 		blockHashes = append(blockHashes, virtualSelectedParentAnticone...)
 	}
 
+	if getBlocksRequest.Limit > 0 && len(blockHashes) > int(getBlocksRequest.Limit) {
+		blockHashes = blockHashes[:getBlocksRequest.Limit]
+		reachedVirtual = false
+	}
+
 	// Prepare the response
 	response := appmessage.NewGetBlocksResponseMessage()
 	response.BlockHashes = hashes.ToStrings(blockHashes)
+
+	// Every block that wasn't returned is in the future of the last returned
+	// block or in its anticone, so using it as the next lowHash skips nothing
+	if !reachedVirtual {
+		response.NextLowHash = blockHashes[len(blockHashes)-1].String()
+	}
 	if getBlocksRequest.IncludeBlocks {
 		rpcBlocks := make([]*appmessage.RPCBlock, len(blockHashes))
 		for i, blockHash := range blockHashes {
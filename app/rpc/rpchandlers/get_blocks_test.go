@@ -163,5 +163,37 @@ func TestHandleGetBlocks(t *testing.T) {
 		if !reflect.DeepEqual(requestAllExplictly.BlockHashes, hashes.ToStrings(expectedOrder)) {
 			t.Fatalf("TestHandleGetBlocks \nexpected: \n%v\n. actual:\n%v", expectedOrder, requestAllExplictly.BlockHashes)
 		}
+		if requestAllExplictly.NextLowHash != "" {
+			t.Fatalf("TestHandleGetBlocks expected no nextLowHash when reaching the virtual, instead got: %s",
+				requestAllExplictly.NextLowHash)
+		}
+
+		// Iterating with a limit must return all the blocks in order. Consecutive
+		// pages overlap, so blocks that were already seen are skipped
+		const limit = 7
+		pagedOrder := make([]string, 0, len(expectedOrder))
+		seen := make(map[string]struct{})
+		lowHash := consensusConfig.GenesisHash.String()
+		for lowHash != "" {
+			request := appmessage.GetBlocksRequestMessage{LowHash: lowHash, Limit: limit}
+			response, err := rpchandlers.HandleGetBlocks(&fakeContext, nil, &request)
+			if err != nil {
+				t.Fatalf("Unexpected error from HandleGetBlocks: '%v'", err)
+			}
+			page := response.(*appmessage.GetBlocksResponseMessage)
+			if len(page.BlockHashes) > limit {
+				t.Fatalf("TestHandleGetBlocks expected at most %d blocks, instead got %d", limit, len(page.BlockHashes))
+			}
+			for _, blockHash := range page.BlockHashes {
+				if _, ok := seen[blockHash]; !ok {
+					seen[blockHash] = struct{}{}
+					pagedOrder = append(pagedOrder, blockHash)
+				}
+			}
+			lowHash = page.NextLowHash
+		}
+		if !reflect.DeepEqual(pagedOrder, hashes.ToStrings(expectedOrder)) {
+			t.Fatalf("TestHandleGetBlocks paged \nexpected: \n%v\n. actual:\n%v", expectedOrder, pagedOrder)
+		}
 	})
 }
@@ -0,0 +1,99 @@
+package rpchandlers
+
+import (
+	"sort"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// maxSafeChainAnalysisWindow is the largest window of blue scores that may
+// be analyzed in RPC safe mode
+const maxSafeChainAnalysisWindow = 10000
+
+// HandleGetChainAnalysis handles the respectively named RPC command
+func HandleGetChainAnalysis(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	getChainAnalysisRequest := request.(*appmessage.GetChainAnalysisRequestMessage)
+
+	lowBlueScore := getChainAnalysisRequest.LowBlueScore
+	highBlueScore := getChainAnalysisRequest.HighBlueScore
+	if highBlueScore == 0 {
+		virtualSelectedParent, err := context.Domain.Consensus().GetVirtualSelectedParent()
+		if err != nil {
+			return nil, err
+		}
+		virtualSelectedParentInfo, err := context.Domain.Consensus().GetBlockInfo(virtualSelectedParent)
+		if err != nil {
+			return nil, err
+		}
+		highBlueScore = virtualSelectedParentInfo.BlueScore
+	}
+	if lowBlueScore > highBlueScore {
+		errorMessage := &appmessage.GetChainAnalysisResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("LowBlueScore %d is greater than HighBlueScore %d",
+			lowBlueScore, highBlueScore)
+		return errorMessage, nil
+	}
+
+	windowSize := highBlueScore - lowBlueScore + 1
+	if context.Config.SafeRPC && windowSize > maxSafeChainAnalysisWindow {
+		errorMessage := &appmessage.GetChainAnalysisResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Requested window size %d is larger than max allowed in "+
+			"RPC safe mode (%d)", windowSize, maxSafeChainAnalysisWindow)
+		return errorMessage, nil
+	}
+	if windowSize > context.Config.ActiveNetParams.PruningDepth() {
+		errorMessage := &appmessage.GetChainAnalysisResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Requested window size %d is larger than pruning point depth %d",
+			windowSize, context.Config.ActiveNetParams.PruningDepth())
+		return errorMessage, nil
+	}
+
+	analysis, err := context.AnalyzeChain(lowBlueScore, highBlueScore)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &appmessage.GetChainAnalysisResponseMessage{
+		LowBlueScore:    analysis.LowBlueScore,
+		HighBlueScore:   analysis.HighBlueScore,
+		ChainBlockCount: analysis.ChainBlockCount,
+		BlockCount:      analysis.BlockCount,
+		RedBlockCount:   analysis.RedBlockCount,
+		OrphanLatency:   orphanLatencyDistribution(analysis.OrphanLatencies),
+	}
+	if analysis.ChainBlockCount > 0 {
+		response.ParallelismFactor = float64(analysis.BlockCount) / float64(analysis.ChainBlockCount)
+	}
+	if analysis.BlockCount > 0 {
+		response.AverageParentCount = float64(analysis.ParentCount) / float64(analysis.BlockCount)
+		response.RedRate = float64(analysis.RedBlockCount) / float64(analysis.BlockCount)
+	}
+	return response, nil
+}
+
+func orphanLatencyDistribution(latencies []time.Duration) *appmessage.OrphanLatencyDistribution {
+	if len(latencies) == 0 {
+		return &appmessage.OrphanLatencyDistribution{}
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	percentile := func(percent int) uint64 {
+		return uint64(latencies[(len(latencies)-1)*percent/100].Milliseconds())
+	}
+	var total time.Duration
+	for _, latency := range latencies {
+		total += latency
+	}
+	return &appmessage.OrphanLatencyDistribution{
+		Count:              uint64(len(latencies)),
+		MinMilliseconds:    uint64(latencies[0].Milliseconds()),
+		MedianMilliseconds: percentile(50),
+		P90Milliseconds:    percentile(90),
+		P99Milliseconds:    percentile(99),
+		MaxMilliseconds:    uint64(latencies[len(latencies)-1].Milliseconds()),
+		MeanMilliseconds:   uint64((total / time.Duration(len(latencies))).Milliseconds()),
+	}
+}
@@ -1,17 +1,24 @@
 package rpchandlers
 
 import (
+	"sort"
+
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/connmanager"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
 )
 
 // HandleGetConnectedPeerInfo handles the respectively named RPC command
-func HandleGetConnectedPeerInfo(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+func HandleGetConnectedPeerInfo(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	getConnectedPeerInfoRequest := request.(*appmessage.GetConnectedPeerInfoRequestMessage)
+
 	peers := context.ProtocolManager.Peers()
 	ibdPeer := context.ProtocolManager.IBDPeer()
 	infos := make([]*appmessage.GetConnectedPeerInfoMessage, 0, len(peers))
 	for _, peer := range peers {
+		ipInfo := context.ConnectionManager.IPInfo(peer.Connection().NetAddress().IP)
 		info := &appmessage.GetConnectedPeerInfoMessage{
 			ID:                        peer.ID().String(),
 			Address:                   peer.Address(),
@@ -22,9 +29,74 @@ func HandleGetConnectedPeerInfo(context *rpccontext.Context, _ *router.Router, _
 			AdvertisedProtocolVersion: peer.AdvertisedProtocolVersion(),
 			TimeConnected:             peer.TimeConnected().Milliseconds(),
 			IsIBDPeer:                 peer == ibdPeer,
+			NetGroup:                  context.AddressManager.GroupKey(peer.Connection().NetAddress()),
+			Services:                  uint64(peer.Services()),
+			ProtocolVersion:           peer.ProtocolVersion(),
+			IsRelayingTransactions:    peer.IsRelayingTransactions(),
+			MessageStatistics:         peerMessageStatistics(peer.Connection().MessageStatistics()),
+			ASN:                       ipInfo.ASN,
+			Country:                   ipInfo.Country,
+		}
+		if peer.SubnetworkID() != nil {
+			info.SubnetworkID = peer.SubnetworkID().String()
+		}
+		if subnetworkRoles := peer.SubnetworkRoles(); subnetworkRoles != nil {
+			info.SubnetworkRoles = &appmessage.RPCSubnetworkRoles{
+				ValidatedSubnetworkIDs: subnetworkIDStrings(subnetworkRoles.ValidatedSubnetworkIDs),
+				IndexedSubnetworkIDs:   subnetworkIDStrings(subnetworkRoles.IndexedSubnetworkIDs),
+				RelayedSubnetworkIDs:   subnetworkIDStrings(subnetworkRoles.RelayedSubnetworkIDs),
+			}
 		}
 		infos = append(infos, info)
 	}
+
+	// Peers are ordered by ID so that the cursor, which is the ID of the
+	// last peer of the previous page, is stable
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ID < infos[j].ID
+	})
+	start := sort.Search(len(infos), func(i int) bool {
+		return infos[i].ID > getConnectedPeerInfoRequest.Cursor
+	})
+	infos = infos[start:]
+
+	nextCursor := ""
+	size := pageSize(getConnectedPeerInfoRequest.Limit, maxConnectedPeerInfoPageSize)
+	if len(infos) > size {
+		infos = infos[:size]
+		nextCursor = infos[size-1].ID
+	}
+
 	response := appmessage.NewGetConnectedPeerInfoResponseMessage(infos)
+	response.NextCursor = nextCursor
+	response.OutboundDiversity = outboundDiversityStatistics(context.ConnectionManager.OutboundDiversityStatistics())
 	return response, nil
 }
+
+func outboundDiversityStatistics(statistics *connmanager.OutboundDiversityStatistics) *appmessage.OutboundDiversityStatistics {
+	outboundDiversity := &appmessage.OutboundDiversityStatistics{
+		IPMapFile:             statistics.IPMapFile,
+		MaxOutboundPerASN:     uint32(statistics.MaxOutboundPerASN),
+		MaxOutboundPerCountry: uint32(statistics.MaxOutboundPerCountry),
+		SkippedByASN:          statistics.SkippedByASN,
+		SkippedByCountry:      statistics.SkippedByCountry,
+	}
+	if !statistics.IPMapLoadTime.IsZero() {
+		outboundDiversity.IPMapLoadTime = statistics.IPMapLoadTime.UnixMilli()
+	}
+	return outboundDiversity
+}
+
+func peerMessageStatistics(statistics []*server.MessageStatistics) []*appmessage.PeerMessageStatistics {
+	peerStatistics := make([]*appmessage.PeerMessageStatistics, len(statistics))
+	for i, commandStatistics := range statistics {
+		peerStatistics[i] = &appmessage.PeerMessageStatistics{
+			Command:          commandStatistics.Command,
+			MessagesSent:     commandStatistics.MessagesSent,
+			BytesSent:        commandStatistics.BytesSent,
+			MessagesReceived: commandStatistics.MessagesReceived,
+			BytesReceived:    commandStatistics.BytesReceived,
+		}
+	}
+	return peerStatistics
+}
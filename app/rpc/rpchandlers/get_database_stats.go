@@ -0,0 +1,116 @@
+package rpchandlers
+
+import (
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/metricsdb"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// defaultDatabaseStatsBucketDepth reports the stores of every consensus
+// instance separately, since their buckets are nested in a prefix bucket
+const defaultDatabaseStatsBucketDepth = 2
+
+// HandleGetDatabaseStats handles the respectively named RPC command
+func HandleGetDatabaseStats(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	getDatabaseStatsRequest := request.(*appmessage.GetDatabaseStatsRequestMessage)
+
+	stats, err := context.Database.Stats()
+	if err != nil {
+		return nil, err
+	}
+	levels := make([]*appmessage.DatabaseLevelStats, len(stats.Levels))
+	for i, level := range stats.Levels {
+		levels[i] = &appmessage.DatabaseLevelStats{
+			FileCount: level.FileCount,
+			Size:      level.Size,
+		}
+	}
+
+	var buckets []*appmessage.DatabaseBucketStats
+	if getDatabaseStatsRequest.IncludeBuckets {
+		bucketDepth := int(getDatabaseStatsRequest.BucketDepth)
+		if bucketDepth == 0 {
+			bucketDepth = defaultDatabaseStatsBucketDepth
+		}
+		// Scan a snapshot, so that the scan sees a consistent database
+		// without holding up the writes that are made while it runs
+		snapshot, err := context.Database.Snapshot()
+		if err != nil {
+			return nil, err
+		}
+		bucketStats, err := database.CollectBucketStats(snapshot, database.MakeBucket(nil), bucketDepth)
+		snapshot.Release()
+		if err != nil {
+			return nil, err
+		}
+		buckets = make([]*appmessage.DatabaseBucketStats, len(bucketStats))
+		for i, stats := range bucketStats {
+			buckets[i] = &appmessage.DatabaseBucketStats{
+				Bucket:    database.BucketDisplayName(stats.Bucket.Path()),
+				KeyCount:  stats.KeyCount,
+				KeySize:   stats.KeySize,
+				ValueSize: stats.ValueSize,
+			}
+		}
+	}
+
+	cacheStats := context.Domain.Consensus().CacheStats()
+	caches := make([]*appmessage.DatabaseCacheStats, len(cacheStats))
+	for i, stats := range cacheStats {
+		caches[i] = &appmessage.DatabaseCacheStats{
+			Name:     stats.Name,
+			Capacity: uint64(stats.Capacity),
+			Hits:     stats.Hits,
+			Misses:   stats.Misses,
+		}
+	}
+
+	var operations []*appmessage.DatabaseOperationMetrics
+	var latencyHistogramBounds []uint64
+	// The database only records metrics when it's wrapped by
+	// a MetricsDB, which it is when --dbmetrics is set
+	if metricsDB, ok := context.Database.(*metricsdb.MetricsDB); ok && getDatabaseStatsRequest.IncludeOperationMetrics {
+		operationMetrics := metricsDB.Metrics()
+		operations = make([]*appmessage.DatabaseOperationMetrics, len(operationMetrics))
+		for i, metrics := range operationMetrics {
+			operations[i] = &appmessage.DatabaseOperationMetrics{
+				Bucket:                   database.BucketDisplayName(metrics.Bucket),
+				Operation:                metrics.Operation,
+				Count:                    metrics.Count,
+				Bytes:                    metrics.Bytes,
+				TotalLatencyMicroseconds: uint64(metrics.TotalLatency.Microseconds()),
+				LatencyHistogram:         metrics.LatencyHistogram,
+			}
+		}
+		latencyHistogramBounds = make([]uint64, len(metricsdb.LatencyBounds))
+		for i, bound := range metricsdb.LatencyBounds {
+			latencyHistogramBounds[i] = uint64(bound.Microseconds())
+		}
+	}
+
+	compactionStatus := context.DatabaseCompactionManager.Status()
+	backupStatus := context.DatabaseBackupManager.Status()
+	lastBackupError := ""
+	if backupStatus.LastBackupError != nil {
+		lastBackupError = backupStatus.LastBackupError.Error()
+	}
+
+	return appmessage.NewGetDatabaseStatsResponseMessage(context.Config.DbType, stats.DiskSize,
+		stats.PendingCompactionBytes, levels, buckets, compactionStatus.IsCompacting,
+		unixMilliseconds(compactionStatus.LastCompactionTime), compactionStatus.LastCompactionDuration.Milliseconds(),
+		backupStatus.IsBackingUp, unixMilliseconds(backupStatus.LastBackupTime), backupStatus.LastBackupDirectory,
+		lastBackupError, caches, operations, latencyHistogramBounds), nil
+}
+
+// unixMilliseconds returns the given time in unix milliseconds,
+// or 0 if it's the zero time
+func unixMilliseconds(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
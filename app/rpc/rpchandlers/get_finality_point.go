@@ -0,0 +1,23 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetFinalityPoint handles the respectively named RPC command
+func HandleGetFinalityPoint(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	finalityPoint, err := context.Domain.Consensus().VirtualFinalityPoint()
+	if err != nil {
+		return nil, err
+	}
+	finalityPointHeader, err := context.Domain.Consensus().GetBlockHeader(finalityPoint)
+	if err != nil {
+		return nil, err
+	}
+
+	response := appmessage.NewGetFinalityPointResponseMessage(finalityPoint.String(), finalityPointHeader.BlueScore())
+
+	return response, nil
+}
@@ -0,0 +1,31 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetIndexStatus handles the respectively named RPC command
+func HandleGetIndexStatus(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	statuses := context.IndexManager.Statuses()
+	indexes := make([]*appmessage.IndexStatus, len(statuses))
+	for i, status := range statuses {
+		operationError := ""
+		if status.Progress.Error != nil {
+			operationError = status.Progress.Error.Error()
+		}
+		indexes[i] = &appmessage.IndexStatus{
+			Name:           status.Name,
+			IsEnabled:      status.IsEnabled,
+			Operation:      status.Progress.Operation,
+			IsRunning:      status.Progress.IsRunning,
+			Processed:      status.Progress.Processed,
+			Total:          status.Progress.Total,
+			StartTime:      unixMilliseconds(status.Progress.StartTime),
+			EndTime:        unixMilliseconds(status.Progress.EndTime),
+			OperationError: operationError,
+		}
+	}
+	return appmessage.NewGetIndexStatusResponseMessage(indexes), nil
+}
@@ -3,6 +3,7 @@ package rpchandlers
 import (
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 	"github.com/kaspanet/kaspad/version"
 )
@@ -21,6 +22,23 @@ func HandleGetInfo(context *rpccontext.Context, _ *router.Router, _ appmessage.M
 		context.Config.UTXOIndex,
 		context.ProtocolManager.Context().HasPeers() && isNearlySynced,
 	)
+	if context.Config.ValidatedSubnetworks != nil || context.Config.IndexedSubnetworks != nil ||
+		context.Config.RelayedSubnetworks != nil {
+
+		response.SubnetworkRoles = &appmessage.RPCSubnetworkRoles{
+			ValidatedSubnetworkIDs: subnetworkIDStrings(context.Config.ValidatedSubnetworks.SubnetworkIDs()),
+			IndexedSubnetworkIDs:   subnetworkIDStrings(context.Config.IndexedSubnetworks.SubnetworkIDs()),
+			RelayedSubnetworkIDs:   subnetworkIDStrings(context.Config.RelayedSubnetworks.SubnetworkIDs()),
+		}
+	}
 
 	return response, nil
 }
+
+func subnetworkIDStrings(subnetworkIDs []*externalapi.DomainSubnetworkID) []string {
+	subnetworkIDStrings := make([]string, len(subnetworkIDs))
+	for i, subnetworkID := range subnetworkIDs {
+		subnetworkIDStrings[i] = subnetworkID.String()
+	}
+	return subnetworkIDStrings
+}
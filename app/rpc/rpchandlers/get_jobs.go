@@ -0,0 +1,34 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetJobs handles the respectively named RPC command
+func HandleGetJobs(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	getJobsRequest := request.(*appmessage.GetJobsRequestMessage)
+
+	var jobs []*rpccontext.Job
+	if len(getJobsRequest.JobIDs) == 0 {
+		jobs = context.JobManager.Jobs()
+	} else {
+		jobs = make([]*rpccontext.Job, len(getJobsRequest.JobIDs))
+		for i, jobID := range getJobsRequest.JobIDs {
+			job, ok := context.JobManager.Job(jobID)
+			if !ok {
+				errorMessage := &appmessage.GetJobsResponseMessage{}
+				errorMessage.Error = appmessage.RPCErrorf("Job %d is unknown", jobID)
+				return errorMessage, nil
+			}
+			jobs[i] = job
+		}
+	}
+
+	rpcJobs := make([]*appmessage.RPCJob, len(jobs))
+	for i, job := range jobs {
+		rpcJobs[i] = job.RPCJob()
+	}
+	return appmessage.NewGetJobsResponseMessage(rpcJobs), nil
+}
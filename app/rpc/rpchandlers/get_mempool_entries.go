@@ -1,47 +1,89 @@
 package rpchandlers
 
 import (
+	"sort"
+
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionid"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 )
 
+type mempoolTransaction struct {
+	id          string
+	transaction *externalapi.DomainTransaction
+	isOrphan    bool
+}
+
 // HandleGetMempoolEntries handles the respectively named RPC command
 func HandleGetMempoolEntries(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
 	getMempoolEntriesRequest := request.(*appmessage.GetMempoolEntriesRequestMessage)
 
-	entries := make([]*appmessage.MempoolEntry, 0)
+	if getMempoolEntriesRequest.Cursor != "" {
+		_, err := transactionid.FromString(getMempoolEntriesRequest.Cursor)
+		if err != nil {
+			errorMessage := &appmessage.GetMempoolEntriesResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Cursor could not be parsed: %s", err)
+			return errorMessage, nil
+		}
+	}
 
 	transactionPoolTransactions, orphanPoolTransactions := context.Domain.MiningManager().AllTransactions(!getMempoolEntriesRequest.FilterTransactionPool, getMempoolEntriesRequest.IncludeOrphanPool)
 
+	transactions := make([]*mempoolTransaction, 0, len(transactionPoolTransactions)+len(orphanPoolTransactions))
 	if !getMempoolEntriesRequest.FilterTransactionPool {
 		for _, transaction := range transactionPoolTransactions {
-			rpcTransaction := appmessage.DomainTransactionToRPCTransaction(transaction)
-			err := context.PopulateTransactionWithVerboseData(rpcTransaction, nil)
-			if err != nil {
-				return nil, err
-			}
-			entries = append(entries, &appmessage.MempoolEntry{
-				Fee:         transaction.Fee,
-				Transaction: rpcTransaction,
-				IsOrphan:    false,
+			transactions = append(transactions, &mempoolTransaction{
+				id:          consensushashing.TransactionID(transaction).String(),
+				transaction: transaction,
+				isOrphan:    false,
 			})
 		}
 	}
 	if getMempoolEntriesRequest.IncludeOrphanPool {
 		for _, transaction := range orphanPoolTransactions {
-			rpcTransaction := appmessage.DomainTransactionToRPCTransaction(transaction)
-			err := context.PopulateTransactionWithVerboseData(rpcTransaction, nil)
-			if err != nil {
-				return nil, err
-			}
-			entries = append(entries, &appmessage.MempoolEntry{
-				Fee:         transaction.Fee,
-				Transaction: rpcTransaction,
-				IsOrphan:    true,
+			transactions = append(transactions, &mempoolTransaction{
+				id:          consensushashing.TransactionID(transaction).String(),
+				transaction: transaction,
+				isOrphan:    true,
 			})
 		}
 	}
 
-	return appmessage.NewGetMempoolEntriesResponseMessage(entries), nil
+	// Transactions are ordered by ID so that the cursor, which is the ID of
+	// the last transaction of the previous page, is stable
+	sort.Slice(transactions, func(i, j int) bool {
+		return transactions[i].id < transactions[j].id
+	})
+	start := sort.Search(len(transactions), func(i int) bool {
+		return transactions[i].id > getMempoolEntriesRequest.Cursor
+	})
+	transactions = transactions[start:]
+
+	nextCursor := ""
+	size := pageSize(getMempoolEntriesRequest.Limit, maxMempoolEntriesPageSize)
+	if len(transactions) > size {
+		transactions = transactions[:size]
+		nextCursor = transactions[size-1].id
+	}
+
+	entries := make([]*appmessage.MempoolEntry, len(transactions))
+	for i, transaction := range transactions {
+		rpcTransaction := appmessage.DomainTransactionToRPCTransaction(transaction.transaction)
+		err := context.PopulateTransactionWithVerboseData(rpcTransaction, nil)
+		if err != nil {
+			return nil, err
+		}
+		entries[i] = &appmessage.MempoolEntry{
+			Fee:         transaction.transaction.Fee,
+			Transaction: rpcTransaction,
+			IsOrphan:    transaction.isOrphan,
+		}
+	}
+
+	response := appmessage.NewGetMempoolEntriesResponseMessage(entries)
+	response.NextCursor = nextCursor
+	return response, nil
 }
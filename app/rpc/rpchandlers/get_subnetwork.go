@@ -3,11 +3,27 @@ package rpchandlers
 import (
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 )
 
 // HandleGetSubnetwork handles the respectively named RPC command
 func HandleGetSubnetwork(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	getSubnetworkRequest := request.(*appmessage.GetSubnetworkRequestMessage)
+
+	subnetworkID, err := subnetworks.FromString(getSubnetworkRequest.SubnetworkID)
+	if err != nil {
+		errorMessage := &appmessage.GetSubnetworkResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Could not parse subnetwork ID: %s", err)
+		return errorMessage, nil
+	}
+	if !context.Config.ValidatedSubnetworks.Passes(*subnetworkID) {
+		errorMessage := &appmessage.GetSubnetworkResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("This node doesn't validate the transactions of "+
+			"the subnetwork %s", subnetworkID)
+		return errorMessage, nil
+	}
+
 	response := &appmessage.GetSubnetworkResponseMessage{}
 	response.Error = appmessage.RPCErrorf("not implemented")
 	return response, nil
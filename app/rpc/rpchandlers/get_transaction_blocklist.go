@@ -0,0 +1,32 @@
+package rpchandlers
+
+import (
+	"encoding/hex"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleGetTransactionBlocklist handles the respectively named RPC command
+func HandleGetTransactionBlocklist(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	entries := context.Domain.MiningManager().TransactionBlocklist()
+
+	rpcEntries := make([]*appmessage.TransactionBlocklistEntry, len(entries))
+	for i, entry := range entries {
+		rpcEntry := &appmessage.TransactionBlocklistEntry{
+			Reason:  entry.Reason,
+			AddedAt: entry.AddedAt.UnixMilliseconds(),
+		}
+		if entry.TransactionID != nil {
+			rpcEntry.TransactionID = entry.TransactionID.String()
+		} else {
+			rpcEntry.ScriptPublicKey = &appmessage.RPCScriptPublicKey{
+				Script:  hex.EncodeToString(entry.ScriptPublicKey.Script),
+				Version: entry.ScriptPublicKey.Version,
+			}
+		}
+		rpcEntries[i] = rpcEntry
+	}
+	return appmessage.NewGetTransactionBlocklistResponseMessage(rpcEntries), nil
+}
@@ -26,8 +26,19 @@ func HandleGetVirtualSelectedParentChainFromBlock(context *rpccontext.Context, _
 		return response, nil
 	}
 
+	// The removed blocks are always returned in full, so that the first page
+	// already tells the client everything it has to roll back
+	nextStartHash := ""
+	limit := getVirtualSelectedParentChainFromBlockRequest.Limit
+	if limit > 0 && len(virtualSelectedParentChain.Added) > int(limit) {
+		virtualSelectedParentChain.Added = virtualSelectedParentChain.Added[:limit]
+		nextStartHash = virtualSelectedParentChain.Added[limit-1].String()
+	}
+
+	includeAcceptanceData := getVirtualSelectedParentChainFromBlockRequest.IncludeAcceptanceData
 	chainChangedNotification, err := context.ConvertVirtualSelectedParentChainChangesToChainChangedNotificationMessage(
-		virtualSelectedParentChain, getVirtualSelectedParentChainFromBlockRequest.IncludeAcceptedTransactionIDs)
+		virtualSelectedParentChain, getVirtualSelectedParentChainFromBlockRequest.IncludeAcceptedTransactionIDs,
+		includeAcceptanceData)
 	if err != nil {
 		return nil, err
 	}
@@ -35,5 +46,13 @@ func HandleGetVirtualSelectedParentChainFromBlock(context *rpccontext.Context, _
 	response := appmessage.NewGetVirtualSelectedParentChainFromBlockResponseMessage(
 		chainChangedNotification.RemovedChainBlockHashes, chainChangedNotification.AddedChainBlockHashes,
 		chainChangedNotification.AcceptedTransactionIDs)
+	response.NextStartHash = nextStartHash
+	if includeAcceptanceData {
+		response.AcceptanceData = chainChangedNotification.AcceptanceData
+		response.RemovedAcceptanceData, err = context.ConvertChainBlocksAcceptanceData(virtualSelectedParentChain.Removed)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return response, nil
 }
@@ -0,0 +1,34 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/miningmanager"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/pkg/errors"
+)
+
+// HandleGetWork handles the respectively named RPC command
+func HandleGetWork(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	getWorkRequest := request.(*appmessage.GetWorkRequestMessage)
+
+	payAddress, err := util.DecodeAddress(getWorkRequest.PayAddress, context.Config.ActiveNetParams.Prefix)
+	if err != nil {
+		errorMessage := &appmessage.GetWorkResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Could not decode address: %s", err)
+		return errorMessage, nil
+	}
+
+	work, isSynced, err := context.BuildWork(payAddress, getWorkRequest.ExtraData)
+	if err != nil {
+		if errors.Is(err, miningmanager.ErrCoinbasePayloadTooLong) {
+			errorMessage := &appmessage.GetWorkResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("%s. Try to shorten the extra data.", err)
+			return errorMessage, nil
+		}
+		return nil, err
+	}
+
+	return appmessage.NewGetWorkResponseMessage(work, isSynced), nil
+}
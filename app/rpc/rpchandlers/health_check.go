@@ -0,0 +1,60 @@
+package rpchandlers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/util/mstime"
+)
+
+// healthCheckDatabaseTimeout is how long the database may take to answer
+// a read before it's considered unresponsive
+const healthCheckDatabaseTimeout = 5 * time.Second
+
+// HandleHealthCheck handles the respectively named RPC command
+func HandleHealthCheck(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	response := appmessage.NewHealthCheckResponseMessage()
+	response.FailedChecks = []string{}
+
+	err := database.CheckResponsive(context.Database, healthCheckDatabaseTimeout)
+	if err != nil {
+		response.FailedChecks = append(response.FailedChecks, err.Error())
+	} else {
+		response.IsDatabaseResponsive = true
+	}
+	response.IsHealthy = response.IsDatabaseResponsive
+
+	consensus := context.Domain.Consensus()
+	virtualSelectedParent, err := consensus.GetVirtualSelectedParent()
+	if err != nil {
+		return nil, err
+	}
+	virtualSelectedParentHeader, err := consensus.GetBlockHeader(virtualSelectedParent)
+	if err != nil {
+		return nil, err
+	}
+	syncAge := mstime.Now().UnixMilliseconds() - virtualSelectedParentHeader.TimeInMilliseconds()
+	response.SyncAge = syncAge
+	maxSyncAge := context.Config.HealthMaxSyncAge
+	response.IsSynced = syncAge <= maxSyncAge.Milliseconds()
+	if !response.IsSynced {
+		response.FailedChecks = append(response.FailedChecks,
+			fmt.Sprintf("the virtual selected parent is %s old, more than %s",
+				time.Duration(syncAge)*time.Millisecond, maxSyncAge))
+	}
+
+	response.ConnectedPeerCount = uint32(len(context.ProtocolManager.Peers()))
+	minPeers := context.Config.HealthMinPeers
+	hasEnoughPeers := int(response.ConnectedPeerCount) >= minPeers
+	if !hasEnoughPeers {
+		response.FailedChecks = append(response.FailedChecks,
+			fmt.Sprintf("%d peers are connected, fewer than %d", response.ConnectedPeerCount, minPeers))
+	}
+
+	response.IsReady = response.IsHealthy && response.IsSynced && hasEnoughPeers
+	return response, nil
+}
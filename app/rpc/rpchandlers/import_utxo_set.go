@@ -0,0 +1,76 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/utxosnapshot"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+// HandleImportUTXOSet handles the respectively named RPC command
+func HandleImportUTXOSet(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if context.Config.SafeRPC {
+		log.Warn("ImportUTXOSet RPC command called while node in safe RPC mode -- ignoring.")
+		response := &appmessage.ImportUTXOSetResponseMessage{}
+		response.Error =
+			appmessage.RPCErrorf("ImportUTXOSet RPC command called while node in safe RPC mode")
+		return response, nil
+	}
+
+	importUTXOSetRequest := request.(*appmessage.ImportUTXOSetRequestMessage)
+	filePath := importUTXOSetRequest.FilePath
+	job := context.JobManager.Start(appmessage.RPCJobKindImportUTXOSet, func(job *rpccontext.Job) (*rpccontext.JobResult, error) {
+		snapshot, err := utxosnapshot.Verify(filePath, func(processedUTXOs uint64) error {
+			if job.IsCancelled() {
+				return rpccontext.ErrJobCancelled
+			}
+			job.SetProgress(processedUTXOs, 0)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// If the pruning point's header is already known, the UTXO commitment
+		// can be checked right away
+		consensus := context.Domain.Consensus()
+		blockInfo, err := consensus.GetBlockInfo(snapshot.PruningPointHash)
+		if err != nil {
+			return nil, err
+		}
+		if blockInfo.Exists {
+			header, err := consensus.GetBlockHeader(snapshot.PruningPointHash)
+			if err != nil {
+				return nil, err
+			}
+			if !header.UTXOCommitment().Equal(snapshot.UTXOCommitment) {
+				return nil, errors.Errorf("the UTXO commitment of the UTXO set is %s, "+
+					"but the UTXO commitment of block %s is %s",
+					snapshot.UTXOCommitment, snapshot.PruningPointHash, header.UTXOCommitment())
+			}
+		}
+
+		context.ProtocolManager.SetImportedUTXOSet(snapshot)
+		log.Infof("Imported %d UTXOs of pruning point %s from %s. They will be used when syncing from "+
+			"a peer with that pruning point", snapshot.UTXOCount, snapshot.PruningPointHash, snapshot.FilePath)
+
+		return &rpccontext.JobResult{UTXOSet: &appmessage.RPCJobUTXOSetResult{
+			PruningPointHash: snapshot.PruningPointHash.String(),
+			UTXOCommitment:   snapshot.UTXOCommitment.String(),
+			UTXOCount:        snapshot.UTXOCount,
+		}}, nil
+	})
+	if importUTXOSetRequest.RunAsJob {
+		return appmessage.NewImportUTXOSetResponseMessage("", "", 0, job.ID()), nil
+	}
+
+	result, err := job.Wait()
+	if err != nil {
+		errorMessage := &appmessage.ImportUTXOSetResponseMessage{JobID: job.ID()}
+		errorMessage.Error = appmessage.RPCErrorf("Could not import the UTXO set: %s", err)
+		return errorMessage, nil
+	}
+	return appmessage.NewImportUTXOSetResponseMessage(result.UTXOSet.PruningPointHash,
+		result.UTXOSet.UTXOCommitment, result.UTXOSet.UTXOCount, job.ID()), nil
+}
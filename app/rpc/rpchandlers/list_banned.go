@@ -0,0 +1,32 @@
+package rpchandlers
+
+import (
+	"sort"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleListBanned handles the respectively named RPC command
+func HandleListBanned(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	bannedSubnets, err := context.AddressManager.BannedSubnets()
+	if err != nil {
+		errorMessage := &appmessage.ListBannedResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Could not list the bans: %s", err)
+		return errorMessage, nil
+	}
+
+	rpcBannedSubnets := make([]*appmessage.BannedSubnet, len(bannedSubnets))
+	for i, bannedSubnet := range bannedSubnets {
+		rpcBannedSubnets[i] = &appmessage.BannedSubnet{
+			Subnet:      bannedSubnet.Subnet.String(),
+			BannedAt:    bannedSubnet.BannedAt.UnixMilliseconds(),
+			BannedUntil: bannedSubnet.BannedUntil.UnixMilliseconds(),
+		}
+	}
+	sort.Slice(rpcBannedSubnets, func(i, j int) bool {
+		return rpcBannedSubnets[i].Subnet < rpcBannedSubnets[j].Subnet
+	})
+	return appmessage.NewListBannedResponseMessage(rpcBannedSubnets), nil
+}
@@ -0,0 +1,51 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
+	"github.com/kaspanet/kaspad/domain/utxoindex"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleNotifyNewTransactions handles the respectively named RPC command
+func HandleNotifyNewTransactions(context *rpccontext.Context, router *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	notifyNewTransactionsRequest := request.(*appmessage.NotifyNewTransactionsRequestMessage)
+
+	addresses, err := context.ConvertAddressStringsToUTXOsChangedNotificationAddresses(notifyNewTransactionsRequest.Addresses)
+	if err != nil {
+		errorMessage := appmessage.NewNotifyNewTransactionsResponseMessage()
+		errorMessage.Error = appmessage.RPCErrorf("Parsing error: %s", err)
+		return errorMessage, nil
+	}
+	filter := &rpccontext.NewTransactionsFilter{
+		ScriptPublicKeys: make(map[utxoindex.ScriptPublicKeyString]struct{}, len(addresses)),
+		MinimumAmount:    notifyNewTransactionsRequest.MinimumAmount,
+	}
+	for _, address := range addresses {
+		filter.ScriptPublicKeys[address.ScriptPublicKeyString] = struct{}{}
+	}
+	if notifyNewTransactionsRequest.SubnetworkID != "" {
+		filter.SubnetworkID, err = subnetworks.FromString(notifyNewTransactionsRequest.SubnetworkID)
+		if err != nil {
+			errorMessage := appmessage.NewNotifyNewTransactionsResponseMessage()
+			errorMessage.Error = appmessage.RPCErrorf("Could not parse subnetwork ID: %s", err)
+			return errorMessage, nil
+		}
+		if !context.Config.ValidatedSubnetworks.Passes(*filter.SubnetworkID) {
+			errorMessage := appmessage.NewNotifyNewTransactionsResponseMessage()
+			errorMessage.Error = appmessage.RPCErrorf("This node doesn't validate the transactions of "+
+				"the subnetwork %s", filter.SubnetworkID)
+			return errorMessage, nil
+		}
+	}
+
+	listener, err := context.NotificationManager.Listener(router)
+	if err != nil {
+		return nil, err
+	}
+	context.NotificationManager.PropagateNewTransactionsNotifications(listener, filter)
+
+	response := appmessage.NewNotifyNewTransactionsResponseMessage()
+	return response, nil
+}
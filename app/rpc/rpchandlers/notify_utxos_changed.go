@@ -26,7 +26,7 @@ func HandleNotifyUTXOsChanged(context *rpccontext.Context, router *router.Router
 	if err != nil {
 		return nil, err
 	}
-	context.NotificationManager.PropagateUTXOsChangedNotifications(listener, addresses)
+	context.NotificationManager.PropagateUTXOsChangedNotifications(listener, addresses, notifyUTXOsChangedRequest.IncludeMempool)
 
 	response := appmessage.NewNotifyUTXOsChangedResponseMessage()
 	return response, nil
@@ -17,7 +17,8 @@ func HandleNotifyVirtualSelectedParentChainChanged(context *rpccontext.Context,
 		return nil, err
 	}
 	listener.PropagateVirtualSelectedParentChainChangedNotifications(
-		notifyVirtualSelectedParentChainChangedRequest.IncludeAcceptedTransactionIDs)
+		notifyVirtualSelectedParentChainChangedRequest.IncludeAcceptedTransactionIDs,
+		notifyVirtualSelectedParentChainChangedRequest.IncludeAcceptanceData)
 
 	response := appmessage.NewNotifyVirtualSelectedParentChainChangedResponseMessage()
 	return response, nil
@@ -0,0 +1,42 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/miningmanager"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/pkg/errors"
+)
+
+// HandleNotifyWork handles the respectively named RPC command
+func HandleNotifyWork(context *rpccontext.Context, router *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	notifyWorkRequest := request.(*appmessage.NotifyWorkRequestMessage)
+
+	payAddress, err := util.DecodeAddress(notifyWorkRequest.PayAddress, context.Config.ActiveNetParams.Prefix)
+	if err != nil {
+		errorMessage := appmessage.NewNotifyWorkResponseMessage()
+		errorMessage.Error = appmessage.RPCErrorf("Could not decode address: %s", err)
+		return errorMessage, nil
+	}
+
+	// Build work once in order to validate that the requested extra data fits in the coinbase
+	_, _, err = context.BuildWork(payAddress, notifyWorkRequest.ExtraData)
+	if err != nil {
+		if errors.Is(err, miningmanager.ErrCoinbasePayloadTooLong) {
+			errorMessage := appmessage.NewNotifyWorkResponseMessage()
+			errorMessage.Error = appmessage.RPCErrorf("%s. Try to shorten the extra data.", err)
+			return errorMessage, nil
+		}
+		return nil, err
+	}
+
+	listener, err := context.NotificationManager.Listener(router)
+	if err != nil {
+		return nil, err
+	}
+	context.NotificationManager.PropagateWorkNotifications(listener, payAddress, notifyWorkRequest.ExtraData)
+
+	response := appmessage.NewNotifyWorkResponseMessage()
+	return response, nil
+}
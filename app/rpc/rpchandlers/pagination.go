@@ -0,0 +1,18 @@
+package rpchandlers
+
+// Server-side limits on the page sizes of list RPCs, so that their
+// responses stay reasonably small regardless of what clients ask for
+const (
+	maxMempoolEntriesPageSize        = 10000
+	maxConnectedPeerInfoPageSize     = 1000
+	maxSearchRawTransactionsPageSize = 1000
+)
+
+// pageSize returns the amount of items to return for a request with the
+// given limit. A limit of 0 means that the client accepts the server's limit
+func pageSize(limit uint32, maxPageSize int) int {
+	if limit == 0 || limit > uint32(maxPageSize) {
+		return maxPageSize
+	}
+	return int(limit)
+}
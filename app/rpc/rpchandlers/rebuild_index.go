@@ -0,0 +1,28 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleRebuildIndex handles the respectively named RPC command
+func HandleRebuildIndex(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if context.Config.SafeRPC {
+		log.Warn("RebuildIndex RPC command called while node in safe RPC mode -- ignoring.")
+		response := appmessage.NewRebuildIndexResponseMessage()
+		response.Error =
+			appmessage.RPCErrorf("RebuildIndex RPC command called while node in safe RPC mode")
+		return response, nil
+	}
+
+	rebuildIndexRequest := request.(*appmessage.RebuildIndexRequestMessage)
+	err := context.IndexManager.Rebuild(rebuildIndexRequest.IndexName)
+	if err != nil {
+		response := appmessage.NewRebuildIndexResponseMessage()
+		response.Error = appmessage.RPCErrorf("Could not rebuild the index: %s", err)
+		return response, nil
+	}
+
+	return appmessage.NewRebuildIndexResponseMessage(), nil
+}
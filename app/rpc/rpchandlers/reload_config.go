@@ -0,0 +1,32 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleReloadConfig handles the respectively named RPC command
+func HandleReloadConfig(context *rpccontext.Context, _ *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	if context.Config.SafeRPC {
+		log.Warn("ReloadConfig RPC command called while node in safe RPC mode -- ignoring.")
+		response := &appmessage.ReloadConfigResponseMessage{}
+		response.Error =
+			appmessage.RPCErrorf("ReloadConfig RPC command called while node in safe RPC mode")
+		return response, nil
+	}
+	if context.ConfigReloader == nil {
+		response := &appmessage.ReloadConfigResponseMessage{}
+		response.Error = appmessage.RPCErrorf("Reloading the configuration is not supported by this node")
+		return response, nil
+	}
+
+	changedOptions, err := context.ConfigReloader()
+	if err != nil {
+		response := &appmessage.ReloadConfigResponseMessage{}
+		response.Error = appmessage.RPCErrorf("Reloading the configuration failed. "+
+			"Keeping the current configuration: %s", err)
+		return response, nil
+	}
+	return appmessage.NewReloadConfigResponseMessage(changedOptions), nil
+}
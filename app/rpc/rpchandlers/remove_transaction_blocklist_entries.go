@@ -0,0 +1,32 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleRemoveTransactionBlocklistEntries handles the respectively named RPC command
+func HandleRemoveTransactionBlocklistEntries(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if context.Config.SafeRPC {
+		log.Warn("RemoveTransactionBlocklistEntries RPC command called while node in safe RPC mode -- ignoring.")
+		response := &appmessage.RemoveTransactionBlocklistEntriesResponseMessage{}
+		response.Error =
+			appmessage.RPCErrorf("RemoveTransactionBlocklistEntries RPC command called while node in safe RPC mode")
+		return response, nil
+	}
+
+	removeTransactionBlocklistEntriesRequest := request.(*appmessage.RemoveTransactionBlocklistEntriesRequestMessage)
+	entries, err := toTransactionBlocklistEntries(removeTransactionBlocklistEntriesRequest.Entries)
+	if err != nil {
+		errorMessage := &appmessage.RemoveTransactionBlocklistEntriesResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Could not parse the blocklist entries: %s", err)
+		return errorMessage, nil
+	}
+
+	removedCount := context.Domain.MiningManager().RemoveFromTransactionBlocklist(entries)
+	for _, entry := range entries {
+		log.Infof("Removed %s from the transaction blocklist", transactionBlocklistEntryString(entry))
+	}
+	return appmessage.NewRemoveTransactionBlocklistEntriesResponseMessage(uint32(removedCount)), nil
+}
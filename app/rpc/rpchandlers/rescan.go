@@ -0,0 +1,63 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/utxoindex"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleRescan handles the respectively named RPC command
+func HandleRescan(context *rpccontext.Context, router *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if context.Config.SafeRPC {
+		log.Warn("Rescan RPC command called while node in safe RPC mode -- ignoring.")
+		errorMessage := &appmessage.RescanResponseMessage{}
+		errorMessage.Error =
+			appmessage.RPCErrorf("Rescan RPC command called while node in safe RPC mode")
+		return errorMessage, nil
+	}
+
+	if router == nil {
+		errorMessage := &appmessage.RescanResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("A rescan requires an RPC connection to send its notifications to")
+		return errorMessage, nil
+	}
+
+	rescanRequest := request.(*appmessage.RescanRequestMessage)
+
+	addresses, err := context.ConvertAddressStringsToUTXOsChangedNotificationAddresses(rescanRequest.Addresses)
+	if err != nil {
+		errorMessage := &appmessage.RescanResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Parsing error: %s", err)
+		return errorMessage, nil
+	}
+	filter := &rpccontext.RescanFilter{
+		ScriptPublicKeys: make(map[utxoindex.ScriptPublicKeyString]struct{}, len(addresses)),
+		Outpoints:        make(map[externalapi.DomainOutpoint]struct{}, len(rescanRequest.Outpoints)),
+	}
+	for _, address := range addresses {
+		filter.ScriptPublicKeys[address.ScriptPublicKeyString] = struct{}{}
+	}
+	for _, outpoint := range rescanRequest.Outpoints {
+		transactionID, err := externalapi.NewDomainTransactionIDFromString(outpoint.TransactionID)
+		if err != nil {
+			errorMessage := &appmessage.RescanResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Could not parse the transaction ID of outpoint %s:%d: %s",
+				outpoint.TransactionID, outpoint.Index, err)
+			return errorMessage, nil
+		}
+		filter.Outpoints[externalapi.DomainOutpoint{TransactionID: *transactionID, Index: outpoint.Index}] = struct{}{}
+	}
+	if len(filter.ScriptPublicKeys) == 0 && len(filter.Outpoints) == 0 {
+		errorMessage := &appmessage.RescanResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("At least one address or outpoint is required")
+		return errorMessage, nil
+	}
+
+	startBlueScore, job, err := context.RescanManager.Start(router, rescanRequest.StartBlueScore, filter)
+	if err != nil {
+		return nil, err
+	}
+	return appmessage.NewRescanResponseMessage(startBlueScore, job.ID()), nil
+}
@@ -0,0 +1,21 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleResumeNotificationSession handles the respectively named RPC command
+func HandleResumeNotificationSession(context *rpccontext.Context, router *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	resumeNotificationSessionRequest := request.(*appmessage.ResumeNotificationSessionRequestMessage)
+
+	replayedCount, err := context.NotificationManager.ResumeNotificationSession(router,
+		resumeNotificationSessionRequest.SessionID, resumeNotificationSessionRequest.LastSequence)
+	if err != nil {
+		errorMessage := &appmessage.ResumeNotificationSessionResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Could not resume notification session: %s", err)
+		return errorMessage, nil
+	}
+	return appmessage.NewResumeNotificationSessionResponseMessage(uint64(replayedCount)), nil
+}
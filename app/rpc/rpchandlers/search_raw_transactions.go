@@ -0,0 +1,177 @@
+package rpchandlers
+
+import (
+	"encoding/hex"
+	"sort"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/addressindex"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/pkg/errors"
+)
+
+// HandleSearchRawTransactions handles the respectively named RPC command
+func HandleSearchRawTransactions(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if !context.Config.AddressIndex {
+		errorMessage := &appmessage.SearchRawTransactionsResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Method unavailable when kaspad is run without --addressindex")
+		return errorMessage, nil
+	}
+
+	searchRawTransactionsRequest := request.(*appmessage.SearchRawTransactionsRequestMessage)
+
+	address, err := util.DecodeAddress(searchRawTransactionsRequest.Address, context.Config.ActiveNetParams.Prefix)
+	if err != nil {
+		errorMessage := &appmessage.SearchRawTransactionsResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Could not decode address '%s': %s",
+			searchRawTransactionsRequest.Address, err)
+		return errorMessage, nil
+	}
+	scriptPublicKey, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		errorMessage := &appmessage.SearchRawTransactionsResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Could not create a scriptPublicKey for address '%s': %s",
+			searchRawTransactionsRequest.Address, err)
+		return errorMessage, nil
+	}
+
+	var cursor []byte
+	if searchRawTransactionsRequest.Cursor != "" {
+		cursor, err = hex.DecodeString(searchRawTransactionsRequest.Cursor)
+		if err != nil {
+			errorMessage := &appmessage.SearchRawTransactionsResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Cursor could not be parsed: %s", err)
+			return errorMessage, nil
+		}
+	}
+
+	transactionEntries, nextCursor, err := context.AddressIndex.Transactions(scriptPublicKey, cursor,
+		pageSize(searchRawTransactionsRequest.Limit, maxSearchRawTransactionsPageSize))
+	if err != nil {
+		if errors.Is(err, addressindex.ErrInvalidCursor) {
+			errorMessage := &appmessage.SearchRawTransactionsResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Cursor could not be parsed: %s", err)
+			return errorMessage, nil
+		}
+		return nil, err
+	}
+
+	entries := make([]*appmessage.AddressTransactionEntry, 0, len(transactionEntries))
+
+	// The mempool isn't paginated, and it holds the newest transactions, so
+	// it's only included in the first page
+	if searchRawTransactionsRequest.IncludeMempool && cursor == nil {
+		mempoolEntries, err := mempoolAddressTransactionEntries(context, scriptPublicKey)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, mempoolEntries...)
+	}
+
+	acceptedEntries, err := acceptedAddressTransactionEntries(context, transactionEntries)
+	if err != nil {
+		return nil, err
+	}
+	entries = append(entries, acceptedEntries...)
+
+	return appmessage.NewSearchRawTransactionsResponseMessage(entries, hex.EncodeToString(nextCursor)), nil
+}
+
+func mempoolAddressTransactionEntries(context *rpccontext.Context,
+	scriptPublicKey *externalapi.ScriptPublicKey) ([]*appmessage.AddressTransactionEntry, error) {
+
+	transactionPoolTransactions, _ := context.Domain.MiningManager().AllTransactions(true, false)
+	entries := make([]*appmessage.AddressTransactionEntry, 0)
+	for _, transaction := range transactionPoolTransactions {
+		if !isScriptPublicKeyInTransaction(transaction, scriptPublicKey) {
+			continue
+		}
+		rpcTransaction := appmessage.DomainTransactionToRPCTransaction(transaction)
+		err := context.PopulateTransactionWithVerboseData(rpcTransaction, nil)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &appmessage.AddressTransactionEntry{Transaction: rpcTransaction})
+	}
+
+	// The mempool has no inherent order, so transactions are ordered by ID
+	// to keep the response stable
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Transaction.VerboseData.TransactionID < entries[j].Transaction.VerboseData.TransactionID
+	})
+	return entries, nil
+}
+
+// isScriptPublicKeyInTransaction returns whether the given transaction pays to, or
+// spends from, the given scriptPublicKey
+func isScriptPublicKeyInTransaction(transaction *externalapi.DomainTransaction,
+	scriptPublicKey *externalapi.ScriptPublicKey) bool {
+
+	for _, output := range transaction.Outputs {
+		if output.ScriptPublicKey.Equal(scriptPublicKey) {
+			return true
+		}
+	}
+	for _, input := range transaction.Inputs {
+		if input.UTXOEntry != nil && input.UTXOEntry.ScriptPublicKey().Equal(scriptPublicKey) {
+			return true
+		}
+	}
+	return false
+}
+
+func acceptedAddressTransactionEntries(context *rpccontext.Context,
+	transactionEntries []*addressindex.TransactionEntry) ([]*appmessage.AddressTransactionEntry, error) {
+
+	// Many transactions of the same address are often in the same block
+	blocks := make(map[externalapi.DomainHash]*externalapi.DomainBlock)
+	entries := make([]*appmessage.AddressTransactionEntry, 0, len(transactionEntries))
+	for _, transactionEntry := range transactionEntries {
+		block, ok := blocks[*transactionEntry.BlockHash]
+		if !ok {
+			var found bool
+			var err error
+			block, found, err = context.Domain.Consensus().GetBlock(transactionEntry.BlockHash)
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				// The block data was pruned
+				block = nil
+			}
+			blocks[*transactionEntry.BlockHash] = block
+		}
+		if block == nil {
+			continue
+		}
+
+		var transaction *externalapi.DomainTransaction
+		for _, blockTransaction := range block.Transactions {
+			if consensushashing.TransactionID(blockTransaction).Equal(transactionEntry.TransactionID) {
+				transaction = blockTransaction
+				break
+			}
+		}
+		if transaction == nil {
+			return nil, errors.Errorf("transaction %s is missing from block %s",
+				transactionEntry.TransactionID, transactionEntry.BlockHash)
+		}
+
+		rpcTransaction := appmessage.DomainTransactionToRPCTransaction(transaction)
+		err := context.PopulateTransactionWithVerboseData(rpcTransaction, block.Header)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &appmessage.AddressTransactionEntry{
+			Transaction:        rpcTransaction,
+			AcceptingBlockHash: transactionEntry.AcceptingBlockHash.String(),
+			AcceptingDAAScore:  transactionEntry.AcceptingDAAScore,
+		})
+	}
+	return entries, nil
+}
@@ -0,0 +1,65 @@
+package rpchandlers
+
+import (
+	"net"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleSetBan handles the respectively named RPC command
+func HandleSetBan(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	if context.Config.SafeRPC {
+		log.Warn("SetBan RPC command called while node in safe RPC mode -- ignoring.")
+		response := appmessage.NewSetBanResponseMessage()
+		response.Error =
+			appmessage.RPCErrorf("SetBan RPC command called while node in safe RPC mode")
+		return response, nil
+	}
+
+	setBanRequest := request.(*appmessage.SetBanRequestMessage)
+	subnet, err := parseSubnet(setBanRequest.Subnet)
+	if err != nil {
+		errorMessage := &appmessage.SetBanResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Could not parse subnet: %s", err)
+		return errorMessage, nil
+	}
+
+	if setBanRequest.Remove {
+		err = context.AddressManager.UnbanSubnet(subnet)
+		if err != nil {
+			errorMessage := &appmessage.SetBanResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Could not unban %s: %s", subnet, err)
+			return errorMessage, nil
+		}
+		return appmessage.NewSetBanResponseMessage(), nil
+	}
+
+	duration := time.Duration(setBanRequest.DurationSeconds) * time.Second
+	err = context.ConnectionManager.BanSubnet(subnet, duration)
+	if err != nil {
+		errorMessage := &appmessage.SetBanResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Could not ban %s: %s", subnet, err)
+		return errorMessage, nil
+	}
+	return appmessage.NewSetBanResponseMessage(), nil
+}
+
+// parseSubnet parses either a subnet in CIDR notation or a single IP
+// address, which is returned as a subnet that contains only that address
+func parseSubnet(subnetString string) (*net.IPNet, error) {
+	_, subnet, err := net.ParseCIDR(subnetString)
+	if err == nil {
+		return subnet, nil
+	}
+	ip := net.ParseIP(subnetString)
+	if ip == nil {
+		return nil, err
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(net.IPv4len*8, net.IPv4len*8)}, nil
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(net.IPv6len*8, net.IPv6len*8)}, nil
+}
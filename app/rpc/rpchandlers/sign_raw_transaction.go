@@ -0,0 +1,226 @@
+package rpchandlers
+
+import (
+	"encoding/hex"
+
+	"github.com/kaspanet/go-secp256k1"
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/pkg/errors"
+)
+
+// signingKeys maps the pay-to-pubkey scripts of the private keys of a
+// signRawTransaction request to these keys. Every private key can be used
+// both as a Schnorr key and as an ECDSA key, so it appears in both maps.
+type signingKeys struct {
+	schnorrKeyPairs map[string]*secp256k1.SchnorrKeyPair
+	ecdsaKeys       map[string]*secp256k1.ECDSAPrivateKey
+}
+
+// HandleSignRawTransaction handles the respectively named RPC command
+func HandleSignRawTransaction(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	signRawTransactionRequest := request.(*appmessage.SignRawTransactionRequestMessage)
+
+	transaction, err := appmessage.RPCTransactionToDomainTransaction(signRawTransactionRequest.Transaction)
+	if err != nil {
+		errorMessage := &appmessage.SignRawTransactionResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Could not parse transaction: %s", err)
+		return errorMessage, nil
+	}
+
+	keys, err := newSigningKeys(signRawTransactionRequest.PrivateKeys, context.Config.ActiveNetParams.Prefix)
+	if err != nil {
+		errorMessage := &appmessage.SignRawTransactionResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Could not parse private keys: %s", err)
+		return errorMessage, nil
+	}
+
+	redeemScripts := make(map[externalapi.DomainOutpoint][]byte)
+	for _, previousOutput := range signRawTransactionRequest.PreviousOutputs {
+		outpoint, err := appmessage.RPCOutpointToDomainOutpoint(previousOutput.Outpoint)
+		if err != nil {
+			errorMessage := &appmessage.SignRawTransactionResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Could not parse previous outpoint: %s", err)
+			return errorMessage, nil
+		}
+		utxoEntry, err := appmessage.RPCUTXOEntryToUTXOEntry(previousOutput.UTXOEntry)
+		if err != nil {
+			errorMessage := &appmessage.SignRawTransactionResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Could not parse the previous output of %s: %s", outpoint, err)
+			return errorMessage, nil
+		}
+		redeemScript, err := hex.DecodeString(previousOutput.RedeemScript)
+		if err != nil {
+			errorMessage := &appmessage.SignRawTransactionResponseMessage{}
+			errorMessage.Error = appmessage.RPCErrorf("Could not parse the redeem script of %s: %s", outpoint, err)
+			return errorMessage, nil
+		}
+		for _, input := range transaction.Inputs {
+			if input.PreviousOutpoint == *outpoint {
+				input.UTXOEntry = utxoEntry
+			}
+		}
+		if len(redeemScript) > 0 {
+			redeemScripts[*outpoint] = redeemScript
+		}
+	}
+
+	inputErrors := []*appmessage.SignRawTransactionInputError{}
+	sighashReusedValues := &consensushashing.SighashReusedValues{}
+	for i, input := range transaction.Inputs {
+		if input.UTXOEntry == nil {
+			inputErrors = append(inputErrors, &appmessage.SignRawTransactionInputError{
+				InputIndex: uint32(i),
+				Message:    errors.Errorf("the previous output %s wasn't given", input.PreviousOutpoint).Error(),
+			})
+			continue
+		}
+
+		signErr := keys.signInput(transaction, i, redeemScripts[input.PreviousOutpoint], sighashReusedValues)
+		verifyErr := verifyInput(transaction, i, sighashReusedValues)
+		if verifyErr != nil {
+			// An input that couldn't be signed is only reported if it isn't
+			// already validly signed
+			inputError := verifyErr
+			if signErr != nil {
+				inputError = signErr
+			}
+			inputErrors = append(inputErrors, &appmessage.SignRawTransactionInputError{
+				InputIndex: uint32(i),
+				Message:    inputError.Error(),
+			})
+		}
+	}
+
+	rpcTransaction := appmessage.DomainTransactionToRPCTransaction(transaction)
+	return appmessage.NewSignRawTransactionResponseMessage(rpcTransaction, len(inputErrors) == 0, inputErrors), nil
+}
+
+func newSigningKeys(privateKeys []string, prefix util.Bech32Prefix) (*signingKeys, error) {
+	keys := &signingKeys{
+		schnorrKeyPairs: make(map[string]*secp256k1.SchnorrKeyPair, len(privateKeys)),
+		ecdsaKeys:       make(map[string]*secp256k1.ECDSAPrivateKey, len(privateKeys)),
+	}
+	for i, privateKey := range privateKeys {
+		privateKeyBytes, err := hex.DecodeString(privateKey)
+		if err != nil {
+			return nil, errors.Errorf("private key #%d is not hex encoded", i)
+		}
+
+		schnorrKeyPair, err := secp256k1.DeserializeSchnorrPrivateKeyFromSlice(privateKeyBytes)
+		if err != nil {
+			return nil, errors.Errorf("private key #%d is invalid: %s", i, err)
+		}
+		schnorrPublicKey, err := schnorrKeyPair.SchnorrPublicKey()
+		if err != nil {
+			return nil, err
+		}
+		serializedSchnorrPublicKey, err := schnorrPublicKey.Serialize()
+		if err != nil {
+			return nil, err
+		}
+		schnorrAddress, err := util.NewAddressPublicKey(serializedSchnorrPublicKey[:], prefix)
+		if err != nil {
+			return nil, err
+		}
+		schnorrScript, err := txscript.PayToAddrScript(schnorrAddress)
+		if err != nil {
+			return nil, err
+		}
+		keys.schnorrKeyPairs[string(schnorrScript.Script)] = schnorrKeyPair
+
+		ecdsaKey, err := secp256k1.DeserializeECDSAPrivateKeyFromSlice(privateKeyBytes)
+		if err != nil {
+			return nil, errors.Errorf("private key #%d is invalid: %s", i, err)
+		}
+		ecdsaPublicKey, err := ecdsaKey.ECDSAPublicKey()
+		if err != nil {
+			return nil, err
+		}
+		serializedECDSAPublicKey, err := ecdsaPublicKey.Serialize()
+		if err != nil {
+			return nil, err
+		}
+		ecdsaAddress, err := util.NewAddressPublicKeyECDSA(serializedECDSAPublicKey[:], prefix)
+		if err != nil {
+			return nil, err
+		}
+		ecdsaScript, err := txscript.PayToAddrScript(ecdsaAddress)
+		if err != nil {
+			return nil, err
+		}
+		keys.ecdsaKeys[string(ecdsaScript.Script)] = ecdsaKey
+	}
+	return keys, nil
+}
+
+// signInput sets the signature script of the input at inputIndex, if one of
+// the keys matches its previous output. If the previous output is
+// pay-to-script-hash, the key must match the given redeem script instead.
+func (k *signingKeys) signInput(transaction *externalapi.DomainTransaction, inputIndex int, redeemScript []byte,
+	sighashReusedValues *consensushashing.SighashReusedValues) error {
+
+	input := transaction.Inputs[inputIndex]
+	scriptPublicKey := input.UTXOEntry.ScriptPublicKey()
+	if scriptPublicKey.Version > constants.MaxScriptPublicKeyVersion {
+		return errors.Errorf("script public key version %d is not supported", scriptPublicKey.Version)
+	}
+
+	script := scriptPublicKey.Script
+	isPayToScriptHash := txscript.GetScriptClass(script) == txscript.ScriptHashTy
+	if isPayToScriptHash {
+		if redeemScript == nil {
+			return errors.Errorf("the previous output is pay-to-script-hash, but its redeem script wasn't given")
+		}
+		expectedScript, err := txscript.PayToScriptHashScript(redeemScript)
+		if err != nil {
+			return err
+		}
+		if string(expectedScript) != string(script) {
+			return errors.Errorf("the given redeem script doesn't match the previous output")
+		}
+		script = redeemScript
+	}
+
+	var signature []byte
+	var err error
+	if schnorrKeyPair, ok := k.schnorrKeyPairs[string(script)]; ok {
+		signature, err = txscript.RawTxInSignature(transaction, inputIndex, consensushashing.SigHashAll,
+			schnorrKeyPair, sighashReusedValues)
+	} else if ecdsaKey, ok := k.ecdsaKeys[string(script)]; ok {
+		signature, err = txscript.RawTxInSignatureECDSA(transaction, inputIndex, consensushashing.SigHashAll,
+			ecdsaKey, sighashReusedValues)
+	} else {
+		return errors.Errorf("none of the given private keys can sign script %x", script)
+	}
+	if err != nil {
+		return err
+	}
+
+	if isPayToScriptHash {
+		input.SignatureScript, err = txscript.PayToScriptHashSignatureScript(redeemScript, signature)
+	} else {
+		input.SignatureScript, err = txscript.NewScriptBuilder().AddData(signature).Script()
+	}
+	return err
+}
+
+// verifyInput returns an error if the signature script of the input at
+// inputIndex doesn't satisfy its previous output
+func verifyInput(transaction *externalapi.DomainTransaction, inputIndex int,
+	sighashReusedValues *consensushashing.SighashReusedValues) error {
+
+	scriptPublicKey := transaction.Inputs[inputIndex].UTXOEntry.ScriptPublicKey()
+	vm, err := txscript.NewEngine(scriptPublicKey, transaction, inputIndex, txscript.ScriptNoFlags,
+		nil, nil, sighashReusedValues)
+	if err != nil {
+		return err
+	}
+	return vm.Execute()
+}
@@ -0,0 +1,17 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleStartNotificationSession handles the respectively named RPC command
+func HandleStartNotificationSession(context *rpccontext.Context, router *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	sessionID, err := context.NotificationManager.StartNotificationSession(router)
+	if err != nil {
+		return nil, err
+	}
+	return appmessage.NewStartNotificationSessionResponseMessage(sessionID, rpccontext.NotificationSessionHistorySize,
+		uint64(rpccontext.NotificationSessionExpiry.Milliseconds())), nil
+}
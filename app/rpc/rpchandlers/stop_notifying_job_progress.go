@@ -0,0 +1,19 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleStopNotifyingJobProgress handles the respectively named RPC command
+func HandleStopNotifyingJobProgress(context *rpccontext.Context, router *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	listener, err := context.NotificationManager.Listener(router)
+	if err != nil {
+		return nil, err
+	}
+	listener.StopPropagatingJobProgressNotifications()
+
+	response := appmessage.NewStopNotifyingJobProgressResponseMessage()
+	return response, nil
+}
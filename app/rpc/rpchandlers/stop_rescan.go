@@ -0,0 +1,15 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+)
+
+// HandleStopRescan handles the respectively named RPC command
+func HandleStopRescan(context *rpccontext.Context, router *router.Router, _ appmessage.Message) (appmessage.Message, error) {
+	if router != nil {
+		context.RescanManager.Stop(router)
+	}
+	return appmessage.NewStopRescanResponseMessage(), nil
+}
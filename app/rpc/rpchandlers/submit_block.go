@@ -1,11 +1,9 @@
 package rpchandlers
 
 import (
-	"encoding/json"
 	"github.com/kaspanet/kaspad/app/appmessage"
-	"github.com/kaspanet/kaspad/app/protocol/protocolerrors"
 	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
-	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 	"github.com/pkg/errors"
@@ -15,23 +13,6 @@ import (
 func HandleSubmitBlock(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
 	submitBlockRequest := request.(*appmessage.SubmitBlockRequestMessage)
 
-	var err error
-	isSynced := false
-	// The node is considered synced if it has peers and consensus state is nearly synced
-	if context.ProtocolManager.Context().HasPeers() {
-		isSynced, err = context.ProtocolManager.Context().IsNearlySynced()
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	if !context.Config.AllowSubmitBlockWhenNotSynced && !isSynced {
-		return &appmessage.SubmitBlockResponseMessage{
-			Error:        appmessage.RPCErrorf("Block not submitted - node is not synced"),
-			RejectReason: appmessage.RejectReasonIsInIBD,
-		}, nil
-	}
-
 	domainBlock, err := appmessage.RPCBlockToDomainBlock(submitBlockRequest.Block)
 	if err != nil {
 		return &appmessage.SubmitBlockResponseMessage{
@@ -40,44 +21,69 @@ func HandleSubmitBlock(context *rpccontext.Context, _ *router.Router, request ap
 		}, nil
 	}
 
-	if !submitBlockRequest.AllowNonDAABlocks {
-		virtualDAAScore, err := context.Domain.Consensus().GetVirtualDAAScore()
-		if err != nil {
-			return nil, err
-		}
-		// A simple heuristic check which signals that the mined block is out of date
-		// and should not be accepted unless user explicitly requests
-		daaWindowSize := uint64(context.Config.NetParams().DifficultyAdjustmentWindowSize)
-		if virtualDAAScore > daaWindowSize && domainBlock.Header.DAAScore() < virtualDAAScore-daaWindowSize {
-			return &appmessage.SubmitBlockResponseMessage{
-				Error: appmessage.RPCErrorf("Block rejected. Reason: block DAA score %d is too far "+
-					"behind virtual's DAA score %d", domainBlock.Header.DAAScore(), virtualDAAScore),
-				RejectReason: appmessage.RejectReasonBlockInvalid,
-			}, nil
-		}
+	if submitBlockRequest.Async {
+		return submitBlockAsync(context, domainBlock, submitBlockRequest.AllowNonDAABlocks)
 	}
+	return context.SubmitBlock(domainBlock, submitBlockRequest.AllowNonDAABlocks, "submitBlock")
+}
 
-	err = context.ProtocolManager.AddBlock(domainBlock)
+// submitBlockAsync schedules the given block for submission and responds immediately with
+// the ID to query the outcome of the submission with
+func submitBlockAsync(context *rpccontext.Context, domainBlock *externalapi.DomainBlock,
+	allowNonDAABlocks bool) (*appmessage.SubmitBlockResponseMessage, error) {
+
+	blockHash := consensushashing.BlockHash(domainBlock)
+	submissionID, err := context.BlockSubmissionManager.Submit(blockHash, func() (string, string, error) {
+		return processBlockSubmission(context, domainBlock, allowNonDAABlocks)
+	})
 	if err != nil {
-		isProtocolOrRuleError := errors.As(err, &ruleerrors.RuleError{}) || errors.As(err, &protocolerrors.ProtocolError{})
-		if !isProtocolOrRuleError {
+		if !errors.Is(err, rpccontext.ErrTooManyPendingBlockSubmissions) {
 			return nil, err
 		}
-
-		jsonBytes, _ := json.MarshalIndent(submitBlockRequest.Block.Header, "", "    ")
-		if jsonBytes != nil {
-			log.Warnf("The RPC submitted block triggered a rule/protocol error (%s), printing "+
-				"the full header for debug purposes: \n%s", err, string(jsonBytes))
-		}
-
 		return &appmessage.SubmitBlockResponseMessage{
-			Error:        appmessage.RPCErrorf("Block rejected. Reason: %s", err),
-			RejectReason: appmessage.RejectReasonBlockInvalid,
+			Error: appmessage.RPCErrorf("Block not submitted - %s", err),
 		}, nil
 	}
 
-	log.Infof("Accepted block %s via submitBlock", consensushashing.BlockHash(domainBlock))
+	return &appmessage.SubmitBlockResponseMessage{SubmissionID: submissionID}, nil
+}
+
+// processBlockSubmission submits a block that was submitted asynchronously and returns the
+// outcome of the submission. Blocks that are rejected only because some of their parents are
+// missing are added to the orphan pool, and get accepted once their parents arrive.
+func processBlockSubmission(context *rpccontext.Context, domainBlock *externalapi.DomainBlock,
+	allowNonDAABlocks bool) (status string, rejectReason string, err error) {
 
-	response := appmessage.NewSubmitBlockResponseMessage()
-	return response, nil
+	response, err := context.SubmitBlock(domainBlock, allowNonDAABlocks, "async submitBlock")
+	if err != nil {
+		return "", "", err
+	}
+	if response.Error == nil {
+		return appmessage.BlockSubmissionStatusAccepted, "", nil
+	}
+
+	if response.RejectReason == appmessage.RejectReasonBlockInvalid {
+		hasMissingParents, err := hasMissingParents(context, domainBlock)
+		if err != nil {
+			return "", "", err
+		}
+		if hasMissingParents {
+			context.ProtocolManager.Context().AddOrphan(domainBlock)
+			return appmessage.BlockSubmissionStatusOrphan, "", nil
+		}
+	}
+	return appmessage.BlockSubmissionStatusRejected, response.Error.Message, nil
+}
+
+func hasMissingParents(context *rpccontext.Context, domainBlock *externalapi.DomainBlock) (bool, error) {
+	for _, parentHash := range domainBlock.Header.DirectParents() {
+		parentInfo, err := context.Domain.Consensus().GetBlockInfo(parentHash)
+		if err != nil {
+			return false, err
+		}
+		if !parentInfo.Exists || parentInfo.BlockStatus == externalapi.StatusHeaderOnly {
+			return true, nil
+		}
+	}
+	return false, nil
 }
@@ -0,0 +1,55 @@
+package rpchandlers
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc/rpccontext"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/pow"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/util/difficulty"
+)
+
+// HandleSubmitWork handles the respectively named RPC command
+func HandleSubmitWork(context *rpccontext.Context, _ *router.Router, request appmessage.Message) (appmessage.Message, error) {
+	submitWorkRequest := request.(*appmessage.SubmitWorkRequestMessage)
+
+	template, ok := context.WorkManager.Get(submitWorkRequest.WorkID)
+	if !ok {
+		errorMessage := &appmessage.SubmitWorkResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Work %s is unknown or stale", submitWorkRequest.WorkID)
+		return errorMessage, nil
+	}
+
+	// The ID of the work is its pre-PoW hash, so the nonce is checked against
+	// the same values the miner was given, without hashing the header again
+	prePowHash, err := externalapi.NewDomainHashFromString(submitWorkRequest.WorkID)
+	if err != nil {
+		return nil, err
+	}
+	state := pow.NewStateFromPrePowHash(prePowHash, template.Header.TimeInMilliseconds(),
+		difficulty.CompactToBig(template.Header.Bits()))
+	state.Nonce = submitWorkRequest.Nonce
+	if !state.CheckProofOfWork() {
+		errorMessage := &appmessage.SubmitWorkResponseMessage{}
+		errorMessage.Error = appmessage.RPCErrorf("Nonce %d does not satisfy the target of work %s",
+			submitWorkRequest.Nonce, submitWorkRequest.WorkID)
+		return errorMessage, nil
+	}
+	mutableHeader := template.Header.ToMutable()
+	mutableHeader.SetNonce(submitWorkRequest.Nonce)
+	block := &externalapi.DomainBlock{
+		Header:       mutableHeader.ToImmutable(),
+		Transactions: template.Transactions,
+	}
+
+	submitBlockResponse, err := context.SubmitBlock(block, false, "submitWork")
+	if err != nil {
+		return nil, err
+	}
+	if submitBlockResponse.Error != nil {
+		return &appmessage.SubmitWorkResponseMessage{Error: submitBlockResponse.Error}, nil
+	}
+
+	return appmessage.NewSubmitWorkResponseMessage(consensushashing.BlockHash(block).String()), nil
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/os/systemd"
+	"github.com/kaspanet/kaspad/version"
+)
+
+// serviceNotifier notifies the service manager kaspad is run by, if it
+// expects notifications, of when kaspad is ready and when it's stopping.
+// If the service manager has a watchdog for kaspad, serviceNotifier also
+// pings it, as long as the database answers reads. A node whose database
+// hangs is then restarted by the service manager, rather than left running
+// without making progress.
+type serviceNotifier struct {
+	db   database.Database
+	quit chan struct{}
+	done chan struct{}
+}
+
+// startServiceNotifier starts pinging the watchdog of the service manager,
+// if it has one for kaspad
+func startServiceNotifier(db database.Database) *serviceNotifier {
+	notifier := &serviceNotifier{
+		db:   db,
+		quit: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+
+	watchdogInterval, err := systemd.WatchdogInterval()
+	if err != nil {
+		log.Warnf("Not pinging the service manager watchdog: %s", err)
+	}
+	if watchdogInterval == 0 {
+		close(notifier.done)
+		return notifier
+	}
+	log.Infof("Pinging the service manager watchdog every %s", watchdogInterval)
+	spawn("serviceNotifier.pingWatchdog", func() {
+		notifier.pingWatchdog(watchdogInterval)
+	})
+	return notifier
+}
+
+func (notifier *serviceNotifier) pingWatchdog(interval time.Duration) {
+	defer close(notifier.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			// The database is given up to the interval to answer, so that
+			// a late answer still leaves time for the ping before the
+			// watchdog timeout, which is twice the interval
+			err := database.CheckResponsive(notifier.db, interval)
+			if err != nil {
+				log.Warnf("Skipping the service manager watchdog ping: %s", err)
+				continue
+			}
+			notifier.notify(systemd.StateWatchdog)
+		case <-notifier.quit:
+			return
+		}
+	}
+}
+
+// notifyReady tells the service manager that kaspad finished starting up
+func (notifier *serviceNotifier) notifyReady() {
+	notifier.notify(systemd.StateReady + "\nSTATUS=Running kaspad " + version.Version())
+}
+
+// notifyStopping tells the service manager that kaspad is shutting down
+func (notifier *serviceNotifier) notifyStopping() {
+	notifier.notify(systemd.StateStopping + "\nSTATUS=Shutting down")
+}
+
+// stop stops pinging the watchdog. It must be called before the database
+// is closed.
+func (notifier *serviceNotifier) stop() error {
+	close(notifier.quit)
+	<-notifier.done
+	return nil
+}
+
+func (notifier *serviceNotifier) notify(state string) {
+	_, err := systemd.Notify(state)
+	if err != nil {
+		log.Warnf("Failed to notify the service manager: %s", err)
+	}
+}
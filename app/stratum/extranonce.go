@@ -0,0 +1,106 @@
+package stratum
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// extranonceSize is the number of leading nonce bytes reserved by the
+// server for every connection. The remaining bytes of the 8-byte nonce are
+// searched by the miner itself.
+const extranonceSize = 2
+
+const maxExtranonce = 1<<(extranonceSize*8) - 1
+
+// errExtranonceSpaceExhausted is returned when all extranonces are in use
+var errExtranonceSpaceExhausted = errors.New("extranonce space is exhausted")
+
+// extranonceAllocator hands out unique extranonces to connected miners so
+// that no two miners ever search the same nonce range
+type extranonceAllocator struct {
+	lock  sync.Mutex
+	next  uint32
+	inUse map[uint32]struct{}
+}
+
+func newExtranonceAllocator() *extranonceAllocator {
+	return &extranonceAllocator{
+		inUse: make(map[uint32]struct{}),
+	}
+}
+
+// allocate returns an extranonce that is not currently used by any connection
+func (ea *extranonceAllocator) allocate() (uint32, error) {
+	ea.lock.Lock()
+	defer ea.lock.Unlock()
+
+	if len(ea.inUse) > maxExtranonce {
+		return 0, errExtranonceSpaceExhausted
+	}
+	for {
+		candidate := ea.next
+		ea.next = (ea.next + 1) & maxExtranonce
+		if _, ok := ea.inUse[candidate]; !ok {
+			ea.inUse[candidate] = struct{}{}
+			return candidate, nil
+		}
+	}
+}
+
+// release returns the given extranonce to the pool
+func (ea *extranonceAllocator) release(extranonce uint32) {
+	ea.lock.Lock()
+	defer ea.lock.Unlock()
+
+	delete(ea.inUse, extranonce)
+}
+
+// extranonceToHex returns the hex representation of the extranonce the way
+// it is sent to miners
+func extranonceToHex(extranonce uint32) string {
+	return hex.EncodeToString(extranonceToBytes(extranonce))
+}
+
+func extranonceToBytes(extranonce uint32) []byte {
+	extranonceBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(extranonceBytes, extranonce)
+	return extranonceBytes[4-extranonceSize:]
+}
+
+// parseSubmittedNonce parses a nonce submitted by a miner. Miners may submit
+// either the full 8-byte nonce or only the part they searched, in which case
+// the connection's extranonce is prepended. The returned nonce is verified to
+// lie within the extranonce's range.
+func parseSubmittedNonce(nonceString string, extranonce uint32) (uint64, error) {
+	nonceHex := nonceString
+	if len(nonceHex) >= 2 && nonceHex[:2] == "0x" {
+		nonceHex = nonceHex[2:]
+	}
+	const fullNonceHexLength = 16
+	if len(nonceHex) < fullNonceHexLength {
+		searchedHexLength := fullNonceHexLength - extranonceSize*2
+		if len(nonceHex) > searchedHexLength {
+			return 0, errors.Errorf("nonce %s has an invalid length", nonceString)
+		}
+		for len(nonceHex) < searchedHexLength {
+			nonceHex = "0" + nonceHex
+		}
+		nonceHex = extranonceToHex(extranonce) + nonceHex
+	}
+	if len(nonceHex) != fullNonceHexLength {
+		return 0, errors.Errorf("nonce %s has an invalid length", nonceString)
+	}
+	nonceBytes, err := hex.DecodeString(nonceHex)
+	if err != nil {
+		return 0, errors.Wrapf(err, "nonce %s is not valid hex", nonceString)
+	}
+	nonce := binary.BigEndian.Uint64(nonceBytes)
+	if uint32(nonce>>(64-extranonceSize*8)) != extranonce {
+		return 0, errors.Errorf("nonce %s is outside of the assigned extranonce range %s",
+			nonceString, extranonceToHex(extranonce))
+	}
+	return nonce, nil
+}
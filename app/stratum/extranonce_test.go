@@ -0,0 +1,99 @@
+package stratum
+
+import (
+	"testing"
+)
+
+func TestExtranonceAllocator(t *testing.T) {
+	allocator := newExtranonceAllocator()
+
+	first, err := allocator.allocate()
+	if err != nil {
+		t.Fatalf("allocate: %s", err)
+	}
+	second, err := allocator.allocate()
+	if err != nil {
+		t.Fatalf("allocate: %s", err)
+	}
+	if first == second {
+		t.Fatalf("got the same extranonce %d twice", first)
+	}
+
+	for i := 2; i <= maxExtranonce; i++ {
+		_, err := allocator.allocate()
+		if err != nil {
+			t.Fatalf("allocate %d: %s", i, err)
+		}
+	}
+	_, err = allocator.allocate()
+	if err != errExtranonceSpaceExhausted {
+		t.Fatalf("expected errExtranonceSpaceExhausted, got %v", err)
+	}
+
+	allocator.release(second)
+	reallocated, err := allocator.allocate()
+	if err != nil {
+		t.Fatalf("allocate after release: %s", err)
+	}
+	if reallocated != second {
+		t.Fatalf("expected released extranonce %d to be reallocated, got %d", second, reallocated)
+	}
+}
+
+func TestParseSubmittedNonce(t *testing.T) {
+	const extranonce = 0x1234
+	tests := []struct {
+		nonce         string
+		expectedNonce uint64
+		expectedError bool
+	}{
+		{nonce: "1234000000000001", expectedNonce: 0x1234000000000001},
+		{nonce: "0x1234abcdef012345", expectedNonce: 0x1234abcdef012345},
+		{nonce: "abcdef012345", expectedNonce: 0x1234abcdef012345},
+		{nonce: "1", expectedNonce: 0x1234000000000001},
+		{nonce: "4321000000000001", expectedError: true},
+		{nonce: "1234abcdef0123456", expectedError: true},
+		{nonce: "234abcdef012345", expectedError: true},
+		{nonce: "zz", expectedError: true},
+	}
+
+	for _, test := range tests {
+		nonce, err := parseSubmittedNonce(test.nonce, extranonce)
+		if test.expectedError {
+			if err == nil {
+				t.Errorf("%s: expected an error, got nonce %x", test.nonce, nonce)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", test.nonce, err)
+			continue
+		}
+		if nonce != test.expectedNonce {
+			t.Errorf("%s: expected nonce %x, got %x", test.nonce, test.expectedNonce, nonce)
+		}
+	}
+}
+
+func TestShareTarget(t *testing.T) {
+	target, err := shareTarget(1)
+	if err != nil {
+		t.Fatalf("shareTarget: %s", err)
+	}
+	if target.Cmp(difficultyOneTarget) != 0 {
+		t.Fatalf("expected difficulty 1 to map to %x, got %x", difficultyOneTarget, target)
+	}
+
+	doubleTarget, err := shareTarget(0.5)
+	if err != nil {
+		t.Fatalf("shareTarget: %s", err)
+	}
+	if doubleTarget.Cmp(target) <= 0 {
+		t.Fatalf("expected a lower difficulty to yield a higher target")
+	}
+
+	_, err = shareTarget(0)
+	if err == nil {
+		t.Fatalf("expected an error for difficulty 0")
+	}
+}
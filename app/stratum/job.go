@@ -0,0 +1,107 @@
+package stratum
+
+import (
+	"encoding/binary"
+	"strconv"
+	"sync"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/pow"
+)
+
+// maxJobsPerSession is the amount of recent jobs a session keeps around, so
+// that shares for slightly outdated jobs can still be validated
+const maxJobsPerSession = 16
+
+// job is a unit of work derived from a block template
+type job struct {
+	id       string
+	block    *externalapi.DomainBlock
+	powState *pow.State
+
+	submittedNoncesLock sync.Mutex
+	submittedNonces     map[uint64]struct{}
+}
+
+func newJob(id uint64, block *externalapi.DomainBlock) *job {
+	return &job{
+		id:              strconv.FormatUint(id, 16),
+		block:           block,
+		powState:        pow.NewState(block.Header.ToMutable()),
+		submittedNonces: make(map[uint64]struct{}),
+	}
+}
+
+// notifyParams returns the parameters of the mining.notify message for this
+// job: the job ID, the pre-PoW hash as four little-endian 64-bit words, and
+// the block timestamp
+func (j *job) notifyParams() []interface{} {
+	prePowHash := j.powState.PrePowHash().ByteSlice()
+	prePowHashWords := make([]uint64, 4)
+	for i := range prePowHashWords {
+		prePowHashWords[i] = binary.LittleEndian.Uint64(prePowHash[i*8:])
+	}
+	return []interface{}{j.id, prePowHashWords, j.powState.Timestamp}
+}
+
+// markNonceSubmitted records the given nonce and returns false if it had
+// already been submitted for this job
+func (j *job) markNonceSubmitted(nonce uint64) bool {
+	j.submittedNoncesLock.Lock()
+	defer j.submittedNoncesLock.Unlock()
+
+	if _, ok := j.submittedNonces[nonce]; ok {
+		return false
+	}
+	j.submittedNonces[nonce] = struct{}{}
+	return true
+}
+
+// jobStore keeps the most recent jobs of a session
+type jobStore struct {
+	lock   sync.Mutex
+	nextID uint64
+	jobs   map[string]*job
+	order  []string
+}
+
+func newJobStore() *jobStore {
+	return &jobStore{
+		jobs: make(map[string]*job),
+	}
+}
+
+// add creates a new job from the given block, evicting the oldest job if
+// the store is full
+func (js *jobStore) add(block *externalapi.DomainBlock) *job {
+	js.lock.Lock()
+	defer js.lock.Unlock()
+
+	js.nextID++
+	newJob := newJob(js.nextID, block)
+	js.jobs[newJob.id] = newJob
+	js.order = append(js.order, newJob.id)
+	if len(js.order) > maxJobsPerSession {
+		delete(js.jobs, js.order[0])
+		js.order = js.order[1:]
+	}
+	return newJob
+}
+
+func (js *jobStore) get(id string) (*job, bool) {
+	js.lock.Lock()
+	defer js.lock.Unlock()
+
+	job, ok := js.jobs[id]
+	return job, ok
+}
+
+func (js *jobStore) latest() (*job, bool) {
+	js.lock.Lock()
+	defer js.lock.Unlock()
+
+	if len(js.order) == 0 {
+		return nil, false
+	}
+	return js.jobs[js.order[len(js.order)-1]], true
+}
@@ -0,0 +1,65 @@
+package stratum
+
+import (
+	"encoding/json"
+)
+
+// Stratum methods supported by the server
+const (
+	methodSubscribe     = "mining.subscribe"
+	methodAuthorize     = "mining.authorize"
+	methodSubmit        = "mining.submit"
+	methodNotify        = "mining.notify"
+	methodSetDifficulty = "mining.set_difficulty"
+	methodSetExtranonce = "mining.set_extranonce"
+)
+
+// stratumProtocolVersion is returned to miners on subscription. Most kaspa
+// mining software expects the EthereumStratum flavor of the protocol, in which
+// the extranonce is a prefix of the nonce
+const stratumProtocolVersion = "EthereumStratum/1.0.0"
+
+// request is a single line sent by a stratum client
+type request struct {
+	ID     interface{}       `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+// response is a reply to a client request
+type response struct {
+	ID     interface{}   `json:"id"`
+	Result interface{}   `json:"result"`
+	Error  []interface{} `json:"error"`
+}
+
+// notification is a server-initiated message
+type notification struct {
+	ID     interface{}   `json:"id"`
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+}
+
+// Stratum error codes, as commonly used by mining pools
+const (
+	errorCodeOther         = 20
+	errorCodeJobNotFound   = 21
+	errorCodeDuplicate     = 22
+	errorCodeLowDifficulty = 23
+	errorCodeUnauthorized  = 24
+	errorCodeNotSubscribed = 25
+)
+
+func newErrorResponse(id interface{}, code int, message string) *response {
+	return &response{
+		ID:    id,
+		Error: []interface{}{code, message, nil},
+	}
+}
+
+func newResultResponse(id interface{}, result interface{}) *response {
+	return &response{
+		ID:     id,
+		Result: result,
+	}
+}
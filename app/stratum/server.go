@@ -0,0 +1,244 @@
+package stratum
+
+import (
+	"math/big"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/app/rpc"
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/pkg/errors"
+)
+
+// jobRefreshInterval is the interval in which miners get a fresh job even if
+// no new block template notification arrived, so that new mempool
+// transactions get mined
+const jobRefreshInterval = 2 * time.Second
+
+// Server is a Stratum (v1) server that lets standard mining software mine
+// directly against the node's block template generator
+type Server struct {
+	cfg        *config.Config
+	domain     domain.Domain
+	rpcManager *rpc.Manager
+
+	listeners       []net.Listener
+	shareDifficulty float64
+	shareTarget     *big.Int
+	extranonces     *extranonceAllocator
+	stats           *Stats
+
+	sessionsLock sync.RWMutex
+	sessions     map[*session]struct{}
+
+	newBlockTemplateChan chan struct{}
+	isStopped            uint32
+	quit                 chan struct{}
+}
+
+// New creates a new Stratum server. Start must be called for it to start
+// accepting connections.
+func New(cfg *config.Config, domain domain.Domain, rpcManager *rpc.Manager) (*Server, error) {
+	target, err := shareTarget(cfg.StratumShareDifficulty)
+	if err != nil {
+		return nil, err
+	}
+	return &Server{
+		cfg:             cfg,
+		domain:          domain,
+		rpcManager:      rpcManager,
+		shareDifficulty: cfg.StratumShareDifficulty,
+		shareTarget:     target,
+		extranonces:     newExtranonceAllocator(),
+		stats:           &Stats{},
+		sessions:        make(map[*session]struct{}),
+
+		newBlockTemplateChan: make(chan struct{}, 1),
+		quit:                 make(chan struct{}),
+	}, nil
+}
+
+// Start starts listening on all the configured stratum listeners
+func (s *Server) Start() error {
+	for _, listenAddress := range s.cfg.StratumListeners {
+		listener, err := net.Listen("tcp", listenAddress)
+		if err != nil {
+			return errors.Wrapf(err, "error listening for stratum connections on %s", listenAddress)
+		}
+		s.listeners = append(s.listeners, listener)
+		log.Infof("Stratum server listening on %s", listener.Addr())
+
+		spawn("stratum.Server.acceptLoop", func() {
+			s.acceptLoop(listener)
+		})
+	}
+
+	spawn("stratum.Server.refreshJobsLoop", s.refreshJobsLoop)
+	return nil
+}
+
+// Stop closes all listeners and connected sessions
+func (s *Server) Stop() {
+	if !atomic.CompareAndSwapUint32(&s.isStopped, 0, 1) {
+		return
+	}
+	close(s.quit)
+
+	for _, listener := range s.listeners {
+		err := listener.Close()
+		if err != nil {
+			log.Warnf("Error closing stratum listener %s: %s", listener.Addr(), err)
+		}
+	}
+
+	s.sessionsLock.RLock()
+	defer s.sessionsLock.RUnlock()
+	for session := range s.sessions {
+		session.close()
+	}
+}
+
+// Stats returns a snapshot of the server's share statistics
+func (s *Server) Stats() Stats {
+	return s.stats.snapshot()
+}
+
+// SessionCount returns the amount of currently connected miners
+func (s *Server) SessionCount() int {
+	s.sessionsLock.RLock()
+	defer s.sessionsLock.RUnlock()
+
+	return len(s.sessions)
+}
+
+// NotifyNewBlockTemplate signals that all connected miners should get a new job.
+// Jobs are built and sent asynchronously so that block processing is never
+// blocked on slow miners.
+func (s *Server) NotifyNewBlockTemplate() error {
+	select {
+	case s.newBlockTemplateChan <- struct{}{}:
+	default:
+		// A new job broadcast is already pending
+	}
+	return nil
+}
+
+func (s *Server) acceptLoop(listener net.Listener) {
+	for {
+		connection, err := listener.Accept()
+		if err != nil {
+			if atomic.LoadUint32(&s.isStopped) != 0 {
+				return
+			}
+			log.Warnf("Error accepting stratum connection on %s: %s", listener.Addr(), err)
+			continue
+		}
+		s.handleConnection(connection)
+	}
+}
+
+func (s *Server) handleConnection(connection net.Conn) {
+	if s.cfg.StratumMaxClients > 0 && s.SessionCount() >= s.cfg.StratumMaxClients {
+		log.Warnf("Limit of %d stratum clients has been exceeded, rejecting %s",
+			s.cfg.StratumMaxClients, connection.RemoteAddr())
+		_ = connection.Close()
+		return
+	}
+
+	extranonce, err := s.extranonces.allocate()
+	if err != nil {
+		log.Warnf("Rejecting stratum connection from %s: %s", connection.RemoteAddr(), err)
+		_ = connection.Close()
+		return
+	}
+
+	session := newSession(s, connection, extranonce, s.shareTarget)
+	s.sessionsLock.Lock()
+	s.sessions[session] = struct{}{}
+	s.sessionsLock.Unlock()
+
+	log.Infof("Stratum miner connected from %s", session)
+
+	spawn("stratum.Server.handleConnection", func() {
+		defer func() {
+			session.close()
+			s.extranonces.release(extranonce)
+
+			s.sessionsLock.Lock()
+			delete(s.sessions, session)
+			s.sessionsLock.Unlock()
+
+			log.Infof("Stratum miner %s disconnected", session)
+		}()
+
+		err := session.handle()
+		if err != nil {
+			log.Warnf("Error handling stratum connection %s: %s", session, err)
+		}
+	})
+}
+
+func (s *Server) refreshJobsLoop() {
+	ticker := time.NewTicker(jobRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.quit:
+			return
+		case <-s.newBlockTemplateChan:
+			s.broadcastNewJob()
+			ticker.Reset(jobRefreshInterval)
+		case <-ticker.C:
+			s.broadcastNewJob()
+		}
+	}
+}
+
+func (s *Server) broadcastNewJob() {
+	s.sessionsLock.RLock()
+	sessions := make([]*session, 0, len(s.sessions))
+	for session := range s.sessions {
+		sessions = append(sessions, session)
+	}
+	s.sessionsLock.RUnlock()
+
+	for _, session := range sessions {
+		err := session.sendNewJob()
+		if err != nil {
+			log.Warnf("Error sending a new job to stratum miner %s: %s", session, err)
+			session.close()
+		}
+	}
+}
+
+// blockRejectedError is returned from submitBlock when a found block is
+// rejected by consensus or by the node's submission policy
+type blockRejectedError struct {
+	reason string
+}
+
+func (e *blockRejectedError) Error() string {
+	return e.reason
+}
+
+// submitBlock adds a block found by a stratum miner to the DAG and relays it,
+// the same way the SubmitBlock RPC command does
+func (s *Server) submitBlock(block *externalapi.DomainBlock) error {
+	response, err := s.rpcManager.SubmitBlock(block, "stratum")
+	if err != nil {
+		return err
+	}
+	if response.Error != nil {
+		if response.RejectReason == appmessage.RejectReasonBlockInvalid {
+			s.stats.addRejectedBlock()
+		}
+		return &blockRejectedError{reason: response.Error.Message}
+	}
+	s.stats.addBlock()
+	return nil
+}
@@ -0,0 +1,269 @@
+package stratum
+
+import (
+	"bufio"
+	"encoding/json"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/version"
+	"github.com/pkg/errors"
+)
+
+// maxRequestLength is the maximum length of a single line sent by a miner
+const maxRequestLength = 16 * 1024
+
+// session represents a single connected miner
+type session struct {
+	server     *Server
+	connection net.Conn
+
+	writeLock sync.Mutex
+
+	extranonce   uint32
+	target       *big.Int
+	jobs         *jobStore
+	isClosed     uint32
+	isStarted    uint32
+	workerName   string
+	payAddress   util.Address
+	stateLock    sync.RWMutex
+	isSubscribed bool
+}
+
+func newSession(server *Server, connection net.Conn, extranonce uint32, target *big.Int) *session {
+	return &session{
+		server:     server,
+		connection: connection,
+		extranonce: extranonce,
+		target:     target,
+		jobs:       newJobStore(),
+	}
+}
+
+func (s *session) String() string {
+	return s.connection.RemoteAddr().String()
+}
+
+// handle reads and handles requests until the connection is closed
+func (s *session) handle() error {
+	scanner := bufio.NewScanner(s.connection)
+	scanner.Buffer(make([]byte, 0, maxRequestLength), maxRequestLength)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		req := &request{}
+		err := json.Unmarshal([]byte(line), req)
+		if err != nil {
+			return errors.Wrapf(err, "received malformed request from %s", s)
+		}
+		err = s.handleRequest(req)
+		if err != nil {
+			return err
+		}
+	}
+	if atomic.LoadUint32(&s.isClosed) != 0 {
+		return nil
+	}
+	return scanner.Err()
+}
+
+func (s *session) handleRequest(req *request) error {
+	switch req.Method {
+	case methodSubscribe:
+		return s.handleSubscribe(req)
+	case methodAuthorize:
+		return s.handleAuthorize(req)
+	case methodSubmit:
+		return s.handleSubmit(req)
+	default:
+		log.Debugf("Received unsupported method %s from %s", req.Method, s)
+		return s.send(newErrorResponse(req.ID, errorCodeOther, "unsupported method "+req.Method))
+	}
+}
+
+func (s *session) handleSubscribe(req *request) error {
+	s.stateLock.Lock()
+	s.isSubscribed = true
+	s.stateLock.Unlock()
+
+	err := s.send(newResultResponse(req.ID, []interface{}{true, stratumProtocolVersion}))
+	if err != nil {
+		return err
+	}
+	return s.send(&notification{
+		Method: methodSetExtranonce,
+		Params: []interface{}{extranonceToHex(s.extranonce), 8 - extranonceSize},
+	})
+}
+
+func (s *session) handleAuthorize(req *request) error {
+	s.stateLock.RLock()
+	isSubscribed := s.isSubscribed
+	s.stateLock.RUnlock()
+	if !isSubscribed {
+		return s.send(newErrorResponse(req.ID, errorCodeNotSubscribed, "not subscribed"))
+	}
+	if len(req.Params) < 1 {
+		return s.send(newErrorResponse(req.ID, errorCodeOther, "missing worker name"))
+	}
+	var username string
+	err := json.Unmarshal(req.Params[0], &username)
+	if err != nil {
+		return s.send(newErrorResponse(req.ID, errorCodeOther, "malformed worker name"))
+	}
+
+	// The username is expected to be of the form <address>.<worker name>
+	addressString, workerName := username, ""
+	if dotIndex := strings.LastIndex(username, "."); dotIndex >= 0 {
+		addressString, workerName = username[:dotIndex], username[dotIndex+1:]
+	}
+	payAddress, err := util.DecodeAddress(addressString, s.server.cfg.ActiveNetParams.Prefix)
+	if err != nil {
+		return s.send(newErrorResponse(req.ID, errorCodeUnauthorized,
+			"could not decode address "+addressString+": "+err.Error()))
+	}
+
+	s.stateLock.Lock()
+	s.payAddress = payAddress
+	s.workerName = workerName
+	s.stateLock.Unlock()
+
+	log.Infof("Stratum miner %s authorized worker %s for address %s", s, workerName, payAddress)
+
+	err = s.send(newResultResponse(req.ID, true))
+	if err != nil {
+		return err
+	}
+	err = s.send(&notification{
+		Method: methodSetDifficulty,
+		Params: []interface{}{s.server.shareDifficulty},
+	})
+	if err != nil {
+		return err
+	}
+
+	atomic.StoreUint32(&s.isStarted, 1)
+	return s.sendNewJob()
+}
+
+func (s *session) handleSubmit(req *request) error {
+	if atomic.LoadUint32(&s.isStarted) == 0 {
+		return s.send(newErrorResponse(req.ID, errorCodeUnauthorized, "unauthorized worker"))
+	}
+	if len(req.Params) < 3 {
+		return s.send(newErrorResponse(req.ID, errorCodeOther, "expected worker, job ID and nonce"))
+	}
+	var jobID, nonceString string
+	err := json.Unmarshal(req.Params[1], &jobID)
+	if err != nil {
+		return s.send(newErrorResponse(req.ID, errorCodeOther, "malformed job ID"))
+	}
+	err = json.Unmarshal(req.Params[2], &nonceString)
+	if err != nil {
+		return s.send(newErrorResponse(req.ID, errorCodeOther, "malformed nonce"))
+	}
+
+	job, ok := s.jobs.get(jobID)
+	if !ok {
+		return s.send(newErrorResponse(req.ID, errorCodeJobNotFound, "job not found"))
+	}
+	nonce, err := parseSubmittedNonce(nonceString, s.extranonce)
+	if err != nil {
+		return s.send(newErrorResponse(req.ID, errorCodeOther, err.Error()))
+	}
+	if !job.markNonceSubmitted(nonce) {
+		s.server.stats.addDuplicateShare()
+		return s.send(newErrorResponse(req.ID, errorCodeDuplicate, "duplicate share"))
+	}
+
+	result := validateShare(job, nonce, s.target)
+	if !result.meetsShareTarget {
+		s.server.stats.addInvalidShare()
+		return s.send(newErrorResponse(req.ID, errorCodeLowDifficulty, "low difficulty share"))
+	}
+	s.server.stats.addValidShare()
+
+	if result.block != nil {
+		err := s.server.submitBlock(result.block)
+		if err != nil {
+			var rejectErr *blockRejectedError
+			if errors.As(err, &rejectErr) {
+				log.Warnf("Block %s found by %s was rejected: %s",
+					consensushashing.BlockHash(result.block), s, rejectErr)
+				return s.send(newErrorResponse(req.ID, errorCodeOther, rejectErr.Error()))
+			}
+			return err
+		}
+		log.Infof("Block %s found by stratum worker %s (%s)",
+			consensushashing.BlockHash(result.block), s.workerName, s)
+	}
+
+	return s.send(newResultResponse(req.ID, true))
+}
+
+// sendNewJob builds a template for the session's pay address and sends it to the miner
+func (s *session) sendNewJob() error {
+	if atomic.LoadUint32(&s.isStarted) == 0 || atomic.LoadUint32(&s.isClosed) != 0 {
+		return nil
+	}
+
+	s.stateLock.RLock()
+	payAddress := s.payAddress
+	s.stateLock.RUnlock()
+
+	scriptPublicKey, err := txscript.PayToAddrScript(payAddress)
+	if err != nil {
+		return err
+	}
+	coinbaseData := &externalapi.DomainCoinbaseData{
+		ScriptPublicKey: scriptPublicKey,
+		ExtraData:       []byte(version.Version() + "/stratum"),
+	}
+	block, _, err := s.server.domain.MiningManager().GetBlockTemplate(coinbaseData)
+	if err != nil {
+		return err
+	}
+
+	if latestJob, ok := s.jobs.latest(); ok && latestJob.block.Header.Equal(block.Header) {
+		return nil
+	}
+	newJob := s.jobs.add(block)
+	return s.send(&notification{
+		Method: methodNotify,
+		Params: newJob.notifyParams(),
+	})
+}
+
+func (s *session) send(message interface{}) error {
+	messageBytes, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	messageBytes = append(messageBytes, '\n')
+
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	_, err = s.connection.Write(messageBytes)
+	return err
+}
+
+func (s *session) close() {
+	if !atomic.CompareAndSwapUint32(&s.isClosed, 0, 1) {
+		return
+	}
+	err := s.connection.Close()
+	if err != nil {
+		log.Debugf("Error closing stratum connection %s: %s", s, err)
+	}
+}
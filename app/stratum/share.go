@@ -0,0 +1,57 @@
+package stratum
+
+import (
+	"math/big"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+// difficultyOneTarget is the share target that corresponds to difficulty 1,
+// i.e. on average 2^32 hashes per share
+var difficultyOneTarget = new(big.Int).Lsh(big.NewInt(1), 256-32)
+
+// shareTarget converts the given share difficulty to a target
+func shareTarget(shareDifficulty float64) (*big.Int, error) {
+	if shareDifficulty <= 0 {
+		return nil, errors.Errorf("share difficulty must be positive, got %f", shareDifficulty)
+	}
+	target := new(big.Float).SetInt(difficultyOneTarget)
+	target.Quo(target, big.NewFloat(shareDifficulty))
+	targetInt, _ := target.Int(nil)
+	return targetInt, nil
+}
+
+// shareResult is the outcome of validating a single share
+type shareResult struct {
+	// meetsShareTarget is true if the share satisfies the session's share difficulty
+	meetsShareTarget bool
+	// block is non-nil if the share also satisfies the network target
+	block *externalapi.DomainBlock
+}
+
+// validateShare checks the given nonce against both the share target and the
+// block's network target. If the network target is met, a copy of the job's
+// block with the nonce set is returned.
+func validateShare(job *job, nonce uint64, target *big.Int) *shareResult {
+	state := *job.powState
+	state.Nonce = nonce
+	powValue := state.CalculateProofOfWorkValue()
+
+	result := &shareResult{
+		meetsShareTarget: powValue.Cmp(target) <= 0,
+	}
+	if powValue.Cmp(&state.Target) <= 0 {
+		// The network target is usually way below the share target, but we
+		// don't want to lose a block in case a miner was assigned a share
+		// difficulty above the network difficulty.
+		result.meetsShareTarget = true
+
+		mutableHeader := job.block.Header.ToMutable()
+		mutableHeader.SetNonce(nonce)
+		block := *job.block
+		block.Header = mutableHeader.ToImmutable()
+		result.block = &block
+	}
+	return result
+}
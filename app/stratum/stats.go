@@ -0,0 +1,28 @@
+package stratum
+
+import "sync/atomic"
+
+// Stats holds counters describing the work submitted to the stratum server
+type Stats struct {
+	ValidShares     uint64
+	InvalidShares   uint64
+	DuplicateShares uint64
+	BlocksFound     uint64
+	BlocksRejected  uint64
+}
+
+func (s *Stats) addValidShare()     { atomic.AddUint64(&s.ValidShares, 1) }
+func (s *Stats) addInvalidShare()   { atomic.AddUint64(&s.InvalidShares, 1) }
+func (s *Stats) addDuplicateShare() { atomic.AddUint64(&s.DuplicateShares, 1) }
+func (s *Stats) addBlock()          { atomic.AddUint64(&s.BlocksFound, 1) }
+func (s *Stats) addRejectedBlock()  { atomic.AddUint64(&s.BlocksRejected, 1) }
+
+func (s *Stats) snapshot() Stats {
+	return Stats{
+		ValidShares:     atomic.LoadUint64(&s.ValidShares),
+		InvalidShares:   atomic.LoadUint64(&s.InvalidShares),
+		DuplicateShares: atomic.LoadUint64(&s.DuplicateShares),
+		BlocksFound:     atomic.LoadUint64(&s.BlocksFound),
+		BlocksRejected:  atomic.LoadUint64(&s.BlocksRejected),
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcauth"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultName     = "rpc"
+	defaultValidity = 10 * 365 * 24 * time.Hour
+	defaultKeyType  = rpcauth.KeyTypeECDSAP256
+)
+
+type configFlags struct {
+	Directory    string        `short:"d" long:"directory" description:"Directory to write the certificate and the key to"`
+	Name         string        `short:"n" long:"name" description:"Name of the files to write, which are <name>.cert and <name>.key"`
+	CommonName   string        `long:"commonname" description:"Common name of the certificate. Defaults to the hostname. Client certificates are authorized by kaspad's --rpccertauth according to it"`
+	Organization string        `short:"o" long:"org" description:"Organization of the certificate"`
+	Hosts        []string      `short:"H" long:"host" description:"DNS name or IP address the certificate is valid for. May be specified more than once. Defaults to localhost, 127.0.0.1, ::1 and the hostname"`
+	Validity     time.Duration `long:"validity" description:"How long the certificate is valid for, such as 8760h"`
+	KeyType      string        `long:"keytype" description:"Type of the key: ecdsa-p256, ecdsa-p384, ed25519, rsa-2048 or rsa-4096"`
+	IsCA         bool          `long:"ca" description:"Generate a CA certificate, which may sign other certificates"`
+	SignCert     string        `long:"signcert" description:"File containing the CA certificate to sign the certificate with. If not set, the certificate is self-signed"`
+	SignKey      string        `long:"signkey" description:"File containing the key of the CA certificate in signcert"`
+	Force        bool          `short:"f" long:"force" description:"Overwrite the certificate and the key if they already exist"`
+}
+
+func parseConfig() (*configFlags, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting the hostname")
+	}
+
+	cfg := &configFlags{
+		Directory:  ".",
+		Name:       defaultName,
+		CommonName: hostname,
+		Validity:   defaultValidity,
+		KeyType:    defaultKeyType,
+	}
+	parser := flags.NewParser(cfg, flags.PrintErrors|flags.HelpFlag)
+	_, err = parser.Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cfg.Hosts) == 0 {
+		cfg.Hosts = []string{"localhost", "127.0.0.1", "::1"}
+		if hostname != "localhost" {
+			cfg.Hosts = append(cfg.Hosts, hostname)
+		}
+	}
+	if cfg.Name == "" || strings.ContainsAny(cfg.Name, `/\`) {
+		return nil, errors.Errorf("--name must be a non-empty file name, but got %q", cfg.Name)
+	}
+	if (cfg.SignCert == "") != (cfg.SignKey == "") {
+		return nil, errors.New("--signcert and --signkey must be used together")
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcauth"
+	"github.com/pkg/errors"
+)
+
+func main() {
+	cfg, err := parseConfig()
+	if err != nil {
+		printErrorAndExit(err)
+	}
+
+	err = generateCertificate(cfg)
+	if err != nil {
+		printErrorAndExit(err)
+	}
+}
+
+func generateCertificate(cfg *configFlags) error {
+	var parent *tls.Certificate
+	if cfg.SignCert != "" {
+		keyPair, err := tls.LoadX509KeyPair(cfg.SignCert, cfg.SignKey)
+		if err != nil {
+			return errors.Wrap(err, "error loading the signing certificate")
+		}
+		parent = &keyPair
+	}
+
+	certificatePEM, keyPEM, err := rpcauth.GenerateCertificate(&rpcauth.CertificateOptions{
+		CommonName:   cfg.CommonName,
+		Organization: cfg.Organization,
+		Hosts:        cfg.Hosts,
+		ValidFor:     cfg.Validity,
+		KeyType:      cfg.KeyType,
+		IsCA:         cfg.IsCA,
+	}, parent)
+	if err != nil {
+		return err
+	}
+
+	certificateFile := filepath.Join(cfg.Directory, cfg.Name+".cert")
+	keyFile := filepath.Join(cfg.Directory, cfg.Name+".key")
+	// The key is written first, so that a certificate is never written
+	// without its key
+	err = writeFile(keyFile, keyPEM, 0600, cfg.Force)
+	if err != nil {
+		return err
+	}
+	err = writeFile(certificateFile, certificatePEM, 0644, cfg.Force)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Certificate: %s\n", certificateFile)
+	fmt.Printf("Key: %s\n", keyFile)
+	return nil
+}
+
+// writeFile writes data to a new file with the given permissions. If the file
+// already exists, it's replaced if force is set, and otherwise an error is
+// returned.
+func writeFile(path string, data []byte, perm os.FileMode, force bool) error {
+	flag := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if force {
+		flag = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	file, err := os.OpenFile(path, flag, perm)
+	if err != nil {
+		if os.IsExist(err) {
+			return errors.Errorf("%s already exists. Use --force to overwrite it", path)
+		}
+		return errors.WithStack(err)
+	}
+	_, err = file.Write(data)
+	if err != nil {
+		file.Close()
+		return errors.WithStack(err)
+	}
+	// The permissions of an overwritten file are kept by os.OpenFile
+	err = file.Chmod(perm)
+	if err != nil {
+		file.Close()
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(file.Close())
+}
+
+func printErrorAndExit(err error) {
+	fmt.Fprintf(os.Stderr, "%s\n", err)
+	os.Exit(1)
+}
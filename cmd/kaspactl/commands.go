@@ -28,6 +28,11 @@ var commandTypes = []reflect.Type{
 
 	reflect.TypeOf(protowire.KaspadMessage_GetBlockTemplateRequest{}),
 	reflect.TypeOf(protowire.KaspadMessage_SubmitBlockRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetBlockSubmissionStatusRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GenerateBlocksRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetAuxCommitmentProofRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetWorkRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_SubmitWorkRequest{}),
 
 	reflect.TypeOf(protowire.KaspadMessage_GetMempoolEntryRequest{}),
 	reflect.TypeOf(protowire.KaspadMessage_GetMempoolEntriesRequest{}),
@@ -38,9 +43,35 @@ var commandTypes = []reflect.Type{
 	reflect.TypeOf(protowire.KaspadMessage_GetUtxosByAddressesRequest{}),
 	reflect.TypeOf(protowire.KaspadMessage_GetBalanceByAddressRequest{}),
 	reflect.TypeOf(protowire.KaspadMessage_GetCoinSupplyRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_SearchRawTransactionsRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_HealthCheckRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_SignRawTransactionRequest{}),
 
 	reflect.TypeOf(protowire.KaspadMessage_BanRequest{}),
 	reflect.TypeOf(protowire.KaspadMessage_UnbanRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_SetBanRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_ListBannedRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_ClearBannedRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_AddTransactionBlocklistEntriesRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_RemoveTransactionBlocklistEntriesRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetTransactionBlocklistRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_DumpUtxoSetRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_ImportUtxoSetRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetJobsRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_CancelJobRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetFinalityPointRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetDatabaseStatsRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_CompactDatabaseRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_BackupDatabaseRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetIndexStatusRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_RebuildIndexRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_DropIndexRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_ExportBlocksRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetAddressManagerInfoRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_ReloadConfigRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_CaptureProfileRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_DebugLevelRequest{}),
+	reflect.TypeOf(protowire.KaspadMessage_GetChainAnalysisRequest{}),
 }
 
 type commandDescription struct {
@@ -94,3 +125,33 @@ func (cd *commandDescription) help() string {
 	}
 	return sb.String()
 }
+
+// parameterHints returns the command with the name and type of each of its
+// parameters, one parameter per line
+func (cd *commandDescription) parameterHints() string {
+	if len(cd.parameters) == 0 {
+		return fmt.Sprintf("%s takes no parameters", cd.name)
+	}
+	sb := &strings.Builder{}
+	sb.WriteString(cd.help())
+	for _, parameter := range cd.parameters {
+		_, _ = fmt.Fprintf(sb, "\n\t%s: %s", parameter.name, parameter.typeHint())
+	}
+	return sb.String()
+}
+
+// typeHint describes the format the value of the parameter is expected in
+func (pd *parameterDescription) typeHint() string {
+	typeof := pd.typeof
+	if typeof.Kind() == reflect.Ptr {
+		typeof = typeof.Elem()
+	}
+	switch typeof.Kind() {
+	case reflect.Struct:
+		return "JSON object"
+	case reflect.Slice:
+		return "comma-separated list of strings"
+	default:
+		return typeof.Kind().String()
+	}
+}
@@ -1,21 +1,39 @@
 package main
 
 import (
+	"path/filepath"
+
 	"github.com/jessevdk/go-flags"
 	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/util"
 	"github.com/pkg/errors"
 )
 
+const (
+	outputFormatJSON  = "json"
+	outputFormatTable = "table"
+)
+
 var (
-	defaultRPCServer        = "localhost"
-	defaultTimeout   uint64 = 30
+	defaultRPCServer          = "localhost"
+	defaultTimeout     uint64 = 30
+	defaultHistoryFile        = filepath.Join(util.AppDir("kaspactl", false), "history")
 )
 
 type configFlags struct {
-	RPCServer                          string `short:"s" long:"rpcserver" description:"RPC server to connect to"`
+	RPCServer                          string `short:"s" long:"rpcserver" description:"RPC server to connect to, or unix:<path> to connect through kaspad's --rpcunixsocket"`
+	RPCUser                            string `short:"u" long:"rpcuser" description:"RPC user to authenticate as"`
+	RPCPassword                        string `short:"P" long:"rpcpass" default-mask:"-" description:"RPC password to authenticate with"`
+	RPCCert                            string `long:"rpccert" description:"File containing the CA certificate that the RPC server's certificate is signed by. If set, kaspactl connects over TLS, as required by kaspad's --rpcclientca"`
+	RPCClientCert                      string `long:"rpcclientcert" description:"File containing the client certificate to present to the RPC server. Requires --rpccert"`
+	RPCClientKey                       string `long:"rpcclientkey" description:"File containing the key of the client certificate"`
+	GenerateRPCAuth                    bool   `long:"gen-rpcauth" description:"Print an rpcauth line for kaspad.conf with the given --rpcuser and --rpcpass and exit. Any remaining arguments are the methods the user is allowed to call"`
 	Timeout                            uint64 `short:"t" long:"timeout" description:"Timeout for the request (in seconds)"`
 	RequestJSON                        string `short:"j" long:"json" description:"The request in JSON format"`
 	ListCommands                       bool   `short:"l" long:"list-commands" description:"List all commands and exit"`
+	Interactive                        bool   `short:"i" long:"interactive" description:"Start an interactive shell that reads commands line by line, with command completion and history"`
+	HistoryFile                        string `long:"historyfile" description:"File to keep the history of the interactive shell in"`
+	OutputFormat                       string `long:"format" description:"Format of the responses {json, table}"`
 	AllowConnectionToDifferentVersions bool   `short:"a" long:"allow-connection-to-different-versions" description:"Allow connections to versions different than kaspactl's version'"`
 	CommandAndParameters               []string
 	config.NetworkFlags
@@ -23,11 +41,13 @@ type configFlags struct {
 
 func parseConfig() (*configFlags, error) {
 	cfg := &configFlags{
-		RPCServer: defaultRPCServer,
-		Timeout:   defaultTimeout,
+		RPCServer:    defaultRPCServer,
+		Timeout:      defaultTimeout,
+		HistoryFile:  defaultHistoryFile,
+		OutputFormat: outputFormatJSON,
 	}
 	parser := flags.NewParser(cfg, flags.HelpFlag)
-	parser.Usage = "kaspactl [OPTIONS] [COMMAND] [COMMAND PARAMETERS].\n\nCommand can be supplied only if --json is not used." +
+	parser.Usage = "kaspactl [OPTIONS] [COMMAND] [COMMAND PARAMETERS].\n\nCommand can be supplied only if --json and --interactive are not used." +
 		"\n\nUse `kaspactl --list-commands` to get a list of all commands and their parameters." +
 		"\nFor optional parameters- use '-' without quotes to not pass the parameter.\n"
 	remainingArgs, err := parser.Parse()
@@ -39,16 +59,36 @@ func parseConfig() (*configFlags, error) {
 		return cfg, nil
 	}
 
+	if cfg.GenerateRPCAuth {
+		if cfg.RPCUser == "" || cfg.RPCPassword == "" {
+			return nil, errors.New("--gen-rpcauth requires --rpcuser and --rpcpass")
+		}
+		cfg.CommandAndParameters = remainingArgs
+		return cfg, nil
+	}
+
+	if cfg.RPCClientCert != "" && cfg.RPCCert == "" || (cfg.RPCClientCert == "") != (cfg.RPCClientKey == "") {
+		return nil, errors.New("--rpcclientcert and --rpcclientkey must be specified together, and require --rpccert")
+	}
+
+	if cfg.OutputFormat != outputFormatJSON && cfg.OutputFormat != outputFormatTable {
+		return nil, errors.Errorf("--format must be one of {%s, %s}", outputFormatJSON, outputFormatTable)
+	}
+
 	err = cfg.ResolveNetwork(parser)
 	if err != nil {
 		return nil, err
 	}
 
 	cfg.CommandAndParameters = remainingArgs
-	if len(cfg.CommandAndParameters) == 0 && cfg.RequestJSON == "" ||
-		len(cfg.CommandAndParameters) > 0 && cfg.RequestJSON != "" {
-
-		return nil, errors.New("Exactly one of --json or a command must be specified")
+	requestCount := 0
+	for _, isRequested := range []bool{len(cfg.CommandAndParameters) > 0, cfg.RequestJSON != "", cfg.Interactive} {
+		if isRequested {
+			requestCount++
+		}
+	}
+	if requestCount != 1 {
+		return nil, errors.New("Exactly one of --json, --interactive or a command must be specified")
 	}
 
 	return cfg, nil
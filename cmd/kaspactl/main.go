@@ -1,9 +1,11 @@
 package main
 
 import (
+	"crypto/tls"
 	"fmt"
 	"github.com/kaspanet/kaspad/version"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
@@ -11,6 +13,7 @@ import (
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/encoding/protojson"
 
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcauth"
 	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient/grpcclient"
 )
 
@@ -23,47 +26,95 @@ func main() {
 		printAllCommands()
 		return
 	}
+	if cfg.GenerateRPCAuth {
+		rpcAuth, err := rpcauth.Generate(cfg.RPCUser, cfg.RPCPassword, cfg.CommandAndParameters)
+		if err != nil {
+			printErrorAndExit(fmt.Sprintf("error generating rpcauth: %s", err))
+		}
+		fmt.Printf("rpcauth=%s\n", rpcAuth)
+		return
+	}
 
-	rpcAddress, err := cfg.NetParams().NormalizeRPCServerAddress(cfg.RPCServer)
-	if err != nil {
-		printErrorAndExit(fmt.Sprintf("error parsing RPC server address: %s", err))
+	connect := func() (*grpcclient.GRPCClient, error) {
+		return connectToServer(cfg)
 	}
-	client, err := grpcclient.Connect(rpcAddress)
+	client, err := connect()
 	if err != nil {
 		printErrorAndExit(fmt.Sprintf("error connecting to the RPC server: %s", err))
 	}
-	defer client.Disconnect()
+	defer func() { client.Disconnect() }()
 
 	if !cfg.AllowConnectionToDifferentVersions {
-		kaspadMessage, err := client.Post(&protowire.KaspadMessage{Payload: &protowire.KaspadMessage_GetInfoRequest{GetInfoRequest: &protowire.GetInfoRequestMessage{}}})
+		err := checkServerVersion(client)
 		if err != nil {
-			printErrorAndExit(fmt.Sprintf("Cannot post GetInfo message: %s", err))
+			printErrorAndExit(err.Error())
 		}
+	}
 
-		localVersion := version.Version()
-		remoteVersion := kaspadMessage.GetGetInfoResponse().ServerVersion
-
-		if localVersion != remoteVersion {
-			printErrorAndExit(fmt.Sprintf("Server version mismatch, expect: %s, got: %s", localVersion, remoteVersion))
+	if cfg.Interactive {
+		shell := newShell(cfg, client, connect)
+		err := shell.run()
+		client = shell.client
+		if err != nil {
+			printErrorAndExit(err.Error())
 		}
+		return
 	}
 
-	responseChan := make(chan string)
-
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	var responseString string
 	if cfg.RequestJSON != "" {
-		go postJSON(cfg, client, responseChan)
+		responseString, err = postWithTimeout(timeout, func() (string, error) {
+			return postJSON(client, cfg.RequestJSON)
+		})
 	} else {
-		go postCommand(cfg, client, responseChan)
+		responseString, err = postWithTimeout(timeout, func() (string, error) {
+			return postCommand(client, cfg.CommandAndParameters)
+		})
 	}
+	if err != nil {
+		printErrorAndExit(err.Error())
+	}
+	formattedResponse, err := formatResponse(responseString, cfg.OutputFormat)
+	if err != nil {
+		printErrorAndExit(err.Error())
+	}
+	fmt.Println(formattedResponse)
+}
 
-	timeout := time.Duration(cfg.Timeout) * time.Second
-	select {
-	case responseString := <-responseChan:
-		prettyResponseString := prettifyResponse(responseString)
-		fmt.Println(prettyResponseString)
-	case <-time.After(timeout):
-		printErrorAndExit(fmt.Sprintf("timeout of %s has been exceeded", timeout))
+func connectToServer(cfg *configFlags) (*grpcclient.GRPCClient, error) {
+	rpcAddress := cfg.RPCServer
+	if !strings.HasPrefix(rpcAddress, "unix:") {
+		var err error
+		rpcAddress, err = cfg.NetParams().NormalizeRPCServerAddress(cfg.RPCServer)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing RPC server address")
+		}
+	}
+	var tlsConfig *tls.Config
+	if cfg.RPCCert != "" {
+		var err error
+		tlsConfig, err = rpcauth.ClientTLSConfig(cfg.RPCCert, cfg.RPCClientCert, cfg.RPCClientKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error loading the TLS configuration")
+		}
 	}
+	return grpcclient.ConnectWithTLS(rpcAddress, cfg.RPCUser, cfg.RPCPassword, tlsConfig)
+}
+
+func checkServerVersion(client *grpcclient.GRPCClient) error {
+	kaspadMessage, err := client.Post(&protowire.KaspadMessage{Payload: &protowire.KaspadMessage_GetInfoRequest{GetInfoRequest: &protowire.GetInfoRequestMessage{}}})
+	if err != nil {
+		return errors.Errorf("Cannot post GetInfo message: %s", err)
+	}
+
+	localVersion := version.Version()
+	remoteVersion := kaspadMessage.GetGetInfoResponse().ServerVersion
+
+	if localVersion != remoteVersion {
+		return errors.Errorf("Server version mismatch, expect: %s, got: %s", localVersion, remoteVersion)
+	}
+	return nil
 }
 
 func printAllCommands() {
@@ -73,43 +124,54 @@ func printAllCommands() {
 	}
 }
 
-func postCommand(cfg *configFlags, client *grpcclient.GRPCClient, responseChan chan string) {
-	message, err := parseCommand(cfg.CommandAndParameters, commandDescriptions())
+// postWithTimeout calls post, and returns an error if it doesn't return
+// within the given timeout. The request itself is not canceled, so the
+// client shouldn't be used for other requests after a timeout.
+func postWithTimeout(timeout time.Duration, post func() (string, error)) (string, error) {
+	type result struct {
+		response string
+		err      error
+	}
+	resultChan := make(chan result, 1)
+	go func() {
+		response, err := post()
+		resultChan <- result{response: response, err: err}
+	}()
+
+	select {
+	case result := <-resultChan:
+		return result.response, result.err
+	case <-time.After(timeout):
+		return "", errors.Wrapf(errTimeout, "timeout of %s has been exceeded", timeout)
+	}
+}
+
+var errTimeout = errors.New("timeout")
+
+func postCommand(client *grpcclient.GRPCClient, commandAndParameters []string) (string, error) {
+	message, err := parseCommand(commandAndParameters, commandDescriptions())
 	if err != nil {
-		printErrorAndExit(fmt.Sprintf("error parsing command: %s", err))
+		return "", errors.Wrapf(err, "error parsing command")
 	}
 
 	response, err := client.Post(message)
 	if err != nil {
-		printErrorAndExit(fmt.Sprintf("error posting the request to the RPC server: %s", err))
+		return "", errors.Wrapf(err, "error posting the request to the RPC server")
 	}
 	responseBytes, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(response)
 	if err != nil {
-		printErrorAndExit(errors.Wrapf(err, "error parsing the response from the RPC server").Error())
+		return "", errors.Wrapf(err, "error parsing the response from the RPC server")
 	}
 
-	responseChan <- string(responseBytes)
+	return string(responseBytes), nil
 }
 
-func postJSON(cfg *configFlags, client *grpcclient.GRPCClient, doneChan chan string) {
-	responseString, err := client.PostJSON(cfg.RequestJSON)
+func postJSON(client *grpcclient.GRPCClient, requestJSON string) (string, error) {
+	responseString, err := client.PostJSON(requestJSON)
 	if err != nil {
-		printErrorAndExit(fmt.Sprintf("error posting the request to the RPC server: %s", err))
+		return "", errors.Wrapf(err, "error posting the request to the RPC server")
 	}
-	doneChan <- responseString
-}
-
-func prettifyResponse(response string) string {
-	kaspadMessage := &protowire.KaspadMessage{}
-	err := protojson.Unmarshal([]byte(response), kaspadMessage)
-	if err != nil {
-		printErrorAndExit(fmt.Sprintf("error parsing the response from the RPC server: %s", err))
-	}
-
-	marshalOptions := &protojson.MarshalOptions{}
-	marshalOptions.Indent = "    "
-	marshalOptions.EmitUnpopulated = true
-	return marshalOptions.Format(kaspadMessage)
+	return responseString, nil
 }
 
 func printErrorAndExit(message string) {
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// formatResponse formats the given response of the RPC server, in its JSON
+// form, in the given output format
+func formatResponse(responseJSON string, outputFormat string) (string, error) {
+	kaspadMessage := &protowire.KaspadMessage{}
+	err := protojson.Unmarshal([]byte(responseJSON), kaspadMessage)
+	if err != nil {
+		return "", errors.Wrapf(err, "error parsing the response from the RPC server")
+	}
+
+	if outputFormat == outputFormatTable {
+		return formatTable(kaspadMessage), nil
+	}
+	marshalOptions := &protojson.MarshalOptions{}
+	marshalOptions.Indent = "    "
+	marshalOptions.EmitUnpopulated = true
+	return marshalOptions.Format(kaspadMessage), nil
+}
+
+// tableField is a single value of a flattened message. Fields of nested
+// messages are named by their path, such as "outboundDiversity.ipMapFile".
+type tableField struct {
+	name  string
+	value string
+}
+
+// formatTable formats the payload of the given message as a table of its
+// fields and values, followed by a table for each of its lists of messages,
+// with a column per field of their elements
+func formatTable(kaspadMessage *protowire.KaspadMessage) string {
+	message := kaspadMessage.ProtoReflect()
+	payloadOneof := message.Descriptor().Oneofs().ByName("payload")
+	payloadField := message.WhichOneof(payloadOneof)
+	if payloadField == nil {
+		return ""
+	}
+	payload := message.Get(payloadField).Message()
+
+	sb := &strings.Builder{}
+	writer := tabwriter.NewWriter(sb, 0, 4, 2, ' ', 0)
+	var lists []protoreflect.FieldDescriptor
+	fields := payload.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if field.IsList() && field.Kind() == protoreflect.MessageKind {
+			lists = append(lists, field)
+			continue
+		}
+		for _, flattenedField := range flattenField(payload, field, "") {
+			_, _ = fmt.Fprintf(writer, "%s\t%s\n", flattenedField.name, flattenedField.value)
+		}
+	}
+	_ = writer.Flush()
+
+	for _, list := range lists {
+		sb.WriteString("\n")
+		writeListTable(sb, list.JSONName(), payload.Get(list).List())
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// writeListTable writes a table with a row for every element of the given
+// list of messages, and a column for every field of them
+func writeListTable(sb *strings.Builder, name string, list protoreflect.List) {
+	if list.Len() == 0 {
+		_, _ = fmt.Fprintf(sb, "%s: none\n", name)
+		return
+	}
+	_, _ = fmt.Fprintf(sb, "%s:\n", name)
+
+	var columns []string
+	columnIndexes := make(map[string]int)
+	rows := make([]map[string]string, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		rows[i] = make(map[string]string)
+		for _, flattenedField := range flattenMessage(list.Get(i).Message(), "") {
+			if _, ok := columnIndexes[flattenedField.name]; !ok {
+				columnIndexes[flattenedField.name] = len(columns)
+				columns = append(columns, flattenedField.name)
+			}
+			rows[i][flattenedField.name] = flattenedField.value
+		}
+	}
+
+	writer := tabwriter.NewWriter(sb, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, strings.Join(columns, "\t"))
+	for _, row := range rows {
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			values[i] = row[column]
+		}
+		_, _ = fmt.Fprintln(writer, strings.Join(values, "\t"))
+	}
+	_ = writer.Flush()
+}
+
+func flattenMessage(message protoreflect.Message, prefix string) []*tableField {
+	var flattenedFields []*tableField
+	fields := message.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		flattenedFields = append(flattenedFields, flattenField(message, fields.Get(i), prefix)...)
+	}
+	return flattenedFields
+}
+
+// flattenField returns the values of the given field of the given message.
+// A nested message is flattened into the values of its own fields, and is
+// omitted if it isn't set. Lists and maps are shown in a single value.
+func flattenField(message protoreflect.Message, field protoreflect.FieldDescriptor, prefix string) []*tableField {
+	name := prefix + field.JSONName()
+	switch {
+	case field.IsList():
+		list := message.Get(field).List()
+		if field.Kind() == protoreflect.MessageKind {
+			return []*tableField{{name: name, value: fmt.Sprintf("<%d items>", list.Len())}}
+		}
+		values := make([]string, list.Len())
+		for i := 0; i < list.Len(); i++ {
+			values[i] = formatScalar(field, list.Get(i))
+		}
+		return []*tableField{{name: name, value: strings.Join(values, ", ")}}
+	case field.IsMap():
+		var values []string
+		message.Get(field).Map().Range(func(key protoreflect.MapKey, value protoreflect.Value) bool {
+			var valueString string
+			if field.MapValue().Kind() == protoreflect.MessageKind {
+				valueString = fmt.Sprintf("<%s>", field.MapValue().Message().Name())
+			} else {
+				valueString = formatScalar(field.MapValue(), value)
+			}
+			values = append(values, fmt.Sprintf("%s=%s", key.String(), valueString))
+			return true
+		})
+		sort.Strings(values)
+		return []*tableField{{name: name, value: strings.Join(values, ", ")}}
+	case field.Kind() == protoreflect.MessageKind:
+		if !message.Has(field) {
+			return nil
+		}
+		return flattenMessage(message.Get(field).Message(), name+".")
+	default:
+		return []*tableField{{name: name, value: formatScalar(field, message.Get(field))}}
+	}
+}
+
+func formatScalar(field protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	switch field.Kind() {
+	case protoreflect.EnumKind:
+		enumValue := field.Enum().Values().ByNumber(value.Enum())
+		if enumValue == nil {
+			return fmt.Sprint(value.Enum())
+		}
+		return string(enumValue.Name())
+	case protoreflect.BytesKind:
+		return hex.EncodeToString(value.Bytes())
+	default:
+		return value.String()
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestFormatTable(t *testing.T) {
+	response := &protowire.KaspadMessage{
+		Payload: &protowire.KaspadMessage_GetConnectedPeerInfoResponse{
+			GetConnectedPeerInfoResponse: &protowire.GetConnectedPeerInfoResponseMessage{
+				Infos: []*protowire.GetConnectedPeerInfoMessage{
+					{Id: "peer1", Address: "1.2.3.4:16111", IsOutbound: true},
+					{Id: "peer2", Address: "5.6.7.8:16111"},
+				},
+			},
+		},
+	}
+	responseJSON, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(response)
+	if err != nil {
+		t.Fatalf("Marshal: %s", err)
+	}
+
+	table, err := formatResponse(string(responseJSON), outputFormatTable)
+	if err != nil {
+		t.Fatalf("formatResponse: %s", err)
+	}
+	infosTableIndex := strings.Index(table, "infos:\n")
+	if infosTableIndex == -1 {
+		t.Fatalf("Missing the infos table:\n%s", table)
+	}
+	lines := strings.Split(table[infosTableIndex:], "\n")
+	if len(lines) != 4 {
+		t.Fatalf("Unexpected infos table:\n%s", table)
+	}
+	header := strings.Fields(lines[1])
+	if header[0] != "id" || header[1] != "address" {
+		t.Fatalf("Unexpected table header: %s", lines[1])
+	}
+	firstRow := strings.Fields(lines[2])
+	if firstRow[0] != "peer1" || firstRow[1] != "1.2.3.4:16111" {
+		t.Fatalf("Unexpected table row: %s", lines[2])
+	}
+
+	emptyResponse := &protowire.KaspadMessage{
+		Payload: &protowire.KaspadMessage_GetBlockCountResponse{
+			GetBlockCountResponse: &protowire.GetBlockCountResponseMessage{BlockCount: 5},
+		},
+	}
+	responseJSON, err = protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(emptyResponse)
+	if err != nil {
+		t.Fatalf("Marshal: %s", err)
+	}
+	table, err = formatResponse(string(responseJSON), outputFormatTable)
+	if err != nil {
+		t.Fatalf("formatResponse: %s", err)
+	}
+	if !strings.Contains(table, "blockCount") || strings.Contains(table, "error") {
+		t.Fatalf("Unexpected table:\n%s", table)
+	}
+}
@@ -0,0 +1,387 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient/grpcclient"
+	"github.com/pkg/errors"
+	"golang.org/x/term"
+)
+
+const shellPrompt = "kaspactl> "
+
+var shellBuiltins = []string{"help", "format", "exit", "quit"}
+
+// shell reads commands line by line and posts them to the RPC server, until
+// it's exited or its input ends
+type shell struct {
+	cfg          *configFlags
+	client       *grpcclient.GRPCClient
+	connect      func() (*grpcclient.GRPCClient, error)
+	commandDescs []*commandDescription
+	outputFormat string
+	out          io.Writer
+}
+
+func newShell(cfg *configFlags, client *grpcclient.GRPCClient,
+	connect func() (*grpcclient.GRPCClient, error)) *shell {
+
+	return &shell{
+		cfg:          cfg,
+		client:       client,
+		connect:      connect,
+		commandDescs: commandDescriptions(),
+		outputFormat: cfg.OutputFormat,
+		out:          os.Stdout,
+	}
+}
+
+func (s *shell) run() error {
+	stdinFD := int(os.Stdin.Fd())
+	if !term.IsTerminal(stdinFD) {
+		return s.runScript(os.Stdin)
+	}
+	return s.runTerminal(stdinFD)
+}
+
+// runScript runs the commands in the given reader, one per line, without
+// prompting for them. This is used when the input isn't a terminal, such
+// as when commands are piped into kaspactl.
+func (s *shell) runScript(reader io.Reader) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(nil, 16*1024*1024)
+	for scanner.Scan() {
+		if shouldExit := s.execute(scanner.Text()); shouldExit {
+			return nil
+		}
+	}
+	return scanner.Err()
+}
+
+func (s *shell) runTerminal(stdinFD int) error {
+	oldState, err := term.MakeRaw(stdinFD)
+	if err != nil {
+		return errors.Wrapf(err, "error setting the terminal to raw mode")
+	}
+	defer term.Restore(stdinFD, oldState)
+
+	history, err := loadShellHistory(s.cfg.HistoryFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading the shell history: %s\r\n", err)
+		history = &shellHistory{}
+	}
+
+	// The terminal keeps its history internally, so the loaded history is
+	// fed to it as input before stdin, while its output is discarded
+	output := &switchableWriter{writer: io.Discard}
+	input := io.MultiReader(strings.NewReader(history.replayInput()), os.Stdin)
+	terminal := term.NewTerminal(&terminalReadWriter{Reader: input, Writer: output}, shellPrompt)
+	if width, height, err := term.GetSize(stdinFD); err == nil && width > 0 {
+		_ = terminal.SetSize(width, height)
+	}
+	for range history.lines {
+		_, err := terminal.ReadLine()
+		if err != nil {
+			return errors.Wrapf(err, "error loading the shell history")
+		}
+	}
+	output.setWriter(os.Stdout)
+
+	terminal.AutoCompleteCallback = func(line string, pos int, key rune) (string, int, bool) {
+		if key != '\t' {
+			return "", 0, false
+		}
+		newLine, newPos, suggestions := s.complete(line, pos)
+		if suggestions != "" {
+			// The terminal's lock is held until the callback returns, and
+			// writing repaints the prompt, so this is done asynchronously
+			go func() { _, _ = terminal.Write([]byte(suggestions + "\n")) }()
+		}
+		return newLine, newPos, newLine != line
+	}
+	s.out = terminal
+
+	fmt.Fprintf(s.out, "Connected to %s. Type 'help' for a list of commands, and press Tab to complete them.\n", s.cfg.RPCServer)
+	for {
+		line, err := terminal.ReadLine()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		err = history.add(line)
+		if err != nil {
+			fmt.Fprintf(s.out, "Error saving the shell history: %s\n", err)
+		}
+		if shouldExit := s.execute(line); shouldExit {
+			return nil
+		}
+	}
+}
+
+// execute executes a single line of input, and returns whether the shell
+// should exit
+func (s *shell) execute(line string) bool {
+	words, err := splitWords(line)
+	if err != nil {
+		fmt.Fprintf(s.out, "%s\n", err)
+		return false
+	}
+	if len(words) == 0 {
+		return false
+	}
+
+	switch strings.ToLower(words[0]) {
+	case "exit", "quit":
+		return true
+	case "help":
+		s.help(words[1:])
+		return false
+	case "format":
+		s.setFormat(words[1:])
+		return false
+	}
+
+	commandDesc := s.findCommand(words[0])
+	if commandDesc == nil {
+		fmt.Fprintf(s.out, "Unknown command: %s. Type 'help' for a list of commands\n", words[0])
+		return false
+	}
+	commandAndParameters := append([]string{commandDesc.name}, words[1:]...)
+
+	timeout := time.Duration(s.cfg.Timeout) * time.Second
+	responseString, err := postWithTimeout(timeout, func() (string, error) {
+		return postCommand(s.client, commandAndParameters)
+	})
+	if err != nil {
+		fmt.Fprintf(s.out, "%s\n", err)
+		if errors.Is(err, errTimeout) {
+			s.reconnect()
+		}
+		return false
+	}
+	formattedResponse, err := formatResponse(responseString, s.outputFormat)
+	if err != nil {
+		fmt.Fprintf(s.out, "%s\n", err)
+		return false
+	}
+	fmt.Fprintf(s.out, "%s\n", formattedResponse)
+	return false
+}
+
+// reconnect replaces the client with a new one. This is required after a
+// timeout, since the response to the timed-out request might still arrive
+// and be mistaken for the response to the next request.
+func (s *shell) reconnect() {
+	fmt.Fprintf(s.out, "Reconnecting to the RPC server...\n")
+	client, err := s.connect()
+	if err != nil {
+		fmt.Fprintf(s.out, "Error reconnecting to the RPC server: %s\n", err)
+		return
+	}
+	oldClient := s.client
+	s.client = client
+	go oldClient.Disconnect()
+}
+
+func (s *shell) help(args []string) {
+	if len(args) == 0 {
+		fmt.Fprintf(s.out, "Commands:\n")
+		for _, commandDesc := range s.commandDescs {
+			fmt.Fprintf(s.out, "\t%s\n", commandDesc.help())
+		}
+		fmt.Fprintf(s.out, "\nShell commands:\n"+
+			"\thelp [command]       Show the parameters of the given command\n"+
+			"\tformat [json|table]  Show or set the format of the responses\n"+
+			"\texit                 Exit the shell\n"+
+			"\nCommand names are case insensitive. Quote parameters that contain spaces,\n"+
+			"and use '-' to not pass an optional parameter.\n")
+		return
+	}
+
+	commandDesc := s.findCommand(args[0])
+	if commandDesc == nil {
+		fmt.Fprintf(s.out, "Unknown command: %s\n", args[0])
+		return
+	}
+	fmt.Fprintf(s.out, "%s\n", commandDesc.parameterHints())
+}
+
+func (s *shell) setFormat(args []string) {
+	if len(args) == 0 {
+		fmt.Fprintf(s.out, "The output format is %s\n", s.outputFormat)
+		return
+	}
+	outputFormat := strings.ToLower(args[0])
+	if outputFormat != outputFormatJSON && outputFormat != outputFormatTable {
+		fmt.Fprintf(s.out, "The output format must be one of {%s, %s}\n", outputFormatJSON, outputFormatTable)
+		return
+	}
+	s.outputFormat = outputFormat
+}
+
+func (s *shell) findCommand(name string) *commandDescription {
+	for _, commandDesc := range s.commandDescs {
+		if strings.EqualFold(commandDesc.name, name) {
+			return commandDesc
+		}
+	}
+	return nil
+}
+
+// complete completes the word before pos in the given line. The first word
+// is completed to a command name, as is the argument of the help command.
+// Once a command is typed, the hints for its parameters are returned as
+// suggestions instead. If the word can't be completed unambiguously, the
+// possible completions are returned as suggestions.
+func (s *shell) complete(line string, pos int) (newLine string, newPos int, suggestions string) {
+	beforeCursor := line[:pos]
+	words := strings.Fields(beforeCursor)
+	isNewWord := len(beforeCursor) == 0 || beforeCursor[len(beforeCursor)-1] == ' '
+	if isNewWord {
+		words = append(words, "")
+	}
+
+	isCommandWord := len(words) == 1 || len(words) == 2 && strings.EqualFold(words[0], "help")
+	if !isCommandWord {
+		commandDesc := s.findCommand(words[0])
+		if commandDesc == nil {
+			return line, pos, ""
+		}
+		return line, pos, commandDesc.parameterHints()
+	}
+
+	prefix := words[len(words)-1]
+	var candidates []string
+	for _, commandDesc := range s.commandDescs {
+		candidates = append(candidates, commandDesc.name)
+	}
+	if len(words) == 1 {
+		candidates = append(candidates, shellBuiltins...)
+	}
+	matches := matchPrefix(candidates, prefix)
+
+	switch len(matches) {
+	case 0:
+		return line, pos, ""
+	case 1:
+		completion := matches[0] + " "
+		wordStart := pos - len(prefix)
+		return line[:wordStart] + completion + line[pos:], wordStart + len(completion), ""
+	}
+	commonPrefix := longestCommonPrefix(matches)
+	if len(commonPrefix) > len(prefix) {
+		wordStart := pos - len(prefix)
+		return line[:wordStart] + commonPrefix + line[pos:], wordStart + len(commonPrefix), ""
+	}
+	return line, pos, strings.Join(matches, "  ")
+}
+
+// matchPrefix returns the sorted candidates that start with the given
+// prefix, ignoring case
+func matchPrefix(candidates []string, prefix string) []string {
+	var matches []string
+	for _, candidate := range candidates {
+		if len(candidate) >= len(prefix) && strings.EqualFold(candidate[:len(prefix)], prefix) {
+			matches = append(matches, candidate)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// longestCommonPrefix returns the longest prefix the given strings share,
+// ignoring case. The prefix is returned in the case of the first string.
+func longestCommonPrefix(strs []string) string {
+	commonPrefix := strs[0]
+	for _, str := range strs[1:] {
+		length := 0
+		for length < len(commonPrefix) && length < len(str) &&
+			strings.EqualFold(commonPrefix[length:length+1], str[length:length+1]) {
+			length++
+		}
+		commonPrefix = commonPrefix[:length]
+	}
+	return commonPrefix
+}
+
+// splitWords splits the given line into words separated by whitespace.
+// Whitespace inside single or double quotes doesn't separate words, and a
+// backslash outside of single quotes escapes the character after it.
+func splitWords(line string) ([]string, error) {
+	var words []string
+	word := &strings.Builder{}
+	inWord := false
+	var quote rune
+	escaped := false
+	for _, char := range line {
+		switch {
+		case escaped:
+			word.WriteRune(char)
+			escaped = false
+		case char == '\\' && quote != '\'':
+			escaped = true
+			inWord = true
+		case quote != 0:
+			if char == quote {
+				quote = 0
+			} else {
+				word.WriteRune(char)
+			}
+		case char == '\'' || char == '"':
+			quote = char
+			inWord = true
+		case char == ' ' || char == '\t':
+			if inWord {
+				words = append(words, word.String())
+				word.Reset()
+				inWord = false
+			}
+		default:
+			word.WriteRune(char)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, errors.Errorf("unterminated %c quote", quote)
+	}
+	if escaped {
+		return nil, errors.New("unterminated escape character")
+	}
+	if inWord {
+		words = append(words, word.String())
+	}
+	return words, nil
+}
+
+// terminalReadWriter combines the input and the output of the terminal into
+// the io.ReadWriter that term.Terminal requires
+type terminalReadWriter struct {
+	io.Reader
+	io.Writer
+}
+
+type switchableWriter struct {
+	sync.Mutex
+	writer io.Writer
+}
+
+func (w *switchableWriter) Write(p []byte) (int, error) {
+	w.Lock()
+	defer w.Unlock()
+	return w.writer.Write(p)
+}
+
+func (w *switchableWriter) setWriter(writer io.Writer) {
+	w.Lock()
+	defer w.Unlock()
+	w.writer = writer
+}
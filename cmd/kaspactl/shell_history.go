@@ -0,0 +1,106 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// shellHistorySize is the number of lines the shell remembers, which
+	// is the size of the terminal's own history
+	shellHistorySize = 100
+
+	// maxShellHistoryFileLines is the number of lines the history file may
+	// grow to before it's trimmed back to shellHistorySize lines
+	maxShellHistoryFileLines = 1000
+)
+
+// shellHistory is the history of the lines entered in the shell. It's kept
+// in a file, to which every entered line is appended.
+type shellHistory struct {
+	path  string
+	lines []string
+}
+
+func loadShellHistory(path string) (*shellHistory, error) {
+	history := &shellHistory{path: path}
+	if path == "" {
+		return history, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return history, nil
+		}
+		return nil, errors.WithStack(err)
+	}
+	var lines []string
+	for _, line := range strings.Split(string(content), "\n") {
+		if isHistoryLine(line) {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > shellHistorySize {
+		history.lines = lines[len(lines)-shellHistorySize:]
+	} else {
+		history.lines = lines
+	}
+
+	if len(lines) > maxShellHistoryFileLines {
+		content := strings.Join(history.lines, "\n") + "\n"
+		err := os.WriteFile(path, []byte(content), 0600)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	return history, nil
+}
+
+// add appends the given line to the history file
+func (h *shellHistory) add(line string) error {
+	if h.path == "" || !isHistoryLine(line) {
+		return nil
+	}
+	err := os.MkdirAll(filepath.Dir(h.path), 0700)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	file, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer file.Close()
+	_, err = file.WriteString(line + "\n")
+	return errors.WithStack(err)
+}
+
+// replayInput returns the history as terminal input, which enters every
+// line of it in order
+func (h *shellHistory) replayInput() string {
+	sb := &strings.Builder{}
+	for _, line := range h.lines {
+		sb.WriteString(line)
+		sb.WriteString("\r")
+	}
+	return sb.String()
+}
+
+// isHistoryLine returns whether the given line should be kept in the
+// history. Empty lines aren't, and neither are lines with control
+// characters, since those would be interpreted as keys when replayed.
+func isHistoryLine(line string) bool {
+	if strings.TrimSpace(line) == "" {
+		return false
+	}
+	for _, char := range line {
+		if !unicode.IsPrint(char) {
+			return false
+		}
+	}
+	return true
+}
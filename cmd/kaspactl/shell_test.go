@@ -0,0 +1,90 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		line          string
+		expectedWords []string
+		expectsError  bool
+	}{
+		{line: "", expectedWords: nil},
+		{line: "   ", expectedWords: nil},
+		{line: "getInfo", expectedWords: []string{"getInfo"}},
+		{line: "  GetBlock  abc   true ", expectedWords: []string{"GetBlock", "abc", "true"}},
+		{line: `SubmitTransaction '{"a": "b c"}' false`, expectedWords: []string{"SubmitTransaction", `{"a": "b c"}`, "false"}},
+		{line: `Ban "1.2.3.4" ""`, expectedWords: []string{"Ban", "1.2.3.4", ""}},
+		{line: `a b\ c 'd\e'`, expectedWords: []string{"a", "b c", `d\e`}},
+		{line: `a "b`, expectsError: true},
+		{line: `a b\`, expectsError: true},
+	}
+
+	for _, test := range tests {
+		words, err := splitWords(test.line)
+		if test.expectsError {
+			if err == nil {
+				t.Errorf("splitWords(%q): expected an error", test.line)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("splitWords(%q): unexpected error: %s", test.line, err)
+			continue
+		}
+		if !reflect.DeepEqual(words, test.expectedWords) {
+			t.Errorf("splitWords(%q): expected %q but got %q", test.line, test.expectedWords, words)
+		}
+	}
+}
+
+func TestShellComplete(t *testing.T) {
+	s := &shell{commandDescs: commandDescriptions()}
+
+	tests := []struct {
+		line                string
+		expectedLine        string
+		expectedSuggestions string
+	}{
+		// A unique prefix is completed, ignoring case
+		{line: "getblockc", expectedLine: "GetBlockCount "},
+		// An ambiguous prefix is completed up to where the candidates differ
+		{line: "GetBlockT", expectedLine: "GetBlockTemplate "},
+		{line: "getmempoolent", expectedLine: "GetMempoolEntr"},
+		// The candidates are suggested if the prefix can't be completed further
+		{line: "GetMempoolEntr", expectedLine: "GetMempoolEntr",
+			expectedSuggestions: "GetMempoolEntries  GetMempoolEntriesByAddresses  GetMempoolEntry"},
+		{line: "exi", expectedLine: "exit "},
+		{line: "ex", expectedLine: "ex", expectedSuggestions: "ExportBlocks  exit"},
+		{line: "help getinf", expectedLine: "help GetInfo "},
+		{line: "NoSuchCommand", expectedLine: "NoSuchCommand"},
+	}
+
+	for _, test := range tests {
+		newLine, newPos, suggestions := s.complete(test.line, len(test.line))
+		if newLine != test.expectedLine {
+			t.Errorf("complete(%q): expected line %q but got %q", test.line, test.expectedLine, newLine)
+		}
+		if newPos != len(newLine) {
+			t.Errorf("complete(%q): expected the cursor at the end of the line, but got %d", test.line, newPos)
+		}
+		if suggestions != test.expectedSuggestions {
+			t.Errorf("complete(%q): expected suggestions %q but got %q", test.line, test.expectedSuggestions, suggestions)
+		}
+	}
+
+	// After the command, its parameters are hinted
+	line := "getblock "
+	newLine, _, suggestions := s.complete(line, len(line))
+	if newLine != line {
+		t.Errorf("complete(%q): expected the line to be unchanged, but got %q", line, newLine)
+	}
+	if !strings.HasPrefix(suggestions, "GetBlock [Hash] [IncludeTransactions]") ||
+		!strings.Contains(suggestions, "IncludeTransactions: bool") {
+
+		t.Errorf("complete(%q): unexpected parameter hints %q", line, suggestions)
+	}
+}
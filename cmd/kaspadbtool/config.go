@@ -0,0 +1,114 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/pkg/errors"
+)
+
+const (
+	verifySubCmd = "verify"
+	statsSubCmd  = "stats"
+	repairSubCmd = "repair"
+)
+
+const (
+	// dataDirname is the name of the directory kaspad keeps its database
+	// in, under the network directory of its app directory
+	dataDirname = "datadir2"
+
+	defaultBucketDepth = 2
+)
+
+type databaseFlags struct {
+	AppDir string `short:"b" long:"appdir" description:"Directory of the kaspad instance whose database to use"`
+	config.NetworkFlags
+}
+
+// databasePath returns the path of the database of the kaspad
+// instance in the configured app directory and network
+func (flags *databaseFlags) databasePath() string {
+	return filepath.Join(flags.AppDir, flags.NetParams().Name, dataDirname)
+}
+
+type verifyConfig struct {
+	DbEncryptionKeyFile    string `long:"dbencryptionkeyfile" description:"File containing the key of the database, if it's encrypted"`
+	DbEncryptionPassphrase string `long:"dbencryptionpassphrase" description:"Passphrase of the database, if it's encrypted"`
+	databaseFlags
+}
+
+type statsConfig struct {
+	Buckets     bool `long:"buckets" description:"Count the keys and their sizes under every bucket. This reads the whole database"`
+	BucketDepth int  `long:"bucketdepth" description:"Depth of the buckets to count the keys of"`
+	databaseFlags
+}
+
+type repairConfig struct {
+	databaseFlags
+}
+
+func parseCommandLine() (subCommand string, config interface{}) {
+	parser := flags.NewParser(&struct{}{}, flags.PrintErrors|flags.HelpFlag)
+
+	verifyConf := &verifyConfig{databaseFlags: newDatabaseFlags()}
+	parser.AddCommand(verifySubCmd, "Verifies the integrity of the database",
+		"Verifies the checksums of all the data in the database, and that every stored block "+
+			"matches its hash and merkle root. The database is opened read-only", verifyConf)
+
+	statsConf := &statsConfig{databaseFlags: newDatabaseFlags(), BucketDepth: defaultBucketDepth}
+	parser.AddCommand(statsSubCmd, "Prints the storage statistics of the database",
+		"Prints the size of the database and of its levels, and optionally of every bucket. "+
+			"The database is opened read-only", statsConf)
+
+	repairConf := &repairConfig{databaseFlags: newDatabaseFlags()}
+	parser.AddCommand(repairSubCmd, "Attempts to repair a corrupted database",
+		"Rebuilds the metadata of a leveldb database from its table files, dropping the tables "+
+			"that can't be read. Data in the dropped tables is lost, so back up the database first", repairConf)
+
+	_, err := parser.Parse()
+	if err != nil {
+		var flagsErr *flags.Error
+		if ok := errors.As(err, &flagsErr); ok && flagsErr.Type == flags.ErrHelp {
+			os.Exit(0)
+		} else {
+			os.Exit(1)
+		}
+		return "", nil
+	}
+
+	switch parser.Command.Active.Name {
+	case verifySubCmd:
+		err := verifyConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		if verifyConf.DbEncryptionKeyFile != "" && verifyConf.DbEncryptionPassphrase != "" {
+			printErrorAndExit(errors.New("--dbencryptionkeyfile and --dbencryptionpassphrase can't be used together"))
+		}
+		config = verifyConf
+	case statsSubCmd:
+		err := statsConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		if statsConf.BucketDepth < 0 {
+			printErrorAndExit(errors.New("--bucketdepth can't be negative"))
+		}
+		config = statsConf
+	case repairSubCmd:
+		err := repairConf.ResolveNetwork(parser)
+		if err != nil {
+			printErrorAndExit(err)
+		}
+		config = repairConf
+	}
+
+	return parser.Command.Active.Name, config
+}
+
+func newDatabaseFlags() databaseFlags {
+	return databaseFlags{AppDir: config.DefaultAppDir}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/pebbledb"
+	"github.com/pkg/errors"
+)
+
+const databaseCacheSizeMiB = 64
+
+// backendDatabase is a database of one of the backends, before it's wrapped
+// with encryption or metrics
+type backendDatabase interface {
+	database.Database
+	VerifyChecksums() error
+}
+
+// databaseBackend returns the backend of the database in dbPath. Databases
+// that were created before the backend file was introduced are always
+// leveldb databases
+func databaseBackend(dbPath string) (string, error) {
+	_, err := os.Stat(dbPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", errors.Errorf("There's no database in '%s'", dbPath)
+		}
+		return "", errors.WithStack(err)
+	}
+
+	backendBytes, err := os.ReadFile(filepath.Join(dbPath, config.DBTypeFilename))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config.DBTypeLevelDB, nil
+		}
+		return "", errors.WithStack(err)
+	}
+	return strings.TrimSpace(string(backendBytes)), nil
+}
+
+// openDatabaseReadOnly opens the database in dbPath for reading only. It
+// fails if the database is used by a running kaspad.
+func openDatabaseReadOnly(dbPath string, backend string) (backendDatabase, error) {
+	var db backendDatabase
+	var err error
+	switch backend {
+	case config.DBTypeLevelDB:
+		db, err = ldb.NewLevelDBReadOnly(dbPath, databaseCacheSizeMiB)
+	case config.DBTypePebble:
+		db, err = pebbledb.NewPebbleDBReadOnly(dbPath, databaseCacheSizeMiB)
+	default:
+		return nil, errors.Errorf("unknown database backend %s", backend)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed opening the %s database in '%s'. "+
+			"Make sure kaspad isn't running", backend, dbPath)
+	}
+	return db, nil
+}
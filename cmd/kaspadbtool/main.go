@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+func main() {
+	subCmd, config := parseCommandLine()
+
+	var err error
+	switch subCmd {
+	case verifySubCmd:
+		err = verify(config.(*verifyConfig))
+	case statsSubCmd:
+		err = stats(config.(*statsConfig))
+	case repairSubCmd:
+		err = repair(config.(*repairConfig))
+	default:
+		err = errors.Errorf("Unknown sub-command '%s'\n", subCmd)
+	}
+
+	if err != nil {
+		printErrorAndExit(err)
+	}
+}
+
+func printErrorAndExit(err error) {
+	fmt.Fprintf(os.Stderr, "%s\n", err)
+	os.Exit(1)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/pkg/errors"
+)
+
+func repair(conf *repairConfig) (err error) {
+	dbPath := conf.databasePath()
+	backend, err := databaseBackend(dbPath)
+	if err != nil {
+		return err
+	}
+	if backend != config.DBTypeLevelDB {
+		return errors.Errorf("%s databases can't be repaired. Restore a backup of the database, "+
+			"or delete it and let kaspad sync again", backend)
+	}
+
+	fmt.Printf("Repairing the leveldb database in '%s'. This might take a while\n", dbPath)
+	err = ldb.RepairLevelDB(dbPath)
+	if err != nil {
+		return errors.Wrapf(err, "failed repairing the database. Make sure kaspad isn't running")
+	}
+
+	db, err := openDatabaseReadOnly(dbPath, backend)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		closeErr := db.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}()
+	err = db.VerifyChecksums()
+	if err != nil {
+		return errors.Wrapf(err, "the database is still corrupted after the repair")
+	}
+	fmt.Printf("The database was repaired. Run '%s' to verify the blocks in it, since entries "+
+		"in tables that couldn't be read were dropped\n", verifySubCmd)
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+func stats(conf *statsConfig) (err error) {
+	dbPath := conf.databasePath()
+	backend, err := databaseBackend(dbPath)
+	if err != nil {
+		return err
+	}
+	db, err := openDatabaseReadOnly(dbPath, backend)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		closeErr := db.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}()
+
+	databaseStats, err := db.Stats()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Database:                 %s\n", dbPath)
+	fmt.Printf("Backend:                  %s\n", backend)
+	fmt.Printf("Disk size:                %d bytes\n", databaseStats.DiskSize)
+	fmt.Printf("Pending compaction bytes: %d\n", databaseStats.PendingCompactionBytes)
+
+	fmt.Println()
+	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "Level\tFiles\tSize")
+	for i, level := range databaseStats.Levels {
+		fmt.Fprintf(writer, "%d\t%d\t%d\n", i, level.FileCount, level.Size)
+	}
+	err = writer.Flush()
+	if err != nil {
+		return err
+	}
+
+	if !conf.Buckets {
+		return nil
+	}
+	bucketStats, err := database.CollectBucketStats(db, database.MakeBucket(nil), conf.BucketDepth)
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	writer = tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(writer, "Bucket\tKeys\tKey size\tValue size")
+	for _, stats := range bucketStats {
+		bucketName := database.BucketDisplayName(stats.Bucket.Path())
+		if bucketName == "" {
+			bucketName = "/"
+		}
+		fmt.Fprintf(writer, "%s\t%d\t%d\t%d\n", bucketName, stats.KeyCount, stats.KeySize, stats.ValueSize)
+	}
+	return writer.Flush()
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+
+	consensusdatabase "github.com/kaspanet/kaspad/domain/consensus/database"
+	"github.com/kaspanet/kaspad/domain/consensus/datastructures/blockstore"
+	"github.com/kaspanet/kaspad/domain/consensus/model"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/merkle"
+	"github.com/kaspanet/kaspad/domain/prefixmanager"
+	"github.com/kaspanet/kaspad/domain/prefixmanager/prefix"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/encrypteddb"
+	"github.com/pkg/errors"
+)
+
+// verifyProgressInterval is the amount of blocks between
+// the progress reports of the block verification
+const verifyProgressInterval = 100_000
+
+func verify(conf *verifyConfig) (err error) {
+	dbPath := conf.databasePath()
+	backend, err := databaseBackend(dbPath)
+	if err != nil {
+		return err
+	}
+	db, err := openDatabaseReadOnly(dbPath, backend)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		closeErr := db.Close()
+		if err == nil {
+			err = closeErr
+		}
+	}()
+
+	fmt.Printf("Verifying the checksums of the %s database in '%s'\n", backend, dbPath)
+	err = db.VerifyChecksums()
+	if err != nil {
+		return errors.Wrapf(err, "the database is corrupted")
+	}
+	fmt.Printf("All the checksums are valid\n")
+
+	decryptedDB, err := openDatabaseEncryption(conf, db)
+	if err != nil {
+		return err
+	}
+	activePrefix, exists, err := prefixmanager.ActivePrefix(decryptedDB)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		fmt.Printf("The database has no consensus data, so there are no blocks to verify\n")
+		return nil
+	}
+
+	fmt.Printf("Verifying the stored blocks\n")
+	verifiedCount, corruptedHashes, err := verifyBlocks(decryptedDB, activePrefix)
+	if err != nil {
+		return err
+	}
+	for _, hash := range corruptedHashes {
+		fmt.Printf("Block %s is corrupted\n", hash)
+	}
+	if len(corruptedHashes) > 0 {
+		return errors.Errorf("%d of the %d stored blocks are corrupted",
+			len(corruptedHashes), verifiedCount+len(corruptedHashes))
+	}
+	fmt.Printf("All the %d stored blocks are valid\n", verifiedCount)
+	return nil
+}
+
+// openDatabaseEncryption wraps the given database with its encryption,
+// if it's encrypted
+func openDatabaseEncryption(conf *verifyConfig, db database.Database) (database.Database, error) {
+	credentials := &encrypteddb.Credentials{}
+	switch {
+	case conf.DbEncryptionKeyFile != "":
+		key, err := encrypteddb.ReadKeyFile(conf.DbEncryptionKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		credentials.Key = key
+	case conf.DbEncryptionPassphrase != "":
+		credentials.Passphrase = []byte(conf.DbEncryptionPassphrase)
+	default:
+		isEncrypted, err := encrypteddb.IsEncrypted(db)
+		if err != nil {
+			return nil, err
+		}
+		if isEncrypted {
+			return nil, errors.New("The database is encrypted. Run with either " +
+				"--dbencryptionkeyfile or --dbencryptionpassphrase to verify its blocks")
+		}
+		return db, nil
+	}
+	return encrypteddb.Open(db, credentials)
+}
+
+// verifyBlocks reads every block in the block store of the consensus with
+// the given prefix, and verifies that its header matches the hash it's
+// stored under, and that its transactions match its merkle root. It returns
+// the amount of valid blocks and the hashes of the corrupted ones.
+func verifyBlocks(db database.Database, dbPrefix *prefix.Prefix) (
+	verifiedCount int, corruptedHashes []*externalapi.DomainHash, err error) {
+
+	dbManager := consensusdatabase.New(db, database.BatchSyncPolicy{})
+	blockStore, err := blockstore.New(dbManager, consensusdatabase.MakeBucket(dbPrefix.Serialize()), 0, false)
+	if err != nil {
+		return 0, nil, err
+	}
+	iterator, err := blockStore.AllBlockHashesIterator(dbManager)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer iterator.Close()
+
+	stagingArea := model.NewStagingArea()
+	for ok := iterator.First(); ok; ok = iterator.Next() {
+		blockHash, err := iterator.Get()
+		if err != nil {
+			return 0, nil, err
+		}
+		block, err := blockStore.Block(dbManager, stagingArea, blockHash)
+		if err != nil {
+			if !consensusdatabase.IsCorruptedError(err) {
+				return 0, nil, err
+			}
+			corruptedHashes = append(corruptedHashes, blockHash)
+			continue
+		}
+		if !merkle.CalculateHashMerkleRoot(block.Transactions).Equal(block.Header.HashMerkleRoot()) {
+			corruptedHashes = append(corruptedHashes, blockHash)
+			continue
+		}
+
+		verifiedCount++
+		if verifiedCount%verifyProgressInterval == 0 {
+			fmt.Printf("Verified %d blocks\n", verifiedCount)
+		}
+	}
+
+	storedCount := blockStore.Count(stagingArea)
+	if foundCount := uint64(verifiedCount + len(corruptedHashes)); foundCount != storedCount {
+		fmt.Printf("Warning: the block store counts %d blocks, but %d were found\n", storedCount, foundCount)
+	}
+	return verifiedCount, corruptedHashes, nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"testing"
+
+	consensusdatabase "github.com/kaspanet/kaspad/domain/consensus/database"
+	"github.com/kaspanet/kaspad/domain/consensus/datastructures/blockstore"
+	"github.com/kaspanet/kaspad/domain/consensus/model"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/domain/prefixmanager/prefix"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+)
+
+func TestVerifyBlocks(t *testing.T) {
+	db, err := ldb.NewLevelDB(t.TempDir(), 8)
+	if err != nil {
+		t.Fatalf("NewLevelDB: %s", err)
+	}
+	defer db.Close()
+
+	dbPrefix, err := prefix.Deserialize([]byte{0})
+	if err != nil {
+		t.Fatalf("Deserialize: %s", err)
+	}
+	prefixBucket := consensusdatabase.MakeBucket(dbPrefix.Serialize())
+	dbManager := consensusdatabase.New(db, database.BatchSyncPolicy{})
+	blockStore, err := blockstore.New(dbManager, prefixBucket, 0, false)
+	if err != nil {
+		t.Fatalf("blockstore.New: %s", err)
+	}
+
+	// A valid block
+	validBlock := dagconfig.SimnetParams.GenesisBlock
+	validBlockHash := consensushashing.BlockHash(validBlock)
+
+	// A block whose transactions don't match its merkle root
+	tamperedBlock := dagconfig.MainnetParams.GenesisBlock.Clone()
+	tamperedBlock.Transactions[0].Payload = []byte("tampered")
+	tamperedBlockHash := consensushashing.BlockHash(tamperedBlock)
+
+	stagingArea := model.NewStagingArea()
+	blockStore.Stage(stagingArea, validBlockHash, validBlock)
+	blockStore.Stage(stagingArea, tamperedBlockHash, tamperedBlock)
+	dbTx, err := dbManager.Begin()
+	if err != nil {
+		t.Fatalf("Begin: %s", err)
+	}
+	err = stagingArea.Commit(dbTx)
+	if err != nil {
+		t.Fatalf("Commit: %s", err)
+	}
+	err = dbTx.Commit()
+	if err != nil {
+		t.Fatalf("Commit: %s", err)
+	}
+
+	// A block that can't be deserialized
+	undecodableBlockHash := externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1})
+	err = db.Put(database.MakeBucket(dbPrefix.Serialize()).Bucket([]byte("blocks")).Key(undecodableBlockHash.ByteSlice()),
+		[]byte("undecodable"))
+	if err != nil {
+		t.Fatalf("Put: %s", err)
+	}
+
+	verifiedCount, corruptedHashes, err := verifyBlocks(db, dbPrefix)
+	if err != nil {
+		t.Fatalf("verifyBlocks: %s", err)
+	}
+	if verifiedCount != 1 {
+		t.Fatalf("Expected 1 valid block, but got %d", verifiedCount)
+	}
+	if len(corruptedHashes) != 2 {
+		t.Fatalf("Expected 2 corrupted blocks, but got %d", len(corruptedHashes))
+	}
+	for _, hash := range corruptedHashes {
+		if !hash.Equal(tamperedBlockHash) && !hash.Equal(undecodableBlockHash) {
+			t.Fatalf("Block %s was unexpectedly reported as corrupted", hash)
+		}
+	}
+}
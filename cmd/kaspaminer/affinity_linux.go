@@ -0,0 +1,18 @@
+package main
+
+import (
+	"runtime"
+
+	"golang.org/x/sys/unix"
+)
+
+// lockToCPU locks the calling goroutine to its OS thread and pins that thread to the given CPU.
+// The thread is never unlocked, so that it's discarded together with the goroutine instead of
+// being reused with a modified affinity.
+func lockToCPU(cpu int) error {
+	runtime.LockOSThread()
+
+	var cpuSet unix.CPUSet
+	cpuSet.Set(cpu)
+	return unix.SchedSetaffinity(0, &cpuSet)
+}
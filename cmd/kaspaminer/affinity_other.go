@@ -0,0 +1,11 @@
+//go:build !linux
+// +build !linux
+
+package main
+
+import "github.com/pkg/errors"
+
+// lockToCPU is not supported on this platform, so CPU affinity hints are ignored
+func lockToCPU(cpu int) error {
+	return errors.New("CPU affinity is not supported on this platform")
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+type workerCountResponse struct {
+	Workers int `json:"workers"`
+}
+
+// startAPIServer serves the miner's hash rates and allows changing the amount of workers
+// while mining:
+//
+//	GET /hashrate              returns the aggregate and per-worker hash rates
+//	GET /workers               returns the amount of workers
+//	POST /workers?count=<N>    changes the amount of workers
+func startAPIServer(listenAddress string, pool *workerPool) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/hashrate", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(w, pool.hashRates())
+	})
+	mux.HandleFunc("/workers", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			workerCount, err := strconv.Atoi(r.URL.Query().Get("count"))
+			if err != nil {
+				http.Error(w, "count must be a number", http.StatusBadRequest)
+				return
+			}
+			err = pool.setWorkerCount(workerCount)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(w, workerCountResponse{Workers: pool.workerCount()})
+	})
+
+	spawn("startAPIServer", func() {
+		log.Infof("Miner API listening on %s", listenAddress)
+		log.Error(http.ListenAndServe(listenAddress, mux))
+	})
+}
+
+func writeJSON(w http.ResponseWriter, response interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(response)
+	if err != nil {
+		log.Warnf("Error writing API response: %s", err)
+	}
+}
@@ -22,7 +22,7 @@ func (mc *minerClient) connect() error {
 	if err != nil {
 		return err
 	}
-	rpcClient, err := rpcclient.NewRPCClient(rpcAddress)
+	rpcClient, err := rpcclient.NewRPCClientWithCredentials(rpcAddress, mc.cfg.RPCUser, mc.cfg.RPCPassword)
 	if err != nil {
 		return err
 	}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"strings"
 
@@ -20,6 +21,8 @@ const (
 	defaultLogFilename          = "kaspaminer.log"
 	defaultErrLogFilename       = "kaspaminer_err.log"
 	defaultTargetBlockRateRatio = 2.0
+	defaultWorkers              = 1
+	maxFeeWait                  = 5000
 )
 
 var (
@@ -33,17 +36,26 @@ var (
 type configFlags struct {
 	ShowVersion           bool     `short:"V" long:"version" description:"Display version information and exit"`
 	RPCServer             string   `short:"s" long:"rpcserver" description:"RPC server to connect to"`
-	MiningAddr            string   `long:"miningaddr" description:"Address to mine to"`
+	RPCUser               string   `short:"u" long:"rpcuser" description:"RPC user to authenticate as"`
+	RPCPassword           string   `short:"P" long:"rpcpass" default-mask:"-" description:"RPC password to authenticate with"`
+	MiningAddrs           []string `long:"miningaddr" description:"Address to mine to -- may be specified multiple times, in which case found blocks are rotated between the addresses"`
+	PayoutShares          []uint64 `long:"payout-share" description:"Share, in percent, of found blocks that are mined to the respective --miningaddr -- must be specified once per mining address and sum up to 100"`
 	NumberOfBlocks        uint64   `short:"n" long:"numblocks" description:"Number of blocks to mine. If omitted, will mine until the process is interrupted."`
 	MineWhenNotSynced     bool     `long:"mine-when-not-synced" description:"Mine even if the node is not synced with the rest of the network."`
 	Profile               string   `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65536"`
 	TargetBlocksPerSecond *float64 `long:"target-blocks-per-second" description:"Sets a maximum block rate. 0 means no limit (The default one is 2 * target network block rate)"`
+	Workers               int      `short:"w" long:"workers" description:"Number of mining workers. The nonce space is split between them"`
+	CPUAffinity           []int    `long:"cpu-affinity" description:"Pin mining workers to the given CPU. Workers are assigned to the given CPUs in round-robin -- may be specified multiple times. Only supported on Linux"`
+	APIListen             string   `long:"apilisten" description:"Serve hash rates and allow changing the number of workers over HTTP on the given address (e.g. localhost:16120)"`
+	MinTemplateFees       uint64   `long:"min-template-fees" description:"Right after a new block, ask kaspad to wait for the mempool to accumulate this amount of fees (in sompi) before building the block template, for at most --max-fee-wait"`
+	MaxFeeWait            uint32   `long:"max-fee-wait" description:"The maximum time, in milliseconds, since a new block to wait for --min-template-fees (Max: 5000)"`
 	config.NetworkFlags
 }
 
 func parseConfig() (*configFlags, error) {
 	cfg := &configFlags{
 		RPCServer: defaultRPCServer,
+		Workers:   defaultWorkers,
 	}
 	parser := flags.NewParser(cfg, flags.PrintErrors|flags.HelpFlag)
 	_, err := parser.Parse()
@@ -77,10 +89,41 @@ func parseConfig() (*configFlags, error) {
 		}
 	}
 
-	if cfg.MiningAddr == "" {
+	if cfg.Workers < 1 || cfg.Workers > maxWorkers {
+		return nil, errors.Errorf("--workers must be between 1 and %d", maxWorkers)
+	}
+
+	for _, cpu := range cfg.CPUAffinity {
+		if cpu < 0 || cpu >= runtime.NumCPU() {
+			return nil, errors.Errorf("--cpu-affinity %d is out of range: there are %d CPUs", cpu, runtime.NumCPU())
+		}
+	}
+
+	if cfg.MaxFeeWait > maxFeeWait {
+		return nil, errors.Errorf("--max-fee-wait must be at most %d", maxFeeWait)
+	}
+
+	if len(cfg.MiningAddrs) == 0 {
 		return nil, errors.New("--miningaddr is required")
 	}
 
+	if len(cfg.PayoutShares) > 0 {
+		if len(cfg.PayoutShares) != len(cfg.MiningAddrs) {
+			return nil, errors.Errorf("--payout-share must be specified once per --miningaddr: got %d shares for %d addresses",
+				len(cfg.PayoutShares), len(cfg.MiningAddrs))
+		}
+		totalShares := uint64(0)
+		for _, share := range cfg.PayoutShares {
+			if share == 0 {
+				return nil, errors.New("--payout-share must be greater than 0")
+			}
+			totalShares += share
+		}
+		if totalShares != 100 {
+			return nil, errors.Errorf("--payout-share values must sum up to 100, but they sum up to %d", totalShares)
+		}
+	}
+
 	initLog(defaultLogFile, defaultErrLogFile)
 
 	return cfg, nil
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/kaspanet/kaspad/util"
 
@@ -41,14 +42,32 @@ func main() {
 	}
 	defer client.Disconnect()
 
-	miningAddr, err := util.DecodeAddress(cfg.MiningAddr, cfg.ActiveNetParams.Prefix)
+	miningAddrs := make([]util.Address, len(cfg.MiningAddrs))
+	for i, miningAddrString := range cfg.MiningAddrs {
+		miningAddrs[i], err = util.DecodeAddress(miningAddrString, cfg.ActiveNetParams.Prefix)
+		if err != nil {
+			printErrorAndExit(errors.Errorf("Error decoding mining address %s: %s", miningAddrString, err))
+		}
+	}
+	payouts, err := newPayoutSelector(miningAddrs, cfg.PayoutShares)
+	if err != nil {
+		printErrorAndExit(err)
+	}
+
+	pool := newWorkerPool(cfg.CPUAffinity, cfg.MineWhenNotSynced)
+	err = pool.setWorkerCount(cfg.Workers)
 	if err != nil {
-		printErrorAndExit(errors.Errorf("Error decoding mining address: %s", err))
+		printErrorAndExit(err)
+	}
+
+	if cfg.APIListen != "" {
+		startAPIServer(cfg.APIListen, pool)
 	}
 
 	doneChan := make(chan struct{})
 	spawn("mineLoop", func() {
-		err = mineLoop(client, cfg.NumberOfBlocks, *cfg.TargetBlocksPerSecond, cfg.MineWhenNotSynced, miningAddr)
+		maxFeeWait := time.Duration(cfg.MaxFeeWait) * time.Millisecond
+		err = mineLoop(client, pool, cfg.NumberOfBlocks, *cfg.TargetBlocksPerSecond, payouts, cfg.MinTemplateFees, maxFeeWait)
 		if err != nil {
 			panic(errors.Wrap(err, "error in mine loop"))
 		}
@@ -4,7 +4,6 @@ import (
 	nativeerrors "errors"
 	"github.com/kaspanet/kaspad/version"
 	"math/rand"
-	"sync/atomic"
 	"time"
 
 	"github.com/kaspanet/kaspad/app/appmessage"
@@ -13,16 +12,13 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/pow"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
-	"github.com/kaspanet/kaspad/util"
 	"github.com/pkg/errors"
 )
 
-var hashesTried uint64
-
 const logHashRateInterval = 10 * time.Second
 
-func mineLoop(client *minerClient, numberOfBlocks uint64, targetBlocksPerSecond float64, mineWhenNotSynced bool,
-	miningAddr util.Address) error {
+func mineLoop(client *minerClient, pool *workerPool, numberOfBlocks uint64, targetBlocksPerSecond float64,
+	payouts *payoutSelector, minTemplateFees uint64, maxFeeWait time.Duration) error {
 	rand.Seed(time.Now().UnixNano()) // Seed the global concurrent-safe random source.
 
 	errChan := make(chan error)
@@ -35,7 +31,7 @@ func mineLoop(client *minerClient, numberOfBlocks uint64, targetBlocksPerSecond
 	foundBlockChan := make(chan *externalapi.DomainBlock, router.DefaultMaxMessages/2)
 
 	spawn("templatesLoop", func() {
-		templatesLoop(client, miningAddr, errChan)
+		templatesLoop(client, payouts, minTemplateFees, maxFeeWait, errChan)
 	})
 
 	spawn("blocksLoop", func() {
@@ -57,7 +53,7 @@ func mineLoop(client *minerClient, numberOfBlocks uint64, targetBlocksPerSecond
 		}
 		windowStart := time.Now()
 		for blockIndex := 1; ; blockIndex++ {
-			foundBlockChan <- mineNextBlock(mineWhenNotSynced)
+			foundBlockChan <- pool.nextBlock()
 			if hasBlockRateTarget {
 				<-blockTicker.C
 				if (blockIndex % windowSize) == 0 {
@@ -73,7 +69,7 @@ func mineLoop(client *minerClient, numberOfBlocks uint64, targetBlocksPerSecond
 	spawn("handleFoundBlock", func() {
 		for i := uint64(0); numberOfBlocks == 0 || i < numberOfBlocks; i++ {
 			block := <-foundBlockChan
-			err := handleFoundBlock(client, block)
+			err := handleFoundBlock(client, payouts, block)
 			if err != nil {
 				errChan <- err
 				return
@@ -82,7 +78,7 @@ func mineLoop(client *minerClient, numberOfBlocks uint64, targetBlocksPerSecond
 		doneChan <- struct{}{}
 	})
 
-	logHashRate()
+	logHashRate(pool)
 
 	select {
 	case err := <-errChan:
@@ -92,23 +88,19 @@ func mineLoop(client *minerClient, numberOfBlocks uint64, targetBlocksPerSecond
 	}
 }
 
-func logHashRate() {
+func logHashRate(pool *workerPool) {
 	spawn("logHashRate", func() {
-		lastCheck := time.Now()
 		for range time.Tick(logHashRateInterval) {
-			currentHashesTried := atomic.LoadUint64(&hashesTried)
-			currentTime := time.Now()
-			kiloHashesTried := float64(currentHashesTried) / 1000.0
-			hashRate := kiloHashesTried / currentTime.Sub(lastCheck).Seconds()
-			log.Infof("Current hash rate is %.2f Khash/s", hashRate)
-			lastCheck = currentTime
-			// subtract from hashesTried the hashes we already sampled
-			atomic.AddUint64(&hashesTried, -currentHashesTried)
+			report := pool.sampleHashRates()
+			log.Infof("Current hash rate is %.2f Khash/s", report.TotalHashRate/1000.0)
+			for _, worker := range report.Workers {
+				log.Debugf("Worker %d hash rate is %.2f Khash/s", worker.Index, worker.HashRate/1000.0)
+			}
 		}
 	})
 }
 
-func handleFoundBlock(client *minerClient, block *externalapi.DomainBlock) error {
+func handleFoundBlock(client *minerClient, payouts *payoutSelector, block *externalapi.DomainBlock) error {
 	blockHash := consensushashing.BlockHash(block)
 	log.Infof("Submitting block %s to %s", blockHash, client.Address())
 
@@ -131,29 +123,9 @@ func handleFoundBlock(client *minerClient, block *externalapi.DomainBlock) error
 		}
 		return errors.Wrapf(err, "Error submitting block %s to %s", blockHash, client.Address())
 	}
-	return nil
-}
 
-func mineNextBlock(mineWhenNotSynced bool) *externalapi.DomainBlock {
-	nonce := rand.Uint64() // Use the global concurrent-safe random source.
-	for {
-		nonce++
-		// For each nonce we try to build a block from the most up to date
-		// block template.
-		// In the rare case where the nonce space is exhausted for a specific
-		// block, it'll keep looping the nonce until a new block template
-		// is discovered.
-		block, state := getBlockForMining(mineWhenNotSynced)
-		state.Nonce = nonce
-		atomic.AddUint64(&hashesTried, 1)
-		if state.CheckProofOfWork() {
-			mutHeader := block.Header.ToMutable()
-			mutHeader.SetNonce(nonce)
-			block.Header = mutHeader.ToImmutable()
-			log.Infof("Found block %s with parents %s", consensushashing.BlockHash(block), block.Header.DirectParents())
-			return block
-		}
-	}
+	// The templates requested from now on pay the next payout address
+	return payouts.blockAccepted(block)
 }
 
 func getBlockForMining(mineWhenNotSynced bool) (*externalapi.DomainBlock, *pow.State) {
@@ -186,9 +158,12 @@ func getBlockForMining(mineWhenNotSynced bool) (*externalapi.DomainBlock, *pow.S
 	}
 }
 
-func templatesLoop(client *minerClient, miningAddr util.Address, errChan chan error) {
+func templatesLoop(client *minerClient, payouts *payoutSelector, minTemplateFees uint64, maxFeeWait time.Duration,
+	errChan chan error) {
+
 	getBlockTemplate := func() {
-		template, err := client.GetBlockTemplate(miningAddr.String(), "kaspaminer-"+version.Version())
+		template, err := client.GetBlockTemplateWithMinFees(payouts.address().String(), "kaspaminer-"+version.Version(),
+			minTemplateFees, maxFeeWait)
 		if nativeerrors.Is(err, router.ErrTimeout) {
 			log.Warnf("Got timeout while requesting block template from %s: %s", client.Address(), err)
 			reconnectErr := client.Reconnect()
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/processes/coinbasemanager"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/pkg/errors"
+)
+
+// payoutSelector chooses the address every block is mined to.
+//
+// A Kaspa block pays its whole reward to the single address in its coinbase
+// payload, so the reward is split between several addresses by mining each
+// block to a different one. Blocks are assigned to addresses using smooth
+// weighted round-robin, so that any window of found blocks is split as close
+// as possible to the configured shares. Without shares, the addresses are
+// simply rotated.
+//
+// Only blocks the node accepted count, and each one is credited to the
+// address its coinbase pays, which isn't the current address if the block
+// was mined from an older template.
+type payoutSelector struct {
+	lock             sync.Mutex
+	addresses        []util.Address
+	scriptPublicKeys []*externalapi.ScriptPublicKey
+	shares           []uint64
+	credits          []int64
+	current          int
+}
+
+func newPayoutSelector(addresses []util.Address, shares []uint64) (*payoutSelector, error) {
+	if len(addresses) == 0 {
+		return nil, errors.New("at least one payout address is required")
+	}
+	if len(shares) == 0 {
+		shares = make([]uint64, len(addresses))
+		for i := range shares {
+			shares[i] = 1
+		}
+	}
+	if len(shares) != len(addresses) {
+		return nil, errors.Errorf("got %d payout shares for %d payout addresses", len(shares), len(addresses))
+	}
+	for i, share := range shares {
+		if share == 0 {
+			return nil, errors.Errorf("the payout share of %s must be greater than 0", addresses[i])
+		}
+	}
+
+	scriptPublicKeys := make([]*externalapi.ScriptPublicKey, len(addresses))
+	for i, address := range addresses {
+		scriptPublicKey, err := txscript.PayToAddrScript(address)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error building the script public key of %s", address)
+		}
+		scriptPublicKeys[i] = scriptPublicKey
+	}
+
+	ps := &payoutSelector{
+		addresses:        addresses,
+		scriptPublicKeys: scriptPublicKeys,
+		shares:           shares,
+		credits:          make([]int64, len(addresses)),
+	}
+	ps.selectNext()
+	return ps, nil
+}
+
+// address returns the address the current block should be mined to
+func (ps *payoutSelector) address() util.Address {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	return ps.addresses[ps.current]
+}
+
+// blockAccepted credits the given block, which the node accepted, to the
+// payout address its coinbase pays, and moves on to the address the next
+// block should be mined to. Blocks that pay none of the payout addresses are
+// ignored.
+func (ps *payoutSelector) blockAccepted(block *externalapi.DomainBlock) error {
+	scriptPublicKey, err := coinbasemanager.CoinbasePayloadScriptPublicKey(block.Transactions[0].Payload)
+	if err != nil {
+		return err
+	}
+
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+
+	for i, payoutScriptPublicKey := range ps.scriptPublicKeys {
+		if payoutScriptPublicKey.Equal(scriptPublicKey) {
+			ps.credit(i)
+			return nil
+		}
+	}
+	return nil
+}
+
+// credit charges the address of the given index with a block: every address
+// earns its share, and the credited one pays the total of the shares
+func (ps *payoutSelector) credit(index int) {
+	totalShares := int64(0)
+	for i, share := range ps.shares {
+		ps.credits[i] += int64(share)
+		totalShares += int64(share)
+	}
+	ps.credits[index] -= totalShares
+	ps.selectNext()
+}
+
+// selectNext selects the address that has the most credit once it earns its
+// share of the next block
+func (ps *payoutSelector) selectNext() {
+	selected := 0
+	for i := range ps.credits {
+		if ps.credits[i]+int64(ps.shares[i]) > ps.credits[selected]+int64(ps.shares[selected]) {
+			selected = i
+		}
+	}
+	ps.current = selected
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/processes/coinbasemanager"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/util"
+)
+
+func testPayoutAddresses(t *testing.T, count int) []util.Address {
+	addresses := make([]util.Address, count)
+	for i := range addresses {
+		publicKey := make([]byte, 32)
+		publicKey[0] = byte(i + 1)
+		address, err := util.NewAddressPublicKey(publicKey, util.Bech32PrefixKaspaDev)
+		if err != nil {
+			t.Fatalf("NewAddressPublicKey: %s", err)
+		}
+		addresses[i] = address
+	}
+	return addresses
+}
+
+// testPayoutBlock returns a block whose coinbase pays the given address
+func testPayoutBlock(t *testing.T, address util.Address) *externalapi.DomainBlock {
+	scriptPublicKey, err := txscript.PayToAddrScript(address)
+	if err != nil {
+		t.Fatalf("PayToAddrScript: %s", err)
+	}
+	payload, err := coinbasemanager.ModifyCoinbasePayload(make([]byte, 16),
+		&externalapi.DomainCoinbaseData{ScriptPublicKey: scriptPublicKey, ExtraData: []byte("kaspaminer")}, 255)
+	if err != nil {
+		t.Fatalf("ModifyCoinbasePayload: %s", err)
+	}
+	return &externalapi.DomainBlock{
+		Transactions: []*externalapi.DomainTransaction{{Payload: payload}},
+	}
+}
+
+// acceptBlock credits the payout selector with a block mined to its current address
+func acceptBlock(t *testing.T, payouts *payoutSelector) {
+	err := payouts.blockAccepted(testPayoutBlock(t, payouts.address()))
+	if err != nil {
+		t.Fatalf("blockAccepted: %s", err)
+	}
+}
+
+func TestPayoutSelectorRotation(t *testing.T) {
+	addresses := testPayoutAddresses(t, 3)
+	payouts, err := newPayoutSelector(addresses, nil)
+	if err != nil {
+		t.Fatalf("newPayoutSelector: %s", err)
+	}
+
+	for i := 0; i < 3*len(addresses); i++ {
+		expectedAddress := addresses[i%len(addresses)]
+		if !(payouts.address().String() == expectedAddress.String()) {
+			t.Fatalf("Block %d: expected payout address %s but got %s", i, expectedAddress, payouts.address())
+		}
+		acceptBlock(t, payouts)
+	}
+}
+
+func TestPayoutSelectorShares(t *testing.T) {
+	tests := []struct {
+		shares      []uint64
+		blockCount  int
+		windowSize  int
+		blockCounts []int
+	}{
+		{shares: []uint64{90, 10}, blockCount: 100, windowSize: 10, blockCounts: []int{90, 10}},
+		{shares: []uint64{50, 30, 20}, blockCount: 100, windowSize: 10, blockCounts: []int{50, 30, 20}},
+		{shares: []uint64{99, 1}, blockCount: 1000, windowSize: 100, blockCounts: []int{990, 10}},
+	}
+
+	for _, test := range tests {
+		addresses := testPayoutAddresses(t, len(test.shares))
+		payouts, err := newPayoutSelector(addresses, test.shares)
+		if err != nil {
+			t.Fatalf("newPayoutSelector: %s", err)
+		}
+
+		blockCounts := make([]int, len(addresses))
+		for i := 0; i < test.blockCount; i++ {
+			address := payouts.address()
+			for j := range addresses {
+				if address.String() == addresses[j].String() {
+					blockCounts[j]++
+				}
+			}
+			acceptBlock(t, payouts)
+
+			// Every full window of blocks should already be split exactly by the shares
+			if (i+1)%test.windowSize == 0 {
+				for j, count := range blockCounts {
+					expectedCount := int(test.shares[j]) * (i + 1) / 100
+					if count != expectedCount {
+						t.Fatalf("Shares %v: expected %d of the first %d blocks to be paid to address %d, but got %d",
+							test.shares, expectedCount, i+1, j, count)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestPayoutSelectorCreditsCoinbaseAddress(t *testing.T) {
+	addresses := testPayoutAddresses(t, 3)
+	payouts, err := newPayoutSelector(addresses[:2], []uint64{50, 50})
+	if err != nil {
+		t.Fatalf("newPayoutSelector: %s", err)
+	}
+
+	// A block mined from a stale template pays the second address while
+	// the first one is current, so the first one stays current
+	if payouts.address().String() != addresses[0].String() {
+		t.Fatalf("Expected the first payout address to be current, but got %s", payouts.address())
+	}
+	err = payouts.blockAccepted(testPayoutBlock(t, addresses[1]))
+	if err != nil {
+		t.Fatalf("blockAccepted: %s", err)
+	}
+	if payouts.address().String() != addresses[0].String() {
+		t.Fatalf("Expected the first payout address to stay current after a block paying the second one, "+
+			"but got %s", payouts.address())
+	}
+
+	// A block that pays none of the payout addresses is ignored
+	err = payouts.blockAccepted(testPayoutBlock(t, addresses[2]))
+	if err != nil {
+		t.Fatalf("blockAccepted: %s", err)
+	}
+	if payouts.address().String() != addresses[0].String() {
+		t.Fatalf("Expected a block paying an unknown address to be ignored, but the current address is %s",
+			payouts.address())
+	}
+
+	// Once both addresses got a block, the rotation starts over
+	acceptBlock(t, payouts)
+	if payouts.address().String() != addresses[0].String() {
+		t.Fatalf("Expected the first payout address to be current after a block for each, but got %s",
+			payouts.address())
+	}
+}
+
+func TestNewPayoutSelectorErrors(t *testing.T) {
+	addresses := testPayoutAddresses(t, 2)
+
+	_, err := newPayoutSelector(nil, nil)
+	if err == nil {
+		t.Fatalf("Expected an error for no payout addresses")
+	}
+	_, err = newPayoutSelector(addresses, []uint64{100})
+	if err == nil {
+		t.Fatalf("Expected an error for a mismatching amount of payout shares")
+	}
+	_, err = newPayoutSelector(addresses, []uint64{100, 0})
+	if err == nil {
+		t.Fatalf("Expected an error for a zero payout share")
+	}
+}
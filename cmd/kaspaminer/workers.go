@@ -0,0 +1,193 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/pkg/errors"
+)
+
+// maxWorkers is the maximum amount of mining workers that may run at once
+const maxWorkers = 1024
+
+// minerWorker mines on its own disjoint part of the nonce space
+type minerWorker struct {
+	index       int
+	nonceStart  uint64
+	nonceRange  uint64
+	cpu         int // -1 if the worker isn't pinned to a CPU
+	hashesTried uint64
+	quit        chan struct{}
+}
+
+// workerHashRate is the hash rate of a single worker, in hashes per second
+type workerHashRate struct {
+	Index    int     `json:"index"`
+	CPU      int     `json:"cpu"`
+	HashRate float64 `json:"hashRate"`
+}
+
+// hashRateReport holds the aggregate and per-worker hash rates, in hashes per second
+type hashRateReport struct {
+	TotalHashRate float64          `json:"totalHashRate"`
+	Workers       []workerHashRate `json:"workers"`
+}
+
+// workerPool runs a resizable set of mining workers and collects the blocks they find
+type workerPool struct {
+	mineWhenNotSynced bool
+	cpuAffinity       []int
+	foundBlockChan    chan *externalapi.DomainBlock
+
+	lock           sync.Mutex
+	workers        []*minerWorker
+	lastHashRates  hashRateReport
+	lastSampleTime time.Time
+}
+
+func newWorkerPool(cpuAffinity []int, mineWhenNotSynced bool) *workerPool {
+	return &workerPool{
+		mineWhenNotSynced: mineWhenNotSynced,
+		cpuAffinity:       cpuAffinity,
+		foundBlockChan:    make(chan *externalapi.DomainBlock),
+		lastSampleTime:    time.Now(),
+	}
+}
+
+// nonceRange returns the part of the nonce space that belongs to the worker with the given
+// index, out of workerCount workers
+func nonceRange(workerIndex int, workerCount int) (start uint64, size uint64) {
+	size = math.MaxUint64 / uint64(workerCount)
+	return uint64(workerIndex) * size, size
+}
+
+// setWorkerCount stops all the running workers and starts workerCount new ones, splitting the
+// nonce space between them. Mining continues from the current block template.
+func (wp *workerPool) setWorkerCount(workerCount int) error {
+	if workerCount < 1 || workerCount > maxWorkers {
+		return errors.Errorf("the amount of workers must be between 1 and %d", maxWorkers)
+	}
+
+	wp.lock.Lock()
+	defer wp.lock.Unlock()
+
+	for _, worker := range wp.workers {
+		close(worker.quit)
+	}
+
+	wp.workers = make([]*minerWorker, workerCount)
+	for i := range wp.workers {
+		start, size := nonceRange(i, workerCount)
+		worker := &minerWorker{
+			index:      i,
+			nonceStart: start,
+			nonceRange: size,
+			cpu:        -1,
+			quit:       make(chan struct{}),
+		}
+		if len(wp.cpuAffinity) > 0 {
+			worker.cpu = wp.cpuAffinity[i%len(wp.cpuAffinity)]
+		}
+		wp.workers[i] = worker
+
+		spawn("minerWorker.mine", func() {
+			wp.mine(worker)
+		})
+	}
+	wp.lastHashRates = hashRateReport{}
+	wp.lastSampleTime = time.Now()
+
+	log.Infof("Mining with %d workers", workerCount)
+	return nil
+}
+
+// workerCount returns the amount of currently running workers
+func (wp *workerPool) workerCount() int {
+	wp.lock.Lock()
+	defer wp.lock.Unlock()
+
+	return len(wp.workers)
+}
+
+// nextBlock blocks until any of the workers finds a block and returns it
+func (wp *workerPool) nextBlock() *externalapi.DomainBlock {
+	return <-wp.foundBlockChan
+}
+
+func (wp *workerPool) mine(worker *minerWorker) {
+	if worker.cpu >= 0 {
+		err := lockToCPU(worker.cpu)
+		if err != nil {
+			log.Warnf("Could not pin worker %d to CPU %d: %s", worker.index, worker.cpu, err)
+		}
+	}
+
+	nonce := worker.nonceStart + rand.Uint64()%worker.nonceRange // Use the global concurrent-safe random source.
+	for {
+		select {
+		case <-worker.quit:
+			return
+		default:
+		}
+
+		// For each nonce we try to build a block from the most up to date
+		// block template.
+		// In the rare case where the nonce range of the worker is exhausted
+		// for a specific block, it'll keep looping the range until a new
+		// block template is discovered.
+		block, state := getBlockForMining(wp.mineWhenNotSynced)
+		state.Nonce = nonce
+		atomic.AddUint64(&worker.hashesTried, 1)
+		if state.CheckProofOfWork() {
+			mutHeader := block.Header.ToMutable()
+			mutHeader.SetNonce(nonce)
+			block.Header = mutHeader.ToImmutable()
+			log.Infof("Worker %d found block %s with parents %s",
+				worker.index, consensushashing.BlockHash(block), block.Header.DirectParents())
+
+			select {
+			case wp.foundBlockChan <- block:
+			case <-worker.quit:
+				return
+			}
+		}
+
+		nonce++
+		if nonce-worker.nonceStart >= worker.nonceRange {
+			nonce = worker.nonceStart
+		}
+	}
+}
+
+// sampleHashRates calculates the hash rate of every worker since the previous sample
+func (wp *workerPool) sampleHashRates() hashRateReport {
+	wp.lock.Lock()
+	defer wp.lock.Unlock()
+
+	now := time.Now()
+	elapsedSeconds := now.Sub(wp.lastSampleTime).Seconds()
+	wp.lastSampleTime = now
+
+	report := hashRateReport{Workers: make([]workerHashRate, len(wp.workers))}
+	for i, worker := range wp.workers {
+		hashesTried := atomic.SwapUint64(&worker.hashesTried, 0)
+		hashRate := float64(hashesTried) / elapsedSeconds
+		report.Workers[i] = workerHashRate{Index: worker.index, CPU: worker.cpu, HashRate: hashRate}
+		report.TotalHashRate += hashRate
+	}
+	wp.lastHashRates = report
+	return report
+}
+
+// hashRates returns the most recently sampled hash rates
+func (wp *workerPool) hashRates() hashRateReport {
+	wp.lock.Lock()
+	defer wp.lock.Unlock()
+
+	return wp.lastHashRates
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNonceRange(t *testing.T) {
+	for _, workerCount := range []int{1, 2, 3, 7, 64, maxWorkers} {
+		expectedStart := uint64(0)
+		for i := 0; i < workerCount; i++ {
+			start, size := nonceRange(i, workerCount)
+			if start != expectedStart {
+				t.Fatalf("worker %d out of %d: expected range to start at %d but got %d",
+					i, workerCount, expectedStart, start)
+			}
+			if size == 0 {
+				t.Fatalf("worker %d out of %d: got an empty range", i, workerCount)
+			}
+			expectedStart = start + size
+		}
+
+		// The ranges are allowed to leave out at most workerCount nonces at the end of the nonce space
+		uncovered := math.MaxUint64 - expectedStart
+		if uncovered >= uint64(workerCount) {
+			t.Fatalf("%d workers: %d nonces at the end of the nonce space are not covered", workerCount, uncovered)
+		}
+	}
+}
@@ -3,9 +3,8 @@ package libkaspawallet
 import (
 	"fmt"
 
-	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet/bip32"
 	"github.com/kaspanet/kaspad/domain/dagconfig"
-	"github.com/pkg/errors"
+	"github.com/kaspanet/kaspad/util/bip32"
 	"github.com/tyler-smith/go-bip39"
 )
 
@@ -53,30 +52,10 @@ func MasterPublicKeyFromMnemonic(params *dagconfig.Params, mnemonic string, isMu
 
 func extendedKeyFromMnemonicAndPath(mnemonic string, path string, params *dagconfig.Params) (*bip32.ExtendedKey, error) {
 	seed := bip39.NewSeed(mnemonic, "")
-	version, err := versionFromParams(params)
-	if err != nil {
-		return nil, err
-	}
-
-	master, err := bip32.NewMasterWithPath(seed, version, path)
+	master, err := bip32.NewMasterWithPath(seed, params.HDPrivateKeyID, path)
 	if err != nil {
 		return nil, err
 	}
 
 	return master, nil
 }
-
-func versionFromParams(params *dagconfig.Params) ([4]byte, error) {
-	switch params.Name {
-	case dagconfig.MainnetParams.Name:
-		return bip32.KaspaMainnetPrivate, nil
-	case dagconfig.TestnetParams.Name:
-		return bip32.KaspaTestnetPrivate, nil
-	case dagconfig.DevnetParams.Name:
-		return bip32.KaspaDevnetPrivate, nil
-	case dagconfig.SimnetParams.Name:
-		return bip32.KaspaSimnetPrivate, nil
-	}
-
-	return [4]byte{}, errors.Errorf("unknown network %s", params.Name)
-}
@@ -1,13 +1,13 @@
 package libkaspawallet
 
 import (
-	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet/bip32"
 	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet/serialization"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
 	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/bip32"
 	"github.com/pkg/errors"
 )
 
@@ -43,9 +43,8 @@ func CreateUnsignedTransaction(
 }
 
 func multiSigRedeemScript(extendedPublicKeys []string, minimumSignatures uint32, path string, ecdsa bool) ([]byte, error) {
-	scriptBuilder := txscript.NewScriptBuilder()
-	scriptBuilder.AddInt64(int64(minimumSignatures))
-	for _, key := range extendedPublicKeys {
+	serializedPublicKeys := make([][]byte, len(extendedPublicKeys))
+	for i, key := range extendedPublicKeys {
 		extendedKey, err := bip32.DeserializeExtendedKey(key)
 		if err != nil {
 			return nil, err
@@ -61,13 +60,12 @@ func multiSigRedeemScript(extendedPublicKeys []string, minimumSignatures uint32,
 			return nil, err
 		}
 
-		var serializedPublicKey []byte
 		if ecdsa {
 			serializedECDSAPublicKey, err := publicKey.Serialize()
 			if err != nil {
 				return nil, err
 			}
-			serializedPublicKey = serializedECDSAPublicKey[:]
+			serializedPublicKeys[i] = serializedECDSAPublicKey[:]
 		} else {
 			schnorrPublicKey, err := publicKey.ToSchnorr()
 			if err != nil {
@@ -78,20 +76,11 @@ func multiSigRedeemScript(extendedPublicKeys []string, minimumSignatures uint32,
 			if err != nil {
 				return nil, err
 			}
-			serializedPublicKey = serializedSchnorrPublicKey[:]
+			serializedPublicKeys[i] = serializedSchnorrPublicKey[:]
 		}
-
-		scriptBuilder.AddData(serializedPublicKey)
-	}
-	scriptBuilder.AddInt64(int64(len(extendedPublicKeys)))
-
-	if ecdsa {
-		scriptBuilder.AddOp(txscript.OpCheckMultiSigECDSA)
-	} else {
-		scriptBuilder.AddOp(txscript.OpCheckMultiSig)
 	}
 
-	return scriptBuilder.Script()
+	return util.MultisigRedeemScript(int(minimumSignatures), serializedPublicKeys)
 }
 
 func createUnsignedTransaction(
@@ -0,0 +1,271 @@
+package addressindex
+
+import (
+	"sync"
+
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
+	"github.com/kaspanet/kaspad/domain/indexes"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+// resetStep is the amount of chain blocks that are indexed in a single
+// database transaction while resetting the address index
+const resetStep = 1000
+
+// AddressIndex maintains an index between scriptPublicKeys and the
+// accepted transactions that credit or debit them
+type AddressIndex struct {
+	domain             domain.Domain
+	store              *addressIndexStore
+	progress           *indexes.Progress
+	indexedSubnetworks subnetworks.Filter
+
+	mutex sync.Mutex
+}
+
+// New creates a new address index, which only indexes the transactions of
+// indexedSubnetworks. If the index was built for other subnetworks, it's reset.
+//
+// NOTE: While this is called no new blocks can be added to the consensus.
+func New(domain domain.Domain, database database.Database, indexedSubnetworks subnetworks.Filter) (*AddressIndex, error) {
+	addressIndex := &AddressIndex{
+		domain:             domain,
+		store:              newAddressIndexStore(database),
+		progress:           indexes.NewProgress(),
+		indexedSubnetworks: indexedSubnetworks,
+	}
+	err := addressIndex.sync()
+	if err != nil {
+		return nil, err
+	}
+
+	return addressIndex, nil
+}
+
+// sync catches the address index up with the virtual selected parent chain.
+// If the chain can't be followed from the index tip, the index is reset.
+func (ai *AddressIndex) sync() error {
+	tip, err := ai.store.getTip()
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return ai.Reset()
+		}
+		return err
+	}
+
+	storedIndexedSubnetworks, err := ai.store.getIndexedSubnetworks()
+	if err != nil {
+		return err
+	}
+	if !storedIndexedSubnetworks.Equal(ai.indexedSubnetworks) {
+		log.Infof("The address index holds the transactions of the subnetworks %s, rather than %s. "+
+			"Resetting the address index", storedIndexedSubnetworks, ai.indexedSubnetworks)
+		return ai.Reset()
+	}
+
+	chainPath, err := ai.domain.Consensus().GetVirtualSelectedParentChainFromBlock(tip)
+	if err != nil {
+		log.Infof("Could not follow the selected chain from the address index tip %s: %s. "+
+			"Resetting the address index", tip, err)
+		return ai.Reset()
+	}
+
+	ai.mutex.Lock()
+	defer ai.mutex.Unlock()
+
+	return ai.update(chainPath)
+}
+
+// Reset deletes the whole address index and resyncs it from consensus,
+// starting from the pruning point.
+func (ai *AddressIndex) Reset() (err error) {
+	ai.mutex.Lock()
+	defer ai.mutex.Unlock()
+
+	ai.progress.Start(indexes.OperationRebuild)
+	defer func() {
+		ai.progress.Finish(err)
+	}()
+
+	err = ai.store.deleteAll()
+	if err != nil {
+		return err
+	}
+
+	pruningPoint, err := ai.domain.Consensus().PruningPoint()
+	if err != nil {
+		return err
+	}
+	chainPath, err := ai.domain.Consensus().GetVirtualSelectedParentChainFromBlock(pruningPoint)
+	if err != nil {
+		return err
+	}
+	err = ai.store.updateIndexedSubnetworks(ai.store.database, ai.indexedSubnetworks)
+	if err != nil {
+		return err
+	}
+	err = ai.store.updateTip(ai.store.database, pruningPoint)
+	if err != nil {
+		return err
+	}
+	ai.progress.SetTotal(uint64(len(chainPath.Added)))
+
+	for start := 0; start < len(chainPath.Added); start += resetStep {
+		end := start + resetStep
+		if end > len(chainPath.Added) {
+			end = len(chainPath.Added)
+		}
+		err := ai.update(&externalapi.SelectedChainPath{Added: chainPath.Added[start:end]})
+		if err != nil {
+			return err
+		}
+		ai.progress.Advance(uint64(end - start))
+		log.Infof("Indexed the transactions of %d out of %d chain blocks", end, len(chainPath.Added))
+	}
+
+	return nil
+}
+
+// Progress returns the progress of the rebuilds of the address index
+func (ai *AddressIndex) Progress() *indexes.Progress {
+	return ai.progress
+}
+
+// Update updates the address index with the given DAG selected parent chain changes
+func (ai *AddressIndex) Update(virtualChangeSet *externalapi.VirtualChangeSet) error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "AddressIndex.Update")
+	defer onEnd()
+
+	if virtualChangeSet.VirtualSelectedParentChainChanges == nil {
+		return nil
+	}
+
+	ai.mutex.Lock()
+	defer ai.mutex.Unlock()
+
+	return ai.update(virtualChangeSet.VirtualSelectedParentChainChanges)
+}
+
+// update removes the transactions accepted by the removed chain blocks, and adds
+// the ones accepted by the added chain blocks. Since both operations are idempotent,
+// it's safe to apply the same changes more than once.
+func (ai *AddressIndex) update(chainPath *externalapi.SelectedChainPath) error {
+	if len(chainPath.Added) == 0 && len(chainPath.Removed) == 0 {
+		return nil
+	}
+
+	dbTransaction, err := ai.store.database.Begin()
+	if err != nil {
+		return err
+	}
+	defer dbTransaction.RollbackUnlessClosed()
+
+	for _, removedChainBlockHash := range chainPath.Removed {
+		log.Tracef("Removing the transactions accepted by %s from the address index", removedChainBlockHash)
+		err := ai.forEachAcceptedTransaction(removedChainBlockHash,
+			func(scriptPublicKey *externalapi.ScriptPublicKey, entry *TransactionEntry) error {
+				return ai.store.remove(dbTransaction, scriptPublicKey, entry)
+			})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, addedChainBlockHash := range chainPath.Added {
+		log.Tracef("Adding the transactions accepted by %s to the address index", addedChainBlockHash)
+		err := ai.forEachAcceptedTransaction(addedChainBlockHash,
+			func(scriptPublicKey *externalapi.ScriptPublicKey, entry *TransactionEntry) error {
+				return ai.store.add(dbTransaction, scriptPublicKey, entry)
+			})
+		if err != nil {
+			return err
+		}
+	}
+
+	// If the chain only retreated, the old tip is kept. Following the chain
+	// from it later removes the same transactions again, which is harmless.
+	if len(chainPath.Added) > 0 {
+		err = ai.store.updateTip(dbTransaction, chainPath.Added[len(chainPath.Added)-1])
+		if err != nil {
+			return err
+		}
+	}
+
+	return dbTransaction.Commit()
+}
+
+// forEachAcceptedTransaction calls handle for every transaction of the indexed subnetworks
+// that was accepted by the given chain block, once for every scriptPublicKey that the
+// transaction credits or debits
+func (ai *AddressIndex) forEachAcceptedTransaction(chainBlockHash *externalapi.DomainHash,
+	handle func(scriptPublicKey *externalapi.ScriptPublicKey, entry *TransactionEntry) error) error {
+
+	chainBlockHeader, err := ai.domain.Consensus().GetBlockHeader(chainBlockHash)
+	if err != nil {
+		return err
+	}
+	acceptanceData, err := ai.domain.Consensus().GetBlockAcceptanceData(chainBlockHash)
+	if err != nil {
+		return err
+	}
+
+	for _, blockAcceptanceData := range acceptanceData {
+		for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+			if !transactionAcceptanceData.IsAccepted {
+				continue
+			}
+			transaction := transactionAcceptanceData.Transaction
+			if !ai.indexedSubnetworks.Passes(transaction.SubnetworkID) {
+				continue
+			}
+			entry := &TransactionEntry{
+				TransactionID:      consensushashing.TransactionID(transaction),
+				BlockHash:          blockAcceptanceData.BlockHash,
+				AcceptingBlockHash: chainBlockHash,
+				AcceptingDAAScore:  chainBlockHeader.DAAScore(),
+			}
+
+			scriptPublicKeys := make(map[string]*externalapi.ScriptPublicKey)
+			for _, output := range transaction.Outputs {
+				scriptPublicKeys[output.ScriptPublicKey.String()] = output.ScriptPublicKey
+			}
+			for _, utxoEntry := range transactionAcceptanceData.TransactionInputUTXOEntries {
+				scriptPublicKeys[utxoEntry.ScriptPublicKey().String()] = utxoEntry.ScriptPublicKey()
+			}
+			for _, scriptPublicKey := range scriptPublicKeys {
+				err := handle(scriptPublicKey, entry)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Transactions returns up to limit accepted transactions that credit or debit the
+// given scriptPublicKey, newest first. Pass a nil cursor to get the first page, and
+// the returned cursor to get the next one. The returned cursor is nil if there are
+// no more transactions.
+func (ai *AddressIndex) Transactions(scriptPublicKey *externalapi.ScriptPublicKey, cursor []byte, limit int) (
+	entries []*TransactionEntry, nextCursor []byte, err error) {
+
+	onEnd := logger.LogAndMeasureExecutionTime(log, "AddressIndex.Transactions")
+	defer onEnd()
+
+	// The mutex is only held while the snapshot is taken, so that a
+	// long scan doesn't block updates to the index
+	ai.mutex.Lock()
+	snapshot, err := ai.store.database.Snapshot()
+	ai.mutex.Unlock()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer snapshot.Release()
+
+	return ai.store.getTransactionEntries(snapshot, scriptPublicKey, cursor, limit)
+}
@@ -0,0 +1,25 @@
+package addressindex
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/migration"
+)
+
+// The keys of the address index before it was moved into its namespace
+var (
+	oldAddressIndexBucket = database.MakeBucket([]byte("address-index"))
+	oldTipKey             = database.MakeBucket([]byte("")).Key([]byte("address-index-tip"))
+)
+
+// MigrateToNamespace moves the data of an address index that was built before
+// the address index was moved into its namespace. The tip is moved last, since
+// the index is reset if it's missing
+func MigrateToNamespace(db database.Database) error {
+	movedCount, err := migration.MoveBucket(db, oldAddressIndexBucket, addressIndexBucket)
+	if err != nil {
+		return err
+	}
+	log.Infof("Moved %d address index entries", movedCount)
+
+	return migration.MoveKey(db, oldTipKey, tipKey)
+}
@@ -0,0 +1,14 @@
+package addressindex
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// TransactionEntry is a transaction that credits or debits some scriptPublicKey,
+// along with the block that contains it and the chain block that accepted it
+type TransactionEntry struct {
+	TransactionID      *externalapi.DomainTransactionID
+	BlockHash          *externalapi.DomainHash
+	AcceptingBlockHash *externalapi.DomainHash
+	AcceptingDAAScore  uint64
+}
@@ -0,0 +1,95 @@
+package addressindex
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
+	"github.com/pkg/errors"
+)
+
+const (
+	daaScoreSize = 8
+
+	// transactionEntryKeySize is the size of the key under which a transaction
+	// entry is stored, within the bucket of its scriptPublicKey
+	transactionEntryKeySize = daaScoreSize + externalapi.DomainHashSize
+
+	transactionEntryValueSize = 2 * externalapi.DomainHashSize
+)
+
+// serializeTransactionEntryKey serializes the accepting DAA score and the transaction ID
+// of the given entry. The DAA score is inverted and big-endian, so that iterating over
+// the keys in lexicographical order yields the newest transactions first.
+func serializeTransactionEntryKey(entry *TransactionEntry) []byte {
+	serializedKey := make([]byte, transactionEntryKeySize)
+	binary.BigEndian.PutUint64(serializedKey[:daaScoreSize], math.MaxUint64-entry.AcceptingDAAScore)
+	copy(serializedKey[daaScoreSize:], entry.TransactionID.ByteSlice())
+	return serializedKey
+}
+
+func serializeTransactionEntryValue(entry *TransactionEntry) []byte {
+	serializedValue := make([]byte, transactionEntryValueSize)
+	copy(serializedValue[:externalapi.DomainHashSize], entry.BlockHash.ByteSlice())
+	copy(serializedValue[externalapi.DomainHashSize:], entry.AcceptingBlockHash.ByteSlice())
+	return serializedValue
+}
+
+func deserializeTransactionEntry(serializedKey []byte, serializedValue []byte) (*TransactionEntry, error) {
+	if len(serializedKey) != transactionEntryKeySize {
+		return nil, errors.Errorf("unexpected transaction entry key size %d", len(serializedKey))
+	}
+	if len(serializedValue) != transactionEntryValueSize {
+		return nil, errors.Errorf("unexpected transaction entry value size %d", len(serializedValue))
+	}
+
+	transactionID, err := externalapi.NewDomainTransactionIDFromByteSlice(serializedKey[daaScoreSize:])
+	if err != nil {
+		return nil, err
+	}
+	blockHash, err := externalapi.NewDomainHashFromByteSlice(serializedValue[:externalapi.DomainHashSize])
+	if err != nil {
+		return nil, err
+	}
+	acceptingBlockHash, err := externalapi.NewDomainHashFromByteSlice(serializedValue[externalapi.DomainHashSize:])
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransactionEntry{
+		TransactionID:      transactionID,
+		BlockHash:          blockHash,
+		AcceptingBlockHash: acceptingBlockHash,
+		AcceptingDAAScore:  math.MaxUint64 - binary.BigEndian.Uint64(serializedKey[:daaScoreSize]),
+	}, nil
+}
+
+// serializeIndexedSubnetworks serializes the subnetworks of the given filter
+// one after the other. A filter that passes every subnetwork is serialized
+// to an empty slice.
+func serializeIndexedSubnetworks(indexedSubnetworks subnetworks.Filter) []byte {
+	subnetworkIDs := indexedSubnetworks.SubnetworkIDs()
+	serializedSubnetworks := make([]byte, 0, len(subnetworkIDs)*externalapi.DomainSubnetworkIDSize)
+	for _, subnetworkID := range subnetworkIDs {
+		serializedSubnetworks = append(serializedSubnetworks, subnetworkID[:]...)
+	}
+	return serializedSubnetworks
+}
+
+func deserializeIndexedSubnetworks(serializedSubnetworks []byte) (subnetworks.Filter, error) {
+	if len(serializedSubnetworks)%externalapi.DomainSubnetworkIDSize != 0 {
+		return nil, errors.Errorf("unexpected indexed subnetworks size %d", len(serializedSubnetworks))
+	}
+	subnetworkIDs := make([]*externalapi.DomainSubnetworkID, 0,
+		len(serializedSubnetworks)/externalapi.DomainSubnetworkIDSize)
+	for start := 0; start < len(serializedSubnetworks); start += externalapi.DomainSubnetworkIDSize {
+		subnetworkID, err := subnetworks.FromBytes(
+			serializedSubnetworks[start : start+externalapi.DomainSubnetworkIDSize])
+		if err != nil {
+			return nil, err
+		}
+		subnetworkIDs = append(subnetworkIDs, subnetworkID)
+	}
+	return subnetworks.NewFilter(subnetworkIDs), nil
+}
@@ -0,0 +1,150 @@
+package addressindex
+
+import (
+	"encoding/binary"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
+	"github.com/kaspanet/kaspad/domain/indexes"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+var namespace = indexes.NewNamespace(indexes.AddressIndexName)
+var addressIndexBucket = namespace.Bucket([]byte("transactions"))
+var tipKey = namespace.TipKey()
+var indexedSubnetworksKey = namespace.Key([]byte("indexed-subnetworks"))
+
+// ErrInvalidCursor indicates that a cursor that was not returned by
+// Transactions was given to it
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+type addressIndexStore struct {
+	database database.Database
+}
+
+func newAddressIndexStore(database database.Database) *addressIndexStore {
+	return &addressIndexStore{
+		database: database,
+	}
+}
+
+func (ais *addressIndexStore) add(dataAccessor database.DataAccessor,
+	scriptPublicKey *externalapi.ScriptPublicKey, entry *TransactionEntry) error {
+
+	key := ais.bucketForScriptPublicKey(scriptPublicKey).Key(serializeTransactionEntryKey(entry))
+	return dataAccessor.Put(key, serializeTransactionEntryValue(entry))
+}
+
+func (ais *addressIndexStore) remove(dataAccessor database.DataAccessor,
+	scriptPublicKey *externalapi.ScriptPublicKey, entry *TransactionEntry) error {
+
+	key := ais.bucketForScriptPublicKey(scriptPublicKey).Key(serializeTransactionEntryKey(entry))
+	return dataAccessor.Delete(key)
+}
+
+func (ais *addressIndexStore) updateTip(dataAccessor database.DataAccessor, tip *externalapi.DomainHash) error {
+	return dataAccessor.Put(tipKey, tip.ByteSlice())
+}
+
+func (ais *addressIndexStore) getTip() (*externalapi.DomainHash, error) {
+	serializedTip, err := ais.database.Get(tipKey)
+	if err != nil {
+		return nil, err
+	}
+	return externalapi.NewDomainHashFromByteSlice(serializedTip)
+}
+
+func (ais *addressIndexStore) updateIndexedSubnetworks(dataAccessor database.DataAccessor,
+	indexedSubnetworks subnetworks.Filter) error {
+
+	return dataAccessor.Put(indexedSubnetworksKey, serializeIndexedSubnetworks(indexedSubnetworks))
+}
+
+// getIndexedSubnetworks returns the subnetworks whose transactions the stored
+// index holds. Indexes that were built before the subnetworks were recorded
+// hold the transactions of every subnetwork.
+func (ais *addressIndexStore) getIndexedSubnetworks() (subnetworks.Filter, error) {
+	serializedSubnetworks, err := ais.database.Get(indexedSubnetworksKey)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return deserializeIndexedSubnetworks(serializedSubnetworks)
+}
+
+func (ais *addressIndexStore) bucketForScriptPublicKey(scriptPublicKey *externalapi.ScriptPublicKey) *database.Bucket {
+	var scriptPublicKeyBytes = make([]byte, 2+len(scriptPublicKey.Script)) // uint16
+	binary.LittleEndian.PutUint16(scriptPublicKeyBytes[:2], scriptPublicKey.Version)
+	copy(scriptPublicKeyBytes[2:], scriptPublicKey.Script)
+	return addressIndexBucket.Bucket(scriptPublicKeyBytes)
+}
+
+// getTransactionEntries returns up to limit entries of the given scriptPublicKey, starting
+// after cursor, or from the first entry if cursor is nil. The returned cursor is nil if
+// there are no more entries.
+func (ais *addressIndexStore) getTransactionEntries(dataReader database.DataReader,
+	scriptPublicKey *externalapi.ScriptPublicKey, cursor []byte, limit int) ([]*TransactionEntry, []byte, error) {
+
+	if cursor != nil && len(cursor) != transactionEntryKeySize {
+		return nil, nil, errors.Wrapf(ErrInvalidCursor, "unexpected cursor size %d", len(cursor))
+	}
+
+	bucket := ais.bucketForScriptPublicKey(scriptPublicKey)
+	dbCursor, err := dataReader.Cursor(bucket)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer dbCursor.Close()
+
+	hasNext := dbCursor.First()
+	if cursor != nil {
+		// Seek moves to the first key that is greater than or equal to the cursor.
+		// The cursor itself was already returned, so it is skipped if it still exists
+		err := dbCursor.Seek(bucket.Key(cursor))
+		if err == nil {
+			hasNext = dbCursor.Next()
+		} else if database.IsNotFoundError(err) {
+			_, err := dbCursor.Key()
+			hasNext = err == nil
+		} else {
+			return nil, nil, err
+		}
+	}
+
+	entries := make([]*TransactionEntry, 0, limit)
+	for ; hasNext; hasNext = dbCursor.Next() {
+		key, err := dbCursor.Key()
+		if err != nil {
+			return nil, nil, err
+		}
+		// The bucket of a scriptPublicKey may be a prefix of the bucket of a
+		// longer scriptPublicKey, whose keys are longer
+		if len(key.Suffix()) != transactionEntryKeySize {
+			continue
+		}
+		if len(entries) == limit {
+			lastEntryKey := serializeTransactionEntryKey(entries[len(entries)-1])
+			return entries, lastEntryKey, nil
+		}
+		value, err := dbCursor.Value()
+		if err != nil {
+			return nil, nil, err
+		}
+		entry, err := deserializeTransactionEntry(key.Suffix(), value)
+		if err != nil {
+			return nil, nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil, nil
+}
+
+func (ais *addressIndexStore) deleteAll() error {
+	// The namespace deletes the tip first, so if anything goes wrong, the address
+	// index will be reset again on the next start.
+	_, err := namespace.Drop(ais.database)
+	return err
+}
@@ -0,0 +1,127 @@
+package addressindex
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/pkg/errors"
+)
+
+func TestGetTransactionEntries(t *testing.T) {
+	database, err := ldb.NewLevelDB(t.TempDir(), 8)
+	if err != nil {
+		t.Fatalf("Could not create a database: %s", err)
+	}
+	defer database.Close()
+	store := newAddressIndexStore(database)
+
+	scriptPublicKey := &externalapi.ScriptPublicKey{Script: []byte{1, 2, 3}, Version: 0}
+	otherScriptPublicKey := &externalapi.ScriptPublicKey{Script: []byte{1, 2, 4}, Version: 0}
+
+	const entryAmount = 10
+	for i := 0; i < entryAmount; i++ {
+		entry := &TransactionEntry{
+			TransactionID:      externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{byte(i)}),
+			BlockHash:          externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{byte(i), 1}),
+			AcceptingBlockHash: externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{byte(i), 2}),
+			AcceptingDAAScore:  uint64(i),
+		}
+		err := store.add(database, scriptPublicKey, entry)
+		if err != nil {
+			t.Fatalf("add: %s", err)
+		}
+		err = store.add(database, otherScriptPublicKey, entry)
+		if err != nil {
+			t.Fatalf("add: %s", err)
+		}
+	}
+
+	// Remove one entry from a single scriptPublicKey
+	removedEntry := &TransactionEntry{
+		TransactionID:     externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{5}),
+		AcceptingDAAScore: 5,
+	}
+	err = store.remove(database, scriptPublicKey, removedEntry)
+	if err != nil {
+		t.Fatalf("remove: %s", err)
+	}
+
+	// Page through the entries and make sure they're returned newest first
+	const limit = 4
+	var entries []*TransactionEntry
+	var cursor []byte
+	for {
+		page, nextCursor, err := store.getTransactionEntries(store.database, scriptPublicKey, cursor, limit)
+		if err != nil {
+			t.Fatalf("getTransactionEntries: %s", err)
+		}
+		if len(page) > limit {
+			t.Fatalf("Got %d entries, which is more than the limit %d", len(page), limit)
+		}
+		entries = append(entries, page...)
+		if nextCursor == nil {
+			break
+		}
+		cursor = nextCursor
+	}
+
+	expectedDAAScores := []uint64{9, 8, 7, 6, 4, 3, 2, 1, 0}
+	if len(entries) != len(expectedDAAScores) {
+		t.Fatalf("Unexpected amount of entries. Want: %d, got: %d", len(expectedDAAScores), len(entries))
+	}
+	for i, entry := range entries {
+		if entry.AcceptingDAAScore != expectedDAAScores[i] {
+			t.Fatalf("Unexpected DAA score of entry %d. Want: %d, got: %d",
+				i, expectedDAAScores[i], entry.AcceptingDAAScore)
+		}
+		expectedBlockHash := externalapi.NewDomainHashFromByteArray(
+			&[externalapi.DomainHashSize]byte{byte(entry.AcceptingDAAScore), 1})
+		if !entry.BlockHash.Equal(expectedBlockHash) {
+			t.Fatalf("Unexpected block hash of entry %d. Want: %s, got: %s", i, expectedBlockHash, entry.BlockHash)
+		}
+	}
+
+	_, _, err = store.getTransactionEntries(store.database, scriptPublicKey, []byte{1, 2, 3}, limit)
+	if !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("Unexpected error for an invalid cursor: %v", err)
+	}
+}
+
+func TestIndexedSubnetworks(t *testing.T) {
+	database, err := ldb.NewLevelDB(t.TempDir(), 8)
+	if err != nil {
+		t.Fatalf("Could not create a database: %s", err)
+	}
+	defer database.Close()
+	store := newAddressIndexStore(database)
+
+	// Indexes that didn't record their subnetworks hold all of them
+	indexedSubnetworks, err := store.getIndexedSubnetworks()
+	if err != nil {
+		t.Fatalf("getIndexedSubnetworks: %s", err)
+	}
+	if indexedSubnetworks != nil {
+		t.Fatalf("Expected all subnetworks to be indexed, but got %s", indexedSubnetworks)
+	}
+
+	filters := []subnetworks.Filter{
+		subnetworks.NewFilter([]*externalapi.DomainSubnetworkID{{5}, {3, 4}}),
+		subnetworks.NewFilter([]*externalapi.DomainSubnetworkID{&subnetworks.SubnetworkIDNative}),
+		nil,
+	}
+	for _, filter := range filters {
+		err := store.updateIndexedSubnetworks(database, filter)
+		if err != nil {
+			t.Fatalf("updateIndexedSubnetworks: %s", err)
+		}
+		indexedSubnetworks, err := store.getIndexedSubnetworks()
+		if err != nil {
+			t.Fatalf("getIndexedSubnetworks: %s", err)
+		}
+		if !indexedSubnetworks.Equal(filter) || (indexedSubnetworks == nil) != (filter == nil) {
+			t.Fatalf("Expected the indexed subnetworks %s, but got %s", filter, indexedSubnetworks)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package blockexport
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kaspanet/kaspad/domain/consensus/database/serialization"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/merkle"
+	"github.com/pkg/errors"
+)
+
+// fileMagic identifies block export files
+var fileMagic = [4]byte{'K', 'B', 'L', 'K'}
+
+const fileVersion uint16 = 1
+
+// hashesChunkSize is the amount of block hashes that are read from
+// consensus at a time. It MUST be >= MergeSetSizeLimit + 1
+const hashesChunkSize = 1 << 10
+
+// maxSerializedBlockSize and maxParentIndexCount protect against allocating
+// huge buffers when reading a corrupt export file
+const (
+	maxSerializedBlockSize = 1 << 24
+	maxParentIndexCount    = 1 << 10
+)
+
+// File describes a file that holds the blocks of a contiguous region of
+// the DAG: the blocks in the past of HighHash, including HighHash, that
+// are not in the past of LowHash. The blocks are written in topological
+// order, and every block is written along with the indices in the file of
+// its parents that are in the region, such that an importer can tell
+// which blocks depend on which without looking them up.
+type File struct {
+	FilePath    string
+	GenesisHash *externalapi.DomainHash
+	LowHash     *externalapi.DomainHash
+	HighHash    *externalapi.DomainHash
+	BlockCount  uint64
+}
+
+// record is a single block in an export file
+type record struct {
+	hash            *externalapi.DomainHash
+	parentIndices   []uint32
+	serializedBlock []byte
+}
+
+// Export writes the blocks between lowHash and highHash of the given
+// consensus to a new file at filePath. The blocks are read and written in
+// chunks, so the region is never held in memory as a whole. The blocks
+// must have bodies, so the region can't be below the pruning point.
+func Export(consensus externalapi.Consensus, genesisHash *externalapi.DomainHash,
+	lowHash *externalapi.DomainHash, highHash *externalapi.DomainHash, filePath string) (*File, error) {
+
+	isLowSelectedAncestorOfHigh, err := consensus.IsInSelectedParentChainOf(lowHash, highHash)
+	if err != nil {
+		return nil, err
+	}
+	if !isLowSelectedAncestorOfHigh {
+		return nil, errors.Errorf("%s is not in the selected parent chain of %s", lowHash, highHash)
+	}
+	export := &File{
+		FilePath:    filePath,
+		GenesisHash: genesisHash,
+		LowHash:     lowHash,
+		HighHash:    highHash,
+	}
+
+	// Write to a temporary file so that a failed export never leaves a
+	// partial file behind
+	temporaryFilePath := filePath + ".tmp"
+	file, err := os.OpenFile(temporaryFilePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	isSuccessful := false
+	defer func() {
+		if !isSuccessful {
+			file.Close()
+			os.Remove(temporaryFilePath)
+		}
+	}()
+
+	writer := bufio.NewWriter(file)
+	err = writeHeader(writer, export)
+	if err != nil {
+		return nil, err
+	}
+	blockIndices := make(map[externalapi.DomainHash]uint32)
+	for currentLowHash := lowHash; !currentLowHash.Equal(highHash); {
+		blockHashes, actualHighHash, err := consensus.GetHashesBetween(currentLowHash, highHash, hashesChunkSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, blockHash := range blockHashes {
+			block, found, err := consensus.GetBlock(blockHash)
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				return nil, errors.Errorf("the body of block %s is missing", blockHash)
+			}
+			serializedBlock, err := proto.Marshal(serialization.DomainBlockToDbBlock(block))
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			var parentIndices []uint32
+			for _, parentHash := range block.Header.DirectParents() {
+				parentIndex, ok := blockIndices[*parentHash]
+				if ok {
+					parentIndices = append(parentIndices, parentIndex)
+				}
+			}
+			err = writeRecord(writer, &record{
+				hash:            blockHash,
+				parentIndices:   parentIndices,
+				serializedBlock: serializedBlock,
+			})
+			if err != nil {
+				return nil, err
+			}
+			blockIndices[*blockHash] = uint32(export.BlockCount)
+			export.BlockCount++
+		}
+		currentLowHash = actualHighHash
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	err = file.Sync()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	err = file.Close()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	// Link rather than rename, so that an existing file at filePath is never
+	// overwritten
+	err = os.Link(temporaryFilePath, filePath)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	isSuccessful = true
+	err = os.Remove(temporaryFilePath)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return export, nil
+}
+
+func writeHeader(writer io.Writer, export *File) error {
+	_, err := writer.Write(fileMagic[:])
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	err = binary.Write(writer, binary.LittleEndian, fileVersion)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for _, hash := range []*externalapi.DomainHash{export.GenesisHash, export.LowHash, export.HighHash} {
+		_, err = writer.Write(hash.ByteSlice())
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+func readHeader(reader io.Reader) (*File, error) {
+	var magic [4]byte
+	_, err := io.ReadFull(reader, magic[:])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if magic != fileMagic {
+		return nil, errors.New("not a block export file")
+	}
+	var version uint16
+	err = binary.Read(reader, binary.LittleEndian, &version)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if version != fileVersion {
+		return nil, errors.Errorf("unsupported block export version %d", version)
+	}
+	hashes := make([]byte, 3*externalapi.DomainHashSize)
+	_, err = io.ReadFull(reader, hashes)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	export := &File{}
+	for i, hash := range []**externalapi.DomainHash{&export.GenesisHash, &export.LowHash, &export.HighHash} {
+		*hash, err = externalapi.NewDomainHashFromByteSlice(
+			hashes[i*externalapi.DomainHashSize : (i+1)*externalapi.DomainHashSize])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return export, nil
+}
+
+func writeRecord(writer io.Writer, record *record) error {
+	_, err := writer.Write(record.hash.ByteSlice())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	err = binary.Write(writer, binary.LittleEndian, uint32(len(record.parentIndices)))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	err = binary.Write(writer, binary.LittleEndian, record.parentIndices)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	err = binary.Write(writer, binary.LittleEndian, uint32(len(record.serializedBlock)))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	_, err = writer.Write(record.serializedBlock)
+	return errors.WithStack(err)
+}
+
+// readRecord reads the record at the given index of the file. It returns
+// io.EOF if there are no more records to read
+func readRecord(reader io.Reader, index uint32) (*record, error) {
+	hashBytes := make([]byte, externalapi.DomainHashSize)
+	_, err := io.ReadFull(reader, hashBytes)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, errors.WithStack(io.ErrUnexpectedEOF)
+	}
+	hash, err := externalapi.NewDomainHashFromByteSlice(hashBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var parentIndexCount uint32
+	err = binary.Read(reader, binary.LittleEndian, &parentIndexCount)
+	if err != nil {
+		return nil, errors.WithStack(io.ErrUnexpectedEOF)
+	}
+	if parentIndexCount > maxParentIndexCount {
+		return nil, errors.Errorf("block %s has too many parents (%d)", hash, parentIndexCount)
+	}
+	parentIndices := make([]uint32, parentIndexCount)
+	err = binary.Read(reader, binary.LittleEndian, parentIndices)
+	if err != nil {
+		return nil, errors.WithStack(io.ErrUnexpectedEOF)
+	}
+	for _, parentIndex := range parentIndices {
+		if parentIndex >= index {
+			return nil, errors.Errorf("block %s at index %d has a parent at index %d, "+
+				"so the blocks are not in topological order", hash, index, parentIndex)
+		}
+	}
+
+	var serializedBlockLength uint32
+	err = binary.Read(reader, binary.LittleEndian, &serializedBlockLength)
+	if err != nil {
+		return nil, errors.WithStack(io.ErrUnexpectedEOF)
+	}
+	if serializedBlockLength > maxSerializedBlockSize {
+		return nil, errors.Errorf("block %s of %d bytes is too big", hash, serializedBlockLength)
+	}
+	serializedBlock := make([]byte, serializedBlockLength)
+	_, err = io.ReadFull(reader, serializedBlock)
+	if err != nil {
+		// The file ends in the middle of a block
+		return nil, errors.WithStack(io.ErrUnexpectedEOF)
+	}
+	return &record{hash: hash, parentIndices: parentIndices, serializedBlock: serializedBlock}, nil
+}
+
+// decodeRecord deserializes the block of the given record
+func decodeRecord(record *record) (*externalapi.DomainBlock, error) {
+	dbBlock := &serialization.DbBlock{}
+	err := proto.Unmarshal(record.serializedBlock, dbBlock)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error deserializing block %s", record.hash)
+	}
+	block, err := serialization.DbBlockToDomainBlock(dbBlock)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error deserializing block %s", record.hash)
+	}
+	return block, nil
+}
+
+// prevalidateBlock runs the checks of the given block that don't depend on
+// the DAG: that its hash is the one in its record, and that its transactions
+// match its merkle root. It also caches the IDs and the masses of its
+// transactions, so that they're not computed while the block is inserted
+func prevalidateBlock(consensus externalapi.Consensus, record *record, block *externalapi.DomainBlock) error {
+	blockHash := consensushashing.BlockHash(block)
+	if !blockHash.Equal(record.hash) {
+		return errors.Errorf("the block recorded as %s hashes to %s", record.hash, blockHash)
+	}
+	merkleRoot := merkle.CalculateHashMerkleRoot(block.Transactions)
+	if !merkleRoot.Equal(block.Header.HashMerkleRoot()) {
+		return errors.Errorf("the transactions of block %s hash to %s instead of its merkle root %s",
+			record.hash, merkleRoot, block.Header.HashMerkleRoot())
+	}
+	for _, transaction := range block.Transactions {
+		consensushashing.TransactionID(transaction)
+		consensus.PopulateMass(transaction)
+	}
+	return nil
+}
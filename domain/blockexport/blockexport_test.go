@@ -0,0 +1,156 @@
+package blockexport
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/testutils"
+)
+
+func TestExportImport(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		factory := consensus.NewFactory()
+		sourceConsensus, teardown, err := factory.NewTestConsensus(consensusConfig, "TestExportImportSource")
+		if err != nil {
+			t.Fatalf("Error setting up consensus: %+v", err)
+		}
+		defer teardown(false)
+
+		// Build a chain with a side branch that's merged back into it, so
+		// that the export has blocks that are not on the selected chain
+		tipHash := consensusConfig.GenesisHash
+		var sideHash *externalapi.DomainHash
+		for i := 0; i < 10; i++ {
+			parentHashes := []*externalapi.DomainHash{tipHash}
+			if i == 5 {
+				parentHashes = append(parentHashes, sideHash)
+			}
+			newTipHash, _, err := sourceConsensus.AddBlock(parentHashes, nil, nil)
+			if err != nil {
+				t.Fatalf("AddBlock: %+v", err)
+			}
+			if i == 2 {
+				sideHash, _, err = sourceConsensus.AddBlock([]*externalapi.DomainHash{tipHash}, nil, nil)
+				if err != nil {
+					t.Fatalf("AddBlock: %+v", err)
+				}
+			}
+			tipHash = newTipHash
+		}
+
+		filePath := filepath.Join(t.TempDir(), "blocks")
+		exportedFile, err := Export(sourceConsensus, consensusConfig.GenesisHash,
+			consensusConfig.GenesisHash, tipHash, filePath)
+		if err != nil {
+			t.Fatalf("Export: %+v", err)
+		}
+		if exportedFile.BlockCount != 11 {
+			t.Fatalf("Unexpected amount of exported blocks. Want: %d, got: %d", 11, exportedFile.BlockCount)
+		}
+		_, err = Export(sourceConsensus, consensusConfig.GenesisHash, consensusConfig.GenesisHash, tipHash, filePath)
+		if err == nil {
+			t.Fatalf("Export unexpectedly overwrote an existing file")
+		}
+
+		destinationConsensus, teardown, err := factory.NewTestConsensus(consensusConfig, "TestExportImportDestination")
+		if err != nil {
+			t.Fatalf("Error setting up consensus: %+v", err)
+		}
+		defer teardown(false)
+
+		_, _, err = Import(destinationConsensus, externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1}),
+			filePath, 4, nil)
+		if err == nil {
+			t.Fatalf("Import unexpectedly accepted the blocks of another network")
+		}
+
+		importedFile, insertedBlockCount, err := Import(destinationConsensus, consensusConfig.GenesisHash, filePath, 4, nil)
+		if err != nil {
+			t.Fatalf("Import: %+v", err)
+		}
+		if importedFile.BlockCount != exportedFile.BlockCount || insertedBlockCount != exportedFile.BlockCount {
+			t.Fatalf("Unexpected amount of imported blocks. Want: %d, got: %d read and %d inserted",
+				exportedFile.BlockCount, importedFile.BlockCount, insertedBlockCount)
+		}
+		virtualSelectedParent, err := destinationConsensus.GetVirtualSelectedParent()
+		if err != nil {
+			t.Fatalf("GetVirtualSelectedParent: %+v", err)
+		}
+		if !virtualSelectedParent.Equal(tipHash) {
+			t.Fatalf("Unexpected virtual selected parent. Want: %s, got: %s", tipHash, virtualSelectedParent)
+		}
+
+		// Importing the same blocks again skips all of them
+		_, insertedBlockCount, err = Import(destinationConsensus, consensusConfig.GenesisHash, filePath, 4, nil)
+		if err != nil {
+			t.Fatalf("Import: %+v", err)
+		}
+		if insertedBlockCount != 0 {
+			t.Fatalf("Import inserted %d blocks that already existed", insertedBlockCount)
+		}
+
+		// A corrupt block fails the import
+		fileBytes, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			t.Fatalf("ReadFile: %s", err)
+		}
+		fileBytes[len(fileBytes)-1] ^= 0xff
+		corruptFilePath := filepath.Join(t.TempDir(), "corrupt")
+		err = ioutil.WriteFile(corruptFilePath, fileBytes, 0600)
+		if err != nil {
+			t.Fatalf("WriteFile: %s", err)
+		}
+		_, _, err = Import(destinationConsensus, consensusConfig.GenesisHash, corruptFilePath, 4, nil)
+		if err == nil {
+			t.Fatalf("Import unexpectedly accepted a corrupt file")
+		}
+	})
+}
+
+func TestPrevalidateBlock(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		factory := consensus.NewFactory()
+		testConsensus, teardown, err := factory.NewTestConsensus(consensusConfig, "TestPrevalidateBlock")
+		if err != nil {
+			t.Fatalf("Error setting up consensus: %+v", err)
+		}
+		defer teardown(false)
+
+		blockHash, _, err := testConsensus.AddBlock([]*externalapi.DomainHash{consensusConfig.GenesisHash}, nil, nil)
+		if err != nil {
+			t.Fatalf("AddBlock: %+v", err)
+		}
+		block, _, err := testConsensus.GetBlock(blockHash)
+		if err != nil {
+			t.Fatalf("GetBlock: %+v", err)
+		}
+		for _, transaction := range block.Transactions {
+			transaction.ID = nil
+		}
+
+		err = prevalidateBlock(testConsensus, &record{hash: blockHash}, block)
+		if err != nil {
+			t.Fatalf("prevalidateBlock: %+v", err)
+		}
+		for i, transaction := range block.Transactions {
+			if transaction.ID == nil {
+				t.Fatalf("The ID of transaction %d wasn't cached", i)
+			}
+		}
+
+		err = prevalidateBlock(testConsensus, &record{hash: consensusConfig.GenesisHash}, block)
+		if err == nil {
+			t.Fatalf("prevalidateBlock unexpectedly accepted a block recorded under another hash")
+		}
+
+		block.Transactions[0].Payload = append(block.Transactions[0].Payload, 0)
+		block.Transactions[0].ID = nil
+		err = prevalidateBlock(testConsensus, &record{hash: blockHash}, block)
+		if err == nil {
+			t.Fatalf("prevalidateBlock unexpectedly accepted transactions that don't match the merkle root")
+		}
+	})
+}
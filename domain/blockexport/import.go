@@ -0,0 +1,191 @@
+package blockexport
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+// importProgressInterval is the amount of blocks between the
+// calls to the progress callback of Import
+const importProgressInterval = 10_000
+
+// importJob is a single block on its way through the stages of the import
+type importJob struct {
+	record *record
+	block  *externalapi.DomainBlock
+	err    error
+
+	// done is closed once the block is decoded and prevalidated,
+	// or once either failed
+	done chan struct{}
+}
+
+// ImportProgress is the progress of an import
+type ImportProgress struct {
+	ReadBlockCount     uint64
+	InsertedBlockCount uint64
+}
+
+// Import reads the block export file at filePath, and inserts its blocks
+// into the given consensus. The import is a pipeline of stages that run
+// concurrently:
+//  1. The records are read from the file in order.
+//  2. workerCount goroutines deserialize the blocks.
+//  3. workerCount goroutines prevalidate the blocks, by running the checks
+//     that don't depend on the DAG and caching what inserting them would
+//     otherwise compute.
+//  4. The blocks are inserted in the order of the file. Blocks that already
+//     have bodies are skipped.
+//
+// The virtual is resolved once after all the blocks are inserted, rather
+// than after each of them. progressReportCallback, if it isn't nil, is
+// called every importProgressInterval read blocks. Import returns the file
+// and the amount of inserted blocks.
+func Import(consensus externalapi.Consensus, genesisHash *externalapi.DomainHash, filePath string,
+	workerCount int, progressReportCallback func(*ImportProgress)) (importedFile *File, insertedBlockCount uint64, err error) {
+
+	if workerCount < 1 {
+		return nil, 0, errors.Errorf("workerCount must be positive, but got %d", workerCount)
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, 0, errors.WithStack(err)
+	}
+	reader := bufio.NewReader(file)
+	importedFile, err = readHeader(reader)
+	if err != nil {
+		file.Close()
+		return nil, 0, errors.Wrapf(err, "error reading the header of %s", filePath)
+	}
+	importedFile.FilePath = filePath
+	if !importedFile.GenesisHash.Equal(genesisHash) {
+		file.Close()
+		return nil, 0, errors.Errorf("%s was exported from a network with genesis %s, "+
+			"but this network's genesis is %s", filePath, importedFile.GenesisHash, genesisHash)
+	}
+	lowBlockInfo, err := consensus.GetBlockInfo(importedFile.LowHash)
+	if err != nil {
+		file.Close()
+		return nil, 0, err
+	}
+	if !lowBlockInfo.HasBody() {
+		file.Close()
+		return nil, 0, errors.Errorf("the blocks of %s are above block %s, which is missing",
+			filePath, importedFile.LowHash)
+	}
+
+	quit := make(chan struct{})
+	defer close(quit)
+	jobs := startImportPipeline(consensus, file, reader, workerCount, quit)
+
+	for job := range jobs {
+		<-job.done
+		if job.err != nil {
+			return nil, 0, errors.Wrapf(job.err, "error reading %s", filePath)
+		}
+		importedFile.BlockCount++
+
+		blockInfo, err := consensus.GetBlockInfo(job.record.hash)
+		if err != nil {
+			return nil, 0, err
+		}
+		if !blockInfo.HasBody() {
+			err = consensus.ValidateAndInsertBlock(job.block, false)
+			if err != nil {
+				return nil, 0, errors.Wrapf(err, "error inserting block %s", job.record.hash)
+			}
+			insertedBlockCount++
+		}
+
+		if progressReportCallback != nil && importedFile.BlockCount%importProgressInterval == 0 {
+			progressReportCallback(&ImportProgress{
+				ReadBlockCount:     importedFile.BlockCount,
+				InsertedBlockCount: insertedBlockCount,
+			})
+		}
+	}
+	err = consensus.ResolveVirtual(nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	return importedFile, insertedBlockCount, nil
+}
+
+// startImportPipeline starts the stages of the import that precede the
+// insertion of the blocks, and returns a channel of their jobs in the order
+// of the file. A job is done once its block is decoded and prevalidated.
+// No more than a few blocks per worker are held in memory at once, since
+// the file is read only as fast as the jobs are taken from the returned
+// channel. The file is closed once all the records are read, or once quit
+// is closed.
+func startImportPipeline(consensus externalapi.Consensus, file *os.File, reader io.Reader,
+	workerCount int, quit <-chan struct{}) <-chan *importJob {
+
+	orderedJobs := make(chan *importJob, 4*workerCount)
+	decodeJobs := make(chan *importJob, workerCount)
+	prevalidateJobs := make(chan *importJob, workerCount)
+
+	decodeWaitGroup := sync.WaitGroup{}
+	decodeWaitGroup.Add(workerCount)
+	for i := 0; i < workerCount; i++ {
+		go func() {
+			defer decodeWaitGroup.Done()
+			for job := range decodeJobs {
+				job.block, job.err = decodeRecord(job.record)
+				if job.err != nil {
+					close(job.done)
+					continue
+				}
+				prevalidateJobs <- job
+			}
+		}()
+	}
+	go func() {
+		decodeWaitGroup.Wait()
+		close(prevalidateJobs)
+	}()
+
+	for i := 0; i < workerCount; i++ {
+		go func() {
+			for job := range prevalidateJobs {
+				job.err = prevalidateBlock(consensus, job.record, job.block)
+				close(job.done)
+			}
+		}()
+	}
+
+	go func() {
+		defer file.Close()
+		defer close(orderedJobs)
+		defer close(decodeJobs)
+
+		for index := uint32(0); ; index++ {
+			record, err := readRecord(reader, index)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			job := &importJob{record: record, err: err, done: make(chan struct{})}
+			select {
+			case orderedJobs <- job:
+			case <-quit:
+				return
+			}
+			if err != nil {
+				close(job.done)
+				return
+			}
+			select {
+			case decodeJobs <- job:
+			case <-quit:
+				return
+			}
+		}
+	}()
+
+	return orderedJobs
+}
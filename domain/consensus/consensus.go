@@ -21,6 +21,7 @@ type consensus struct {
 
 	genesisBlock *externalapi.DomainBlock
 	genesisHash  *externalapi.DomainHash
+	clock        mstime.Clock
 
 	expectedDAAWindowDurationInMilliseconds int64
 
@@ -171,6 +172,41 @@ func (s *consensus) BuildBlock(coinbaseData *externalapi.DomainCoinbaseData,
 	return block, err
 }
 
+// BuildBlockWithParents builds a block over the given parents rather than over the
+// virtual, with the given coinbaseData and transactions, and returns the block
+// together with its past UTXO-diff from the virtual
+func (s *consensus) BuildBlockWithParents(parentHashes []*externalapi.DomainHash,
+	coinbaseData *externalapi.DomainCoinbaseData, transactions []*externalapi.DomainTransaction) (
+	*externalapi.DomainBlock, externalapi.UTXODiff, error) {
+
+	// Require write lock because BuildBlockWithParents stages temporary data
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if len(parentHashes) == 0 {
+		return nil, nil, errors.New("a block must have at least one parent")
+	}
+	stagingArea := model.NewStagingArea()
+	for _, parentHash := range parentHashes {
+		exists, err := s.blockStatusStore.Exists(s.databaseContext, stagingArea, parentHash)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !exists {
+			return nil, nil, errors.Errorf("parent %s does not exist", parentHash)
+		}
+		status, err := s.blockStatusStore.Get(s.databaseContext, stagingArea, parentHash)
+		if err != nil {
+			return nil, nil, err
+		}
+		if status == externalapi.StatusInvalid || status == externalapi.StatusHeaderOnly {
+			return nil, nil, errors.Errorf("parent %s has status %s", parentHash, status)
+		}
+	}
+
+	return s.blockBuilder.BuildBlockWithParents(parentHashes, coinbaseData, transactions)
+}
+
 // BuildBlockTemplate builds a block over the current state, with the transactions
 // selected by the given transactionSelector plus metadata information related to
 // coinbase rewards and node sync status
@@ -239,6 +275,16 @@ func (s *consensus) ValidateAndInsertBlock(block *externalapi.DomainBlock, updat
 	return s.validateAndInsertBlockWithLock(block, updateVirtual)
 }
 
+// ValidateBlockProposal fully validates the given unmined block against the current
+// state without checking its proof of work and without inserting it
+func (s *consensus) ValidateBlockProposal(block *externalapi.DomainBlock) error {
+	// Require write lock because ValidateBlockProposal stages temporary data
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.blockProcessor.ValidateBlockProposal(block)
+}
+
 func (s *consensus) validateAndInsertBlockWithLock(block *externalapi.DomainBlock, updateVirtual bool) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -695,6 +741,15 @@ func (s *consensus) Tips() ([]*externalapi.DomainHash, error) {
 	return s.consensusStateStore.Tips(stagingArea, s.databaseContext)
 }
 
+func (s *consensus) VirtualFinalityPoint() (*externalapi.DomainHash, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	stagingArea := model.NewStagingArea()
+
+	return s.finalityManager.VirtualFinalityPoint(stagingArea)
+}
+
 func (s *consensus) GetVirtualInfo() (*externalapi.VirtualInfo, error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -1133,7 +1188,7 @@ func (s *consensus) isNearlySyncedNoLock() (bool, error) {
 		return false, err
 	}
 
-	now := mstime.Now().UnixMilliseconds()
+	now := s.clock.Now().UnixMilliseconds()
 	// As a heuristic, we allow the node to mine if he is likely to be within the current DAA window of fully synced nodes.
 	// Such blocks contribute to security by maintaining the current difficulty despite possibly being slightly out of sync.
 	if now-virtualSelectedParentHeader.TimeInMilliseconds() < s.expectedDAAWindowDurationInMilliseconds {
@@ -1146,3 +1201,11 @@ func (s *consensus) isNearlySyncedNoLock() (bool, error) {
 		virtualSelectedParentHeader.TimeInMilliseconds())
 	return false, nil
 }
+
+// CacheStats returns the statistics of the block and block header caches
+func (s *consensus) CacheStats() []*externalapi.CacheStats {
+	return []*externalapi.CacheStats{
+		s.blockStore.CacheStats(),
+		s.blockHeaderStore.CacheStats(),
+	}
+}
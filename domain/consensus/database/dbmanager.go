@@ -6,7 +6,8 @@ import (
 )
 
 type dbManager struct {
-	db database.Database
+	db        database.Database
+	committer *database.BatchCommitter
 }
 
 func (dbw *dbManager) Get(key model.DBKey) ([]byte, error) {
@@ -35,14 +36,16 @@ func (dbw *dbManager) Cursor(bucket model.DBBucket) (model.DBCursor, error) {
 }
 
 func (dbw *dbManager) Begin() (model.DBTransaction, error) {
-	transaction, err := dbw.db.Begin()
+	batch, err := dbw.db.NewBatch()
 	if err != nil {
 		return nil, err
 	}
-	return newDBTransaction(transaction), nil
+	return newDBTransaction(dbw.db, batch, dbw.committer), nil
 }
 
-// New returns wraps the given database as an instance of model.DBManager
-func New(db database.Database) model.DBManager {
-	return &dbManager{db: db}
+// New returns wraps the given database as an instance of model.DBManager.
+// Its transactions are committed as batches, which are synced to disk
+// according to the given policy
+func New(db database.Database, syncPolicy database.BatchSyncPolicy) model.DBManager {
+	return &dbManager{db: db, committer: database.NewBatchCommitter(syncPolicy)}
 }
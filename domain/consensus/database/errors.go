@@ -12,3 +12,12 @@ var ErrNotFound = database.ErrNotFound
 func IsNotFoundError(err error) bool {
 	return database.IsNotFoundError(err)
 }
+
+// ErrCorrupted denotes that the data read for the
+// requested item is corrupted.
+var ErrCorrupted = database.ErrCorrupted
+
+// IsCorruptedError checks whether an error is an ErrCorrupted.
+func IsCorruptedError(err error) bool {
+	return database.IsCorruptedError(err)
+}
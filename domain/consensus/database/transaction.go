@@ -3,22 +3,39 @@ package database
 import (
 	"github.com/kaspanet/kaspad/domain/consensus/model"
 	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
 )
 
+// dbTransaction accumulates its writes in a database batch, which is
+// committed through the batch committer of its dbManager. Like with
+// database transactions, reads are done from the database directly,
+// so writes within the transaction are not available to read from it.
 type dbTransaction struct {
-	transaction database.Transaction
+	db        database.Database
+	batch     database.Batch
+	committer *database.BatchCommitter
+	isClosed  bool
 }
 
 func (d *dbTransaction) Get(key model.DBKey) ([]byte, error) {
-	return d.transaction.Get(dbKeyToDatabaseKey(key))
+	if d.isClosed {
+		return nil, errors.New("cannot get from a closed transaction")
+	}
+	return d.db.Get(dbKeyToDatabaseKey(key))
 }
 
 func (d *dbTransaction) Has(key model.DBKey) (bool, error) {
-	return d.transaction.Has(dbKeyToDatabaseKey(key))
+	if d.isClosed {
+		return false, errors.New("cannot has from a closed transaction")
+	}
+	return d.db.Has(dbKeyToDatabaseKey(key))
 }
 
 func (d *dbTransaction) Cursor(bucket model.DBBucket) (model.DBCursor, error) {
-	cursor, err := d.transaction.Cursor(dbBucketToDatabaseBucket(bucket))
+	if d.isClosed {
+		return nil, errors.New("cannot open a cursor from a closed transaction")
+	}
+	cursor, err := d.db.Cursor(dbBucketToDatabaseBucket(bucket))
 	if err != nil {
 		return nil, err
 	}
@@ -26,25 +43,38 @@ func (d *dbTransaction) Cursor(bucket model.DBBucket) (model.DBCursor, error) {
 }
 
 func (d *dbTransaction) Put(key model.DBKey, value []byte) error {
-	return d.transaction.Put(dbKeyToDatabaseKey(key), value)
+	return d.batch.Put(dbKeyToDatabaseKey(key), value)
 }
 
 func (d *dbTransaction) Delete(key model.DBKey) error {
-	return d.transaction.Delete(dbKeyToDatabaseKey(key))
+	return d.batch.Delete(dbKeyToDatabaseKey(key))
 }
 
 func (d *dbTransaction) Rollback() error {
-	return d.transaction.Rollback()
+	if d.isClosed {
+		return errors.New("cannot rollback a closed transaction")
+	}
+	d.isClosed = true
+	return d.batch.Discard()
 }
 
 func (d *dbTransaction) Commit() error {
-	return d.transaction.Commit()
+	if d.isClosed {
+		return errors.New("cannot commit a closed transaction")
+	}
+	d.isClosed = true
+	return d.committer.Commit(d.batch)
 }
 
 func (d *dbTransaction) RollbackUnlessClosed() error {
-	return d.transaction.RollbackUnlessClosed()
+	if d.isClosed {
+		return nil
+	}
+	return d.Rollback()
 }
 
-func newDBTransaction(transaction database.Transaction) model.DBTransaction {
-	return &dbTransaction{transaction: transaction}
+func newDBTransaction(db database.Database, batch database.Batch,
+	committer *database.BatchCommitter) model.DBTransaction {
+
+	return &dbTransaction{db: db, batch: batch, committer: committer}
 }
@@ -173,6 +173,11 @@ func (bhs *blockHeaderStore) Count(stagingArea *model.StagingArea) uint64 {
 	return bhs.count(stagingShard)
 }
 
+// CacheStats returns the statistics of the block header cache
+func (bhs *blockHeaderStore) CacheStats() *externalapi.CacheStats {
+	return bhs.cache.Stats("headers")
+}
+
 func (bhs *blockHeaderStore) count(stagingShard *blockHeaderStagingShard) uint64 {
 	return bhs.countCached + uint64(len(stagingShard.toAdd)) - uint64(len(stagingShard.toDelete))
 }
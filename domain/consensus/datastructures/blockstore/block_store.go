@@ -2,9 +2,11 @@ package blockstore
 
 import (
 	"github.com/golang/protobuf/proto"
+	"github.com/kaspanet/kaspad/domain/consensus/database"
 	"github.com/kaspanet/kaspad/domain/consensus/database/serialization"
 	"github.com/kaspanet/kaspad/domain/consensus/model"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/lrucache"
 	"github.com/kaspanet/kaspad/util/staging"
 	"github.com/pkg/errors"
@@ -91,7 +93,13 @@ func (bs *blockStore) block(dbContext model.DBReader, stagingShard *blockStaging
 
 	block, err := bs.deserializeBlock(blockBytes)
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrapf(database.ErrCorrupted, "block %s could not be deserialized: %s", blockHash, err)
+	}
+	// The block hash serves as the checksum of the stored header
+	storedBlockHash := consensushashing.BlockHash(block)
+	if !storedBlockHash.Equal(blockHash) {
+		return nil, errors.Wrapf(database.ErrCorrupted, "block %s was stored with the header of block %s",
+			blockHash, storedBlockHash)
 	}
 	bs.cache.Add(blockHash, block)
 	return block.Clone(), nil
@@ -166,6 +174,11 @@ func (bs *blockStore) Count(stagingArea *model.StagingArea) uint64 {
 	return bs.count(stagingShard)
 }
 
+// CacheStats returns the statistics of the block cache
+func (bs *blockStore) CacheStats() *externalapi.CacheStats {
+	return bs.cache.Stats("blocks")
+}
+
 func (bs *blockStore) count(stagingShard *blockStagingShard) uint64 {
 	return bs.countCached + uint64(len(stagingShard.toAdd)) - uint64(len(stagingShard.toDelete))
 }
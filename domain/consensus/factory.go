@@ -12,6 +12,7 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensus/processes/blockparentbuilder"
 	parentssanager "github.com/kaspanet/kaspad/domain/consensus/processes/parentsmanager"
 	"github.com/kaspanet/kaspad/domain/consensus/processes/pruningproofmanager"
+	"github.com/kaspanet/kaspad/util/mstime"
 	"github.com/kaspanet/kaspad/util/staging"
 	"github.com/pkg/errors"
 
@@ -62,6 +63,8 @@ const (
 	defaultTestLeveldbCacheSizeMiB = 8
 	defaultPreallocateCaches       = true
 	defaultTestPreallocateCaches   = false
+	defaultBlockCacheSize          = 200
+	defaultHeaderCacheSize         = 10_000
 )
 
 // Config is the full config required to run consensus
@@ -73,6 +76,28 @@ type Config struct {
 	EnableSanityCheckPruningUTXOSet bool
 
 	SkipAddingGenesis bool
+
+	// BlockCacheSize and HeaderCacheSize are the amount of blocks and block headers kept in
+	// memory after they're read from the database. Zero means the default size is used
+	BlockCacheSize  int
+	HeaderCacheSize int
+
+	// BatchSyncPolicy decides which of the database transactions consensus
+	// commits, such as the ones that connect blocks, are synced to disk. The
+	// zero value leaves syncing to the database's durability
+	BatchSyncPolicy infrastructuredatabase.BatchSyncPolicy
+
+	// Clock is the time that block timestamps are validated and built
+	// against. Nil means the local system time
+	Clock mstime.Clock
+}
+
+// ClockOrSystemClock returns config.Clock, or the local system clock if it's nil
+func (config *Config) ClockOrSystemClock() mstime.Clock {
+	if config.Clock == nil {
+		return mstime.SystemClock
+	}
+	return config.Clock
 }
 
 // Factory instantiates new Consensuses
@@ -114,7 +139,8 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 	consensusEventsChan chan externalapi.ConsensusEvent) (
 	consensusInstance externalapi.Consensus, shouldMigrate bool, err error) {
 
-	dbManager := consensusdatabase.New(db)
+	dbManager := consensusdatabase.New(db, config.BatchSyncPolicy)
+	clock := config.ClockOrSystemClock()
 	prefixBucket := consensusdatabase.MakeBucket(dbPrefix.Serialize())
 
 	pruningWindowSizeForCaches := int(config.PruningDepth())
@@ -134,11 +160,19 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 	mergeDepthRootStore := mergedepthrootstore.New(prefixBucket, 200, preallocateCaches)
 	daaWindowStore := daawindowstore.New(prefixBucket, 10_000, preallocateCaches)
 	acceptanceDataStore := acceptancedatastore.New(prefixBucket, 200, preallocateCaches)
-	blockStore, err := blockstore.New(dbManager, prefixBucket, 200, preallocateCaches)
+	blockCacheSize := config.BlockCacheSize
+	if blockCacheSize == 0 {
+		blockCacheSize = defaultBlockCacheSize
+	}
+	blockStore, err := blockstore.New(dbManager, prefixBucket, blockCacheSize, preallocateCaches)
 	if err != nil {
 		return nil, false, err
 	}
-	blockHeaderStore, err := blockheaderstore.New(dbManager, prefixBucket, 10_000, preallocateCaches)
+	headerCacheSize := config.HeaderCacheSize
+	if headerCacheSize == 0 {
+		headerCacheSize = defaultHeaderCacheSize
+	}
+	blockHeaderStore, err := blockheaderstore.New(dbManager, prefixBucket, headerCacheSize, preallocateCaches)
 	if err != nil {
 		return nil, false, err
 	}
@@ -350,6 +384,7 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 		config.TimestampDeviationTolerance,
 		config.TargetTimePerBlock,
 		config.MaxBlockLevel,
+		clock,
 
 		dbManager,
 		difficultyManager,
@@ -397,6 +432,7 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 	blockBuilder := blockbuilder.New(
 		dbManager,
 		genesisHash,
+		clock,
 
 		difficultyManager,
 		pastMedianTimeManager,
@@ -407,6 +443,7 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 		finalityManager,
 		blockParentBuilder,
 		pruningManager,
+		reachabilityManager,
 
 		acceptanceDataStore,
 		blockRelationStore,
@@ -478,6 +515,7 @@ func (f *factory) NewConsensus(config *Config, db infrastructuredatabase.Databas
 
 		genesisBlock: config.GenesisBlock,
 		genesisHash:  config.GenesisHash,
+		clock:        clock,
 
 		expectedDAAWindowDurationInMilliseconds: config.TargetTimePerBlock.Milliseconds() *
 			int64(config.DifficultyAdjustmentWindowSize),
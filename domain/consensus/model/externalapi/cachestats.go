@@ -0,0 +1,9 @@
+package externalapi
+
+// CacheStats are the statistics of a consensus cache
+type CacheStats struct {
+	Name     string
+	Capacity int
+	Hits     uint64
+	Misses   uint64
+}
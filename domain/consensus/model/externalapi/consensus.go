@@ -5,8 +5,11 @@ type Consensus interface {
 	Init(skipAddingGenesis bool) error
 	BuildBlock(coinbaseData *DomainCoinbaseData, transactions []*DomainTransaction) (*DomainBlock, error)
 	BuildBlockTemplate(coinbaseData *DomainCoinbaseData, transactions []*DomainTransaction) (*DomainBlockTemplate, error)
+	BuildBlockWithParents(parentHashes []*DomainHash, coinbaseData *DomainCoinbaseData,
+		transactions []*DomainTransaction) (*DomainBlock, UTXODiff, error)
 	ValidateAndInsertBlock(block *DomainBlock, updateVirtual bool) error
 	ValidateAndInsertBlockWithTrustedData(block *BlockWithTrustedData, validateUTXO bool) error
+	ValidateBlockProposal(block *DomainBlock) error
 	ValidateTransactionAndPopulateWithConsensusData(transaction *DomainTransaction) error
 	ImportPruningPoints(pruningPoints []BlockHeader) error
 	BuildPruningPointProof() (*PruningPointProof, error)
@@ -38,6 +41,7 @@ type Consensus interface {
 	CreateFullHeadersSelectedChainBlockLocator() (BlockLocator, error)
 	GetSyncInfo() (*SyncInfo, error)
 	Tips() ([]*DomainHash, error)
+	VirtualFinalityPoint() (*DomainHash, error)
 	GetVirtualInfo() (*VirtualInfo, error)
 	GetVirtualDAAScore() (uint64, error)
 	IsValidPruningPoint(blockHash *DomainHash) (bool, error)
@@ -56,4 +60,5 @@ type Consensus interface {
 	IsChainBlock(blockHash *DomainHash) (bool, error)
 	VirtualMergeDepthRoot() (*DomainHash, error)
 	IsNearlySynced() (bool, error)
+	CacheStats() []*CacheStats
 }
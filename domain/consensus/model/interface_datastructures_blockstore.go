@@ -12,5 +12,6 @@ type BlockStore interface {
 	Blocks(dbContext DBReader, stagingArea *StagingArea, blockHashes []*externalapi.DomainHash) ([]*externalapi.DomainBlock, error)
 	Delete(stagingArea *StagingArea, blockHash *externalapi.DomainHash)
 	Count(stagingArea *StagingArea) uint64
+	CacheStats() *externalapi.CacheStats
 	AllBlockHashesIterator(dbContext DBReader) (BlockIterator, error)
 }
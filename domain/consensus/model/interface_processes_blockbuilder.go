@@ -6,4 +6,9 @@ import "github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 type BlockBuilder interface {
 	BuildBlock(coinbaseData *externalapi.DomainCoinbaseData,
 		transactions []*externalapi.DomainTransaction) (block *externalapi.DomainBlock, coinbaseHasRedReward bool, err error)
+
+	// BuildBlockWithParents builds a block with provided parents, coinbaseData and transactions,
+	// and returns the block together with its past UTXO-diff from the virtual.
+	BuildBlockWithParents(parentHashes []*externalapi.DomainHash, coinbaseData *externalapi.DomainCoinbaseData,
+		transactions []*externalapi.DomainTransaction) (*externalapi.DomainBlock, externalapi.UTXODiff, error)
 }
@@ -7,4 +7,5 @@ type BlockProcessor interface {
 	ValidateAndInsertBlock(block *externalapi.DomainBlock, shouldValidateAgainstUTXO bool) (*externalapi.VirtualChangeSet, externalapi.BlockStatus, error)
 	ValidateAndInsertImportedPruningPoint(newPruningPoint *externalapi.DomainHash) error
 	ValidateAndInsertBlockWithTrustedData(block *externalapi.BlockWithTrustedData, validateUTXO bool) (*externalapi.VirtualChangeSet, externalapi.BlockStatus, error)
+	ValidateBlockProposal(block *externalapi.DomainBlock) error
 }
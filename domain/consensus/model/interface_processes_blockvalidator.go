@@ -12,4 +12,5 @@ type BlockValidator interface {
 	ValidateHeaderInContext(stagingArea *StagingArea, blockHash *externalapi.DomainHash, isBlockWithTrustedData bool) error
 	ValidateBodyInContext(stagingArea *StagingArea, blockHash *externalapi.DomainHash, isBlockWithTrustedData bool) error
 	ValidatePruningPointViolationAndProofOfWorkAndDifficulty(stagingArea *StagingArea, blockHash *externalapi.DomainHash, isBlockWithTrustedData bool) error
+	ValidatePruningPointViolationAndDifficulty(stagingArea *StagingArea, blockHash *externalapi.DomainHash) error
 }
@@ -14,4 +14,7 @@ type ConsensusStateManager interface {
 	RecoverUTXOIfRequired() error
 	ReverseUTXODiffs(tipHash *externalapi.DomainHash, reversalData *UTXODiffReversalData) error
 	ResolveVirtual(maxBlocksToResolve uint64) (*externalapi.VirtualChangeSet, bool, error)
+	ResolveBlockStatus(stagingArea *StagingArea, blockHash *externalapi.DomainHash,
+		useSeparateStagingAreaPerBlock bool) (externalapi.BlockStatus, error)
+	VerifyBlockUTXO(stagingArea *StagingArea, blockHash *externalapi.DomainHash) error
 }
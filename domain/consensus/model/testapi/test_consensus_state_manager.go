@@ -12,4 +12,5 @@ type TestConsensusStateManager interface {
 		outpoint *externalapi.DomainOutpoint) error
 	ResolveBlockStatus(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash,
 		useSeparateStagingAreaPerBlock bool) (externalapi.BlockStatus, error)
+	PickVirtualParents(stagingArea *model.StagingArea, tips []*externalapi.DomainHash) ([]*externalapi.DomainHash, error)
 }
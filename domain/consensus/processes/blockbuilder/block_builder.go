@@ -20,6 +20,7 @@ import (
 type blockBuilder struct {
 	databaseContext model.DBManager
 	genesisHash     *externalapi.DomainHash
+	clock           mstime.Clock
 
 	difficultyManager     model.DifficultyManager
 	pastMedianTimeManager model.PastMedianTimeManager
@@ -30,6 +31,7 @@ type blockBuilder struct {
 	finalityManager       model.FinalityManager
 	pruningManager        model.PruningManager
 	blockParentBuilder    model.BlockParentBuilder
+	reachabilityManager   model.ReachabilityManager
 
 	acceptanceDataStore model.AcceptanceDataStore
 	blockRelationStore  model.BlockRelationStore
@@ -42,6 +44,7 @@ type blockBuilder struct {
 func New(
 	databaseContext model.DBManager,
 	genesisHash *externalapi.DomainHash,
+	clock mstime.Clock,
 
 	difficultyManager model.DifficultyManager,
 	pastMedianTimeManager model.PastMedianTimeManager,
@@ -52,6 +55,7 @@ func New(
 	finalityManager model.FinalityManager,
 	blockParentBuilder model.BlockParentBuilder,
 	pruningManager model.PruningManager,
+	reachabilityManager model.ReachabilityManager,
 
 	acceptanceDataStore model.AcceptanceDataStore,
 	blockRelationStore model.BlockRelationStore,
@@ -63,6 +67,7 @@ func New(
 	return &blockBuilder{
 		databaseContext: databaseContext,
 		genesisHash:     genesisHash,
+		clock:           clock,
 
 		difficultyManager:     difficultyManager,
 		pastMedianTimeManager: pastMedianTimeManager,
@@ -73,6 +78,7 @@ func New(
 		finalityManager:       finalityManager,
 		blockParentBuilder:    blockParentBuilder,
 		pruningManager:        pruningManager,
+		reachabilityManager:   reachabilityManager,
 
 		acceptanceDataStore: acceptanceDataStore,
 		blockRelationStore:  blockRelationStore,
@@ -255,7 +261,7 @@ func (bb *blockBuilder) newBlockTime(stagingArea *model.StagingArea) (int64, err
 	// timestamp is truncated to a millisecond boundary before comparison since a
 	// block timestamp does not supported a precision greater than one
 	// millisecond.
-	newTimestamp := mstime.Now().UnixMilliseconds()
+	newTimestamp := bb.clock.Now().UnixMilliseconds()
 	minTimestamp, err := bb.minBlockTime(stagingArea, model.VirtualBlockHash)
 	if err != nil {
 		return 0, err
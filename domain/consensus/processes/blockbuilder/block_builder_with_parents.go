@@ -0,0 +1,216 @@
+package blockbuilder
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/blockheader"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/pkg/errors"
+)
+
+var tempBlockHash = externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{
+	1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1})
+
+// BuildBlockWithParents builds a block with provided parents, coinbaseData and transactions,
+// and returns the block together with its past UTXO-diff from the virtual.
+// The block's timestamp is the current time, or the minimum time allowed over
+// the given parents if that's later.
+func (bb *blockBuilder) BuildBlockWithParents(parentHashes []*externalapi.DomainHash,
+	coinbaseData *externalapi.DomainCoinbaseData, transactions []*externalapi.DomainTransaction) (
+	*externalapi.DomainBlock, externalapi.UTXODiff, error) {
+
+	onEnd := logger.LogAndMeasureExecutionTime(log, "BuildBlockWithParents")
+	defer onEnd()
+
+	stagingArea := model.NewStagingArea()
+
+	block, diff, err := bb.buildBlockWithParents(stagingArea, parentHashes, coinbaseData, transactions, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// It's invalid to insert a block with prefilled fields to consensus, so we
+	// clean them before returning the block.
+	cleanBlockPrefilledFields(block)
+
+	if now := bb.clock.Now().UnixMilliseconds(); now > block.Header.TimeInMilliseconds() {
+		header := block.Header.ToMutable()
+		header.SetTimeInMilliseconds(now)
+		block.Header = header.ToImmutable()
+	}
+
+	return block, diff, nil
+}
+
+func cleanBlockPrefilledFields(block *externalapi.DomainBlock) {
+	for _, tx := range block.Transactions {
+		tx.Fee = 0
+		tx.Mass = 0
+		tx.ID = nil
+
+		for _, input := range tx.Inputs {
+			input.UTXOEntry = nil
+		}
+	}
+}
+
+func (bb *blockBuilder) buildUTXOInvalidHeader(stagingArea *model.StagingArea,
+	parentHashes []*externalapi.DomainHash, bits uint32, daaScore, blueScore uint64, blueWork *big.Int,
+	transactions []*externalapi.DomainTransaction, nonce uint64) (externalapi.BlockHeader, error) {
+
+	timeInMilliseconds, err := bb.minBlockTime(stagingArea, tempBlockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	hashMerkleRoot := bb.newBlockHashMerkleRoot(transactions)
+
+	pruningPoint, err := bb.newBlockPruningPoint(stagingArea, tempBlockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	parents, err := bb.blockParentBuilder.BuildParents(stagingArea, daaScore, parentHashes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, blockLevelParents := range parents {
+		sort.Slice(blockLevelParents, func(i, j int) bool {
+			return blockLevelParents[i].Less(blockLevelParents[j])
+		})
+	}
+
+	return blockheader.NewImmutableBlockHeader(
+		constants.BlockVersion,
+		parents,
+		hashMerkleRoot,
+		&externalapi.DomainHash{},
+		&externalapi.DomainHash{},
+		timeInMilliseconds,
+		bits,
+		nonce,
+		daaScore,
+		blueScore,
+		blueWork,
+		pruningPoint,
+	), nil
+}
+
+func (bb *blockBuilder) buildHeaderWithParents(stagingArea *model.StagingArea,
+	parentHashes []*externalapi.DomainHash, bits uint32, transactions []*externalapi.DomainTransaction,
+	acceptanceData externalapi.AcceptanceData, multiset model.Multiset, daaScore, blueScore uint64, blueWork *big.Int,
+	nonce uint64) (externalapi.BlockHeader, error) {
+
+	header, err := bb.buildUTXOInvalidHeader(stagingArea, parentHashes, bits, daaScore, blueScore, blueWork, transactions, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	hashMerkleRoot := bb.newBlockHashMerkleRoot(transactions)
+	acceptedIDMerkleRoot, err := bb.calculateAcceptedIDMerkleRoot(acceptanceData)
+	if err != nil {
+		return nil, err
+	}
+	utxoCommitment := multiset.Hash()
+
+	return blockheader.NewImmutableBlockHeader(
+		header.Version(),
+		header.Parents(),
+		hashMerkleRoot,
+		acceptedIDMerkleRoot,
+		utxoCommitment,
+		header.TimeInMilliseconds(),
+		header.Bits(),
+		header.Nonce(),
+		header.DAAScore(),
+		header.BlueScore(),
+		header.BlueWork(),
+		header.PruningPoint(),
+	), nil
+}
+
+func (bb *blockBuilder) buildBlockWithParents(stagingArea *model.StagingArea, parentHashes []*externalapi.DomainHash,
+	coinbaseData *externalapi.DomainCoinbaseData, transactions []*externalapi.DomainTransaction, nonce uint64) (
+	*externalapi.DomainBlock, externalapi.UTXODiff, error) {
+
+	if coinbaseData == nil {
+		scriptPublicKeyScript, err := txscript.PayToScriptHashScript([]byte{txscript.OpTrue})
+		if err != nil {
+			panic(errors.Wrapf(err, "Couldn't parse opTrueScript. This should never happen"))
+		}
+		scriptPublicKey := &externalapi.ScriptPublicKey{Script: scriptPublicKeyScript, Version: constants.MaxScriptPublicKeyVersion}
+		coinbaseData = &externalapi.DomainCoinbaseData{
+			ScriptPublicKey: scriptPublicKey,
+			ExtraData:       []byte{},
+		}
+	}
+
+	bb.blockRelationStore.StageBlockRelation(stagingArea, tempBlockHash, &model.BlockRelations{Parents: parentHashes})
+
+	err := bb.ghostdagManager.GHOSTDAG(stagingArea, tempBlockHash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bits, err := bb.difficultyManager.StageDAADataAndReturnRequiredDifficulty(stagingArea, tempBlockHash, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	daaScore, err := bb.daaBlocksStore.DAAScore(bb.databaseContext, stagingArea, tempBlockHash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ghostdagData, err := bb.ghostdagDataStore.Get(bb.databaseContext, stagingArea, tempBlockHash, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	blueWork := ghostdagData.BlueWork()
+	blueScore := ghostdagData.BlueScore()
+
+	selectedParentStatus, err := bb.consensusStateManager.ResolveBlockStatus(
+		stagingArea, ghostdagData.SelectedParent(), false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if selectedParentStatus == externalapi.StatusDisqualifiedFromChain {
+		return nil, nil, errors.Errorf("Error building block with selectedParent %s with status DisqualifiedFromChain",
+			ghostdagData.SelectedParent())
+	}
+
+	pastUTXO, acceptanceData, multiset, err :=
+		bb.consensusStateManager.CalculatePastUTXOAndAcceptanceData(stagingArea, tempBlockHash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bb.acceptanceDataStore.Stage(stagingArea, tempBlockHash, acceptanceData)
+
+	coinbase, _, err := bb.coinbaseManager.ExpectedCoinbaseTransaction(stagingArea, tempBlockHash, coinbaseData)
+	if err != nil {
+		return nil, nil, err
+	}
+	transactionsWithCoinbase := append([]*externalapi.DomainTransaction{coinbase}, transactions...)
+
+	err = bb.reachabilityManager.AddBlock(stagingArea, tempBlockHash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	header, err := bb.buildHeaderWithParents(stagingArea, parentHashes, bits, transactionsWithCoinbase,
+		acceptanceData, multiset, daaScore, blueScore, blueWork, nonce)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &externalapi.DomainBlock{
+		Header:       header,
+		Transactions: transactionsWithCoinbase,
+	}, pastUTXO, nil
+}
@@ -5,14 +5,8 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensus/model"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/model/testapi"
-	"github.com/kaspanet/kaspad/domain/consensus/utils/blockheader"
-	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
-	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
 	"github.com/kaspanet/kaspad/infrastructure/logger"
-	"github.com/pkg/errors"
-	"math/big"
-	"sort"
 )
 
 type testBlockBuilder struct {
@@ -21,9 +15,6 @@ type testBlockBuilder struct {
 	nonceCounter  uint64
 }
 
-var tempBlockHash = externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{
-	1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1})
-
 // NewTestBlockBuilder creates an instance of a TestBlockBuilder
 func NewTestBlockBuilder(baseBlockBuilder model.BlockBuilder, testConsensus testapi.TestConsensus) testapi.TestBlockBuilder {
 	return &testBlockBuilder{
@@ -32,20 +23,11 @@ func NewTestBlockBuilder(baseBlockBuilder model.BlockBuilder, testConsensus test
 	}
 }
 
-func cleanBlockPrefilledFields(block *externalapi.DomainBlock) {
-	for _, tx := range block.Transactions {
-		tx.Fee = 0
-		tx.Mass = 0
-		tx.ID = nil
-
-		for _, input := range tx.Inputs {
-			input.UTXOEntry = nil
-		}
-	}
-}
-
 // BuildBlockWithParents builds a block with provided parents, coinbaseData and transactions,
 // and returns the block together with its past UTXO-diff from the virtual.
+// Unlike the base BlockBuilder, the block's timestamp is the minimum time allowed over
+// the given parents and its nonce is taken from the nonce counter, so that tests are
+// deterministic.
 func (bb *testBlockBuilder) BuildBlockWithParents(parentHashes []*externalapi.DomainHash,
 	coinbaseData *externalapi.DomainCoinbaseData, transactions []*externalapi.DomainTransaction) (
 	*externalapi.DomainBlock, externalapi.UTXODiff, error) {
@@ -55,7 +37,8 @@ func (bb *testBlockBuilder) BuildBlockWithParents(parentHashes []*externalapi.Do
 
 	stagingArea := model.NewStagingArea()
 
-	block, diff, err := bb.buildBlockWithParents(stagingArea, parentHashes, coinbaseData, transactions)
+	bb.nonceCounter++
+	block, diff, err := bb.buildBlockWithParents(stagingArea, parentHashes, coinbaseData, transactions, bb.nonceCounter)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -67,162 +50,6 @@ func (bb *testBlockBuilder) BuildBlockWithParents(parentHashes []*externalapi.Do
 	return block, diff, nil
 }
 
-func (bb *testBlockBuilder) buildUTXOInvalidHeader(stagingArea *model.StagingArea,
-	parentHashes []*externalapi.DomainHash, bits uint32, daaScore, blueScore uint64, blueWork *big.Int,
-	transactions []*externalapi.DomainTransaction) (externalapi.BlockHeader, error) {
-
-	timeInMilliseconds, err := bb.minBlockTime(stagingArea, tempBlockHash)
-	if err != nil {
-		return nil, err
-	}
-
-	hashMerkleRoot := bb.newBlockHashMerkleRoot(transactions)
-
-	pruningPoint, err := bb.newBlockPruningPoint(stagingArea, tempBlockHash)
-	if err != nil {
-		return nil, err
-	}
-
-	parents, err := bb.blockParentBuilder.BuildParents(stagingArea, daaScore, parentHashes)
-	if err != nil {
-		return nil, err
-	}
-
-	for _, blockLevelParents := range parents {
-		sort.Slice(blockLevelParents, func(i, j int) bool {
-			return blockLevelParents[i].Less(blockLevelParents[j])
-		})
-	}
-
-	bb.nonceCounter++
-	return blockheader.NewImmutableBlockHeader(
-		constants.BlockVersion,
-		parents,
-		hashMerkleRoot,
-		&externalapi.DomainHash{},
-		&externalapi.DomainHash{},
-		timeInMilliseconds,
-		bits,
-		bb.nonceCounter,
-		daaScore,
-		blueScore,
-		blueWork,
-		pruningPoint,
-	), nil
-}
-
-func (bb *testBlockBuilder) buildHeaderWithParents(stagingArea *model.StagingArea,
-	parentHashes []*externalapi.DomainHash, bits uint32, transactions []*externalapi.DomainTransaction,
-	acceptanceData externalapi.AcceptanceData, multiset model.Multiset, daaScore, blueScore uint64, blueWork *big.Int) (externalapi.BlockHeader, error) {
-
-	header, err := bb.buildUTXOInvalidHeader(stagingArea, parentHashes, bits, daaScore, blueScore, blueWork, transactions)
-	if err != nil {
-		return nil, err
-	}
-
-	hashMerkleRoot := bb.newBlockHashMerkleRoot(transactions)
-	acceptedIDMerkleRoot, err := bb.calculateAcceptedIDMerkleRoot(acceptanceData)
-	if err != nil {
-		return nil, err
-	}
-	utxoCommitment := multiset.Hash()
-
-	return blockheader.NewImmutableBlockHeader(
-		header.Version(),
-		header.Parents(),
-		hashMerkleRoot,
-		acceptedIDMerkleRoot,
-		utxoCommitment,
-		header.TimeInMilliseconds(),
-		header.Bits(),
-		header.Nonce(),
-		header.DAAScore(),
-		header.BlueScore(),
-		header.BlueWork(),
-		header.PruningPoint(),
-	), nil
-}
-
-func (bb *testBlockBuilder) buildBlockWithParents(stagingArea *model.StagingArea, parentHashes []*externalapi.DomainHash,
-	coinbaseData *externalapi.DomainCoinbaseData, transactions []*externalapi.DomainTransaction) (
-	*externalapi.DomainBlock, externalapi.UTXODiff, error) {
-
-	if coinbaseData == nil {
-		scriptPublicKeyScript, err := txscript.PayToScriptHashScript([]byte{txscript.OpTrue})
-		if err != nil {
-			panic(errors.Wrapf(err, "Couldn't parse opTrueScript. This should never happen"))
-		}
-		scriptPublicKey := &externalapi.ScriptPublicKey{Script: scriptPublicKeyScript, Version: constants.MaxScriptPublicKeyVersion}
-		coinbaseData = &externalapi.DomainCoinbaseData{
-			ScriptPublicKey: scriptPublicKey,
-			ExtraData:       []byte{},
-		}
-	}
-
-	bb.blockRelationStore.StageBlockRelation(stagingArea, tempBlockHash, &model.BlockRelations{Parents: parentHashes})
-
-	err := bb.ghostdagManager.GHOSTDAG(stagingArea, tempBlockHash)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	bits, err := bb.difficultyManager.StageDAADataAndReturnRequiredDifficulty(stagingArea, tempBlockHash, false)
-	if err != nil {
-		return nil, nil, err
-	}
-	daaScore, err := bb.daaBlocksStore.DAAScore(bb.databaseContext, stagingArea, tempBlockHash)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	ghostdagData, err := bb.ghostdagDataStore.Get(bb.databaseContext, stagingArea, tempBlockHash, false)
-	if err != nil {
-		return nil, nil, err
-	}
-	blueWork := ghostdagData.BlueWork()
-	blueScore := ghostdagData.BlueScore()
-
-	selectedParentStatus, err := bb.testConsensus.ConsensusStateManager().ResolveBlockStatus(
-		stagingArea, ghostdagData.SelectedParent(), false)
-	if err != nil {
-		return nil, nil, err
-	}
-	if selectedParentStatus == externalapi.StatusDisqualifiedFromChain {
-		return nil, nil, errors.Errorf("Error building block with selectedParent %s with status DisqualifiedFromChain",
-			ghostdagData.SelectedParent())
-	}
-
-	pastUTXO, acceptanceData, multiset, err :=
-		bb.consensusStateManager.CalculatePastUTXOAndAcceptanceData(stagingArea, tempBlockHash)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	bb.acceptanceDataStore.Stage(stagingArea, tempBlockHash, acceptanceData)
-
-	coinbase, _, err := bb.coinbaseManager.ExpectedCoinbaseTransaction(stagingArea, tempBlockHash, coinbaseData)
-	if err != nil {
-		return nil, nil, err
-	}
-	transactionsWithCoinbase := append([]*externalapi.DomainTransaction{coinbase}, transactions...)
-
-	err = bb.testConsensus.ReachabilityManager().AddBlock(stagingArea, tempBlockHash)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	header, err := bb.buildHeaderWithParents(
-		stagingArea, parentHashes, bits, transactionsWithCoinbase, acceptanceData, multiset, daaScore, blueScore, blueWork)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	return &externalapi.DomainBlock{
-		Header:       header,
-		Transactions: transactionsWithCoinbase,
-	}, pastUTXO, nil
-}
-
 func (bb *testBlockBuilder) BuildUTXOInvalidHeader(parentHashes []*externalapi.DomainHash) (externalapi.BlockHeader,
 	error) {
 
@@ -273,7 +100,9 @@ func (bb *testBlockBuilder) BuildUTXOInvalidBlock(parentHashes []*externalapi.Do
 		return nil, err
 	}
 
-	header, err := bb.buildUTXOInvalidHeader(stagingArea, parentHashes, bits, daaScore, blueScore, blueWork, transactions)
+	bb.nonceCounter++
+	header, err := bb.buildUTXOInvalidHeader(stagingArea, parentHashes, bits, daaScore, blueScore, blueWork,
+		transactions, bb.nonceCounter)
 	if err != nil {
 		return nil, err
 	}
@@ -170,3 +170,13 @@ func (bp *blockProcessor) ValidateAndInsertBlockWithTrustedData(block *externala
 
 	return bp.validateAndInsertBlockWithTrustedData(stagingArea, block, shouldValidateAgainstUTXO)
 }
+
+// ValidateBlockProposal validates the given block as if it were about to be inserted,
+// without checking its proof of work and without applying it to the current state
+func (bp *blockProcessor) ValidateBlockProposal(block *externalapi.DomainBlock) error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "ValidateBlockProposal")
+	defer onEnd()
+
+	stagingArea := model.NewStagingArea()
+	return bp.validateBlockProposal(stagingArea, block)
+}
@@ -0,0 +1,60 @@
+package blockprocessor
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/pkg/errors"
+)
+
+// validateBlockProposal runs the same validations as validateAndInsertBlock over a
+// block that wasn't mined yet, so its proof of work isn't checked. Nothing is ever
+// committed, so the block isn't added to the DAG and isn't marked as invalid if it
+// fails validation.
+func (bp *blockProcessor) validateBlockProposal(stagingArea *model.StagingArea, block *externalapi.DomainBlock) error {
+	blockHash := consensushashing.BlockHash(block)
+	log.Debugf("Validating block proposal %s", blockHash)
+
+	if isHeaderOnlyBlock(block) {
+		return errors.Wrapf(ruleerrors.ErrNoTransactions, "block proposal %s has no transactions", blockHash)
+	}
+
+	err := bp.checkBlockStatus(stagingArea, block)
+	if err != nil {
+		return err
+	}
+
+	hasValidatedHeader, err := bp.hasValidatedHeader(stagingArea, blockHash)
+	if err != nil {
+		return err
+	}
+
+	if !hasValidatedHeader {
+		bp.blockHeaderStore.Stage(stagingArea, blockHash, block.Header)
+	}
+
+	err = bp.validatePreProofOfWork(stagingArea, block)
+	if err != nil {
+		return err
+	}
+
+	if !hasValidatedHeader {
+		err = bp.blockValidator.ValidatePruningPointViolationAndDifficulty(stagingArea, blockHash)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = bp.validatePostProofOfWork(stagingArea, block, false)
+	if err != nil {
+		return err
+	}
+
+	_, err = bp.setBlockStatusAfterBlockValidation(stagingArea, block, false)
+	if err != nil {
+		return err
+	}
+
+	return bp.consensusStateManager.VerifyBlockUTXO(stagingArea, blockHash)
+}
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/kaspanet/kaspad/domain/consensus"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
@@ -124,3 +125,40 @@ func CheckBlockTimestampInIsolation(t *testing.T, tc testapi.TestConsensus, cfg
 		}
 	})
 }
+
+func TestCheckBlockTimestampInIsolationWithClock(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		clock := mstime.NewManualClock(mstime.Now())
+		consensusConfig.Clock = clock
+		tc, teardown, err := consensus.NewFactory().NewTestConsensus(consensusConfig,
+			"TestCheckBlockTimestampInIsolationWithClock")
+		if err != nil {
+			t.Fatalf("Error setting up consensus: %+v", err)
+		}
+		defer teardown(false)
+
+		block, err := tc.BuildBlock(&externalapi.DomainCoinbaseData{ScriptPublicKey: &externalapi.ScriptPublicKey{}}, nil)
+		if err != nil {
+			t.Fatalf("BuildBlock: %+v", err)
+		}
+		if block.Header.TimeInMilliseconds() != clock.Now().UnixMilliseconds() {
+			t.Fatalf("Expected the block to be timestamped with the time of the clock, %d, but got %d",
+				clock.Now().UnixMilliseconds(), block.Header.TimeInMilliseconds())
+		}
+
+		// Once the clock falls behind the block by more than the tolerance,
+		// the block is in the future
+		tolerance := time.Duration(consensusConfig.TimestampDeviationTolerance) * consensusConfig.TargetTimePerBlock
+		clock.Advance(-tolerance - time.Second)
+		err = tc.ValidateAndInsertBlock(block, true)
+		if !errors.Is(err, ruleerrors.ErrTimeTooMuchInTheFuture) {
+			t.Fatalf("Expected the block to be too much in the future, but got: %+v", err)
+		}
+
+		clock.Advance(time.Second)
+		err = tc.ValidateAndInsertBlock(block, true)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertBlock: %+v", err)
+		}
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensus/model"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/util/difficulty"
+	"github.com/kaspanet/kaspad/util/mstime"
 )
 
 // blockValidator exposes a set of validation classes, after which
@@ -25,6 +26,7 @@ type blockValidator struct {
 	timestampDeviationTolerance int
 	targetTimePerBlock          time.Duration
 	maxBlockLevel               int
+	clock                       mstime.Clock
 
 	databaseContext       model.DBReader
 	difficultyManager     model.DifficultyManager
@@ -64,6 +66,7 @@ func New(powMax *big.Int,
 	timestampDeviationTolerance int,
 	targetTimePerBlock time.Duration,
 	maxBlockLevel int,
+	clock mstime.Clock,
 
 	databaseContext model.DBReader,
 
@@ -103,6 +106,7 @@ func New(powMax *big.Int,
 		mergeSetSizeLimit:          mergeSetSizeLimit,
 		maxBlockParents:            maxBlockParents,
 		maxBlockLevel:              maxBlockLevel,
+		clock:                      clock,
 
 		timestampDeviationTolerance: timestampDeviationTolerance,
 		targetTimePerBlock:          targetTimePerBlock,
@@ -17,6 +17,24 @@ func (v *blockValidator) ValidatePruningPointViolationAndProofOfWorkAndDifficult
 	onEnd := logger.LogAndMeasureExecutionTime(log, "ValidatePruningPointViolationAndProofOfWorkAndDifficulty")
 	defer onEnd()
 
+	return v.validatePruningPointViolationAndProofOfWorkAndDifficulty(stagingArea, blockHash, isBlockWithTrustedData, true)
+}
+
+// ValidatePruningPointViolationAndDifficulty runs the same validations as
+// ValidatePruningPointViolationAndProofOfWorkAndDifficulty, except for the
+// proof of work check. It's meant for blocks that weren't mined yet.
+func (v *blockValidator) ValidatePruningPointViolationAndDifficulty(stagingArea *model.StagingArea,
+	blockHash *externalapi.DomainHash) error {
+
+	onEnd := logger.LogAndMeasureExecutionTime(log, "ValidatePruningPointViolationAndDifficulty")
+	defer onEnd()
+
+	return v.validatePruningPointViolationAndProofOfWorkAndDifficulty(stagingArea, blockHash, false, false)
+}
+
+func (v *blockValidator) validatePruningPointViolationAndProofOfWorkAndDifficulty(stagingArea *model.StagingArea,
+	blockHash *externalapi.DomainHash, isBlockWithTrustedData bool, shouldValidateProofOfWork bool) error {
+
 	header, err := v.blockHeaderStore.BlockHeader(v.databaseContext, stagingArea, blockHash)
 	if err != nil {
 		return err
@@ -49,7 +67,7 @@ func (v *blockValidator) ValidatePruningPointViolationAndProofOfWorkAndDifficult
 		}
 	}
 
-	if !blockHash.Equal(v.genesisHash) {
+	if shouldValidateProofOfWork && !blockHash.Equal(v.genesisHash) {
 		err = v.checkProofOfWork(header)
 		if err != nil {
 			return err
@@ -60,6 +60,32 @@ func ModifyCoinbasePayload(payload []byte, coinbaseData *externalapi.DomainCoinb
 	return payload, nil
 }
 
+// CoinbasePayloadExtraDataOffset returns the offset of the extra data within the payload
+// of a coinbase transaction paying to the given scriptPublicKey
+func CoinbasePayloadExtraDataOffset(scriptPublicKey *externalapi.ScriptPublicKey) int {
+	return uint64Len + lengthOfSubsidy + lengthOfVersionScriptPubKey + lengthOfScriptPubKeyLength + len(scriptPublicKey.Script)
+}
+
+// CoinbasePayloadScriptPublicKey returns the scriptPublicKey that the given
+// coinbase payload pays the block reward to
+func CoinbasePayloadScriptPublicKey(payload []byte) (*externalapi.ScriptPublicKey, error) {
+	minLength := uint64Len + lengthOfSubsidy + lengthOfVersionScriptPubKey + lengthOfScriptPubKeyLength
+	if len(payload) < minLength {
+		return nil, errors.Wrapf(ruleerrors.ErrBadCoinbasePayloadLen,
+			"coinbase payload is less than the minimum length of %d", minLength)
+	}
+
+	version := binary.LittleEndian.Uint16(payload[uint64Len+lengthOfSubsidy:])
+	scriptLength := int(payload[uint64Len+lengthOfSubsidy+lengthOfVersionScriptPubKey])
+	if len(payload) < minLength+scriptLength {
+		return nil, errors.Wrapf(ruleerrors.ErrBadCoinbasePayloadLen,
+			"coinbase payload doesn't have enough bytes to contain a script public key of %d bytes", scriptLength)
+	}
+	script := payload[minLength : minLength+scriptLength]
+
+	return &externalapi.ScriptPublicKey{Script: script, Version: version}, nil
+}
+
 // ExtractCoinbaseDataBlueScoreAndSubsidy deserializes the coinbase payload to its component (scriptPubKey, extra data, and subsidy).
 func (c *coinbaseManager) ExtractCoinbaseDataBlueScoreAndSubsidy(coinbaseTx *externalapi.DomainTransaction) (
 	blueScore uint64, coinbaseData *externalapi.DomainCoinbaseData, subsidy uint64, err error) {
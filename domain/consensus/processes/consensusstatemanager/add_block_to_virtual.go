@@ -36,8 +36,8 @@ func (csm *consensusStateManager) AddBlock(stagingArea *model.StagingArea, block
 			}
 
 			if shouldNotify {
-				//TODO: Send finality conflict notification
 				log.Warnf("Finality Violation Detected! Block %s violates finality!", blockHash)
+				sendFinalityConflictAlert(blockHash)
 			}
 
 			if !isViolatingFinality {
@@ -1,8 +1,11 @@
 package consensusstatemanager
 
 import (
+	"fmt"
+
 	"github.com/kaspanet/kaspad/domain/consensus/model"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/alerts"
 )
 
 func (csm *consensusStateManager) isViolatingFinality(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash,
@@ -68,3 +71,14 @@ func (csm *consensusStateManager) isViolatingFinality(stagingArea *model.Staging
 
 	return false, false, nil
 }
+
+// sendFinalityConflictAlert alerts the operator that blockHash violates
+// finality, which means the network has split
+func sendFinalityConflictAlert(blockHash *externalapi.DomainHash) {
+	alerts.Send(&alerts.Event{
+		Kind:     alerts.KindFinalityConflict,
+		Severity: alerts.SeverityCritical,
+		Message:  fmt.Sprintf("Block %s violates finality", blockHash),
+		Fields:   map[string]string{"block": blockHash.String()},
+	})
+}
@@ -10,6 +10,11 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensus/utils/hashset"
 )
 
+// maxVirtualParentsRefillRounds bounds the amount of times pickVirtualParents refills parent
+// slots freed by bounded merge breaking parents, since each round requires running GHOSTDAG
+// over the virtual.
+const maxVirtualParentsRefillRounds = 2
+
 func (csm *consensusStateManager) pickVirtualParents(stagingArea *model.StagingArea, tips []*externalapi.DomainHash) ([]*externalapi.DomainHash, error) {
 	onEnd := logger.LogAndMeasureExecutionTime(log, "pickVirtualParents")
 	defer onEnd()
@@ -59,6 +64,55 @@ func (csm *consensusStateManager) pickVirtualParents(stagingArea *model.StagingA
 	selectedVirtualParents := []*externalapi.DomainHash{virtualSelectedParent}
 	mergeSetSize := uint64(1) // starts counting from 1 because selectedParent is already in the mergeSet
 
+	// Parents that break the bounded merge set are only discovered after the whole parent set is chosen.
+	// Instead of leaving their slots empty, we refill them with the remaining candidates. This way an
+	// adversary publishing many bounded-merge-breaking tips can't crowd honest tips out of the virtual parents.
+	// Note that mergeSetSize is not decreased when parents are removed, which keeps it an upper bound.
+	for refillRound := 0; ; refillRound++ {
+		selectedVirtualParents, candidates, mergeSetSize, err = csm.addVirtualParentCandidates(
+			stagingArea, selectedVirtualParents, candidates, mergeSetSize)
+		if err != nil {
+			return nil, err
+		}
+
+		boundedMergeBreakingParents, err := csm.boundedMergeBreakingParents(stagingArea, selectedVirtualParents)
+		if err != nil {
+			return nil, err
+		}
+		if len(boundedMergeBreakingParents) == 0 {
+			break
+		}
+		log.Tracef("The following parents are omitted for "+
+			"breaking the bounded merge set: %s", boundedMergeBreakingParents)
+
+		// Remove all boundedMergeBreakingParents from selectedVirtualParents
+		for _, breakingParent := range boundedMergeBreakingParents {
+			for i, parent := range selectedVirtualParents {
+				if parent.Equal(breakingParent) {
+					selectedVirtualParents[i] = selectedVirtualParents[len(selectedVirtualParents)-1]
+					selectedVirtualParents = selectedVirtualParents[:len(selectedVirtualParents)-1]
+					break
+				}
+			}
+		}
+
+		if len(candidates) == 0 || refillRound == maxVirtualParentsRefillRounds {
+			break
+		}
+		log.Debugf("Refilling %d virtual parent slots freed by bounded merge breaking parents",
+			len(boundedMergeBreakingParents))
+	}
+	log.Debugf("The virtual parents resolved to be: %s", selectedVirtualParents)
+	return selectedVirtualParents, nil
+}
+
+// addVirtualParentCandidates adds candidates to selectedVirtualParents, in order, as long as the
+// merge set size limit and the maximum amount of parents allow. It returns the new parents, the
+// candidates that were not considered yet, and the updated merge set size.
+func (csm *consensusStateManager) addVirtualParentCandidates(stagingArea *model.StagingArea,
+	selectedVirtualParents []*externalapi.DomainHash, candidates []*externalapi.DomainHash, mergeSetSize uint64) (
+	[]*externalapi.DomainHash, []*externalapi.DomainHash, uint64, error) {
+
 	// First condition implies that no point in searching since limit was already reached
 	for mergeSetSize < csm.mergeSetSizeLimit && len(candidates) > 0 && uint64(len(selectedVirtualParents)) < uint64(csm.maxBlockParents) {
 		candidate := candidates[0]
@@ -70,7 +124,7 @@ func (csm *consensusStateManager) pickVirtualParents(stagingArea *model.StagingA
 		canBeParent, newCandidate, mergeSetIncrease, err := csm.mergeSetIncrease(
 			stagingArea, candidate, selectedVirtualParents, mergeSetSize)
 		if err != nil {
-			return nil, err
+			return nil, nil, 0, err
 		}
 		if canBeParent {
 			mergeSetSize += mergeSetIncrease
@@ -81,7 +135,7 @@ func (csm *consensusStateManager) pickVirtualParents(stagingArea *model.StagingA
 		// If we already have a candidate in the past of newCandidate then skip.
 		isInFutureOfCandidates, err := csm.dagTopologyManager.IsAnyAncestorOf(stagingArea, candidates, newCandidate)
 		if err != nil {
-			return nil, err
+			return nil, nil, 0, err
 		}
 		if isInFutureOfCandidates {
 			continue
@@ -89,31 +143,13 @@ func (csm *consensusStateManager) pickVirtualParents(stagingArea *model.StagingA
 		// Remove all candidates in the future of newCandidate
 		candidates, err = csm.removeHashesInFutureOf(stagingArea, candidates, newCandidate)
 		if err != nil {
-			return nil, err
+			return nil, nil, 0, err
 		}
 		candidates = append(candidates, newCandidate)
 		log.Debugf("Block %s increases merge set too much, instead adding its ancestor %s", candidate, newCandidate)
 	}
 
-	boundedMergeBreakingParents, err := csm.boundedMergeBreakingParents(stagingArea, selectedVirtualParents)
-	if err != nil {
-		return nil, err
-	}
-	log.Tracef("The following parents are omitted for "+
-		"breaking the bounded merge set: %s", boundedMergeBreakingParents)
-
-	// Remove all boundedMergeBreakingParents from selectedVirtualParents
-	for _, breakingParent := range boundedMergeBreakingParents {
-		for i, parent := range selectedVirtualParents {
-			if parent.Equal(breakingParent) {
-				selectedVirtualParents[i] = selectedVirtualParents[len(selectedVirtualParents)-1]
-				selectedVirtualParents = selectedVirtualParents[:len(selectedVirtualParents)-1]
-				break
-			}
-		}
-	}
-	log.Debugf("The virtual parents resolved to be: %s", selectedVirtualParents)
-	return selectedVirtualParents, nil
+	return selectedVirtualParents, candidates, mergeSetSize, nil
 }
 
 func (csm *consensusStateManager) removeHashesInFutureOf(stagingArea *model.StagingArea, hashes []*externalapi.DomainHash,
@@ -21,9 +21,8 @@ func (csm *testConsensusStateManager) AddUTXOToMultiset(
 	return addUTXOToMultiset(multiset, entry, outpoint)
 }
 
-func (csm *testConsensusStateManager) ResolveBlockStatus(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash,
-	useSeparateStagingAreaPerBlock bool) (externalapi.BlockStatus, error) {
+func (csm *testConsensusStateManager) PickVirtualParents(stagingArea *model.StagingArea,
+	tips []*externalapi.DomainHash) ([]*externalapi.DomainHash, error) {
 
-	status, _, err := csm.resolveBlockStatus(stagingArea, blockHash, useSeparateStagingAreaPerBlock)
-	return status, err
+	return csm.pickVirtualParents(stagingArea, tips)
 }
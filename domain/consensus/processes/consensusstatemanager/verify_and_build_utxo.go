@@ -12,9 +12,45 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensus/model"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
 	"github.com/pkg/errors"
 )
 
+// VerifyBlockUTXO verifies the given staged block against its past UTXO set and
+// returns the reason it failed as a rule error. Unlike AddBlock, it doesn't stage
+// the status of the block itself.
+func (csm *consensusStateManager) VerifyBlockUTXO(stagingArea *model.StagingArea, blockHash *externalapi.DomainHash) error {
+	onEnd := logger.LogAndMeasureExecutionTime(log, "VerifyBlockUTXO")
+	defer onEnd()
+
+	ghostdagData, err := csm.ghostdagDataStore.Get(csm.databaseContext, stagingArea, blockHash, false)
+	if err != nil {
+		return err
+	}
+
+	selectedParentStatus, err := csm.ResolveBlockStatus(stagingArea, ghostdagData.SelectedParent(), false)
+	if err != nil {
+		return err
+	}
+	if selectedParentStatus == externalapi.StatusDisqualifiedFromChain {
+		return errors.Wrapf(ruleerrors.ErrSelectedParentDisqualifiedFromChain,
+			"selected parent %s of block %s is disqualified from chain", ghostdagData.SelectedParent(), blockHash)
+	}
+
+	pastUTXO, acceptanceData, multiset, err := csm.CalculatePastUTXOAndAcceptanceData(stagingArea, blockHash)
+	if err != nil {
+		return err
+	}
+	csm.acceptanceDataStore.Stage(stagingArea, blockHash, acceptanceData)
+
+	block, err := csm.blockStore.Block(csm.databaseContext, stagingArea, blockHash)
+	if err != nil {
+		return err
+	}
+
+	return csm.verifyUTXO(stagingArea, block, blockHash, pastUTXO, acceptanceData, multiset)
+}
+
 func (csm *consensusStateManager) verifyUTXO(stagingArea *model.StagingArea, block *externalapi.DomainBlock,
 	blockHash *externalapi.DomainHash, pastUTXODiff externalapi.UTXODiff, acceptanceData externalapi.AcceptanceData,
 	multiset model.Multiset) error {
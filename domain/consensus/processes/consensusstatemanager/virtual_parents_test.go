@@ -10,6 +10,7 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensus/model/testapi"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/testutils"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
 )
 
 func TestConsensusStateManager_pickVirtualParents(t *testing.T) {
@@ -110,3 +111,97 @@ func TestConsensusStateManager_pickVirtualParents(t *testing.T) {
 		}
 	})
 }
+
+// addAdversarialTips adds to tc an honest selected chain of the given length, honestTipCount honest tips
+// on top of it, and adversarialTipCount tips pointing directly at genesis. With a small enough merge depth the
+// adversarial tips break the bounded merge set if they are merged by the virtual.
+func addAdversarialTips(tb testing.TB, tc testapi.TestConsensus, genesisHash *externalapi.DomainHash,
+	chainLength int, honestTipCount int, adversarialTipCount int) (honestTips []*externalapi.DomainHash) {
+
+	chainTip := genesisHash
+	for i := 0; i < chainLength; i++ {
+		var err error
+		chainTip, _, err = tc.AddBlock([]*externalapi.DomainHash{chainTip}, nil, nil)
+		if err != nil {
+			tb.Fatalf("AddBlock: %+v", err)
+		}
+	}
+
+	honestTips = make([]*externalapi.DomainHash, 0, honestTipCount)
+	for i := 0; i < honestTipCount; i++ {
+		honestTip, _, err := tc.AddBlock([]*externalapi.DomainHash{chainTip}, nil, nil)
+		if err != nil {
+			tb.Fatalf("AddBlock: %+v", err)
+		}
+		honestTips = append(honestTips, honestTip)
+	}
+
+	for i := 0; i < adversarialTipCount; i++ {
+		_, _, err := tc.AddBlock([]*externalapi.DomainHash{genesisHash}, nil, nil)
+		if err != nil {
+			tb.Fatalf("AddBlock: %+v", err)
+		}
+	}
+
+	return honestTips
+}
+
+func TestPickVirtualParentsRefillsBoundedMergeBreakingParents(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.K = 3
+		consensusConfig.MergeDepth = 10
+
+		tc, teardown, err := consensus.NewFactory().NewTestConsensus(consensusConfig,
+			"TestPickVirtualParentsRefillsBoundedMergeBreakingParents")
+		if err != nil {
+			t.Fatalf("Error setting up tc: %+v", err)
+		}
+		defer teardown(false)
+
+		honestTips := addAdversarialTips(t, tc, consensusConfig.GenesisHash, 2*int(consensusConfig.MergeDepth),
+			int(consensusConfig.MaxBlockParents), int(consensusConfig.MaxBlockParents))
+
+		stagingArea := model.NewStagingArea()
+		virtualRelations, err := tc.BlockRelationStore().BlockRelation(tc.DatabaseContext(), stagingArea, model.VirtualBlockHash)
+		if err != nil {
+			t.Fatalf("BlockRelation: %+v", err)
+		}
+
+		// All the adversarial tips are expected to be replaced by honest tips
+		sort.Sort(testutils.NewTestGhostDAGSorter(stagingArea, honestTips, tc, t))
+		virtualParents := virtualRelations.Parents
+		sort.Sort(testutils.NewTestGhostDAGSorter(stagingArea, virtualParents, tc, t))
+		if !externalapi.HashesEqual(virtualParents, honestTips) {
+			t.Fatalf("Expected the virtual parents to be the honest tips %s, instead got %s", honestTips, virtualParents)
+		}
+	})
+}
+
+func BenchmarkPickVirtualParentsAdversarialTips(b *testing.B) {
+	consensusConfig := &consensus.Config{Params: dagconfig.DevnetParams}
+	consensusConfig.SkipProofOfWork = true
+	consensusConfig.K = 3
+	consensusConfig.MergeDepth = 10
+
+	tc, teardown, err := consensus.NewFactory().NewTestConsensus(consensusConfig, "BenchmarkPickVirtualParentsAdversarialTips")
+	if err != nil {
+		b.Fatalf("Error setting up tc: %+v", err)
+	}
+	defer teardown(false)
+
+	addAdversarialTips(b, tc, consensusConfig.GenesisHash, 2*int(consensusConfig.MergeDepth),
+		int(consensusConfig.MaxBlockParents), 2*int(consensusConfig.MaxBlockParents))
+
+	tips, err := tc.Tips()
+	if err != nil {
+		b.Fatalf("Tips: %+v", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := tc.ConsensusStateManager().PickVirtualParents(model.NewStagingArea(), tips)
+		if err != nil {
+			b.Fatalf("PickVirtualParents: %+v", err)
+		}
+	}
+}
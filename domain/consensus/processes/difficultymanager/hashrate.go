@@ -1,11 +1,13 @@
 package difficultymanager
 
 import (
+	"math"
 	"math/big"
 
 	"github.com/kaspanet/kaspad/domain/consensus/model"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/util/difficulty"
 	"github.com/pkg/errors"
 )
 
@@ -25,50 +27,58 @@ func (dm *difficultyManager) estimateNetworkHashesPerSecond(stagingArea *model.S
 		return 0, errors.Errorf("windowSize must be equal to or greater than %d", minWindowSize)
 	}
 
-	blockWindow, windowHashes, err := dm.blockWindow(stagingArea, startHash, windowSize)
+	blockWindow, _, err := dm.blockWindow(stagingArea, startHash, windowSize)
 	if err != nil {
 		return 0, err
 	}
+	return blockWindow.hashesPerSecond()
+}
+
+// hashesPerSecond estimates the rate at which the blocks in the window were mined,
+// in hashes per second. The estimate is the total work of the blocks in the window
+// divided by the time it took to mine them.
+//
+// All the blocks in the window are counted, including red ones. Red blocks are
+// mined in parallel to blue blocks, and the hashes spent on them are part of the
+// network's hashrate. Counting blue work alone would underestimate the hashrate
+// by the rate of parallel blocks.
+func (window blockWindow) hashesPerSecond() (uint64, error) {
+	// The window is padded with genesis if there aren't enough blocks
+	// in the past of its starting block
+	uniqueBlocks := make(blockWindow, 0, len(window))
+	seen := make(map[externalapi.DomainHash]struct{}, len(window))
+	for _, block := range window {
+		if _, ok := seen[*block.hash]; ok {
+			continue
+		}
+		seen[*block.hash] = struct{}{}
+		uniqueBlocks = append(uniqueBlocks, block)
+	}
 
 	// return 0 if no blocks had been mined yet
-	if len(windowHashes) == 0 {
+	if len(uniqueBlocks) < 2 {
 		return 0, nil
 	}
 
-	minWindowTimestamp, maxWindowTimestamp, _ := blockWindow.minMaxTimestamps()
+	minWindowTimestamp, maxWindowTimestamp, minIndex := uniqueBlocks.minMaxTimestamps()
 	if minWindowTimestamp == maxWindowTimestamp {
 		return 0, errors.Errorf("min window timestamp is equal to the max window timestamp")
 	}
 
-	firstHash := windowHashes[0]
-	firstBlockGHOSTDAGData, err := dm.ghostdagStore.Get(dm.databaseContext, stagingArea, firstHash, false)
-	if err != nil {
-		return 0, err
-	}
-	firstBlockBlueWork := firstBlockGHOSTDAGData.BlueWork()
-	minWindowBlueWork := firstBlockBlueWork
-	maxWindowBlueWork := firstBlockBlueWork
-	for _, hash := range windowHashes[1:] {
-		blockGHOSTDAGData, err := dm.ghostdagStore.Get(dm.databaseContext, stagingArea, hash, false)
-		if err != nil {
-			return 0, err
-		}
-		blockBlueWork := blockGHOSTDAGData.BlueWork()
-		if blockBlueWork.Cmp(minWindowBlueWork) < 0 {
-			minWindowBlueWork = blockBlueWork
-		}
-		if blockBlueWork.Cmp(maxWindowBlueWork) > 0 {
-			maxWindowBlueWork = blockBlueWork
+	// The work of the earliest block was done before the timespan of the window began
+	totalWork := new(big.Int)
+	for i, block := range uniqueBlocks {
+		if i == minIndex {
+			continue
 		}
+		totalWork.Add(totalWork, difficulty.CalcWork(block.Bits))
 	}
 
-	windowsDiff := (maxWindowTimestamp - minWindowTimestamp) / 1000 // Divided by 1000 to convert milliseconds to seconds
-	if windowsDiff == 0 {
-		return 0, nil
+	const millisecondsInSecond = 1000
+	hashesPerSecond := totalWork.Mul(totalWork, big.NewInt(millisecondsInSecond))
+	hashesPerSecond.Div(hashesPerSecond, big.NewInt(maxWindowTimestamp-minWindowTimestamp))
+	if !hashesPerSecond.IsUint64() {
+		return math.MaxUint64, nil
 	}
-
-	nominator := new(big.Int).Sub(maxWindowBlueWork, minWindowBlueWork)
-	denominator := big.NewInt(windowsDiff)
-	networkHashesPerSecondBigInt := new(big.Int).Div(nominator, denominator)
-	return networkHashesPerSecondBigInt.Uint64(), nil
+	return hashesPerSecond.Uint64(), nil
 }
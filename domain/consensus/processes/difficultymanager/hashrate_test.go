@@ -0,0 +1,70 @@
+package difficultymanager
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/util/difficulty"
+)
+
+func TestHashesPerSecond(t *testing.T) {
+	const bits = 0x1e7fffff
+	work := difficulty.CalcWork(bits)
+
+	newBlock := func(hashByte byte, timeInMilliseconds int64) difficultyBlock {
+		return difficultyBlock{
+			timeInMilliseconds: timeInMilliseconds,
+			Bits:               bits,
+			hash:               externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{hashByte}),
+			blueWork:           big.NewInt(int64(hashByte)),
+		}
+	}
+	expectedHashesPerSecond := func(blockCount int64, timespanInMilliseconds int64) uint64 {
+		totalWork := new(big.Int).Mul(work, big.NewInt(blockCount*1000))
+		return totalWork.Div(totalWork, big.NewInt(timespanInMilliseconds)).Uint64()
+	}
+
+	tests := []struct {
+		name     string
+		window   blockWindow
+		expected uint64
+	}{
+		{
+			name:     "only genesis",
+			window:   blockWindow{newBlock(0, 0), newBlock(0, 0), newBlock(0, 0)},
+			expected: 0,
+		},
+		{
+			name:     "chain",
+			window:   blockWindow{newBlock(1, 1000), newBlock(2, 2000), newBlock(3, 3000), newBlock(4, 4000)},
+			expected: expectedHashesPerSecond(3, 3000),
+		},
+		{
+			name: "parallel blocks",
+			window: blockWindow{newBlock(1, 1000), newBlock(2, 2000), newBlock(3, 2000),
+				newBlock(4, 3000), newBlock(5, 3000), newBlock(6, 4000)},
+			expected: expectedHashesPerSecond(5, 3000),
+		},
+		{
+			name:     "padded with genesis",
+			window:   blockWindow{newBlock(0, 0), newBlock(0, 0), newBlock(1, 500), newBlock(2, 1500)},
+			expected: expectedHashesPerSecond(2, 1500),
+		},
+	}
+
+	for _, test := range tests {
+		hashesPerSecond, err := test.window.hashesPerSecond()
+		if err != nil {
+			t.Fatalf("%s: hashesPerSecond: %+v", test.name, err)
+		}
+		if hashesPerSecond != test.expected {
+			t.Errorf("%s: expected %d hashes per second but got %d", test.name, test.expected, hashesPerSecond)
+		}
+	}
+
+	_, err := blockWindow{newBlock(1, 1000), newBlock(2, 1000)}.hashesPerSecond()
+	if err == nil {
+		t.Errorf("Expected an error for a window with no timespan")
+	}
+}
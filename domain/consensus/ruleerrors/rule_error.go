@@ -226,6 +226,10 @@ var (
 
 	ErrPruningPointSelectedChildDisqualifiedFromChain = newRuleError("ErrPruningPointSelectedChildDisqualifiedFromChain")
 
+	// ErrSelectedParentDisqualifiedFromChain indicates that a block's selected parent
+	// is disqualified from chain, so the block cannot be UTXO-verified
+	ErrSelectedParentDisqualifiedFromChain = newRuleError("ErrSelectedParentDisqualifiedFromChain")
+
 	// ErrUnexpectedFinalityPoint indicates a block header pruning point does not align with
 	// the expected value.
 	ErrUnexpectedHeaderPruningPoint = newRuleError("ErrUnexpectedHeaderPruningPoint")
@@ -263,6 +267,11 @@ func (e RuleError) Error() string {
 	return e.message
 }
 
+// Name returns the name of the violated rule, e.g. ErrBadMerkleRoot
+func (e RuleError) Name() string {
+	return e.message
+}
+
 // Unwrap satisfies the errors.Unwrap interface
 func (e RuleError) Unwrap() error {
 	return e.inner
@@ -1,6 +1,8 @@
 package lrucache
 
 import (
+	"sync/atomic"
+
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 )
 
@@ -9,6 +11,11 @@ import (
 type LRUCache struct {
 	cache    map[externalapi.DomainHash]interface{}
 	capacity int
+
+	// hits and misses count the calls to Get. They're accessed
+	// atomically, so that they may be read while the cache is in use
+	hits   uint64
+	misses uint64
 }
 
 // New creates a new LRUCache
@@ -38,8 +45,10 @@ func (c *LRUCache) Add(key *externalapi.DomainHash, value interface{}) {
 func (c *LRUCache) Get(key *externalapi.DomainHash) (interface{}, bool) {
 	value, ok := c.cache[*key]
 	if !ok {
+		atomic.AddUint64(&c.misses, 1)
 		return nil, false
 	}
+	atomic.AddUint64(&c.hits, 1)
 	return value, true
 }
 
@@ -55,6 +64,17 @@ func (c *LRUCache) Remove(key *externalapi.DomainHash) {
 	delete(c.cache, *key)
 }
 
+// Stats returns the statistics of the calls to Get. It's safe
+// to call while the cache is in use
+func (c *LRUCache) Stats(name string) *externalapi.CacheStats {
+	return &externalapi.CacheStats{
+		Name:     name,
+		Capacity: c.capacity,
+		Hits:     atomic.LoadUint64(&c.hits),
+		Misses:   atomic.LoadUint64(&c.misses),
+	}
+}
+
 func (c *LRUCache) evictRandom() {
 	var keyToEvict externalapi.DomainHash
 	for key := range c.cache {
@@ -98,3 +98,62 @@ func merkleRoot(hashes []*externalapi.DomainHash) *externalapi.DomainHash {
 
 	return merkles[len(merkles)-1]
 }
+
+// CalculateHashMerkleBranch returns the merkle branch proving that the transaction at the
+// given index is included in the hash merkle root of the given transactions. The branch
+// consists of the sibling hashes along the path from the transaction hash up to the root,
+// ordered from the bottom of the tree up.
+// See `CalculateMerkleRootFromBranch` for how the branch is verified.
+func CalculateHashMerkleBranch(transactions []*externalapi.DomainTransaction, index int) []*externalapi.DomainHash {
+	// Build the bottom level of the tree, padded to the next power of two with nil
+	// nodes, the same way `merkleRoot` does.
+	level := make([]*externalapi.DomainHash, nextPowerOfTwo(len(transactions)))
+	for i, tx := range transactions {
+		level[i] = consensushashing.TransactionHash(tx)
+	}
+
+	var branch []*externalapi.DomainHash
+	for len(level) > 1 {
+		sibling := level[index^1]
+		// A missing right child is hashed as zeros
+		if sibling == nil {
+			sibling = &externalapi.DomainHash{}
+		}
+		branch = append(branch, sibling)
+
+		nextLevel := make([]*externalapi.DomainHash, len(level)/2)
+		for i := range nextLevel {
+			left, right := level[2*i], level[2*i+1]
+			switch {
+			case left == nil:
+				nextLevel[i] = nil
+			case right == nil:
+				nextLevel[i] = hashMerkleBranches(left, &externalapi.DomainHash{})
+			default:
+				nextLevel[i] = hashMerkleBranches(left, right)
+			}
+		}
+		level = nextLevel
+		index /= 2
+	}
+
+	return branch
+}
+
+// CalculateMerkleRootFromBranch calculates the merkle root implied by the given leaf
+// hash at the given index and its merkle branch, as returned by `CalculateHashMerkleBranch`.
+// The leaf is included in a tree iff the result equals that tree's merkle root.
+func CalculateMerkleRootFromBranch(leafHash *externalapi.DomainHash, index int,
+	branch []*externalapi.DomainHash) *externalapi.DomainHash {
+
+	current := leafHash
+	for _, sibling := range branch {
+		if index%2 == 0 {
+			current = hashMerkleBranches(current, sibling)
+		} else {
+			current = hashMerkleBranches(sibling, current)
+		}
+		index /= 2
+	}
+	return current
+}
@@ -0,0 +1,39 @@
+package merkle
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+)
+
+func TestCalculateHashMerkleBranch(t *testing.T) {
+	for numTransactions := 1; numTransactions <= 9; numTransactions++ {
+		transactions := make([]*externalapi.DomainTransaction, numTransactions)
+		for i := range transactions {
+			transactions[i] = &externalapi.DomainTransaction{
+				Inputs:  []*externalapi.DomainTransactionInput{},
+				Outputs: []*externalapi.DomainTransactionOutput{},
+				Payload: []byte{byte(i)},
+			}
+		}
+		root := CalculateHashMerkleRoot(transactions)
+
+		for index, transaction := range transactions {
+			branch := CalculateHashMerkleBranch(transactions, index)
+			leafHash := consensushashing.TransactionHash(transaction)
+			if !CalculateMerkleRootFromBranch(leafHash, index, branch).Equal(root) {
+				t.Fatalf("The branch of transaction %d out of %d doesn't lead to the merkle root",
+					index, numTransactions)
+			}
+
+			if numTransactions > 1 {
+				otherIndex := (index + 1) % numTransactions
+				if CalculateMerkleRootFromBranch(leafHash, otherIndex, branch).Equal(root) {
+					t.Fatalf("The branch of transaction %d out of %d leads to the merkle root at index %d",
+						index, numTransactions, otherIndex)
+				}
+			}
+		}
+	}
+}
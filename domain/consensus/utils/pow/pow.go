@@ -41,6 +41,18 @@ func NewState(header externalapi.MutableBlockHeader) *State {
 	}
 }
 
+// NewStateFromPrePowHash creates a new state from the values external miners receive
+// as work, without requiring the full header. SubmitWork uses it to check a nonce
+// against the work it was solved for
+func NewStateFromPrePowHash(prePowHash *externalapi.DomainHash, timestamp int64, target *big.Int) *State {
+	return &State{
+		Target:     *target,
+		prePowHash: *prePowHash,
+		mat:        *generateMatrix(prePowHash),
+		Timestamp:  timestamp,
+	}
+}
+
 // CalculateProofOfWorkValue hashes the internal header and returns its big.Int value
 func (state *State) CalculateProofOfWorkValue() *big.Int {
 	// PRE_POW_HASH || TIME || 32 zero byte padding || NONCE
@@ -61,6 +73,13 @@ func (state *State) CalculateProofOfWorkValue() *big.Int {
 	return toBig(heavyHash)
 }
 
+// PrePowHash returns the hash of the header with its timestamp and nonce zeroed out,
+// which is what external miners hash together with the timestamp and nonce
+func (state *State) PrePowHash() *externalapi.DomainHash {
+	prePowHash := state.prePowHash
+	return &prePowHash
+}
+
 // IncrementNonce the nonce in State by 1
 func (state *State) IncrementNonce() {
 	state.Nonce++
@@ -0,0 +1,89 @@
+package subnetworks
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// Filter is the set of subnetworks that a node serves in some role, such as
+// validating or relaying their transactions. The native and built-in
+// subnetworks are served by every node, so they always pass a filter. A nil
+// Filter passes every subnetwork.
+type Filter map[externalapi.DomainSubnetworkID]struct{}
+
+// NewFilter returns a Filter of the given subnetworks. It returns nil, which
+// passes every subnetwork, if no subnetworks are given
+func NewFilter(subnetworkIDs []*externalapi.DomainSubnetworkID) Filter {
+	if len(subnetworkIDs) == 0 {
+		return nil
+	}
+	filter := make(Filter, len(subnetworkIDs))
+	for _, subnetworkID := range subnetworkIDs {
+		filter[*subnetworkID] = struct{}{}
+	}
+	return filter
+}
+
+// Passes returns whether the given subnetwork passes the filter
+func (f Filter) Passes(subnetworkID externalapi.DomainSubnetworkID) bool {
+	if f == nil || IsBuiltInOrNative(subnetworkID) {
+		return true
+	}
+	_, ok := f[subnetworkID]
+	return ok
+}
+
+// IsSubsetOf returns whether every subnetwork that passes the filter
+// also passes the other filter
+func (f Filter) IsSubsetOf(other Filter) bool {
+	if other == nil {
+		return true
+	}
+	if f == nil {
+		return false
+	}
+	for subnetworkID := range f {
+		if !other.Passes(subnetworkID) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal returns whether the same subnetworks pass both filters
+func (f Filter) Equal(other Filter) bool {
+	return f.IsSubsetOf(other) && other.IsSubsetOf(f)
+}
+
+// SubnetworkIDs returns the subnetworks of the filter, in ascending order.
+// It returns nil if the filter passes every subnetwork
+func (f Filter) SubnetworkIDs() []*externalapi.DomainSubnetworkID {
+	if f == nil {
+		return nil
+	}
+	subnetworkIDs := make([]*externalapi.DomainSubnetworkID, 0, len(f))
+	for subnetworkID := range f {
+		subnetworkID := subnetworkID
+		subnetworkIDs = append(subnetworkIDs, &subnetworkID)
+	}
+	sort.Slice(subnetworkIDs, func(i, j int) bool {
+		return Less(*subnetworkIDs[i], *subnetworkIDs[j])
+	})
+	return subnetworkIDs
+}
+
+// String returns the subnetworks of the filter, separated by commas,
+// or "all" if the filter passes every subnetwork
+func (f Filter) String() string {
+	if f == nil {
+		return "all"
+	}
+	subnetworkIDs := f.SubnetworkIDs()
+	subnetworkIDStrings := make([]string, len(subnetworkIDs))
+	for i, subnetworkID := range subnetworkIDs {
+		subnetworkIDStrings[i] = subnetworkID.String()
+	}
+	return strings.Join(subnetworkIDStrings, ",")
+}
@@ -0,0 +1,57 @@
+package txscript
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/txmass"
+)
+
+// IsDustOutput returns whether or not the passed transaction output amount
+// is considered dust or not based on the passed minimum transaction relay fee
+// rate. The rate is applied in sompi per 1000 bytes of the serialized size of
+// the output and of an input that spends it. The mempool passes its minimum
+// relay fee, which it otherwise charges per 1000 grams of mass, as is. Dust is
+// defined in terms of the minimum transaction relay fee. In particular, if the
+// cost to the network to spend coins is more than 1/3 of the minimum
+// transaction relay fee, it is considered dust.
+//
+// This is the dust policy of the mempool, which rejects transactions with
+// dust outputs, so wallets can use it to check their outputs beforehand.
+func IsDustOutput(output *externalapi.DomainTransactionOutput, minRelayFeeRate util.Amount) bool {
+	// Unspendable outputs are considered dust.
+	if IsUnspendable(output.ScriptPublicKey.Script) {
+		return true
+	}
+
+	// The total serialized size consists of the output and the associated
+	// input to redeem it. Since there is no input to redeem it yet, a fixed
+	// size of 148 bytes is used for it.
+	//
+	// Pay-to-pubkey bytes breakdown:
+	//
+	//  Output to pubkey (52 bytes):
+	//   8 value, 2 script version, 8 script len, 34 script [1 OP_DATA_32,
+	//   32 pubkey, 1 OP_CHECKSIG]
+	//
+	//  Input (118 bytes):
+	//   36 prev outpoint, 8 script len, 66 script [1 OP_DATA_65,
+	//   64 sig, 1 sighash type], 8 sequence
+	//
+	// The most common scripts are pay-to-pubkey, and the 148 bytes cover
+	// the 118 bytes of a pay-to-pubkey input.
+	totalSerializedSize := txmass.TransactionOutputEstimatedSerializedSize(output) + 148
+
+	// The output is considered dust if the cost to the network to spend the
+	// coins is more than 1/3 of the minimum free transaction relay fee.
+	// minRelayFeeRate is in sompi per 1000 bytes, so the value per byte is
+	// multiplied by 1000 to compare it with the rate.
+	//
+	// Using the 52 bytes of a pay-to-pubkey output from the breakdown above,
+	// the total size is 200 bytes. With the default minimum transaction
+	// relay fee of 1000, this equates to values less than 3 * 200 = 600
+	// sompi being considered dust.
+	//
+	// The following is equivalent to (value/totalSerializedSize) * (1/3) * 1000
+	// without needing to do floating point math.
+	return output.Value*1000/(3*totalSerializedSize) < uint64(minRelayFeeRate)
+}
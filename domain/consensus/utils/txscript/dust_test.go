@@ -0,0 +1,47 @@
+package txscript
+
+import (
+	"math"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
+	"github.com/kaspanet/kaspad/util"
+)
+
+func TestIsDustOutput(t *testing.T) {
+	address, err := util.DecodeAddress("kaspa:qr35ennsep3hxfe7lnz5ee7j5jgmkjswsn35ennsep3hxfe7ln35cdv0dy335",
+		util.Bech32PrefixKaspa)
+	if err != nil {
+		t.Fatalf("DecodeAddress: %s", err)
+	}
+	scriptPublicKey, err := PayToAddrScript(address)
+	if err != nil {
+		t.Fatalf("PayToAddrScript: %s", err)
+	}
+
+	tests := []struct {
+		name            string
+		value           uint64
+		scriptPublicKey *externalapi.ScriptPublicKey
+		minRelayFeeRate util.Amount
+		isDust          bool
+	}{
+		{"zero value with zero relay fee", 0, scriptPublicKey, 0, false},
+		{"zero value with very small relay fee", 0, scriptPublicKey, 1, true},
+		{"pay-to-pubkey with value 599", 599, scriptPublicKey, 1000, true},
+		{"pay-to-pubkey with value 600", 600, scriptPublicKey, 1000, false},
+		{"max sompi amount is never dust", constants.MaxSompi, scriptPublicKey, 1000, false},
+		{"maximum uint64 value", math.MaxUint64, scriptPublicKey, math.MaxUint64, true},
+		{"OP_RETURN", 5000, &externalapi.ScriptPublicKey{Script: []byte{OpReturn}}, 0, true},
+		{"malformed push", 5000, &externalapi.ScriptPublicKey{Script: []byte{OpData2, 1}}, 0, true},
+	}
+
+	for _, test := range tests {
+		output := &externalapi.DomainTransactionOutput{Value: test.value, ScriptPublicKey: test.scriptPublicKey}
+		isDust := IsDustOutput(output, test.minRelayFeeRate)
+		if isDust != test.isDust {
+			t.Errorf("%s: expected IsDustOutput to return %t, but got %t", test.name, test.isDust, isDust)
+		}
+	}
+}
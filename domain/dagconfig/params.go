@@ -139,6 +139,10 @@ type Params struct {
 	// Address encoding magics
 	PrivateKeyID byte // First byte of a WIF private key
 
+	// BIP32 hierarchical deterministic extended key magics
+	HDPrivateKeyID [4]byte
+	HDPublicKeyID  [4]byte
+
 	// EnableNonNativeSubnetworks enables non-native/coinbase transactions
 	EnableNonNativeSubnetworks bool
 
@@ -267,6 +271,10 @@ var MainnetParams = Params{
 	// Address encoding magics
 	PrivateKeyID: 0x80, // starts with 5 (uncompressed) or K (compressed)
 
+	// BIP32 hierarchical deterministic extended key magics
+	HDPrivateKeyID: [4]byte{0x03, 0x8f, 0x2e, 0xf4}, // starts with kprv
+	HDPublicKeyID:  [4]byte{0x03, 0x8f, 0x33, 0x2e}, // starts with kpub
+
 	// EnableNonNativeSubnetworks enables non-native/coinbase transactions
 	EnableNonNativeSubnetworks: false,
 
@@ -336,6 +344,10 @@ var TestnetParams = Params{
 	// Address encoding magics
 	PrivateKeyID: 0xef, // starts with 9 (uncompressed) or c (compressed)
 
+	// BIP32 hierarchical deterministic extended key magics
+	HDPrivateKeyID: [4]byte{0x03, 0x90, 0x9e, 0x07}, // starts with ktrv
+	HDPublicKeyID:  [4]byte{0x03, 0x90, 0xa2, 0x41}, // starts with ktub
+
 	// EnableNonNativeSubnetworks enables non-native/coinbase transactions
 	EnableNonNativeSubnetworks: false,
 
@@ -399,6 +411,11 @@ var SimnetParams = Params{
 	AcceptUnroutable: false,
 
 	PrivateKeyID: 0x64, // starts with 4 (uncompressed) or F (compressed)
+
+	// BIP32 hierarchical deterministic extended key magics
+	HDPrivateKeyID: [4]byte{0x03, 0x90, 0x42, 0x42}, // starts with ksrv
+	HDPublicKeyID:  [4]byte{0x03, 0x90, 0x46, 0x7d}, // starts with ksub
+
 	// Human-readable part for Bech32 encoded addresses
 	Prefix: util.Bech32PrefixKaspaSim,
 
@@ -464,6 +481,10 @@ var DevnetParams = Params{
 	// Address encoding magics
 	PrivateKeyID: 0xef, // starts with 9 (uncompressed) or c (compressed)
 
+	// BIP32 hierarchical deterministic extended key magics
+	HDPrivateKeyID: [4]byte{0x03, 0x8b, 0x3d, 0x80}, // starts with kdrv
+	HDPublicKeyID:  [4]byte{0x03, 0x8b, 0x41, 0xba}, // starts with kdub
+
 	// EnableNonNativeSubnetworks enables non-native/coinbase transactions
 	EnableNonNativeSubnetworks: false,
 
@@ -489,7 +510,18 @@ var DevnetParams = Params{
 // network or previously-registered into this package.
 var ErrDuplicateNet = errors.New("duplicate Kaspa network")
 
-var registeredNets = make(map[appmessage.KaspaNet]struct{})
+var (
+	registeredNets = make(map[appmessage.KaspaNet]struct{})
+
+	// hdPrivateToPublicKeyIDs maps the HD private key IDs of the registered
+	// networks to their HD public key IDs
+	hdPrivateToPublicKeyIDs = make(map[[4]byte][4]byte)
+)
+
+// ErrUnknownHDKeyID describes an error where the provided id which
+// is intended to identify the network for a hierarchical deterministic
+// private extended key is not registered.
+var ErrUnknownHDKeyID = errors.New("unknown hd private extended key bytes")
 
 // Register registers the network parameters for a Kaspa network. This may
 // error with ErrDuplicateNet if the network is already registered (either
@@ -505,10 +537,22 @@ func Register(params *Params) error {
 		return ErrDuplicateNet
 	}
 	registeredNets[params.Net] = struct{}{}
+	hdPrivateToPublicKeyIDs[params.HDPrivateKeyID] = params.HDPublicKeyID
 
 	return nil
 }
 
+// HDPrivateKeyToPublicKeyID accepts a private hierarchical deterministic
+// extended key id and returns the associated public key id. When the provided
+// id is not registered, the ErrUnknownHDKeyID error will be returned.
+func HDPrivateKeyToPublicKeyID(id [4]byte) ([4]byte, error) {
+	publicKeyID, ok := hdPrivateToPublicKeyIDs[id]
+	if !ok {
+		return [4]byte{}, ErrUnknownHDKeyID
+	}
+	return publicKeyID, nil
+}
+
 // mustRegister performs the same function as Register except it panics if there
 // is an error. This should only be called from package init functions.
 func mustRegister(params *Params) {
@@ -10,8 +10,10 @@ import (
 // network. This is necessary to test the registration of and
 // lookup of encoding magics from the network.
 var mockNetParams = Params{
-	Name: "mocknet",
-	Net:  1<<32 - 1,
+	Name:           "mocknet",
+	Net:            1<<32 - 1,
+	HDPrivateKeyID: [4]byte{0x01, 0x02, 0x03, 0x04},
+	HDPublicKeyID:  [4]byte{0x05, 0x06, 0x07, 0x08},
 }
 
 func TestRegister(t *testing.T) {
@@ -21,9 +23,16 @@ func TestRegister(t *testing.T) {
 		err    error
 	}
 
+	type hdKeyIDTest struct {
+		privateKeyID [4]byte
+		publicKeyID  [4]byte
+		err          error
+	}
+
 	tests := []struct {
 		name     string
 		register []registerTest
+		hdKeyIDs []hdKeyIDTest
 	}{
 		{
 			name: "default networks",
@@ -44,6 +53,32 @@ func TestRegister(t *testing.T) {
 					err:    ErrDuplicateNet,
 				},
 			},
+			hdKeyIDs: []hdKeyIDTest{
+				{
+					privateKeyID: MainnetParams.HDPrivateKeyID,
+					publicKeyID:  MainnetParams.HDPublicKeyID,
+					err:          nil,
+				},
+				{
+					privateKeyID: TestnetParams.HDPrivateKeyID,
+					publicKeyID:  TestnetParams.HDPublicKeyID,
+					err:          nil,
+				},
+				{
+					privateKeyID: SimnetParams.HDPrivateKeyID,
+					publicKeyID:  SimnetParams.HDPublicKeyID,
+					err:          nil,
+				},
+				{
+					privateKeyID: DevnetParams.HDPrivateKeyID,
+					publicKeyID:  DevnetParams.HDPublicKeyID,
+					err:          nil,
+				},
+				{
+					privateKeyID: mockNetParams.HDPrivateKeyID,
+					err:          ErrUnknownHDKeyID,
+				},
+			},
 		},
 		{
 			name: "register mocknet",
@@ -54,6 +89,13 @@ func TestRegister(t *testing.T) {
 					err:    nil,
 				},
 			},
+			hdKeyIDs: []hdKeyIDTest{
+				{
+					privateKeyID: mockNetParams.HDPrivateKeyID,
+					publicKeyID:  mockNetParams.HDPublicKeyID,
+					err:          nil,
+				},
+			},
 		},
 		{
 			name: "more duplicates",
@@ -91,5 +133,16 @@ func TestRegister(t *testing.T) {
 					network.name, network.name, err, network.err)
 			}
 		}
+		for i, hdKeyID := range test.hdKeyIDs {
+			publicKeyID, err := HDPrivateKeyToPublicKeyID(hdKeyID.privateKeyID)
+			if err != hdKeyID.err {
+				t.Errorf("%s: HD key ID test #%d: got error %v expected %v", test.name, i, err, hdKeyID.err)
+				continue
+			}
+			if publicKeyID != hdKeyID.publicKeyID {
+				t.Errorf("%s: HD key ID test #%d: got public key ID %x expected %x",
+					test.name, i, publicKeyID, hdKeyID.publicKeyID)
+			}
+		}
 	}
 }
@@ -218,7 +218,7 @@ func New(consensusConfig *consensus.Config, mempoolConfig *mempool.Config, db in
 		}
 	}
 
-	miningManagerFactory := miningmanager.NewFactory()
+	miningManagerFactory := miningmanager.NewFactoryWithClock(consensusConfig.ClockOrSystemClock())
 
 	// We create a consensus wrapper because the actual consensus might change
 	consensusReference := consensusreference.NewConsensusReference(&domainInstance.consensus)
@@ -0,0 +1,106 @@
+package indexes
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+// The names of the optional indexes, which are also the names of their namespaces
+const (
+	UTXOIndexName    = "utxoindex"
+	AddressIndexName = "addressindex"
+)
+
+// dropBatchSize is the amount of keys deleted in every
+// database transaction while a namespace is dropped
+const dropBatchSize = 10_000
+
+var indexesBucket = database.MakeBucket([]byte("indexes"))
+
+// Namespace is the part of the database in which an optional index keeps all of
+// its data, apart from the consensus data. It may be dropped without affecting
+// anything outside of it
+type Namespace struct {
+	name   string
+	bucket *database.Bucket
+}
+
+// NewNamespace returns the namespace of the index with the given name
+func NewNamespace(name string) *Namespace {
+	return &Namespace{
+		name:   name,
+		bucket: indexesBucket.Bucket([]byte(name)),
+	}
+}
+
+// Name returns the name of the index the namespace belongs to
+func (n *Namespace) Name() string {
+	return n.name
+}
+
+// Bucket returns the bucket with the given name inside the namespace
+func (n *Namespace) Bucket(name []byte) *database.Bucket {
+	return n.bucket.Bucket(name)
+}
+
+// Key returns the key with the given suffix inside the namespace
+func (n *Namespace) Key(suffix []byte) *database.Key {
+	return n.bucket.Key(suffix)
+}
+
+// TipKey returns the key under which the index records the point up to which
+// it's synced. An index with no tip is rebuilt, so the tip is written last
+// while the index is built, and deleted first while it's dropped
+func (n *Namespace) TipKey() *database.Key {
+	return n.bucket.Key([]byte("tip"))
+}
+
+// Drop deletes all the data in the namespace. It returns the amount of deleted keys
+func (n *Namespace) Drop(db database.Database) (int, error) {
+	err := db.Delete(n.TipKey())
+	if err != nil {
+		return 0, err
+	}
+
+	cursor, err := db.Cursor(n.bucket)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close()
+
+	dbTx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = dbTx.RollbackUnlessClosed()
+	}()
+
+	deletedCount := 0
+	for ok := cursor.First(); ok; ok = cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return deletedCount, err
+		}
+		// The cursor may change the key once it's moved, while
+		// transactions may keep what they're given until they're committed
+		suffix := make([]byte, len(key.Suffix()))
+		copy(suffix, key.Suffix())
+		err = dbTx.Delete(n.bucket.Key(suffix))
+		if err != nil {
+			return deletedCount, err
+		}
+		deletedCount++
+
+		if deletedCount%dropBatchSize == 0 {
+			err = dbTx.Commit()
+			if err != nil {
+				return deletedCount, err
+			}
+			dbTx, err = db.Begin()
+			if err != nil {
+				return deletedCount, err
+			}
+		}
+	}
+	return deletedCount, dbTx.Commit()
+}
@@ -0,0 +1,74 @@
+package indexes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+)
+
+func TestNamespaceDrop(t *testing.T) {
+	db, err := ldb.NewLevelDB(t.TempDir(), 8)
+	if err != nil {
+		t.Fatalf("Could not create a database: %s", err)
+	}
+	defer db.Close()
+
+	namespace := NewNamespace(UTXOIndexName)
+	otherNamespace := NewNamespace(AddressIndexName)
+	// A key outside of any namespace, such as a consensus key, whose bucket
+	// path starts the same as the namespace path
+	outsideKey := database.MakeBucket([]byte("indexes-outside")).Key([]byte("key"))
+
+	const entryCount = 25
+	for _, ns := range []*Namespace{namespace, otherNamespace} {
+		for i := 0; i < entryCount; i++ {
+			err := db.Put(ns.Bucket([]byte("entries")).Key([]byte(fmt.Sprintf("key%d", i))), []byte{1})
+			if err != nil {
+				t.Fatalf("Put: %s", err)
+			}
+		}
+		err := db.Put(ns.TipKey(), []byte{2})
+		if err != nil {
+			t.Fatalf("Put: %s", err)
+		}
+	}
+	err = db.Put(outsideKey, []byte{3})
+	if err != nil {
+		t.Fatalf("Put: %s", err)
+	}
+
+	deletedCount, err := namespace.Drop(db)
+	if err != nil {
+		t.Fatalf("Drop: %s", err)
+	}
+	if deletedCount != entryCount {
+		t.Fatalf("Unexpected deleted count. Want: %d, got: %d", entryCount, deletedCount)
+	}
+
+	keyCount := func(bucket *database.Bucket) int {
+		bucketStats, err := database.CollectBucketStats(db, bucket, 0)
+		if err != nil {
+			t.Fatalf("CollectBucketStats: %s", err)
+		}
+		count := 0
+		for _, stats := range bucketStats {
+			count += int(stats.KeyCount)
+		}
+		return count
+	}
+	if count := keyCount(namespace.bucket); count != 0 {
+		t.Fatalf("Expected the dropped namespace to be empty, but it has %d keys", count)
+	}
+	if count := keyCount(otherNamespace.bucket); count != entryCount+1 {
+		t.Fatalf("Unexpected key count in the other namespace. Want: %d, got: %d", entryCount+1, count)
+	}
+	hasOutsideKey, err := db.Has(outsideKey)
+	if err != nil {
+		t.Fatalf("Has: %s", err)
+	}
+	if !hasOutsideKey {
+		t.Fatalf("Dropping the namespace deleted a key outside of it")
+	}
+}
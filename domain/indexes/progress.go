@@ -0,0 +1,84 @@
+package indexes
+
+import (
+	"sync"
+	"time"
+)
+
+// The operations whose progress is tracked
+const (
+	OperationRebuild = "rebuild"
+	OperationDrop    = "drop"
+)
+
+// Progress tracks the progress of the operation that's applied to an index
+type Progress struct {
+	lock   sync.Mutex
+	status ProgressStatus
+}
+
+// ProgressStatus is the status of the last operation that was applied to an index
+type ProgressStatus struct {
+	Operation string
+	IsRunning bool
+
+	// Processed is the amount of items the operation processed so far. Total is
+	// the amount of items the operation processes, or 0 if it isn't known
+	Processed uint64
+	Total     uint64
+
+	StartTime time.Time
+	EndTime   time.Time
+	Error     error
+}
+
+// NewProgress returns a new Progress of an index no operation was applied to
+func NewProgress() *Progress {
+	return &Progress{}
+}
+
+// Start marks that the given operation started
+func (p *Progress) Start(operation string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.status = ProgressStatus{
+		Operation: operation,
+		IsRunning: true,
+		StartTime: time.Now(),
+	}
+}
+
+// SetTotal sets the amount of items the running operation processes
+func (p *Progress) SetTotal(total uint64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.status.Total = total
+}
+
+// Advance adds the given amount to the amount of items the running operation processed
+func (p *Progress) Advance(processed uint64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.status.Processed += processed
+}
+
+// Finish marks that the running operation finished with the given error, which is nil if it succeeded
+func (p *Progress) Finish(err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.status.IsRunning = false
+	p.status.EndTime = time.Now()
+	p.status.Error = err
+}
+
+// Status returns the status of the last operation that was applied to the index
+func (p *Progress) Status() ProgressStatus {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.status
+}
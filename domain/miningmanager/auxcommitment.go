@@ -0,0 +1,46 @@
+package miningmanager
+
+import (
+	"bytes"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// AuxCommitmentTag marks an auxiliary chain commitment within a coinbase payload.
+// The tag is immediately followed by the 32 bytes of the commitment.
+var AuxCommitmentTag = []byte("kaux")
+
+// AppendAuxCommitment returns a copy of the given coinbase extra data payload with the
+// given auxiliary chain commitment appended to it, so that sidechains and timestamping
+// services can anchor the commitment into the DAG.
+// The result is meant to be passed to NewCoinbaseData.
+func AppendAuxCommitment(payload []byte, commitment *externalapi.DomainHash) []byte {
+	payloadWithCommitment := make([]byte, 0, len(payload)+len(AuxCommitmentTag)+externalapi.DomainHashSize)
+	payloadWithCommitment = append(payloadWithCommitment, payload...)
+	payloadWithCommitment = append(payloadWithCommitment, AuxCommitmentTag...)
+	return append(payloadWithCommitment, commitment.ByteSlice()...)
+}
+
+// ExtractAuxCommitment returns the auxiliary chain commitment embedded in the given
+// coinbase payload by AppendAuxCommitment. If the tag appears more than once, the last
+// one followed by a complete commitment is used, since the commitment is appended after
+// any other extra data.
+func ExtractAuxCommitment(coinbasePayload []byte) (*externalapi.DomainHash, bool) {
+	end := len(coinbasePayload)
+	for {
+		tagIndex := bytes.LastIndex(coinbasePayload[:end], AuxCommitmentTag)
+		if tagIndex < 0 {
+			return nil, false
+		}
+		commitmentStart := tagIndex + len(AuxCommitmentTag)
+		if commitmentStart+externalapi.DomainHashSize <= len(coinbasePayload) {
+			commitment, err := externalapi.NewDomainHashFromByteSlice(
+				coinbasePayload[commitmentStart : commitmentStart+externalapi.DomainHashSize])
+			if err != nil {
+				return nil, false
+			}
+			return commitment, true
+		}
+		end = tagIndex
+	}
+}
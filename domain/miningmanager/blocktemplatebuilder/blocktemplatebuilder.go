@@ -2,12 +2,12 @@ package blocktemplatebuilder
 
 import (
 	"github.com/kaspanet/kaspad/domain/consensus/processes/coinbasemanager"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/merkle"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
 	"github.com/kaspanet/kaspad/domain/consensusreference"
 	"github.com/kaspanet/kaspad/util/mstime"
 	"math"
-	"sort"
 
 	"github.com/kaspanet/kaspad/util/difficulty"
 
@@ -20,14 +20,10 @@ import (
 
 type candidateTx struct {
 	*consensusexternalapi.DomainTransaction
-	txValue  float64
-	gasLimit uint64
-
-	p     float64
-	start float64
-	end   float64
-
-	isMarkedForDeletion bool
+	id             *consensusexternalapi.DomainTransactionID
+	isHighPriority bool
+	score          float64
+	gasLimit       uint64
 }
 
 // blockTemplateBuilder creates block templates for a miner to consume
@@ -35,17 +31,20 @@ type blockTemplateBuilder struct {
 	consensusReference consensusreference.ConsensusReference
 	mempool            miningmanagerapi.Mempool
 	policy             policy
+	clock              mstime.Clock
 
 	coinbasePayloadScriptPublicKeyMaxLength uint8
 }
 
 // New creates a new blockTemplateBuilder
 func New(consensusReference consensusreference.ConsensusReference, mempool miningmanagerapi.Mempool,
-	blockMaxMass uint64, coinbasePayloadScriptPublicKeyMaxLength uint8) miningmanagerapi.BlockTemplateBuilder {
+	blockMaxMass uint64, coinbasePayloadScriptPublicKeyMaxLength uint8,
+	clock mstime.Clock) miningmanagerapi.BlockTemplateBuilder {
 	return &blockTemplateBuilder{
 		consensusReference: consensusReference,
 		mempool:            mempool,
 		policy:             policy{BlockMaxMass: blockMaxMass},
+		clock:              clock,
 
 		coinbasePayloadScriptPublicKeyMaxLength: coinbasePayloadScriptPublicKeyMaxLength,
 	}
@@ -118,26 +117,23 @@ func New(consensusReference consensusreference.ConsensusReference, mempool minin
 func (btb *blockTemplateBuilder) BuildBlockTemplate(
 	coinbaseData *consensusexternalapi.DomainCoinbaseData) (*consensusexternalapi.DomainBlockTemplate, error) {
 
-	mempoolTransactions := btb.mempool.BlockCandidateTransactions()
-	candidateTxs := make([]*candidateTx, 0, len(mempoolTransactions))
-	for _, tx := range mempoolTransactions {
-		// Calculate the tx value
+	blockCandidates := btb.mempool.BlockCandidates()
+	candidateTxs := make([]*candidateTx, 0, len(blockCandidates))
+	for _, blockCandidate := range blockCandidates {
+		tx := blockCandidate.Transaction
 		gasLimit := uint64(0)
 		if !subnetworks.IsBuiltInOrNative(tx.SubnetworkID) {
 			panic("We currently don't support non native subnetworks")
 		}
 		candidateTxs = append(candidateTxs, &candidateTx{
 			DomainTransaction: tx,
-			txValue:           btb.calcTxValue(tx),
+			id:                consensushashing.TransactionID(tx),
+			isHighPriority:    blockCandidate.IsHighPriority,
+			score:             calcScore(blockCandidate),
 			gasLimit:          gasLimit,
 		})
 	}
 
-	// Sort the candidate txs by subnetworkID.
-	sort.Slice(candidateTxs, func(i, j int) bool {
-		return subnetworks.Less(candidateTxs[i].SubnetworkID, candidateTxs[j].SubnetworkID)
-	})
-
 	log.Debugf("Considering %d transactions for inclusion to new block",
 		len(candidateTxs))
 
@@ -192,7 +188,7 @@ func (btb *blockTemplateBuilder) ModifyBlockTemplate(newCoinbaseData *consensuse
 	// TODO: can be optimized to O(log(#transactions)) by caching the whole merkle tree in BlockTemplate and changing only the relevant path
 	mutableHeader.SetHashMerkleRoot(merkle.CalculateHashMerkleRoot(blockTemplateToModify.Block.Transactions))
 
-	newTimestamp := mstime.Now().UnixMilliseconds()
+	newTimestamp := btb.clock.Now().UnixMilliseconds()
 	if newTimestamp >= mutableHeader.TimeInMilliseconds() {
 		// Only if new time stamp is later than current, update the header. Otherwise,
 		// we keep the previous time as built by internal consensus median time logic
@@ -205,18 +201,20 @@ func (btb *blockTemplateBuilder) ModifyBlockTemplate(newCoinbaseData *consensuse
 	return blockTemplateToModify, nil
 }
 
-// calcTxValue calculates a value to be used in transaction selection.
-// The higher the number the more likely it is that the transaction will be
-// included in the block.
-func (btb *blockTemplateBuilder) calcTxValue(tx *consensusexternalapi.DomainTransaction) float64 {
-	massLimit := btb.policy.BlockMaxMass
-
-	mass := tx.Mass
-	fee := tx.Fee
-	if subnetworks.IsBuiltInOrNative(tx.SubnetworkID) {
-		return float64(fee) / (float64(mass) / float64(massLimit))
+// calcScore calculates the score transactions are selected by, which is the fee per
+// gram of mass of the transaction's package. A transaction's package consists of the
+// transaction and its descendants in the mempool, which can only be included in later
+// blocks once the transaction is (child-pays-for-parent). A package never lowers the
+// score of a transaction below its own fee rate.
+func calcScore(blockCandidate *miningmanagerapi.BlockCandidate) float64 {
+	tx := blockCandidate.Transaction
+	if tx.Mass == 0 {
+		return 0
+	}
+	score := float64(tx.Fee) / float64(tx.Mass)
+	if blockCandidate.DescendantsMass == 0 {
+		return score
 	}
-	// TODO: Replace with real gas once implemented
-	gasLimit := uint64(math.MaxUint64)
-	return float64(fee) / (float64(mass)/float64(massLimit) + float64(tx.Gas)/float64(gasLimit))
+	packageScore := float64(tx.Fee+blockCandidate.DescendantsFee) / float64(tx.Mass+blockCandidate.DescendantsMass)
+	return math.Max(score, packageScore)
 }
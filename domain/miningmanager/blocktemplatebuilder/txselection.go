@@ -1,32 +1,12 @@
 package blocktemplatebuilder
 
 import (
-	"math"
-	"math/rand"
 	"sort"
 
 	consensusexternalapi "github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
-	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
 )
 
-const (
-	// alpha is a coefficient that defines how uniform the distribution of
-	// candidate transactions should be. A smaller alpha makes the distribution
-	// more uniform. Alpha is used when determining a candidate transaction's
-	// initial p value.
-	alpha = 3
-
-	// rebalanceThreshold is the percentage of candidate transactions under which
-	// we don't rebalance. Rebalancing is a heavy operation so we prefer to avoid
-	// rebalancing very often. On the other hand, if we don't rebalance often enough
-	// we risk having too many collisions.
-	// The value is derived from the max probability of collision. That is to say,
-	// if rebalanceThreshold is 0.95, there's a 1-in-20 chance of collision.
-	// See selectTxs for further details.
-	rebalanceThreshold = 0.95
-)
-
 type selectedTransactions struct {
 	selectedTxs []*consensusexternalapi.DomainTransaction
 	txMasses    []uint64
@@ -35,179 +15,153 @@ type selectedTransactions struct {
 	totalFees   uint64
 }
 
-// selectTransactions implements a probabilistic transaction selection algorithm.
-// The algorithm, roughly, is as follows:
-// 1. We assign a probability to each transaction equal to:
-//    (candidateTx.Value^alpha) / Σ(tx.Value^alpha)
-//    Where the sum of the probabilities of all txs is 1.
-// 2. We draw a random number in [0,1) and select a transaction accordingly.
-// 3. If it's valid, add it to the selectedTxs and remove it from the candidates.
-// 4. Continue iterating the above until we have either selected all
-//    available transactions or ran out of gas/block space.
+// selectTransactions selects the candidate transactions that will be included in
+// the next block, maximizing their total value under the block mass limit. This is
+// a knapsack problem, which is solved approximately as follows:
+//  1. High-priority transactions are selected first, ordered by score.
+//  2. The rest of the transactions are selected greedily by score. Transactions
+//     that don't fit in the remaining mass are skipped, so that smaller transactions
+//     may still fill the block.
+//  3. If a single transaction that fits in the block is worth more than all the
+//     transactions selected in step 2, it's selected instead of them.
+//
+// The value of a transaction is its score times its mass, which equals its fee for
+// transactions without descendants in the mempool. See calcScore for details.
 //
-// Note that we make two optimizations here:
-// * Draw a number in [0,Σ(tx.Value^alpha)) to avoid normalization
-// * Instead of removing a candidate after each iteration, mark it for deletion.
-//   Once the sum of probabilities of marked transactions is greater than
-//   rebalanceThreshold percent of the sum of probabilities of all transactions,
-//   rebalance.
-
-// selectTransactions loops over the candidate transactions
-// and appends the ones that will be included in the next block into
-// txsForBlockTemplates.
-// See selectTxs for further details.
+// Greedy selection alone may be arbitrarily bad when a high-score transaction
+// crowds out a large and valuable one; step 3 guarantees at least half of the
+// optimal value. Ties are broken by transaction ID, so the selection is
+// deterministic.
 func (btb *blockTemplateBuilder) selectTransactions(candidateTxs []*candidateTx) selectedTransactions {
-	txsForBlockTemplate := selectedTransactions{
-		selectedTxs: make([]*consensusexternalapi.DomainTransaction, 0, len(candidateTxs)),
-		txMasses:    make([]uint64, 0, len(candidateTxs)),
-		txFees:      make([]uint64, 0, len(candidateTxs)),
-		totalMass:   0,
-		totalFees:   0,
+	sortedCandidateTxs := make([]*candidateTx, len(candidateTxs))
+	copy(sortedCandidateTxs, candidateTxs)
+	sort.Slice(sortedCandidateTxs, func(i, j int) bool {
+		return candidateTxLess(sortedCandidateTxs[i], sortedCandidateTxs[j])
+	})
+
+	selection := newTxSelection(btb.policy.BlockMaxMass)
+	for _, candidateTx := range sortedCandidateTxs {
+		if candidateTx.isHighPriority {
+			selection.tryAdd(candidateTx)
+		}
 	}
-	usedCount, usedP := 0, 0.0
-	candidateTxs, totalP := rebalanceCandidates(candidateTxs, true)
-	gasUsageMap := make(map[consensusexternalapi.DomainSubnetworkID]uint64)
-
-	markCandidateTxForDeletion := func(candidateTx *candidateTx) {
-		candidateTx.isMarkedForDeletion = true
-		usedCount++
-		usedP += candidateTx.p
+	highPriorityCount := len(selection.selectedTxs)
+	highPriorityMass := selection.totalMass
+	highPriorityGasUsage := make(map[consensusexternalapi.DomainSubnetworkID]uint64, len(selection.gasUsage))
+	for subnetworkID, gasUsage := range selection.gasUsage {
+		highPriorityGasUsage[subnetworkID] = gasUsage
 	}
 
-	selectedTxs := make([]*candidateTx, 0)
-	for len(candidateTxs)-usedCount > 0 {
-		// Rebalance the candidates if it's required
-		if usedP >= rebalanceThreshold*totalP {
-			candidateTxs, totalP = rebalanceCandidates(candidateTxs, false)
-			usedCount, usedP = 0, 0.0
-
-			// Break if we now ran out of transactions
-			if len(candidateTxs) == 0 {
-				break
-			}
+	greedyValue := 0.0
+	for _, candidateTx := range sortedCandidateTxs {
+		if !candidateTx.isHighPriority && selection.tryAdd(candidateTx) {
+			greedyValue += candidateTx.value()
 		}
+	}
 
-		// Select a candidate tx at random
-		r := rand.Float64()
-		r *= totalP
-		selectedTx := findTx(candidateTxs, r)
-
-		// If isMarkedForDeletion is set, it means we got a collision.
-		// Ignore and select another Tx.
-		if selectedTx.isMarkedForDeletion {
+	var bestSingleTx *candidateTx
+	for _, candidateTx := range sortedCandidateTxs {
+		if candidateTx.isHighPriority || highPriorityMass+candidateTx.Mass > btb.policy.BlockMaxMass {
 			continue
 		}
-		tx := selectedTx.DomainTransaction
-
-		// Enforce maximum transaction mass per block. Also check
-		// for overflow.
-		if txsForBlockTemplate.totalMass+selectedTx.Mass < txsForBlockTemplate.totalMass ||
-			txsForBlockTemplate.totalMass+selectedTx.Mass > btb.policy.BlockMaxMass {
-			log.Tracef("Tx %s would exceed the max block mass. "+
-				"As such, stopping.", consensushashing.TransactionID(tx))
-			break
-		}
-
-		// Enforce maximum gas per subnetwork per block. Also check
-		// for overflow.
-		if !subnetworks.IsBuiltInOrNative(tx.SubnetworkID) {
-			subnetworkID := tx.SubnetworkID
-			gasUsage, ok := gasUsageMap[subnetworkID]
-			if !ok {
-				gasUsage = 0
-			}
-			txGas := tx.Gas
-			if gasUsage+txGas < gasUsage ||
-				gasUsage+txGas > selectedTx.gasLimit {
-				log.Tracef("Tx %s would exceed the gas limit in "+
-					"subnetwork %s. Removing all remaining txs from this "+
-					"subnetwork.",
-					consensushashing.TransactionID(tx), subnetworkID)
-				for _, candidateTx := range candidateTxs {
-					// candidateTxs are ordered by subnetwork, so we can safely assume
-					// that transactions after subnetworkID will not be relevant.
-					if subnetworks.Less(subnetworkID, candidateTx.SubnetworkID) {
-						break
-					}
-
-					if candidateTx.SubnetworkID == subnetworkID {
-						markCandidateTxForDeletion(candidateTx)
-					}
-				}
-				continue
-			}
-			gasUsageMap[subnetworkID] = gasUsage + txGas
+		if bestSingleTx == nil || candidateTx.value() > bestSingleTx.value() {
+			bestSingleTx = candidateTx
 		}
-
-		// Add the transaction to the result, increment counters, and
-		// save the masses, fees, and signature operation counts to the
-		// result.
-		selectedTxs = append(selectedTxs, selectedTx)
-		txsForBlockTemplate.totalMass += selectedTx.Mass
-		txsForBlockTemplate.totalFees += selectedTx.Fee
-
-		log.Tracef("Adding tx %s (feePerMegaGram %d)",
-			consensushashing.TransactionID(tx), selectedTx.Fee*1e6/selectedTx.Mass)
-
-		markCandidateTxForDeletion(selectedTx)
+	}
+	if bestSingleTx != nil && bestSingleTx.value() > greedyValue {
+		log.Debugf("Tx %s is worth more than the %d greedily selected txs. Selecting it instead",
+			bestSingleTx.id, len(selection.selectedTxs)-highPriorityCount)
+		selection.truncate(highPriorityCount, highPriorityMass, highPriorityGasUsage)
+		selection.tryAdd(bestSingleTx)
 	}
 
-	sort.Slice(selectedTxs, func(i, j int) bool {
-		return subnetworks.Less(selectedTxs[i].SubnetworkID, selectedTxs[j].SubnetworkID)
+	// Consensus requires transactions to be sorted by subnetwork
+	sort.SliceStable(selection.selectedTxs, func(i, j int) bool {
+		return subnetworks.Less(selection.selectedTxs[i].SubnetworkID, selection.selectedTxs[j].SubnetworkID)
 	})
-	for _, selectedTx := range selectedTxs {
+
+	txsForBlockTemplate := selectedTransactions{
+		selectedTxs: make([]*consensusexternalapi.DomainTransaction, 0, len(selection.selectedTxs)),
+		txMasses:    make([]uint64, 0, len(selection.selectedTxs)),
+		txFees:      make([]uint64, 0, len(selection.selectedTxs)),
+	}
+	for _, selectedTx := range selection.selectedTxs {
 		txsForBlockTemplate.selectedTxs = append(txsForBlockTemplate.selectedTxs, selectedTx.DomainTransaction)
 		txsForBlockTemplate.txMasses = append(txsForBlockTemplate.txMasses, selectedTx.Mass)
 		txsForBlockTemplate.txFees = append(txsForBlockTemplate.txFees, selectedTx.Fee)
+		txsForBlockTemplate.totalMass += selectedTx.Mass
+		txsForBlockTemplate.totalFees += selectedTx.Fee
 	}
 	return txsForBlockTemplate
 }
 
-func rebalanceCandidates(oldCandidateTxs []*candidateTx, isFirstRun bool) (
-	candidateTxs []*candidateTx, totalP float64) {
+// candidateTxLess orders candidate transactions by descending score, breaking ties by
+// transaction ID
+func candidateTxLess(a, b *candidateTx) bool {
+	if a.score != b.score {
+		return a.score > b.score
+	}
+	return a.id.Less(b.id)
+}
 
-	totalP = 0.0
+// value returns the value of including the candidate transaction in a block
+func (candidateTx *candidateTx) value() float64 {
+	return candidateTx.score * float64(candidateTx.Mass)
+}
 
-	candidateTxs = make([]*candidateTx, 0, len(oldCandidateTxs))
-	for _, candidateTx := range oldCandidateTxs {
-		if candidateTx.isMarkedForDeletion {
-			continue
-		}
+// txSelection tracks the transactions selected so far along with the resources they use
+type txSelection struct {
+	maxMass     uint64
+	selectedTxs []*candidateTx
+	totalMass   uint64
+	gasUsage    map[consensusexternalapi.DomainSubnetworkID]uint64
+}
 
-		candidateTxs = append(candidateTxs, candidateTx)
+func newTxSelection(maxMass uint64) *txSelection {
+	return &txSelection{
+		maxMass:  maxMass,
+		gasUsage: make(map[consensusexternalapi.DomainSubnetworkID]uint64),
 	}
+}
 
-	for _, candidateTx := range candidateTxs {
-		if isFirstRun {
-			candidateTx.p = math.Pow(candidateTx.txValue, alpha)
-		}
-		candidateTx.start = totalP
-		candidateTx.end = totalP + candidateTx.p
-
-		totalP += candidateTx.p
+// tryAdd adds the candidate transaction to the selection if it fits in the
+// remaining mass and gas, and returns whether it was added
+func (selection *txSelection) tryAdd(candidateTx *candidateTx) bool {
+	// Enforce maximum transaction mass per block. Also check
+	// for overflow.
+	if selection.totalMass+candidateTx.Mass < selection.totalMass ||
+		selection.totalMass+candidateTx.Mass > selection.maxMass {
+		log.Tracef("Tx %s would exceed the max block mass. "+
+			"As such, skipping it.", candidateTx.id)
+		return false
 	}
-	return
-}
 
-// findTx finds the candidateTx in whose range r falls.
-// For example, if we have candidateTxs with starts and ends:
-// * tx1: start 0,   end 100
-// * tx2: start 100, end 105
-// * tx3: start 105, end 2000
-// And r=102, then findTx will return tx2.
-func findTx(candidateTxs []*candidateTx, r float64) *candidateTx {
-	min := 0
-	max := len(candidateTxs) - 1
-	for {
-		i := (min + max) / 2
-		candidateTx := candidateTxs[i]
-		if candidateTx.end < r {
-			min = i + 1
-			continue
-		} else if candidateTx.start > r {
-			max = i - 1
-			continue
+	// Enforce maximum gas per subnetwork per block. Also check
+	// for overflow.
+	if !subnetworks.IsBuiltInOrNative(candidateTx.SubnetworkID) {
+		gasUsage := selection.gasUsage[candidateTx.SubnetworkID]
+		if gasUsage+candidateTx.Gas < gasUsage ||
+			gasUsage+candidateTx.Gas > candidateTx.gasLimit {
+			log.Tracef("Tx %s would exceed the gas limit in "+
+				"subnetwork %s. As such, skipping it.",
+				candidateTx.id, candidateTx.SubnetworkID)
+			return false
 		}
-		return candidateTx
+		selection.gasUsage[candidateTx.SubnetworkID] = gasUsage + candidateTx.Gas
 	}
+
+	selection.selectedTxs = append(selection.selectedTxs, candidateTx)
+	selection.totalMass += candidateTx.Mass
+
+	log.Tracef("Adding tx %s (score %f)", candidateTx.id, candidateTx.score)
+	return true
+}
+
+// truncate drops all but the first count selected transactions, restoring the given mass and gas usage
+func (selection *txSelection) truncate(count int, totalMass uint64,
+	gasUsage map[consensusexternalapi.DomainSubnetworkID]uint64) {
+
+	selection.selectedTxs = selection.selectedTxs[:count]
+	selection.totalMass = totalMass
+	selection.gasUsage = gasUsage
 }
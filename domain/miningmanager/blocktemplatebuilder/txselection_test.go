@@ -0,0 +1,150 @@
+package blocktemplatebuilder
+
+import (
+	"testing"
+
+	consensusexternalapi "github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
+	miningmanagerapi "github.com/kaspanet/kaspad/domain/miningmanager/model"
+)
+
+type testCandidate struct {
+	name            string
+	mass            uint64
+	fee             uint64
+	isHighPriority  bool
+	descendantsFee  uint64
+	descendantsMass uint64
+}
+
+func buildCandidateTxs(testCandidates []testCandidate) ([]*candidateTx, map[*consensusexternalapi.DomainTransaction]string) {
+	candidateTxs := make([]*candidateTx, len(testCandidates))
+	names := make(map[*consensusexternalapi.DomainTransaction]string, len(testCandidates))
+	for i, testCandidate := range testCandidates {
+		var idBytes [consensusexternalapi.DomainHashSize]byte
+		copy(idBytes[:], testCandidate.name)
+		id := consensusexternalapi.NewDomainTransactionIDFromByteArray(&idBytes)
+		tx := &consensusexternalapi.DomainTransaction{
+			SubnetworkID: subnetworks.SubnetworkIDNative,
+			Mass:         testCandidate.mass,
+			Fee:          testCandidate.fee,
+			ID:           id,
+		}
+		candidateTxs[i] = &candidateTx{
+			DomainTransaction: tx,
+			id:                id,
+			isHighPriority:    testCandidate.isHighPriority,
+			score: calcScore(&miningmanagerapi.BlockCandidate{
+				Transaction:     tx,
+				IsHighPriority:  testCandidate.isHighPriority,
+				DescendantsFee:  testCandidate.descendantsFee,
+				DescendantsMass: testCandidate.descendantsMass,
+			}),
+		}
+		names[tx] = testCandidate.name
+	}
+	return candidateTxs, names
+}
+
+func TestSelectTransactions(t *testing.T) {
+	tests := []struct {
+		name          string
+		blockMaxMass  uint64
+		candidates    []testCandidate
+		expectedNames []string
+	}{
+		{
+			name:         "all transactions fit",
+			blockMaxMass: 1000,
+			candidates: []testCandidate{
+				{name: "a", mass: 100, fee: 100},
+				{name: "b", mass: 100, fee: 300},
+				{name: "c", mass: 100, fee: 200},
+			},
+			expectedNames: []string{"b", "c", "a"},
+		},
+		{
+			name:         "transactions that don't fit are skipped rather than ending the selection",
+			blockMaxMass: 100,
+			candidates: []testCandidate{
+				{name: "a", mass: 60, fee: 600},
+				{name: "b", mass: 60, fee: 540},
+				{name: "c", mass: 40, fee: 200},
+			},
+			expectedNames: []string{"a", "c"},
+		},
+		{
+			name:         "a single valuable transaction beats greedily selected ones",
+			blockMaxMass: 100,
+			candidates: []testCandidate{
+				{name: "a", mass: 10, fee: 20},
+				{name: "b", mass: 100, fee: 150},
+			},
+			expectedNames: []string{"b"},
+		},
+		{
+			name:         "descendants pay for their parent",
+			blockMaxMass: 100,
+			candidates: []testCandidate{
+				{name: "a", mass: 100, fee: 200},
+				{name: "b", mass: 100, fee: 100, descendantsFee: 900, descendantsMass: 100},
+			},
+			expectedNames: []string{"b"},
+		},
+		{
+			name:         "descendants never lower the score of their parent",
+			blockMaxMass: 100,
+			candidates: []testCandidate{
+				{name: "a", mass: 100, fee: 200},
+				{name: "b", mass: 100, fee: 300, descendantsFee: 1, descendantsMass: 1000},
+			},
+			expectedNames: []string{"b"},
+		},
+		{
+			name:         "high priority transactions are selected first",
+			blockMaxMass: 200,
+			candidates: []testCandidate{
+				{name: "a", mass: 100, fee: 1000},
+				{name: "b", mass: 100, fee: 900},
+				{name: "c", mass: 100, fee: 1, isHighPriority: true},
+			},
+			expectedNames: []string{"c", "a"},
+		},
+		{
+			name:         "ties are broken by transaction ID",
+			blockMaxMass: 200,
+			candidates: []testCandidate{
+				{name: "c", mass: 100, fee: 100},
+				{name: "a", mass: 100, fee: 100},
+				{name: "b", mass: 100, fee: 100},
+			},
+			expectedNames: []string{"a", "b"},
+		},
+	}
+
+	for _, test := range tests {
+		btb := &blockTemplateBuilder{policy: policy{BlockMaxMass: test.blockMaxMass}}
+		candidateTxs, names := buildCandidateTxs(test.candidates)
+
+		// Run the selection several times to make sure it's deterministic
+		for i := 0; i < 10; i++ {
+			selected := btb.selectTransactions(candidateTxs)
+			selectedNames := make([]string, len(selected.selectedTxs))
+			for j, tx := range selected.selectedTxs {
+				selectedNames[j] = names[tx]
+			}
+			if len(selectedNames) != len(test.expectedNames) {
+				t.Fatalf("%s: expected %v to be selected but got %v", test.name, test.expectedNames, selectedNames)
+			}
+			for j := range selectedNames {
+				if selectedNames[j] != test.expectedNames[j] {
+					t.Fatalf("%s: expected %v to be selected but got %v", test.name, test.expectedNames, selectedNames)
+				}
+			}
+			if selected.totalMass > test.blockMaxMass {
+				t.Fatalf("%s: selected mass %d is above the block max mass %d",
+					test.name, selected.totalMass, test.blockMaxMass)
+			}
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package miningmanager
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/processes/coinbasemanager"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/merkle"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/pkg/errors"
+)
+
+// ErrCoinbasePayloadTooLong is returned when the requested coinbase data doesn't
+// fit in a coinbase payload
+var ErrCoinbasePayloadTooLong = errors.New("coinbase payload is above max length")
+
+// ExtranonceRegion is the part of a coinbase payload that is reserved for miners to
+// roll, in addition to the header nonce
+type ExtranonceRegion struct {
+	// Offset is the offset of the region within the coinbase payload
+	Offset int
+
+	// Size is the size of the region in bytes
+	Size int
+}
+
+// NewCoinbaseData builds coinbase data paying to scriptPublicKey, whose extra data
+// consists of the given payload bytes (e.g. a pool tag or a merged mining commitment)
+// followed by extranonceSize zero bytes reserved for an extranonce.
+//
+// The returned ExtranonceRegion locates the reserved bytes within the payload of
+// coinbase transactions built from the coinbase data, and is nil if extranonceSize
+// is 0. An error is returned if the resulting coinbase payload exceeds the limits
+// of the given params. Since the payload length is fixed once the extranonce region
+// is reserved, rolling the extranonce never changes the coinbase mass.
+func NewCoinbaseData(params *dagconfig.Params, scriptPublicKey *externalapi.ScriptPublicKey,
+	payload []byte, extranonceSize int) (*externalapi.DomainCoinbaseData, *ExtranonceRegion, error) {
+
+	if len(scriptPublicKey.Script) > int(params.CoinbasePayloadScriptPublicKeyMaxLength) {
+		return nil, nil, errors.Errorf("coinbase script public key is above max length (%d)",
+			params.CoinbasePayloadScriptPublicKeyMaxLength)
+	}
+	if extranonceSize < 0 {
+		return nil, nil, errors.Errorf("extranonce size %d is negative", extranonceSize)
+	}
+
+	extraDataOffset := coinbasemanager.CoinbasePayloadExtraDataOffset(scriptPublicKey)
+	payloadLength := extraDataOffset + len(payload) + extranonceSize
+	if uint64(payloadLength) > params.MaxCoinbasePayloadLength {
+		return nil, nil, errors.Wrapf(ErrCoinbasePayloadTooLong, "max length is %d but got %d",
+			params.MaxCoinbasePayloadLength, payloadLength)
+	}
+
+	extraData := make([]byte, len(payload)+extranonceSize)
+	copy(extraData, payload)
+	coinbaseData := &externalapi.DomainCoinbaseData{
+		ScriptPublicKey: scriptPublicKey,
+		ExtraData:       extraData,
+	}
+	if extranonceSize == 0 {
+		return coinbaseData, nil, nil
+	}
+	return coinbaseData, &ExtranonceRegion{
+		Offset: extraDataOffset + len(payload),
+		Size:   extranonceSize,
+	}, nil
+}
+
+// SetExtranonce returns a copy of the given block, whose coinbase has the given
+// extranonce written to the given region and whose hash merkle root is updated
+// accordingly. The given block is not modified, since templates may be shared
+// between callers.
+func SetExtranonce(block *externalapi.DomainBlock, region *ExtranonceRegion, extranonce []byte) (
+	*externalapi.DomainBlock, error) {
+
+	if len(extranonce) != region.Size {
+		return nil, errors.Errorf("expected an extranonce of %d bytes but got %d", region.Size, len(extranonce))
+	}
+	coinbaseTransaction := block.Transactions[transactionhelper.CoinbaseTransactionIndex]
+	if len(coinbaseTransaction.Payload) < region.Offset+region.Size {
+		return nil, errors.Errorf("the extranonce region [%d, %d) lies outside of the coinbase payload of length %d",
+			region.Offset, region.Offset+region.Size, len(coinbaseTransaction.Payload))
+	}
+
+	newCoinbaseTransaction := coinbaseTransaction.Clone()
+	copy(newCoinbaseTransaction.Payload[region.Offset:], extranonce)
+	// The cached ID no longer matches the modified payload
+	newCoinbaseTransaction.ID = nil
+	newTransactions := make([]*externalapi.DomainTransaction, len(block.Transactions))
+	copy(newTransactions, block.Transactions)
+	newTransactions[transactionhelper.CoinbaseTransactionIndex] = newCoinbaseTransaction
+
+	mutableHeader := block.Header.ToMutable()
+	mutableHeader.SetHashMerkleRoot(merkle.CalculateHashMerkleRoot(newTransactions))
+	return &externalapi.DomainBlock{
+		Header:       mutableHeader.ToImmutable(),
+		Transactions: newTransactions,
+	}, nil
+}
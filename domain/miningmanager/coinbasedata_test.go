@@ -0,0 +1,149 @@
+package miningmanager_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/processes/coinbasemanager"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/merkle"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/testutils"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+	"github.com/kaspanet/kaspad/domain/consensusreference"
+	"github.com/kaspanet/kaspad/domain/miningmanager"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool"
+)
+
+func TestNewCoinbaseData(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		params := &consensusConfig.Params
+		scriptPublicKey := &externalapi.ScriptPublicKey{Script: make([]byte, 34), Version: 0}
+		extraDataOffset := coinbasemanager.CoinbasePayloadExtraDataOffset(scriptPublicKey)
+		maxExtraDataLength := int(params.MaxCoinbasePayloadLength) - extraDataOffset
+
+		coinbaseData, region, err := miningmanager.NewCoinbaseData(params, scriptPublicKey, []byte("pool"), 8)
+		if err != nil {
+			t.Fatalf("NewCoinbaseData: %+v", err)
+		}
+		if !bytes.Equal(coinbaseData.ExtraData, append([]byte("pool"), make([]byte, 8)...)) {
+			t.Fatalf("Unexpected extra data %x", coinbaseData.ExtraData)
+		}
+		if region.Offset != extraDataOffset+len("pool") || region.Size != 8 {
+			t.Fatalf("Unexpected extranonce region %+v", region)
+		}
+
+		_, region, err = miningmanager.NewCoinbaseData(params, scriptPublicKey, []byte("pool"), 0)
+		if err != nil {
+			t.Fatalf("NewCoinbaseData: %+v", err)
+		}
+		if region != nil {
+			t.Fatalf("Expected no extranonce region when no extranonce is reserved")
+		}
+
+		_, _, err = miningmanager.NewCoinbaseData(params, scriptPublicKey, make([]byte, maxExtraDataLength-8), 8)
+		if err != nil {
+			t.Fatalf("NewCoinbaseData: a payload of exactly the max length was rejected: %+v", err)
+		}
+		_, _, err = miningmanager.NewCoinbaseData(params, scriptPublicKey, make([]byte, maxExtraDataLength-7), 8)
+		if !errors.Is(err, miningmanager.ErrCoinbasePayloadTooLong) {
+			t.Fatalf("Expected ErrCoinbasePayloadTooLong but got %v", err)
+		}
+
+		longScriptPublicKey := &externalapi.ScriptPublicKey{
+			Script:  make([]byte, params.CoinbasePayloadScriptPublicKeyMaxLength+1),
+			Version: 0,
+		}
+		_, _, err = miningmanager.NewCoinbaseData(params, longScriptPublicKey, nil, 0)
+		if err == nil {
+			t.Fatalf("Expected an error for a script public key above the max length")
+		}
+	})
+}
+
+func TestSetExtranonce(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.SkipProofOfWork = true
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestSetExtranonce")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		miningFactory := miningmanager.NewFactory()
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params))
+
+		scriptPublicKey := &externalapi.ScriptPublicKey{Script: nil, Version: 0}
+		coinbaseData, region, err := miningmanager.NewCoinbaseData(&consensusConfig.Params, scriptPublicKey, []byte("pool"), 4)
+		if err != nil {
+			t.Fatalf("NewCoinbaseData: %+v", err)
+		}
+		template, _, err := miningManager.GetBlockTemplate(coinbaseData)
+		if err != nil {
+			t.Fatalf("GetBlockTemplate: %+v", err)
+		}
+		templateHashMerkleRoot := template.Header.HashMerkleRoot()
+
+		_, err = miningmanager.SetExtranonce(template, region, []byte{1, 2})
+		if err == nil {
+			t.Fatalf("Expected an error for an extranonce of the wrong size")
+		}
+
+		extranonce := []byte{1, 2, 3, 4}
+		block, err := miningmanager.SetExtranonce(template, region, extranonce)
+		if err != nil {
+			t.Fatalf("SetExtranonce: %+v", err)
+		}
+
+		coinbasePayload := block.Transactions[transactionhelper.CoinbaseTransactionIndex].Payload
+		if !bytes.Equal(coinbasePayload[region.Offset:region.Offset+region.Size], extranonce) {
+			t.Fatalf("The extranonce wasn't written to the extranonce region")
+		}
+		if !block.Header.HashMerkleRoot().Equal(merkle.CalculateHashMerkleRoot(block.Transactions)) {
+			t.Fatalf("The hash merkle root wasn't updated")
+		}
+		if !template.Header.HashMerkleRoot().Equal(templateHashMerkleRoot) {
+			t.Fatalf("SetExtranonce modified the template")
+		}
+
+		err = tc.ValidateAndInsertBlock(block, true)
+		if err != nil {
+			t.Fatalf("A block with a rolled extranonce was rejected: %+v", err)
+		}
+	})
+}
+
+func TestAuxCommitment(t *testing.T) {
+	commitment := externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1, 2, 3})
+	payload := miningmanager.AppendAuxCommitment([]byte("pool"), commitment)
+	if !bytes.HasPrefix(payload, []byte("pool")) {
+		t.Fatalf("AppendAuxCommitment didn't keep the original payload")
+	}
+
+	// The extranonce region follows the commitment in actual coinbase payloads
+	coinbasePayload := append(append([]byte("header fields"), payload...), make([]byte, 8)...)
+	extracted, ok := miningmanager.ExtractAuxCommitment(coinbasePayload)
+	if !ok {
+		t.Fatalf("ExtractAuxCommitment didn't find the commitment")
+	}
+	if !extracted.Equal(commitment) {
+		t.Fatalf("Expected commitment %s but got %s", commitment, extracted)
+	}
+
+	// A tag that isn't followed by a complete commitment is ignored
+	truncatedPayload := append(coinbasePayload, miningmanager.AuxCommitmentTag...)
+	extracted, ok = miningmanager.ExtractAuxCommitment(truncatedPayload)
+	if !ok || !extracted.Equal(commitment) {
+		t.Fatalf("Expected commitment %s to be extracted despite a trailing tag", commitment)
+	}
+
+	_, ok = miningmanager.ExtractAuxCommitment([]byte("pool"))
+	if ok {
+		t.Fatalf("ExtractAuxCommitment found a commitment in a payload without one")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/kaspanet/kaspad/domain/dagconfig"
 	"github.com/kaspanet/kaspad/domain/miningmanager/blocktemplatebuilder"
 	mempoolpkg "github.com/kaspanet/kaspad/domain/miningmanager/mempool"
+	"github.com/kaspanet/kaspad/util/mstime"
 	"sync"
 	"time"
 )
@@ -14,25 +15,37 @@ type Factory interface {
 	NewMiningManager(consensus consensusreference.ConsensusReference, params *dagconfig.Params, mempoolConfig *mempoolpkg.Config) MiningManager
 }
 
-type factory struct{}
+type factory struct {
+	clock mstime.Clock
+}
 
 // NewMiningManager instantiate a new mining manager
 func (f *factory) NewMiningManager(consensusReference consensusreference.ConsensusReference, params *dagconfig.Params,
 	mempoolConfig *mempoolpkg.Config) MiningManager {
 
 	mempool := mempoolpkg.New(mempoolConfig, consensusReference)
-	blockTemplateBuilder := blocktemplatebuilder.New(consensusReference, mempool, params.MaxBlockMass, params.CoinbasePayloadScriptPublicKeyMaxLength)
+	blockTemplateBuilder := blocktemplatebuilder.New(consensusReference, mempool, params.MaxBlockMass,
+		params.CoinbasePayloadScriptPublicKeyMaxLength, f.clock)
 
 	return &miningManager{
 		consensusReference:   consensusReference,
 		mempool:              mempool,
 		blockTemplateBuilder: blockTemplateBuilder,
 		cachingTime:          time.Time{},
+		mempoolModified:      make(chan struct{}),
 		cacheLock:            &sync.Mutex{},
+		clock:                f.clock,
 	}
 }
 
-// NewFactory creates a new mining manager factory
+// NewFactory creates a new mining manager factory, whose mining managers
+// timestamp their block templates with the local system time
 func NewFactory() Factory {
-	return &factory{}
+	return NewFactoryWithClock(mstime.SystemClock)
+}
+
+// NewFactoryWithClock creates a new mining manager factory, whose mining
+// managers timestamp their block templates with the time of clock
+func NewFactoryWithClock(clock mstime.Clock) Factory {
+	return &factory{clock: clock}
 }
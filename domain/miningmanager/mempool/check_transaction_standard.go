@@ -3,8 +3,6 @@ package mempool
 import (
 	"fmt"
 
-	"github.com/kaspanet/kaspad/util/txmass"
-
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
 
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
@@ -99,49 +97,11 @@ func (mp *mempool) checkTransactionStandardInIsolation(transaction *externalapi.
 
 // IsTransactionOutputDust returns whether or not the passed transaction output amount
 // is considered dust or not based on the configured minimum transaction relay fee.
-// Dust is defined in terms of the minimum transaction relay fee. In
-// particular, if the cost to the network to spend coins is more than 1/3 of the
-// minimum transaction relay fee, it is considered dust.
+// See txscript.IsDustOutput for how dust is defined.
 //
 // It is exported for use by transaction generators and wallets
 func (mp *mempool) IsTransactionOutputDust(output *externalapi.DomainTransactionOutput) bool {
-	// Unspendable outputs are considered dust.
-	if txscript.IsUnspendable(output.ScriptPublicKey.Script) {
-		return true
-	}
-
-	// The total serialized size consists of the output and the associated
-	// input script to redeem it. Since there is no input script
-	// to redeem it yet, use the minimum size of a typical input script.
-	//
-	// Pay-to-pubkey bytes breakdown:
-	//
-	//  Output to pubkey (43 bytes):
-	//   8 value, 1 script len, 34 script [1 OP_DATA_32,
-	//   32 pubkey, 1 OP_CHECKSIG]
-	//
-	//  Input (105 bytes):
-	//   36 prev outpoint, 1 script len, 64 script [1 OP_DATA_64,
-	//   64 sig], 4 sequence
-	//
-	// The most common scripts are pay-to-pubkey, and as per the above
-	// breakdown, the minimum size of a p2pk input script is 148 bytes. So
-	// that figure is used.
-	totalSerializedSize := txmass.TransactionOutputEstimatedSerializedSize(output) + 148
-
-	// The output is considered dust if the cost to the network to spend the
-	// coins is more than 1/3 of the minimum free transaction relay fee.
-	// mp.config.MinimumRelayTransactionFee is in sompi/KB, so multiply
-	// by 1000 to convert to bytes.
-	//
-	// Using the typical values for a pay-to-pubkey transaction from
-	// the breakdown above and the default minimum free transaction relay
-	// fee of 1000, this equates to values less than 546 sompi being
-	// considered dust.
-	//
-	// The following is equivalent to (value/totalSerializedSize) * (1/3) * 1000
-	// without needing to do floating point math.
-	return output.Value*1000/(3*totalSerializedSize) < uint64(mp.config.MinimumRelayTransactionFee)
+	return txscript.IsDustOutput(output, mp.config.MinimumRelayTransactionFee)
 }
 
 // checkTransactionStandardInContext performs a series of checks on a transaction's
@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
+	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
 
 	"github.com/kaspanet/kaspad/util"
 
@@ -50,6 +52,14 @@ type Config struct {
 	MinimumRelayTransactionFee            util.Amount
 	MinimumStandardTransactionVersion     uint16
 	MaximumStandardTransactionVersion     uint16
+
+	// ValidatedSubnetworks are the subnetworks whose transactions the mempool
+	// accepts. Transactions of any other subnetwork are rejected
+	ValidatedSubnetworks subnetworks.Filter
+
+	// TransactionBlocklist is the initial blocklist of the mempool. See
+	// miningmanagermodel.TransactionBlocklistEntry
+	TransactionBlocklist []*miningmanagermodel.TransactionBlocklistEntry
 }
 
 // DefaultConfig returns the default mempool configuration
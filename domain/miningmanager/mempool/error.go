@@ -50,6 +50,8 @@ const (
 	RejectFinality        RejectCode = 0x43
 	RejectDifficulty      RejectCode = 0x44
 	RejectImmatureSpend   RejectCode = 0x45
+	RejectBlocklisted     RejectCode = 0x46
+	RejectUnservedSubnet  RejectCode = 0x47
 	RejectBadOrphan       RejectCode = 0x64
 )
 
@@ -66,6 +68,8 @@ var rejectCodeStrings = map[RejectCode]string{
 	RejectDifficulty:      "REJECT_DIFFICULTY",
 	RejectNotRequested:    "REJECT_NOT_REQUESTED",
 	RejectImmatureSpend:   "REJECT_IMMATURE_SPEND",
+	RejectBlocklisted:     "REJECT_BLOCKLISTED",
+	RejectUnservedSubnet:  "REJECT_UNSERVED_SUBNETWORK",
 	RejectBadOrphan:       "REJECT_BAD_ORPHAN",
 }
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
+	"github.com/kaspanet/kaspad/util"
 )
 
 type mempool struct {
@@ -18,6 +19,8 @@ type mempool struct {
 	mempoolUTXOSet   *mempoolUTXOSet
 	transactionsPool *transactionsPool
 	orphansPool      *orphansPool
+
+	transactionBlocklist *transactionBlocklist
 }
 
 // New constructs a new mempool
@@ -30,6 +33,13 @@ func New(config *Config, consensusReference consensusreference.ConsensusReferenc
 	mp.mempoolUTXOSet = newMempoolUTXOSet(mp)
 	mp.transactionsPool = newTransactionsPool(mp)
 	mp.orphansPool = newOrphansPool(mp)
+	mp.transactionBlocklist = newTransactionBlocklist()
+	for _, entry := range config.TransactionBlocklist {
+		mp.transactionBlocklist.add(entry)
+	}
+	if len(config.TransactionBlocklist) > 0 {
+		log.Infof("Loaded %d transaction blocklist entries from the configuration", len(config.TransactionBlocklist))
+	}
 
 	return mp
 }
@@ -137,11 +147,11 @@ func (mp *mempool) HandleNewBlockTransactions(transactions []*externalapi.Domain
 	return mp.handleNewBlockTransactions(transactions)
 }
 
-func (mp *mempool) BlockCandidateTransactions() []*externalapi.DomainTransaction {
+func (mp *mempool) BlockCandidates() []*miningmanagermodel.BlockCandidate {
 	mp.mtx.RLock()
 	defer mp.mtx.RUnlock()
 
-	return mp.transactionsPool.allReadyTransactions()
+	return mp.transactionsPool.allBlockCandidates()
 }
 
 func (mp *mempool) RevalidateHighPriorityTransactions() (validTransactions []*externalapi.DomainTransaction, err error) {
@@ -164,3 +174,52 @@ func (mp *mempool) RemoveTransaction(transactionID *externalapi.DomainTransactio
 
 	return mp.removeTransaction(transactionID, removeRedeemers)
 }
+
+// SetMinimumRelayTransactionFee replaces the minimum fee, in sompi/kB, of the
+// transactions accepted to the mempool. Transactions that are already in the
+// mempool are kept.
+func (mp *mempool) SetMinimumRelayTransactionFee(minimumRelayTransactionFee util.Amount) {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	mp.config.MinimumRelayTransactionFee = minimumRelayTransactionFee
+}
+
+// AddToTransactionBlocklist adds entries to the blocklist of the mempool,
+// which refuses the transactions they block from now on. The transactions
+// that they block and are already in the mempool are removed, along with
+// their redeemers. The IDs of the blocked transactions that were removed
+// are returned.
+func (mp *mempool) AddToTransactionBlocklist(entries []*miningmanagermodel.TransactionBlocklistEntry) (
+	removedTransactionIDs []*externalapi.DomainTransactionID, err error) {
+
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	return mp.addToTransactionBlocklist(entries)
+}
+
+// RemoveFromTransactionBlocklist removes the entries of the transaction IDs
+// and the script public keys of entries from the blocklist of the mempool.
+// It returns the number of entries that were removed.
+func (mp *mempool) RemoveFromTransactionBlocklist(entries []*miningmanagermodel.TransactionBlocklistEntry) int {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+
+	removedCount := 0
+	for _, entry := range entries {
+		if mp.transactionBlocklist.remove(entry) {
+			removedCount++
+		}
+	}
+	return removedCount
+}
+
+// TransactionBlocklist returns the entries of the blocklist of the mempool,
+// ordered by the time they were added
+func (mp *mempool) TransactionBlocklist() []*miningmanagermodel.TransactionBlocklistEntry {
+	mp.mtx.RLock()
+	defer mp.mtx.RUnlock()
+
+	return mp.transactionBlocklist.entries()
+}
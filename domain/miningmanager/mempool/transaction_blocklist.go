@@ -0,0 +1,153 @@
+package mempool
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
+	"github.com/kaspanet/kaspad/util/mstime"
+)
+
+// transactionBlocklist holds the transaction IDs and output scripts that the
+// mempool refuses transactions by
+type transactionBlocklist struct {
+	transactionIDs   map[externalapi.DomainTransactionID]*miningmanagermodel.TransactionBlocklistEntry
+	scriptPublicKeys map[string]*miningmanagermodel.TransactionBlocklistEntry
+}
+
+func newTransactionBlocklist() *transactionBlocklist {
+	return &transactionBlocklist{
+		transactionIDs:   make(map[externalapi.DomainTransactionID]*miningmanagermodel.TransactionBlocklistEntry),
+		scriptPublicKeys: make(map[string]*miningmanagermodel.TransactionBlocklistEntry),
+	}
+}
+
+// add adds entry to the blocklist, replacing the entry of the same
+// transaction ID or script public key, if there is one. AddedAt is set to
+// now unless it's already set.
+func (tb *transactionBlocklist) add(entry *miningmanagermodel.TransactionBlocklistEntry) {
+	entryCopy := *entry
+	if entryCopy.AddedAt.IsZero() {
+		entryCopy.AddedAt = mstime.Now()
+	}
+	if entryCopy.TransactionID != nil {
+		tb.transactionIDs[*entryCopy.TransactionID] = &entryCopy
+		return
+	}
+	tb.scriptPublicKeys[entryCopy.ScriptPublicKey.String()] = &entryCopy
+}
+
+// remove removes the entry of the transaction ID or the script public key
+// of entry from the blocklist. It returns false if there was no such entry.
+func (tb *transactionBlocklist) remove(entry *miningmanagermodel.TransactionBlocklistEntry) bool {
+	if entry.TransactionID != nil {
+		if _, ok := tb.transactionIDs[*entry.TransactionID]; !ok {
+			return false
+		}
+		delete(tb.transactionIDs, *entry.TransactionID)
+		return true
+	}
+	key := entry.ScriptPublicKey.String()
+	if _, ok := tb.scriptPublicKeys[key]; !ok {
+		return false
+	}
+	delete(tb.scriptPublicKeys, key)
+	return true
+}
+
+// match returns the entry that blocks transaction, or nil if it's not blocked
+func (tb *transactionBlocklist) match(transaction *externalapi.DomainTransaction) *miningmanagermodel.TransactionBlocklistEntry {
+	if len(tb.transactionIDs) > 0 {
+		if entry, ok := tb.transactionIDs[*consensushashing.TransactionID(transaction)]; ok {
+			return entry
+		}
+	}
+	if len(tb.scriptPublicKeys) > 0 {
+		for _, output := range transaction.Outputs {
+			if entry, ok := tb.scriptPublicKeys[output.ScriptPublicKey.String()]; ok {
+				return entry
+			}
+		}
+	}
+	return nil
+}
+
+// entries returns copies of the entries of the blocklist, ordered by the
+// time they were added
+func (tb *transactionBlocklist) entries() []*miningmanagermodel.TransactionBlocklistEntry {
+	entries := make([]*miningmanagermodel.TransactionBlocklistEntry, 0,
+		len(tb.transactionIDs)+len(tb.scriptPublicKeys))
+	for _, entry := range tb.transactionIDs {
+		entryCopy := *entry
+		entries = append(entries, &entryCopy)
+	}
+	for _, entry := range tb.scriptPublicKeys {
+		entryCopy := *entry
+		entries = append(entries, &entryCopy)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].AddedAt.Before(entries[j].AddedAt)
+	})
+	return entries
+}
+
+// checkTransactionBlocklist returns a RejectBlocklisted error if the
+// blocklist blocks transaction
+func (mp *mempool) checkTransactionBlocklist(transaction *externalapi.DomainTransaction) error {
+	entry := mp.transactionBlocklist.match(transaction)
+	if entry == nil {
+		return nil
+	}
+	transactionID := consensushashing.TransactionID(transaction)
+	var str string
+	if entry.TransactionID != nil {
+		str = fmt.Sprintf("transaction %s is blocklisted", transactionID)
+	} else {
+		str = fmt.Sprintf("transaction %s pays to the blocklisted script public key %x",
+			transactionID, entry.ScriptPublicKey.Script)
+	}
+	log.Debugf("Rejected %s", str)
+	return transactionRuleError(RejectBlocklisted, str)
+}
+
+// addToTransactionBlocklist adds entries to the blocklist, and removes the
+// transactions they block, along with their redeemers, from the mempool
+func (mp *mempool) addToTransactionBlocklist(entries []*miningmanagermodel.TransactionBlocklistEntry) (
+	removedTransactionIDs []*externalapi.DomainTransactionID, err error) {
+
+	for _, entry := range entries {
+		mp.transactionBlocklist.add(entry)
+	}
+
+	var blockedTransactionIDs []*externalapi.DomainTransactionID
+	for transactionID, mempoolTransaction := range mp.transactionsPool.allTransactions {
+		if mp.transactionBlocklist.match(mempoolTransaction.Transaction()) != nil {
+			transactionIDCopy := transactionID
+			blockedTransactionIDs = append(blockedTransactionIDs, &transactionIDCopy)
+		}
+	}
+	for transactionID, orphanTransaction := range mp.orphansPool.allOrphans {
+		if mp.transactionBlocklist.match(orphanTransaction.Transaction()) != nil {
+			transactionIDCopy := transactionID
+			blockedTransactionIDs = append(blockedTransactionIDs, &transactionIDCopy)
+		}
+	}
+
+	for _, transactionID := range blockedTransactionIDs {
+		// An earlier transaction may have already been removed as the
+		// redeemer of another
+		_, isInTransactionsPool := mp.transactionsPool.allTransactions[*transactionID]
+		_, isOrphan := mp.orphansPool.allOrphans[*transactionID]
+		if !isInTransactionsPool && !isOrphan {
+			continue
+		}
+		err := mp.removeTransaction(transactionID, true)
+		if err != nil {
+			return nil, err
+		}
+		removedTransactionIDs = append(removedTransactionIDs, transactionID)
+	}
+	return removedTransactionIDs, nil
+}
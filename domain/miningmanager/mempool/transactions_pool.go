@@ -8,6 +8,7 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
 	"github.com/kaspanet/kaspad/domain/miningmanager/mempool/model"
+	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
 )
 
 type transactionsPool struct {
@@ -131,13 +132,29 @@ func (tp *transactionsPool) expireOldTransactions() error {
 	return nil
 }
 
-func (tp *transactionsPool) allReadyTransactions() []*externalapi.DomainTransaction {
-	result := []*externalapi.DomainTransaction{}
+func (tp *transactionsPool) allBlockCandidates() []*miningmanagermodel.BlockCandidate {
+	result := []*miningmanagermodel.BlockCandidate{}
 
 	for _, mempoolTransaction := range tp.allTransactions {
-		if len(mempoolTransaction.ParentTransactionsInPool()) == 0 {
-			result = append(result, mempoolTransaction.Transaction().Clone()) //this pointer leaves the mempool, and gets its utxo set to nil, hence we clone.
+		if len(mempoolTransaction.ParentTransactionsInPool()) != 0 {
+			continue
+		}
+
+		candidate := &miningmanagermodel.BlockCandidate{
+			Transaction:    mempoolTransaction.Transaction().Clone(), //this pointer leaves the mempool, and gets its utxo set to nil, hence we clone.
+			IsHighPriority: mempoolTransaction.IsHighPriority(),
+		}
+		// A transaction may be reached through several of its parents
+		visited := make(map[externalapi.DomainTransactionID]struct{})
+		for _, redeemer := range tp.getRedeemers(mempoolTransaction) {
+			if _, ok := visited[*redeemer.TransactionID()]; ok {
+				continue
+			}
+			visited[*redeemer.TransactionID()] = struct{}{}
+			candidate.DescendantsFee += redeemer.Transaction().Fee
+			candidate.DescendantsMass += redeemer.Transaction().Mass
 		}
+		result = append(result, candidate)
 	}
 
 	return result
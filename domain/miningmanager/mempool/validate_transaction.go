@@ -26,6 +26,16 @@ func (mp *mempool) validateTransactionInIsolation(transaction *externalapi.Domai
 			fmt.Sprintf("transaction %s is already in the mempool", transactionID))
 	}
 
+	if !mp.config.ValidatedSubnetworks.Passes(transaction.SubnetworkID) {
+		return transactionRuleError(RejectUnservedSubnet,
+			fmt.Sprintf("transaction %s is of the subnetwork %s, whose transactions this node doesn't validate",
+				transactionID, transaction.SubnetworkID))
+	}
+
+	if err := mp.checkTransactionBlocklist(transaction); err != nil {
+		return err
+	}
+
 	if !mp.config.AcceptNonStandard {
 		if err := mp.checkTransactionStandardInIsolation(transaction); err != nil {
 			// Attempt to extract a reject code from the error so
@@ -1,18 +1,23 @@
 package miningmanager
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/domain/consensusreference"
 	miningmanagermodel "github.com/kaspanet/kaspad/domain/miningmanager/model"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/mstime"
 )
 
 // MiningManager creates block templates for mining as well as maintaining
 // known transactions that have no yet been added to any block
 type MiningManager interface {
 	GetBlockTemplate(coinbaseData *externalapi.DomainCoinbaseData) (block *externalapi.DomainBlock, isNearlySynced bool, err error)
+	GetBlockTemplateWithInfo(coinbaseData *externalapi.DomainCoinbaseData, options BlockTemplateOptions) (
+		block *externalapi.DomainBlock, isNearlySynced bool, info BlockTemplateInfo, err error)
 	ClearBlockTemplate()
 	GetBlockTemplateBuilder() miningmanagermodel.BlockTemplateBuilder
 	GetTransaction(transactionID *externalapi.DomainTransactionID, includeTransactionPool bool, includeOrphanPool bool) (
@@ -33,70 +38,241 @@ type MiningManager interface {
 	ValidateAndInsertTransaction(transaction *externalapi.DomainTransaction, isHighPriority bool, allowOrphan bool) (
 		acceptedTransactions []*externalapi.DomainTransaction, err error)
 	RevalidateHighPriorityTransactions() (validTransactions []*externalapi.DomainTransaction, err error)
+	SetMinimumRelayTransactionFee(minimumRelayTransactionFee util.Amount)
+	AddToTransactionBlocklist(entries []*miningmanagermodel.TransactionBlocklistEntry) (
+		removedTransactionIDs []*externalapi.DomainTransactionID, err error)
+	RemoveFromTransactionBlocklist(entries []*miningmanagermodel.TransactionBlocklistEntry) int
+	TransactionBlocklist() []*miningmanagermodel.TransactionBlocklistEntry
+}
+
+const (
+	// maxBlockTemplateAge is the maximum time a block template is reused before it
+	// is rebuilt from scratch, even if no change to the virtual or to the mempool
+	// was noticed
+	maxBlockTemplateAge = 10 * time.Second
+
+	// mempoolRefreshInterval is the minimum time between block template rebuilds
+	// caused by mempool changes. Transactions usually arrive much more frequently
+	// than templates are requested, so rebuilding on every change is wasteful.
+	mempoolRefreshInterval = time.Second
+
+	// maxCachedCoinbaseTemplates is the maximum amount of per-coinbase templates
+	// kept for the current block template
+	maxCachedCoinbaseTemplates = 100
+)
+
+// BlockTemplateOptions are per-call options for GetBlockTemplateWithInfo
+type BlockTemplateOptions struct {
+	// MinFees is the minimum total fees, in sompi, of the mempool transactions that
+	// may be included in the block template. Right after the virtual changes, the
+	// mempool is often nearly empty since the new block consumed most of its
+	// transactions. If MinFees is set, building the template is delayed until the
+	// mempool accumulates that amount of fees, or until MaxWait passes since the
+	// virtual changed, whichever comes first.
+	MinFees uint64
+
+	// MaxWait is the maximum time since the last virtual change to wait for MinFees
+	MaxWait time.Duration
+}
+
+// BlockTemplateInfo describes the cached block template a block returned from
+// GetBlockTemplateWithInfo was derived from
+type BlockTemplateInfo struct {
+	// Version is incremented every time the block template is rebuilt from scratch
+	Version uint64
+
+	// Age is the time that passed since the block template was rebuilt
+	Age time.Duration
 }
 
 type miningManager struct {
-	consensusReference   consensusreference.ConsensusReference
-	mempool              miningmanagermodel.Mempool
-	blockTemplateBuilder miningmanagermodel.BlockTemplateBuilder
-	cachedBlockTemplate  *externalapi.DomainBlockTemplate
-	cachingTime          time.Time
-	cacheLock            *sync.Mutex
+	consensusReference      consensusreference.ConsensusReference
+	mempool                 miningmanagermodel.Mempool
+	blockTemplateBuilder    miningmanagermodel.BlockTemplateBuilder
+	cachedBlockTemplate     *externalapi.DomainBlockTemplate
+	cachedCoinbaseTemplates map[string]*externalapi.DomainBlockTemplate
+	cachedTemplateVersion   uint64
+	isMempoolModified       bool
+	mempoolModified         chan struct{}
+	cachingTime             time.Time
+	virtualChangeTime       time.Time
+	cacheLock               *sync.Mutex
+	clock                   mstime.Clock
 }
 
 // GetBlockTemplate obtains a block template for a miner to consume
 func (mm *miningManager) GetBlockTemplate(coinbaseData *externalapi.DomainCoinbaseData) (block *externalapi.DomainBlock, isNearlySynced bool, err error) {
+	block, isNearlySynced, _, err = mm.GetBlockTemplateWithInfo(coinbaseData, BlockTemplateOptions{})
+	return block, isNearlySynced, err
+}
+
+// GetBlockTemplateWithInfo obtains a block template for a miner to consume, along with
+// the version and age of the cached template it was derived from.
+//
+// The block template is rebuilt from scratch only when the virtual changes, or when the
+// mempool changes (at most once per mempoolRefreshInterval). Otherwise, the template is
+// adapted to the requested coinbase data, and templates for recently requested coinbase
+// data only get their timestamp updated.
+//
+// See BlockTemplateOptions for the available options.
+func (mm *miningManager) GetBlockTemplateWithInfo(coinbaseData *externalapi.DomainCoinbaseData,
+	options BlockTemplateOptions) (block *externalapi.DomainBlock, isNearlySynced bool, info BlockTemplateInfo, err error) {
+
+	hasWaitedForFees := mm.waitForMempoolFees(options)
+
 	mm.cacheLock.Lock()
-	immutableCachedTemplate := mm.getImmutableCachedTemplate()
-	// We first try and use a cached template
-	if immutableCachedTemplate != nil {
-		mm.cacheLock.Unlock()
-		if immutableCachedTemplate.CoinbaseData.Equal(coinbaseData) {
-			return immutableCachedTemplate.Block, immutableCachedTemplate.IsNearlySynced, nil
+	defer mm.cacheLock.Unlock()
+
+	if mm.shouldRebuildBlockTemplate(hasWaitedForFees) {
+		blockTemplate, err := mm.blockTemplateBuilder.BuildBlockTemplate(coinbaseData)
+		if err != nil {
+			return nil, false, BlockTemplateInfo{}, err
 		}
+		mm.setImmutableCachedTemplate(blockTemplate)
+		return blockTemplate.Block, blockTemplate.IsNearlySynced, mm.blockTemplateInfo(), nil
+	}
+
+	key := coinbaseDataKey(coinbaseData)
+	blockTemplate, ok := mm.cachedCoinbaseTemplates[key]
+	if ok {
+		blockTemplate = mm.withUpdatedTimestamp(blockTemplate)
+	} else {
 		// Coinbase data is new -- make the minimum changes required
 		// Note we first clone the block template since it is modified by the call
-		modifiedBlockTemplate, err := mm.blockTemplateBuilder.ModifyBlockTemplate(coinbaseData, immutableCachedTemplate.Clone())
+		blockTemplate, err = mm.blockTemplateBuilder.ModifyBlockTemplate(coinbaseData, mm.cachedBlockTemplate.Clone())
 		if err != nil {
-			return nil, false, err
+			return nil, false, BlockTemplateInfo{}, err
+		}
+		if len(mm.cachedCoinbaseTemplates) >= maxCachedCoinbaseTemplates {
+			mm.cachedCoinbaseTemplates = make(map[string]*externalapi.DomainBlockTemplate)
 		}
-
-		// No point in updating cache since we have no reason to believe this coinbase will be used more
-		// than the previous one, and we want to maintain the original template caching time
-		return modifiedBlockTemplate.Block, modifiedBlockTemplate.IsNearlySynced, nil
-	}
-	defer mm.cacheLock.Unlock()
-	// No relevant cache, build a template
-	blockTemplate, err := mm.blockTemplateBuilder.BuildBlockTemplate(coinbaseData)
-	if err != nil {
-		return nil, false, err
 	}
-	// Cache the built template
-	mm.setImmutableCachedTemplate(blockTemplate)
-	return blockTemplate.Block, blockTemplate.IsNearlySynced, nil
+	mm.cachedCoinbaseTemplates[key] = blockTemplate
+	return blockTemplate.Block, blockTemplate.IsNearlySynced, mm.blockTemplateInfo(), nil
 }
 
 func (mm *miningManager) ClearBlockTemplate() {
 	mm.cacheLock.Lock()
 	mm.cachingTime = time.Time{}
+	mm.virtualChangeTime = time.Now()
 	mm.cachedBlockTemplate = nil
+	mm.cachedCoinbaseTemplates = nil
 	mm.cacheLock.Unlock()
 }
 
-func (mm *miningManager) getImmutableCachedTemplate() *externalapi.DomainBlockTemplate {
-	if time.Since(mm.cachingTime) > time.Second {
-		// No point in cache optimizations if queries are more than a second apart -- we prefer rechecking the mempool.
-		// Full explanation: On the one hand this is a sub-millisecond optimization, so there is no harm in doing the full block building
-		// every ~1 second. Additionally, we would like to refresh the mempool access even if virtual info was
-		// unmodified for a while. All in all, caching for max 1 second is a good compromise.
-		mm.cachedBlockTemplate = nil
+// shouldRebuildBlockTemplate returns whether the cached block template is outdated.
+// If ignoreMempoolRefreshInterval is set, any mempool change outdates the template.
+func (mm *miningManager) shouldRebuildBlockTemplate(ignoreMempoolRefreshInterval bool) bool {
+	if mm.cachedBlockTemplate == nil {
+		return true
 	}
-	return mm.cachedBlockTemplate
+	templateAge := time.Since(mm.cachingTime)
+	if templateAge > maxBlockTemplateAge {
+		return true
+	}
+	// Transaction selection depends on the whole mempool, so any mempool change
+	// requires a rebuild of the template
+	return mm.isMempoolModified && (ignoreMempoolRefreshInterval || templateAge > mempoolRefreshInterval)
+}
+
+// waitForMempoolFees blocks until the fees in the mempool reach options.MinFees, or
+// until options.MaxWait passes since the last virtual change. The fees are only
+// summed again once the mempool is modified. Returns whether it had to wait.
+func (mm *miningManager) waitForMempoolFees(options BlockTemplateOptions) bool {
+	if options.MinFees == 0 || options.MaxWait == 0 {
+		return false
+	}
+
+	mm.cacheLock.Lock()
+	deadline := mm.virtualChangeTime.Add(options.MaxWait)
+	mm.cacheLock.Unlock()
+
+	timeout := time.Until(deadline)
+	if timeout <= 0 {
+		return false
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	hasWaited := false
+	for {
+		// The channel is taken before the fees are summed, so that a
+		// modification in between isn't missed
+		mm.cacheLock.Lock()
+		mempoolModified := mm.mempoolModified
+		mm.cacheLock.Unlock()
+
+		if mm.mempoolFees() >= options.MinFees {
+			return hasWaited
+		}
+		select {
+		case <-mempoolModified:
+			hasWaited = true
+		case <-timer.C:
+			return true
+		}
+	}
+}
+
+// mempoolFees returns the total fees of the mempool transactions that may be
+// included in a block
+func (mm *miningManager) mempoolFees() uint64 {
+	fees := uint64(0)
+	for _, candidate := range mm.mempool.BlockCandidates() {
+		fees += candidate.Transaction.Fee
+	}
+	return fees
 }
 
 func (mm *miningManager) setImmutableCachedTemplate(blockTemplate *externalapi.DomainBlockTemplate) {
 	mm.cachingTime = time.Now()
 	mm.cachedBlockTemplate = blockTemplate
+	mm.cachedCoinbaseTemplates = map[string]*externalapi.DomainBlockTemplate{
+		coinbaseDataKey(blockTemplate.CoinbaseData): blockTemplate,
+	}
+	mm.cachedTemplateVersion++
+	mm.isMempoolModified = false
+}
+
+func (mm *miningManager) blockTemplateInfo() BlockTemplateInfo {
+	return BlockTemplateInfo{
+		Version: mm.cachedTemplateVersion,
+		Age:     time.Since(mm.cachingTime),
+	}
+}
+
+// markMempoolModified outdates the cached block template, and wakes up the
+// callers that wait for mempool fees
+func (mm *miningManager) markMempoolModified() {
+	mm.cacheLock.Lock()
+	mm.isMempoolModified = true
+	close(mm.mempoolModified)
+	mm.mempoolModified = make(chan struct{})
+	mm.cacheLock.Unlock()
+}
+
+func coinbaseDataKey(coinbaseData *externalapi.DomainCoinbaseData) string {
+	return fmt.Sprintf("%d/%x/%x", coinbaseData.ScriptPublicKey.Version, coinbaseData.ScriptPublicKey.Script, coinbaseData.ExtraData)
+}
+
+// withUpdatedTimestamp returns a copy of the given block template with its timestamp set
+// to the current time. Cached templates are shared between callers, so they are never
+// modified in place.
+func (mm *miningManager) withUpdatedTimestamp(blockTemplate *externalapi.DomainBlockTemplate) *externalapi.DomainBlockTemplate {
+	newTimestamp := mm.clock.Now().UnixMilliseconds()
+	if newTimestamp <= blockTemplate.Block.Header.TimeInMilliseconds() {
+		// Keep the previous time as built by internal consensus median time logic
+		return blockTemplate
+	}
+	mutableHeader := blockTemplate.Block.Header.ToMutable()
+	mutableHeader.SetTimeInMilliseconds(newTimestamp)
+
+	updatedBlockTemplate := *blockTemplate
+	updatedBlockTemplate.Block = &externalapi.DomainBlock{
+		Header:       mutableHeader.ToImmutable(),
+		Transactions: blockTemplate.Block.Transactions,
+	}
+	return &updatedBlockTemplate
 }
 
 func (mm *miningManager) GetBlockTemplateBuilder() miningmanagermodel.BlockTemplateBuilder {
@@ -105,6 +281,7 @@ func (mm *miningManager) GetBlockTemplateBuilder() miningmanagermodel.BlockTempl
 
 // HandleNewBlockTransactions handles the transactions for a new block that was just added to the DAG
 func (mm *miningManager) HandleNewBlockTransactions(txs []*externalapi.DomainTransaction) ([]*externalapi.DomainTransaction, error) {
+	defer mm.markMempoolModified()
 	return mm.mempool.HandleNewBlockTransactions(txs)
 }
 
@@ -114,7 +291,11 @@ func (mm *miningManager) HandleNewBlockTransactions(txs []*externalapi.DomainTra
 func (mm *miningManager) ValidateAndInsertTransaction(transaction *externalapi.DomainTransaction,
 	isHighPriority bool, allowOrphan bool) (acceptedTransactions []*externalapi.DomainTransaction, err error) {
 
-	return mm.mempool.ValidateAndInsertTransaction(transaction, isHighPriority, allowOrphan)
+	acceptedTransactions, err = mm.mempool.ValidateAndInsertTransaction(transaction, isHighPriority, allowOrphan)
+	if len(acceptedTransactions) > 0 {
+		mm.markMempoolModified()
+	}
+	return acceptedTransactions, err
 }
 
 func (mm *miningManager) GetTransaction(
@@ -152,5 +333,31 @@ func (mm *miningManager) TransactionCount(includeTransactionPool bool, includeOr
 func (mm *miningManager) RevalidateHighPriorityTransactions() (
 	validTransactions []*externalapi.DomainTransaction, err error) {
 
+	defer mm.markMempoolModified()
 	return mm.mempool.RevalidateHighPriorityTransactions()
 }
+
+// SetMinimumRelayTransactionFee replaces the minimum fee, in sompi/kB, of the
+// transactions accepted to the mempool
+func (mm *miningManager) SetMinimumRelayTransactionFee(minimumRelayTransactionFee util.Amount) {
+	mm.mempool.SetMinimumRelayTransactionFee(minimumRelayTransactionFee)
+}
+
+// AddToTransactionBlocklist adds entries to the blocklist of the mempool, and
+// removes the transactions they block from it. See Mempool.AddToTransactionBlocklist
+func (mm *miningManager) AddToTransactionBlocklist(entries []*miningmanagermodel.TransactionBlocklistEntry) (
+	removedTransactionIDs []*externalapi.DomainTransactionID, err error) {
+
+	defer mm.markMempoolModified()
+	return mm.mempool.AddToTransactionBlocklist(entries)
+}
+
+// RemoveFromTransactionBlocklist removes entries from the blocklist of the mempool
+func (mm *miningManager) RemoveFromTransactionBlocklist(entries []*miningmanagermodel.TransactionBlocklistEntry) int {
+	return mm.mempool.RemoveFromTransactionBlocklist(entries)
+}
+
+// TransactionBlocklist returns the entries of the blocklist of the mempool
+func (mm *miningManager) TransactionBlocklist() []*miningmanagermodel.TransactionBlocklistEntry {
+	return mm.mempool.TransactionBlocklist()
+}
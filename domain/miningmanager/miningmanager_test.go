@@ -1,6 +1,8 @@
 package miningmanager_test
 
 import (
+	"bytes"
+
 	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet"
 	"github.com/kaspanet/kaspad/domain/consensusreference"
 	"github.com/kaspanet/kaspad/domain/miningmanager/model"
@@ -9,6 +11,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/kaspanet/kaspad/domain/miningmanager/mempool"
 
@@ -741,6 +744,265 @@ func TestModifyBlockTemplate(t *testing.T) {
 	})
 }
 
+func TestBlockTemplateCache(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestBlockTemplateCache")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		miningFactory := miningmanager.NewFactory()
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params))
+
+		emptyCoinbaseData := &externalapi.DomainCoinbaseData{
+			ScriptPublicKey: &externalapi.ScriptPublicKey{Script: nil, Version: 0},
+			ExtraData:       nil}
+		coinbaseUsual, err := generateNewCoinbase(consensusConfig.Prefix, opUsual)
+		if err != nil {
+			t.Fatalf("Generate coinbase: %v.", err)
+		}
+
+		block, _, info, err := miningManager.GetBlockTemplateWithInfo(emptyCoinbaseData, miningmanager.BlockTemplateOptions{})
+		if err != nil {
+			t.Fatalf("GetBlockTemplateWithInfo: %v", err)
+		}
+
+		// Requesting a template for different coinbase data should only modify the coinbase
+		otherBlock, _, otherInfo, err := miningManager.GetBlockTemplateWithInfo(coinbaseUsual, miningmanager.BlockTemplateOptions{})
+		if err != nil {
+			t.Fatalf("GetBlockTemplateWithInfo: %v", err)
+		}
+		if otherInfo.Version != info.Version {
+			t.Fatalf("Expected template version %d for new coinbase data, but got %d", info.Version, otherInfo.Version)
+		}
+		if bytes.Equal(block.Transactions[transactionhelper.CoinbaseTransactionIndex].Payload,
+			otherBlock.Transactions[transactionhelper.CoinbaseTransactionIndex].Payload) {
+			t.Fatalf("Expected the coinbase payload to change along with the coinbase data")
+		}
+
+		// Requesting a template for previously requested coinbase data should reuse the cached template
+		sameBlock, _, sameInfo, err := miningManager.GetBlockTemplateWithInfo(emptyCoinbaseData, miningmanager.BlockTemplateOptions{})
+		if err != nil {
+			t.Fatalf("GetBlockTemplateWithInfo: %v", err)
+		}
+		if sameInfo.Version != info.Version {
+			t.Fatalf("Expected template version %d for cached coinbase data, but got %d", info.Version, sameInfo.Version)
+		}
+		if !bytes.Equal(block.Transactions[transactionhelper.CoinbaseTransactionIndex].Payload,
+			sameBlock.Transactions[transactionhelper.CoinbaseTransactionIndex].Payload) {
+			t.Fatalf("Expected the cached coinbase payload to be reused")
+		}
+		if sameBlock.Header.TimeInMilliseconds() < block.Header.TimeInMilliseconds() {
+			t.Fatalf("Expected the timestamp of a cached template not to decrease")
+		}
+
+		// Clearing the template, as done when the virtual changes, should rebuild it
+		miningManager.ClearBlockTemplate()
+		_, _, rebuiltInfo, err := miningManager.GetBlockTemplateWithInfo(coinbaseUsual, miningmanager.BlockTemplateOptions{})
+		if err != nil {
+			t.Fatalf("GetBlockTemplateWithInfo: %v", err)
+		}
+		if rebuiltInfo.Version != info.Version+1 {
+			t.Fatalf("Expected template version %d after clearing the template, but got %d",
+				info.Version+1, rebuiltInfo.Version)
+		}
+	})
+}
+
+func TestBlockTemplateMinFees(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestBlockTemplateMinFees")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		miningFactory := miningmanager.NewFactory()
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params))
+
+		emptyCoinbaseData := &externalapi.DomainCoinbaseData{
+			ScriptPublicKey: &externalapi.ScriptPublicKey{Script: nil, Version: 0},
+			ExtraData:       nil}
+		const maxWait = 200 * time.Millisecond
+		options := miningmanager.BlockTemplateOptions{MinFees: 1, MaxWait: maxWait}
+
+		// Right after the virtual changes, an empty mempool should delay the template until MaxWait passes
+		miningManager.ClearBlockTemplate()
+		start := time.Now()
+		_, _, _, err = miningManager.GetBlockTemplateWithInfo(emptyCoinbaseData, options)
+		if err != nil {
+			t.Fatalf("GetBlockTemplateWithInfo: %v", err)
+		}
+		if elapsed := time.Since(start); elapsed < maxWait/2 {
+			t.Fatalf("Expected the template to wait for fees for about %s, but it took %s", maxWait, elapsed)
+		}
+
+		// Once MaxWait has passed since the virtual changed, the template should not wait
+		start = time.Now()
+		_, _, _, err = miningManager.GetBlockTemplateWithInfo(emptyCoinbaseData, options)
+		if err != nil {
+			t.Fatalf("GetBlockTemplateWithInfo: %v", err)
+		}
+		if elapsed := time.Since(start); elapsed >= maxWait/2 {
+			t.Fatalf("Expected the template not to wait after MaxWait passed, but it took %s", elapsed)
+		}
+	})
+}
+
+// TestTransactionBlocklist verifies that the mempool refuses the transactions of its blocklist,
+// and removes them once they're blocklisted.
+func TestTransactionBlocklist(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestTransactionBlocklist")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		miningFactory := miningmanager.NewFactory()
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempool.DefaultConfig(&consensusConfig.Params))
+
+		isBlocklistedError := func(err error) bool {
+			txRuleError := &mempool.TxRuleError{}
+			return errors.As(err, txRuleError) && txRuleError.RejectCode == mempool.RejectBlocklisted
+		}
+
+		transactions := make([]*externalapi.DomainTransaction, 3)
+		for i := range transactions {
+			transactions[i] = createTransactionWithUTXOEntry(t, i, 0)
+			_, err = miningManager.ValidateAndInsertTransaction(transactions[i], false, true)
+			if err != nil {
+				t.Fatalf("ValidateAndInsertTransaction: %v", err)
+			}
+		}
+
+		// Blocklisting a transaction removes it from the mempool, and the mempool refuses it from then on
+		transactionIDEntry := &model.TransactionBlocklistEntry{
+			TransactionID: consensushashing.TransactionID(transactions[0]),
+			Reason:        "test",
+		}
+		removedTransactionIDs, err := miningManager.AddToTransactionBlocklist(
+			[]*model.TransactionBlocklistEntry{transactionIDEntry})
+		if err != nil {
+			t.Fatalf("AddToTransactionBlocklist: %v", err)
+		}
+		if len(removedTransactionIDs) != 1 || !removedTransactionIDs[0].Equal(transactionIDEntry.TransactionID) {
+			t.Fatalf("Expected only %s to be removed from the mempool, but got %v",
+				transactionIDEntry.TransactionID, removedTransactionIDs)
+		}
+		if miningManager.TransactionCount(true, true) != len(transactions)-1 {
+			t.Fatalf("Expected %d transactions in the mempool, but got %d",
+				len(transactions)-1, miningManager.TransactionCount(true, true))
+		}
+		_, err = miningManager.ValidateAndInsertTransaction(transactions[0], false, true)
+		if !isBlocklistedError(err) {
+			t.Fatalf("Expected a blocklisted transaction to be rejected, but got %+v", err)
+		}
+
+		// Once it's removed from the blocklist, the transaction is accepted again
+		if miningManager.RemoveFromTransactionBlocklist([]*model.TransactionBlocklistEntry{transactionIDEntry}) != 1 {
+			t.Fatalf("Expected the entry to be removed from the blocklist")
+		}
+		if miningManager.RemoveFromTransactionBlocklist([]*model.TransactionBlocklistEntry{transactionIDEntry}) != 0 {
+			t.Fatalf("Expected the entry to be removed only once")
+		}
+		_, err = miningManager.ValidateAndInsertTransaction(transactions[0], false, true)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertTransaction: %v", err)
+		}
+
+		// Blocklisting a script public key removes all the transactions that pay to it
+		scriptPublicKeyEntry := &model.TransactionBlocklistEntry{
+			ScriptPublicKey: transactions[0].Outputs[0].ScriptPublicKey,
+			Reason:          "test",
+		}
+		removedTransactionIDs, err = miningManager.AddToTransactionBlocklist(
+			[]*model.TransactionBlocklistEntry{scriptPublicKeyEntry})
+		if err != nil {
+			t.Fatalf("AddToTransactionBlocklist: %v", err)
+		}
+		if len(removedTransactionIDs) != len(transactions) || miningManager.TransactionCount(true, true) != 0 {
+			t.Fatalf("Expected all %d transactions to be removed from the mempool, but %d were",
+				len(transactions), len(removedTransactionIDs))
+		}
+		_, err = miningManager.ValidateAndInsertTransaction(createTransactionWithUTXOEntry(t, len(transactions), 0), false, true)
+		if !isBlocklistedError(err) {
+			t.Fatalf("Expected a transaction that pays to a blocklisted script public key to be rejected, but got %+v", err)
+		}
+		blocklist := miningManager.TransactionBlocklist()
+		if len(blocklist) != 1 || !blocklist[0].ScriptPublicKey.Equal(scriptPublicKeyEntry.ScriptPublicKey) ||
+			blocklist[0].Reason != scriptPublicKeyEntry.Reason || blocklist[0].AddedAt.IsZero() {
+			t.Fatalf("Unexpected blocklist %v", blocklist)
+		}
+
+		// The blocklist of the configuration applies from the start
+		mempoolConfig := mempool.DefaultConfig(&consensusConfig.Params)
+		mempoolConfig.TransactionBlocklist = []*model.TransactionBlocklistEntry{transactionIDEntry}
+		miningManager = miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig)
+		_, err = miningManager.ValidateAndInsertTransaction(transactions[0], false, true)
+		if !isBlocklistedError(err) {
+			t.Fatalf("Expected a transaction blocklisted by the configuration to be rejected, but got %+v", err)
+		}
+		_, err = miningManager.ValidateAndInsertTransaction(transactions[1], false, true)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertTransaction: %v", err)
+		}
+	})
+}
+
+// TestValidatedSubnetworks verifies that the mempool rejects the transactions of subnetworks it doesn't validate
+func TestValidatedSubnetworks(t *testing.T) {
+	testutils.ForAllNets(t, true, func(t *testing.T, consensusConfig *consensus.Config) {
+		consensusConfig.BlockCoinbaseMaturity = 0
+		factory := consensus.NewFactory()
+		tc, teardown, err := factory.NewTestConsensus(consensusConfig, "TestValidatedSubnetworks")
+		if err != nil {
+			t.Fatalf("Error setting up TestConsensus: %+v", err)
+		}
+		defer teardown(false)
+
+		validatedSubnetworkID := externalapi.DomainSubnetworkID{100}
+		unvalidatedSubnetworkID := externalapi.DomainSubnetworkID{101}
+
+		miningFactory := miningmanager.NewFactory()
+		tcAsConsensus := tc.(externalapi.Consensus)
+		tcAsConsensusPointer := &tcAsConsensus
+		consensusReference := consensusreference.NewConsensusReference(&tcAsConsensusPointer)
+		mempoolConfig := mempool.DefaultConfig(&consensusConfig.Params)
+		mempoolConfig.ValidatedSubnetworks = subnetworks.NewFilter(
+			[]*externalapi.DomainSubnetworkID{&validatedSubnetworkID})
+		miningManager := miningFactory.NewMiningManager(consensusReference, &consensusConfig.Params, mempoolConfig)
+
+		unvalidatedTransaction := createTransactionWithUTXOEntry(t, 0, 0)
+		unvalidatedTransaction.SubnetworkID = unvalidatedSubnetworkID
+		_, err = miningManager.ValidateAndInsertTransaction(unvalidatedTransaction, false, true)
+		txRuleError := &mempool.TxRuleError{}
+		if !errors.As(err, txRuleError) || txRuleError.RejectCode != mempool.RejectUnservedSubnet {
+			t.Fatalf("Expected a transaction of an unvalidated subnetwork to be rejected, but got %+v", err)
+		}
+
+		// The native subnetwork is always validated, even when it isn't configured
+		_, err = miningManager.ValidateAndInsertTransaction(createTransactionWithUTXOEntry(t, 1, 0), false, true)
+		if err != nil {
+			t.Fatalf("ValidateAndInsertTransaction: %v", err)
+		}
+	})
+}
+
 func sweepCompareModifiedTemplateToBuilt(
 	t *testing.T, consensusConfig *consensus.Config, builder model.BlockTemplateBuilder) {
 	for i := 0; i < 4; i++ {
@@ -2,13 +2,14 @@ package model
 
 import (
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/util"
 )
 
 // Mempool maintains a set of known transactions that
 // are intended to be mined into new blocks
 type Mempool interface {
 	HandleNewBlockTransactions(txs []*externalapi.DomainTransaction) ([]*externalapi.DomainTransaction, error)
-	BlockCandidateTransactions() []*externalapi.DomainTransaction
+	BlockCandidates() []*BlockCandidate
 	ValidateAndInsertTransaction(transaction *externalapi.DomainTransaction, isHighPriority bool, allowOrphan bool) (
 		acceptedTransactions []*externalapi.DomainTransaction, err error)
 	RemoveTransactions(txs []*externalapi.DomainTransaction, removeRedeemers bool) error
@@ -39,4 +40,24 @@ type Mempool interface {
 		includeOrphanPool bool) int
 	RevalidateHighPriorityTransactions() (validTransactions []*externalapi.DomainTransaction, err error)
 	IsTransactionOutputDust(output *externalapi.DomainTransactionOutput) bool
+	SetMinimumRelayTransactionFee(minimumRelayTransactionFee util.Amount)
+	AddToTransactionBlocklist(entries []*TransactionBlocklistEntry) (
+		removedTransactionIDs []*externalapi.DomainTransactionID, err error)
+	RemoveFromTransactionBlocklist(entries []*TransactionBlocklistEntry) int
+	TransactionBlocklist() []*TransactionBlocklistEntry
+}
+
+// BlockCandidate is a mempool transaction that is ready to be included in a block,
+// along with the information required to select it
+type BlockCandidate struct {
+	Transaction *externalapi.DomainTransaction
+
+	// IsHighPriority is set for transactions that were submitted directly to this node
+	IsHighPriority bool
+
+	// DescendantsFee and DescendantsMass are the total fee and mass of the mempool
+	// transactions that spend the outputs of Transaction, directly or indirectly.
+	// These can only be included in blocks once Transaction is.
+	DescendantsFee  uint64
+	DescendantsMass uint64
 }
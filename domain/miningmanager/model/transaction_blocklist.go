@@ -0,0 +1,25 @@
+package model
+
+import (
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/util/mstime"
+)
+
+// TransactionBlocklistEntry is a transaction ID or an output script that the
+// mempool refuses to accept and relay transactions by. Exactly one of
+// TransactionID and ScriptPublicKey is set. The blocklist is a relay policy,
+// so blocks that contain such transactions are still valid.
+type TransactionBlocklistEntry struct {
+	// TransactionID blocks the transaction with this ID
+	TransactionID *externalapi.DomainTransactionID
+
+	// ScriptPublicKey blocks the transactions that have an output with this
+	// script public key
+	ScriptPublicKey *externalapi.ScriptPublicKey
+
+	// Reason is a free text note of why the entry was added
+	Reason string
+
+	// AddedAt is the time the entry was added to the blocklist
+	AddedAt mstime.Time
+}
@@ -0,0 +1,30 @@
+package utxoindex
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/migration"
+)
+
+// The keys of the UTXO index before it was moved into its namespace
+var (
+	oldUTXOIndexBucket      = database.MakeBucket([]byte("utxo-index"))
+	oldVirtualParentsKey    = database.MakeBucket([]byte("")).Key([]byte("utxo-index-virtual-parents"))
+	oldCirculatingSupplyKey = database.MakeBucket([]byte("")).Key([]byte("utxo-index-circulating-supply"))
+)
+
+// MigrateToNamespace moves the data of a UTXO index that was built before the
+// UTXO index was moved into its namespace. The virtual parents are moved last,
+// since the index is reset if they're missing
+func MigrateToNamespace(db database.Database) error {
+	movedCount, err := migration.MoveBucket(db, oldUTXOIndexBucket, utxoIndexBucket)
+	if err != nil {
+		return err
+	}
+	log.Infof("Moved %d UTXO index entries", movedCount)
+
+	err = migration.MoveKey(db, oldCirculatingSupplyKey, circulatingSupplyKey)
+	if err != nil {
+		return err
+	}
+	return migration.MoveKey(db, oldVirtualParentsKey, virtualParentsKey)
+}
@@ -5,14 +5,16 @@ import (
 
 	"github.com/kaspanet/kaspad/domain/consensus/database/binaryserialization"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/indexes"
 	"github.com/kaspanet/kaspad/infrastructure/db/database"
 	"github.com/kaspanet/kaspad/infrastructure/logger"
 	"github.com/pkg/errors"
 )
 
-var utxoIndexBucket = database.MakeBucket([]byte("utxo-index"))
-var virtualParentsKey = database.MakeBucket([]byte("")).Key([]byte("utxo-index-virtual-parents"))
-var circulatingSupplyKey = database.MakeBucket([]byte("")).Key([]byte("utxo-index-circulating-supply"))
+var namespace = indexes.NewNamespace(indexes.UTXOIndexName)
+var utxoIndexBucket = namespace.Bucket([]byte("utxos"))
+var virtualParentsKey = namespace.TipKey()
+var circulatingSupplyKey = namespace.Key([]byte("circulating-supply"))
 
 type utxoIndexStore struct {
 	database database.Database
@@ -263,13 +265,20 @@ func (uis *utxoIndexStore) isAnythingStaged() bool {
 	return len(uis.toAdd) > 0 || len(uis.toRemove) > 0
 }
 
-func (uis *utxoIndexStore) getUTXOOutpointEntryPairs(scriptPublicKey *externalapi.ScriptPublicKey) (UTXOOutpointEntryPairs, error) {
+// snapshot takes a snapshot of the index, to be read from without holding
+// the index's mutex
+func (uis *utxoIndexStore) snapshot() (database.Snapshot, error) {
 	if uis.isAnythingStaged() {
-		return nil, errors.Errorf("cannot get utxo outpoint entry pairs while staging isn't empty")
+		return nil, errors.Errorf("cannot take a snapshot while staging isn't empty")
 	}
+	return uis.database.Snapshot()
+}
+
+func (uis *utxoIndexStore) getUTXOOutpointEntryPairs(dataReader database.DataReader,
+	scriptPublicKey *externalapi.ScriptPublicKey) (UTXOOutpointEntryPairs, error) {
 
 	bucket := uis.bucketForScriptPublicKey(scriptPublicKey)
-	cursor, err := uis.database.Cursor(bucket)
+	cursor, err := dataReader.Cursor(bucket)
 	if err != nil {
 		return nil, err
 	}
@@ -311,36 +320,10 @@ func (uis *utxoIndexStore) getVirtualParents() ([]*externalapi.DomainHash, error
 }
 
 func (uis *utxoIndexStore) deleteAll() error {
-	// First we delete the virtual parents, so if anything goes wrong, the UTXO index will be marked as "not synced"
-	// and will be reset.
-	err := uis.database.Delete(virtualParentsKey)
-	if err != nil {
-		return err
-	}
-
-	err = uis.database.Delete(circulatingSupplyKey)
-	if err != nil {
-		return err
-	}
-
-	cursor, err := uis.database.Cursor(utxoIndexBucket)
-	if err != nil {
-		return err
-	}
-	defer cursor.Close()
-	for cursor.Next() {
-		key, err := cursor.Key()
-		if err != nil {
-			return err
-		}
-
-		err = uis.database.Delete(key)
-		if err != nil {
-			return err
-		}
-	}
-
-	return nil
+	// The namespace deletes the virtual parents first, so if anything goes wrong,
+	// the UTXO index will be marked as "not synced" and will be reset.
+	_, err := namespace.Drop(uis.database)
+	return err
 }
 
 func (uis *utxoIndexStore) initializeCirculatingSompiSupply() error {
@@ -3,6 +3,7 @@ package utxoindex
 import (
 	"github.com/kaspanet/kaspad/domain"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/indexes"
 	"github.com/kaspanet/kaspad/infrastructure/db/database"
 	"github.com/kaspanet/kaspad/infrastructure/logger"
 	"sync"
@@ -11,8 +12,9 @@ import (
 // UTXOIndex maintains an index between transaction scriptPublicKeys
 // and UTXOs
 type UTXOIndex struct {
-	domain domain.Domain
-	store  *utxoIndexStore
+	domain   domain.Domain
+	store    *utxoIndexStore
+	progress *indexes.Progress
 
 	mutex sync.Mutex
 }
@@ -22,8 +24,9 @@ type UTXOIndex struct {
 // NOTE: While this is called no new blocks can be added to the consensus.
 func New(domain domain.Domain, database database.Database) (*UTXOIndex, error) {
 	utxoIndex := &UTXOIndex{
-		domain: domain,
-		store:  newUTXOIndexStore(database),
+		domain:   domain,
+		store:    newUTXOIndexStore(database),
+		progress: indexes.NewProgress(),
 	}
 	isSynced, err := utxoIndex.isSynced()
 	if err != nil {
@@ -48,11 +51,16 @@ func New(domain domain.Domain, database database.Database) (*UTXOIndex, error) {
 }
 
 // Reset deletes the whole UTXO index and resyncs it from consensus.
-func (ui *UTXOIndex) Reset() error {
+func (ui *UTXOIndex) Reset() (err error) {
 	ui.mutex.Lock()
 	defer ui.mutex.Unlock()
 
-	err := ui.store.deleteAll()
+	ui.progress.Start(indexes.OperationRebuild)
+	defer func() {
+		ui.progress.Finish(err)
+	}()
+
+	err = ui.store.deleteAll()
 	if err != nil {
 		return err
 	}
@@ -79,6 +87,7 @@ func (ui *UTXOIndex) Reset() error {
 		if err != nil {
 			return err
 		}
+		ui.progress.Advance(uint64(len(virtualUTXOs)))
 
 		if len(virtualUTXOs) < step {
 			break
@@ -91,6 +100,11 @@ func (ui *UTXOIndex) Reset() error {
 	return ui.store.updateAndCommitVirtualParentsWithoutTransaction(virtualInfo.ParentHashes)
 }
 
+// Progress returns the progress of the rebuilds of the UTXO index
+func (ui *UTXOIndex) Progress() *indexes.Progress {
+	return ui.progress
+}
+
 func (ui *UTXOIndex) isSynced() (bool, error) {
 	utxoIndexVirtualParents, err := ui.store.getVirtualParents()
 	if err != nil {
@@ -185,10 +199,17 @@ func (ui *UTXOIndex) UTXOs(scriptPublicKey *externalapi.ScriptPublicKey) (UTXOOu
 	onEnd := logger.LogAndMeasureExecutionTime(log, "UTXOIndex.UTXOs")
 	defer onEnd()
 
+	// The mutex is only held while the snapshot is taken, so that a
+	// long scan doesn't block updates to the index
 	ui.mutex.Lock()
-	defer ui.mutex.Unlock()
+	snapshot, err := ui.store.snapshot()
+	ui.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	defer snapshot.Release()
 
-	return ui.store.getUTXOOutpointEntryPairs(scriptPublicKey)
+	return ui.store.getUTXOOutpointEntryPairs(snapshot, scriptPublicKey)
 }
 
 // GetCirculatingSompiSupply returns the current circulating supply of sompis in the network
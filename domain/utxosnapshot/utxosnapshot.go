@@ -0,0 +1,320 @@
+package utxosnapshot
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"os"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/multiset"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/utxo"
+	"github.com/pkg/errors"
+)
+
+// fileMagic identifies UTXO set snapshot files
+var fileMagic = [4]byte{'K', 'U', 'T', 'X'}
+
+const fileVersion uint16 = 1
+
+// chunkSize is the amount of UTXOs that are read from or written to
+// consensus at a time
+const chunkSize = 1000
+
+// maxSerializedUTXOSize protects against allocating huge buffers when
+// reading a corrupt snapshot file
+const maxSerializedUTXOSize = 1 << 20
+
+// ErrCommitmentMismatch indicates that the UTXOs of a snapshot don't hash to
+// its UTXO commitment
+var ErrCommitmentMismatch = errors.New("the UTXO set doesn't match its commitment")
+
+// ProgressFunc is called after every chunk of UTXOs with the amount of UTXOs
+// that were processed so far. If it returns an error, the operation stops
+// and returns that error.
+type ProgressFunc func(processedUTXOs uint64) error
+
+// Snapshot describes a file that holds the UTXO set of a pruning point
+type Snapshot struct {
+	FilePath         string
+	PruningPointHash *externalapi.DomainHash
+	UTXOCommitment   *externalapi.DomainHash
+	UTXOCount        uint64
+}
+
+// Dump writes the UTXO set of the current pruning point of the given
+// consensus to a new file at filePath. The UTXOs are read and written in
+// chunks, so the set is never held in memory as a whole. The written UTXOs
+// are checked against the pruning point's UTXO commitment. onProgress may
+// be nil.
+func Dump(consensus externalapi.Consensus, filePath string, onProgress ProgressFunc) (*Snapshot, error) {
+	pruningPointHash, err := consensus.PruningPoint()
+	if err != nil {
+		return nil, err
+	}
+	pruningPointHeader, err := consensus.GetBlockHeader(pruningPointHash)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &Snapshot{
+		FilePath:         filePath,
+		PruningPointHash: pruningPointHash,
+		UTXOCommitment:   pruningPointHeader.UTXOCommitment(),
+	}
+
+	// Write to a temporary file so that a failed dump never leaves a
+	// partial snapshot behind
+	temporaryFilePath := filePath + ".tmp"
+	file, err := os.OpenFile(temporaryFilePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	isSuccessful := false
+	defer func() {
+		if !isSuccessful {
+			file.Close()
+			os.Remove(temporaryFilePath)
+		}
+	}()
+
+	writer := bufio.NewWriter(file)
+	err = writeHeader(writer, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	utxoSetMultiset := multiset.New()
+	var fromOutpoint *externalapi.DomainOutpoint
+	for {
+		pairs, err := consensus.GetPruningPointUTXOs(pruningPointHash, fromOutpoint, chunkSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, pair := range pairs {
+			serializedUTXO, err := utxo.SerializeUTXO(pair.UTXOEntry, pair.Outpoint)
+			if err != nil {
+				return nil, err
+			}
+			err = writeSerializedUTXO(writer, serializedUTXO)
+			if err != nil {
+				return nil, err
+			}
+			utxoSetMultiset.Add(serializedUTXO)
+		}
+		snapshot.UTXOCount += uint64(len(pairs))
+		if onProgress != nil {
+			err := onProgress(snapshot.UTXOCount)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if len(pairs) < chunkSize {
+			break
+		}
+		fromOutpoint = pairs[len(pairs)-1].Outpoint
+	}
+	if !utxoSetMultiset.Hash().Equal(snapshot.UTXOCommitment) {
+		return nil, errors.Wrapf(ErrCommitmentMismatch, "the UTXO set of pruning point %s hashes to %s "+
+			"instead of %s", pruningPointHash, utxoSetMultiset.Hash(), snapshot.UTXOCommitment)
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	err = file.Sync()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	err = file.Close()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	// Link rather than rename, so that an existing file at filePath is never
+	// overwritten
+	err = os.Link(temporaryFilePath, filePath)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	isSuccessful = true
+	err = os.Remove(temporaryFilePath)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return snapshot, nil
+}
+
+// Verify reads the snapshot file at filePath and checks that its UTXOs hash
+// to its UTXO commitment. It does not check that the commitment is the one
+// of the snapshot's pruning point, which is checked once the snapshot is
+// imported. onProgress may be nil.
+func Verify(filePath string, onProgress ProgressFunc) (*Snapshot, error) {
+	snapshot := &Snapshot{FilePath: filePath}
+	utxoSetMultiset := multiset.New()
+	err := snapshot.forEachChunk(func(serializedUTXOs [][]byte) error {
+		for _, serializedUTXO := range serializedUTXOs {
+			utxoSetMultiset.Add(serializedUTXO)
+		}
+		snapshot.UTXOCount += uint64(len(serializedUTXOs))
+		if onProgress != nil {
+			return onProgress(snapshot.UTXOCount)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !utxoSetMultiset.Hash().Equal(snapshot.UTXOCommitment) {
+		return nil, errors.Wrapf(ErrCommitmentMismatch, "the UTXO set in %s hashes to %s "+
+			"instead of %s", filePath, utxoSetMultiset.Hash(), snapshot.UTXOCommitment)
+	}
+	return snapshot, nil
+}
+
+// Import appends the UTXOs of the snapshot to the imported pruning point
+// UTXO set of the given consensus. The caller is expected to call
+// ValidateAndInsertImportedPruningPoint afterwards, which checks the UTXOs
+// against the pruning point's UTXO commitment.
+func (s *Snapshot) Import(consensus externalapi.Consensus) error {
+	return s.forEachChunk(func(serializedUTXOs [][]byte) error {
+		pairs := make([]*externalapi.OutpointAndUTXOEntryPair, len(serializedUTXOs))
+		for i, serializedUTXO := range serializedUTXOs {
+			entry, outpoint, err := utxo.DeserializeUTXO(serializedUTXO)
+			if err != nil {
+				return err
+			}
+			pairs[i] = &externalapi.OutpointAndUTXOEntryPair{Outpoint: outpoint, UTXOEntry: entry}
+		}
+		return consensus.AppendImportedPruningPointUTXOs(pairs)
+	})
+}
+
+// forEachChunk reads the snapshot file, sets the snapshot's pruning point
+// hash and UTXO commitment from its header if they're not set yet, and calls
+// handleChunk with every chunk of serialized UTXOs
+func (s *Snapshot) forEachChunk(handleChunk func(serializedUTXOs [][]byte) error) error {
+	file, err := os.Open(s.FilePath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	pruningPointHash, utxoCommitment, err := readHeader(reader)
+	if err != nil {
+		return errors.Wrapf(err, "error reading the header of %s", s.FilePath)
+	}
+	if s.PruningPointHash == nil {
+		s.PruningPointHash, s.UTXOCommitment = pruningPointHash, utxoCommitment
+	} else if !s.PruningPointHash.Equal(pruningPointHash) || !s.UTXOCommitment.Equal(utxoCommitment) {
+		return errors.Errorf("%s has changed since it was verified", s.FilePath)
+	}
+
+	serializedUTXOs := make([][]byte, 0, chunkSize)
+	for {
+		serializedUTXO, err := readSerializedUTXO(reader)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return errors.Wrapf(err, "error reading %s", s.FilePath)
+		}
+		serializedUTXOs = append(serializedUTXOs, serializedUTXO)
+		if len(serializedUTXOs) == chunkSize {
+			err := handleChunk(serializedUTXOs)
+			if err != nil {
+				return err
+			}
+			serializedUTXOs = make([][]byte, 0, chunkSize)
+		}
+	}
+	if len(serializedUTXOs) > 0 {
+		return handleChunk(serializedUTXOs)
+	}
+	return nil
+}
+
+func writeHeader(writer io.Writer, snapshot *Snapshot) error {
+	_, err := writer.Write(fileMagic[:])
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	err = binary.Write(writer, binary.LittleEndian, fileVersion)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	_, err = writer.Write(snapshot.PruningPointHash.ByteSlice())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	_, err = writer.Write(snapshot.UTXOCommitment.ByteSlice())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func readHeader(reader io.Reader) (pruningPointHash *externalapi.DomainHash,
+	utxoCommitment *externalapi.DomainHash, err error) {
+
+	var magic [4]byte
+	_, err = io.ReadFull(reader, magic[:])
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	if magic != fileMagic {
+		return nil, nil, errors.New("not a UTXO set snapshot")
+	}
+	var version uint16
+	err = binary.Read(reader, binary.LittleEndian, &version)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	if version != fileVersion {
+		return nil, nil, errors.Errorf("unsupported snapshot version %d", version)
+	}
+	hashes := make([]byte, 2*externalapi.DomainHashSize)
+	_, err = io.ReadFull(reader, hashes)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	pruningPointHash, err = externalapi.NewDomainHashFromByteSlice(hashes[:externalapi.DomainHashSize])
+	if err != nil {
+		return nil, nil, err
+	}
+	utxoCommitment, err = externalapi.NewDomainHashFromByteSlice(hashes[externalapi.DomainHashSize:])
+	if err != nil {
+		return nil, nil, err
+	}
+	return pruningPointHash, utxoCommitment, nil
+}
+
+func writeSerializedUTXO(writer io.Writer, serializedUTXO []byte) error {
+	err := binary.Write(writer, binary.LittleEndian, uint32(len(serializedUTXO)))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	_, err = writer.Write(serializedUTXO)
+	return errors.WithStack(err)
+}
+
+// readSerializedUTXO returns io.EOF if there are no more UTXOs to read
+func readSerializedUTXO(reader io.Reader) ([]byte, error) {
+	var length uint32
+	err := binary.Read(reader, binary.LittleEndian, &length)
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, errors.WithStack(err)
+	}
+	if length > maxSerializedUTXOSize {
+		return nil, errors.Errorf("UTXO of %d bytes is too big", length)
+	}
+	serializedUTXO := make([]byte, length)
+	_, err = io.ReadFull(reader, serializedUTXO)
+	if err != nil {
+		// The file ends in the middle of a UTXO
+		return nil, errors.WithStack(io.ErrUnexpectedEOF)
+	}
+	return serializedUTXO, nil
+}
@@ -0,0 +1,94 @@
+package utxosnapshot
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/multiset"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/utxo"
+	"github.com/pkg/errors"
+)
+
+func TestVerify(t *testing.T) {
+	const utxoCount = chunkSize + 1
+	utxoSetMultiset := multiset.New()
+	serializedUTXOs := make([][]byte, utxoCount)
+	for i := range serializedUTXOs {
+		outpoint := &externalapi.DomainOutpoint{
+			TransactionID: *externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{byte(i), byte(i >> 8)}),
+			Index:         uint32(i),
+		}
+		entry := utxo.NewUTXOEntry(uint64(i+1), &externalapi.ScriptPublicKey{Script: []byte{byte(i)}}, i == 0, uint64(i))
+		serializedUTXO, err := utxo.SerializeUTXO(entry, outpoint)
+		if err != nil {
+			t.Fatalf("SerializeUTXO: %s", err)
+		}
+		serializedUTXOs[i] = serializedUTXO
+		utxoSetMultiset.Add(serializedUTXO)
+	}
+	snapshot := &Snapshot{
+		PruningPointHash: externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{1}),
+		UTXOCommitment:   utxoSetMultiset.Hash(),
+	}
+	buffer := &bytes.Buffer{}
+	err := writeHeader(buffer, snapshot)
+	if err != nil {
+		t.Fatalf("writeHeader: %s", err)
+	}
+	for _, serializedUTXO := range serializedUTXOs {
+		err := writeSerializedUTXO(buffer, serializedUTXO)
+		if err != nil {
+			t.Fatalf("writeSerializedUTXO: %s", err)
+		}
+	}
+	snapshotBytes := buffer.Bytes()
+
+	directory := t.TempDir()
+	writeSnapshot := func(name string, snapshotBytes []byte) string {
+		filePath := filepath.Join(directory, name)
+		err := ioutil.WriteFile(filePath, snapshotBytes, 0600)
+		if err != nil {
+			t.Fatalf("WriteFile: %s", err)
+		}
+		return filePath
+	}
+
+	verifiedSnapshot, err := Verify(writeSnapshot("valid", snapshotBytes), nil)
+	if err != nil {
+		t.Fatalf("Verify: %s", err)
+	}
+	if !verifiedSnapshot.PruningPointHash.Equal(snapshot.PruningPointHash) ||
+		!verifiedSnapshot.UTXOCommitment.Equal(snapshot.UTXOCommitment) || verifiedSnapshot.UTXOCount != utxoCount {
+
+		t.Fatalf("Unexpected verified snapshot: %+v", verifiedSnapshot)
+	}
+
+	tamperedBytes := append([]byte{}, snapshotBytes...)
+	tamperedBytes[len(tamperedBytes)-1]++
+	_, err = Verify(writeSnapshot("tampered", tamperedBytes), nil)
+	if !errors.Is(err, ErrCommitmentMismatch) {
+		t.Fatalf("Expected verifying a tampered snapshot to return ErrCommitmentMismatch, but got: %v", err)
+	}
+
+	// Dropping whole UTXOs leaves a well formed file whose UTXO set doesn't
+	// match its commitment
+	withoutLastUTXO := snapshotBytes[:len(snapshotBytes)-4-len(serializedUTXOs[utxoCount-1])]
+	_, err = Verify(writeSnapshot("without last UTXO", withoutLastUTXO), nil)
+	if !errors.Is(err, ErrCommitmentMismatch) {
+		t.Fatalf("Expected verifying a snapshot without its last UTXO to return ErrCommitmentMismatch, but got: %v", err)
+	}
+
+	for name, invalidBytes := range map[string][]byte{
+		"truncated":   snapshotBytes[:len(snapshotBytes)-1],
+		"bad magic":   append([]byte("XXXX"), snapshotBytes[4:]...),
+		"only header": snapshotBytes[:10],
+	} {
+		_, err := Verify(writeSnapshot(name, invalidBytes), nil)
+		if err == nil {
+			t.Errorf("Expected verifying a %s snapshot to fail", name)
+		}
+	}
+}
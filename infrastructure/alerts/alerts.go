@@ -0,0 +1,278 @@
+// Package alerts posts events that need the attention of the node's operator,
+// such as the node falling out of sync or running low on disk space, to the
+// webhooks it's configured with.
+//
+// Alerting is disabled until Start is called, and Send does nothing while
+// it's disabled, so that events can be sent unconditionally from anywhere in
+// kaspad. Send never blocks: the events are queued, rate limited per kind,
+// and delivered to the sinks with retries in the background.
+package alerts
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Kind identifies what an event is about
+type Kind string
+
+// The kinds of the events kaspad sends
+const (
+	// KindNodeUnsynced is sent when the node falls out of sync with the
+	// network, and resolved once it's synced again
+	KindNodeUnsynced Kind = "node_unsynced"
+
+	// KindFinalityConflict is sent when a block that violates finality
+	// is found, which means the network has split
+	KindFinalityConflict Kind = "finality_conflict"
+
+	// KindDiskSpaceLow is sent when the free disk space of the data
+	// directory falls below its minimum, and resolved once there's enough
+	KindDiskSpaceLow Kind = "disk_space_low"
+
+	// KindPeerCountCollapse is sent when the number of connected peers
+	// falls below its minimum, and resolved once there are enough
+	KindPeerCountCollapse Kind = "peer_count_collapse"
+
+	// KindInvalidBlockFromWhitelistedPeer is sent when a whitelisted peer
+	// relays an invalid block, which may mean it's misconfigured or
+	// compromised
+	KindInvalidBlockFromWhitelistedPeer Kind = "invalid_block_from_whitelisted_peer"
+
+	// KindClockDrift is sent when the local clock drifts from the peers'
+	// clocks by more than block timestamps may deviate, and resolved once
+	// it's back within the tolerance
+	KindClockDrift Kind = "clock_drift"
+)
+
+// Severity is how urgent an event is
+type Severity string
+
+// The severities of events
+const (
+	// SeverityResolved marks an event that ends the problem a previous
+	// event of the same kind reported
+	SeverityResolved Severity = "resolved"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// Event is a single alert
+type Event struct {
+	Kind     Kind              `json:"kind"`
+	Severity Severity          `json:"severity"`
+	Message  string            `json:"message"`
+	Fields   map[string]string `json:"fields,omitempty"`
+
+	// Time and Source are set by Send
+	Time   time.Time `json:"time"`
+	Source string    `json:"source"`
+
+	// SuppressedCount is the number of events of the same kind and
+	// severity that were dropped by the rate limit since the previous one
+	// was sent
+	SuppressedCount int `json:"suppressedCount,omitempty"`
+}
+
+const (
+	// queueSize is how many events may wait for delivery. Events that are
+	// sent while the queue is full are dropped.
+	queueSize = 100
+
+	// maxAttempts is how many times delivering an event to a sink is
+	// attempted before it's given up
+	maxAttempts = 4
+
+	// sendTimeout is how long a single delivery attempt may take
+	sendTimeout = 10 * time.Second
+)
+
+// retryBackoff is the delay before the first retry of a failed delivery.
+// Every retry after it doubles the delay.
+var retryBackoff = time.Second
+
+// Config configures where events are delivered and how often
+type Config struct {
+	// Sinks are the destinations every event is delivered to
+	Sinks []Sink
+
+	// Source identifies the node in the events, such as by its host name
+	// and network
+	Source string
+
+	// RateLimit is the minimum interval between two events of the same
+	// kind and severity. The events that come sooner are dropped, and
+	// counted in the next event that's sent.
+	RateLimit time.Duration
+}
+
+type dispatcher struct {
+	config *Config
+	queue  chan *Event
+	quit   chan struct{}
+	done   chan struct{}
+
+	rateLimitLock   sync.Mutex
+	lastSent        map[rateLimitKey]time.Time
+	suppressedCount map[rateLimitKey]int
+}
+
+type rateLimitKey struct {
+	kind     Kind
+	severity Severity
+}
+
+// current holds the running *dispatcher. It's nil while alerting is
+// disabled.
+var current atomic.Value
+
+// Start enables alerting with the given config. The events are delivered
+// until Stop is called.
+func Start(config *Config) error {
+	if len(config.Sinks) == 0 {
+		return errors.New("at least one alert sink is required")
+	}
+	d := &dispatcher{
+		config:          config,
+		queue:           make(chan *Event, queueSize),
+		quit:            make(chan struct{}),
+		done:            make(chan struct{}),
+		lastSent:        make(map[rateLimitKey]time.Time),
+		suppressedCount: make(map[rateLimitKey]int),
+	}
+	current.Store(d)
+	spawn("alerts.dispatcher.deliverLoop", d.deliverLoop)
+	for _, sink := range config.Sinks {
+		log.Infof("Sending alerts to %s", sink)
+	}
+	return nil
+}
+
+// Stop disables alerting, and delivers the events that were already queued,
+// waiting up to timeout for them to be delivered. Failed deliveries aren't
+// retried once Stop is called.
+func Stop(timeout time.Duration) error {
+	d := currentDispatcher()
+	if d == nil {
+		return nil
+	}
+	current.Store((*dispatcher)(nil))
+	close(d.quit)
+	select {
+	case <-d.done:
+		return nil
+	case <-time.After(timeout):
+		return errors.Errorf("delivering the remaining alerts took longer than %s", timeout)
+	}
+}
+
+// IsEnabled returns whether alerting is enabled
+func IsEnabled() bool {
+	return currentDispatcher() != nil
+}
+
+// Send queues event for delivery to all the sinks, unless alerting is
+// disabled, or an event of the same kind and severity was sent within the
+// rate limit
+func Send(event *Event) {
+	d := currentDispatcher()
+	if d == nil {
+		return
+	}
+	event.Time = time.Now()
+	event.Source = d.config.Source
+
+	suppressedCount, isAllowed := d.allow(event)
+	if !isAllowed {
+		log.Debugf("Suppressed a %s %s alert: %s", event.Severity, event.Kind, event.Message)
+		return
+	}
+	event.SuppressedCount = suppressedCount
+
+	select {
+	case d.queue <- event:
+	default:
+		log.Warnf("Dropped a %s %s alert, since too many alerts are waiting for delivery: %s",
+			event.Severity, event.Kind, event.Message)
+	}
+}
+
+func currentDispatcher() *dispatcher {
+	d, _ := current.Load().(*dispatcher)
+	return d
+}
+
+// allow returns whether event is allowed by the rate limit, and if it is,
+// how many events of its kind and severity were suppressed before it
+func (d *dispatcher) allow(event *Event) (suppressedCount int, isAllowed bool) {
+	d.rateLimitLock.Lock()
+	defer d.rateLimitLock.Unlock()
+
+	key := rateLimitKey{kind: event.Kind, severity: event.Severity}
+	lastSent, ok := d.lastSent[key]
+	if ok && event.Time.Sub(lastSent) < d.config.RateLimit {
+		d.suppressedCount[key]++
+		return 0, false
+	}
+	d.lastSent[key] = event.Time
+	suppressedCount = d.suppressedCount[key]
+	delete(d.suppressedCount, key)
+	return suppressedCount, true
+}
+
+func (d *dispatcher) deliverLoop() {
+	defer close(d.done)
+
+	for {
+		select {
+		case event := <-d.queue:
+			d.deliver(event)
+		case <-d.quit:
+			// The events that are still queued are delivered before
+			// stopping
+			for len(d.queue) > 0 {
+				d.deliver(<-d.queue)
+			}
+			return
+		}
+	}
+}
+
+func (d *dispatcher) deliver(event *Event) {
+	log.Infof("Alert (%s %s): %s", event.Severity, event.Kind, event.Message)
+	for _, sink := range d.config.Sinks {
+		err := d.deliverToSink(sink, event)
+		if err != nil {
+			log.Warnf("Couldn't deliver the %s alert to %s: %s", event.Kind, sink, err)
+		}
+	}
+}
+
+// deliverToSink sends event to sink, and retries with an exponential
+// backoff if sending fails with an error that isn't permanent
+func (d *dispatcher) deliverToSink(sink Sink, event *Event) error {
+	backoff := retryBackoff
+	for attempt := 1; ; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+		err := sink.Send(ctx, event)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		if attempt == maxAttempts || errors.As(err, &permanentError{}) {
+			return err
+		}
+		log.Debugf("Retrying the delivery of the %s alert to %s in %s: %s", event.Kind, sink, backoff, err)
+
+		select {
+		case <-time.After(backoff):
+		case <-d.quit:
+			return errors.Wrapf(err, "gave up retrying since kaspad is shutting down")
+		}
+		backoff *= 2
+	}
+}
@@ -0,0 +1,196 @@
+package alerts
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type webhook struct {
+	server   *httptest.Server
+	lock     sync.Mutex
+	bodies   [][]byte
+	statuses []int
+}
+
+// newWebhook returns a webhook server that responds with the given statuses
+// in order, and with 200 once they run out
+func newWebhook(t *testing.T, statuses ...int) *webhook {
+	w := &webhook{statuses: statuses}
+	w.server = httptest.NewServer(http.HandlerFunc(func(responseWriter http.ResponseWriter, request *http.Request) {
+		var body json.RawMessage
+		err := json.NewDecoder(request.Body).Decode(&body)
+		if err != nil {
+			t.Errorf("Decode: %s", err)
+		}
+
+		w.lock.Lock()
+		defer w.lock.Unlock()
+		w.bodies = append(w.bodies, body)
+		if len(w.statuses) > 0 {
+			responseWriter.WriteHeader(w.statuses[0])
+			w.statuses = w.statuses[1:]
+		}
+	}))
+	t.Cleanup(w.server.Close)
+	return w
+}
+
+func (w *webhook) received() [][]byte {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return append([][]byte(nil), w.bodies...)
+}
+
+func startAlerts(t *testing.T, rateLimit time.Duration, sinks ...Sink) {
+	err := Start(&Config{Sinks: sinks, Source: "test-node", RateLimit: rateLimit})
+	if err != nil {
+		t.Fatalf("Start: %+v", err)
+	}
+	t.Cleanup(func() { _ = Stop(time.Second) })
+}
+
+func stopAlerts(t *testing.T) {
+	err := Stop(5 * time.Second)
+	if err != nil {
+		t.Fatalf("Stop: %+v", err)
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	webhook := newWebhook(t)
+	sink, err := NewWebhookSink(webhook.server.URL + "/secret-token")
+	if err != nil {
+		t.Fatalf("NewWebhookSink: %+v", err)
+	}
+	if strings.Contains(sink.String(), "secret-token") {
+		t.Fatalf("Expected the sink's description not to include the URL's path, but got %s", sink)
+	}
+	startAlerts(t, time.Hour, sink)
+
+	Send(&Event{Kind: KindNodeUnsynced, Severity: SeverityWarning, Message: "unsynced",
+		Fields: map[string]string{"peers": "3"}})
+	stopAlerts(t)
+
+	received := webhook.received()
+	if len(received) != 1 {
+		t.Fatalf("Expected 1 alert to be delivered, but got %d", len(received))
+	}
+	var event Event
+	err = json.Unmarshal(received[0], &event)
+	if err != nil {
+		t.Fatalf("Unmarshal: %s", err)
+	}
+	if event.Kind != KindNodeUnsynced || event.Severity != SeverityWarning || event.Message != "unsynced" ||
+		event.Source != "test-node" || event.Fields["peers"] != "3" || event.Time.IsZero() {
+		t.Fatalf("Unexpected event %+v", event)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	webhook := newWebhook(t)
+	sink, err := NewWebhookSink(webhook.server.URL)
+	if err != nil {
+		t.Fatalf("NewWebhookSink: %+v", err)
+	}
+	startAlerts(t, time.Hour, sink)
+
+	for i := 0; i < 3; i++ {
+		Send(&Event{Kind: KindDiskSpaceLow, Severity: SeverityCritical, Message: "low"})
+	}
+	// Events of other kinds or severities have their own limit
+	Send(&Event{Kind: KindDiskSpaceLow, Severity: SeverityResolved, Message: "resolved"})
+	Send(&Event{Kind: KindPeerCountCollapse, Severity: SeverityWarning, Message: "collapse"})
+
+	d := currentDispatcher()
+	d.rateLimitLock.Lock()
+	suppressedCount := d.suppressedCount[rateLimitKey{kind: KindDiskSpaceLow, severity: SeverityCritical}]
+	d.rateLimitLock.Unlock()
+	if suppressedCount != 2 {
+		t.Fatalf("Expected 2 events to be suppressed, but got %d", suppressedCount)
+	}
+	stopAlerts(t)
+
+	if received := webhook.received(); len(received) != 3 {
+		t.Fatalf("Expected 3 alerts to be delivered, but got %d", len(received))
+	}
+}
+
+func TestRetries(t *testing.T) {
+	originalRetryBackoff := retryBackoff
+	retryBackoff = time.Millisecond
+	defer func() { retryBackoff = originalRetryBackoff }()
+
+	// A server error is retried until it succeeds
+	retriedWebhook := newWebhook(t, http.StatusInternalServerError, http.StatusTooManyRequests)
+	retriedSink, err := NewWebhookSink(retriedWebhook.server.URL)
+	if err != nil {
+		t.Fatalf("NewWebhookSink: %+v", err)
+	}
+	// A client error isn't retried
+	rejectingWebhook := newWebhook(t, http.StatusBadRequest)
+	rejectingSink, err := NewWebhookSink(rejectingWebhook.server.URL)
+	if err != nil {
+		t.Fatalf("NewWebhookSink: %+v", err)
+	}
+	startAlerts(t, time.Hour, retriedSink, rejectingSink)
+
+	Send(&Event{Kind: KindFinalityConflict, Severity: SeverityCritical, Message: "conflict"})
+	// Stop doesn't retry, so the retries have to happen before it's called
+	deadline := time.Now().Add(5 * time.Second)
+	for len(retriedWebhook.received()) < 3 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	stopAlerts(t)
+
+	if received := retriedWebhook.received(); len(received) != 3 {
+		t.Fatalf("Expected the alert to be delivered on the third attempt, but got %d attempts", len(received))
+	}
+	if received := rejectingWebhook.received(); len(received) != 1 {
+		t.Fatalf("Expected the rejected alert not to be retried, but got %d attempts", len(received))
+	}
+}
+
+func TestSlackMessage(t *testing.T) {
+	event := &Event{
+		Kind:            KindPeerCountCollapse,
+		Severity:        SeverityWarning,
+		Message:         "Only 0 peers are connected",
+		Fields:          map[string]string{"peers": "0", "minPeers": "1"},
+		Source:          "node-1",
+		SuppressedCount: 2,
+	}
+	expected := "*[WARNING] peer_count_collapse* on node-1: Only 0 peers are connected\n" +
+		"• minPeers: 1\n" +
+		"• peers: 0\n" +
+		"_2 similar alerts were suppressed since the previous one_"
+	if message := slackMessage(event); message != expected {
+		t.Fatalf("Expected the message:\n%s\nbut got:\n%s", expected, message)
+	}
+}
+
+func TestSendWhileDisabled(t *testing.T) {
+	if IsEnabled() {
+		t.Fatalf("Expected alerting to be disabled")
+	}
+	// Send does nothing while alerting is disabled
+	Send(&Event{Kind: KindClockDrift, Severity: SeverityWarning, Message: "drift"})
+}
+
+func TestInvalidURLs(t *testing.T) {
+	for _, rawURL := range []string{"", "ftp://example.com/hook", "http://", "://"} {
+		_, err := NewWebhookSink(rawURL)
+		if err == nil {
+			t.Errorf("Expected an error for the URL %q", rawURL)
+		}
+		_, err = NewSlackSink(rawURL)
+		if err == nil {
+			t.Errorf("Expected an error for the Slack URL %q", rawURL)
+		}
+	}
+}
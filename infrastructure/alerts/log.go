@@ -0,0 +1,9 @@
+package alerts
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/util/panics"
+)
+
+var log = logger.RegisterSubSystem("ALRT")
+var spawn = panics.GoroutineWrapperFunc(log)
@@ -0,0 +1,148 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Sink is a destination alerts are delivered to
+type Sink interface {
+	// Send delivers event. It returns an error wrapping a permanentError
+	// if retrying it wouldn't help.
+	Send(ctx context.Context, event *Event) error
+
+	// String describes the sink in the log, without its credentials
+	String() string
+}
+
+// permanentError is a delivery error that retrying wouldn't fix, such as
+// the sink rejecting the request as malformed
+type permanentError struct {
+	error
+}
+
+func (e permanentError) Unwrap() error {
+	return e.error
+}
+
+// webhookSink posts every event as a JSON object to a URL
+type webhookSink struct {
+	url         string
+	description string
+}
+
+// NewWebhookSink returns a Sink that posts every event, as the JSON encoding
+// of Event, to rawURL
+func NewWebhookSink(rawURL string) (Sink, error) {
+	description, err := describeURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return &webhookSink{url: rawURL, description: "webhook " + description}, nil
+}
+
+func (s *webhookSink) Send(ctx context.Context, event *Event) error {
+	return postJSON(ctx, s.url, event)
+}
+
+func (s *webhookSink) String() string {
+	return s.description
+}
+
+// slackSink posts every event as a message to a Slack incoming webhook
+type slackSink struct {
+	url         string
+	description string
+}
+
+// NewSlackSink returns a Sink that posts every event as a message to the
+// Slack incoming webhook at rawURL
+func NewSlackSink(rawURL string) (Sink, error) {
+	description, err := describeURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return &slackSink{url: rawURL, description: "Slack webhook " + description}, nil
+}
+
+func (s *slackSink) Send(ctx context.Context, event *Event) error {
+	return postJSON(ctx, s.url, map[string]string{"text": slackMessage(event)})
+}
+
+func (s *slackSink) String() string {
+	return s.description
+}
+
+// slackMessage formats event as a Slack message, with its fields on
+// separate lines in alphabetical order
+func slackMessage(event *Event) string {
+	var message strings.Builder
+	fmt.Fprintf(&message, "*[%s] %s* on %s: %s", strings.ToUpper(string(event.Severity)), event.Kind,
+		event.Source, event.Message)
+
+	keys := make([]string, 0, len(event.Fields))
+	for key := range event.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(&message, "\n• %s: %s", key, event.Fields[key])
+	}
+	if event.SuppressedCount > 0 {
+		fmt.Fprintf(&message, "\n_%d similar alerts were suppressed since the previous one_", event.SuppressedCount)
+	}
+	return message.String()
+}
+
+// describeURL validates rawURL, and returns its scheme and host for the
+// log. The path of webhook URLs often holds their secret, so it's left out.
+func describeURL(rawURL string) (string, error) {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid alert URL")
+	}
+	if (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return "", errors.Errorf("the alert URL must be an http or https URL with a host")
+	}
+	return parsedURL.Scheme + "://" + parsedURL.Host, nil
+}
+
+func postJSON(ctx context.Context, url string, body interface{}) error {
+	serializedBody, err := json.Marshal(body)
+	if err != nil {
+		return permanentError{err}
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(serializedBody))
+	if err != nil {
+		return permanentError{err}
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	// The body is drained so that the connection can be reused
+	_, _ = io.Copy(ioutil.Discard, response.Body)
+
+	if response.StatusCode/100 == 2 {
+		return nil
+	}
+	err = errors.Errorf("the webhook responded with %s", response.Status)
+	// Client errors other than rate limiting won't go away by retrying
+	if response.StatusCode/100 == 4 && response.StatusCode != http.StatusTooManyRequests {
+		return permanentError{err}
+	}
+	return err
+}
@@ -7,8 +7,10 @@ package config
 import (
 	// _ "embed" is necessary for the go:embed feature.
 	_ "embed"
+	"encoding/hex"
 	"fmt"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -18,9 +20,15 @@ import (
 
 	"github.com/btcsuite/go-socks/socks"
 	"github.com/jessevdk/go-flags"
+	"github.com/kaspanet/go-secp256k1"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionid"
 	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
 	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcauth"
 	"github.com/kaspanet/kaspad/util"
 	"github.com/kaspanet/kaspad/util/network"
 	"github.com/kaspanet/kaspad/version"
@@ -30,6 +38,7 @@ import (
 const (
 	defaultConfigFilename      = "kaspad.conf"
 	defaultLogLevel            = "info"
+	defaultLogFormat           = logger.LogFormatText
 	defaultLogDirname          = "logs"
 	defaultLogFilename         = "kaspad.log"
 	defaultErrLogFilename      = "kaspad_err.log"
@@ -53,7 +62,38 @@ const (
 	defaultSigCacheMaxSize  = 100_000
 	sampleConfigFilename    = "sample-kaspad.conf"
 	defaultMaxUTXOCacheSize = 5_000_000_000
+	defaultBlockCacheSize   = 200
+	defaultHeaderCacheSize  = 10_000
+	defaultDBSyncInterval   = time.Second
 	defaultProtocolVersion  = 5
+	// defaultStratumPort is the port stratum listeners use when none is specified
+	defaultStratumPort            = "5555"
+	defaultStratumShareDifficulty = 4
+	defaultStratumMaxClients      = 128
+	// defaultAPIPort is the port gRPC API listeners use when none is specified
+	defaultAPIPort = "16120"
+	// defaultRESTPort is the port REST listeners use when none is specified
+	defaultRESTPort         = "16130"
+	defaultHealthMinPeers   = 1
+	defaultHealthMaxSyncAge = 10 * time.Minute
+	// defaultTracingSampleRate exports every trace
+	defaultTracingSampleRate = 1
+	defaultAlertRateLimit    = 10 * time.Minute
+	defaultAlertMinDiskSpace = 1024
+	// defaultRPCUnixSocketMode only lets the user running kaspad connect to
+	// the RPC Unix domain socket
+	defaultRPCUnixSocketMode os.FileMode = 0600
+)
+
+const (
+	// DBTypeLevelDB is the --dbtype of the leveldb database backend
+	DBTypeLevelDB = "leveldb"
+	// DBTypePebble is the --dbtype of the pebble database backend
+	DBTypePebble = "pebble"
+
+	// DBTypeFilename is the name of the file in the database directory
+	// that holds the --dbtype the database was created with
+	DBTypeFilename = "backend"
 )
 
 var (
@@ -79,6 +119,8 @@ var RunServiceCommand func(string) error
 type Flags struct {
 	ShowVersion                     bool          `short:"V" long:"version" description:"Display version information and exit"`
 	ConfigFile                      string        `short:"C" long:"configfile" description:"Path to configuration file"`
+	ConfigDropInDir                 string        `long:"configdropindir" description:"Directory of configuration files that override the configuration file, which are read in lexical order of their names. Only the files whose names end with .conf are read (default: conf.d next to the configuration file)"`
+	PrintConfig                     bool          `long:"printconfig" description:"Print the effective configuration, merged from the configuration file, the drop-in directory, the KASPAD_* environment variables and the command line, and exit"`
 	AppDir                          string        `short:"b" long:"appdir" description:"Directory to store data"`
 	LogDir                          string        `long:"logdir" description:"Directory to log output."`
 	AddPeers                        []string      `short:"a" long:"addpeer" description:"Add a peer to connect with at startup"`
@@ -92,26 +134,60 @@ type Flags struct {
 	BanThreshold                    uint32        `long:"banthreshold" description:"Maximum allowed ban score before disconnecting and banning misbehaving peers."`
 	Whitelists                      []string      `long:"whitelist" description:"Add an IP network or IP that will not be banned. (eg. 192.168.1.0/24 or ::1)"`
 	RPCListeners                    []string      `long:"rpclisten" description:"Add an interface/port to listen for RPC connections (default port: 16110, testnet: 16210)"`
+	RPCUnixSocket                   string        `long:"rpcunixsocket" description:"Path of a Unix domain socket to additionally serve RPC on. Clients connecting through it don't authenticate, so access is controlled by the socket file's permissions alone"`
+	RPCUnixSocketMode               string        `long:"rpcunixsocketmode" description:"Permissions of the RPC Unix domain socket file, in octal"`
+	RPCAuth                         []string      `long:"rpcauth" description:"Add an RPC user of the form <user>:<salt>$<hash>[:<method>,<method>,...], as generated by kaspactl --gen-rpcauth. If any are specified, RPC and gRPC API clients must authenticate, and may only call the listed methods, if any are listed"`
+	RPCClientCA                     string        `long:"rpcclientca" description:"File containing the PEM encoded CA certificates that RPC clients must present a certificate signed by. If set, RPC is served over TLS with rpccert and rpckey, and clients must present such a certificate in addition to any rpcauth credentials"`
+	RPCCertAuth                     []string      `long:"rpccertauth" description:"Allow RPC clients whose certificate has the given common name, of the form <common name>[:<method>,<method>,...]. If any are specified, clients with other certificates are rejected, and each may only call the listed methods, if any are listed. Requires rpcclientca"`
 	RPCCert                         string        `long:"rpccert" description:"File containing the certificate file"`
 	RPCKey                          string        `long:"rpckey" description:"File containing the certificate key"`
 	RPCMaxClients                   int           `long:"rpcmaxclients" description:"Max number of RPC clients for standard connections"`
 	RPCMaxWebsockets                int           `long:"rpcmaxwebsockets" description:"Max number of RPC websocket connections"`
 	RPCMaxConcurrentReqs            int           `long:"rpcmaxconcurrentreqs" description:"Max number of concurrent RPC requests that may be processed concurrently"`
 	DisableRPC                      bool          `long:"norpc" description:"Disable built-in RPC server"`
+	APIListeners                    []string      `long:"apilisten" description:"Add an interface/port to listen for typed gRPC API connections (default port: 16120). The gRPC API server is disabled unless this option is specified"`
+	RESTListeners                   []string      `long:"restlisten" description:"Add an interface/port to listen for read-only REST requests (default port: 16130). The REST server is disabled unless this option is specified"`
 	SafeRPC                         bool          `long:"saferpc" description:"Disable RPC commands which affect the state of the node"`
 	DisableDNSSeed                  bool          `long:"nodnsseed" description:"Disable DNS seeding for peers"`
 	DNSSeed                         string        `long:"dnsseed" description:"Override DNS seeds with specified hostname (Only 1 hostname allowed)"`
 	GRPCSeed                        string        `long:"grpcseed" description:"Hostname of gRPC server for seeding peers"`
+	ConnectionRetryDelay            time.Duration `long:"connretrydelay" description:"How long to wait before retrying a failed connection to a persistent peer. The delay doubles after every consecutive failure (default: 30s)"`
+	ConnectionRetryMaxDelay         time.Duration `long:"connretrymaxdelay" description:"The maximum delay between retries of a failed connection to a persistent peer (default: 10m)"`
+	ConnectionRetryJitter           float64       `long:"connretryjitter" description:"The fraction, between 0 and 1, by which the delays between connection retries are randomly shortened or lengthened"`
+	ConnectionRetryMaxFailures      int           `long:"connretrymaxfailures" description:"Stop retrying a persistent peer after this many consecutive failed connections. 0 retries forever"`
+	IPMapFile                       string        `long:"ipmap" description:"File that maps IPs to their ASNs and countries for --maxoutboundperasn and --maxoutboundpercountry: either an asmap as used by Bitcoin Core, or a .csv file of network,asn,country lines. The file is reloaded when it changes"`
+	MaxOutboundPerASN               int           `long:"maxoutboundperasn" description:"Max number of outbound peers in the same ASN, according to --ipmap. 0 means no limit"`
+	MaxOutboundPerCountry           int           `long:"maxoutboundpercountry" description:"Max number of outbound peers in the same country, according to --ipmap. 0 means no limit"`
+	HTTPSeeds                       []string      `long:"httpseed" description:"Add the URL of an HTTPS seed list to fetch peers from when DNS seeding fails. Requires --httpseedpubkey"`
+	HTTPSeedPubKey                  string        `long:"httpseedpubkey" description:"Hex-encoded Schnorr public key that the HTTPS seed lists must be signed with"`
 	ExternalIPs                     []string      `long:"externalip" description:"Add an ip to the list of local addresses we claim to listen on to peers"`
 	Proxy                           string        `long:"proxy" description:"Connect via SOCKS5 proxy (eg. 127.0.0.1:9050)"`
 	ProxyUser                       string        `long:"proxyuser" description:"Username for proxy server"`
 	ProxyPass                       string        `long:"proxypass" default-mask:"-" description:"Password for proxy server"`
-	DbType                          string        `long:"dbtype" description:"Database backend to use for the Block DAG"`
+	DbType                          string        `long:"dbtype" description:"Database backend to use for the Block DAG {leveldb, pebble}"`
+	DbDurability                    string        `long:"dbdurability" description:"When database writes are synced to disk: never, leaving it to the operating system, periodically, or on every write {none, batch, full}"`
+	DbSyncInterval                  time.Duration `long:"dbsyncinterval" description:"How often database writes are synced to disk when --dbdurability=batch"`
+	DbSyncBatches                   uint64        `long:"dbsyncbatches" description:"When --dbdurability=batch, also sync the database to disk after every this many blocks are committed. 0 syncs by time alone"`
+	DbMetrics                       bool          `long:"dbmetrics" description:"Record the count, byte volume and latency of the database operations on every bucket, and report them in getDatabaseStats"`
+	ImportBlocks                    string        `long:"importblocks" description:"Import the blocks in a file that was written by the exportBlocks RPC before connecting to peers"`
+	ImportBlocksWorkers             int           `long:"importblocksworkers" description:"Number of goroutines in each of the decoding and prevalidation stages of --importblocks (default: the number of CPUs)"`
+	DbEncryptionKeyFile             string        `long:"dbencryptionkeyfile" description:"File containing a hex-encoded 32-byte key to encrypt the database with. Encryption can only be enabled when the database is created"`
+	DbEncryptionPassphrase          string        `long:"dbencryptionpassphrase" default-mask:"-" description:"Passphrase to derive the key to encrypt the database with from. Encryption can only be enabled when the database is created"`
 	Profile                         string        `long:"profile" description:"Enable HTTP profiling on given port -- NOTE port must be between 1024 and 65536"`
+	TracingEndpoint                 string        `long:"tracingendpoint" description:"Export OpenTelemetry traces of the processing of blocks, transactions and RPC requests to this OTLP/HTTP endpoint, such as http://localhost:4318. Tracing is disabled if this option is not specified"`
+	TracingSampleRate               float64       `long:"tracingsamplerate" description:"The fraction, between 0 and 1, of the traces to export when --tracingendpoint is set"`
+	AlertWebhooks                   []string      `long:"alertwebhook" description:"Add a URL to post alerts to, such as the node falling out of sync or running low on disk space, as JSON objects"`
+	AlertSlackWebhooks              []string      `long:"alertslackwebhook" description:"Add a Slack incoming webhook URL to post alerts to as messages"`
+	AlertRateLimit                  time.Duration `long:"alertratelimit" description:"The minimum interval between two alerts of the same kind. The alerts that come sooner are dropped and counted in the next one. Valid time units are {s, m, h}"`
+	AlertMinDiskSpace               uint64        `long:"alertmindiskspace" description:"Alert when the free disk space of the data directory falls below this many MiB. 0 disables the alert"`
 	LogLevel                        string        `short:"d" long:"loglevel" description:"Logging level for all subsystems {trace, debug, info, warn, error, critical} -- You may also specify <subsystem>=<level>,<subsystem2>=<level>,... to set the log level for individual subsystems -- Use show to list available subsystems"`
+	LogFormat                       string        `long:"logformat" description:"Format of the log lines {text, json} -- json writes every line as a JSON object with the keys time, level, subsystem and message, followed by the fields of the message, such as peer and block"`
+	LogSampling                     uint64        `long:"logsampling" description:"Log at most this many messages of the same kind per second, and then only every this many-th one. Messages are of the same kind if they share a subsystem, a level and a format. Warnings and errors are never sampled. 0 disables sampling"`
 	Upnp                            bool          `long:"upnp" description:"Use UPnP to map our listening port outside of NAT"`
 	MinRelayTxFee                   float64       `long:"minrelaytxfee" description:"The minimum transaction fee in KAS/kB to be considered a non-zero fee."`
 	MaxOrphanTxs                    uint64        `long:"maxorphantx" description:"Max number of orphan transactions to keep in memory"`
+	BlocklistTxs                    []string      `long:"blocklisttx" description:"Add a transaction ID to the blocklist of the mempool. Blocklisted transactions are neither accepted to the mempool nor relayed, but blocks that contain them are still valid"`
+	BlocklistScripts                []string      `long:"blocklistscript" description:"Add a hex-encoded script public key to the blocklist of the mempool. Transactions that pay to it are neither accepted to the mempool nor relayed, but blocks that contain them are still valid"`
 	BlockMaxMass                    uint64        `long:"blockmaxmass" description:"Maximum transaction mass to be used when creating a block"`
 	UserAgentComments               []string      `long:"uacomment" description:"Comment to add to the user agent -- See BIP 14 for more information."`
 	NoPeerBloomFilters              bool          `long:"nopeerbloomfilters" description:"Disable bloom filtering support"`
@@ -120,12 +196,27 @@ type Flags struct {
 	RelayNonStd                     bool          `long:"relaynonstd" description:"Relay non-standard transactions regardless of the default settings for the active network."`
 	RejectNonStd                    bool          `long:"rejectnonstd" description:"Reject non-standard transactions regardless of the default settings for the active network."`
 	ResetDatabase                   bool          `long:"reset-db" description:"Reset database before starting node. It's needed when switching between subnetworks."`
+	MigrateDatabase                 bool          `long:"migrate-db" description:"Migrate the existing database to the backend set by --dbtype before starting node. The old database is kept as a backup"`
+	SchemaMigrationBackup           bool          `long:"schema-migration-backup" description:"Back the database up before applying database schema migrations to it"`
+	SchemaMigrationDryRun           bool          `long:"schema-migration-dry-run" description:"List the database schema migrations that would be applied to the database, and exit without applying them"`
 	MaxUTXOCacheSize                uint64        `long:"maxutxocachesize" description:"Max size of loaded UTXO into ram from the disk in bytes"`
+	BlockCacheSize                  uint          `long:"blockcachesize" description:"The maximum number of recently read blocks kept in memory"`
+	HeaderCacheSize                 uint          `long:"headercachesize" description:"The maximum number of recently read block headers kept in memory"`
 	UTXOIndex                       bool          `long:"utxoindex" description:"Enable the UTXO index"`
+	AddressIndex                    bool          `long:"addressindex" description:"Enable the address index, which maps addresses to the transactions that credit or debit them"`
+	ValidateSubnetworks             []string      `long:"validatesubnetwork" description:"Only validate and accept to the mempool the transactions of this subnetwork, and of the native and built-in subnetworks, which every node validates. May be specified multiple times. Transactions of all subnetworks are validated unless this option is specified"`
+	IndexSubnetworks                []string      `long:"indexsubnetwork" description:"Only add to the address index the transactions of this subnetwork, and of the native and built-in subnetworks. May be specified multiple times. Transactions of all subnetworks are indexed unless this option is specified. Changing it rebuilds the address index"`
+	RelaySubnetworks                []string      `long:"relaysubnetwork" description:"Only relay to peers the transactions of this subnetwork, and of the native and built-in subnetworks. May be specified multiple times, and only with validated subnetworks. Defaults to the validated subnetworks"`
 	IsArchivalNode                  bool          `long:"archival" description:"Run as an archival node: don't delete old block data when moving the pruning point (Warning: heavy disk usage)'"`
+	CompactAfterPruning             bool          `long:"compactafterpruning" description:"Compact the database whenever the pruning point moves, to reclaim the space of the pruned blocks right away. Has no effect with --archival"`
 	AllowSubmitBlockWhenNotSynced   bool          `long:"allow-submit-block-when-not-synced" hidden:"true" description:"Allow the node to accept blocks from RPC while not synced (this flag is mainly used for testing)"`
 	EnableSanityCheckPruningUTXOSet bool          `long:"enable-sanity-check-pruning-utxo" hidden:"true" description:"When moving the pruning point - check that the utxo set matches the utxo commitment"`
 	ProtocolVersion                 uint32        `long:"protocol-version" description:"Use non default p2p protocol version"`
+	HealthMinPeers                  int           `long:"healthminpeers" description:"The minimum number of connected peers for the node to be reported as ready by the healthCheck RPC and the REST /ready endpoint"`
+	HealthMaxSyncAge                time.Duration `long:"healthmaxsyncage" description:"The maximum age of the virtual selected parent for the node to be reported as ready by the healthCheck RPC and the REST /ready endpoint. Valid time units are {s, m, h}"`
+	StratumListeners                []string      `long:"stratumlisten" description:"Add an interface/port to listen for Stratum miner connections (default port: 5555). The Stratum server is disabled unless this option is specified"`
+	StratumShareDifficulty          float64       `long:"stratumdifficulty" description:"The share difficulty assigned to Stratum miners"`
+	StratumMaxClients               int           `long:"stratummaxclients" description:"Max number of concurrently connected Stratum miners"`
 	NetworkFlags
 	ServiceOptions *ServiceOptions
 }
@@ -141,6 +232,27 @@ type Config struct {
 	MinRelayTxFee util.Amount
 	Whitelists    []*net.IPNet
 	SubnetworkID  *externalapi.DomainSubnetworkID // nil in full nodes
+
+	// BlocklistedTransactionIDs and BlocklistedScriptPublicKeys are the
+	// parsed values of BlocklistTxs and BlocklistScripts
+	BlocklistedTransactionIDs   []*externalapi.DomainTransactionID
+	BlocklistedScriptPublicKeys []*externalapi.ScriptPublicKey
+
+	// ValidatedSubnetworks, IndexedSubnetworks and RelayedSubnetworks are the
+	// parsed values of ValidateSubnetworks, IndexSubnetworks and RelaySubnetworks
+	ValidatedSubnetworks subnetworks.Filter
+	IndexedSubnetworks   subnetworks.Filter
+	RelayedSubnetworks   subnetworks.Filter
+
+	// HTTPSeedPublicKey is the parsed value of HTTPSeedPubKey
+	HTTPSeedPublicKey *secp256k1.SchnorrPublicKey
+
+	// RPCUnixSocketFileMode is the parsed value of RPCUnixSocketMode
+	RPCUnixSocketFileMode os.FileMode
+
+	// commandLineArgs are the command line options the config was loaded
+	// with, which are parsed again when it's reloaded
+	commandLineArgs []string
 }
 
 // ServiceOptions defines the configuration options for the daemon as a service on
@@ -176,6 +288,7 @@ func defaultFlags() *Flags {
 	return &Flags{
 		ConfigFile:           defaultConfigFile,
 		LogLevel:             defaultLogLevel,
+		LogFormat:            defaultLogFormat,
 		TargetOutboundPeers:  defaultTargetOutboundPeers,
 		MaxInboundPeers:      defaultMaxInboundPeers,
 		BanDuration:          defaultBanDuration,
@@ -191,14 +304,32 @@ func defaultFlags() *Flags {
 		SigCacheMaxSize:      defaultSigCacheMaxSize,
 		MinRelayTxFee:        defaultMinRelayTxFee,
 		MaxUTXOCacheSize:     defaultMaxUTXOCacheSize,
+		BlockCacheSize:       defaultBlockCacheSize,
+		HeaderCacheSize:      defaultHeaderCacheSize,
 		ServiceOptions:       &ServiceOptions{},
 		ProtocolVersion:      defaultProtocolVersion,
+		DbType:               DBTypeLevelDB,
+		DbDurability:         string(database.DurabilityNone),
+		DbSyncInterval:       defaultDBSyncInterval,
+
+		StratumShareDifficulty: defaultStratumShareDifficulty,
+		StratumMaxClients:      defaultStratumMaxClients,
+
+		HealthMinPeers:   defaultHealthMinPeers,
+		HealthMaxSyncAge: defaultHealthMaxSyncAge,
+
+		TracingSampleRate: defaultTracingSampleRate,
+
+		AlertRateLimit:    defaultAlertRateLimit,
+		AlertMinDiskSpace: defaultAlertMinDiskSpace,
+
+		RPCUnixSocketMode: fmt.Sprintf("%04o", defaultRPCUnixSocketMode),
 	}
 }
 
 // DefaultConfig returns the default kaspad configuration
 func DefaultConfig() *Config {
-	config := &Config{Flags: defaultFlags()}
+	config := &Config{Flags: defaultFlags(), RPCUnixSocketFileMode: defaultRPCUnixSocketMode}
 	config.NetworkFlags.ActiveNetParams = &dagconfig.MainnetParams
 	return config
 }
@@ -208,22 +339,31 @@ func DefaultConfig() *Config {
 //
 // The configuration proceeds as follows:
 //  1. Start with a default config with sane settings
-//  2. Pre-parse the command line to check for an alternative config file
+//  2. Pre-parse the environment variables and the command line to check for
+//     an alternative config file and drop-in directory
 //  3. Load configuration file overwriting defaults with any specified options
-//  4. Parse CLI options and overwrite/add any specified options
+//  4. Load the files in the drop-in directory in lexical order, each
+//     overwriting the options specified before it
+//  5. Parse the KASPAD_* environment variables and overwrite/add any
+//     specified options
+//  6. Parse CLI options and overwrite/add any specified options
 //
 // The above results in kaspad functioning properly without any config settings
-// while still allowing the user to override settings with config files and
-// command line options. Command line options always take precedence.
+// while still allowing the user to override settings with config files,
+// environment variables and command line options. Command line options always
+// take precedence.
 func LoadConfig() (*Config, error) {
 	cfgFlags := defaultFlags()
 
-	// Pre-parse the command line options to see if an alternative config
-	// file or the version flag was specified. Any errors aside from the
-	// help message error can be ignored here since they will be caught by
-	// the final parse below.
-	preCfg := cfgFlags
+	// Pre-parse the environment variables and the command line options to
+	// see if an alternative config file or drop-in directory, or the
+	// version flag was specified. Any errors aside from the help message
+	// error can be ignored here since they will be caught by the final
+	// parse below. The pre-parse is into separate flags, so that the
+	// options that were set by each source can be told apart below.
+	preCfg := defaultFlags()
 	preParser := newConfigParser(preCfg, flags.HelpFlag)
+	_, _ = parseEnvironment(preParser, os.Environ(), newOptionSources(preParser))
 	_, err := preParser.Parse()
 	if err != nil {
 		var flagsErr *flags.Error
@@ -245,9 +385,12 @@ func LoadConfig() (*Config, error) {
 	// Load additional config from file.
 	var configFileError error
 	parser := newConfigParser(cfgFlags, flags.Default)
+	sources := newOptionSources(parser)
 	cfg := &Config{
-		Flags: cfgFlags,
+		Flags:           cfgFlags,
+		commandLineArgs: os.Args[1:],
 	}
+	configDropInDir := configDropInDir(preCfg.ConfigDropInDir, preCfg.ConfigFile)
 	if !preCfg.Simnet || preCfg.ConfigFile != defaultConfigFile {
 		if _, err := os.Stat(preCfg.ConfigFile); os.IsNotExist(err) {
 			err := createDefaultConfigFile(preCfg.ConfigFile)
@@ -263,6 +406,18 @@ func LoadConfig() (*Config, error) {
 			}
 			configFileError = err
 		}
+		sources.record("the configuration file " + preCfg.ConfigFile)
+	}
+	if !preCfg.Simnet || preCfg.ConfigFile != defaultConfigFile || preCfg.ConfigDropInDir != "" {
+		err := parseConfigDropInDir(parser, configDropInDir, sources)
+		if err != nil {
+			return nil, errors.Errorf("%s\n\n%s", err, usageMessage)
+		}
+	}
+
+	unknownEnvironmentVariables, err := parseEnvironment(parser, os.Environ(), sources)
+	if err != nil {
+		return nil, errors.Errorf("%s\n\n%s", err, usageMessage)
 	}
 
 	// Parse command line options again to ensure they take precedence.
@@ -274,6 +429,15 @@ func LoadConfig() (*Config, error) {
 		}
 		return nil, err
 	}
+	sources.record("the command line")
+	cfg.ConfigDropInDir = configDropInDir
+
+	// Print the effective configuration and exit if the printconfig flag
+	// was specified.
+	if cfg.PrintConfig {
+		writeEffectiveConfig(os.Stdout, parser, sources)
+		os.Exit(0)
+	}
 
 	// Create the home directory if it doesn't already exist.
 	funcName := "loadConfig"
@@ -340,6 +504,18 @@ func LoadConfig() (*Config, error) {
 		os.Exit(0)
 	}
 
+	// Set the format of the log lines, which has to be done before the
+	// logger starts running
+	err = logger.BackendLog.SetFormat(cfg.LogFormat)
+	if err != nil {
+		str := "%s: The logformat option must be one of {%s, %s} -- parsed [%s]"
+		err := errors.Errorf(str, funcName, logger.LogFormatText, logger.LogFormatJSON, cfg.LogFormat)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	logger.BackendLog.SetSamplingRate(cfg.LogSampling)
+
 	// Initialize log rotation. After log rotation has been initialized, the
 	// logger variables may be used.
 	logger.InitLog(filepath.Join(cfg.LogDir, defaultLogFilename), filepath.Join(cfg.LogDir, defaultErrLogFilename))
@@ -352,6 +528,45 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	// Validate the database backend
+	if cfg.DbType != DBTypeLevelDB && cfg.DbType != DBTypePebble {
+		str := "%s: The dbtype option must be one of {%s, %s} -- parsed [%s]"
+		err := errors.Errorf(str, funcName, DBTypeLevelDB, DBTypePebble, cfg.DbType)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	// Validate the database durability
+	switch database.Durability(cfg.DbDurability) {
+	case database.DurabilityNone, database.DurabilityBatch, database.DurabilityFull:
+	default:
+		str := "%s: The dbdurability option must be one of {%s, %s, %s} -- parsed [%s]"
+		err := errors.Errorf(str, funcName, database.DurabilityNone, database.DurabilityBatch,
+			database.DurabilityFull, cfg.DbDurability)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if cfg.DbSyncInterval <= 0 {
+		str := "%s: The dbsyncinterval option must be positive -- parsed [%s]"
+		err := errors.Errorf(str, funcName, cfg.DbSyncInterval)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	if cfg.DbEncryptionKeyFile != "" && cfg.DbEncryptionPassphrase != "" {
+		str := "%s: The dbencryptionkeyfile and dbencryptionpassphrase options can't be used together"
+		err := errors.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if cfg.DbEncryptionKeyFile != "" {
+		cfg.DbEncryptionKeyFile = cleanAndExpandPath(cfg.DbEncryptionKeyFile)
+	}
+
 	// Validate profile port number
 	if cfg.Profile != "" {
 		profilePort, err := strconv.Atoi(cfg.Profile)
@@ -364,6 +579,22 @@ func LoadConfig() (*Config, error) {
 		}
 	}
 
+	if cfg.TracingSampleRate < 0 || cfg.TracingSampleRate > 1 {
+		str := "%s: The tracingsamplerate option must be between 0 and 1 -- parsed [%g]"
+		err := errors.Errorf(str, funcName, cfg.TracingSampleRate)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	if cfg.AlertRateLimit < 0 {
+		str := "%s: The alertratelimit option may not be negative -- parsed [%s]"
+		err := errors.Errorf(str, funcName, cfg.AlertRateLimit)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
 	// Don't allow ban durations that are too short.
 	if cfg.BanDuration < time.Second {
 		str := "%s: The banduration option may not be less than 1s -- parsed [%s]"
@@ -405,6 +636,64 @@ func LoadConfig() (*Config, error) {
 		}
 	}
 
+	// The outbound diversity limits need a map of the IPs' ASNs and countries
+	if cfg.ImportBlocksWorkers < 0 {
+		str := "%s: the --importblocksworkers option can't be negative"
+		err := errors.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if cfg.MaxOutboundPerASN < 0 || cfg.MaxOutboundPerCountry < 0 {
+		str := "%s: the --maxoutboundperasn and --maxoutboundpercountry options can't be negative"
+		err := errors.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if (cfg.MaxOutboundPerASN > 0 || cfg.MaxOutboundPerCountry > 0) && cfg.IPMapFile == "" {
+		str := "%s: the --maxoutboundperasn and --maxoutboundpercountry options require --ipmap"
+		err := errors.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if cfg.IPMapFile != "" {
+		cfg.IPMapFile = cleanAndExpandPath(cfg.IPMapFile)
+	}
+
+	// Validate the HTTPS seeds and the key their lists are signed with.
+	if len(cfg.HTTPSeeds) > 0 || cfg.HTTPSeedPubKey != "" {
+		if len(cfg.HTTPSeeds) == 0 || cfg.HTTPSeedPubKey == "" {
+			str := "%s: the --httpseed and --httpseedpubkey options must be used together"
+			err := errors.Errorf(str, funcName)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, err
+		}
+		for _, httpSeed := range cfg.HTTPSeeds {
+			seedURL, err := url.Parse(httpSeed)
+			if err != nil || seedURL.Scheme != "https" || seedURL.Host == "" {
+				str := "%s: the HTTPS seed '%s' is not an https URL"
+				err := errors.Errorf(str, funcName, httpSeed)
+				fmt.Fprintln(os.Stderr, err)
+				fmt.Fprintln(os.Stderr, usageMessage)
+				return nil, err
+			}
+		}
+		publicKeyBytes, err := hex.DecodeString(cfg.HTTPSeedPubKey)
+		if err == nil {
+			cfg.HTTPSeedPublicKey, err = secp256k1.DeserializeSchnorrPubKey(publicKeyBytes)
+		}
+		if err != nil {
+			str := "%s: the HTTPS seed public key '%s' is invalid: %s"
+			err := errors.Errorf(str, funcName, cfg.HTTPSeedPubKey, err)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, err
+		}
+	}
+
 	// --addPeer and --connect do not mix.
 	if len(cfg.AddPeers) > 0 && len(cfg.ConnectPeers) > 0 {
 		str := "%s: the --addpeer and --connect options can not be " +
@@ -449,6 +738,41 @@ func LoadConfig() (*Config, error) {
 		}
 	}
 
+	_, err = rpcauth.NewCredentials(cfg.RPCAuth)
+	if err != nil {
+		err := errors.Wrapf(err, "%s: invalid rpcauth", funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	if len(cfg.RPCCertAuth) > 0 && cfg.RPCClientCA == "" {
+		str := "%s: The rpccertauth option requires rpcclientca"
+		err := errors.Errorf(str, funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	_, err = rpcauth.NewCertificateCredentials(cfg.RPCCertAuth)
+	if err != nil {
+		err := errors.Wrapf(err, "%s: invalid rpccertauth", funcName)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	if cfg.RPCClientCA != "" {
+		cfg.RPCClientCA = cleanAndExpandPath(cfg.RPCClientCA)
+		cfg.RPCCert = cleanAndExpandPath(cfg.RPCCert)
+		cfg.RPCKey = cleanAndExpandPath(cfg.RPCKey)
+		_, err = rpcauth.ServerTLSConfig(cfg.RPCCert, cfg.RPCKey, cfg.RPCClientCA)
+		if err != nil {
+			err := errors.Wrapf(err, "%s: invalid RPC TLS configuration", funcName)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, err
+		}
+	}
+
 	if cfg.RPCMaxConcurrentReqs < 0 {
 		str := "%s: The rpcmaxwebsocketconcurrentrequests option may " +
 			"not be less than 0 -- parsed [%d]"
@@ -477,6 +801,66 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	// Parse the initial blocklist of the mempool
+	for _, blocklistTx := range cfg.BlocklistTxs {
+		transactionID, err := transactionid.FromString(blocklistTx)
+		if err != nil {
+			str := "%s: The blocklisttx value of '%s' is invalid: %s"
+			err := errors.Errorf(str, funcName, blocklistTx, err)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, err
+		}
+		cfg.BlocklistedTransactionIDs = append(cfg.BlocklistedTransactionIDs, transactionID)
+	}
+	for _, blocklistScript := range cfg.BlocklistScripts {
+		script, err := hex.DecodeString(blocklistScript)
+		if err != nil || len(script) == 0 {
+			str := "%s: The blocklistscript value of '%s' is not a hex-encoded script"
+			err := errors.Errorf(str, funcName, blocklistScript)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, err
+		}
+		cfg.BlocklistedScriptPublicKeys = append(cfg.BlocklistedScriptPublicKeys,
+			&externalapi.ScriptPublicKey{Script: script, Version: constants.MaxScriptPublicKeyVersion})
+	}
+
+	// Parse the subnetworks that the node serves. Relaying transactions
+	// that the node doesn't validate would let invalid ones through
+	cfg.ValidatedSubnetworks, err = parseSubnetworkFilter("validatesubnetwork", cfg.ValidateSubnetworks)
+	if err != nil {
+		err := errors.Errorf("%s: %s", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	cfg.IndexedSubnetworks, err = parseSubnetworkFilter("indexsubnetwork", cfg.IndexSubnetworks)
+	if err != nil {
+		err := errors.Errorf("%s: %s", funcName, err)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	cfg.RelayedSubnetworks = cfg.ValidatedSubnetworks
+	if len(cfg.RelaySubnetworks) > 0 {
+		cfg.RelayedSubnetworks, err = parseSubnetworkFilter("relaysubnetwork", cfg.RelaySubnetworks)
+		if err != nil {
+			err := errors.Errorf("%s: %s", funcName, err)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, err
+		}
+		if !cfg.RelayedSubnetworks.IsSubsetOf(cfg.ValidatedSubnetworks) {
+			str := "%s: The relaysubnetwork option may only specify subnetworks that are also " +
+				"specified by the validatesubnetwork option"
+			err := errors.Errorf(str, funcName)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, err
+		}
+	}
+
 	// Limit the max block mass to a sane value.
 	if cfg.BlockMaxMass < blockMaxMassMin || cfg.BlockMaxMass >
 		blockMaxMassMax {
@@ -518,6 +902,72 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	// Add default port to all gRPC API listener addresses if needed and remove
+	// duplicate addresses.
+	cfg.APIListeners, err = network.NormalizeAddresses(cfg.APIListeners, defaultAPIPort)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add default port to all REST listener addresses if needed and remove
+	// duplicate addresses.
+	cfg.RESTListeners, err = network.NormalizeAddresses(cfg.RESTListeners, defaultRESTPort)
+	if err != nil {
+		return nil, err
+	}
+
+	// Add default port to all stratum listener addresses if needed and remove
+	// duplicate addresses.
+	cfg.StratumListeners, err = network.NormalizeAddresses(cfg.StratumListeners, defaultStratumPort)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.StratumShareDifficulty <= 0 {
+		str := "%s: The stratumdifficulty option must be greater than 0 -- parsed [%f]"
+		err := errors.Errorf(str, funcName, cfg.StratumShareDifficulty)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	if cfg.HealthMinPeers < 0 {
+		str := "%s: The healthminpeers option may not be less than 0 -- parsed [%d]"
+		err := errors.Errorf(str, funcName, cfg.HealthMinPeers)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	if cfg.HealthMaxSyncAge <= 0 {
+		str := "%s: The healthmaxsyncage option must be greater than 0 -- parsed [%s]"
+		err := errors.Errorf(str, funcName, cfg.HealthMaxSyncAge)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+
+	if cfg.RPCUnixSocket != "" {
+		if runtime.GOOS == "windows" {
+			str := "%s: The rpcunixsocket option is not supported on Windows"
+			err := errors.Errorf(str, funcName)
+			fmt.Fprintln(os.Stderr, err)
+			fmt.Fprintln(os.Stderr, usageMessage)
+			return nil, err
+		}
+		cfg.RPCUnixSocket = cleanAndExpandPath(cfg.RPCUnixSocket)
+	}
+
+	rpcUnixSocketMode, err := strconv.ParseUint(cfg.RPCUnixSocketMode, 8, 32)
+	if err != nil || os.FileMode(rpcUnixSocketMode)&^os.ModePerm != 0 {
+		str := "%s: The rpcunixsocketmode option must be octal file permissions such as 0660 -- parsed [%s]"
+		err := errors.Errorf(str, funcName, cfg.RPCUnixSocketMode)
+		fmt.Fprintln(os.Stderr, err)
+		fmt.Fprintln(os.Stderr, usageMessage)
+		return nil, err
+	}
+	cfg.RPCUnixSocketFileMode = os.FileMode(rpcUnixSocketMode)
+
 	// Disallow --addpeer and --connect used together
 	if len(cfg.AddPeers) > 0 && len(cfg.ConnectPeers) > 0 {
 		str := "%s: --addpeer and --connect can not be used together"
@@ -572,9 +1022,33 @@ func LoadConfig() (*Config, error) {
 	if configFileError != nil {
 		log.Warnf("%s", configFileError)
 	}
+	if cfg.HasOverriddenDAGParams() {
+		params := cfg.NetParams()
+		log.Warnf("Using overridden DAG params, which nodes must share to stay in consensus: "+
+			"K=%d, target time per block=%s, pow max=%s, finality depth=%d",
+			params.K, params.TargetTimePerBlock, params.PowMax.Text(16), params.FinalityDepth())
+	}
+	for _, variable := range unknownEnvironmentVariables {
+		log.Warnf("Ignoring the environment variable %s, which doesn't match any option", variable)
+	}
 	return cfg, nil
 }
 
+// parseSubnetworkFilter parses the hex-encoded subnetwork IDs given by the
+// option with the given name into a subnetworks.Filter
+func parseSubnetworkFilter(optionName string, subnetworkIDStrings []string) (subnetworks.Filter, error) {
+	subnetworkIDs := make([]*externalapi.DomainSubnetworkID, len(subnetworkIDStrings))
+	for i, subnetworkIDString := range subnetworkIDStrings {
+		subnetworkID, err := subnetworks.FromString(subnetworkIDString)
+		if err != nil {
+			return nil, errors.Errorf("The %s value of '%s' is not a subnetwork ID: %s",
+				optionName, subnetworkIDString, err)
+		}
+		subnetworkIDs[i] = subnetworkID
+	}
+	return subnetworks.NewFilter(subnetworkIDs), nil
+}
+
 // createDefaultConfig copies the file sample-kaspad.conf to the given destination path,
 // and populates it with some randomly generated RPC username and password.
 func createDefaultConfigFile(destinationPath string) error {
@@ -6,6 +6,7 @@ import (
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"math/big"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/jessevdk/go-flags"
@@ -21,6 +22,13 @@ type NetworkFlags struct {
 	Devnet                bool   `long:"devnet" description:"Use the development test network"`
 	OverrideDAGParamsFile string `long:"override-dag-params-file" description:"Overrides DAG params (allowed only on devnet)"`
 
+	// These flags override the key DAG params of devnet, and take
+	// precedence over override-dag-params-file
+	DevnetK                  externalapi.KType `long:"devnet-k" description:"Overrides the GHOSTDAG K parameter (allowed only on devnet)"`
+	DevnetTargetTimePerBlock time.Duration     `long:"devnet-target-time-per-block" description:"Overrides the target time between blocks, such as 100ms (allowed only on devnet)"`
+	DevnetPowMax             string            `long:"devnet-pow-max" description:"Overrides the highest proof of work value a block may have, in hex, which is the lowest difficulty (allowed only on devnet)"`
+	DevnetFinalityDepth      uint64            `long:"devnet-finality-depth" description:"Overrides the finality depth, in blocks (allowed only on devnet)"`
+
 	ActiveNetParams *dagconfig.Params
 }
 
@@ -97,14 +105,93 @@ func (networkFlags *NetworkFlags) NetParams() *dagconfig.Params {
 
 func (networkFlags *NetworkFlags) overrideDAGParams() error {
 
-	if networkFlags.OverrideDAGParamsFile == "" {
+	if !networkFlags.HasOverriddenDAGParams() {
 		return nil
 	}
 
 	if !networkFlags.Devnet {
-		return errors.Errorf("override-dag-params-file is allowed only when using devnet")
+		if networkFlags.OverrideDAGParamsFile != "" {
+			return errors.Errorf("override-dag-params-file is allowed only when using devnet")
+		}
+		return errors.Errorf("the devnet-* options are allowed only when using devnet")
+	}
+
+	// The params are copied, so that the defaults of devnet are kept intact
+	params := *networkFlags.ActiveNetParams
+	networkFlags.ActiveNetParams = &params
+
+	if networkFlags.OverrideDAGParamsFile != "" {
+		err := networkFlags.overrideDAGParamsFromFile()
+		if err != nil {
+			return err
+		}
+	}
+
+	return networkFlags.overrideDAGParamsFromDevnetFlags()
+}
+
+// hasDevnetFlags returns whether any of the flags that override the key DAG
+// params of devnet are set
+func (networkFlags *NetworkFlags) hasDevnetFlags() bool {
+	return networkFlags.DevnetK != 0 || networkFlags.DevnetTargetTimePerBlock != 0 ||
+		networkFlags.DevnetPowMax != "" || networkFlags.DevnetFinalityDepth != 0
+}
+
+// HasOverriddenDAGParams returns whether the DAG params of the active network
+// differ from its defaults
+func (networkFlags *NetworkFlags) HasOverriddenDAGParams() bool {
+	return networkFlags.OverrideDAGParamsFile != "" || networkFlags.hasDevnetFlags()
+}
+
+func (networkFlags *NetworkFlags) overrideDAGParamsFromDevnetFlags() error {
+	if networkFlags.DevnetK != 0 {
+		networkFlags.ActiveNetParams.K = networkFlags.DevnetK
+	}
+
+	if networkFlags.DevnetTargetTimePerBlock < 0 {
+		return errors.Errorf("devnet-target-time-per-block must be positive, but got %s",
+			networkFlags.DevnetTargetTimePerBlock)
+	}
+	if networkFlags.DevnetTargetTimePerBlock != 0 {
+		networkFlags.ActiveNetParams.TargetTimePerBlock = networkFlags.DevnetTargetTimePerBlock
 	}
 
+	if networkFlags.DevnetPowMax != "" {
+		powMax, err := networkFlags.parsePowMax(networkFlags.DevnetPowMax)
+		if err != nil {
+			return errors.Wrapf(err, "invalid devnet-pow-max")
+		}
+		networkFlags.ActiveNetParams.PowMax = powMax
+	}
+
+	// The finality depth is set after the target time per block, since the
+	// params hold the finality window as a duration
+	if networkFlags.DevnetFinalityDepth != 0 {
+		networkFlags.ActiveNetParams.FinalityDuration =
+			time.Duration(networkFlags.DevnetFinalityDepth) * networkFlags.ActiveNetParams.TargetTimePerBlock
+	}
+
+	return nil
+}
+
+// parsePowMax parses a hex encoded proof of work limit, which may not be
+// higher than the target of the genesis block of the active network
+func (networkFlags *NetworkFlags) parsePowMax(hexPowMax string) (*big.Int, error) {
+	powMax, ok := big.NewInt(0).SetString(strings.TrimPrefix(hexPowMax, "0x"), 16)
+	if !ok || powMax.Sign() <= 0 {
+		return nil, errors.Errorf("couldn't convert %s to a positive big int", hexPowMax)
+	}
+
+	genesisTarget := difficulty.CompactToBig(networkFlags.ActiveNetParams.GenesisBlock.Header.Bits())
+	if powMax.Cmp(genesisTarget) > 0 {
+		return nil, errors.Errorf("powMax (%s) is smaller than genesis's target (%s)", powMax.Text(16),
+			genesisTarget.Text(16))
+	}
+	return powMax, nil
+}
+
+func (networkFlags *NetworkFlags) overrideDAGParamsFromFile() error {
+
 	overrideDAGParamsFile, err := os.Open(networkFlags.OverrideDAGParamsFile)
 	if err != nil {
 		return err
@@ -155,15 +242,9 @@ func (networkFlags *NetworkFlags) overrideDAGParams() error {
 	}
 
 	if config.PowMax != nil {
-		powMax, ok := big.NewInt(0).SetString(*config.PowMax, 16)
-		if !ok {
-			return errors.Errorf("couldn't convert %s to big int", *config.PowMax)
-		}
-
-		genesisTarget := difficulty.CompactToBig(networkFlags.ActiveNetParams.GenesisBlock.Header.Bits())
-		if powMax.Cmp(genesisTarget) > 0 {
-			return errors.Errorf("powMax (%s) is smaller than genesis's target (%s)", powMax.Text(16),
-				genesisTarget.Text(16))
+		powMax, err := networkFlags.parsePowMax(*config.PowMax)
+		if err != nil {
+			return err
 		}
 		networkFlags.ActiveNetParams.PowMax = powMax
 	}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+)
+
+func TestResolveNetworkDevnetOverrides(t *testing.T) {
+	networkFlags := &NetworkFlags{}
+	parser := flags.NewParser(networkFlags, flags.None)
+	_, err := parser.ParseArgs([]string{"--devnet", "--devnet-k=40", "--devnet-target-time-per-block=100ms",
+		"--devnet-pow-max=0x7fffffff", "--devnet-finality-depth=500"})
+	if err != nil {
+		t.Fatalf("ParseArgs: %s", err)
+	}
+	err = networkFlags.ResolveNetwork(parser)
+	if err != nil {
+		t.Fatalf("ResolveNetwork: %s", err)
+	}
+
+	params := networkFlags.NetParams()
+	if params.Name != dagconfig.DevnetParams.Name {
+		t.Fatalf("Expected devnet, but got %s", params.Name)
+	}
+	if params.K != 40 {
+		t.Errorf("Expected K 40, but got %d", params.K)
+	}
+	if params.TargetTimePerBlock != 100*time.Millisecond {
+		t.Errorf("Expected a target time per block of 100ms, but got %s", params.TargetTimePerBlock)
+	}
+	if params.PowMax.Text(16) != "7fffffff" {
+		t.Errorf("Expected pow max 7fffffff, but got %s", params.PowMax.Text(16))
+	}
+	if params.FinalityDepth() != 500 {
+		t.Errorf("Expected a finality depth of 500, but got %d", params.FinalityDepth())
+	}
+	if !networkFlags.HasOverriddenDAGParams() {
+		t.Errorf("Expected the DAG params to be reported as overridden")
+	}
+
+	// The defaults of devnet must stay intact
+	if dagconfig.DevnetParams.K == 40 ||
+		dagconfig.DevnetParams.TargetTimePerBlock == 100*time.Millisecond {
+		t.Errorf("Expected the default devnet params not to change")
+	}
+}
+
+func TestResolveNetworkDevnetOverridesErrors(t *testing.T) {
+	tests := []struct {
+		args          []string
+		expectedError string
+	}{
+		{
+			args:          []string{"--testnet", "--devnet-k=40"},
+			expectedError: "allowed only when using devnet",
+		},
+		{
+			args:          []string{"--devnet", "--devnet-pow-max=xyz"},
+			expectedError: "invalid devnet-pow-max",
+		},
+		{
+			args:          []string{"--devnet", "--devnet-target-time-per-block=-1s"},
+			expectedError: "must be positive",
+		},
+	}
+	for _, test := range tests {
+		networkFlags := &NetworkFlags{}
+		parser := flags.NewParser(networkFlags, flags.None)
+		_, err := parser.ParseArgs(test.args)
+		if err != nil {
+			t.Fatalf("ParseArgs: %s", err)
+		}
+		err = networkFlags.ResolveNetwork(parser)
+		if err == nil || !strings.Contains(err.Error(), test.expectedError) {
+			t.Errorf("%v: expected an error containing %q, but got %v", test.args, test.expectedError, err)
+		}
+	}
+}
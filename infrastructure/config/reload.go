@@ -0,0 +1,116 @@
+package config
+
+import (
+	"os"
+	"time"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcauth"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/pkg/errors"
+)
+
+// ReloadableOptions are the options that can be changed without restarting
+// kaspad, by sending it SIGHUP or with the reloadConfig RPC
+type ReloadableOptions struct {
+	LogLevel      string
+	BanDuration   time.Duration
+	MinRelayTxFee util.Amount
+	RPCAuth       []string
+	RPCCertAuth   []string
+
+	// RPCCredentials and RPCCertificateCredentials are the parsed values
+	// of RPCAuth and RPCCertAuth
+	RPCCredentials            *rpcauth.Credentials
+	RPCCertificateCredentials *rpcauth.CertificateCredentials
+}
+
+// ReloadableOptions returns the current values of the options that can be
+// reloaded. The parsed RPC credentials aren't set.
+func (cfg *Config) ReloadableOptions() *ReloadableOptions {
+	return &ReloadableOptions{
+		LogLevel:      cfg.LogLevel,
+		BanDuration:   cfg.BanDuration,
+		MinRelayTxFee: cfg.MinRelayTxFee,
+		RPCAuth:       cfg.RPCAuth,
+		RPCCertAuth:   cfg.RPCCertAuth,
+	}
+}
+
+// LoadReloadableOptions parses the configuration file, the drop-in
+// directory, the environment variables and the command line options that the
+// config was loaded with again, and returns the options within them that can
+// be reloaded. Command line options still take precedence. An error is returned if any of the reloadable options is
+// invalid. Changes to the other options are ignored until kaspad restarts.
+func (cfg *Config) LoadReloadableOptions() (*ReloadableOptions, error) {
+	cfgFlags := defaultFlags()
+	parser := newConfigParser(cfgFlags, flags.PassDoubleDash)
+	if !cfg.Simnet || cfg.ConfigFile != defaultConfigFile {
+		err := flags.NewIniParser(parser).ParseFile(cfg.ConfigFile)
+		if err != nil {
+			if pErr := &(os.PathError{}); !errors.As(err, &pErr) {
+				return nil, errors.Wrapf(err, "Error parsing config file")
+			}
+		}
+	}
+	sources := newOptionSources(parser)
+	dropInDir := configDropInDir(cfg.ConfigDropInDir, cfg.ConfigFile)
+	if !cfg.Simnet || cfg.ConfigFile != defaultConfigFile || dropInDir != configDropInDir("", defaultConfigFile) {
+		err := parseConfigDropInDir(parser, dropInDir, sources)
+		if err != nil {
+			return nil, err
+		}
+	}
+	_, err := parseEnvironment(parser, os.Environ(), sources)
+	if err != nil {
+		return nil, err
+	}
+	_, err = parser.ParseArgs(cfg.commandLineArgs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Error parsing command line arguments")
+	}
+
+	options := &ReloadableOptions{
+		LogLevel:    cfgFlags.LogLevel,
+		BanDuration: cfgFlags.BanDuration,
+		RPCAuth:     cfgFlags.RPCAuth,
+		RPCCertAuth: cfgFlags.RPCCertAuth,
+	}
+
+	err = logger.ValidateLogLevels(options.LogLevel)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid loglevel")
+	}
+
+	if options.BanDuration < time.Second {
+		return nil, errors.Errorf("The banduration option may not be less than 1s -- parsed [%s]",
+			options.BanDuration)
+	}
+
+	options.MinRelayTxFee, err = util.NewAmount(cfgFlags.MinRelayTxFee)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid minrelaytxfee")
+	}
+	if options.MinRelayTxFee == 0 {
+		return nil, errors.Errorf("The minrelaytxfee option must be greater than 0 -- parsed [%d]",
+			options.MinRelayTxFee)
+	}
+
+	options.RPCCredentials, err = rpcauth.NewCredentials(options.RPCAuth)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid rpcauth")
+	}
+	// The rpcclientca option itself can't be reloaded, since it's part of
+	// the RPC server's TLS configuration
+	if len(options.RPCCertAuth) > 0 && cfg.RPCClientCA == "" {
+		return nil, errors.Errorf("The rpccertauth option requires rpcclientca, which can't be " +
+			"set without restarting kaspad")
+	}
+	options.RPCCertificateCredentials, err = rpcauth.NewCertificateCredentials(options.RPCCertAuth)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid rpccertauth")
+	}
+
+	return options, nil
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadReloadableOptions(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "kaspad.conf")
+	cfg := DefaultConfig()
+	cfg.ConfigFile = configFile
+	cfg.commandLineArgs = []string{"--banduration=2h"}
+
+	err := ioutil.WriteFile(configFile, []byte("[Application Options]\n"+
+		"loglevel=debug\nbanduration=1h\nminrelaytxfee=0.0001\n"), 0600)
+	if err != nil {
+		t.Fatalf("WriteFile: %s", err)
+	}
+	options, err := cfg.LoadReloadableOptions()
+	if err != nil {
+		t.Fatalf("LoadReloadableOptions: %s", err)
+	}
+	if options.LogLevel != "debug" {
+		t.Fatalf("Unexpected log level %s", options.LogLevel)
+	}
+	// Command line options take precedence
+	if options.BanDuration != 2*time.Hour {
+		t.Fatalf("Unexpected ban duration %s", options.BanDuration)
+	}
+	if options.MinRelayTxFee != 10000 {
+		t.Fatalf("Unexpected minimum relay fee %d", options.MinRelayTxFee)
+	}
+	if options.RPCCredentials != nil {
+		t.Fatalf("Unexpected RPC credentials without rpcauth")
+	}
+
+	invalidConfigs := []string{
+		"loglevel=loud\n",
+		"minrelaytxfee=0\n",
+		"rpcauth=invalid\n",
+		// rpcclientca isn't set
+		"rpccertauth=client\n",
+	}
+	for _, invalidConfig := range invalidConfigs {
+		err := ioutil.WriteFile(configFile, []byte("[Application Options]\n"+invalidConfig), 0600)
+		if err != nil {
+			t.Fatalf("WriteFile: %s", err)
+		}
+		_, err = cfg.LoadReloadableOptions()
+		if err == nil {
+			t.Fatalf("LoadReloadableOptions unexpectedly accepted %q", invalidConfig)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/pkg/errors"
+)
+
+const (
+	// environmentVariablePrefix is the prefix of the environment variables
+	// that set options. The rest of the name of such a variable is the long
+	// name of its option in upper case, with dashes replaced by
+	// underscores, for example KASPAD_RPCLISTEN or KASPAD_MIGRATE_DB.
+	environmentVariablePrefix = "KASPAD_"
+
+	// environmentVariableListSeparator separates the values of an option
+	// that may be specified more than once, such as addpeer, when it's set
+	// by an environment variable
+	environmentVariableListSeparator = ","
+
+	// defaultConfigDropInDirname is the name of the drop-in directory
+	// next to the configuration file, which is used if the configdropindir
+	// option isn't set
+	defaultConfigDropInDirname = "conf.d"
+
+	// configDropInFileExtension is the extension of the files in the
+	// drop-in directory that are read. Other files are ignored.
+	configDropInFileExtension = ".conf"
+
+	// redactedValue replaces the values of secret options, such as
+	// passwords, in the printed configuration
+	redactedValue = "<redacted>"
+)
+
+// The configuration is merged from these sources, in order of increasing
+// precedence:
+//  1. The defaults
+//  2. The configuration file
+//  3. The files in the drop-in directory, in lexical order of their names
+//  4. The environment variables
+//  5. The command line
+//
+// Options that may be specified more than once accumulate the values from
+// the configuration file, the drop-in files and the environment variables.
+// If such an option is specified on the command line, its values there
+// replace the rest.
+
+// environmentVariableName returns the name of the environment variable that
+// sets the option with the given long name
+func environmentVariableName(longName string) string {
+	return environmentVariablePrefix + strings.ToUpper(strings.ReplaceAll(longName, "-", "_"))
+}
+
+// configDropInDir returns the drop-in directory that belongs to the given
+// configuration file, unless dropInDir is set
+func configDropInDir(dropInDir string, configFile string) string {
+	if dropInDir != "" {
+		return cleanAndExpandPath(dropInDir)
+	}
+	return filepath.Join(filepath.Dir(configFile), defaultConfigDropInDirname)
+}
+
+// eachOption calls f for every option of the parser that has a long name,
+// along with the name of the group it's in
+func eachOption(parser *flags.Parser, f func(groupName string, option *flags.Option)) {
+	var eachGroupOption func(group *flags.Group)
+	eachGroupOption = func(group *flags.Group) {
+		for _, option := range group.Options() {
+			if option.LongName != "" {
+				f(group.ShortDescription, option)
+			}
+		}
+		for _, subGroup := range group.Groups() {
+			eachGroupOption(subGroup)
+		}
+	}
+	for _, group := range parser.Groups() {
+		eachGroupOption(group)
+	}
+}
+
+// parseConfigDropInDir parses the files in the given drop-in directory whose
+// names end with configDropInFileExtension into the parser, in lexical order
+// of their names. A missing directory is treated as an empty one.
+func parseConfigDropInDir(parser *flags.Parser, directory string, sources *optionSources) error {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "Error reading config drop-in directory")
+	}
+	// os.ReadDir returns the entries sorted by name
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != configDropInFileExtension {
+			continue
+		}
+		filePath := filepath.Join(directory, entry.Name())
+		err := flags.NewIniParser(parser).ParseFile(filePath)
+		if err != nil {
+			return errors.Wrapf(err, "Error parsing config drop-in file")
+		}
+		sources.record(filePath)
+	}
+	return nil
+}
+
+// parseEnvironment parses the environment variables that set options, which
+// are given in the form of os.Environ, into the parser. It returns the names
+// of the variables that start with environmentVariablePrefix but don't match
+// any option.
+func parseEnvironment(parser *flags.Parser, environment []string, sources *optionSources) (
+	unknownVariables []string, err error) {
+
+	options := make(map[string]*flags.Option)
+	eachOption(parser, func(_ string, option *flags.Option) {
+		options[environmentVariableName(option.LongName)] = option
+	})
+
+	sortedEnvironment := append([]string{}, environment...)
+	sort.Strings(sortedEnvironment)
+	for _, variable := range sortedEnvironment {
+		name, value, _ := strings.Cut(variable, "=")
+		if !strings.HasPrefix(name, environmentVariablePrefix) {
+			continue
+		}
+		option, ok := options[name]
+		if !ok {
+			unknownVariables = append(unknownVariables, name)
+			continue
+		}
+
+		// The variable is parsed as a single line INI file, so that its
+		// value is converted exactly like it is in the configuration file
+		values := []string{value}
+		if option.Field().Type.Kind() == reflect.Slice {
+			values = strings.Split(value, environmentVariableListSeparator)
+		}
+		ini := &strings.Builder{}
+		for _, value := range values {
+			fmt.Fprintf(ini, "%s = %s\n", option.LongName, strconv.Quote(strings.TrimSpace(value)))
+		}
+		err := flags.NewIniParser(parser).Parse(strings.NewReader(ini.String()))
+		if err != nil {
+			// The location within the single line INI file is meaningless
+			var iniErr *flags.IniError
+			if errors.As(err, &iniErr) {
+				return nil, errors.Errorf("Error parsing environment variable %s: %s", name, iniErr.Message)
+			}
+			return nil, errors.Errorf("Error parsing environment variable %s: %s", name, err)
+		}
+		sources.record("environment variable " + name)
+	}
+	return unknownVariables, nil
+}
+
+// optionSources tracks which of the configuration sources set each option
+// of a parser last
+type optionSources struct {
+	parser *flags.Parser
+
+	// values are the values of the options, by their long names, as of
+	// the last recorded source
+	values  map[string]string
+	sources map[string]string
+}
+
+// newOptionSources returns an optionSources that considers the current
+// values of the options of the parser to be their defaults
+func newOptionSources(parser *flags.Parser) *optionSources {
+	sources := &optionSources{
+		parser:  parser,
+		values:  make(map[string]string),
+		sources: make(map[string]string),
+	}
+	sources.record("")
+	return sources
+}
+
+// record marks the options whose values changed since the last recorded
+// source as set by the given source
+func (sources *optionSources) record(source string) {
+	eachOption(sources.parser, func(_ string, option *flags.Option) {
+		value := fmt.Sprint(option.Value())
+		previousValue, ok := sources.values[option.LongName]
+		if ok && value != previousValue && source != "" {
+			sources.sources[option.LongName] = source
+		}
+		sources.values[option.LongName] = value
+	})
+}
+
+// writeEffectiveConfig writes the options of the parser in the format of the
+// configuration file. Options that weren't set are commented out, with their
+// defaults. Options that were set are preceded by a comment that names the
+// source that set them last. The values of secret options are redacted.
+func writeEffectiveConfig(writer io.Writer, parser *flags.Parser, sources *optionSources) {
+	fmt.Fprintln(writer, "; The effective configuration, merged from the defaults, the configuration file,")
+	fmt.Fprintln(writer, "; the drop-in directory, the environment variables and the command line.")
+	fmt.Fprintln(writer, "; Options that aren't set are commented out with their defaults.")
+
+	currentGroupName := ""
+	eachOption(parser, func(groupName string, option *flags.Option) {
+		if option.LongName == "help" {
+			return
+		}
+		if groupName != currentGroupName {
+			fmt.Fprintf(writer, "\n[%s]\n", groupName)
+			currentGroupName = groupName
+		}
+
+		var values []string
+		value := reflect.ValueOf(option.Value())
+		if value.Kind() == reflect.Slice {
+			for i := 0; i < value.Len(); i++ {
+				values = append(values, formatConfigValue(value.Index(i).Interface()))
+			}
+		} else {
+			values = append(values, formatConfigValue(value.Interface()))
+		}
+
+		source, isSet := sources.sources[option.LongName]
+		if !isSet {
+			if len(values) == 0 {
+				writeConfigLine(writer, "; ", option.LongName, "")
+			}
+			for _, value := range values {
+				writeConfigLine(writer, "; ", option.LongName, value)
+			}
+			return
+		}
+		fmt.Fprintf(writer, "; Last set by %s\n", source)
+		for _, value := range values {
+			// Options whose defaults are masked with "-", such as
+			// passwords, are secret
+			if option.DefaultMask == "-" && value != "" {
+				value = redactedValue
+			}
+			writeConfigLine(writer, "", option.LongName, value)
+		}
+	})
+}
+
+func writeConfigLine(writer io.Writer, prefix string, name string, value string) {
+	if value == "" {
+		fmt.Fprintf(writer, "%s%s =\n", prefix, name)
+		return
+	}
+	fmt.Fprintf(writer, "%s%s = %s\n", prefix, name, value)
+}
+
+// formatConfigValue formats the value of an option such that the
+// configuration file parser reads it back as is
+func formatConfigValue(value interface{}) string {
+	if boolValue, ok := value.(bool); ok {
+		if boolValue {
+			return "1"
+		}
+		return "0"
+	}
+	formattedValue := fmt.Sprint(value)
+	if formattedValue != strings.TrimSpace(formattedValue) || strings.HasPrefix(formattedValue, `"`) ||
+		!strconv.CanBackquote(formattedValue) {
+		return strconv.Quote(formattedValue)
+	}
+	return formattedValue
+}
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jessevdk/go-flags"
+)
+
+func TestConfigSources(t *testing.T) {
+	directory := t.TempDir()
+	configFile := filepath.Join(directory, "kaspad.conf")
+	dropInDir := configDropInDir("", configFile)
+	err := os.Mkdir(dropInDir, 0700)
+	if err != nil {
+		t.Fatalf("Mkdir: %s", err)
+	}
+	files := map[string]string{
+		configFile: "[Application Options]\nmaxinpeers=10\naddpeer=1.1.1.1\nproxypass=secret\n",
+		filepath.Join(dropInDir, "10-first.conf"):  "maxinpeers=20\nbanduration=1h\n",
+		filepath.Join(dropInDir, "20-second.conf"): "maxinpeers=30\naddpeer=2.2.2.2\n",
+		// Only files with the .conf extension are read
+		filepath.Join(dropInDir, "30-third.conf.bak"): "maxinpeers=40\n",
+	}
+	for filePath, content := range files {
+		err := ioutil.WriteFile(filePath, []byte(content), 0600)
+		if err != nil {
+			t.Fatalf("WriteFile: %s", err)
+		}
+	}
+
+	cfgFlags := defaultFlags()
+	parser := newConfigParser(cfgFlags, flags.None)
+	sources := newOptionSources(parser)
+	err = flags.NewIniParser(parser).ParseFile(configFile)
+	if err != nil {
+		t.Fatalf("ParseFile: %s", err)
+	}
+	sources.record("the configuration file")
+	err = parseConfigDropInDir(parser, dropInDir, sources)
+	if err != nil {
+		t.Fatalf("parseConfigDropInDir: %s", err)
+	}
+	unknownVariables, err := parseEnvironment(parser, []string{
+		"KASPAD_BANDURATION=2h",
+		"KASPAD_UACOMMENT=first, second",
+		"KASPAD_NOLISTEN=1",
+		"KASPAD_UNKNOWN=1",
+		"PATH=/bin",
+	}, sources)
+	if err != nil {
+		t.Fatalf("parseEnvironment: %s", err)
+	}
+	_, err = parser.ParseArgs([]string{"--appdir=/data"})
+	if err != nil {
+		t.Fatalf("ParseArgs: %s", err)
+	}
+	sources.record("the command line")
+
+	if cfgFlags.MaxInboundPeers != 30 {
+		t.Fatalf("Expected the last drop-in file to set maxinpeers, but it's %d", cfgFlags.MaxInboundPeers)
+	}
+	if cfgFlags.BanDuration != 2*time.Hour {
+		t.Fatalf("Expected the environment to override banduration, but it's %s", cfgFlags.BanDuration)
+	}
+	if !reflect.DeepEqual(cfgFlags.AddPeers, []string{"1.1.1.1", "2.2.2.2"}) {
+		t.Fatalf("Expected addpeer to accumulate, but it's %s", cfgFlags.AddPeers)
+	}
+	if !reflect.DeepEqual(cfgFlags.UserAgentComments, []string{"first", "second"}) {
+		t.Fatalf("Unexpected uacomment %s", cfgFlags.UserAgentComments)
+	}
+	if !cfgFlags.DisableListen || cfgFlags.AppDir != "/data" {
+		t.Fatalf("Unexpected nolisten %t and appdir %s", cfgFlags.DisableListen, cfgFlags.AppDir)
+	}
+	if !reflect.DeepEqual(unknownVariables, []string{"KASPAD_UNKNOWN"}) {
+		t.Fatalf("Unexpected unknown variables %s", unknownVariables)
+	}
+
+	expectedSources := map[string]string{
+		"maxinpeers":  filepath.Join(dropInDir, "20-second.conf"),
+		"addpeer":     filepath.Join(dropInDir, "20-second.conf"),
+		"proxypass":   "the configuration file",
+		"banduration": "environment variable KASPAD_BANDURATION",
+		"uacomment":   "environment variable KASPAD_UACOMMENT",
+		"nolisten":    "environment variable KASPAD_NOLISTEN",
+		"appdir":      "the command line",
+	}
+	if !reflect.DeepEqual(sources.sources, expectedSources) {
+		t.Fatalf("Expected the sources %v, but got %v", expectedSources, sources.sources)
+	}
+
+	output := &strings.Builder{}
+	writeEffectiveConfig(output, parser, sources)
+	for _, expectedLine := range []string{
+		"; Last set by environment variable KASPAD_BANDURATION\nbanduration = 2h0m0s\n",
+		"addpeer = 1.1.1.1\naddpeer = 2.2.2.2\n",
+		"nolisten = 1\n",
+		"proxypass = " + redactedValue + "\n",
+		"; outpeers = 8\n",
+	} {
+		if !strings.Contains(output.String(), expectedLine) {
+			t.Fatalf("Expected the effective configuration to contain %q, but got:\n%s", expectedLine, output)
+		}
+	}
+	if strings.Contains(output.String(), "secret") {
+		t.Fatalf("The effective configuration contains a secret:\n%s", output)
+	}
+
+	// The effective configuration can be read back as a configuration file
+	readBackFlags := defaultFlags()
+	err = flags.NewIniParser(newConfigParser(readBackFlags, flags.None)).Parse(strings.NewReader(output.String()))
+	if err != nil {
+		t.Fatalf("Parse: %s", err)
+	}
+	if readBackFlags.MaxInboundPeers != 30 || readBackFlags.BanDuration != 2*time.Hour ||
+		!reflect.DeepEqual(readBackFlags.AddPeers, cfgFlags.AddPeers) || !readBackFlags.DisableListen {
+		t.Fatalf("The effective configuration was read back differently")
+	}
+}
+
+func TestConfigSourcesErrors(t *testing.T) {
+	parser := newConfigParser(defaultFlags(), flags.None)
+	sources := newOptionSources(parser)
+	_, err := parseEnvironment(parser, []string{"KASPAD_MAXINPEERS=many"}, sources)
+	if err == nil || !strings.Contains(err.Error(), "KASPAD_MAXINPEERS") {
+		t.Fatalf("Expected an error that names the variable, but got %v", err)
+	}
+
+	dropInDir := t.TempDir()
+	err = ioutil.WriteFile(filepath.Join(dropInDir, "invalid.conf"), []byte("unknownoption=1\n"), 0600)
+	if err != nil {
+		t.Fatalf("WriteFile: %s", err)
+	}
+	err = parseConfigDropInDir(parser, dropInDir, sources)
+	if err == nil {
+		t.Fatalf("Expected an error for an unknown option in a drop-in file")
+	}
+
+	// A missing drop-in directory is treated as an empty one
+	err = parseConfigDropInDir(parser, filepath.Join(dropInDir, "missing"), sources)
+	if err != nil {
+		t.Fatalf("parseConfigDropInDir: %s", err)
+	}
+}
@@ -0,0 +1,89 @@
+package database
+
+import (
+	"sync"
+	"time"
+)
+
+// Batch accumulates writes to any number of buckets, and writes them
+// atomically once it's committed. Unlike a Transaction, it can't be
+// read from, and its commit chooses whether it's synced to disk.
+type Batch interface {
+	// Put sets the value for the given key once the batch is
+	// committed. It overwrites any previous value for that key.
+	Put(key *Key, value []byte) error
+
+	// Delete deletes the value for the given key once the batch
+	// is committed. Will not return an error if the key doesn't exist.
+	Delete(key *Key) error
+
+	// Len returns the amount of writes in the batch.
+	Len() int
+
+	// Commit writes the batch atomically. If sync is set, the batch,
+	// along with every write before it, is synced to disk before Commit
+	// returns. A database with DurabilityFull syncs every batch,
+	// regardless of sync.
+	Commit(sync bool) error
+
+	// Discard drops the batch without writing it. It has no
+	// effect if the batch was already committed or discarded.
+	Discard() error
+}
+
+// BatchSyncPolicy decides which of the batches a BatchCommitter
+// commits are synced to disk.
+type BatchSyncPolicy struct {
+	// EveryBatches syncs every EveryBatches batches. One syncs every
+	// batch, and zero doesn't sync by the amount of batches.
+	EveryBatches uint64
+
+	// Interval syncs the first batch that's committed once Interval
+	// passed since the last sync. Zero doesn't sync by time.
+	Interval time.Duration
+}
+
+// BatchCommitter commits batches, and syncs some of them to disk
+// according to a BatchSyncPolicy. Syncing a batch also syncs all the
+// batches before it, so the cost of a sync is shared by all of them.
+type BatchCommitter struct {
+	policy BatchSyncPolicy
+
+	lock            sync.Mutex
+	unsyncedBatches uint64
+	lastSyncTime    time.Time
+}
+
+// NewBatchCommitter returns a new BatchCommitter with the given policy.
+func NewBatchCommitter(policy BatchSyncPolicy) *BatchCommitter {
+	return &BatchCommitter{
+		policy:       policy,
+		lastSyncTime: time.Now(),
+	}
+}
+
+// Commit commits the given batch, and syncs it if the policy calls for it.
+func (bc *BatchCommitter) Commit(batch Batch) error {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+
+	sync := bc.shouldSync()
+	err := batch.Commit(sync)
+	if err != nil {
+		return err
+	}
+	if sync {
+		bc.unsyncedBatches = 0
+		bc.lastSyncTime = time.Now()
+	} else {
+		bc.unsyncedBatches++
+	}
+	return nil
+}
+
+func (bc *BatchCommitter) shouldSync() bool {
+	if bc.policy.EveryBatches > 0 && bc.unsyncedBatches+1 >= bc.policy.EveryBatches {
+		return true
+	}
+	return bc.policy.Interval > 0 && time.Since(bc.lastSyncTime) >= bc.policy.Interval
+}
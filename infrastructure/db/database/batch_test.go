@@ -0,0 +1,161 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+func TestBatchCommit(t *testing.T) {
+	testForAllDatabaseTypes(t, "TestBatchCommit", testBatchCommit)
+}
+
+func testBatchCommit(t *testing.T, db database.Database, testName string) {
+	// Write to two buckets within a single batch
+	key1 := database.MakeBucket([]byte("bucket1")).Key([]byte("key"))
+	key2 := database.MakeBucket([]byte("bucket2")).Key([]byte("key"))
+	err := db.Put(key2, []byte("old"))
+	if err != nil {
+		t.Fatalf("%s: Put unexpectedly failed: %s", testName, err)
+	}
+	batch, err := db.NewBatch()
+	if err != nil {
+		t.Fatalf("%s: NewBatch unexpectedly failed: %s", testName, err)
+	}
+	err = batch.Put(key1, []byte("value"))
+	if err != nil {
+		t.Fatalf("%s: Put unexpectedly failed: %s", testName, err)
+	}
+	err = batch.Delete(key2)
+	if err != nil {
+		t.Fatalf("%s: Delete unexpectedly failed: %s", testName, err)
+	}
+	if batch.Len() != 2 {
+		t.Fatalf("%s: Unexpected batch length. Want: %d, got: %d", testName, 2, batch.Len())
+	}
+
+	// Nothing is written before the batch is committed
+	exists, err := db.Has(key1)
+	if err != nil {
+		t.Fatalf("%s: Has unexpectedly failed: %s", testName, err)
+	}
+	if exists {
+		t.Fatalf("%s: A write of the batch was written before it was committed", testName)
+	}
+
+	err = batch.Commit(true)
+	if err != nil {
+		t.Fatalf("%s: Commit unexpectedly failed: %s", testName, err)
+	}
+	exists, err = db.Has(key1)
+	if err != nil {
+		t.Fatalf("%s: Has unexpectedly failed: %s", testName, err)
+	}
+	if !exists {
+		t.Fatalf("%s: The put of the batch wasn't written", testName)
+	}
+	exists, err = db.Has(key2)
+	if err != nil {
+		t.Fatalf("%s: Has unexpectedly failed: %s", testName, err)
+	}
+	if exists {
+		t.Fatalf("%s: The delete of the batch wasn't written", testName)
+	}
+
+	// A committed batch can't be written to or committed again
+	err = batch.Put(key1, []byte("value"))
+	if err == nil {
+		t.Fatalf("%s: Put into a committed batch unexpectedly succeeded", testName)
+	}
+	err = batch.Commit(false)
+	if err == nil {
+		t.Fatalf("%s: Committing a committed batch unexpectedly succeeded", testName)
+	}
+
+	// A discarded batch isn't written
+	batch, err = db.NewBatch()
+	if err != nil {
+		t.Fatalf("%s: NewBatch unexpectedly failed: %s", testName, err)
+	}
+	err = batch.Put(key2, []byte("value"))
+	if err != nil {
+		t.Fatalf("%s: Put unexpectedly failed: %s", testName, err)
+	}
+	err = batch.Discard()
+	if err != nil {
+		t.Fatalf("%s: Discard unexpectedly failed: %s", testName, err)
+	}
+	exists, err = db.Has(key2)
+	if err != nil {
+		t.Fatalf("%s: Has unexpectedly failed: %s", testName, err)
+	}
+	if exists {
+		t.Fatalf("%s: A discarded batch was written", testName)
+	}
+}
+
+type fakeBatch struct {
+	syncs []bool
+}
+
+func (b *fakeBatch) Put(*database.Key, []byte) error { return nil }
+func (b *fakeBatch) Delete(*database.Key) error      { return nil }
+func (b *fakeBatch) Len() int                        { return 0 }
+func (b *fakeBatch) Discard() error                  { return nil }
+
+func (b *fakeBatch) Commit(sync bool) error {
+	b.syncs = append(b.syncs, sync)
+	return nil
+}
+
+func TestBatchCommitter(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        database.BatchSyncPolicy
+		expectedSyncs []bool
+	}{
+		{
+			name:          "no syncs",
+			policy:        database.BatchSyncPolicy{},
+			expectedSyncs: []bool{false, false, false, false},
+		},
+		{
+			name:          "every batch",
+			policy:        database.BatchSyncPolicy{EveryBatches: 1},
+			expectedSyncs: []bool{true, true, true, true},
+		},
+		{
+			name:          "every 3 batches",
+			policy:        database.BatchSyncPolicy{EveryBatches: 3},
+			expectedSyncs: []bool{false, false, true, false},
+		},
+		{
+			name:          "by a passed interval",
+			policy:        database.BatchSyncPolicy{Interval: time.Nanosecond},
+			expectedSyncs: []bool{true, true, true, true},
+		},
+		{
+			name:          "by an interval that didn't pass",
+			policy:        database.BatchSyncPolicy{Interval: time.Hour},
+			expectedSyncs: []bool{false, false, false, false},
+		},
+	}
+	for _, test := range tests {
+		committer := database.NewBatchCommitter(test.policy)
+		batch := &fakeBatch{}
+		for range test.expectedSyncs {
+			time.Sleep(time.Millisecond)
+			err := committer.Commit(batch)
+			if err != nil {
+				t.Fatalf("%s: Commit unexpectedly failed: %s", test.name, err)
+			}
+		}
+		for i, expectedSync := range test.expectedSyncs {
+			if batch.syncs[i] != expectedSync {
+				t.Fatalf("%s: Unexpected sync of batch #%d. Want: %t, got: %t",
+					test.name, i, expectedSync, batch.syncs[i])
+			}
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/kaspanet/kaspad/infrastructure/db/database"
 	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/pebbledb"
 )
 
 type databasePrepareFunc func(t *testing.T, testName string) (db database.Database, name string, teardownFunc func())
@@ -16,6 +17,7 @@ type databasePrepareFunc func(t *testing.T, testName string) (db database.Databa
 // See testForAllDatabaseTypes for further details.
 var databasePrepareFuncs = []databasePrepareFunc{
 	prepareLDBForTest,
+	preparePebbleDBForTest,
 }
 
 func prepareLDBForTest(t *testing.T, testName string) (db database.Database, name string, teardownFunc func()) {
@@ -40,6 +42,28 @@ func prepareLDBForTest(t *testing.T, testName string) (db database.Database, nam
 	return db, "ldb", teardownFunc
 }
 
+func preparePebbleDBForTest(t *testing.T, testName string) (db database.Database, name string, teardownFunc func()) {
+	// Create a temp db to run tests against
+	path, err := ioutil.TempDir("", testName)
+	if err != nil {
+		t.Fatalf("%s: TempDir unexpectedly "+
+			"failed: %s", testName, err)
+	}
+	db, err = pebbledb.NewPebbleDB(path, 8)
+	if err != nil {
+		t.Fatalf("%s: Open unexpectedly "+
+			"failed: %s", testName, err)
+	}
+	teardownFunc = func() {
+		err = db.Close()
+		if err != nil {
+			t.Fatalf("%s: Close unexpectedly "+
+				"failed: %s", testName, err)
+		}
+	}
+	return db, "pebbledb", teardownFunc
+}
+
 // testForAllDatabaseTypes runs the given testFunc for every database
 // type defined in databasePrepareFuncs. This is to make sure that
 // all supported database types adhere to the assumptions defined in
@@ -0,0 +1,57 @@
+package database
+
+// Copy copies all the data of the source database into the destination
+// database, in transactions of up to batchSize entries each. It returns
+// the amount of entries that were copied.
+func Copy(destination Database, source Database, batchSize int) (int, error) {
+	cursor, err := source.Cursor(MakeBucket(nil))
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close()
+
+	dbTx, err := destination.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = dbTx.RollbackUnlessClosed()
+	}()
+
+	copiedCount := 0
+	for ok := cursor.First(); ok; ok = cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return copiedCount, err
+		}
+		value, err := cursor.Value()
+		if err != nil {
+			return copiedCount, err
+		}
+		// The cursor may change the key and value once it's moved, while
+		// transactions may keep what they're given until they're committed
+		err = dbTx.Put(MakeBucket(nil).Key(copyBytes(key.Suffix())), copyBytes(value))
+		if err != nil {
+			return copiedCount, err
+		}
+		copiedCount++
+
+		if copiedCount%batchSize == 0 {
+			err = dbTx.Commit()
+			if err != nil {
+				return copiedCount, err
+			}
+			dbTx, err = destination.Begin()
+			if err != nil {
+				return copiedCount, err
+			}
+		}
+	}
+	return copiedCount, dbTx.Commit()
+}
+
+func copyBytes(bytes []byte) []byte {
+	bytesCopy := make([]byte, len(bytes))
+	copy(bytesCopy, bytes)
+	return bytesCopy
+}
@@ -0,0 +1,63 @@
+package database_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+func TestCopy(t *testing.T) {
+	for _, sourcePrepareFunc := range databasePrepareFuncs {
+		for _, destinationPrepareFunc := range databasePrepareFuncs {
+			testCopy(t, sourcePrepareFunc, destinationPrepareFunc)
+		}
+	}
+}
+
+func testCopy(t *testing.T, sourcePrepareFunc databasePrepareFunc, destinationPrepareFunc databasePrepareFunc) {
+	source, sourceName, sourceTeardownFunc := sourcePrepareFunc(t, "TestCopy")
+	defer sourceTeardownFunc()
+	destination, destinationName, destinationTeardownFunc := destinationPrepareFunc(t, "TestCopy")
+	defer destinationTeardownFunc()
+	testName := fmt.Sprintf("TestCopy: %s to %s", sourceName, destinationName)
+
+	const entryCount = 25
+	buckets := []*database.Bucket{
+		database.MakeBucket([]byte("bucket1")),
+		database.MakeBucket([]byte("bucket2")).Bucket([]byte("inner")),
+	}
+	for _, bucket := range buckets {
+		for i := 0; i < entryCount; i++ {
+			err := source.Put(bucket.Key([]byte(fmt.Sprintf("key%d", i))), []byte(fmt.Sprintf("value%d", i)))
+			if err != nil {
+				t.Fatalf("%s: Put unexpectedly failed: %s", testName, err)
+			}
+		}
+	}
+
+	// A batch size that doesn't divide the amount of entries leaves
+	// a partial batch to be committed at the end
+	copiedCount, err := database.Copy(destination, source, 7)
+	if err != nil {
+		t.Fatalf("%s: Copy unexpectedly failed: %s", testName, err)
+	}
+	if copiedCount != entryCount*len(buckets) {
+		t.Fatalf("%s: unexpected copied count. Want: %d, got: %d",
+			testName, entryCount*len(buckets), copiedCount)
+	}
+
+	for _, bucket := range buckets {
+		for i := 0; i < entryCount; i++ {
+			value, err := destination.Get(bucket.Key([]byte(fmt.Sprintf("key%d", i))))
+			if err != nil {
+				t.Fatalf("%s: Get unexpectedly failed: %s", testName, err)
+			}
+			expectedValue := []byte(fmt.Sprintf("value%d", i))
+			if !bytes.Equal(value, expectedValue) {
+				t.Fatalf("%s: unexpected value. Want: %s, got: %s", testName, expectedValue, value)
+			}
+		}
+	}
+}
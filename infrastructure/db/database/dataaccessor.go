@@ -1,12 +1,8 @@
 package database
 
-// DataAccessor defines the common interface by which data gets
-// accessed in a generic kaspad database.
-type DataAccessor interface {
-	// Put sets the value for the given key. It overwrites
-	// any previous value for that key.
-	Put(key *Key, value []byte) error
-
+// DataReader defines the common interface by which data gets
+// read in a generic kaspad database.
+type DataReader interface {
 	// Get gets the value for the given key. It returns
 	// ErrNotFound if the given key does not exist.
 	Get(key *Key) ([]byte, error)
@@ -15,10 +11,20 @@ type DataAccessor interface {
 	// given key.
 	Has(key *Key) (bool, error)
 
+	// Cursor begins a new cursor over the given bucket.
+	Cursor(bucket *Bucket) (Cursor, error)
+}
+
+// DataAccessor defines the common interface by which data gets
+// accessed in a generic kaspad database.
+type DataAccessor interface {
+	DataReader
+
+	// Put sets the value for the given key. It overwrites
+	// any previous value for that key.
+	Put(key *Key, value []byte) error
+
 	// Delete deletes the value for the given key. Will not
 	// return an error if the key doesn't exist.
 	Delete(key *Key) error
-
-	// Cursor begins a new cursor over the given bucket.
-	Cursor(bucket *Bucket) (Cursor, error)
 }
@@ -14,9 +14,23 @@ type Database interface {
 	// Begin begins a new database transaction.
 	Begin() (Transaction, error)
 
+	// NewBatch returns a new, empty batch of writes.
+	NewBatch() (Batch, error)
+
+	// Snapshot takes a snapshot of the current state of the database.
+	Snapshot() (Snapshot, error)
+
 	// Compact compacts the database instance.
 	Compact() error
 
+	// Stats returns the storage statistics of the database instance.
+	Stats() (*Stats, error)
+
+	// Backup writes a consistent copy of the database into a new
+	// database in the given path, while the database keeps being
+	// used. The path must not exist.
+	Backup(path string) error
+
 	// Close closes the database.
 	Close() error
 }
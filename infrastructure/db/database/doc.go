@@ -27,6 +27,12 @@ Note: transactions provide data consistency over the state of the database as it
 when the transaction started. There is NO guarantee that if one puts data into the
 transaction then it will be available to get within the same transaction.
 
+# Snapshot
+
+This defines the interface of a read-only view of the database as it was when the
+snapshot was taken. Reading from a snapshot doesn't block writers, so long-running
+scans should read from one.
+
 # Cursor
 
 This iterates over database entries given some bucket.
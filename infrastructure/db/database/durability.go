@@ -0,0 +1,85 @@
+package database
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Durability is the policy by which a database syncs its writes to disk.
+type Durability string
+
+const (
+	// DurabilityNone never syncs writes, and leaves flushing them to the
+	// operating system. A crash of the machine may lose the latest writes,
+	// but a crash of the process alone loses nothing.
+	DurabilityNone Durability = "none"
+
+	// DurabilityBatch syncs the writes periodically, such that a single
+	// sync covers all the writes since the previous one. A crash of the
+	// machine loses at most the writes of the latest sync interval.
+	DurabilityBatch Durability = "batch"
+
+	// DurabilityFull syncs every write before it returns.
+	DurabilityFull Durability = "full"
+)
+
+// BatchSyncer calls a sync function every interval, as long as there were
+// writes since the previous sync. Backends use it to implement DurabilityBatch.
+type BatchSyncer struct {
+	syncFunc func()
+	interval time.Duration
+	isDirty  uint32
+
+	stopOnce sync.Once
+	quit     chan struct{}
+	done     chan struct{}
+}
+
+// NewBatchSyncer returns a new BatchSyncer, which starts syncing right away.
+func NewBatchSyncer(syncFunc func(), interval time.Duration) *BatchSyncer {
+	syncer := &BatchSyncer{
+		syncFunc: syncFunc,
+		interval: interval,
+		quit:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go syncer.run()
+	return syncer
+}
+
+// MarkDirty records that there was a write that wasn't synced yet.
+func (bs *BatchSyncer) MarkDirty() {
+	atomic.StoreUint32(&bs.isDirty, 1)
+}
+
+// Stop stops the syncer, after syncing the writes that weren't synced yet.
+// It must be called before the database is closed.
+func (bs *BatchSyncer) Stop() {
+	bs.stopOnce.Do(func() {
+		close(bs.quit)
+		<-bs.done
+	})
+}
+
+func (bs *BatchSyncer) run() {
+	defer close(bs.done)
+
+	ticker := time.NewTicker(bs.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			bs.syncIfDirty()
+		case <-bs.quit:
+			bs.syncIfDirty()
+			return
+		}
+	}
+}
+
+func (bs *BatchSyncer) syncIfDirty() {
+	if atomic.CompareAndSwapUint32(&bs.isDirty, 1, 0) {
+		bs.syncFunc()
+	}
+}
@@ -0,0 +1,126 @@
+package database_test
+
+import (
+	"io/ioutil"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/pebbledb"
+)
+
+func TestBatchSyncer(t *testing.T) {
+	var syncCount int32
+	syncer := database.NewBatchSyncer(func() {
+		atomic.AddInt32(&syncCount, 1)
+	}, 10*time.Millisecond)
+
+	// Nothing is synced as long as there were no writes
+	time.Sleep(50 * time.Millisecond)
+	if atomic.LoadInt32(&syncCount) != 0 {
+		t.Fatalf("The syncer synced without writes")
+	}
+
+	// Many writes share a single sync
+	for i := 0; i < 100; i++ {
+		syncer.MarkDirty()
+	}
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&syncCount) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for the syncer to sync")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if atomic.LoadInt32(&syncCount) != 1 {
+		t.Fatalf("Unexpected amount of syncs. Want: %d, got: %d", 1, atomic.LoadInt32(&syncCount))
+	}
+
+	// Stopping the syncer syncs the writes that weren't synced yet
+	syncer.MarkDirty()
+	syncer.Stop()
+	if atomic.LoadInt32(&syncCount) != 2 {
+		t.Fatalf("Unexpected amount of syncs after Stop. Want: %d, got: %d", 2, atomic.LoadInt32(&syncCount))
+	}
+	syncer.Stop()
+}
+
+func TestDurability(t *testing.T) {
+	openFuncs := map[string]func(path string, durability database.Durability) (database.Database, error){
+		"ldb": func(path string, durability database.Durability) (database.Database, error) {
+			return ldb.NewLevelDBWithDurability(path, 8, durability, 10*time.Millisecond)
+		},
+		"pebbledb": func(path string, durability database.Durability) (database.Database, error) {
+			return pebbledb.NewPebbleDBWithDurability(path, 8, durability, 10*time.Millisecond)
+		},
+	}
+	durabilities := []database.Durability{database.DurabilityNone, database.DurabilityBatch, database.DurabilityFull}
+
+	for dbType, open := range openFuncs {
+		for _, durability := range durabilities {
+			testName := dbType + ": " + string(durability)
+			path, err := ioutil.TempDir("", "TestDurability")
+			if err != nil {
+				t.Fatalf("%s: TempDir: %s", testName, err)
+			}
+			db, err := open(path, durability)
+			if err != nil {
+				t.Fatalf("%s: Open: %s", testName, err)
+			}
+
+			key := database.MakeBucket([]byte("bucket")).Key([]byte("key"))
+			err = db.Put(key, []byte("value"))
+			if err != nil {
+				t.Fatalf("%s: Put: %s", testName, err)
+			}
+			deletedKey := database.MakeBucket([]byte("bucket")).Key([]byte("deleted"))
+			dbTx, err := db.Begin()
+			if err != nil {
+				t.Fatalf("%s: Begin: %s", testName, err)
+			}
+			err = dbTx.Put(deletedKey, []byte("value"))
+			if err != nil {
+				t.Fatalf("%s: Put: %s", testName, err)
+			}
+			err = dbTx.Commit()
+			if err != nil {
+				t.Fatalf("%s: Commit: %s", testName, err)
+			}
+			err = db.Delete(deletedKey)
+			if err != nil {
+				t.Fatalf("%s: Delete: %s", testName, err)
+			}
+			err = db.Close()
+			if err != nil {
+				t.Fatalf("%s: Close: %s", testName, err)
+			}
+
+			// The writes are all there once the database is opened again
+			db, err = open(path, durability)
+			if err != nil {
+				t.Fatalf("%s: Open: %s", testName, err)
+			}
+			value, err := db.Get(key)
+			if err != nil {
+				t.Fatalf("%s: Get: %s", testName, err)
+			}
+			if string(value) != "value" {
+				t.Fatalf("%s: Unexpected value. Want: %s, got: %s", testName, "value", value)
+			}
+			exists, err := db.Has(deletedKey)
+			if err != nil {
+				t.Fatalf("%s: Has: %s", testName, err)
+			}
+			if exists {
+				t.Fatalf("%s: A deleted key exists after the database was opened again", testName)
+			}
+			err = db.Close()
+			if err != nil {
+				t.Fatalf("%s: Close: %s", testName, err)
+			}
+		}
+	}
+}
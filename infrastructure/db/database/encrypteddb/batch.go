@@ -0,0 +1,32 @@
+package encrypteddb
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+// encryptedBatch wraps a batch of the database the EncryptedDB
+// wraps, and encrypts the values that are written to it
+type encryptedBatch struct {
+	batch database.Batch
+	db    *EncryptedDB
+}
+
+func (b *encryptedBatch) Put(key *database.Key, value []byte) error {
+	return b.batch.Put(key, b.db.sealer.seal(key, value))
+}
+
+func (b *encryptedBatch) Delete(key *database.Key) error {
+	return b.batch.Delete(key)
+}
+
+func (b *encryptedBatch) Len() int {
+	return b.batch.Len()
+}
+
+func (b *encryptedBatch) Commit(sync bool) error {
+	return b.batch.Commit(sync)
+}
+
+func (b *encryptedBatch) Discard() error {
+	return b.batch.Discard()
+}
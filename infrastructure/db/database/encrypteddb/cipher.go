@@ -0,0 +1,57 @@
+package encrypteddb
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"sync/atomic"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+// sealer encrypts values with AES-GCM. The nonce of every value is a
+// random nonce, chosen once per sealer, plus a counter. Random nonces
+// alone would repeat after a few billion writes, which a node reaches
+// within weeks
+type sealer struct {
+	aead      cipher.AEAD
+	nonceBase []byte
+	counter   uint64
+}
+
+func newSealer(aead cipher.AEAD) (*sealer, error) {
+	nonceBase := make([]byte, aead.NonceSize())
+	_, err := rand.Read(nonceBase)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &sealer{aead: aead, nonceBase: nonceBase}, nil
+}
+
+// seal encrypts the value of the given key. The key is authenticated
+// along with the value, so that values can't be moved between keys.
+// The returned ciphertext is prefixed with its nonce
+func (s *sealer) seal(key *database.Key, value []byte) []byte {
+	nonceSize := s.aead.NonceSize()
+	ciphertext := make([]byte, nonceSize, nonceSize+len(value)+s.aead.Overhead())
+	copy(ciphertext, s.nonceBase)
+	counterBytes := ciphertext[nonceSize-8:]
+	counter := atomic.AddUint64(&s.counter, 1)
+	binary.BigEndian.PutUint64(counterBytes, binary.BigEndian.Uint64(counterBytes)+counter)
+
+	return s.aead.Seal(ciphertext, ciphertext[:nonceSize], value, key.Bytes())
+}
+
+// open decrypts the value of the given key that was encrypted by seal
+func open(aead cipher.AEAD, key *database.Key, ciphertext []byte) ([]byte, error) {
+	nonceSize := aead.NonceSize()
+	if len(ciphertext) < nonceSize+aead.Overhead() {
+		return nil, errors.Wrapf(database.ErrCorrupted, "the value of key %s is too short to be encrypted", key)
+	}
+	plaintext, err := aead.Open(nil, ciphertext[:nonceSize], ciphertext[nonceSize:], key.Bytes())
+	if err != nil {
+		return nil, errors.Wrapf(database.ErrCorrupted, "failed decrypting the value of key %s: %s", key, err)
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,75 @@
+package encrypteddb
+
+import (
+	"bytes"
+	"crypto/cipher"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+// encryptedCursor wraps a cursor of the database the EncryptedDB wraps,
+// and decrypts the values it iterates over. It skips the encryption
+// parameters, which aren't encrypted
+type encryptedCursor struct {
+	cursor database.Cursor
+	aead   cipher.AEAD
+}
+
+func (c *encryptedCursor) Next() bool {
+	if !c.cursor.Next() {
+		return false
+	}
+	return c.skipParameters()
+}
+
+func (c *encryptedCursor) First() bool {
+	if !c.cursor.First() {
+		return false
+	}
+	return c.skipParameters()
+}
+
+func (c *encryptedCursor) Seek(key *database.Key) error {
+	err := c.cursor.Seek(key)
+	if err != nil {
+		return err
+	}
+	if !c.skipParameters() {
+		return errors.Wrapf(database.ErrNotFound, "key %s not found", key)
+	}
+	return nil
+}
+
+// skipParameters moves the cursor past the encryption parameters, if
+// it's at them. It returns false if the cursor is exhausted
+func (c *encryptedCursor) skipParameters() bool {
+	key, err := c.cursor.Key()
+	if err != nil {
+		return true
+	}
+	if bytes.Equal(key.Bytes(), parametersKey.Bytes()) {
+		return c.cursor.Next()
+	}
+	return true
+}
+
+func (c *encryptedCursor) Key() (*database.Key, error) {
+	return c.cursor.Key()
+}
+
+func (c *encryptedCursor) Value() ([]byte, error) {
+	key, err := c.cursor.Key()
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := c.cursor.Value()
+	if err != nil {
+		return nil, err
+	}
+	return open(c.aead, key, ciphertext)
+}
+
+func (c *encryptedCursor) Close() error {
+	return c.cursor.Close()
+}
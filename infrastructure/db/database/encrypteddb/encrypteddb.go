@@ -0,0 +1,178 @@
+package encrypteddb
+
+import (
+	"crypto/cipher"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+// EncryptedDB wraps a database, and encrypts the values that are written
+// to it with AES-GCM. Keys aren't encrypted, so that cursors keep iterating
+// over buckets in order.
+//
+// Encryption is configured when the database is created: an empty database
+// is encrypted with the credentials it's first opened with, and from then on
+// can only be opened with the same credentials.
+type EncryptedDB struct {
+	database database.Database
+	aead     cipher.AEAD
+	sealer   *sealer
+}
+
+// Open wraps the given database with encryption of the key that's derived
+// from the given credentials. It fails if the database isn't empty and
+// wasn't encrypted when it was created, or if the credentials aren't the
+// ones it was encrypted with.
+func Open(db database.Database, credentials *Credentials) (*EncryptedDB, error) {
+	if (credentials.Passphrase == nil) == (credentials.Key == nil) {
+		return nil, errors.New("exactly one of a passphrase and a key is required")
+	}
+
+	params, found, err := readParameters(db)
+	if err != nil {
+		return nil, err
+	}
+	isNew := !found
+	if isNew {
+		isEmpty, err := isEmpty(db)
+		if err != nil {
+			return nil, err
+		}
+		if !isEmpty {
+			return nil, errors.New("the database isn't encrypted. Encryption can only " +
+				"be enabled when the database is created")
+		}
+		params, err = newParameters(credentials)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	aead, err := params.aead(credentials)
+	if err != nil {
+		return nil, err
+	}
+	err = params.verify(aead)
+	if err != nil {
+		return nil, err
+	}
+	sealer, err := newSealer(aead)
+	if err != nil {
+		return nil, err
+	}
+
+	if isNew {
+		err = params.store(db)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &EncryptedDB{
+		database: db,
+		aead:     aead,
+		sealer:   sealer,
+	}, nil
+}
+
+// IsEncrypted returns whether the given database was encrypted when it was created
+func IsEncrypted(db database.Database) (bool, error) {
+	_, found, err := readParameters(db)
+	return found, err
+}
+
+func isEmpty(db database.Database) (bool, error) {
+	cursor, err := db.Cursor(database.MakeBucket(nil))
+	if err != nil {
+		return false, err
+	}
+	defer cursor.Close()
+
+	return !cursor.First(), nil
+}
+
+// Put sets the value for the given key. It overwrites
+// any previous value for that key.
+func (db *EncryptedDB) Put(key *database.Key, value []byte) error {
+	return db.database.Put(key, db.sealer.seal(key, value))
+}
+
+// Get gets the value for the given key. It returns
+// ErrNotFound if the given key does not exist.
+func (db *EncryptedDB) Get(key *database.Key) ([]byte, error) {
+	ciphertext, err := db.database.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return open(db.aead, key, ciphertext)
+}
+
+// Has returns true if the database does contains the
+// given key.
+func (db *EncryptedDB) Has(key *database.Key) (bool, error) {
+	return db.database.Has(key)
+}
+
+// Delete deletes the value for the given key. Will not
+// return an error if the key doesn't exist.
+func (db *EncryptedDB) Delete(key *database.Key) error {
+	return db.database.Delete(key)
+}
+
+// Cursor begins a new cursor over the given bucket.
+func (db *EncryptedDB) Cursor(bucket *database.Bucket) (database.Cursor, error) {
+	cursor, err := db.database.Cursor(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedCursor{cursor: cursor, aead: db.aead}, nil
+}
+
+// Begin begins a new database transaction.
+func (db *EncryptedDB) Begin() (database.Transaction, error) {
+	transaction, err := db.database.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedTransaction{transaction: transaction, db: db}, nil
+}
+
+// NewBatch returns a new, empty batch of writes.
+func (db *EncryptedDB) NewBatch() (database.Batch, error) {
+	batch, err := db.database.NewBatch()
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedBatch{batch: batch, db: db}, nil
+}
+
+// Snapshot takes a snapshot of the current state of the database.
+func (db *EncryptedDB) Snapshot() (database.Snapshot, error) {
+	snapshot, err := db.database.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedSnapshot{snapshot: snapshot, db: db}, nil
+}
+
+// Compact compacts the database instance.
+func (db *EncryptedDB) Compact() error {
+	return db.database.Compact()
+}
+
+// Stats returns the storage statistics of the database instance.
+func (db *EncryptedDB) Stats() (*database.Stats, error) {
+	return db.database.Stats()
+}
+
+// Backup writes a consistent copy of the database into a new
+// database in the given path. The copy stays encrypted, and
+// is opened with the same credentials as the database.
+func (db *EncryptedDB) Backup(path string) error {
+	return db.database.Backup(path)
+}
+
+// Close closes the database.
+func (db *EncryptedDB) Close() error {
+	return db.database.Close()
+}
@@ -0,0 +1,232 @@
+package encrypteddb
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/pkg/errors"
+)
+
+func openTestDatabase(t *testing.T, path string) database.Database {
+	db, err := ldb.NewLevelDB(path, 8)
+	if err != nil {
+		t.Fatalf("NewLevelDB: %s", err)
+	}
+	return db
+}
+
+func testKey() []byte {
+	return bytes.Repeat([]byte{1}, KeySize)
+}
+
+func TestEncryptedDB(t *testing.T) {
+	path, err := ioutil.TempDir("", "TestEncryptedDB")
+	if err != nil {
+		t.Fatalf("TempDir: %s", err)
+	}
+	defer os.RemoveAll(path)
+
+	rawDB := openTestDatabase(t, path)
+	db, err := Open(rawDB, &Credentials{Key: testKey()})
+	if err != nil {
+		t.Fatalf("Open: %s", err)
+	}
+
+	bucket := database.MakeBucket([]byte("bucket"))
+	plaintext := []byte("a value that's stored encrypted")
+	err = db.Put(bucket.Key([]byte("a")), plaintext)
+	if err != nil {
+		t.Fatalf("Put: %s", err)
+	}
+	dbTx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Begin: %s", err)
+	}
+	err = dbTx.Put(bucket.Key([]byte("b")), plaintext)
+	if err != nil {
+		t.Fatalf("Put: %s", err)
+	}
+	err = dbTx.Commit()
+	if err != nil {
+		t.Fatalf("Commit: %s", err)
+	}
+
+	// The value is decrypted when it's read, and isn't stored as is
+	value, err := db.Get(bucket.Key([]byte("b")))
+	if err != nil {
+		t.Fatalf("Get: %s", err)
+	}
+	if !bytes.Equal(value, plaintext) {
+		t.Fatalf("Unexpected value. Want: %s, got: %s", plaintext, value)
+	}
+	rawValue, err := rawDB.Get(bucket.Key([]byte("b")))
+	if err != nil {
+		t.Fatalf("Get: %s", err)
+	}
+	if bytes.Contains(rawValue, plaintext) {
+		t.Fatalf("The value is stored unencrypted")
+	}
+
+	// Values can't be moved between keys
+	err = rawDB.Put(bucket.Key([]byte("c")), rawValue)
+	if err != nil {
+		t.Fatalf("Put: %s", err)
+	}
+	_, err = db.Get(bucket.Key([]byte("c")))
+	if !errors.Is(err, database.ErrCorrupted) {
+		t.Fatalf("Expected a value that was moved between keys to be corrupted, but got: %v", err)
+	}
+	err = db.Delete(bucket.Key([]byte("c")))
+	if err != nil {
+		t.Fatalf("Delete: %s", err)
+	}
+
+	// Cursors decrypt the values, and skip the encryption parameters
+	cursor, err := db.Cursor(database.MakeBucket(nil))
+	if err != nil {
+		t.Fatalf("Cursor: %s", err)
+	}
+	entryCount := 0
+	for ok := cursor.First(); ok; ok = cursor.Next() {
+		value, err := cursor.Value()
+		if err != nil {
+			t.Fatalf("Value: %s", err)
+		}
+		if !bytes.Equal(value, plaintext) {
+			t.Fatalf("Unexpected cursor value. Want: %s, got: %s", plaintext, value)
+		}
+		entryCount++
+	}
+	err = cursor.Close()
+	if err != nil {
+		t.Fatalf("Close: %s", err)
+	}
+	if entryCount != 2 {
+		t.Fatalf("Unexpected amount of entries. Want: %d, got: %d", 2, entryCount)
+	}
+
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("Close: %s", err)
+	}
+
+	// The database can only be opened with the key it was created with
+	rawDB = openTestDatabase(t, path)
+	defer rawDB.Close()
+	isEncrypted, err := IsEncrypted(rawDB)
+	if err != nil {
+		t.Fatalf("IsEncrypted: %s", err)
+	}
+	if !isEncrypted {
+		t.Fatalf("The database isn't marked as encrypted")
+	}
+	_, err = Open(rawDB, &Credentials{Key: bytes.Repeat([]byte{2}, KeySize)})
+	if !errors.Is(err, ErrWrongKey) {
+		t.Fatalf("Expected opening with a wrong key to return ErrWrongKey, but got: %v", err)
+	}
+	_, err = Open(rawDB, &Credentials{Passphrase: []byte("passphrase")})
+	if err == nil {
+		t.Fatalf("Expected opening a database that was encrypted with a key with a passphrase to fail")
+	}
+	db, err = Open(rawDB, &Credentials{Key: testKey()})
+	if err != nil {
+		t.Fatalf("Open: %s", err)
+	}
+	value, err = db.Get(bucket.Key([]byte("a")))
+	if err != nil {
+		t.Fatalf("Get: %s", err)
+	}
+	if !bytes.Equal(value, plaintext) {
+		t.Fatalf("Unexpected value after reopening. Want: %s, got: %s", plaintext, value)
+	}
+}
+
+func TestEncryptedDBPassphrase(t *testing.T) {
+	path, err := ioutil.TempDir("", "TestEncryptedDBPassphrase")
+	if err != nil {
+		t.Fatalf("TempDir: %s", err)
+	}
+	defer os.RemoveAll(path)
+
+	rawDB := openTestDatabase(t, path)
+	defer rawDB.Close()
+	db, err := Open(rawDB, &Credentials{Passphrase: []byte("passphrase")})
+	if err != nil {
+		t.Fatalf("Open: %s", err)
+	}
+	key := database.MakeBucket(nil).Key([]byte("key"))
+	err = db.Put(key, []byte("value"))
+	if err != nil {
+		t.Fatalf("Put: %s", err)
+	}
+
+	_, err = Open(rawDB, &Credentials{Passphrase: []byte("wrong passphrase")})
+	if !errors.Is(err, ErrWrongKey) {
+		t.Fatalf("Expected opening with a wrong passphrase to return ErrWrongKey, but got: %v", err)
+	}
+	db, err = Open(rawDB, &Credentials{Passphrase: []byte("passphrase")})
+	if err != nil {
+		t.Fatalf("Open: %s", err)
+	}
+	value, err := db.Get(key)
+	if err != nil {
+		t.Fatalf("Get: %s", err)
+	}
+	if string(value) != "value" {
+		t.Fatalf("Unexpected value. Want: %s, got: %s", "value", value)
+	}
+}
+
+func TestEncryptedDBRequiresEmptyDatabase(t *testing.T) {
+	path, err := ioutil.TempDir("", "TestEncryptedDBRequiresEmptyDatabase")
+	if err != nil {
+		t.Fatalf("TempDir: %s", err)
+	}
+	defer os.RemoveAll(path)
+
+	rawDB := openTestDatabase(t, path)
+	defer rawDB.Close()
+	err = rawDB.Put(database.MakeBucket(nil).Key([]byte("key")), []byte("value"))
+	if err != nil {
+		t.Fatalf("Put: %s", err)
+	}
+	_, err = Open(rawDB, &Credentials{Key: testKey()})
+	if err == nil {
+		t.Fatalf("Expected encrypting a database that isn't empty to fail")
+	}
+}
+
+func TestReadKeyFile(t *testing.T) {
+	path, err := ioutil.TempDir("", "TestReadKeyFile")
+	if err != nil {
+		t.Fatalf("TempDir: %s", err)
+	}
+	defer os.RemoveAll(path)
+
+	keyFile := filepath.Join(path, "db.key")
+	err = os.WriteFile(keyFile, []byte("0101010101010101010101010101010101010101010101010101010101010101\n"), 0600)
+	if err != nil {
+		t.Fatalf("WriteFile: %s", err)
+	}
+	key, err := ReadKeyFile(keyFile)
+	if err != nil {
+		t.Fatalf("ReadKeyFile: %s", err)
+	}
+	if !bytes.Equal(key, testKey()) {
+		t.Fatalf("Unexpected key. Want: %x, got: %x", testKey(), key)
+	}
+
+	err = os.WriteFile(keyFile, []byte("0101"), 0600)
+	if err != nil {
+		t.Fatalf("WriteFile: %s", err)
+	}
+	_, err = ReadKeyFile(keyFile)
+	if err == nil {
+		t.Fatalf("Expected reading a short key to fail")
+	}
+}
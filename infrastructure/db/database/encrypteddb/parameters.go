@@ -0,0 +1,166 @@
+package encrypteddb
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/argon2"
+)
+
+// KeySize is the size of the AES-256 keys the database is encrypted with
+const KeySize = 32
+
+const (
+	kdfNone     = "none"
+	kdfArgon2ID = "argon2id"
+
+	saltSize       = 16
+	argon2Time     = 1
+	argon2MemoryKB = 64 * 1024
+	argon2Threads  = 4
+)
+
+// parametersKey is the key the encryption parameters are stored in. It's
+// stored unencrypted, and hidden from the cursors of the encrypted database
+var parametersKey = database.MakeBucket([]byte("encryption")).Key([]byte("parameters"))
+
+// checkPlaintext is encrypted into the parameters, so that a wrong key
+// is detected when the database is opened, rather than on the first read
+var checkPlaintext = []byte("kaspad encrypted database")
+
+// ErrWrongKey is returned when the database is opened with a key that
+// isn't the key it was encrypted with
+var ErrWrongKey = errors.New("wrong database encryption key")
+
+// Credentials are what the encryption key of the database is derived
+// from. Exactly one of the fields is set
+type Credentials struct {
+	// Passphrase is stretched into the key with argon2id
+	Passphrase []byte
+
+	// Key is used as is, and must be KeySize bytes long
+	Key []byte
+}
+
+// ReadKeyFile reads a hex-encoded key of KeySize bytes from the given file
+func ReadKeyFile(path string) ([]byte, error) {
+	keyHex, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(keyHex)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "the key file %s is not hex-encoded", path)
+	}
+	if len(key) != KeySize {
+		return nil, errors.Errorf("the key in %s is %d bytes long, but must be %d bytes long",
+			path, len(key), KeySize)
+	}
+	return key, nil
+}
+
+// parameters are how the encryption key of the database is derived,
+// which are fixed when the database is created
+type parameters struct {
+	KDF   string `json:"kdf"`
+	Salt  []byte `json:"salt,omitempty"`
+	Check []byte `json:"check"`
+}
+
+func newParameters(credentials *Credentials) (*parameters, error) {
+	params := &parameters{}
+	if credentials.Passphrase != nil {
+		params.KDF = kdfArgon2ID
+		params.Salt = make([]byte, saltSize)
+		_, err := rand.Read(params.Salt)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	} else {
+		params.KDF = kdfNone
+	}
+
+	aead, err := params.aead(credentials)
+	if err != nil {
+		return nil, err
+	}
+	sealer, err := newSealer(aead)
+	if err != nil {
+		return nil, err
+	}
+	params.Check = sealer.seal(parametersKey, checkPlaintext)
+	return params, nil
+}
+
+func readParameters(dataAccessor database.DataAccessor) (params *parameters, found bool, err error) {
+	paramsBytes, err := dataAccessor.Get(parametersKey)
+	if database.IsNotFoundError(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	params = &parameters{}
+	err = json.Unmarshal(paramsBytes, params)
+	if err != nil {
+		return nil, false, errors.Wrapf(database.ErrCorrupted, "the encryption parameters are corrupted: %s", err)
+	}
+	return params, true, nil
+}
+
+func (params *parameters) store(dataAccessor database.DataAccessor) error {
+	paramsBytes, err := json.Marshal(params)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return dataAccessor.Put(parametersKey, paramsBytes)
+}
+
+// aead derives the key from the given credentials, and returns the AES-GCM
+// cipher of the key. It doesn't check that the key is the right one
+func (params *parameters) aead(credentials *Credentials) (cipher.AEAD, error) {
+	var key []byte
+	switch params.KDF {
+	case kdfNone:
+		if credentials.Key == nil {
+			return nil, errors.New("the database is encrypted with a key file, but a passphrase was given")
+		}
+		if len(credentials.Key) != KeySize {
+			return nil, errors.Errorf("the key is %d bytes long, but must be %d bytes long",
+				len(credentials.Key), KeySize)
+		}
+		key = credentials.Key
+	case kdfArgon2ID:
+		if credentials.Passphrase == nil {
+			return nil, errors.New("the database is encrypted with a passphrase, but a key file was given")
+		}
+		key = argon2.IDKey(credentials.Passphrase, params.Salt, argon2Time, argon2MemoryKB, argon2Threads, KeySize)
+	default:
+		return nil, errors.Errorf("unknown key derivation function %s", params.KDF)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	return aead, errors.WithStack(err)
+}
+
+// verify returns ErrWrongKey if the given cipher isn't of the key
+// the database was encrypted with
+func (params *parameters) verify(aead cipher.AEAD) error {
+	plaintext, err := open(aead, parametersKey, params.Check)
+	if err != nil || subtle.ConstantTimeCompare(plaintext, checkPlaintext) != 1 {
+		return errors.WithStack(ErrWrongKey)
+	}
+	return nil
+}
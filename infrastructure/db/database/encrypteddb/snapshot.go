@@ -0,0 +1,36 @@
+package encrypteddb
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+// encryptedSnapshot wraps a snapshot of the database the
+// EncryptedDB wraps, and decrypts the values that are read from it
+type encryptedSnapshot struct {
+	snapshot database.Snapshot
+	db       *EncryptedDB
+}
+
+func (s *encryptedSnapshot) Get(key *database.Key) ([]byte, error) {
+	ciphertext, err := s.snapshot.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return open(s.db.aead, key, ciphertext)
+}
+
+func (s *encryptedSnapshot) Has(key *database.Key) (bool, error) {
+	return s.snapshot.Has(key)
+}
+
+func (s *encryptedSnapshot) Cursor(bucket *database.Bucket) (database.Cursor, error) {
+	cursor, err := s.snapshot.Cursor(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedCursor{cursor: cursor, aead: s.db.aead}, nil
+}
+
+func (s *encryptedSnapshot) Release() error {
+	return s.snapshot.Release()
+}
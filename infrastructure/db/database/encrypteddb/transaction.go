@@ -0,0 +1,52 @@
+package encrypteddb
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+// encryptedTransaction wraps a transaction of the database the
+// EncryptedDB wraps, and encrypts the values that are written to it
+type encryptedTransaction struct {
+	transaction database.Transaction
+	db          *EncryptedDB
+}
+
+func (tx *encryptedTransaction) Put(key *database.Key, value []byte) error {
+	return tx.transaction.Put(key, tx.db.sealer.seal(key, value))
+}
+
+func (tx *encryptedTransaction) Get(key *database.Key) ([]byte, error) {
+	ciphertext, err := tx.transaction.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return open(tx.db.aead, key, ciphertext)
+}
+
+func (tx *encryptedTransaction) Has(key *database.Key) (bool, error) {
+	return tx.transaction.Has(key)
+}
+
+func (tx *encryptedTransaction) Delete(key *database.Key) error {
+	return tx.transaction.Delete(key)
+}
+
+func (tx *encryptedTransaction) Cursor(bucket *database.Bucket) (database.Cursor, error) {
+	cursor, err := tx.transaction.Cursor(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedCursor{cursor: cursor, aead: tx.db.aead}, nil
+}
+
+func (tx *encryptedTransaction) Rollback() error {
+	return tx.transaction.Rollback()
+}
+
+func (tx *encryptedTransaction) Commit() error {
+	return tx.transaction.Commit()
+}
+
+func (tx *encryptedTransaction) RollbackUnlessClosed() error {
+	return tx.transaction.RollbackUnlessClosed()
+}
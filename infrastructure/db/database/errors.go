@@ -10,3 +10,12 @@ var ErrNotFound = errors.New("not found")
 func IsNotFoundError(err error) bool {
 	return errors.Is(err, ErrNotFound)
 }
+
+// ErrCorrupted denotes that the data the database read
+// for the requested item is corrupted.
+var ErrCorrupted = errors.New("corrupted")
+
+// IsCorruptedError checks whether an error is an ErrCorrupted.
+func IsCorruptedError(err error) bool {
+	return errors.Is(err, ErrCorrupted)
+}
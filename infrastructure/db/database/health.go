@@ -0,0 +1,32 @@
+package database
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// healthCheckKey is the key CheckResponsive reads. It's never written, so
+// reading it touches no data.
+var healthCheckKey = MakeBucket([]byte("health-check")).Key([]byte("health-check"))
+
+// CheckResponsive returns an error if a read from the given database fails,
+// or doesn't complete within the given timeout. A read that times out is
+// abandoned rather than canceled.
+func CheckResponsive(db DataAccessor, timeout time.Duration) error {
+	errChan := make(chan error, 1)
+	go func() {
+		_, err := db.Has(healthCheckKey)
+		errChan <- err
+	}()
+
+	select {
+	case err := <-errChan:
+		if err != nil {
+			return errors.Errorf("the database failed to answer a read: %s", err)
+		}
+		return nil
+	case <-time.After(timeout):
+		return errors.Errorf("the database didn't answer a read within %s", timeout)
+	}
+}
@@ -0,0 +1,56 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+func TestCheckResponsive(t *testing.T) {
+	testForAllDatabaseTypes(t, "TestCheckResponsive", testCheckResponsive)
+}
+
+func testCheckResponsive(t *testing.T, db database.Database, testName string) {
+	err := database.CheckResponsive(db, time.Second)
+	if err != nil {
+		t.Fatalf("%s: CheckResponsive "+
+			"unexpectedly failed: %s", testName, err)
+	}
+}
+
+// unresponsiveDataAccessor is a DataAccessor whose Has calls fail with err,
+// or block until unblock is closed if err is nil
+type unresponsiveDataAccessor struct {
+	database.DataAccessor
+	err     error
+	unblock chan struct{}
+}
+
+func (dataAccessor *unresponsiveDataAccessor) Has(*database.Key) (bool, error) {
+	if dataAccessor.err != nil {
+		return false, dataAccessor.err
+	}
+	<-dataAccessor.unblock
+	return false, nil
+}
+
+func TestCheckResponsiveFailures(t *testing.T) {
+	failing := &unresponsiveDataAccessor{err: errors.New("disk error")}
+	err := database.CheckResponsive(failing, time.Second)
+	if err == nil {
+		t.Fatalf("CheckResponsive unexpectedly succeeded on a database that fails reads")
+	}
+
+	blocking := &unresponsiveDataAccessor{unblock: make(chan struct{})}
+	defer close(blocking.unblock)
+	start := time.Now()
+	err = database.CheckResponsive(blocking, 100*time.Millisecond)
+	if err == nil {
+		t.Fatalf("CheckResponsive unexpectedly succeeded on a database that doesn't answer reads")
+	}
+	if time.Since(start) > time.Second {
+		t.Fatalf("CheckResponsive took %s, well beyond its timeout", time.Since(start))
+	}
+}
@@ -0,0 +1,64 @@
+package ldb
+
+import (
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// backupBatchSize is the amount of bytes that are written to the
+// backup in every batch
+const backupBatchSize = 4 * opt.MiB
+
+// Backup writes the contents of a snapshot of the leveldb
+// instance into a new leveldb instance in the given path.
+func (db *LevelDB) Backup(path string) error {
+	snapshot, err := db.ldb.GetSnapshot()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer snapshot.Release()
+
+	options := Options()
+	options.ErrorIfExist = true
+	// NoSync would turn the sync of the last write into a no-op
+	options.NoSync = false
+	backup, err := leveldb.OpenFile(path, &options)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = copySnapshot(backup, snapshot)
+	if err != nil {
+		_ = backup.Close()
+		return err
+	}
+	return errors.WithStack(backup.Close())
+}
+
+func copySnapshot(destination *leveldb.DB, snapshot *leveldb.Snapshot) error {
+	iterator := snapshot.NewIterator(nil, nil)
+	defer iterator.Release()
+
+	batch := new(leveldb.Batch)
+	for iterator.Next() {
+		// The batch copies the key and value, which the iterator may reuse
+		batch.Put(iterator.Key(), iterator.Value())
+		if len(batch.Dump()) >= backupBatchSize {
+			err := destination.Write(batch, nil)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			batch.Reset()
+		}
+	}
+	err := iterator.Error()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// The backup is synced once it's complete, since the
+	// database writes without syncing
+	err = destination.Write(batch, &opt.WriteOptions{Sync: true})
+	return errors.WithStack(err)
+}
@@ -0,0 +1,82 @@
+package ldb
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+)
+
+// LevelDBBatch is a thin wrapper around native leveldb batches.
+type LevelDBBatch struct {
+	db       *LevelDB
+	batch    *leveldb.Batch
+	isClosed bool
+}
+
+// NewBatch returns a new, empty batch of writes.
+func (db *LevelDB) NewBatch() (database.Batch, error) {
+	return &LevelDBBatch{
+		db:    db,
+		batch: new(leveldb.Batch),
+	}, nil
+}
+
+// Put sets the value for the given key once the batch is
+// committed. It overwrites any previous value for that key.
+func (b *LevelDBBatch) Put(key *database.Key, value []byte) error {
+	if b.isClosed {
+		return errors.New("cannot put into a closed batch")
+	}
+
+	b.batch.Put(key.Bytes(), value)
+	return nil
+}
+
+// Delete deletes the value for the given key once the batch
+// is committed. Will not return an error if the key doesn't exist.
+func (b *LevelDBBatch) Delete(key *database.Key) error {
+	if b.isClosed {
+		return errors.New("cannot delete from a closed batch")
+	}
+
+	b.batch.Delete(key.Bytes())
+	return nil
+}
+
+// Len returns the amount of writes in the batch.
+func (b *LevelDBBatch) Len() int {
+	return b.batch.Len()
+}
+
+// Commit writes the batch atomically, and syncs it to disk if sync is set.
+func (b *LevelDBBatch) Commit(sync bool) error {
+	if b.isClosed {
+		return errors.New("cannot commit a closed batch")
+	}
+
+	b.isClosed = true
+	writeOptions := b.db.writeOptions
+	if sync {
+		writeOptions = &opt.WriteOptions{Sync: true}
+	}
+	err := b.db.ldb.Write(b.batch, writeOptions)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if !sync {
+		b.db.wrote()
+	}
+	return nil
+}
+
+// Discard drops the batch without writing it.
+func (b *LevelDBBatch) Discard() error {
+	if b.isClosed {
+		return nil
+	}
+
+	b.isClosed = true
+	b.batch.Reset()
+	return nil
+}
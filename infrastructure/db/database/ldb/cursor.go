@@ -19,13 +19,17 @@ type LevelDBCursor struct {
 
 // Cursor begins a new cursor over the given prefix.
 func (db *LevelDB) Cursor(bucket *database.Bucket) (database.Cursor, error) {
-	ldbIterator := db.ldb.NewIterator(util.BytesPrefix(bucket.Path()), nil)
+	return newCursor(db.ldb, bucket), nil
+}
+
+func newCursor(reader ldbReader, bucket *database.Bucket) *LevelDBCursor {
+	ldbIterator := reader.NewIterator(util.BytesPrefix(bucket.Path()), nil)
 
 	return &LevelDBCursor{
 		ldbIterator: ldbIterator,
 		bucket:      bucket,
 		isClosed:    false,
-	}, nil
+	}
 }
 
 // Next moves the iterator to the next key/value pair. It returns whether the
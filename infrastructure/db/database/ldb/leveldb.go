@@ -1,6 +1,9 @@
 package ldb
 
 import (
+	"os"
+	"time"
+
 	"github.com/kaspanet/kaspad/infrastructure/db/database"
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
@@ -9,15 +12,38 @@ import (
 	"github.com/syndtr/goleveldb/leveldb/util"
 )
 
+// syncKey is the key that's deleted to sync the journal when the
+// durability is batch, since leveldb skips empty writes. Deleting a
+// key that doesn't exist leaves the data unchanged.
+var syncKey = []byte("durability-sync")
+
 // LevelDB defines a thin wrapper around leveldb.
 type LevelDB struct {
 	ldb *leveldb.DB
+
+	// writeOptions are the options of all the writes, which
+	// sync them when the durability is full
+	writeOptions *opt.WriteOptions
+
+	// batchSyncer is nil unless the durability is batch
+	batchSyncer *database.BatchSyncer
 }
 
 // NewLevelDB opens a leveldb instance defined by the given path.
+// The instance never syncs its writes to disk.
 func NewLevelDB(path string, cacheSizeMiB int) (*LevelDB, error) {
+	return NewLevelDBWithDurability(path, cacheSizeMiB, database.DurabilityNone, 0)
+}
+
+// NewLevelDBWithDurability opens a leveldb instance defined by the given path,
+// which syncs its writes to disk according to the given durability. The sync
+// interval is only used when the durability is batch.
+func NewLevelDBWithDurability(path string, cacheSizeMiB int,
+	durability database.Durability, syncInterval time.Duration) (*LevelDB, error) {
+
 	// Open leveldb. If it doesn't exist, create it.
 	options := Options()
+	options.NoSync = durability == database.DurabilityNone
 	options.BlockCacheCapacity = cacheSizeMiB * opt.MiB
 	options.WriteBuffer = (cacheSizeMiB * opt.MiB) / 2
 	ldb, err := leveldb.OpenFile(path, &options)
@@ -45,9 +71,86 @@ func NewLevelDB(path string, cacheSizeMiB int) (*LevelDB, error) {
 	db := &LevelDB{
 		ldb: ldb,
 	}
+	switch durability {
+	case database.DurabilityFull:
+		db.writeOptions = &opt.WriteOptions{Sync: true}
+	case database.DurabilityBatch:
+		db.batchSyncer = database.NewBatchSyncer(db.sync, syncInterval)
+	}
 	return db, nil
 }
 
+// NewLevelDBReadOnly opens the existing leveldb instance defined by the given
+// path for reading only. Every write to the instance fails, and unlike
+// NewLevelDB, it doesn't attempt to recover the instance if it's corrupted.
+func NewLevelDBReadOnly(path string, cacheSizeMiB int) (*LevelDB, error) {
+	options := Options()
+	options.ReadOnly = true
+	options.ErrorIfMissing = true
+	options.BlockCacheCapacity = cacheSizeMiB * opt.MiB
+	ldb, err := leveldb.OpenFile(path, &options)
+	if err != nil {
+		if ldbErrors.IsCorrupted(err) {
+			return nil, errors.Wrapf(database.ErrCorrupted, "the database in %s is corrupted: %s", path, err)
+		}
+		return nil, errors.WithStack(err)
+	}
+	return &LevelDB{ldb: ldb}, nil
+}
+
+// RepairLevelDB rebuilds the manifest of the leveldb instance defined by
+// the given path from its table files, dropping the tables that can't be
+// read. Entries in the dropped tables are lost. This is the recovery that
+// NewLevelDB attempts when it detects a corruption while opening the
+// instance, but it's done whether the instance looks corrupted or not.
+func RepairLevelDB(path string) error {
+	// RecoverFile creates an empty instance if there's none in the path
+	_, err := os.Stat(path)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	options := Options()
+	ldb, err := leveldb.RecoverFile(path, &options)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(ldb.Close())
+}
+
+// VerifyChecksums reads every entry of the leveldb instance and verifies the
+// checksums of the blocks they're stored in. It returns ErrCorrupted when a
+// checksum doesn't match.
+func (db *LevelDB) VerifyChecksums() error {
+	iterator := db.ldb.NewIterator(nil, &opt.ReadOptions{Strict: opt.StrictBlockChecksum | opt.StrictReader})
+	defer iterator.Release()
+	for iterator.Next() {
+		// Moving to an entry reads its block, which verifies its checksum
+	}
+	err := iterator.Error()
+	if err != nil {
+		if ldbErrors.IsCorrupted(err) {
+			return errors.Wrapf(database.ErrCorrupted, "%s", err)
+		}
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// sync syncs the journal, and with it all the writes before it
+func (db *LevelDB) sync() {
+	err := db.ldb.Delete(syncKey, &opt.WriteOptions{Sync: true})
+	if err != nil {
+		log.Errorf("Failed syncing the database: %s", err)
+	}
+}
+
+// wrote is called after every write to the database
+func (db *LevelDB) wrote() {
+	if db.batchSyncer != nil {
+		db.batchSyncer.MarkDirty()
+	}
+}
+
 // Compact compacts the leveldb instance.
 func (db *LevelDB) Compact() error {
 	err := db.ldb.CompactRange(util.Range{Start: nil, Limit: nil})
@@ -56,6 +159,9 @@ func (db *LevelDB) Compact() error {
 
 // Close closes the leveldb instance.
 func (db *LevelDB) Close() error {
+	if db.batchSyncer != nil {
+		db.batchSyncer.Stop()
+	}
 	err := db.ldb.Close()
 	return errors.WithStack(err)
 }
@@ -63,20 +169,28 @@ func (db *LevelDB) Close() error {
 // Put sets the value for the given key. It overwrites
 // any previous value for that key.
 func (db *LevelDB) Put(key *database.Key, value []byte) error {
-	err := db.ldb.Put(key.Bytes(), value, nil)
-	return errors.WithStack(err)
+	err := db.ldb.Put(key.Bytes(), value, db.writeOptions)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	db.wrote()
+	return nil
 }
 
 // Get gets the value for the given key. It returns
 // ErrNotFound if the given key does not exist.
 func (db *LevelDB) Get(key *database.Key) ([]byte, error) {
-	data, err := db.ldb.Get(key.Bytes(), nil)
+	return get(db.ldb, key)
+}
+
+func get(reader ldbReader, key *database.Key) ([]byte, error) {
+	data, err := reader.Get(key.Bytes(), nil)
 	if err != nil {
 		if errors.Is(err, leveldb.ErrNotFound) {
 			return nil, errors.Wrapf(database.ErrNotFound,
 				"key %s not found", key)
 		}
-		return nil, errors.WithStack(err)
+		return nil, wrapReadError(err, key)
 	}
 	return data, nil
 }
@@ -84,9 +198,13 @@ func (db *LevelDB) Get(key *database.Key) ([]byte, error) {
 // Has returns true if the database does contains the
 // given key.
 func (db *LevelDB) Has(key *database.Key) (bool, error) {
-	exists, err := db.ldb.Has(key.Bytes(), nil)
+	return has(db.ldb, key)
+}
+
+func has(reader ldbReader, key *database.Key) (bool, error) {
+	exists, err := reader.Has(key.Bytes(), nil)
 	if err != nil {
-		return false, errors.WithStack(err)
+		return false, wrapReadError(err, key)
 	}
 	return exists, nil
 }
@@ -94,6 +212,19 @@ func (db *LevelDB) Has(key *database.Key) (bool, error) {
 // Delete deletes the value for the given key. Will not
 // return an error if the key doesn't exist.
 func (db *LevelDB) Delete(key *database.Key) error {
-	err := db.ldb.Delete(key.Bytes(), nil)
+	err := db.ldb.Delete(key.Bytes(), db.writeOptions)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	db.wrote()
+	return nil
+}
+
+// wrapReadError wraps errors that leveldb returned while reading the
+// given key, such that corruption is reported as ErrCorrupted
+func wrapReadError(err error, key *database.Key) error {
+	if ldbErrors.IsCorrupted(err) {
+		return errors.Wrapf(database.ErrCorrupted, "key %s is corrupted: %s", key, err)
+	}
 	return errors.WithStack(err)
 }
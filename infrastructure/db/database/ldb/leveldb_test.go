@@ -1,7 +1,9 @@
 package ldb
 
 import (
+	"bytes"
 	"io/ioutil"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -151,3 +153,241 @@ func TestLevelDBTransactionSanity(t *testing.T) {
 			"returned unexpected error: %s", err)
 	}
 }
+
+// createCorruptedDatabase creates a database with a single key in the given
+// path, and corrupts the table file the key was written into
+func createCorruptedDatabase(t *testing.T, testName string, path string) *database.Key {
+	ldb, err := NewLevelDB(path, 8)
+	if err != nil {
+		t.Fatalf("%s: NewLevelDB unexpectedly "+
+			"failed: %s", testName, err)
+	}
+	key := database.MakeBucket(nil).Key([]byte("key"))
+	err = ldb.Put(key, bytes.Repeat([]byte("value"), 1000))
+	if err != nil {
+		t.Fatalf("%s: Put unexpectedly "+
+			"failed: %s", testName, err)
+	}
+	// Compact the database so that the value is written into a table file
+	err = ldb.Compact()
+	if err != nil {
+		t.Fatalf("%s: Compact unexpectedly "+
+			"failed: %s", testName, err)
+	}
+	err = ldb.Close()
+	if err != nil {
+		t.Fatalf("%s: Close unexpectedly "+
+			"failed: %s", testName, err)
+	}
+
+	tableFilePaths, err := filepath.Glob(filepath.Join(path, "*.ldb"))
+	if err != nil || len(tableFilePaths) == 0 {
+		t.Fatalf("%s: no table files were found: %v", testName, err)
+	}
+	for _, tableFilePath := range tableFilePaths {
+		tableBytes, err := ioutil.ReadFile(tableFilePath)
+		if err != nil {
+			t.Fatalf("%s: ReadFile unexpectedly "+
+				"failed: %s", testName, err)
+		}
+		for i := 0; i < len(tableBytes)/2; i++ {
+			tableBytes[i] ^= 0xff
+		}
+		err = ioutil.WriteFile(tableFilePath, tableBytes, 0600)
+		if err != nil {
+			t.Fatalf("%s: WriteFile unexpectedly "+
+				"failed: %s", testName, err)
+		}
+	}
+	return key
+}
+
+func TestLevelDBCorruption(t *testing.T) {
+	path, err := ioutil.TempDir("", "TestLevelDBCorruption")
+	if err != nil {
+		t.Fatalf("TestLevelDBCorruption: TempDir unexpectedly "+
+			"failed: %s", err)
+	}
+	key := createCorruptedDatabase(t, "TestLevelDBCorruption", path)
+
+	ldb, err := NewLevelDB(path, 8)
+	if err != nil {
+		t.Fatalf("TestLevelDBCorruption: NewLevelDB unexpectedly "+
+			"failed: %s", err)
+	}
+	defer ldb.Close()
+	_, err = ldb.Get(key)
+	if !database.IsCorruptedError(err) {
+		t.Fatalf("TestLevelDBCorruption: expected Get to return ErrCorrupted, but got: %v", err)
+	}
+}
+
+func TestLevelDBReadOnly(t *testing.T) {
+	path := t.TempDir()
+	_, err := NewLevelDBReadOnly(filepath.Join(path, "missing"), 8)
+	if err == nil {
+		t.Fatalf("TestLevelDBReadOnly: expected opening a missing database to fail")
+	}
+
+	ldb, err := NewLevelDB(path, 8)
+	if err != nil {
+		t.Fatalf("TestLevelDBReadOnly: NewLevelDB unexpectedly "+
+			"failed: %s", err)
+	}
+	key := database.MakeBucket(nil).Key([]byte("key"))
+	err = ldb.Put(key, []byte("value"))
+	if err != nil {
+		t.Fatalf("TestLevelDBReadOnly: Put unexpectedly "+
+			"failed: %s", err)
+	}
+	err = ldb.Close()
+	if err != nil {
+		t.Fatalf("TestLevelDBReadOnly: Close unexpectedly "+
+			"failed: %s", err)
+	}
+
+	ldb, err = NewLevelDBReadOnly(path, 8)
+	if err != nil {
+		t.Fatalf("TestLevelDBReadOnly: NewLevelDBReadOnly unexpectedly "+
+			"failed: %s", err)
+	}
+	defer ldb.Close()
+	value, err := ldb.Get(key)
+	if err != nil {
+		t.Fatalf("TestLevelDBReadOnly: Get unexpectedly "+
+			"failed: %s", err)
+	}
+	if !bytes.Equal(value, []byte("value")) {
+		t.Fatalf("TestLevelDBReadOnly: unexpected value %s", value)
+	}
+	err = ldb.Put(key, []byte("other value"))
+	if err == nil {
+		t.Fatalf("TestLevelDBReadOnly: expected Put to fail")
+	}
+}
+
+func TestLevelDBVerifyChecksums(t *testing.T) {
+	ldb, teardownFunc := prepareDatabaseForTest(t, "TestLevelDBVerifyChecksums")
+	for i := 0; i < 100; i++ {
+		err := ldb.Put(database.MakeBucket(nil).Key([]byte{byte(i)}), bytes.Repeat([]byte{byte(i)}, 1000))
+		if err != nil {
+			t.Fatalf("TestLevelDBVerifyChecksums: Put unexpectedly "+
+				"failed: %s", err)
+		}
+	}
+	err := ldb.Compact()
+	if err != nil {
+		t.Fatalf("TestLevelDBVerifyChecksums: Compact unexpectedly "+
+			"failed: %s", err)
+	}
+	err = ldb.VerifyChecksums()
+	if err != nil {
+		t.Fatalf("TestLevelDBVerifyChecksums: VerifyChecksums unexpectedly "+
+			"failed: %s", err)
+	}
+	teardownFunc()
+
+	path := t.TempDir()
+	createCorruptedDatabase(t, "TestLevelDBVerifyChecksums", path)
+	ldb, err = NewLevelDBReadOnly(path, 8)
+	if err != nil {
+		if database.IsCorruptedError(err) {
+			return
+		}
+		t.Fatalf("TestLevelDBVerifyChecksums: NewLevelDBReadOnly unexpectedly "+
+			"failed: %s", err)
+	}
+	defer ldb.Close()
+	err = ldb.VerifyChecksums()
+	if !database.IsCorruptedError(err) {
+		t.Fatalf("TestLevelDBVerifyChecksums: expected VerifyChecksums to return ErrCorrupted, but got: %v", err)
+	}
+}
+
+func TestRepairLevelDB(t *testing.T) {
+	path := t.TempDir()
+	err := RepairLevelDB(filepath.Join(path, "missing"))
+	if err == nil {
+		t.Fatalf("TestRepairLevelDB: expected repairing a missing database to fail")
+	}
+
+	key := createCorruptedDatabase(t, "TestRepairLevelDB", path)
+	err = RepairLevelDB(path)
+	if err != nil {
+		t.Fatalf("TestRepairLevelDB: RepairLevelDB unexpectedly "+
+			"failed: %s", err)
+	}
+
+	// The corrupted table is dropped, and the key with it
+	ldb, err := NewLevelDBReadOnly(path, 8)
+	if err != nil {
+		t.Fatalf("TestRepairLevelDB: NewLevelDBReadOnly unexpectedly "+
+			"failed: %s", err)
+	}
+	defer ldb.Close()
+	err = ldb.VerifyChecksums()
+	if err != nil {
+		t.Fatalf("TestRepairLevelDB: VerifyChecksums unexpectedly "+
+			"failed: %s", err)
+	}
+	_, err = ldb.Get(key)
+	if !database.IsNotFoundError(err) {
+		t.Fatalf("TestRepairLevelDB: expected Get to return ErrNotFound, but got: %v", err)
+	}
+}
+
+func TestLevelDBBackup(t *testing.T) {
+	ldb, teardownFunc := prepareDatabaseForTest(t, "TestLevelDBBackup")
+	defer teardownFunc()
+
+	bucket := database.MakeBucket([]byte("bucket"))
+	for i := 0; i < 100; i++ {
+		err := ldb.Put(bucket.Key([]byte{byte(i)}), []byte{byte(i)})
+		if err != nil {
+			t.Fatalf("TestLevelDBBackup: Put unexpectedly "+
+				"failed: %s", err)
+		}
+	}
+
+	backupPath := filepath.Join(t.TempDir(), "backup")
+	err := ldb.Backup(backupPath)
+	if err != nil {
+		t.Fatalf("TestLevelDBBackup: Backup unexpectedly "+
+			"failed: %s", err)
+	}
+	// Writes after the backup was taken don't change it
+	err = ldb.Put(bucket.Key([]byte{100}), []byte{100})
+	if err != nil {
+		t.Fatalf("TestLevelDBBackup: Put unexpectedly "+
+			"failed: %s", err)
+	}
+	err = ldb.Backup(backupPath)
+	if err == nil {
+		t.Fatalf("TestLevelDBBackup: expected backing up into an existing path to fail")
+	}
+
+	backup, err := NewLevelDB(backupPath, 8)
+	if err != nil {
+		t.Fatalf("TestLevelDBBackup: NewLevelDB unexpectedly "+
+			"failed: %s", err)
+	}
+	defer backup.Close()
+	for i := 0; i < 100; i++ {
+		value, err := backup.Get(bucket.Key([]byte{byte(i)}))
+		if err != nil {
+			t.Fatalf("TestLevelDBBackup: Get unexpectedly "+
+				"failed: %s", err)
+		}
+		if !bytes.Equal(value, []byte{byte(i)}) {
+			t.Fatalf("TestLevelDBBackup: unexpected value. Want: %x, got: %x", []byte{byte(i)}, value)
+		}
+	}
+	has, err := backup.Has(bucket.Key([]byte{100}))
+	if err != nil {
+		t.Fatalf("TestLevelDBBackup: Has unexpectedly "+
+			"failed: %s", err)
+	}
+	if has {
+		t.Fatalf("TestLevelDBBackup: the backup contains a key that was put after it was taken")
+	}
+}
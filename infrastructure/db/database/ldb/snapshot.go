@@ -0,0 +1,68 @@
+package ldb
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// ldbReader is implemented by both leveldb databases and their snapshots
+type ldbReader interface {
+	Get(key []byte, ro *opt.ReadOptions) ([]byte, error)
+	Has(key []byte, ro *opt.ReadOptions) (bool, error)
+	NewIterator(slice *util.Range, ro *opt.ReadOptions) iterator.Iterator
+}
+
+// LevelDBSnapshot is a thin wrapper around native leveldb snapshots.
+type LevelDBSnapshot struct {
+	snapshot   *leveldb.Snapshot
+	isReleased bool
+}
+
+// Snapshot takes a snapshot of the current state of the database.
+func (db *LevelDB) Snapshot() (database.Snapshot, error) {
+	snapshot, err := db.ldb.GetSnapshot()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return &LevelDBSnapshot{snapshot: snapshot}, nil
+}
+
+// Get gets the value for the given key. It returns
+// ErrNotFound if the given key does not exist.
+func (s *LevelDBSnapshot) Get(key *database.Key) ([]byte, error) {
+	if s.isReleased {
+		return nil, errors.New("cannot get from a released snapshot")
+	}
+	return get(s.snapshot, key)
+}
+
+// Has returns true if the database does contains the
+// given key.
+func (s *LevelDBSnapshot) Has(key *database.Key) (bool, error) {
+	if s.isReleased {
+		return false, errors.New("cannot has from a released snapshot")
+	}
+	return has(s.snapshot, key)
+}
+
+// Cursor begins a new cursor over the given bucket.
+func (s *LevelDBSnapshot) Cursor(bucket *database.Bucket) (database.Cursor, error) {
+	if s.isReleased {
+		return nil, errors.New("cannot open a cursor from a released snapshot")
+	}
+	return newCursor(s.snapshot, bucket), nil
+}
+
+// Release releases the snapshot.
+func (s *LevelDBSnapshot) Release() error {
+	if s.isReleased {
+		return errors.New("cannot release an already released snapshot")
+	}
+	s.isReleased = true
+	s.snapshot.Release()
+	return nil
+}
@@ -0,0 +1,49 @@
+package ldb
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// Stats returns the storage statistics of the leveldb instance.
+func (db *LevelDB) Stats() (*database.Stats, error) {
+	var dbStats leveldb.DBStats
+	err := db.ldb.Stats(&dbStats)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	stats := &database.Stats{
+		Levels: make([]*database.LevelStats, len(dbStats.LevelSizes)),
+	}
+	for level, size := range dbStats.LevelSizes {
+		stats.DiskSize += uint64(size)
+		stats.Levels[level] = &database.LevelStats{
+			FileCount: uint64(dbStats.LevelTablesCounts[level]),
+			Size:      uint64(size),
+		}
+	}
+	stats.PendingCompactionBytes = pendingCompactionBytes(stats.Levels)
+	return stats, nil
+}
+
+// pendingCompactionBytes estimates the compaction debt of leveldb, which doesn't
+// track it, from the levels that are over the size that triggers their compaction
+func pendingCompactionBytes(levels []*database.LevelStats) uint64 {
+	options := Options()
+	pendingBytes := uint64(0)
+	for level, levelStats := range levels {
+		if level == 0 {
+			if levelStats.FileCount >= uint64(options.GetCompactionL0Trigger()) {
+				pendingBytes += levelStats.Size
+			}
+			continue
+		}
+		maxLevelSize := uint64(options.GetCompactionTotalSize(level))
+		if levelStats.Size > maxLevelSize {
+			pendingBytes += levelStats.Size - maxLevelSize
+		}
+	}
+	return pendingBytes
+}
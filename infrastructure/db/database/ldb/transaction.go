@@ -40,7 +40,12 @@ func (tx *LevelDBTransaction) Commit() error {
 	}
 
 	tx.isClosed = true
-	return errors.WithStack(tx.db.ldb.Write(tx.batch, nil))
+	err := tx.db.ldb.Write(tx.batch, tx.db.writeOptions)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	tx.db.wrote()
+	return nil
 }
 
 // Rollback rolls back whatever changes were made to the
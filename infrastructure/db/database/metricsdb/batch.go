@@ -0,0 +1,45 @@
+package metricsdb
+
+import (
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+// metricsBatch wraps a batch of the database the MetricsDB wraps. Like
+// with transactions, the writes of a batch are recorded when they're
+// added to it, and the time it takes to write them is recorded when
+// it's committed
+type metricsBatch struct {
+	batch    database.Batch
+	registry *registry
+}
+
+func (b *metricsBatch) Put(key *database.Key, value []byte) error {
+	start := time.Now()
+	err := b.batch.Put(key, value)
+	b.registry.record(key.Bucket().Path(), OperationPut, keySize(key)+len(value), start)
+	return err
+}
+
+func (b *metricsBatch) Delete(key *database.Key) error {
+	start := time.Now()
+	err := b.batch.Delete(key)
+	b.registry.record(key.Bucket().Path(), OperationDelete, keySize(key), start)
+	return err
+}
+
+func (b *metricsBatch) Len() int {
+	return b.batch.Len()
+}
+
+func (b *metricsBatch) Commit(sync bool) error {
+	start := time.Now()
+	err := b.batch.Commit(sync)
+	b.registry.record(nil, OperationCommit, 0, start)
+	return err
+}
+
+func (b *metricsBatch) Discard() error {
+	return b.batch.Discard()
+}
@@ -0,0 +1,69 @@
+package metricsdb
+
+import (
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+// metricsCursor wraps a cursor of the database the MetricsDB wraps. Every
+// move of the cursor is recorded as a cursor operation over its bucket,
+// and the keys and values that are read are added to its byte volume
+type metricsCursor struct {
+	cursor  database.Cursor
+	metrics *operationMetrics
+}
+
+func newCursor(dataReader database.DataReader, registry *registry, bucket *database.Bucket) (database.Cursor, error) {
+	cursor, err := dataReader.Cursor(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsCursor{
+		cursor:  cursor,
+		metrics: registry.operationMetrics(bucket.Path(), OperationCursor),
+	}, nil
+}
+
+func (c *metricsCursor) Next() bool {
+	start := time.Now()
+	ok := c.cursor.Next()
+	c.metrics.record(0, time.Since(start))
+	return ok
+}
+
+func (c *metricsCursor) First() bool {
+	start := time.Now()
+	ok := c.cursor.First()
+	c.metrics.record(0, time.Since(start))
+	return ok
+}
+
+func (c *metricsCursor) Seek(key *database.Key) error {
+	start := time.Now()
+	err := c.cursor.Seek(key)
+	c.metrics.record(0, time.Since(start))
+	return err
+}
+
+func (c *metricsCursor) Key() (*database.Key, error) {
+	key, err := c.cursor.Key()
+	if err != nil {
+		return nil, err
+	}
+	c.metrics.addBytes(keySize(key))
+	return key, nil
+}
+
+func (c *metricsCursor) Value() ([]byte, error) {
+	value, err := c.cursor.Value()
+	if err != nil {
+		return nil, err
+	}
+	c.metrics.addBytes(len(value))
+	return value, nil
+}
+
+func (c *metricsCursor) Close() error {
+	return c.cursor.Close()
+}
@@ -0,0 +1,175 @@
+package metricsdb
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// The operations the metrics are recorded for
+const (
+	OperationGet    = "get"
+	OperationHas    = "has"
+	OperationPut    = "put"
+	OperationDelete = "delete"
+	OperationCursor = "cursor"
+	OperationCommit = "commit"
+)
+
+// LatencyBounds are the upper bounds of the buckets of the latency
+// histograms. The histograms have an additional last bucket for the
+// operations that took longer than the last bound
+var LatencyBounds = []time.Duration{
+	time.Microsecond,
+	10 * time.Microsecond,
+	100 * time.Microsecond,
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+}
+
+// OperationMetrics are the metrics of a single operation type
+// over the keys under a single bucket
+type OperationMetrics struct {
+	// Bucket is the path of the bucket. Commits aren't of any
+	// bucket, and their bucket is empty
+	Bucket    []byte
+	Operation string
+
+	// Count is the amount of operations. For cursors, it's
+	// the amount of times they were moved
+	Count uint64
+
+	// Bytes is the amount of key and value bytes the
+	// operations read or wrote
+	Bytes uint64
+
+	TotalLatency time.Duration
+
+	// LatencyHistogram are the amounts of operations whose latency
+	// fell in each bucket of LatencyBounds, followed by the amount
+	// of operations that took longer than all of them
+	LatencyHistogram []uint64
+}
+
+type metricsKey struct {
+	bucket    string
+	operation string
+}
+
+type operationMetrics struct {
+	count            uint64
+	bytes            uint64
+	totalLatency     int64
+	latencyHistogram []uint64
+}
+
+func (m *operationMetrics) record(byteCount int, latency time.Duration) {
+	atomic.AddUint64(&m.count, 1)
+	atomic.AddUint64(&m.bytes, uint64(byteCount))
+	atomic.AddInt64(&m.totalLatency, int64(latency))
+	atomic.AddUint64(&m.latencyHistogram[latencyBucket(latency)], 1)
+}
+
+func (m *operationMetrics) addBytes(byteCount int) {
+	atomic.AddUint64(&m.bytes, uint64(byteCount))
+}
+
+func latencyBucket(latency time.Duration) int {
+	for i, bound := range LatencyBounds {
+		if latency <= bound {
+			return i
+		}
+	}
+	return len(LatencyBounds)
+}
+
+// registry holds the metrics of all the operations and buckets. Buckets
+// are cut off at a fixed depth, such that the keys of a store, whose
+// suffixes are nested buckets of their own, are all counted together
+type registry struct {
+	bucketDepth int
+
+	lock    sync.RWMutex
+	metrics map[metricsKey]*operationMetrics
+}
+
+func newRegistry(bucketDepth int) *registry {
+	return &registry{
+		bucketDepth: bucketDepth,
+		metrics:     make(map[metricsKey]*operationMetrics),
+	}
+}
+
+func (r *registry) record(bucketPath []byte, operation string, byteCount int, start time.Time) {
+	r.operationMetrics(bucketPath, operation).record(byteCount, time.Since(start))
+}
+
+// operationMetrics returns the metrics of the given operation
+// over the bucket with the given path
+func (r *registry) operationMetrics(bucketPath []byte, operation string) *operationMetrics {
+	key := metricsKey{bucket: string(r.cutBucketPath(bucketPath)), operation: operation}
+
+	r.lock.RLock()
+	metrics, ok := r.metrics[key]
+	r.lock.RUnlock()
+	if ok {
+		return metrics
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	metrics, ok = r.metrics[key]
+	if !ok {
+		metrics = &operationMetrics{latencyHistogram: make([]uint64, len(LatencyBounds)+1)}
+		r.metrics[key] = metrics
+	}
+	return metrics
+}
+
+// cutBucketPath cuts the given bucket path off at the registry's bucket
+// depth. Bucket paths are used rather than key bytes, since key suffixes
+// are mostly hashes, which may contain the bucket separator
+func (r *registry) cutBucketPath(bucketPath []byte) []byte {
+	end := 0
+	for i := 0; i < r.bucketDepth; i++ {
+		separatorIndex := bytes.IndexByte(bucketPath[end:], '/')
+		if separatorIndex == -1 {
+			break
+		}
+		end += separatorIndex + 1
+	}
+	return bucketPath[:end]
+}
+
+func (r *registry) snapshot() []*OperationMetrics {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	snapshot := make([]*OperationMetrics, 0, len(r.metrics))
+	for key, metrics := range r.metrics {
+		latencyHistogram := make([]uint64, len(metrics.latencyHistogram))
+		for i := range latencyHistogram {
+			latencyHistogram[i] = atomic.LoadUint64(&metrics.latencyHistogram[i])
+		}
+		snapshot = append(snapshot, &OperationMetrics{
+			Bucket:           []byte(key.bucket),
+			Operation:        key.operation,
+			Count:            atomic.LoadUint64(&metrics.count),
+			Bytes:            atomic.LoadUint64(&metrics.bytes),
+			TotalLatency:     time.Duration(atomic.LoadInt64(&metrics.totalLatency)),
+			LatencyHistogram: latencyHistogram,
+		})
+	}
+	sort.Slice(snapshot, func(i, j int) bool {
+		comparison := bytes.Compare(snapshot[i].Bucket, snapshot[j].Bucket)
+		if comparison != 0 {
+			return comparison < 0
+		}
+		return snapshot[i].Operation < snapshot[j].Operation
+	})
+	return snapshot
+}
@@ -0,0 +1,140 @@
+package metricsdb
+
+import (
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+// MetricsDB wraps a database, and records the count, the byte volume and
+// the latency of the operations on it, per bucket and per operation type
+type MetricsDB struct {
+	database database.Database
+	registry *registry
+}
+
+// New wraps the given database with metrics. The metrics of keys that are
+// more than bucketDepth buckets deep are recorded in their ancestor bucket
+// that's bucketDepth buckets deep
+func New(db database.Database, bucketDepth int) *MetricsDB {
+	return &MetricsDB{
+		database: db,
+		registry: newRegistry(bucketDepth),
+	}
+}
+
+// Metrics returns the metrics that were recorded since the database
+// was wrapped, sorted by bucket and then by operation
+func (db *MetricsDB) Metrics() []*OperationMetrics {
+	return db.registry.snapshot()
+}
+
+// Put sets the value for the given key. It overwrites
+// any previous value for that key.
+func (db *MetricsDB) Put(key *database.Key, value []byte) error {
+	return put(db.database, db.registry, key, value)
+}
+
+// Get gets the value for the given key. It returns
+// ErrNotFound if the given key does not exist.
+func (db *MetricsDB) Get(key *database.Key) ([]byte, error) {
+	return get(db.database, db.registry, key)
+}
+
+// Has returns true if the database does contains the
+// given key.
+func (db *MetricsDB) Has(key *database.Key) (bool, error) {
+	return has(db.database, db.registry, key)
+}
+
+// Delete deletes the value for the given key. Will not
+// return an error if the key doesn't exist.
+func (db *MetricsDB) Delete(key *database.Key) error {
+	return deleteKey(db.database, db.registry, key)
+}
+
+// Cursor begins a new cursor over the given bucket.
+func (db *MetricsDB) Cursor(bucket *database.Bucket) (database.Cursor, error) {
+	return newCursor(db.database, db.registry, bucket)
+}
+
+// Begin begins a new database transaction.
+func (db *MetricsDB) Begin() (database.Transaction, error) {
+	transaction, err := db.database.Begin()
+	if err != nil {
+		return nil, err
+	}
+	return &metricsTransaction{transaction: transaction, registry: db.registry}, nil
+}
+
+// NewBatch returns a new, empty batch of writes.
+func (db *MetricsDB) NewBatch() (database.Batch, error) {
+	batch, err := db.database.NewBatch()
+	if err != nil {
+		return nil, err
+	}
+	return &metricsBatch{batch: batch, registry: db.registry}, nil
+}
+
+// Snapshot takes a snapshot of the current state of the database.
+func (db *MetricsDB) Snapshot() (database.Snapshot, error) {
+	snapshot, err := db.database.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &metricsSnapshot{snapshot: snapshot, registry: db.registry}, nil
+}
+
+// Compact compacts the database instance.
+func (db *MetricsDB) Compact() error {
+	return db.database.Compact()
+}
+
+// Stats returns the storage statistics of the database instance.
+func (db *MetricsDB) Stats() (*database.Stats, error) {
+	return db.database.Stats()
+}
+
+// Backup writes a consistent copy of the database into a new
+// database in the given path, while the database keeps being
+// used. The path must not exist.
+func (db *MetricsDB) Backup(path string) error {
+	return db.database.Backup(path)
+}
+
+// Close closes the database.
+func (db *MetricsDB) Close() error {
+	return db.database.Close()
+}
+
+func put(dataAccessor database.DataAccessor, registry *registry, key *database.Key, value []byte) error {
+	start := time.Now()
+	err := dataAccessor.Put(key, value)
+	registry.record(key.Bucket().Path(), OperationPut, keySize(key)+len(value), start)
+	return err
+}
+
+func get(dataReader database.DataReader, registry *registry, key *database.Key) ([]byte, error) {
+	start := time.Now()
+	value, err := dataReader.Get(key)
+	registry.record(key.Bucket().Path(), OperationGet, keySize(key)+len(value), start)
+	return value, err
+}
+
+func has(dataReader database.DataReader, registry *registry, key *database.Key) (bool, error) {
+	start := time.Now()
+	exists, err := dataReader.Has(key)
+	registry.record(key.Bucket().Path(), OperationHas, keySize(key), start)
+	return exists, err
+}
+
+func deleteKey(dataAccessor database.DataAccessor, registry *registry, key *database.Key) error {
+	start := time.Now()
+	err := dataAccessor.Delete(key)
+	registry.record(key.Bucket().Path(), OperationDelete, keySize(key), start)
+	return err
+}
+
+func keySize(key *database.Key) int {
+	return len(key.Bucket().Path()) + len(key.Suffix())
+}
@@ -0,0 +1,110 @@
+package metricsdb
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+)
+
+func TestMetricsDB(t *testing.T) {
+	path, err := ioutil.TempDir("", "TestMetricsDB")
+	if err != nil {
+		t.Fatalf("TempDir: %s", err)
+	}
+	defer os.RemoveAll(path)
+
+	rawDB, err := ldb.NewLevelDB(path, 8)
+	if err != nil {
+		t.Fatalf("NewLevelDB: %s", err)
+	}
+	db := New(rawDB, 2)
+	defer db.Close()
+
+	// Keys deeper than two buckets are recorded in their ancestor
+	// bucket that's two buckets deep
+	storeBucket := database.MakeBucket([]byte("prefix")).Bucket([]byte("store"))
+	nestedBucket := storeBucket.Bucket([]byte("nested"))
+	for i := byte(0); i < 3; i++ {
+		err = db.Put(nestedBucket.Key([]byte{i}), []byte("value"))
+		if err != nil {
+			t.Fatalf("Put: %s", err)
+		}
+	}
+	_, err = db.Get(storeBucket.Key([]byte{0}))
+	if !database.IsNotFoundError(err) {
+		t.Fatalf("Expected getting a missing key to return ErrNotFound, but got: %v", err)
+	}
+
+	dbTx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("Begin: %s", err)
+	}
+	err = dbTx.Delete(nestedBucket.Key([]byte{0}))
+	if err != nil {
+		t.Fatalf("Delete: %s", err)
+	}
+	err = dbTx.Commit()
+	if err != nil {
+		t.Fatalf("Commit: %s", err)
+	}
+
+	cursor, err := db.Cursor(storeBucket)
+	if err != nil {
+		t.Fatalf("Cursor: %s", err)
+	}
+	for ok := cursor.First(); ok; ok = cursor.Next() {
+		_, err = cursor.Value()
+		if err != nil {
+			t.Fatalf("Value: %s", err)
+		}
+	}
+	err = cursor.Close()
+	if err != nil {
+		t.Fatalf("Close: %s", err)
+	}
+
+	metricsByOperation := make(map[string]*OperationMetrics)
+	for _, metrics := range db.Metrics() {
+		metricsByOperation[string(metrics.Bucket)+" "+metrics.Operation] = metrics
+	}
+	storePath := string(storeBucket.Path())
+	expectedCounts := map[string]uint64{
+		storePath + " " + OperationPut:    3,
+		storePath + " " + OperationGet:    1,
+		storePath + " " + OperationDelete: 1,
+		// The cursor was moved to the two keys that
+		// remained, and then past the last of them
+		storePath + " " + OperationCursor: 3,
+		" " + OperationCommit:             1,
+	}
+	if len(metricsByOperation) != len(expectedCounts) {
+		t.Fatalf("Unexpected amount of metrics. Want: %d, got: %d", len(expectedCounts), len(metricsByOperation))
+	}
+	for name, expectedCount := range expectedCounts {
+		metrics, ok := metricsByOperation[name]
+		if !ok {
+			t.Fatalf("Missing the metrics of %s", name)
+		}
+		if metrics.Count != expectedCount {
+			t.Fatalf("Unexpected count of %s. Want: %d, got: %d", name, expectedCount, metrics.Count)
+		}
+		histogramCount := uint64(0)
+		for _, count := range metrics.LatencyHistogram {
+			histogramCount += count
+		}
+		if histogramCount != metrics.Count {
+			t.Fatalf("The latency histogram of %s counts %d operations, but there were %d",
+				name, histogramCount, metrics.Count)
+		}
+	}
+
+	// Every put wrote its key and its value
+	putBytes := uint64(3 * (len(nestedBucket.Path()) + 1 + len("value")))
+	if metricsByOperation[storePath+" "+OperationPut].Bytes != putBytes {
+		t.Fatalf("Unexpected amount of put bytes. Want: %d, got: %d",
+			putBytes, metricsByOperation[storePath+" "+OperationPut].Bytes)
+	}
+}
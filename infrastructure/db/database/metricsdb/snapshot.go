@@ -0,0 +1,28 @@
+package metricsdb
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+// metricsSnapshot wraps a snapshot of the database the MetricsDB wraps.
+// Its reads are recorded like the reads of the database itself
+type metricsSnapshot struct {
+	snapshot database.Snapshot
+	registry *registry
+}
+
+func (s *metricsSnapshot) Get(key *database.Key) ([]byte, error) {
+	return get(s.snapshot, s.registry, key)
+}
+
+func (s *metricsSnapshot) Has(key *database.Key) (bool, error) {
+	return has(s.snapshot, s.registry, key)
+}
+
+func (s *metricsSnapshot) Cursor(bucket *database.Bucket) (database.Cursor, error) {
+	return newCursor(s.snapshot, s.registry, bucket)
+}
+
+func (s *metricsSnapshot) Release() error {
+	return s.snapshot.Release()
+}
@@ -0,0 +1,50 @@
+package metricsdb
+
+import (
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+// metricsTransaction wraps a transaction of the database the MetricsDB
+// wraps. The writes of a transaction are recorded when they're added to it,
+// and the time it takes to write them is recorded when it's committed
+type metricsTransaction struct {
+	transaction database.Transaction
+	registry    *registry
+}
+
+func (tx *metricsTransaction) Put(key *database.Key, value []byte) error {
+	return put(tx.transaction, tx.registry, key, value)
+}
+
+func (tx *metricsTransaction) Get(key *database.Key) ([]byte, error) {
+	return get(tx.transaction, tx.registry, key)
+}
+
+func (tx *metricsTransaction) Has(key *database.Key) (bool, error) {
+	return has(tx.transaction, tx.registry, key)
+}
+
+func (tx *metricsTransaction) Delete(key *database.Key) error {
+	return deleteKey(tx.transaction, tx.registry, key)
+}
+
+func (tx *metricsTransaction) Cursor(bucket *database.Bucket) (database.Cursor, error) {
+	return newCursor(tx.transaction, tx.registry, bucket)
+}
+
+func (tx *metricsTransaction) Rollback() error {
+	return tx.transaction.Rollback()
+}
+
+func (tx *metricsTransaction) Commit() error {
+	start := time.Now()
+	err := tx.transaction.Commit()
+	tx.registry.record(nil, OperationCommit, 0, start)
+	return err
+}
+
+func (tx *metricsTransaction) RollbackUnlessClosed() error {
+	return tx.transaction.RollbackUnlessClosed()
+}
@@ -0,0 +1,141 @@
+package migration
+
+import (
+	"encoding/binary"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+var schemaVersionKey = database.MakeBucket([]byte("schema")).Key([]byte("version"))
+
+// Migration changes the layout of the data in the database. A migration
+// may be interrupted before the database records that it was applied, in
+// which case it's applied again, so it must be idempotent
+type Migration struct {
+	// Version is the schema version of the database once the migration
+	// is applied. Every migration has a version greater than the one
+	// of the migration before it
+	Version     uint64
+	Description string
+	Migrate     func(db database.Database) error
+}
+
+// SchemaVersion returns the schema version of the given database. Databases
+// that no migration was applied to are of schema version 0
+func SchemaVersion(dataAccessor database.DataAccessor) (uint64, error) {
+	versionBytes, err := dataAccessor.Get(schemaVersionKey)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if len(versionBytes) != 8 {
+		return 0, errors.Wrapf(database.ErrCorrupted, "the schema version is %d bytes long", len(versionBytes))
+	}
+	return binary.LittleEndian.Uint64(versionBytes), nil
+}
+
+func setSchemaVersion(dataAccessor database.DataAccessor, version uint64) error {
+	versionBytes := make([]byte, 8)
+	binary.LittleEndian.PutUint64(versionBytes, version)
+	return dataAccessor.Put(schemaVersionKey, versionBytes)
+}
+
+// LatestVersion returns the schema version of databases that
+// all the given migrations were applied to
+func LatestVersion(migrations []*Migration) uint64 {
+	if len(migrations) == 0 {
+		return 0
+	}
+	return migrations[len(migrations)-1].Version
+}
+
+// Pending returns the migrations that weren't applied to the given database yet,
+// in the order they should be applied. Databases that are empty are considered
+// to be of the latest version, and have no pending migrations
+func Pending(db database.Database, migrations []*Migration) ([]*Migration, error) {
+	err := validate(migrations)
+	if err != nil {
+		return nil, err
+	}
+
+	isEmpty, err := isEmpty(db)
+	if err != nil {
+		return nil, err
+	}
+	if isEmpty {
+		return nil, nil
+	}
+
+	schemaVersion, err := SchemaVersion(db)
+	if err != nil {
+		return nil, err
+	}
+	latestVersion := LatestVersion(migrations)
+	if schemaVersion > latestVersion {
+		return nil, errors.Errorf("the database schema version %d is newer than the latest "+
+			"schema version %d. Was the database created by a newer version of kaspad?", schemaVersion, latestVersion)
+	}
+
+	for i, migration := range migrations {
+		if migration.Version > schemaVersion {
+			return migrations[i:], nil
+		}
+	}
+	return nil, nil
+}
+
+// Run applies the migrations that weren't applied to the given database yet
+// in order, and records the schema version of the database after each of them.
+// Empty databases are only marked as being of the latest version. It returns
+// the migrations that were applied
+func Run(db database.Database, migrations []*Migration) ([]*Migration, error) {
+	isEmpty, err := isEmpty(db)
+	if err != nil {
+		return nil, err
+	}
+	pendingMigrations, err := Pending(db, migrations)
+	if err != nil {
+		return nil, err
+	}
+	if isEmpty {
+		return nil, setSchemaVersion(db, LatestVersion(migrations))
+	}
+
+	for _, migration := range pendingMigrations {
+		log.Infof("Applying database migration %d: %s", migration.Version, migration.Description)
+		err := migration.Migrate(db)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed applying database migration %d", migration.Version)
+		}
+		err = setSchemaVersion(db, migration.Version)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return pendingMigrations, nil
+}
+
+func validate(migrations []*Migration) error {
+	previousVersion := uint64(0)
+	for _, migration := range migrations {
+		if migration.Version <= previousVersion {
+			return errors.Errorf("database migration %d comes after migration %d, "+
+				"but its version isn't greater", migration.Version, previousVersion)
+		}
+		previousVersion = migration.Version
+	}
+	return nil
+}
+
+func isEmpty(db database.Database) (bool, error) {
+	cursor, err := db.Cursor(database.MakeBucket(nil))
+	if err != nil {
+		return false, err
+	}
+	defer cursor.Close()
+
+	return !cursor.First(), nil
+}
@@ -0,0 +1,194 @@
+package migration
+
+import (
+	"os"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/pkg/errors"
+)
+
+func prepareDatabaseForTest(t *testing.T, testName string) (db database.Database, teardownFunc func()) {
+	path, err := os.MkdirTemp("", testName)
+	if err != nil {
+		t.Fatalf("%s: MkdirTemp unexpectedly failed: %s", testName, err)
+	}
+	db, err = ldb.NewLevelDB(path, 8)
+	if err != nil {
+		t.Fatalf("%s: NewLevelDB unexpectedly failed: %s", testName, err)
+	}
+	return db, func() {
+		err := db.Close()
+		if err != nil {
+			t.Fatalf("%s: Close unexpectedly failed: %s", testName, err)
+		}
+		err = os.RemoveAll(path)
+		if err != nil {
+			t.Fatalf("%s: RemoveAll unexpectedly failed: %s", testName, err)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	db, teardownFunc := prepareDatabaseForTest(t, "TestRun")
+	defer teardownFunc()
+
+	var appliedVersions []uint64
+	newMigration := func(version uint64, migrateErr error) *Migration {
+		return &Migration{
+			Version:     version,
+			Description: "test migration",
+			Migrate: func(db database.Database) error {
+				if migrateErr != nil {
+					return migrateErr
+				}
+				appliedVersions = append(appliedVersions, version)
+				return nil
+			},
+		}
+	}
+	migrations := []*Migration{newMigration(1, nil), newMigration(2, nil)}
+
+	// Empty databases are marked as being of the latest version without applying any migration
+	appliedMigrations, err := Run(db, migrations)
+	if err != nil {
+		t.Fatalf("Run: %s", err)
+	}
+	if len(appliedMigrations) != 0 || len(appliedVersions) != 0 {
+		t.Fatalf("Expected no migration to be applied to an empty database, but got: %v", appliedVersions)
+	}
+	schemaVersion, err := SchemaVersion(db)
+	if err != nil {
+		t.Fatalf("SchemaVersion: %s", err)
+	}
+	if schemaVersion != 2 {
+		t.Fatalf("Unexpected schema version. Want: %d, got: %d", 2, schemaVersion)
+	}
+
+	// Newly added migrations are applied in order, and only once
+	migrations = append(migrations, newMigration(3, nil), newMigration(5, nil))
+	pendingMigrations, err := Pending(db, migrations)
+	if err != nil {
+		t.Fatalf("Pending: %s", err)
+	}
+	if len(pendingMigrations) != 2 {
+		t.Fatalf("Unexpected amount of pending migrations. Want: %d, got: %d", 2, len(pendingMigrations))
+	}
+	for i := 0; i < 2; i++ {
+		_, err = Run(db, migrations)
+		if err != nil {
+			t.Fatalf("Run: %s", err)
+		}
+	}
+	if len(appliedVersions) != 2 || appliedVersions[0] != 3 || appliedVersions[1] != 5 {
+		t.Fatalf("Unexpected applied migrations. Want: [3 5], got: %v", appliedVersions)
+	}
+
+	// A failed migration is applied again by the next run
+	migrations = append(migrations, newMigration(6, errors.New("test error")), newMigration(7, nil))
+	_, err = Run(db, migrations)
+	if err == nil {
+		t.Fatalf("Expected Run to fail when a migration fails")
+	}
+	schemaVersion, err = SchemaVersion(db)
+	if err != nil {
+		t.Fatalf("SchemaVersion: %s", err)
+	}
+	if schemaVersion != 5 {
+		t.Fatalf("Unexpected schema version after a failed migration. Want: %d, got: %d", 5, schemaVersion)
+	}
+	migrations[4] = newMigration(6, nil)
+	appliedMigrations, err = Run(db, migrations)
+	if err != nil {
+		t.Fatalf("Run: %s", err)
+	}
+	if len(appliedMigrations) != 2 {
+		t.Fatalf("Unexpected amount of applied migrations. Want: %d, got: %d", 2, len(appliedMigrations))
+	}
+
+	// Databases of a newer schema version than the latest migration are rejected
+	_, err = Run(db, migrations[:2])
+	if err == nil {
+		t.Fatalf("Expected Run to fail for a database of a newer schema version")
+	}
+}
+
+func TestRunUnorderedMigrations(t *testing.T) {
+	db, teardownFunc := prepareDatabaseForTest(t, "TestRunUnorderedMigrations")
+	defer teardownFunc()
+
+	migrate := func(database.Database) error { return nil }
+	migrations := []*Migration{
+		{Version: 2, Description: "second", Migrate: migrate},
+		{Version: 1, Description: "first", Migrate: migrate},
+	}
+	_, err := Run(db, migrations)
+	if err == nil {
+		t.Fatalf("Expected Run to fail for migrations that aren't ordered by version")
+	}
+}
+
+func TestMoveBucket(t *testing.T) {
+	db, teardownFunc := prepareDatabaseForTest(t, "TestMoveBucket")
+	defer teardownFunc()
+
+	source := database.MakeBucket([]byte("source"))
+	destination := database.MakeBucket([]byte("destination")).Bucket([]byte("inner"))
+	const entryCount = 25
+	for i := 0; i < entryCount; i++ {
+		err := db.Put(source.Key([]byte{byte(i)}), []byte{byte(i), 1})
+		if err != nil {
+			t.Fatalf("Put: %s", err)
+		}
+	}
+	sourceKey := database.MakeBucket(nil).Key([]byte("source-key"))
+	destinationKey := destination.Key([]byte("key"))
+	err := db.Put(sourceKey, []byte("value"))
+	if err != nil {
+		t.Fatalf("Put: %s", err)
+	}
+
+	// Moving again, as an interrupted migration would, finds nothing left to move
+	for i := 0; i < 2; i++ {
+		movedCount, err := MoveBucket(db, source, destination)
+		if err != nil {
+			t.Fatalf("MoveBucket: %s", err)
+		}
+		expectedMovedCount := entryCount
+		if i > 0 {
+			expectedMovedCount = 0
+		}
+		if movedCount != expectedMovedCount {
+			t.Fatalf("Unexpected moved count. Want: %d, got: %d", expectedMovedCount, movedCount)
+		}
+		err = MoveKey(db, sourceKey, destinationKey)
+		if err != nil {
+			t.Fatalf("MoveKey: %s", err)
+		}
+	}
+
+	for i := 0; i < entryCount; i++ {
+		value, err := db.Get(destination.Key([]byte{byte(i)}))
+		if err != nil {
+			t.Fatalf("Get: %s", err)
+		}
+		if len(value) != 2 || value[0] != byte(i) {
+			t.Fatalf("Unexpected value of moved key %d: %x", i, value)
+		}
+		hasSourceKey, err := db.Has(source.Key([]byte{byte(i)}))
+		if err != nil {
+			t.Fatalf("Has: %s", err)
+		}
+		if hasSourceKey {
+			t.Fatalf("Key %d wasn't deleted from the source bucket", i)
+		}
+	}
+	value, err := db.Get(destinationKey)
+	if err != nil {
+		t.Fatalf("Get: %s", err)
+	}
+	if string(value) != "value" {
+		t.Fatalf("Unexpected value of the moved key: %s", value)
+	}
+}
@@ -0,0 +1,99 @@
+package migration
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+// moveBatchSize is the amount of entries moved in every database transaction
+const moveBatchSize = 10_000
+
+// MoveBucket moves all the entries of the source bucket into the destination
+// bucket, keeping their keys relative to the bucket. Every entry is put in the
+// destination bucket and deleted from the source bucket in the same transaction,
+// so a move that's interrupted may be resumed by moving the bucket again. It
+// returns the amount of entries that were moved
+func MoveBucket(db database.Database, source *database.Bucket, destination *database.Bucket) (int, error) {
+	cursor, err := db.Cursor(source)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close()
+
+	dbTx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = dbTx.RollbackUnlessClosed()
+	}()
+
+	movedCount := 0
+	for ok := cursor.First(); ok; ok = cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return movedCount, err
+		}
+		value, err := cursor.Value()
+		if err != nil {
+			return movedCount, err
+		}
+		// The cursor may change the key and value once it's moved, while
+		// transactions may keep what they're given until they're committed
+		suffix := copyBytes(key.Suffix())
+		err = dbTx.Put(destination.Key(suffix), copyBytes(value))
+		if err != nil {
+			return movedCount, err
+		}
+		err = dbTx.Delete(source.Key(suffix))
+		if err != nil {
+			return movedCount, err
+		}
+		movedCount++
+
+		if movedCount%moveBatchSize == 0 {
+			err = dbTx.Commit()
+			if err != nil {
+				return movedCount, err
+			}
+			dbTx, err = db.Begin()
+			if err != nil {
+				return movedCount, err
+			}
+		}
+	}
+	return movedCount, dbTx.Commit()
+}
+
+// MoveKey moves the value of the source key into the destination key. Keys
+// that don't exist, such as ones that were already moved, are skipped
+func MoveKey(db database.Database, source *database.Key, destination *database.Key) error {
+	value, err := db.Get(source)
+	if err != nil {
+		if database.IsNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+
+	dbTx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer dbTx.RollbackUnlessClosed()
+
+	err = dbTx.Put(destination, value)
+	if err != nil {
+		return err
+	}
+	err = dbTx.Delete(source)
+	if err != nil {
+		return err
+	}
+	return dbTx.Commit()
+}
+
+func copyBytes(bytes []byte) []byte {
+	bytesCopy := make([]byte, len(bytes))
+	copy(bytesCopy, bytes)
+	return bytesCopy
+}
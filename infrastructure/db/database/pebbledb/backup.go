@@ -0,0 +1,14 @@
+package pebbledb
+
+import (
+	"github.com/cockroachdb/pebble"
+	"github.com/pkg/errors"
+)
+
+// Backup writes a checkpoint of the pebble instance into the given
+// path. The table files of the checkpoint are hard links to the
+// files of the database where possible.
+func (db *PebbleDB) Backup(path string) error {
+	err := db.db.Checkpoint(path, pebble.WithFlushedWAL())
+	return errors.WithStack(err)
+}
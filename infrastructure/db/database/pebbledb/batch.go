@@ -0,0 +1,78 @@
+package pebbledb
+
+import (
+	"github.com/cockroachdb/pebble"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+// PebbleDBBatch is a thin wrapper around native pebble batches.
+type PebbleDBBatch struct {
+	db       *PebbleDB
+	batch    *pebble.Batch
+	isClosed bool
+}
+
+// NewBatch returns a new, empty batch of writes.
+func (db *PebbleDB) NewBatch() (database.Batch, error) {
+	return &PebbleDBBatch{
+		db:    db,
+		batch: db.db.NewBatch(),
+	}, nil
+}
+
+// Put sets the value for the given key once the batch is
+// committed. It overwrites any previous value for that key.
+func (b *PebbleDBBatch) Put(key *database.Key, value []byte) error {
+	if b.isClosed {
+		return errors.New("cannot put into a closed batch")
+	}
+
+	return errors.WithStack(b.batch.Set(key.Bytes(), value, nil))
+}
+
+// Delete deletes the value for the given key once the batch
+// is committed. Will not return an error if the key doesn't exist.
+func (b *PebbleDBBatch) Delete(key *database.Key) error {
+	if b.isClosed {
+		return errors.New("cannot delete from a closed batch")
+	}
+
+	return errors.WithStack(b.batch.Delete(key.Bytes(), nil))
+}
+
+// Len returns the amount of writes in the batch.
+func (b *PebbleDBBatch) Len() int {
+	return int(b.batch.Count())
+}
+
+// Commit writes the batch atomically, and syncs it to disk if sync is set.
+func (b *PebbleDBBatch) Commit(sync bool) error {
+	if b.isClosed {
+		return errors.New("cannot commit a closed batch")
+	}
+
+	b.isClosed = true
+	writeOptions := b.db.writeOptions
+	if sync {
+		writeOptions = pebble.Sync
+	}
+	err := b.batch.Commit(writeOptions)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if !sync {
+		b.db.wrote()
+	}
+	return errors.WithStack(b.batch.Close())
+}
+
+// Discard drops the batch without writing it.
+func (b *PebbleDBBatch) Discard() error {
+	if b.isClosed {
+		return nil
+	}
+
+	b.isClosed = true
+	return errors.WithStack(b.batch.Close())
+}
@@ -0,0 +1,155 @@
+package pebbledb
+
+import (
+	"bytes"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+// PebbleDBCursor is a thin wrapper around native pebble iterators.
+type PebbleDBCursor struct {
+	db             *PebbleDB
+	pebbleIterator *pebble.Iterator
+	bucket         *database.Bucket
+
+	// isPositioned is false until the iterator is first moved. Unlike
+	// leveldb iterators, pebble iterators must be positioned before
+	// Next is called
+	isPositioned bool
+	isClosed     bool
+}
+
+// Cursor begins a new cursor over the given prefix.
+func (db *PebbleDB) Cursor(bucket *database.Bucket) (database.Cursor, error) {
+	return db.newCursor(db.db, bucket)
+}
+
+// newCursor begins a new cursor over the given prefix of the given reader,
+// which is either the database or one of its snapshots
+func (db *PebbleDB) newCursor(reader pebble.Reader, bucket *database.Bucket) (database.Cursor, error) {
+	pebbleIterator := reader.NewIter(&pebble.IterOptions{
+		LowerBound: bucket.Path(),
+		UpperBound: prefixUpperBound(bucket.Path()),
+	})
+
+	cursor := &PebbleDBCursor{
+		db:             db,
+		pebbleIterator: pebbleIterator,
+		bucket:         bucket,
+		isClosed:       false,
+	}
+
+	db.openCursorsLock.Lock()
+	defer db.openCursorsLock.Unlock()
+	db.openCursors[cursor] = struct{}{}
+
+	return cursor, nil
+}
+
+// prefixUpperBound returns the smallest key that's greater than all the keys with
+// the given prefix, or nil if there's no such key
+func prefixUpperBound(prefix []byte) []byte {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xff {
+			upperBound := make([]byte, i+1)
+			copy(upperBound, prefix)
+			upperBound[i]++
+			return upperBound
+		}
+	}
+	return nil
+}
+
+// Next moves the iterator to the next key/value pair. It returns whether the
+// iterator is exhausted. Panics if the cursor is closed.
+func (c *PebbleDBCursor) Next() bool {
+	if c.isClosed {
+		panic("cannot call next on a closed cursor")
+	}
+	if !c.isPositioned {
+		return c.First()
+	}
+	return c.pebbleIterator.Next()
+}
+
+// First moves the iterator to the first key/value pair. It returns false if
+// such a pair does not exist. Panics if the cursor is closed.
+func (c *PebbleDBCursor) First() bool {
+	if c.isClosed {
+		panic("cannot call first on a closed cursor")
+	}
+	c.isPositioned = true
+	return c.pebbleIterator.First()
+}
+
+// Seek moves the iterator to the first key/value pair whose key is greater
+// than or equal to the given key. It returns ErrNotFound if such pair does not
+// exist.
+func (c *PebbleDBCursor) Seek(key *database.Key) error {
+	if c.isClosed {
+		return errors.New("cannot seek a closed cursor")
+	}
+
+	c.isPositioned = true
+	found := c.pebbleIterator.SeekGE(key.Bytes())
+	if !found {
+		return errors.Wrapf(database.ErrNotFound, "key %s not found", key)
+	}
+
+	// Use c.pebbleIterator.Key because c.Key removes the prefix from the key
+	currentKey := c.pebbleIterator.Key()
+	if !bytes.Equal(currentKey, key.Bytes()) {
+		return errors.Wrapf(database.ErrNotFound, "key %s not found", key)
+	}
+
+	return nil
+}
+
+// Key returns the key of the current key/value pair, or ErrNotFound if done.
+// Note that the key is trimmed to not include the prefix the cursor was opened
+// with. The caller should not modify the contents of the returned slice, and
+// its contents may change on the next call to Next.
+func (c *PebbleDBCursor) Key() (*database.Key, error) {
+	if c.isClosed {
+		return nil, errors.New("cannot get the key of a closed cursor")
+	}
+	if !c.isPositioned || !c.pebbleIterator.Valid() {
+		return nil, errors.Wrapf(database.ErrNotFound, "cannot get the "+
+			"key of an exhausted cursor")
+	}
+	suffix := bytes.TrimPrefix(c.pebbleIterator.Key(), c.bucket.Path())
+	return c.bucket.Key(suffix), nil
+}
+
+// Value returns the value of the current key/value pair, or ErrNotFound if done.
+// The caller should not modify the contents of the returned slice, and its
+// contents may change on the next call to Next.
+func (c *PebbleDBCursor) Value() ([]byte, error) {
+	if c.isClosed {
+		return nil, errors.New("cannot get the value of a closed cursor")
+	}
+	if !c.isPositioned || !c.pebbleIterator.Valid() {
+		return nil, errors.Wrapf(database.ErrNotFound, "cannot get the "+
+			"value of an exhausted cursor")
+	}
+	return c.pebbleIterator.Value(), nil
+}
+
+// Close releases associated resources.
+func (c *PebbleDBCursor) Close() error {
+	if c.isClosed {
+		return errors.New("cannot close an already closed cursor")
+	}
+	c.isClosed = true
+
+	c.db.openCursorsLock.Lock()
+	delete(c.db.openCursors, c)
+	c.db.openCursorsLock.Unlock()
+
+	err := c.pebbleIterator.Close()
+	c.pebbleIterator = nil
+	c.bucket = nil
+	return errors.WithStack(err)
+}
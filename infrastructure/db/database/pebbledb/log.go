@@ -0,0 +1,22 @@
+package pebbledb
+
+import (
+	"fmt"
+
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+var log = logger.RegisterSubSystem("KSDB")
+
+// pebbleLogger writes the log messages of pebble to the KSDB subsystem
+type pebbleLogger struct{}
+
+func (pebbleLogger) Infof(format string, args ...interface{}) {
+	log.Debugf(format, args...)
+}
+
+func (pebbleLogger) Fatalf(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	log.Criticalf("Pebble failed: %s", message)
+	panic(message)
+}
@@ -0,0 +1,11 @@
+package pebbledb
+
+import "github.com/cockroachdb/pebble"
+
+// Options is a function that returns a pebble
+// Options struct for opening a database.
+func Options() *pebble.Options {
+	return &pebble.Options{
+		Logger: pebbleLogger{},
+	}
+}
@@ -0,0 +1,269 @@
+package pebbledb
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+const mebibyte = 1 << 20
+
+// PebbleDB defines a thin wrapper around pebble.
+type PebbleDB struct {
+	db *pebble.DB
+
+	// openCursors are closed when the database is closed, since
+	// pebble, unlike leveldb, fails to close while iterators are open
+	openCursorsLock sync.Mutex
+	openCursors     map[*PebbleDBCursor]struct{}
+
+	// openSnapshots are released when the database is closed, for
+	// the same reason
+	openSnapshotsLock sync.Mutex
+	openSnapshots     map[*PebbleDBSnapshot]struct{}
+
+	// writeOptions are the options of all the writes, which
+	// sync them when the durability is full
+	writeOptions *pebble.WriteOptions
+
+	// batchSyncer is nil unless the durability is batch
+	batchSyncer *database.BatchSyncer
+}
+
+// NewPebbleDB opens a pebble instance defined by the given path.
+// The instance never syncs its writes to disk.
+func NewPebbleDB(path string, cacheSizeMiB int) (*PebbleDB, error) {
+	return NewPebbleDBWithDurability(path, cacheSizeMiB, database.DurabilityNone, 0)
+}
+
+// NewPebbleDBWithDurability opens a pebble instance defined by the given path,
+// which syncs its writes to disk according to the given durability. The sync
+// interval is only used when the durability is batch.
+func NewPebbleDBWithDurability(path string, cacheSizeMiB int,
+	durability database.Durability, syncInterval time.Duration) (*PebbleDB, error) {
+
+	// Open pebble. If it doesn't exist, create it.
+	cache := pebble.NewCache(int64(cacheSizeMiB) * mebibyte)
+	defer cache.Unref()
+
+	options := Options()
+	options.Cache = cache
+	options.MemTableSize = cacheSizeMiB * mebibyte / 2
+	db, err := pebble.Open(path, options)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	pebbleDB := &PebbleDB{
+		db:            db,
+		openCursors:   make(map[*PebbleDBCursor]struct{}),
+		openSnapshots: make(map[*PebbleDBSnapshot]struct{}),
+		writeOptions:  pebble.NoSync,
+	}
+	switch durability {
+	case database.DurabilityFull:
+		pebbleDB.writeOptions = pebble.Sync
+	case database.DurabilityBatch:
+		pebbleDB.batchSyncer = database.NewBatchSyncer(pebbleDB.sync, syncInterval)
+	}
+	return pebbleDB, nil
+}
+
+// NewPebbleDBReadOnly opens the existing pebble instance defined by the given
+// path for reading only. Every write to the instance fails.
+func NewPebbleDBReadOnly(path string, cacheSizeMiB int) (*PebbleDB, error) {
+	cache := pebble.NewCache(int64(cacheSizeMiB) * mebibyte)
+	defer cache.Unref()
+
+	options := Options()
+	options.Cache = cache
+	options.ReadOnly = true
+	options.ErrorIfNotExists = true
+	db, err := pebble.Open(path, options)
+	if err != nil {
+		if pebble.IsCorruptionError(err) {
+			return nil, errors.Wrapf(database.ErrCorrupted, "the database in %s is corrupted: %s", path, err)
+		}
+		return nil, errors.WithStack(err)
+	}
+	return &PebbleDB{
+		db:            db,
+		openCursors:   make(map[*PebbleDBCursor]struct{}),
+		openSnapshots: make(map[*PebbleDBSnapshot]struct{}),
+		writeOptions:  pebble.NoSync,
+	}, nil
+}
+
+// VerifyChecksums reads every entry of the pebble instance, verifying the
+// checksums of the blocks they're stored in as well as the order of the
+// entries in and across its levels. It returns ErrCorrupted when either is
+// wrong.
+func (db *PebbleDB) VerifyChecksums() error {
+	err := db.db.CheckLevels(nil)
+	if err != nil {
+		if pebble.IsCorruptionError(err) {
+			return errors.Wrapf(database.ErrCorrupted, "%s", err)
+		}
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// sync syncs the write-ahead log, and with it all the writes before it
+func (db *PebbleDB) sync() {
+	err := db.db.LogData(nil, pebble.Sync)
+	if err != nil {
+		log.Errorf("Failed syncing the database: %s", err)
+	}
+}
+
+// wrote is called after every write to the database
+func (db *PebbleDB) wrote() {
+	if db.batchSyncer != nil {
+		db.batchSyncer.MarkDirty()
+	}
+}
+
+// Compact compacts the pebble instance.
+func (db *PebbleDB) Compact() error {
+	iterator := db.db.NewIter(nil)
+	if !iterator.Last() {
+		return errors.WithStack(iterator.Close())
+	}
+	// The end of the range is exclusive, so it's set right after the last key
+	lastKey := iterator.Key()
+	end := make([]byte, len(lastKey)+1)
+	copy(end, lastKey)
+	err := iterator.Close()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = db.db.Compact(nil, end, true)
+	return errors.WithStack(err)
+}
+
+// Close closes the pebble instance.
+func (db *PebbleDB) Close() error {
+	db.openCursorsLock.Lock()
+	openCursors := make([]*PebbleDBCursor, 0, len(db.openCursors))
+	for cursor := range db.openCursors {
+		openCursors = append(openCursors, cursor)
+	}
+	db.openCursorsLock.Unlock()
+
+	if len(openCursors) > 0 {
+		log.Debugf("Closing %d cursors that were left open", len(openCursors))
+	}
+	for _, cursor := range openCursors {
+		err := cursor.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Snapshots are released only after the cursors, since
+	// some of the cursors may have been opened from them
+	db.openSnapshotsLock.Lock()
+	openSnapshots := make([]*PebbleDBSnapshot, 0, len(db.openSnapshots))
+	for snapshot := range db.openSnapshots {
+		openSnapshots = append(openSnapshots, snapshot)
+	}
+	db.openSnapshotsLock.Unlock()
+
+	if len(openSnapshots) > 0 {
+		log.Debugf("Releasing %d snapshots that were left open", len(openSnapshots))
+	}
+	for _, snapshot := range openSnapshots {
+		err := snapshot.Release()
+		if err != nil {
+			return err
+		}
+	}
+
+	if db.batchSyncer != nil {
+		db.batchSyncer.Stop()
+	}
+	err := db.db.Close()
+	return errors.WithStack(err)
+}
+
+// Put sets the value for the given key. It overwrites
+// any previous value for that key.
+func (db *PebbleDB) Put(key *database.Key, value []byte) error {
+	err := db.db.Set(key.Bytes(), value, db.writeOptions)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	db.wrote()
+	return nil
+}
+
+// Get gets the value for the given key. It returns
+// ErrNotFound if the given key does not exist.
+func (db *PebbleDB) Get(key *database.Key) ([]byte, error) {
+	return get(db.db, key)
+}
+
+func get(reader pebble.Reader, key *database.Key) ([]byte, error) {
+	data, closer, err := reader.Get(key.Bytes())
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, errors.Wrapf(database.ErrNotFound,
+				"key %s not found", key)
+		}
+		return nil, wrapReadError(err, key)
+	}
+	// The data is only valid until closer is closed
+	dataCopy := make([]byte, len(data))
+	copy(dataCopy, data)
+	err = closer.Close()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return dataCopy, nil
+}
+
+// Has returns true if the database does contains the
+// given key.
+func (db *PebbleDB) Has(key *database.Key) (bool, error) {
+	return has(db.db, key)
+}
+
+func has(reader pebble.Reader, key *database.Key) (bool, error) {
+	_, closer, err := reader.Get(key.Bytes())
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return false, nil
+		}
+		return false, wrapReadError(err, key)
+	}
+	err = closer.Close()
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return true, nil
+}
+
+// Delete deletes the value for the given key. Will not
+// return an error if the key doesn't exist.
+func (db *PebbleDB) Delete(key *database.Key) error {
+	err := db.db.Delete(key.Bytes(), db.writeOptions)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	db.wrote()
+	return nil
+}
+
+// wrapReadError wraps errors that pebble returned while reading the
+// given key, such that corruption is reported as ErrCorrupted
+func wrapReadError(err error, key *database.Key) error {
+	if pebble.IsCorruptionError(err) {
+		return errors.Wrapf(database.ErrCorrupted, "key %s is corrupted: %s", key, err)
+	}
+	return errors.WithStack(err)
+}
@@ -0,0 +1,276 @@
+package pebbledb
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+func prepareDatabaseForTest(t *testing.T, testName string) (db *PebbleDB, teardownFunc func()) {
+	// Create a temp db to run tests against
+	path, err := ioutil.TempDir("", testName)
+	if err != nil {
+		t.Fatalf("%s: TempDir unexpectedly "+
+			"failed: %s", testName, err)
+	}
+	db, err = NewPebbleDB(path, 8)
+	if err != nil {
+		t.Fatalf("%s: NewPebbleDB unexpectedly "+
+			"failed: %s", testName, err)
+	}
+	teardownFunc = func() {
+		err = db.Close()
+		if err != nil {
+			t.Fatalf("%s: Close unexpectedly "+
+				"failed: %s", testName, err)
+		}
+	}
+	return db, teardownFunc
+}
+
+func TestPrefixUpperBound(t *testing.T) {
+	tests := []struct {
+		prefix   []byte
+		expected []byte
+	}{
+		{prefix: []byte{1, 2, 3}, expected: []byte{1, 2, 4}},
+		{prefix: []byte{1, 2, 0xff}, expected: []byte{1, 3}},
+		{prefix: []byte{0xff, 0xff}, expected: nil},
+		{prefix: []byte{}, expected: nil},
+	}
+	for _, test := range tests {
+		upperBound := prefixUpperBound(test.prefix)
+		if !bytes.Equal(upperBound, test.expected) {
+			t.Errorf("TestPrefixUpperBound: unexpected upper bound of %x. Want: %x, got: %x",
+				test.prefix, test.expected, upperBound)
+		}
+	}
+}
+
+func TestPebbleDBCloseWithOpenCursors(t *testing.T) {
+	db, teardownFunc := prepareDatabaseForTest(t, "TestPebbleDBCloseWithOpenCursors")
+
+	bucket := database.MakeBucket([]byte("bucket"))
+	err := db.Put(bucket.Key([]byte("key")), []byte("value"))
+	if err != nil {
+		t.Fatalf("TestPebbleDBCloseWithOpenCursors: Put "+
+			"unexpectedly failed: %s", err)
+	}
+	_, err = db.Cursor(bucket)
+	if err != nil {
+		t.Fatalf("TestPebbleDBCloseWithOpenCursors: Cursor "+
+			"unexpectedly failed: %s", err)
+	}
+
+	// Closing the database closes the cursor that was left open
+	teardownFunc()
+}
+
+// createCorruptedDatabase creates a database with a single key in the given
+// path, and corrupts the table file the key was written into
+func createCorruptedDatabase(t *testing.T, testName string, path string) *database.Key {
+	db, err := NewPebbleDB(path, 8)
+	if err != nil {
+		t.Fatalf("%s: NewPebbleDB unexpectedly "+
+			"failed: %s", testName, err)
+	}
+	key := database.MakeBucket(nil).Key([]byte("key"))
+	err = db.Put(key, bytes.Repeat([]byte("value"), 1000))
+	if err != nil {
+		t.Fatalf("%s: Put unexpectedly "+
+			"failed: %s", testName, err)
+	}
+	// Compact the database so that the value is written into a table file
+	err = db.Compact()
+	if err != nil {
+		t.Fatalf("%s: Compact unexpectedly "+
+			"failed: %s", testName, err)
+	}
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("%s: Close unexpectedly "+
+			"failed: %s", testName, err)
+	}
+
+	tableFilePaths, err := filepath.Glob(filepath.Join(path, "*.sst"))
+	if err != nil || len(tableFilePaths) == 0 {
+		t.Fatalf("%s: no table files were found: %v", testName, err)
+	}
+	for _, tableFilePath := range tableFilePaths {
+		tableBytes, err := ioutil.ReadFile(tableFilePath)
+		if err != nil {
+			t.Fatalf("%s: ReadFile unexpectedly "+
+				"failed: %s", testName, err)
+		}
+		for i := 0; i < len(tableBytes)/2; i++ {
+			tableBytes[i] ^= 0xff
+		}
+		err = ioutil.WriteFile(tableFilePath, tableBytes, 0600)
+		if err != nil {
+			t.Fatalf("%s: WriteFile unexpectedly "+
+				"failed: %s", testName, err)
+		}
+	}
+	return key
+}
+
+func TestPebbleDBCorruption(t *testing.T) {
+	path, err := ioutil.TempDir("", "TestPebbleDBCorruption")
+	if err != nil {
+		t.Fatalf("TestPebbleDBCorruption: TempDir unexpectedly "+
+			"failed: %s", err)
+	}
+	key := createCorruptedDatabase(t, "TestPebbleDBCorruption", path)
+
+	db, err := NewPebbleDB(path, 8)
+	if err != nil {
+		t.Fatalf("TestPebbleDBCorruption: NewPebbleDB unexpectedly "+
+			"failed: %s", err)
+	}
+	defer db.Close()
+	_, err = db.Get(key)
+	if !database.IsCorruptedError(err) {
+		t.Fatalf("TestPebbleDBCorruption: expected Get to return ErrCorrupted, but got: %v", err)
+	}
+}
+
+func TestPebbleDBReadOnly(t *testing.T) {
+	path := t.TempDir()
+	_, err := NewPebbleDBReadOnly(filepath.Join(path, "missing"), 8)
+	if err == nil {
+		t.Fatalf("TestPebbleDBReadOnly: expected opening a missing database to fail")
+	}
+
+	db, err := NewPebbleDB(path, 8)
+	if err != nil {
+		t.Fatalf("TestPebbleDBReadOnly: NewPebbleDB unexpectedly "+
+			"failed: %s", err)
+	}
+	key := database.MakeBucket(nil).Key([]byte("key"))
+	err = db.Put(key, []byte("value"))
+	if err != nil {
+		t.Fatalf("TestPebbleDBReadOnly: Put unexpectedly "+
+			"failed: %s", err)
+	}
+	err = db.Close()
+	if err != nil {
+		t.Fatalf("TestPebbleDBReadOnly: Close unexpectedly "+
+			"failed: %s", err)
+	}
+
+	db, err = NewPebbleDBReadOnly(path, 8)
+	if err != nil {
+		t.Fatalf("TestPebbleDBReadOnly: NewPebbleDBReadOnly unexpectedly "+
+			"failed: %s", err)
+	}
+	defer db.Close()
+	value, err := db.Get(key)
+	if err != nil {
+		t.Fatalf("TestPebbleDBReadOnly: Get unexpectedly "+
+			"failed: %s", err)
+	}
+	if !bytes.Equal(value, []byte("value")) {
+		t.Fatalf("TestPebbleDBReadOnly: unexpected value %s", value)
+	}
+	err = db.Put(key, []byte("other value"))
+	if err == nil {
+		t.Fatalf("TestPebbleDBReadOnly: expected Put to fail")
+	}
+}
+
+func TestPebbleDBVerifyChecksums(t *testing.T) {
+	db, teardownFunc := prepareDatabaseForTest(t, "TestPebbleDBVerifyChecksums")
+	for i := 0; i < 100; i++ {
+		err := db.Put(database.MakeBucket(nil).Key([]byte{byte(i)}), bytes.Repeat([]byte{byte(i)}, 1000))
+		if err != nil {
+			t.Fatalf("TestPebbleDBVerifyChecksums: Put unexpectedly "+
+				"failed: %s", err)
+		}
+	}
+	err := db.Compact()
+	if err != nil {
+		t.Fatalf("TestPebbleDBVerifyChecksums: Compact unexpectedly "+
+			"failed: %s", err)
+	}
+	err = db.VerifyChecksums()
+	if err != nil {
+		t.Fatalf("TestPebbleDBVerifyChecksums: VerifyChecksums unexpectedly "+
+			"failed: %s", err)
+	}
+	teardownFunc()
+
+	path := t.TempDir()
+	createCorruptedDatabase(t, "TestPebbleDBVerifyChecksums", path)
+	db, err = NewPebbleDBReadOnly(path, 8)
+	if err != nil {
+		if database.IsCorruptedError(err) {
+			return
+		}
+		t.Fatalf("TestPebbleDBVerifyChecksums: NewPebbleDBReadOnly unexpectedly "+
+			"failed: %s", err)
+	}
+	defer db.Close()
+	err = db.VerifyChecksums()
+	if !database.IsCorruptedError(err) {
+		t.Fatalf("TestPebbleDBVerifyChecksums: expected VerifyChecksums to return ErrCorrupted, but got: %v", err)
+	}
+}
+
+func TestPebbleDBBackup(t *testing.T) {
+	db, teardownFunc := prepareDatabaseForTest(t, "TestPebbleDBBackup")
+	defer teardownFunc()
+
+	bucket := database.MakeBucket([]byte("bucket"))
+	for i := 0; i < 100; i++ {
+		err := db.Put(bucket.Key([]byte{byte(i)}), []byte{byte(i)})
+		if err != nil {
+			t.Fatalf("TestPebbleDBBackup: Put unexpectedly "+
+				"failed: %s", err)
+		}
+	}
+
+	backupPath := filepath.Join(t.TempDir(), "backup")
+	err := db.Backup(backupPath)
+	if err != nil {
+		t.Fatalf("TestPebbleDBBackup: Backup unexpectedly "+
+			"failed: %s", err)
+	}
+	// Writes after the backup was taken don't change it
+	err = db.Put(bucket.Key([]byte{100}), []byte{100})
+	if err != nil {
+		t.Fatalf("TestPebbleDBBackup: Put unexpectedly "+
+			"failed: %s", err)
+	}
+	err = db.Backup(backupPath)
+	if err == nil {
+		t.Fatalf("TestPebbleDBBackup: expected backing up into an existing path to fail")
+	}
+
+	backup, err := NewPebbleDB(backupPath, 8)
+	if err != nil {
+		t.Fatalf("TestPebbleDBBackup: NewPebbleDB unexpectedly "+
+			"failed: %s", err)
+	}
+	defer backup.Close()
+	for i := 0; i < 100; i++ {
+		value, err := backup.Get(bucket.Key([]byte{byte(i)}))
+		if err != nil {
+			t.Fatalf("TestPebbleDBBackup: Get unexpectedly "+
+				"failed: %s", err)
+		}
+		if !bytes.Equal(value, []byte{byte(i)}) {
+			t.Fatalf("TestPebbleDBBackup: unexpected value. Want: %x, got: %x", []byte{byte(i)}, value)
+		}
+	}
+	has, err := backup.Has(bucket.Key([]byte{100}))
+	if err != nil {
+		t.Fatalf("TestPebbleDBBackup: Has unexpectedly "+
+			"failed: %s", err)
+	}
+	if has {
+		t.Fatalf("TestPebbleDBBackup: the backup contains a key that was put after it was taken")
+	}
+}
@@ -0,0 +1,70 @@
+package pebbledb
+
+import (
+	"github.com/cockroachdb/pebble"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+)
+
+// PebbleDBSnapshot is a thin wrapper around native pebble snapshots.
+type PebbleDBSnapshot struct {
+	db         *PebbleDB
+	snapshot   *pebble.Snapshot
+	isReleased bool
+}
+
+// Snapshot takes a snapshot of the current state of the database.
+func (db *PebbleDB) Snapshot() (database.Snapshot, error) {
+	snapshot := &PebbleDBSnapshot{
+		db:       db,
+		snapshot: db.db.NewSnapshot(),
+	}
+
+	db.openSnapshotsLock.Lock()
+	defer db.openSnapshotsLock.Unlock()
+	db.openSnapshots[snapshot] = struct{}{}
+
+	return snapshot, nil
+}
+
+// Get gets the value for the given key. It returns
+// ErrNotFound if the given key does not exist.
+func (s *PebbleDBSnapshot) Get(key *database.Key) ([]byte, error) {
+	if s.isReleased {
+		return nil, errors.New("cannot get from a released snapshot")
+	}
+	return get(s.snapshot, key)
+}
+
+// Has returns true if the database does contains the
+// given key.
+func (s *PebbleDBSnapshot) Has(key *database.Key) (bool, error) {
+	if s.isReleased {
+		return false, errors.New("cannot has from a released snapshot")
+	}
+	return has(s.snapshot, key)
+}
+
+// Cursor begins a new cursor over the given bucket.
+func (s *PebbleDBSnapshot) Cursor(bucket *database.Bucket) (database.Cursor, error) {
+	if s.isReleased {
+		return nil, errors.New("cannot open a cursor from a released snapshot")
+	}
+	return s.db.newCursor(s.snapshot, bucket)
+}
+
+// Release releases the snapshot.
+func (s *PebbleDBSnapshot) Release() error {
+	if s.isReleased {
+		return errors.New("cannot release an already released snapshot")
+	}
+	s.isReleased = true
+
+	s.db.openSnapshotsLock.Lock()
+	delete(s.db.openSnapshots, s)
+	s.db.openSnapshotsLock.Unlock()
+
+	err := s.snapshot.Close()
+	s.snapshot = nil
+	return errors.WithStack(err)
+}
@@ -0,0 +1,23 @@
+package pebbledb
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+// Stats returns the storage statistics of the pebble instance.
+func (db *PebbleDB) Stats() (*database.Stats, error) {
+	metrics := db.db.Metrics()
+
+	stats := &database.Stats{
+		DiskSize:               metrics.DiskSpaceUsage(),
+		PendingCompactionBytes: metrics.Compact.EstimatedDebt,
+		Levels:                 make([]*database.LevelStats, len(metrics.Levels)),
+	}
+	for level, levelMetrics := range metrics.Levels {
+		stats.Levels[level] = &database.LevelStats{
+			FileCount: uint64(levelMetrics.NumFiles),
+			Size:      uint64(levelMetrics.Size),
+		}
+	}
+	return stats, nil
+}
@@ -0,0 +1,15 @@
+package database
+
+// Snapshot is a read-only view of the database as it was when the snapshot
+// was taken. Writes that are made after it was taken are not visible through
+// it. Reading from a snapshot doesn't block writers, nor is it blocked by
+// them, so it's meant for long-running reads that need a consistent view of
+// the database, such as scans over whole buckets.
+type Snapshot interface {
+	DataReader
+
+	// Release releases the snapshot. The data it holds on to may only be
+	// dropped by the database once it's released. Its cursors must be
+	// closed before it's released.
+	Release() error
+}
@@ -0,0 +1,109 @@
+package database_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+func TestSnapshot(t *testing.T) {
+	testForAllDatabaseTypes(t, "TestSnapshot", testSnapshot)
+}
+
+func testSnapshot(t *testing.T, db database.Database, testName string) {
+	bucket := database.MakeBucket([]byte("bucket"))
+	changedKey := bucket.Key([]byte("changed"))
+	deletedKey := bucket.Key([]byte("deleted"))
+	addedKey := bucket.Key([]byte("added"))
+	for _, key := range []*database.Key{changedKey, deletedKey} {
+		err := db.Put(key, []byte("old"))
+		if err != nil {
+			t.Fatalf("%s: Put unexpectedly failed: %s", testName, err)
+		}
+	}
+
+	snapshot, err := db.Snapshot()
+	if err != nil {
+		t.Fatalf("%s: Snapshot unexpectedly failed: %s", testName, err)
+	}
+
+	// Write to the database after the snapshot was taken
+	err = db.Put(changedKey, []byte("new"))
+	if err != nil {
+		t.Fatalf("%s: Put unexpectedly failed: %s", testName, err)
+	}
+	err = db.Delete(deletedKey)
+	if err != nil {
+		t.Fatalf("%s: Delete unexpectedly failed: %s", testName, err)
+	}
+	err = db.Put(addedKey, []byte("new"))
+	if err != nil {
+		t.Fatalf("%s: Put unexpectedly failed: %s", testName, err)
+	}
+
+	// None of the writes are visible through the snapshot
+	value, err := snapshot.Get(changedKey)
+	if err != nil {
+		t.Fatalf("%s: Get unexpectedly failed: %s", testName, err)
+	}
+	if !bytes.Equal(value, []byte("old")) {
+		t.Fatalf("%s: Unexpected value. Want: %s, got: %s", testName, "old", value)
+	}
+	exists, err := snapshot.Has(deletedKey)
+	if err != nil {
+		t.Fatalf("%s: Has unexpectedly failed: %s", testName, err)
+	}
+	if !exists {
+		t.Fatalf("%s: A key that was deleted after the snapshot is missing from it", testName)
+	}
+	_, err = snapshot.Get(addedKey)
+	if !database.IsNotFoundError(err) {
+		t.Fatalf("%s: Get of a key that was added after the snapshot "+
+			"unexpectedly returned a non-ErrNotFound error: %s", testName, err)
+	}
+	cursor, err := snapshot.Cursor(bucket)
+	if err != nil {
+		t.Fatalf("%s: Cursor unexpectedly failed: %s", testName, err)
+	}
+	keyCount := 0
+	for cursor.Next() {
+		value, err := cursor.Value()
+		if err != nil {
+			t.Fatalf("%s: Value unexpectedly failed: %s", testName, err)
+		}
+		if !bytes.Equal(value, []byte("old")) {
+			t.Fatalf("%s: Unexpected value. Want: %s, got: %s", testName, "old", value)
+		}
+		keyCount++
+	}
+	if keyCount != 2 {
+		t.Fatalf("%s: Unexpected amount of keys. Want: %d, got: %d", testName, 2, keyCount)
+	}
+	err = cursor.Close()
+	if err != nil {
+		t.Fatalf("%s: Close unexpectedly failed: %s", testName, err)
+	}
+
+	// The database itself sees the writes
+	value, err = db.Get(changedKey)
+	if err != nil {
+		t.Fatalf("%s: Get unexpectedly failed: %s", testName, err)
+	}
+	if !bytes.Equal(value, []byte("new")) {
+		t.Fatalf("%s: Unexpected value. Want: %s, got: %s", testName, "new", value)
+	}
+
+	err = snapshot.Release()
+	if err != nil {
+		t.Fatalf("%s: Release unexpectedly failed: %s", testName, err)
+	}
+	_, err = snapshot.Get(changedKey)
+	if err == nil {
+		t.Fatalf("%s: Get from a released snapshot unexpectedly succeeded", testName)
+	}
+	err = snapshot.Release()
+	if err == nil {
+		t.Fatalf("%s: Releasing a released snapshot unexpectedly succeeded", testName)
+	}
+}
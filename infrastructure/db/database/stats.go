@@ -0,0 +1,123 @@
+package database
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Stats are the storage statistics of a database instance.
+type Stats struct {
+	// DiskSize is the amount of bytes the tables of the
+	// database take on disk.
+	DiskSize uint64
+
+	// PendingCompactionBytes is an estimate of the amount of bytes
+	// compactions have to rewrite before the database is back in
+	// shape. A growing value means compactions don't keep up with
+	// writes, which eventually stalls them.
+	PendingCompactionBytes uint64
+
+	// Levels are the statistics of the levels of the database,
+	// starting from level 0.
+	Levels []*LevelStats
+}
+
+// LevelStats are the storage statistics of a single database level.
+type LevelStats struct {
+	FileCount uint64
+	Size      uint64
+}
+
+// BucketStats are the statistics of the keys under a bucket.
+type BucketStats struct {
+	Bucket    *Bucket
+	KeyCount  uint64
+	KeySize   uint64
+	ValueSize uint64
+}
+
+// CollectBucketStats scans all the keys under the given bucket, and returns
+// the statistics of the buckets that are depth levels below it, sorted by
+// their path. Keys that are less than depth levels below the bucket are
+// counted in the deepest bucket they're in.
+//
+// Note that this reads the whole bucket, which may take a long while.
+func CollectBucketStats(dataReader DataReader, bucket *Bucket, depth int) ([]*BucketStats, error) {
+	cursor, err := dataReader.Cursor(bucket)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	statsByPath := make(map[string]*BucketStats)
+	for ok := cursor.First(); ok; ok = cursor.Next() {
+		key, err := cursor.Key()
+		if err != nil {
+			return nil, err
+		}
+		value, err := cursor.Value()
+		if err != nil {
+			return nil, err
+		}
+
+		path := subBucketPath(bucket.Path(), key.Suffix(), depth)
+		stats, ok := statsByPath[string(path)]
+		if !ok {
+			stats = &BucketStats{Bucket: MakeBucket(path)}
+			statsByPath[string(path)] = stats
+		}
+		stats.KeyCount++
+		stats.KeySize += uint64(len(bucket.Path()) + len(key.Suffix()))
+		stats.ValueSize += uint64(len(value))
+	}
+
+	bucketStats := make([]*BucketStats, 0, len(statsByPath))
+	for _, stats := range statsByPath {
+		bucketStats = append(bucketStats, stats)
+	}
+	sort.Slice(bucketStats, func(i, j int) bool {
+		return bytes.Compare(bucketStats[i].Bucket.Path(), bucketStats[j].Bucket.Path()) < 0
+	})
+	return bucketStats, nil
+}
+
+// subBucketPath returns the path of the bucket that's depth levels below
+// bucketPath and contains the key with the given suffix
+func subBucketPath(bucketPath []byte, suffix []byte, depth int) []byte {
+	path := make([]byte, len(bucketPath), len(bucketPath)+len(suffix))
+	copy(path, bucketPath)
+	for i := 0; i < depth; i++ {
+		separatorIndex := bytes.IndexByte(suffix, bucketSeparator)
+		if separatorIndex == -1 {
+			break
+		}
+		path = append(path, suffix[:separatorIndex+1]...)
+		suffix = suffix[separatorIndex+1:]
+	}
+	return path
+}
+
+// BucketDisplayName returns the given bucket path with its components that
+// aren't printable, such as consensus prefixes, hex encoded
+func BucketDisplayName(path []byte) string {
+	if len(path) == 0 {
+		return ""
+	}
+	components := strings.Split(strings.TrimSuffix(string(path), "/"), "/")
+	for i, component := range components {
+		isPrintable := len(component) > 0
+		for _, r := range component {
+			if r > unicode.MaxASCII || !unicode.IsPrint(r) {
+				isPrintable = false
+				break
+			}
+		}
+		if !isPrintable {
+			components[i] = hex.EncodeToString([]byte(component))
+		}
+	}
+	return strings.Join(components, "/")
+}
@@ -0,0 +1,119 @@
+package database_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+)
+
+func TestDatabaseStats(t *testing.T) {
+	testForAllDatabaseTypes(t, "TestDatabaseStats", testDatabaseStats)
+}
+
+func testDatabaseStats(t *testing.T, db database.Database, testName string) {
+	bucket := database.MakeBucket([]byte("bucket"))
+	for i := 0; i < 100; i++ {
+		err := db.Put(bucket.Key([]byte(fmt.Sprintf("key%d", i))), bytes.Repeat([]byte{byte(i)}, 1000))
+		if err != nil {
+			t.Fatalf("%s: Put unexpectedly failed: %s", testName, err)
+		}
+	}
+	err := db.Compact()
+	if err != nil {
+		t.Fatalf("%s: Compact unexpectedly failed: %s", testName, err)
+	}
+
+	stats, err := db.Stats()
+	if err != nil {
+		t.Fatalf("%s: Stats unexpectedly failed: %s", testName, err)
+	}
+	if len(stats.Levels) == 0 {
+		t.Fatalf("%s: Stats returned no levels", testName)
+	}
+	levelsSize := uint64(0)
+	for _, level := range stats.Levels {
+		levelsSize += level.Size
+	}
+	if levelsSize == 0 || stats.DiskSize < levelsSize {
+		t.Fatalf("%s: unexpected sizes after compaction. Disk size: %d, levels size: %d",
+			testName, stats.DiskSize, levelsSize)
+	}
+}
+
+func TestCollectBucketStats(t *testing.T) {
+	testForAllDatabaseTypes(t, "TestCollectBucketStats", testCollectBucketStats)
+}
+
+func testCollectBucketStats(t *testing.T, db database.Database, testName string) {
+	prefixBucket := database.MakeBucket([]byte("prefix"))
+	entries := []struct {
+		bucket *database.Bucket
+		key    string
+		value  string
+	}{
+		{bucket: prefixBucket.Bucket([]byte("a")), key: "key1", value: "value1"},
+		{bucket: prefixBucket.Bucket([]byte("a")), key: "key2", value: "value2"},
+		{bucket: prefixBucket.Bucket([]byte("a")).Bucket([]byte("inner")), key: "key3", value: "value3"},
+		{bucket: prefixBucket.Bucket([]byte("b")), key: "key4", value: "value4"},
+		{bucket: prefixBucket, key: "key5", value: "value5"},
+		{bucket: database.MakeBucket([]byte("other")), key: "key6", value: "value6"},
+	}
+	for _, entry := range entries {
+		err := db.Put(entry.bucket.Key([]byte(entry.key)), []byte(entry.value))
+		if err != nil {
+			t.Fatalf("%s: Put unexpectedly failed: %s", testName, err)
+		}
+	}
+
+	bucketStats, err := database.CollectBucketStats(db, prefixBucket, 1)
+	if err != nil {
+		t.Fatalf("%s: CollectBucketStats unexpectedly failed: %s", testName, err)
+	}
+	expectedStats := []struct {
+		path     string
+		keyCount uint64
+	}{
+		{path: "prefix/", keyCount: 1},
+		{path: "prefix/a/", keyCount: 3},
+		{path: "prefix/b/", keyCount: 1},
+	}
+	if len(bucketStats) != len(expectedStats) {
+		t.Fatalf("%s: unexpected amount of buckets. Want: %d, got: %d",
+			testName, len(expectedStats), len(bucketStats))
+	}
+	for i, expected := range expectedStats {
+		if string(bucketStats[i].Bucket.Path()) != expected.path {
+			t.Fatalf("%s: unexpected bucket %d. Want: %s, got: %s",
+				testName, i, expected.path, bucketStats[i].Bucket.Path())
+		}
+		if bucketStats[i].KeyCount != expected.keyCount {
+			t.Fatalf("%s: unexpected key count of %s. Want: %d, got: %d",
+				testName, expected.path, expected.keyCount, bucketStats[i].KeyCount)
+		}
+		if bucketStats[i].ValueSize != expected.keyCount*uint64(len("value1")) {
+			t.Fatalf("%s: unexpected value size of %s. Want: %d, got: %d",
+				testName, expected.path, expected.keyCount*uint64(len("value1")), bucketStats[i].ValueSize)
+		}
+	}
+}
+
+func TestBucketDisplayName(t *testing.T) {
+	tests := []struct {
+		path     []byte
+		expected string
+	}{
+		{path: nil, expected: ""},
+		{path: []byte("blocks/"), expected: "blocks"},
+		{path: append([]byte{1, '/'}, []byte("block-headers/")...), expected: "01/block-headers"},
+		{path: []byte("utxo-index/\xff\x00/"), expected: "utxo-index/ff00"},
+	}
+	for _, test := range tests {
+		displayName := database.BucketDisplayName(test.path)
+		if displayName != test.expected {
+			t.Errorf("TestBucketDisplayName: unexpected display name of %x. Want: %s, got: %s",
+				test.path, test.expected, displayName)
+		}
+	}
+}
@@ -58,13 +58,15 @@ type Backend struct {
 	writers   []logWriter
 	writeChan chan logEntry
 	syncClose sync.Mutex // used to sync that the logger finished writing everything
+	format    string
+	sampler   sampler
 }
 
 // NewBackendWithFlags configures a Backend to use the specified flags rather than using
 // the package's defaults as determined through the LOGFLAGS environment
 // variable.
 func NewBackendWithFlags(flags uint32) *Backend {
-	return &Backend{flag: flags, writeChan: make(chan logEntry, logsBuffer)}
+	return &Backend{flag: flags, writeChan: make(chan logEntry, logsBuffer), format: LogFormatText}
 }
 
 // NewBackend creates a new logger backend.
@@ -136,6 +138,30 @@ func (b *Backend) AddLogFileWithCustomRotator(logFile string, logLevel Level, th
 	return nil
 }
 
+// SetFormat sets the format the backend writes log lines in, which is one of
+// LogFormatText and LogFormatJSON. It must be set before the backend runs.
+func (b *Backend) SetFormat(format string) error {
+	if b.IsRunning() {
+		return errors.New("The logger is already running")
+	}
+	if format != LogFormatText && format != LogFormatJSON {
+		return errors.Errorf("'%s' Isn't a valid log format", format)
+	}
+	b.format = format
+	return nil
+}
+
+// SetSamplingRate limits how many messages of the same kind are logged per
+// second. Messages are of the same kind if they're of the same subsystem and
+// level, and have the same format string. Once rate messages of a kind were
+// logged in the current second, only every rate-th message of that kind is
+// logged, and the next logged message counts the suppressed ones in its
+// "suppressed" field. Warnings and more severe messages are never sampled.
+// A rate of 0 disables sampling, which is the default.
+func (b *Backend) SetSamplingRate(rate uint64) {
+	b.sampler.setRate(rate)
+}
+
 // Run launches the logger backend in a separate go-routine. should only be called once.
 func (b *Backend) Run() error {
 	if !atomic.CompareAndSwapUint32(&b.isRunning, 0, 1) {
@@ -187,5 +213,6 @@ func (b *Backend) Close() {
 // Backend b. A tag describes the subsystem and is included in all log
 // messages. The logger uses the info verbosity level by default.
 func (b *Backend) Logger(subsystemTag string) *Logger {
-	return &Logger{LevelOff, subsystemTag, b, b.writeChan}
+	level := LevelOff
+	return &Logger{lvl: &level, tag: subsystemTag, b: b, writeChan: b.writeChan}
 }
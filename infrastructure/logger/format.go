@@ -0,0 +1,126 @@
+package logger
+
+import (
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/kaspanet/kaspad/util/mstime"
+)
+
+// The formats the backend may write log lines in
+const (
+	// LogFormatText writes each log line as text, in the form
+	// 'YYYY-MM-DD hh:mm:ss.sss [LVL] TAG: message key=value ...'
+	LogFormatText = "text"
+
+	// LogFormatJSON writes each log line as a JSON object, with the keys
+	// time, level, subsystem and message, followed by file and line if
+	// either of the LogFlagShortFile or LogFlagLongFile flags are specified,
+	// and then by the fields of the message
+	LogFormatJSON = "json"
+)
+
+// Field is a key and a value that give a log message context, such as the
+// peer or the block it's about. See Logger.WithField.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// suppressedField is the key of the field that counts the messages that
+// were suppressed by sampling since the previous message of the same kind
+const suppressedField = "suppressed"
+
+// formatEntry appends a complete log line, including its final newline, in
+// the given format to buf
+func formatEntry(buf []byte, logFormat string, t mstime.Time, lvl Level, tag string, file string, line int,
+	message string, fields []Field) []byte {
+
+	if logFormat == LogFormatJSON {
+		return formatJSONEntry(buf, t, lvl, tag, file, line, message, fields)
+	}
+
+	formatHeader(&buf, t, lvl.String(), tag, file, line)
+	buf = append(buf, message...)
+	for _, field := range fields {
+		buf = append(buf, ' ')
+		buf = append(buf, field.Key...)
+		buf = append(buf, '=')
+		buf = append(buf, fmt.Sprint(field.Value)...)
+	}
+	return append(buf, '\n')
+}
+
+func formatJSONEntry(buf []byte, t mstime.Time, lvl Level, tag string, file string, line int,
+	message string, fields []Field) []byte {
+
+	buf = append(buf, `{"time":`...)
+	buf = appendJSONString(buf, t.ToNativeTime().UTC().Format("2006-01-02T15:04:05.000Z07:00"))
+	buf = append(buf, `,"level":`...)
+	buf = appendJSONString(buf, lvl.Name())
+	buf = append(buf, `,"subsystem":`...)
+	buf = appendJSONString(buf, tag)
+	buf = append(buf, `,"message":`...)
+	buf = appendJSONString(buf, message)
+	if file != "" {
+		buf = append(buf, `,"file":`...)
+		buf = appendJSONString(buf, file)
+		buf = append(buf, `,"line":`...)
+		buf = strconv.AppendInt(buf, int64(line), 10)
+	}
+	for _, field := range fields {
+		buf = append(buf, ',')
+		buf = appendJSONString(buf, field.Key)
+		buf = append(buf, ':')
+		switch value := field.Value.(type) {
+		case int:
+			buf = strconv.AppendInt(buf, int64(value), 10)
+		case uint64:
+			buf = strconv.AppendUint(buf, value, 10)
+		case bool:
+			buf = strconv.AppendBool(buf, value)
+		default:
+			buf = appendJSONString(buf, fmt.Sprint(value))
+		}
+	}
+	return append(buf, "}\n"...)
+}
+
+const hexDigits = "0123456789abcdef"
+
+// appendJSONString appends s to buf as a JSON string. Unlike encoding/json,
+// it doesn't escape HTML characters, which are common in log messages.
+// Invalid UTF-8 is replaced with the Unicode replacement character.
+func appendJSONString(buf []byte, s string) []byte {
+	buf = append(buf, '"')
+	for i := 0; i < len(s); {
+		char := s[i]
+		if char >= utf8.RuneSelf {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			if r == utf8.RuneError && size == 1 {
+				buf = append(buf, string(utf8.RuneError)...)
+			} else {
+				buf = append(buf, s[i:i+size]...)
+			}
+			i += size
+			continue
+		}
+		switch {
+		case char == '"' || char == '\\':
+			buf = append(buf, '\\', char)
+		case char == '\n':
+			buf = append(buf, '\\', 'n')
+		case char == '\r':
+			buf = append(buf, '\\', 'r')
+		case char == '\t':
+			buf = append(buf, '\\', 't')
+		case char < 0x20:
+			buf = append(buf, '\\', 'u', '0', '0', hexDigits[char>>4], hexDigits[char&0xf])
+		default:
+			buf = append(buf, char)
+		}
+		i++
+	}
+	return append(buf, '"')
+}
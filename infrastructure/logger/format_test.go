@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/util/mstime"
+)
+
+func TestFormatEntry(t *testing.T) {
+	entryTime := mstime.ToMSTime(time.Date(2021, 3, 4, 5, 6, 7, 8_000_000, time.UTC))
+	fields := []Field{{Key: "peer", Value: "127.0.0.1:16111"}, {Key: "count", Value: 3}}
+
+	textEntry := string(formatEntry(nil, LogFormatText, entryTime, LevelInfo, "PROT", "", 0,
+		"Accepted block", fields))
+	if !strings.HasSuffix(textEntry, " [INF] PROT: Accepted block peer=127.0.0.1:16111 count=3\n") {
+		t.Fatalf("Unexpected text entry %q", textEntry)
+	}
+
+	message := "A \"quoted\" <message>\nwith\tcontrol \x01 characters and invalid UTF-8 \xff"
+	jsonEntry := formatEntry(nil, LogFormatJSON, entryTime, LevelWarn, "PROT", "file.go", 12, message, fields)
+	if jsonEntry[len(jsonEntry)-1] != '\n' || strings.Count(string(jsonEntry), "\n") != 1 {
+		t.Fatalf("The JSON entry %q isn't a single line", jsonEntry)
+	}
+	if !strings.HasPrefix(string(jsonEntry), `{"time":"2021-03-04T05:06:07.008Z","level":"warn","subsystem":"PROT",`) {
+		t.Fatalf("Unexpected JSON entry %s", jsonEntry)
+	}
+	var decodedEntry map[string]interface{}
+	err := json.Unmarshal(jsonEntry, &decodedEntry)
+	if err != nil {
+		t.Fatalf("The JSON entry %s isn't valid JSON: %s", jsonEntry, err)
+	}
+	expectedMessage := strings.Replace(message, "\xff", "\ufffd", 1)
+	if decodedEntry["message"] != expectedMessage {
+		t.Fatalf("Unexpected message %q. Want: %q", decodedEntry["message"], expectedMessage)
+	}
+	if decodedEntry["file"] != "file.go" || decodedEntry["line"] != float64(12) ||
+		decodedEntry["peer"] != "127.0.0.1:16111" || decodedEntry["count"] != float64(3) {
+		t.Fatalf("Unexpected JSON entry %s", jsonEntry)
+	}
+}
+
+func TestSampler(t *testing.T) {
+	s := &sampler{}
+	for i := 0; i < 10; i++ {
+		_, shouldLog := s.sample("PROT", LevelInfo, "message %d")
+		if !shouldLog {
+			t.Fatalf("Expected messages to be logged while sampling is disabled")
+		}
+	}
+
+	const rate = 3
+	s.setRate(rate)
+	// Wait for the start of a second, so that all the messages are sampled
+	// within the same second
+	time.Sleep(time.Until(time.Now().Truncate(time.Second).Add(time.Second)))
+
+	var loggedIndexes []int
+	var suppressedCounts []uint64
+	for i := 0; i < 12; i++ {
+		suppressedCount, shouldLog := s.sample("PROT", LevelInfo, "message %d")
+		if shouldLog {
+			loggedIndexes = append(loggedIndexes, i)
+			suppressedCounts = append(suppressedCounts, suppressedCount)
+		}
+	}
+	// The first 3 messages are logged, and then every 3rd message
+	expectedLoggedIndexes := []int{0, 1, 2, 5, 8, 11}
+	expectedSuppressedCounts := []uint64{0, 0, 0, 2, 2, 2}
+	if len(loggedIndexes) != len(expectedLoggedIndexes) {
+		t.Fatalf("Unexpected logged messages %v. Want: %v", loggedIndexes, expectedLoggedIndexes)
+	}
+	for i := range loggedIndexes {
+		if loggedIndexes[i] != expectedLoggedIndexes[i] || suppressedCounts[i] != expectedSuppressedCounts[i] {
+			t.Fatalf("Unexpected logged messages %v with suppressed counts %v. Want: %v with %v",
+				loggedIndexes, suppressedCounts, expectedLoggedIndexes, expectedSuppressedCounts)
+		}
+	}
+
+	// Other kinds of messages are counted separately, and warnings aren't sampled
+	for i := 0; i < 10; i++ {
+		_, shouldLogOtherFormat := s.sample("PROT", LevelInfo, "other message %d")
+		_, shouldLogWarning := s.sample("PROT", LevelWarn, "message %d")
+		if (i < rate && !shouldLogOtherFormat) || !shouldLogWarning {
+			t.Fatalf("Unexpected sampling of message %d of another kind", i)
+		}
+	}
+}
@@ -135,6 +135,17 @@ func SupportedSubsystems() []string {
 	return subsystems
 }
 
+// LogLevels returns the current logging level of every subsystem
+func LogLevels() map[string]Level {
+	subsystemLoggersMutex.Lock()
+	defer subsystemLoggersMutex.Unlock()
+	levels := make(map[string]Level, len(subsystemLoggers))
+	for subsysID, logger := range subsystemLoggers {
+		levels[subsysID] = logger.Level()
+	}
+	return levels
+}
+
 func getSubsystem(tag string) (logger *Logger, ok bool) {
 	subsystemLoggersMutex.Lock()
 	defer subsystemLoggersMutex.Unlock()
@@ -146,20 +157,53 @@ func getSubsystem(tag string) (logger *Logger, ok bool) {
 // the levels accordingly. An appropriate error is returned if anything is
 // invalid.
 func ParseAndSetLogLevels(logLevel string) error {
+	levels, err := parseLogLevels(logLevel)
+	if err != nil {
+		return err
+	}
+	setParsedLogLevels(levels, false)
+	return nil
+}
+
+// ReplaceLogLevels is like ParseAndSetLogLevels, except that the subsystems
+// whose level the specified debug level doesn't set are switched off, as
+// they are when it's set on startup. Nothing is changed if it's invalid.
+func ReplaceLogLevels(logLevel string) error {
+	levels, err := parseLogLevels(logLevel)
+	if err != nil {
+		return err
+	}
+	setParsedLogLevels(levels, true)
+	return nil
+}
+
+// ValidateLogLevels returns an error if the specified debug level is invalid
+func ValidateLogLevels(logLevel string) error {
+	_, err := parseLogLevels(logLevel)
+	return err
+}
+
+// parseLogLevels parses the specified debug level into the level of every
+// subsystem it sets. A level of all subsystems has an empty subsystem ID.
+func parseLogLevels(logLevel string) (map[string]Level, error) {
 	// When the specified string doesn't have any delimters, treat it as
 	// the log level for all subsystems.
 	if !strings.Contains(logLevel, ",") && !strings.Contains(logLevel, "=") {
-		// Validate and change the logging level for all subsystems.
-		return SetLogLevelsString(logLevel)
+		level, ok := LevelFromString(logLevel)
+		if !ok {
+			return nil, errors.Errorf("'%s' Isn't a valid log level", logLevel)
+		}
+		return map[string]Level{"": level}, nil
 	}
 
 	// Split the specified string into subsystem/level pairs while detecting
-	// issues and update the log levels accordingly.
+	// issues.
+	levels := make(map[string]Level)
 	for _, logLevelPair := range strings.Split(logLevel, ",") {
 		if !strings.Contains(logLevelPair, "=") {
 			str := "The specified debug level contains an invalid " +
 				"subsystem/level pair [%s]"
-			return errors.Errorf(str, logLevelPair)
+			return nil, errors.Errorf(str, logLevelPair)
 		}
 
 		// Extract the specified subsystem and log level.
@@ -170,13 +214,34 @@ func ParseAndSetLogLevels(logLevel string) error {
 		if _, exists := getSubsystem(subsysID); !exists {
 			str := "The specified subsystem [%s] is invalid -- " +
 				"supported subsytems %s"
-			return errors.Errorf(str, subsysID, strings.Join(SupportedSubsystems(), ", "))
+			return nil, errors.Errorf(str, subsysID, strings.Join(SupportedSubsystems(), ", "))
 		}
 
-		err := SetLogLevel(subsysID, logLevel)
-		if err != nil {
-			return err
+		level, ok := LevelFromString(logLevel)
+		if !ok {
+			return nil, errors.Errorf("'%s' Isn't a valid log level", logLevel)
+		}
+		levels[subsysID] = level
+	}
+	return levels, nil
+}
+
+// setParsedLogLevels sets the levels that were returned from parseLogLevels.
+// If switchOffOthers is true, the levels of the other subsystems are set to
+// LevelOff.
+func setParsedLogLevels(levels map[string]Level, switchOffOthers bool) {
+	subsystemLoggersMutex.Lock()
+	defer subsystemLoggersMutex.Unlock()
+
+	for subsysID, logger := range subsystemLoggers {
+		level, ok := levels[subsysID]
+		if !ok {
+			level, ok = levels[""]
+		}
+		if ok {
+			logger.SetLevel(level)
+		} else if switchOffOthers {
+			logger.SetLevel(LevelOff)
 		}
 	}
-	return nil
 }
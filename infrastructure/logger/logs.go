@@ -33,18 +33,19 @@
 package logger
 
 import (
-	"bytes"
 	"fmt"
 	"github.com/kaspanet/kaspad/util/mstime"
 	"os"
 	"runtime"
+	"strings"
 	"sync/atomic"
 )
 
 // Logger is a subsystem logger for a Backend.
 type Logger struct {
-	lvl       Level // atomic
+	lvl       *Level // atomic
 	tag       string
+	fields    []Field
 	b         *Backend
 	writeChan chan<- logEntry
 }
@@ -131,7 +132,14 @@ func (l *Logger) Criticalf(format string, args ...interface{}) {
 func (l *Logger) Write(logLevel Level, args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= logLevel {
-		l.print(logLevel, l.tag, args...)
+		var format string
+		if len(args) > 0 {
+			format, _ = args[0].(string)
+		}
+		suppressedCount, shouldLog := l.b.sampler.sample(l.tag, logLevel, format)
+		if shouldLog {
+			l.print(logLevel, l.tag, suppressedCount, args...)
+		}
 	}
 }
 
@@ -140,18 +148,31 @@ func (l *Logger) Write(logLevel Level, args ...interface{}) {
 func (l *Logger) Writef(logLevel Level, format string, args ...interface{}) {
 	lvl := l.Level()
 	if lvl <= logLevel {
-		l.printf(logLevel, l.tag, format, args...)
+		suppressedCount, shouldLog := l.b.sampler.sample(l.tag, logLevel, format)
+		if shouldLog {
+			l.printf(logLevel, l.tag, suppressedCount, format, args...)
+		}
 	}
 }
 
 // Level returns the current logging level
 func (l *Logger) Level() Level {
-	return Level(atomic.LoadUint32((*uint32)(&l.lvl)))
+	return Level(atomic.LoadUint32((*uint32)(l.lvl)))
 }
 
 // SetLevel changes the logging level to the passed level.
 func (l *Logger) SetLevel(level Level) {
-	atomic.StoreUint32((*uint32)(&l.lvl), uint32(level))
+	atomic.StoreUint32((*uint32)(l.lvl), uint32(level))
+}
+
+// WithField returns a logger that adds the given field to every message it
+// writes, after the fields of l. The returned logger shares the subsystem
+// and the level of l.
+func (l *Logger) WithField(key string, value interface{}) *Logger {
+	fields := make([]Field, len(l.fields), len(l.fields)+1)
+	copy(fields, l.fields)
+	fields = append(fields, Field{Key: key, Value: value})
+	return &Logger{lvl: l.lvl, tag: l.tag, fields: fields, b: l.b, writeChan: l.writeChan}
 }
 
 // Backend returns the log backend
@@ -160,10 +181,10 @@ func (l *Logger) Backend() *Backend {
 }
 
 // printf outputs a log message to the writer associated with the backend after
-// creating a prefix for the given level and tag according to the formatHeader
-// function and formatting the provided arguments according to the given format
-// specifier.
-func (l *Logger) printf(lvl Level, tag string, format string, args ...interface{}) {
+// formatting the provided arguments according to the given format specifier,
+// and formatting the message with its header and fields according to the
+// format of the backend.
+func (l *Logger) printf(lvl Level, tag string, suppressedCount uint64, format string, args ...interface{}) {
 	t := mstime.Now() // get as early as possible
 
 	var file string
@@ -172,25 +193,22 @@ func (l *Logger) printf(lvl Level, tag string, format string, args ...interface{
 		file, line = callsite(l.b.flag)
 	}
 
-	buf := make([]byte, 0, normalLogSize)
-
-	formatHeader(&buf, t, lvl.String(), tag, file, line)
-	bytesBuf := bytes.NewBuffer(buf)
-	_, _ = fmt.Fprintf(bytesBuf, format, args...)
-	bytesBuf.WriteByte('\n')
+	message := fmt.Sprintf(format, args...)
+	logLine := formatEntry(make([]byte, 0, normalLogSize), l.b.format, t, lvl, tag, file, line,
+		message, l.messageFields(suppressedCount))
 
 	if !l.b.IsRunning() {
-		_, _ = fmt.Fprintf(os.Stderr, bytesBuf.String())
+		_, _ = fmt.Fprint(os.Stderr, string(logLine))
 		panic("Writing to the logger when it's not running")
 	}
-	l.writeChan <- logEntry{bytesBuf.Bytes(), lvl}
+	l.writeChan <- logEntry{logLine, lvl}
 }
 
 // print outputs a log message to the writer associated with the backend after
-// creating a prefix for the given level and tag according to the formatHeader
-// function and formatting the provided arguments using the default formatting
-// rules.
-func (l *Logger) print(lvl Level, tag string, args ...interface{}) {
+// formatting the provided arguments using the default formatting rules, and
+// formatting the message with its header and fields according to the format
+// of the backend.
+func (l *Logger) print(lvl Level, tag string, suppressedCount uint64, args ...interface{}) {
 	if atomic.LoadUint32(&l.b.isRunning) == 0 {
 		panic("printing log without initializing")
 	}
@@ -202,15 +220,25 @@ func (l *Logger) print(lvl Level, tag string, args ...interface{}) {
 		file, line = callsite(l.b.flag)
 	}
 
-	buf := make([]byte, 0, normalLogSize)
-	formatHeader(&buf, t, lvl.String(), tag, file, line)
-	bytesBuf := bytes.NewBuffer(buf)
-	_, _ = fmt.Fprintln(bytesBuf, args...)
+	message := strings.TrimSuffix(fmt.Sprintln(args...), "\n")
+	logLine := formatEntry(make([]byte, 0, normalLogSize), l.b.format, t, lvl, tag, file, line,
+		message, l.messageFields(suppressedCount))
 
 	if !l.b.IsRunning() {
 		panic("Writing to the logger when it's not running")
 	}
-	l.writeChan <- logEntry{bytesBuf.Bytes(), lvl}
+	l.writeChan <- logEntry{logLine, lvl}
+}
+
+// messageFields returns the fields of l, followed by the count of the
+// messages that were suppressed by sampling, if there are any
+func (l *Logger) messageFields(suppressedCount uint64) []Field {
+	if suppressedCount == 0 {
+		return l.fields
+	}
+	fields := make([]Field, len(l.fields), len(l.fields)+1)
+	copy(fields, l.fields)
+	return append(fields, Field{Key: suppressedField, Value: suppressedCount})
 }
 
 // From stdlib log package.
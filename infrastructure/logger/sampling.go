@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// sampler limits how many messages of the same kind are logged per second.
+// Messages are of the same kind if they're of the same subsystem and level,
+// and have the same format string. Warnings and more severe messages are
+// never sampled.
+type sampler struct {
+	rate     uint64   // atomic. 0 disables sampling
+	counters sync.Map // sampleKey -> *sampleCounter
+}
+
+type sampleKey struct {
+	tag    string
+	level  Level
+	format string
+}
+
+type sampleCounter struct {
+	sync.Mutex
+	second          int64
+	count           uint64
+	suppressedCount uint64
+}
+
+// sample returns whether a message of the given kind should be logged. Once
+// rate messages of that kind were logged in the current second, only every
+// rate-th message is. If the message should be logged, sample also returns
+// the number of messages of its kind that were suppressed since the last one
+// that was logged.
+func (s *sampler) sample(tag string, level Level, format string) (suppressedCount uint64, shouldLog bool) {
+	rate := atomic.LoadUint64(&s.rate)
+	if rate == 0 || level >= LevelWarn || format == "" {
+		return 0, true
+	}
+
+	key := sampleKey{tag: tag, level: level, format: format}
+	value, ok := s.counters.Load(key)
+	if !ok {
+		value, _ = s.counters.LoadOrStore(key, &sampleCounter{})
+	}
+	counter := value.(*sampleCounter)
+
+	counter.Lock()
+	defer counter.Unlock()
+	second := time.Now().Unix()
+	if second != counter.second {
+		counter.second = second
+		counter.count = 0
+	}
+	counter.count++
+	if counter.count > rate && (counter.count-rate)%rate != 0 {
+		counter.suppressedCount++
+		return 0, false
+	}
+	suppressedCount = counter.suppressedCount
+	counter.suppressedCount = 0
+	return suppressedCount, true
+}
+
+func (s *sampler) setRate(rate uint64) {
+	atomic.StoreUint64(&s.rate, rate)
+}
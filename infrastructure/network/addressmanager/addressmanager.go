@@ -16,8 +16,13 @@ import (
 )
 
 const (
-	maxAddresses                   = 4096
 	connectionFailedCountForRemove = 4
+
+	// LowTrustConnectionFailedCount is the connection failure count that
+	// addresses from less trusted sources, such as the HTTPS seeds, start
+	// with. They're picked less often than the addresses that start with
+	// a single failure, and are the first to be evicted from a full bucket.
+	LowTrustConnectionFailedCount = 2
 )
 
 // addressRandomizer is the interface for the randomizer needed for the AddressManager.
@@ -34,6 +39,30 @@ type addressKey struct {
 type address struct {
 	netAddress            *appmessage.NetAddress
 	connectionFailedCount uint64
+
+	// services are the services the address advertised in its version
+	// message, or 0 if the address was never connected to
+	services appmessage.ServiceFlag
+
+	// source is the IP of the peer that sent the address, or nil if the
+	// address wasn't received from a peer
+	source net.IP
+
+	// bannedUntil is when the ban of a banned address expires. It's
+	// unused for addresses that aren't banned.
+	bannedUntil mstime.Time
+
+	// bucket is the bucket of a not-banned address. It's unused for
+	// addresses that are banned.
+	bucket int
+}
+
+// BannedSubnet is a banned IP address or subnet. A single banned IP address
+// is represented by a subnet with a full mask.
+type BannedSubnet struct {
+	Subnet      *net.IPNet
+	BannedAt    mstime.Time
+	BannedUntil mstime.Time
 }
 
 type ipv6 [net.IPv6len]byte
@@ -62,11 +91,15 @@ type AddressManager struct {
 	mutex          sync.Mutex
 	cfg            *Config
 	random         addressRandomizer
+
+	// connectionStatistics are the connection statistics of every source
+	// group, which are kept in memory only
+	connectionStatistics map[string]*connectionStatistics
 }
 
 // New returns a new Kaspa address manager.
 func New(cfg *Config, database database.Database) (*AddressManager, error) {
-	addressStore, err := newAddressStore(database)
+	addressStore, err := newAddressStore(database, cfg.AcceptUnroutable)
 	if err != nil {
 		return nil, err
 	}
@@ -80,40 +113,25 @@ func New(cfg *Config, database database.Database) (*AddressManager, error) {
 		localAddresses: localAddresses,
 		random:         NewAddressRandomize(connectionFailedCountForRemove),
 		cfg:            cfg,
+
+		connectionStatistics: make(map[string]*connectionStatistics),
 	}, nil
 }
 
-func (am *AddressManager) addAddressNoLock(netAddress *appmessage.NetAddress) error {
-	if !IsRoutable(netAddress, am.cfg.AcceptUnroutable) {
-		return nil
-	}
-
-	key := netAddressKey(netAddress)
+func (am *AddressManager) addAddressNoLock(netAddress *appmessage.NetAddress, source net.IP) error {
 	// We mark `connectionFailedCount` as 0 only after first success
-	address := &address{netAddress: netAddress, connectionFailedCount: 1}
-	err := am.store.add(key, address)
-	if err != nil {
-		return err
-	}
-
-	if am.store.notBannedCount() > maxAddresses {
-		allAddresses := am.store.getAllNotBanned()
-
-		maxConnectionFailedCount := uint64(0)
-		toRemove := allAddresses[0]
-		for _, address := range allAddresses[1:] {
-			if address.connectionFailedCount > maxConnectionFailedCount {
-				maxConnectionFailedCount = address.connectionFailedCount
-				toRemove = address
-			}
-		}
+	return am.addNoLock(&address{netAddress: netAddress, connectionFailedCount: 1, source: source})
+}
 
-		err := am.removeAddressNoLock(toRemove.netAddress)
-		if err != nil {
-			return err
-		}
+// addNoLock adds the given address unless it's already known, or unless
+// its bucket is full of addresses that failed no more connections than it
+func (am *AddressManager) addNoLock(address *address) error {
+	if !IsRoutable(address.netAddress, am.cfg.AcceptUnroutable) {
+		return nil
 	}
-	return nil
+
+	key := netAddressKey(address.netAddress)
+	return am.store.add(key, address)
 }
 
 func (am *AddressManager) removeAddressNoLock(address *appmessage.NetAddress) error {
@@ -126,7 +144,7 @@ func (am *AddressManager) AddAddress(address *appmessage.NetAddress) error {
 	am.mutex.Lock()
 	defer am.mutex.Unlock()
 
-	return am.addAddressNoLock(address)
+	return am.addAddressNoLock(address, nil)
 }
 
 // AddAddresses adds addresses to the address manager
@@ -135,7 +153,24 @@ func (am *AddressManager) AddAddresses(addresses ...*appmessage.NetAddress) erro
 	defer am.mutex.Unlock()
 
 	for _, address := range addresses {
-		err := am.addAddressNoLock(address)
+		err := am.addAddressNoLock(address, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddAddressesFromSource adds addresses that were sent by the peer at the
+// given source address to the address manager
+func (am *AddressManager) AddAddressesFromSource(source *appmessage.NetAddress,
+	addresses ...*appmessage.NetAddress) error {
+
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+
+	for _, address := range addresses {
+		err := am.addAddressNoLock(address, source.IP)
 		if err != nil {
 			return err
 		}
@@ -143,6 +178,21 @@ func (am *AddressManager) AddAddresses(addresses ...*appmessage.NetAddress) erro
 	return nil
 }
 
+// UpdateServices records the services the given address advertised. It
+// does nothing if the address isn't known to the address manager.
+func (am *AddressManager) UpdateServices(address *appmessage.NetAddress, services appmessage.ServiceFlag) error {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+
+	key := netAddressKey(address)
+	entry, ok := am.store.getNotBanned(key)
+	if !ok {
+		return nil
+	}
+	entry.services = services
+	return am.store.updateNotBanned(key, entry)
+}
+
 // RemoveAddress removes addresses from the address manager
 func (am *AddressManager) RemoveAddress(address *appmessage.NetAddress) error {
 	am.mutex.Lock()
@@ -162,6 +212,7 @@ func (am *AddressManager) MarkConnectionFailure(address *appmessage.NetAddress)
 	if !ok {
 		return errors.Errorf("address %s is not registered with the address manager", address.TCPAddress())
 	}
+	am.recordConnectionNoLock(entry, false)
 	entry.connectionFailedCount = entry.connectionFailedCount + 1
 
 	if entry.connectionFailedCount >= connectionFailedCountForRemove {
@@ -183,6 +234,7 @@ func (am *AddressManager) MarkConnectionSuccess(address *appmessage.NetAddress)
 	if !ok {
 		return errors.Errorf("address %s is not registered with the address manager", address.TCPAddress())
 	}
+	am.recordConnectionNoLock(entry, true)
 	entry.connectionFailedCount = 0
 	return am.store.updateNotBanned(key, entry)
 }
@@ -223,11 +275,32 @@ func (am *AddressManager) BestLocalAddress(remoteAddress *appmessage.NetAddress)
 	return am.localAddresses.bestLocalAddress(remoteAddress)
 }
 
-// Ban marks the given address as banned
+// Ban marks the given address as banned for the configured ban duration
 func (am *AddressManager) Ban(addressToBan *appmessage.NetAddress) error {
 	am.mutex.Lock()
 	defer am.mutex.Unlock()
 
+	return am.banNoLock(addressToBan, addressToBan.Timestamp.Add(am.banDuration()))
+}
+
+// SetBanDuration replaces how long addresses banned without an explicit
+// duration stay banned. Addresses that are already banned keep their
+// expiration.
+func (am *AddressManager) SetBanDuration(banDuration time.Duration) {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+
+	am.cfg.BanDuration = banDuration
+}
+
+func (am *AddressManager) banDuration() time.Duration {
+	if am.cfg.BanDuration == 0 {
+		return legacyBanDuration
+	}
+	return am.cfg.BanDuration
+}
+
+func (am *AddressManager) banNoLock(addressToBan *appmessage.NetAddress, bannedUntil mstime.Time) error {
 	keyToBan := netAddressKey(addressToBan)
 	keysToDelete := make([]addressKey, 0)
 	for _, address := range am.store.getAllNotBannedNetAddresses() {
@@ -243,10 +316,132 @@ func (am *AddressManager) Ban(addressToBan *appmessage.NetAddress) error {
 		}
 	}
 
-	address := &address{netAddress: addressToBan}
+	address := &address{netAddress: addressToBan, bannedUntil: bannedUntil}
 	return am.store.addBanned(keyToBan, address)
 }
 
+// BanSubnet bans all the IP addresses in the given subnet for the given
+// duration, or for the configured ban duration if it's 0. A ban of the same
+// subnet is replaced.
+func (am *AddressManager) BanSubnet(subnet *net.IPNet, duration time.Duration) error {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+
+	if duration == 0 {
+		duration = am.banDuration()
+	}
+	bannedAt := mstime.Now()
+	bannedUntil := bannedAt.Add(duration)
+	if isSingleIP(subnet) {
+		return am.banNoLock(appmessage.NewNetAddressTimestamp(bannedAt, subnet.IP, 0), bannedUntil)
+	}
+	return am.store.addBannedSubnet(&BannedSubnet{Subnet: subnet, BannedAt: bannedAt, BannedUntil: bannedUntil})
+}
+
+// UnbanSubnet removes the ban of the given subnet, which must have been
+// banned with exactly the same subnet, or as a single IP address
+func (am *AddressManager) UnbanSubnet(subnet *net.IPNet) error {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+
+	if isSingleIP(subnet) {
+		key := netAddressKey(appmessage.NewNetAddressIPPort(subnet.IP, 0))
+		if !am.store.isBanned(key) {
+			return errors.Wrapf(ErrAddressNotFound, "address %s is not banned", subnet.IP)
+		}
+		return am.store.removeBanned(key)
+	}
+	if !am.store.isBannedSubnet(subnet) {
+		return errors.Wrapf(ErrAddressNotFound, "subnet %s is not banned", subnet)
+	}
+	return am.store.removeBannedSubnet(subnet)
+}
+
+// BannedSubnets returns all the banned IP addresses and subnets whose bans
+// haven't expired
+func (am *AddressManager) BannedSubnets() ([]*BannedSubnet, error) {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+
+	err := am.removeExpiredBansNoLock()
+	if err != nil {
+		return nil, err
+	}
+
+	bannedAddresses := am.store.getAllBanned()
+	bannedSubnets := make([]*BannedSubnet, 0, len(bannedAddresses))
+	for _, bannedAddress := range bannedAddresses {
+		ip := bannedAddress.netAddress.IP
+		bits := net.IPv6len * 8
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+			bits = net.IPv4len * 8
+		}
+		bannedSubnets = append(bannedSubnets, &BannedSubnet{
+			Subnet:      &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)},
+			BannedAt:    bannedAddress.netAddress.Timestamp,
+			BannedUntil: bannedAddress.bannedUntil,
+		})
+	}
+	return append(bannedSubnets, am.store.getAllBannedSubnets()...), nil
+}
+
+// ClearBanned removes all bans
+func (am *AddressManager) ClearBanned() error {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+
+	for _, bannedAddress := range am.store.getAllBanned() {
+		err := am.store.removeBanned(netAddressKey(bannedAddress.netAddress))
+		if err != nil {
+			return err
+		}
+	}
+	for _, bannedSubnet := range am.store.getAllBannedSubnets() {
+		err := am.store.removeBannedSubnet(bannedSubnet.Subnet)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (am *AddressManager) removeExpiredBansNoLock() error {
+	for _, bannedAddress := range am.store.getAllBanned() {
+		err := am.unbanIfOldEnough(netAddressKey(bannedAddress.netAddress))
+		if err != nil {
+			return err
+		}
+	}
+	now := mstime.Now()
+	for _, bannedSubnet := range am.store.getAllBannedSubnets() {
+		if now.After(bannedSubnet.BannedUntil) {
+			err := am.store.removeBannedSubnet(bannedSubnet.Subnet)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isInBannedSubnetNoLock returns whether the given IP is in a subnet whose
+// ban hasn't expired
+func (am *AddressManager) isInBannedSubnetNoLock(ip net.IP) bool {
+	now := mstime.Now()
+	for _, bannedSubnet := range am.store.getAllBannedSubnets() {
+		if bannedSubnet.Subnet.Contains(ip) && !now.After(bannedSubnet.BannedUntil) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSingleIP(subnet *net.IPNet) bool {
+	ones, bits := subnet.Mask.Size()
+	return ones == bits
+}
+
 // Unban unmarks the given address as banned
 func (am *AddressManager) Unban(address *appmessage.NetAddress) error {
 	am.mutex.Lock()
@@ -272,6 +467,9 @@ func (am *AddressManager) IsBanned(address *appmessage.NetAddress) (bool, error)
 		return false, err
 	}
 	if !am.store.isBanned(key) {
+		if am.isInBannedSubnetNoLock(address.IP) {
+			return true, nil
+		}
 		if !am.store.isNotBanned(key) {
 			return false, errors.Wrapf(ErrAddressNotFound, "address %s "+
 				"is not registered with the address manager", address.TCPAddress())
@@ -288,8 +486,7 @@ func (am *AddressManager) unbanIfOldEnough(key addressKey) error {
 		return nil
 	}
 
-	const maxBanTime = 24 * time.Hour
-	if mstime.Since(address.netAddress.Timestamp) > maxBanTime {
+	if mstime.Now().After(address.bannedUntil) {
 		err := am.store.removeBanned(key)
 		if err != nil {
 			return err
@@ -8,11 +8,13 @@ import (
 	"net"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/infrastructure/config"
 	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
 	"github.com/kaspanet/kaspad/util/mstime"
+	"github.com/pkg/errors"
 )
 
 func newAddressManagerForTest(t *testing.T, testName string) (addressManager *AddressManager, teardown func()) {
@@ -309,6 +311,8 @@ func TestOverfillAddressManager(t *testing.T) {
 	addressManager, teardown := newAddressManagerForTest(t, "TestAddressManager")
 	defer teardown()
 
+	// All the addresses of a network group that weren't sent by a peer go
+	// in the same bucket
 	generateTestAddresses := func(amount int) []*appmessage.NetAddress {
 		testAddresses := make([]*appmessage.NetAddress, 0, amount)
 		for i := byte(0); i < 128; i++ {
@@ -327,23 +331,24 @@ func TestOverfillAddressManager(t *testing.T) {
 	}
 
 	// Add a single test address to the address manager
-	testAddress := &appmessage.NetAddress{IP: net.IP{5, 6, 0, 0}, Timestamp: mstime.Now()}
+	testAddress := &appmessage.NetAddress{IP: net.IP{1, 2, 255, 255}, Timestamp: mstime.Now()}
 	err := addressManager.AddAddress(testAddress)
 	if err != nil {
 		t.Fatalf("AddAddress: %s", err)
 	}
 
-	// Add `maxAddresses-1` addresses to the address manager
-	addresses := generateTestAddresses(maxAddresses - 1)
+	// Add `bucketSize` addresses to the address manager, the last of
+	// which doesn't fit in the bucket
+	addresses := generateTestAddresses(bucketSize)
 	err = addressManager.AddAddresses(addresses...)
 	if err != nil {
 		t.Fatalf("AddAddresses: %s", err)
 	}
 
-	// Make sure that it now contains exactly `maxAddresses` entries
+	// Make sure that it now contains exactly `bucketSize` entries
 	returnedAddresses := addressManager.Addresses()
-	if len(returnedAddresses) != maxAddresses {
-		t.Fatalf("Unexpected address amount. Want: %d, got: %d", maxAddresses, len(returnedAddresses))
+	if len(returnedAddresses) != bucketSize {
+		t.Fatalf("Unexpected address amount. Want: %d, got: %d", bucketSize, len(returnedAddresses))
 	}
 
 	// Mark the first test address as a connection failure
@@ -353,15 +358,15 @@ func TestOverfillAddressManager(t *testing.T) {
 	}
 
 	// Add one more address to the address manager
-	err = addressManager.AddAddress(&appmessage.NetAddress{IP: net.IP{7, 8, 0, 0}, Timestamp: mstime.Now()})
+	err = addressManager.AddAddress(&appmessage.NetAddress{IP: net.IP{1, 2, 254, 254}, Timestamp: mstime.Now()})
 	if err != nil {
 		t.Fatalf("AddAddress: %s", err)
 	}
 
-	// Make sure that it now still contains exactly `maxAddresses` entries
+	// Make sure that it now still contains exactly `bucketSize` entries
 	returnedAddresses = addressManager.Addresses()
-	if len(returnedAddresses) != maxAddresses {
-		t.Fatalf("Unexpected address amount. Want: %d, got: %d", maxAddresses, len(returnedAddresses))
+	if len(returnedAddresses) != bucketSize {
+		t.Fatalf("Unexpected address amount. Want: %d, got: %d", bucketSize, len(returnedAddresses))
 	}
 
 	// Make sure that the first address is no longer in the
@@ -372,3 +377,219 @@ func TestOverfillAddressManager(t *testing.T) {
 		}
 	}
 }
+
+func TestAddressesOfSourceGroup(t *testing.T) {
+	addressManager, teardown := newAddressManagerForTest(t, "TestAddressesOfSourceGroup")
+	defer teardown()
+
+	// A peer sends addresses of many network groups
+	source := &appmessage.NetAddress{IP: net.IP{1, 2, 3, 4}}
+	addresses := make([]*appmessage.NetAddress, 0, maxAddresses)
+	for i := byte(1); i <= 64; i++ {
+		for j := byte(0); j < 64; j++ {
+			addresses = append(addresses, &appmessage.NetAddress{IP: net.IP{100, i, j, 1}, Timestamp: mstime.Now()})
+		}
+	}
+	err := addressManager.AddAddressesFromSource(source, addresses...)
+	if err != nil {
+		t.Fatalf("AddAddressesFromSource: %s", err)
+	}
+
+	// Only a few of the buckets take its addresses
+	returnedAddresses := addressManager.Addresses()
+	if len(returnedAddresses) > bucketsPerSourceGroup*bucketSize {
+		t.Fatalf("A single source group took %d addresses, which is more than %d",
+			len(returnedAddresses), bucketsPerSourceGroup*bucketSize)
+	}
+
+	// Addresses that were placed in buckets are restored to the same buckets
+	database := addressManager.store.database
+	restoredAddressManager, err := New(addressManager.cfg, database)
+	if err != nil {
+		t.Fatalf("New: %s", err)
+	}
+	for key, address := range addressManager.store.notBannedAddresses {
+		restoredAddress, ok := restoredAddressManager.store.getNotBanned(key)
+		if !ok {
+			t.Fatalf("Address %s wasn't restored", address.netAddress.IP)
+		}
+		if restoredAddress.bucket != address.bucket {
+			t.Fatalf("Address %s was restored to bucket %d instead of %d",
+				address.netAddress.IP, restoredAddress.bucket, address.bucket)
+		}
+	}
+}
+
+func TestMigrateToBuckets(t *testing.T) {
+	addressManager, teardown := newAddressManagerForTest(t, "TestMigrateToBuckets")
+	defer teardown()
+
+	// Write more addresses of a single network group than fit in a bucket,
+	// in the format that was used before addresses had buckets
+	database := addressManager.store.database
+	for i := 0; i < 2*bucketSize; i++ {
+		address := &address{
+			netAddress:            &appmessage.NetAddress{IP: net.IP{1, 2, 3, byte(i)}, Timestamp: mstime.Now()},
+			connectionFailedCount: uint64(i % 2),
+		}
+		key := netAddressKey(address.netAddress)
+		serializedAddress := addressManager.store.serializeAddress(address)[1 : legacyAddressSize+1]
+		err := database.Put(addressManager.store.notBannedDatabaseKey(key), serializedAddress)
+		if err != nil {
+			t.Fatalf("Put: %s", err)
+		}
+	}
+	err := database.Delete(bucketKeyKey)
+	if err != nil {
+		t.Fatalf("Delete: %s", err)
+	}
+
+	migratedAddressManager, err := New(addressManager.cfg, database)
+	if err != nil {
+		t.Fatalf("New: %s", err)
+	}
+	migratedAddresses := migratedAddressManager.store.getAllNotBanned()
+	if len(migratedAddresses) != bucketSize {
+		t.Fatalf("Unexpected address amount. Want: %d, got: %d", bucketSize, len(migratedAddresses))
+	}
+	for _, address := range migratedAddresses {
+		if address.connectionFailedCount != 0 {
+			t.Fatalf("Address %s that failed a connection was kept over ones that didn't",
+				address.netAddress.IP)
+		}
+	}
+
+	// The addresses that didn't fit are deleted, so the next restore keeps
+	// all of the addresses
+	restoredAddressManager, err := New(addressManager.cfg, database)
+	if err != nil {
+		t.Fatalf("New: %s", err)
+	}
+	if len(restoredAddressManager.Addresses()) != bucketSize {
+		t.Fatalf("Unexpected address amount. Want: %d, got: %d", bucketSize, len(restoredAddressManager.Addresses()))
+	}
+}
+
+func TestBanSubnet(t *testing.T) {
+	cfg := config.DefaultConfig()
+
+	datadir := t.TempDir()
+	database, err := ldb.NewLevelDB(datadir, 8)
+	if err != nil {
+		t.Fatalf("Could not create a database: %s", err)
+	}
+	defer database.Close()
+
+	addressManager, err := New(NewConfig(cfg), database)
+	if err != nil {
+		t.Fatalf("Error creating address manager: %s", err)
+	}
+
+	_, subnet, err := net.ParseCIDR("1.2.3.0/24")
+	if err != nil {
+		t.Fatalf("ParseCIDR: %s", err)
+	}
+	_, singleIP, err := net.ParseCIDR("5.6.7.8/32")
+	if err != nil {
+		t.Fatalf("ParseCIDR: %s", err)
+	}
+	_, expiringSubnet, err := net.ParseCIDR("9.9.0.0/16")
+	if err != nil {
+		t.Fatalf("ParseCIDR: %s", err)
+	}
+	err = addressManager.BanSubnet(subnet, time.Hour)
+	if err != nil {
+		t.Fatalf("BanSubnet: %s", err)
+	}
+	err = addressManager.BanSubnet(singleIP, 0)
+	if err != nil {
+		t.Fatalf("BanSubnet: %s", err)
+	}
+	err = addressManager.BanSubnet(expiringSubnet, time.Millisecond)
+	if err != nil {
+		t.Fatalf("BanSubnet: %s", err)
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	// Reopen the database to make sure that the bans are persisted
+	err = database.Close()
+	if err != nil {
+		t.Fatalf("Close() failed: %s", err)
+	}
+	database, err = ldb.NewLevelDB(datadir, 8)
+	if err != nil {
+		t.Fatalf("Could not create a database: %s", err)
+	}
+	defer database.Close()
+	addressManager, err = New(NewConfig(cfg), database)
+	if err != nil {
+		t.Fatalf("Error creating address manager: %s", err)
+	}
+
+	tests := []struct {
+		ip             string
+		expectedBanned bool
+	}{
+		{ip: "1.2.3.4", expectedBanned: true},
+		{ip: "1.2.4.4", expectedBanned: false},
+		{ip: "5.6.7.8", expectedBanned: true},
+		{ip: "9.9.1.1", expectedBanned: false},
+	}
+	for _, test := range tests {
+		address := appmessage.NewNetAddressIPPort(net.ParseIP(test.ip), 16111)
+		err := addressManager.AddAddresses(address)
+		if err != nil {
+			t.Fatalf("AddAddresses: %s", err)
+		}
+		isBanned, err := addressManager.IsBanned(address)
+		if err != nil {
+			t.Fatalf("IsBanned: %s", err)
+		}
+		if isBanned != test.expectedBanned {
+			t.Errorf("Unexpected IsBanned(%s). Want: %t, got: %t", test.ip, test.expectedBanned, isBanned)
+		}
+	}
+
+	bannedSubnets, err := addressManager.BannedSubnets()
+	if err != nil {
+		t.Fatalf("BannedSubnets: %s", err)
+	}
+	if len(bannedSubnets) != 2 {
+		t.Fatalf("Unexpected amount of banned subnets. Want: 2, got: %d", len(bannedSubnets))
+	}
+	for _, bannedSubnet := range bannedSubnets {
+		banDuration := bannedSubnet.BannedUntil.Sub(bannedSubnet.BannedAt)
+		switch bannedSubnet.Subnet.String() {
+		case subnet.String():
+			if banDuration != time.Hour {
+				t.Errorf("Unexpected ban duration of %s. Want: %s, got: %s", subnet, time.Hour, banDuration)
+			}
+		case singleIP.String():
+			if banDuration != cfg.BanDuration {
+				t.Errorf("Unexpected ban duration of %s. Want: %s, got: %s", singleIP, cfg.BanDuration, banDuration)
+			}
+		default:
+			t.Errorf("Unexpected banned subnet %s", bannedSubnet.Subnet)
+		}
+	}
+
+	err = addressManager.UnbanSubnet(subnet)
+	if err != nil {
+		t.Fatalf("UnbanSubnet: %s", err)
+	}
+	err = addressManager.UnbanSubnet(subnet)
+	if !errors.Is(err, ErrAddressNotFound) {
+		t.Fatalf("Expected unbanning a subnet that isn't banned to return ErrAddressNotFound, but got: %v", err)
+	}
+	err = addressManager.ClearBanned()
+	if err != nil {
+		t.Fatalf("ClearBanned: %s", err)
+	}
+	bannedSubnets, err = addressManager.BannedSubnets()
+	if err != nil {
+		t.Fatalf("BannedSubnets: %s", err)
+	}
+	if len(bannedSubnets) != 0 {
+		t.Fatalf("Expected no banned subnets after ClearBanned, but got %d", len(bannedSubnets))
+	}
+}
@@ -0,0 +1,230 @@
+package addressmanager
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/util/mstime"
+	"github.com/pkg/errors"
+)
+
+// addressSetVersion is the version of the AddressSet format
+const addressSetVersion = 1
+
+// AddressSet is a set of addresses that's exported from one address manager
+// to be imported or merged into another, such as when bootstrapping a new
+// node from the addresses known to a trusted one.
+type AddressSet struct {
+	Version   uint32             `json:"version"`
+	Addresses []*ExportedAddress `json:"addresses"`
+}
+
+// ExportedAddress is a single address of an AddressSet. BannedUntil is
+// only set for banned addresses.
+type ExportedAddress struct {
+	IP                    string `json:"ip"`
+	Port                  uint16 `json:"port"`
+	Services              uint64 `json:"services"`
+	LastSeen              int64  `json:"lastSeen"`
+	Source                string `json:"source,omitempty"`
+	ConnectionFailedCount uint64 `json:"connectionFailedCount"`
+	BannedUntil           int64  `json:"bannedUntil,omitempty"`
+}
+
+// NewAddressSet returns an AddressSet of the current version with the
+// given addresses
+func NewAddressSet(addresses []*ExportedAddress) *AddressSet {
+	return &AddressSet{Version: addressSetVersion, Addresses: addresses}
+}
+
+// WriteAddressSet writes the given address set to writer as JSON
+func WriteAddressSet(writer io.Writer, addressSet *AddressSet) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return errors.WithStack(encoder.Encode(addressSet))
+}
+
+// ReadAddressSet reads an address set that was written by WriteAddressSet
+func ReadAddressSet(reader io.Reader) (*AddressSet, error) {
+	addressSet := &AddressSet{}
+	err := json.NewDecoder(reader).Decode(addressSet)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding address set")
+	}
+	if addressSet.Version != addressSetVersion {
+		return nil, errors.Errorf("unsupported address set version %d", addressSet.Version)
+	}
+	return addressSet, nil
+}
+
+// Export returns all the addresses of the address manager, banned and not
+// banned, as an AddressSet
+func (am *AddressManager) Export() *AddressSet {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+
+	notBannedAddresses := am.store.getAllNotBanned()
+	bannedAddresses := am.store.getAllBanned()
+	exportedAddresses := make([]*ExportedAddress, 0, len(notBannedAddresses)+len(bannedAddresses))
+	for _, address := range notBannedAddresses {
+		exportedAddresses = append(exportedAddresses, exportAddress(address, false))
+	}
+	for _, address := range bannedAddresses {
+		exportedAddresses = append(exportedAddresses, exportAddress(address, true))
+	}
+	return NewAddressSet(exportedAddresses)
+}
+
+// Import replaces all the addresses of the address manager, banned and not
+// banned, with the addresses of the given set. Banned subnets are kept.
+func (am *AddressManager) Import(addressSet *AddressSet) error {
+	addresses, err := importAddresses(addressSet)
+	if err != nil {
+		return err
+	}
+
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+
+	for _, address := range am.store.getAllNotBanned() {
+		err := am.store.remove(netAddressKey(address.netAddress))
+		if err != nil {
+			return err
+		}
+	}
+	for _, address := range am.store.getAllBanned() {
+		err := am.store.removeBanned(netAddressKey(address.netAddress))
+		if err != nil {
+			return err
+		}
+	}
+	for _, address := range addresses {
+		err := am.importAddressNoLock(address)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Merge adds the addresses of the given set that the address manager
+// doesn't know, as long as they fit in their buckets. Addresses it already knows keep their connection failures
+// and source, but take the later last-seen time and the services of either.
+// A ban in the set replaces a ban of the same IP if it expires later.
+// Merge returns the amount of addresses that were added.
+func (am *AddressManager) Merge(addressSet *AddressSet) (int, error) {
+	addresses, err := importAddresses(addressSet)
+	if err != nil {
+		return 0, err
+	}
+
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+
+	addedCount := 0
+	for _, address := range addresses {
+		key := netAddressKey(address.netAddress)
+		if !address.bannedUntil.IsZero() {
+			bannedAddress, ok := am.store.getBanned(key)
+			if ok && !address.bannedUntil.After(bannedAddress.bannedUntil) {
+				continue
+			}
+			if !ok {
+				addedCount++
+			}
+			err := am.importAddressNoLock(address)
+			if err != nil {
+				return 0, err
+			}
+			continue
+		}
+
+		if am.store.isBanned(key) {
+			continue
+		}
+		existingAddress, ok := am.store.getNotBanned(key)
+		if !ok {
+			err := am.addNoLock(address)
+			if err != nil {
+				return 0, err
+			}
+			// The address isn't added if it's unroutable or if its
+			// bucket is full
+			if am.store.isNotBanned(key) {
+				addedCount++
+			}
+			continue
+		}
+		existingAddress.services |= address.services
+		if address.netAddress.Timestamp.After(existingAddress.netAddress.Timestamp) {
+			existingAddress.netAddress.Timestamp = address.netAddress.Timestamp
+		}
+		err := am.store.updateNotBanned(key, existingAddress)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return addedCount, nil
+}
+
+func (am *AddressManager) importAddressNoLock(address *address) error {
+	if !address.bannedUntil.IsZero() {
+		return am.banNoLock(address.netAddress, address.bannedUntil)
+	}
+	return am.addNoLock(address)
+}
+
+func exportAddress(address *address, isBanned bool) *ExportedAddress {
+	exportedAddress := &ExportedAddress{
+		IP:                    address.netAddress.IP.String(),
+		Port:                  address.netAddress.Port,
+		Services:              uint64(address.services),
+		LastSeen:              address.netAddress.Timestamp.UnixMilliseconds(),
+		ConnectionFailedCount: address.connectionFailedCount,
+	}
+	if address.source != nil {
+		exportedAddress.Source = address.source.String()
+	}
+	if isBanned {
+		exportedAddress.BannedUntil = address.bannedUntil.UnixMilliseconds()
+	}
+	return exportedAddress
+}
+
+// importAddresses converts the addresses of the given set, and fails if any
+// of them is invalid
+func importAddresses(addressSet *AddressSet) ([]*address, error) {
+	if addressSet.Version != addressSetVersion {
+		return nil, errors.Errorf("unsupported address set version %d", addressSet.Version)
+	}
+	addresses := make([]*address, len(addressSet.Addresses))
+	for i, exportedAddress := range addressSet.Addresses {
+		ip := net.ParseIP(exportedAddress.IP)
+		if ip == nil {
+			return nil, errors.Errorf("invalid IP %s", exportedAddress.IP)
+		}
+		address := &address{
+			netAddress: &appmessage.NetAddress{
+				IP:        ip,
+				Port:      exportedAddress.Port,
+				Timestamp: mstime.UnixMilliseconds(exportedAddress.LastSeen),
+			},
+			connectionFailedCount: exportedAddress.ConnectionFailedCount,
+			services:              appmessage.ServiceFlag(exportedAddress.Services),
+		}
+		if exportedAddress.Source != "" {
+			address.source = net.ParseIP(exportedAddress.Source)
+			if address.source == nil {
+				return nil, errors.Errorf("invalid source IP %s of address %s",
+					exportedAddress.Source, exportedAddress.IP)
+			}
+		}
+		if exportedAddress.BannedUntil != 0 {
+			address.bannedUntil = mstime.UnixMilliseconds(exportedAddress.BannedUntil)
+		}
+		addresses[i] = address
+	}
+	return addresses, nil
+}
@@ -0,0 +1,131 @@
+package addressmanager
+
+import (
+	"bytes"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/util/mstime"
+)
+
+func TestExportImportAddresses(t *testing.T) {
+	sourceManager, teardown := newAddressManagerForTest(t, "TestExportImportAddressesSource")
+	defer teardown()
+
+	peerAddress := &appmessage.NetAddress{IP: net.IP{1, 2, 3, 4}, Port: 16111, Timestamp: mstime.Now()}
+	receivedAddress := &appmessage.NetAddress{IP: net.IP{5, 6, 7, 8}, Port: 16111, Timestamp: mstime.Now()}
+	bannedAddress := &appmessage.NetAddress{IP: net.IP{9, 9, 9, 9}, Port: 16111, Timestamp: mstime.Now()}
+	err := sourceManager.AddAddresses(peerAddress, bannedAddress)
+	if err != nil {
+		t.Fatalf("AddAddresses: %s", err)
+	}
+	err = sourceManager.UpdateServices(peerAddress, appmessage.SFNodeNetwork)
+	if err != nil {
+		t.Fatalf("UpdateServices: %s", err)
+	}
+	err = sourceManager.AddAddressesFromSource(peerAddress, receivedAddress)
+	if err != nil {
+		t.Fatalf("AddAddressesFromSource: %s", err)
+	}
+	err = sourceManager.Ban(bannedAddress)
+	if err != nil {
+		t.Fatalf("Ban: %s", err)
+	}
+
+	// Round-trip the exported set through its serialization
+	var buffer bytes.Buffer
+	err = WriteAddressSet(&buffer, sourceManager.Export())
+	if err != nil {
+		t.Fatalf("WriteAddressSet: %s", err)
+	}
+	addressSet, err := ReadAddressSet(&buffer)
+	if err != nil {
+		t.Fatalf("ReadAddressSet: %s", err)
+	}
+	if len(addressSet.Addresses) != 3 {
+		t.Fatalf("Unexpected amount of exported addresses. Want: %d, got: %d", 3, len(addressSet.Addresses))
+	}
+
+	destinationManager, teardown := newAddressManagerForTest(t, "TestExportImportAddressesDestination")
+	defer teardown()
+
+	localAddress := &appmessage.NetAddress{IP: net.IP{12, 34, 56, 78}, Port: 16111, Timestamp: mstime.Now()}
+	err = destinationManager.AddAddress(localAddress)
+	if err != nil {
+		t.Fatalf("AddAddress: %s", err)
+	}
+	err = destinationManager.Import(addressSet)
+	if err != nil {
+		t.Fatalf("Import: %s", err)
+	}
+
+	// Import replaces the addresses that were there before
+	addresses := destinationManager.Addresses()
+	if len(addresses) != 2 {
+		t.Fatalf("Unexpected amount of addresses. Want: %d, got: %d", 2, len(addresses))
+	}
+	isBanned, err := destinationManager.IsBanned(bannedAddress)
+	if err != nil {
+		t.Fatalf("IsBanned: %s", err)
+	}
+	if !isBanned {
+		t.Fatalf("The banned address wasn't imported as banned")
+	}
+	received, ok := destinationManager.store.getNotBanned(netAddressKey(receivedAddress))
+	if !ok {
+		t.Fatalf("The received address wasn't imported")
+	}
+	if !received.source.Equal(peerAddress.IP) {
+		t.Fatalf("Unexpected source. Want: %s, got: %s", peerAddress.IP, received.source)
+	}
+	peer, ok := destinationManager.store.getNotBanned(netAddressKey(peerAddress))
+	if !ok {
+		t.Fatalf("The peer address wasn't imported")
+	}
+	if peer.services != appmessage.SFNodeNetwork {
+		t.Fatalf("Unexpected services. Want: %d, got: %d", appmessage.SFNodeNetwork, peer.services)
+	}
+
+	// Merge keeps the addresses that are there, and only updates
+	// the last-seen time of addresses that were seen later
+	err = destinationManager.AddAddress(localAddress)
+	if err != nil {
+		t.Fatalf("AddAddress: %s", err)
+	}
+	laterAddress := &appmessage.NetAddress{IP: net.IP{11, 22, 33, 44}, Port: 16111, Timestamp: mstime.Now()}
+	err = sourceManager.AddAddress(laterAddress)
+	if err != nil {
+		t.Fatalf("AddAddress: %s", err)
+	}
+	lastSeen := peerAddress.Timestamp.Add(time.Hour)
+	addressSet = sourceManager.Export()
+	for _, exportedAddress := range addressSet.Addresses {
+		if exportedAddress.IP == peerAddress.IP.String() {
+			exportedAddress.LastSeen = lastSeen.UnixMilliseconds()
+		}
+	}
+	addedCount, err := destinationManager.Merge(addressSet)
+	if err != nil {
+		t.Fatalf("Merge: %s", err)
+	}
+	if addedCount != 1 {
+		t.Fatalf("Unexpected amount of merged addresses. Want: %d, got: %d", 1, addedCount)
+	}
+	addresses = destinationManager.Addresses()
+	if len(addresses) != 4 {
+		t.Fatalf("Unexpected amount of addresses. Want: %d, got: %d", 4, len(addresses))
+	}
+	peer, _ = destinationManager.store.getNotBanned(netAddressKey(peerAddress))
+	if peer.netAddress.Timestamp != lastSeen {
+		t.Fatalf("Unexpected last-seen time. Want: %s, got: %s", lastSeen, peer.netAddress.Timestamp)
+	}
+
+	// Sets of other versions are rejected
+	addressSet.Version = addressSetVersion + 1
+	_, err = destinationManager.Merge(addressSet)
+	if err == nil {
+		t.Fatalf("Merge unexpectedly accepted an address set of an unknown version")
+	}
+}
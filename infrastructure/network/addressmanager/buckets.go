@@ -0,0 +1,139 @@
+package addressmanager
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sort"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+)
+
+// Not-banned addresses are placed in buckets, such that the addresses that
+// are sent from a single network group can only take a small part of the
+// address manager. This makes it much harder for an attacker who controls a
+// few network groups to fill the address manager with its own addresses,
+// and so to eclipse the node.
+//
+// The bucket of an address is picked by a keyed hash of the network group of
+// the peer that sent it, and of the network group of the address itself, like
+// the new table of bitcoind's address manager. Addresses that weren't sent by
+// a peer are treated as if they were sent by themselves. The key is secret,
+// so an attacker can't tell which addresses go in which bucket.
+const (
+	bucketCount           = 64
+	bucketSize            = 64
+	bucketsPerSourceGroup = 8
+
+	// maxAddresses is the amount of not-banned addresses that fit in all
+	// the buckets
+	maxAddresses = bucketCount * bucketSize
+
+	bucketKeySize = 32
+)
+
+// bucketKeyKey is the key of the secret key of the bucket hash
+var bucketKeyKey = database.MakeBucket(nil).Key([]byte("address-bucket-key"))
+
+// bucketIndex returns the bucket the given address belongs in
+func (as *addressStore) bucketIndex(address *address) int {
+	addressGroup := groupKey(address.netAddress, as.acceptUnroutable)
+	sourceGroup := addressGroup
+	if address.source != nil {
+		sourceGroup = groupKey(appmessage.NewNetAddressIPPort(address.source, 0), as.acceptUnroutable)
+	}
+
+	// The addresses of a single source group only go in
+	// bucketsPerSourceGroup of the buckets
+	sourceGroupBucket := as.bucketHash([]byte(addressGroup), []byte(sourceGroup)) % bucketsPerSourceGroup
+	serializedSourceGroupBucket := make([]byte, 8)
+	binary.LittleEndian.PutUint64(serializedSourceGroupBucket, sourceGroupBucket)
+	return int(as.bucketHash([]byte(sourceGroup), serializedSourceGroupBucket) % bucketCount)
+}
+
+// bucketHash returns a keyed hash of the given fields
+func (as *addressStore) bucketHash(fields ...[]byte) uint64 {
+	hasher, err := blake2b.New256(as.bucketKey)
+	if err != nil {
+		panic(errors.Wrap(err, "this should never happen. The bucket key is of a valid size"))
+	}
+	for _, field := range fields {
+		serializedLength := make([]byte, 8)
+		binary.LittleEndian.PutUint64(serializedLength, uint64(len(field)))
+		hasher.Write(serializedLength)
+		hasher.Write(field)
+	}
+	return binary.LittleEndian.Uint64(hasher.Sum(nil))
+}
+
+// worstInBucket returns the address of the given bucket that failed the
+// most connections
+func (as *addressStore) worstInBucket(bucket int) (addressKey, *address) {
+	var worstKey addressKey
+	var worstAddress *address
+	for key := range as.buckets[bucket] {
+		address := as.notBannedAddresses[key]
+		if worstAddress == nil || address.connectionFailedCount > worstAddress.connectionFailedCount {
+			worstKey = key
+			worstAddress = address
+		}
+	}
+	return worstKey, worstAddress
+}
+
+// restoreBuckets places the restored not-banned addresses in their buckets.
+// The addresses of stores that were written before addresses had buckets
+// may not all fit, in which case the ones that failed the most connections
+// are dropped.
+func (as *addressStore) restoreBuckets(restoredAddresses map[addressKey]*address) error {
+	bucketKey, err := as.database.Get(bucketKeyKey)
+	isMigrating := database.IsNotFoundError(err)
+	if err != nil && !isMigrating {
+		return err
+	}
+	if isMigrating {
+		bucketKey = make([]byte, bucketKeySize)
+		_, err := rand.Read(bucketKey)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	as.bucketKey = bucketKey
+
+	keys := make([]addressKey, 0, len(restoredAddresses))
+	for key := range restoredAddresses {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return restoredAddresses[keys[i]].connectionFailedCount < restoredAddresses[keys[j]].connectionFailedCount
+	})
+	droppedCount := 0
+	for _, key := range keys {
+		address := restoredAddresses[key]
+		address.bucket = as.bucketIndex(address)
+		if len(as.buckets[address.bucket]) >= bucketSize {
+			err := as.database.Delete(as.notBannedDatabaseKey(key))
+			if err != nil {
+				return err
+			}
+			droppedCount++
+			continue
+		}
+		as.notBannedAddresses[key] = address
+		as.buckets[address.bucket][key] = struct{}{}
+	}
+
+	if isMigrating {
+		log.Infof("Placed %d addresses in buckets, and dropped %d addresses that didn't fit",
+			len(restoredAddresses)-droppedCount, droppedCount)
+		// The key is only written once the addresses that didn't fit are
+		// deleted, so that an interrupted migration is started over
+		return as.database.Put(bucketKeyKey, bucketKey)
+	}
+	if droppedCount > 0 {
+		log.Infof("Dropped %d addresses that didn't fit in their buckets", droppedCount)
+	}
+	return nil
+}
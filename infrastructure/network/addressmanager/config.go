@@ -2,6 +2,7 @@ package addressmanager
 
 import (
 	"net"
+	"time"
 
 	"github.com/kaspanet/kaspad/infrastructure/config"
 )
@@ -13,6 +14,10 @@ type Config struct {
 	ExternalIPs      []string
 	Listeners        []string
 	Lookup           func(string) ([]net.IP, error)
+
+	// BanDuration is how long addresses banned without an explicit
+	// duration stay banned
+	BanDuration time.Duration
 }
 
 // NewConfig returns a new address manager Config.
@@ -23,5 +28,6 @@ func NewConfig(cfg *config.Config) *Config {
 		ExternalIPs:      cfg.ExternalIPs,
 		Listeners:        cfg.Listeners,
 		Lookup:           cfg.Lookup,
+		BanDuration:      cfg.BanDuration,
 	}
 }
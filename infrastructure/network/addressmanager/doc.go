@@ -30,5 +30,12 @@ about connected, known good, and attempted addresses to periodically purge
 peers which no longer appear to be good peers as well as bias the selection
 toward known good peers. The general idea is to make a best effort at only
 providing usable addresses.
+
+# Address Sets
+
+The addresses known to an address manager, along with their services, last-seen
+times, sources and bans, can be exported as a versioned AddressSet and imported
+or merged into another address manager. This is useful for bootstrapping a new
+node from the view of a trusted one.
 */
 package addressmanager
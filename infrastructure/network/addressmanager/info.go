@@ -0,0 +1,170 @@
+package addressmanager
+
+import (
+	"sort"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/util/mstime"
+)
+
+const (
+	// noSourceGroup is the source group of the addresses that weren't sent
+	// by a peer, such as the addresses from the DNS seeds
+	noSourceGroup = "none"
+
+	// otherSourceGroup collects the connection statistics of the source
+	// groups beyond the first maxTrackedSourceGroups, so that a flood of
+	// addresses from many groups can't grow the statistics without bound
+	otherSourceGroup       = "other"
+	maxTrackedSourceGroups = 1024
+)
+
+// addressAgeBucketLimits are the upper limits of the last-seen ages in
+// an age distribution. The last bucket has no limit.
+var addressAgeBucketLimits = []time.Duration{
+	time.Hour,
+	24 * time.Hour,
+	7 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+}
+
+// Info describes the quality of the address manager's addresses, so that
+// poisoning or starvation of the address table can be detected. An
+// address is counted as tried if it was connected to since it last failed
+// a connection, and as new otherwise.
+type Info struct {
+	AddressCount       int
+	BannedAddressCount int
+	TriedCount         int
+	NewCount           int
+
+	// BucketOccupancy is the amount of addresses in each of the buckets,
+	// each of which holds up to BucketSize addresses
+	BucketOccupancy []int
+	BucketSize      int
+
+	// Sources are ordered by descending address count
+	Sources []*SourceInfo
+
+	AgeDistribution []*AgeBucket
+}
+
+// SourceInfo describes the addresses that were sent by peers of a single
+// network group. The connection attempts are counted since the address
+// manager was started, including the ones to addresses that were removed.
+type SourceInfo struct {
+	Group               string
+	AddressCount        int
+	ConnectionAttempts  uint64
+	ConnectionSuccesses uint64
+}
+
+// AgeBucket counts the addresses that were last seen up to MaxAge ago, and
+// more than the MaxAge of the previous bucket. A zero MaxAge has no limit.
+type AgeBucket struct {
+	MaxAge time.Duration
+	Count  int
+}
+
+// connectionStatistics counts the connections to the addresses of a
+// single source group
+type connectionStatistics struct {
+	attempts  uint64
+	successes uint64
+}
+
+// Info returns the current Info of the address manager
+func (am *AddressManager) Info() *Info {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+
+	info := &Info{
+		BannedAddressCount: len(am.store.getAllBanned()),
+		BucketOccupancy:    make([]int, bucketCount),
+		BucketSize:         bucketSize,
+		AgeDistribution:    make([]*AgeBucket, len(addressAgeBucketLimits)+1),
+	}
+	for i := range info.AgeDistribution {
+		info.AgeDistribution[i] = &AgeBucket{}
+		if i < len(addressAgeBucketLimits) {
+			info.AgeDistribution[i].MaxAge = addressAgeBucketLimits[i]
+		}
+	}
+	for i, bucket := range am.store.buckets {
+		info.BucketOccupancy[i] = len(bucket)
+	}
+
+	sources := make(map[string]*SourceInfo)
+	sourceInfo := func(group string) *SourceInfo {
+		source, ok := sources[group]
+		if !ok {
+			source = &SourceInfo{Group: group}
+			sources[group] = source
+		}
+		return source
+	}
+	now := mstime.Now()
+	for _, address := range am.store.getAllNotBanned() {
+		info.AddressCount++
+		if address.connectionFailedCount == 0 {
+			info.TriedCount++
+		} else {
+			info.NewCount++
+		}
+		sourceInfo(am.sourceGroup(address)).AddressCount++
+
+		age := now.Sub(address.netAddress.Timestamp)
+		ageBucket := sort.Search(len(addressAgeBucketLimits), func(i int) bool {
+			return age <= addressAgeBucketLimits[i]
+		})
+		info.AgeDistribution[ageBucket].Count++
+	}
+	for group, statistics := range am.connectionStatistics {
+		source := sourceInfo(group)
+		source.ConnectionAttempts = statistics.attempts
+		source.ConnectionSuccesses = statistics.successes
+	}
+
+	info.Sources = make([]*SourceInfo, 0, len(sources))
+	for _, source := range sources {
+		info.Sources = append(info.Sources, source)
+	}
+	sort.Slice(info.Sources, func(i, j int) bool {
+		if info.Sources[i].AddressCount != info.Sources[j].AddressCount {
+			return info.Sources[i].AddressCount > info.Sources[j].AddressCount
+		}
+		return info.Sources[i].Group < info.Sources[j].Group
+	})
+	return info
+}
+
+// sourceGroup returns the network group of the peer that sent the given
+// address
+func (am *AddressManager) sourceGroup(address *address) string {
+	if address.source == nil {
+		return noSourceGroup
+	}
+	return groupKey(appmessage.NewNetAddressIPPort(address.source, 0), am.cfg.AcceptUnroutable)
+}
+
+// recordConnectionNoLock counts a connection attempt to the given address
+// in the statistics of its source group
+func (am *AddressManager) recordConnectionNoLock(address *address, isSuccessful bool) {
+	group := am.sourceGroup(address)
+	statistics, ok := am.connectionStatistics[group]
+	if !ok {
+		if len(am.connectionStatistics) >= maxTrackedSourceGroups {
+			group = otherSourceGroup
+		}
+		statistics, ok = am.connectionStatistics[group]
+		if !ok {
+			statistics = &connectionStatistics{}
+			am.connectionStatistics[group] = statistics
+		}
+	}
+	statistics.attempts++
+	if isSuccessful {
+		statistics.successes++
+	}
+}
@@ -0,0 +1,80 @@
+package addressmanager
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/util/mstime"
+)
+
+func TestInfo(t *testing.T) {
+	addressManager, teardown := newAddressManagerForTest(t, "TestInfo")
+	defer teardown()
+
+	seededAddress := &appmessage.NetAddress{IP: net.IP{1, 2, 3, 4}, Port: 16111, Timestamp: mstime.Now()}
+	oldAddress := &appmessage.NetAddress{IP: net.IP{5, 6, 7, 8}, Port: 16111,
+		Timestamp: mstime.Now().Add(-10 * 24 * time.Hour)}
+	receivedAddress := &appmessage.NetAddress{IP: net.IP{9, 10, 11, 12}, Port: 16111,
+		Timestamp: mstime.Now().Add(-2 * time.Hour)}
+	bannedAddress := &appmessage.NetAddress{IP: net.IP{13, 14, 15, 16}, Port: 16111, Timestamp: mstime.Now()}
+	err := addressManager.AddAddresses(seededAddress, oldAddress, bannedAddress)
+	if err != nil {
+		t.Fatalf("AddAddresses: %s", err)
+	}
+	err = addressManager.AddAddressesFromSource(seededAddress, receivedAddress)
+	if err != nil {
+		t.Fatalf("AddAddressesFromSource: %s", err)
+	}
+	err = addressManager.Ban(bannedAddress)
+	if err != nil {
+		t.Fatalf("Ban: %s", err)
+	}
+	err = addressManager.MarkConnectionSuccess(seededAddress)
+	if err != nil {
+		t.Fatalf("MarkConnectionSuccess: %s", err)
+	}
+	err = addressManager.MarkConnectionFailure(receivedAddress)
+	if err != nil {
+		t.Fatalf("MarkConnectionFailure: %s", err)
+	}
+
+	info := addressManager.Info()
+	if info.AddressCount != 3 || info.BannedAddressCount != 1 {
+		t.Fatalf("Unexpected address counts. Want: 3 and 1 banned, got: %d and %d banned",
+			info.AddressCount, info.BannedAddressCount)
+	}
+	if info.TriedCount != 1 || info.NewCount != 2 {
+		t.Fatalf("Unexpected tried and new counts. Want: 1 and 2, got: %d and %d", info.TriedCount, info.NewCount)
+	}
+
+	occupancy := 0
+	for _, bucketOccupancy := range info.BucketOccupancy {
+		occupancy += bucketOccupancy
+	}
+	if len(info.BucketOccupancy) != bucketCount || occupancy != info.AddressCount {
+		t.Fatalf("Unexpected bucket occupancy %v", info.BucketOccupancy)
+	}
+
+	expectedAgeCounts := []int{1, 1, 0, 1, 0}
+	for i, ageBucket := range info.AgeDistribution {
+		if ageBucket.Count != expectedAgeCounts[i] {
+			t.Fatalf("Unexpected count of addresses up to %s old. Want: %d, got: %d",
+				ageBucket.MaxAge, expectedAgeCounts[i], ageBucket.Count)
+		}
+	}
+
+	expectedSources := []*SourceInfo{
+		{Group: noSourceGroup, AddressCount: 2, ConnectionAttempts: 1, ConnectionSuccesses: 1},
+		{Group: "1.2.0.0", AddressCount: 1, ConnectionAttempts: 1, ConnectionSuccesses: 0},
+	}
+	if len(info.Sources) != len(expectedSources) {
+		t.Fatalf("Unexpected amount of sources. Want: %d, got: %d", len(expectedSources), len(info.Sources))
+	}
+	for i, expectedSource := range expectedSources {
+		if *info.Sources[i] != *expectedSource {
+			t.Fatalf("Unexpected source. Want: %+v, got: %+v", expectedSource, info.Sources[i])
+		}
+	}
+}
@@ -223,10 +223,14 @@ func IsRoutable(na *appmessage.NetAddress, acceptUnroutable bool) bool {
 // "local" for a local address, and the string "unroutable" for an unroutable
 // address.
 func (am *AddressManager) GroupKey(na *appmessage.NetAddress) string {
+	return groupKey(na, am.cfg.AcceptUnroutable)
+}
+
+func groupKey(na *appmessage.NetAddress, acceptUnroutable bool) string {
 	if IsLocal(na) {
 		return "local"
 	}
-	if !IsRoutable(na, am.cfg.AcceptUnroutable) {
+	if !IsRoutable(na, acceptUnroutable) {
 		return "unroutable"
 	}
 	if IsIPv4(na) {
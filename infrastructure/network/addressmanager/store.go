@@ -7,22 +7,51 @@ import (
 	"github.com/kaspanet/kaspad/util/mstime"
 	"github.com/pkg/errors"
 	"net"
+	"time"
 )
 
 var notBannedAddressBucket = database.MakeBucket([]byte("not-banned-addresses"))
 var bannedAddressBucket = database.MakeBucket([]byte("banned-addresses"))
+var bannedSubnetBucket = database.MakeBucket([]byte("banned-subnets"))
+
+// legacyBanDuration is how long addresses that were banned before bans had
+// an expiry stay banned
+const legacyBanDuration = 24 * time.Hour
+
+// addressSerializationVersion is written at the start of every serialized
+// address. Addresses that were serialized before the serialization was
+// versioned are told apart by their size.
+const addressSerializationVersion = 1
+
+const (
+	legacyAddressSize = 16 + 2 + 8 + 8                 // ipv6 + port + timestamp + connectionFailedCount
+	addressSize       = 1 + legacyAddressSize + 8 + 16 // version + legacy fields + services + source ipv6
+	bannedUntilSize   = 8
+)
 
 type addressStore struct {
 	database           database.Database
+	acceptUnroutable   bool
 	notBannedAddresses map[addressKey]*address
 	bannedAddresses    map[ipv6]*address
+	bannedSubnets      map[string]*BannedSubnet
+
+	// buckets are the keys of the not-banned addresses in each bucket
+	buckets   []map[addressKey]struct{}
+	bucketKey []byte
 }
 
-func newAddressStore(database database.Database) (*addressStore, error) {
+func newAddressStore(database database.Database, acceptUnroutable bool) (*addressStore, error) {
 	addressStore := &addressStore{
 		database:           database,
+		acceptUnroutable:   acceptUnroutable,
 		notBannedAddresses: map[addressKey]*address{},
 		bannedAddresses:    map[ipv6]*address{},
+		bannedSubnets:      map[string]*BannedSubnet{},
+		buckets:            make([]map[addressKey]struct{}, bucketCount),
+	}
+	for i := range addressStore.buckets {
+		addressStore.buckets[i] = map[addressKey]struct{}{}
 	}
 	err := addressStore.restoreNotBannedAddresses()
 	if err != nil {
@@ -32,35 +61,51 @@ func newAddressStore(database database.Database) (*addressStore, error) {
 	if err != nil {
 		return nil, err
 	}
+	err = addressStore.restoreBannedSubnets()
+	if err != nil {
+		return nil, err
+	}
 
-	log.Infof("Loaded %d addresses and %d banned addresses",
-		len(addressStore.notBannedAddresses), len(addressStore.bannedAddresses))
+	log.Infof("Loaded %d addresses, %d banned addresses and %d banned subnets",
+		len(addressStore.notBannedAddresses), len(addressStore.bannedAddresses), len(addressStore.bannedSubnets))
 
 	return addressStore, nil
 }
 
 func (as *addressStore) restoreNotBannedAddresses() error {
-	cursor, err := as.database.Cursor(notBannedAddressBucket)
+	restoredAddresses, err := as.readNotBannedAddresses()
 	if err != nil {
 		return err
 	}
+	return as.restoreBuckets(restoredAddresses)
+}
+
+func (as *addressStore) readNotBannedAddresses() (map[addressKey]*address, error) {
+	cursor, err := as.database.Cursor(notBannedAddressBucket)
+	if err != nil {
+		return nil, err
+	}
 	defer cursor.Close()
+	restoredAddresses := make(map[addressKey]*address)
 	for ok := cursor.First(); ok; ok = cursor.Next() {
 		databaseKey, err := cursor.Key()
 		if err != nil {
-			return err
+			return nil, err
 		}
 		serializedKey := databaseKey.Suffix()
 		key := as.deserializeAddressKey(serializedKey)
 
 		serializedNetAddress, err := cursor.Value()
 		if err != nil {
-			return err
+			return nil, err
+		}
+		netAddress, err := as.deserializeAddress(serializedNetAddress)
+		if err != nil {
+			return nil, err
 		}
-		netAddress := as.deserializeAddress(serializedNetAddress)
-		as.notBannedAddresses[key] = netAddress
+		restoredAddresses[key] = netAddress
 	}
-	return nil
+	return restoredAddresses, nil
 }
 
 func (as *addressStore) restoreBannedAddresses() error {
@@ -81,22 +126,63 @@ func (as *addressStore) restoreBannedAddresses() error {
 		if err != nil {
 			return err
 		}
-		netAddress := as.deserializeAddress(serializedNetAddress)
+		netAddress, err := as.deserializeBannedAddress(serializedNetAddress)
+		if err != nil {
+			return err
+		}
 		as.bannedAddresses[ipv6] = netAddress
 	}
 	return nil
 }
 
-func (as *addressStore) notBannedCount() int {
-	return len(as.notBannedAddresses)
+func (as *addressStore) restoreBannedSubnets() error {
+	cursor, err := as.database.Cursor(bannedSubnetBucket)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	for ok := cursor.First(); ok; ok = cursor.Next() {
+		databaseKey, err := cursor.Key()
+		if err != nil {
+			return err
+		}
+		serializedBannedSubnet, err := cursor.Value()
+		if err != nil {
+			return err
+		}
+		bannedSubnet, err := as.deserializeBannedSubnet(string(databaseKey.Suffix()), serializedBannedSubnet)
+		if err != nil {
+			return err
+		}
+		as.bannedSubnets[bannedSubnet.Subnet.String()] = bannedSubnet
+	}
+	return nil
 }
 
+// add adds the given address unless it's already in the store. If the
+// address's bucket is full, the address of the bucket that failed the most
+// connections is removed to make room for it, unless it failed no more
+// connections than the given address, in which case the given address
+// isn't added.
 func (as *addressStore) add(key addressKey, address *address) error {
 	if _, ok := as.notBannedAddresses[key]; ok {
 		return nil
 	}
 
+	address.bucket = as.bucketIndex(address)
+	if len(as.buckets[address.bucket]) >= bucketSize {
+		worstKey, worstAddress := as.worstInBucket(address.bucket)
+		if worstAddress.connectionFailedCount <= address.connectionFailedCount {
+			return nil
+		}
+		err := as.remove(worstKey)
+		if err != nil {
+			return err
+		}
+	}
+
 	as.notBannedAddresses[key] = address
+	as.buckets[address.bucket][key] = struct{}{}
 
 	databaseKey := as.notBannedDatabaseKey(key)
 	serializedAddress := as.serializeAddress(address)
@@ -105,10 +191,12 @@ func (as *addressStore) add(key addressKey, address *address) error {
 
 // updateNotBanned updates the not-banned address collection
 func (as *addressStore) updateNotBanned(key addressKey, address *address) error {
-	if _, ok := as.notBannedAddresses[key]; !ok {
+	existingAddress, ok := as.notBannedAddresses[key]
+	if !ok {
 		return errors.Errorf("address %s is not in the store", address.netAddress.TCPAddress())
 	}
 
+	address.bucket = existingAddress.bucket
 	as.notBannedAddresses[key] = address
 
 	databaseKey := as.notBannedDatabaseKey(key)
@@ -122,6 +210,9 @@ func (as *addressStore) getNotBanned(key addressKey) (*address, bool) {
 }
 
 func (as *addressStore) remove(key addressKey) error {
+	if address, ok := as.notBannedAddresses[key]; ok {
+		delete(as.buckets[address.bucket], key)
+	}
 	delete(as.notBannedAddresses, key)
 
 	databaseKey := as.notBannedDatabaseKey(key)
@@ -161,15 +252,13 @@ func (as *addressStore) isNotBanned(key addressKey) bool {
 	return ok
 }
 
+// addBanned adds the given banned address, replacing the ban of its IP if
+// there already is one
 func (as *addressStore) addBanned(key addressKey, address *address) error {
-	if _, ok := as.bannedAddresses[key.address]; ok {
-		return nil
-	}
-
 	as.bannedAddresses[key.address] = address
 
 	databaseKey := as.bannedDatabaseKey(key)
-	serializedAddress := as.serializeAddress(address)
+	serializedAddress := as.serializeBannedAddress(address)
 	return as.database.Put(databaseKey, serializedAddress)
 }
 
@@ -198,6 +287,45 @@ func (as *addressStore) getBanned(key addressKey) (*address, bool) {
 	return bannedAddress, ok
 }
 
+func (as *addressStore) getAllBanned() []*address {
+	bannedAddresses := make([]*address, 0, len(as.bannedAddresses))
+	for _, bannedAddress := range as.bannedAddresses {
+		bannedAddresses = append(bannedAddresses, bannedAddress)
+	}
+	return bannedAddresses
+}
+
+// addBannedSubnet adds the given banned subnet, replacing its ban if there
+// already is one
+func (as *addressStore) addBannedSubnet(bannedSubnet *BannedSubnet) error {
+	subnetString := bannedSubnet.Subnet.String()
+	as.bannedSubnets[subnetString] = bannedSubnet
+
+	databaseKey := bannedSubnetBucket.Key([]byte(subnetString))
+	return as.database.Put(databaseKey, as.serializeBannedSubnet(bannedSubnet))
+}
+
+func (as *addressStore) removeBannedSubnet(subnet *net.IPNet) error {
+	subnetString := subnet.String()
+	delete(as.bannedSubnets, subnetString)
+
+	databaseKey := bannedSubnetBucket.Key([]byte(subnetString))
+	return as.database.Delete(databaseKey)
+}
+
+func (as *addressStore) isBannedSubnet(subnet *net.IPNet) bool {
+	_, ok := as.bannedSubnets[subnet.String()]
+	return ok
+}
+
+func (as *addressStore) getAllBannedSubnets() []*BannedSubnet {
+	bannedSubnets := make([]*BannedSubnet, 0, len(as.bannedSubnets))
+	for _, bannedSubnet := range as.bannedSubnets {
+		bannedSubnets = append(bannedSubnets, bannedSubnet)
+	}
+	return bannedSubnets
+}
+
 // netAddressKeys returns a key of the ip address to use it in maps.
 func netAddressesKeys(netAddresses []*appmessage.NetAddress) map[addressKey]bool {
 	result := make(map[addressKey]bool, len(netAddresses))
@@ -240,18 +368,46 @@ func (as *addressStore) deserializeAddressKey(serializedKey []byte) addressKey {
 }
 
 func (as *addressStore) serializeAddress(address *address) []byte {
-	serializedSize := 16 + 2 + 8 + 8 // ipv6 + port + timestamp + connectionFailedCount
-	serializedNetAddress := make([]byte, serializedSize)
-
-	copy(serializedNetAddress[:], address.netAddress.IP.To16()[:])
-	binary.LittleEndian.PutUint16(serializedNetAddress[16:], address.netAddress.Port)
-	binary.LittleEndian.PutUint64(serializedNetAddress[18:], uint64(address.netAddress.Timestamp.UnixMilliseconds()))
-	binary.LittleEndian.PutUint64(serializedNetAddress[26:], uint64(address.connectionFailedCount))
+	serializedNetAddress := make([]byte, addressSize)
+
+	serializedNetAddress[0] = addressSerializationVersion
+	copy(serializedNetAddress[1:], address.netAddress.IP.To16()[:])
+	binary.LittleEndian.PutUint16(serializedNetAddress[17:], address.netAddress.Port)
+	binary.LittleEndian.PutUint64(serializedNetAddress[19:], uint64(address.netAddress.Timestamp.UnixMilliseconds()))
+	binary.LittleEndian.PutUint64(serializedNetAddress[27:], uint64(address.connectionFailedCount))
+	binary.LittleEndian.PutUint64(serializedNetAddress[35:], uint64(address.services))
+	if address.source != nil {
+		copy(serializedNetAddress[43:], address.source.To16()[:])
+	}
 
 	return serializedNetAddress
 }
 
-func (as *addressStore) deserializeAddress(serializedAddress []byte) *address {
+// deserializeAddress deserializes an address serialized by serializeAddress,
+// or by the unversioned serialization that preceded it
+func (as *addressStore) deserializeAddress(serializedAddress []byte) (*address, error) {
+	if len(serializedAddress) == legacyAddressSize || len(serializedAddress) == legacyAddressSize+bannedUntilSize {
+		return as.deserializeLegacyAddress(serializedAddress), nil
+	}
+	if len(serializedAddress) < addressSize {
+		return nil, errors.Errorf("serialized address is too short (%d bytes)", len(serializedAddress))
+	}
+	version := serializedAddress[0]
+	if version != addressSerializationVersion {
+		return nil, errors.Errorf("unknown address serialization version %d", version)
+	}
+
+	address := as.deserializeLegacyAddress(serializedAddress[1:])
+	address.services = appmessage.ServiceFlag(binary.LittleEndian.Uint64(serializedAddress[35:]))
+	source := make(net.IP, 16)
+	copy(source[:], serializedAddress[43:])
+	if !source.IsUnspecified() {
+		address.source = source
+	}
+	return address, nil
+}
+
+func (as *addressStore) deserializeLegacyAddress(serializedAddress []byte) *address {
 	ip := make(net.IP, 16)
 	copy(ip[:], serializedAddress[:])
 
@@ -268,3 +424,54 @@ func (as *addressStore) deserializeAddress(serializedAddress []byte) *address {
 		connectionFailedCount: connectionFailedCount,
 	}
 }
+
+// serializeBannedAddress serializes the given address like serializeAddress,
+// followed by the time its ban expires
+func (as *addressStore) serializeBannedAddress(address *address) []byte {
+	serializedAddress := as.serializeAddress(address)
+	serializedBannedUntil := make([]byte, bannedUntilSize)
+	binary.LittleEndian.PutUint64(serializedBannedUntil, uint64(address.bannedUntil.UnixMilliseconds()))
+	return append(serializedAddress, serializedBannedUntil...)
+}
+
+// deserializeBannedAddress deserializes an address serialized by
+// serializeBannedAddress. Addresses banned before bans had an expiry are
+// serialized without it, and expire legacyBanDuration after they were banned.
+func (as *addressStore) deserializeBannedAddress(serializedAddress []byte) (*address, error) {
+	address, err := as.deserializeAddress(serializedAddress)
+	if err != nil {
+		return nil, err
+	}
+	if len(serializedAddress) == legacyAddressSize {
+		address.bannedUntil = address.netAddress.Timestamp.Add(legacyBanDuration)
+		return address, nil
+	}
+	if len(serializedAddress) != legacyAddressSize+bannedUntilSize && len(serializedAddress) != addressSize+bannedUntilSize {
+		return nil, errors.Errorf("unexpected serialized banned address size %d", len(serializedAddress))
+	}
+	serializedBannedUntil := serializedAddress[len(serializedAddress)-bannedUntilSize:]
+	address.bannedUntil = mstime.UnixMilliseconds(int64(binary.LittleEndian.Uint64(serializedBannedUntil)))
+	return address, nil
+}
+
+func (as *addressStore) serializeBannedSubnet(bannedSubnet *BannedSubnet) []byte {
+	serializedSize := 8 + 8 // bannedAt + bannedUntil
+	serializedBannedSubnet := make([]byte, serializedSize)
+
+	binary.LittleEndian.PutUint64(serializedBannedSubnet[:], uint64(bannedSubnet.BannedAt.UnixMilliseconds()))
+	binary.LittleEndian.PutUint64(serializedBannedSubnet[8:], uint64(bannedSubnet.BannedUntil.UnixMilliseconds()))
+
+	return serializedBannedSubnet
+}
+
+func (as *addressStore) deserializeBannedSubnet(subnetString string, serializedBannedSubnet []byte) (*BannedSubnet, error) {
+	_, subnet, err := net.ParseCIDR(subnetString)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid banned subnet %s", subnetString)
+	}
+	return &BannedSubnet{
+		Subnet:      subnet,
+		BannedAt:    mstime.UnixMilliseconds(int64(binary.LittleEndian.Uint64(serializedBannedSubnet[:]))),
+		BannedUntil: mstime.UnixMilliseconds(int64(binary.LittleEndian.Uint64(serializedBannedSubnet[8:]))),
+	}, nil
+}
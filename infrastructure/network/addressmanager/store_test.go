@@ -36,12 +36,52 @@ func TestAddressSerialization(t *testing.T) {
 			Timestamp: mstime.Now(),
 		},
 		connectionFailedCount: 98465,
+		services:              appmessage.SFNodeNetwork,
+		source:                net.ParseIP("2602:100:abcd::103"),
 	}
 
 	serializedTestAddress := addressStore.serializeAddress(testAddress)
-	deserializedTestAddress := addressStore.deserializeAddress(serializedTestAddress)
+	deserializedTestAddress, err := addressStore.deserializeAddress(serializedTestAddress)
+	if err != nil {
+		t.Fatalf("deserializeAddress: %s", err)
+	}
 	if !reflect.DeepEqual(testAddress, deserializedTestAddress) {
 		t.Fatalf("testAddress and deserializedTestAddress are not equal\n"+
 			"testAddress:%+v\ndeserializedTestAddress:%+v", testAddress, deserializedTestAddress)
 	}
 }
+
+func TestLegacyAddressDeserialization(t *testing.T) {
+	addressManager, teardown := newAddressManagerForTest(t, "TestLegacyAddressDeserialization")
+	defer teardown()
+	addressStore := addressManager.store
+
+	testAddress := &address{
+		netAddress: &appmessage.NetAddress{
+			IP:        net.ParseIP("2602:100:abcd::102"),
+			Port:      12345,
+			Timestamp: mstime.Now(),
+		},
+		connectionFailedCount: 98465,
+	}
+
+	// Addresses used to be serialized without a version, services or source
+	serializedTestAddress := addressStore.serializeAddress(testAddress)[1 : legacyAddressSize+1]
+	deserializedTestAddress, err := addressStore.deserializeAddress(serializedTestAddress)
+	if err != nil {
+		t.Fatalf("deserializeAddress: %s", err)
+	}
+	if !reflect.DeepEqual(testAddress, deserializedTestAddress) {
+		t.Fatalf("testAddress and deserializedTestAddress are not equal\n"+
+			"testAddress:%+v\ndeserializedTestAddress:%+v", testAddress, deserializedTestAddress)
+	}
+
+	deserializedTestAddress, err = addressStore.deserializeBannedAddress(serializedTestAddress)
+	if err != nil {
+		t.Fatalf("deserializeBannedAddress: %s", err)
+	}
+	expectedBannedUntil := testAddress.netAddress.Timestamp.Add(legacyBanDuration)
+	if deserializedTestAddress.bannedUntil != expectedBannedUntil {
+		t.Fatalf("Unexpected bannedUntil. Want: %s, got: %s", expectedBannedUntil, deserializedTestAddress.bannedUntil)
+	}
+}
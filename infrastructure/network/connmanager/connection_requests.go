@@ -4,21 +4,6 @@ import (
 	"time"
 )
 
-const (
-	minRetryDuration = 30 * time.Second
-	maxRetryDuration = 10 * time.Minute
-)
-
-func nextRetryDuration(previousDuration time.Duration) time.Duration {
-	if previousDuration < minRetryDuration {
-		return minRetryDuration
-	}
-	if previousDuration*2 > maxRetryDuration {
-		return maxRetryDuration
-	}
-	return previousDuration * 2
-}
-
 // checkRequestedConnections checks that all activeRequested are still active, and initiates connections
 // for pendingRequested.
 // While doing so, it filters out of connSet all connections that were initiated as a connectionRequest
@@ -36,6 +21,7 @@ func (c *ConnectionManager) checkRequestedConnections(connSet connectionSet) {
 			if connReq.isPermanent { // if is one-try - ignore. If permanent - add to pending list to retry
 				connReq.nextAttempt = now
 				connReq.retryDuration = 0
+				connReq.failureCount = 0
 				c.pendingRequested[address] = connReq
 			}
 			continue
@@ -73,13 +59,22 @@ func (c *ConnectionManager) checkRequestedConnections(connSet connectionSet) {
 				continue
 			}
 			// if connection request is permanent - keep in pending, and increase retry time
-			connReq.retryDuration = nextRetryDuration(connReq.retryDuration)
-			connReq.nextAttempt = now.Add(connReq.retryDuration)
-			log.Debugf("Retrying permanent connection to %s in %s", address, connReq.retryDuration)
+			retryPolicy := c.policy.retryPolicy(address)
+			connReq.failureCount++
+			if retryPolicy.hasReachedMaxFailures(connReq.failureCount) {
+				log.Warnf("Giving up on permanent connection to %s after %d failures", address, connReq.failureCount)
+				delete(c.pendingRequested, address)
+				continue
+			}
+			connReq.retryDuration = retryPolicy.nextRetryDuration(connReq.retryDuration)
+			retryDelay := retryPolicy.withJitter(connReq.retryDuration)
+			connReq.nextAttempt = now.Add(retryDelay)
+			log.Debugf("Retrying permanent connection to %s in %s", address, retryDelay)
 			continue
 		}
 
 		// if connected successfully - move from pending to active
+		connReq.failureCount = 0
 		delete(c.pendingRequested, address)
 		c.activeRequested[address] = connReq
 	}
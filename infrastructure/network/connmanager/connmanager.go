@@ -2,12 +2,14 @@ package connmanager
 
 import (
 	"net"
+	"net/http"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/infrastructure/network/dnsseed"
+	"github.com/kaspanet/kaspad/infrastructure/network/ipmap"
 	"github.com/pkg/errors"
 
 	"github.com/kaspanet/kaspad/infrastructure/network/addressmanager"
@@ -23,14 +25,22 @@ type connectionRequest struct {
 	isPermanent   bool
 	nextAttempt   time.Time
 	retryDuration time.Duration
+	failureCount  int
 }
 
 // ConnectionManager monitors that the current active connections satisfy the requirements of
 // outgoing, requested and incoming connections
 type ConnectionManager struct {
+	// skippedByASN and skippedByCountry are accessed atomically, so they
+	// come first to be 64-bit aligned
+	skippedByASN     uint64
+	skippedByCountry uint64
+
 	cfg            *config.Config
 	netAdapter     *netadapter.NetAdapter
 	addressManager *addressmanager.AddressManager
+	policy         *Policy
+	ipMap          *ipmap.Reloader
 
 	activeRequested  map[string]*connectionRequest
 	pendingRequested map[string]*connectionRequest
@@ -44,14 +54,30 @@ type ConnectionManager struct {
 
 	resetLoopChan chan struct{}
 	loopTicker    *time.Ticker
+
+	hasSeededFromDNS bool
+	httpSeedClient   *http.Client
 }
 
-// New instantiates a new instance of a ConnectionManager
+// New instantiates a new instance of a ConnectionManager with the default policy
 func New(cfg *config.Config, netAdapter *netadapter.NetAdapter, addressManager *addressmanager.AddressManager) (*ConnectionManager, error) {
+	return NewWithPolicy(cfg, netAdapter, addressManager, DefaultPolicy())
+}
+
+// NewWithPolicy instantiates a new instance of a ConnectionManager that
+// retries failed connection requests according to the given policy
+func NewWithPolicy(cfg *config.Config, netAdapter *netadapter.NetAdapter,
+	addressManager *addressmanager.AddressManager, policy *Policy) (*ConnectionManager, error) {
+
+	err := policy.validate()
+	if err != nil {
+		return nil, err
+	}
 	c := &ConnectionManager{
 		cfg:              cfg,
 		netAdapter:       netAdapter,
 		addressManager:   addressManager,
+		policy:           policy,
 		activeRequested:  map[string]*connectionRequest{},
 		pendingRequested: map[string]*connectionRequest{},
 		activeOutgoing:   map[string]struct{}{},
@@ -59,6 +85,15 @@ func New(cfg *config.Config, netAdapter *netadapter.NetAdapter, addressManager *
 		resetLoopChan:    make(chan struct{}),
 		loopTicker:       time.NewTicker(connectionsLoopInterval),
 	}
+	if cfg.IPMapFile != "" {
+		c.ipMap, err = ipmap.NewReloader(cfg.IPMapFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(cfg.HTTPSeeds) > 0 {
+		c.httpSeedClient = dnsseed.NewHTTPSeedClient(cfg.Dial)
+	}
 
 	connectPeers := cfg.AddPeers
 	if len(cfg.ConnectPeers) > 0 {
@@ -80,6 +115,9 @@ func New(cfg *config.Config, netAdapter *netadapter.NetAdapter, addressManager *
 
 // Start begins the operation of the ConnectionManager
 func (c *ConnectionManager) Start() {
+	if c.ipMap != nil {
+		c.ipMap.Start(ipMapReloadInterval)
+	}
 	spawn("ConnectionManager.connectionsLoop", c.connectionsLoop)
 }
 
@@ -92,6 +130,9 @@ func (c *ConnectionManager) Stop() {
 	}
 
 	c.loopTicker.Stop()
+	if c.ipMap != nil {
+		c.ipMap.Stop()
+	}
 	// Force the next iteration so the connection loop will stop immediately and not after `connectionsLoopInterval`.
 	c.run()
 }
@@ -166,6 +207,33 @@ func (c *ConnectionManager) BanByIP(ip net.IP) error {
 	return c.addressManager.Ban(appmessage.NewNetAddressIPPort(ip, 0))
 }
 
+// BanSubnet bans all the IP addresses in the given subnet for the given
+// duration, or for the configured ban duration if it's 0, and disconnects
+// from all the connections with these addresses
+func (c *ConnectionManager) BanSubnet(subnet *net.IPNet, duration time.Duration) error {
+	subnetHasPermanentConnection, err := c.subnetHasPermanentConnection(subnet)
+	if err != nil {
+		return err
+	}
+
+	if subnetHasPermanentConnection {
+		return errors.Wrapf(ErrCannotBanPermanent, "Cannot ban %s because it has a permanent connection", subnet)
+	}
+
+	err = c.addressManager.BanSubnet(subnet, duration)
+	if err != nil {
+		return err
+	}
+
+	connections := c.netAdapter.P2PConnections()
+	for _, conn := range connections {
+		if subnet.Contains(conn.NetAddress().IP) {
+			conn.Disconnect()
+		}
+	}
+	return nil
+}
+
 // IsBanned returns whether the given netConnection is banned
 func (c *ConnectionManager) IsBanned(netConnection *netadapter.NetConnection) (bool, error) {
 	if c.isPermanent(netConnection.Address()) {
@@ -199,6 +267,16 @@ func (c *ConnectionManager) isPermanent(addressString string) bool {
 }
 
 func (c *ConnectionManager) ipHasPermanentConnection(ip net.IP) (bool, error) {
+	return c.hasPermanentConnection(ip.Equal)
+}
+
+func (c *ConnectionManager) subnetHasPermanentConnection(subnet *net.IPNet) (bool, error) {
+	return c.hasPermanentConnection(subnet.Contains)
+}
+
+// hasPermanentConnection returns whether a permanent connection request
+// resolves to an IP for which matches returns true
+func (c *ConnectionManager) hasPermanentConnection(matches func(ip net.IP) bool) (bool, error) {
 	c.connectionRequestsLock.RLock()
 	defer c.connectionRequestsLock.RUnlock()
 
@@ -213,7 +291,7 @@ func (c *ConnectionManager) ipHasPermanentConnection(ip net.IP) (bool, error) {
 		}
 
 		for _, extractedIP := range ips {
-			if extractedIP.Equal(ip) {
+			if matches(extractedIP) {
 				return true, nil
 			}
 		}
@@ -230,7 +308,7 @@ func (c *ConnectionManager) ipHasPermanentConnection(ip net.IP) (bool, error) {
 		}
 
 		for _, extractedIP := range ips {
-			if extractedIP.Equal(ip) {
+			if matches(extractedIP) {
 				return true, nil
 			}
 		}
@@ -256,6 +334,14 @@ func (c *ConnectionManager) extractAddressIPs(address string) ([]net.IP, error)
 func (c *ConnectionManager) seedFromDNS() {
 	cfg := c.cfg
 	if len(c.activeOutgoing) == 0 && !cfg.DisableDNSSeed {
+		// We're seeding again, so the addresses of the previous DNS seeding
+		// didn't lead to a single outgoing connection. Either DNS seeding
+		// failed, or it's censored, so fall back to the HTTPS seeds
+		if c.hasSeededFromDNS && c.httpSeedClient != nil {
+			c.seedFromHTTPS()
+		}
+		c.hasSeededFromDNS = true
+
 		dnsseed.SeedFromDNS(cfg.NetParams(), cfg.DNSSeed, false, nil,
 			cfg.Lookup, func(addresses []*appmessage.NetAddress) {
 				// Kaspad uses a lookup of the dns seeder here. Since seeder returns
@@ -270,3 +356,26 @@ func (c *ConnectionManager) seedFromDNS() {
 			})
 	}
 }
+
+func (c *ConnectionManager) seedFromHTTPS() {
+	cfg := c.cfg
+	dnsseed.SeedFromHTTPS(cfg.NetParams(), cfg.HTTPSeeds, cfg.HTTPSeedPublicKey, c.httpSeedClient,
+		func(addresses []*dnsseed.HTTPSeedAddress) {
+			exportedAddresses := make([]*addressmanager.ExportedAddress, len(addresses))
+			for i, address := range addresses {
+				exportedAddresses[i] = &addressmanager.ExportedAddress{
+					IP:                    address.NetAddress.IP.String(),
+					Port:                  address.NetAddress.Port,
+					Services:              uint64(address.Services),
+					LastSeen:              address.NetAddress.Timestamp.UnixMilliseconds(),
+					ConnectionFailedCount: addressmanager.LowTrustConnectionFailedCount,
+				}
+			}
+			addedCount, err := c.addressManager.Merge(addressmanager.NewAddressSet(exportedAddresses))
+			if err != nil {
+				log.Warnf("Error merging the addresses of the HTTPS seeds: %s", err)
+				return
+			}
+			log.Debugf("Merged %d new addresses from the HTTPS seeds", addedCount)
+		})
+}
@@ -0,0 +1,120 @@
+package connmanager
+
+import (
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/network/ipmap"
+)
+
+const (
+	// ipMapReloadInterval is how often the IP map file is checked for changes
+	ipMapReloadInterval = time.Minute
+
+	// diversityCandidateFactor is how many more addresses than needed
+	// are drawn when the outbound diversity limits are set, so that
+	// enough remain after the ones that exceed the limits are skipped
+	diversityCandidateFactor = 4
+)
+
+// OutboundDiversityStatistics describes the limits on how many outbound
+// peers may share an ASN or a country, and how many addresses they kept
+// from being selected
+type OutboundDiversityStatistics struct {
+	// IPMapFile is empty if no IP map is set
+	IPMapFile     string
+	IPMapLoadTime time.Time
+
+	MaxOutboundPerASN     int
+	MaxOutboundPerCountry int
+
+	SkippedByASN     uint64
+	SkippedByCountry uint64
+}
+
+// outboundDiversity counts the outbound peers of every ASN and country
+// while new outbound peers are selected
+type outboundDiversity struct {
+	connectionManager *ConnectionManager
+	asnCounts         map[uint32]int
+	countryCounts     map[string]int
+}
+
+func (c *ConnectionManager) hasDiversityLimits() bool {
+	return c.ipMap != nil && (c.cfg.MaxOutboundPerASN > 0 || c.cfg.MaxOutboundPerCountry > 0)
+}
+
+func (c *ConnectionManager) newOutboundDiversity() *outboundDiversity {
+	diversity := &outboundDiversity{
+		connectionManager: c,
+		asnCounts:         make(map[uint32]int),
+		countryCounts:     make(map[string]int),
+	}
+	for address := range c.activeOutgoing {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			continue
+		}
+		ip := net.ParseIP(host)
+		if ip == nil {
+			continue
+		}
+		diversity.add(ip)
+	}
+	return diversity
+}
+
+// allows returns whether another outbound peer may be connected at the
+// given address. IPs of an unknown ASN or country aren't limited by it.
+func (diversity *outboundDiversity) allows(netAddress *appmessage.NetAddress) bool {
+	c := diversity.connectionManager
+	info := c.IPInfo(netAddress.IP)
+	if c.cfg.MaxOutboundPerASN > 0 && info.ASN != 0 && diversity.asnCounts[info.ASN] >= c.cfg.MaxOutboundPerASN {
+		atomic.AddUint64(&c.skippedByASN, 1)
+		return false
+	}
+	if c.cfg.MaxOutboundPerCountry > 0 && info.Country != "" &&
+		diversity.countryCounts[info.Country] >= c.cfg.MaxOutboundPerCountry {
+
+		atomic.AddUint64(&c.skippedByCountry, 1)
+		return false
+	}
+	return true
+}
+
+func (diversity *outboundDiversity) add(ip net.IP) {
+	info := diversity.connectionManager.IPInfo(ip)
+	if info.ASN != 0 {
+		diversity.asnCounts[info.ASN]++
+	}
+	if info.Country != "" {
+		diversity.countryCounts[info.Country]++
+	}
+}
+
+// IPInfo returns the ASN and country of the given IP, as far as the IP
+// map knows them. It's empty if no IP map is set.
+func (c *ConnectionManager) IPInfo(ip net.IP) ipmap.Info {
+	if c.ipMap == nil {
+		return ipmap.Info{}
+	}
+	return c.ipMap.Lookup(ip)
+}
+
+// OutboundDiversityStatistics returns the statistics of the outbound
+// diversity limits
+func (c *ConnectionManager) OutboundDiversityStatistics() *OutboundDiversityStatistics {
+	statistics := &OutboundDiversityStatistics{
+		MaxOutboundPerASN:     c.cfg.MaxOutboundPerASN,
+		MaxOutboundPerCountry: c.cfg.MaxOutboundPerCountry,
+		SkippedByASN:          atomic.LoadUint64(&c.skippedByASN),
+		SkippedByCountry:      atomic.LoadUint64(&c.skippedByCountry),
+	}
+	if c.ipMap != nil {
+		statistics.IPMapFile = c.ipMap.Path()
+		statistics.IPMapLoadTime = c.ipMap.LoadTime()
+	}
+	return statistics
+}
@@ -0,0 +1,68 @@
+package connmanager
+
+import (
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/network/ipmap"
+)
+
+func TestOutboundDiversity(t *testing.T) {
+	ipMapFile := filepath.Join(t.TempDir(), "ipmap.csv")
+	err := ioutil.WriteFile(ipMapFile, []byte("1.1.0.0/16,100,US\n2.2.0.0/16,200,US\n3.3.0.0/16,300,DE\n5.5.0.0/16,500,US\n"), 0600)
+	if err != nil {
+		t.Fatalf("WriteFile: %s", err)
+	}
+	ipMap, err := ipmap.NewReloader(ipMapFile)
+	if err != nil {
+		t.Fatalf("NewReloader: %s", err)
+	}
+	cfg := &config.Config{Flags: &config.Flags{MaxOutboundPerASN: 1, MaxOutboundPerCountry: 2}}
+	c := &ConnectionManager{
+		cfg:            cfg,
+		ipMap:          ipMap,
+		activeOutgoing: map[string]struct{}{"1.1.1.1:16111": {}},
+	}
+	if !c.hasDiversityLimits() {
+		t.Fatalf("The diversity limits are unexpectedly disabled")
+	}
+
+	diversity := c.newOutboundDiversity()
+	tests := []struct {
+		ip        string
+		isAllowed bool
+	}{
+		// AS100 already has an outbound peer
+		{ip: "1.1.2.2", isAllowed: false},
+		{ip: "2.2.2.2", isAllowed: true},
+		// US already has two outbound peers
+		{ip: "5.5.5.5", isAllowed: false},
+		{ip: "3.3.3.3", isAllowed: true},
+		// IPs that aren't in the map aren't limited
+		{ip: "4.4.4.4", isAllowed: true},
+		{ip: "4.4.4.5", isAllowed: true},
+	}
+	for _, test := range tests {
+		ip := net.ParseIP(test.ip)
+		isAllowed := diversity.allows(appmessage.NewNetAddressIPPort(ip, 16111))
+		if isAllowed != test.isAllowed {
+			t.Fatalf("Unexpected result for %s. Want: %t, got: %t", test.ip, test.isAllowed, isAllowed)
+		}
+		if isAllowed {
+			diversity.add(ip)
+		}
+	}
+
+	statistics := c.OutboundDiversityStatistics()
+	if statistics.SkippedByASN != 1 || statistics.SkippedByCountry != 1 {
+		t.Fatalf("Unexpected skipped counts. Want: 1 by ASN and 1 by country, got: %d and %d",
+			statistics.SkippedByASN, statistics.SkippedByCountry)
+	}
+	if statistics.IPMapFile != ipMapFile {
+		t.Fatalf("Unexpected IP map file %s", statistics.IPMapFile)
+	}
+}
@@ -31,9 +31,24 @@ func (c *ConnectionManager) checkOutgoingConnections(connSet connectionSet) {
 		liveConnections, c.targetOutgoing, c.targetOutgoing-liveConnections)
 
 	connectionsNeededCount := c.targetOutgoing - len(c.activeOutgoing)
-	netAddresses := c.addressManager.RandomAddresses(connectionsNeededCount, connectedAddresses)
+	candidateCount := connectionsNeededCount
+	var diversity *outboundDiversity
+	if c.hasDiversityLimits() {
+		candidateCount *= diversityCandidateFactor
+		diversity = c.newOutboundDiversity()
+	}
+	netAddresses := c.addressManager.RandomAddresses(candidateCount, connectedAddresses)
 
+	attemptCount := 0
 	for _, netAddress := range netAddresses {
+		if attemptCount == connectionsNeededCount {
+			break
+		}
+		if diversity != nil && !diversity.allows(netAddress) {
+			continue
+		}
+		attemptCount++
+
 		addressString := netAddress.TCPAddress().String()
 
 		log.Debugf("Connecting to %s because we have %d outgoing connections and the target is "+
@@ -48,11 +63,14 @@ func (c *ConnectionManager) checkOutgoingConnections(connSet connectionSet) {
 		c.addressManager.MarkConnectionSuccess(netAddress)
 
 		c.activeOutgoing[addressString] = struct{}{}
+		if diversity != nil {
+			diversity.add(netAddress.IP)
+		}
 	}
 
-	if len(netAddresses) < connectionsNeededCount {
+	if attemptCount < connectionsNeededCount {
 		log.Debugf("Need %d more outgoing connections - seeding addresses from DNS",
-			connectionsNeededCount-len(netAddresses))
+			connectionsNeededCount-attemptCount)
 
 		// seedFromDNS is an asynchronous method, therefore addresses for connection
 		// should be available on next iteration
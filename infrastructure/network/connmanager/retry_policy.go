@@ -0,0 +1,113 @@
+package connmanager
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// RetryPolicy decides how the connection manager retries a requested
+// connection that it failed to make. The delay before the first retry
+// is InitialDelay, and it doubles after every consecutive failure up to
+// MaxDelay.
+type RetryPolicy struct {
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+
+	// Jitter is the fraction, between 0 and 1, by which every delay is
+	// randomly shortened or lengthened, so that nodes that lost a peer
+	// together don't all retry it at once.
+	Jitter float64
+
+	// MaxFailures is the amount of consecutive failures after which a
+	// persistent connection request is dropped. Zero retries forever.
+	MaxFailures int
+}
+
+// DefaultRetryPolicy is the retry policy of connection requests that
+// have no override
+var DefaultRetryPolicy = RetryPolicy{
+	InitialDelay: 30 * time.Second,
+	MaxDelay:     10 * time.Minute,
+}
+
+// Policy is the retry behavior of a ConnectionManager
+type Policy struct {
+	Default RetryPolicy
+
+	// PeerOverrides maps the addresses of persistent peers, as they were
+	// requested, to the retry policies that replace Default for them.
+	PeerOverrides map[string]*RetryPolicy
+}
+
+// DefaultPolicy returns a Policy that applies DefaultRetryPolicy to all
+// the connection requests
+func DefaultPolicy() *Policy {
+	return &Policy{Default: DefaultRetryPolicy}
+}
+
+func (p *Policy) validate() error {
+	err := p.Default.validate()
+	if err != nil {
+		return errors.Wrap(err, "invalid default retry policy")
+	}
+	for address, retryPolicy := range p.PeerOverrides {
+		err := retryPolicy.validate()
+		if err != nil {
+			return errors.Wrapf(err, "invalid retry policy for %s", address)
+		}
+	}
+	return nil
+}
+
+func (p *Policy) retryPolicy(address string) *RetryPolicy {
+	retryPolicy, ok := p.PeerOverrides[address]
+	if ok {
+		return retryPolicy
+	}
+	return &p.Default
+}
+
+func (rp *RetryPolicy) validate() error {
+	if rp.InitialDelay <= 0 {
+		return errors.Errorf("the initial delay must be positive, but is %s", rp.InitialDelay)
+	}
+	if rp.MaxDelay < rp.InitialDelay {
+		return errors.Errorf("the max delay %s is shorter than the initial delay %s", rp.MaxDelay, rp.InitialDelay)
+	}
+	if rp.Jitter < 0 || rp.Jitter > 1 {
+		return errors.Errorf("the jitter must be between 0 and 1, but is %f", rp.Jitter)
+	}
+	if rp.MaxFailures < 0 {
+		return errors.Errorf("the max failures can't be negative, but is %d", rp.MaxFailures)
+	}
+	return nil
+}
+
+// nextRetryDuration returns the delay before the retry that follows a
+// retry that was delayed by previousDuration, before jitter
+func (rp *RetryPolicy) nextRetryDuration(previousDuration time.Duration) time.Duration {
+	if previousDuration < rp.InitialDelay {
+		return rp.InitialDelay
+	}
+	if previousDuration*2 > rp.MaxDelay {
+		return rp.MaxDelay
+	}
+	return previousDuration * 2
+}
+
+// withJitter randomly shortens or lengthens the given delay by up to
+// the policy's jitter
+func (rp *RetryPolicy) withJitter(duration time.Duration) time.Duration {
+	if rp.Jitter == 0 {
+		return duration
+	}
+	return duration + time.Duration((rand.Float64()*2-1)*rp.Jitter*float64(duration))
+}
+
+// hasReachedMaxFailures returns whether a request that failed
+// failureCount consecutive times should be dropped
+func (rp *RetryPolicy) hasReachedMaxFailures(failureCount int) bool {
+	return rp.MaxFailures > 0 && failureCount >= rp.MaxFailures
+}
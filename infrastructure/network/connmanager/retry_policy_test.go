@@ -0,0 +1,58 @@
+package connmanager
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	retryPolicy := &RetryPolicy{InitialDelay: time.Second, MaxDelay: 5 * time.Second, Jitter: 0.5, MaxFailures: 3}
+	err := retryPolicy.validate()
+	if err != nil {
+		t.Fatalf("validate: %s", err)
+	}
+
+	expectedDurations := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	duration := time.Duration(0)
+	for i, expectedDuration := range expectedDurations {
+		duration = retryPolicy.nextRetryDuration(duration)
+		if duration != expectedDuration {
+			t.Fatalf("Unexpected duration of retry %d. Want: %s, got: %s", i, expectedDuration, duration)
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		delay := retryPolicy.withJitter(4 * time.Second)
+		if delay < 2*time.Second || delay > 6*time.Second {
+			t.Fatalf("The delay %s is jittered by more than half of 4s", delay)
+		}
+	}
+
+	if retryPolicy.hasReachedMaxFailures(2) || !retryPolicy.hasReachedMaxFailures(3) {
+		t.Fatalf("Unexpected max failures check")
+	}
+	if DefaultRetryPolicy.hasReachedMaxFailures(1000) {
+		t.Fatalf("The default retry policy unexpectedly gave up")
+	}
+
+	invalidRetryPolicies := []*RetryPolicy{
+		{InitialDelay: 0, MaxDelay: time.Second},
+		{InitialDelay: time.Second, MaxDelay: time.Millisecond},
+		{InitialDelay: time.Second, MaxDelay: time.Second, Jitter: 1.5},
+		{InitialDelay: time.Second, MaxDelay: time.Second, MaxFailures: -1},
+	}
+	for _, invalidRetryPolicy := range invalidRetryPolicies {
+		if invalidRetryPolicy.validate() == nil {
+			t.Fatalf("validate unexpectedly accepted %+v", invalidRetryPolicy)
+		}
+	}
+
+	override := &RetryPolicy{InitialDelay: time.Millisecond, MaxDelay: time.Second}
+	policy := &Policy{Default: DefaultRetryPolicy, PeerOverrides: map[string]*RetryPolicy{"127.0.0.1:16111": override}}
+	if policy.retryPolicy("127.0.0.1:16111") != override {
+		t.Fatalf("The override of 127.0.0.1:16111 wasn't used")
+	}
+	if *policy.retryPolicy("127.0.0.1:16112") != DefaultRetryPolicy {
+		t.Fatalf("The default retry policy wasn't used for 127.0.0.1:16112")
+	}
+}
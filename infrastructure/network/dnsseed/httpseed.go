@@ -0,0 +1,204 @@
+package dnsseed
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kaspanet/go-secp256k1"
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/util/mstime"
+	"github.com/pkg/errors"
+)
+
+const (
+	// httpSeedTimeout is the timeout of a whole HTTPS seed list request
+	httpSeedTimeout = 30 * time.Second
+
+	// maxHTTPSeedListSize and maxHTTPSeedAddresses protect against
+	// seed servers that respond with huge lists
+	maxHTTPSeedListSize  = 1 << 20
+	maxHTTPSeedAddresses = 1000
+
+	// maxHTTPSeedListAge is the age beyond which a seed list is rejected,
+	// so that an old list can't be replayed to direct nodes to peers that
+	// are long gone. maxHTTPSeedListClockSkew is how far in the future
+	// a list's timestamp may be.
+	maxHTTPSeedListAge       = 7 * 24 * time.Hour
+	maxHTTPSeedListClockSkew = 10 * time.Minute
+)
+
+// HTTPSeedAddress is an address of an HTTPS seed list, along with the
+// services it advertises.
+type HTTPSeedAddress struct {
+	NetAddress *appmessage.NetAddress
+	Services   appmessage.ServiceFlag
+}
+
+// OnHTTPSeed is the signature of the callback function which is invoked
+// when HTTPS seeding is successful.
+type OnHTTPSeed func(addresses []*HTTPSeedAddress)
+
+// httpSeedList is the signed envelope that HTTPS seeds respond with.
+// Signature is the hex of a Schnorr signature over the SHA256 of the
+// exact bytes of Payload.
+type httpSeedList struct {
+	Payload   json.RawMessage `json:"payload"`
+	Signature string          `json:"signature"`
+}
+
+type httpSeedListPayload struct {
+	Network   string                 `json:"network"`
+	Timestamp int64                  `json:"timestamp"`
+	Addresses []*httpSeedListAddress `json:"addresses"`
+}
+
+type httpSeedListAddress struct {
+	IP       string `json:"ip"`
+	Port     uint16 `json:"port"`
+	Services uint64 `json:"services"`
+}
+
+// NewHTTPSeedClient returns an HTTP client for SeedFromHTTPS that dials
+// with the given function, so that HTTPS seeding goes through the same
+// proxy as the rest of the node's connections.
+func NewHTTPSeedClient(dialFn func(string, string, time.Duration) (net.Conn, error)) *http.Client {
+	return &http.Client{
+		Timeout: httpSeedTimeout,
+		Transport: &http.Transport{
+			Dial: func(network, address string) (net.Conn, error) {
+				return dialFn(network, address, httpSeedTimeout)
+			},
+			TLSHandshakeTimeout: httpSeedTimeout,
+		},
+	}
+}
+
+// SeedFromHTTPS fetches signed seed lists from the given HTTPS URLs. It's
+// the fallback for when DNS seeding fails or is censored, so a list is only
+// accepted if it's signed by publicKey, is for the network of dagParams,
+// and isn't too old.
+func SeedFromHTTPS(dagParams *dagconfig.Params, urls []string, publicKey *secp256k1.SchnorrPublicKey,
+	client *http.Client, seedFn OnHTTPSeed) {
+
+	for _, url := range urls {
+		url := url
+		spawn("SeedFromHTTPS", func() {
+			addresses, err := fetchHTTPSeedList(dagParams, url, publicKey, client)
+			if err != nil {
+				log.Infof("HTTPS discovery failed on seed %s: %s", url, err)
+				return
+			}
+
+			log.Infof("%d addresses found from HTTPS seed %s", len(addresses), url)
+
+			if len(addresses) == 0 {
+				return
+			}
+			seedFn(addresses)
+		})
+	}
+}
+
+func fetchHTTPSeedList(dagParams *dagconfig.Params, url string, publicKey *secp256k1.SchnorrPublicKey,
+	client *http.Client) ([]*HTTPSeedAddress, error) {
+
+	response, err := client.Get(url)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status %s", response.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxHTTPSeedListSize+1))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if len(body) > maxHTTPSeedListSize {
+		return nil, errors.Errorf("the seed list is larger than %d bytes", maxHTTPSeedListSize)
+	}
+	return parseHTTPSeedList(dagParams, body, publicKey, mstime.Now())
+}
+
+func parseHTTPSeedList(dagParams *dagconfig.Params, body []byte, publicKey *secp256k1.SchnorrPublicKey,
+	now mstime.Time) ([]*HTTPSeedAddress, error) {
+
+	list := &httpSeedList{}
+	err := json.Unmarshal(body, list)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding the seed list")
+	}
+	err = verifyHTTPSeedListSignature(list, publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := &httpSeedListPayload{}
+	err = json.Unmarshal(list.Payload, payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding the seed list payload")
+	}
+	if payload.Network != dagParams.Name {
+		return nil, errors.Errorf("the seed list is for network %s rather than %s", payload.Network, dagParams.Name)
+	}
+	timestamp := mstime.UnixMilliseconds(payload.Timestamp)
+	if timestamp.Before(now.Add(-maxHTTPSeedListAge)) {
+		return nil, errors.Errorf("the seed list from %s is too old", timestamp)
+	}
+	if timestamp.After(now.Add(maxHTTPSeedListClockSkew)) {
+		return nil, errors.Errorf("the seed list from %s is in the future", timestamp)
+	}
+	if len(payload.Addresses) > maxHTTPSeedAddresses {
+		return nil, errors.Errorf("the seed list has %d addresses, which is more than the maximum of %d",
+			len(payload.Addresses), maxHTTPSeedAddresses)
+	}
+
+	randSource := rand.New(rand.NewSource(time.Now().UnixNano()))
+	// if this errors then we have *real* problems
+	defaultPort, _ := strconv.Atoi(dagParams.DefaultPort)
+	addresses := make([]*HTTPSeedAddress, 0, len(payload.Addresses))
+	for _, listAddress := range payload.Addresses {
+		ip := net.ParseIP(listAddress.IP)
+		if ip == nil {
+			return nil, errors.Errorf("the seed list has an invalid IP %s", listAddress.IP)
+		}
+		port := listAddress.Port
+		if port == 0 {
+			port = uint16(defaultPort)
+		}
+		addresses = append(addresses, &HTTPSeedAddress{
+			NetAddress: appmessage.NewNetAddressTimestamp(
+				// seed with addresses from a time randomly selected
+				// between 3 and 7 days ago.
+				now.Add(-1*time.Second*time.Duration(secondsIn3Days+
+					randSource.Int31n(secondsIn4Days))),
+				ip, port),
+			Services: appmessage.ServiceFlag(listAddress.Services),
+		})
+	}
+	return addresses, nil
+}
+
+func verifyHTTPSeedListSignature(list *httpSeedList, publicKey *secp256k1.SchnorrPublicKey) error {
+	signatureBytes, err := hex.DecodeString(list.Signature)
+	if err != nil {
+		return errors.Wrap(err, "error decoding the seed list signature")
+	}
+	signature, err := secp256k1.DeserializeSchnorrSignatureFromSlice(signatureBytes)
+	if err != nil {
+		return errors.Wrap(err, "error deserializing the seed list signature")
+	}
+	payloadHash := secp256k1.Hash(sha256.Sum256(list.Payload))
+	if !publicKey.SchnorrVerify(&payloadHash, signature) {
+		return errors.New("the seed list signature is invalid")
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package dnsseed
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/go-secp256k1"
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/util/mstime"
+)
+
+func signedHTTPSeedList(t *testing.T, keyPair *secp256k1.SchnorrKeyPair, payload *httpSeedListPayload) []byte {
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("Marshal: %s", err)
+	}
+	payloadHash := secp256k1.Hash(sha256.Sum256(payloadBytes))
+	signature, err := keyPair.SchnorrSign(&payloadHash)
+	if err != nil {
+		t.Fatalf("SchnorrSign: %s", err)
+	}
+	list, err := json.Marshal(&httpSeedList{Payload: payloadBytes, Signature: signature.Serialize().String()})
+	if err != nil {
+		t.Fatalf("Marshal: %s", err)
+	}
+	return list
+}
+
+func TestParseHTTPSeedList(t *testing.T) {
+	dagParams := &dagconfig.MainnetParams
+	keyPair, err := secp256k1.GenerateSchnorrKeyPair()
+	if err != nil {
+		t.Fatalf("GenerateSchnorrKeyPair: %s", err)
+	}
+	publicKey, err := keyPair.SchnorrPublicKey()
+	if err != nil {
+		t.Fatalf("SchnorrPublicKey: %s", err)
+	}
+	otherKeyPair, err := secp256k1.GenerateSchnorrKeyPair()
+	if err != nil {
+		t.Fatalf("GenerateSchnorrKeyPair: %s", err)
+	}
+
+	now := mstime.Now()
+	validPayload := func() *httpSeedListPayload {
+		return &httpSeedListPayload{
+			Network:   dagParams.Name,
+			Timestamp: now.UnixMilliseconds(),
+			Addresses: []*httpSeedListAddress{
+				{IP: "12.34.56.78", Port: 1234, Services: uint64(appmessage.SFNodeNetwork)},
+				{IP: "2001:db8::1"},
+			},
+		}
+	}
+
+	addresses, err := parseHTTPSeedList(dagParams, signedHTTPSeedList(t, keyPair, validPayload()), publicKey, now)
+	if err != nil {
+		t.Fatalf("parseHTTPSeedList: %s", err)
+	}
+	if len(addresses) != 2 {
+		t.Fatalf("Unexpected amount of addresses. Want: 2, got: %d", len(addresses))
+	}
+	if addresses[0].NetAddress.Port != 1234 || addresses[0].Services != appmessage.SFNodeNetwork {
+		t.Fatalf("Unexpected first address %s with services %d", addresses[0].NetAddress, addresses[0].Services)
+	}
+	if addresses[1].NetAddress.Port != 16111 {
+		t.Fatalf("The second address didn't get the default port. Got: %d", addresses[1].NetAddress.Port)
+	}
+
+	tamperedList := &httpSeedList{}
+	err = json.Unmarshal(signedHTTPSeedList(t, keyPair, validPayload()), tamperedList)
+	if err != nil {
+		t.Fatalf("Unmarshal: %s", err)
+	}
+	tamperedPayload := validPayload()
+	tamperedPayload.Addresses[0].IP = "87.65.43.21"
+	tamperedList.Payload, err = json.Marshal(tamperedPayload)
+	if err != nil {
+		t.Fatalf("Marshal: %s", err)
+	}
+	tamperedListBytes, err := json.Marshal(tamperedList)
+	if err != nil {
+		t.Fatalf("Marshal: %s", err)
+	}
+
+	otherNetworkPayload := validPayload()
+	otherNetworkPayload.Network = dagconfig.TestnetParams.Name
+	oldPayload := validPayload()
+	oldPayload.Timestamp = now.Add(-maxHTTPSeedListAge - time.Hour).UnixMilliseconds()
+	futurePayload := validPayload()
+	futurePayload.Timestamp = now.Add(maxHTTPSeedListClockSkew + time.Hour).UnixMilliseconds()
+	invalidIPPayload := validPayload()
+	invalidIPPayload.Addresses[0].IP = "not an IP"
+
+	tests := []struct {
+		name string
+		list []byte
+	}{
+		{name: "signed by another key", list: signedHTTPSeedList(t, otherKeyPair, validPayload())},
+		{name: "tampered", list: tamperedListBytes},
+		{name: "another network", list: signedHTTPSeedList(t, keyPair, otherNetworkPayload)},
+		{name: "too old", list: signedHTTPSeedList(t, keyPair, oldPayload)},
+		{name: "in the future", list: signedHTTPSeedList(t, keyPair, futurePayload)},
+		{name: "invalid IP", list: signedHTTPSeedList(t, keyPair, invalidIPPayload)},
+		{name: "not JSON", list: []byte("not JSON")},
+	}
+	for _, test := range tests {
+		_, err := parseHTTPSeedList(dagParams, test.list, publicKey, now)
+		if err == nil {
+			t.Errorf("parseHTTPSeedList unexpectedly accepted a list that's %s", test.name)
+		}
+	}
+}
+
+func TestSeedFromHTTPS(t *testing.T) {
+	dagParams := &dagconfig.MainnetParams
+	keyPair, err := secp256k1.GenerateSchnorrKeyPair()
+	if err != nil {
+		t.Fatalf("GenerateSchnorrKeyPair: %s", err)
+	}
+	publicKey, err := keyPair.SchnorrPublicKey()
+	if err != nil {
+		t.Fatalf("SchnorrPublicKey: %s", err)
+	}
+	list := signedHTTPSeedList(t, keyPair, &httpSeedListPayload{
+		Network:   dagParams.Name,
+		Timestamp: mstime.Now().UnixMilliseconds(),
+		Addresses: []*httpSeedListAddress{{IP: "12.34.56.78", Port: 16111}},
+	})
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		_, _ = writer.Write(list)
+	}))
+	defer server.Close()
+
+	seeded := make(chan []*HTTPSeedAddress)
+	SeedFromHTTPS(dagParams, []string{server.URL}, publicKey, server.Client(), func(addresses []*HTTPSeedAddress) {
+		seeded <- addresses
+	})
+	select {
+	case addresses := <-seeded:
+		if len(addresses) != 1 || addresses[0].NetAddress.IP.String() != "12.34.56.78" {
+			t.Fatalf("Unexpected seeded addresses %v", addresses)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Timed out waiting for the HTTPS seed")
+	}
+}
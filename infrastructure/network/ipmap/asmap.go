@@ -0,0 +1,260 @@
+package ipmap
+
+import (
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+// ASMap maps IPs to the ASNs that announce them, as encoded in the asmap
+// format of Bitcoin Core. An asmap is a compact program of RETURN, JUMP,
+// MATCH and DEFAULT instructions that walks the bits of an IPv6 address,
+// with IPv4 addresses mapped into ::ffff:0:0/96. ASMap has no countries.
+type ASMap struct {
+	bits []bool
+}
+
+type asmapInstruction uint32
+
+const (
+	asmapReturn  asmapInstruction = 0
+	asmapJump    asmapInstruction = 1
+	asmapMatch   asmapInstruction = 2
+	asmapDefault asmapInstruction = 3
+)
+
+// invalidASMapValue is returned by decodeBits when the value
+// straddles the end of the asmap
+const invalidASMapValue = 0xffffffff
+
+var (
+	asmapTypeBitSizes  = []uint8{0, 0, 1}
+	asmapASNBitSizes   = []uint8{15, 16, 17, 18, 19, 20, 21, 22, 23, 24}
+	asmapMatchBitSizes = []uint8{1, 2, 3, 4, 5, 6, 7, 8}
+	asmapJumpBitSizes  = []uint8{5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25,
+		26, 27, 28, 29, 30}
+)
+
+// DecodeASMap decodes the given asmap, and fails if it's malformed
+func DecodeASMap(data []byte) (*ASMap, error) {
+	bits := make([]bool, 0, len(data)*8)
+	for _, dataByte := range data {
+		for bit := 0; bit < 8; bit++ {
+			bits = append(bits, (dataByte>>bit)&1 == 1)
+		}
+	}
+	asmap := &ASMap{bits: bits}
+	err := asmap.check()
+	if err != nil {
+		return nil, err
+	}
+	return asmap, nil
+}
+
+// Lookup returns the ASN of the given IP, or zero if it's unknown
+func (asmap *ASMap) Lookup(ip net.IP) Info {
+	ipv6 := ip.To16()
+	if ipv6 == nil {
+		return Info{}
+	}
+	ipBits := make([]bool, 128)
+	for i := range ipBits {
+		ipBits[i] = (ipv6[i/8]>>(7-i%8))&1 == 1
+	}
+	return Info{ASN: asmap.interpret(ipBits)}
+}
+
+// interpret runs the asmap on the given bits of an IP. A malformed asmap
+// gives zero, which is never a valid ASN.
+func (asmap *ASMap) interpret(ipBits []bool) uint32 {
+	position := 0
+	remainingBits := len(ipBits)
+	defaultASN := uint32(0)
+	for position < len(asmap.bits) {
+		instruction := asmapInstruction(asmap.decodeBits(&position, 0, asmapTypeBitSizes))
+		switch instruction {
+		case asmapReturn:
+			asn := asmap.decodeBits(&position, 1, asmapASNBitSizes)
+			if asn == invalidASMapValue {
+				return 0
+			}
+			return asn
+		case asmapJump:
+			jump := asmap.decodeBits(&position, 17, asmapJumpBitSizes)
+			if jump == invalidASMapValue || remainingBits == 0 || int64(jump) >= int64(len(asmap.bits)-position) {
+				return 0
+			}
+			if ipBits[len(ipBits)-remainingBits] {
+				position += int(jump)
+			}
+			remainingBits--
+		case asmapMatch:
+			match := asmap.decodeBits(&position, 2, asmapMatchBitSizes)
+			if match == invalidASMapValue {
+				return 0
+			}
+			matchLength := bitLength(match) - 1
+			if remainingBits < matchLength {
+				return 0
+			}
+			for bit := 0; bit < matchLength; bit++ {
+				expectedBit := (match>>(matchLength-1-bit))&1 == 1
+				if ipBits[len(ipBits)-remainingBits] != expectedBit {
+					return defaultASN
+				}
+				remainingBits--
+			}
+		case asmapDefault:
+			defaultASN = asmap.decodeBits(&position, 1, asmapASNBitSizes)
+			if defaultASN == invalidASMapValue {
+				return 0
+			}
+		default:
+			return 0
+		}
+	}
+	return 0
+}
+
+// check walks all the instructions of the asmap once, and makes sure
+// that every path through it ends in a RETURN without running past the
+// end of the asmap or consuming more bits than an IP has. It follows the
+// sanity check of Bitcoin Core, so it accepts the same asmaps.
+func (asmap *ASMap) check() error {
+	type jumpTarget struct {
+		position      int
+		remainingBits int
+	}
+	var jumpTargets []jumpTarget
+	position := 0
+	remainingBits := 128
+	previousInstruction := asmapJump
+	hadIncompleteMatch := false
+	for position < len(asmap.bits) {
+		if len(jumpTargets) > 0 && position >= jumpTargets[len(jumpTargets)-1].position {
+			return errors.New("the asmap jumps into the middle of an instruction")
+		}
+		instruction := asmapInstruction(asmap.decodeBits(&position, 0, asmapTypeBitSizes))
+		switch instruction {
+		case asmapReturn:
+			if previousInstruction == asmapDefault {
+				return errors.New("the asmap has a RETURN right after a DEFAULT")
+			}
+			asn := asmap.decodeBits(&position, 1, asmapASNBitSizes)
+			if asn == invalidASMapValue {
+				return errors.New("the asmap has an ASN that straddles its end")
+			}
+			if len(jumpTargets) == 0 {
+				if len(asmap.bits)-position > 7 {
+					return errors.New("the asmap has data after its last RETURN")
+				}
+				for _, bit := range asmap.bits[position:] {
+					if bit {
+						return errors.New("the asmap has non-zero padding")
+					}
+				}
+				return nil
+			}
+			// Continue as if the last jump was taken
+			if position != jumpTargets[len(jumpTargets)-1].position {
+				return errors.New("the asmap has unreachable code")
+			}
+			remainingBits = jumpTargets[len(jumpTargets)-1].remainingBits
+			jumpTargets = jumpTargets[:len(jumpTargets)-1]
+			previousInstruction = asmapJump
+		case asmapJump:
+			jump := asmap.decodeBits(&position, 17, asmapJumpBitSizes)
+			if jump == invalidASMapValue {
+				return errors.New("the asmap has a jump that straddles its end")
+			}
+			if int64(jump) > int64(len(asmap.bits)-position) {
+				return errors.New("the asmap jumps past its end")
+			}
+			if remainingBits == 0 {
+				return errors.New("the asmap consumes more bits than an IP has")
+			}
+			remainingBits--
+			target := position + int(jump)
+			if len(jumpTargets) > 0 && target >= jumpTargets[len(jumpTargets)-1].position {
+				return errors.New("the asmap has intersecting jumps")
+			}
+			jumpTargets = append(jumpTargets, jumpTarget{position: target, remainingBits: remainingBits})
+			previousInstruction = asmapJump
+		case asmapMatch:
+			match := asmap.decodeBits(&position, 2, asmapMatchBitSizes)
+			if match == invalidASMapValue {
+				return errors.New("the asmap has a match that straddles its end")
+			}
+			matchLength := bitLength(match) - 1
+			if previousInstruction != asmapMatch {
+				hadIncompleteMatch = false
+			}
+			if matchLength < 8 && hadIncompleteMatch {
+				return errors.New("the asmap has consecutive incomplete matches")
+			}
+			hadIncompleteMatch = matchLength < 8
+			if remainingBits < matchLength {
+				return errors.New("the asmap consumes more bits than an IP has")
+			}
+			remainingBits -= matchLength
+			previousInstruction = asmapMatch
+		case asmapDefault:
+			if previousInstruction == asmapDefault {
+				return errors.New("the asmap has consecutive DEFAULTs")
+			}
+			asn := asmap.decodeBits(&position, 1, asmapASNBitSizes)
+			if asn == invalidASMapValue {
+				return errors.New("the asmap has an ASN that straddles its end")
+			}
+			previousInstruction = asmapDefault
+		default:
+			return errors.New("the asmap has an instruction that straddles its end")
+		}
+	}
+	return errors.New("the asmap ends without a RETURN")
+}
+
+// decodeBits decodes a variable length integer at the given position of
+// the asmap, and advances the position past it. The integer is encoded
+// as a class, which is a run of one bits terminated by a zero bit (the
+// last class has no terminator), followed by a mantissa of the size of
+// the class. invalidASMapValue is returned if the asmap ends within the
+// integer.
+func (asmap *ASMap) decodeBits(position *int, minValue uint32, bitSizes []uint8) uint32 {
+	value := minValue
+	for i, bitSize := range bitSizes {
+		isLastClass := i == len(bitSizes)-1
+		isHigherClass := false
+		if !isLastClass {
+			if *position >= len(asmap.bits) {
+				return invalidASMapValue
+			}
+			isHigherClass = asmap.bits[*position]
+			*position++
+		}
+		if isHigherClass {
+			value += 1 << bitSize
+			continue
+		}
+		for bit := 0; bit < int(bitSize); bit++ {
+			if *position >= len(asmap.bits) {
+				return invalidASMapValue
+			}
+			if asmap.bits[*position] {
+				value += 1 << (int(bitSize) - 1 - bit)
+			}
+			*position++
+		}
+		return value
+	}
+	return invalidASMapValue
+}
+
+// bitLength returns the amount of bits needed to represent value
+func bitLength(value uint32) int {
+	length := 0
+	for ; value > 0; value >>= 1 {
+		length++
+	}
+	return length
+}
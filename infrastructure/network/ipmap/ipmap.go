@@ -0,0 +1,44 @@
+package ipmap
+
+import (
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Info is what a Map knows about an IP. A zero ASN or an empty Country
+// means that it's unknown.
+type Info struct {
+	ASN     uint32
+	Country string
+}
+
+// Map maps IPs to the ASNs that announce them and to their countries.
+// Implementations must be safe for concurrent use.
+type Map interface {
+	Lookup(ip net.IP) Info
+}
+
+// LoadFile loads the map at the given path. Files with a .csv extension
+// are read as a Table, and all others as an asmap.
+func LoadFile(path string) (Map, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		table, err := ParseTable(data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing %s", path)
+		}
+		return table, nil
+	}
+	asmap, err := DecodeASMap(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error decoding %s", path)
+	}
+	return asmap, nil
+}
@@ -0,0 +1,206 @@
+package ipmap
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// asmapEncoder writes asmap instructions, for building test asmaps
+type asmapEncoder struct {
+	bits []bool
+}
+
+func (encoder *asmapEncoder) encodeBits(value uint32, minValue uint32, bitSizes []uint8) {
+	value -= minValue
+	for i, bitSize := range bitSizes {
+		isLastClass := i == len(bitSizes)-1
+		if !isLastClass && value >= 1<<bitSize {
+			encoder.bits = append(encoder.bits, true)
+			value -= 1 << bitSize
+			continue
+		}
+		if !isLastClass {
+			encoder.bits = append(encoder.bits, false)
+		}
+		for bit := int(bitSize) - 1; bit >= 0; bit-- {
+			encoder.bits = append(encoder.bits, (value>>bit)&1 == 1)
+		}
+		return
+	}
+}
+
+func (encoder *asmapEncoder) returnASN(asn uint32) {
+	encoder.encodeBits(uint32(asmapReturn), 0, asmapTypeBitSizes)
+	encoder.encodeBits(asn, 1, asmapASNBitSizes)
+}
+
+func (encoder *asmapEncoder) defaultASN(asn uint32) {
+	encoder.encodeBits(uint32(asmapDefault), 0, asmapTypeBitSizes)
+	encoder.encodeBits(asn, 1, asmapASNBitSizes)
+}
+
+func (encoder *asmapEncoder) jump(offset int) {
+	encoder.encodeBits(uint32(asmapJump), 0, asmapTypeBitSizes)
+	encoder.encodeBits(uint32(offset), 17, asmapJumpBitSizes)
+}
+
+// matchBits matches the bits of the given IP from startBit up to endBit,
+// 8 bits at a time
+func (encoder *asmapEncoder) matchBits(ip net.IP, startBit int, endBit int) {
+	ipv6 := ip.To16()
+	for start := startBit; start < endBit; start += 8 {
+		length := endBit - start
+		if length > 8 {
+			length = 8
+		}
+		match := uint32(1)
+		for bit := start; bit < start+length; bit++ {
+			match = match<<1 | uint32((ipv6[bit/8]>>(7-bit%8))&1)
+		}
+		encoder.encodeBits(uint32(asmapMatch), 0, asmapTypeBitSizes)
+		encoder.encodeBits(match, 2, asmapMatchBitSizes)
+	}
+}
+
+func (encoder *asmapEncoder) bytes() []byte {
+	data := make([]byte, (len(encoder.bits)+7)/8)
+	for i, bit := range encoder.bits {
+		if bit {
+			data[i/8] |= 1 << (i % 8)
+		}
+	}
+	return data
+}
+
+// testASMap maps 1.2.3.0/24 to AS13335, the rest of the IPs whose first
+// bit is zero to AS100, and the IPs whose first bit is one to AS200
+func testASMap() []byte {
+	zeroBranch := &asmapEncoder{}
+	zeroBranch.defaultASN(100)
+	// The jump already consumed the first bit
+	zeroBranch.matchBits(net.ParseIP("1.2.3.0"), 1, 120)
+	zeroBranch.returnASN(13335)
+
+	encoder := &asmapEncoder{}
+	encoder.jump(len(zeroBranch.bits))
+	encoder.bits = append(encoder.bits, zeroBranch.bits...)
+	encoder.returnASN(200)
+	return encoder.bytes()
+}
+
+func TestASMap(t *testing.T) {
+	asmap, err := DecodeASMap(testASMap())
+	if err != nil {
+		t.Fatalf("DecodeASMap: %s", err)
+	}
+	tests := []struct {
+		ip  string
+		asn uint32
+	}{
+		{ip: "1.2.3.4", asn: 13335},
+		{ip: "1.2.3.255", asn: 13335},
+		{ip: "1.2.4.4", asn: 100},
+		{ip: "2001:db8::1", asn: 100},
+		{ip: "8000::1", asn: 200},
+	}
+	for _, test := range tests {
+		info := asmap.Lookup(net.ParseIP(test.ip))
+		if info.ASN != test.asn {
+			t.Errorf("Unexpected ASN of %s. Want: %d, got: %d", test.ip, test.asn, info.ASN)
+		}
+	}
+
+	data := testASMap()
+	_, err = DecodeASMap(data[:len(data)-3])
+	if err == nil {
+		t.Fatalf("DecodeASMap unexpectedly accepted a truncated asmap")
+	}
+	_, err = DecodeASMap(append(data, 0))
+	if err == nil {
+		t.Fatalf("DecodeASMap unexpectedly accepted an asmap with excessive padding")
+	}
+}
+
+func TestTable(t *testing.T) {
+	table, err := ParseTable([]byte(`# network,asn,country
+1.2.0.0/16,AS13335,au
+1.2.3.0/24,,US
+2001:db8::/32,64500,
+`))
+	if err != nil {
+		t.Fatalf("ParseTable: %s", err)
+	}
+	tests := []struct {
+		ip   string
+		info Info
+	}{
+		{ip: "1.2.4.4", info: Info{ASN: 13335, Country: "AU"}},
+		{ip: "1.2.3.4", info: Info{Country: "US"}},
+		{ip: "2001:db8::1", info: Info{ASN: 64500}},
+		{ip: "5.6.7.8", info: Info{}},
+	}
+	for _, test := range tests {
+		info := table.Lookup(net.ParseIP(test.ip))
+		if info != test.info {
+			t.Errorf("Unexpected info of %s. Want: %+v, got: %+v", test.ip, test.info, info)
+		}
+	}
+
+	_, err = ParseTable([]byte("1.2.3.0/24,notanasn,US\n"))
+	if err == nil {
+		t.Fatalf("ParseTable unexpectedly accepted an invalid ASN")
+	}
+}
+
+func TestReloader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ipmap.csv")
+	err := ioutil.WriteFile(path, []byte("1.2.3.0/24,1,US\n"), 0600)
+	if err != nil {
+		t.Fatalf("WriteFile: %s", err)
+	}
+	reloader, err := NewReloader(path)
+	if err != nil {
+		t.Fatalf("NewReloader: %s", err)
+	}
+	ip := net.ParseIP("1.2.3.4")
+	if reloader.Lookup(ip).ASN != 1 {
+		t.Fatalf("Unexpected ASN %d", reloader.Lookup(ip).ASN)
+	}
+
+	// A file that fails to load keeps the previous map
+	err = ioutil.WriteFile(path, []byte("invalid"), 0600)
+	if err != nil {
+		t.Fatalf("WriteFile: %s", err)
+	}
+	err = os.Chtimes(path, time.Now(), time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("Chtimes: %s", err)
+	}
+	_, err = reloader.reloadIfChanged()
+	if err == nil {
+		t.Fatalf("reloadIfChanged unexpectedly loaded an invalid file")
+	}
+	if reloader.Lookup(ip).ASN != 1 {
+		t.Fatalf("The previous map wasn't kept")
+	}
+
+	err = ioutil.WriteFile(path, []byte("1.2.3.0/24,2,US\n"), 0600)
+	if err != nil {
+		t.Fatalf("WriteFile: %s", err)
+	}
+	err = os.Chtimes(path, time.Now(), time.Now().Add(2*time.Minute))
+	if err != nil {
+		t.Fatalf("Chtimes: %s", err)
+	}
+	reloader.Start(10 * time.Millisecond)
+	defer reloader.Stop()
+	for start := time.Now(); reloader.Lookup(ip).ASN != 2; time.Sleep(10 * time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("Timed out waiting for the map to be reloaded")
+		}
+	}
+}
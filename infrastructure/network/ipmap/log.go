@@ -0,0 +1,9 @@
+package ipmap
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/util/panics"
+)
+
+var log = logger.RegisterSubSystem("CMGR")
+var spawn = panics.GoroutineWrapperFunc(log)
@@ -0,0 +1,120 @@
+package ipmap
+
+import (
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Reloader is a Map that's loaded from a file, and reloads it whenever the
+// file changes, so that an updated map can be dropped in without a restart.
+// A file that fails to load keeps the previous map in use.
+type Reloader struct {
+	path string
+
+	lock     sync.RWMutex
+	current  Map
+	modTime  time.Time
+	loadTime time.Time
+
+	quit chan struct{}
+	done chan struct{}
+}
+
+// NewReloader loads the map at the given path, and fails if it can't
+func NewReloader(path string) (*Reloader, error) {
+	reloader := &Reloader{path: path}
+	_, err := reloader.reloadIfChanged()
+	if err != nil {
+		return nil, err
+	}
+	return reloader, nil
+}
+
+// Lookup looks the given IP up in the current map
+func (reloader *Reloader) Lookup(ip net.IP) Info {
+	reloader.lock.RLock()
+	defer reloader.lock.RUnlock()
+
+	return reloader.current.Lookup(ip)
+}
+
+// Path returns the path of the map file
+func (reloader *Reloader) Path() string {
+	return reloader.path
+}
+
+// LoadTime returns when the current map was loaded
+func (reloader *Reloader) LoadTime() time.Time {
+	reloader.lock.RLock()
+	defer reloader.lock.RUnlock()
+
+	return reloader.loadTime
+}
+
+// Start checks the file for changes every interval until Stop is called
+func (reloader *Reloader) Start(interval time.Duration) {
+	reloader.quit = make(chan struct{})
+	reloader.done = make(chan struct{})
+	spawn("Reloader.reloadLoop", func() {
+		defer close(reloader.done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				isReloaded, err := reloader.reloadIfChanged()
+				if err != nil {
+					log.Warnf("Error reloading the IP map %s, so the previous one is kept: %s", reloader.path, err)
+					continue
+				}
+				if isReloaded {
+					log.Infof("Reloaded the IP map %s", reloader.path)
+				}
+			case <-reloader.quit:
+				return
+			}
+		}
+	})
+}
+
+// Stop stops checking the file for changes
+func (reloader *Reloader) Stop() {
+	if reloader.quit == nil {
+		return
+	}
+	close(reloader.quit)
+	<-reloader.done
+}
+
+// reloadIfChanged loads the file if it was modified since it was last
+// loaded, and returns whether it did
+func (reloader *Reloader) reloadIfChanged() (bool, error) {
+	fileInfo, err := os.Stat(reloader.path)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	reloader.lock.RLock()
+	isChanged := reloader.current == nil || !fileInfo.ModTime().Equal(reloader.modTime)
+	reloader.lock.RUnlock()
+	if !isChanged {
+		return false, nil
+	}
+
+	ipMap, err := LoadFile(reloader.path)
+	if err != nil {
+		return false, err
+	}
+
+	reloader.lock.Lock()
+	defer reloader.lock.Unlock()
+
+	reloader.current = ipMap
+	reloader.modTime = fileInfo.ModTime()
+	reloader.loadTime = time.Now()
+	return true, nil
+}
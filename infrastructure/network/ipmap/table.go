@@ -0,0 +1,95 @@
+package ipmap
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Table maps IP networks to ASNs and countries. It's parsed from CSV lines
+// of the form "network,asn,country", such as "1.2.3.0/24,13335,AU", which
+// can be generated from the CSV databases of MaxMind. Either the ASN or the
+// country may be empty. Lines that start with # are comments. An IP gets
+// the values of the most specific network that contains it.
+type Table struct {
+	// entries holds the entries of every prefix length, keyed by the
+	// masked 16-byte network
+	entries    [net.IPv6len*8 + 1]map[[net.IPv6len]byte]Info
+	prefixLens []int
+}
+
+// ParseTable parses a Table from the given CSV data
+func ParseTable(data []byte) (*Table, error) {
+	table := &Table{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Split(line, ",")
+		if len(fields) != 3 {
+			return nil, errors.Errorf("line %d has %d fields instead of 3", lineNumber, len(fields))
+		}
+		_, network, err := net.ParseCIDR(strings.TrimSpace(fields[0]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d has an invalid network", lineNumber)
+		}
+		info := Info{Country: strings.ToUpper(strings.TrimSpace(fields[2]))}
+		asnField := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(fields[1])), "AS")
+		if asnField != "" {
+			asn, err := strconv.ParseUint(asnField, 10, 32)
+			if err != nil {
+				return nil, errors.Wrapf(err, "line %d has an invalid ASN", lineNumber)
+			}
+			info.ASN = uint32(asn)
+		}
+		table.add(network, info)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return table, nil
+}
+
+func (table *Table) add(network *net.IPNet, info Info) {
+	prefixLen, bits := network.Mask.Size()
+	if bits == net.IPv4len*8 {
+		// IPv4 networks are looked up within ::ffff:0:0/96
+		prefixLen += (net.IPv6len - net.IPv4len) * 8
+	}
+	if table.entries[prefixLen] == nil {
+		table.entries[prefixLen] = make(map[[net.IPv6len]byte]Info)
+		table.prefixLens = append(table.prefixLens, prefixLen)
+		// Keep the most specific prefixes first
+		for i := len(table.prefixLens) - 1; i > 0 && table.prefixLens[i] > table.prefixLens[i-1]; i-- {
+			table.prefixLens[i], table.prefixLens[i-1] = table.prefixLens[i-1], table.prefixLens[i]
+		}
+	}
+	table.entries[prefixLen][maskedIP(network.IP.To16(), prefixLen)] = info
+}
+
+// Lookup returns what the table knows about the given IP
+func (table *Table) Lookup(ip net.IP) Info {
+	ipv6 := ip.To16()
+	if ipv6 == nil {
+		return Info{}
+	}
+	for _, prefixLen := range table.prefixLens {
+		info, ok := table.entries[prefixLen][maskedIP(ipv6, prefixLen)]
+		if ok {
+			return info
+		}
+	}
+	return Info{}
+}
+
+func maskedIP(ipv6 net.IP, prefixLen int) [net.IPv6len]byte {
+	var masked [net.IPv6len]byte
+	copy(masked[:], ipv6.Mask(net.CIDRMask(prefixLen, net.IPv6len*8)))
+	return masked
+}
@@ -1,6 +1,7 @@
 package netadapter
 
 import (
+	"crypto/tls"
 	"sync"
 	"sync/atomic"
 
@@ -10,6 +11,7 @@ import (
 	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcauth"
 	"github.com/pkg/errors"
 )
 
@@ -27,10 +29,14 @@ type NetAdapter struct {
 	id                   *id.ID
 	p2pServer            server.P2PServer
 	p2pRouterInitializer RouterInitializer
-	rpcServer            server.Server
+	rpcServer            server.AuthenticatingServer
 	rpcRouterInitializer RouterInitializer
+	apiServer            server.AuthenticatingServer
 	stop                 uint32
 
+	// rpcTLSConfig is nil unless the RPC server is served over TLS
+	rpcTLSConfig *rpcauth.ReloadableServerTLSConfig
+
 	p2pConnections     map[*NetConnection]struct{}
 	p2pConnectionsLock sync.RWMutex
 }
@@ -38,15 +44,48 @@ type NetAdapter struct {
 // NewNetAdapter creates and starts a new NetAdapter on the
 // given listeningPort
 func NewNetAdapter(cfg *config.Config) (*NetAdapter, error) {
+	return NewNetAdapterWithTransport(cfg, nil, nil)
+}
+
+// NewNetAdapterWithTransport creates a new NetAdapter whose P2P connections
+// are dialed with dialer and accepted from listeners that are opened with
+// listenerFactory. A nil dialer or listenerFactory uses plain TCP. This
+// allows embedders to connect peers over proxies, custom transports, or
+// in-memory transports that simulate a network in tests.
+func NewNetAdapterWithTransport(cfg *config.Config, dialer server.Dialer,
+	listenerFactory server.ListenerFactory) (*NetAdapter, error) {
+
 	netAdapterID, err := id.GenerateID()
 	if err != nil {
 		return nil, err
 	}
-	p2pServer, err := grpcserver.NewP2PServer(cfg.Listeners)
+	p2pServer, err := grpcserver.NewP2PServer(cfg.Listeners, dialer, listenerFactory)
+	if err != nil {
+		return nil, err
+	}
+	rpcCredentials, err := rpcauth.NewCredentials(cfg.RPCAuth)
 	if err != nil {
 		return nil, err
 	}
-	rpcServer, err := grpcserver.NewRPCServer(cfg.RPCListeners, cfg.RPCMaxClients)
+	rpcUnixSocket := cfg.RPCUnixSocket
+	if cfg.DisableRPC {
+		rpcUnixSocket = ""
+	}
+	var rpcTLSConfig *rpcauth.ReloadableServerTLSConfig
+	var rpcServerTLSConfig *tls.Config
+	if cfg.RPCClientCA != "" {
+		rpcTLSConfig, err = rpcauth.NewReloadableServerTLSConfig(cfg.RPCCert, cfg.RPCKey, cfg.RPCClientCA)
+		if err != nil {
+			return nil, err
+		}
+		rpcServerTLSConfig = rpcTLSConfig.TLSConfig()
+	}
+	rpcCertificateCredentials, err := rpcauth.NewCertificateCredentials(cfg.RPCCertAuth)
+	if err != nil {
+		return nil, err
+	}
+	rpcServer, err := grpcserver.NewRPCServer(cfg.RPCListeners, rpcUnixSocket, cfg.RPCUnixSocketFileMode,
+		cfg.RPCMaxClients, rpcCredentials, rpcServerTLSConfig, rpcCertificateCredentials)
 	if err != nil {
 		return nil, err
 	}
@@ -56,12 +95,23 @@ func NewNetAdapter(cfg *config.Config) (*NetAdapter, error) {
 		p2pServer: p2pServer,
 		rpcServer: rpcServer,
 
+		rpcTLSConfig: rpcTLSConfig,
+
 		p2pConnections: make(map[*NetConnection]struct{}),
 	}
 
 	adapter.p2pServer.SetOnConnectedHandler(adapter.onP2PConnectedHandler)
 	adapter.rpcServer.SetOnConnectedHandler(adapter.onRPCConnectedHandler)
 
+	// The gRPC API server is optional, and is only created if it has anywhere to listen on
+	if len(cfg.APIListeners) > 0 {
+		adapter.apiServer, err = grpcserver.NewAPIServer(cfg.APIListeners, cfg.RPCMaxClients, rpcCredentials)
+		if err != nil {
+			return nil, err
+		}
+		adapter.apiServer.SetOnConnectedHandler(adapter.onAPIConnectedHandler)
+	}
+
 	return &adapter, nil
 }
 
@@ -82,6 +132,12 @@ func (na *NetAdapter) Start() error {
 	if err != nil {
 		return err
 	}
+	if na.apiServer != nil {
+		err = na.apiServer.Start()
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -95,9 +151,44 @@ func (na *NetAdapter) Stop() error {
 	if err != nil {
 		return err
 	}
+	if na.apiServer != nil {
+		err = na.apiServer.Stop()
+		if err != nil {
+			return err
+		}
+	}
 	return na.rpcServer.Stop()
 }
 
+// SetRPCCredentials replaces the credentials that RPC and gRPC API clients
+// authenticate with. RPC clients that are already connected keep the
+// credential they authenticated with until they reconnect.
+func (na *NetAdapter) SetRPCCredentials(credentials *rpcauth.Credentials,
+	certificateCredentials *rpcauth.CertificateCredentials) {
+
+	na.rpcServer.SetCredentials(credentials, certificateCredentials)
+	if na.apiServer != nil {
+		na.apiServer.SetCredentials(credentials, nil)
+	}
+}
+
+// LoadRPCTLSConfig loads the RPC certificate, its key and the client CAs
+// from their files again, without using them yet. It returns whether they
+// changed, and nil if the RPC server isn't served over TLS.
+func (na *NetAdapter) LoadRPCTLSConfig() (loaded *rpcauth.LoadedServerTLSConfig, isChanged bool, err error) {
+	if na.rpcTLSConfig == nil {
+		return nil, false, nil
+	}
+	return na.rpcTLSConfig.Load()
+}
+
+// UseRPCTLSConfig makes new RPC connections use the given TLS configuration,
+// as returned by LoadRPCTLSConfig. RPC clients that are already connected
+// stay connected.
+func (na *NetAdapter) UseRPCTLSConfig(loaded *rpcauth.LoadedServerTLSConfig) {
+	na.rpcTLSConfig.Use(loaded)
+}
+
 // P2PConnect tells the NetAdapter's underlying p2p server to initiate a connection
 // to the given address
 func (na *NetAdapter) P2PConnect(address string) error {
@@ -134,6 +225,8 @@ func (na *NetAdapter) onP2PConnectedHandler(connection server.Connection) error
 	defer na.p2pConnectionsLock.Unlock()
 
 	netConnection.setOnDisconnectedHandler(func() {
+		log.Infof("Disconnected from %s", netConnection)
+
 		na.p2pConnectionsLock.Lock()
 		defer na.p2pConnectionsLock.Unlock()
 
@@ -149,7 +242,21 @@ func (na *NetAdapter) onP2PConnectedHandler(connection server.Connection) error
 
 func (na *NetAdapter) onRPCConnectedHandler(connection server.Connection) error {
 	netConnection := newNetConnection(connection, na.rpcRouterInitializer, "on RPC connected")
-	netConnection.setOnDisconnectedHandler(func() {})
+	netConnection.setOnDisconnectedHandler(func() {
+		log.Infof("Disconnected from %s", netConnection)
+	})
+	netConnection.start()
+
+	return nil
+}
+
+// onAPIConnectedHandler handles a single call of the gRPC API. Calls are served
+// exactly like RPC connections, but are short-lived, so they're logged at a lower level.
+func (na *NetAdapter) onAPIConnectedHandler(connection server.Connection) error {
+	netConnection := newNetConnection(connection, na.rpcRouterInitializer, "on API connected")
+	netConnection.setOnDisconnectedHandler(func() {
+		log.Debugf("API call from %s ended", netConnection)
+	})
 	netConnection.start()
 
 	return nil
@@ -29,7 +29,6 @@ func newNetConnection(connection server.Connection, routerInitializer RouterInit
 	}
 
 	netConnection.connection.SetOnDisconnectedHandler(func() {
-		log.Infof("Disconnected from %s", netConnection)
 		// If the disconnection came because of a network error and not because of the application layer, we
 		// need to close the router as well.
 		if atomic.AddUint32(&netConnection.isRouterClosed, 1) == 1 {
@@ -80,6 +79,12 @@ func (c *NetConnection) NetAddress() *appmessage.NetAddress {
 	return appmessage.NewNetAddress(c.connection.Address())
 }
 
+// MessageStatistics returns the statistics of the messages sent and received
+// on this connection, by command
+func (c *NetConnection) MessageStatistics() []*server.MessageStatistics {
+	return c.connection.MessageStatistics()
+}
+
 func (c *NetConnection) setOnDisconnectedHandler(onDisconnectedHandler server.OnDisconnectedHandler) {
 	c.onDisconnectedHandler = onDisconnectedHandler
 }
@@ -1,6 +1,7 @@
 package router
 
 import (
+	"context"
 	"sync"
 	"time"
 
@@ -106,6 +107,20 @@ func (r *Route) DequeueWithTimeout(timeout time.Duration) (appmessage.Message, e
 	}
 }
 
+// DequeueWithContext attempts to dequeue a message from the Route
+// and returns an error if the given context is done first.
+func (r *Route) DequeueWithContext(ctx context.Context) (appmessage.Message, error) {
+	select {
+	case <-ctx.Done():
+		return nil, errors.Wrapf(ctx.Err(), "route '%s' got no message", r.name)
+	case message, isOpen := <-r.channel:
+		if !isOpen {
+			return nil, errors.WithStack(ErrRouteClosed)
+		}
+		return message, nil
+	}
+}
+
 // Close closes this route
 func (r *Route) Close() {
 	r.closeLock.Lock()
@@ -0,0 +1,80 @@
+package grpcserver
+
+import (
+	"net"
+	"sync/atomic"
+
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
+	"github.com/pkg/errors"
+)
+
+// apiConnection is an in-process connection that carries a single call of the
+// gRPC API. Requests are enqueued directly into its router, and responses and
+// notifications are dequeued from the router's outgoing route.
+type apiConnection struct {
+	address               *net.TCPAddr
+	router                *router.Router
+	onDisconnectedHandler server.OnDisconnectedHandler
+	isConnected           uint32
+}
+
+func newAPIConnection(address *net.TCPAddr) *apiConnection {
+	return &apiConnection{
+		address:     address,
+		isConnected: 1,
+	}
+}
+
+func (c *apiConnection) Start(router *router.Router) {
+	if c.onDisconnectedHandler == nil {
+		panic(errors.New("onDisconnectedHandler is nil"))
+	}
+
+	c.router = router
+}
+
+func (c *apiConnection) String() string {
+	return c.Address().String()
+}
+
+func (c *apiConnection) IsConnected() bool {
+	return atomic.LoadUint32(&c.isConnected) != 0
+}
+
+func (c *apiConnection) SetOnDisconnectedHandler(onDisconnectedHandler server.OnDisconnectedHandler) {
+	c.onDisconnectedHandler = onDisconnectedHandler
+}
+
+// SetOnInvalidMessageHandler is a no-op, since API requests are
+// converted before they reach the connection
+func (c *apiConnection) SetOnInvalidMessageHandler(_ server.OnInvalidMessageHandler) {
+}
+
+func (c *apiConnection) IsOutbound() bool {
+	return false
+}
+
+// Disconnect disconnects the connection
+// Calling this function a second time doesn't do anything
+//
+// This is part of the Connection interface
+func (c *apiConnection) Disconnect() {
+	if !atomic.CompareAndSwapUint32(&c.isConnected, 1, 0) {
+		return
+	}
+
+	if c.onDisconnectedHandler != nil {
+		c.onDisconnectedHandler()
+	}
+}
+
+func (c *apiConnection) Address() *net.TCPAddr {
+	return c.address
+}
+
+// MessageStatistics returns nil, since an API call only has a single
+// request and response
+func (c *apiConnection) MessageStatistics() []*server.MessageStatistics {
+	return nil
+}
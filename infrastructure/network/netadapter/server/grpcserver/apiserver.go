@@ -0,0 +1,469 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcauth"
+	"github.com/kaspanet/kaspad/util/panics"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type apiServer struct {
+	protowire.UnimplementedAPIServer
+	gRPCServer
+	*serverCredentials
+}
+
+// NewAPIServer creates a new server for the typed gRPC API.
+// Every call of the API is handed to the onConnectedHandler as a connection of its
+// own, which carries the call's request and its response, and in case of a
+// subscription, its notifications until the client cancels the call.
+// If credentials is not nil, every call must be authenticated with one of them,
+// and is rejected unless its credential allows its method.
+func NewAPIServer(listeningAddresses []string, apiMaxInboundCalls int,
+	credentials *rpcauth.Credentials) (server.AuthenticatingServer, error) {
+
+	gRPCServer := newGRPCServer(listeningAddresses, RPCMaxMessageSize, apiMaxInboundCalls, "API")
+	apiServer := &apiServer{gRPCServer: *gRPCServer, serverCredentials: newServerCredentials(credentials, nil)}
+	protowire.RegisterAPIServer(gRPCServer.server, apiServer)
+	return apiServer, nil
+}
+
+// authenticate returns the credential of the client of the given call, or nil
+// if it may call all methods. The API server doesn't authenticate client
+// certificates.
+func (s *apiServer) authenticate(ctx context.Context) (*rpcauth.Credential, error) {
+	credentials, _ := s.get()
+	return authenticate(ctx, credentials)
+}
+
+// connect creates a connection for a single API call. The connection is
+// disconnected once the call's context is done.
+func (s *apiServer) connect(ctx context.Context) (*apiConnection, func(), error) {
+	_, err := s.incrementInboundConnectionCountAndLimitIfRequired()
+	if err != nil {
+		return nil, nil, status.Error(codes.ResourceExhausted, err.Error())
+	}
+
+	peerInfo, ok := peer.FromContext(ctx)
+	if !ok {
+		s.decrementInboundConnectionCount()
+		return nil, nil, errors.Errorf("Error getting call peer info from context")
+	}
+	tcpAddress, ok := peerInfo.Addr.(*net.TCPAddr)
+	if !ok {
+		s.decrementInboundConnectionCount()
+		return nil, nil, errors.Errorf("non-tcp connections are not supported")
+	}
+
+	connection := newAPIConnection(tcpAddress)
+	err = s.onConnectedHandler(connection)
+	if err != nil {
+		s.decrementInboundConnectionCount()
+		return nil, nil, err
+	}
+
+	disconnectedChan := make(chan struct{})
+	spawn("apiServer.connect-disconnectWhenDone", func() {
+		select {
+		case <-ctx.Done():
+		case <-disconnectedChan:
+		}
+		connection.Disconnect()
+	})
+	disconnect := func() {
+		close(disconnectedChan)
+		s.decrementInboundConnectionCount()
+	}
+	return connection, disconnect, nil
+}
+
+// handleRequest passes the given request to a new connection and returns its response
+func (s *apiServer) handleRequest(ctx context.Context, request *protowire.KaspadMessage) (*protowire.KaspadMessage, error) {
+	defer panics.HandlePanic(log, "apiServer.handleRequest", nil)
+
+	credential, err := s.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	connection, disconnect, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer disconnect()
+
+	response, err := s.sendRequest(connection, credential, request)
+	if err != nil {
+		return nil, err
+	}
+	return protowire.FromAppMessage(response)
+}
+
+// handleSubscription passes the given notification request to a new connection and calls
+// sendNotification with every notification the connection receives, until the stream's
+// context is done. getResponseError extracts the error of the request's response.
+// The stream's header is sent once the subscription is in effect, so that clients may
+// wait for it before doing anything that triggers notifications.
+func (s *apiServer) handleSubscription(stream grpc.ServerStream, request *protowire.KaspadMessage,
+	getResponseError func(response *protowire.KaspadMessage) *protowire.RPCError,
+	sendNotification func(notification *protowire.KaspadMessage) error) error {
+
+	defer panics.HandlePanic(log, "apiServer.handleSubscription", nil)
+
+	ctx := stream.Context()
+	credential, err := s.authenticate(ctx)
+	if err != nil {
+		return err
+	}
+	connection, disconnect, err := s.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer disconnect()
+
+	response, err := s.sendRequest(connection, credential, request)
+	if err != nil {
+		return err
+	}
+	protoResponse, err := protowire.FromAppMessage(response)
+	if err != nil {
+		return err
+	}
+	if rpcError := getResponseError(protoResponse); rpcError != nil {
+		return status.Error(codes.FailedPrecondition, rpcError.Message)
+	}
+	err = stream.SendHeader(metadata.MD{})
+	if err != nil {
+		return err
+	}
+
+	for {
+		notification, err := connection.router.OutgoingRoute().Dequeue()
+		if err != nil {
+			if errors.Is(err, router.ErrRouteClosed) {
+				return ctx.Err()
+			}
+			return err
+		}
+		protoNotification, err := protowire.FromAppMessage(notification)
+		if err != nil {
+			return err
+		}
+		err = sendNotification(protoNotification)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// sendRequest passes the given request to the given connection and returns its response.
+// credential is the credential the call was authenticated with, if any.
+func (s *apiServer) sendRequest(connection *apiConnection, credential *rpcauth.Credential,
+	request *protowire.KaspadMessage) (appmessage.Message, error) {
+
+	appRequest, err := request.ToAppMessage()
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if credential != nil && !credential.IsCommandAllowed(appRequest.Command()) {
+		return nil, status.Errorf(codes.PermissionDenied, "user %s is not allowed to call %s",
+			credential.User, rpcauth.MethodName(appRequest.Command()))
+	}
+	err = connection.router.EnqueueIncomingMessage(appRequest)
+	if err != nil {
+		return nil, err
+	}
+	response, err := connection.router.OutgoingRoute().Dequeue()
+	if err != nil {
+		if errors.Is(err, router.ErrRouteClosed) {
+			return nil, status.Error(codes.Canceled, "the call was canceled before it was handled")
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+func (s *apiServer) GetInfo(ctx context.Context, request *protowire.GetInfoRequestMessage) (
+	*protowire.GetInfoResponseMessage, error) {
+
+	response, err := s.handleRequest(ctx, &protowire.KaspadMessage{
+		Payload: &protowire.KaspadMessage_GetInfoRequest{GetInfoRequest: request}})
+	if err != nil {
+		return nil, err
+	}
+	return response.GetGetInfoResponse(), nil
+}
+
+func (s *apiServer) GetBlockDagInfo(ctx context.Context, request *protowire.GetBlockDagInfoRequestMessage) (
+	*protowire.GetBlockDagInfoResponseMessage, error) {
+
+	response, err := s.handleRequest(ctx, &protowire.KaspadMessage{
+		Payload: &protowire.KaspadMessage_GetBlockDagInfoRequest{GetBlockDagInfoRequest: request}})
+	if err != nil {
+		return nil, err
+	}
+	return response.GetGetBlockDagInfoResponse(), nil
+}
+
+func (s *apiServer) GetSelectedTipHash(ctx context.Context, request *protowire.GetSelectedTipHashRequestMessage) (
+	*protowire.GetSelectedTipHashResponseMessage, error) {
+
+	response, err := s.handleRequest(ctx, &protowire.KaspadMessage{
+		Payload: &protowire.KaspadMessage_GetSelectedTipHashRequest{GetSelectedTipHashRequest: request}})
+	if err != nil {
+		return nil, err
+	}
+	return response.GetGetSelectedTipHashResponse(), nil
+}
+
+func (s *apiServer) GetBlock(ctx context.Context, request *protowire.GetBlockRequestMessage) (
+	*protowire.GetBlockResponseMessage, error) {
+
+	response, err := s.handleRequest(ctx, &protowire.KaspadMessage{
+		Payload: &protowire.KaspadMessage_GetBlockRequest{GetBlockRequest: request}})
+	if err != nil {
+		return nil, err
+	}
+	return response.GetGetBlockResponse(), nil
+}
+
+func (s *apiServer) GetBlocks(ctx context.Context, request *protowire.GetBlocksRequestMessage) (
+	*protowire.GetBlocksResponseMessage, error) {
+
+	response, err := s.handleRequest(ctx, &protowire.KaspadMessage{
+		Payload: &protowire.KaspadMessage_GetBlocksRequest{GetBlocksRequest: request}})
+	if err != nil {
+		return nil, err
+	}
+	return response.GetGetBlocksResponse(), nil
+}
+
+func (s *apiServer) GetVirtualSelectedParentChainFromBlock(ctx context.Context,
+	request *protowire.GetVirtualSelectedParentChainFromBlockRequestMessage) (
+	*protowire.GetVirtualSelectedParentChainFromBlockResponseMessage, error) {
+
+	response, err := s.handleRequest(ctx, &protowire.KaspadMessage{
+		Payload: &protowire.KaspadMessage_GetVirtualSelectedParentChainFromBlockRequest{
+			GetVirtualSelectedParentChainFromBlockRequest: request}})
+	if err != nil {
+		return nil, err
+	}
+	return response.GetGetVirtualSelectedParentChainFromBlockResponse(), nil
+}
+
+func (s *apiServer) GetUtxosByAddresses(ctx context.Context, request *protowire.GetUtxosByAddressesRequestMessage) (
+	*protowire.GetUtxosByAddressesResponseMessage, error) {
+
+	response, err := s.handleRequest(ctx, &protowire.KaspadMessage{
+		Payload: &protowire.KaspadMessage_GetUtxosByAddressesRequest{GetUtxosByAddressesRequest: request}})
+	if err != nil {
+		return nil, err
+	}
+	return response.GetGetUtxosByAddressesResponse(), nil
+}
+
+func (s *apiServer) GetBalanceByAddress(ctx context.Context, request *protowire.GetBalanceByAddressRequestMessage) (
+	*protowire.GetBalanceByAddressResponseMessage, error) {
+
+	response, err := s.handleRequest(ctx, &protowire.KaspadMessage{
+		Payload: &protowire.KaspadMessage_GetBalanceByAddressRequest{GetBalanceByAddressRequest: request}})
+	if err != nil {
+		return nil, err
+	}
+	return response.GetGetBalanceByAddressResponse(), nil
+}
+
+func (s *apiServer) GetBalancesByAddresses(ctx context.Context, request *protowire.GetBalancesByAddressesRequestMessage) (
+	*protowire.GetBalancesByAddressesResponseMessage, error) {
+
+	response, err := s.handleRequest(ctx, &protowire.KaspadMessage{
+		Payload: &protowire.KaspadMessage_GetBalancesByAddressesRequest{GetBalancesByAddressesRequest: request}})
+	if err != nil {
+		return nil, err
+	}
+	return response.GetGetBalancesByAddressesResponse(), nil
+}
+
+func (s *apiServer) SubmitBlock(ctx context.Context, request *protowire.SubmitBlockRequestMessage) (
+	*protowire.SubmitBlockResponseMessage, error) {
+
+	response, err := s.handleRequest(ctx, &protowire.KaspadMessage{
+		Payload: &protowire.KaspadMessage_SubmitBlockRequest{SubmitBlockRequest: request}})
+	if err != nil {
+		return nil, err
+	}
+	return response.GetSubmitBlockResponse(), nil
+}
+
+func (s *apiServer) SubmitTransaction(ctx context.Context, request *protowire.SubmitTransactionRequestMessage) (
+	*protowire.SubmitTransactionResponseMessage, error) {
+
+	response, err := s.handleRequest(ctx, &protowire.KaspadMessage{
+		Payload: &protowire.KaspadMessage_SubmitTransactionRequest{SubmitTransactionRequest: request}})
+	if err != nil {
+		return nil, err
+	}
+	return response.GetSubmitTransactionResponse(), nil
+}
+
+func (s *apiServer) GetMempoolEntry(ctx context.Context, request *protowire.GetMempoolEntryRequestMessage) (
+	*protowire.GetMempoolEntryResponseMessage, error) {
+
+	response, err := s.handleRequest(ctx, &protowire.KaspadMessage{
+		Payload: &protowire.KaspadMessage_GetMempoolEntryRequest{GetMempoolEntryRequest: request}})
+	if err != nil {
+		return nil, err
+	}
+	return response.GetGetMempoolEntryResponse(), nil
+}
+
+func (s *apiServer) GetMempoolEntries(ctx context.Context, request *protowire.GetMempoolEntriesRequestMessage) (
+	*protowire.GetMempoolEntriesResponseMessage, error) {
+
+	response, err := s.handleRequest(ctx, &protowire.KaspadMessage{
+		Payload: &protowire.KaspadMessage_GetMempoolEntriesRequest{GetMempoolEntriesRequest: request}})
+	if err != nil {
+		return nil, err
+	}
+	return response.GetGetMempoolEntriesResponse(), nil
+}
+
+func (s *apiServer) GetMempoolEntriesByAddresses(ctx context.Context,
+	request *protowire.GetMempoolEntriesByAddressesRequestMessage) (
+	*protowire.GetMempoolEntriesByAddressesResponseMessage, error) {
+
+	response, err := s.handleRequest(ctx, &protowire.KaspadMessage{
+		Payload: &protowire.KaspadMessage_GetMempoolEntriesByAddressesRequest{GetMempoolEntriesByAddressesRequest: request}})
+	if err != nil {
+		return nil, err
+	}
+	return response.GetGetMempoolEntriesByAddressesResponse(), nil
+}
+
+func (s *apiServer) GetConnectedPeerInfo(ctx context.Context, request *protowire.GetConnectedPeerInfoRequestMessage) (
+	*protowire.GetConnectedPeerInfoResponseMessage, error) {
+
+	response, err := s.handleRequest(ctx, &protowire.KaspadMessage{
+		Payload: &protowire.KaspadMessage_GetConnectedPeerInfoRequest{GetConnectedPeerInfoRequest: request}})
+	if err != nil {
+		return nil, err
+	}
+	return response.GetGetConnectedPeerInfoResponse(), nil
+}
+
+func (s *apiServer) GetPeerAddresses(ctx context.Context, request *protowire.GetPeerAddressesRequestMessage) (
+	*protowire.GetPeerAddressesResponseMessage, error) {
+
+	response, err := s.handleRequest(ctx, &protowire.KaspadMessage{
+		Payload: &protowire.KaspadMessage_GetPeerAddressesRequest{GetPeerAddressesRequest: request}})
+	if err != nil {
+		return nil, err
+	}
+	return response.GetGetPeerAddressesResponse(), nil
+}
+
+func (s *apiServer) NotifyBlockAdded(request *protowire.NotifyBlockAddedRequestMessage,
+	stream protowire.API_NotifyBlockAddedServer) error {
+
+	return s.handleSubscription(stream,
+		&protowire.KaspadMessage{Payload: &protowire.KaspadMessage_NotifyBlockAddedRequest{NotifyBlockAddedRequest: request}},
+		func(response *protowire.KaspadMessage) *protowire.RPCError {
+			return response.GetNotifyBlockAddedResponse().GetError()
+		},
+		func(notification *protowire.KaspadMessage) error {
+			return stream.Send(notification.GetBlockAddedNotification())
+		})
+}
+
+func (s *apiServer) NotifyNewTip(request *protowire.NotifyNewTipRequestMessage,
+	stream protowire.API_NotifyNewTipServer) error {
+
+	return s.handleSubscription(stream,
+		&protowire.KaspadMessage{Payload: &protowire.KaspadMessage_NotifyNewTipRequest{NotifyNewTipRequest: request}},
+		func(response *protowire.KaspadMessage) *protowire.RPCError {
+			return response.GetNotifyNewTipResponse().GetError()
+		},
+		func(notification *protowire.KaspadMessage) error {
+			return stream.Send(notification.GetNewTipNotification())
+		})
+}
+
+func (s *apiServer) NotifyVirtualSelectedParentChainChanged(
+	request *protowire.NotifyVirtualSelectedParentChainChangedRequestMessage,
+	stream protowire.API_NotifyVirtualSelectedParentChainChangedServer) error {
+
+	return s.handleSubscription(stream,
+		&protowire.KaspadMessage{Payload: &protowire.KaspadMessage_NotifyVirtualSelectedParentChainChangedRequest{
+			NotifyVirtualSelectedParentChainChangedRequest: request}},
+		func(response *protowire.KaspadMessage) *protowire.RPCError {
+			return response.GetNotifyVirtualSelectedParentChainChangedResponse().GetError()
+		},
+		func(notification *protowire.KaspadMessage) error {
+			return stream.Send(notification.GetVirtualSelectedParentChainChangedNotification())
+		})
+}
+
+func (s *apiServer) NotifyVirtualDaaScoreChanged(request *protowire.NotifyVirtualDaaScoreChangedRequestMessage,
+	stream protowire.API_NotifyVirtualDaaScoreChangedServer) error {
+
+	return s.handleSubscription(stream,
+		&protowire.KaspadMessage{Payload: &protowire.KaspadMessage_NotifyVirtualDaaScoreChangedRequest{
+			NotifyVirtualDaaScoreChangedRequest: request}},
+		func(response *protowire.KaspadMessage) *protowire.RPCError {
+			return response.GetNotifyVirtualDaaScoreChangedResponse().GetError()
+		},
+		func(notification *protowire.KaspadMessage) error {
+			return stream.Send(notification.GetVirtualDaaScoreChangedNotification())
+		})
+}
+
+func (s *apiServer) NotifyFinalityPointAdvanced(request *protowire.NotifyFinalityPointAdvancedRequestMessage,
+	stream protowire.API_NotifyFinalityPointAdvancedServer) error {
+
+	return s.handleSubscription(stream,
+		&protowire.KaspadMessage{Payload: &protowire.KaspadMessage_NotifyFinalityPointAdvancedRequest{
+			NotifyFinalityPointAdvancedRequest: request}},
+		func(response *protowire.KaspadMessage) *protowire.RPCError {
+			return response.GetNotifyFinalityPointAdvancedResponse().GetError()
+		},
+		func(notification *protowire.KaspadMessage) error {
+			return stream.Send(notification.GetFinalityPointAdvancedNotification())
+		})
+}
+
+func (s *apiServer) NotifyUtxosChanged(request *protowire.NotifyUtxosChangedRequestMessage,
+	stream protowire.API_NotifyUtxosChangedServer) error {
+
+	return s.handleSubscription(stream,
+		&protowire.KaspadMessage{Payload: &protowire.KaspadMessage_NotifyUtxosChangedRequest{NotifyUtxosChangedRequest: request}},
+		func(response *protowire.KaspadMessage) *protowire.RPCError {
+			return response.GetNotifyUtxosChangedResponse().GetError()
+		},
+		func(notification *protowire.KaspadMessage) error {
+			return stream.Send(notification.GetUtxosChangedNotification())
+		})
+}
+
+func (s *apiServer) NotifyNewTransactions(request *protowire.NotifyNewTransactionsRequestMessage,
+	stream protowire.API_NotifyNewTransactionsServer) error {
+
+	return s.handleSubscription(stream,
+		&protowire.KaspadMessage{Payload: &protowire.KaspadMessage_NotifyNewTransactionsRequest{
+			NotifyNewTransactionsRequest: request}},
+		func(response *protowire.KaspadMessage) *protowire.RPCError {
+			return response.GetNotifyNewTransactionsResponse().GetError()
+		},
+		func(notification *protowire.KaspadMessage) error {
+			return stream.Send(notification.GetNewTransactionsNotification())
+		})
+}
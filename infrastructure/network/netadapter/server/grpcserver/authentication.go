@@ -0,0 +1,81 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcauth"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// authenticate returns the credential that the client of the given call
+// authenticated with, or nil if the server doesn't require authentication
+func authenticate(ctx context.Context, credentials *rpcauth.Credentials) (*rpcauth.Credential, error) {
+	if credentials == nil {
+		return nil, nil
+	}
+
+	authorization := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(rpcauth.AuthorizationMetadataKey); len(values) == 1 {
+			authorization = values[0]
+		}
+	}
+	credential, err := credentials.AuthenticateAuthorization(authorization)
+	if err != nil {
+		address := "unknown address"
+		if peerInfo, ok := peer.FromContext(ctx); ok {
+			address = peerInfo.Addr.String()
+		}
+		log.Warnf("Rejected an RPC client from %s: %s", address, err)
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return credential, nil
+}
+
+// isUnixSocketClient returns whether the client of the given call connected
+// through a Unix domain socket
+func isUnixSocketClient(ctx context.Context) bool {
+	peerInfo, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+	_, ok = peerInfo.Addr.(*net.UnixAddr)
+	return ok
+}
+
+// serverCredentials holds the credentials that the clients of a server
+// authenticate with, which may be replaced while the server runs
+type serverCredentials struct {
+	lock                   sync.RWMutex
+	credentials            *rpcauth.Credentials
+	certificateCredentials *rpcauth.CertificateCredentials
+}
+
+func newServerCredentials(credentials *rpcauth.Credentials,
+	certificateCredentials *rpcauth.CertificateCredentials) *serverCredentials {
+
+	return &serverCredentials{credentials: credentials, certificateCredentials: certificateCredentials}
+}
+
+func (c *serverCredentials) get() (*rpcauth.Credentials, *rpcauth.CertificateCredentials) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.credentials, c.certificateCredentials
+}
+
+// SetCredentials replaces the credentials that clients authenticate with
+func (c *serverCredentials) SetCredentials(credentials *rpcauth.Credentials,
+	certificateCredentials *rpcauth.CertificateCredentials) {
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.credentials = credentials
+	c.certificateCredentials = certificateCredentials
+}
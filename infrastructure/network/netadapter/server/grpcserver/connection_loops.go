@@ -1,9 +1,11 @@
 package grpcserver
 
 import (
+	"fmt"
 	"github.com/davecgh/go-spew/spew"
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcauth"
 	"io"
 	"os"
 	"strconv"
@@ -12,6 +14,7 @@ import (
 
 	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
 )
@@ -71,6 +74,7 @@ func (c *gRPCConnection) sendLoop() error {
 		if err != nil {
 			return err
 		}
+		c.statistics.recordSent(message.Command(), proto.Size(messageProto))
 	}
 	return nil
 }
@@ -92,6 +96,15 @@ func (c *gRPCConnection) receiveLoop() error {
 			}
 			return err
 		}
+		c.statistics.recordReceived(message.Command(), proto.Size(protoMessage))
+
+		if c.credential != nil && !c.credential.IsCommandAllowed(message.Command()) {
+			err := c.rejectForbiddenRequest(protoMessage, message)
+			if err != nil {
+				return err
+			}
+			continue
+		}
 
 		messageNumber++
 		message.SetMessageNumber(messageNumber)
@@ -124,3 +137,27 @@ func (c *gRPCConnection) receiveLoop() error {
 	}
 	return nil
 }
+
+// rejectForbiddenRequest responds to a request that the connection's credential
+// doesn't allow with an error response, instead of passing it on to the router.
+// Messages that aren't RPC requests have no response, and fail the connection.
+func (c *gRPCConnection) rejectForbiddenRequest(protoMessage *protowire.KaspadMessage, message appmessage.Message) error {
+	log.Warnf("RPC user %s from %s is not allowed to send '%s'", c.credential.User, c, message.Command())
+
+	errorResponse, err := protowire.NewRPCErrorResponse(protoMessage,
+		fmt.Sprintf("user %s is not allowed to call %s", c.credential.User, rpcauth.MethodName(message.Command())))
+	if err != nil {
+		return err
+	}
+	appErrorResponse, err := errorResponse.ToAppMessage()
+	if err != nil {
+		return err
+	}
+	// The response is sent through the outgoing route, since only the
+	// send loop may send on the stream
+	err = c.router.OutgoingRoute().Enqueue(appErrorResponse)
+	if errors.Is(err, routerpkg.ErrRouteClosed) {
+		return nil
+	}
+	return err
+}
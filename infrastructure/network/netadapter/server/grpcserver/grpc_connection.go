@@ -1,12 +1,14 @@
 package grpcserver
 
 import (
+	"fmt"
 	"net"
 	"sync"
 	"sync/atomic"
 
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcauth"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -22,6 +24,12 @@ type gRPCConnection struct {
 	router                   *router.Router
 	lowLevelClientConnection *grpc.ClientConn
 
+	// credential is the credential an inbound RPC client authenticated with.
+	// It is nil if the connection isn't required to authenticate.
+	credential *rpcauth.Credential
+
+	statistics *messageStatistics
+
 	// streamLock protects concurrent access to stream.
 	// Note that it's an RWMutex. Despite what the name
 	// implies, we use it to RLock() send() and receive() because
@@ -50,6 +58,7 @@ func newConnection(server *gRPCServer, address *net.TCPAddr, stream grpcStream,
 		stopChan:                 make(chan struct{}),
 		isConnected:              1,
 		lowLevelClientConnection: lowLevelClientConnection,
+		statistics:               newMessageStatistics(),
 	}
 
 	return connection
@@ -81,6 +90,9 @@ func (c *gRPCConnection) Start(router *router.Router) {
 }
 
 func (c *gRPCConnection) String() string {
+	if c.address == nil {
+		return fmt.Sprintf("unix socket %s", c.server.unixSocketPath)
+	}
 	return c.Address().String()
 }
 
@@ -127,6 +139,12 @@ func (c *gRPCConnection) Address() *net.TCPAddr {
 	return c.address
 }
 
+// MessageStatistics returns the statistics of the messages sent and received
+// on the connection, by command
+func (c *gRPCConnection) MessageStatistics() []*server.MessageStatistics {
+	return c.statistics.snapshot()
+}
+
 func (c *gRPCConnection) receive() (*protowire.KaspadMessage, error) {
 	// We use RLock here and in send() because they can work
 	// in parallel. closeSend(), however, must not have either
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcauth"
 	"github.com/kaspanet/kaspad/util/panics"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/peer"
 	"net"
+	"os"
 	"sync"
 	"time"
 )
@@ -19,21 +21,35 @@ type gRPCServer struct {
 	server             *grpc.Server
 	name               string
 
+	// unixSocketPath is the path of a Unix domain socket to listen on in
+	// addition to listeningAddresses. It's empty if there is none.
+	unixSocketPath string
+	unixSocketMode os.FileMode
+
+	// listenerFactory opens the listeners of listeningAddresses
+	listenerFactory server.ListenerFactory
+
 	maxInboundConnections      int
 	inboundConnectionCount     int
 	inboundConnectionCountLock *sync.Mutex
 }
 
-// newGRPCServer creates a gRPC server
-func newGRPCServer(listeningAddresses []string, maxMessageSize int, maxInboundConnections int, name string) *gRPCServer {
+// newGRPCServer creates a gRPC server. extraOptions are passed to the
+// underlying grpc.Server along with the message size limits.
+func newGRPCServer(listeningAddresses []string, maxMessageSize int, maxInboundConnections int, name string,
+	extraOptions ...grpc.ServerOption) *gRPCServer {
+
 	log.Debugf("Created new %s GRPC server with maxMessageSize %d and maxInboundConnections %d", name, maxMessageSize, maxInboundConnections)
+	options := append([]grpc.ServerOption{grpc.MaxRecvMsgSize(maxMessageSize), grpc.MaxSendMsgSize(maxMessageSize)},
+		extraOptions...)
 	return &gRPCServer{
-		server:                     grpc.NewServer(grpc.MaxRecvMsgSize(maxMessageSize), grpc.MaxSendMsgSize(maxMessageSize)),
+		server:                     grpc.NewServer(options...),
 		listeningAddresses:         listeningAddresses,
 		name:                       name,
 		maxInboundConnections:      maxInboundConnections,
 		inboundConnectionCount:     0,
 		inboundConnectionCountLock: &sync.Mutex{},
+		listenerFactory:            &net.ListenConfig{},
 	}
 }
 
@@ -49,15 +65,58 @@ func (s *gRPCServer) Start() error {
 		}
 	}
 
+	if s.unixSocketPath != "" {
+		err := s.listenOnUnixSocket()
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (s *gRPCServer) listenOn(listenAddr string) error {
-	listener, err := net.Listen("tcp", listenAddr)
+	listener, err := s.listenerFactory.Listen(context.Background(), "tcp", listenAddr)
 	if err != nil {
 		return errors.Wrapf(err, "%s error listening on %s", s.name, listenAddr)
 	}
 
+	s.serve(listener, listenAddr)
+	return nil
+}
+
+// listenOnUnixSocket listens on unixSocketPath, replacing a socket left
+// behind by a previous run, and restricts access to it to unixSocketMode
+func (s *gRPCServer) listenOnUnixSocket() error {
+	fileInfo, err := os.Lstat(s.unixSocketPath)
+	if err == nil {
+		if fileInfo.Mode()&os.ModeSocket == 0 {
+			return errors.Errorf("%s error listening on %s: the file exists and is not a socket",
+				s.name, s.unixSocketPath)
+		}
+		err := os.Remove(s.unixSocketPath)
+		if err != nil {
+			return errors.Wrapf(err, "%s error removing stale socket %s", s.name, s.unixSocketPath)
+		}
+	} else if !os.IsNotExist(err) {
+		return errors.Wrapf(err, "%s error listening on %s", s.name, s.unixSocketPath)
+	}
+
+	listener, err := net.Listen("unix", s.unixSocketPath)
+	if err != nil {
+		return errors.Wrapf(err, "%s error listening on %s", s.name, s.unixSocketPath)
+	}
+	err = os.Chmod(s.unixSocketPath, s.unixSocketMode)
+	if err != nil {
+		listener.Close()
+		return errors.Wrapf(err, "%s error setting the permissions of %s", s.name, s.unixSocketPath)
+	}
+
+	s.serve(listener, s.unixSocketPath)
+	return nil
+}
+
+func (s *gRPCServer) serve(listener net.Listener, listenAddr string) {
 	spawn(fmt.Sprintf("%s.gRPCServer.listenOn-Serve", s.name), func() {
 		err := s.server.Serve(listener)
 		if err != nil {
@@ -66,7 +125,6 @@ func (s *gRPCServer) listenOn(listenAddr string) error {
 	})
 
 	log.Infof("%s Server listening on %s", s.name, listener.Addr())
-	return nil
 }
 
 func (s *gRPCServer) Stop() error {
@@ -93,7 +151,9 @@ func (s *gRPCServer) SetOnConnectedHandler(onConnectedHandler server.OnConnected
 	s.onConnectedHandler = onConnectedHandler
 }
 
-func (s *gRPCServer) handleInboundConnection(ctx context.Context, stream grpcStream) error {
+func (s *gRPCServer) handleInboundConnection(ctx context.Context, stream grpcStream,
+	credential *rpcauth.Credential) error {
+
 	connectionCount, err := s.incrementInboundConnectionCountAndLimitIfRequired()
 	if err != nil {
 		return err
@@ -104,19 +164,26 @@ func (s *gRPCServer) handleInboundConnection(ctx context.Context, stream grpcStr
 	if !ok {
 		return errors.Errorf("Error getting stream peer info from context")
 	}
-	tcpAddress, ok := peerInfo.Addr.(*net.TCPAddr)
-	if !ok {
-		return errors.Errorf("non-tcp connections are not supported")
+	var tcpAddress *net.TCPAddr
+	switch address := peerInfo.Addr.(type) {
+	case *net.TCPAddr:
+		tcpAddress = address
+	case *net.UnixAddr:
+		// Clients connected through a Unix domain socket have no network
+		// address, so the connection is left without one
+	default:
+		return errors.Errorf("%s connections are not supported", peerInfo.Addr.Network())
 	}
 
 	connection := newConnection(s, tcpAddress, stream, nil)
+	connection.credential = credential
 
 	err = s.onConnectedHandler(connection)
 	if err != nil {
 		return err
 	}
 
-	log.Infof("%s Incoming connection from %s #%d", s.name, peerInfo.Addr, connectionCount)
+	log.Infof("%s Incoming connection from %s #%d", s.name, connection, connectionCount)
 
 	<-connection.stopChan
 	return nil
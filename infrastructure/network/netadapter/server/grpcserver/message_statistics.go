@@ -0,0 +1,64 @@
+package grpcserver
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
+)
+
+// messageStatistics counts the messages sent and received on a connection
+// by command
+type messageStatistics struct {
+	lock      sync.Mutex
+	byCommand map[appmessage.MessageCommand]*server.MessageStatistics
+}
+
+func newMessageStatistics() *messageStatistics {
+	return &messageStatistics{byCommand: make(map[appmessage.MessageCommand]*server.MessageStatistics)}
+}
+
+func (s *messageStatistics) recordSent(command appmessage.MessageCommand, size int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	statistics := s.commandStatistics(command)
+	statistics.MessagesSent++
+	statistics.BytesSent += uint64(size)
+}
+
+func (s *messageStatistics) recordReceived(command appmessage.MessageCommand, size int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	statistics := s.commandStatistics(command)
+	statistics.MessagesReceived++
+	statistics.BytesReceived += uint64(size)
+}
+
+// commandStatistics must be called with the lock held
+func (s *messageStatistics) commandStatistics(command appmessage.MessageCommand) *server.MessageStatistics {
+	statistics, ok := s.byCommand[command]
+	if !ok {
+		statistics = &server.MessageStatistics{Command: command.String()}
+		s.byCommand[command] = statistics
+	}
+	return statistics
+}
+
+// snapshot returns a copy of the statistics, ordered by command
+func (s *messageStatistics) snapshot() []*server.MessageStatistics {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	snapshot := make([]*server.MessageStatistics, 0, len(s.byCommand))
+	for _, statistics := range s.byCommand {
+		statisticsCopy := *statistics
+		snapshot = append(snapshot, &statisticsCopy)
+	}
+	sort.Slice(snapshot, func(i, j int) bool {
+		return snapshot[i].Command < snapshot[j].Command
+	})
+	return snapshot
+}
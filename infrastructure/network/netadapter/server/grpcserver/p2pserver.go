@@ -16,6 +16,9 @@ import (
 type p2pServer struct {
 	protowire.UnimplementedP2PServer
 	gRPCServer
+
+	// dialer is nil if connections are dialed by gRPC itself
+	dialer server.Dialer
 }
 
 const p2pMaxMessageSize = 1024 * 1024 * 1024 // 1GB
@@ -26,10 +29,17 @@ const p2pMaxMessageSize = 1024 * 1024 * 1024 // 1GB
 // is handled in the ConnectionManager instead.
 const p2pMaxInboundConnections = 0
 
-// NewP2PServer creates a new P2PServer
-func NewP2PServer(listeningAddresses []string) (server.P2PServer, error) {
+// NewP2PServer creates a new P2PServer. Outgoing connections are opened
+// with dialer and listeners are opened with listenerFactory, unless they
+// are nil, in which case plain TCP is used.
+func NewP2PServer(listeningAddresses []string, dialer server.Dialer,
+	listenerFactory server.ListenerFactory) (server.P2PServer, error) {
+
 	gRPCServer := newGRPCServer(listeningAddresses, p2pMaxMessageSize, p2pMaxInboundConnections, "P2P")
-	p2pServer := &p2pServer{gRPCServer: *gRPCServer}
+	if listenerFactory != nil {
+		gRPCServer.listenerFactory = listenerFactory
+	}
+	p2pServer := &p2pServer{gRPCServer: *gRPCServer, dialer: dialer}
 	protowire.RegisterP2PServer(gRPCServer.server, p2pServer)
 	return p2pServer, nil
 }
@@ -37,7 +47,7 @@ func NewP2PServer(listeningAddresses []string) (server.P2PServer, error) {
 func (p *p2pServer) MessageStream(stream protowire.P2P_MessageStreamServer) error {
 	defer panics.HandlePanic(log, "p2pServer.MessageStream", nil)
 
-	return p.handleInboundConnection(stream.Context(), stream)
+	return p.handleInboundConnection(stream.Context(), stream, nil)
 }
 
 // Connect connects to the given address
@@ -49,7 +59,13 @@ func (p *p2pServer) Connect(address string) (server.Connection, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
 	defer cancel()
 
-	gRPCClientConnection, err := grpc.DialContext(ctx, address, grpc.WithInsecure(), grpc.WithBlock())
+	dialOptions := []grpc.DialOption{grpc.WithInsecure(), grpc.WithBlock()}
+	if p.dialer != nil {
+		dialOptions = append(dialOptions, grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+			return p.dialer.DialContext(ctx, "tcp", address)
+		}))
+	}
+	gRPCClientConnection, err := grpc.DialContext(ctx, address, dialOptions...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "%s error connecting to %s", p.name, address)
 	}
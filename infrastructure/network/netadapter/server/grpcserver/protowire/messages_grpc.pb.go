@@ -253,3 +253,1071 @@ var RPC_ServiceDesc = grpc.ServiceDesc{
 	},
 	Metadata: "messages.proto",
 }
+
+// APIClient is the client API for API service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type APIClient interface {
+	GetInfo(ctx context.Context, in *GetInfoRequestMessage, opts ...grpc.CallOption) (*GetInfoResponseMessage, error)
+	GetBlockDagInfo(ctx context.Context, in *GetBlockDagInfoRequestMessage, opts ...grpc.CallOption) (*GetBlockDagInfoResponseMessage, error)
+	GetSelectedTipHash(ctx context.Context, in *GetSelectedTipHashRequestMessage, opts ...grpc.CallOption) (*GetSelectedTipHashResponseMessage, error)
+	GetBlock(ctx context.Context, in *GetBlockRequestMessage, opts ...grpc.CallOption) (*GetBlockResponseMessage, error)
+	GetBlocks(ctx context.Context, in *GetBlocksRequestMessage, opts ...grpc.CallOption) (*GetBlocksResponseMessage, error)
+	GetVirtualSelectedParentChainFromBlock(ctx context.Context, in *GetVirtualSelectedParentChainFromBlockRequestMessage, opts ...grpc.CallOption) (*GetVirtualSelectedParentChainFromBlockResponseMessage, error)
+	GetUtxosByAddresses(ctx context.Context, in *GetUtxosByAddressesRequestMessage, opts ...grpc.CallOption) (*GetUtxosByAddressesResponseMessage, error)
+	GetBalanceByAddress(ctx context.Context, in *GetBalanceByAddressRequestMessage, opts ...grpc.CallOption) (*GetBalanceByAddressResponseMessage, error)
+	GetBalancesByAddresses(ctx context.Context, in *GetBalancesByAddressesRequestMessage, opts ...grpc.CallOption) (*GetBalancesByAddressesResponseMessage, error)
+	SubmitBlock(ctx context.Context, in *SubmitBlockRequestMessage, opts ...grpc.CallOption) (*SubmitBlockResponseMessage, error)
+	SubmitTransaction(ctx context.Context, in *SubmitTransactionRequestMessage, opts ...grpc.CallOption) (*SubmitTransactionResponseMessage, error)
+	GetMempoolEntry(ctx context.Context, in *GetMempoolEntryRequestMessage, opts ...grpc.CallOption) (*GetMempoolEntryResponseMessage, error)
+	GetMempoolEntries(ctx context.Context, in *GetMempoolEntriesRequestMessage, opts ...grpc.CallOption) (*GetMempoolEntriesResponseMessage, error)
+	GetMempoolEntriesByAddresses(ctx context.Context, in *GetMempoolEntriesByAddressesRequestMessage, opts ...grpc.CallOption) (*GetMempoolEntriesByAddressesResponseMessage, error)
+	GetConnectedPeerInfo(ctx context.Context, in *GetConnectedPeerInfoRequestMessage, opts ...grpc.CallOption) (*GetConnectedPeerInfoResponseMessage, error)
+	GetPeerAddresses(ctx context.Context, in *GetPeerAddressesRequestMessage, opts ...grpc.CallOption) (*GetPeerAddressesResponseMessage, error)
+	NotifyBlockAdded(ctx context.Context, in *NotifyBlockAddedRequestMessage, opts ...grpc.CallOption) (API_NotifyBlockAddedClient, error)
+	NotifyNewTip(ctx context.Context, in *NotifyNewTipRequestMessage, opts ...grpc.CallOption) (API_NotifyNewTipClient, error)
+	NotifyVirtualSelectedParentChainChanged(ctx context.Context, in *NotifyVirtualSelectedParentChainChangedRequestMessage, opts ...grpc.CallOption) (API_NotifyVirtualSelectedParentChainChangedClient, error)
+	NotifyVirtualDaaScoreChanged(ctx context.Context, in *NotifyVirtualDaaScoreChangedRequestMessage, opts ...grpc.CallOption) (API_NotifyVirtualDaaScoreChangedClient, error)
+	NotifyFinalityPointAdvanced(ctx context.Context, in *NotifyFinalityPointAdvancedRequestMessage, opts ...grpc.CallOption) (API_NotifyFinalityPointAdvancedClient, error)
+	NotifyUtxosChanged(ctx context.Context, in *NotifyUtxosChangedRequestMessage, opts ...grpc.CallOption) (API_NotifyUtxosChangedClient, error)
+	NotifyNewTransactions(ctx context.Context, in *NotifyNewTransactionsRequestMessage, opts ...grpc.CallOption) (API_NotifyNewTransactionsClient, error)
+}
+
+type aPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAPIClient(cc grpc.ClientConnInterface) APIClient {
+	return &aPIClient{cc}
+}
+
+func (c *aPIClient) GetInfo(ctx context.Context, in *GetInfoRequestMessage, opts ...grpc.CallOption) (*GetInfoResponseMessage, error) {
+	out := new(GetInfoResponseMessage)
+	err := c.cc.Invoke(ctx, "/protowire.API/GetInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetBlockDagInfo(ctx context.Context, in *GetBlockDagInfoRequestMessage, opts ...grpc.CallOption) (*GetBlockDagInfoResponseMessage, error) {
+	out := new(GetBlockDagInfoResponseMessage)
+	err := c.cc.Invoke(ctx, "/protowire.API/GetBlockDagInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetSelectedTipHash(ctx context.Context, in *GetSelectedTipHashRequestMessage, opts ...grpc.CallOption) (*GetSelectedTipHashResponseMessage, error) {
+	out := new(GetSelectedTipHashResponseMessage)
+	err := c.cc.Invoke(ctx, "/protowire.API/GetSelectedTipHash", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetBlock(ctx context.Context, in *GetBlockRequestMessage, opts ...grpc.CallOption) (*GetBlockResponseMessage, error) {
+	out := new(GetBlockResponseMessage)
+	err := c.cc.Invoke(ctx, "/protowire.API/GetBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetBlocks(ctx context.Context, in *GetBlocksRequestMessage, opts ...grpc.CallOption) (*GetBlocksResponseMessage, error) {
+	out := new(GetBlocksResponseMessage)
+	err := c.cc.Invoke(ctx, "/protowire.API/GetBlocks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetVirtualSelectedParentChainFromBlock(ctx context.Context, in *GetVirtualSelectedParentChainFromBlockRequestMessage, opts ...grpc.CallOption) (*GetVirtualSelectedParentChainFromBlockResponseMessage, error) {
+	out := new(GetVirtualSelectedParentChainFromBlockResponseMessage)
+	err := c.cc.Invoke(ctx, "/protowire.API/GetVirtualSelectedParentChainFromBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetUtxosByAddresses(ctx context.Context, in *GetUtxosByAddressesRequestMessage, opts ...grpc.CallOption) (*GetUtxosByAddressesResponseMessage, error) {
+	out := new(GetUtxosByAddressesResponseMessage)
+	err := c.cc.Invoke(ctx, "/protowire.API/GetUtxosByAddresses", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetBalanceByAddress(ctx context.Context, in *GetBalanceByAddressRequestMessage, opts ...grpc.CallOption) (*GetBalanceByAddressResponseMessage, error) {
+	out := new(GetBalanceByAddressResponseMessage)
+	err := c.cc.Invoke(ctx, "/protowire.API/GetBalanceByAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetBalancesByAddresses(ctx context.Context, in *GetBalancesByAddressesRequestMessage, opts ...grpc.CallOption) (*GetBalancesByAddressesResponseMessage, error) {
+	out := new(GetBalancesByAddressesResponseMessage)
+	err := c.cc.Invoke(ctx, "/protowire.API/GetBalancesByAddresses", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) SubmitBlock(ctx context.Context, in *SubmitBlockRequestMessage, opts ...grpc.CallOption) (*SubmitBlockResponseMessage, error) {
+	out := new(SubmitBlockResponseMessage)
+	err := c.cc.Invoke(ctx, "/protowire.API/SubmitBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) SubmitTransaction(ctx context.Context, in *SubmitTransactionRequestMessage, opts ...grpc.CallOption) (*SubmitTransactionResponseMessage, error) {
+	out := new(SubmitTransactionResponseMessage)
+	err := c.cc.Invoke(ctx, "/protowire.API/SubmitTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetMempoolEntry(ctx context.Context, in *GetMempoolEntryRequestMessage, opts ...grpc.CallOption) (*GetMempoolEntryResponseMessage, error) {
+	out := new(GetMempoolEntryResponseMessage)
+	err := c.cc.Invoke(ctx, "/protowire.API/GetMempoolEntry", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetMempoolEntries(ctx context.Context, in *GetMempoolEntriesRequestMessage, opts ...grpc.CallOption) (*GetMempoolEntriesResponseMessage, error) {
+	out := new(GetMempoolEntriesResponseMessage)
+	err := c.cc.Invoke(ctx, "/protowire.API/GetMempoolEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetMempoolEntriesByAddresses(ctx context.Context, in *GetMempoolEntriesByAddressesRequestMessage, opts ...grpc.CallOption) (*GetMempoolEntriesByAddressesResponseMessage, error) {
+	out := new(GetMempoolEntriesByAddressesResponseMessage)
+	err := c.cc.Invoke(ctx, "/protowire.API/GetMempoolEntriesByAddresses", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetConnectedPeerInfo(ctx context.Context, in *GetConnectedPeerInfoRequestMessage, opts ...grpc.CallOption) (*GetConnectedPeerInfoResponseMessage, error) {
+	out := new(GetConnectedPeerInfoResponseMessage)
+	err := c.cc.Invoke(ctx, "/protowire.API/GetConnectedPeerInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) GetPeerAddresses(ctx context.Context, in *GetPeerAddressesRequestMessage, opts ...grpc.CallOption) (*GetPeerAddressesResponseMessage, error) {
+	out := new(GetPeerAddressesResponseMessage)
+	err := c.cc.Invoke(ctx, "/protowire.API/GetPeerAddresses", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) NotifyBlockAdded(ctx context.Context, in *NotifyBlockAddedRequestMessage, opts ...grpc.CallOption) (API_NotifyBlockAddedClient, error) {
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[0], "/protowire.API/NotifyBlockAdded", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPINotifyBlockAddedClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_NotifyBlockAddedClient interface {
+	Recv() (*BlockAddedNotificationMessage, error)
+	grpc.ClientStream
+}
+
+type aPINotifyBlockAddedClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPINotifyBlockAddedClient) Recv() (*BlockAddedNotificationMessage, error) {
+	m := new(BlockAddedNotificationMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) NotifyNewTip(ctx context.Context, in *NotifyNewTipRequestMessage, opts ...grpc.CallOption) (API_NotifyNewTipClient, error) {
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[1], "/protowire.API/NotifyNewTip", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPINotifyNewTipClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_NotifyNewTipClient interface {
+	Recv() (*NewTipNotificationMessage, error)
+	grpc.ClientStream
+}
+
+type aPINotifyNewTipClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPINotifyNewTipClient) Recv() (*NewTipNotificationMessage, error) {
+	m := new(NewTipNotificationMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) NotifyVirtualSelectedParentChainChanged(ctx context.Context, in *NotifyVirtualSelectedParentChainChangedRequestMessage, opts ...grpc.CallOption) (API_NotifyVirtualSelectedParentChainChangedClient, error) {
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[2], "/protowire.API/NotifyVirtualSelectedParentChainChanged", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPINotifyVirtualSelectedParentChainChangedClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_NotifyVirtualSelectedParentChainChangedClient interface {
+	Recv() (*VirtualSelectedParentChainChangedNotificationMessage, error)
+	grpc.ClientStream
+}
+
+type aPINotifyVirtualSelectedParentChainChangedClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPINotifyVirtualSelectedParentChainChangedClient) Recv() (*VirtualSelectedParentChainChangedNotificationMessage, error) {
+	m := new(VirtualSelectedParentChainChangedNotificationMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) NotifyVirtualDaaScoreChanged(ctx context.Context, in *NotifyVirtualDaaScoreChangedRequestMessage, opts ...grpc.CallOption) (API_NotifyVirtualDaaScoreChangedClient, error) {
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[3], "/protowire.API/NotifyVirtualDaaScoreChanged", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPINotifyVirtualDaaScoreChangedClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_NotifyVirtualDaaScoreChangedClient interface {
+	Recv() (*VirtualDaaScoreChangedNotificationMessage, error)
+	grpc.ClientStream
+}
+
+type aPINotifyVirtualDaaScoreChangedClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPINotifyVirtualDaaScoreChangedClient) Recv() (*VirtualDaaScoreChangedNotificationMessage, error) {
+	m := new(VirtualDaaScoreChangedNotificationMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) NotifyFinalityPointAdvanced(ctx context.Context, in *NotifyFinalityPointAdvancedRequestMessage, opts ...grpc.CallOption) (API_NotifyFinalityPointAdvancedClient, error) {
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[4], "/protowire.API/NotifyFinalityPointAdvanced", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPINotifyFinalityPointAdvancedClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_NotifyFinalityPointAdvancedClient interface {
+	Recv() (*FinalityPointAdvancedNotificationMessage, error)
+	grpc.ClientStream
+}
+
+type aPINotifyFinalityPointAdvancedClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPINotifyFinalityPointAdvancedClient) Recv() (*FinalityPointAdvancedNotificationMessage, error) {
+	m := new(FinalityPointAdvancedNotificationMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) NotifyUtxosChanged(ctx context.Context, in *NotifyUtxosChangedRequestMessage, opts ...grpc.CallOption) (API_NotifyUtxosChangedClient, error) {
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[5], "/protowire.API/NotifyUtxosChanged", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPINotifyUtxosChangedClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_NotifyUtxosChangedClient interface {
+	Recv() (*UtxosChangedNotificationMessage, error)
+	grpc.ClientStream
+}
+
+type aPINotifyUtxosChangedClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPINotifyUtxosChangedClient) Recv() (*UtxosChangedNotificationMessage, error) {
+	m := new(UtxosChangedNotificationMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) NotifyNewTransactions(ctx context.Context, in *NotifyNewTransactionsRequestMessage, opts ...grpc.CallOption) (API_NotifyNewTransactionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[6], "/protowire.API/NotifyNewTransactions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPINotifyNewTransactionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type API_NotifyNewTransactionsClient interface {
+	Recv() (*NewTransactionsNotificationMessage, error)
+	grpc.ClientStream
+}
+
+type aPINotifyNewTransactionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPINotifyNewTransactionsClient) Recv() (*NewTransactionsNotificationMessage, error) {
+	m := new(NewTransactionsNotificationMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// APIServer is the server API for API service.
+// All implementations must embed UnimplementedAPIServer
+// for forward compatibility
+type APIServer interface {
+	GetInfo(context.Context, *GetInfoRequestMessage) (*GetInfoResponseMessage, error)
+	GetBlockDagInfo(context.Context, *GetBlockDagInfoRequestMessage) (*GetBlockDagInfoResponseMessage, error)
+	GetSelectedTipHash(context.Context, *GetSelectedTipHashRequestMessage) (*GetSelectedTipHashResponseMessage, error)
+	GetBlock(context.Context, *GetBlockRequestMessage) (*GetBlockResponseMessage, error)
+	GetBlocks(context.Context, *GetBlocksRequestMessage) (*GetBlocksResponseMessage, error)
+	GetVirtualSelectedParentChainFromBlock(context.Context, *GetVirtualSelectedParentChainFromBlockRequestMessage) (*GetVirtualSelectedParentChainFromBlockResponseMessage, error)
+	GetUtxosByAddresses(context.Context, *GetUtxosByAddressesRequestMessage) (*GetUtxosByAddressesResponseMessage, error)
+	GetBalanceByAddress(context.Context, *GetBalanceByAddressRequestMessage) (*GetBalanceByAddressResponseMessage, error)
+	GetBalancesByAddresses(context.Context, *GetBalancesByAddressesRequestMessage) (*GetBalancesByAddressesResponseMessage, error)
+	SubmitBlock(context.Context, *SubmitBlockRequestMessage) (*SubmitBlockResponseMessage, error)
+	SubmitTransaction(context.Context, *SubmitTransactionRequestMessage) (*SubmitTransactionResponseMessage, error)
+	GetMempoolEntry(context.Context, *GetMempoolEntryRequestMessage) (*GetMempoolEntryResponseMessage, error)
+	GetMempoolEntries(context.Context, *GetMempoolEntriesRequestMessage) (*GetMempoolEntriesResponseMessage, error)
+	GetMempoolEntriesByAddresses(context.Context, *GetMempoolEntriesByAddressesRequestMessage) (*GetMempoolEntriesByAddressesResponseMessage, error)
+	GetConnectedPeerInfo(context.Context, *GetConnectedPeerInfoRequestMessage) (*GetConnectedPeerInfoResponseMessage, error)
+	GetPeerAddresses(context.Context, *GetPeerAddressesRequestMessage) (*GetPeerAddressesResponseMessage, error)
+	NotifyBlockAdded(*NotifyBlockAddedRequestMessage, API_NotifyBlockAddedServer) error
+	NotifyNewTip(*NotifyNewTipRequestMessage, API_NotifyNewTipServer) error
+	NotifyVirtualSelectedParentChainChanged(*NotifyVirtualSelectedParentChainChangedRequestMessage, API_NotifyVirtualSelectedParentChainChangedServer) error
+	NotifyVirtualDaaScoreChanged(*NotifyVirtualDaaScoreChangedRequestMessage, API_NotifyVirtualDaaScoreChangedServer) error
+	NotifyFinalityPointAdvanced(*NotifyFinalityPointAdvancedRequestMessage, API_NotifyFinalityPointAdvancedServer) error
+	NotifyUtxosChanged(*NotifyUtxosChangedRequestMessage, API_NotifyUtxosChangedServer) error
+	NotifyNewTransactions(*NotifyNewTransactionsRequestMessage, API_NotifyNewTransactionsServer) error
+	mustEmbedUnimplementedAPIServer()
+}
+
+// UnimplementedAPIServer must be embedded to have forward compatible implementations.
+type UnimplementedAPIServer struct {
+}
+
+func (UnimplementedAPIServer) GetInfo(context.Context, *GetInfoRequestMessage) (*GetInfoResponseMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInfo not implemented")
+}
+func (UnimplementedAPIServer) GetBlockDagInfo(context.Context, *GetBlockDagInfoRequestMessage) (*GetBlockDagInfoResponseMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockDagInfo not implemented")
+}
+func (UnimplementedAPIServer) GetSelectedTipHash(context.Context, *GetSelectedTipHashRequestMessage) (*GetSelectedTipHashResponseMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSelectedTipHash not implemented")
+}
+func (UnimplementedAPIServer) GetBlock(context.Context, *GetBlockRequestMessage) (*GetBlockResponseMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (UnimplementedAPIServer) GetBlocks(context.Context, *GetBlocksRequestMessage) (*GetBlocksResponseMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlocks not implemented")
+}
+func (UnimplementedAPIServer) GetVirtualSelectedParentChainFromBlock(context.Context, *GetVirtualSelectedParentChainFromBlockRequestMessage) (*GetVirtualSelectedParentChainFromBlockResponseMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVirtualSelectedParentChainFromBlock not implemented")
+}
+func (UnimplementedAPIServer) GetUtxosByAddresses(context.Context, *GetUtxosByAddressesRequestMessage) (*GetUtxosByAddressesResponseMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUtxosByAddresses not implemented")
+}
+func (UnimplementedAPIServer) GetBalanceByAddress(context.Context, *GetBalanceByAddressRequestMessage) (*GetBalanceByAddressResponseMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalanceByAddress not implemented")
+}
+func (UnimplementedAPIServer) GetBalancesByAddresses(context.Context, *GetBalancesByAddressesRequestMessage) (*GetBalancesByAddressesResponseMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalancesByAddresses not implemented")
+}
+func (UnimplementedAPIServer) SubmitBlock(context.Context, *SubmitBlockRequestMessage) (*SubmitBlockResponseMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitBlock not implemented")
+}
+func (UnimplementedAPIServer) SubmitTransaction(context.Context, *SubmitTransactionRequestMessage) (*SubmitTransactionResponseMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitTransaction not implemented")
+}
+func (UnimplementedAPIServer) GetMempoolEntry(context.Context, *GetMempoolEntryRequestMessage) (*GetMempoolEntryResponseMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMempoolEntry not implemented")
+}
+func (UnimplementedAPIServer) GetMempoolEntries(context.Context, *GetMempoolEntriesRequestMessage) (*GetMempoolEntriesResponseMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMempoolEntries not implemented")
+}
+func (UnimplementedAPIServer) GetMempoolEntriesByAddresses(context.Context, *GetMempoolEntriesByAddressesRequestMessage) (*GetMempoolEntriesByAddressesResponseMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMempoolEntriesByAddresses not implemented")
+}
+func (UnimplementedAPIServer) GetConnectedPeerInfo(context.Context, *GetConnectedPeerInfoRequestMessage) (*GetConnectedPeerInfoResponseMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConnectedPeerInfo not implemented")
+}
+func (UnimplementedAPIServer) GetPeerAddresses(context.Context, *GetPeerAddressesRequestMessage) (*GetPeerAddressesResponseMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPeerAddresses not implemented")
+}
+func (UnimplementedAPIServer) NotifyBlockAdded(*NotifyBlockAddedRequestMessage, API_NotifyBlockAddedServer) error {
+	return status.Errorf(codes.Unimplemented, "method NotifyBlockAdded not implemented")
+}
+func (UnimplementedAPIServer) NotifyNewTip(*NotifyNewTipRequestMessage, API_NotifyNewTipServer) error {
+	return status.Errorf(codes.Unimplemented, "method NotifyNewTip not implemented")
+}
+func (UnimplementedAPIServer) NotifyVirtualSelectedParentChainChanged(*NotifyVirtualSelectedParentChainChangedRequestMessage, API_NotifyVirtualSelectedParentChainChangedServer) error {
+	return status.Errorf(codes.Unimplemented, "method NotifyVirtualSelectedParentChainChanged not implemented")
+}
+func (UnimplementedAPIServer) NotifyVirtualDaaScoreChanged(*NotifyVirtualDaaScoreChangedRequestMessage, API_NotifyVirtualDaaScoreChangedServer) error {
+	return status.Errorf(codes.Unimplemented, "method NotifyVirtualDaaScoreChanged not implemented")
+}
+func (UnimplementedAPIServer) NotifyFinalityPointAdvanced(*NotifyFinalityPointAdvancedRequestMessage, API_NotifyFinalityPointAdvancedServer) error {
+	return status.Errorf(codes.Unimplemented, "method NotifyFinalityPointAdvanced not implemented")
+}
+func (UnimplementedAPIServer) NotifyUtxosChanged(*NotifyUtxosChangedRequestMessage, API_NotifyUtxosChangedServer) error {
+	return status.Errorf(codes.Unimplemented, "method NotifyUtxosChanged not implemented")
+}
+func (UnimplementedAPIServer) NotifyNewTransactions(*NotifyNewTransactionsRequestMessage, API_NotifyNewTransactionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method NotifyNewTransactions not implemented")
+}
+func (UnimplementedAPIServer) mustEmbedUnimplementedAPIServer() {}
+
+// UnsafeAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to APIServer will
+// result in compilation errors.
+type UnsafeAPIServer interface {
+	mustEmbedUnimplementedAPIServer()
+}
+
+func RegisterAPIServer(s grpc.ServiceRegistrar, srv APIServer) {
+	s.RegisterService(&API_ServiceDesc, srv)
+}
+
+func _API_GetInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInfoRequestMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protowire.API/GetInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetInfo(ctx, req.(*GetInfoRequestMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetBlockDagInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockDagInfoRequestMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetBlockDagInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protowire.API/GetBlockDagInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetBlockDagInfo(ctx, req.(*GetBlockDagInfoRequestMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetSelectedTipHash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSelectedTipHashRequestMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetSelectedTipHash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protowire.API/GetSelectedTipHash",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetSelectedTipHash(ctx, req.(*GetSelectedTipHashRequestMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlockRequestMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protowire.API/GetBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetBlock(ctx, req.(*GetBlockRequestMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBlocksRequestMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetBlocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protowire.API/GetBlocks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetBlocks(ctx, req.(*GetBlocksRequestMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetVirtualSelectedParentChainFromBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVirtualSelectedParentChainFromBlockRequestMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetVirtualSelectedParentChainFromBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protowire.API/GetVirtualSelectedParentChainFromBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetVirtualSelectedParentChainFromBlock(ctx, req.(*GetVirtualSelectedParentChainFromBlockRequestMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetUtxosByAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUtxosByAddressesRequestMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetUtxosByAddresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protowire.API/GetUtxosByAddresses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetUtxosByAddresses(ctx, req.(*GetUtxosByAddressesRequestMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetBalanceByAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceByAddressRequestMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetBalanceByAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protowire.API/GetBalanceByAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetBalanceByAddress(ctx, req.(*GetBalanceByAddressRequestMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetBalancesByAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalancesByAddressesRequestMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetBalancesByAddresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protowire.API/GetBalancesByAddresses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetBalancesByAddresses(ctx, req.(*GetBalancesByAddressesRequestMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_SubmitBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitBlockRequestMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).SubmitBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protowire.API/SubmitBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).SubmitBlock(ctx, req.(*SubmitBlockRequestMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_SubmitTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitTransactionRequestMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).SubmitTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protowire.API/SubmitTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).SubmitTransaction(ctx, req.(*SubmitTransactionRequestMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetMempoolEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMempoolEntryRequestMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetMempoolEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protowire.API/GetMempoolEntry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetMempoolEntry(ctx, req.(*GetMempoolEntryRequestMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetMempoolEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMempoolEntriesRequestMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetMempoolEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protowire.API/GetMempoolEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetMempoolEntries(ctx, req.(*GetMempoolEntriesRequestMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetMempoolEntriesByAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMempoolEntriesByAddressesRequestMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetMempoolEntriesByAddresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protowire.API/GetMempoolEntriesByAddresses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetMempoolEntriesByAddresses(ctx, req.(*GetMempoolEntriesByAddressesRequestMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetConnectedPeerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConnectedPeerInfoRequestMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetConnectedPeerInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protowire.API/GetConnectedPeerInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetConnectedPeerInfo(ctx, req.(*GetConnectedPeerInfoRequestMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_GetPeerAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPeerAddressesRequestMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).GetPeerAddresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protowire.API/GetPeerAddresses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).GetPeerAddresses(ctx, req.(*GetPeerAddressesRequestMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_NotifyBlockAdded_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(NotifyBlockAddedRequestMessage)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).NotifyBlockAdded(m, &aPINotifyBlockAddedServer{stream})
+}
+
+type API_NotifyBlockAddedServer interface {
+	Send(*BlockAddedNotificationMessage) error
+	grpc.ServerStream
+}
+
+type aPINotifyBlockAddedServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPINotifyBlockAddedServer) Send(m *BlockAddedNotificationMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_NotifyNewTip_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(NotifyNewTipRequestMessage)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).NotifyNewTip(m, &aPINotifyNewTipServer{stream})
+}
+
+type API_NotifyNewTipServer interface {
+	Send(*NewTipNotificationMessage) error
+	grpc.ServerStream
+}
+
+type aPINotifyNewTipServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPINotifyNewTipServer) Send(m *NewTipNotificationMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_NotifyVirtualSelectedParentChainChanged_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(NotifyVirtualSelectedParentChainChangedRequestMessage)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).NotifyVirtualSelectedParentChainChanged(m, &aPINotifyVirtualSelectedParentChainChangedServer{stream})
+}
+
+type API_NotifyVirtualSelectedParentChainChangedServer interface {
+	Send(*VirtualSelectedParentChainChangedNotificationMessage) error
+	grpc.ServerStream
+}
+
+type aPINotifyVirtualSelectedParentChainChangedServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPINotifyVirtualSelectedParentChainChangedServer) Send(m *VirtualSelectedParentChainChangedNotificationMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_NotifyVirtualDaaScoreChanged_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(NotifyVirtualDaaScoreChangedRequestMessage)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).NotifyVirtualDaaScoreChanged(m, &aPINotifyVirtualDaaScoreChangedServer{stream})
+}
+
+type API_NotifyVirtualDaaScoreChangedServer interface {
+	Send(*VirtualDaaScoreChangedNotificationMessage) error
+	grpc.ServerStream
+}
+
+type aPINotifyVirtualDaaScoreChangedServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPINotifyVirtualDaaScoreChangedServer) Send(m *VirtualDaaScoreChangedNotificationMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_NotifyFinalityPointAdvanced_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(NotifyFinalityPointAdvancedRequestMessage)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).NotifyFinalityPointAdvanced(m, &aPINotifyFinalityPointAdvancedServer{stream})
+}
+
+type API_NotifyFinalityPointAdvancedServer interface {
+	Send(*FinalityPointAdvancedNotificationMessage) error
+	grpc.ServerStream
+}
+
+type aPINotifyFinalityPointAdvancedServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPINotifyFinalityPointAdvancedServer) Send(m *FinalityPointAdvancedNotificationMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_NotifyUtxosChanged_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(NotifyUtxosChangedRequestMessage)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).NotifyUtxosChanged(m, &aPINotifyUtxosChangedServer{stream})
+}
+
+type API_NotifyUtxosChangedServer interface {
+	Send(*UtxosChangedNotificationMessage) error
+	grpc.ServerStream
+}
+
+type aPINotifyUtxosChangedServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPINotifyUtxosChangedServer) Send(m *UtxosChangedNotificationMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _API_NotifyNewTransactions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(NotifyNewTransactionsRequestMessage)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(APIServer).NotifyNewTransactions(m, &aPINotifyNewTransactionsServer{stream})
+}
+
+type API_NotifyNewTransactionsServer interface {
+	Send(*NewTransactionsNotificationMessage) error
+	grpc.ServerStream
+}
+
+type aPINotifyNewTransactionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPINotifyNewTransactionsServer) Send(m *NewTransactionsNotificationMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// API_ServiceDesc is the grpc.ServiceDesc for API service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var API_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "protowire.API",
+	HandlerType: (*APIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetInfo",
+			Handler:    _API_GetInfo_Handler,
+		},
+		{
+			MethodName: "GetBlockDagInfo",
+			Handler:    _API_GetBlockDagInfo_Handler,
+		},
+		{
+			MethodName: "GetSelectedTipHash",
+			Handler:    _API_GetSelectedTipHash_Handler,
+		},
+		{
+			MethodName: "GetBlock",
+			Handler:    _API_GetBlock_Handler,
+		},
+		{
+			MethodName: "GetBlocks",
+			Handler:    _API_GetBlocks_Handler,
+		},
+		{
+			MethodName: "GetVirtualSelectedParentChainFromBlock",
+			Handler:    _API_GetVirtualSelectedParentChainFromBlock_Handler,
+		},
+		{
+			MethodName: "GetUtxosByAddresses",
+			Handler:    _API_GetUtxosByAddresses_Handler,
+		},
+		{
+			MethodName: "GetBalanceByAddress",
+			Handler:    _API_GetBalanceByAddress_Handler,
+		},
+		{
+			MethodName: "GetBalancesByAddresses",
+			Handler:    _API_GetBalancesByAddresses_Handler,
+		},
+		{
+			MethodName: "SubmitBlock",
+			Handler:    _API_SubmitBlock_Handler,
+		},
+		{
+			MethodName: "SubmitTransaction",
+			Handler:    _API_SubmitTransaction_Handler,
+		},
+		{
+			MethodName: "GetMempoolEntry",
+			Handler:    _API_GetMempoolEntry_Handler,
+		},
+		{
+			MethodName: "GetMempoolEntries",
+			Handler:    _API_GetMempoolEntries_Handler,
+		},
+		{
+			MethodName: "GetMempoolEntriesByAddresses",
+			Handler:    _API_GetMempoolEntriesByAddresses_Handler,
+		},
+		{
+			MethodName: "GetConnectedPeerInfo",
+			Handler:    _API_GetConnectedPeerInfo_Handler,
+		},
+		{
+			MethodName: "GetPeerAddresses",
+			Handler:    _API_GetPeerAddresses_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "NotifyBlockAdded",
+			Handler:       _API_NotifyBlockAdded_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "NotifyNewTip",
+			Handler:       _API_NotifyNewTip_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "NotifyVirtualSelectedParentChainChanged",
+			Handler:       _API_NotifyVirtualSelectedParentChainChanged_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "NotifyVirtualDaaScoreChanged",
+			Handler:       _API_NotifyVirtualDaaScoreChanged_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "NotifyFinalityPointAdvanced",
+			Handler:       _API_NotifyFinalityPointAdvanced_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "NotifyUtxosChanged",
+			Handler:       _API_NotifyUtxosChanged_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "NotifyNewTransactions",
+			Handler:       _API_NotifyNewTransactions_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "messages.proto",
+}
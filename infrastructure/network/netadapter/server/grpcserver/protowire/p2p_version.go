@@ -2,6 +2,7 @@ package protowire
 
 import (
 	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/id"
 	"github.com/kaspanet/kaspad/util/mstime"
 	"github.com/pkg/errors"
@@ -30,6 +31,15 @@ func (x *VersionMessage) toAppMessage() (appmessage.Message, error) {
 		return nil, err
 	}
 
+	// Nodes that serve every subnetwork may set SubnetworkRoles==nil
+	var subnetworkRoles *appmessage.SubnetworkRoles
+	if x.SubnetworkRoles != nil {
+		subnetworkRoles, err = x.SubnetworkRoles.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	err = appmessage.ValidateUserAgent(x.UserAgent)
 	if err != nil {
 		return nil, err
@@ -54,6 +64,7 @@ func (x *VersionMessage) toAppMessage() (appmessage.Message, error) {
 		UserAgent:       x.UserAgent,
 		DisableRelayTx:  x.DisableRelayTx,
 		SubnetworkID:    subnetworkID,
+		SubnetworkRoles: subnetworkRoles,
 	}, nil
 }
 
@@ -84,6 +95,61 @@ func (x *KaspadMessage_Version) fromAppMessage(msgVersion *appmessage.MsgVersion
 		UserAgent:       msgVersion.UserAgent,
 		DisableRelayTx:  msgVersion.DisableRelayTx,
 		SubnetworkId:    domainSubnetworkIDToProto(msgVersion.SubnetworkID),
+		SubnetworkRoles: appMessageSubnetworkRolesToProto(msgVersion.SubnetworkRoles),
 	}
 	return nil
 }
+
+func (x *SubnetworkRoles) toAppMessage() (*appmessage.SubnetworkRoles, error) {
+	validatedSubnetworkIDs, err := protoSubnetworkIDsToDomain(x.ValidatedSubnetworkIds)
+	if err != nil {
+		return nil, err
+	}
+	indexedSubnetworkIDs, err := protoSubnetworkIDsToDomain(x.IndexedSubnetworkIds)
+	if err != nil {
+		return nil, err
+	}
+	relayedSubnetworkIDs, err := protoSubnetworkIDsToDomain(x.RelayedSubnetworkIds)
+	if err != nil {
+		return nil, err
+	}
+	return &appmessage.SubnetworkRoles{
+		ValidatedSubnetworkIDs: validatedSubnetworkIDs,
+		IndexedSubnetworkIDs:   indexedSubnetworkIDs,
+		RelayedSubnetworkIDs:   relayedSubnetworkIDs,
+	}, nil
+}
+
+func appMessageSubnetworkRolesToProto(subnetworkRoles *appmessage.SubnetworkRoles) *SubnetworkRoles {
+	if subnetworkRoles == nil {
+		return nil
+	}
+	return &SubnetworkRoles{
+		ValidatedSubnetworkIds: domainSubnetworkIDsToProto(subnetworkRoles.ValidatedSubnetworkIDs),
+		IndexedSubnetworkIds:   domainSubnetworkIDsToProto(subnetworkRoles.IndexedSubnetworkIDs),
+		RelayedSubnetworkIds:   domainSubnetworkIDsToProto(subnetworkRoles.RelayedSubnetworkIDs),
+	}
+}
+
+func protoSubnetworkIDsToDomain(protoSubnetworkIDs []*SubnetworkId) ([]*externalapi.DomainSubnetworkID, error) {
+	if len(protoSubnetworkIDs) == 0 {
+		return nil, nil
+	}
+	subnetworkIDs := make([]*externalapi.DomainSubnetworkID, len(protoSubnetworkIDs))
+	for i, protoSubnetworkID := range protoSubnetworkIDs {
+		subnetworkID, err := protoSubnetworkID.toDomain()
+		if err != nil {
+			return nil, err
+		}
+		subnetworkIDs[i] = subnetworkID
+	}
+	return subnetworkIDs, nil
+}
+
+func domainSubnetworkIDsToProto(subnetworkIDs []*externalapi.DomainSubnetworkID) []*SubnetworkId {
+	protoSubnetworkIDs := make([]*SubnetworkId, len(subnetworkIDs))
+	for i, subnetworkID := range subnetworkIDs {
+		protoSubnetworkIDs[i] = domainSubnetworkIDToProto(subnetworkID)
+	}
+	return protoSubnetworkIDs
+}
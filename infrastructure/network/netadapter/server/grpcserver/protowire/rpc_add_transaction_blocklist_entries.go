@@ -0,0 +1,118 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_AddTransactionBlocklistEntriesRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_AddTransactionBlocklistEntriesRequest is nil")
+	}
+	return x.AddTransactionBlocklistEntriesRequest.toAppMessage()
+}
+
+func (x *AddTransactionBlocklistEntriesRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "AddTransactionBlocklistEntriesRequestMessage is nil")
+	}
+	entries, err := transactionBlocklistEntriesToAppMessage(x.Entries)
+	if err != nil {
+		return nil, err
+	}
+	return &appmessage.AddTransactionBlocklistEntriesRequestMessage{
+		Entries: entries,
+	}, nil
+}
+
+func (x *KaspadMessage_AddTransactionBlocklistEntriesRequest) fromAppMessage(
+	message *appmessage.AddTransactionBlocklistEntriesRequestMessage) error {
+
+	x.AddTransactionBlocklistEntriesRequest = &AddTransactionBlocklistEntriesRequestMessage{
+		Entries: transactionBlocklistEntriesFromAppMessage(message.Entries),
+	}
+	return nil
+}
+
+func (x *KaspadMessage_AddTransactionBlocklistEntriesResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_AddTransactionBlocklistEntriesResponse is nil")
+	}
+	return x.AddTransactionBlocklistEntriesResponse.toAppMessage()
+}
+
+func (x *AddTransactionBlocklistEntriesResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "AddTransactionBlocklistEntriesResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	if rpcErr != nil && len(x.RemovedTransactionIds) != 0 {
+		return nil, errors.New("AddTransactionBlocklistEntriesResponseMessage contains both an error and a response")
+	}
+	return &appmessage.AddTransactionBlocklistEntriesResponseMessage{
+		RemovedTransactionIDs: x.RemovedTransactionIds,
+		Error:                 rpcErr,
+	}, nil
+}
+
+func (x *KaspadMessage_AddTransactionBlocklistEntriesResponse) fromAppMessage(
+	message *appmessage.AddTransactionBlocklistEntriesResponseMessage) error {
+
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.AddTransactionBlocklistEntriesResponse = &AddTransactionBlocklistEntriesResponseMessage{
+		RemovedTransactionIds: message.RemovedTransactionIDs,
+		Error:                 err,
+	}
+	return nil
+}
+
+func transactionBlocklistEntriesToAppMessage(entries []*TransactionBlocklistEntry) (
+	[]*appmessage.TransactionBlocklistEntry, error) {
+
+	appEntries := make([]*appmessage.TransactionBlocklistEntry, len(entries))
+	for i, entry := range entries {
+		if entry == nil {
+			return nil, errors.Wrapf(errorNil, "TransactionBlocklistEntry is nil")
+		}
+		var scriptPublicKey *appmessage.RPCScriptPublicKey
+		if entry.ScriptPublicKey != nil {
+			var err error
+			scriptPublicKey, err = entry.ScriptPublicKey.toAppMessage()
+			if err != nil {
+				return nil, err
+			}
+		}
+		appEntries[i] = &appmessage.TransactionBlocklistEntry{
+			TransactionID:   entry.TransactionId,
+			ScriptPublicKey: scriptPublicKey,
+			Reason:          entry.Reason,
+			AddedAt:         entry.AddedAt,
+		}
+	}
+	return appEntries, nil
+}
+
+func transactionBlocklistEntriesFromAppMessage(entries []*appmessage.TransactionBlocklistEntry) []*TransactionBlocklistEntry {
+	protoEntries := make([]*TransactionBlocklistEntry, len(entries))
+	for i, entry := range entries {
+		var scriptPublicKey *RpcScriptPublicKey
+		if entry.ScriptPublicKey != nil {
+			scriptPublicKey = &RpcScriptPublicKey{}
+			scriptPublicKey.fromAppMessage(entry.ScriptPublicKey)
+		}
+		protoEntries[i] = &TransactionBlocklistEntry{
+			TransactionId:   entry.TransactionID,
+			ScriptPublicKey: scriptPublicKey,
+			Reason:          entry.Reason,
+			AddedAt:         entry.AddedAt,
+		}
+	}
+	return protoEntries
+}
@@ -0,0 +1,61 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_CancelJobRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_CancelJobRequest is nil")
+	}
+	return x.CancelJobRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_CancelJobRequest) fromAppMessage(message *appmessage.CancelJobRequestMessage) error {
+	x.CancelJobRequest = &CancelJobRequestMessage{
+		JobId: message.JobID,
+	}
+	return nil
+}
+
+func (x *CancelJobRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "CancelJobRequestMessage is nil")
+	}
+	return &appmessage.CancelJobRequestMessage{
+		JobID: x.JobId,
+	}, nil
+}
+
+func (x *KaspadMessage_CancelJobResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_CancelJobResponse is nil")
+	}
+	return x.CancelJobResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_CancelJobResponse) fromAppMessage(message *appmessage.CancelJobResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.CancelJobResponse = &CancelJobResponseMessage{
+		Error: err,
+	}
+	return nil
+}
+
+func (x *CancelJobResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "CancelJobResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.CancelJobResponseMessage{
+		Error: rpcErr,
+	}, nil
+}
@@ -0,0 +1,69 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_CaptureProfileRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_CaptureProfileRequest is nil")
+	}
+	return x.CaptureProfileRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_CaptureProfileRequest) fromAppMessage(message *appmessage.CaptureProfileRequestMessage) error {
+	x.CaptureProfileRequest = &CaptureProfileRequestMessage{
+		ProfileType:     message.ProfileType,
+		DurationSeconds: message.DurationSeconds,
+		IncludeData:     message.IncludeData,
+	}
+	return nil
+}
+
+func (x *CaptureProfileRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "CaptureProfileRequestMessage is nil")
+	}
+	return &appmessage.CaptureProfileRequestMessage{
+		ProfileType:     x.ProfileType,
+		DurationSeconds: x.DurationSeconds,
+		IncludeData:     x.IncludeData,
+	}, nil
+}
+
+func (x *KaspadMessage_CaptureProfileResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_CaptureProfileResponse is nil")
+	}
+	return x.CaptureProfileResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_CaptureProfileResponse) fromAppMessage(message *appmessage.CaptureProfileResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.CaptureProfileResponse = &CaptureProfileResponseMessage{
+		FilePath: message.FilePath,
+		Data:     message.Data,
+		Error:    err,
+	}
+	return nil
+}
+
+func (x *CaptureProfileResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "CaptureProfileResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.CaptureProfileResponseMessage{
+		FilePath: x.FilePath,
+		Data:     x.Data,
+		Error:    rpcErr,
+	}, nil
+}
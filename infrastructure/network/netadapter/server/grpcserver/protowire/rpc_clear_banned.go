@@ -0,0 +1,50 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_ClearBannedRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_ClearBannedRequest is nil")
+	}
+	return &appmessage.ClearBannedRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_ClearBannedRequest) fromAppMessage(_ *appmessage.ClearBannedRequestMessage) error {
+	x.ClearBannedRequest = &ClearBannedRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_ClearBannedResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_ClearBannedResponse is nil")
+	}
+	return x.ClearBannedResponse.toAppMessage()
+}
+
+func (x *ClearBannedResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ClearBannedResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.ClearBannedResponseMessage{
+		Error: rpcErr,
+	}, nil
+}
+
+func (x *KaspadMessage_ClearBannedResponse) fromAppMessage(message *appmessage.ClearBannedResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.ClearBannedResponse = &ClearBannedResponseMessage{
+		Error: err,
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_DebugLevelRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_DebugLevelRequest is nil")
+	}
+	return x.DebugLevelRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_DebugLevelRequest) fromAppMessage(message *appmessage.DebugLevelRequestMessage) error {
+	x.DebugLevelRequest = &DebugLevelRequestMessage{
+		LevelSpec: message.LevelSpec,
+	}
+	return nil
+}
+
+func (x *DebugLevelRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "DebugLevelRequestMessage is nil")
+	}
+	return &appmessage.DebugLevelRequestMessage{
+		LevelSpec: x.LevelSpec,
+	}, nil
+}
+
+func (x *KaspadMessage_DebugLevelResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_DebugLevelResponse is nil")
+	}
+	return x.DebugLevelResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_DebugLevelResponse) fromAppMessage(message *appmessage.DebugLevelResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	subsystemLogLevels := make([]*SubsystemLogLevel, len(message.SubsystemLogLevels))
+	for i, subsystemLogLevel := range message.SubsystemLogLevels {
+		subsystemLogLevels[i] = &SubsystemLogLevel{
+			Subsystem: subsystemLogLevel.Subsystem,
+			Level:     subsystemLogLevel.Level,
+		}
+	}
+	x.DebugLevelResponse = &DebugLevelResponseMessage{
+		SubsystemLogLevels: subsystemLogLevels,
+		Error:              err,
+	}
+	return nil
+}
+
+func (x *DebugLevelResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "DebugLevelResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	subsystemLogLevels := make([]*appmessage.SubsystemLogLevel, len(x.SubsystemLogLevels))
+	for i, subsystemLogLevel := range x.SubsystemLogLevels {
+		if subsystemLogLevel == nil {
+			return nil, errors.Wrapf(errorNil, "SubsystemLogLevel is nil")
+		}
+		subsystemLogLevels[i] = &appmessage.SubsystemLogLevel{
+			Subsystem: subsystemLogLevel.Subsystem,
+			Level:     subsystemLogLevel.Level,
+		}
+	}
+	return &appmessage.DebugLevelResponseMessage{
+		SubsystemLogLevels: subsystemLogLevels,
+		Error:              rpcErr,
+	}, nil
+}
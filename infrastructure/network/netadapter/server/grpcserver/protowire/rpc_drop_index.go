@@ -0,0 +1,61 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_DropIndexRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_DropIndexRequest is nil")
+	}
+	return x.DropIndexRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_DropIndexRequest) fromAppMessage(message *appmessage.DropIndexRequestMessage) error {
+	x.DropIndexRequest = &DropIndexRequestMessage{
+		IndexName: message.IndexName,
+	}
+	return nil
+}
+
+func (x *DropIndexRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "DropIndexRequestMessage is nil")
+	}
+	return &appmessage.DropIndexRequestMessage{
+		IndexName: x.IndexName,
+	}, nil
+}
+
+func (x *KaspadMessage_DropIndexResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_DropIndexResponse is nil")
+	}
+	return x.DropIndexResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_DropIndexResponse) fromAppMessage(message *appmessage.DropIndexResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.DropIndexResponse = &DropIndexResponseMessage{
+		Error: err,
+	}
+	return nil
+}
+
+func (x *DropIndexResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "DropIndexResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.DropIndexResponseMessage{
+		Error: rpcErr,
+	}, nil
+}
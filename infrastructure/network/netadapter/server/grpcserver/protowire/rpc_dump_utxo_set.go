@@ -0,0 +1,71 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_DumpUtxoSetRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_DumpUtxoSetRequest is nil")
+	}
+	return x.DumpUtxoSetRequest.toAppMessage()
+}
+
+func (x *DumpUtxoSetRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "DumpUtxoSetRequestMessage is nil")
+	}
+	return &appmessage.DumpUTXOSetRequestMessage{
+		FilePath: x.FilePath,
+		RunAsJob: x.RunAsJob,
+	}, nil
+}
+
+func (x *KaspadMessage_DumpUtxoSetRequest) fromAppMessage(message *appmessage.DumpUTXOSetRequestMessage) error {
+	x.DumpUtxoSetRequest = &DumpUtxoSetRequestMessage{
+		FilePath: message.FilePath,
+		RunAsJob: message.RunAsJob,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_DumpUtxoSetResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_DumpUtxoSetResponse is nil")
+	}
+	return x.DumpUtxoSetResponse.toAppMessage()
+}
+
+func (x *DumpUtxoSetResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "DumpUtxoSetResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.DumpUTXOSetResponseMessage{
+		PruningPointHash: x.PruningPointHash,
+		UTXOCommitment:   x.UtxoCommitment,
+		UTXOCount:        x.UtxoCount,
+		JobID:            x.JobId,
+		Error:            rpcErr,
+	}, nil
+}
+
+func (x *KaspadMessage_DumpUtxoSetResponse) fromAppMessage(message *appmessage.DumpUTXOSetResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.DumpUtxoSetResponse = &DumpUtxoSetResponseMessage{
+		PruningPointHash: message.PruningPointHash,
+		UtxoCommitment:   message.UTXOCommitment,
+		UtxoCount:        message.UTXOCount,
+		JobId:            message.JobID,
+		Error:            err,
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package protowire
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// NewRPCErrorResponse builds the response of the given RPC request, with
+// only its error set to the given message. This allows rejecting any request
+// without knowing its type, as long as its response has an error field.
+func NewRPCErrorResponse(request *KaspadMessage, errorMessage string) (*KaspadMessage, error) {
+	requestReflection := request.ProtoReflect()
+	payloadDescriptor := requestReflection.Descriptor().Oneofs().ByName("payload")
+	requestField := requestReflection.WhichOneof(payloadDescriptor)
+	if requestField == nil {
+		return nil, errors.Errorf("the message has no payload")
+	}
+	requestName := string(requestField.Name())
+	if !strings.HasSuffix(requestName, "Request") {
+		return nil, errors.Errorf("%s is not an RPC request", requestName)
+	}
+	responseName := protoreflect.Name(strings.TrimSuffix(requestName, "Request") + "Response")
+	responseField := requestReflection.Descriptor().Fields().ByName(responseName)
+	if responseField == nil || responseField.Message() == nil {
+		return nil, errors.Errorf("%s has no response", requestName)
+	}
+	errorField := responseField.Message().Fields().ByName("error")
+	if errorField == nil || errorField.Message() == nil ||
+		errorField.Message().FullName() != (&RPCError{}).ProtoReflect().Descriptor().FullName() {
+
+		return nil, errors.Errorf("%s has no error field", responseName)
+	}
+
+	response := &KaspadMessage{}
+	responseReflection := response.ProtoReflect()
+	responseValue := responseReflection.NewField(responseField)
+	responseValue.Message().Set(errorField, protoreflect.ValueOfMessage((&RPCError{Message: errorMessage}).ProtoReflect()))
+	responseReflection.Set(responseField, responseValue)
+	return response, nil
+}
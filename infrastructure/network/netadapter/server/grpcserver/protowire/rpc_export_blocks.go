@@ -0,0 +1,71 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_ExportBlocksRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_ExportBlocksRequest is nil")
+	}
+	return x.ExportBlocksRequest.toAppMessage()
+}
+
+func (x *ExportBlocksRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ExportBlocksRequestMessage is nil")
+	}
+	return &appmessage.ExportBlocksRequestMessage{
+		FilePath: x.FilePath,
+		LowHash:  x.LowHash,
+		HighHash: x.HighHash,
+	}, nil
+}
+
+func (x *KaspadMessage_ExportBlocksRequest) fromAppMessage(message *appmessage.ExportBlocksRequestMessage) error {
+	x.ExportBlocksRequest = &ExportBlocksRequestMessage{
+		FilePath: message.FilePath,
+		LowHash:  message.LowHash,
+		HighHash: message.HighHash,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_ExportBlocksResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_ExportBlocksResponse is nil")
+	}
+	return x.ExportBlocksResponse.toAppMessage()
+}
+
+func (x *ExportBlocksResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ExportBlocksResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.ExportBlocksResponseMessage{
+		LowHash:    x.LowHash,
+		HighHash:   x.HighHash,
+		BlockCount: x.BlockCount,
+		Error:      rpcErr,
+	}, nil
+}
+
+func (x *KaspadMessage_ExportBlocksResponse) fromAppMessage(message *appmessage.ExportBlocksResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.ExportBlocksResponse = &ExportBlocksResponseMessage{
+		LowHash:    message.LowHash,
+		HighHash:   message.HighHash,
+		BlockCount: message.BlockCount,
+		Error:      err,
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GenerateBlocksRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GenerateBlocksRequest is nil")
+	}
+	return x.GenerateBlocksRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_GenerateBlocksRequest) fromAppMessage(message *appmessage.GenerateBlocksRequestMessage) error {
+	x.GenerateBlocksRequest = &GenerateBlocksRequestMessage{
+		PayAddress:   message.PayAddress,
+		Count:        message.Count,
+		ParentHashes: message.ParentHashes,
+		Timestamp:    message.Timestamp,
+	}
+	return nil
+}
+
+func (x *GenerateBlocksRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GenerateBlocksRequestMessage is nil")
+	}
+	return &appmessage.GenerateBlocksRequestMessage{
+		PayAddress:   x.PayAddress,
+		Count:        x.Count,
+		ParentHashes: x.ParentHashes,
+		Timestamp:    x.Timestamp,
+	}, nil
+}
+
+func (x *KaspadMessage_GenerateBlocksResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GenerateBlocksResponse is nil")
+	}
+	return x.GenerateBlocksResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GenerateBlocksResponse) fromAppMessage(message *appmessage.GenerateBlocksResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.GenerateBlocksResponse = &GenerateBlocksResponseMessage{
+		BlockHashes: message.BlockHashes,
+		Error:       err,
+	}
+	return nil
+}
+
+func (x *GenerateBlocksResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GenerateBlocksResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.GenerateBlocksResponseMessage{
+		BlockHashes: x.BlockHashes,
+		Error:       rpcErr,
+	}, nil
+}
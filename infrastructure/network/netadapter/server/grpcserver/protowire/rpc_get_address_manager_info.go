@@ -0,0 +1,104 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetAddressManagerInfoRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetAddressManagerInfoRequest is nil")
+	}
+	return &appmessage.GetAddressManagerInfoRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_GetAddressManagerInfoRequest) fromAppMessage(_ *appmessage.GetAddressManagerInfoRequestMessage) error {
+	x.GetAddressManagerInfoRequest = &GetAddressManagerInfoRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_GetAddressManagerInfoResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetAddressManagerInfoResponse is nil")
+	}
+	return x.GetAddressManagerInfoResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetAddressManagerInfoResponse) fromAppMessage(message *appmessage.GetAddressManagerInfoResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	sources := make([]*AddressSourceInfo, len(message.Sources))
+	for i, source := range message.Sources {
+		sources[i] = &AddressSourceInfo{
+			Group:               source.Group,
+			AddressCount:        source.AddressCount,
+			ConnectionAttempts:  source.ConnectionAttempts,
+			ConnectionSuccesses: source.ConnectionSuccesses,
+		}
+	}
+	ageDistribution := make([]*AddressAgeBucket, len(message.AgeDistribution))
+	for i, ageBucket := range message.AgeDistribution {
+		ageDistribution[i] = &AddressAgeBucket{
+			MaxAge: ageBucket.MaxAge,
+			Count:  ageBucket.Count,
+		}
+	}
+	x.GetAddressManagerInfoResponse = &GetAddressManagerInfoResponseMessage{
+		AddressCount:       message.AddressCount,
+		BannedAddressCount: message.BannedAddressCount,
+		TriedCount:         message.TriedCount,
+		NewCount:           message.NewCount,
+		BucketOccupancy:    message.BucketOccupancy,
+		BucketSize:         message.BucketSize,
+		Sources:            sources,
+		AgeDistribution:    ageDistribution,
+		Error:              err,
+	}
+	return nil
+}
+
+func (x *GetAddressManagerInfoResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetAddressManagerInfoResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	sources := make([]*appmessage.AddressSourceInfo, len(x.Sources))
+	for i, source := range x.Sources {
+		if source == nil {
+			return nil, errors.Wrapf(errorNil, "AddressSourceInfo is nil")
+		}
+		sources[i] = &appmessage.AddressSourceInfo{
+			Group:               source.Group,
+			AddressCount:        source.AddressCount,
+			ConnectionAttempts:  source.ConnectionAttempts,
+			ConnectionSuccesses: source.ConnectionSuccesses,
+		}
+	}
+	ageDistribution := make([]*appmessage.AddressAgeBucket, len(x.AgeDistribution))
+	for i, ageBucket := range x.AgeDistribution {
+		if ageBucket == nil {
+			return nil, errors.Wrapf(errorNil, "AddressAgeBucket is nil")
+		}
+		ageDistribution[i] = &appmessage.AddressAgeBucket{
+			MaxAge: ageBucket.MaxAge,
+			Count:  ageBucket.Count,
+		}
+	}
+	return &appmessage.GetAddressManagerInfoResponseMessage{
+		AddressCount:       x.AddressCount,
+		BannedAddressCount: x.BannedAddressCount,
+		TriedCount:         x.TriedCount,
+		NewCount:           x.NewCount,
+		BucketOccupancy:    x.BucketOccupancy,
+		BucketSize:         x.BucketSize,
+		Sources:            sources,
+		AgeDistribution:    ageDistribution,
+		Error:              rpcErr,
+	}, nil
+}
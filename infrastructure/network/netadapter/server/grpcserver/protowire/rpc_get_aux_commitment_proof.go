@@ -0,0 +1,95 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetAuxCommitmentProofRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetAuxCommitmentProofRequest is nil")
+	}
+	return x.GetAuxCommitmentProofRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_GetAuxCommitmentProofRequest) fromAppMessage(message *appmessage.GetAuxCommitmentProofRequestMessage) error {
+	x.GetAuxCommitmentProofRequest = &GetAuxCommitmentProofRequestMessage{
+		BlockHash: message.BlockHash,
+	}
+	return nil
+}
+
+func (x *GetAuxCommitmentProofRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetAuxCommitmentProofRequestMessage is nil")
+	}
+	return &appmessage.GetAuxCommitmentProofRequestMessage{
+		BlockHash: x.BlockHash,
+	}, nil
+}
+
+func (x *KaspadMessage_GetAuxCommitmentProofResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetAuxCommitmentProofResponse is nil")
+	}
+	return x.GetAuxCommitmentProofResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetAuxCommitmentProofResponse) fromAppMessage(message *appmessage.GetAuxCommitmentProofResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	var header *RpcBlockHeader
+	if message.Header != nil {
+		header = &RpcBlockHeader{}
+		header.fromAppMessage(message.Header)
+	}
+	var coinbaseTransaction *RpcTransaction
+	if message.CoinbaseTransaction != nil {
+		coinbaseTransaction = &RpcTransaction{}
+		coinbaseTransaction.fromAppMessage(message.CoinbaseTransaction)
+	}
+	x.GetAuxCommitmentProofResponse = &GetAuxCommitmentProofResponseMessage{
+		AuxCommitment:       message.AuxCommitment,
+		Header:              header,
+		CoinbaseTransaction: coinbaseTransaction,
+		MerkleBranch:        message.MerkleBranch,
+		Error:               err,
+	}
+	return nil
+}
+
+func (x *GetAuxCommitmentProofResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetAuxCommitmentProofResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	var header *appmessage.RPCBlockHeader
+	if x.Header != nil {
+		var err error
+		header, err = x.Header.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+	}
+	var coinbaseTransaction *appmessage.RPCTransaction
+	if x.CoinbaseTransaction != nil {
+		var err error
+		coinbaseTransaction, err = x.CoinbaseTransaction.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &appmessage.GetAuxCommitmentProofResponseMessage{
+		AuxCommitment:       x.AuxCommitment,
+		Header:              header,
+		CoinbaseTransaction: coinbaseTransaction,
+		MerkleBranch:        x.MerkleBranch,
+		Error:               rpcErr,
+	}, nil
+}
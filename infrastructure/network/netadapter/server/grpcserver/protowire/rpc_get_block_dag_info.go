@@ -33,7 +33,7 @@ func (x *GetBlockDagInfoResponseMessage) toAppMessage() (appmessage.Message, err
 	if err != nil && !errors.Is(err, errorNil) {
 		return nil, err
 	}
-	if rpcErr != nil && (x.NetworkName != "" || x.BlockCount != 0 || x.HeaderCount != 0 || len(x.TipHashes) != 0 || len(x.VirtualParentHashes) != 0 || x.Difficulty != 0 || x.PastMedianTime != 0 || x.PruningPointHash != "") {
+	if rpcErr != nil && (x.NetworkName != "" || x.BlockCount != 0 || x.HeaderCount != 0 || len(x.TipHashes) != 0 || len(x.VirtualParentHashes) != 0 || x.Difficulty != 0 || x.PastMedianTime != 0 || x.PruningPointHash != "" || x.VirtualSelectedParentHash != "") {
 		return nil, errors.New("GetBlockDagInfoResponseMessage contains both an error and a response")
 	}
 	return &appmessage.GetBlockDAGInfoResponseMessage{
@@ -46,7 +46,21 @@ func (x *GetBlockDagInfoResponseMessage) toAppMessage() (appmessage.Message, err
 		PastMedianTime:      x.PastMedianTime,
 		PruningPointHash:    x.PruningPointHash,
 		VirtualDAAScore:     x.VirtualDaaScore,
-		Error:               rpcErr,
+
+		VirtualBlueScore:               x.VirtualBlueScore,
+		VirtualSelectedParentHash:      x.VirtualSelectedParentHash,
+		VirtualSelectedParentTimestamp: x.VirtualSelectedParentTimestamp,
+		HeadersSelectedTipHash:         x.HeadersSelectedTipHash,
+		FinalityPointHash:              x.FinalityPointHash,
+		PruningPointDAAScore:           x.PruningPointDaaScore,
+		IsArchival:                     x.IsArchival,
+		IsSynced:                       x.IsSynced,
+		IsIBDRunning:                   x.IsIbdRunning,
+		SyncProgress:                   x.SyncProgress,
+		ConnectedPeerCount:             x.ConnectedPeerCount,
+		MempoolSize:                    x.MempoolSize,
+
+		Error: rpcErr,
 	}, nil
 }
 
@@ -65,7 +79,21 @@ func (x *KaspadMessage_GetBlockDagInfoResponse) fromAppMessage(message *appmessa
 		PastMedianTime:      message.PastMedianTime,
 		PruningPointHash:    message.PruningPointHash,
 		VirtualDaaScore:     message.VirtualDAAScore,
-		Error:               err,
+
+		VirtualBlueScore:               message.VirtualBlueScore,
+		VirtualSelectedParentHash:      message.VirtualSelectedParentHash,
+		VirtualSelectedParentTimestamp: message.VirtualSelectedParentTimestamp,
+		HeadersSelectedTipHash:         message.HeadersSelectedTipHash,
+		FinalityPointHash:              message.FinalityPointHash,
+		PruningPointDaaScore:           message.PruningPointDAAScore,
+		IsArchival:                     message.IsArchival,
+		IsSynced:                       message.IsSynced,
+		IsIbdRunning:                   message.IsIBDRunning,
+		SyncProgress:                   message.SyncProgress,
+		ConnectedPeerCount:             message.ConnectedPeerCount,
+		MempoolSize:                    message.MempoolSize,
+
+		Error: err,
 	}
 	return nil
 }
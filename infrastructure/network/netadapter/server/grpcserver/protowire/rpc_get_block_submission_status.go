@@ -0,0 +1,67 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetBlockSubmissionStatusRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetBlockSubmissionStatusRequest is nil")
+	}
+	return x.GetBlockSubmissionStatusRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_GetBlockSubmissionStatusRequest) fromAppMessage(message *appmessage.GetBlockSubmissionStatusRequestMessage) error {
+	x.GetBlockSubmissionStatusRequest = &GetBlockSubmissionStatusRequestMessage{
+		SubmissionId: message.SubmissionID,
+	}
+	return nil
+}
+
+func (x *GetBlockSubmissionStatusRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetBlockSubmissionStatusRequestMessage is nil")
+	}
+	return &appmessage.GetBlockSubmissionStatusRequestMessage{
+		SubmissionID: x.SubmissionId,
+	}, nil
+}
+
+func (x *KaspadMessage_GetBlockSubmissionStatusResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetBlockSubmissionStatusResponse is nil")
+	}
+	return x.GetBlockSubmissionStatusResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetBlockSubmissionStatusResponse) fromAppMessage(message *appmessage.GetBlockSubmissionStatusResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.GetBlockSubmissionStatusResponse = &GetBlockSubmissionStatusResponseMessage{
+		BlockHash:    message.BlockHash,
+		Status:       message.Status,
+		RejectReason: message.RejectReason,
+		Error:        err,
+	}
+	return nil
+}
+
+func (x *GetBlockSubmissionStatusResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetBlockSubmissionStatusResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.GetBlockSubmissionStatusResponseMessage{
+		BlockHash:    x.BlockHash,
+		Status:       x.Status,
+		RejectReason: x.RejectReason,
+		Error:        rpcErr,
+	}, nil
+}
@@ -13,9 +13,23 @@ func (x *KaspadMessage_GetBlockTemplateRequest) toAppMessage() (appmessage.Messa
 }
 
 func (x *KaspadMessage_GetBlockTemplateRequest) fromAppMessage(message *appmessage.GetBlockTemplateRequestMessage) error {
+	var proposal *RpcBlock
+	if message.Proposal != nil {
+		proposal = &RpcBlock{}
+		err := proposal.fromAppMessage(message.Proposal)
+		if err != nil {
+			return err
+		}
+	}
+
 	x.GetBlockTemplateRequest = &GetBlockTemplateRequestMessage{
-		PayAddress: message.PayAddress,
-		ExtraData:  message.ExtraData,
+		PayAddress:             message.PayAddress,
+		ExtraData:              message.ExtraData,
+		MinFees:                message.MinFees,
+		MaxFeeWaitMilliseconds: message.MaxFeeWaitMilliseconds,
+		Mode:                   message.Mode,
+		Proposal:               proposal,
+		AuxCommitment:          message.AuxCommitment,
 	}
 	return nil
 }
@@ -24,9 +38,22 @@ func (x *GetBlockTemplateRequestMessage) toAppMessage() (appmessage.Message, err
 	if x == nil {
 		return nil, errors.Wrapf(errorNil, "GetBlockTemplateRequestMessage is nil")
 	}
+	var proposal *appmessage.RPCBlock
+	if x.Proposal != nil {
+		var err error
+		proposal, err = x.Proposal.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &appmessage.GetBlockTemplateRequestMessage{
-		PayAddress: x.PayAddress,
-		ExtraData:  x.ExtraData,
+		PayAddress:             x.PayAddress,
+		ExtraData:              x.ExtraData,
+		MinFees:                x.MinFees,
+		MaxFeeWaitMilliseconds: x.MaxFeeWaitMilliseconds,
+		Mode:                   x.Mode,
+		Proposal:               proposal,
+		AuxCommitment:          x.AuxCommitment,
 	}, nil
 }
 
@@ -54,9 +81,14 @@ func (x *KaspadMessage_GetBlockTemplateResponse) fromAppMessage(message *appmess
 	}
 
 	x.GetBlockTemplateResponse = &GetBlockTemplateResponseMessage{
-		Block:    block,
-		IsSynced: message.IsSynced,
-		Error:    err,
+		Block:                     block,
+		IsSynced:                  message.IsSynced,
+		TemplateVersion:           message.TemplateVersion,
+		TemplateAgeInMilliseconds: message.TemplateAgeInMilliseconds,
+		ProposalAccepted:          message.ProposalAccepted,
+		ProposalRejectReason:      message.ProposalRejectReason,
+		ProposalRejectMessage:     message.ProposalRejectMessage,
+		Error:                     err,
 	}
 	return nil
 }
@@ -82,8 +114,13 @@ func (x *GetBlockTemplateResponseMessage) toAppMessage() (appmessage.Message, er
 		}
 	}
 	return &appmessage.GetBlockTemplateResponseMessage{
-		Block:    msgBlock,
-		IsSynced: x.IsSynced,
-		Error:    rpcError,
+		Block:                     msgBlock,
+		IsSynced:                  x.IsSynced,
+		TemplateVersion:           x.TemplateVersion,
+		TemplateAgeInMilliseconds: x.TemplateAgeInMilliseconds,
+		ProposalAccepted:          x.ProposalAccepted,
+		ProposalRejectReason:      x.ProposalRejectReason,
+		ProposalRejectMessage:     x.ProposalRejectMessage,
+		Error:                     rpcError,
 	}, nil
 }
@@ -17,6 +17,7 @@ func (x *KaspadMessage_GetBlocksRequest) fromAppMessage(message *appmessage.GetB
 		LowHash:             message.LowHash,
 		IncludeBlocks:       message.IncludeBlocks,
 		IncludeTransactions: message.IncludeTransactions,
+		Limit:               message.Limit,
 	}
 	return nil
 }
@@ -29,6 +30,7 @@ func (x *GetBlocksRequestMessage) toAppMessage() (appmessage.Message, error) {
 		LowHash:             x.LowHash,
 		IncludeBlocks:       x.IncludeBlocks,
 		IncludeTransactions: x.IncludeTransactions,
+		Limit:               x.Limit,
 	}, nil
 }
 
@@ -45,7 +47,8 @@ func (x *KaspadMessage_GetBlocksResponse) fromAppMessage(message *appmessage.Get
 		err = &RPCError{Message: message.Error.Message}
 	}
 	x.GetBlocksResponse = &GetBlocksResponseMessage{
-		Error: err,
+		NextLowHash: message.NextLowHash,
+		Error:       err,
 	}
 	x.GetBlocksResponse.BlockHashes = message.BlockHashes
 	x.GetBlocksResponse.Blocks = make([]*RpcBlock, len(message.Blocks))
@@ -84,6 +87,7 @@ func (x *GetBlocksResponseMessage) toAppMessage() (appmessage.Message, error) {
 	return &appmessage.GetBlocksResponseMessage{
 		BlockHashes: x.BlockHashes,
 		Blocks:      blocks,
+		NextLowHash: x.NextLowHash,
 		Error:       rpcErr,
 	}, nil
 }
@@ -0,0 +1,105 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetChainAnalysisRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetChainAnalysisRequest is nil")
+	}
+	return x.GetChainAnalysisRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_GetChainAnalysisRequest) fromAppMessage(message *appmessage.GetChainAnalysisRequestMessage) error {
+	x.GetChainAnalysisRequest = &GetChainAnalysisRequestMessage{
+		LowBlueScore:  message.LowBlueScore,
+		HighBlueScore: message.HighBlueScore,
+	}
+	return nil
+}
+
+func (x *GetChainAnalysisRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetChainAnalysisRequestMessage is nil")
+	}
+	return &appmessage.GetChainAnalysisRequestMessage{
+		LowBlueScore:  x.LowBlueScore,
+		HighBlueScore: x.HighBlueScore,
+	}, nil
+}
+
+func (x *KaspadMessage_GetChainAnalysisResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetChainAnalysisResponse is nil")
+	}
+	return x.GetChainAnalysisResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetChainAnalysisResponse) fromAppMessage(message *appmessage.GetChainAnalysisResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	var orphanLatency *OrphanLatencyDistribution
+	if message.OrphanLatency != nil {
+		orphanLatency = &OrphanLatencyDistribution{
+			Count:              message.OrphanLatency.Count,
+			MinMilliseconds:    message.OrphanLatency.MinMilliseconds,
+			MedianMilliseconds: message.OrphanLatency.MedianMilliseconds,
+			P90Milliseconds:    message.OrphanLatency.P90Milliseconds,
+			P99Milliseconds:    message.OrphanLatency.P99Milliseconds,
+			MaxMilliseconds:    message.OrphanLatency.MaxMilliseconds,
+			MeanMilliseconds:   message.OrphanLatency.MeanMilliseconds,
+		}
+	}
+	x.GetChainAnalysisResponse = &GetChainAnalysisResponseMessage{
+		LowBlueScore:       message.LowBlueScore,
+		HighBlueScore:      message.HighBlueScore,
+		ChainBlockCount:    message.ChainBlockCount,
+		BlockCount:         message.BlockCount,
+		RedBlockCount:      message.RedBlockCount,
+		ParallelismFactor:  message.ParallelismFactor,
+		AverageParentCount: message.AverageParentCount,
+		RedRate:            message.RedRate,
+		OrphanLatency:      orphanLatency,
+		Error:              err,
+	}
+	return nil
+}
+
+func (x *GetChainAnalysisResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetChainAnalysisResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	var orphanLatency *appmessage.OrphanLatencyDistribution
+	if x.OrphanLatency != nil {
+		orphanLatency = &appmessage.OrphanLatencyDistribution{
+			Count:              x.OrphanLatency.Count,
+			MinMilliseconds:    x.OrphanLatency.MinMilliseconds,
+			MedianMilliseconds: x.OrphanLatency.MedianMilliseconds,
+			P90Milliseconds:    x.OrphanLatency.P90Milliseconds,
+			P99Milliseconds:    x.OrphanLatency.P99Milliseconds,
+			MaxMilliseconds:    x.OrphanLatency.MaxMilliseconds,
+			MeanMilliseconds:   x.OrphanLatency.MeanMilliseconds,
+		}
+	}
+	return &appmessage.GetChainAnalysisResponseMessage{
+		LowBlueScore:       x.LowBlueScore,
+		HighBlueScore:      x.HighBlueScore,
+		ChainBlockCount:    x.ChainBlockCount,
+		BlockCount:         x.BlockCount,
+		RedBlockCount:      x.RedBlockCount,
+		ParallelismFactor:  x.ParallelismFactor,
+		AverageParentCount: x.AverageParentCount,
+		RedRate:            x.RedRate,
+		OrphanLatency:      orphanLatency,
+		Error:              rpcErr,
+	}, nil
+}
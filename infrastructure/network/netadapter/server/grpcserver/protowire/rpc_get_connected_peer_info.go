@@ -6,13 +6,35 @@ import (
 )
 
 func (x *KaspadMessage_GetConnectedPeerInfoRequest) toAppMessage() (appmessage.Message, error) {
-	return &appmessage.GetConnectedPeerInfoRequestMessage{}, nil
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetConnectedPeerInfoRequest is nil")
+	}
+	// Older clients send this request without a body, which is
+	// equivalent to a request for the first page
+	if x.GetConnectedPeerInfoRequest == nil {
+		return &appmessage.GetConnectedPeerInfoRequestMessage{}, nil
+	}
+	return x.GetConnectedPeerInfoRequest.toAppMessage()
 }
 
-func (x *KaspadMessage_GetConnectedPeerInfoRequest) fromAppMessage(_ *appmessage.GetConnectedPeerInfoRequestMessage) error {
+func (x *KaspadMessage_GetConnectedPeerInfoRequest) fromAppMessage(message *appmessage.GetConnectedPeerInfoRequestMessage) error {
+	x.GetConnectedPeerInfoRequest = &GetConnectedPeerInfoRequestMessage{
+		Limit:  message.Limit,
+		Cursor: message.Cursor,
+	}
 	return nil
 }
 
+func (x *GetConnectedPeerInfoRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetConnectedPeerInfoRequestMessage is nil")
+	}
+	return &appmessage.GetConnectedPeerInfoRequestMessage{
+		Limit:  x.Limit,
+		Cursor: x.Cursor,
+	}, nil
+}
+
 func (x *KaspadMessage_GetConnectedPeerInfoResponse) toAppMessage() (appmessage.Message, error) {
 	if x == nil {
 		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetConnectedPeerInfoResponse is nil")
@@ -37,11 +59,42 @@ func (x *KaspadMessage_GetConnectedPeerInfoResponse) fromAppMessage(message *app
 			AdvertisedProtocolVersion: info.AdvertisedProtocolVersion,
 			TimeConnected:             info.TimeConnected,
 			IsIbdPeer:                 info.IsIBDPeer,
+			NetGroup:                  info.NetGroup,
+			Services:                  info.Services,
+			ProtocolVersion:           info.ProtocolVersion,
+			IsRelayingTransactions:    info.IsRelayingTransactions,
+			SubnetworkId:              info.SubnetworkID,
+			MessageStatistics:         make([]*PeerMessageStatistics, len(info.MessageStatistics)),
+			Asn:                       info.ASN,
+			Country:                   info.Country,
+			SubnetworkRoles:           appMessageRPCSubnetworkRolesToProto(info.SubnetworkRoles),
+		}
+		for j, statistics := range info.MessageStatistics {
+			infos[i].MessageStatistics[j] = &PeerMessageStatistics{
+				Command:          statistics.Command,
+				MessagesSent:     statistics.MessagesSent,
+				BytesSent:        statistics.BytesSent,
+				MessagesReceived: statistics.MessagesReceived,
+				BytesReceived:    statistics.BytesReceived,
+			}
+		}
+	}
+	var outboundDiversity *OutboundDiversityStatistics
+	if message.OutboundDiversity != nil {
+		outboundDiversity = &OutboundDiversityStatistics{
+			IpMapFile:             message.OutboundDiversity.IPMapFile,
+			IpMapLoadTime:         message.OutboundDiversity.IPMapLoadTime,
+			MaxOutboundPerAsn:     message.OutboundDiversity.MaxOutboundPerASN,
+			MaxOutboundPerCountry: message.OutboundDiversity.MaxOutboundPerCountry,
+			SkippedByAsn:          message.OutboundDiversity.SkippedByASN,
+			SkippedByCountry:      message.OutboundDiversity.SkippedByCountry,
 		}
 	}
 	x.GetConnectedPeerInfoResponse = &GetConnectedPeerInfoResponseMessage{
-		Infos: infos,
-		Error: err,
+		Infos:             infos,
+		NextCursor:        message.NextCursor,
+		OutboundDiversity: outboundDiversity,
+		Error:             err,
 	}
 	return nil
 }
@@ -68,9 +121,23 @@ func (x *GetConnectedPeerInfoResponseMessage) toAppMessage() (appmessage.Message
 		infos[i] = appInfo
 	}
 
+	var outboundDiversity *appmessage.OutboundDiversityStatistics
+	if x.OutboundDiversity != nil {
+		outboundDiversity = &appmessage.OutboundDiversityStatistics{
+			IPMapFile:             x.OutboundDiversity.IpMapFile,
+			IPMapLoadTime:         x.OutboundDiversity.IpMapLoadTime,
+			MaxOutboundPerASN:     x.OutboundDiversity.MaxOutboundPerAsn,
+			MaxOutboundPerCountry: x.OutboundDiversity.MaxOutboundPerCountry,
+			SkippedByASN:          x.OutboundDiversity.SkippedByAsn,
+			SkippedByCountry:      x.OutboundDiversity.SkippedByCountry,
+		}
+	}
+
 	return &appmessage.GetConnectedPeerInfoResponseMessage{
-		Infos: infos,
-		Error: rpcErr,
+		Infos:             infos,
+		NextCursor:        x.NextCursor,
+		OutboundDiversity: outboundDiversity,
+		Error:             rpcErr,
 	}, nil
 }
 
@@ -78,6 +145,16 @@ func (x *GetConnectedPeerInfoMessage) toAppMessage() (*appmessage.GetConnectedPe
 	if x == nil {
 		return nil, errors.Wrapf(errorNil, "GetConnectedPeerInfoMessage is nil")
 	}
+	messageStatistics := make([]*appmessage.PeerMessageStatistics, len(x.MessageStatistics))
+	for i, statistics := range x.MessageStatistics {
+		messageStatistics[i] = &appmessage.PeerMessageStatistics{
+			Command:          statistics.Command,
+			MessagesSent:     statistics.MessagesSent,
+			BytesSent:        statistics.BytesSent,
+			MessagesReceived: statistics.MessagesReceived,
+			BytesReceived:    statistics.BytesReceived,
+		}
+	}
 	return &appmessage.GetConnectedPeerInfoMessage{
 		ID:                        x.Id,
 		Address:                   x.Address,
@@ -86,7 +163,16 @@ func (x *GetConnectedPeerInfoMessage) toAppMessage() (*appmessage.GetConnectedPe
 		TimeOffset:                x.TimeOffset,
 		UserAgent:                 x.UserAgent,
 		AdvertisedProtocolVersion: x.AdvertisedProtocolVersion,
-		TimeConnected:             x.TimeOffset,
+		TimeConnected:             x.TimeConnected,
 		IsIBDPeer:                 x.IsIbdPeer,
+		NetGroup:                  x.NetGroup,
+		Services:                  x.Services,
+		ProtocolVersion:           x.ProtocolVersion,
+		IsRelayingTransactions:    x.IsRelayingTransactions,
+		SubnetworkID:              x.SubnetworkId,
+		MessageStatistics:         messageStatistics,
+		ASN:                       x.Asn,
+		Country:                   x.Country,
+		SubnetworkRoles:           x.SubnetworkRoles.toAppMessage(),
 	}, nil
 }
@@ -0,0 +1,172 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetDatabaseStatsRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetDatabaseStatsRequest is nil")
+	}
+	return x.GetDatabaseStatsRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_GetDatabaseStatsRequest) fromAppMessage(message *appmessage.GetDatabaseStatsRequestMessage) error {
+	x.GetDatabaseStatsRequest = &GetDatabaseStatsRequestMessage{
+		IncludeBuckets:          message.IncludeBuckets,
+		BucketDepth:             message.BucketDepth,
+		IncludeOperationMetrics: message.IncludeOperationMetrics,
+	}
+	return nil
+}
+
+func (x *GetDatabaseStatsRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetDatabaseStatsRequestMessage is nil")
+	}
+	return &appmessage.GetDatabaseStatsRequestMessage{
+		IncludeBuckets:          x.IncludeBuckets,
+		BucketDepth:             x.BucketDepth,
+		IncludeOperationMetrics: x.IncludeOperationMetrics,
+	}, nil
+}
+
+func (x *KaspadMessage_GetDatabaseStatsResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetDatabaseStatsResponse is nil")
+	}
+	return x.GetDatabaseStatsResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetDatabaseStatsResponse) fromAppMessage(message *appmessage.GetDatabaseStatsResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	levels := make([]*DatabaseLevelStats, len(message.Levels))
+	for i, level := range message.Levels {
+		levels[i] = &DatabaseLevelStats{
+			FileCount: level.FileCount,
+			Size:      level.Size,
+		}
+	}
+	buckets := make([]*DatabaseBucketStats, len(message.Buckets))
+	for i, bucket := range message.Buckets {
+		buckets[i] = &DatabaseBucketStats{
+			Bucket:    bucket.Bucket,
+			KeyCount:  bucket.KeyCount,
+			KeySize:   bucket.KeySize,
+			ValueSize: bucket.ValueSize,
+		}
+	}
+	caches := make([]*DatabaseCacheStats, len(message.Caches))
+	for i, cache := range message.Caches {
+		caches[i] = &DatabaseCacheStats{
+			Name:     cache.Name,
+			Capacity: cache.Capacity,
+			Hits:     cache.Hits,
+			Misses:   cache.Misses,
+		}
+	}
+	operations := make([]*DatabaseOperationMetrics, len(message.Operations))
+	for i, operation := range message.Operations {
+		operations[i] = &DatabaseOperationMetrics{
+			Bucket:                   operation.Bucket,
+			Operation:                operation.Operation,
+			Count:                    operation.Count,
+			Bytes:                    operation.Bytes,
+			TotalLatencyMicroseconds: operation.TotalLatencyMicroseconds,
+			LatencyHistogram:         operation.LatencyHistogram,
+		}
+	}
+	x.GetDatabaseStatsResponse = &GetDatabaseStatsResponseMessage{
+		Backend:                message.Backend,
+		DiskSize:               message.DiskSize,
+		PendingCompactionBytes: message.PendingCompactionBytes,
+		Levels:                 levels,
+		Buckets:                buckets,
+		IsCompacting:           message.IsCompacting,
+		LastCompactionTime:     message.LastCompactionTime,
+		LastCompactionDuration: message.LastCompactionDuration,
+		IsBackingUp:            message.IsBackingUp,
+		LastBackupTime:         message.LastBackupTime,
+		LastBackupDirectory:    message.LastBackupDirectory,
+		LastBackupError:        message.LastBackupError,
+		Caches:                 caches,
+		Operations:             operations,
+		LatencyHistogramBounds: message.LatencyHistogramBounds,
+		Error:                  err,
+	}
+	return nil
+}
+
+func (x *GetDatabaseStatsResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetDatabaseStatsResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	if rpcErr != nil && len(x.Backend) != 0 {
+		return nil, errors.New("GetDatabaseStatsResponseMessage contains both an error and a response")
+	}
+
+	levels := make([]*appmessage.DatabaseLevelStats, len(x.Levels))
+	for i, level := range x.Levels {
+		levels[i] = &appmessage.DatabaseLevelStats{
+			FileCount: level.FileCount,
+			Size:      level.Size,
+		}
+	}
+	buckets := make([]*appmessage.DatabaseBucketStats, len(x.Buckets))
+	for i, bucket := range x.Buckets {
+		buckets[i] = &appmessage.DatabaseBucketStats{
+			Bucket:    bucket.Bucket,
+			KeyCount:  bucket.KeyCount,
+			KeySize:   bucket.KeySize,
+			ValueSize: bucket.ValueSize,
+		}
+	}
+	caches := make([]*appmessage.DatabaseCacheStats, len(x.Caches))
+	for i, cache := range x.Caches {
+		caches[i] = &appmessage.DatabaseCacheStats{
+			Name:     cache.Name,
+			Capacity: cache.Capacity,
+			Hits:     cache.Hits,
+			Misses:   cache.Misses,
+		}
+	}
+	operations := make([]*appmessage.DatabaseOperationMetrics, len(x.Operations))
+	for i, operation := range x.Operations {
+		operations[i] = &appmessage.DatabaseOperationMetrics{
+			Bucket:                   operation.Bucket,
+			Operation:                operation.Operation,
+			Count:                    operation.Count,
+			Bytes:                    operation.Bytes,
+			TotalLatencyMicroseconds: operation.TotalLatencyMicroseconds,
+			LatencyHistogram:         operation.LatencyHistogram,
+		}
+	}
+	return &appmessage.GetDatabaseStatsResponseMessage{
+		Backend:                x.Backend,
+		DiskSize:               x.DiskSize,
+		PendingCompactionBytes: x.PendingCompactionBytes,
+		Levels:                 levels,
+		Buckets:                buckets,
+		IsCompacting:           x.IsCompacting,
+		LastCompactionTime:     x.LastCompactionTime,
+		LastCompactionDuration: x.LastCompactionDuration,
+		IsBackingUp:            x.IsBackingUp,
+		LastBackupTime:         x.LastBackupTime,
+		LastBackupDirectory:    x.LastBackupDirectory,
+		LastBackupError:        x.LastBackupError,
+		Caches:                 caches,
+		Operations:             operations,
+		LatencyHistogramBounds: x.LatencyHistogramBounds,
+		Error:                  rpcErr,
+	}, nil
+}
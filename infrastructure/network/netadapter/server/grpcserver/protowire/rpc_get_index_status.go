@@ -0,0 +1,82 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetIndexStatusRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.GetIndexStatusRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_GetIndexStatusRequest) fromAppMessage(_ *appmessage.GetIndexStatusRequestMessage) error {
+	x.GetIndexStatusRequest = &GetIndexStatusRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_GetIndexStatusResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetIndexStatusResponse is nil")
+	}
+	return x.GetIndexStatusResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetIndexStatusResponse) fromAppMessage(message *appmessage.GetIndexStatusResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	indexes := make([]*IndexStatus, len(message.Indexes))
+	for i, index := range message.Indexes {
+		indexes[i] = &IndexStatus{
+			Name:           index.Name,
+			IsEnabled:      index.IsEnabled,
+			Operation:      index.Operation,
+			IsRunning:      index.IsRunning,
+			Processed:      index.Processed,
+			Total:          index.Total,
+			StartTime:      index.StartTime,
+			EndTime:        index.EndTime,
+			OperationError: index.OperationError,
+		}
+	}
+	x.GetIndexStatusResponse = &GetIndexStatusResponseMessage{
+		Indexes: indexes,
+		Error:   err,
+	}
+	return nil
+}
+
+func (x *GetIndexStatusResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetIndexStatusResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	if rpcErr != nil && len(x.Indexes) != 0 {
+		return nil, errors.New("GetIndexStatusResponseMessage contains both an error and a response")
+	}
+
+	indexes := make([]*appmessage.IndexStatus, len(x.Indexes))
+	for i, index := range x.Indexes {
+		indexes[i] = &appmessage.IndexStatus{
+			Name:           index.Name,
+			IsEnabled:      index.IsEnabled,
+			Operation:      index.Operation,
+			IsRunning:      index.IsRunning,
+			Processed:      index.Processed,
+			Total:          index.Total,
+			StartTime:      index.StartTime,
+			EndTime:        index.EndTime,
+			OperationError: index.OperationError,
+		}
+	}
+	return &appmessage.GetIndexStatusResponseMessage{
+		Indexes: indexes,
+		Error:   rpcErr,
+	}, nil
+}
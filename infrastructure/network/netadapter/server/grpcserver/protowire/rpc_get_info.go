@@ -27,12 +27,13 @@ func (x *KaspadMessage_GetInfoResponse) fromAppMessage(message *appmessage.GetIn
 		err = &RPCError{Message: message.Error.Message}
 	}
 	x.GetInfoResponse = &GetInfoResponseMessage{
-		P2PId:         message.P2PID,
-		ServerVersion: message.ServerVersion,
-		MempoolSize:   message.MempoolSize,
-		IsUtxoIndexed: message.IsUtxoIndexed,
-		IsSynced:      message.IsSynced,
-		Error:         err,
+		P2PId:           message.P2PID,
+		ServerVersion:   message.ServerVersion,
+		MempoolSize:     message.MempoolSize,
+		IsUtxoIndexed:   message.IsUtxoIndexed,
+		IsSynced:        message.IsSynced,
+		SubnetworkRoles: appMessageRPCSubnetworkRolesToProto(message.SubnetworkRoles),
+		Error:           err,
 	}
 	return nil
 }
@@ -52,12 +53,35 @@ func (x *GetInfoResponseMessage) toAppMessage() (appmessage.Message, error) {
 	}
 
 	return &appmessage.GetInfoResponseMessage{
-		P2PID:         x.P2PId,
-		MempoolSize:   x.MempoolSize,
-		ServerVersion: x.ServerVersion,
-		IsUtxoIndexed: x.IsUtxoIndexed,
-		IsSynced:      x.IsSynced,
+		P2PID:           x.P2PId,
+		MempoolSize:     x.MempoolSize,
+		ServerVersion:   x.ServerVersion,
+		IsUtxoIndexed:   x.IsUtxoIndexed,
+		IsSynced:        x.IsSynced,
+		SubnetworkRoles: x.SubnetworkRoles.toAppMessage(),
 
 		Error: rpcErr,
 	}, nil
 }
+
+func (x *RpcSubnetworkRoles) toAppMessage() *appmessage.RPCSubnetworkRoles {
+	if x == nil {
+		return nil
+	}
+	return &appmessage.RPCSubnetworkRoles{
+		ValidatedSubnetworkIDs: x.ValidatedSubnetworkIds,
+		IndexedSubnetworkIDs:   x.IndexedSubnetworkIds,
+		RelayedSubnetworkIDs:   x.RelayedSubnetworkIds,
+	}
+}
+
+func appMessageRPCSubnetworkRolesToProto(subnetworkRoles *appmessage.RPCSubnetworkRoles) *RpcSubnetworkRoles {
+	if subnetworkRoles == nil {
+		return nil
+	}
+	return &RpcSubnetworkRoles{
+		ValidatedSubnetworkIds: subnetworkRoles.ValidatedSubnetworkIDs,
+		IndexedSubnetworkIds:   subnetworkRoles.IndexedSubnetworkIDs,
+		RelayedSubnetworkIds:   subnetworkRoles.RelayedSubnetworkIDs,
+	}
+}
@@ -0,0 +1,134 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetJobsRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetJobsRequest is nil")
+	}
+	return x.GetJobsRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_GetJobsRequest) fromAppMessage(message *appmessage.GetJobsRequestMessage) error {
+	x.GetJobsRequest = &GetJobsRequestMessage{
+		JobIds: message.JobIDs,
+	}
+	return nil
+}
+
+func (x *GetJobsRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetJobsRequestMessage is nil")
+	}
+	return &appmessage.GetJobsRequestMessage{
+		JobIDs: x.JobIds,
+	}, nil
+}
+
+func (x *KaspadMessage_GetJobsResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetJobsResponse is nil")
+	}
+	return x.GetJobsResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetJobsResponse) fromAppMessage(message *appmessage.GetJobsResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	jobs := make([]*RpcJob, len(message.Jobs))
+	for i, job := range message.Jobs {
+		jobs[i] = &RpcJob{}
+		jobs[i].fromAppMessage(job)
+	}
+	x.GetJobsResponse = &GetJobsResponseMessage{
+		Jobs:  jobs,
+		Error: err,
+	}
+	return nil
+}
+
+func (x *GetJobsResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetJobsResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	if rpcErr != nil && len(x.Jobs) != 0 {
+		return nil, errors.New("GetJobsResponseMessage contains both an error and a response")
+	}
+
+	jobs := make([]*appmessage.RPCJob, len(x.Jobs))
+	for i, job := range x.Jobs {
+		jobs[i], err = job.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &appmessage.GetJobsResponseMessage{
+		Jobs:  jobs,
+		Error: rpcErr,
+	}, nil
+}
+
+func (x *RpcJob) toAppMessage() (*appmessage.RPCJob, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "RpcJob is nil")
+	}
+	job := &appmessage.RPCJob{
+		ID:        x.Id,
+		Kind:      x.Kind,
+		State:     x.State,
+		Processed: x.Processed,
+		Total:     x.Total,
+		StartTime: x.StartTime,
+		EndTime:   x.EndTime,
+		Error:     x.Error,
+	}
+	if x.UtxoSetResult != nil {
+		job.UTXOSetResult = &appmessage.RPCJobUTXOSetResult{
+			PruningPointHash: x.UtxoSetResult.PruningPointHash,
+			UTXOCommitment:   x.UtxoSetResult.UtxoCommitment,
+			UTXOCount:        x.UtxoSetResult.UtxoCount,
+		}
+	}
+	if x.RescanResult != nil {
+		job.RescanResult = &appmessage.RPCJobRescanResult{
+			CheckpointBlueScore: x.RescanResult.CheckpointBlueScore,
+			CheckpointBlockHash: x.RescanResult.CheckpointBlockHash,
+		}
+	}
+	return job, nil
+}
+
+func (x *RpcJob) fromAppMessage(message *appmessage.RPCJob) {
+	x.Id = message.ID
+	x.Kind = message.Kind
+	x.State = message.State
+	x.Processed = message.Processed
+	x.Total = message.Total
+	x.StartTime = message.StartTime
+	x.EndTime = message.EndTime
+	x.Error = message.Error
+	if message.UTXOSetResult != nil {
+		x.UtxoSetResult = &RpcJobUtxoSetResult{
+			PruningPointHash: message.UTXOSetResult.PruningPointHash,
+			UtxoCommitment:   message.UTXOSetResult.UTXOCommitment,
+			UtxoCount:        message.UTXOSetResult.UTXOCount,
+		}
+	}
+	if message.RescanResult != nil {
+		x.RescanResult = &RpcJobRescanResult{
+			CheckpointBlueScore: message.RescanResult.CheckpointBlueScore,
+			CheckpointBlockHash: message.RescanResult.CheckpointBlockHash,
+		}
+	}
+}
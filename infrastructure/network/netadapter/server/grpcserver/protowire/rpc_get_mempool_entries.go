@@ -16,6 +16,8 @@ func (x *KaspadMessage_GetMempoolEntriesRequest) fromAppMessage(message *appmess
 	x.GetMempoolEntriesRequest = &GetMempoolEntriesRequestMessage{
 		IncludeOrphanPool:     message.IncludeOrphanPool,
 		FilterTransactionPool: message.FilterTransactionPool,
+		Limit:                 message.Limit,
+		Cursor:                message.Cursor,
 	}
 	return nil
 }
@@ -27,6 +29,8 @@ func (x *GetMempoolEntriesRequestMessage) toAppMessage() (appmessage.Message, er
 	return &appmessage.GetMempoolEntriesRequestMessage{
 		IncludeOrphanPool:     x.IncludeOrphanPool,
 		FilterTransactionPool: x.FilterTransactionPool,
+		Limit:                 x.Limit,
+		Cursor:                x.Cursor,
 	}, nil
 }
 
@@ -51,8 +55,9 @@ func (x *KaspadMessage_GetMempoolEntriesResponse) fromAppMessage(message *appmes
 		}
 	}
 	x.GetMempoolEntriesResponse = &GetMempoolEntriesResponseMessage{
-		Entries: entries,
-		Error:   rpcErr,
+		Entries:    entries,
+		NextCursor: message.NextCursor,
+		Error:      rpcErr,
 	}
 	return nil
 }
@@ -79,7 +84,8 @@ func (x *GetMempoolEntriesResponseMessage) toAppMessage() (appmessage.Message, e
 	}
 
 	return &appmessage.GetMempoolEntriesResponseMessage{
-		Entries: entries,
-		Error:   rpcErr,
+		Entries:    entries,
+		NextCursor: x.NextCursor,
+		Error:      rpcErr,
 	}, nil
 }
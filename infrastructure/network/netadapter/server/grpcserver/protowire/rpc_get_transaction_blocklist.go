@@ -0,0 +1,63 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetTransactionBlocklistRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetTransactionBlocklistRequest is nil")
+	}
+	return &appmessage.GetTransactionBlocklistRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_GetTransactionBlocklistRequest) fromAppMessage(
+	_ *appmessage.GetTransactionBlocklistRequestMessage) error {
+
+	x.GetTransactionBlocklistRequest = &GetTransactionBlocklistRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_GetTransactionBlocklistResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetTransactionBlocklistResponse is nil")
+	}
+	return x.GetTransactionBlocklistResponse.toAppMessage()
+}
+
+func (x *GetTransactionBlocklistResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetTransactionBlocklistResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	if rpcErr != nil && len(x.Entries) != 0 {
+		return nil, errors.New("GetTransactionBlocklistResponseMessage contains both an error and a response")
+	}
+	entries, err := transactionBlocklistEntriesToAppMessage(x.Entries)
+	if err != nil {
+		return nil, err
+	}
+	return &appmessage.GetTransactionBlocklistResponseMessage{
+		Entries: entries,
+		Error:   rpcErr,
+	}, nil
+}
+
+func (x *KaspadMessage_GetTransactionBlocklistResponse) fromAppMessage(
+	message *appmessage.GetTransactionBlocklistResponseMessage) error {
+
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.GetTransactionBlocklistResponse = &GetTransactionBlocklistResponseMessage{
+		Entries: transactionBlocklistEntriesFromAppMessage(message.Entries),
+		Error:   err,
+	}
+	return nil
+}
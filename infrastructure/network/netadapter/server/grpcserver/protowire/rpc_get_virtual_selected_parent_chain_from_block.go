@@ -16,6 +16,8 @@ func (x *KaspadMessage_GetVirtualSelectedParentChainFromBlockRequest) fromAppMes
 	x.GetVirtualSelectedParentChainFromBlockRequest = &GetVirtualSelectedParentChainFromBlockRequestMessage{
 		StartHash:                     message.StartHash,
 		IncludeAcceptedTransactionIds: message.IncludeAcceptedTransactionIDs,
+		IncludeAcceptanceData:         message.IncludeAcceptanceData,
+		Limit:                         message.Limit,
 	}
 	return nil
 }
@@ -27,6 +29,8 @@ func (x *GetVirtualSelectedParentChainFromBlockRequestMessage) toAppMessage() (a
 	return &appmessage.GetVirtualSelectedParentChainFromBlockRequestMessage{
 		StartHash:                     x.StartHash,
 		IncludeAcceptedTransactionIDs: x.IncludeAcceptedTransactionIds,
+		IncludeAcceptanceData:         x.IncludeAcceptanceData,
+		Limit:                         x.Limit,
 	}, nil
 }
 
@@ -46,12 +50,23 @@ func (x *KaspadMessage_GetVirtualSelectedParentChainFromBlockResponse) fromAppMe
 		RemovedChainBlockHashes: message.RemovedChainBlockHashes,
 		AddedChainBlockHashes:   message.AddedChainBlockHashes,
 		AcceptedTransactionIds:  make([]*AcceptedTransactionIds, len(message.AcceptedTransactionIDs)),
+		AcceptanceData:          make([]*ChainBlockAcceptanceData, len(message.AcceptanceData)),
+		RemovedAcceptanceData:   make([]*ChainBlockAcceptanceData, len(message.RemovedAcceptanceData)),
+		NextStartHash:           message.NextStartHash,
 		Error:                   err,
 	}
 	for i, acceptedTransactionIDs := range message.AcceptedTransactionIDs {
 		x.GetVirtualSelectedParentChainFromBlockResponse.AcceptedTransactionIds[i] = &AcceptedTransactionIds{}
 		x.GetVirtualSelectedParentChainFromBlockResponse.AcceptedTransactionIds[i].fromAppMessage(acceptedTransactionIDs)
 	}
+	for i, acceptanceData := range message.AcceptanceData {
+		x.GetVirtualSelectedParentChainFromBlockResponse.AcceptanceData[i] = &ChainBlockAcceptanceData{}
+		x.GetVirtualSelectedParentChainFromBlockResponse.AcceptanceData[i].fromAppMessage(acceptanceData)
+	}
+	for i, acceptanceData := range message.RemovedAcceptanceData {
+		x.GetVirtualSelectedParentChainFromBlockResponse.RemovedAcceptanceData[i] = &ChainBlockAcceptanceData{}
+		x.GetVirtualSelectedParentChainFromBlockResponse.RemovedAcceptanceData[i].fromAppMessage(acceptanceData)
+	}
 	return nil
 }
 
@@ -73,12 +88,21 @@ func (x *GetVirtualSelectedParentChainFromBlockResponseMessage) toAppMessage() (
 		RemovedChainBlockHashes: x.RemovedChainBlockHashes,
 		AddedChainBlockHashes:   x.AddedChainBlockHashes,
 		AcceptedTransactionIDs:  make([]*appmessage.AcceptedTransactionIDs, len(x.AcceptedTransactionIds)),
+		AcceptanceData:          make([]*appmessage.ChainBlockAcceptanceData, len(x.AcceptanceData)),
+		RemovedAcceptanceData:   make([]*appmessage.ChainBlockAcceptanceData, len(x.RemovedAcceptanceData)),
+		NextStartHash:           x.NextStartHash,
 		Error:                   rpcErr,
 	}
 
 	for i, acceptedTransactionIds := range x.AcceptedTransactionIds {
 		message.AcceptedTransactionIDs[i] = acceptedTransactionIds.toAppMessage()
 	}
+	for i, acceptanceData := range x.AcceptanceData {
+		message.AcceptanceData[i] = acceptanceData.toAppMessage()
+	}
+	for i, acceptanceData := range x.RemovedAcceptanceData {
+		message.RemovedAcceptanceData[i] = acceptanceData.toAppMessage()
+	}
 
 	return message, nil
 }
@@ -0,0 +1,109 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_GetWorkRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetWorkRequest is nil")
+	}
+	return x.GetWorkRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_GetWorkRequest) fromAppMessage(message *appmessage.GetWorkRequestMessage) error {
+	x.GetWorkRequest = &GetWorkRequestMessage{
+		PayAddress: message.PayAddress,
+		ExtraData:  message.ExtraData,
+	}
+	return nil
+}
+
+func (x *GetWorkRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetWorkRequestMessage is nil")
+	}
+	return &appmessage.GetWorkRequestMessage{
+		PayAddress: x.PayAddress,
+		ExtraData:  x.ExtraData,
+	}, nil
+}
+
+func (x *KaspadMessage_GetWorkResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_GetWorkResponse is nil")
+	}
+	return x.GetWorkResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_GetWorkResponse) fromAppMessage(message *appmessage.GetWorkResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	var work *RpcWork
+	if message.Work != nil {
+		work = &RpcWork{}
+		convErr := work.fromAppMessage(message.Work)
+		if convErr != nil {
+			return convErr
+		}
+	}
+	x.GetWorkResponse = &GetWorkResponseMessage{
+		Work:     work,
+		IsSynced: message.IsSynced,
+		Error:    err,
+	}
+	return nil
+}
+
+func (x *GetWorkResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "GetWorkResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	var work *appmessage.RPCWork
+	if x.Work != nil {
+		var err error
+		work, err = x.Work.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &appmessage.GetWorkResponseMessage{
+		Work:     work,
+		IsSynced: x.IsSynced,
+		Error:    rpcErr,
+	}, nil
+}
+
+func (x *RpcWork) toAppMessage() (*appmessage.RPCWork, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "RpcWork is nil")
+	}
+	return &appmessage.RPCWork{
+		ID:         x.Id,
+		PrePowHash: x.PrePowHash,
+		Timestamp:  x.Timestamp,
+		Bits:       x.Bits,
+		Target:     x.Target,
+		DAAScore:   x.DaaScore,
+		BlueScore:  x.BlueScore,
+	}, nil
+}
+
+func (x *RpcWork) fromAppMessage(message *appmessage.RPCWork) error {
+	x.Id = message.ID
+	x.PrePowHash = message.PrePowHash
+	x.Timestamp = message.Timestamp
+	x.Bits = message.Bits
+	x.Target = message.Target
+	x.DaaScore = message.DAAScore
+	x.BlueScore = message.BlueScore
+	return nil
+}
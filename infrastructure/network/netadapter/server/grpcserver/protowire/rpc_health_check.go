@@ -0,0 +1,64 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_HealthCheckRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_HealthCheckRequest is nil")
+	}
+	return &appmessage.HealthCheckRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_HealthCheckRequest) fromAppMessage(_ *appmessage.HealthCheckRequestMessage) error {
+	x.HealthCheckRequest = &HealthCheckRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_HealthCheckResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_HealthCheckResponse is nil")
+	}
+	return x.HealthCheckResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_HealthCheckResponse) fromAppMessage(message *appmessage.HealthCheckResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.HealthCheckResponse = &HealthCheckResponseMessage{
+		IsHealthy:            message.IsHealthy,
+		IsReady:              message.IsReady,
+		IsDatabaseResponsive: message.IsDatabaseResponsive,
+		IsSynced:             message.IsSynced,
+		SyncAge:              message.SyncAge,
+		ConnectedPeerCount:   message.ConnectedPeerCount,
+		FailedChecks:         message.FailedChecks,
+		Error:                err,
+	}
+	return nil
+}
+
+func (x *HealthCheckResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "HealthCheckResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.HealthCheckResponseMessage{
+		IsHealthy:            x.IsHealthy,
+		IsReady:              x.IsReady,
+		IsDatabaseResponsive: x.IsDatabaseResponsive,
+		IsSynced:             x.IsSynced,
+		SyncAge:              x.SyncAge,
+		ConnectedPeerCount:   x.ConnectedPeerCount,
+		FailedChecks:         x.FailedChecks,
+		Error:                rpcErr,
+	}, nil
+}
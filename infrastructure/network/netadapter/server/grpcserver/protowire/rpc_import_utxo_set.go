@@ -0,0 +1,71 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_ImportUtxoSetRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_ImportUtxoSetRequest is nil")
+	}
+	return x.ImportUtxoSetRequest.toAppMessage()
+}
+
+func (x *ImportUtxoSetRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ImportUtxoSetRequestMessage is nil")
+	}
+	return &appmessage.ImportUTXOSetRequestMessage{
+		FilePath: x.FilePath,
+		RunAsJob: x.RunAsJob,
+	}, nil
+}
+
+func (x *KaspadMessage_ImportUtxoSetRequest) fromAppMessage(message *appmessage.ImportUTXOSetRequestMessage) error {
+	x.ImportUtxoSetRequest = &ImportUtxoSetRequestMessage{
+		FilePath: message.FilePath,
+		RunAsJob: message.RunAsJob,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_ImportUtxoSetResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_ImportUtxoSetResponse is nil")
+	}
+	return x.ImportUtxoSetResponse.toAppMessage()
+}
+
+func (x *ImportUtxoSetResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ImportUtxoSetResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.ImportUTXOSetResponseMessage{
+		PruningPointHash: x.PruningPointHash,
+		UTXOCommitment:   x.UtxoCommitment,
+		UTXOCount:        x.UtxoCount,
+		JobID:            x.JobId,
+		Error:            rpcErr,
+	}, nil
+}
+
+func (x *KaspadMessage_ImportUtxoSetResponse) fromAppMessage(message *appmessage.ImportUTXOSetResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.ImportUtxoSetResponse = &ImportUtxoSetResponseMessage{
+		PruningPointHash: message.PruningPointHash,
+		UtxoCommitment:   message.UTXOCommitment,
+		UtxoCount:        message.UTXOCount,
+		JobId:            message.JobID,
+		Error:            err,
+	}
+	return nil
+}
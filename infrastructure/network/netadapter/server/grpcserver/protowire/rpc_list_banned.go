@@ -0,0 +1,71 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_ListBannedRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_ListBannedRequest is nil")
+	}
+	return &appmessage.ListBannedRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_ListBannedRequest) fromAppMessage(_ *appmessage.ListBannedRequestMessage) error {
+	x.ListBannedRequest = &ListBannedRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_ListBannedResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_ListBannedResponse is nil")
+	}
+	return x.ListBannedResponse.toAppMessage()
+}
+
+func (x *ListBannedResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ListBannedResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	if rpcErr != nil && len(x.BannedSubnets) != 0 {
+		return nil, errors.New("ListBannedResponseMessage contains both an error and a response")
+	}
+	bannedSubnets := make([]*appmessage.BannedSubnet, len(x.BannedSubnets))
+	for i, bannedSubnet := range x.BannedSubnets {
+		bannedSubnets[i] = &appmessage.BannedSubnet{
+			Subnet:      bannedSubnet.Subnet,
+			BannedAt:    bannedSubnet.BannedAt,
+			BannedUntil: bannedSubnet.BannedUntil,
+		}
+	}
+	return &appmessage.ListBannedResponseMessage{
+		BannedSubnets: bannedSubnets,
+		Error:         rpcErr,
+	}, nil
+}
+
+func (x *KaspadMessage_ListBannedResponse) fromAppMessage(message *appmessage.ListBannedResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	bannedSubnets := make([]*BannedSubnet, len(message.BannedSubnets))
+	for i, bannedSubnet := range message.BannedSubnets {
+		bannedSubnets[i] = &BannedSubnet{
+			Subnet:      bannedSubnet.Subnet,
+			BannedAt:    bannedSubnet.BannedAt,
+			BannedUntil: bannedSubnet.BannedUntil,
+		}
+	}
+	x.ListBannedResponse = &ListBannedResponseMessage{
+		BannedSubnets: bannedSubnets,
+		Error:         err,
+	}
+	return nil
+}
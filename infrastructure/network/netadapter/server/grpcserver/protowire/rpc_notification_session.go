@@ -0,0 +1,115 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_StartNotificationSessionRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_StartNotificationSessionRequest is nil")
+	}
+	return &appmessage.StartNotificationSessionRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_StartNotificationSessionRequest) fromAppMessage(_ *appmessage.StartNotificationSessionRequestMessage) error {
+	x.StartNotificationSessionRequest = &StartNotificationSessionRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_StartNotificationSessionResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_StartNotificationSessionResponse is nil")
+	}
+	return x.StartNotificationSessionResponse.toAppMessage()
+}
+
+func (x *StartNotificationSessionResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "StartNotificationSessionResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.StartNotificationSessionResponseMessage{
+		SessionID:          x.SessionId,
+		HistorySize:        x.HistorySize,
+		ExpiryMilliseconds: x.ExpiryMilliseconds,
+		Error:              rpcErr,
+	}, nil
+}
+
+func (x *KaspadMessage_StartNotificationSessionResponse) fromAppMessage(message *appmessage.StartNotificationSessionResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.StartNotificationSessionResponse = &StartNotificationSessionResponseMessage{
+		SessionId:          message.SessionID,
+		HistorySize:        message.HistorySize,
+		ExpiryMilliseconds: message.ExpiryMilliseconds,
+		Error:              err,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_ResumeNotificationSessionRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_ResumeNotificationSessionRequest is nil")
+	}
+	return x.ResumeNotificationSessionRequest.toAppMessage()
+}
+
+func (x *ResumeNotificationSessionRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ResumeNotificationSessionRequestMessage is nil")
+	}
+	return &appmessage.ResumeNotificationSessionRequestMessage{
+		SessionID:    x.SessionId,
+		LastSequence: x.LastSequence,
+	}, nil
+}
+
+func (x *KaspadMessage_ResumeNotificationSessionRequest) fromAppMessage(message *appmessage.ResumeNotificationSessionRequestMessage) error {
+	x.ResumeNotificationSessionRequest = &ResumeNotificationSessionRequestMessage{
+		SessionId:    message.SessionID,
+		LastSequence: message.LastSequence,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_ResumeNotificationSessionResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_ResumeNotificationSessionResponse is nil")
+	}
+	return x.ResumeNotificationSessionResponse.toAppMessage()
+}
+
+func (x *ResumeNotificationSessionResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ResumeNotificationSessionResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.ResumeNotificationSessionResponseMessage{
+		ReplayedCount: x.ReplayedCount,
+		Error:         rpcErr,
+	}, nil
+}
+
+func (x *KaspadMessage_ResumeNotificationSessionResponse) fromAppMessage(message *appmessage.ResumeNotificationSessionResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.ResumeNotificationSessionResponse = &ResumeNotificationSessionResponseMessage{
+		ReplayedCount: message.ReplayedCount,
+		Error:         err,
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_NotifyFinalityPointAdvancedRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_NotifyFinalityPointAdvancedRequest is nil")
+	}
+	return x.NotifyFinalityPointAdvancedRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_NotifyFinalityPointAdvancedRequest) fromAppMessage(message *appmessage.NotifyFinalityPointAdvancedRequestMessage) error {
+	x.NotifyFinalityPointAdvancedRequest = &NotifyFinalityPointAdvancedRequestMessage{}
+	return nil
+}
+
+func (x *NotifyFinalityPointAdvancedRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "NotifyFinalityPointAdvancedRequestMessage is nil")
+	}
+	return &appmessage.NotifyFinalityPointAdvancedRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_NotifyFinalityPointAdvancedResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_NotifyFinalityPointAdvancedResponse is nil")
+	}
+	return x.NotifyFinalityPointAdvancedResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_NotifyFinalityPointAdvancedResponse) fromAppMessage(message *appmessage.NotifyFinalityPointAdvancedResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.NotifyFinalityPointAdvancedResponse = &NotifyFinalityPointAdvancedResponseMessage{
+		Error: err,
+	}
+	return nil
+}
+
+func (x *NotifyFinalityPointAdvancedResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "NotifyFinalityPointAdvancedResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.NotifyFinalityPointAdvancedResponseMessage{
+		Error: rpcErr,
+	}, nil
+}
+
+func (x *KaspadMessage_FinalityPointAdvancedNotification) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_FinalityPointAdvancedNotification is nil")
+	}
+	return x.FinalityPointAdvancedNotification.toAppMessage()
+}
+
+func (x *KaspadMessage_FinalityPointAdvancedNotification) fromAppMessage(message *appmessage.FinalityPointAdvancedNotificationMessage) error {
+	x.FinalityPointAdvancedNotification = &FinalityPointAdvancedNotificationMessage{
+		FinalityPointHash:         message.FinalityPointHash,
+		PreviousFinalityPointHash: message.PreviousFinalityPointHash,
+	}
+	return nil
+}
+
+func (x *FinalityPointAdvancedNotificationMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "FinalityPointAdvancedNotificationMessage is nil")
+	}
+	return &appmessage.FinalityPointAdvancedNotificationMessage{
+		FinalityPointHash:         x.FinalityPointHash,
+		PreviousFinalityPointHash: x.PreviousFinalityPointHash,
+	}, nil
+}
@@ -0,0 +1,86 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_NotifyJobProgressRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_NotifyJobProgressRequest is nil")
+	}
+	return x.NotifyJobProgressRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_NotifyJobProgressRequest) fromAppMessage(message *appmessage.NotifyJobProgressRequestMessage) error {
+	x.NotifyJobProgressRequest = &NotifyJobProgressRequestMessage{}
+	return nil
+}
+
+func (x *NotifyJobProgressRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "NotifyJobProgressRequestMessage is nil")
+	}
+	return &appmessage.NotifyJobProgressRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_NotifyJobProgressResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_NotifyJobProgressResponse is nil")
+	}
+	return x.NotifyJobProgressResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_NotifyJobProgressResponse) fromAppMessage(message *appmessage.NotifyJobProgressResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.NotifyJobProgressResponse = &NotifyJobProgressResponseMessage{
+		Error: err,
+	}
+	return nil
+}
+
+func (x *NotifyJobProgressResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "NotifyJobProgressResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.NotifyJobProgressResponseMessage{
+		Error: rpcErr,
+	}, nil
+}
+
+func (x *KaspadMessage_JobProgressNotification) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_JobProgressNotification is nil")
+	}
+	return x.JobProgressNotification.toAppMessage()
+}
+
+func (x *KaspadMessage_JobProgressNotification) fromAppMessage(message *appmessage.JobProgressNotificationMessage) error {
+	job := &RpcJob{}
+	job.fromAppMessage(message.Job)
+	x.JobProgressNotification = &JobProgressNotificationMessage{
+		Job: job,
+	}
+	return nil
+}
+
+func (x *JobProgressNotificationMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "JobProgressNotificationMessage is nil")
+	}
+	job, err := x.Job.toAppMessage()
+	if err != nil {
+		return nil, err
+	}
+	return &appmessage.JobProgressNotificationMessage{
+		Job: job,
+	}, nil
+}
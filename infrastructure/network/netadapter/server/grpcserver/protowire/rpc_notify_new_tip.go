@@ -0,0 +1,82 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_NotifyNewTipRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_NotifyNewTipRequest is nil")
+	}
+	return x.NotifyNewTipRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_NotifyNewTipRequest) fromAppMessage(message *appmessage.NotifyNewTipRequestMessage) error {
+	x.NotifyNewTipRequest = &NotifyNewTipRequestMessage{}
+	return nil
+}
+
+func (x *NotifyNewTipRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "NotifyNewTipRequestMessage is nil")
+	}
+	return &appmessage.NotifyNewTipRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_NotifyNewTipResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_NotifyNewTipResponse is nil")
+	}
+	return x.NotifyNewTipResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_NotifyNewTipResponse) fromAppMessage(message *appmessage.NotifyNewTipResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.NotifyNewTipResponse = &NotifyNewTipResponseMessage{
+		Error: err,
+	}
+	return nil
+}
+
+func (x *NotifyNewTipResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "NotifyNewTipResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.NotifyNewTipResponseMessage{
+		Error: rpcErr,
+	}, nil
+}
+
+func (x *KaspadMessage_NewTipNotification) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_NewTipNotification is nil")
+	}
+	return x.NewTipNotification.toAppMessage()
+}
+
+func (x *KaspadMessage_NewTipNotification) fromAppMessage(message *appmessage.NewTipNotificationMessage) error {
+	x.NewTipNotification = &NewTipNotificationMessage{
+		TipHash:   message.TipHash,
+		TipHashes: message.TipHashes,
+	}
+	return nil
+}
+
+func (x *NewTipNotificationMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "NewTipNotificationMessage is nil")
+	}
+	return &appmessage.NewTipNotificationMessage{
+		TipHash:   x.TipHash,
+		TipHashes: x.TipHashes,
+	}, nil
+}
@@ -0,0 +1,101 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_NotifyNewTransactionsRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_NotifyNewTransactionsRequest is nil")
+	}
+	return x.NotifyNewTransactionsRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_NotifyNewTransactionsRequest) fromAppMessage(message *appmessage.NotifyNewTransactionsRequestMessage) error {
+	x.NotifyNewTransactionsRequest = &NotifyNewTransactionsRequestMessage{
+		Addresses:     message.Addresses,
+		SubnetworkId:  message.SubnetworkID,
+		MinimumAmount: message.MinimumAmount,
+	}
+	return nil
+}
+
+func (x *NotifyNewTransactionsRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "NotifyNewTransactionsRequestMessage is nil")
+	}
+	return &appmessage.NotifyNewTransactionsRequestMessage{
+		Addresses:     x.Addresses,
+		SubnetworkID:  x.SubnetworkId,
+		MinimumAmount: x.MinimumAmount,
+	}, nil
+}
+
+func (x *KaspadMessage_NotifyNewTransactionsResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_NotifyNewTransactionsResponse is nil")
+	}
+	return x.NotifyNewTransactionsResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_NotifyNewTransactionsResponse) fromAppMessage(message *appmessage.NotifyNewTransactionsResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.NotifyNewTransactionsResponse = &NotifyNewTransactionsResponseMessage{
+		Error: err,
+	}
+	return nil
+}
+
+func (x *NotifyNewTransactionsResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "NotifyNewTransactionsResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.NotifyNewTransactionsResponseMessage{
+		Error: rpcErr,
+	}, nil
+}
+
+func (x *KaspadMessage_NewTransactionsNotification) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_NewTransactionsNotification is nil")
+	}
+	return x.NewTransactionsNotification.toAppMessage()
+}
+
+func (x *KaspadMessage_NewTransactionsNotification) fromAppMessage(message *appmessage.NewTransactionsNotificationMessage) error {
+	transactions := make([]*RpcTransaction, len(message.Transactions))
+	for i, transaction := range message.Transactions {
+		transactions[i] = &RpcTransaction{}
+		transactions[i].fromAppMessage(transaction)
+	}
+	x.NewTransactionsNotification = &NewTransactionsNotificationMessage{
+		Transactions: transactions,
+	}
+	return nil
+}
+
+func (x *NewTransactionsNotificationMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "NewTransactionsNotificationMessage is nil")
+	}
+	transactions := make([]*appmessage.RPCTransaction, len(x.Transactions))
+	for i, transaction := range x.Transactions {
+		var err error
+		transactions[i], err = transaction.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &appmessage.NewTransactionsNotificationMessage{
+		Transactions: transactions,
+	}, nil
+}
@@ -14,7 +14,8 @@ func (x *KaspadMessage_NotifyUtxosChangedRequest) toAppMessage() (appmessage.Mes
 
 func (x *KaspadMessage_NotifyUtxosChangedRequest) fromAppMessage(message *appmessage.NotifyUTXOsChangedRequestMessage) error {
 	x.NotifyUtxosChangedRequest = &NotifyUtxosChangedRequestMessage{
-		Addresses: message.Addresses,
+		Addresses:      message.Addresses,
+		IncludeMempool: message.IncludeMempool,
 	}
 	return nil
 }
@@ -24,7 +25,8 @@ func (x *NotifyUtxosChangedRequestMessage) toAppMessage() (appmessage.Message, e
 		return nil, errors.Wrapf(errorNil, "NotifyUtxosChangedRequestMessage is nil")
 	}
 	return &appmessage.NotifyUTXOsChangedRequestMessage{
-		Addresses: x.Addresses,
+		Addresses:      x.Addresses,
+		IncludeMempool: x.IncludeMempool,
 	}, nil
 }
 
@@ -68,55 +70,69 @@ func (x *KaspadMessage_UtxosChangedNotification) toAppMessage() (appmessage.Mess
 }
 
 func (x *KaspadMessage_UtxosChangedNotification) fromAppMessage(message *appmessage.UTXOsChangedNotificationMessage) error {
-	added := make([]*UtxosByAddressesEntry, len(message.Added))
-	for i, entry := range message.Added {
-		added[i] = &UtxosByAddressesEntry{}
-		added[i].fromAppMessage(entry)
-	}
-
-	removed := make([]*UtxosByAddressesEntry, len(message.Removed))
-	for i, entry := range message.Removed {
-		removed[i] = &UtxosByAddressesEntry{}
-		removed[i].fromAppMessage(entry)
-	}
-
 	x.UtxosChangedNotification = &UtxosChangedNotificationMessage{
-		Added:   added,
-		Removed: removed,
+		Added:          utxosByAddressesEntriesFromAppMessage(message.Added),
+		Removed:        utxosByAddressesEntriesFromAppMessage(message.Removed),
+		MempoolAdded:   utxosByAddressesEntriesFromAppMessage(message.MempoolAdded),
+		MempoolRemoved: utxosByAddressesEntriesFromAppMessage(message.MempoolRemoved),
 	}
 	return nil
 }
 
+func utxosByAddressesEntriesFromAppMessage(entries []*appmessage.UTXOsByAddressesEntry) []*UtxosByAddressesEntry {
+	protoEntries := make([]*UtxosByAddressesEntry, len(entries))
+	for i, entry := range entries {
+		protoEntries[i] = &UtxosByAddressesEntry{}
+		protoEntries[i].fromAppMessage(entry)
+	}
+	return protoEntries
+}
+
 func (x *UtxosChangedNotificationMessage) toAppMessage() (appmessage.Message, error) {
 	if x == nil {
 		return nil, errors.Wrapf(errorNil, "UtxosChangedNotificationMessage is nil")
 	}
-	added := make([]*appmessage.UTXOsByAddressesEntry, len(x.Added))
-	for i, entry := range x.Added {
-		entryAsAppMessage, err := entry.toAppMessage()
-		if err != nil {
-			return nil, err
-		}
-		// UTXOEntry is optional in other places, but here it's required.
-		if entryAsAppMessage.UTXOEntry == nil {
-			return nil, errors.Wrapf(errorNil, "UTXOEntry is nil in UTXOsByAddressesEntry.Added")
-		}
-		added[i] = entryAsAppMessage
+	added, err := utxosByAddressesEntriesToAppMessage(x.Added, true)
+	if err != nil {
+		return nil, err
+	}
+	removed, err := utxosByAddressesEntriesToAppMessage(x.Removed, false)
+	if err != nil {
+		return nil, err
+	}
+	mempoolAdded, err := utxosByAddressesEntriesToAppMessage(x.MempoolAdded, true)
+	if err != nil {
+		return nil, err
 	}
+	mempoolRemoved, err := utxosByAddressesEntriesToAppMessage(x.MempoolRemoved, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &appmessage.UTXOsChangedNotificationMessage{
+		Added:          added,
+		Removed:        removed,
+		MempoolAdded:   mempoolAdded,
+		MempoolRemoved: mempoolRemoved,
+	}, nil
+}
+
+func utxosByAddressesEntriesToAppMessage(entries []*UtxosByAddressesEntry,
+	isUTXOEntryRequired bool) ([]*appmessage.UTXOsByAddressesEntry, error) {
 
-	removed := make([]*appmessage.UTXOsByAddressesEntry, len(x.Removed))
-	for i, entry := range x.Removed {
+	appMessageEntries := make([]*appmessage.UTXOsByAddressesEntry, len(entries))
+	for i, entry := range entries {
 		entryAsAppMessage, err := entry.toAppMessage()
 		if err != nil {
 			return nil, err
 		}
-		removed[i] = entryAsAppMessage
+		// UTXOEntry is optional in other places, but it's required for added UTXOs.
+		if isUTXOEntryRequired && entryAsAppMessage.UTXOEntry == nil {
+			return nil, errors.Wrapf(errorNil, "UTXOEntry is nil in an added UTXOsByAddressesEntry")
+		}
+		appMessageEntries[i] = entryAsAppMessage
 	}
-
-	return &appmessage.UTXOsChangedNotificationMessage{
-		Added:   added,
-		Removed: removed,
-	}, nil
+	return appMessageEntries, nil
 }
 
 func (x *UtxosByAddressesEntry) toAppMessage() (*appmessage.UTXOsByAddressesEntry, error) {
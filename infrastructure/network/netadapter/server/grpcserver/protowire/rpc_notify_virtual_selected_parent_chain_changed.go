@@ -11,12 +11,14 @@ func (x *KaspadMessage_NotifyVirtualSelectedParentChainChangedRequest) toAppMess
 	}
 	return &appmessage.NotifyVirtualSelectedParentChainChangedRequestMessage{
 		IncludeAcceptedTransactionIDs: x.NotifyVirtualSelectedParentChainChangedRequest.IncludeAcceptedTransactionIds,
+		IncludeAcceptanceData:         x.NotifyVirtualSelectedParentChainChangedRequest.IncludeAcceptanceData,
 	}, nil
 }
 
 func (x *KaspadMessage_NotifyVirtualSelectedParentChainChangedRequest) fromAppMessage(appmessage *appmessage.NotifyVirtualSelectedParentChainChangedRequestMessage) error {
 	x.NotifyVirtualSelectedParentChainChangedRequest = &NotifyVirtualSelectedParentChainChangedRequestMessage{
 		IncludeAcceptedTransactionIds: appmessage.IncludeAcceptedTransactionIDs,
+		IncludeAcceptanceData:         appmessage.IncludeAcceptanceData,
 	}
 	return nil
 }
@@ -65,12 +67,17 @@ func (x *KaspadMessage_VirtualSelectedParentChainChangedNotification) fromAppMes
 		RemovedChainBlockHashes: message.RemovedChainBlockHashes,
 		AddedChainBlockHashes:   message.AddedChainBlockHashes,
 		AcceptedTransactionIds:  make([]*AcceptedTransactionIds, len(message.AcceptedTransactionIDs)),
+		AcceptanceData:          make([]*ChainBlockAcceptanceData, len(message.AcceptanceData)),
 	}
 
 	for i, acceptedTransactionIDs := range message.AcceptedTransactionIDs {
 		x.VirtualSelectedParentChainChangedNotification.AcceptedTransactionIds[i] = &AcceptedTransactionIds{}
 		x.VirtualSelectedParentChainChangedNotification.AcceptedTransactionIds[i].fromAppMessage(acceptedTransactionIDs)
 	}
+	for i, chainBlockAcceptanceData := range message.AcceptanceData {
+		x.VirtualSelectedParentChainChangedNotification.AcceptanceData[i] = &ChainBlockAcceptanceData{}
+		x.VirtualSelectedParentChainChangedNotification.AcceptanceData[i].fromAppMessage(chainBlockAcceptanceData)
+	}
 	return nil
 }
 
@@ -82,10 +89,41 @@ func (x *VirtualSelectedParentChainChangedNotificationMessage) toAppMessage() (a
 		RemovedChainBlockHashes: x.RemovedChainBlockHashes,
 		AddedChainBlockHashes:   x.AddedChainBlockHashes,
 		AcceptedTransactionIDs:  make([]*appmessage.AcceptedTransactionIDs, len(x.AcceptedTransactionIds)),
+		AcceptanceData:          make([]*appmessage.ChainBlockAcceptanceData, len(x.AcceptanceData)),
 	}
 
 	for i, acceptedTransactionIds := range x.AcceptedTransactionIds {
 		message.AcceptedTransactionIDs[i] = acceptedTransactionIds.toAppMessage()
 	}
+	for i, chainBlockAcceptanceData := range x.AcceptanceData {
+		message.AcceptanceData[i] = chainBlockAcceptanceData.toAppMessage()
+	}
 	return message, nil
 }
+
+func (x *ChainBlockAcceptanceData) fromAppMessage(chainBlockAcceptanceData *appmessage.ChainBlockAcceptanceData) {
+	x.AcceptingBlockHash = chainBlockAcceptanceData.AcceptingBlockHash
+	x.MergedBlocks = make([]*MergedBlockAcceptanceData, len(chainBlockAcceptanceData.MergedBlocks))
+	for i, mergedBlock := range chainBlockAcceptanceData.MergedBlocks {
+		x.MergedBlocks[i] = &MergedBlockAcceptanceData{
+			BlockHash:              mergedBlock.BlockHash,
+			AcceptedTransactionIds: mergedBlock.AcceptedTransactionIDs,
+			RejectedTransactionIds: mergedBlock.RejectedTransactionIDs,
+		}
+	}
+}
+
+func (x *ChainBlockAcceptanceData) toAppMessage() *appmessage.ChainBlockAcceptanceData {
+	mergedBlocks := make([]*appmessage.MergedBlockAcceptanceData, len(x.MergedBlocks))
+	for i, mergedBlock := range x.MergedBlocks {
+		mergedBlocks[i] = &appmessage.MergedBlockAcceptanceData{
+			BlockHash:              mergedBlock.BlockHash,
+			AcceptedTransactionIDs: mergedBlock.AcceptedTransactionIds,
+			RejectedTransactionIDs: mergedBlock.RejectedTransactionIds,
+		}
+	}
+	return &appmessage.ChainBlockAcceptanceData{
+		AcceptingBlockHash: x.AcceptingBlockHash,
+		MergedBlocks:       mergedBlocks,
+	}
+}
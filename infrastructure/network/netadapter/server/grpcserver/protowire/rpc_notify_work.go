@@ -0,0 +1,104 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_NotifyWorkRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_NotifyWorkRequest is nil")
+	}
+	return x.NotifyWorkRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_NotifyWorkRequest) fromAppMessage(message *appmessage.NotifyWorkRequestMessage) error {
+	x.NotifyWorkRequest = &NotifyWorkRequestMessage{
+		PayAddress: message.PayAddress,
+		ExtraData:  message.ExtraData,
+	}
+	return nil
+}
+
+func (x *NotifyWorkRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "NotifyWorkRequestMessage is nil")
+	}
+	return &appmessage.NotifyWorkRequestMessage{
+		PayAddress: x.PayAddress,
+		ExtraData:  x.ExtraData,
+	}, nil
+}
+
+func (x *KaspadMessage_NotifyWorkResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_NotifyWorkResponse is nil")
+	}
+	return x.NotifyWorkResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_NotifyWorkResponse) fromAppMessage(message *appmessage.NotifyWorkResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.NotifyWorkResponse = &NotifyWorkResponseMessage{
+		Error: err,
+	}
+	return nil
+}
+
+func (x *NotifyWorkResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "NotifyWorkResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.NotifyWorkResponseMessage{
+		Error: rpcErr,
+	}, nil
+}
+
+func (x *KaspadMessage_WorkNotification) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_WorkNotification is nil")
+	}
+	return x.WorkNotification.toAppMessage()
+}
+
+func (x *KaspadMessage_WorkNotification) fromAppMessage(message *appmessage.WorkNotificationMessage) error {
+	var work *RpcWork
+	if message.Work != nil {
+		work = &RpcWork{}
+		err := work.fromAppMessage(message.Work)
+		if err != nil {
+			return err
+		}
+	}
+	x.WorkNotification = &WorkNotificationMessage{
+		Work:     work,
+		IsSynced: message.IsSynced,
+	}
+	return nil
+}
+
+func (x *WorkNotificationMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "WorkNotificationMessage is nil")
+	}
+	var work *appmessage.RPCWork
+	if x.Work != nil {
+		var err error
+		work, err = x.Work.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &appmessage.WorkNotificationMessage{
+		Work:     work,
+		IsSynced: x.IsSynced,
+	}, nil
+}
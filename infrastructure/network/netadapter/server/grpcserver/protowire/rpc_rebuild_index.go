@@ -0,0 +1,61 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_RebuildIndexRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_RebuildIndexRequest is nil")
+	}
+	return x.RebuildIndexRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_RebuildIndexRequest) fromAppMessage(message *appmessage.RebuildIndexRequestMessage) error {
+	x.RebuildIndexRequest = &RebuildIndexRequestMessage{
+		IndexName: message.IndexName,
+	}
+	return nil
+}
+
+func (x *RebuildIndexRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "RebuildIndexRequestMessage is nil")
+	}
+	return &appmessage.RebuildIndexRequestMessage{
+		IndexName: x.IndexName,
+	}, nil
+}
+
+func (x *KaspadMessage_RebuildIndexResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_RebuildIndexResponse is nil")
+	}
+	return x.RebuildIndexResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_RebuildIndexResponse) fromAppMessage(message *appmessage.RebuildIndexResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.RebuildIndexResponse = &RebuildIndexResponseMessage{
+		Error: err,
+	}
+	return nil
+}
+
+func (x *RebuildIndexResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "RebuildIndexResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.RebuildIndexResponseMessage{
+		Error: rpcErr,
+	}, nil
+}
@@ -0,0 +1,49 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_ReloadConfigRequest) toAppMessage() (appmessage.Message, error) {
+	return &appmessage.ReloadConfigRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_ReloadConfigRequest) fromAppMessage(_ *appmessage.ReloadConfigRequestMessage) error {
+	x.ReloadConfigRequest = &ReloadConfigRequestMessage{}
+	return nil
+}
+
+func (x *KaspadMessage_ReloadConfigResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_ReloadConfigResponse is nil")
+	}
+	return x.ReloadConfigResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_ReloadConfigResponse) fromAppMessage(message *appmessage.ReloadConfigResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.ReloadConfigResponse = &ReloadConfigResponseMessage{
+		ChangedOptions: message.ChangedOptions,
+		Error:          err,
+	}
+	return nil
+}
+
+func (x *ReloadConfigResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "ReloadConfigResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.ReloadConfigResponseMessage{
+		ChangedOptions: x.ChangedOptions,
+		Error:          rpcErr,
+	}, nil
+}
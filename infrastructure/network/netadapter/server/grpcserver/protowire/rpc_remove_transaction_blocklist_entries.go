@@ -0,0 +1,74 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_RemoveTransactionBlocklistEntriesRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_RemoveTransactionBlocklistEntriesRequest is nil")
+	}
+	return x.RemoveTransactionBlocklistEntriesRequest.toAppMessage()
+}
+
+func (x *RemoveTransactionBlocklistEntriesRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "RemoveTransactionBlocklistEntriesRequestMessage is nil")
+	}
+	entries, err := transactionBlocklistEntriesToAppMessage(x.Entries)
+	if err != nil {
+		return nil, err
+	}
+	return &appmessage.RemoveTransactionBlocklistEntriesRequestMessage{
+		Entries: entries,
+	}, nil
+}
+
+func (x *KaspadMessage_RemoveTransactionBlocklistEntriesRequest) fromAppMessage(
+	message *appmessage.RemoveTransactionBlocklistEntriesRequestMessage) error {
+
+	x.RemoveTransactionBlocklistEntriesRequest = &RemoveTransactionBlocklistEntriesRequestMessage{
+		Entries: transactionBlocklistEntriesFromAppMessage(message.Entries),
+	}
+	return nil
+}
+
+func (x *KaspadMessage_RemoveTransactionBlocklistEntriesResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_RemoveTransactionBlocklistEntriesResponse is nil")
+	}
+	return x.RemoveTransactionBlocklistEntriesResponse.toAppMessage()
+}
+
+func (x *RemoveTransactionBlocklistEntriesResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "RemoveTransactionBlocklistEntriesResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	if rpcErr != nil && x.RemovedCount != 0 {
+		return nil, errors.New("RemoveTransactionBlocklistEntriesResponseMessage contains both an error and a response")
+	}
+	return &appmessage.RemoveTransactionBlocklistEntriesResponseMessage{
+		RemovedCount: x.RemovedCount,
+		Error:        rpcErr,
+	}, nil
+}
+
+func (x *KaspadMessage_RemoveTransactionBlocklistEntriesResponse) fromAppMessage(
+	message *appmessage.RemoveTransactionBlocklistEntriesResponseMessage) error {
+
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.RemoveTransactionBlocklistEntriesResponse = &RemoveTransactionBlocklistEntriesResponseMessage{
+		RemovedCount: message.RemovedCount,
+		Error:        err,
+	}
+	return nil
+}
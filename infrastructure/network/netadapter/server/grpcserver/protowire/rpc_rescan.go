@@ -0,0 +1,195 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_RescanRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_RescanRequest is nil")
+	}
+	return x.RescanRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_RescanRequest) fromAppMessage(message *appmessage.RescanRequestMessage) error {
+	outpoints := make([]*RpcOutpoint, len(message.Outpoints))
+	for i, outpoint := range message.Outpoints {
+		outpoints[i] = &RpcOutpoint{}
+		outpoints[i].fromAppMessage(outpoint)
+	}
+	x.RescanRequest = &RescanRequestMessage{
+		StartBlueScore: message.StartBlueScore,
+		Addresses:      message.Addresses,
+		Outpoints:      outpoints,
+	}
+	return nil
+}
+
+func (x *RescanRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "RescanRequestMessage is nil")
+	}
+	outpoints := make([]*appmessage.RPCOutpoint, len(x.Outpoints))
+	for i, outpoint := range x.Outpoints {
+		var err error
+		outpoints[i], err = outpoint.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &appmessage.RescanRequestMessage{
+		StartBlueScore: x.StartBlueScore,
+		Addresses:      x.Addresses,
+		Outpoints:      outpoints,
+	}, nil
+}
+
+func (x *KaspadMessage_RescanResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_RescanResponse is nil")
+	}
+	return x.RescanResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_RescanResponse) fromAppMessage(message *appmessage.RescanResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.RescanResponse = &RescanResponseMessage{
+		StartBlueScore: message.StartBlueScore,
+		JobId:          message.JobID,
+		Error:          err,
+	}
+	return nil
+}
+
+func (x *RescanResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "RescanResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.RescanResponseMessage{
+		StartBlueScore: x.StartBlueScore,
+		JobID:          x.JobId,
+		Error:          rpcErr,
+	}, nil
+}
+
+func (x *KaspadMessage_RescanProgressNotification) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_RescanProgressNotification is nil")
+	}
+	return x.RescanProgressNotification.toAppMessage()
+}
+
+func (x *KaspadMessage_RescanProgressNotification) fromAppMessage(message *appmessage.RescanProgressNotificationMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	matches := make([]*RescanMatch, len(message.Matches))
+	for i, match := range message.Matches {
+		transaction := &RpcTransaction{}
+		transaction.fromAppMessage(match.Transaction)
+		matches[i] = &RescanMatch{
+			AcceptingBlockHash: match.AcceptingBlockHash,
+			Transaction:        transaction,
+		}
+	}
+	x.RescanProgressNotification = &RescanProgressNotificationMessage{
+		Matches:                 matches,
+		CheckpointBlueScore:     message.CheckpointBlueScore,
+		CheckpointBlockHash:     message.CheckpointBlockHash,
+		RemovedChainBlockHashes: message.RemovedChainBlockHashes,
+		IsFinished:              message.IsFinished,
+		Error:                   err,
+	}
+	return nil
+}
+
+func (x *RescanProgressNotificationMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "RescanProgressNotificationMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	matches := make([]*appmessage.RescanMatch, len(x.Matches))
+	for i, match := range x.Matches {
+		transaction, err := match.Transaction.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+		matches[i] = &appmessage.RescanMatch{
+			AcceptingBlockHash: match.AcceptingBlockHash,
+			Transaction:        transaction,
+		}
+	}
+	return &appmessage.RescanProgressNotificationMessage{
+		Matches:                 matches,
+		CheckpointBlueScore:     x.CheckpointBlueScore,
+		CheckpointBlockHash:     x.CheckpointBlockHash,
+		RemovedChainBlockHashes: x.RemovedChainBlockHashes,
+		IsFinished:              x.IsFinished,
+		Error:                   rpcErr,
+	}, nil
+}
+
+func (x *KaspadMessage_StopRescanRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_StopRescanRequest is nil")
+	}
+	return x.StopRescanRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_StopRescanRequest) fromAppMessage(message *appmessage.StopRescanRequestMessage) error {
+	x.StopRescanRequest = &StopRescanRequestMessage{}
+	return nil
+}
+
+func (x *StopRescanRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "StopRescanRequestMessage is nil")
+	}
+	return &appmessage.StopRescanRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_StopRescanResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_StopRescanResponse is nil")
+	}
+	return x.StopRescanResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_StopRescanResponse) fromAppMessage(message *appmessage.StopRescanResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.StopRescanResponse = &StopRescanResponseMessage{
+		Error: err,
+	}
+	return nil
+}
+
+func (x *StopRescanResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "StopRescanResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.StopRescanResponseMessage{
+		Error: rpcErr,
+	}, nil
+}
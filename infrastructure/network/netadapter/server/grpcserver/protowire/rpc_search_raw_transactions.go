@@ -0,0 +1,116 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_SearchRawTransactionsRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_SearchRawTransactionsRequest is nil")
+	}
+	return x.SearchRawTransactionsRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_SearchRawTransactionsRequest) fromAppMessage(message *appmessage.SearchRawTransactionsRequestMessage) error {
+	x.SearchRawTransactionsRequest = &SearchRawTransactionsRequestMessage{
+		Address:        message.Address,
+		Limit:          message.Limit,
+		Cursor:         message.Cursor,
+		IncludeMempool: message.IncludeMempool,
+	}
+	return nil
+}
+
+func (x *SearchRawTransactionsRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "SearchRawTransactionsRequestMessage is nil")
+	}
+	return &appmessage.SearchRawTransactionsRequestMessage{
+		Address:        x.Address,
+		Limit:          x.Limit,
+		Cursor:         x.Cursor,
+		IncludeMempool: x.IncludeMempool,
+	}, nil
+}
+
+func (x *KaspadMessage_SearchRawTransactionsResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_SearchRawTransactionsResponse is nil")
+	}
+	return x.SearchRawTransactionsResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_SearchRawTransactionsResponse) fromAppMessage(message *appmessage.SearchRawTransactionsResponseMessage) error {
+	var rpcErr *RPCError
+	if message.Error != nil {
+		rpcErr = &RPCError{Message: message.Error.Message}
+	}
+	entries := make([]*AddressTransactionEntry, len(message.Entries))
+	for i, entry := range message.Entries {
+		entries[i] = &AddressTransactionEntry{}
+		entries[i].fromAppMessage(entry)
+	}
+	x.SearchRawTransactionsResponse = &SearchRawTransactionsResponseMessage{
+		Entries:    entries,
+		NextCursor: message.NextCursor,
+		Error:      rpcErr,
+	}
+	return nil
+}
+
+func (x *SearchRawTransactionsResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "SearchRawTransactionsResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+
+	if rpcErr != nil && len(x.Entries) != 0 {
+		return nil, errors.New("SearchRawTransactionsResponseMessage contains both an error and a response")
+	}
+	entries := make([]*appmessage.AddressTransactionEntry, len(x.Entries))
+	for i, entry := range x.Entries {
+		entries[i], err = entry.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &appmessage.SearchRawTransactionsResponseMessage{
+		Entries:    entries,
+		NextCursor: x.NextCursor,
+		Error:      rpcErr,
+	}, nil
+}
+
+func (x *AddressTransactionEntry) toAppMessage() (*appmessage.AddressTransactionEntry, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "AddressTransactionEntry is nil")
+	}
+	transaction, err := x.Transaction.toAppMessage()
+	if err != nil {
+		return nil, err
+	}
+	return &appmessage.AddressTransactionEntry{
+		Transaction:        transaction,
+		AcceptingBlockHash: x.AcceptingBlockHash,
+		AcceptingDAAScore:  x.AcceptingDaaScore,
+	}, nil
+}
+
+func (x *AddressTransactionEntry) fromAppMessage(entry *appmessage.AddressTransactionEntry) {
+	var transaction *RpcTransaction
+	if entry.Transaction != nil {
+		transaction = &RpcTransaction{}
+		transaction.fromAppMessage(entry.Transaction)
+	}
+	*x = AddressTransactionEntry{
+		Transaction:        transaction,
+		AcceptingBlockHash: entry.AcceptingBlockHash,
+		AcceptingDaaScore:  entry.AcceptingDAAScore,
+	}
+}
@@ -0,0 +1,65 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_SetBanRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_SetBanRequest is nil")
+	}
+	return x.SetBanRequest.toAppMessage()
+}
+
+func (x *SetBanRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "SetBanRequestMessage is nil")
+	}
+	return &appmessage.SetBanRequestMessage{
+		Subnet:          x.Subnet,
+		DurationSeconds: x.DurationSeconds,
+		Remove:          x.Remove,
+	}, nil
+}
+
+func (x *KaspadMessage_SetBanRequest) fromAppMessage(message *appmessage.SetBanRequestMessage) error {
+	x.SetBanRequest = &SetBanRequestMessage{
+		Subnet:          message.Subnet,
+		DurationSeconds: message.DurationSeconds,
+		Remove:          message.Remove,
+	}
+	return nil
+}
+
+func (x *KaspadMessage_SetBanResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_SetBanResponse is nil")
+	}
+	return x.SetBanResponse.toAppMessage()
+}
+
+func (x *SetBanResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "SetBanResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.SetBanResponseMessage{
+		Error: rpcErr,
+	}, nil
+}
+
+func (x *KaspadMessage_SetBanResponse) fromAppMessage(message *appmessage.SetBanResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.SetBanResponse = &SetBanResponseMessage{
+		Error: err,
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_SignRawTransactionRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_SignRawTransactionRequest is nil")
+	}
+	return x.SignRawTransactionRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_SignRawTransactionRequest) fromAppMessage(message *appmessage.SignRawTransactionRequestMessage) error {
+	previousOutputs := make([]*SignRawTransactionPreviousOutput, len(message.PreviousOutputs))
+	for i, previousOutput := range message.PreviousOutputs {
+		previousOutputs[i] = &SignRawTransactionPreviousOutput{}
+		previousOutputs[i].fromAppMessage(previousOutput)
+	}
+	x.SignRawTransactionRequest = &SignRawTransactionRequestMessage{
+		Transaction:     &RpcTransaction{},
+		PrivateKeys:     message.PrivateKeys,
+		PreviousOutputs: previousOutputs,
+	}
+	x.SignRawTransactionRequest.Transaction.fromAppMessage(message.Transaction)
+	return nil
+}
+
+func (x *SignRawTransactionRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "SignRawTransactionRequestMessage is nil")
+	}
+	rpcTransaction, err := x.Transaction.toAppMessage()
+	if err != nil {
+		return nil, err
+	}
+	previousOutputs := make([]*appmessage.SignRawTransactionPreviousOutput, len(x.PreviousOutputs))
+	for i, previousOutput := range x.PreviousOutputs {
+		previousOutputs[i], err = previousOutput.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &appmessage.SignRawTransactionRequestMessage{
+		Transaction:     rpcTransaction,
+		PrivateKeys:     x.PrivateKeys,
+		PreviousOutputs: previousOutputs,
+	}, nil
+}
+
+func (x *SignRawTransactionPreviousOutput) toAppMessage() (*appmessage.SignRawTransactionPreviousOutput, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "SignRawTransactionPreviousOutput is nil")
+	}
+	outpoint, err := x.Outpoint.toAppMessage()
+	if err != nil {
+		return nil, err
+	}
+	utxoEntry, err := x.UtxoEntry.toAppMessage()
+	if err != nil {
+		return nil, err
+	}
+	return &appmessage.SignRawTransactionPreviousOutput{
+		Outpoint:     outpoint,
+		UTXOEntry:    utxoEntry,
+		RedeemScript: x.RedeemScript,
+	}, nil
+}
+
+func (x *SignRawTransactionPreviousOutput) fromAppMessage(message *appmessage.SignRawTransactionPreviousOutput) {
+	outpoint := &RpcOutpoint{}
+	outpoint.fromAppMessage(message.Outpoint)
+	utxoEntry := &RpcUtxoEntry{}
+	utxoEntry.fromAppMessage(message.UTXOEntry)
+	*x = SignRawTransactionPreviousOutput{
+		Outpoint:     outpoint,
+		UtxoEntry:    utxoEntry,
+		RedeemScript: message.RedeemScript,
+	}
+}
+
+func (x *KaspadMessage_SignRawTransactionResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_SignRawTransactionResponse is nil")
+	}
+	return x.SignRawTransactionResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_SignRawTransactionResponse) fromAppMessage(message *appmessage.SignRawTransactionResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	var transaction *RpcTransaction
+	if message.Transaction != nil {
+		transaction = &RpcTransaction{}
+		transaction.fromAppMessage(message.Transaction)
+	}
+	inputErrors := make([]*SignRawTransactionInputError, len(message.InputErrors))
+	for i, inputError := range message.InputErrors {
+		inputErrors[i] = &SignRawTransactionInputError{
+			InputIndex: inputError.InputIndex,
+			Message:    inputError.Message,
+		}
+	}
+	x.SignRawTransactionResponse = &SignRawTransactionResponseMessage{
+		Transaction: transaction,
+		IsComplete:  message.IsComplete,
+		InputErrors: inputErrors,
+		Error:       err,
+	}
+	return nil
+}
+
+func (x *SignRawTransactionResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "SignRawTransactionResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	// Transaction is nil if there's an error
+	var transaction *appmessage.RPCTransaction
+	if rpcErr == nil {
+		transaction, err = x.Transaction.toAppMessage()
+		if err != nil {
+			return nil, err
+		}
+	}
+	inputErrors := make([]*appmessage.SignRawTransactionInputError, len(x.InputErrors))
+	for i, inputError := range x.InputErrors {
+		inputErrors[i] = &appmessage.SignRawTransactionInputError{
+			InputIndex: inputError.InputIndex,
+			Message:    inputError.Message,
+		}
+	}
+	return &appmessage.SignRawTransactionResponseMessage{
+		Transaction: transaction,
+		IsComplete:  x.IsComplete,
+		InputErrors: inputErrors,
+		Error:       rpcErr,
+	}, nil
+}
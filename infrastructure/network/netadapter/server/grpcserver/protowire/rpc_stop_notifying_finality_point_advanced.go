@@ -0,0 +1,57 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_StopNotifyingFinalityPointAdvancedRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_StopNotifyingFinalityPointAdvancedRequest is nil")
+	}
+	return x.StopNotifyingFinalityPointAdvancedRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_StopNotifyingFinalityPointAdvancedRequest) fromAppMessage(message *appmessage.StopNotifyingFinalityPointAdvancedRequestMessage) error {
+	x.StopNotifyingFinalityPointAdvancedRequest = &StopNotifyingFinalityPointAdvancedRequestMessage{}
+	return nil
+}
+
+func (x *StopNotifyingFinalityPointAdvancedRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "StopNotifyingFinalityPointAdvancedRequestMessage is nil")
+	}
+	return &appmessage.StopNotifyingFinalityPointAdvancedRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_StopNotifyingFinalityPointAdvancedResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_StopNotifyingFinalityPointAdvancedResponse is nil")
+	}
+	return x.StopNotifyingFinalityPointAdvancedResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_StopNotifyingFinalityPointAdvancedResponse) fromAppMessage(message *appmessage.StopNotifyingFinalityPointAdvancedResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.StopNotifyingFinalityPointAdvancedResponse = &StopNotifyingFinalityPointAdvancedResponseMessage{
+		Error: err,
+	}
+	return nil
+}
+
+func (x *StopNotifyingFinalityPointAdvancedResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "StopNotifyingFinalityPointAdvancedResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.StopNotifyingFinalityPointAdvancedResponseMessage{
+		Error: rpcErr,
+	}, nil
+}
@@ -0,0 +1,57 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_StopNotifyingJobProgressRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_StopNotifyingJobProgressRequest is nil")
+	}
+	return x.StopNotifyingJobProgressRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_StopNotifyingJobProgressRequest) fromAppMessage(message *appmessage.StopNotifyingJobProgressRequestMessage) error {
+	x.StopNotifyingJobProgressRequest = &StopNotifyingJobProgressRequestMessage{}
+	return nil
+}
+
+func (x *StopNotifyingJobProgressRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "StopNotifyingJobProgressRequestMessage is nil")
+	}
+	return &appmessage.StopNotifyingJobProgressRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_StopNotifyingJobProgressResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_StopNotifyingJobProgressResponse is nil")
+	}
+	return x.StopNotifyingJobProgressResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_StopNotifyingJobProgressResponse) fromAppMessage(message *appmessage.StopNotifyingJobProgressResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.StopNotifyingJobProgressResponse = &StopNotifyingJobProgressResponseMessage{
+		Error: err,
+	}
+	return nil
+}
+
+func (x *StopNotifyingJobProgressResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "StopNotifyingJobProgressResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.StopNotifyingJobProgressResponseMessage{
+		Error: rpcErr,
+	}, nil
+}
@@ -0,0 +1,57 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_StopNotifyingNewTipRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_StopNotifyingNewTipRequest is nil")
+	}
+	return x.StopNotifyingNewTipRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_StopNotifyingNewTipRequest) fromAppMessage(message *appmessage.StopNotifyingNewTipRequestMessage) error {
+	x.StopNotifyingNewTipRequest = &StopNotifyingNewTipRequestMessage{}
+	return nil
+}
+
+func (x *StopNotifyingNewTipRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "StopNotifyingNewTipRequestMessage is nil")
+	}
+	return &appmessage.StopNotifyingNewTipRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_StopNotifyingNewTipResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_StopNotifyingNewTipResponse is nil")
+	}
+	return x.StopNotifyingNewTipResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_StopNotifyingNewTipResponse) fromAppMessage(message *appmessage.StopNotifyingNewTipResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.StopNotifyingNewTipResponse = &StopNotifyingNewTipResponseMessage{
+		Error: err,
+	}
+	return nil
+}
+
+func (x *StopNotifyingNewTipResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "StopNotifyingNewTipResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.StopNotifyingNewTipResponseMessage{
+		Error: rpcErr,
+	}, nil
+}
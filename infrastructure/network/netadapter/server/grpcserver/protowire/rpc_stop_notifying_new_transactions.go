@@ -0,0 +1,57 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_StopNotifyingNewTransactionsRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_StopNotifyingNewTransactionsRequest is nil")
+	}
+	return x.StopNotifyingNewTransactionsRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_StopNotifyingNewTransactionsRequest) fromAppMessage(message *appmessage.StopNotifyingNewTransactionsRequestMessage) error {
+	x.StopNotifyingNewTransactionsRequest = &StopNotifyingNewTransactionsRequestMessage{}
+	return nil
+}
+
+func (x *StopNotifyingNewTransactionsRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "StopNotifyingNewTransactionsRequestMessage is nil")
+	}
+	return &appmessage.StopNotifyingNewTransactionsRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_StopNotifyingNewTransactionsResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_StopNotifyingNewTransactionsResponse is nil")
+	}
+	return x.StopNotifyingNewTransactionsResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_StopNotifyingNewTransactionsResponse) fromAppMessage(message *appmessage.StopNotifyingNewTransactionsResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.StopNotifyingNewTransactionsResponse = &StopNotifyingNewTransactionsResponseMessage{
+		Error: err,
+	}
+	return nil
+}
+
+func (x *StopNotifyingNewTransactionsResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "StopNotifyingNewTransactionsResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.StopNotifyingNewTransactionsResponseMessage{
+		Error: rpcErr,
+	}, nil
+}
@@ -0,0 +1,57 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_StopNotifyingVirtualSelectedParentChainChangedRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_StopNotifyingVirtualSelectedParentChainChangedRequest is nil")
+	}
+	return x.StopNotifyingVirtualSelectedParentChainChangedRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_StopNotifyingVirtualSelectedParentChainChangedRequest) fromAppMessage(message *appmessage.StopNotifyingVirtualSelectedParentChainChangedRequestMessage) error {
+	x.StopNotifyingVirtualSelectedParentChainChangedRequest = &StopNotifyingVirtualSelectedParentChainChangedRequestMessage{}
+	return nil
+}
+
+func (x *StopNotifyingVirtualSelectedParentChainChangedRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "StopNotifyingVirtualSelectedParentChainChangedRequestMessage is nil")
+	}
+	return &appmessage.StopNotifyingVirtualSelectedParentChainChangedRequestMessage{}, nil
+}
+
+func (x *KaspadMessage_StopNotifyingVirtualSelectedParentChainChangedResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_StopNotifyingVirtualSelectedParentChainChangedResponse is nil")
+	}
+	return x.StopNotifyingVirtualSelectedParentChainChangedResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_StopNotifyingVirtualSelectedParentChainChangedResponse) fromAppMessage(message *appmessage.StopNotifyingVirtualSelectedParentChainChangedResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.StopNotifyingVirtualSelectedParentChainChangedResponse = &StopNotifyingVirtualSelectedParentChainChangedResponseMessage{
+		Error: err,
+	}
+	return nil
+}
+
+func (x *StopNotifyingVirtualSelectedParentChainChangedResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "StopNotifyingVirtualSelectedParentChainChangedResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.StopNotifyingVirtualSelectedParentChainChangedResponseMessage{
+		Error: rpcErr,
+	}, nil
+}
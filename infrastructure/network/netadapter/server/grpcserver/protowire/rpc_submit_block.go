@@ -16,6 +16,7 @@ func (x *KaspadMessage_SubmitBlockRequest) toAppMessage() (appmessage.Message, e
 func (x *KaspadMessage_SubmitBlockRequest) fromAppMessage(message *appmessage.SubmitBlockRequestMessage) error {
 	x.SubmitBlockRequest = &SubmitBlockRequestMessage{Block: &RpcBlock{}}
 	x.SubmitBlockRequest.AllowNonDAABlocks = message.AllowNonDAABlocks
+	x.SubmitBlockRequest.Async = message.Async
 	return x.SubmitBlockRequest.Block.fromAppMessage(message.Block)
 }
 
@@ -30,6 +31,7 @@ func (x *SubmitBlockRequestMessage) toAppMessage() (appmessage.Message, error) {
 	return &appmessage.SubmitBlockRequestMessage{
 		Block:             blockAppMessage,
 		AllowNonDAABlocks: x.GetAllowNonDAABlocks(),
+		Async:             x.GetAsync(),
 	}, nil
 }
 
@@ -47,6 +49,7 @@ func (x *KaspadMessage_SubmitBlockResponse) fromAppMessage(message *appmessage.S
 	}
 	x.SubmitBlockResponse = &SubmitBlockResponseMessage{
 		RejectReason: SubmitBlockResponseMessage_RejectReason(message.RejectReason),
+		SubmissionId: message.SubmissionID,
 		Error:        err,
 	}
 	return nil
@@ -63,6 +66,7 @@ func (x *SubmitBlockResponseMessage) toAppMessage() (appmessage.Message, error)
 	}
 	return &appmessage.SubmitBlockResponseMessage{
 		RejectReason: appmessage.RejectReason(x.RejectReason),
+		SubmissionID: x.SubmissionId,
 		Error:        rpcErr,
 	}, nil
 }
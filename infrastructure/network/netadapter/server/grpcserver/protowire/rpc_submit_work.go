@@ -0,0 +1,65 @@
+package protowire
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func (x *KaspadMessage_SubmitWorkRequest) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_SubmitWorkRequest is nil")
+	}
+	return x.SubmitWorkRequest.toAppMessage()
+}
+
+func (x *KaspadMessage_SubmitWorkRequest) fromAppMessage(message *appmessage.SubmitWorkRequestMessage) error {
+	x.SubmitWorkRequest = &SubmitWorkRequestMessage{
+		WorkId: message.WorkID,
+		Nonce:  message.Nonce,
+	}
+	return nil
+}
+
+func (x *SubmitWorkRequestMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "SubmitWorkRequestMessage is nil")
+	}
+	return &appmessage.SubmitWorkRequestMessage{
+		WorkID: x.WorkId,
+		Nonce:  x.Nonce,
+	}, nil
+}
+
+func (x *KaspadMessage_SubmitWorkResponse) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "KaspadMessage_SubmitWorkResponse is nil")
+	}
+	return x.SubmitWorkResponse.toAppMessage()
+}
+
+func (x *KaspadMessage_SubmitWorkResponse) fromAppMessage(message *appmessage.SubmitWorkResponseMessage) error {
+	var err *RPCError
+	if message.Error != nil {
+		err = &RPCError{Message: message.Error.Message}
+	}
+	x.SubmitWorkResponse = &SubmitWorkResponseMessage{
+		BlockHash: message.BlockHash,
+		Error:     err,
+	}
+	return nil
+}
+
+func (x *SubmitWorkResponseMessage) toAppMessage() (appmessage.Message, error) {
+	if x == nil {
+		return nil, errors.Wrapf(errorNil, "SubmitWorkResponseMessage is nil")
+	}
+	rpcErr, err := x.Error.toAppMessage()
+	// Error is an optional field
+	if err != nil && !errors.Is(err, errorNil) {
+		return nil, err
+	}
+	return &appmessage.SubmitWorkResponseMessage{
+		BlockHash: x.BlockHash,
+		Error:     rpcErr,
+	}, nil
+}
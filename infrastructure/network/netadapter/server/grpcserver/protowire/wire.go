@@ -22,17 +22,23 @@ func (x *KaspadMessage) ToAppMessage() (appmessage.Message, error) {
 	if err != nil {
 		return nil, err
 	}
+	appMessage.SetNotificationSequence(x.NotificationSequence)
 	return appMessage, nil
 }
 
 // FromAppMessage creates a KaspadMessage from a appmessage.Message
 func FromAppMessage(message appmessage.Message) (*KaspadMessage, error) {
+	notificationSequence := message.NotificationSequence()
+	if sequencedNotification, ok := message.(*appmessage.SequencedNotificationMessage); ok {
+		message = sequencedNotification.Notification
+	}
 	payload, err := toPayload(message)
 	if err != nil {
 		return nil, err
 	}
 	return &KaspadMessage{
-		Payload: payload,
+		Payload:              payload,
+		NotificationSequence: notificationSequence,
 	}, nil
 }
 
@@ -968,6 +974,678 @@ func toRPCPayload(message appmessage.Message) (isKaspadMessage_Payload, error) {
 			return nil, err
 		}
 		return payload, nil
+	case *appmessage.GetWorkRequestMessage:
+		payload := new(KaspadMessage_GetWorkRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetWorkResponseMessage:
+		payload := new(KaspadMessage_GetWorkResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.SubmitWorkRequestMessage:
+		payload := new(KaspadMessage_SubmitWorkRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.SubmitWorkResponseMessage:
+		payload := new(KaspadMessage_SubmitWorkResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyWorkRequestMessage:
+		payload := new(KaspadMessage_NotifyWorkRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyWorkResponseMessage:
+		payload := new(KaspadMessage_NotifyWorkResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.WorkNotificationMessage:
+		payload := new(KaspadMessage_WorkNotification)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetBlockSubmissionStatusRequestMessage:
+		payload := new(KaspadMessage_GetBlockSubmissionStatusRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetBlockSubmissionStatusResponseMessage:
+		payload := new(KaspadMessage_GetBlockSubmissionStatusResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GenerateBlocksRequestMessage:
+		payload := new(KaspadMessage_GenerateBlocksRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GenerateBlocksResponseMessage:
+		payload := new(KaspadMessage_GenerateBlocksResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetAuxCommitmentProofRequestMessage:
+		payload := new(KaspadMessage_GetAuxCommitmentProofRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetAuxCommitmentProofResponseMessage:
+		payload := new(KaspadMessage_GetAuxCommitmentProofResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyNewTipRequestMessage:
+		payload := new(KaspadMessage_NotifyNewTipRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyNewTipResponseMessage:
+		payload := new(KaspadMessage_NotifyNewTipResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NewTipNotificationMessage:
+		payload := new(KaspadMessage_NewTipNotification)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.StopNotifyingNewTipRequestMessage:
+		payload := new(KaspadMessage_StopNotifyingNewTipRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.StopNotifyingNewTipResponseMessage:
+		payload := new(KaspadMessage_StopNotifyingNewTipResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyFinalityPointAdvancedRequestMessage:
+		payload := new(KaspadMessage_NotifyFinalityPointAdvancedRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyFinalityPointAdvancedResponseMessage:
+		payload := new(KaspadMessage_NotifyFinalityPointAdvancedResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.FinalityPointAdvancedNotificationMessage:
+		payload := new(KaspadMessage_FinalityPointAdvancedNotification)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.StopNotifyingFinalityPointAdvancedRequestMessage:
+		payload := new(KaspadMessage_StopNotifyingFinalityPointAdvancedRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.StopNotifyingFinalityPointAdvancedResponseMessage:
+		payload := new(KaspadMessage_StopNotifyingFinalityPointAdvancedResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.StopNotifyingVirtualSelectedParentChainChangedRequestMessage:
+		payload := new(KaspadMessage_StopNotifyingVirtualSelectedParentChainChangedRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.StopNotifyingVirtualSelectedParentChainChangedResponseMessage:
+		payload := new(KaspadMessage_StopNotifyingVirtualSelectedParentChainChangedResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.SearchRawTransactionsRequestMessage:
+		payload := new(KaspadMessage_SearchRawTransactionsRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.SearchRawTransactionsResponseMessage:
+		payload := new(KaspadMessage_SearchRawTransactionsResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.HealthCheckRequestMessage:
+		payload := new(KaspadMessage_HealthCheckRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.HealthCheckResponseMessage:
+		payload := new(KaspadMessage_HealthCheckResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.SignRawTransactionRequestMessage:
+		payload := new(KaspadMessage_SignRawTransactionRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.SignRawTransactionResponseMessage:
+		payload := new(KaspadMessage_SignRawTransactionResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyNewTransactionsRequestMessage:
+		payload := new(KaspadMessage_NotifyNewTransactionsRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyNewTransactionsResponseMessage:
+		payload := new(KaspadMessage_NotifyNewTransactionsResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NewTransactionsNotificationMessage:
+		payload := new(KaspadMessage_NewTransactionsNotification)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.StopNotifyingNewTransactionsRequestMessage:
+		payload := new(KaspadMessage_StopNotifyingNewTransactionsRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.StopNotifyingNewTransactionsResponseMessage:
+		payload := new(KaspadMessage_StopNotifyingNewTransactionsResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.SetBanRequestMessage:
+		payload := new(KaspadMessage_SetBanRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.SetBanResponseMessage:
+		payload := new(KaspadMessage_SetBanResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ListBannedRequestMessage:
+		payload := new(KaspadMessage_ListBannedRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ListBannedResponseMessage:
+		payload := new(KaspadMessage_ListBannedResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ClearBannedRequestMessage:
+		payload := new(KaspadMessage_ClearBannedRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ClearBannedResponseMessage:
+		payload := new(KaspadMessage_ClearBannedResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.DumpUTXOSetRequestMessage:
+		payload := new(KaspadMessage_DumpUtxoSetRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.DumpUTXOSetResponseMessage:
+		payload := new(KaspadMessage_DumpUtxoSetResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ImportUTXOSetRequestMessage:
+		payload := new(KaspadMessage_ImportUtxoSetRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ImportUTXOSetResponseMessage:
+		payload := new(KaspadMessage_ImportUtxoSetResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.StartNotificationSessionRequestMessage:
+		payload := new(KaspadMessage_StartNotificationSessionRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.StartNotificationSessionResponseMessage:
+		payload := new(KaspadMessage_StartNotificationSessionResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ResumeNotificationSessionRequestMessage:
+		payload := new(KaspadMessage_ResumeNotificationSessionRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ResumeNotificationSessionResponseMessage:
+		payload := new(KaspadMessage_ResumeNotificationSessionResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetFinalityPointRequestMessage:
+		payload := new(KaspadMessage_GetFinalityPointRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetFinalityPointResponseMessage:
+		payload := new(KaspadMessage_GetFinalityPointResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetDatabaseStatsRequestMessage:
+		payload := new(KaspadMessage_GetDatabaseStatsRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetDatabaseStatsResponseMessage:
+		payload := new(KaspadMessage_GetDatabaseStatsResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.CompactDatabaseRequestMessage:
+		payload := new(KaspadMessage_CompactDatabaseRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.CompactDatabaseResponseMessage:
+		payload := new(KaspadMessage_CompactDatabaseResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.BackupDatabaseRequestMessage:
+		payload := new(KaspadMessage_BackupDatabaseRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.BackupDatabaseResponseMessage:
+		payload := new(KaspadMessage_BackupDatabaseResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetIndexStatusRequestMessage:
+		payload := new(KaspadMessage_GetIndexStatusRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetIndexStatusResponseMessage:
+		payload := new(KaspadMessage_GetIndexStatusResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.RebuildIndexRequestMessage:
+		payload := new(KaspadMessage_RebuildIndexRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.RebuildIndexResponseMessage:
+		payload := new(KaspadMessage_RebuildIndexResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.DropIndexRequestMessage:
+		payload := new(KaspadMessage_DropIndexRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.DropIndexResponseMessage:
+		payload := new(KaspadMessage_DropIndexResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ExportBlocksRequestMessage:
+		payload := new(KaspadMessage_ExportBlocksRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ExportBlocksResponseMessage:
+		payload := new(KaspadMessage_ExportBlocksResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetAddressManagerInfoRequestMessage:
+		payload := new(KaspadMessage_GetAddressManagerInfoRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetAddressManagerInfoResponseMessage:
+		payload := new(KaspadMessage_GetAddressManagerInfoResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ReloadConfigRequestMessage:
+		payload := new(KaspadMessage_ReloadConfigRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.ReloadConfigResponseMessage:
+		payload := new(KaspadMessage_ReloadConfigResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.CaptureProfileRequestMessage:
+		payload := new(KaspadMessage_CaptureProfileRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.CaptureProfileResponseMessage:
+		payload := new(KaspadMessage_CaptureProfileResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.DebugLevelRequestMessage:
+		payload := new(KaspadMessage_DebugLevelRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.DebugLevelResponseMessage:
+		payload := new(KaspadMessage_DebugLevelResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetChainAnalysisRequestMessage:
+		payload := new(KaspadMessage_GetChainAnalysisRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetChainAnalysisResponseMessage:
+		payload := new(KaspadMessage_GetChainAnalysisResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.RescanRequestMessage:
+		payload := new(KaspadMessage_RescanRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.RescanResponseMessage:
+		payload := new(KaspadMessage_RescanResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.RescanProgressNotificationMessage:
+		payload := new(KaspadMessage_RescanProgressNotification)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.StopRescanRequestMessage:
+		payload := new(KaspadMessage_StopRescanRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.StopRescanResponseMessage:
+		payload := new(KaspadMessage_StopRescanResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.AddTransactionBlocklistEntriesRequestMessage:
+		payload := new(KaspadMessage_AddTransactionBlocklistEntriesRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.AddTransactionBlocklistEntriesResponseMessage:
+		payload := new(KaspadMessage_AddTransactionBlocklistEntriesResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.RemoveTransactionBlocklistEntriesRequestMessage:
+		payload := new(KaspadMessage_RemoveTransactionBlocklistEntriesRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.RemoveTransactionBlocklistEntriesResponseMessage:
+		payload := new(KaspadMessage_RemoveTransactionBlocklistEntriesResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetTransactionBlocklistRequestMessage:
+		payload := new(KaspadMessage_GetTransactionBlocklistRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetTransactionBlocklistResponseMessage:
+		payload := new(KaspadMessage_GetTransactionBlocklistResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetJobsRequestMessage:
+		payload := new(KaspadMessage_GetJobsRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.GetJobsResponseMessage:
+		payload := new(KaspadMessage_GetJobsResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.CancelJobRequestMessage:
+		payload := new(KaspadMessage_CancelJobRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.CancelJobResponseMessage:
+		payload := new(KaspadMessage_CancelJobResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyJobProgressRequestMessage:
+		payload := new(KaspadMessage_NotifyJobProgressRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.NotifyJobProgressResponseMessage:
+		payload := new(KaspadMessage_NotifyJobProgressResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.JobProgressNotificationMessage:
+		payload := new(KaspadMessage_JobProgressNotification)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.StopNotifyingJobProgressRequestMessage:
+		payload := new(KaspadMessage_StopNotifyingJobProgressRequest)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
+	case *appmessage.StopNotifyingJobProgressResponseMessage:
+		payload := new(KaspadMessage_StopNotifyingJobProgressResponse)
+		err := payload.fromAppMessage(message)
+		if err != nil {
+			return nil, err
+		}
+		return payload, nil
 	default:
 		return nil, nil
 	}
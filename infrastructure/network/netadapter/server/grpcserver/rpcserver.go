@@ -1,23 +1,57 @@
 package grpcserver
 
 import (
+	"context"
+	"crypto/tls"
+	"os"
+
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcauth"
 	"github.com/kaspanet/kaspad/util/panics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 type rpcServer struct {
 	protowire.UnimplementedRPCServer
 	gRPCServer
+	*serverCredentials
+
+	// isMutualTLS is true if clients must present a client certificate,
+	// which is then authenticated with the certificate credentials
+	isMutualTLS bool
 }
 
 // RPCMaxMessageSize is the max message size for the RPC server to send and receive
 const RPCMaxMessageSize = 1024 * 1024 * 1024 // 1 GB
 
-// NewRPCServer creates a new RPCServer
-func NewRPCServer(listeningAddresses []string, rpcMaxInboundConnections int) (server.Server, error) {
-	gRPCServer := newGRPCServer(listeningAddresses, RPCMaxMessageSize, rpcMaxInboundConnections, "RPC")
-	rpcServer := &rpcServer{gRPCServer: *gRPCServer}
+// NewRPCServer creates a new RPCServer.
+// If credentials is not nil, clients must authenticate with one of them,
+// and may only send the requests that their credential allows.
+// If unixSocketPath is not empty, the server also listens on a Unix domain
+// socket with the given permissions. Clients connecting through it don't
+// authenticate, since only users that may access the socket file can connect.
+// If tlsConfig is not nil, the server is served over TLS, and clients must
+// present a client certificate that certificateCredentials allows. Clients
+// that also authenticate with a password may only send the requests that
+// both of their credentials allow.
+func NewRPCServer(listeningAddresses []string, unixSocketPath string, unixSocketMode os.FileMode,
+	rpcMaxInboundConnections int, credentials *rpcauth.Credentials, tlsConfig *tls.Config,
+	certificateCredentials *rpcauth.CertificateCredentials) (server.AuthenticatingServer, error) {
+
+	var extraOptions []grpc.ServerOption
+	if tlsConfig != nil {
+		extraOptions = append(extraOptions, grpc.Creds(newUnixSocketAwareCredentials(tlsConfig)))
+	}
+	gRPCServer := newGRPCServer(listeningAddresses, RPCMaxMessageSize, rpcMaxInboundConnections, "RPC", extraOptions...)
+	gRPCServer.unixSocketPath = unixSocketPath
+	gRPCServer.unixSocketMode = unixSocketMode
+	rpcServer := &rpcServer{
+		gRPCServer:        *gRPCServer,
+		serverCredentials: newServerCredentials(credentials, certificateCredentials),
+		isMutualTLS:       tlsConfig != nil,
+	}
 	protowire.RegisterRPCServer(gRPCServer.server, rpcServer)
 	return rpcServer, nil
 }
@@ -25,5 +59,42 @@ func NewRPCServer(listeningAddresses []string, rpcMaxInboundConnections int) (se
 func (r *rpcServer) MessageStream(stream protowire.RPC_MessageStreamServer) error {
 	defer panics.HandlePanic(log, "rpcServer.MessageStream", nil)
 
-	return r.handleInboundConnection(stream.Context(), stream)
+	credential, err := r.authenticate(stream.Context())
+	if err != nil {
+		return err
+	}
+	// The header lets clients know that they were authenticated before
+	// they send their first request
+	err = stream.SendHeader(metadata.MD{})
+	if err != nil {
+		return err
+	}
+
+	return r.handleInboundConnection(stream.Context(), stream, credential)
+}
+
+// authenticate returns the credential of the client of the given call, or nil
+// if it may send all requests
+func (r *rpcServer) authenticate(ctx context.Context) (*rpcauth.Credential, error) {
+	if isUnixSocketClient(ctx) {
+		return nil, nil
+	}
+
+	credentials, certificateCredentials := r.get()
+	credential, err := authenticate(ctx, credentials)
+	if err != nil {
+		return nil, err
+	}
+	if !r.isMutualTLS {
+		return credential, nil
+	}
+
+	certificateCredential, err := authenticateCertificate(ctx, certificateCredentials)
+	if err != nil {
+		return nil, err
+	}
+	if credential == nil {
+		return certificateCredential, nil
+	}
+	return credential.Intersect(certificateCredential), nil
 }
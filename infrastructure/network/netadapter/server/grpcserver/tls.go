@@ -0,0 +1,59 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcauth"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// unixSocketAwareCredentials serves TLS on every connection except those
+// accepted on a Unix domain socket, which are already restricted by the
+// socket file's permissions
+type unixSocketAwareCredentials struct {
+	credentials.TransportCredentials
+}
+
+func newUnixSocketAwareCredentials(tlsConfig *tls.Config) credentials.TransportCredentials {
+	return &unixSocketAwareCredentials{TransportCredentials: credentials.NewTLS(tlsConfig)}
+}
+
+func (c *unixSocketAwareCredentials) ServerHandshake(rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	if _, ok := rawConn.LocalAddr().(*net.UnixAddr); ok {
+		return rawConn, nil, nil
+	}
+	return c.TransportCredentials.ServerHandshake(rawConn)
+}
+
+func (c *unixSocketAwareCredentials) Clone() credentials.TransportCredentials {
+	return &unixSocketAwareCredentials{TransportCredentials: c.TransportCredentials.Clone()}
+}
+
+// authenticateCertificate returns the credential of the client certificate
+// that the client of the given call presented during the TLS handshake
+func authenticateCertificate(ctx context.Context,
+	certificateCredentials *rpcauth.CertificateCredentials) (*rpcauth.Credential, error) {
+
+	peerInfo, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, errors.Errorf("Error getting stream peer info from context")
+	}
+	tlsInfo, ok := peerInfo.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		log.Warnf("Rejected an RPC client from %s: no verified client certificate", peerInfo.Addr)
+		return nil, status.Error(codes.Unauthenticated, "a client certificate is required")
+	}
+	commonName := tlsInfo.State.VerifiedChains[0][0].Subject.CommonName
+	credential, err := certificateCredentials.Authenticate(commonName)
+	if err != nil {
+		log.Warnf("Rejected an RPC client from %s with certificate %s: %s", peerInfo.Addr, commonName, err)
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return credential, nil
+}
@@ -1,10 +1,12 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"net"
 
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcauth"
 )
 
 // OnConnectedHandler is a function that is to be called
@@ -27,6 +29,33 @@ type Server interface {
 	SetOnConnectedHandler(onConnectedHandler OnConnectedHandler)
 }
 
+// AuthenticatingServer is a server whose clients authenticate with RPC
+// credentials. The credentials may be replaced while the server runs, in
+// which case they apply to the clients that authenticate afterwards.
+type AuthenticatingServer interface {
+	Server
+	SetCredentials(credentials *rpcauth.Credentials, certificateCredentials *rpcauth.CertificateCredentials)
+}
+
+// Dialer opens the outgoing connections of a P2PServer. It allows replacing
+// the transport peers are connected over, such as with a proxy or with an
+// in-memory transport in tests. *net.Dialer implements it.
+//
+// The remote address of the connections it opens must be a *net.TCPAddr,
+// since peers are told apart by their TCP addresses.
+type Dialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// ListenerFactory opens the listeners a P2PServer accepts incoming
+// connections on. *net.ListenConfig implements it.
+//
+// The remote address of the connections its listeners accept must be a
+// *net.TCPAddr, like that of the connections a Dialer opens.
+type ListenerFactory interface {
+	Listen(ctx context.Context, network, address string) (net.Listener, error)
+}
+
 // P2PServer represents a p2p server.
 type P2PServer interface {
 	Server
@@ -43,4 +72,15 @@ type Connection interface {
 	SetOnDisconnectedHandler(onDisconnectedHandler OnDisconnectedHandler)
 	SetOnInvalidMessageHandler(onInvalidMessageHandler OnInvalidMessageHandler)
 	Address() *net.TCPAddr
+	MessageStatistics() []*MessageStatistics
+}
+
+// MessageStatistics counts the messages of a single command that were sent
+// and received on a connection, and their serialized sizes
+type MessageStatistics struct {
+	Command          string
+	MessagesSent     uint64
+	BytesSent        uint64
+	MessagesReceived uint64
+	BytesReceived    uint64
 }
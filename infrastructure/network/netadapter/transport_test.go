@@ -0,0 +1,199 @@
+package netadapter
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+// memoryNetwork is an in-memory transport that implements both server.Dialer
+// and server.ListenerFactory. Its connections never touch the network.
+type memoryNetwork struct {
+	lock          sync.Mutex
+	listeners     map[string]*memoryListener
+	nextLocalPort int
+}
+
+func newMemoryNetwork() *memoryNetwork {
+	return &memoryNetwork{listeners: make(map[string]*memoryListener), nextLocalPort: 40000}
+}
+
+func (n *memoryNetwork) Listen(_ context.Context, _, address string) (net.Listener, error) {
+	tcpAddress, err := net.ResolveTCPAddr("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if _, ok := n.listeners[address]; ok {
+		return nil, errors.Errorf("%s is already listened on", address)
+	}
+	listener := &memoryListener{
+		network:     n,
+		address:     tcpAddress,
+		connections: make(chan net.Conn),
+		closed:      make(chan struct{}),
+	}
+	n.listeners[address] = listener
+	return listener, nil
+}
+
+func (n *memoryNetwork) DialContext(ctx context.Context, _, address string) (net.Conn, error) {
+	n.lock.Lock()
+	listener, ok := n.listeners[address]
+	localAddress := &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: n.nextLocalPort}
+	n.nextLocalPort++
+	n.lock.Unlock()
+	if !ok {
+		return nil, errors.Errorf("nothing listens on %s", address)
+	}
+
+	clientSide, serverSide := net.Pipe()
+	select {
+	case listener.connections <- &memoryConnection{Conn: serverSide, localAddress: listener.address, remoteAddress: localAddress}:
+		return &memoryConnection{Conn: clientSide, localAddress: localAddress, remoteAddress: listener.address}, nil
+	case <-listener.closed:
+		return nil, errors.Errorf("the listener of %s is closed", address)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+type memoryListener struct {
+	network     *memoryNetwork
+	address     *net.TCPAddr
+	connections chan net.Conn
+	closed      chan struct{}
+	closeOnce   sync.Once
+}
+
+func (l *memoryListener) Accept() (net.Conn, error) {
+	select {
+	case connection := <-l.connections:
+		return connection, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *memoryListener) Close() error {
+	l.closeOnce.Do(func() {
+		close(l.closed)
+		l.network.lock.Lock()
+		delete(l.network.listeners, l.address.String())
+		l.network.lock.Unlock()
+	})
+	return nil
+}
+
+func (l *memoryListener) Addr() net.Addr {
+	return l.address
+}
+
+// memoryConnection reports TCP addresses, since peers are told apart by them
+type memoryConnection struct {
+	net.Conn
+	localAddress  *net.TCPAddr
+	remoteAddress *net.TCPAddr
+}
+
+func (c *memoryConnection) LocalAddr() net.Addr {
+	return c.localAddress
+}
+
+func (c *memoryConnection) RemoteAddr() net.Addr {
+	return c.remoteAddress
+}
+
+func TestNetAdapterWithTransport(t *testing.T) {
+	const timeout = time.Second * 5
+
+	network := newMemoryNetwork()
+
+	// These addresses are never listened on by the OS
+	addressA := "10.11.12.1:16111"
+	addressB := "10.11.12.2:16111"
+	cfgA, cfgB := config.DefaultConfig(), config.DefaultConfig()
+	cfgA.Listeners = []string{addressA}
+	cfgB.Listeners = []string{addressB}
+	cfgA.RPCListeners = []string{"127.0.0.1:0"}
+	cfgB.RPCListeners = []string{"127.0.0.1:0"}
+
+	adapterA, err := NewNetAdapterWithTransport(cfgA, network, network)
+	if err != nil {
+		t.Fatalf("NetAdapter instantiation failed: %+v", err)
+	}
+	adapterA.SetP2PRouterInitializer(func(router *router.Router, connection *NetConnection) {})
+	adapterA.SetRPCRouterInitializer(func(router *router.Router, connection *NetConnection) {})
+	err = adapterA.Start()
+	if err != nil {
+		t.Fatalf("Start() failed: %+v", err)
+	}
+	defer adapterA.Stop()
+
+	routeChan := make(chan *router.Route, 1)
+	adapterB, err := NewNetAdapterWithTransport(cfgB, network, network)
+	if err != nil {
+		t.Fatalf("NetAdapter instantiation failed: %+v", err)
+	}
+	adapterB.SetP2PRouterInitializer(func(router *router.Router, connection *NetConnection) {
+		route, err := router.AddIncomingRoute("B", []appmessage.MessageCommand{appmessage.CmdPing})
+		if err != nil {
+			t.Errorf("AddIncomingRoute failed: %+v", err)
+			return
+		}
+		routeChan <- route
+	})
+	adapterB.SetRPCRouterInitializer(func(router *router.Router, connection *NetConnection) {})
+	err = adapterB.Start()
+	if err != nil {
+		t.Fatalf("Start() failed: %+v", err)
+	}
+	defer adapterB.Stop()
+
+	err = adapterA.P2PConnect(addressB)
+	if err != nil {
+		t.Fatalf("Connection to %s failed: %+v", addressB, err)
+	}
+	connections := adapterA.P2PConnections()
+	if len(connections) != 1 {
+		t.Fatalf("Expected 1 connection, got %d", len(connections))
+	}
+	if connections[0].Address() != addressB {
+		t.Fatalf("Unexpected connection address. Want: %s, got: %s", addressB, connections[0].Address())
+	}
+
+	err = adapterA.P2PBroadcast(connections, appmessage.NewMsgPing(1))
+	if err != nil {
+		t.Fatalf("Broadcast failed: %+v", err)
+	}
+	var route *router.Route
+	select {
+	case route = <-routeChan:
+	case <-time.After(timeout):
+		t.Fatalf("Timed out waiting for the incoming connection")
+	}
+	message, err := route.DequeueWithTimeout(timeout)
+	if err != nil {
+		t.Fatalf("Dequeuing message failed: %+v", err)
+	}
+	if message.Command() != appmessage.CmdPing {
+		t.Fatalf("Expected a ping, but got %s", message.Command())
+	}
+
+	// Nothing listens on the address in the in-memory network,
+	// even though the address is valid
+	err = adapterA.P2PConnect("10.11.12.3:16111")
+	if err == nil {
+		t.Fatalf("Connecting to an address nothing listens on unexpectedly succeeded")
+	}
+}
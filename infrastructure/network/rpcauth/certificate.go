@@ -0,0 +1,252 @@
+package rpcauth
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+// CertificateCredentials maps the common names of RPC client certificates,
+// as configured with --rpccertauth, to their credentials
+type CertificateCredentials struct {
+	byCommonName map[string]*Credential
+}
+
+// ParseCertificateCredential parses an --rpccertauth value of the form
+// <common name>[:<method>,<method>,...]
+// The methods are given as in ParseCredential. The credential's user is the
+// common name.
+func ParseCertificateCredential(rpcCertAuth string) (*Credential, error) {
+	parts := strings.Split(rpcCertAuth, ":")
+	if len(parts) > 2 {
+		return nil, errors.Errorf("rpccertauth %s is not of the form <common name>[:<methods>]", rpcCertAuth)
+	}
+	commonName := parts[0]
+	if commonName == "" {
+		return nil, errors.Errorf("rpccertauth %s has an empty common name", rpcCertAuth)
+	}
+
+	credential := &Credential{User: commonName}
+	if len(parts) == 2 {
+		var err error
+		credential.allowedMethods, err = parseMethods(parts[1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid methods for rpccertauth common name %s", commonName)
+		}
+	}
+	return credential, nil
+}
+
+// NewCertificateCredentials parses the given --rpccertauth values. It returns
+// nil if no values are given, in which case every client certificate that was
+// verified against the client CA may call all methods.
+func NewCertificateCredentials(rpcCertAuths []string) (*CertificateCredentials, error) {
+	if len(rpcCertAuths) == 0 {
+		return nil, nil
+	}
+	credentials := &CertificateCredentials{byCommonName: make(map[string]*Credential, len(rpcCertAuths))}
+	for _, rpcCertAuth := range rpcCertAuths {
+		credential, err := ParseCertificateCredential(rpcCertAuth)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := credentials.byCommonName[credential.User]; ok {
+			return nil, errors.Errorf("rpccertauth common name %s is specified more than once", credential.User)
+		}
+		credentials.byCommonName[credential.User] = credential
+	}
+	return credentials, nil
+}
+
+// Authenticate returns the credential of the given client certificate
+// common name, or ErrUnauthenticated if it has none. The certificate itself
+// must already be verified. If c is nil, all methods are allowed.
+func (c *CertificateCredentials) Authenticate(commonName string) (*Credential, error) {
+	if c == nil {
+		return &Credential{User: commonName}, nil
+	}
+	credential, ok := c.byCommonName[commonName]
+	if !ok {
+		return nil, errors.WithStack(ErrUnauthenticated)
+	}
+	return credential, nil
+}
+
+// Intersect returns a credential of c's user that only allows the methods
+// that both c and other allow
+func (c *Credential) Intersect(other *Credential) *Credential {
+	intersection := &Credential{User: c.User}
+	switch {
+	case c.allowedMethods == nil:
+		intersection.allowedMethods = other.allowedMethods
+	case other.allowedMethods == nil:
+		intersection.allowedMethods = c.allowedMethods
+	default:
+		intersection.allowedMethods = make(map[string]struct{})
+		for method := range c.allowedMethods {
+			if _, ok := other.allowedMethods[method]; ok {
+				intersection.allowedMethods[method] = struct{}{}
+			}
+		}
+	}
+	return intersection
+}
+
+// ServerTLSConfig returns the TLS configuration of an RPC server that
+// presents the given certificate, and requires clients to present a
+// certificate signed by one of the CAs in clientCAFile
+func ServerTLSConfig(certFile string, keyFile string, clientCAFile string) (*tls.Config, error) {
+	loaded, err := loadServerTLSConfig(certFile, keyFile, clientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	return loaded.config, nil
+}
+
+// LoadedServerTLSConfig is a server TLS configuration that was loaded from
+// files by ReloadableServerTLSConfig.Load
+type LoadedServerTLSConfig struct {
+	config *tls.Config
+
+	// fingerprint is the hash of the contents of the files the
+	// configuration was loaded from
+	fingerprint [sha256.Size]byte
+}
+
+func loadServerTLSConfig(certFile string, keyFile string, clientCAFile string) (*LoadedServerTLSConfig, error) {
+	certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error loading the RPC certificate %s", certFile)
+	}
+	pemClientCAs, err := ioutil.ReadFile(clientCAFile)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading %s", clientCAFile)
+	}
+	clientCAs, err := parseCertificatePool(clientCAFile, pemClientCAs)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.New()
+	for _, certificateBytes := range certificate.Certificate {
+		hash.Write(certificateBytes)
+	}
+	hash.Write(pemClientCAs)
+	loaded := &LoadedServerTLSConfig{
+		config: &tls.Config{
+			Certificates: []tls.Certificate{certificate},
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			ClientCAs:    clientCAs,
+			MinVersion:   tls.VersionTLS12,
+		},
+	}
+	copy(loaded.fingerprint[:], hash.Sum(nil))
+	return loaded, nil
+}
+
+// ReloadableServerTLSConfig is the TLS configuration of an RPC server, as
+// returned by ServerTLSConfig, whose certificate and client CAs can be
+// loaded again from their files while the server runs. That way certificates
+// can be rotated without restarting the server. Connections that were
+// already established keep the certificates they were established with.
+type ReloadableServerTLSConfig struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+
+	current atomic.Value // *LoadedServerTLSConfig
+}
+
+// NewReloadableServerTLSConfig loads the TLS configuration of an RPC server
+// from the given files, like ServerTLSConfig does
+func NewReloadableServerTLSConfig(certFile string, keyFile string,
+	clientCAFile string) (*ReloadableServerTLSConfig, error) {
+
+	loaded, err := loadServerTLSConfig(certFile, keyFile, clientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	reloadable := &ReloadableServerTLSConfig{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+	}
+	reloadable.current.Store(loaded)
+	return reloadable, nil
+}
+
+// TLSConfig returns a TLS configuration that uses the configuration that
+// was loaded last for every new connection
+func (c *ReloadableServerTLSConfig) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			config := c.current.Load().(*LoadedServerTLSConfig).config.Clone()
+			// The configuration returned here replaces the one gRPC
+			// adds its application protocol to
+			config.NextProtos = []string{"h2"}
+			return config, nil
+		},
+	}
+}
+
+// Load loads the certificate, its key and the client CAs from their files
+// again, and returns the configuration they make up without using it yet.
+// It also returns whether the files changed since the configuration in use
+// was loaded.
+func (c *ReloadableServerTLSConfig) Load() (loaded *LoadedServerTLSConfig, isChanged bool, err error) {
+	loaded, err = loadServerTLSConfig(c.certFile, c.keyFile, c.clientCAFile)
+	if err != nil {
+		return nil, false, err
+	}
+	return loaded, loaded.fingerprint != c.current.Load().(*LoadedServerTLSConfig).fingerprint, nil
+}
+
+// Use makes new connections use the given configuration, as returned by Load
+func (c *ReloadableServerTLSConfig) Use(loaded *LoadedServerTLSConfig) {
+	c.current.Store(loaded)
+}
+
+// ClientTLSConfig returns the TLS configuration of an RPC client that trusts
+// the server certificates signed by one of the CAs in serverCAFile, and
+// presents the given certificate. If certFile is empty, the client presents
+// no certificate.
+func ClientTLSConfig(serverCAFile string, certFile string, keyFile string) (*tls.Config, error) {
+	rootCAs, err := loadCertificatePool(serverCAFile)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{
+		RootCAs:    rootCAs,
+		MinVersion: tls.VersionTLS12,
+	}
+	if certFile != "" {
+		certificate, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error loading the client certificate %s", certFile)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return tlsConfig, nil
+}
+
+func loadCertificatePool(file string) (*x509.CertPool, error) {
+	pemCertificates, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading %s", file)
+	}
+	return parseCertificatePool(file, pemCertificates)
+}
+
+func parseCertificatePool(file string, pemCertificates []byte) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pemCertificates) {
+		return nil, errors.Errorf("%s contains no PEM encoded certificates", file)
+	}
+	return pool, nil
+}
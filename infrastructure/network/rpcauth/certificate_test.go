@@ -0,0 +1,168 @@
+package rpcauth
+
+import (
+	"bytes"
+	"crypto/tls"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func TestCertificateCredentials(t *testing.T) {
+	credentials, err := NewCertificateCredentials([]string{"admin", "explorer:getBlock,getInfo"})
+	if err != nil {
+		t.Fatalf("NewCertificateCredentials: %s", err)
+	}
+
+	adminCredential, err := credentials.Authenticate("admin")
+	if err != nil {
+		t.Fatalf("Authenticate: %s", err)
+	}
+	if !adminCredential.IsCommandAllowed(appmessage.CmdShutDownRequestMessage) {
+		t.Fatalf("Expected admin to be allowed to call all methods")
+	}
+	explorerCredential, err := credentials.Authenticate("explorer")
+	if err != nil {
+		t.Fatalf("Authenticate: %s", err)
+	}
+	if explorerCredential.IsCommandAllowed(appmessage.CmdSubmitBlockRequestMessage) {
+		t.Fatalf("Expected explorer not to be allowed to call submitBlock")
+	}
+	_, err = credentials.Authenticate("stranger")
+	if !errors.Is(err, ErrUnauthenticated) {
+		t.Fatalf("Expected ErrUnauthenticated for an unlisted common name, but got: %v", err)
+	}
+
+	var noCredentials *CertificateCredentials
+	anyCredential, err := noCredentials.Authenticate("anyone")
+	if err != nil {
+		t.Fatalf("Authenticate: %s", err)
+	}
+	if anyCredential.User != "anyone" || !anyCredential.IsCommandAllowed(appmessage.CmdShutDownRequestMessage) {
+		t.Fatalf("Expected any common name to be allowed to call all methods without rpccertauth")
+	}
+
+	for _, rpcCertAuth := range []string{"", ":getBlock", "explorer:getBlok", "explorer:getBlock:getInfo"} {
+		_, err := ParseCertificateCredential(rpcCertAuth)
+		if err == nil {
+			t.Errorf("Expected ParseCertificateCredential(%s) to fail", rpcCertAuth)
+		}
+	}
+	_, err = NewCertificateCredentials([]string{"explorer", "explorer:getInfo"})
+	if err == nil {
+		t.Errorf("Expected NewCertificateCredentials to fail for duplicate common names")
+	}
+}
+
+func TestCredentialIntersect(t *testing.T) {
+	all := &Credential{User: "all"}
+	blocks, err := ParseCertificateCredential("blocks:getBlock,getBlocks")
+	if err != nil {
+		t.Fatalf("ParseCertificateCredential: %s", err)
+	}
+	info, err := ParseCertificateCredential("info:getBlock,getInfo")
+	if err != nil {
+		t.Fatalf("ParseCertificateCredential: %s", err)
+	}
+
+	if intersection := all.Intersect(blocks); !intersection.IsCommandAllowed(appmessage.CmdGetBlocksRequestMessage) ||
+		intersection.IsCommandAllowed(appmessage.CmdGetInfoRequestMessage) || intersection.User != "all" {
+
+		t.Fatalf("Expected the intersection with all methods to allow exactly the other credential's methods")
+	}
+	intersection := blocks.Intersect(info)
+	if !intersection.IsCommandAllowed(appmessage.CmdGetBlockRequestMessage) ||
+		intersection.IsCommandAllowed(appmessage.CmdGetBlocksRequestMessage) ||
+		intersection.IsCommandAllowed(appmessage.CmdGetInfoRequestMessage) {
+
+		t.Fatalf("Expected the intersection to only allow the methods both credentials allow")
+	}
+}
+
+func TestReloadableServerTLSConfig(t *testing.T) {
+	directory := t.TempDir()
+	certFile := filepath.Join(directory, "rpc.cert")
+	keyFile := filepath.Join(directory, "rpc.key")
+	clientCAFile := filepath.Join(directory, "ca.cert")
+	writeCertificate := func(certificateFile string, keyFile string) []byte {
+		certificatePEM, keyPEM, err := GenerateCertificate(&CertificateOptions{
+			CommonName: "node",
+			ValidFor:   time.Hour,
+			KeyType:    KeyTypeECDSAP256,
+		}, nil)
+		if err != nil {
+			t.Fatalf("GenerateCertificate: %s", err)
+		}
+		err = ioutil.WriteFile(certificateFile, certificatePEM, 0600)
+		if err != nil {
+			t.Fatalf("WriteFile: %s", err)
+		}
+		err = ioutil.WriteFile(keyFile, keyPEM, 0600)
+		if err != nil {
+			t.Fatalf("WriteFile: %s", err)
+		}
+		keyPair, err := tls.X509KeyPair(certificatePEM, keyPEM)
+		if err != nil {
+			t.Fatalf("X509KeyPair: %s", err)
+		}
+		return keyPair.Certificate[0]
+	}
+	presentedCertificate := func(tlsConfig *tls.Config) []byte {
+		config, err := tlsConfig.GetConfigForClient(&tls.ClientHelloInfo{})
+		if err != nil {
+			t.Fatalf("GetConfigForClient: %s", err)
+		}
+		return config.Certificates[0].Certificate[0]
+	}
+
+	firstCertificate := writeCertificate(certFile, keyFile)
+	writeCertificate(clientCAFile, filepath.Join(directory, "ca.key"))
+	reloadable, err := NewReloadableServerTLSConfig(certFile, keyFile, clientCAFile)
+	if err != nil {
+		t.Fatalf("NewReloadableServerTLSConfig: %s", err)
+	}
+	tlsConfig := reloadable.TLSConfig()
+	if !bytes.Equal(presentedCertificate(tlsConfig), firstCertificate) {
+		t.Fatalf("Unexpected certificate")
+	}
+	_, isChanged, err := reloadable.Load()
+	if err != nil {
+		t.Fatalf("Load: %s", err)
+	}
+	if isChanged {
+		t.Fatalf("Expected the configuration not to change while its files didn't")
+	}
+
+	secondCertificate := writeCertificate(certFile, keyFile)
+	loaded, isChanged, err := reloadable.Load()
+	if err != nil {
+		t.Fatalf("Load: %s", err)
+	}
+	if !isChanged {
+		t.Fatalf("Expected the configuration to change with its certificate")
+	}
+	// The loaded configuration isn't used until Use is called
+	if !bytes.Equal(presentedCertificate(tlsConfig), firstCertificate) {
+		t.Fatalf("Expected the first certificate before Use")
+	}
+	reloadable.Use(loaded)
+	if !bytes.Equal(presentedCertificate(tlsConfig), secondCertificate) {
+		t.Fatalf("Expected the second certificate after Use")
+	}
+
+	err = ioutil.WriteFile(keyFile, []byte("not a key"), 0600)
+	if err != nil {
+		t.Fatalf("WriteFile: %s", err)
+	}
+	_, _, err = reloadable.Load()
+	if err == nil {
+		t.Fatalf("Expected loading an invalid key to fail")
+	}
+	if !bytes.Equal(presentedCertificate(tlsConfig), secondCertificate) {
+		t.Fatalf("Expected a failed load to keep the second certificate")
+	}
+}
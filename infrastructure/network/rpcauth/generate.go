@@ -0,0 +1,169 @@
+package rpcauth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// The types of keys GenerateCertificate can generate
+const (
+	KeyTypeECDSAP256 = "ecdsa-p256"
+	KeyTypeECDSAP384 = "ecdsa-p384"
+	KeyTypeEd25519   = "ed25519"
+	KeyTypeRSA2048   = "rsa-2048"
+	KeyTypeRSA4096   = "rsa-4096"
+)
+
+// KeyTypes are the types of keys GenerateCertificate can generate
+var KeyTypes = []string{KeyTypeECDSAP256, KeyTypeECDSAP384, KeyTypeEd25519, KeyTypeRSA2048, KeyTypeRSA4096}
+
+// certificateClockSkew is how long before its generation a certificate
+// becomes valid, so that it's valid right away on hosts whose clocks are
+// slightly behind
+const certificateClockSkew = time.Hour
+
+// CertificateOptions are the properties of a certificate that
+// GenerateCertificate generates
+type CertificateOptions struct {
+	// CommonName is the common name of the certificate's subject. RPC
+	// clients that present the certificate are authorized by --rpccertauth
+	// according to it.
+	CommonName   string
+	Organization string
+
+	// Hosts are the DNS names and IP addresses the certificate is valid for
+	Hosts []string
+
+	ValidFor time.Duration
+
+	// KeyType is one of KeyTypes
+	KeyType string
+
+	// IsCA is whether the certificate may sign other certificates.
+	// Self-signed certificates always may, so that they can be trusted as
+	// their own CA.
+	IsCA bool
+}
+
+// GenerateCertificate generates a key and a certificate for it with the
+// given options, and returns both PEM encoded. The certificate is signed by
+// parent, which must be a CA certificate, or is self-signed if parent is
+// nil. The certificate may be used both by RPC servers and by RPC clients.
+func GenerateCertificate(options *CertificateOptions, parent *tls.Certificate) (
+	certificatePEM []byte, keyPEM []byte, err error) {
+
+	if options.ValidFor <= 0 {
+		return nil, nil, errors.Errorf("the validity of a certificate must be positive, but got %s",
+			options.ValidFor)
+	}
+	key, publicKey, err := generateKey(options.KeyType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Serial numbers must be unique per CA, so they're random 128 bit
+	// numbers
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			CommonName: options.CommonName,
+		},
+		NotBefore:             now.Add(-certificateClockSkew),
+		NotAfter:              now.Add(options.ValidFor),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  options.IsCA || parent == nil,
+	}
+	if options.Organization != "" {
+		template.Subject.Organization = []string{options.Organization}
+	}
+	if _, ok := publicKey.(*rsa.PublicKey); ok {
+		template.KeyUsage |= x509.KeyUsageKeyEncipherment
+	}
+	if template.IsCA {
+		template.KeyUsage |= x509.KeyUsageCertSign
+	}
+	for _, host := range options.Hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	parentCertificate, parentKey := template, key
+	if parent != nil {
+		if len(parent.Certificate) == 0 {
+			return nil, nil, errors.New("the signing certificate is empty")
+		}
+		parentCertificate, err = x509.ParseCertificate(parent.Certificate[0])
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "error parsing the signing certificate")
+		}
+		if !parentCertificate.IsCA {
+			return nil, nil, errors.Errorf("the signing certificate %s isn't a CA certificate",
+				parentCertificate.Subject.CommonName)
+		}
+		var ok bool
+		parentKey, ok = parent.PrivateKey.(crypto.Signer)
+		if !ok {
+			return nil, nil, errors.New("the key of the signing certificate can't sign")
+		}
+		if template.NotAfter.After(parentCertificate.NotAfter) {
+			return nil, nil, errors.Errorf("the certificate would outlive the signing certificate, "+
+				"which expires at %s", parentCertificate.NotAfter)
+		}
+	}
+
+	certificateBytes, err := x509.CreateCertificate(rand.Reader, template, parentCertificate, publicKey, parentKey)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error creating the certificate")
+	}
+	keyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error encoding the key")
+	}
+	certificatePEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificateBytes})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyBytes})
+	return certificatePEM, keyPEM, nil
+}
+
+func generateKey(keyType string) (key crypto.Signer, publicKey crypto.PublicKey, err error) {
+	switch keyType {
+	case KeyTypeECDSAP256:
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case KeyTypeECDSAP384:
+		key, err = ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case KeyTypeEd25519:
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	case KeyTypeRSA2048:
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	case KeyTypeRSA4096:
+		key, err = rsa.GenerateKey(rand.Reader, 4096)
+	default:
+		return nil, nil, errors.Errorf("unknown key type %q. The key type must be one of %s", keyType, KeyTypes)
+	}
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "error generating a %s key", keyType)
+	}
+	return key, key.Public(), nil
+}
@@ -0,0 +1,123 @@
+package rpcauth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestGenerateCertificate(t *testing.T) {
+	for _, keyType := range KeyTypes {
+		// 4096 bit RSA keys take long to generate, and are generated
+		// just like 2048 bit ones
+		if keyType == KeyTypeRSA4096 {
+			continue
+		}
+		options := &CertificateOptions{
+			CommonName:   "node",
+			Organization: "kaspad",
+			Hosts:        []string{"localhost", "node.example.com", "127.0.0.1", "::1"},
+			ValidFor:     24 * time.Hour,
+			KeyType:      keyType,
+		}
+		certificatePEM, keyPEM, err := GenerateCertificate(options, nil)
+		if err != nil {
+			t.Fatalf("%s: GenerateCertificate: %s", keyType, err)
+		}
+		keyPair, err := tls.X509KeyPair(certificatePEM, keyPEM)
+		if err != nil {
+			t.Fatalf("%s: X509KeyPair: %s", keyType, err)
+		}
+		certificate, err := x509.ParseCertificate(keyPair.Certificate[0])
+		if err != nil {
+			t.Fatalf("%s: ParseCertificate: %s", keyType, err)
+		}
+		if certificate.Subject.CommonName != "node" || len(certificate.DNSNames) != 2 ||
+			len(certificate.IPAddresses) != 2 || !certificate.IPAddresses[1].Equal(net.IPv6loopback) {
+			t.Fatalf("%s: unexpected subject %s, DNS names %s or IP addresses %s", keyType,
+				certificate.Subject, certificate.DNSNames, certificate.IPAddresses)
+		}
+		if !certificate.IsCA {
+			t.Fatalf("%s: expected a self-signed certificate to be a CA", keyType)
+		}
+		if validFor := certificate.NotAfter.Sub(time.Now()); validFor > options.ValidFor || validFor < options.ValidFor-time.Minute {
+			t.Fatalf("%s: expected the certificate to be valid for %s, but it's valid for %s",
+				keyType, options.ValidFor, validFor)
+		}
+
+		// The self-signed certificate is trusted as its own CA
+		roots := x509.NewCertPool()
+		roots.AddCert(certificate)
+		_, err = certificate.Verify(x509.VerifyOptions{DNSName: "node.example.com", Roots: roots})
+		if err != nil {
+			t.Fatalf("%s: Verify: %s", keyType, err)
+		}
+	}
+}
+
+func TestGenerateSignedCertificate(t *testing.T) {
+	caCertificatePEM, caKeyPEM, err := GenerateCertificate(&CertificateOptions{
+		CommonName: "ca",
+		ValidFor:   48 * time.Hour,
+		KeyType:    KeyTypeECDSAP384,
+		IsCA:       true,
+	}, nil)
+	if err != nil {
+		t.Fatalf("GenerateCertificate: %s", err)
+	}
+	caKeyPair, err := tls.X509KeyPair(caCertificatePEM, caKeyPEM)
+	if err != nil {
+		t.Fatalf("X509KeyPair: %s", err)
+	}
+
+	clientOptions := &CertificateOptions{CommonName: "explorer", ValidFor: 24 * time.Hour, KeyType: KeyTypeEd25519}
+	clientCertificatePEM, clientKeyPEM, err := GenerateCertificate(clientOptions, &caKeyPair)
+	if err != nil {
+		t.Fatalf("GenerateCertificate: %s", err)
+	}
+	clientKeyPair, err := tls.X509KeyPair(clientCertificatePEM, clientKeyPEM)
+	if err != nil {
+		t.Fatalf("X509KeyPair: %s", err)
+	}
+	clientCertificate, err := x509.ParseCertificate(clientKeyPair.Certificate[0])
+	if err != nil {
+		t.Fatalf("ParseCertificate: %s", err)
+	}
+	if clientCertificate.IsCA {
+		t.Fatalf("Expected a signed certificate not to be a CA")
+	}
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(caCertificatePEM)
+	_, err = clientCertificate.Verify(x509.VerifyOptions{
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		t.Fatalf("Verify: %s", err)
+	}
+
+	// A certificate that isn't a CA can't sign, and a signed certificate
+	// can't outlive its CA
+	_, _, err = GenerateCertificate(clientOptions, &clientKeyPair)
+	if err == nil {
+		t.Fatalf("Expected signing with a certificate that isn't a CA to fail")
+	}
+	_, _, err = GenerateCertificate(&CertificateOptions{CommonName: "admin", ValidFor: 72 * time.Hour,
+		KeyType: KeyTypeECDSAP256}, &caKeyPair)
+	if err == nil {
+		t.Fatalf("Expected a certificate that outlives its CA to fail")
+	}
+}
+
+func TestGenerateCertificateErrors(t *testing.T) {
+	_, _, err := GenerateCertificate(&CertificateOptions{ValidFor: time.Hour, KeyType: "dsa"}, nil)
+	if err == nil {
+		t.Fatalf("Expected an unknown key type to fail")
+	}
+	_, _, err = GenerateCertificate(&CertificateOptions{KeyType: KeyTypeECDSAP256}, nil)
+	if err == nil {
+		t.Fatalf("Expected a certificate without a validity to fail")
+	}
+}
@@ -0,0 +1,226 @@
+package rpcauth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+// AuthorizationMetadataKey is the gRPC metadata key that carries the
+// credentials of an RPC client, in the form of an HTTP Basic authorization value
+const AuthorizationMetadataKey = "authorization"
+
+const (
+	basicAuthorizationPrefix = "Basic "
+	allMethods               = "*"
+	saltLength               = 16
+)
+
+// ErrUnauthenticated is returned when a client's credentials don't match any
+// of the configured credentials
+var ErrUnauthenticated = errors.New("invalid or missing RPC credentials")
+
+// Credential is a single RPC user, as configured with --rpcauth.
+// Only the salted hash of its password is kept.
+type Credential struct {
+	User string
+
+	salt string
+	hash []byte
+
+	// allowedMethods is nil if all methods are allowed
+	allowedMethods map[string]struct{}
+}
+
+// ParseCredential parses an --rpcauth value of the form
+// <user>:<salt>$<hash>[:<method>,<method>,...]
+// where hash is the hex encoded HMAC-SHA256 of the password, keyed with the
+// salt. If no methods are given, or if one of them is "*", all methods are allowed.
+// Method names are the names of the RPC requests without their "Request"
+// suffix, such as getBlock, and are case-insensitive.
+func ParseCredential(rpcAuth string) (*Credential, error) {
+	parts := strings.Split(rpcAuth, ":")
+	if len(parts) != 2 && len(parts) != 3 {
+		return nil, errors.Errorf("rpcauth %s is not of the form <user>:<salt>$<hash>[:<methods>]", rpcAuth)
+	}
+	user := parts[0]
+	if user == "" {
+		return nil, errors.Errorf("rpcauth %s has an empty user", rpcAuth)
+	}
+
+	saltAndHash := strings.Split(parts[1], "$")
+	if len(saltAndHash) != 2 || saltAndHash[0] == "" {
+		return nil, errors.Errorf("the password of rpcauth user %s is not of the form <salt>$<hash>", user)
+	}
+	hash, err := hex.DecodeString(saltAndHash[1])
+	if err != nil || len(hash) != sha256.Size {
+		return nil, errors.Errorf("the password hash of rpcauth user %s is not a hex encoded SHA256 hash", user)
+	}
+
+	credential := &Credential{
+		User: user,
+		salt: saltAndHash[0],
+		hash: hash,
+	}
+	if len(parts) == 3 {
+		credential.allowedMethods, err = parseMethods(parts[2])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid methods for rpcauth user %s", user)
+		}
+	}
+	return credential, nil
+}
+
+func parseMethods(methodsString string) (map[string]struct{}, error) {
+	knownMethods := methodNames()
+	methods := make(map[string]struct{})
+	for _, method := range strings.Split(methodsString, ",") {
+		method = strings.ToLower(strings.TrimSpace(method))
+		if method == allMethods {
+			return nil, nil
+		}
+		if _, ok := knownMethods[method]; !ok {
+			return nil, errors.Errorf("unknown method '%s'", method)
+		}
+		methods[method] = struct{}{}
+	}
+	return methods, nil
+}
+
+// methodNames returns the normalized names of all RPC requests
+func methodNames() map[string]struct{} {
+	names := make(map[string]struct{})
+	for _, commandString := range appmessage.RPCMessageCommandToString {
+		if strings.HasSuffix(commandString, "Request") {
+			names[strings.ToLower(strings.TrimSuffix(commandString, "Request"))] = struct{}{}
+		}
+	}
+	return names
+}
+
+// MethodName returns the name under which the given RPC request command is
+// allowed in --rpcauth, or an empty string if the command isn't an RPC request
+func MethodName(command appmessage.MessageCommand) string {
+	commandString, ok := appmessage.RPCMessageCommandToString[command]
+	if !ok || !strings.HasSuffix(commandString, "Request") {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSuffix(commandString, "Request"))
+}
+
+// IsCommandAllowed returns whether the credential allows sending requests
+// of the given command
+func (c *Credential) IsCommandAllowed(command appmessage.MessageCommand) bool {
+	method := MethodName(command)
+	if method == "" {
+		return false
+	}
+	if c.allowedMethods == nil {
+		return true
+	}
+	_, ok := c.allowedMethods[method]
+	return ok
+}
+
+func (c *Credential) matches(password string) bool {
+	return subtle.ConstantTimeCompare(hashPassword(c.salt, password), c.hash) == 1
+}
+
+func hashPassword(salt string, password string) []byte {
+	mac := hmac.New(sha256.New, []byte(salt))
+	mac.Write([]byte(password))
+	return mac.Sum(nil)
+}
+
+// Credentials is the set of credentials that are allowed to use the RPC server
+type Credentials struct {
+	byUser map[string]*Credential
+}
+
+// NewCredentials parses the given --rpcauth values. It returns nil if no values
+// are given, in which case the RPC server doesn't require authentication.
+func NewCredentials(rpcAuths []string) (*Credentials, error) {
+	if len(rpcAuths) == 0 {
+		return nil, nil
+	}
+	credentials := &Credentials{byUser: make(map[string]*Credential, len(rpcAuths))}
+	for _, rpcAuth := range rpcAuths {
+		credential, err := ParseCredential(rpcAuth)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := credentials.byUser[credential.User]; ok {
+			return nil, errors.Errorf("rpcauth user %s is specified more than once", credential.User)
+		}
+		credentials.byUser[credential.User] = credential
+	}
+	return credentials, nil
+}
+
+// Authenticate returns the credential of the given user if the given password
+// matches it, or ErrUnauthenticated otherwise
+func (c *Credentials) Authenticate(user string, password string) (*Credential, error) {
+	credential, ok := c.byUser[user]
+	if !ok || !credential.matches(password) {
+		return nil, errors.WithStack(ErrUnauthenticated)
+	}
+	return credential, nil
+}
+
+// AuthenticateAuthorization authenticates the given value of the
+// authorization metadata, as built by BasicAuthorization
+func (c *Credentials) AuthenticateAuthorization(authorization string) (*Credential, error) {
+	if !strings.HasPrefix(authorization, basicAuthorizationPrefix) {
+		return nil, errors.WithStack(ErrUnauthenticated)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(authorization, basicAuthorizationPrefix))
+	if err != nil {
+		return nil, errors.WithStack(ErrUnauthenticated)
+	}
+	separatorIndex := strings.Index(string(decoded), ":")
+	if separatorIndex < 0 {
+		return nil, errors.WithStack(ErrUnauthenticated)
+	}
+	return c.Authenticate(string(decoded[:separatorIndex]), string(decoded[separatorIndex+1:]))
+}
+
+// BasicAuthorization returns the value of the authorization metadata that
+// clients send to authenticate as the given user
+func BasicAuthorization(user string, password string) string {
+	return basicAuthorizationPrefix + base64.StdEncoding.EncodeToString([]byte(user+":"+password))
+}
+
+// Generate returns an --rpcauth value for the given user and password,
+// with a random salt. The given methods are appended to it, if there are any.
+func Generate(user string, password string, methods []string) (string, error) {
+	if user == "" || strings.Contains(user, ":") {
+		return "", errors.Errorf("user must be non-empty and must not contain ':'")
+	}
+	if len(methods) > 0 {
+		_, err := parseMethods(strings.Join(methods, ","))
+		if err != nil {
+			return "", err
+		}
+	}
+
+	saltBytes := make([]byte, saltLength)
+	_, err := rand.Read(saltBytes)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	salt := hex.EncodeToString(saltBytes)
+
+	rpcAuth := fmt.Sprintf("%s:%s$%s", user, salt, hex.EncodeToString(hashPassword(salt, password)))
+	if len(methods) > 0 {
+		rpcAuth += ":" + strings.Join(methods, ",")
+	}
+	return rpcAuth, nil
+}
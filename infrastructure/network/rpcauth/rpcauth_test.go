@@ -0,0 +1,96 @@
+package rpcauth
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+func TestCredentials(t *testing.T) {
+	admin, err := Generate("admin", "admin password", nil)
+	if err != nil {
+		t.Fatalf("Generate: %s", err)
+	}
+	explorer, err := Generate("explorer", "explorer password", []string{"getBlock", "GETINFO"})
+	if err != nil {
+		t.Fatalf("Generate: %s", err)
+	}
+	credentials, err := NewCredentials([]string{admin, explorer})
+	if err != nil {
+		t.Fatalf("NewCredentials: %s", err)
+	}
+
+	adminCredential, err := credentials.Authenticate("admin", "admin password")
+	if err != nil {
+		t.Fatalf("Authenticate: %s", err)
+	}
+	if !adminCredential.IsCommandAllowed(appmessage.CmdShutDownRequestMessage) {
+		t.Fatalf("Expected admin to be allowed to call all methods")
+	}
+
+	explorerCredential, err := credentials.AuthenticateAuthorization(BasicAuthorization("explorer", "explorer password"))
+	if err != nil {
+		t.Fatalf("AuthenticateAuthorization: %s", err)
+	}
+	if explorerCredential.User != "explorer" {
+		t.Fatalf("Unexpected user. Want: explorer, got: %s", explorerCredential.User)
+	}
+	if !explorerCredential.IsCommandAllowed(appmessage.CmdGetBlockRequestMessage) ||
+		!explorerCredential.IsCommandAllowed(appmessage.CmdGetInfoRequestMessage) {
+		t.Fatalf("Expected explorer to be allowed to call getBlock and getInfo")
+	}
+	if explorerCredential.IsCommandAllowed(appmessage.CmdSubmitBlockRequestMessage) {
+		t.Fatalf("Expected explorer not to be allowed to call submitBlock")
+	}
+	if explorerCredential.IsCommandAllowed(appmessage.CmdGetBlockResponseMessage) ||
+		adminCredential.IsCommandAllowed(appmessage.CmdBlock) {
+		t.Fatalf("Expected messages that aren't RPC requests not to be allowed")
+	}
+
+	failingAuthentications := []struct {
+		name          string
+		authorization string
+	}{
+		{name: "wrong password", authorization: BasicAuthorization("explorer", "admin password")},
+		{name: "unknown user", authorization: BasicAuthorization("miner", "admin password")},
+		{name: "missing", authorization: ""},
+		{name: "not basic", authorization: "Bearer token"},
+		{name: "not base64", authorization: "Basic !!!"},
+	}
+	for _, test := range failingAuthentications {
+		_, err := credentials.AuthenticateAuthorization(test.authorization)
+		if !errors.Is(err, ErrUnauthenticated) {
+			t.Errorf("%s: expected ErrUnauthenticated, but got: %v", test.name, err)
+		}
+	}
+}
+
+func TestParseCredential(t *testing.T) {
+	const hash = "c7aac1003a28e02e2c3cc7c0cfe0d011d04d70f8549f580ecab0c4f40cfffb9b"
+	tests := []struct {
+		rpcAuth       string
+		expectedError bool
+	}{
+		{rpcAuth: "user:salt$" + hash},
+		{rpcAuth: "user:salt$" + hash + ":getBlock,getBlocks"},
+		{rpcAuth: "user:salt$" + hash + ":getBlock,*"},
+		{rpcAuth: "user:salt$" + hash + ":getBlok", expectedError: true},
+		{rpcAuth: "user:salt$" + hash + ":", expectedError: true},
+		{rpcAuth: "user:salt$abcd", expectedError: true},
+		{rpcAuth: "user:" + hash, expectedError: true},
+		{rpcAuth: ":salt$" + hash, expectedError: true},
+		{rpcAuth: "user", expectedError: true},
+	}
+	for _, test := range tests {
+		_, err := ParseCredential(test.rpcAuth)
+		if test.expectedError != (err != nil) {
+			t.Errorf("ParseCredential(%s): expected error: %t, got: %v", test.rpcAuth, test.expectedError, err)
+		}
+	}
+
+	_, err := NewCredentials([]string{"user:a$" + hash, "user:b$" + hash})
+	if err == nil {
+		t.Errorf("Expected NewCredentials to fail for duplicate users")
+	}
+}
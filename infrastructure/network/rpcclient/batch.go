@@ -0,0 +1,289 @@
+package rpcclient
+
+import (
+	"context"
+	"sort"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+// maxBatchRequestsInFlight is the amount of requests of a batch that are sent before
+// their responses are received. It's kept below the capacity of the client's routes
+const maxBatchRequestsInFlight = 100
+
+// Batch collects RPC calls, and sends them together once Send is called. The requests
+// are sent without waiting for the responses of the requests before them, which saves
+// a round trip to the RPC server per call. The results of every call are received from
+// the future that was returned when it was added to the batch.
+//
+// While a batch is sent, other requests that are answered by the same commands as the
+// batch's calls wait for it.
+type Batch struct {
+	client *RPCClient
+	calls  []*batchCall
+	isSent bool
+}
+
+type batchCall struct {
+	request         appmessage.Message
+	responseCommand appmessage.MessageCommand
+
+	response appmessage.Message
+	err      error
+}
+
+var errBatchNotSent = errors.New("the batch was not sent yet")
+
+// NewBatch returns a new empty batch of calls to the RPC server
+func (c *RPCClient) NewBatch() *Batch {
+	return &Batch{client: c}
+}
+
+func (b *Batch) add(request appmessage.Message, responseCommand appmessage.MessageCommand) *batchCall {
+	call := &batchCall{
+		request:         request,
+		responseCommand: responseCommand,
+		err:             errBatchNotSent,
+	}
+	b.calls = append(b.calls, call)
+	return call
+}
+
+// Len returns the amount of calls in the batch
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+// Send sends the calls of the batch and waits for all their responses
+func (b *Batch) Send() error {
+	return b.SendContext(context.Background())
+}
+
+// SendContext operates the same as Send, except that it gives up waiting for the responses once ctx is done.
+// If ctx has no deadline, every response must be received within the client's timeout of the one before it.
+// The calls that don't get a response are sent again as the client's retry policy allows
+func (b *Batch) SendContext(ctx context.Context) error {
+	if b.isSent {
+		return errors.Errorf("the batch was already sent")
+	}
+	b.isSent = true
+	return b.client.sendCallsWithRetries(ctx, b.calls)
+}
+
+// sendCalls sends the requests of the given calls in order and sets their responses
+func (c *RPCClient) sendCalls(ctx context.Context, calls []*batchCall) error {
+	if len(calls) == 0 {
+		return nil
+	}
+
+	waitContext := func() (context.Context, context.CancelFunc) {
+		if _, ok := ctx.Deadline(); ok {
+			return ctx, func() {}
+		}
+		return context.WithTimeout(ctx, c.timeout)
+	}
+	waitError := func(waitCtx context.Context, responseCommand appmessage.MessageCommand) error {
+		if ctx.Err() != nil {
+			return errors.Wrapf(ctx.Err(), "stopped waiting for %s", responseCommand)
+		}
+		if waitCtx.Err() != nil {
+			return errors.Wrapf(routerpkg.ErrTimeout, "got no %s after %s", responseCommand, c.timeout)
+		}
+		return nil
+	}
+
+	rpcRouter := c.rpcRouter
+
+	// The request locks are always taken in the same order, so that
+	// batches that wait for each other's locks don't deadlock
+	responseCommandSet := make(map[appmessage.MessageCommand]struct{})
+	for _, call := range calls {
+		responseCommandSet[call.responseCommand] = struct{}{}
+	}
+	responseCommands := make([]appmessage.MessageCommand, 0, len(responseCommandSet))
+	for responseCommand := range responseCommandSet {
+		responseCommands = append(responseCommands, responseCommand)
+	}
+	sort.Slice(responseCommands, func(i, j int) bool { return responseCommands[i] < responseCommands[j] })
+
+	lockedResponseCommands := make([]appmessage.MessageCommand, 0, len(responseCommands))
+	defer func() {
+		for _, responseCommand := range lockedResponseCommands {
+			<-rpcRouter.responses[responseCommand].requestLock
+		}
+	}()
+	for _, responseCommand := range responseCommands {
+		waitCtx, cancel := waitContext()
+		select {
+		case rpcRouter.responses[responseCommand].requestLock <- struct{}{}:
+			cancel()
+		case <-waitCtx.Done():
+			err := waitError(waitCtx, responseCommand)
+			cancel()
+			return c.failCalls(calls, err)
+		}
+		lockedResponseCommands = append(lockedResponseCommands, responseCommand)
+	}
+
+	sentCount := 0
+	for receivedCount := range calls {
+		for sentCount < len(calls) && sentCount-receivedCount < maxBatchRequestsInFlight {
+			err := rpcRouter.outgoingRoute().Enqueue(calls[sentCount].request)
+			if err != nil {
+				c.abandonCalls(rpcRouter, calls[receivedCount:sentCount])
+				return c.failCalls(calls[receivedCount:], err)
+			}
+			sentCount++
+		}
+
+		// The RPC server answers requests in order, so the response of the
+		// oldest call that wasn't answered yet is the next one to arrive
+		call := calls[receivedCount]
+		responses := rpcRouter.responses[call.responseCommand]
+		for {
+			waitCtx, cancel := waitContext()
+			response, err := responses.route.DequeueWithContext(waitCtx)
+			if err != nil {
+				if waitCtx.Err() != nil {
+					err = waitError(waitCtx, call.responseCommand)
+				}
+				cancel()
+				c.abandonCalls(rpcRouter, calls[receivedCount:sentCount])
+				return c.failCalls(calls[receivedCount:], err)
+			}
+			cancel()
+			if responses.abandonedResponses > 0 {
+				responses.abandonedResponses--
+				continue
+			}
+			call.response = response
+			call.err = nil
+			break
+		}
+	}
+	return nil
+}
+
+// abandonCalls marks the responses of the given calls, which were sent, as responses that
+// are to be discarded once they're received. It must be called with their request locks held
+func (c *RPCClient) abandonCalls(rpcRouter *rpcRouter, calls []*batchCall) {
+	for _, call := range calls {
+		rpcRouter.responses[call.responseCommand].abandonedResponses++
+	}
+}
+
+func (c *RPCClient) failCalls(calls []*batchCall, err error) error {
+	for _, call := range calls {
+		call.err = err
+	}
+	return err
+}
+
+// GetBlockFuture is the future result of a GetBlock call of a batch
+type GetBlockFuture struct {
+	client *RPCClient
+	call   *batchCall
+}
+
+// GetBlock adds a GetBlock call to the batch
+func (b *Batch) GetBlock(hash string, includeTransactions bool) *GetBlockFuture {
+	return &GetBlockFuture{
+		client: b.client,
+		call: b.add(appmessage.NewGetBlockRequestMessage(hash, includeTransactions),
+			appmessage.CmdGetBlockResponseMessage),
+	}
+}
+
+// Receive returns the results of the call, once the batch was sent
+func (f *GetBlockFuture) Receive() (*appmessage.GetBlockResponseMessage, error) {
+	if f.call.err != nil {
+		return nil, f.call.err
+	}
+	getBlockResponse := f.call.response.(*appmessage.GetBlockResponseMessage)
+	if getBlockResponse.Error != nil {
+		return nil, f.client.convertRPCError(getBlockResponse.Error)
+	}
+	return getBlockResponse, nil
+}
+
+// GetBlocksFuture is the future result of a GetBlocks call of a batch
+type GetBlocksFuture struct {
+	client *RPCClient
+	call   *batchCall
+}
+
+// GetBlocks adds a GetBlocks call to the batch
+func (b *Batch) GetBlocks(lowHash string, includeBlocks bool, includeTransactions bool) *GetBlocksFuture {
+	return &GetBlocksFuture{
+		client: b.client,
+		call: b.add(appmessage.NewGetBlocksRequestMessage(lowHash, includeBlocks, includeTransactions),
+			appmessage.CmdGetBlocksResponseMessage),
+	}
+}
+
+// Receive returns the results of the call, once the batch was sent
+func (f *GetBlocksFuture) Receive() (*appmessage.GetBlocksResponseMessage, error) {
+	if f.call.err != nil {
+		return nil, f.call.err
+	}
+	getBlocksResponse := f.call.response.(*appmessage.GetBlocksResponseMessage)
+	if getBlocksResponse.Error != nil {
+		return nil, f.client.convertRPCError(getBlocksResponse.Error)
+	}
+	return getBlocksResponse, nil
+}
+
+// GetMempoolEntryFuture is the future result of a GetMempoolEntry call of a batch
+type GetMempoolEntryFuture struct {
+	client *RPCClient
+	call   *batchCall
+}
+
+// GetMempoolEntry adds a GetMempoolEntry call to the batch
+func (b *Batch) GetMempoolEntry(txID string, includeOrphanPool bool, filterTransactionPool bool) *GetMempoolEntryFuture {
+	return &GetMempoolEntryFuture{
+		client: b.client,
+		call: b.add(appmessage.NewGetMempoolEntryRequestMessage(txID, includeOrphanPool, filterTransactionPool),
+			appmessage.CmdGetMempoolEntryResponseMessage),
+	}
+}
+
+// Receive returns the results of the call, once the batch was sent
+func (f *GetMempoolEntryFuture) Receive() (*appmessage.GetMempoolEntryResponseMessage, error) {
+	if f.call.err != nil {
+		return nil, f.call.err
+	}
+	getMempoolEntryResponse := f.call.response.(*appmessage.GetMempoolEntryResponseMessage)
+	if getMempoolEntryResponse.Error != nil {
+		return nil, f.client.convertRPCError(getMempoolEntryResponse.Error)
+	}
+	return getMempoolEntryResponse, nil
+}
+
+// GetBalanceByAddressFuture is the future result of a GetBalanceByAddress call of a batch
+type GetBalanceByAddressFuture struct {
+	client *RPCClient
+	call   *batchCall
+}
+
+// GetBalanceByAddress adds a GetBalanceByAddress call to the batch
+func (b *Batch) GetBalanceByAddress(address string) *GetBalanceByAddressFuture {
+	return &GetBalanceByAddressFuture{
+		client: b.client,
+		call:   b.add(appmessage.NewGetBalanceByAddressRequest(address), appmessage.CmdGetBalanceByAddressResponseMessage),
+	}
+}
+
+// Receive returns the results of the call, once the batch was sent
+func (f *GetBalanceByAddressFuture) Receive() (*appmessage.GetBalanceByAddressResponseMessage, error) {
+	if f.call.err != nil {
+		return nil, f.call.err
+	}
+	getBalanceByAddressResponse := f.call.response.(*appmessage.GetBalanceByAddressResponseMessage)
+	if getBalanceByAddressResponse.Error != nil {
+		return nil, f.client.convertRPCError(getBalanceByAddressResponse.Error)
+	}
+	return getBalanceByAddressResponse, nil
+}
@@ -0,0 +1,199 @@
+package rpcclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultBlockRangeConcurrency = 16
+	defaultBlockRangePageSize    = 1000
+)
+
+// ErrChainChanged is returned by a block range download when the selected parent chain
+// it was following was reorganized in the middle of the range. Downloading again from the
+// hash of the last block that was received continues along the new chain
+var ErrChainChanged = errors.New("the selected parent chain changed during the download")
+
+// BlockRangeOptions configure a block range download
+type BlockRangeOptions struct {
+	// IncludeTransactions makes the downloaded blocks include their transactions
+	IncludeTransactions bool
+
+	// MinBlueScore skips the chain blocks whose blue score is lower than it
+	MinBlueScore uint64
+
+	// MaxBlueScore ends the range before the first chain block whose blue score is higher
+	// than it. Zero means the range ends at the virtual's selected parent
+	MaxBlueScore uint64
+
+	// EndHash ends the range with the chain block with this hash. An empty EndHash means the
+	// range ends at the virtual's selected parent
+	EndHash string
+
+	// Concurrency is the maximum amount of blocks that are requested before they're
+	// delivered. Zero means defaultBlockRangeConcurrency
+	Concurrency int
+
+	// PageSize is the amount of chain block hashes requested at a time. Zero means
+	// defaultBlockRangePageSize
+	PageSize uint32
+
+	// RetryPolicy overrides the client's retry policy for the requests of the download.
+	// If it's nil, requests that fail with a transient error are sent up to 3 more times
+	RetryPolicy *RetryPolicy
+}
+
+// BlockRangeResult is a single block of a block range download. Err is set only in
+// the last result of a download that failed
+type BlockRangeResult struct {
+	Block *appmessage.RPCBlock
+	Err   error
+}
+
+func (o *BlockRangeOptions) withDefaults() *BlockRangeOptions {
+	options := BlockRangeOptions{}
+	if o != nil {
+		options = *o
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = defaultBlockRangeConcurrency
+	}
+	if options.PageSize == 0 {
+		options.PageSize = defaultBlockRangePageSize
+	}
+	if options.RetryPolicy == nil {
+		options.RetryPolicy = &RetryPolicy{
+			MaxRetries:  3,
+			Backoff:     ExponentialBackoff(100*time.Millisecond, 2*time.Second),
+			IsRetryable: IsTransientError,
+		}
+	}
+	return &options
+}
+
+// DownloadChainBlocks downloads the selected parent chain blocks that come after startHash, in
+// chain order, and delivers them over the returned channel, which is closed once the range ends.
+// Up to options.Concurrency blocks are requested at the same time, so the RPC server is kept busy
+// while the blocks before them are handled.
+//
+// If startHash isn't a chain block, the range starts at the chain block it diverged from the
+// selected parent chain at. A download that fails ends with a result whose Err is set. Canceling
+// ctx ends the download without a result
+func (c *RPCClient) DownloadChainBlocks(ctx context.Context, startHash string,
+	options *BlockRangeOptions) <-chan *BlockRangeResult {
+
+	options = options.withDefaults()
+	ctx, cancel := context.WithCancel(WithRetryPolicy(ctx, options.RetryPolicy))
+
+	// Every pending download is a channel of its single result. The pending downloads
+	// are delivered in the order they were started, which bounds the downloads that run
+	// at the same time to the capacity of pendingChan
+	pendingChan := make(chan chan *BlockRangeResult, options.Concurrency)
+	resultsChan := make(chan *BlockRangeResult)
+
+	spawn("DownloadChainBlocks-requestBlocks", func() {
+		defer close(pendingChan)
+		err := c.forEachChainBlockHash(ctx, startHash, options, func(hash string) bool {
+			blockResultChan := make(chan *BlockRangeResult, 1)
+			select {
+			case pendingChan <- blockResultChan:
+			case <-ctx.Done():
+				return false
+			}
+			spawn("DownloadChainBlocks-getBlock", func() {
+				response, err := c.GetBlockContext(ctx, hash, options.IncludeTransactions)
+				if err != nil {
+					blockResultChan <- &BlockRangeResult{Err: errors.Wrapf(err, "error getting block %s", hash)}
+					return
+				}
+				blockResultChan <- &BlockRangeResult{Block: response.Block}
+			})
+			return true
+		})
+		if err != nil {
+			errorResultChan := make(chan *BlockRangeResult, 1)
+			errorResultChan <- &BlockRangeResult{Err: err}
+			select {
+			case pendingChan <- errorResultChan:
+			case <-ctx.Done():
+			}
+		}
+	})
+
+	spawn("DownloadChainBlocks-deliverBlocks", func() {
+		defer close(resultsChan)
+		defer cancel()
+		for blockResultChan := range pendingChan {
+			var result *BlockRangeResult
+			select {
+			case result = <-blockResultChan:
+			case <-ctx.Done():
+				return
+			}
+			if result.Err != nil && ctx.Err() != nil {
+				// The block failed because the download was canceled
+				return
+			}
+			if result.Err == nil {
+				blueScore := result.Block.Header.BlueScore
+				if blueScore < options.MinBlueScore {
+					continue
+				}
+				if options.MaxBlueScore != 0 && blueScore > options.MaxBlueScore {
+					return
+				}
+			}
+			select {
+			case resultsChan <- result:
+			case <-ctx.Done():
+				return
+			}
+			if result.Err != nil {
+				return
+			}
+		}
+	})
+
+	return resultsChan
+}
+
+// forEachChainBlockHash calls handler with the hashes of the chain blocks of the range, in order,
+// until handler returns false
+func (c *RPCClient) forEachChainBlockHash(ctx context.Context, startHash string, options *BlockRangeOptions,
+	handler func(hash string) bool) error {
+
+	isFirstPage := true
+	for {
+		response, err := c.GetVirtualSelectedParentChainFromBlockPageContext(
+			ctx, startHash, false, false, options.PageSize)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return errors.Wrapf(err, "error getting the chain blocks after %s", startHash)
+		}
+		// The first page may roll back blocks, when startHash isn't a chain block. Later
+		// pages start at a block that was added to the chain by the page before them
+		if !isFirstPage && len(response.RemovedChainBlockHashes) > 0 {
+			return errors.Wrapf(ErrChainChanged, "%s was removed from the selected parent chain", startHash)
+		}
+		isFirstPage = false
+
+		for _, hash := range response.AddedChainBlockHashes {
+			if !handler(hash) {
+				return nil
+			}
+			if hash == options.EndHash {
+				return nil
+			}
+		}
+		if response.NextStartHash == "" {
+			return nil
+		}
+		startHash = response.NextStartHash
+	}
+}
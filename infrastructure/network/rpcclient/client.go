@@ -0,0 +1,356 @@
+package rpcclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+)
+
+// Client is the interface of RPCClient. Applications that depend on it rather than on
+// RPCClient may be tested against an in-memory mock of the RPC server, such as
+// rpcclientmock.Server
+type Client interface {
+	Reconnect() error
+	SetTimeout(timeout time.Duration)
+	Close() error
+	Address() string
+	SetLogger(backend *logger.Backend, level logger.Level)
+	SetRetryPolicy(retryPolicy *RetryPolicy)
+	SetReconnectPolicy(reconnectPolicy *RetryPolicy)
+	AddInterceptor(interceptor Interceptor)
+	SetOnNotificationsMissedHandler(handler func())
+	NewBatch() *Batch
+	DownloadChainBlocks(ctx context.Context, startHash string, options *BlockRangeOptions) <-chan *BlockRangeResult
+
+	BackupDatabase(targetDirectory string) (*appmessage.BackupDatabaseResponseMessage, error)
+	BackupDatabaseContext(ctx context.Context, targetDirectory string) (*appmessage.BackupDatabaseResponseMessage, error)
+	BackupDatabaseAsync(ctx context.Context, targetDirectory string, handler func(*appmessage.BackupDatabaseResponseMessage, error))
+
+	Ban(ip string) (*appmessage.BanResponseMessage, error)
+	BanContext(ctx context.Context, ip string) (*appmessage.BanResponseMessage, error)
+	BanAsync(ctx context.Context, ip string, handler func(*appmessage.BanResponseMessage, error))
+
+	CancelJob(jobID uint64) (*appmessage.CancelJobResponseMessage, error)
+	CancelJobContext(ctx context.Context, jobID uint64) (*appmessage.CancelJobResponseMessage, error)
+	CancelJobAsync(ctx context.Context, jobID uint64, handler func(*appmessage.CancelJobResponseMessage, error))
+
+	CaptureProfile(profileType string, durationSeconds uint32, includeData bool) (*appmessage.CaptureProfileResponseMessage, error)
+	CaptureProfileContext(ctx context.Context, profileType string, durationSeconds uint32, includeData bool) (*appmessage.CaptureProfileResponseMessage, error)
+	CaptureProfileAsync(ctx context.Context, profileType string, durationSeconds uint32, includeData bool, handler func(*appmessage.CaptureProfileResponseMessage, error))
+
+	ClearBanned() (*appmessage.ClearBannedResponseMessage, error)
+	ClearBannedContext(ctx context.Context) (*appmessage.ClearBannedResponseMessage, error)
+	ClearBannedAsync(ctx context.Context, handler func(*appmessage.ClearBannedResponseMessage, error))
+
+	CompactDatabase() (*appmessage.CompactDatabaseResponseMessage, error)
+	CompactDatabaseContext(ctx context.Context) (*appmessage.CompactDatabaseResponseMessage, error)
+	CompactDatabaseAsync(ctx context.Context, handler func(*appmessage.CompactDatabaseResponseMessage, error))
+
+	AddPeer(address string, isPermanent bool) error
+	AddPeerContext(ctx context.Context, address string, isPermanent bool) error
+	AddPeerAsync(ctx context.Context, address string, isPermanent bool, handler func(error))
+
+	AddTransactionBlocklistEntries(entries []*appmessage.TransactionBlocklistEntry) (*appmessage.AddTransactionBlocklistEntriesResponseMessage, error)
+	AddTransactionBlocklistEntriesContext(ctx context.Context, entries []*appmessage.TransactionBlocklistEntry) (*appmessage.AddTransactionBlocklistEntriesResponseMessage, error)
+	AddTransactionBlocklistEntriesAsync(ctx context.Context, entries []*appmessage.TransactionBlocklistEntry, handler func(*appmessage.AddTransactionBlocklistEntriesResponseMessage, error))
+
+	DebugLevel(levelSpec string) (*appmessage.DebugLevelResponseMessage, error)
+	DebugLevelContext(ctx context.Context, levelSpec string) (*appmessage.DebugLevelResponseMessage, error)
+	DebugLevelAsync(ctx context.Context, levelSpec string, handler func(*appmessage.DebugLevelResponseMessage, error))
+
+	DropIndex(indexName string) (*appmessage.DropIndexResponseMessage, error)
+	DropIndexContext(ctx context.Context, indexName string) (*appmessage.DropIndexResponseMessage, error)
+	DropIndexAsync(ctx context.Context, indexName string, handler func(*appmessage.DropIndexResponseMessage, error))
+
+	DumpUTXOSet(filePath string, runAsJob bool) (*appmessage.DumpUTXOSetResponseMessage, error)
+	DumpUTXOSetContext(ctx context.Context, filePath string, runAsJob bool) (*appmessage.DumpUTXOSetResponseMessage, error)
+	DumpUTXOSetAsync(ctx context.Context, filePath string, runAsJob bool, handler func(*appmessage.DumpUTXOSetResponseMessage, error))
+
+	EstimateNetworkHashesPerSecond(startHash string, windowSize uint32) (*appmessage.EstimateNetworkHashesPerSecondResponseMessage, error)
+	EstimateNetworkHashesPerSecondContext(ctx context.Context, startHash string, windowSize uint32) (*appmessage.EstimateNetworkHashesPerSecondResponseMessage, error)
+	EstimateNetworkHashesPerSecondAsync(ctx context.Context, startHash string, windowSize uint32, handler func(*appmessage.EstimateNetworkHashesPerSecondResponseMessage, error))
+
+	ExportBlocks(filePath string, lowHash string, highHash string) (*appmessage.ExportBlocksResponseMessage, error)
+	ExportBlocksContext(ctx context.Context, filePath string, lowHash string, highHash string) (*appmessage.ExportBlocksResponseMessage, error)
+	ExportBlocksAsync(ctx context.Context, filePath string, lowHash string, highHash string, handler func(*appmessage.ExportBlocksResponseMessage, error))
+
+	GenerateBlocks(payAddress string, count uint32, parentHashes []string, timestamp int64) (*appmessage.GenerateBlocksResponseMessage, error)
+	GenerateBlocksContext(ctx context.Context, payAddress string, count uint32, parentHashes []string, timestamp int64) (*appmessage.GenerateBlocksResponseMessage, error)
+	GenerateBlocksAsync(ctx context.Context, payAddress string, count uint32, parentHashes []string, timestamp int64, handler func(*appmessage.GenerateBlocksResponseMessage, error))
+
+	GetAddressManagerInfo() (*appmessage.GetAddressManagerInfoResponseMessage, error)
+	GetAddressManagerInfoContext(ctx context.Context) (*appmessage.GetAddressManagerInfoResponseMessage, error)
+	GetAddressManagerInfoAsync(ctx context.Context, handler func(*appmessage.GetAddressManagerInfoResponseMessage, error))
+
+	GetAuxCommitmentProof(blockHash string) (*appmessage.GetAuxCommitmentProofResponseMessage, error)
+	GetAuxCommitmentProofContext(ctx context.Context, blockHash string) (*appmessage.GetAuxCommitmentProofResponseMessage, error)
+	GetAuxCommitmentProofAsync(ctx context.Context, blockHash string, handler func(*appmessage.GetAuxCommitmentProofResponseMessage, error))
+
+	GetBalanceByAddress(address string) (*appmessage.GetBalanceByAddressResponseMessage, error)
+	GetBalanceByAddressContext(ctx context.Context, address string) (*appmessage.GetBalanceByAddressResponseMessage, error)
+	GetBalanceByAddressAsync(ctx context.Context, address string, handler func(*appmessage.GetBalanceByAddressResponseMessage, error))
+
+	GetBalancesByAddresses(addresses []string) (*appmessage.GetBalancesByAddressesResponseMessage, error)
+	GetBalancesByAddressesContext(ctx context.Context, addresses []string) (*appmessage.GetBalancesByAddressesResponseMessage, error)
+	GetBalancesByAddressesAsync(ctx context.Context, addresses []string, handler func(*appmessage.GetBalancesByAddressesResponseMessage, error))
+
+	GetBlock(hash string, includeTransactions bool) (*appmessage.GetBlockResponseMessage, error)
+	GetBlockContext(ctx context.Context, hash string, includeTransactions bool) (*appmessage.GetBlockResponseMessage, error)
+	GetBlockAsync(ctx context.Context, hash string, includeTransactions bool, handler func(*appmessage.GetBlockResponseMessage, error))
+
+	GetBlockCount() (*appmessage.GetBlockCountResponseMessage, error)
+	GetBlockCountContext(ctx context.Context) (*appmessage.GetBlockCountResponseMessage, error)
+	GetBlockCountAsync(ctx context.Context, handler func(*appmessage.GetBlockCountResponseMessage, error))
+
+	GetBlockDAGInfo() (*appmessage.GetBlockDAGInfoResponseMessage, error)
+	GetBlockDAGInfoContext(ctx context.Context) (*appmessage.GetBlockDAGInfoResponseMessage, error)
+	GetBlockDAGInfoAsync(ctx context.Context, handler func(*appmessage.GetBlockDAGInfoResponseMessage, error))
+
+	GetBlockSubmissionStatus(submissionID string) (*appmessage.GetBlockSubmissionStatusResponseMessage, error)
+	GetBlockSubmissionStatusContext(ctx context.Context, submissionID string) (*appmessage.GetBlockSubmissionStatusResponseMessage, error)
+	GetBlockSubmissionStatusAsync(ctx context.Context, submissionID string, handler func(*appmessage.GetBlockSubmissionStatusResponseMessage, error))
+
+	GetBlockTemplate(miningAddress, extraData string) (*appmessage.GetBlockTemplateResponseMessage, error)
+	GetBlockTemplateContext(ctx context.Context, miningAddress, extraData string) (*appmessage.GetBlockTemplateResponseMessage, error)
+	GetBlockTemplateAsync(ctx context.Context, miningAddress, extraData string, handler func(*appmessage.GetBlockTemplateResponseMessage, error))
+	GetBlockTemplateWithMinFees(miningAddress, extraData string, minFees uint64, maxFeeWait time.Duration) (*appmessage.GetBlockTemplateResponseMessage, error)
+	GetBlockTemplateWithMinFeesContext(ctx context.Context, miningAddress, extraData string, minFees uint64, maxFeeWait time.Duration) (*appmessage.GetBlockTemplateResponseMessage, error)
+	GetBlockTemplateWithMinFeesAsync(ctx context.Context, miningAddress, extraData string, minFees uint64, maxFeeWait time.Duration, handler func(*appmessage.GetBlockTemplateResponseMessage, error))
+	GetBlockTemplateWithAuxCommitment(miningAddress, extraData string, auxCommitment *externalapi.DomainHash) (*appmessage.GetBlockTemplateResponseMessage, error)
+	GetBlockTemplateWithAuxCommitmentContext(ctx context.Context, miningAddress, extraData string, auxCommitment *externalapi.DomainHash) (*appmessage.GetBlockTemplateResponseMessage, error)
+	GetBlockTemplateWithAuxCommitmentAsync(ctx context.Context, miningAddress, extraData string, auxCommitment *externalapi.DomainHash, handler func(*appmessage.GetBlockTemplateResponseMessage, error))
+	ProposeBlockTemplate(block *externalapi.DomainBlock) (*appmessage.GetBlockTemplateResponseMessage, error)
+	ProposeBlockTemplateContext(ctx context.Context, block *externalapi.DomainBlock) (*appmessage.GetBlockTemplateResponseMessage, error)
+	ProposeBlockTemplateAsync(ctx context.Context, block *externalapi.DomainBlock, handler func(*appmessage.GetBlockTemplateResponseMessage, error))
+
+	GetBlocks(lowHash string, includeBlocks bool, includeTransactions bool) (*appmessage.GetBlocksResponseMessage, error)
+	GetBlocksContext(ctx context.Context, lowHash string, includeBlocks bool, includeTransactions bool) (*appmessage.GetBlocksResponseMessage, error)
+	GetBlocksAsync(ctx context.Context, lowHash string, includeBlocks bool, includeTransactions bool, handler func(*appmessage.GetBlocksResponseMessage, error))
+	GetBlocksPage(lowHash string, includeBlocks bool, includeTransactions bool, limit uint32) (*appmessage.GetBlocksResponseMessage, error)
+	GetBlocksPageContext(ctx context.Context, lowHash string, includeBlocks bool, includeTransactions bool, limit uint32) (*appmessage.GetBlocksResponseMessage, error)
+	GetBlocksPageAsync(ctx context.Context, lowHash string, includeBlocks bool, includeTransactions bool, limit uint32, handler func(*appmessage.GetBlocksResponseMessage, error))
+
+	GetVirtualSelectedParentChainFromBlock(startHash string, includeAcceptedTransactionIDs bool) (*appmessage.GetVirtualSelectedParentChainFromBlockResponseMessage, error)
+	GetVirtualSelectedParentChainFromBlockContext(ctx context.Context, startHash string, includeAcceptedTransactionIDs bool) (*appmessage.GetVirtualSelectedParentChainFromBlockResponseMessage, error)
+	GetVirtualSelectedParentChainFromBlockAsync(ctx context.Context, startHash string, includeAcceptedTransactionIDs bool, handler func(*appmessage.GetVirtualSelectedParentChainFromBlockResponseMessage, error))
+	GetVirtualSelectedParentChainFromBlockPage(startHash string, includeAcceptedTransactionIDs bool, includeAcceptanceData bool, limit uint32) (*appmessage.GetVirtualSelectedParentChainFromBlockResponseMessage, error)
+	GetVirtualSelectedParentChainFromBlockPageContext(ctx context.Context, startHash string, includeAcceptedTransactionIDs bool, includeAcceptanceData bool, limit uint32) (*appmessage.GetVirtualSelectedParentChainFromBlockResponseMessage, error)
+	GetVirtualSelectedParentChainFromBlockPageAsync(ctx context.Context, startHash string, includeAcceptedTransactionIDs bool, includeAcceptanceData bool, limit uint32, handler func(*appmessage.GetVirtualSelectedParentChainFromBlockResponseMessage, error))
+
+	GetChainAnalysis(lowBlueScore uint64, highBlueScore uint64) (*appmessage.GetChainAnalysisResponseMessage, error)
+	GetChainAnalysisContext(ctx context.Context, lowBlueScore uint64, highBlueScore uint64) (*appmessage.GetChainAnalysisResponseMessage, error)
+	GetChainAnalysisAsync(ctx context.Context, lowBlueScore uint64, highBlueScore uint64, handler func(*appmessage.GetChainAnalysisResponseMessage, error))
+
+	GetCoinSupply() (*appmessage.GetCoinSupplyResponseMessage, error)
+	GetCoinSupplyContext(ctx context.Context) (*appmessage.GetCoinSupplyResponseMessage, error)
+	GetCoinSupplyAsync(ctx context.Context, handler func(*appmessage.GetCoinSupplyResponseMessage, error))
+
+	GetConnectedPeerInfo() (*appmessage.GetConnectedPeerInfoResponseMessage, error)
+	GetConnectedPeerInfoContext(ctx context.Context) (*appmessage.GetConnectedPeerInfoResponseMessage, error)
+	GetConnectedPeerInfoAsync(ctx context.Context, handler func(*appmessage.GetConnectedPeerInfoResponseMessage, error))
+	GetConnectedPeerInfoPage(limit uint32, cursor string) (*appmessage.GetConnectedPeerInfoResponseMessage, error)
+	GetConnectedPeerInfoPageContext(ctx context.Context, limit uint32, cursor string) (*appmessage.GetConnectedPeerInfoResponseMessage, error)
+	GetConnectedPeerInfoPageAsync(ctx context.Context, limit uint32, cursor string, handler func(*appmessage.GetConnectedPeerInfoResponseMessage, error))
+
+	GetDatabaseStats(includeBuckets bool, bucketDepth uint32, includeOperationMetrics bool) (*appmessage.GetDatabaseStatsResponseMessage, error)
+	GetDatabaseStatsContext(ctx context.Context, includeBuckets bool, bucketDepth uint32, includeOperationMetrics bool) (*appmessage.GetDatabaseStatsResponseMessage, error)
+	GetDatabaseStatsAsync(ctx context.Context, includeBuckets bool, bucketDepth uint32, includeOperationMetrics bool, handler func(*appmessage.GetDatabaseStatsResponseMessage, error))
+
+	GetFinalityPoint() (*appmessage.GetFinalityPointResponseMessage, error)
+	GetFinalityPointContext(ctx context.Context) (*appmessage.GetFinalityPointResponseMessage, error)
+	GetFinalityPointAsync(ctx context.Context, handler func(*appmessage.GetFinalityPointResponseMessage, error))
+
+	GetHeaders(startHash string, limit uint64, isAscending bool) (*appmessage.GetHeadersResponseMessage, error)
+	GetHeadersContext(ctx context.Context, startHash string, limit uint64, isAscending bool) (*appmessage.GetHeadersResponseMessage, error)
+	GetHeadersAsync(ctx context.Context, startHash string, limit uint64, isAscending bool, handler func(*appmessage.GetHeadersResponseMessage, error))
+
+	GetIndexStatus() (*appmessage.GetIndexStatusResponseMessage, error)
+	GetIndexStatusContext(ctx context.Context) (*appmessage.GetIndexStatusResponseMessage, error)
+	GetIndexStatusAsync(ctx context.Context, handler func(*appmessage.GetIndexStatusResponseMessage, error))
+
+	GetInfo() (*appmessage.GetInfoResponseMessage, error)
+	GetInfoContext(ctx context.Context) (*appmessage.GetInfoResponseMessage, error)
+	GetInfoAsync(ctx context.Context, handler func(*appmessage.GetInfoResponseMessage, error))
+
+	GetJobs(jobIDs []uint64) (*appmessage.GetJobsResponseMessage, error)
+	GetJobsContext(ctx context.Context, jobIDs []uint64) (*appmessage.GetJobsResponseMessage, error)
+	GetJobsAsync(ctx context.Context, jobIDs []uint64, handler func(*appmessage.GetJobsResponseMessage, error))
+
+	GetMempoolEntries(includeOrphanPool bool, filterTransactionPool bool) (*appmessage.GetMempoolEntriesResponseMessage, error)
+	GetMempoolEntriesContext(ctx context.Context, includeOrphanPool bool, filterTransactionPool bool) (*appmessage.GetMempoolEntriesResponseMessage, error)
+	GetMempoolEntriesAsync(ctx context.Context, includeOrphanPool bool, filterTransactionPool bool, handler func(*appmessage.GetMempoolEntriesResponseMessage, error))
+	GetMempoolEntriesPage(includeOrphanPool bool, filterTransactionPool bool, limit uint32, cursor string) (*appmessage.GetMempoolEntriesResponseMessage, error)
+	GetMempoolEntriesPageContext(ctx context.Context, includeOrphanPool bool, filterTransactionPool bool, limit uint32, cursor string) (*appmessage.GetMempoolEntriesResponseMessage, error)
+	GetMempoolEntriesPageAsync(ctx context.Context, includeOrphanPool bool, filterTransactionPool bool, limit uint32, cursor string, handler func(*appmessage.GetMempoolEntriesResponseMessage, error))
+
+	GetMempoolEntriesByAddresses(addresses []string, includeOrphanPool bool, filterTransactionPool bool) (*appmessage.GetMempoolEntriesByAddressesResponseMessage, error)
+	GetMempoolEntriesByAddressesContext(ctx context.Context, addresses []string, includeOrphanPool bool, filterTransactionPool bool) (*appmessage.GetMempoolEntriesByAddressesResponseMessage, error)
+	GetMempoolEntriesByAddressesAsync(ctx context.Context, addresses []string, includeOrphanPool bool, filterTransactionPool bool, handler func(*appmessage.GetMempoolEntriesByAddressesResponseMessage, error))
+
+	GetMempoolEntry(txID string, includeOrphanPool bool, filterTransactionPool bool) (*appmessage.GetMempoolEntryResponseMessage, error)
+	GetMempoolEntryContext(ctx context.Context, txID string, includeOrphanPool bool, filterTransactionPool bool) (*appmessage.GetMempoolEntryResponseMessage, error)
+	GetMempoolEntryAsync(ctx context.Context, txID string, includeOrphanPool bool, filterTransactionPool bool, handler func(*appmessage.GetMempoolEntryResponseMessage, error))
+
+	GetPeerAddresses() (*appmessage.GetPeerAddressesResponseMessage, error)
+	GetPeerAddressesContext(ctx context.Context) (*appmessage.GetPeerAddressesResponseMessage, error)
+	GetPeerAddressesAsync(ctx context.Context, handler func(*appmessage.GetPeerAddressesResponseMessage, error))
+
+	GetSelectedTipHash() (*appmessage.GetSelectedTipHashResponseMessage, error)
+	GetSelectedTipHashContext(ctx context.Context) (*appmessage.GetSelectedTipHashResponseMessage, error)
+	GetSelectedTipHashAsync(ctx context.Context, handler func(*appmessage.GetSelectedTipHashResponseMessage, error))
+
+	GetSubnetwork(subnetworkID string) (*appmessage.GetSubnetworkResponseMessage, error)
+	GetSubnetworkContext(ctx context.Context, subnetworkID string) (*appmessage.GetSubnetworkResponseMessage, error)
+	GetSubnetworkAsync(ctx context.Context, subnetworkID string, handler func(*appmessage.GetSubnetworkResponseMessage, error))
+
+	GetTransactionBlocklist() (*appmessage.GetTransactionBlocklistResponseMessage, error)
+	GetTransactionBlocklistContext(ctx context.Context) (*appmessage.GetTransactionBlocklistResponseMessage, error)
+	GetTransactionBlocklistAsync(ctx context.Context, handler func(*appmessage.GetTransactionBlocklistResponseMessage, error))
+
+	GetUTXOsByAddresses(addresses []string) (*appmessage.GetUTXOsByAddressesResponseMessage, error)
+	GetUTXOsByAddressesContext(ctx context.Context, addresses []string) (*appmessage.GetUTXOsByAddressesResponseMessage, error)
+	GetUTXOsByAddressesAsync(ctx context.Context, addresses []string, handler func(*appmessage.GetUTXOsByAddressesResponseMessage, error))
+
+	GetVirtualSelectedParentBlueScore() (*appmessage.GetVirtualSelectedParentBlueScoreResponseMessage, error)
+	GetVirtualSelectedParentBlueScoreContext(ctx context.Context) (*appmessage.GetVirtualSelectedParentBlueScoreResponseMessage, error)
+	GetVirtualSelectedParentBlueScoreAsync(ctx context.Context, handler func(*appmessage.GetVirtualSelectedParentBlueScoreResponseMessage, error))
+
+	GetWork(payAddress string, extraData string) (*appmessage.GetWorkResponseMessage, error)
+	GetWorkContext(ctx context.Context, payAddress string, extraData string) (*appmessage.GetWorkResponseMessage, error)
+	GetWorkAsync(ctx context.Context, payAddress string, extraData string, handler func(*appmessage.GetWorkResponseMessage, error))
+
+	HealthCheck() (*appmessage.HealthCheckResponseMessage, error)
+	HealthCheckContext(ctx context.Context) (*appmessage.HealthCheckResponseMessage, error)
+	HealthCheckAsync(ctx context.Context, handler func(*appmessage.HealthCheckResponseMessage, error))
+
+	ImportUTXOSet(filePath string, runAsJob bool) (*appmessage.ImportUTXOSetResponseMessage, error)
+	ImportUTXOSetContext(ctx context.Context, filePath string, runAsJob bool) (*appmessage.ImportUTXOSetResponseMessage, error)
+	ImportUTXOSetAsync(ctx context.Context, filePath string, runAsJob bool, handler func(*appmessage.ImportUTXOSetResponseMessage, error))
+
+	ListBanned() (*appmessage.ListBannedResponseMessage, error)
+	ListBannedContext(ctx context.Context) (*appmessage.ListBannedResponseMessage, error)
+	ListBannedAsync(ctx context.Context, handler func(*appmessage.ListBannedResponseMessage, error))
+
+	StartNotificationSession() (*appmessage.StartNotificationSessionResponseMessage, error)
+	StartNotificationSessionContext(ctx context.Context) (*appmessage.StartNotificationSessionResponseMessage, error)
+	StartNotificationSessionAsync(ctx context.Context, handler func(*appmessage.StartNotificationSessionResponseMessage, error))
+	ResumeNotificationSession(sessionID string, lastSequence uint64) (*appmessage.ResumeNotificationSessionResponseMessage, error)
+	ResumeNotificationSessionContext(ctx context.Context, sessionID string, lastSequence uint64) (*appmessage.ResumeNotificationSessionResponseMessage, error)
+	ResumeNotificationSessionAsync(ctx context.Context, sessionID string, lastSequence uint64, handler func(*appmessage.ResumeNotificationSessionResponseMessage, error))
+
+	RegisterForBlockAddedNotifications(onBlockAdded func(notification *appmessage.BlockAddedNotificationMessage)) error
+	RegisterForBlockAddedNotificationsContext(ctx context.Context, onBlockAdded func(notification *appmessage.BlockAddedNotificationMessage)) error
+
+	RegisterForVirtualSelectedParentChainChangedNotifications(includeAcceptedTransactionIDs bool, onChainChanged func(notification *appmessage.VirtualSelectedParentChainChangedNotificationMessage)) error
+	RegisterForVirtualSelectedParentChainChangedNotificationsContext(ctx context.Context, includeAcceptedTransactionIDs bool, onChainChanged func(notification *appmessage.VirtualSelectedParentChainChangedNotificationMessage)) error
+	RegisterForVirtualSelectedParentChainChangedNotificationsWithAcceptanceData(onChainChanged func(notification *appmessage.VirtualSelectedParentChainChangedNotificationMessage)) error
+	RegisterForVirtualSelectedParentChainChangedNotificationsWithAcceptanceDataContext(ctx context.Context, onChainChanged func(notification *appmessage.VirtualSelectedParentChainChangedNotificationMessage)) error
+	UnregisterFromVirtualSelectedParentChainChangedNotifications() error
+	UnregisterFromVirtualSelectedParentChainChangedNotificationsContext(ctx context.Context) error
+
+	RegisterForFinalityConflictsNotifications(onFinalityConflict func(notification *appmessage.FinalityConflictNotificationMessage), onFinalityConflictResolved func(notification *appmessage.FinalityConflictResolvedNotificationMessage)) error
+	RegisterForFinalityConflictsNotificationsContext(ctx context.Context, onFinalityConflict func(notification *appmessage.FinalityConflictNotificationMessage), onFinalityConflictResolved func(notification *appmessage.FinalityConflictResolvedNotificationMessage)) error
+
+	RegisterForFinalityPointAdvancedNotifications(onFinalityPointAdvanced func(notification *appmessage.FinalityPointAdvancedNotificationMessage)) error
+	RegisterForFinalityPointAdvancedNotificationsContext(ctx context.Context, onFinalityPointAdvanced func(notification *appmessage.FinalityPointAdvancedNotificationMessage)) error
+	UnregisterFromFinalityPointAdvancedNotifications() error
+	UnregisterFromFinalityPointAdvancedNotificationsContext(ctx context.Context) error
+
+	RegisterForJobProgressNotifications(onJobProgress func(notification *appmessage.JobProgressNotificationMessage)) error
+	RegisterForJobProgressNotificationsContext(ctx context.Context, onJobProgress func(notification *appmessage.JobProgressNotificationMessage)) error
+	UnregisterFromJobProgressNotifications() error
+	UnregisterFromJobProgressNotificationsContext(ctx context.Context) error
+
+	RegisterForNewBlockTemplateNotifications(onNewBlockTemplate func(notification *appmessage.NewBlockTemplateNotificationMessage)) error
+	RegisterForNewBlockTemplateNotificationsContext(ctx context.Context, onNewBlockTemplate func(notification *appmessage.NewBlockTemplateNotificationMessage)) error
+
+	RegisterForNewTipNotifications(onNewTip func(notification *appmessage.NewTipNotificationMessage)) error
+	RegisterForNewTipNotificationsContext(ctx context.Context, onNewTip func(notification *appmessage.NewTipNotificationMessage)) error
+	UnregisterFromNewTipNotifications() error
+	UnregisterFromNewTipNotificationsContext(ctx context.Context) error
+
+	RegisterForNewTransactionsNotifications(addresses []string, subnetworkID string, minimumAmount uint64, onNewTransactions func(notification *appmessage.NewTransactionsNotificationMessage)) error
+	RegisterForNewTransactionsNotificationsContext(ctx context.Context, addresses []string, subnetworkID string, minimumAmount uint64, onNewTransactions func(notification *appmessage.NewTransactionsNotificationMessage)) error
+	UnregisterFromNewTransactionsNotifications() error
+	UnregisterFromNewTransactionsNotificationsContext(ctx context.Context) error
+
+	RegisterPruningPointUTXOSetNotifications(onPruningPointUTXOSetNotifications func()) error
+	RegisterPruningPointUTXOSetNotificationsContext(ctx context.Context, onPruningPointUTXOSetNotifications func()) error
+	UnregisterPruningPointUTXOSetNotifications() error
+	UnregisterPruningPointUTXOSetNotificationsContext(ctx context.Context) error
+
+	RegisterForUTXOsChangedNotifications(addresses []string, onUTXOsChanged func(notification *appmessage.UTXOsChangedNotificationMessage)) error
+	RegisterForUTXOsChangedNotificationsContext(ctx context.Context, addresses []string, onUTXOsChanged func(notification *appmessage.UTXOsChangedNotificationMessage)) error
+	RegisterForUTXOsChangedNotificationsWithMempool(addresses []string, includeMempool bool, onUTXOsChanged func(notification *appmessage.UTXOsChangedNotificationMessage)) error
+	RegisterForUTXOsChangedNotificationsWithMempoolContext(ctx context.Context, addresses []string, includeMempool bool, onUTXOsChanged func(notification *appmessage.UTXOsChangedNotificationMessage)) error
+	ModifyUTXOsChangedNotifications(addresses []string, includeMempool bool) error
+	ModifyUTXOsChangedNotificationsContext(ctx context.Context, addresses []string, includeMempool bool) error
+	StopNotifyingUTXOsChanged(addresses []string) error
+	StopNotifyingUTXOsChangedContext(ctx context.Context, addresses []string) error
+
+	RegisterForVirtualDaaScoreChangedNotifications(onVirtualDaaScoreChanged func(notification *appmessage.VirtualDaaScoreChangedNotificationMessage)) error
+	RegisterForVirtualDaaScoreChangedNotificationsContext(ctx context.Context, onVirtualDaaScoreChanged func(notification *appmessage.VirtualDaaScoreChangedNotificationMessage)) error
+
+	RegisterForVirtualSelectedParentBlueScoreChangedNotifications(onVirtualSelectedParentBlueScoreChanged func(notification *appmessage.VirtualSelectedParentBlueScoreChangedNotificationMessage)) error
+	RegisterForVirtualSelectedParentBlueScoreChangedNotificationsContext(ctx context.Context, onVirtualSelectedParentBlueScoreChanged func(notification *appmessage.VirtualSelectedParentBlueScoreChangedNotificationMessage)) error
+
+	RegisterForWorkNotifications(payAddress string, extraData string, onWork func(notification *appmessage.WorkNotificationMessage)) error
+	RegisterForWorkNotificationsContext(ctx context.Context, payAddress string, extraData string, onWork func(notification *appmessage.WorkNotificationMessage)) error
+
+	RebuildIndex(indexName string) (*appmessage.RebuildIndexResponseMessage, error)
+	RebuildIndexContext(ctx context.Context, indexName string) (*appmessage.RebuildIndexResponseMessage, error)
+	RebuildIndexAsync(ctx context.Context, indexName string, handler func(*appmessage.RebuildIndexResponseMessage, error))
+
+	ReloadConfig() (*appmessage.ReloadConfigResponseMessage, error)
+	ReloadConfigContext(ctx context.Context) (*appmessage.ReloadConfigResponseMessage, error)
+	ReloadConfigAsync(ctx context.Context, handler func(*appmessage.ReloadConfigResponseMessage, error))
+
+	RemoveTransactionBlocklistEntries(entries []*appmessage.TransactionBlocklistEntry) (*appmessage.RemoveTransactionBlocklistEntriesResponseMessage, error)
+	RemoveTransactionBlocklistEntriesContext(ctx context.Context, entries []*appmessage.TransactionBlocklistEntry) (*appmessage.RemoveTransactionBlocklistEntriesResponseMessage, error)
+	RemoveTransactionBlocklistEntriesAsync(ctx context.Context, entries []*appmessage.TransactionBlocklistEntry, handler func(*appmessage.RemoveTransactionBlocklistEntriesResponseMessage, error))
+
+	ResolveFinalityConflict(finalityBlockHash string) (*appmessage.ResolveFinalityConflictResponseMessage, error)
+	ResolveFinalityConflictContext(ctx context.Context, finalityBlockHash string) (*appmessage.ResolveFinalityConflictResponseMessage, error)
+	ResolveFinalityConflictAsync(ctx context.Context, finalityBlockHash string, handler func(*appmessage.ResolveFinalityConflictResponseMessage, error))
+	Rescan(startBlueScore uint64, addresses []string, outpoints []*appmessage.RPCOutpoint, onProgress func(notification *appmessage.RescanProgressNotificationMessage)) (*appmessage.RescanResponseMessage, error)
+	RescanContext(ctx context.Context, startBlueScore uint64, addresses []string, outpoints []*appmessage.RPCOutpoint, onProgress func(notification *appmessage.RescanProgressNotificationMessage)) (*appmessage.RescanResponseMessage, error)
+
+	SearchRawTransactions(address string, limit uint32, cursor string, includeMempool bool) (*appmessage.SearchRawTransactionsResponseMessage, error)
+	SearchRawTransactionsContext(ctx context.Context, address string, limit uint32, cursor string, includeMempool bool) (*appmessage.SearchRawTransactionsResponseMessage, error)
+	SearchRawTransactionsAsync(ctx context.Context, address string, limit uint32, cursor string, includeMempool bool, handler func(*appmessage.SearchRawTransactionsResponseMessage, error))
+
+	SubmitTransaction(transaction *appmessage.RPCTransaction, allowOrphan bool) (*appmessage.SubmitTransactionResponseMessage, error)
+	SubmitTransactionContext(ctx context.Context, transaction *appmessage.RPCTransaction, allowOrphan bool) (*appmessage.SubmitTransactionResponseMessage, error)
+	SubmitTransactionAsync(ctx context.Context, transaction *appmessage.RPCTransaction, allowOrphan bool, handler func(*appmessage.SubmitTransactionResponseMessage, error))
+
+	SetBan(subnet string, durationSeconds uint64, remove bool) (*appmessage.SetBanResponseMessage, error)
+	SetBanContext(ctx context.Context, subnet string, durationSeconds uint64, remove bool) (*appmessage.SetBanResponseMessage, error)
+	SetBanAsync(ctx context.Context, subnet string, durationSeconds uint64, remove bool, handler func(*appmessage.SetBanResponseMessage, error))
+
+	SignRawTransaction(transaction *appmessage.RPCTransaction, privateKeys []string, previousOutputs []*appmessage.SignRawTransactionPreviousOutput) (*appmessage.SignRawTransactionResponseMessage, error)
+	SignRawTransactionContext(ctx context.Context, transaction *appmessage.RPCTransaction, privateKeys []string, previousOutputs []*appmessage.SignRawTransactionPreviousOutput) (*appmessage.SignRawTransactionResponseMessage, error)
+	SignRawTransactionAsync(ctx context.Context, transaction *appmessage.RPCTransaction, privateKeys []string, previousOutputs []*appmessage.SignRawTransactionPreviousOutput, handler func(*appmessage.SignRawTransactionResponseMessage, error))
+	StopRescan() error
+	StopRescanContext(ctx context.Context) error
+
+	SubmitBlock(block *externalapi.DomainBlock) (appmessage.RejectReason, error)
+	SubmitBlockContext(ctx context.Context, block *externalapi.DomainBlock) (appmessage.RejectReason, error)
+	SubmitBlockAlsoIfNonDAA(block *externalapi.DomainBlock) (appmessage.RejectReason, error)
+	SubmitBlockAlsoIfNonDAAContext(ctx context.Context, block *externalapi.DomainBlock) (appmessage.RejectReason, error)
+	SubmitBlockAlsoIfNonDAAAsync(ctx context.Context, block *externalapi.DomainBlock, handler func(appmessage.RejectReason, error))
+	SubmitBlockAsync(block *externalapi.DomainBlock) (submissionID string, err error)
+	SubmitBlockAsyncContext(ctx context.Context, block *externalapi.DomainBlock) (submissionID string, err error)
+
+	SubmitWork(workID string, nonce uint64) (*appmessage.SubmitWorkResponseMessage, error)
+	SubmitWorkContext(ctx context.Context, workID string, nonce uint64) (*appmessage.SubmitWorkResponseMessage, error)
+	SubmitWorkAsync(ctx context.Context, workID string, nonce uint64, handler func(*appmessage.SubmitWorkResponseMessage, error))
+
+	Unban(ip string) (*appmessage.UnbanResponseMessage, error)
+	UnbanContext(ctx context.Context, ip string) (*appmessage.UnbanResponseMessage, error)
+	UnbanAsync(ctx context.Context, ip string, handler func(*appmessage.UnbanResponseMessage, error))
+}
+
+var _ Client = (*RPCClient)(nil)
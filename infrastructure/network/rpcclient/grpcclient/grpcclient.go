@@ -2,13 +2,17 @@ package grpcclient
 
 import (
 	"context"
+	"crypto/tls"
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver"
 	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcauth"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
 	"io"
 	"time"
 )
@@ -29,21 +33,53 @@ type GRPCClient struct {
 
 // Connect connects to the RPC server with the given address
 func Connect(address string) (*GRPCClient, error) {
+	return ConnectWithCredentials(address, "", "")
+}
+
+// ConnectWithCredentials connects to the RPC server with the given address,
+// and authenticates with the given user and password. If user is empty,
+// the client doesn't authenticate.
+func ConnectWithCredentials(address string, user string, password string) (*GRPCClient, error) {
+	return ConnectWithTLS(address, user, password, nil)
+}
+
+// ConnectWithTLS is like ConnectWithCredentials, but connects over TLS with
+// the given configuration, which may include a client certificate. If
+// tlsConfig is nil, the connection isn't encrypted.
+func ConnectWithTLS(address string, user string, password string, tlsConfig *tls.Config) (*GRPCClient, error) {
 	const dialTimeout = 5 * time.Second
 	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
 	defer cancel()
 
-	gRPCConnection, err := grpc.DialContext(ctx, address, grpc.WithInsecure(), grpc.WithBlock())
+	transportOption := grpc.WithInsecure()
+	if tlsConfig != nil {
+		transportOption = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+	}
+	gRPCConnection, err := grpc.DialContext(ctx, address, transportOption, grpc.WithBlock())
 	if err != nil {
 		return nil, errors.Wrapf(err, "error connecting to %s", address)
 	}
 
+	streamContext := context.Background()
+	if user != "" {
+		streamContext = metadata.AppendToOutgoingContext(streamContext,
+			rpcauth.AuthorizationMetadataKey, rpcauth.BasicAuthorization(user, password))
+	}
 	grpcClient := protowire.NewRPCClient(gRPCConnection)
-	stream, err := grpcClient.MessageStream(context.Background(), grpc.UseCompressor(gzip.Name),
+	stream, err := grpcClient.MessageStream(streamContext, grpc.UseCompressor(gzip.Name),
 		grpc.MaxCallRecvMsgSize(grpcserver.RPCMaxMessageSize), grpc.MaxCallSendMsgSize(grpcserver.RPCMaxMessageSize))
 	if err != nil {
 		return nil, errors.Wrapf(err, "error getting client stream for %s", address)
 	}
+	if user != "" {
+		// The server sends the header once the client is authenticated,
+		// so waiting for it reports invalid credentials right away
+		_, err = stream.Header()
+		if err != nil {
+			gRPCConnection.Close()
+			return nil, errors.Wrapf(err, "error authenticating to %s", address)
+		}
+	}
 	return &GRPCClient{stream: stream, connection: gRPCConnection}, nil
 }
 
@@ -0,0 +1,43 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// RequestHandler sends a request to the RPC server and returns its response. Errors the
+// RPC server answers a request with are set in the response rather than returned
+type RequestHandler func(ctx context.Context, request appmessage.Message) (appmessage.Message, error)
+
+// Interceptor is called with every request the client sends, and handles it by calling
+// next. Interceptors may log or measure requests, change a request before passing it on,
+// or call next again to retry a request that failed
+type Interceptor func(ctx context.Context, request appmessage.Message, next RequestHandler) (appmessage.Message, error)
+
+// AddInterceptor adds the given interceptor to the requests of the client. The interceptors
+// are called in the order they were added, so that the first is the outermost one.
+//
+// Requests sent as part of a Batch aren't passed to the interceptors, since they're sent
+// without waiting for each other's responses
+func (c *RPCClient) AddInterceptor(interceptor Interceptor) {
+	c.interceptorsLock.Lock()
+	defer c.interceptorsLock.Unlock()
+
+	c.interceptors = append(c.interceptors, interceptor)
+}
+
+// intercept returns handler wrapped by all the interceptors of the client
+func (c *RPCClient) intercept(handler RequestHandler) RequestHandler {
+	c.interceptorsLock.RLock()
+	defer c.interceptorsLock.RUnlock()
+
+	for i := len(c.interceptors) - 1; i >= 0; i-- {
+		interceptor := c.interceptors[i]
+		next := handler
+		handler = func(ctx context.Context, request appmessage.Message) (appmessage.Message, error) {
+			return interceptor(ctx, request, next)
+		}
+	}
+	return handler
+}
@@ -0,0 +1,469 @@
+package rpcclient
+
+import (
+	"context"
+	"crypto/tls"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultHealthCheckInterval = 5 * time.Second
+	healthCheckTimeout         = 5 * time.Second
+)
+
+// ErrNoHealthyNodes is returned by MultiNodeClient when none of its nodes is healthy
+var ErrNoHealthyNodes = errors.New("no healthy node")
+
+// MultiNodeClient is an RPC client that's connected to several kaspad nodes. It
+// health-checks the nodes periodically, sends reads to one healthy node at a time,
+// sends broadcasts to every healthy node, and keeps notification subscriptions on
+// a single node, the primary, which is replaced by another healthy node if it fails.
+type MultiNodeClient struct {
+	rpcUser     string
+	rpcPassword string
+	tlsConfig   *tls.Config
+
+	lock          sync.Mutex
+	nodes         []*multiNodeClientNode
+	nextReadNode  int
+	primaryNode   *multiNodeClientNode
+	subscriptions []func(client *RPCClient) error
+
+	onFailoverHandler   func(rpcAddress string)
+	failoverPolicy      *RetryPolicy
+	healthCheckInterval time.Duration
+	healthCheckNowChan  chan struct{}
+	closeChan           chan struct{}
+	isClosed            uint32
+}
+
+type multiNodeClientNode struct {
+	rpcAddress string
+
+	// client is nil while the node is unhealthy. The client of a node that fails
+	// a health check is closed, so that it doesn't reconnect by itself, and it's
+	// replaced by a new client once the node is reachable again
+	client *RPCClient
+}
+
+// NewMultiNodeClient connects to the kaspad nodes with the given addresses. It returns
+// an error only if none of the nodes could be connected to. The nodes that couldn't
+// be connected to are connected to once they become reachable.
+func NewMultiNodeClient(rpcAddresses []string) (*MultiNodeClient, error) {
+	return NewMultiNodeClientWithTLS(rpcAddresses, "", "", nil)
+}
+
+// NewMultiNodeClientWithTLS is like NewMultiNodeClient, but connects to the nodes
+// the same way NewRPCClientWithTLS does
+func NewMultiNodeClientWithTLS(rpcAddresses []string, rpcUser string, rpcPassword string,
+	tlsConfig *tls.Config) (*MultiNodeClient, error) {
+
+	if len(rpcAddresses) == 0 {
+		return nil, errors.Errorf("at least one RPC address is required")
+	}
+
+	multiNodeClient := &MultiNodeClient{
+		rpcUser:             rpcUser,
+		rpcPassword:         rpcPassword,
+		tlsConfig:           tlsConfig,
+		nodes:               make([]*multiNodeClientNode, len(rpcAddresses)),
+		failoverPolicy:      defaultFailoverPolicy(),
+		healthCheckInterval: defaultHealthCheckInterval,
+		healthCheckNowChan:  make(chan struct{}, 1),
+		closeChan:           make(chan struct{}),
+	}
+	for i, rpcAddress := range rpcAddresses {
+		multiNodeClient.nodes[i] = &multiNodeClientNode{rpcAddress: rpcAddress}
+	}
+	multiNodeClient.checkNodes()
+	if len(multiNodeClient.healthyNodes()) == 0 {
+		return nil, errors.Wrapf(ErrNoHealthyNodes, "could not connect to any of %s", rpcAddresses)
+	}
+
+	spawn("MultiNodeClient-healthCheckLoop", multiNodeClient.healthCheckLoop)
+	return multiNodeClient, nil
+}
+
+// SetHealthCheckInterval sets how often the nodes are health-checked. It applies
+// from the health check after the next one
+func (c *MultiNodeClient) SetHealthCheckInterval(healthCheckInterval time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.healthCheckInterval = healthCheckInterval
+}
+
+// defaultFailoverPolicy moves reads to every other healthy node, right away, unless
+// they fail with an error returned by the node
+func defaultFailoverPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries: UnlimitedRetries,
+		IsRetryable: func(err error) bool {
+			return !errors.Is(err, ErrRPC)
+		},
+	}
+}
+
+// SetFailoverPolicy sets the policy by which reads that fail are moved to the next
+// healthy node. Every retry is sent to a different node, so reads aren't retried more
+// times than there are other healthy nodes. By default, reads that fail for a reason
+// other than an error returned by the node are moved to every other healthy node
+func (c *MultiNodeClient) SetFailoverPolicy(failoverPolicy *RetryPolicy) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.failoverPolicy = failoverPolicy
+}
+
+// SetOnFailoverHandler sets a handler that's called with the address of the new primary
+// node, after the notification subscriptions are moved to it from a primary node that
+// failed. Notifications may have been missed in between, so clients that track state
+// through notifications should rescan it then
+func (c *MultiNodeClient) SetOnFailoverHandler(onFailoverHandler func(rpcAddress string)) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.onFailoverHandler = onFailoverHandler
+}
+
+// HealthyNodes returns the addresses of the nodes that passed their last health check
+func (c *MultiNodeClient) HealthyNodes() []string {
+	healthyNodes := c.healthyNodes()
+	rpcAddresses := make([]string, len(healthyNodes))
+	for i, node := range healthyNodes {
+		rpcAddresses[i] = node.rpcAddress
+	}
+	return rpcAddresses
+}
+
+// PrimaryNode returns the address of the node that notification subscriptions are sent to
+func (c *MultiNodeClient) PrimaryNode() string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.primaryNode == nil {
+		return ""
+	}
+	return c.primaryNode.rpcAddress
+}
+
+// Read calls readFunc with the client of a healthy node. The healthy nodes take turns,
+// so that reads are spread between them. If readFunc fails, and the failover policy
+// allows it, readFunc is called again with the client of the next healthy node, and
+// the node it failed on is health-checked right away
+func (c *MultiNodeClient) Read(readFunc func(client *RPCClient) error) error {
+	healthyNodes := c.healthyNodes()
+	if len(healthyNodes) == 0 {
+		return errors.WithStack(ErrNoHealthyNodes)
+	}
+
+	c.lock.Lock()
+	firstNodeIndex := c.nextReadNode
+	c.nextReadNode++
+	failoverPolicy := c.failoverPolicy
+	c.lock.Unlock()
+
+	for i := 0; ; i++ {
+		node := healthyNodes[(firstNodeIndex+i)%len(healthyNodes)]
+		err := readFunc(node.client)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, ErrRPC) {
+			log.Warnf("Reading from %s failed: %s", node.rpcAddress, err)
+			c.checkNodesNow()
+		}
+		retry := i + 1
+		if retry == len(healthyNodes) || !failoverPolicy.allowsRetry(retry, err) {
+			return err
+		}
+		time.Sleep(failoverPolicy.delay(retry))
+	}
+}
+
+// Broadcast calls broadcastFunc with the client of every healthy node, concurrently.
+// It returns an error only if broadcastFunc failed for every node
+func (c *MultiNodeClient) Broadcast(broadcastFunc func(client *RPCClient) error) error {
+	healthyNodes := c.healthyNodes()
+	if len(healthyNodes) == 0 {
+		return errors.WithStack(ErrNoHealthyNodes)
+	}
+
+	errs := make([]error, len(healthyNodes))
+	waitGroup := sync.WaitGroup{}
+	waitGroup.Add(len(healthyNodes))
+	for i, node := range healthyNodes {
+		i, node := i, node
+		spawn("MultiNodeClient.Broadcast", func() {
+			defer waitGroup.Done()
+			errs[i] = broadcastFunc(node.client)
+		})
+	}
+	waitGroup.Wait()
+
+	for i, err := range errs {
+		if err == nil {
+			return nil
+		}
+		log.Warnf("Broadcasting to %s failed: %s", healthyNodes[i].rpcAddress, err)
+	}
+	return errs[0]
+}
+
+// SubmitTransaction submits the given transaction to every healthy node, and returns the
+// response of one of the nodes that accepted it
+func (c *MultiNodeClient) SubmitTransaction(transaction *appmessage.RPCTransaction,
+	allowOrphan bool) (*appmessage.SubmitTransactionResponseMessage, error) {
+
+	var acceptedResponse *appmessage.SubmitTransactionResponseMessage
+	acceptedResponseLock := sync.Mutex{}
+	err := c.Broadcast(func(client *RPCClient) error {
+		response, err := client.SubmitTransaction(transaction, allowOrphan)
+		if err != nil {
+			return err
+		}
+		acceptedResponseLock.Lock()
+		defer acceptedResponseLock.Unlock()
+		acceptedResponse = response
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return acceptedResponse, nil
+}
+
+// SubmitBlock submits the given block to every healthy node. It returns an error only if
+// every node rejected the block, along with the reason one of them rejected it for
+func (c *MultiNodeClient) SubmitBlock(block *externalapi.DomainBlock) (appmessage.RejectReason, error) {
+	rejectReason := appmessage.RejectReasonNone
+	rejectReasonLock := sync.Mutex{}
+	err := c.Broadcast(func(client *RPCClient) error {
+		nodeRejectReason, err := client.SubmitBlock(block)
+		if err != nil {
+			rejectReasonLock.Lock()
+			defer rejectReasonLock.Unlock()
+			rejectReason = nodeRejectReason
+		}
+		return err
+	})
+	if err != nil {
+		return rejectReason, err
+	}
+	return appmessage.RejectReasonNone, nil
+}
+
+// Subscribe calls subscribeFunc, which registers for notifications or changes a
+// subscription, with the client of the primary node. It's called again with the
+// client of the next primary node whenever the primary node is replaced
+func (c *MultiNodeClient) Subscribe(subscribeFunc func(client *RPCClient) error) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.primaryNode == nil {
+		return errors.WithStack(ErrNoHealthyNodes)
+	}
+	err := subscribeFunc(c.primaryNode.client)
+	if err != nil {
+		return err
+	}
+	c.subscriptions = append(c.subscriptions, subscribeFunc)
+	return nil
+}
+
+// Close closes the connections to all the nodes
+func (c *MultiNodeClient) Close() error {
+	swapped := atomic.CompareAndSwapUint32(&c.isClosed, 0, 1)
+	if !swapped {
+		return errors.Errorf("Cannot close a client that had already been closed")
+	}
+	close(c.closeChan)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, node := range c.nodes {
+		if node.client != nil {
+			closeNodeClient(node.rpcAddress, node.client)
+			node.client = nil
+		}
+	}
+	c.primaryNode = nil
+	return nil
+}
+
+// healthyNodes returns copies of the healthy nodes, whose clients may be used without the lock
+func (c *MultiNodeClient) healthyNodes() []*multiNodeClientNode {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	healthyNodes := make([]*multiNodeClientNode, 0, len(c.nodes))
+	for _, node := range c.nodes {
+		if node.client != nil {
+			healthyNodes = append(healthyNodes, &multiNodeClientNode{rpcAddress: node.rpcAddress, client: node.client})
+		}
+	}
+	return healthyNodes
+}
+
+func (c *MultiNodeClient) checkNodesNow() {
+	select {
+	case c.healthCheckNowChan <- struct{}{}:
+	default:
+	}
+}
+
+func (c *MultiNodeClient) healthCheckLoop() {
+	for {
+		c.lock.Lock()
+		healthCheckInterval := c.healthCheckInterval
+		c.lock.Unlock()
+
+		select {
+		case <-c.closeChan:
+			return
+		case <-c.healthCheckNowChan:
+		case <-time.After(healthCheckInterval):
+		}
+		c.checkNodes()
+	}
+}
+
+// checkNodes health-checks every node, connects to the nodes that became reachable,
+// and replaces the primary node if it's no longer healthy
+func (c *MultiNodeClient) checkNodes() {
+	waitGroup := sync.WaitGroup{}
+	waitGroup.Add(len(c.nodes))
+	for _, node := range c.nodes {
+		node := node
+		spawn("MultiNodeClient.checkNodes", func() {
+			defer waitGroup.Done()
+			c.checkNode(node)
+		})
+	}
+	waitGroup.Wait()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if atomic.LoadUint32(&c.isClosed) == 1 {
+		return
+	}
+	if c.primaryNode != nil && c.primaryNode.client != nil {
+		return
+	}
+	c.failOver()
+}
+
+func (c *MultiNodeClient) checkNode(node *multiNodeClientNode) {
+	c.lock.Lock()
+	client := node.client
+	c.lock.Unlock()
+
+	if client == nil {
+		var err error
+		client, err = NewRPCClientWithTLS(node.rpcAddress, c.rpcUser, c.rpcPassword, c.tlsConfig)
+		if err != nil {
+			log.Debugf("Node %s is unreachable: %s", node.rpcAddress, err)
+			return
+		}
+	}
+
+	err := checkNodeHealth(client)
+	if err != nil {
+		log.Warnf("Node %s failed its health check: %s", node.rpcAddress, err)
+		closeNodeClient(node.rpcAddress, client)
+		client = nil
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// The client may have been closed since the node was checked
+	if atomic.LoadUint32(&c.isClosed) == 1 {
+		if client != nil && client != node.client {
+			closeNodeClient(node.rpcAddress, client)
+		}
+		return
+	}
+	if client != nil && node.client == nil {
+		log.Infof("Node %s is healthy", node.rpcAddress)
+	}
+	node.client = client
+}
+
+func checkNodeHealth(client *RPCClient) error {
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+
+	response, err := client.HealthCheckContext(ctx)
+	if err != nil {
+		return err
+	}
+	if !response.IsHealthy {
+		return errors.Errorf("the node is unhealthy: %s", response.FailedChecks)
+	}
+	return nil
+}
+
+// failOver makes the first healthy node that accepts all the notification
+// subscriptions the primary node. It must be called with the lock held
+func (c *MultiNodeClient) failOver() {
+	previousPrimaryNode := c.primaryNode
+	c.primaryNode = nil
+	for _, node := range c.nodes {
+		if node.client == nil {
+			continue
+		}
+		err := c.subscribe(node.client)
+		if err != nil {
+			// The client may have some of the subscriptions, so it's replaced
+			// by a new one once the node passes a health check again
+			log.Warnf("Could not move the notification subscriptions to %s: %s", node.rpcAddress, err)
+			closeNodeClient(node.rpcAddress, node.client)
+			node.client = nil
+			continue
+		}
+		c.primaryNode = node
+		break
+	}
+	if c.primaryNode == nil {
+		log.Warnf("No healthy node to send notifications")
+		return
+	}
+	if previousPrimaryNode == nil && len(c.subscriptions) == 0 {
+		return
+	}
+
+	log.Infof("Node %s is the new primary node", c.primaryNode.rpcAddress)
+	if c.onFailoverHandler != nil {
+		onFailoverHandler := c.onFailoverHandler
+		rpcAddress := c.primaryNode.rpcAddress
+		spawn("MultiNodeClient-onFailoverHandler", func() {
+			onFailoverHandler(rpcAddress)
+		})
+	}
+}
+
+func closeNodeClient(rpcAddress string, client *RPCClient) {
+	err := client.Close()
+	if err != nil {
+		log.Warnf("Error closing the connection to %s: %s", rpcAddress, err)
+	}
+}
+
+func (c *MultiNodeClient) subscribe(client *RPCClient) error {
+	for _, subscribeFunc := range c.subscriptions {
+		err := subscribeFunc(client)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package rpcclient
+
+import (
+	"context"
+	"time"
+
+	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+// UnlimitedRetries is the MaxRetries of a RetryPolicy that retries until it succeeds
+const UnlimitedRetries = -1
+
+// RetryPolicy decides whether and when an operation that failed is tried again. The
+// client applies it to the requests it sends, to reconnecting after the connection to
+// the RPC server is lost, and MultiNodeClient applies it to moving reads to another node.
+//
+// A nil RetryPolicy never retries
+type RetryPolicy struct {
+	// MaxRetries is the maximum amount of times an operation is tried again after it
+	// fails. UnlimitedRetries means there's no maximum
+	MaxRetries int
+
+	// Backoff returns how long to wait before every retry. If it's nil, operations
+	// are retried right away
+	Backoff BackoffFunc
+
+	// IsRetryable returns whether an operation that failed with the given error may be
+	// tried again. If it's nil, every error is retryable
+	IsRetryable func(err error) bool
+}
+
+// BackoffFunc returns how long to wait before the given retry. The first retry is retry 1
+type BackoffFunc func(retry int) time.Duration
+
+// ConstantBackoff returns a BackoffFunc that waits the given delay before every retry
+func ConstantBackoff(delay time.Duration) BackoffFunc {
+	return func(_ int) time.Duration {
+		return delay
+	}
+}
+
+// ExponentialBackoff returns a BackoffFunc that waits initialDelay before the first
+// retry, and doubles the delay before every further retry, up to maxDelay
+func ExponentialBackoff(initialDelay time.Duration, maxDelay time.Duration) BackoffFunc {
+	return func(retry int) time.Duration {
+		delay := initialDelay
+		for i := 1; i < retry && delay < maxDelay; i++ {
+			delay *= 2
+		}
+		if delay > maxDelay {
+			return maxDelay
+		}
+		return delay
+	}
+}
+
+// IsTransientError returns whether err is an error that trying again may resolve: the RPC
+// server not answering in time, or the connection to it being lost. Errors the RPC server
+// answered with, and errors of requests whose context is done, aren't transient
+func IsTransientError(err error) bool {
+	if errors.Is(err, ErrRPC) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	return errors.Is(err, routerpkg.ErrTimeout) || errors.Is(err, routerpkg.ErrRouteClosed) ||
+		errors.Is(err, routerpkg.ErrRouteCapacityReached)
+}
+
+// defaultReconnectPolicy keeps trying to reconnect, waiting 500 milliseconds after the
+// first failed attempt and doubling the delay after every further one, up to a minute
+func defaultReconnectPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries: UnlimitedRetries,
+		Backoff:    ExponentialBackoff(500*time.Millisecond, time.Minute),
+	}
+}
+
+// allowsRetry returns whether the given retry of an operation that failed with err is allowed
+func (p *RetryPolicy) allowsRetry(retry int, err error) bool {
+	if p == nil {
+		return false
+	}
+	if p.MaxRetries != UnlimitedRetries && retry > p.MaxRetries {
+		return false
+	}
+	return p.IsRetryable == nil || p.IsRetryable(err)
+}
+
+// delay returns how long to wait before the given retry
+func (p *RetryPolicy) delay(retry int) time.Duration {
+	if p == nil || p.Backoff == nil {
+		return 0
+	}
+	return p.Backoff(retry)
+}
+
+// sleepContext waits for the given delay. It returns false if ctx is done first
+func sleepContext(ctx context.Context, delay time.Duration) bool {
+	if delay <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+type retryPolicyContextKey struct{}
+
+// WithRetryPolicy returns a copy of ctx that makes the requests it's given to override
+// the client's retry policy with the given one. A nil policy makes them never retry
+func WithRetryPolicy(ctx context.Context, retryPolicy *RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyContextKey{}, retryPolicy)
+}
+
+// SetRetryPolicy sets the policy by which requests that fail are sent again. Requests
+// may override it using WithRetryPolicy. By default, requests aren't retried.
+//
+// Only requests that didn't get a response are retried, so an error the RPC server
+// answered a request with never causes it to be sent again. Requests that aren't
+// idempotent, such as SubmitTransaction, may be handled by the RPC server more than once
+// when they're retried after a timeout, so policies usually only retry timeouts of
+// requests that are safe to send again
+func (c *RPCClient) SetRetryPolicy(retryPolicy *RetryPolicy) {
+	c.retryPolicy = retryPolicy
+}
+
+// SetReconnectPolicy sets the policy by which the client attempts to reconnect after the
+// connection to the RPC server is lost, or after Reconnect is called. If the client runs out
+// of retries, it stays disconnected until Reconnect is called. By default, it attempts to
+// reconnect until it succeeds, with an exponential backoff of up to a minute
+func (c *RPCClient) SetReconnectPolicy(reconnectPolicy *RetryPolicy) {
+	c.reconnectPolicy = reconnectPolicy
+}
+
+func (c *RPCClient) requestRetryPolicy(ctx context.Context) *RetryPolicy {
+	if retryPolicy, ok := ctx.Value(retryPolicyContextKey{}).(*RetryPolicy); ok {
+		return retryPolicy
+	}
+	return c.retryPolicy
+}
+
+// sendCallsWithRetries sends the given calls, and sends the calls that didn't get a
+// response again as long as the retry policy of ctx allows it
+func (c *RPCClient) sendCallsWithRetries(ctx context.Context, calls []*batchCall) error {
+	retryPolicy := c.requestRetryPolicy(ctx)
+	for retry := 1; ; retry++ {
+		err := c.sendCalls(ctx, calls)
+		if err == nil {
+			return nil
+		}
+		if !retryPolicy.allowsRetry(retry, err) {
+			return err
+		}
+
+		unansweredCalls := make([]*batchCall, 0, len(calls))
+		for _, call := range calls {
+			if call.err != nil {
+				unansweredCalls = append(unansweredCalls, call)
+			}
+		}
+		delay := retryPolicy.delay(retry)
+		log.Debugf("Sending %d requests to %s again in %s: %s", len(unansweredCalls), c.rpcAddress, delay, err)
+		if !sleepContext(ctx, delay) {
+			return err
+		}
+		calls = unansweredCalls
+	}
+}
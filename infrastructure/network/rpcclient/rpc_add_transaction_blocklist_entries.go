@@ -0,0 +1,39 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// AddTransactionBlocklistEntries sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) AddTransactionBlocklistEntries(entries []*appmessage.TransactionBlocklistEntry) (*appmessage.AddTransactionBlocklistEntriesResponseMessage, error) {
+	return c.AddTransactionBlocklistEntriesContext(context.Background(), entries)
+}
+
+// AddTransactionBlocklistEntriesContext operates the same as AddTransactionBlocklistEntries, except that it gives up waiting for the
+// response once ctx is done
+func (c *RPCClient) AddTransactionBlocklistEntriesContext(ctx context.Context, entries []*appmessage.TransactionBlocklistEntry) (
+	*appmessage.AddTransactionBlocklistEntriesResponseMessage, error) {
+
+	response, err := c.request(ctx, appmessage.NewAddTransactionBlocklistEntriesRequestMessage(entries),
+		appmessage.CmdAddTransactionBlocklistEntriesResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	addTransactionBlocklistEntriesResponse := response.(*appmessage.AddTransactionBlocklistEntriesResponseMessage)
+	if addTransactionBlocklistEntriesResponse.Error != nil {
+		return nil, c.convertRPCError(addTransactionBlocklistEntriesResponse.Error)
+	}
+	return addTransactionBlocklistEntriesResponse, nil
+}
+
+// AddTransactionBlocklistEntriesAsync operates the same as AddTransactionBlocklistEntriesContext, except that it doesn't wait for the
+// response, and calls handler with the results once they're received
+func (c *RPCClient) AddTransactionBlocklistEntriesAsync(ctx context.Context, entries []*appmessage.TransactionBlocklistEntry,
+	handler func(*appmessage.AddTransactionBlocklistEntriesResponseMessage, error)) {
+
+	spawn("AddTransactionBlocklistEntriesAsync", func() {
+		handler(c.AddTransactionBlocklistEntriesContext(ctx, entries))
+	})
+}
@@ -0,0 +1,35 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// BackupDatabase sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) BackupDatabase(targetDirectory string) (*appmessage.BackupDatabaseResponseMessage, error) {
+	return c.BackupDatabaseContext(context.Background(), targetDirectory)
+}
+
+// BackupDatabaseContext operates the same as BackupDatabase, except that it gives up waiting for the response
+// once ctx is done
+func (c *RPCClient) BackupDatabaseContext(ctx context.Context, targetDirectory string) (*appmessage.BackupDatabaseResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewBackupDatabaseRequestMessage(targetDirectory),
+		appmessage.CmdBackupDatabaseResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	backupDatabaseResponse := response.(*appmessage.BackupDatabaseResponseMessage)
+	if backupDatabaseResponse.Error != nil {
+		return nil, c.convertRPCError(backupDatabaseResponse.Error)
+	}
+	return backupDatabaseResponse, nil
+}
+
+// BackupDatabaseAsync operates the same as BackupDatabaseContext, except that it doesn't wait for the response,
+// and calls handler with the results once they're received
+func (c *RPCClient) BackupDatabaseAsync(ctx context.Context, targetDirectory string, handler func(*appmessage.BackupDatabaseResponseMessage, error)) {
+	spawn("BackupDatabaseAsync", func() {
+		handler(c.BackupDatabaseContext(ctx, targetDirectory))
+	})
+}
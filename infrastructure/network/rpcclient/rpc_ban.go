@@ -1,14 +1,19 @@
 package rpcclient
 
-import "github.com/kaspanet/kaspad/app/appmessage"
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
 
 // Ban sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) Ban(ip string) (*appmessage.BanResponseMessage, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewBanRequestMessage(ip))
-	if err != nil {
-		return nil, err
-	}
-	response, err := c.route(appmessage.CmdBanRequestMessage).DequeueWithTimeout(c.timeout)
+	return c.BanContext(context.Background(), ip)
+}
+
+// BanContext operates the same as Ban, except that it gives up waiting for the response once ctx is done
+func (c *RPCClient) BanContext(ctx context.Context, ip string) (*appmessage.BanResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewBanRequestMessage(ip), appmessage.CmdBanRequestMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -18,3 +23,11 @@ func (c *RPCClient) Ban(ip string) (*appmessage.BanResponseMessage, error) {
 	}
 	return banResponse, nil
 }
+
+// BanAsync operates the same as BanContext, except that it doesn't wait for the response, and calls handler with the
+// results once they're received
+func (c *RPCClient) BanAsync(ctx context.Context, ip string, handler func(*appmessage.BanResponseMessage, error)) {
+	spawn("BanAsync", func() {
+		handler(c.BanContext(ctx, ip))
+	})
+}
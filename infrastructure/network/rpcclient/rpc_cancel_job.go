@@ -0,0 +1,35 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// CancelJob sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) CancelJob(jobID uint64) (*appmessage.CancelJobResponseMessage, error) {
+	return c.CancelJobContext(context.Background(), jobID)
+}
+
+// CancelJobContext operates the same as CancelJob, except that it gives up waiting for the response
+// once ctx is done
+func (c *RPCClient) CancelJobContext(ctx context.Context, jobID uint64) (*appmessage.CancelJobResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewCancelJobRequestMessage(jobID),
+		appmessage.CmdCancelJobResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	cancelJobResponse := response.(*appmessage.CancelJobResponseMessage)
+	if cancelJobResponse.Error != nil {
+		return nil, c.convertRPCError(cancelJobResponse.Error)
+	}
+	return cancelJobResponse, nil
+}
+
+// CancelJobAsync operates the same as CancelJobContext, except that it doesn't wait for the response,
+// and calls handler with the results once they're received
+func (c *RPCClient) CancelJobAsync(ctx context.Context, jobID uint64, handler func(*appmessage.CancelJobResponseMessage, error)) {
+	spawn("CancelJobAsync", func() {
+		handler(c.CancelJobContext(ctx, jobID))
+	})
+}
@@ -0,0 +1,35 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// CaptureProfile sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) CaptureProfile(profileType string, durationSeconds uint32, includeData bool) (*appmessage.CaptureProfileResponseMessage, error) {
+	return c.CaptureProfileContext(context.Background(), profileType, durationSeconds, includeData)
+}
+
+// CaptureProfileContext operates the same as CaptureProfile, except that it gives up waiting for the response
+// once ctx is done
+func (c *RPCClient) CaptureProfileContext(ctx context.Context, profileType string, durationSeconds uint32, includeData bool) (*appmessage.CaptureProfileResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewCaptureProfileRequestMessage(profileType, durationSeconds, includeData),
+		appmessage.CmdCaptureProfileResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	captureProfileResponse := response.(*appmessage.CaptureProfileResponseMessage)
+	if captureProfileResponse.Error != nil {
+		return nil, c.convertRPCError(captureProfileResponse.Error)
+	}
+	return captureProfileResponse, nil
+}
+
+// CaptureProfileAsync operates the same as CaptureProfileContext, except that it doesn't wait for the response,
+// and calls handler with the results once they're received
+func (c *RPCClient) CaptureProfileAsync(ctx context.Context, profileType string, durationSeconds uint32, includeData bool, handler func(*appmessage.CaptureProfileResponseMessage, error)) {
+	spawn("CaptureProfileAsync", func() {
+		handler(c.CaptureProfileContext(ctx, profileType, durationSeconds, includeData))
+	})
+}
@@ -0,0 +1,35 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// ClearBanned sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) ClearBanned() (*appmessage.ClearBannedResponseMessage, error) {
+	return c.ClearBannedContext(context.Background())
+}
+
+// ClearBannedContext operates the same as ClearBanned, except that it gives up waiting for the response once ctx is
+// done
+func (c *RPCClient) ClearBannedContext(ctx context.Context) (*appmessage.ClearBannedResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewClearBannedRequestMessage(),
+		appmessage.CmdClearBannedResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	clearBannedResponse := response.(*appmessage.ClearBannedResponseMessage)
+	if clearBannedResponse.Error != nil {
+		return nil, c.convertRPCError(clearBannedResponse.Error)
+	}
+	return clearBannedResponse, nil
+}
+
+// ClearBannedAsync operates the same as ClearBannedContext, except that it doesn't wait for the response, and calls
+// handler with the results once they're received
+func (c *RPCClient) ClearBannedAsync(ctx context.Context, handler func(*appmessage.ClearBannedResponseMessage, error)) {
+	spawn("ClearBannedAsync", func() {
+		handler(c.ClearBannedContext(ctx))
+	})
+}
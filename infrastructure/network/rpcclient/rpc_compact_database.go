@@ -0,0 +1,35 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// CompactDatabase sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) CompactDatabase() (*appmessage.CompactDatabaseResponseMessage, error) {
+	return c.CompactDatabaseContext(context.Background())
+}
+
+// CompactDatabaseContext operates the same as CompactDatabase, except that it gives up waiting for the response
+// once ctx is done
+func (c *RPCClient) CompactDatabaseContext(ctx context.Context) (*appmessage.CompactDatabaseResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewCompactDatabaseRequestMessage(),
+		appmessage.CmdCompactDatabaseResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	compactDatabaseResponse := response.(*appmessage.CompactDatabaseResponseMessage)
+	if compactDatabaseResponse.Error != nil {
+		return nil, c.convertRPCError(compactDatabaseResponse.Error)
+	}
+	return compactDatabaseResponse, nil
+}
+
+// CompactDatabaseAsync operates the same as CompactDatabaseContext, except that it doesn't wait for the response,
+// and calls handler with the results once they're received
+func (c *RPCClient) CompactDatabaseAsync(ctx context.Context, handler func(*appmessage.CompactDatabaseResponseMessage, error)) {
+	spawn("CompactDatabaseAsync", func() {
+		handler(c.CompactDatabaseContext(ctx))
+	})
+}
@@ -1,14 +1,20 @@
 package rpcclient
 
-import "github.com/kaspanet/kaspad/app/appmessage"
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
 
 // AddPeer sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) AddPeer(address string, isPermanent bool) error {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewAddPeerRequestMessage(address, isPermanent))
-	if err != nil {
-		return err
-	}
-	response, err := c.route(appmessage.CmdAddPeerResponseMessage).DequeueWithTimeout(c.timeout)
+	return c.AddPeerContext(context.Background(), address, isPermanent)
+}
+
+// AddPeerContext operates the same as AddPeer, except that it gives up waiting for the response once ctx is done
+func (c *RPCClient) AddPeerContext(ctx context.Context, address string, isPermanent bool) error {
+	response, err := c.request(ctx, appmessage.NewAddPeerRequestMessage(address, isPermanent),
+		appmessage.CmdAddPeerResponseMessage)
 	if err != nil {
 		return err
 	}
@@ -18,3 +24,11 @@ func (c *RPCClient) AddPeer(address string, isPermanent bool) error {
 	}
 	return nil
 }
+
+// AddPeerAsync operates the same as AddPeerContext, except that it doesn't wait for the response, and calls handler
+// with the results once they're received
+func (c *RPCClient) AddPeerAsync(ctx context.Context, address string, isPermanent bool, handler func(error)) {
+	spawn("AddPeerAsync", func() {
+		handler(c.AddPeerContext(ctx, address, isPermanent))
+	})
+}
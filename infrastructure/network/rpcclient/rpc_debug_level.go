@@ -0,0 +1,35 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// DebugLevel sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) DebugLevel(levelSpec string) (*appmessage.DebugLevelResponseMessage, error) {
+	return c.DebugLevelContext(context.Background(), levelSpec)
+}
+
+// DebugLevelContext operates the same as DebugLevel, except that it gives up waiting for the response
+// once ctx is done
+func (c *RPCClient) DebugLevelContext(ctx context.Context, levelSpec string) (*appmessage.DebugLevelResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewDebugLevelRequestMessage(levelSpec),
+		appmessage.CmdDebugLevelResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	debugLevelResponse := response.(*appmessage.DebugLevelResponseMessage)
+	if debugLevelResponse.Error != nil {
+		return nil, c.convertRPCError(debugLevelResponse.Error)
+	}
+	return debugLevelResponse, nil
+}
+
+// DebugLevelAsync operates the same as DebugLevelContext, except that it doesn't wait for the response,
+// and calls handler with the results once they're received
+func (c *RPCClient) DebugLevelAsync(ctx context.Context, levelSpec string, handler func(*appmessage.DebugLevelResponseMessage, error)) {
+	spawn("DebugLevelAsync", func() {
+		handler(c.DebugLevelContext(ctx, levelSpec))
+	})
+}
@@ -0,0 +1,35 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// DropIndex sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) DropIndex(indexName string) (*appmessage.DropIndexResponseMessage, error) {
+	return c.DropIndexContext(context.Background(), indexName)
+}
+
+// DropIndexContext operates the same as DropIndex, except that it gives up waiting for the response
+// once ctx is done
+func (c *RPCClient) DropIndexContext(ctx context.Context, indexName string) (*appmessage.DropIndexResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewDropIndexRequestMessage(indexName),
+		appmessage.CmdDropIndexResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	dropIndexResponse := response.(*appmessage.DropIndexResponseMessage)
+	if dropIndexResponse.Error != nil {
+		return nil, c.convertRPCError(dropIndexResponse.Error)
+	}
+	return dropIndexResponse, nil
+}
+
+// DropIndexAsync operates the same as DropIndexContext, except that it doesn't wait for the response,
+// and calls handler with the results once they're received
+func (c *RPCClient) DropIndexAsync(ctx context.Context, indexName string, handler func(*appmessage.DropIndexResponseMessage, error)) {
+	spawn("DropIndexAsync", func() {
+		handler(c.DropIndexContext(ctx, indexName))
+	})
+}
@@ -0,0 +1,36 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// DumpUTXOSet sends an RPC request respective to the function's name and returns the RPC server's response.
+// If runAsJob is true, the response only holds the ID of the job that writes the file. See GetJobs
+func (c *RPCClient) DumpUTXOSet(filePath string, runAsJob bool) (*appmessage.DumpUTXOSetResponseMessage, error) {
+	return c.DumpUTXOSetContext(context.Background(), filePath, runAsJob)
+}
+
+// DumpUTXOSetContext operates the same as DumpUTXOSet, except that it gives up waiting for the response once ctx is
+// done
+func (c *RPCClient) DumpUTXOSetContext(ctx context.Context, filePath string, runAsJob bool) (*appmessage.DumpUTXOSetResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewDumpUTXOSetRequestMessage(filePath, runAsJob),
+		appmessage.CmdDumpUTXOSetResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	dumpUTXOSetResponse := response.(*appmessage.DumpUTXOSetResponseMessage)
+	if dumpUTXOSetResponse.Error != nil {
+		return nil, c.convertRPCError(dumpUTXOSetResponse.Error)
+	}
+	return dumpUTXOSetResponse, nil
+}
+
+// DumpUTXOSetAsync operates the same as DumpUTXOSetContext, except that it doesn't wait for the response, and calls
+// handler with the results once they're received
+func (c *RPCClient) DumpUTXOSetAsync(ctx context.Context, filePath string, runAsJob bool, handler func(*appmessage.DumpUTXOSetResponseMessage, error)) {
+	spawn("DumpUTXOSetAsync", func() {
+		handler(c.DumpUTXOSetContext(ctx, filePath, runAsJob))
+	})
+}
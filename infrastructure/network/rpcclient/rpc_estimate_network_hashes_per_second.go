@@ -1,14 +1,21 @@
 package rpcclient
 
-import "github.com/kaspanet/kaspad/app/appmessage"
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
 
 // EstimateNetworkHashesPerSecond sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) EstimateNetworkHashesPerSecond(startHash string, windowSize uint32) (*appmessage.EstimateNetworkHashesPerSecondResponseMessage, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewEstimateNetworkHashesPerSecondRequestMessage(startHash, windowSize))
-	if err != nil {
-		return nil, err
-	}
-	response, err := c.route(appmessage.CmdEstimateNetworkHashesPerSecondResponseMessage).DequeueWithTimeout(c.timeout)
+	return c.EstimateNetworkHashesPerSecondContext(context.Background(), startHash, windowSize)
+}
+
+// EstimateNetworkHashesPerSecondContext operates the same as EstimateNetworkHashesPerSecond, except that it gives up
+// waiting for the response once ctx is done
+func (c *RPCClient) EstimateNetworkHashesPerSecondContext(ctx context.Context, startHash string, windowSize uint32) (*appmessage.EstimateNetworkHashesPerSecondResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewEstimateNetworkHashesPerSecondRequestMessage(startHash, windowSize),
+		appmessage.CmdEstimateNetworkHashesPerSecondResponseMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -18,3 +25,11 @@ func (c *RPCClient) EstimateNetworkHashesPerSecond(startHash string, windowSize
 	}
 	return estimateNetworkHashesPerSecondResponse, nil
 }
+
+// EstimateNetworkHashesPerSecondAsync operates the same as EstimateNetworkHashesPerSecondContext, except that it
+// doesn't wait for the response, and calls handler with the results once they're received
+func (c *RPCClient) EstimateNetworkHashesPerSecondAsync(ctx context.Context, startHash string, windowSize uint32, handler func(*appmessage.EstimateNetworkHashesPerSecondResponseMessage, error)) {
+	spawn("EstimateNetworkHashesPerSecondAsync", func() {
+		handler(c.EstimateNetworkHashesPerSecondContext(ctx, startHash, windowSize))
+	})
+}
@@ -0,0 +1,35 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// ExportBlocks sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) ExportBlocks(filePath string, lowHash string, highHash string) (*appmessage.ExportBlocksResponseMessage, error) {
+	return c.ExportBlocksContext(context.Background(), filePath, lowHash, highHash)
+}
+
+// ExportBlocksContext operates the same as ExportBlocks, except that it gives up waiting for the response
+// once ctx is done
+func (c *RPCClient) ExportBlocksContext(ctx context.Context, filePath string, lowHash string, highHash string) (*appmessage.ExportBlocksResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewExportBlocksRequestMessage(filePath, lowHash, highHash),
+		appmessage.CmdExportBlocksResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	exportBlocksResponse := response.(*appmessage.ExportBlocksResponseMessage)
+	if exportBlocksResponse.Error != nil {
+		return nil, c.convertRPCError(exportBlocksResponse.Error)
+	}
+	return exportBlocksResponse, nil
+}
+
+// ExportBlocksAsync operates the same as ExportBlocksContext, except that it doesn't wait for the response,
+// and calls handler with the results once they're received
+func (c *RPCClient) ExportBlocksAsync(ctx context.Context, filePath string, lowHash string, highHash string, handler func(*appmessage.ExportBlocksResponseMessage, error)) {
+	spawn("ExportBlocksAsync", func() {
+		handler(c.ExportBlocksContext(ctx, filePath, lowHash, highHash))
+	})
+}
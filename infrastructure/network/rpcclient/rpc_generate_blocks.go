@@ -0,0 +1,35 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// GenerateBlocks sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GenerateBlocks(payAddress string, count uint32, parentHashes []string, timestamp int64) (*appmessage.GenerateBlocksResponseMessage, error) {
+	return c.GenerateBlocksContext(context.Background(), payAddress, count, parentHashes, timestamp)
+}
+
+// GenerateBlocksContext operates the same as GenerateBlocks, except that it gives up waiting for the response once ctx
+// is done
+func (c *RPCClient) GenerateBlocksContext(ctx context.Context, payAddress string, count uint32, parentHashes []string, timestamp int64) (*appmessage.GenerateBlocksResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGenerateBlocksRequestMessage(payAddress, count, parentHashes, timestamp),
+		appmessage.CmdGenerateBlocksResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	generateBlocksResponse := response.(*appmessage.GenerateBlocksResponseMessage)
+	if generateBlocksResponse.Error != nil {
+		return nil, c.convertRPCError(generateBlocksResponse.Error)
+	}
+	return generateBlocksResponse, nil
+}
+
+// GenerateBlocksAsync operates the same as GenerateBlocksContext, except that it doesn't wait for the response, and
+// calls handler with the results once they're received
+func (c *RPCClient) GenerateBlocksAsync(ctx context.Context, payAddress string, count uint32, parentHashes []string, timestamp int64, handler func(*appmessage.GenerateBlocksResponseMessage, error)) {
+	spawn("GenerateBlocksAsync", func() {
+		handler(c.GenerateBlocksContext(ctx, payAddress, count, parentHashes, timestamp))
+	})
+}
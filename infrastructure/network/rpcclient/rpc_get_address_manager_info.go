@@ -0,0 +1,35 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// GetAddressManagerInfo sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetAddressManagerInfo() (*appmessage.GetAddressManagerInfoResponseMessage, error) {
+	return c.GetAddressManagerInfoContext(context.Background())
+}
+
+// GetAddressManagerInfoContext operates the same as GetAddressManagerInfo, except that it gives up waiting for the response
+// once ctx is done
+func (c *RPCClient) GetAddressManagerInfoContext(ctx context.Context) (*appmessage.GetAddressManagerInfoResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGetAddressManagerInfoRequestMessage(),
+		appmessage.CmdGetAddressManagerInfoResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	getAddressManagerInfoResponse := response.(*appmessage.GetAddressManagerInfoResponseMessage)
+	if getAddressManagerInfoResponse.Error != nil {
+		return nil, c.convertRPCError(getAddressManagerInfoResponse.Error)
+	}
+	return getAddressManagerInfoResponse, nil
+}
+
+// GetAddressManagerInfoAsync operates the same as GetAddressManagerInfoContext, except that it doesn't wait for the response,
+// and calls handler with the results once they're received
+func (c *RPCClient) GetAddressManagerInfoAsync(ctx context.Context, handler func(*appmessage.GetAddressManagerInfoResponseMessage, error)) {
+	spawn("GetAddressManagerInfoAsync", func() {
+		handler(c.GetAddressManagerInfoContext(ctx))
+	})
+}
@@ -0,0 +1,35 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// GetAuxCommitmentProof sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetAuxCommitmentProof(blockHash string) (*appmessage.GetAuxCommitmentProofResponseMessage, error) {
+	return c.GetAuxCommitmentProofContext(context.Background(), blockHash)
+}
+
+// GetAuxCommitmentProofContext operates the same as GetAuxCommitmentProof, except that it gives up waiting for the
+// response once ctx is done
+func (c *RPCClient) GetAuxCommitmentProofContext(ctx context.Context, blockHash string) (*appmessage.GetAuxCommitmentProofResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGetAuxCommitmentProofRequestMessage(blockHash),
+		appmessage.CmdGetAuxCommitmentProofResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	getAuxCommitmentProofResponse := response.(*appmessage.GetAuxCommitmentProofResponseMessage)
+	if getAuxCommitmentProofResponse.Error != nil {
+		return nil, c.convertRPCError(getAuxCommitmentProofResponse.Error)
+	}
+	return getAuxCommitmentProofResponse, nil
+}
+
+// GetAuxCommitmentProofAsync operates the same as GetAuxCommitmentProofContext, except that it doesn't wait for the
+// response, and calls handler with the results once they're received
+func (c *RPCClient) GetAuxCommitmentProofAsync(ctx context.Context, blockHash string, handler func(*appmessage.GetAuxCommitmentProofResponseMessage, error)) {
+	spawn("GetAuxCommitmentProofAsync", func() {
+		handler(c.GetAuxCommitmentProofContext(ctx, blockHash))
+	})
+}
@@ -1,14 +1,21 @@
 package rpcclient
 
-import "github.com/kaspanet/kaspad/app/appmessage"
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
 
 // GetBalanceByAddress sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) GetBalanceByAddress(address string) (*appmessage.GetBalanceByAddressResponseMessage, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetBalanceByAddressRequest(address))
-	if err != nil {
-		return nil, err
-	}
-	response, err := c.route(appmessage.CmdGetBalanceByAddressResponseMessage).DequeueWithTimeout(c.timeout)
+	return c.GetBalanceByAddressContext(context.Background(), address)
+}
+
+// GetBalanceByAddressContext operates the same as GetBalanceByAddress, except that it gives up waiting for the response
+// once ctx is done
+func (c *RPCClient) GetBalanceByAddressContext(ctx context.Context, address string) (*appmessage.GetBalanceByAddressResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGetBalanceByAddressRequest(address),
+		appmessage.CmdGetBalanceByAddressResponseMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -18,3 +25,11 @@ func (c *RPCClient) GetBalanceByAddress(address string) (*appmessage.GetBalanceB
 	}
 	return getBalanceByAddressResponse, nil
 }
+
+// GetBalanceByAddressAsync operates the same as GetBalanceByAddressContext, except that it doesn't wait for the
+// response, and calls handler with the results once they're received
+func (c *RPCClient) GetBalanceByAddressAsync(ctx context.Context, address string, handler func(*appmessage.GetBalanceByAddressResponseMessage, error)) {
+	spawn("GetBalanceByAddressAsync", func() {
+		handler(c.GetBalanceByAddressContext(ctx, address))
+	})
+}
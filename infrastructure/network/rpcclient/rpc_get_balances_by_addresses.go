@@ -1,14 +1,21 @@
 package rpcclient
 
-import "github.com/kaspanet/kaspad/app/appmessage"
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
 
 // GetBalancesByAddresses sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) GetBalancesByAddresses(addresses []string) (*appmessage.GetBalancesByAddressesResponseMessage, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetBalancesByAddressesRequest(addresses))
-	if err != nil {
-		return nil, err
-	}
-	response, err := c.route(appmessage.CmdGetBalancesByAddressesResponseMessage).DequeueWithTimeout(c.timeout)
+	return c.GetBalancesByAddressesContext(context.Background(), addresses)
+}
+
+// GetBalancesByAddressesContext operates the same as GetBalancesByAddresses, except that it gives up waiting for the
+// response once ctx is done
+func (c *RPCClient) GetBalancesByAddressesContext(ctx context.Context, addresses []string) (*appmessage.GetBalancesByAddressesResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGetBalancesByAddressesRequest(addresses),
+		appmessage.CmdGetBalancesByAddressesResponseMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -18,3 +25,11 @@ func (c *RPCClient) GetBalancesByAddresses(addresses []string) (*appmessage.GetB
 	}
 	return getBalancesByAddressesResponse, nil
 }
+
+// GetBalancesByAddressesAsync operates the same as GetBalancesByAddressesContext, except that it doesn't wait for the
+// response, and calls handler with the results once they're received
+func (c *RPCClient) GetBalancesByAddressesAsync(ctx context.Context, addresses []string, handler func(*appmessage.GetBalancesByAddressesResponseMessage, error)) {
+	spawn("GetBalancesByAddressesAsync", func() {
+		handler(c.GetBalancesByAddressesContext(ctx, addresses))
+	})
+}
@@ -1,17 +1,24 @@
 package rpcclient
 
-import "github.com/kaspanet/kaspad/app/appmessage"
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
 
 // GetBlock sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) GetBlock(hash string, includeTransactions bool) (
 	*appmessage.GetBlockResponseMessage, error) {
 
-	err := c.rpcRouter.outgoingRoute().Enqueue(
-		appmessage.NewGetBlockRequestMessage(hash, includeTransactions))
-	if err != nil {
-		return nil, err
-	}
-	response, err := c.route(appmessage.CmdGetBlockResponseMessage).DequeueWithTimeout(c.timeout)
+	return c.GetBlockContext(context.Background(), hash, includeTransactions)
+}
+
+// GetBlockContext operates the same as GetBlock, except that it gives up waiting for the response once ctx is done
+func (c *RPCClient) GetBlockContext(ctx context.Context, hash string, includeTransactions bool) (
+	*appmessage.GetBlockResponseMessage, error) {
+
+	response, err := c.request(ctx, appmessage.NewGetBlockRequestMessage(hash, includeTransactions),
+		appmessage.CmdGetBlockResponseMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -21,3 +28,11 @@ func (c *RPCClient) GetBlock(hash string, includeTransactions bool) (
 	}
 	return GetBlockResponse, nil
 }
+
+// GetBlockAsync operates the same as GetBlockContext, except that it doesn't wait for the response, and calls handler
+// with the results once they're received
+func (c *RPCClient) GetBlockAsync(ctx context.Context, hash string, includeTransactions bool, handler func(*appmessage.GetBlockResponseMessage, error)) {
+	spawn("GetBlockAsync", func() {
+		handler(c.GetBlockContext(ctx, hash, includeTransactions))
+	})
+}
@@ -1,14 +1,21 @@
 package rpcclient
 
-import "github.com/kaspanet/kaspad/app/appmessage"
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
 
 // GetBlockCount sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) GetBlockCount() (*appmessage.GetBlockCountResponseMessage, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetBlockCountRequestMessage())
-	if err != nil {
-		return nil, err
-	}
-	response, err := c.route(appmessage.CmdGetBlockCountResponseMessage).DequeueWithTimeout(c.timeout)
+	return c.GetBlockCountContext(context.Background())
+}
+
+// GetBlockCountContext operates the same as GetBlockCount, except that it gives up waiting for the response once ctx is
+// done
+func (c *RPCClient) GetBlockCountContext(ctx context.Context) (*appmessage.GetBlockCountResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGetBlockCountRequestMessage(),
+		appmessage.CmdGetBlockCountResponseMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -18,3 +25,11 @@ func (c *RPCClient) GetBlockCount() (*appmessage.GetBlockCountResponseMessage, e
 	}
 	return getBlockCountResponse, nil
 }
+
+// GetBlockCountAsync operates the same as GetBlockCountContext, except that it doesn't wait for the response, and calls
+// handler with the results once they're received
+func (c *RPCClient) GetBlockCountAsync(ctx context.Context, handler func(*appmessage.GetBlockCountResponseMessage, error)) {
+	spawn("GetBlockCountAsync", func() {
+		handler(c.GetBlockCountContext(ctx))
+	})
+}
@@ -1,14 +1,21 @@
 package rpcclient
 
-import "github.com/kaspanet/kaspad/app/appmessage"
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
 
 // GetBlockDAGInfo sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) GetBlockDAGInfo() (*appmessage.GetBlockDAGInfoResponseMessage, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetBlockDAGInfoRequestMessage())
-	if err != nil {
-		return nil, err
-	}
-	response, err := c.route(appmessage.CmdGetBlockDAGInfoResponseMessage).DequeueWithTimeout(c.timeout)
+	return c.GetBlockDAGInfoContext(context.Background())
+}
+
+// GetBlockDAGInfoContext operates the same as GetBlockDAGInfo, except that it gives up waiting for the response once
+// ctx is done
+func (c *RPCClient) GetBlockDAGInfoContext(ctx context.Context) (*appmessage.GetBlockDAGInfoResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGetBlockDAGInfoRequestMessage(),
+		appmessage.CmdGetBlockDAGInfoResponseMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -18,3 +25,11 @@ func (c *RPCClient) GetBlockDAGInfo() (*appmessage.GetBlockDAGInfoResponseMessag
 	}
 	return GetBlockDAGInfoResponse, nil
 }
+
+// GetBlockDAGInfoAsync operates the same as GetBlockDAGInfoContext, except that it doesn't wait for the response, and
+// calls handler with the results once they're received
+func (c *RPCClient) GetBlockDAGInfoAsync(ctx context.Context, handler func(*appmessage.GetBlockDAGInfoResponseMessage, error)) {
+	spawn("GetBlockDAGInfoAsync", func() {
+		handler(c.GetBlockDAGInfoContext(ctx))
+	})
+}
@@ -0,0 +1,35 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// GetBlockSubmissionStatus sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetBlockSubmissionStatus(submissionID string) (*appmessage.GetBlockSubmissionStatusResponseMessage, error) {
+	return c.GetBlockSubmissionStatusContext(context.Background(), submissionID)
+}
+
+// GetBlockSubmissionStatusContext operates the same as GetBlockSubmissionStatus, except that it gives up waiting for
+// the response once ctx is done
+func (c *RPCClient) GetBlockSubmissionStatusContext(ctx context.Context, submissionID string) (*appmessage.GetBlockSubmissionStatusResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGetBlockSubmissionStatusRequestMessage(submissionID),
+		appmessage.CmdGetBlockSubmissionStatusResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	getBlockSubmissionStatusResponse := response.(*appmessage.GetBlockSubmissionStatusResponseMessage)
+	if getBlockSubmissionStatusResponse.Error != nil {
+		return nil, c.convertRPCError(getBlockSubmissionStatusResponse.Error)
+	}
+	return getBlockSubmissionStatusResponse, nil
+}
+
+// GetBlockSubmissionStatusAsync operates the same as GetBlockSubmissionStatusContext, except that it doesn't wait for
+// the response, and calls handler with the results once they're received
+func (c *RPCClient) GetBlockSubmissionStatusAsync(ctx context.Context, submissionID string, handler func(*appmessage.GetBlockSubmissionStatusResponseMessage, error)) {
+	spawn("GetBlockSubmissionStatusAsync", func() {
+		handler(c.GetBlockSubmissionStatusContext(ctx, submissionID))
+	})
+}
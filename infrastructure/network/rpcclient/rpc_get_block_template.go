@@ -1,14 +1,127 @@
 package rpcclient
 
-import "github.com/kaspanet/kaspad/app/appmessage"
+import (
+	"context"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
 
 // GetBlockTemplate sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) GetBlockTemplate(miningAddress, extraData string) (*appmessage.GetBlockTemplateResponseMessage, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetBlockTemplateRequestMessage(miningAddress, extraData))
+	return c.GetBlockTemplateContext(context.Background(), miningAddress, extraData)
+}
+
+// GetBlockTemplateContext operates the same as GetBlockTemplate, except that it gives up waiting for the response once
+// ctx is done
+func (c *RPCClient) GetBlockTemplateContext(ctx context.Context, miningAddress, extraData string) (*appmessage.GetBlockTemplateResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGetBlockTemplateRequestMessage(miningAddress, extraData),
+		appmessage.CmdGetBlockTemplateResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	getBlockTemplateResponse := response.(*appmessage.GetBlockTemplateResponseMessage)
+	if getBlockTemplateResponse.Error != nil {
+		return nil, c.convertRPCError(getBlockTemplateResponse.Error)
+	}
+	return getBlockTemplateResponse, nil
+}
+
+// GetBlockTemplateAsync operates the same as GetBlockTemplateContext, except that it doesn't wait for the response, and
+// calls handler with the results once they're received
+func (c *RPCClient) GetBlockTemplateAsync(ctx context.Context, miningAddress, extraData string, handler func(*appmessage.GetBlockTemplateResponseMessage, error)) {
+	spawn("GetBlockTemplateAsync", func() {
+		handler(c.GetBlockTemplateContext(ctx, miningAddress, extraData))
+	})
+}
+
+// GetBlockTemplateWithMinFees operates the same as GetBlockTemplate, except that if the
+// virtual changed less than maxFeeWait ago, the node waits for the mempool to accumulate
+// at least minFees before building the template
+func (c *RPCClient) GetBlockTemplateWithMinFees(miningAddress, extraData string, minFees uint64,
+	maxFeeWait time.Duration) (*appmessage.GetBlockTemplateResponseMessage, error) {
+
+	return c.GetBlockTemplateWithMinFeesContext(context.Background(), miningAddress, extraData, minFees, maxFeeWait)
+}
+
+// GetBlockTemplateWithMinFeesContext operates the same as GetBlockTemplateWithMinFees, except that it gives up waiting
+// for the response once ctx is done
+func (c *RPCClient) GetBlockTemplateWithMinFeesContext(ctx context.Context, miningAddress, extraData string, minFees uint64,
+	maxFeeWait time.Duration) (*appmessage.GetBlockTemplateResponseMessage, error) {
+
+	request := appmessage.NewGetBlockTemplateRequestMessage(miningAddress, extraData)
+	request.MinFees = minFees
+	request.MaxFeeWaitMilliseconds = uint32(maxFeeWait.Milliseconds())
+	response, err := c.request(ctx, request, appmessage.CmdGetBlockTemplateResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	getBlockTemplateResponse := response.(*appmessage.GetBlockTemplateResponseMessage)
+	if getBlockTemplateResponse.Error != nil {
+		return nil, c.convertRPCError(getBlockTemplateResponse.Error)
+	}
+	return getBlockTemplateResponse, nil
+}
+
+// GetBlockTemplateWithMinFeesAsync operates the same as GetBlockTemplateWithMinFeesContext, except that it doesn't wait
+// for the response, and calls handler with the results once they're received
+func (c *RPCClient) GetBlockTemplateWithMinFeesAsync(ctx context.Context, miningAddress, extraData string, minFees uint64,
+	maxFeeWait time.Duration, handler func(*appmessage.GetBlockTemplateResponseMessage, error)) {
+	spawn("GetBlockTemplateWithMinFeesAsync", func() {
+		handler(c.GetBlockTemplateWithMinFeesContext(ctx, miningAddress, extraData, minFees, maxFeeWait))
+	})
+}
+
+// GetBlockTemplateWithAuxCommitment operates the same as GetBlockTemplate, except that the
+// given auxiliary chain commitment is embedded in the coinbase payload of the template.
+// Once the block is accepted, the inclusion proof of the commitment is available via
+// GetAuxCommitmentProof.
+func (c *RPCClient) GetBlockTemplateWithAuxCommitment(miningAddress, extraData string,
+	auxCommitment *externalapi.DomainHash) (*appmessage.GetBlockTemplateResponseMessage, error) {
+
+	return c.GetBlockTemplateWithAuxCommitmentContext(context.Background(), miningAddress, extraData, auxCommitment)
+}
+
+// GetBlockTemplateWithAuxCommitmentContext operates the same as GetBlockTemplateWithAuxCommitment, except that it gives
+// up waiting for the response once ctx is done
+func (c *RPCClient) GetBlockTemplateWithAuxCommitmentContext(ctx context.Context, miningAddress, extraData string,
+	auxCommitment *externalapi.DomainHash) (*appmessage.GetBlockTemplateResponseMessage, error) {
+
+	request := appmessage.NewGetBlockTemplateRequestMessage(miningAddress, extraData)
+	request.AuxCommitment = auxCommitment.String()
+	response, err := c.request(ctx, request, appmessage.CmdGetBlockTemplateResponseMessage)
 	if err != nil {
 		return nil, err
 	}
-	response, err := c.route(appmessage.CmdGetBlockTemplateResponseMessage).DequeueWithTimeout(c.timeout)
+	getBlockTemplateResponse := response.(*appmessage.GetBlockTemplateResponseMessage)
+	if getBlockTemplateResponse.Error != nil {
+		return nil, c.convertRPCError(getBlockTemplateResponse.Error)
+	}
+	return getBlockTemplateResponse, nil
+}
+
+// GetBlockTemplateWithAuxCommitmentAsync operates the same as GetBlockTemplateWithAuxCommitmentContext, except that it
+// doesn't wait for the response, and calls handler with the results once they're received
+func (c *RPCClient) GetBlockTemplateWithAuxCommitmentAsync(ctx context.Context, miningAddress, extraData string,
+	auxCommitment *externalapi.DomainHash, handler func(*appmessage.GetBlockTemplateResponseMessage, error)) {
+	spawn("GetBlockTemplateWithAuxCommitmentAsync", func() {
+		handler(c.GetBlockTemplateWithAuxCommitmentContext(ctx, miningAddress, extraData, auxCommitment))
+	})
+}
+
+// ProposeBlockTemplate sends a GetBlockTemplate request in proposal mode, asking the RPC
+// server to fully validate the given block, except for its proof of work, without adding
+// or broadcasting it. Whether the block was accepted, and why not, is set in the response.
+func (c *RPCClient) ProposeBlockTemplate(block *externalapi.DomainBlock) (*appmessage.GetBlockTemplateResponseMessage, error) {
+	return c.ProposeBlockTemplateContext(context.Background(), block)
+}
+
+// ProposeBlockTemplateContext operates the same as ProposeBlockTemplate, except that it gives up waiting for the
+// response once ctx is done
+func (c *RPCClient) ProposeBlockTemplateContext(ctx context.Context, block *externalapi.DomainBlock) (*appmessage.GetBlockTemplateResponseMessage, error) {
+	request := appmessage.NewGetBlockTemplateProposalRequestMessage(appmessage.DomainBlockToRPCBlock(block))
+	response, err := c.request(ctx, request, appmessage.CmdGetBlockTemplateResponseMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -18,3 +131,11 @@ func (c *RPCClient) GetBlockTemplate(miningAddress, extraData string) (*appmessa
 	}
 	return getBlockTemplateResponse, nil
 }
+
+// ProposeBlockTemplateAsync operates the same as ProposeBlockTemplateContext, except that it doesn't wait for the
+// response, and calls handler with the results once they're received
+func (c *RPCClient) ProposeBlockTemplateAsync(ctx context.Context, block *externalapi.DomainBlock, handler func(*appmessage.GetBlockTemplateResponseMessage, error)) {
+	spawn("ProposeBlockTemplateAsync", func() {
+		handler(c.ProposeBlockTemplateContext(ctx, block))
+	})
+}
@@ -1,17 +1,50 @@
 package rpcclient
 
-import "github.com/kaspanet/kaspad/app/appmessage"
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
 
 // GetBlocks sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) GetBlocks(lowHash string, includeBlocks bool,
 	includeTransactions bool) (*appmessage.GetBlocksResponseMessage, error) {
 
-	err := c.rpcRouter.outgoingRoute().Enqueue(
-		appmessage.NewGetBlocksRequestMessage(lowHash, includeBlocks, includeTransactions))
-	if err != nil {
-		return nil, err
-	}
-	response, err := c.route(appmessage.CmdGetBlocksResponseMessage).DequeueWithTimeout(c.timeout)
+	return c.GetBlocksContext(context.Background(), lowHash, includeBlocks, includeTransactions)
+}
+
+// GetBlocksContext operates the same as GetBlocks, except that it gives up waiting for the response once ctx is done
+func (c *RPCClient) GetBlocksContext(ctx context.Context, lowHash string, includeBlocks bool,
+	includeTransactions bool) (*appmessage.GetBlocksResponseMessage, error) {
+
+	return c.GetBlocksPageContext(ctx, lowHash, includeBlocks, includeTransactions, 0)
+}
+
+// GetBlocksAsync operates the same as GetBlocksContext, except that it doesn't wait for the response, and calls handler
+// with the results once they're received
+func (c *RPCClient) GetBlocksAsync(ctx context.Context, lowHash string, includeBlocks bool,
+	includeTransactions bool, handler func(*appmessage.GetBlocksResponseMessage, error)) {
+	spawn("GetBlocksAsync", func() {
+		handler(c.GetBlocksContext(ctx, lowHash, includeBlocks, includeTransactions))
+	})
+}
+
+// GetBlocksPage is like GetBlocks, but returns up to limit blocks.
+// Pass the response's NextLowHash as lowHash to get the next page
+func (c *RPCClient) GetBlocksPage(lowHash string, includeBlocks bool,
+	includeTransactions bool, limit uint32) (*appmessage.GetBlocksResponseMessage, error) {
+
+	return c.GetBlocksPageContext(context.Background(), lowHash, includeBlocks, includeTransactions, limit)
+}
+
+// GetBlocksPageContext operates the same as GetBlocksPage, except that it gives up waiting for the response once ctx is
+// done
+func (c *RPCClient) GetBlocksPageContext(ctx context.Context, lowHash string, includeBlocks bool,
+	includeTransactions bool, limit uint32) (*appmessage.GetBlocksResponseMessage, error) {
+
+	request := appmessage.NewGetBlocksRequestMessage(lowHash, includeBlocks, includeTransactions)
+	request.Limit = limit
+	response, err := c.request(ctx, request, appmessage.CmdGetBlocksResponseMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -21,3 +54,12 @@ func (c *RPCClient) GetBlocks(lowHash string, includeBlocks bool,
 	}
 	return GetBlocksResponse, nil
 }
+
+// GetBlocksPageAsync operates the same as GetBlocksPageContext, except that it doesn't wait for the response, and calls
+// handler with the results once they're received
+func (c *RPCClient) GetBlocksPageAsync(ctx context.Context, lowHash string, includeBlocks bool,
+	includeTransactions bool, limit uint32, handler func(*appmessage.GetBlocksResponseMessage, error)) {
+	spawn("GetBlocksPageAsync", func() {
+		handler(c.GetBlocksPageContext(ctx, lowHash, includeBlocks, includeTransactions, limit))
+	})
+}
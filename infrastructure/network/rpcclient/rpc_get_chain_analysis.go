@@ -0,0 +1,35 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// GetChainAnalysis sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetChainAnalysis(lowBlueScore uint64, highBlueScore uint64) (*appmessage.GetChainAnalysisResponseMessage, error) {
+	return c.GetChainAnalysisContext(context.Background(), lowBlueScore, highBlueScore)
+}
+
+// GetChainAnalysisContext operates the same as GetChainAnalysis, except that it gives up waiting for the response
+// once ctx is done
+func (c *RPCClient) GetChainAnalysisContext(ctx context.Context, lowBlueScore uint64, highBlueScore uint64) (*appmessage.GetChainAnalysisResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGetChainAnalysisRequestMessage(lowBlueScore, highBlueScore),
+		appmessage.CmdGetChainAnalysisResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	getChainAnalysisResponse := response.(*appmessage.GetChainAnalysisResponseMessage)
+	if getChainAnalysisResponse.Error != nil {
+		return nil, c.convertRPCError(getChainAnalysisResponse.Error)
+	}
+	return getChainAnalysisResponse, nil
+}
+
+// GetChainAnalysisAsync operates the same as GetChainAnalysisContext, except that it doesn't wait for the response,
+// and calls handler with the results once they're received
+func (c *RPCClient) GetChainAnalysisAsync(ctx context.Context, lowBlueScore uint64, highBlueScore uint64, handler func(*appmessage.GetChainAnalysisResponseMessage, error)) {
+	spawn("GetChainAnalysisAsync", func() {
+		handler(c.GetChainAnalysisContext(ctx, lowBlueScore, highBlueScore))
+	})
+}
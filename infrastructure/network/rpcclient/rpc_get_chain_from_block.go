@@ -1,16 +1,52 @@
 package rpcclient
 
-import "github.com/kaspanet/kaspad/app/appmessage"
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
 
 // GetVirtualSelectedParentChainFromBlock sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) GetVirtualSelectedParentChainFromBlock(startHash string, includeAcceptedTransactionIDs bool) (
 	*appmessage.GetVirtualSelectedParentChainFromBlockResponseMessage, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(
-		appmessage.NewGetVirtualSelectedParentChainFromBlockRequestMessage(startHash, includeAcceptedTransactionIDs))
-	if err != nil {
-		return nil, err
-	}
-	response, err := c.route(appmessage.CmdGetVirtualSelectedParentChainFromBlockResponseMessage).DequeueWithTimeout(c.timeout)
+
+	return c.GetVirtualSelectedParentChainFromBlockContext(context.Background(), startHash, includeAcceptedTransactionIDs)
+}
+
+// GetVirtualSelectedParentChainFromBlockContext operates the same as GetVirtualSelectedParentChainFromBlock, except
+// that it gives up waiting for the response once ctx is done
+func (c *RPCClient) GetVirtualSelectedParentChainFromBlockContext(ctx context.Context, startHash string, includeAcceptedTransactionIDs bool) (
+	*appmessage.GetVirtualSelectedParentChainFromBlockResponseMessage, error) {
+
+	return c.GetVirtualSelectedParentChainFromBlockPageContext(ctx, startHash, includeAcceptedTransactionIDs, false, 0)
+}
+
+// GetVirtualSelectedParentChainFromBlockAsync operates the same as GetVirtualSelectedParentChainFromBlockContext,
+// except that it doesn't wait for the response, and calls handler with the results once they're received
+func (c *RPCClient) GetVirtualSelectedParentChainFromBlockAsync(ctx context.Context, startHash string, includeAcceptedTransactionIDs bool, handler func(*appmessage.GetVirtualSelectedParentChainFromBlockResponseMessage, error)) {
+	spawn("GetVirtualSelectedParentChainFromBlockAsync", func() {
+		handler(c.GetVirtualSelectedParentChainFromBlockContext(ctx, startHash, includeAcceptedTransactionIDs))
+	})
+}
+
+// GetVirtualSelectedParentChainFromBlockPage returns the chain blocks removed since startHash
+// and up to limit chain blocks added after it. Pass the response's NextStartHash as startHash
+// to get the next page
+func (c *RPCClient) GetVirtualSelectedParentChainFromBlockPage(startHash string, includeAcceptedTransactionIDs bool,
+	includeAcceptanceData bool, limit uint32) (*appmessage.GetVirtualSelectedParentChainFromBlockResponseMessage, error) {
+
+	return c.GetVirtualSelectedParentChainFromBlockPageContext(context.Background(), startHash, includeAcceptedTransactionIDs, includeAcceptanceData, limit)
+}
+
+// GetVirtualSelectedParentChainFromBlockPageContext operates the same as GetVirtualSelectedParentChainFromBlockPage,
+// except that it gives up waiting for the response once ctx is done
+func (c *RPCClient) GetVirtualSelectedParentChainFromBlockPageContext(ctx context.Context, startHash string, includeAcceptedTransactionIDs bool,
+	includeAcceptanceData bool, limit uint32) (*appmessage.GetVirtualSelectedParentChainFromBlockResponseMessage, error) {
+
+	request := appmessage.NewGetVirtualSelectedParentChainFromBlockRequestMessage(startHash, includeAcceptedTransactionIDs)
+	request.IncludeAcceptanceData = includeAcceptanceData
+	request.Limit = limit
+	response, err := c.request(ctx, request, appmessage.CmdGetVirtualSelectedParentChainFromBlockResponseMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -20,3 +56,13 @@ func (c *RPCClient) GetVirtualSelectedParentChainFromBlock(startHash string, inc
 	}
 	return GetVirtualSelectedParentChainFromBlockResponse, nil
 }
+
+// GetVirtualSelectedParentChainFromBlockPageAsync operates the same as
+// GetVirtualSelectedParentChainFromBlockPageContext, except that it doesn't wait for the response, and calls handler
+// with the results once they're received
+func (c *RPCClient) GetVirtualSelectedParentChainFromBlockPageAsync(ctx context.Context, startHash string, includeAcceptedTransactionIDs bool,
+	includeAcceptanceData bool, limit uint32, handler func(*appmessage.GetVirtualSelectedParentChainFromBlockResponseMessage, error)) {
+	spawn("GetVirtualSelectedParentChainFromBlockPageAsync", func() {
+		handler(c.GetVirtualSelectedParentChainFromBlockPageContext(ctx, startHash, includeAcceptedTransactionIDs, includeAcceptanceData, limit))
+	})
+}
@@ -1,14 +1,21 @@
 package rpcclient
 
-import "github.com/kaspanet/kaspad/app/appmessage"
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
 
 // GetCoinSupply sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) GetCoinSupply() (*appmessage.GetCoinSupplyResponseMessage, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetCoinSupplyRequestMessage())
-	if err != nil {
-		return nil, err
-	}
-	response, err := c.route(appmessage.CmdGetCoinSupplyResponseMessage).DequeueWithTimeout(c.timeout)
+	return c.GetCoinSupplyContext(context.Background())
+}
+
+// GetCoinSupplyContext operates the same as GetCoinSupply, except that it gives up waiting for the response once ctx is
+// done
+func (c *RPCClient) GetCoinSupplyContext(ctx context.Context) (*appmessage.GetCoinSupplyResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGetCoinSupplyRequestMessage(),
+		appmessage.CmdGetCoinSupplyResponseMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -18,3 +25,11 @@ func (c *RPCClient) GetCoinSupply() (*appmessage.GetCoinSupplyResponseMessage, e
 	}
 	return geCoinSupplyResponse, nil
 }
+
+// GetCoinSupplyAsync operates the same as GetCoinSupplyContext, except that it doesn't wait for the response, and calls
+// handler with the results once they're received
+func (c *RPCClient) GetCoinSupplyAsync(ctx context.Context, handler func(*appmessage.GetCoinSupplyResponseMessage, error)) {
+	spawn("GetCoinSupplyAsync", func() {
+		handler(c.GetCoinSupplyContext(ctx))
+	})
+}
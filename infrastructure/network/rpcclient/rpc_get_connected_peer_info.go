@@ -1,14 +1,44 @@
 package rpcclient
 
-import "github.com/kaspanet/kaspad/app/appmessage"
+import (
+	"context"
 
-// GetConnectedPeerInfo sends an RPC request respective to the function's name and returns the RPC server's response
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// GetConnectedPeerInfo sends an RPC request respective to the function's name and returns the RPC server's response.
+// It returns the first page of peers, of the server's maximum size
 func (c *RPCClient) GetConnectedPeerInfo() (*appmessage.GetConnectedPeerInfoResponseMessage, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetConnectedPeerInfoRequestMessage())
-	if err != nil {
-		return nil, err
-	}
-	response, err := c.route(appmessage.CmdGetConnectedPeerInfoResponseMessage).DequeueWithTimeout(c.timeout)
+	return c.GetConnectedPeerInfoContext(context.Background())
+}
+
+// GetConnectedPeerInfoContext operates the same as GetConnectedPeerInfo, except that it gives up waiting for the
+// response once ctx is done
+func (c *RPCClient) GetConnectedPeerInfoContext(ctx context.Context) (*appmessage.GetConnectedPeerInfoResponseMessage, error) {
+	return c.GetConnectedPeerInfoPageContext(ctx, 0, "")
+}
+
+// GetConnectedPeerInfoAsync operates the same as GetConnectedPeerInfoContext, except that it doesn't wait for the
+// response, and calls handler with the results once they're received
+func (c *RPCClient) GetConnectedPeerInfoAsync(ctx context.Context, handler func(*appmessage.GetConnectedPeerInfoResponseMessage, error)) {
+	spawn("GetConnectedPeerInfoAsync", func() {
+		handler(c.GetConnectedPeerInfoContext(ctx))
+	})
+}
+
+// GetConnectedPeerInfoPage returns up to limit peers whose IDs come after cursor.
+// Pass the response's NextCursor as cursor to get the next page
+func (c *RPCClient) GetConnectedPeerInfoPage(limit uint32, cursor string) (*appmessage.GetConnectedPeerInfoResponseMessage, error) {
+	return c.GetConnectedPeerInfoPageContext(context.Background(), limit, cursor)
+}
+
+// GetConnectedPeerInfoPageContext operates the same as GetConnectedPeerInfoPage, except that it gives up waiting for
+// the response once ctx is done
+func (c *RPCClient) GetConnectedPeerInfoPageContext(ctx context.Context, limit uint32, cursor string) (*appmessage.GetConnectedPeerInfoResponseMessage, error) {
+	request := appmessage.NewGetConnectedPeerInfoRequestMessage()
+	request.Limit = limit
+	request.Cursor = cursor
+	response, err := c.request(ctx, request, appmessage.CmdGetConnectedPeerInfoResponseMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -18,3 +48,11 @@ func (c *RPCClient) GetConnectedPeerInfo() (*appmessage.GetConnectedPeerInfoResp
 	}
 	return getConnectedPeerInfoResponse, nil
 }
+
+// GetConnectedPeerInfoPageAsync operates the same as GetConnectedPeerInfoPageContext, except that it doesn't wait for
+// the response, and calls handler with the results once they're received
+func (c *RPCClient) GetConnectedPeerInfoPageAsync(ctx context.Context, limit uint32, cursor string, handler func(*appmessage.GetConnectedPeerInfoResponseMessage, error)) {
+	spawn("GetConnectedPeerInfoPageAsync", func() {
+		handler(c.GetConnectedPeerInfoPageContext(ctx, limit, cursor))
+	})
+}
@@ -0,0 +1,35 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// GetDatabaseStats sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetDatabaseStats(includeBuckets bool, bucketDepth uint32, includeOperationMetrics bool) (*appmessage.GetDatabaseStatsResponseMessage, error) {
+	return c.GetDatabaseStatsContext(context.Background(), includeBuckets, bucketDepth, includeOperationMetrics)
+}
+
+// GetDatabaseStatsContext operates the same as GetDatabaseStats, except that it gives up waiting for the response
+// once ctx is done
+func (c *RPCClient) GetDatabaseStatsContext(ctx context.Context, includeBuckets bool, bucketDepth uint32, includeOperationMetrics bool) (*appmessage.GetDatabaseStatsResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGetDatabaseStatsRequestMessage(includeBuckets, bucketDepth, includeOperationMetrics),
+		appmessage.CmdGetDatabaseStatsResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	getDatabaseStatsResponse := response.(*appmessage.GetDatabaseStatsResponseMessage)
+	if getDatabaseStatsResponse.Error != nil {
+		return nil, c.convertRPCError(getDatabaseStatsResponse.Error)
+	}
+	return getDatabaseStatsResponse, nil
+}
+
+// GetDatabaseStatsAsync operates the same as GetDatabaseStatsContext, except that it doesn't wait for the response,
+// and calls handler with the results once they're received
+func (c *RPCClient) GetDatabaseStatsAsync(ctx context.Context, includeBuckets bool, bucketDepth uint32, includeOperationMetrics bool, handler func(*appmessage.GetDatabaseStatsResponseMessage, error)) {
+	spawn("GetDatabaseStatsAsync", func() {
+		handler(c.GetDatabaseStatsContext(ctx, includeBuckets, bucketDepth, includeOperationMetrics))
+	})
+}
@@ -0,0 +1,35 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// GetFinalityPoint sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetFinalityPoint() (*appmessage.GetFinalityPointResponseMessage, error) {
+	return c.GetFinalityPointContext(context.Background())
+}
+
+// GetFinalityPointContext operates the same as GetFinalityPoint, except that it gives up waiting for the response
+// once ctx is done
+func (c *RPCClient) GetFinalityPointContext(ctx context.Context) (*appmessage.GetFinalityPointResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGetFinalityPointRequestMessage(),
+		appmessage.CmdGetFinalityPointResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	getFinalityPointResponse := response.(*appmessage.GetFinalityPointResponseMessage)
+	if getFinalityPointResponse.Error != nil {
+		return nil, c.convertRPCError(getFinalityPointResponse.Error)
+	}
+	return getFinalityPointResponse, nil
+}
+
+// GetFinalityPointAsync operates the same as GetFinalityPointContext, except that it doesn't wait for the response,
+// and calls handler with the results once they're received
+func (c *RPCClient) GetFinalityPointAsync(ctx context.Context, handler func(*appmessage.GetFinalityPointResponseMessage, error)) {
+	spawn("GetFinalityPointAsync", func() {
+		handler(c.GetFinalityPointContext(ctx))
+	})
+}
@@ -1,14 +1,20 @@
 package rpcclient
 
-import "github.com/kaspanet/kaspad/app/appmessage"
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
 
 // GetHeaders sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) GetHeaders(startHash string, limit uint64, isAscending bool) (*appmessage.GetHeadersResponseMessage, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetHeadersRequestMessage(startHash, limit, isAscending))
-	if err != nil {
-		return nil, err
-	}
-	response, err := c.route(appmessage.CmdGetHeadersResponseMessage).DequeueWithTimeout(c.timeout)
+	return c.GetHeadersContext(context.Background(), startHash, limit, isAscending)
+}
+
+// GetHeadersContext operates the same as GetHeaders, except that it gives up waiting for the response once ctx is done
+func (c *RPCClient) GetHeadersContext(ctx context.Context, startHash string, limit uint64, isAscending bool) (*appmessage.GetHeadersResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGetHeadersRequestMessage(startHash, limit, isAscending),
+		appmessage.CmdGetHeadersResponseMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -18,3 +24,11 @@ func (c *RPCClient) GetHeaders(startHash string, limit uint64, isAscending bool)
 	}
 	return getHeadersResponse, nil
 }
+
+// GetHeadersAsync operates the same as GetHeadersContext, except that it doesn't wait for the response, and calls
+// handler with the results once they're received
+func (c *RPCClient) GetHeadersAsync(ctx context.Context, startHash string, limit uint64, isAscending bool, handler func(*appmessage.GetHeadersResponseMessage, error)) {
+	spawn("GetHeadersAsync", func() {
+		handler(c.GetHeadersContext(ctx, startHash, limit, isAscending))
+	})
+}
@@ -0,0 +1,35 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// GetIndexStatus sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetIndexStatus() (*appmessage.GetIndexStatusResponseMessage, error) {
+	return c.GetIndexStatusContext(context.Background())
+}
+
+// GetIndexStatusContext operates the same as GetIndexStatus, except that it gives up waiting for the response
+// once ctx is done
+func (c *RPCClient) GetIndexStatusContext(ctx context.Context) (*appmessage.GetIndexStatusResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGetIndexStatusRequestMessage(),
+		appmessage.CmdGetIndexStatusResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	getIndexStatusResponse := response.(*appmessage.GetIndexStatusResponseMessage)
+	if getIndexStatusResponse.Error != nil {
+		return nil, c.convertRPCError(getIndexStatusResponse.Error)
+	}
+	return getIndexStatusResponse, nil
+}
+
+// GetIndexStatusAsync operates the same as GetIndexStatusContext, except that it doesn't wait for the response,
+// and calls handler with the results once they're received
+func (c *RPCClient) GetIndexStatusAsync(ctx context.Context, handler func(*appmessage.GetIndexStatusResponseMessage, error)) {
+	spawn("GetIndexStatusAsync", func() {
+		handler(c.GetIndexStatusContext(ctx))
+	})
+}
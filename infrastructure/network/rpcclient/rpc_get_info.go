@@ -1,14 +1,19 @@
 package rpcclient
 
-import "github.com/kaspanet/kaspad/app/appmessage"
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
 
 // GetInfo sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) GetInfo() (*appmessage.GetInfoResponseMessage, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetInfoRequestMessage())
-	if err != nil {
-		return nil, err
-	}
-	response, err := c.route(appmessage.CmdGetInfoResponseMessage).DequeueWithTimeout(c.timeout)
+	return c.GetInfoContext(context.Background())
+}
+
+// GetInfoContext operates the same as GetInfo, except that it gives up waiting for the response once ctx is done
+func (c *RPCClient) GetInfoContext(ctx context.Context) (*appmessage.GetInfoResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGetInfoRequestMessage(), appmessage.CmdGetInfoResponseMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -18,3 +23,11 @@ func (c *RPCClient) GetInfo() (*appmessage.GetInfoResponseMessage, error) {
 	}
 	return getInfoResponse, nil
 }
+
+// GetInfoAsync operates the same as GetInfoContext, except that it doesn't wait for the response, and calls handler
+// with the results once they're received
+func (c *RPCClient) GetInfoAsync(ctx context.Context, handler func(*appmessage.GetInfoResponseMessage, error)) {
+	spawn("GetInfoAsync", func() {
+		handler(c.GetInfoContext(ctx))
+	})
+}
@@ -0,0 +1,36 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// GetJobs sends an RPC request respective to the function's name and returns the RPC server's response.
+// If jobIDs is empty, the response holds all the jobs the node keeps
+func (c *RPCClient) GetJobs(jobIDs []uint64) (*appmessage.GetJobsResponseMessage, error) {
+	return c.GetJobsContext(context.Background(), jobIDs)
+}
+
+// GetJobsContext operates the same as GetJobs, except that it gives up waiting for the response
+// once ctx is done
+func (c *RPCClient) GetJobsContext(ctx context.Context, jobIDs []uint64) (*appmessage.GetJobsResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGetJobsRequestMessage(jobIDs),
+		appmessage.CmdGetJobsResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	getJobsResponse := response.(*appmessage.GetJobsResponseMessage)
+	if getJobsResponse.Error != nil {
+		return nil, c.convertRPCError(getJobsResponse.Error)
+	}
+	return getJobsResponse, nil
+}
+
+// GetJobsAsync operates the same as GetJobsContext, except that it doesn't wait for the response,
+// and calls handler with the results once they're received
+func (c *RPCClient) GetJobsAsync(ctx context.Context, jobIDs []uint64, handler func(*appmessage.GetJobsResponseMessage, error)) {
+	spawn("GetJobsAsync", func() {
+		handler(c.GetJobsContext(ctx, jobIDs))
+	})
+}
@@ -1,14 +1,48 @@
 package rpcclient
 
-import "github.com/kaspanet/kaspad/app/appmessage"
+import (
+	"context"
 
-// GetMempoolEntries sends an RPC request respective to the function's name and returns the RPC server's response
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// GetMempoolEntries sends an RPC request respective to the function's name and returns the RPC server's response.
+// It returns the first page of entries, of the server's maximum size
 func (c *RPCClient) GetMempoolEntries(includeOrphanPool bool, filterTransactionPool bool) (*appmessage.GetMempoolEntriesResponseMessage, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetMempoolEntriesRequestMessage(includeOrphanPool, filterTransactionPool))
-	if err != nil {
-		return nil, err
-	}
-	response, err := c.route(appmessage.CmdGetMempoolEntriesResponseMessage).DequeueWithTimeout(c.timeout)
+	return c.GetMempoolEntriesContext(context.Background(), includeOrphanPool, filterTransactionPool)
+}
+
+// GetMempoolEntriesContext operates the same as GetMempoolEntries, except that it gives up waiting for the response
+// once ctx is done
+func (c *RPCClient) GetMempoolEntriesContext(ctx context.Context, includeOrphanPool bool, filterTransactionPool bool) (*appmessage.GetMempoolEntriesResponseMessage, error) {
+	return c.GetMempoolEntriesPageContext(ctx, includeOrphanPool, filterTransactionPool, 0, "")
+}
+
+// GetMempoolEntriesAsync operates the same as GetMempoolEntriesContext, except that it doesn't wait for the response,
+// and calls handler with the results once they're received
+func (c *RPCClient) GetMempoolEntriesAsync(ctx context.Context, includeOrphanPool bool, filterTransactionPool bool, handler func(*appmessage.GetMempoolEntriesResponseMessage, error)) {
+	spawn("GetMempoolEntriesAsync", func() {
+		handler(c.GetMempoolEntriesContext(ctx, includeOrphanPool, filterTransactionPool))
+	})
+}
+
+// GetMempoolEntriesPage returns up to limit mempool entries whose transaction IDs come after cursor.
+// Pass the response's NextCursor as cursor to get the next page
+func (c *RPCClient) GetMempoolEntriesPage(includeOrphanPool bool, filterTransactionPool bool,
+	limit uint32, cursor string) (*appmessage.GetMempoolEntriesResponseMessage, error) {
+
+	return c.GetMempoolEntriesPageContext(context.Background(), includeOrphanPool, filterTransactionPool, limit, cursor)
+}
+
+// GetMempoolEntriesPageContext operates the same as GetMempoolEntriesPage, except that it gives up waiting for the
+// response once ctx is done
+func (c *RPCClient) GetMempoolEntriesPageContext(ctx context.Context, includeOrphanPool bool, filterTransactionPool bool,
+	limit uint32, cursor string) (*appmessage.GetMempoolEntriesResponseMessage, error) {
+
+	request := appmessage.NewGetMempoolEntriesRequestMessage(includeOrphanPool, filterTransactionPool)
+	request.Limit = limit
+	request.Cursor = cursor
+	response, err := c.request(ctx, request, appmessage.CmdGetMempoolEntriesResponseMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -18,3 +52,12 @@ func (c *RPCClient) GetMempoolEntries(includeOrphanPool bool, filterTransactionP
 	}
 	return getMempoolEntriesResponse, nil
 }
+
+// GetMempoolEntriesPageAsync operates the same as GetMempoolEntriesPageContext, except that it doesn't wait for the
+// response, and calls handler with the results once they're received
+func (c *RPCClient) GetMempoolEntriesPageAsync(ctx context.Context, includeOrphanPool bool, filterTransactionPool bool,
+	limit uint32, cursor string, handler func(*appmessage.GetMempoolEntriesResponseMessage, error)) {
+	spawn("GetMempoolEntriesPageAsync", func() {
+		handler(c.GetMempoolEntriesPageContext(ctx, includeOrphanPool, filterTransactionPool, limit, cursor))
+	})
+}
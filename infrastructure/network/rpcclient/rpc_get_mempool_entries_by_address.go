@@ -1,14 +1,21 @@
 package rpcclient
 
-import "github.com/kaspanet/kaspad/app/appmessage"
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
 
 // GetMempoolEntriesByAddresses sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) GetMempoolEntriesByAddresses(addresses []string, includeOrphanPool bool, filterTransactionPool bool) (*appmessage.GetMempoolEntriesByAddressesResponseMessage, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetMempoolEntriesByAddressesRequestMessage(addresses, includeOrphanPool, filterTransactionPool))
-	if err != nil {
-		return nil, err
-	}
-	response, err := c.route(appmessage.CmdGetMempoolEntriesByAddressesResponseMessage).DequeueWithTimeout(c.timeout)
+	return c.GetMempoolEntriesByAddressesContext(context.Background(), addresses, includeOrphanPool, filterTransactionPool)
+}
+
+// GetMempoolEntriesByAddressesContext operates the same as GetMempoolEntriesByAddresses, except that it gives up
+// waiting for the response once ctx is done
+func (c *RPCClient) GetMempoolEntriesByAddressesContext(ctx context.Context, addresses []string, includeOrphanPool bool, filterTransactionPool bool) (*appmessage.GetMempoolEntriesByAddressesResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGetMempoolEntriesByAddressesRequestMessage(addresses, includeOrphanPool, filterTransactionPool),
+		appmessage.CmdGetMempoolEntriesByAddressesResponseMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -18,3 +25,11 @@ func (c *RPCClient) GetMempoolEntriesByAddresses(addresses []string, includeOrph
 	}
 	return getMempoolEntriesByAddressesResponse, nil
 }
+
+// GetMempoolEntriesByAddressesAsync operates the same as GetMempoolEntriesByAddressesContext, except that it doesn't
+// wait for the response, and calls handler with the results once they're received
+func (c *RPCClient) GetMempoolEntriesByAddressesAsync(ctx context.Context, addresses []string, includeOrphanPool bool, filterTransactionPool bool, handler func(*appmessage.GetMempoolEntriesByAddressesResponseMessage, error)) {
+	spawn("GetMempoolEntriesByAddressesAsync", func() {
+		handler(c.GetMempoolEntriesByAddressesContext(ctx, addresses, includeOrphanPool, filterTransactionPool))
+	})
+}
@@ -1,14 +1,21 @@
 package rpcclient
 
-import "github.com/kaspanet/kaspad/app/appmessage"
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
 
 // GetMempoolEntry sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) GetMempoolEntry(txID string, includeOrphanPool bool, filterTransactionPool bool) (*appmessage.GetMempoolEntryResponseMessage, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetMempoolEntryRequestMessage(txID, includeOrphanPool, filterTransactionPool))
-	if err != nil {
-		return nil, err
-	}
-	response, err := c.route(appmessage.CmdGetMempoolEntryResponseMessage).DequeueWithTimeout(c.timeout)
+	return c.GetMempoolEntryContext(context.Background(), txID, includeOrphanPool, filterTransactionPool)
+}
+
+// GetMempoolEntryContext operates the same as GetMempoolEntry, except that it gives up waiting for the response once
+// ctx is done
+func (c *RPCClient) GetMempoolEntryContext(ctx context.Context, txID string, includeOrphanPool bool, filterTransactionPool bool) (*appmessage.GetMempoolEntryResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGetMempoolEntryRequestMessage(txID, includeOrphanPool, filterTransactionPool),
+		appmessage.CmdGetMempoolEntryResponseMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -18,3 +25,11 @@ func (c *RPCClient) GetMempoolEntry(txID string, includeOrphanPool bool, filterT
 	}
 	return getMempoolEntryResponse, nil
 }
+
+// GetMempoolEntryAsync operates the same as GetMempoolEntryContext, except that it doesn't wait for the response, and
+// calls handler with the results once they're received
+func (c *RPCClient) GetMempoolEntryAsync(ctx context.Context, txID string, includeOrphanPool bool, filterTransactionPool bool, handler func(*appmessage.GetMempoolEntryResponseMessage, error)) {
+	spawn("GetMempoolEntryAsync", func() {
+		handler(c.GetMempoolEntryContext(ctx, txID, includeOrphanPool, filterTransactionPool))
+	})
+}
@@ -1,14 +1,21 @@
 package rpcclient
 
-import "github.com/kaspanet/kaspad/app/appmessage"
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
 
 // GetPeerAddresses sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) GetPeerAddresses() (*appmessage.GetPeerAddressesResponseMessage, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetPeerAddressesRequestMessage())
-	if err != nil {
-		return nil, err
-	}
-	response, err := c.route(appmessage.CmdGetPeerAddressesResponseMessage).DequeueWithTimeout(c.timeout)
+	return c.GetPeerAddressesContext(context.Background())
+}
+
+// GetPeerAddressesContext operates the same as GetPeerAddresses, except that it gives up waiting for the response once
+// ctx is done
+func (c *RPCClient) GetPeerAddressesContext(ctx context.Context) (*appmessage.GetPeerAddressesResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGetPeerAddressesRequestMessage(),
+		appmessage.CmdGetPeerAddressesResponseMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -18,3 +25,11 @@ func (c *RPCClient) GetPeerAddresses() (*appmessage.GetPeerAddressesResponseMess
 	}
 	return getPeerAddressesResponse, nil
 }
+
+// GetPeerAddressesAsync operates the same as GetPeerAddressesContext, except that it doesn't wait for the response, and
+// calls handler with the results once they're received
+func (c *RPCClient) GetPeerAddressesAsync(ctx context.Context, handler func(*appmessage.GetPeerAddressesResponseMessage, error)) {
+	spawn("GetPeerAddressesAsync", func() {
+		handler(c.GetPeerAddressesContext(ctx))
+	})
+}
@@ -1,14 +1,21 @@
 package rpcclient
 
-import "github.com/kaspanet/kaspad/app/appmessage"
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
 
 // GetSelectedTipHash sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) GetSelectedTipHash() (*appmessage.GetSelectedTipHashResponseMessage, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetSelectedTipHashRequestMessage())
-	if err != nil {
-		return nil, err
-	}
-	response, err := c.route(appmessage.CmdGetSelectedTipHashResponseMessage).DequeueWithTimeout(c.timeout)
+	return c.GetSelectedTipHashContext(context.Background())
+}
+
+// GetSelectedTipHashContext operates the same as GetSelectedTipHash, except that it gives up waiting for the response
+// once ctx is done
+func (c *RPCClient) GetSelectedTipHashContext(ctx context.Context) (*appmessage.GetSelectedTipHashResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGetSelectedTipHashRequestMessage(),
+		appmessage.CmdGetSelectedTipHashResponseMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -18,3 +25,11 @@ func (c *RPCClient) GetSelectedTipHash() (*appmessage.GetSelectedTipHashResponse
 	}
 	return getSelectedTipHashResponse, nil
 }
+
+// GetSelectedTipHashAsync operates the same as GetSelectedTipHashContext, except that it doesn't wait for the response,
+// and calls handler with the results once they're received
+func (c *RPCClient) GetSelectedTipHashAsync(ctx context.Context, handler func(*appmessage.GetSelectedTipHashResponseMessage, error)) {
+	spawn("GetSelectedTipHashAsync", func() {
+		handler(c.GetSelectedTipHashContext(ctx))
+	})
+}
@@ -1,14 +1,21 @@
 package rpcclient
 
-import "github.com/kaspanet/kaspad/app/appmessage"
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
 
 // GetSubnetwork sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) GetSubnetwork(subnetworkID string) (*appmessage.GetSubnetworkResponseMessage, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetSubnetworkRequestMessage(subnetworkID))
-	if err != nil {
-		return nil, err
-	}
-	response, err := c.route(appmessage.CmdGetSubnetworkResponseMessage).DequeueWithTimeout(c.timeout)
+	return c.GetSubnetworkContext(context.Background(), subnetworkID)
+}
+
+// GetSubnetworkContext operates the same as GetSubnetwork, except that it gives up waiting for the response once ctx is
+// done
+func (c *RPCClient) GetSubnetworkContext(ctx context.Context, subnetworkID string) (*appmessage.GetSubnetworkResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGetSubnetworkRequestMessage(subnetworkID),
+		appmessage.CmdGetSubnetworkResponseMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -18,3 +25,11 @@ func (c *RPCClient) GetSubnetwork(subnetworkID string) (*appmessage.GetSubnetwor
 	}
 	return getSubnetworkResponse, nil
 }
+
+// GetSubnetworkAsync operates the same as GetSubnetworkContext, except that it doesn't wait for the response, and calls
+// handler with the results once they're received
+func (c *RPCClient) GetSubnetworkAsync(ctx context.Context, subnetworkID string, handler func(*appmessage.GetSubnetworkResponseMessage, error)) {
+	spawn("GetSubnetworkAsync", func() {
+		handler(c.GetSubnetworkContext(ctx, subnetworkID))
+	})
+}
@@ -0,0 +1,37 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// GetTransactionBlocklist sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetTransactionBlocklist() (*appmessage.GetTransactionBlocklistResponseMessage, error) {
+	return c.GetTransactionBlocklistContext(context.Background())
+}
+
+// GetTransactionBlocklistContext operates the same as GetTransactionBlocklist, except that it gives up waiting for the
+// response once ctx is done
+func (c *RPCClient) GetTransactionBlocklistContext(ctx context.Context) (*appmessage.GetTransactionBlocklistResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGetTransactionBlocklistRequestMessage(),
+		appmessage.CmdGetTransactionBlocklistResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	getTransactionBlocklistResponse := response.(*appmessage.GetTransactionBlocklistResponseMessage)
+	if getTransactionBlocklistResponse.Error != nil {
+		return nil, c.convertRPCError(getTransactionBlocklistResponse.Error)
+	}
+	return getTransactionBlocklistResponse, nil
+}
+
+// GetTransactionBlocklistAsync operates the same as GetTransactionBlocklistContext, except that it doesn't wait for the
+// response, and calls handler with the results once they're received
+func (c *RPCClient) GetTransactionBlocklistAsync(ctx context.Context,
+	handler func(*appmessage.GetTransactionBlocklistResponseMessage, error)) {
+
+	spawn("GetTransactionBlocklistAsync", func() {
+		handler(c.GetTransactionBlocklistContext(ctx))
+	})
+}
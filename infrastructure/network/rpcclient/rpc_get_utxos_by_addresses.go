@@ -1,14 +1,21 @@
 package rpcclient
 
-import "github.com/kaspanet/kaspad/app/appmessage"
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
 
 // GetUTXOsByAddresses sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) GetUTXOsByAddresses(addresses []string) (*appmessage.GetUTXOsByAddressesResponseMessage, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetUTXOsByAddressesRequestMessage(addresses))
-	if err != nil {
-		return nil, err
-	}
-	response, err := c.route(appmessage.CmdGetUTXOsByAddressesResponseMessage).DequeueWithTimeout(c.timeout)
+	return c.GetUTXOsByAddressesContext(context.Background(), addresses)
+}
+
+// GetUTXOsByAddressesContext operates the same as GetUTXOsByAddresses, except that it gives up waiting for the response
+// once ctx is done
+func (c *RPCClient) GetUTXOsByAddressesContext(ctx context.Context, addresses []string) (*appmessage.GetUTXOsByAddressesResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGetUTXOsByAddressesRequestMessage(addresses),
+		appmessage.CmdGetUTXOsByAddressesResponseMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -18,3 +25,11 @@ func (c *RPCClient) GetUTXOsByAddresses(addresses []string) (*appmessage.GetUTXO
 	}
 	return getUTXOsByAddressesResponse, nil
 }
+
+// GetUTXOsByAddressesAsync operates the same as GetUTXOsByAddressesContext, except that it doesn't wait for the
+// response, and calls handler with the results once they're received
+func (c *RPCClient) GetUTXOsByAddressesAsync(ctx context.Context, addresses []string, handler func(*appmessage.GetUTXOsByAddressesResponseMessage, error)) {
+	spawn("GetUTXOsByAddressesAsync", func() {
+		handler(c.GetUTXOsByAddressesContext(ctx, addresses))
+	})
+}
@@ -1,14 +1,21 @@
 package rpcclient
 
-import "github.com/kaspanet/kaspad/app/appmessage"
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
 
 // GetVirtualSelectedParentBlueScore sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) GetVirtualSelectedParentBlueScore() (*appmessage.GetVirtualSelectedParentBlueScoreResponseMessage, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewGetVirtualSelectedParentBlueScoreRequestMessage())
-	if err != nil {
-		return nil, err
-	}
-	response, err := c.route(appmessage.CmdGetVirtualSelectedParentBlueScoreResponseMessage).DequeueWithTimeout(c.timeout)
+	return c.GetVirtualSelectedParentBlueScoreContext(context.Background())
+}
+
+// GetVirtualSelectedParentBlueScoreContext operates the same as GetVirtualSelectedParentBlueScore, except that it gives
+// up waiting for the response once ctx is done
+func (c *RPCClient) GetVirtualSelectedParentBlueScoreContext(ctx context.Context) (*appmessage.GetVirtualSelectedParentBlueScoreResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGetVirtualSelectedParentBlueScoreRequestMessage(),
+		appmessage.CmdGetVirtualSelectedParentBlueScoreResponseMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -18,3 +25,11 @@ func (c *RPCClient) GetVirtualSelectedParentBlueScore() (*appmessage.GetVirtualS
 	}
 	return getVirtualSelectedParentBlueScoreResponse, nil
 }
+
+// GetVirtualSelectedParentBlueScoreAsync operates the same as GetVirtualSelectedParentBlueScoreContext, except that it
+// doesn't wait for the response, and calls handler with the results once they're received
+func (c *RPCClient) GetVirtualSelectedParentBlueScoreAsync(ctx context.Context, handler func(*appmessage.GetVirtualSelectedParentBlueScoreResponseMessage, error)) {
+	spawn("GetVirtualSelectedParentBlueScoreAsync", func() {
+		handler(c.GetVirtualSelectedParentBlueScoreContext(ctx))
+	})
+}
@@ -0,0 +1,34 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// GetWork sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) GetWork(payAddress string, extraData string) (*appmessage.GetWorkResponseMessage, error) {
+	return c.GetWorkContext(context.Background(), payAddress, extraData)
+}
+
+// GetWorkContext operates the same as GetWork, except that it gives up waiting for the response once ctx is done
+func (c *RPCClient) GetWorkContext(ctx context.Context, payAddress string, extraData string) (*appmessage.GetWorkResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewGetWorkRequestMessage(payAddress, extraData),
+		appmessage.CmdGetWorkResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	getWorkResponse := response.(*appmessage.GetWorkResponseMessage)
+	if getWorkResponse.Error != nil {
+		return nil, c.convertRPCError(getWorkResponse.Error)
+	}
+	return getWorkResponse, nil
+}
+
+// GetWorkAsync operates the same as GetWorkContext, except that it doesn't wait for the response, and calls handler
+// with the results once they're received
+func (c *RPCClient) GetWorkAsync(ctx context.Context, payAddress string, extraData string, handler func(*appmessage.GetWorkResponseMessage, error)) {
+	spawn("GetWorkAsync", func() {
+		handler(c.GetWorkContext(ctx, payAddress, extraData))
+	})
+}
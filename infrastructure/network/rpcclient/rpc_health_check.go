@@ -0,0 +1,35 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// HealthCheck sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) HealthCheck() (*appmessage.HealthCheckResponseMessage, error) {
+	return c.HealthCheckContext(context.Background())
+}
+
+// HealthCheckContext operates the same as HealthCheck, except that it gives up waiting for the response once ctx is
+// done
+func (c *RPCClient) HealthCheckContext(ctx context.Context) (*appmessage.HealthCheckResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewHealthCheckRequestMessage(),
+		appmessage.CmdHealthCheckResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	healthCheckResponse := response.(*appmessage.HealthCheckResponseMessage)
+	if healthCheckResponse.Error != nil {
+		return nil, c.convertRPCError(healthCheckResponse.Error)
+	}
+	return healthCheckResponse, nil
+}
+
+// HealthCheckAsync operates the same as HealthCheckContext, except that it doesn't wait for the response, and calls
+// handler with the results once they're received
+func (c *RPCClient) HealthCheckAsync(ctx context.Context, handler func(*appmessage.HealthCheckResponseMessage, error)) {
+	spawn("HealthCheckAsync", func() {
+		handler(c.HealthCheckContext(ctx))
+	})
+}
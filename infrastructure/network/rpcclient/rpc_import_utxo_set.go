@@ -0,0 +1,36 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// ImportUTXOSet sends an RPC request respective to the function's name and returns the RPC server's response.
+// If runAsJob is true, the response only holds the ID of the job that imports the file. See GetJobs
+func (c *RPCClient) ImportUTXOSet(filePath string, runAsJob bool) (*appmessage.ImportUTXOSetResponseMessage, error) {
+	return c.ImportUTXOSetContext(context.Background(), filePath, runAsJob)
+}
+
+// ImportUTXOSetContext operates the same as ImportUTXOSet, except that it gives up waiting for the response once ctx is
+// done
+func (c *RPCClient) ImportUTXOSetContext(ctx context.Context, filePath string, runAsJob bool) (*appmessage.ImportUTXOSetResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewImportUTXOSetRequestMessage(filePath, runAsJob),
+		appmessage.CmdImportUTXOSetResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	importUTXOSetResponse := response.(*appmessage.ImportUTXOSetResponseMessage)
+	if importUTXOSetResponse.Error != nil {
+		return nil, c.convertRPCError(importUTXOSetResponse.Error)
+	}
+	return importUTXOSetResponse, nil
+}
+
+// ImportUTXOSetAsync operates the same as ImportUTXOSetContext, except that it doesn't wait for the response, and calls
+// handler with the results once they're received
+func (c *RPCClient) ImportUTXOSetAsync(ctx context.Context, filePath string, runAsJob bool, handler func(*appmessage.ImportUTXOSetResponseMessage, error)) {
+	spawn("ImportUTXOSetAsync", func() {
+		handler(c.ImportUTXOSetContext(ctx, filePath, runAsJob))
+	})
+}
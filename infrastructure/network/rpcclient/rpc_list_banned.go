@@ -0,0 +1,33 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// ListBanned sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) ListBanned() (*appmessage.ListBannedResponseMessage, error) {
+	return c.ListBannedContext(context.Background())
+}
+
+// ListBannedContext operates the same as ListBanned, except that it gives up waiting for the response once ctx is done
+func (c *RPCClient) ListBannedContext(ctx context.Context) (*appmessage.ListBannedResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewListBannedRequestMessage(), appmessage.CmdListBannedResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	listBannedResponse := response.(*appmessage.ListBannedResponseMessage)
+	if listBannedResponse.Error != nil {
+		return nil, c.convertRPCError(listBannedResponse.Error)
+	}
+	return listBannedResponse, nil
+}
+
+// ListBannedAsync operates the same as ListBannedContext, except that it doesn't wait for the response, and calls
+// handler with the results once they're received
+func (c *RPCClient) ListBannedAsync(ctx context.Context, handler func(*appmessage.ListBannedResponseMessage, error)) {
+	spawn("ListBannedAsync", func() {
+		handler(c.ListBannedContext(ctx))
+	})
+}
@@ -0,0 +1,186 @@
+package rpcclient
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// notificationSession tracks which notifications of the client's notification session
+// were handled, so that the session is resumed after the right notification when the
+// client reconnects, and notifications that are sent again aren't handled twice
+type notificationSession struct {
+	sync.Mutex
+
+	id          string
+	historySize uint64
+
+	// lastSequence is the sequence up to which all notifications were handled
+	lastSequence uint64
+
+	// handledSequences holds the handled notifications after lastSequence, which
+	// were received after some notifications were dropped
+	handledSequences map[uint64]struct{}
+}
+
+func (s *notificationSession) start(id string, historySize uint32) {
+	s.Lock()
+	defer s.Unlock()
+
+	// The first notifications of the session may be handled before the
+	// response that started it, so the sequences that were handled before
+	// the client had a session belong to the new session
+	if s.id != "" || s.handledSequences == nil {
+		s.handledSequences = make(map[uint64]struct{})
+	}
+	s.id = id
+	s.historySize = uint64(historySize)
+	s.lastSequence = 0
+	s.advanceLastSequence()
+}
+
+// resume makes the session with the given ID, which is resumed after lastSequence, the tracked session
+func (s *notificationSession) resume(id string, lastSequence uint64) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.id != id {
+		s.historySize = 0
+	}
+	if s.handledSequences == nil {
+		s.handledSequences = make(map[uint64]struct{})
+	}
+	// Sent again notifications may be handled before the response that resumed the session
+	for handledSequence := range s.handledSequences {
+		if handledSequence <= lastSequence {
+			delete(s.handledSequences, handledSequence)
+		}
+	}
+	s.id = id
+	s.lastSequence = lastSequence
+	s.advanceLastSequence()
+}
+
+func (s *notificationSession) resumePoint() (id string, lastSequence uint64) {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.id, s.lastSequence
+}
+
+// markHandled marks the notification with the given sequence as handled, and returns
+// whether it wasn't handled before. It also returns whether some notifications that
+// came before it were dropped and can no longer be sent again by resuming the session
+func (s *notificationSession) markHandled(sequence uint64) (isNew bool, isMissingNotifications bool) {
+	s.Lock()
+	defer s.Unlock()
+
+	// Notifications without a sequence weren't sent as part of a session
+	if sequence == 0 {
+		return true, false
+	}
+	if s.id == "" {
+		if s.handledSequences == nil {
+			s.handledSequences = make(map[uint64]struct{})
+		}
+		s.handledSequences[sequence] = struct{}{}
+		return true, false
+	}
+	if _, ok := s.handledSequences[sequence]; ok || sequence <= s.lastSequence {
+		return false, false
+	}
+
+	s.handledSequences[sequence] = struct{}{}
+	// The history size of a session that was resumed by another client is unknown
+	if s.historySize > 0 && uint64(len(s.handledSequences)) > s.historySize {
+		// The notifications after lastSequence are no longer kept by the
+		// server, so skip to the first handled notification after them
+		firstHandledSequence := sequence
+		for handledSequence := range s.handledSequences {
+			if handledSequence < firstHandledSequence {
+				firstHandledSequence = handledSequence
+			}
+		}
+		s.lastSequence = firstHandledSequence - 1
+		isMissingNotifications = true
+	}
+	s.advanceLastSequence()
+	return true, isMissingNotifications
+}
+
+func (s *notificationSession) advanceLastSequence() {
+	for {
+		if _, ok := s.handledSequences[s.lastSequence+1]; !ok {
+			return
+		}
+		delete(s.handledSequences, s.lastSequence+1)
+		s.lastSequence++
+	}
+}
+
+// StartNotificationSession sends an RPC request respective to the function's name and returns the RPC server's response.
+// Every notification received afterwards carries its sequence in NotificationSequence. Once a session is started,
+// the client resumes it whenever it reconnects, so that it doesn't miss notifications while it's disconnected
+func (c *RPCClient) StartNotificationSession() (*appmessage.StartNotificationSessionResponseMessage, error) {
+	return c.StartNotificationSessionContext(context.Background())
+}
+
+// StartNotificationSessionContext operates the same as StartNotificationSession, except that it gives up waiting for
+// the response once ctx is done
+func (c *RPCClient) StartNotificationSessionContext(ctx context.Context) (*appmessage.StartNotificationSessionResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewStartNotificationSessionRequestMessage(),
+		appmessage.CmdStartNotificationSessionResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	startNotificationSessionResponse := response.(*appmessage.StartNotificationSessionResponseMessage)
+	if startNotificationSessionResponse.Error != nil {
+		return nil, c.convertRPCError(startNotificationSessionResponse.Error)
+	}
+	c.notificationSession.start(startNotificationSessionResponse.SessionID, startNotificationSessionResponse.HistorySize)
+	return startNotificationSessionResponse, nil
+}
+
+// StartNotificationSessionAsync operates the same as StartNotificationSessionContext, except that it doesn't wait for
+// the response, and calls handler with the results once they're received
+func (c *RPCClient) StartNotificationSessionAsync(ctx context.Context, handler func(*appmessage.StartNotificationSessionResponseMessage, error)) {
+	spawn("StartNotificationSessionAsync", func() {
+		handler(c.StartNotificationSessionContext(ctx))
+	})
+}
+
+// ResumeNotificationSession sends an RPC request respective to the function's name and returns the RPC server's response.
+// The missed notifications are passed to the handlers of the notifications this client is registered for, so the
+// client should register for the session's notifications before resuming it
+func (c *RPCClient) ResumeNotificationSession(sessionID string, lastSequence uint64) (
+	*appmessage.ResumeNotificationSessionResponseMessage, error) {
+
+	return c.ResumeNotificationSessionContext(context.Background(), sessionID, lastSequence)
+}
+
+// ResumeNotificationSessionContext operates the same as ResumeNotificationSession, except that it gives up waiting for
+// the response once ctx is done
+func (c *RPCClient) ResumeNotificationSessionContext(ctx context.Context, sessionID string, lastSequence uint64) (
+	*appmessage.ResumeNotificationSessionResponseMessage, error) {
+
+	response, err := c.request(ctx, appmessage.NewResumeNotificationSessionRequestMessage(sessionID, lastSequence),
+		appmessage.CmdResumeNotificationSessionResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	resumeNotificationSessionResponse := response.(*appmessage.ResumeNotificationSessionResponseMessage)
+	if resumeNotificationSessionResponse.Error != nil {
+		return nil, c.convertRPCError(resumeNotificationSessionResponse.Error)
+	}
+	c.notificationSession.resume(sessionID, lastSequence)
+	return resumeNotificationSessionResponse, nil
+}
+
+// ResumeNotificationSessionAsync operates the same as ResumeNotificationSessionContext, except that it doesn't wait for
+// the response, and calls handler with the results once they're received
+func (c *RPCClient) ResumeNotificationSessionAsync(ctx context.Context, sessionID string, lastSequence uint64, handler func(*appmessage.ResumeNotificationSessionResponseMessage, error)) {
+	spawn("ResumeNotificationSessionAsync", func() {
+		handler(c.ResumeNotificationSessionContext(ctx, sessionID, lastSequence))
+	})
+}
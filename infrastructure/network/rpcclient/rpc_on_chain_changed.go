@@ -1,9 +1,9 @@
 package rpcclient
 
 import (
+	"context"
+
 	"github.com/kaspanet/kaspad/app/appmessage"
-	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
-	"github.com/pkg/errors"
 )
 
 // RegisterForVirtualSelectedParentChainChangedNotifications sends an RPC request respective to the function's name and returns the RPC server's response.
@@ -11,31 +11,81 @@ import (
 func (c *RPCClient) RegisterForVirtualSelectedParentChainChangedNotifications(includeAcceptedTransactionIDs bool,
 	onChainChanged func(notification *appmessage.VirtualSelectedParentChainChangedNotificationMessage)) error {
 
-	err := c.rpcRouter.outgoingRoute().Enqueue(
-		appmessage.NewNotifyVirtualSelectedParentChainChangedRequestMessage(includeAcceptedTransactionIDs))
-	if err != nil {
-		return err
-	}
-	response, err := c.route(appmessage.CmdNotifyVirtualSelectedParentChainChangedResponseMessage).DequeueWithTimeout(c.timeout)
-	if err != nil {
-		return err
-	}
-	notifyChainChangedResponse := response.(*appmessage.NotifyVirtualSelectedParentChainChangedResponseMessage)
-	if notifyChainChangedResponse.Error != nil {
-		return c.convertRPCError(notifyChainChangedResponse.Error)
-	}
-	spawn("RegisterForVirtualSelectedParentChainChangedNotifications", func() {
-		for {
-			notification, err := c.route(appmessage.CmdVirtualSelectedParentChainChangedNotificationMessage).Dequeue()
-			if err != nil {
-				if errors.Is(err, routerpkg.ErrRouteClosed) {
-					break
-				}
-				panic(err)
-			}
+	return c.RegisterForVirtualSelectedParentChainChangedNotificationsContext(context.Background(), includeAcceptedTransactionIDs, onChainChanged)
+}
+
+// RegisterForVirtualSelectedParentChainChangedNotificationsContext operates the same as
+// RegisterForVirtualSelectedParentChainChangedNotifications, except that it gives up waiting for the response once ctx
+// is done
+func (c *RPCClient) RegisterForVirtualSelectedParentChainChangedNotificationsContext(ctx context.Context, includeAcceptedTransactionIDs bool,
+	onChainChanged func(notification *appmessage.VirtualSelectedParentChainChangedNotificationMessage)) error {
+
+	return c.registerForVirtualSelectedParentChainChangedNotifications(ctx,
+		appmessage.NewNotifyVirtualSelectedParentChainChangedRequestMessage(includeAcceptedTransactionIDs), onChainChanged)
+}
+
+// RegisterForVirtualSelectedParentChainChangedNotificationsWithAcceptanceData is the same as
+// RegisterForVirtualSelectedParentChainChangedNotifications, except that the notifications also
+// include, for every added chain block, which transactions of every merged block were accepted
+// and which were rejected
+func (c *RPCClient) RegisterForVirtualSelectedParentChainChangedNotificationsWithAcceptanceData(
+	onChainChanged func(notification *appmessage.VirtualSelectedParentChainChangedNotificationMessage)) error {
+
+	return c.RegisterForVirtualSelectedParentChainChangedNotificationsWithAcceptanceDataContext(context.Background(), onChainChanged)
+}
+
+// RegisterForVirtualSelectedParentChainChangedNotificationsWithAcceptanceDataContext operates the same as
+// RegisterForVirtualSelectedParentChainChangedNotificationsWithAcceptanceData, except that it gives up waiting for the
+// response once ctx is done
+func (c *RPCClient) RegisterForVirtualSelectedParentChainChangedNotificationsWithAcceptanceDataContext(ctx context.Context,
+	onChainChanged func(notification *appmessage.VirtualSelectedParentChainChangedNotificationMessage)) error {
+
+	request := appmessage.NewNotifyVirtualSelectedParentChainChangedRequestMessage(false)
+	request.IncludeAcceptanceData = true
+	return c.registerForVirtualSelectedParentChainChangedNotifications(ctx, request, onChainChanged)
+}
+
+func (c *RPCClient) registerForVirtualSelectedParentChainChangedNotifications(ctx context.Context,
+	request *appmessage.NotifyVirtualSelectedParentChainChangedRequestMessage,
+	onChainChanged func(notification *appmessage.VirtualSelectedParentChainChangedNotificationMessage)) error {
+
+	return c.subscribe(ctx, func(ctx context.Context) error {
+		response, err := c.request(ctx, request, appmessage.CmdNotifyVirtualSelectedParentChainChangedResponseMessage)
+		if err != nil {
+			return err
+		}
+		notifyChainChangedResponse := response.(*appmessage.NotifyVirtualSelectedParentChainChangedResponseMessage)
+		if notifyChainChangedResponse.Error != nil {
+			return c.convertRPCError(notifyChainChangedResponse.Error)
+		}
+		c.handleNotifications("RegisterForVirtualSelectedParentChainChangedNotifications", appmessage.CmdVirtualSelectedParentChainChangedNotificationMessage, func(notification appmessage.Message) {
 			ChainChangedNotification := notification.(*appmessage.VirtualSelectedParentChainChangedNotificationMessage)
 			onChainChanged(ChainChangedNotification)
+		})
+		return nil
+	})
+}
+
+// UnregisterFromVirtualSelectedParentChainChangedNotifications sends an RPC request respective to the function's name and returns the RPC server's response.
+// Additionally, it stops the RPC server from sending chain changed notifications
+func (c *RPCClient) UnregisterFromVirtualSelectedParentChainChangedNotifications() error {
+	return c.UnregisterFromVirtualSelectedParentChainChangedNotificationsContext(context.Background())
+}
+
+// UnregisterFromVirtualSelectedParentChainChangedNotificationsContext operates the same as
+// UnregisterFromVirtualSelectedParentChainChangedNotifications, except that it gives up waiting for the response once
+// ctx is done
+func (c *RPCClient) UnregisterFromVirtualSelectedParentChainChangedNotificationsContext(ctx context.Context) error {
+	return c.subscribe(ctx, func(ctx context.Context) error {
+		response, err := c.request(ctx, appmessage.NewStopNotifyingVirtualSelectedParentChainChangedRequestMessage(),
+			appmessage.CmdStopNotifyingVirtualSelectedParentChainChangedResponseMessage)
+		if err != nil {
+			return err
+		}
+		stopNotifyingChainChangedResponse := response.(*appmessage.StopNotifyingVirtualSelectedParentChainChangedResponseMessage)
+		if stopNotifyingChainChangedResponse.Error != nil {
+			return c.convertRPCError(stopNotifyingChainChangedResponse.Error)
 		}
+		return nil
 	})
-	return nil
 }
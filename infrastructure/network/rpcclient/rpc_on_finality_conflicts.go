@@ -1,9 +1,9 @@
 package rpcclient
 
 import (
+	"context"
+
 	"github.com/kaspanet/kaspad/app/appmessage"
-	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
-	"github.com/pkg/errors"
 )
 
 // RegisterForFinalityConflictsNotifications sends an RPC request respective to the function's name and returns the RPC server's response.
@@ -12,43 +12,33 @@ func (c *RPCClient) RegisterForFinalityConflictsNotifications(
 	onFinalityConflict func(notification *appmessage.FinalityConflictNotificationMessage),
 	onFinalityConflictResolved func(notification *appmessage.FinalityConflictResolvedNotificationMessage)) error {
 
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewNotifyFinalityConflictsRequestMessage())
-	if err != nil {
-		return err
-	}
-	response, err := c.route(appmessage.CmdNotifyFinalityConflictsResponseMessage).DequeueWithTimeout(c.timeout)
-	if err != nil {
-		return err
-	}
-	notifyFinalityConflictsResponse := response.(*appmessage.NotifyFinalityConflictsResponseMessage)
-	if notifyFinalityConflictsResponse.Error != nil {
-		return c.convertRPCError(notifyFinalityConflictsResponse.Error)
-	}
-	spawn("RegisterForFinalityConflictsNotifications-finalityConflict", func() {
-		for {
-			notification, err := c.route(appmessage.CmdFinalityConflictNotificationMessage).Dequeue()
-			if err != nil {
-				if errors.Is(err, routerpkg.ErrRouteClosed) {
-					break
-				}
-				panic(err)
-			}
+	return c.RegisterForFinalityConflictsNotificationsContext(context.Background(), onFinalityConflict, onFinalityConflictResolved)
+}
+
+// RegisterForFinalityConflictsNotificationsContext operates the same as RegisterForFinalityConflictsNotifications,
+// except that it gives up waiting for the response once ctx is done
+func (c *RPCClient) RegisterForFinalityConflictsNotificationsContext(ctx context.Context,
+	onFinalityConflict func(notification *appmessage.FinalityConflictNotificationMessage),
+	onFinalityConflictResolved func(notification *appmessage.FinalityConflictResolvedNotificationMessage)) error {
+
+	return c.subscribe(ctx, func(ctx context.Context) error {
+		response, err := c.request(ctx, appmessage.NewNotifyFinalityConflictsRequestMessage(),
+			appmessage.CmdNotifyFinalityConflictsResponseMessage)
+		if err != nil {
+			return err
+		}
+		notifyFinalityConflictsResponse := response.(*appmessage.NotifyFinalityConflictsResponseMessage)
+		if notifyFinalityConflictsResponse.Error != nil {
+			return c.convertRPCError(notifyFinalityConflictsResponse.Error)
+		}
+		c.handleNotifications("RegisterForFinalityConflictsNotifications-finalityConflict", appmessage.CmdFinalityConflictNotificationMessage, func(notification appmessage.Message) {
 			finalityConflictNotification := notification.(*appmessage.FinalityConflictNotificationMessage)
 			onFinalityConflict(finalityConflictNotification)
-		}
-	})
-	spawn("RegisterForFinalityConflictsNotifications-finalityConflictResolved", func() {
-		for {
-			notification, err := c.route(appmessage.CmdFinalityConflictResolvedNotificationMessage).Dequeue()
-			if err != nil {
-				if errors.Is(err, routerpkg.ErrRouteClosed) {
-					break
-				}
-				panic(err)
-			}
+		})
+		c.handleNotifications("RegisterForFinalityConflictsNotifications-finalityConflictResolved", appmessage.CmdFinalityConflictResolvedNotificationMessage, func(notification appmessage.Message) {
 			finalityConflictResolvedNotification := notification.(*appmessage.FinalityConflictResolvedNotificationMessage)
 			onFinalityConflictResolved(finalityConflictResolvedNotification)
-		}
+		})
+		return nil
 	})
-	return nil
 }
@@ -0,0 +1,57 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// RegisterForFinalityPointAdvancedNotifications sends an RPC request respective to the function's name and returns the RPC server's response.
+// Additionally, it starts listening for the appropriate notification using the given handler function
+func (c *RPCClient) RegisterForFinalityPointAdvancedNotifications(onFinalityPointAdvanced func(notification *appmessage.FinalityPointAdvancedNotificationMessage)) error {
+	return c.RegisterForFinalityPointAdvancedNotificationsContext(context.Background(), onFinalityPointAdvanced)
+}
+
+// RegisterForFinalityPointAdvancedNotificationsContext operates the same as
+// RegisterForFinalityPointAdvancedNotifications, except that it gives up waiting for the response once ctx is done
+func (c *RPCClient) RegisterForFinalityPointAdvancedNotificationsContext(ctx context.Context, onFinalityPointAdvanced func(notification *appmessage.FinalityPointAdvancedNotificationMessage)) error {
+	return c.subscribe(ctx, func(ctx context.Context) error {
+		response, err := c.request(ctx, appmessage.NewNotifyFinalityPointAdvancedRequestMessage(),
+			appmessage.CmdNotifyFinalityPointAdvancedResponseMessage)
+		if err != nil {
+			return err
+		}
+		notifyFinalityPointAdvancedResponse := response.(*appmessage.NotifyFinalityPointAdvancedResponseMessage)
+		if notifyFinalityPointAdvancedResponse.Error != nil {
+			return c.convertRPCError(notifyFinalityPointAdvancedResponse.Error)
+		}
+		c.handleNotifications("RegisterForFinalityPointAdvancedNotifications", appmessage.CmdFinalityPointAdvancedNotificationMessage, func(notification appmessage.Message) {
+			finalityPointAdvancedNotification := notification.(*appmessage.FinalityPointAdvancedNotificationMessage)
+			onFinalityPointAdvanced(finalityPointAdvancedNotification)
+		})
+		return nil
+	})
+}
+
+// UnregisterFromFinalityPointAdvancedNotifications sends an RPC request respective to the function's name and returns the RPC server's response.
+// Additionally, it stops the RPC server from sending finality point advanced notifications
+func (c *RPCClient) UnregisterFromFinalityPointAdvancedNotifications() error {
+	return c.UnregisterFromFinalityPointAdvancedNotificationsContext(context.Background())
+}
+
+// UnregisterFromFinalityPointAdvancedNotificationsContext operates the same as
+// UnregisterFromFinalityPointAdvancedNotifications, except that it gives up waiting for the response once ctx is done
+func (c *RPCClient) UnregisterFromFinalityPointAdvancedNotificationsContext(ctx context.Context) error {
+	return c.subscribe(ctx, func(ctx context.Context) error {
+		response, err := c.request(ctx, appmessage.NewStopNotifyingFinalityPointAdvancedRequestMessage(),
+			appmessage.CmdStopNotifyingFinalityPointAdvancedResponseMessage)
+		if err != nil {
+			return err
+		}
+		stopNotifyingFinalityPointAdvancedResponse := response.(*appmessage.StopNotifyingFinalityPointAdvancedResponseMessage)
+		if stopNotifyingFinalityPointAdvancedResponse.Error != nil {
+			return c.convertRPCError(stopNotifyingFinalityPointAdvancedResponse.Error)
+		}
+		return nil
+	})
+}
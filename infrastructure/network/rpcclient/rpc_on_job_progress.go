@@ -0,0 +1,57 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// RegisterForJobProgressNotifications sends an RPC request respective to the function's name and returns the RPC server's response.
+// Additionally, it starts listening for the appropriate notification using the given handler function
+func (c *RPCClient) RegisterForJobProgressNotifications(onJobProgress func(notification *appmessage.JobProgressNotificationMessage)) error {
+	return c.RegisterForJobProgressNotificationsContext(context.Background(), onJobProgress)
+}
+
+// RegisterForJobProgressNotificationsContext operates the same as
+// RegisterForJobProgressNotifications, except that it gives up waiting for the response once ctx is done
+func (c *RPCClient) RegisterForJobProgressNotificationsContext(ctx context.Context, onJobProgress func(notification *appmessage.JobProgressNotificationMessage)) error {
+	return c.subscribe(ctx, func(ctx context.Context) error {
+		response, err := c.request(ctx, appmessage.NewNotifyJobProgressRequestMessage(),
+			appmessage.CmdNotifyJobProgressResponseMessage)
+		if err != nil {
+			return err
+		}
+		notifyJobProgressResponse := response.(*appmessage.NotifyJobProgressResponseMessage)
+		if notifyJobProgressResponse.Error != nil {
+			return c.convertRPCError(notifyJobProgressResponse.Error)
+		}
+		c.handleNotifications("RegisterForJobProgressNotifications", appmessage.CmdJobProgressNotificationMessage, func(notification appmessage.Message) {
+			jobProgressNotification := notification.(*appmessage.JobProgressNotificationMessage)
+			onJobProgress(jobProgressNotification)
+		})
+		return nil
+	})
+}
+
+// UnregisterFromJobProgressNotifications sends an RPC request respective to the function's name and returns the RPC server's response.
+// Additionally, it stops the RPC server from sending job progress notifications
+func (c *RPCClient) UnregisterFromJobProgressNotifications() error {
+	return c.UnregisterFromJobProgressNotificationsContext(context.Background())
+}
+
+// UnregisterFromJobProgressNotificationsContext operates the same as
+// UnregisterFromJobProgressNotifications, except that it gives up waiting for the response once ctx is done
+func (c *RPCClient) UnregisterFromJobProgressNotificationsContext(ctx context.Context) error {
+	return c.subscribe(ctx, func(ctx context.Context) error {
+		response, err := c.request(ctx, appmessage.NewStopNotifyingJobProgressRequestMessage(),
+			appmessage.CmdStopNotifyingJobProgressResponseMessage)
+		if err != nil {
+			return err
+		}
+		stopNotifyingJobProgressResponse := response.(*appmessage.StopNotifyingJobProgressResponseMessage)
+		if stopNotifyingJobProgressResponse.Error != nil {
+			return c.convertRPCError(stopNotifyingJobProgressResponse.Error)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,57 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// RegisterForNewTipNotifications sends an RPC request respective to the function's name and returns the RPC server's response.
+// Additionally, it starts listening for the appropriate notification using the given handler function
+func (c *RPCClient) RegisterForNewTipNotifications(onNewTip func(notification *appmessage.NewTipNotificationMessage)) error {
+	return c.RegisterForNewTipNotificationsContext(context.Background(), onNewTip)
+}
+
+// RegisterForNewTipNotificationsContext operates the same as RegisterForNewTipNotifications, except that it gives up
+// waiting for the response once ctx is done
+func (c *RPCClient) RegisterForNewTipNotificationsContext(ctx context.Context, onNewTip func(notification *appmessage.NewTipNotificationMessage)) error {
+	return c.subscribe(ctx, func(ctx context.Context) error {
+		response, err := c.request(ctx, appmessage.NewNotifyNewTipRequestMessage(),
+			appmessage.CmdNotifyNewTipResponseMessage)
+		if err != nil {
+			return err
+		}
+		notifyNewTipResponse := response.(*appmessage.NotifyNewTipResponseMessage)
+		if notifyNewTipResponse.Error != nil {
+			return c.convertRPCError(notifyNewTipResponse.Error)
+		}
+		c.handleNotifications("RegisterForNewTipNotifications", appmessage.CmdNewTipNotificationMessage, func(notification appmessage.Message) {
+			newTipNotification := notification.(*appmessage.NewTipNotificationMessage)
+			onNewTip(newTipNotification)
+		})
+		return nil
+	})
+}
+
+// UnregisterFromNewTipNotifications sends an RPC request respective to the function's name and returns the RPC server's response.
+// Additionally, it stops the RPC server from sending new tip notifications
+func (c *RPCClient) UnregisterFromNewTipNotifications() error {
+	return c.UnregisterFromNewTipNotificationsContext(context.Background())
+}
+
+// UnregisterFromNewTipNotificationsContext operates the same as UnregisterFromNewTipNotifications, except that it gives
+// up waiting for the response once ctx is done
+func (c *RPCClient) UnregisterFromNewTipNotificationsContext(ctx context.Context) error {
+	return c.subscribe(ctx, func(ctx context.Context) error {
+		response, err := c.request(ctx, appmessage.NewStopNotifyingNewTipRequestMessage(),
+			appmessage.CmdStopNotifyingNewTipResponseMessage)
+		if err != nil {
+			return err
+		}
+		stopNotifyingNewTipResponse := response.(*appmessage.StopNotifyingNewTipResponseMessage)
+		if stopNotifyingNewTipResponse.Error != nil {
+			return c.convertRPCError(stopNotifyingNewTipResponse.Error)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,62 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// RegisterForNewTransactionsNotifications sends an RPC request respective to the function's name and returns the RPC server's response.
+// Additionally, it starts listening for the appropriate notification using the given handler function.
+// Only transactions that pass the given filters are notified. See NotifyNewTransactionsRequestMessage
+func (c *RPCClient) RegisterForNewTransactionsNotifications(addresses []string, subnetworkID string, minimumAmount uint64,
+	onNewTransactions func(notification *appmessage.NewTransactionsNotificationMessage)) error {
+
+	return c.RegisterForNewTransactionsNotificationsContext(context.Background(), addresses, subnetworkID, minimumAmount, onNewTransactions)
+}
+
+// RegisterForNewTransactionsNotificationsContext operates the same as RegisterForNewTransactionsNotifications, except
+// that it gives up waiting for the response once ctx is done
+func (c *RPCClient) RegisterForNewTransactionsNotificationsContext(ctx context.Context, addresses []string, subnetworkID string, minimumAmount uint64,
+	onNewTransactions func(notification *appmessage.NewTransactionsNotificationMessage)) error {
+
+	return c.subscribe(ctx, func(ctx context.Context) error {
+		response, err := c.request(ctx, appmessage.NewNotifyNewTransactionsRequestMessage(addresses, subnetworkID, minimumAmount),
+			appmessage.CmdNotifyNewTransactionsResponseMessage)
+		if err != nil {
+			return err
+		}
+		notifyNewTransactionsResponse := response.(*appmessage.NotifyNewTransactionsResponseMessage)
+		if notifyNewTransactionsResponse.Error != nil {
+			return c.convertRPCError(notifyNewTransactionsResponse.Error)
+		}
+		c.handleNotifications("RegisterForNewTransactionsNotifications", appmessage.CmdNewTransactionsNotificationMessage, func(notification appmessage.Message) {
+			newTransactionsNotification := notification.(*appmessage.NewTransactionsNotificationMessage)
+			onNewTransactions(newTransactionsNotification)
+		})
+		return nil
+	})
+}
+
+// UnregisterFromNewTransactionsNotifications sends an RPC request respective to the function's name and returns the RPC server's response.
+// Additionally, it stops the RPC server from sending new transactions notifications
+func (c *RPCClient) UnregisterFromNewTransactionsNotifications() error {
+	return c.UnregisterFromNewTransactionsNotificationsContext(context.Background())
+}
+
+// UnregisterFromNewTransactionsNotificationsContext operates the same as UnregisterFromNewTransactionsNotifications,
+// except that it gives up waiting for the response once ctx is done
+func (c *RPCClient) UnregisterFromNewTransactionsNotificationsContext(ctx context.Context) error {
+	return c.subscribe(ctx, func(ctx context.Context) error {
+		response, err := c.request(ctx, appmessage.NewStopNotifyingNewTransactionsRequestMessage(),
+			appmessage.CmdStopNotifyingNewTransactionsResponseMessage)
+		if err != nil {
+			return err
+		}
+		stopNotifyingNewTransactionsResponse := response.(*appmessage.StopNotifyingNewTransactionsResponseMessage)
+		if stopNotifyingNewTransactionsResponse.Error != nil {
+			return c.convertRPCError(stopNotifyingNewTransactionsResponse.Error)
+		}
+		return nil
+	})
+}
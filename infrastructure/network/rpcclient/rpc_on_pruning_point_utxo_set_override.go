@@ -1,58 +1,59 @@
 package rpcclient
 
 import (
+	"context"
+
 	"github.com/kaspanet/kaspad/app/appmessage"
-	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
-	"github.com/pkg/errors"
 )
 
 // RegisterPruningPointUTXOSetNotifications sends an RPC request respective to the function's name and returns the RPC server's response.
 // Additionally, it starts listening for the appropriate notification using the given handler function
 func (c *RPCClient) RegisterPruningPointUTXOSetNotifications(onPruningPointUTXOSetNotifications func()) error {
+	return c.RegisterPruningPointUTXOSetNotificationsContext(context.Background(), onPruningPointUTXOSetNotifications)
+}
+
+// RegisterPruningPointUTXOSetNotificationsContext operates the same as RegisterPruningPointUTXOSetNotifications, except
+// that it gives up waiting for the response once ctx is done
+func (c *RPCClient) RegisterPruningPointUTXOSetNotificationsContext(ctx context.Context, onPruningPointUTXOSetNotifications func()) error {
 
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewNotifyPruningPointUTXOSetOverrideRequestMessage())
-	if err != nil {
-		return err
-	}
-	response, err := c.route(appmessage.CmdNotifyPruningPointUTXOSetOverrideResponseMessage).DequeueWithTimeout(c.timeout)
-	if err != nil {
-		return err
-	}
-	notifyPruningPointUTXOSetOverrideResponse := response.(*appmessage.NotifyPruningPointUTXOSetOverrideResponseMessage)
-	if notifyPruningPointUTXOSetOverrideResponse.Error != nil {
-		return c.convertRPCError(notifyPruningPointUTXOSetOverrideResponse.Error)
-	}
-	spawn("RegisterPruningPointUTXOSetNotifications", func() {
-		for {
-			notification, err := c.route(appmessage.CmdPruningPointUTXOSetOverrideNotificationMessage).Dequeue()
-			if err != nil {
-				if errors.Is(err, routerpkg.ErrRouteClosed) {
-					break
-				}
-				panic(err)
-			}
+	return c.subscribe(ctx, func(ctx context.Context) error {
+		response, err := c.request(ctx, appmessage.NewNotifyPruningPointUTXOSetOverrideRequestMessage(),
+			appmessage.CmdNotifyPruningPointUTXOSetOverrideResponseMessage)
+		if err != nil {
+			return err
+		}
+		notifyPruningPointUTXOSetOverrideResponse := response.(*appmessage.NotifyPruningPointUTXOSetOverrideResponseMessage)
+		if notifyPruningPointUTXOSetOverrideResponse.Error != nil {
+			return c.convertRPCError(notifyPruningPointUTXOSetOverrideResponse.Error)
+		}
+		c.handleNotifications("RegisterPruningPointUTXOSetNotifications", appmessage.CmdPruningPointUTXOSetOverrideNotificationMessage, func(notification appmessage.Message) {
 			_ = notification.(*appmessage.PruningPointUTXOSetOverrideNotificationMessage) // Sanity check the type
 			onPruningPointUTXOSetNotifications()
-		}
+		})
+		return nil
 	})
-	return nil
 }
 
 // UnregisterPruningPointUTXOSetNotifications sends an RPC request respective to the function's name and returns the RPC server's response.
 // Additionally, it stops listening for the appropriate notification using the given handler function
 func (c *RPCClient) UnregisterPruningPointUTXOSetNotifications() error {
+	return c.UnregisterPruningPointUTXOSetNotificationsContext(context.Background())
+}
+
+// UnregisterPruningPointUTXOSetNotificationsContext operates the same as UnregisterPruningPointUTXOSetNotifications,
+// except that it gives up waiting for the response once ctx is done
+func (c *RPCClient) UnregisterPruningPointUTXOSetNotificationsContext(ctx context.Context) error {
 
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewStopNotifyingPruningPointUTXOSetOverrideRequestMessage())
-	if err != nil {
-		return err
-	}
-	response, err := c.route(appmessage.CmdStopNotifyingPruningPointUTXOSetOverrideResponseMessage).DequeueWithTimeout(c.timeout)
-	if err != nil {
-		return err
-	}
-	stopNotifyPruningPointUTXOSetOverrideResponse := response.(*appmessage.StopNotifyingPruningPointUTXOSetOverrideResponseMessage)
-	if stopNotifyPruningPointUTXOSetOverrideResponse.Error != nil {
-		return c.convertRPCError(stopNotifyPruningPointUTXOSetOverrideResponse.Error)
-	}
-	return nil
+	return c.subscribe(ctx, func(ctx context.Context) error {
+		response, err := c.request(ctx, appmessage.NewStopNotifyingPruningPointUTXOSetOverrideRequestMessage(),
+			appmessage.CmdStopNotifyingPruningPointUTXOSetOverrideResponseMessage)
+		if err != nil {
+			return err
+		}
+		stopNotifyPruningPointUTXOSetOverrideResponse := response.(*appmessage.StopNotifyingPruningPointUTXOSetOverrideResponseMessage)
+		if stopNotifyPruningPointUTXOSetOverrideResponse.Error != nil {
+			return c.convertRPCError(stopNotifyPruningPointUTXOSetOverrideResponse.Error)
+		}
+		return nil
+	})
 }
@@ -1,9 +1,9 @@
 package rpcclient
 
 import (
+	"context"
+
 	"github.com/kaspanet/kaspad/app/appmessage"
-	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
-	"github.com/pkg/errors"
 )
 
 // RegisterForUTXOsChangedNotifications sends an RPC request respective to the function's name and returns the RPC server's response.
@@ -11,11 +11,63 @@ import (
 func (c *RPCClient) RegisterForUTXOsChangedNotifications(addresses []string,
 	onUTXOsChanged func(notification *appmessage.UTXOsChangedNotificationMessage)) error {
 
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewNotifyUTXOsChangedRequestMessage(addresses))
-	if err != nil {
-		return err
-	}
-	response, err := c.route(appmessage.CmdNotifyUTXOsChangedResponseMessage).DequeueWithTimeout(c.timeout)
+	return c.RegisterForUTXOsChangedNotificationsContext(context.Background(), addresses, onUTXOsChanged)
+}
+
+// RegisterForUTXOsChangedNotificationsContext operates the same as RegisterForUTXOsChangedNotifications, except that it
+// gives up waiting for the response once ctx is done
+func (c *RPCClient) RegisterForUTXOsChangedNotificationsContext(ctx context.Context, addresses []string,
+	onUTXOsChanged func(notification *appmessage.UTXOsChangedNotificationMessage)) error {
+
+	return c.RegisterForUTXOsChangedNotificationsWithMempoolContext(ctx, addresses, false, onUTXOsChanged)
+}
+
+// RegisterForUTXOsChangedNotificationsWithMempool is the same as RegisterForUTXOsChangedNotifications,
+// except that if includeMempool is true, the handler function is also called with UTXOs that are
+// created or spent by transactions entering the mempool.
+func (c *RPCClient) RegisterForUTXOsChangedNotificationsWithMempool(addresses []string, includeMempool bool,
+	onUTXOsChanged func(notification *appmessage.UTXOsChangedNotificationMessage)) error {
+
+	return c.RegisterForUTXOsChangedNotificationsWithMempoolContext(context.Background(), addresses, includeMempool, onUTXOsChanged)
+}
+
+// RegisterForUTXOsChangedNotificationsWithMempoolContext operates the same as
+// RegisterForUTXOsChangedNotificationsWithMempool, except that it gives up waiting for the response once ctx is done
+func (c *RPCClient) RegisterForUTXOsChangedNotificationsWithMempoolContext(ctx context.Context, addresses []string, includeMempool bool,
+	onUTXOsChanged func(notification *appmessage.UTXOsChangedNotificationMessage)) error {
+
+	return c.subscribe(ctx, func(ctx context.Context) error {
+		err := c.modifyUTXOsChangedNotifications(ctx, addresses, includeMempool)
+		if err != nil {
+			return err
+		}
+		c.handleNotifications("RegisterForUTXOsChangedNotifications", appmessage.CmdUTXOsChangedNotificationMessage, func(notification appmessage.Message) {
+			UTXOsChangedNotification := notification.(*appmessage.UTXOsChangedNotificationMessage)
+			onUTXOsChanged(UTXOsChangedNotification)
+		})
+		return nil
+	})
+}
+
+// ModifyUTXOsChangedNotifications adds the given addresses to an existing UTXOs changed subscription,
+// and sets whether it includes UTXOs changed by transactions entering the mempool. Notifications are
+// delivered to the handler function that was given when registering.
+func (c *RPCClient) ModifyUTXOsChangedNotifications(addresses []string, includeMempool bool) error {
+	return c.ModifyUTXOsChangedNotificationsContext(context.Background(), addresses, includeMempool)
+}
+
+// ModifyUTXOsChangedNotificationsContext operates the same as ModifyUTXOsChangedNotifications, except that it gives up
+// waiting for the response once ctx is done
+func (c *RPCClient) ModifyUTXOsChangedNotificationsContext(ctx context.Context, addresses []string, includeMempool bool) error {
+	return c.subscribe(ctx, func(ctx context.Context) error {
+		return c.modifyUTXOsChangedNotifications(ctx, addresses, includeMempool)
+	})
+}
+
+func (c *RPCClient) modifyUTXOsChangedNotifications(ctx context.Context, addresses []string, includeMempool bool) error {
+	request := appmessage.NewNotifyUTXOsChangedRequestMessage(addresses)
+	request.IncludeMempool = includeMempool
+	response, err := c.request(ctx, request, appmessage.CmdNotifyUTXOsChangedResponseMessage)
 	if err != nil {
 		return err
 	}
@@ -23,18 +75,28 @@ func (c *RPCClient) RegisterForUTXOsChangedNotifications(addresses []string,
 	if notifyUTXOsChangedResponse.Error != nil {
 		return c.convertRPCError(notifyUTXOsChangedResponse.Error)
 	}
-	spawn("RegisterForUTXOsChangedNotifications", func() {
-		for {
-			notification, err := c.route(appmessage.CmdUTXOsChangedNotificationMessage).Dequeue()
-			if err != nil {
-				if errors.Is(err, routerpkg.ErrRouteClosed) {
-					break
-				}
-				panic(err)
-			}
-			UTXOsChangedNotification := notification.(*appmessage.UTXOsChangedNotificationMessage)
-			onUTXOsChanged(UTXOsChangedNotification)
+	return nil
+}
+
+// StopNotifyingUTXOsChanged sends an RPC request respective to the function's name and returns the RPC server's response.
+// Leave addresses empty, or remove the last address, to stop the notifications entirely.
+func (c *RPCClient) StopNotifyingUTXOsChanged(addresses []string) error {
+	return c.StopNotifyingUTXOsChangedContext(context.Background(), addresses)
+}
+
+// StopNotifyingUTXOsChangedContext operates the same as StopNotifyingUTXOsChanged, except that it gives up waiting for
+// the response once ctx is done
+func (c *RPCClient) StopNotifyingUTXOsChangedContext(ctx context.Context, addresses []string) error {
+	return c.subscribe(ctx, func(ctx context.Context) error {
+		response, err := c.request(ctx, appmessage.NewStopNotifyingUTXOsChangedRequestMessage(addresses),
+			appmessage.CmdStopNotifyingUTXOsChangedResponseMessage)
+		if err != nil {
+			return err
 		}
+		stopNotifyingUTXOsChangedResponse := response.(*appmessage.StopNotifyingUTXOsChangedResponseMessage)
+		if stopNotifyingUTXOsChangedResponse.Error != nil {
+			return c.convertRPCError(stopNotifyingUTXOsChangedResponse.Error)
+		}
+		return nil
 	})
-	return nil
 }
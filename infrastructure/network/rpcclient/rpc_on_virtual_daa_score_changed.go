@@ -1,9 +1,9 @@
 package rpcclient
 
 import (
+	"context"
+
 	"github.com/kaspanet/kaspad/app/appmessage"
-	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
-	"github.com/pkg/errors"
 )
 
 // RegisterForVirtualDaaScoreChangedNotifications sends an RPC request respective to the function's
@@ -12,30 +12,28 @@ import (
 func (c *RPCClient) RegisterForVirtualDaaScoreChangedNotifications(
 	onVirtualDaaScoreChanged func(notification *appmessage.VirtualDaaScoreChangedNotificationMessage)) error {
 
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewNotifyVirtualDaaScoreChangedRequestMessage())
-	if err != nil {
-		return err
-	}
-	response, err := c.route(appmessage.CmdNotifyVirtualDaaScoreChangedResponseMessage).DequeueWithTimeout(c.timeout)
-	if err != nil {
-		return err
-	}
-	notifyVirtualDaaScoreChangedResponse := response.(*appmessage.NotifyVirtualDaaScoreChangedResponseMessage)
-	if notifyVirtualDaaScoreChangedResponse.Error != nil {
-		return c.convertRPCError(notifyVirtualDaaScoreChangedResponse.Error)
-	}
-	spawn("RegisterForVirtualDaaScoreChangedNotifications", func() {
-		for {
-			notification, err := c.route(appmessage.CmdVirtualDaaScoreChangedNotificationMessage).Dequeue()
-			if err != nil {
-				if errors.Is(err, routerpkg.ErrRouteClosed) {
-					break
-				}
-				panic(err)
-			}
+	return c.RegisterForVirtualDaaScoreChangedNotificationsContext(context.Background(), onVirtualDaaScoreChanged)
+}
+
+// RegisterForVirtualDaaScoreChangedNotificationsContext operates the same as
+// RegisterForVirtualDaaScoreChangedNotifications, except that it gives up waiting for the response once ctx is done
+func (c *RPCClient) RegisterForVirtualDaaScoreChangedNotificationsContext(ctx context.Context,
+	onVirtualDaaScoreChanged func(notification *appmessage.VirtualDaaScoreChangedNotificationMessage)) error {
+
+	return c.subscribe(ctx, func(ctx context.Context) error {
+		response, err := c.request(ctx, appmessage.NewNotifyVirtualDaaScoreChangedRequestMessage(),
+			appmessage.CmdNotifyVirtualDaaScoreChangedResponseMessage)
+		if err != nil {
+			return err
+		}
+		notifyVirtualDaaScoreChangedResponse := response.(*appmessage.NotifyVirtualDaaScoreChangedResponseMessage)
+		if notifyVirtualDaaScoreChangedResponse.Error != nil {
+			return c.convertRPCError(notifyVirtualDaaScoreChangedResponse.Error)
+		}
+		c.handleNotifications("RegisterForVirtualDaaScoreChangedNotifications", appmessage.CmdVirtualDaaScoreChangedNotificationMessage, func(notification appmessage.Message) {
 			VirtualDaaScoreChangedNotification := notification.(*appmessage.VirtualDaaScoreChangedNotificationMessage)
 			onVirtualDaaScoreChanged(VirtualDaaScoreChangedNotification)
-		}
+		})
+		return nil
 	})
-	return nil
 }
@@ -1,9 +1,9 @@
 package rpcclient
 
 import (
+	"context"
+
 	"github.com/kaspanet/kaspad/app/appmessage"
-	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
-	"github.com/pkg/errors"
 )
 
 // RegisterForVirtualSelectedParentBlueScoreChangedNotifications sends an RPC request respective to the function's
@@ -12,30 +12,29 @@ import (
 func (c *RPCClient) RegisterForVirtualSelectedParentBlueScoreChangedNotifications(
 	onVirtualSelectedParentBlueScoreChanged func(notification *appmessage.VirtualSelectedParentBlueScoreChangedNotificationMessage)) error {
 
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewNotifyVirtualSelectedParentBlueScoreChangedRequestMessage())
-	if err != nil {
-		return err
-	}
-	response, err := c.route(appmessage.CmdNotifyVirtualSelectedParentBlueScoreChangedResponseMessage).DequeueWithTimeout(c.timeout)
-	if err != nil {
-		return err
-	}
-	notifyVirtualSelectedParentBlueScoreChangedResponse := response.(*appmessage.NotifyVirtualSelectedParentBlueScoreChangedResponseMessage)
-	if notifyVirtualSelectedParentBlueScoreChangedResponse.Error != nil {
-		return c.convertRPCError(notifyVirtualSelectedParentBlueScoreChangedResponse.Error)
-	}
-	spawn("RegisterForVirtualSelectedParentBlueScoreChangedNotifications", func() {
-		for {
-			notification, err := c.route(appmessage.CmdVirtualSelectedParentBlueScoreChangedNotificationMessage).Dequeue()
-			if err != nil {
-				if errors.Is(err, routerpkg.ErrRouteClosed) {
-					break
-				}
-				panic(err)
-			}
+	return c.RegisterForVirtualSelectedParentBlueScoreChangedNotificationsContext(context.Background(), onVirtualSelectedParentBlueScoreChanged)
+}
+
+// RegisterForVirtualSelectedParentBlueScoreChangedNotificationsContext operates the same as
+// RegisterForVirtualSelectedParentBlueScoreChangedNotifications, except that it gives up waiting for the response once
+// ctx is done
+func (c *RPCClient) RegisterForVirtualSelectedParentBlueScoreChangedNotificationsContext(ctx context.Context,
+	onVirtualSelectedParentBlueScoreChanged func(notification *appmessage.VirtualSelectedParentBlueScoreChangedNotificationMessage)) error {
+
+	return c.subscribe(ctx, func(ctx context.Context) error {
+		response, err := c.request(ctx, appmessage.NewNotifyVirtualSelectedParentBlueScoreChangedRequestMessage(),
+			appmessage.CmdNotifyVirtualSelectedParentBlueScoreChangedResponseMessage)
+		if err != nil {
+			return err
+		}
+		notifyVirtualSelectedParentBlueScoreChangedResponse := response.(*appmessage.NotifyVirtualSelectedParentBlueScoreChangedResponseMessage)
+		if notifyVirtualSelectedParentBlueScoreChangedResponse.Error != nil {
+			return c.convertRPCError(notifyVirtualSelectedParentBlueScoreChangedResponse.Error)
+		}
+		c.handleNotifications("RegisterForVirtualSelectedParentBlueScoreChangedNotifications", appmessage.CmdVirtualSelectedParentBlueScoreChangedNotificationMessage, func(notification appmessage.Message) {
 			VirtualSelectedParentBlueScoreChangedNotification := notification.(*appmessage.VirtualSelectedParentBlueScoreChangedNotificationMessage)
 			onVirtualSelectedParentBlueScoreChanged(VirtualSelectedParentBlueScoreChangedNotification)
-		}
+		})
+		return nil
 	})
-	return nil
 }
@@ -0,0 +1,34 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// RegisterForWorkNotifications sends an RPC request respective to the function's name and returns the RPC server's response.
+// Additionally, it starts listening for the appropriate notification using the given handler function
+func (c *RPCClient) RegisterForWorkNotifications(payAddress string, extraData string, onWork func(notification *appmessage.WorkNotificationMessage)) error {
+	return c.RegisterForWorkNotificationsContext(context.Background(), payAddress, extraData, onWork)
+}
+
+// RegisterForWorkNotificationsContext operates the same as RegisterForWorkNotifications, except that it gives up
+// waiting for the response once ctx is done
+func (c *RPCClient) RegisterForWorkNotificationsContext(ctx context.Context, payAddress string, extraData string, onWork func(notification *appmessage.WorkNotificationMessage)) error {
+	return c.subscribe(ctx, func(ctx context.Context) error {
+		response, err := c.request(ctx, appmessage.NewNotifyWorkRequestMessage(payAddress, extraData),
+			appmessage.CmdNotifyWorkResponseMessage)
+		if err != nil {
+			return err
+		}
+		notifyWorkResponse := response.(*appmessage.NotifyWorkResponseMessage)
+		if notifyWorkResponse.Error != nil {
+			return c.convertRPCError(notifyWorkResponse.Error)
+		}
+		c.handleNotifications("RegisterForWorkNotifications", appmessage.CmdWorkNotificationMessage, func(notification appmessage.Message) {
+			workNotification := notification.(*appmessage.WorkNotificationMessage)
+			onWork(workNotification)
+		})
+		return nil
+	})
+}
@@ -0,0 +1,35 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// RebuildIndex sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) RebuildIndex(indexName string) (*appmessage.RebuildIndexResponseMessage, error) {
+	return c.RebuildIndexContext(context.Background(), indexName)
+}
+
+// RebuildIndexContext operates the same as RebuildIndex, except that it gives up waiting for the response
+// once ctx is done
+func (c *RPCClient) RebuildIndexContext(ctx context.Context, indexName string) (*appmessage.RebuildIndexResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewRebuildIndexRequestMessage(indexName),
+		appmessage.CmdRebuildIndexResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	rebuildIndexResponse := response.(*appmessage.RebuildIndexResponseMessage)
+	if rebuildIndexResponse.Error != nil {
+		return nil, c.convertRPCError(rebuildIndexResponse.Error)
+	}
+	return rebuildIndexResponse, nil
+}
+
+// RebuildIndexAsync operates the same as RebuildIndexContext, except that it doesn't wait for the response,
+// and calls handler with the results once they're received
+func (c *RPCClient) RebuildIndexAsync(ctx context.Context, indexName string, handler func(*appmessage.RebuildIndexResponseMessage, error)) {
+	spawn("RebuildIndexAsync", func() {
+		handler(c.RebuildIndexContext(ctx, indexName))
+	})
+}
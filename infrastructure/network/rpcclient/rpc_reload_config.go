@@ -0,0 +1,35 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// ReloadConfig sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) ReloadConfig() (*appmessage.ReloadConfigResponseMessage, error) {
+	return c.ReloadConfigContext(context.Background())
+}
+
+// ReloadConfigContext operates the same as ReloadConfig, except that it gives up waiting for the response
+// once ctx is done
+func (c *RPCClient) ReloadConfigContext(ctx context.Context) (*appmessage.ReloadConfigResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewReloadConfigRequestMessage(),
+		appmessage.CmdReloadConfigResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	reloadConfigResponse := response.(*appmessage.ReloadConfigResponseMessage)
+	if reloadConfigResponse.Error != nil {
+		return nil, c.convertRPCError(reloadConfigResponse.Error)
+	}
+	return reloadConfigResponse, nil
+}
+
+// ReloadConfigAsync operates the same as ReloadConfigContext, except that it doesn't wait for the response,
+// and calls handler with the results once they're received
+func (c *RPCClient) ReloadConfigAsync(ctx context.Context, handler func(*appmessage.ReloadConfigResponseMessage, error)) {
+	spawn("ReloadConfigAsync", func() {
+		handler(c.ReloadConfigContext(ctx))
+	})
+}
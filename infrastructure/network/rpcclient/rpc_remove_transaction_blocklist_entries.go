@@ -0,0 +1,39 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// RemoveTransactionBlocklistEntries sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) RemoveTransactionBlocklistEntries(entries []*appmessage.TransactionBlocklistEntry) (*appmessage.RemoveTransactionBlocklistEntriesResponseMessage, error) {
+	return c.RemoveTransactionBlocklistEntriesContext(context.Background(), entries)
+}
+
+// RemoveTransactionBlocklistEntriesContext operates the same as RemoveTransactionBlocklistEntries, except that it gives up waiting for the
+// response once ctx is done
+func (c *RPCClient) RemoveTransactionBlocklistEntriesContext(ctx context.Context, entries []*appmessage.TransactionBlocklistEntry) (
+	*appmessage.RemoveTransactionBlocklistEntriesResponseMessage, error) {
+
+	response, err := c.request(ctx, appmessage.NewRemoveTransactionBlocklistEntriesRequestMessage(entries),
+		appmessage.CmdRemoveTransactionBlocklistEntriesResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	removeTransactionBlocklistEntriesResponse := response.(*appmessage.RemoveTransactionBlocklistEntriesResponseMessage)
+	if removeTransactionBlocklistEntriesResponse.Error != nil {
+		return nil, c.convertRPCError(removeTransactionBlocklistEntriesResponse.Error)
+	}
+	return removeTransactionBlocklistEntriesResponse, nil
+}
+
+// RemoveTransactionBlocklistEntriesAsync operates the same as RemoveTransactionBlocklistEntriesContext, except that it doesn't wait for the
+// response, and calls handler with the results once they're received
+func (c *RPCClient) RemoveTransactionBlocklistEntriesAsync(ctx context.Context, entries []*appmessage.TransactionBlocklistEntry,
+	handler func(*appmessage.RemoveTransactionBlocklistEntriesResponseMessage, error)) {
+
+	spawn("RemoveTransactionBlocklistEntriesAsync", func() {
+		handler(c.RemoveTransactionBlocklistEntriesContext(ctx, entries))
+	})
+}
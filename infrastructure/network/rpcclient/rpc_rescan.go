@@ -0,0 +1,95 @@
+package rpcclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+// staleRescanNotificationTimeout is how long Rescan waits for progress
+// notifications of previous rescans while it discards them
+const staleRescanNotificationTimeout = time.Millisecond
+
+// Rescan sends an RPC request respective to the function's name, and calls onProgress with every
+// RescanProgress notification of the rescan until it finishes. See RescanRequestMessage
+func (c *RPCClient) Rescan(startBlueScore uint64, addresses []string, outpoints []*appmessage.RPCOutpoint,
+	onProgress func(notification *appmessage.RescanProgressNotificationMessage)) (*appmessage.RescanResponseMessage, error) {
+
+	return c.RescanContext(context.Background(), startBlueScore, addresses, outpoints, onProgress)
+}
+
+// RescanContext operates the same as Rescan, except that it stops the rescan and gives up once ctx is done.
+// A rescan that was interrupted can be resumed from the checkpoint of the last notification onProgress was called with
+func (c *RPCClient) RescanContext(ctx context.Context, startBlueScore uint64, addresses []string, outpoints []*appmessage.RPCOutpoint,
+	onProgress func(notification *appmessage.RescanProgressNotificationMessage)) (*appmessage.RescanResponseMessage, error) {
+
+	// Stopping the previous rescan, if there is one, makes sure that
+	// all of its notifications were received, so they can be discarded
+	err := c.StopRescanContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	route := c.route(appmessage.CmdRescanProgressNotificationMessage)
+	for {
+		_, err := route.DequeueWithTimeout(staleRescanNotificationTimeout)
+		if err != nil {
+			if errors.Is(err, routerpkg.ErrTimeout) {
+				break
+			}
+			return nil, err
+		}
+	}
+
+	response, err := c.request(ctx, appmessage.NewRescanRequestMessage(startBlueScore, addresses, outpoints),
+		appmessage.CmdRescanResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	rescanResponse := response.(*appmessage.RescanResponseMessage)
+	if rescanResponse.Error != nil {
+		return nil, c.convertRPCError(rescanResponse.Error)
+	}
+
+	for {
+		notification, err := route.DequeueWithContext(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				stopErr := c.StopRescanContext(context.Background())
+				if stopErr != nil {
+					log.Warnf("Error stopping a rescan that was given up on: %s", stopErr)
+				}
+			}
+			return nil, err
+		}
+		rescanProgressNotification := notification.(*appmessage.RescanProgressNotificationMessage)
+		if rescanProgressNotification.Error != nil {
+			return nil, c.convertRPCError(rescanProgressNotification.Error)
+		}
+		onProgress(rescanProgressNotification)
+		if rescanProgressNotification.IsFinished {
+			return rescanResponse, nil
+		}
+	}
+}
+
+// StopRescan sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) StopRescan() error {
+	return c.StopRescanContext(context.Background())
+}
+
+// StopRescanContext operates the same as StopRescan, except that it gives up waiting for the response
+// once ctx is done
+func (c *RPCClient) StopRescanContext(ctx context.Context) error {
+	response, err := c.request(ctx, appmessage.NewStopRescanRequestMessage(), appmessage.CmdStopRescanResponseMessage)
+	if err != nil {
+		return err
+	}
+	stopRescanResponse := response.(*appmessage.StopRescanResponseMessage)
+	if stopRescanResponse.Error != nil {
+		return c.convertRPCError(stopRescanResponse.Error)
+	}
+	return nil
+}
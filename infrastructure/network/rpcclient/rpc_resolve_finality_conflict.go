@@ -1,14 +1,21 @@
 package rpcclient
 
-import "github.com/kaspanet/kaspad/app/appmessage"
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
 
 // ResolveFinalityConflict sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) ResolveFinalityConflict(finalityBlockHash string) (*appmessage.ResolveFinalityConflictResponseMessage, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewResolveFinalityConflictRequestMessage(finalityBlockHash))
-	if err != nil {
-		return nil, err
-	}
-	response, err := c.route(appmessage.CmdResolveFinalityConflictResponseMessage).DequeueWithTimeout(c.timeout)
+	return c.ResolveFinalityConflictContext(context.Background(), finalityBlockHash)
+}
+
+// ResolveFinalityConflictContext operates the same as ResolveFinalityConflict, except that it gives up waiting for the
+// response once ctx is done
+func (c *RPCClient) ResolveFinalityConflictContext(ctx context.Context, finalityBlockHash string) (*appmessage.ResolveFinalityConflictResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewResolveFinalityConflictRequestMessage(finalityBlockHash),
+		appmessage.CmdResolveFinalityConflictResponseMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -18,3 +25,11 @@ func (c *RPCClient) ResolveFinalityConflict(finalityBlockHash string) (*appmessa
 	}
 	return resolveFinalityConflictResponse, nil
 }
+
+// ResolveFinalityConflictAsync operates the same as ResolveFinalityConflictContext, except that it doesn't wait for the
+// response, and calls handler with the results once they're received
+func (c *RPCClient) ResolveFinalityConflictAsync(ctx context.Context, finalityBlockHash string, handler func(*appmessage.ResolveFinalityConflictResponseMessage, error)) {
+	spawn("ResolveFinalityConflictAsync", func() {
+		handler(c.ResolveFinalityConflictContext(ctx, finalityBlockHash))
+	})
+}
@@ -0,0 +1,40 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// SearchRawTransactions sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) SearchRawTransactions(address string, limit uint32, cursor string,
+	includeMempool bool) (*appmessage.SearchRawTransactionsResponseMessage, error) {
+
+	return c.SearchRawTransactionsContext(context.Background(), address, limit, cursor, includeMempool)
+}
+
+// SearchRawTransactionsContext operates the same as SearchRawTransactions, except that it gives up waiting for the
+// response once ctx is done
+func (c *RPCClient) SearchRawTransactionsContext(ctx context.Context, address string, limit uint32, cursor string,
+	includeMempool bool) (*appmessage.SearchRawTransactionsResponseMessage, error) {
+
+	response, err := c.request(ctx, appmessage.NewSearchRawTransactionsRequestMessage(address, limit, cursor, includeMempool),
+		appmessage.CmdSearchRawTransactionsResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	searchRawTransactionsResponse := response.(*appmessage.SearchRawTransactionsResponseMessage)
+	if searchRawTransactionsResponse.Error != nil {
+		return nil, c.convertRPCError(searchRawTransactionsResponse.Error)
+	}
+	return searchRawTransactionsResponse, nil
+}
+
+// SearchRawTransactionsAsync operates the same as SearchRawTransactionsContext, except that it doesn't wait for the
+// response, and calls handler with the results once they're received
+func (c *RPCClient) SearchRawTransactionsAsync(ctx context.Context, address string, limit uint32, cursor string,
+	includeMempool bool, handler func(*appmessage.SearchRawTransactionsResponseMessage, error)) {
+	spawn("SearchRawTransactionsAsync", func() {
+		handler(c.SearchRawTransactionsContext(ctx, address, limit, cursor, includeMempool))
+	})
+}
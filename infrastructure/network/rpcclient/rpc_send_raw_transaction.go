@@ -1,16 +1,21 @@
 package rpcclient
 
 import (
+	"context"
+
 	"github.com/kaspanet/kaspad/app/appmessage"
 )
 
 // SubmitTransaction sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) SubmitTransaction(transaction *appmessage.RPCTransaction, allowOrphan bool) (*appmessage.SubmitTransactionResponseMessage, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewSubmitTransactionRequestMessage(transaction, allowOrphan))
-	if err != nil {
-		return nil, err
-	}
-	response, err := c.route(appmessage.CmdSubmitTransactionResponseMessage).DequeueWithTimeout(c.timeout)
+	return c.SubmitTransactionContext(context.Background(), transaction, allowOrphan)
+}
+
+// SubmitTransactionContext operates the same as SubmitTransaction, except that it gives up waiting for the response
+// once ctx is done
+func (c *RPCClient) SubmitTransactionContext(ctx context.Context, transaction *appmessage.RPCTransaction, allowOrphan bool) (*appmessage.SubmitTransactionResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewSubmitTransactionRequestMessage(transaction, allowOrphan),
+		appmessage.CmdSubmitTransactionResponseMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -21,3 +26,11 @@ func (c *RPCClient) SubmitTransaction(transaction *appmessage.RPCTransaction, al
 
 	return submitTransactionResponse, nil
 }
+
+// SubmitTransactionAsync operates the same as SubmitTransactionContext, except that it doesn't wait for the response,
+// and calls handler with the results once they're received
+func (c *RPCClient) SubmitTransactionAsync(ctx context.Context, transaction *appmessage.RPCTransaction, allowOrphan bool, handler func(*appmessage.SubmitTransactionResponseMessage, error)) {
+	spawn("SubmitTransactionAsync", func() {
+		handler(c.SubmitTransactionContext(ctx, transaction, allowOrphan))
+	})
+}
@@ -0,0 +1,34 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// SetBan sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) SetBan(subnet string, durationSeconds uint64, remove bool) (*appmessage.SetBanResponseMessage, error) {
+	return c.SetBanContext(context.Background(), subnet, durationSeconds, remove)
+}
+
+// SetBanContext operates the same as SetBan, except that it gives up waiting for the response once ctx is done
+func (c *RPCClient) SetBanContext(ctx context.Context, subnet string, durationSeconds uint64, remove bool) (*appmessage.SetBanResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewSetBanRequestMessage(subnet, durationSeconds, remove),
+		appmessage.CmdSetBanResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	setBanResponse := response.(*appmessage.SetBanResponseMessage)
+	if setBanResponse.Error != nil {
+		return nil, c.convertRPCError(setBanResponse.Error)
+	}
+	return setBanResponse, nil
+}
+
+// SetBanAsync operates the same as SetBanContext, except that it doesn't wait for the response, and calls handler with
+// the results once they're received
+func (c *RPCClient) SetBanAsync(ctx context.Context, subnet string, durationSeconds uint64, remove bool, handler func(*appmessage.SetBanResponseMessage, error)) {
+	spawn("SetBanAsync", func() {
+		handler(c.SetBanContext(ctx, subnet, durationSeconds, remove))
+	})
+}
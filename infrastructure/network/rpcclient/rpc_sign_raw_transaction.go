@@ -0,0 +1,40 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// SignRawTransaction sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) SignRawTransaction(transaction *appmessage.RPCTransaction, privateKeys []string,
+	previousOutputs []*appmessage.SignRawTransactionPreviousOutput) (*appmessage.SignRawTransactionResponseMessage, error) {
+
+	return c.SignRawTransactionContext(context.Background(), transaction, privateKeys, previousOutputs)
+}
+
+// SignRawTransactionContext operates the same as SignRawTransaction, except that it gives up waiting for the response
+// once ctx is done
+func (c *RPCClient) SignRawTransactionContext(ctx context.Context, transaction *appmessage.RPCTransaction, privateKeys []string,
+	previousOutputs []*appmessage.SignRawTransactionPreviousOutput) (*appmessage.SignRawTransactionResponseMessage, error) {
+
+	response, err := c.request(ctx, appmessage.NewSignRawTransactionRequestMessage(transaction, privateKeys, previousOutputs),
+		appmessage.CmdSignRawTransactionResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	signRawTransactionResponse := response.(*appmessage.SignRawTransactionResponseMessage)
+	if signRawTransactionResponse.Error != nil {
+		return nil, c.convertRPCError(signRawTransactionResponse.Error)
+	}
+	return signRawTransactionResponse, nil
+}
+
+// SignRawTransactionAsync operates the same as SignRawTransactionContext, except that it doesn't wait for the response,
+// and calls handler with the results once they're received
+func (c *RPCClient) SignRawTransactionAsync(ctx context.Context, transaction *appmessage.RPCTransaction, privateKeys []string,
+	previousOutputs []*appmessage.SignRawTransactionPreviousOutput, handler func(*appmessage.SignRawTransactionResponseMessage, error)) {
+	spawn("SignRawTransactionAsync", func() {
+		handler(c.SignRawTransactionContext(ctx, transaction, privateKeys, previousOutputs))
+	})
+}
@@ -1,17 +1,15 @@
 package rpcclient
 
 import (
+	"context"
+
 	"github.com/kaspanet/kaspad/app/appmessage"
 	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
 )
 
-func (c *RPCClient) submitBlock(block *externalapi.DomainBlock, allowNonDAABlocks bool) (appmessage.RejectReason, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(
-		appmessage.NewSubmitBlockRequestMessage(appmessage.DomainBlockToRPCBlock(block), allowNonDAABlocks))
-	if err != nil {
-		return appmessage.RejectReasonNone, err
-	}
-	response, err := c.route(appmessage.CmdSubmitBlockResponseMessage).DequeueWithTimeout(c.timeout)
+func (c *RPCClient) submitBlock(ctx context.Context, block *externalapi.DomainBlock, allowNonDAABlocks bool) (appmessage.RejectReason, error) {
+	response, err := c.request(ctx, appmessage.NewSubmitBlockRequestMessage(appmessage.DomainBlockToRPCBlock(block), allowNonDAABlocks),
+		appmessage.CmdSubmitBlockResponseMessage)
 	if err != nil {
 		return appmessage.RejectReasonNone, err
 	}
@@ -24,10 +22,52 @@ func (c *RPCClient) submitBlock(block *externalapi.DomainBlock, allowNonDAABlock
 
 // SubmitBlock sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) SubmitBlock(block *externalapi.DomainBlock) (appmessage.RejectReason, error) {
-	return c.submitBlock(block, false)
+	return c.SubmitBlockContext(context.Background(), block)
+}
+
+// SubmitBlockContext operates the same as SubmitBlock, except that it gives up waiting for the response once ctx is
+// done
+func (c *RPCClient) SubmitBlockContext(ctx context.Context, block *externalapi.DomainBlock) (appmessage.RejectReason, error) {
+	return c.submitBlock(ctx, block, false)
 }
 
 // SubmitBlockAlsoIfNonDAA operates the same as SubmitBlock with the exception that `allowNonDAABlocks` is set to true
 func (c *RPCClient) SubmitBlockAlsoIfNonDAA(block *externalapi.DomainBlock) (appmessage.RejectReason, error) {
-	return c.submitBlock(block, true)
+	return c.SubmitBlockAlsoIfNonDAAContext(context.Background(), block)
+}
+
+// SubmitBlockAlsoIfNonDAAContext operates the same as SubmitBlockAlsoIfNonDAA, except that it gives up waiting for the
+// response once ctx is done
+func (c *RPCClient) SubmitBlockAlsoIfNonDAAContext(ctx context.Context, block *externalapi.DomainBlock) (appmessage.RejectReason, error) {
+	return c.submitBlock(ctx, block, true)
+}
+
+// SubmitBlockAlsoIfNonDAAAsync operates the same as SubmitBlockAlsoIfNonDAAContext, except that it doesn't wait for the
+// response, and calls handler with the results once they're received
+func (c *RPCClient) SubmitBlockAlsoIfNonDAAAsync(ctx context.Context, block *externalapi.DomainBlock, handler func(appmessage.RejectReason, error)) {
+	spawn("SubmitBlockAlsoIfNonDAAAsync", func() {
+		handler(c.SubmitBlockAlsoIfNonDAAContext(ctx, block))
+	})
+}
+
+// SubmitBlockAsync submits the given block without waiting for it to be validated, and returns
+// an ID to query the outcome of the submission with using GetBlockSubmissionStatus
+func (c *RPCClient) SubmitBlockAsync(block *externalapi.DomainBlock) (submissionID string, err error) {
+	return c.SubmitBlockAsyncContext(context.Background(), block)
+}
+
+// SubmitBlockAsyncContext operates the same as SubmitBlockAsync, except that it gives up waiting for the response once
+// ctx is done
+func (c *RPCClient) SubmitBlockAsyncContext(ctx context.Context, block *externalapi.DomainBlock) (submissionID string, err error) {
+	request := appmessage.NewSubmitBlockRequestMessage(appmessage.DomainBlockToRPCBlock(block), false)
+	request.Async = true
+	response, err := c.request(ctx, request, appmessage.CmdSubmitBlockResponseMessage)
+	if err != nil {
+		return "", err
+	}
+	submitBlockResponse := response.(*appmessage.SubmitBlockResponseMessage)
+	if submitBlockResponse.Error != nil {
+		return "", c.convertRPCError(submitBlockResponse.Error)
+	}
+	return submitBlockResponse.SubmissionID, nil
 }
@@ -0,0 +1,34 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+// SubmitWork sends an RPC request respective to the function's name and returns the RPC server's response
+func (c *RPCClient) SubmitWork(workID string, nonce uint64) (*appmessage.SubmitWorkResponseMessage, error) {
+	return c.SubmitWorkContext(context.Background(), workID, nonce)
+}
+
+// SubmitWorkContext operates the same as SubmitWork, except that it gives up waiting for the response once ctx is done
+func (c *RPCClient) SubmitWorkContext(ctx context.Context, workID string, nonce uint64) (*appmessage.SubmitWorkResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewSubmitWorkRequestMessage(workID, nonce),
+		appmessage.CmdSubmitWorkResponseMessage)
+	if err != nil {
+		return nil, err
+	}
+	submitWorkResponse := response.(*appmessage.SubmitWorkResponseMessage)
+	if submitWorkResponse.Error != nil {
+		return nil, c.convertRPCError(submitWorkResponse.Error)
+	}
+	return submitWorkResponse, nil
+}
+
+// SubmitWorkAsync operates the same as SubmitWorkContext, except that it doesn't wait for the response, and calls
+// handler with the results once they're received
+func (c *RPCClient) SubmitWorkAsync(ctx context.Context, workID string, nonce uint64, handler func(*appmessage.SubmitWorkResponseMessage, error)) {
+	spawn("SubmitWorkAsync", func() {
+		handler(c.SubmitWorkContext(ctx, workID, nonce))
+	})
+}
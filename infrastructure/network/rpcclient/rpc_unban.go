@@ -1,14 +1,19 @@
 package rpcclient
 
-import "github.com/kaspanet/kaspad/app/appmessage"
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
 
 // Unban sends an RPC request respective to the function's name and returns the RPC server's response
 func (c *RPCClient) Unban(ip string) (*appmessage.UnbanResponseMessage, error) {
-	err := c.rpcRouter.outgoingRoute().Enqueue(appmessage.NewUnbanRequestMessage(ip))
-	if err != nil {
-		return nil, err
-	}
-	response, err := c.route(appmessage.CmdUnbanRequestMessage).DequeueWithTimeout(c.timeout)
+	return c.UnbanContext(context.Background(), ip)
+}
+
+// UnbanContext operates the same as Unban, except that it gives up waiting for the response once ctx is done
+func (c *RPCClient) UnbanContext(ctx context.Context, ip string) (*appmessage.UnbanResponseMessage, error) {
+	response, err := c.request(ctx, appmessage.NewUnbanRequestMessage(ip), appmessage.CmdUnbanRequestMessage)
 	if err != nil {
 		return nil, err
 	}
@@ -18,3 +23,11 @@ func (c *RPCClient) Unban(ip string) (*appmessage.UnbanResponseMessage, error) {
 	}
 	return unbanResponse, nil
 }
+
+// UnbanAsync operates the same as UnbanContext, except that it doesn't wait for the response, and calls handler with
+// the results once they're received
+func (c *RPCClient) UnbanAsync(ctx context.Context, ip string, handler func(*appmessage.UnbanResponseMessage, error)) {
+	spawn("UnbanAsync", func() {
+		handler(c.UnbanContext(ctx, ip))
+	})
+}
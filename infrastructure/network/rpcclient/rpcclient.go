@@ -1,6 +1,9 @@
 package rpcclient
 
 import (
+	"context"
+	"crypto/tls"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -15,28 +18,91 @@ import (
 
 const defaultTimeout = 30 * time.Second
 
+var errClosedWhileReconnecting = errors.New("the client was closed while reconnecting")
+
+// Connection is a connection to an RPC server. The client sends its requests, and receives
+// responses and notifications, through the router that's attached to the connection.
+// The client normally connects using grpcclient, whose GRPCClient is a Connection
+type Connection interface {
+	AttachRouter(router *routerpkg.Router)
+	SetOnDisconnectedHandler(onDisconnectedHandler grpcclient.OnDisconnectedHandler)
+	SetOnErrorHandler(onErrorHandler grpcclient.OnErrorHandler)
+	Disconnect() error
+	Close() error
+}
+
+// DialFunc makes a new connection to the RPC server. The client calls it
+// whenever it connects or reconnects
+type DialFunc func() (Connection, error)
+
 // RPCClient is an RPC client
 type RPCClient struct {
+	// GRPCClient is nil if the client was created with a DialFunc that
+	// doesn't connect using grpcclient
 	*grpcclient.GRPCClient
 
-	rpcAddress           string
-	rpcRouter            *rpcRouter
-	isConnected          uint32
-	isClosed             uint32
-	isReconnecting       uint32
-	lastDisconnectedTime time.Time
+	rpcAddress     string
+	dial           DialFunc
+	connection     Connection
+	rpcRouter      *rpcRouter
+	isConnected    uint32
+	isClosed       uint32
+	isReconnecting uint32
+
+	// subscriptions holds the calls that subscribed to notifications, or
+	// changed or ended subscriptions, in order. They're called again after
+	// reconnecting to restore the subscriptions
+	subscriptionsLock            sync.Mutex
+	subscriptions                []func(ctx context.Context) error
+	notificationSession          *notificationSession
+	onNotificationsMissedHandler func()
 
-	timeout time.Duration
+	interceptorsLock sync.RWMutex
+	interceptors     []Interceptor
+
+	timeout         time.Duration
+	retryPolicy     *RetryPolicy
+	reconnectPolicy *RetryPolicy
 }
 
 // NewRPCClient сreates a new RPC client with a default call timeout value
 func NewRPCClient(rpcAddress string) (*RPCClient, error) {
+	return NewRPCClientWithCredentials(rpcAddress, "", "")
+}
+
+// NewRPCClientWithCredentials creates a new RPC client with a default call timeout
+// value, that authenticates with the given user and password. The credentials are
+// also used when reconnecting.
+func NewRPCClientWithCredentials(rpcAddress string, rpcUser string, rpcPassword string) (*RPCClient, error) {
+	return NewRPCClientWithTLS(rpcAddress, rpcUser, rpcPassword, nil)
+}
+
+// NewRPCClientWithTLS is like NewRPCClientWithCredentials, but connects over
+// TLS with the given configuration, which may include a client certificate.
+// If tlsConfig is nil, the connection isn't encrypted.
+func NewRPCClientWithTLS(rpcAddress string, rpcUser string, rpcPassword string, tlsConfig *tls.Config) (*RPCClient, error) {
+	return NewRPCClientWithDialer(rpcAddress, func() (Connection, error) {
+		return grpcclient.ConnectWithTLS(rpcAddress, rpcUser, rpcPassword, tlsConfig)
+	})
+}
+
+// NewRPCClientWithDialer creates a new RPC client with a default call timeout value, that
+// connects to the RPC server by calling dial. rpcAddress is only used to describe the server
+func NewRPCClientWithDialer(rpcAddress string, dial DialFunc) (*RPCClient, error) {
 	rpcClient := &RPCClient{
 		rpcAddress: rpcAddress,
+		dial:       dial,
 		timeout:    defaultTimeout,
+
+		reconnectPolicy: defaultReconnectPolicy(),
+
+		notificationSession: &notificationSession{},
 	}
 	err := rpcClient.connect()
 	if err != nil {
+		// The client isn't returned, so it's marked as closed to keep it from
+		// reconnecting if the RPC server drops the connection
+		atomic.StoreUint32(&rpcClient.isClosed, 1)
 		return nil, err
 	}
 
@@ -44,27 +110,34 @@ func NewRPCClient(rpcAddress string) (*RPCClient, error) {
 }
 
 func (c *RPCClient) connect() error {
-	rpcClient, err := grpcclient.Connect(c.rpcAddress)
+	connection, err := c.dial()
 	if err != nil {
 		return errors.Wrapf(err, "error connecting to address %s", c.rpcAddress)
 	}
-	rpcClient.SetOnDisconnectedHandler(c.handleClientDisconnected)
-	rpcClient.SetOnErrorHandler(c.handleClientError)
 	rpcRouter, err := buildRPCRouter()
 	if err != nil {
 		return errors.Wrapf(err, "error creating the RPC router")
 	}
+	connection.SetOnDisconnectedHandler(func() {
+		c.handleClientDisconnected(rpcRouter)
+	})
+	connection.SetOnErrorHandler(func(err error) {
+		c.handleClientError(rpcRouter, err)
+	})
 
 	atomic.StoreUint32(&c.isConnected, 1)
-	rpcClient.AttachRouter(rpcRouter.router)
+	connection.AttachRouter(rpcRouter.router)
 
-	c.GRPCClient = rpcClient
+	c.connection = connection
+	c.GRPCClient, _ = connection.(*grpcclient.GRPCClient)
 	c.rpcRouter = rpcRouter
 
 	log.Infof("Connected to %s", c.rpcAddress)
 
 	getInfoResponse, err := c.GetInfo()
 	if err != nil {
+		// Close the connection, so that it isn't reconnected once the RPC server drops it
+		c.closeConnectionAndLogError()
 		return errors.Wrapf(err, "error making GetInfo request")
 	}
 
@@ -79,7 +152,7 @@ func (c *RPCClient) connect() error {
 }
 
 func (c *RPCClient) disconnect() error {
-	err := c.GRPCClient.Disconnect()
+	err := c.connection.Disconnect()
 	if err != nil {
 		return err
 	}
@@ -88,7 +161,12 @@ func (c *RPCClient) disconnect() error {
 }
 
 // Reconnect forces the client to attempt to reconnect to the address
-// this client initially was connected to
+// this client initially was connected to, as many times as the client's
+// reconnect policy allows. Once reconnected, the client subscribes again
+// to the notifications it was subscribed to.
+//
+// Reconnect waits for the notifications received over the previous
+// connection to be handled, so it must not be called by notification handlers.
 func (c *RPCClient) Reconnect() error {
 	if atomic.LoadUint32(&c.isClosed) == 1 {
 		return errors.Errorf("Cannot reconnect from a closed client")
@@ -112,45 +190,91 @@ func (c *RPCClient) Reconnect() error {
 		}
 	}
 
-	// Attempt to connect until we succeed
-	for {
-		const retryDelay = 10 * time.Second
-		if time.Since(c.lastDisconnectedTime) > retryDelay {
-			err := c.connect()
+	// Attempt to connect until we succeed or the reconnect policy gives up
+	reconnectPolicy := c.reconnectPolicy
+	for retry := 1; ; retry++ {
+		if atomic.LoadUint32(&c.isClosed) == 1 {
+			return errClosedWhileReconnecting
+		}
+		c.closeConnectionAndLogError()
+
+		err := c.connect()
+		if err == nil {
+			err = c.restoreSubscriptions()
 			if err == nil {
+				// The client may have been closed before the new connection was made
+				if atomic.LoadUint32(&c.isClosed) == 1 {
+					c.closeConnectionAndLogError()
+					return errClosedWhileReconnecting
+				}
 				return nil
 			}
-			log.Warnf("Could not automatically reconnect to %s: %s", c.rpcAddress, err)
-			log.Warnf("Retrying in %s", retryDelay)
 		}
+		log.Warnf("Could not automatically reconnect to %s: %s", c.rpcAddress, err)
+		if !reconnectPolicy.allowsRetry(retry, err) {
+			// The new connection may have been made before restoring the subscriptions failed
+			c.closeConnectionAndLogError()
+			atomic.StoreUint32(&c.isConnected, 0)
+			return errors.Wrapf(err, "gave up reconnecting to %s after %d attempts", c.rpcAddress, retry)
+		}
+		retryDelay := reconnectPolicy.delay(retry)
+		log.Warnf("Retrying in %s", retryDelay)
 		time.Sleep(retryDelay)
 	}
 }
 
-func (c *RPCClient) handleClientDisconnected() {
+// closeConnection closes the current connection, unless it was already closed.
+// Closing the router of the connection ends the goroutines that handle its
+// notifications, once they handle the notifications that were already received.
+// Waiting for them keeps the notifications of the next connection from being
+// handled before older ones
+func (c *RPCClient) closeConnection() error {
+	if !c.rpcRouter.close() {
+		return nil
+	}
+	c.rpcRouter.notificationHandlers.Wait()
+	return c.connection.Close()
+}
+
+func (c *RPCClient) closeConnectionAndLogError() {
+	err := c.closeConnection()
+	if err != nil {
+		log.Warnf("Error closing the connection to %s: %s", c.rpcAddress, err)
+	}
+}
+
+func (c *RPCClient) handleClientDisconnected(rpcRouter *rpcRouter) {
+	// Connections that were closed on purpose, by reconnecting or
+	// by closing the client, aren't reconnected
+	if atomic.LoadUint32(&rpcRouter.isClosed) == 1 {
+		return
+	}
 	atomic.StoreUint32(&c.isConnected, 0)
 	if atomic.LoadUint32(&c.isClosed) == 0 {
 		err := c.disconnect()
 		if err != nil {
 			panic(err)
 		}
-		c.lastDisconnectedTime = time.Now()
 		err = c.Reconnect()
 		if err != nil {
-			panic(err)
+			if errors.Is(err, errClosedWhileReconnecting) {
+				return
+			}
+			log.Errorf("The client stays disconnected until Reconnect is called: %s", err)
 		}
 	}
 }
 
-func (c *RPCClient) handleClientError(err error) {
-	if atomic.LoadUint32(&c.isClosed) == 1 {
+func (c *RPCClient) handleClientError(rpcRouter *rpcRouter, err error) {
+	if atomic.LoadUint32(&c.isClosed) == 1 || atomic.LoadUint32(&rpcRouter.isClosed) == 1 {
 		return
 	}
 	log.Warnf("Received error from client: %s", err)
-	c.handleClientDisconnected()
+	c.handleClientDisconnected(rpcRouter)
 }
 
-// SetTimeout sets the timeout by which to wait for RPC responses
+// SetTimeout sets the timeout by which to wait for RPC responses. Requests given a context
+// that has a deadline wait for their responses until the deadline instead
 func (c *RPCClient) SetTimeout(timeout time.Duration) {
 	c.timeout = timeout
 }
@@ -161,8 +285,11 @@ func (c *RPCClient) Close() error {
 	if !swapped {
 		return errors.Errorf("Cannot close a client that had already been closed")
 	}
-	c.rpcRouter.router.Close()
-	return c.GRPCClient.Close()
+	// The connection may have already been closed by reconnecting
+	if !c.rpcRouter.close() {
+		return nil
+	}
+	return c.connection.Close()
 }
 
 // Address returns the address the RPC client connected to
@@ -174,6 +301,23 @@ func (c *RPCClient) route(command appmessage.MessageCommand) *routerpkg.Route {
 	return c.rpcRouter.routes[command]
 }
 
+// request sends the given request, through the client's interceptors, and returns its
+// response, whose command is responseCommand. If ctx has no deadline, the client's timeout
+// applies to every attempt the retry policy allows
+func (c *RPCClient) request(ctx context.Context, request appmessage.Message,
+	responseCommand appmessage.MessageCommand) (appmessage.Message, error) {
+
+	handler := c.intercept(func(ctx context.Context, request appmessage.Message) (appmessage.Message, error) {
+		call := &batchCall{request: request, responseCommand: responseCommand}
+		err := c.sendCallsWithRetries(ctx, []*batchCall{call})
+		if err != nil {
+			return nil, err
+		}
+		return call.response, nil
+	})
+	return handler(ctx, request)
+}
+
 // ErrRPC is an error in the RPC protocol
 var ErrRPC = errors.New("rpc error")
 
@@ -0,0 +1,124 @@
+package rpcclientmock
+
+import (
+	"sync"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient/grpcclient"
+	"github.com/pkg/errors"
+)
+
+var errConnectionClosed = errors.New("the connection to the mock RPC server is closed")
+
+// connection is the connection of a single client to the server. It handles the
+// requests of the client in the order they're sent, as kaspad does
+type connection struct {
+	server *Server
+
+	lock                  sync.Mutex
+	router                *router.Router
+	isClosed              bool
+	subscriptions         map[appmessage.MessageCommand]struct{}
+	onDisconnectedHandler grpcclient.OnDisconnectedHandler
+}
+
+func newConnection(server *Server) *connection {
+	return &connection{
+		server:        server,
+		subscriptions: make(map[appmessage.MessageCommand]struct{}),
+	}
+}
+
+// AttachRouter attaches the given router to the connection and starts handling
+// the requests that are sent through it
+func (c *connection) AttachRouter(router *router.Router) {
+	c.lock.Lock()
+	c.router = router
+	c.lock.Unlock()
+
+	spawn("connection.AttachRouter-handleRequests", func() {
+		for {
+			request, err := router.OutgoingRoute().Dequeue()
+			if err != nil {
+				return
+			}
+			if c.closed() {
+				return
+			}
+			c.server.handleRequest(c, request)
+		}
+	})
+}
+
+// SetOnDisconnectedHandler sets the handler that's called when the server drops the connection
+func (c *connection) SetOnDisconnectedHandler(onDisconnectedHandler grpcclient.OnDisconnectedHandler) {
+	c.onDisconnectedHandler = onDisconnectedHandler
+}
+
+// SetOnErrorHandler does nothing, since the connection never fails other than by
+// being dropped, which calls the onDisconnectedHandler
+func (c *connection) SetOnErrorHandler(_ grpcclient.OnErrorHandler) {
+}
+
+// Disconnect disconnects from the server
+func (c *connection) Disconnect() error {
+	if c.close() {
+		c.server.removeConnection(c)
+	}
+	return nil
+}
+
+// Close closes the connection
+func (c *connection) Close() error {
+	return c.Disconnect()
+}
+
+// close marks the connection as closed, and returns false if it was already closed
+func (c *connection) close() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.isClosed {
+		return false
+	}
+	c.isClosed = true
+	return true
+}
+
+func (c *connection) closed() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.isClosed
+}
+
+// send sends the given response or notification to the client
+func (c *connection) send(message appmessage.Message) error {
+	c.lock.Lock()
+	isClosed := c.isClosed
+	router := c.router
+	c.lock.Unlock()
+
+	if isClosed || router == nil {
+		return errConnectionClosed
+	}
+	return router.EnqueueIncomingMessage(message)
+}
+
+func (c *connection) subscribe(notificationCommands []appmessage.MessageCommand) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, notificationCommand := range notificationCommands {
+		c.subscriptions[notificationCommand] = struct{}{}
+	}
+}
+
+func (c *connection) isSubscribed(notificationCommand appmessage.MessageCommand) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	_, ok := c.subscriptions[notificationCommand]
+	return ok
+}
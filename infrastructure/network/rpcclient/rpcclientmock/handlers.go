@@ -0,0 +1,273 @@
+package rpcclientmock
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/version"
+)
+
+// subscription is what a request that subscribes to notifications is answered with,
+// and the notifications it subscribes to
+type subscription struct {
+	newResponse          func() appmessage.Message
+	notificationCommands []appmessage.MessageCommand
+}
+
+var subscriptions = map[appmessage.MessageCommand]subscription{
+	appmessage.CmdNotifyBlockAddedRequestMessage: {
+		newResponse:          func() appmessage.Message { return appmessage.NewNotifyBlockAddedResponseMessage() },
+		notificationCommands: []appmessage.MessageCommand{appmessage.CmdBlockAddedNotificationMessage},
+	},
+	appmessage.CmdNotifyVirtualSelectedParentChainChangedRequestMessage: {
+		newResponse: func() appmessage.Message {
+			return appmessage.NewNotifyVirtualSelectedParentChainChangedResponseMessage()
+		},
+		notificationCommands: []appmessage.MessageCommand{appmessage.CmdVirtualSelectedParentChainChangedNotificationMessage},
+	},
+	appmessage.CmdNotifyVirtualSelectedParentBlueScoreChangedRequestMessage: {
+		newResponse: func() appmessage.Message {
+			return appmessage.NewNotifyVirtualSelectedParentBlueScoreChangedResponseMessage()
+		},
+		notificationCommands: []appmessage.MessageCommand{appmessage.CmdVirtualSelectedParentBlueScoreChangedNotificationMessage},
+	},
+	appmessage.CmdNotifyVirtualDaaScoreChangedRequestMessage: {
+		newResponse:          func() appmessage.Message { return appmessage.NewNotifyVirtualDaaScoreChangedResponseMessage() },
+		notificationCommands: []appmessage.MessageCommand{appmessage.CmdVirtualDaaScoreChangedNotificationMessage},
+	},
+	appmessage.CmdNotifyFinalityConflictsRequestMessage: {
+		newResponse: func() appmessage.Message { return appmessage.NewNotifyFinalityConflictsResponseMessage() },
+		notificationCommands: []appmessage.MessageCommand{appmessage.CmdFinalityConflictNotificationMessage,
+			appmessage.CmdFinalityConflictResolvedNotificationMessage},
+	},
+	appmessage.CmdNotifyFinalityPointAdvancedRequestMessage: {
+		newResponse:          func() appmessage.Message { return appmessage.NewNotifyFinalityPointAdvancedResponseMessage() },
+		notificationCommands: []appmessage.MessageCommand{appmessage.CmdFinalityPointAdvancedNotificationMessage},
+	},
+	appmessage.CmdNotifyJobProgressRequestMessage: {
+		newResponse:          func() appmessage.Message { return appmessage.NewNotifyJobProgressResponseMessage() },
+		notificationCommands: []appmessage.MessageCommand{appmessage.CmdJobProgressNotificationMessage},
+	},
+	appmessage.CmdNotifyNewBlockTemplateRequestMessage: {
+		newResponse:          func() appmessage.Message { return appmessage.NewNotifyNewBlockTemplateResponseMessage() },
+		notificationCommands: []appmessage.MessageCommand{appmessage.CmdNewBlockTemplateNotificationMessage},
+	},
+	appmessage.CmdNotifyNewTipRequestMessage: {
+		newResponse:          func() appmessage.Message { return appmessage.NewNotifyNewTipResponseMessage() },
+		notificationCommands: []appmessage.MessageCommand{appmessage.CmdNewTipNotificationMessage},
+	},
+	appmessage.CmdNotifyNewTransactionsRequestMessage: {
+		newResponse:          func() appmessage.Message { return appmessage.NewNotifyNewTransactionsResponseMessage() },
+		notificationCommands: []appmessage.MessageCommand{appmessage.CmdNewTransactionsNotificationMessage},
+	},
+	appmessage.CmdNotifyPruningPointUTXOSetOverrideRequestMessage: {
+		newResponse: func() appmessage.Message {
+			return appmessage.NewNotifyPruningPointUTXOSetOverrideResponseMessage()
+		},
+		notificationCommands: []appmessage.MessageCommand{appmessage.CmdPruningPointUTXOSetOverrideNotificationMessage},
+	},
+	appmessage.CmdNotifyUTXOsChangedRequestMessage: {
+		newResponse:          func() appmessage.Message { return appmessage.NewNotifyUTXOsChangedResponseMessage() },
+		notificationCommands: []appmessage.MessageCommand{appmessage.CmdUTXOsChangedNotificationMessage},
+	},
+	appmessage.CmdNotifyWorkRequestMessage: {
+		newResponse:          func() appmessage.Message { return appmessage.NewNotifyWorkResponseMessage() },
+		notificationCommands: []appmessage.MessageCommand{appmessage.CmdWorkNotificationMessage},
+	},
+}
+
+func (s *Server) addBuiltInHandlers() {
+	for command, subscription := range subscriptions {
+		newResponse := subscription.newResponse
+		s.handlers[command] = func(_ appmessage.Message) (appmessage.Message, error) {
+			return newResponse(), nil
+		}
+	}
+
+	s.handlers[appmessage.CmdGetInfoRequestMessage] = s.handleGetInfo
+	s.handlers[appmessage.CmdGetCurrentNetworkRequestMessage] = s.handleGetCurrentNetwork
+	s.handlers[appmessage.CmdGetBlockRequestMessage] = s.handleGetBlock
+	s.handlers[appmessage.CmdGetBlocksRequestMessage] = s.handleGetBlocks
+	s.handlers[appmessage.CmdGetBlockCountRequestMessage] = s.handleGetBlockCount
+	s.handlers[appmessage.CmdGetSelectedTipHashRequestMessage] = s.handleGetSelectedTipHash
+	s.handlers[appmessage.CmdGetBlockDAGInfoRequestMessage] = s.handleGetBlockDAGInfo
+	s.handlers[appmessage.CmdGetVirtualSelectedParentBlueScoreRequestMessage] = s.handleGetVirtualSelectedParentBlueScore
+	s.handlers[appmessage.CmdGetVirtualSelectedParentChainFromBlockRequestMessage] =
+		s.handleGetVirtualSelectedParentChainFromBlock
+}
+
+// AddBlock adds the given block to the server's canned blocks, as the new selected tip.
+// The blocks that are added form the selected parent chain, in the order they're added.
+// The block's VerboseData must be set, and its hash is taken from it.
+//
+// Adding a block doesn't notify the clients. Use Notify to send them the notifications
+// that a node would send
+func (s *Server) AddBlock(block *appmessage.RPCBlock) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	hash := block.VerboseData.Hash
+	s.blocks[hash] = block
+	s.chain = append(s.chain, hash)
+	s.tipHashes = []string{hash}
+}
+
+func (s *Server) handleGetInfo(_ appmessage.Message) (appmessage.Message, error) {
+	return appmessage.NewGetInfoResponseMessage(Address, 0, version.Version(), false, true), nil
+}
+
+func (s *Server) handleGetCurrentNetwork(_ appmessage.Message) (appmessage.Message, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return appmessage.NewGetCurrentNetworkResponseMessage(s.networkName), nil
+}
+
+func (s *Server) handleGetBlock(request appmessage.Message) (appmessage.Message, error) {
+	getBlockRequest := request.(*appmessage.GetBlockRequestMessage)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	response := appmessage.NewGetBlockResponseMessage()
+	block, ok := s.blocks[getBlockRequest.Hash]
+	if !ok {
+		response.Error = appmessage.RPCErrorf("Block %s not found", getBlockRequest.Hash)
+		return response, nil
+	}
+	response.Block = blockForResponse(block, getBlockRequest.IncludeTransactions)
+	return response, nil
+}
+
+func (s *Server) handleGetBlocks(request appmessage.Message) (appmessage.Message, error) {
+	getBlocksRequest := request.(*appmessage.GetBlocksRequestMessage)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	response := appmessage.NewGetBlocksResponseMessage()
+	lowIndex := 0
+	if getBlocksRequest.LowHash != "" {
+		var ok bool
+		lowIndex, ok = s.chainIndex(getBlocksRequest.LowHash)
+		if !ok {
+			response.Error = appmessage.RPCErrorf("Could not find lowHash %s", getBlocksRequest.LowHash)
+			return response, nil
+		}
+	}
+	blockHashes := s.chain[lowIndex:]
+	if getBlocksRequest.Limit > 0 && len(blockHashes) > int(getBlocksRequest.Limit) {
+		blockHashes = blockHashes[:getBlocksRequest.Limit]
+		response.NextLowHash = blockHashes[len(blockHashes)-1]
+	}
+	response.BlockHashes = append([]string{}, blockHashes...)
+	if getBlocksRequest.IncludeBlocks {
+		response.Blocks = make([]*appmessage.RPCBlock, len(blockHashes))
+		for i, hash := range blockHashes {
+			response.Blocks[i] = blockForResponse(s.blocks[hash], getBlocksRequest.IncludeTransactions)
+		}
+	}
+	return response, nil
+}
+
+func (s *Server) handleGetBlockCount(_ appmessage.Message) (appmessage.Message, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	response := &appmessage.GetBlockCountResponseMessage{
+		BlockCount:  uint64(len(s.chain)),
+		HeaderCount: uint64(len(s.chain)),
+	}
+	return response, nil
+}
+
+func (s *Server) handleGetSelectedTipHash(_ appmessage.Message) (appmessage.Message, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return appmessage.NewGetSelectedTipHashResponseMessage(s.selectedTipHash()), nil
+}
+
+func (s *Server) handleGetBlockDAGInfo(_ appmessage.Message) (appmessage.Message, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	response := appmessage.NewGetBlockDAGInfoResponseMessage()
+	response.NetworkName = s.networkName
+	response.BlockCount = uint64(len(s.chain))
+	response.HeaderCount = uint64(len(s.chain))
+	response.TipHashes = append([]string{}, s.tipHashes...)
+	response.VirtualParentHashes = append([]string{}, s.tipHashes...)
+	response.VirtualSelectedParentHash = s.selectedTipHash()
+	response.HeadersSelectedTipHash = s.selectedTipHash()
+	response.IsSynced = true
+	if len(s.chain) > 0 {
+		selectedTip := s.blocks[s.selectedTipHash()]
+		response.VirtualBlueScore = selectedTip.Header.BlueScore + 1
+		response.VirtualDAAScore = selectedTip.Header.DAAScore + 1
+		response.VirtualSelectedParentTimestamp = selectedTip.Header.Timestamp
+		response.PastMedianTime = selectedTip.Header.Timestamp
+	}
+	return response, nil
+}
+
+func (s *Server) handleGetVirtualSelectedParentBlueScore(_ appmessage.Message) (appmessage.Message, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	blueScore := uint64(0)
+	if len(s.chain) > 0 {
+		blueScore = s.blocks[s.selectedTipHash()].Header.BlueScore
+	}
+	return appmessage.NewGetVirtualSelectedParentBlueScoreResponseMessage(blueScore), nil
+}
+
+func (s *Server) handleGetVirtualSelectedParentChainFromBlock(request appmessage.Message) (appmessage.Message, error) {
+	getChainRequest := request.(*appmessage.GetVirtualSelectedParentChainFromBlockRequestMessage)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	startIndex, ok := s.chainIndex(getChainRequest.StartHash)
+	if !ok {
+		response := &appmessage.GetVirtualSelectedParentChainFromBlockResponseMessage{}
+		response.Error = appmessage.RPCErrorf("Could not build virtual "+
+			"selected parent chain from %s: block not found", getChainRequest.StartHash)
+		return response, nil
+	}
+	addedChainBlockHashes := s.chain[startIndex+1:]
+	nextStartHash := ""
+	if getChainRequest.Limit > 0 && len(addedChainBlockHashes) > int(getChainRequest.Limit) {
+		addedChainBlockHashes = addedChainBlockHashes[:getChainRequest.Limit]
+		nextStartHash = addedChainBlockHashes[len(addedChainBlockHashes)-1]
+	}
+	response := appmessage.NewGetVirtualSelectedParentChainFromBlockResponseMessage(
+		[]string{}, append([]string{}, addedChainBlockHashes...), nil)
+	response.NextStartHash = nextStartHash
+	return response, nil
+}
+
+// chainIndex returns the index of the block with the given hash in the selected parent chain
+func (s *Server) chainIndex(hash string) (int, bool) {
+	for i, chainBlockHash := range s.chain {
+		if chainBlockHash == hash {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func (s *Server) selectedTipHash() string {
+	if len(s.chain) == 0 {
+		return ""
+	}
+	return s.chain[len(s.chain)-1]
+}
+
+// blockForResponse returns the given block, without its transactions unless includeTransactions is set
+func blockForResponse(block *appmessage.RPCBlock, includeTransactions bool) *appmessage.RPCBlock {
+	if includeTransactions {
+		return block
+	}
+	blockWithoutTransactions := *block
+	blockWithoutTransactions.Transactions = nil
+	return &blockWithoutTransactions
+}
@@ -0,0 +1,9 @@
+package rpcclientmock
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/util/panics"
+)
+
+var log = logger.RegisterSubSystem("RPCC")
+var spawn = panics.GoroutineWrapperFunc(log)
@@ -0,0 +1,242 @@
+package rpcclientmock
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient"
+	"github.com/pkg/errors"
+)
+
+// Address is the address of the clients that are connected to a Server
+const Address = "rpcclientmock"
+
+// Handler answers a request the server received. The response it returns must be of
+// the type that answers the request. Returning an error drops the connection the request
+// was received over, as a server that failed would
+type Handler func(request appmessage.Message) (appmessage.Message, error)
+
+// Fault is a failure the server injects into the handling of requests
+type Fault struct {
+	// Delay is how long the server waits before it handles the request. Requests
+	// received over the same connection wait for the requests before them. A delay
+	// longer than the client's timeout makes the client time out
+	Delay time.Duration
+
+	// Disconnect makes the server drop the connection the request was received over,
+	// without answering it
+	Disconnect bool
+
+	// Count is the amount of requests the fault applies to. Zero means it applies
+	// until it's removed
+	Count int
+}
+
+// Server is an in-memory mock of a kaspad RPC server, which RPC clients connect to without
+// a network. It answers requests with handlers, which are either the built-in handlers of the
+// requests that are answered from the server's canned blocks, or handlers set by Handle.
+// The server drops the connections that requests without a handler are received over,
+// since the client expects every request to be answered.
+//
+// Clients subscribe to notifications the same way they do with kaspad, and receive the
+// notifications that are sent using Notify
+type Server struct {
+	lock              sync.Mutex
+	networkName       string
+	handlers          map[appmessage.MessageCommand]Handler
+	faults            map[appmessage.MessageCommand]*Fault
+	requestCounts     map[appmessage.MessageCommand]int
+	connections       map[*connection]struct{}
+	refuseConnections bool
+
+	blocks    map[string]*appmessage.RPCBlock
+	chain     []string
+	tipHashes []string
+}
+
+// NewServer returns a new mock RPC server of the mainnet network, with no blocks
+func NewServer() *Server {
+	server := &Server{
+		networkName:   dagconfig.MainnetParams.Name,
+		handlers:      make(map[appmessage.MessageCommand]Handler),
+		faults:        make(map[appmessage.MessageCommand]*Fault),
+		requestCounts: make(map[appmessage.MessageCommand]int),
+		connections:   make(map[*connection]struct{}),
+		blocks:        make(map[string]*appmessage.RPCBlock),
+	}
+	server.addBuiltInHandlers()
+	return server
+}
+
+// NewClient returns a new RPC client that's connected to the server. The client
+// reconnects to the server whenever the server drops its connection
+func (s *Server) NewClient() (*rpcclient.RPCClient, error) {
+	return rpcclient.NewRPCClientWithDialer(Address, s.dial)
+}
+
+// SetNetworkName sets the name of the network the server reports it's running on
+func (s *Server) SetNetworkName(networkName string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.networkName = networkName
+}
+
+// Handle sets the handler of the requests with the given command, replacing
+// the built-in handler of the command, if there is one
+func (s *Server) Handle(command appmessage.MessageCommand, handler Handler) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.handlers[command] = handler
+}
+
+// InjectFault makes the server apply the given fault to the requests with the
+// given command. A nil fault removes the fault of the command
+func (s *Server) InjectFault(command appmessage.MessageCommand, fault *Fault) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if fault == nil {
+		delete(s.faults, command)
+		return
+	}
+	faultCopy := *fault
+	s.faults[command] = &faultCopy
+}
+
+// RefuseConnections sets whether clients fail to connect to the server, as they
+// would if it was down
+func (s *Server) RefuseConnections(refuse bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.refuseConnections = refuse
+}
+
+// DisconnectAll drops the connections of all the clients
+func (s *Server) DisconnectAll() {
+	s.lock.Lock()
+	connections := make([]*connection, 0, len(s.connections))
+	for connection := range s.connections {
+		connections = append(connections, connection)
+	}
+	s.lock.Unlock()
+
+	for _, connection := range connections {
+		s.drop(connection)
+	}
+}
+
+// RequestCount returns the amount of requests with the given command the server received
+func (s *Server) RequestCount(command appmessage.MessageCommand) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.requestCounts[command]
+}
+
+// Notify sends the given notification to the clients that subscribed to it, and
+// returns the amount of clients it was sent to
+func (s *Server) Notify(notification appmessage.Message) int {
+	s.lock.Lock()
+	connections := make([]*connection, 0, len(s.connections))
+	for connection := range s.connections {
+		if connection.isSubscribed(notification.Command()) {
+			connections = append(connections, connection)
+		}
+	}
+	s.lock.Unlock()
+
+	sentCount := 0
+	for _, connection := range connections {
+		err := connection.send(notification)
+		if err != nil {
+			log.Warnf("Error sending %s to a client of the mock RPC server: %s", notification.Command(), err)
+			continue
+		}
+		sentCount++
+	}
+	return sentCount
+}
+
+func (s *Server) dial() (rpcclient.Connection, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.refuseConnections {
+		return nil, errors.Errorf("the mock RPC server refused the connection")
+	}
+	connection := newConnection(s)
+	s.connections[connection] = struct{}{}
+	return connection, nil
+}
+
+func (s *Server) removeConnection(connection *connection) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.connections, connection)
+}
+
+// drop closes the given connection the way a server that dropped it would, which
+// makes its client reconnect
+func (s *Server) drop(connection *connection) {
+	if !connection.close() {
+		return
+	}
+	s.removeConnection(connection)
+	if connection.onDisconnectedHandler != nil {
+		spawn("Server.drop-onDisconnectedHandler", connection.onDisconnectedHandler)
+	}
+}
+
+// handleRequest answers the given request, which was received over the given connection
+func (s *Server) handleRequest(connection *connection, request appmessage.Message) {
+	command := request.Command()
+
+	s.lock.Lock()
+	s.requestCounts[command]++
+	handler := s.handlers[command]
+	var fault *Fault
+	if storedFault, ok := s.faults[command]; ok {
+		faultCopy := *storedFault
+		fault = &faultCopy
+		if storedFault.Count > 0 {
+			storedFault.Count--
+			if storedFault.Count == 0 {
+				delete(s.faults, command)
+			}
+		}
+	}
+	s.lock.Unlock()
+
+	if fault != nil {
+		time.Sleep(fault.Delay)
+		if fault.Disconnect {
+			s.drop(connection)
+			return
+		}
+	}
+	if handler == nil {
+		log.Warnf("The mock RPC server has no handler for %s, so it dropped the connection", command)
+		s.drop(connection)
+		return
+	}
+
+	response, err := handler(request)
+	if err != nil {
+		log.Warnf("The mock RPC server dropped a connection after handling %s: %s", command, err)
+		s.drop(connection)
+		return
+	}
+	if subscription, ok := subscriptions[command]; ok {
+		connection.subscribe(subscription.notificationCommands)
+	}
+	err = connection.send(response)
+	if err != nil {
+		log.Warnf("Error sending %s to a client of the mock RPC server: %s", response.Command(), err)
+	}
+}
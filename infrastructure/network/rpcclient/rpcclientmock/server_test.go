@@ -0,0 +1,166 @@
+package rpcclientmock
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient"
+	"github.com/pkg/errors"
+)
+
+const testTimeout = 10 * time.Second
+
+func addTestBlocks(server *Server, blockCount int) []string {
+	blockHashes := make([]string, blockCount)
+	for i := range blockHashes {
+		blockHashes[i] = fmt.Sprintf("%064x", i+1)
+		server.AddBlock(&appmessage.RPCBlock{
+			Header:      &appmessage.RPCBlockHeader{BlueScore: uint64(i)},
+			VerboseData: &appmessage.RPCBlockVerboseData{Hash: blockHashes[i], BlueScore: uint64(i)},
+		})
+	}
+	return blockHashes
+}
+
+func TestServerCannedBlocks(t *testing.T) {
+	server := NewServer()
+	blockHashes := addTestBlocks(server, 10)
+
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+	defer client.Close()
+
+	getBlockResponse, err := client.GetBlock(blockHashes[3], false)
+	if err != nil {
+		t.Fatalf("GetBlock: %s", err)
+	}
+	if getBlockResponse.Block.VerboseData.Hash != blockHashes[3] {
+		t.Fatalf("Unexpected block. Want: %s, got: %s", blockHashes[3], getBlockResponse.Block.VerboseData.Hash)
+	}
+	_, err = client.GetBlock(fmt.Sprintf("%064x", 0), false)
+	if !errors.Is(err, rpcclient.ErrRPC) {
+		t.Fatalf("Expected getting an unknown block to return an RPC error, but got: %v", err)
+	}
+
+	selectedTipHashResponse, err := client.GetSelectedTipHash()
+	if err != nil {
+		t.Fatalf("GetSelectedTipHash: %s", err)
+	}
+	if selectedTipHashResponse.SelectedTipHash != blockHashes[9] {
+		t.Fatalf("Unexpected selected tip. Want: %s, got: %s", blockHashes[9], selectedTipHashResponse.SelectedTipHash)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	i := 1
+	for result := range client.DownloadChainBlocks(ctx, blockHashes[0], &rpcclient.BlockRangeOptions{PageSize: 3}) {
+		if result.Err != nil {
+			t.Fatalf("DownloadChainBlocks: %s", result.Err)
+		}
+		if result.Block.VerboseData.Hash != blockHashes[i] {
+			t.Fatalf("Unexpected block %d. Want: %s, got: %s", i, blockHashes[i], result.Block.VerboseData.Hash)
+		}
+		i++
+	}
+	if i != len(blockHashes) {
+		t.Fatalf("Unexpected amount of downloaded blocks. Want: %d, got: %d", len(blockHashes)-1, i-1)
+	}
+}
+
+func TestServerFaults(t *testing.T) {
+	server := NewServer()
+	blockHashes := addTestBlocks(server, 1)
+
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+	defer client.Close()
+	client.SetTimeout(100 * time.Millisecond)
+
+	server.InjectFault(appmessage.CmdGetBlockRequestMessage, &Fault{Delay: 300 * time.Millisecond, Count: 1})
+	_, err = client.GetBlock(blockHashes[0], false)
+	if !errors.Is(err, router.ErrTimeout) {
+		t.Fatalf("Expected a delayed response to time out, but got: %v", err)
+	}
+	// The fault applied to a single request
+	client.SetTimeout(testTimeout)
+	_, err = client.GetBlock(blockHashes[0], false)
+	if err != nil {
+		t.Fatalf("GetBlock: %s", err)
+	}
+
+	server.Handle(appmessage.CmdGetBlockCountRequestMessage, func(_ appmessage.Message) (appmessage.Message, error) {
+		return &appmessage.GetBlockCountResponseMessage{BlockCount: 1000}, nil
+	})
+	getBlockCountResponse, err := client.GetBlockCount()
+	if err != nil {
+		t.Fatalf("GetBlockCount: %s", err)
+	}
+	if getBlockCountResponse.BlockCount != 1000 {
+		t.Fatalf("Unexpected block count. Want: %d, got: %d", 1000, getBlockCountResponse.BlockCount)
+	}
+	if server.RequestCount(appmessage.CmdGetBlockRequestMessage) != 2 {
+		t.Fatalf("Unexpected amount of GetBlock requests. Want: %d, got: %d",
+			2, server.RequestCount(appmessage.CmdGetBlockRequestMessage))
+	}
+}
+
+func TestServerNotifications(t *testing.T) {
+	server := NewServer()
+	blockHashes := addTestBlocks(server, 2)
+
+	client, err := server.NewClient()
+	if err != nil {
+		t.Fatalf("NewClient: %s", err)
+	}
+	defer client.Close()
+
+	if server.Notify(appmessage.NewBlockAddedNotificationMessage(&appmessage.RPCBlock{})) != 0 {
+		t.Fatalf("A notification was sent to a client that didn't subscribe to it")
+	}
+
+	onBlockAddedChan := make(chan *appmessage.BlockAddedNotificationMessage, 1)
+	err = client.RegisterForBlockAddedNotifications(func(notification *appmessage.BlockAddedNotificationMessage) {
+		onBlockAddedChan <- notification
+	})
+	if err != nil {
+		t.Fatalf("RegisterForBlockAddedNotifications: %s", err)
+	}
+
+	notifyAndReceive := func(hash string) {
+		getBlockResponse, err := client.GetBlock(hash, true)
+		if err != nil {
+			t.Fatalf("GetBlock: %s", err)
+		}
+		if server.Notify(appmessage.NewBlockAddedNotificationMessage(getBlockResponse.Block)) != 1 {
+			t.Fatalf("The notification wasn't sent to the client")
+		}
+		select {
+		case notification := <-onBlockAddedChan:
+			if notification.Block.VerboseData.Hash != hash {
+				t.Fatalf("Unexpected notification. Want: %s, got: %s", hash, notification.Block.VerboseData.Hash)
+			}
+		case <-time.After(testTimeout):
+			t.Fatalf("Timed out waiting for the notification")
+		}
+	}
+	notifyAndReceive(blockHashes[0])
+
+	// The client subscribes again once it reconnects after the server drops its connection
+	server.DisconnectAll()
+	deadline := time.Now().Add(testTimeout)
+	for server.RequestCount(appmessage.CmdNotifyBlockAddedRequestMessage) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for the client to subscribe again")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	notifyAndReceive(blockHashes[1])
+}
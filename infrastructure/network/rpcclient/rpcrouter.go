@@ -1,32 +1,73 @@
 package rpcclient
 
 import (
+	"sync"
+	"sync/atomic"
+
 	"github.com/kaspanet/kaspad/app/appmessage"
 	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
 )
 
 type rpcRouter struct {
-	router *routerpkg.Router
-	routes map[appmessage.MessageCommand]*routerpkg.Route
+	router    *routerpkg.Router
+	routes    map[appmessage.MessageCommand]*routerpkg.Route
+	responses map[appmessage.MessageCommand]*responseQueue
+	isClosed  uint32
+
+	// notificationHandlers counts the goroutines that handle the
+	// notifications received over this router
+	notificationHandlers sync.WaitGroup
 }
 
 func buildRPCRouter() (*rpcRouter, error) {
 	router := routerpkg.NewRouter("RPC server")
 	routes := make(map[appmessage.MessageCommand]*routerpkg.Route, len(appmessage.RPCMessageCommandToString))
+	responses := make(map[appmessage.MessageCommand]*responseQueue, len(appmessage.RPCMessageCommandToString))
 	for messageType := range appmessage.RPCMessageCommandToString {
 		route, err := router.AddIncomingRoute("rpc client", []appmessage.MessageCommand{messageType})
 		if err != nil {
 			return nil, err
 		}
 		routes[messageType] = route
+		responses[messageType] = &responseQueue{
+			route:       route,
+			requestLock: make(chan struct{}, 1),
+		}
 	}
 
 	return &rpcRouter{
-		router: router,
-		routes: routes,
+		router:    router,
+		routes:    routes,
+		responses: responses,
 	}, nil
 }
 
 func (r *rpcRouter) outgoingRoute() *routerpkg.Route {
 	return r.router.OutgoingRoute()
 }
+
+// close closes the router, and returns false if it was already closed
+func (r *rpcRouter) close() bool {
+	swapped := atomic.CompareAndSwapUint32(&r.isClosed, 0, 1)
+	if !swapped {
+		return false
+	}
+	r.router.Close()
+	return true
+}
+
+// responseQueue is where the responses of one command are received. The RPC server
+// answers requests in order, so requests that are answered by the same command are
+// sent by one caller at a time, so that every response is received by the request
+// it answers
+type responseQueue struct {
+	route *routerpkg.Route
+
+	// requestLock is held, from sending requests until their responses are received,
+	// by holding its only slot. Unlike a mutex, waiting for it can be given up on
+	requestLock chan struct{}
+
+	// abandonedResponses is the amount of responses that are yet to be received
+	// for requests that stopped waiting for them. It's guarded by requestLock
+	abandonedResponses int
+}
@@ -0,0 +1,116 @@
+package rpcclient
+
+import (
+	"context"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	routerpkg "github.com/kaspanet/kaspad/infrastructure/network/netadapter/router"
+	"github.com/pkg/errors"
+)
+
+// SetOnNotificationsMissedHandler sets a handler that's called after the client reconnects
+// and restores its notification subscriptions, if notifications may have been missed while
+// it was disconnected. Clients that track state through notifications should rescan it then.
+//
+// Notifications are only known not to have been missed if the client started a notification
+// session with StartNotificationSession, and the session could be resumed.
+func (c *RPCClient) SetOnNotificationsMissedHandler(handler func()) {
+	c.subscriptionsLock.Lock()
+	defer c.subscriptionsLock.Unlock()
+
+	c.onNotificationsMissedHandler = handler
+}
+
+// subscribe calls the given function, which subscribes to notifications or changes a
+// subscription, and keeps it so that it's called again whenever the client reconnects.
+// Only the first call is given ctx
+func (c *RPCClient) subscribe(ctx context.Context, subscribeFunc func(ctx context.Context) error) error {
+	err := subscribeFunc(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.subscriptionsLock.Lock()
+	defer c.subscriptionsLock.Unlock()
+
+	c.subscriptions = append(c.subscriptions, subscribeFunc)
+	return nil
+}
+
+// restoreSubscriptions subscribes again, over a new connection, to the notifications
+// the client was subscribed to, and resumes its notification session if it has one
+func (c *RPCClient) restoreSubscriptions() error {
+	c.subscriptionsLock.Lock()
+	subscriptions := c.subscriptions
+	onNotificationsMissedHandler := c.onNotificationsMissedHandler
+	c.subscriptionsLock.Unlock()
+
+	if len(subscriptions) == 0 {
+		return nil
+	}
+	for _, subscribeFunc := range subscriptions {
+		err := subscribeFunc(context.Background())
+		if err != nil {
+			return errors.Wrapf(err, "error restoring notification subscriptions")
+		}
+	}
+	log.Infof("Restored %d notification subscriptions", len(subscriptions))
+
+	sessionID, lastSequence := c.notificationSession.resumePoint()
+	if sessionID != "" {
+		resumeResponse, err := c.ResumeNotificationSession(sessionID, lastSequence)
+		if err == nil {
+			log.Infof("Resumed notification session %s with %d missed notifications",
+				sessionID, resumeResponse.ReplayedCount)
+			return nil
+		}
+		if !errors.Is(err, ErrRPC) {
+			return err
+		}
+		log.Warnf("Could not resume notification session %s: %s", sessionID, err)
+		_, err = c.StartNotificationSession()
+		if err != nil {
+			return err
+		}
+	}
+
+	if onNotificationsMissedHandler != nil {
+		spawn("restoreSubscriptions-onNotificationsMissedHandler", onNotificationsMissedHandler)
+	}
+	return nil
+}
+
+// handleNotifications spawns a goroutine that calls handleNotification with every
+// notification of the given command that's received over the current connection
+func (c *RPCClient) handleNotifications(name string, command appmessage.MessageCommand,
+	handleNotification func(notification appmessage.Message)) {
+
+	rpcRouter := c.rpcRouter
+	route := rpcRouter.routes[command]
+	rpcRouter.notificationHandlers.Add(1)
+	spawn(name, func() {
+		defer rpcRouter.notificationHandlers.Done()
+
+		for {
+			notification, err := route.Dequeue()
+			if err != nil {
+				if errors.Is(err, routerpkg.ErrRouteClosed) {
+					break
+				}
+				panic(err)
+			}
+			isNew, isMissingNotifications := c.notificationSession.markHandled(notification.NotificationSequence())
+			if isMissingNotifications {
+				c.subscriptionsLock.Lock()
+				onNotificationsMissedHandler := c.onNotificationsMissedHandler
+				c.subscriptionsLock.Unlock()
+				if onNotificationsMissedHandler != nil {
+					spawn("handleNotifications-onNotificationsMissedHandler", onNotificationsMissedHandler)
+				}
+			}
+			if isNew {
+				handleNotification(notification)
+			}
+		}
+	})
+}
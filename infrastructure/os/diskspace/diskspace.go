@@ -0,0 +1,8 @@
+// Package diskspace reports how much disk space is free for kaspad's data.
+package diskspace
+
+import "github.com/pkg/errors"
+
+// ErrUnsupported is returned by Available on platforms where the free disk
+// space can't be queried
+var ErrUnsupported = errors.New("querying the free disk space isn't supported on this platform")
@@ -0,0 +1,10 @@
+//go:build !linux && !darwin && !freebsd && !windows
+// +build !linux,!darwin,!freebsd,!windows
+
+package diskspace
+
+// Available returns ErrUnsupported, since the free disk space can't be
+// queried on this platform
+func Available(path string) (uint64, error) {
+	return 0, ErrUnsupported
+}
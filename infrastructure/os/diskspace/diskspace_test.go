@@ -0,0 +1,25 @@
+package diskspace
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestAvailable(t *testing.T) {
+	available, err := Available(t.TempDir())
+	if errors.Is(err, ErrUnsupported) {
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Fatalf("Available: %+v", err)
+	}
+	if available == 0 {
+		t.Fatalf("Expected some disk space to be available for the temporary directory")
+	}
+
+	_, err = Available("/nonexistent/kaspad/path")
+	if err == nil {
+		t.Fatalf("Expected an error for a path that doesn't exist")
+	}
+}
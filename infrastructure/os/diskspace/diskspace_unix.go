@@ -0,0 +1,20 @@
+//go:build linux || darwin || freebsd
+// +build linux darwin freebsd
+
+package diskspace
+
+import (
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// Available returns the number of bytes that are available to unprivileged
+// users on the filesystem that contains path
+func Available(path string) (uint64, error) {
+	var stat unix.Statfs_t
+	err := unix.Statfs(path, &stat)
+	if err != nil {
+		return 0, errors.Wrapf(err, "couldn't query the filesystem of %s", path)
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), nil
+}
@@ -0,0 +1,21 @@
+package diskspace
+
+import (
+	"github.com/pkg/errors"
+	"golang.org/x/sys/windows"
+)
+
+// Available returns the number of bytes that are available to the user
+// kaspad runs as on the volume that contains path
+func Available(path string) (uint64, error) {
+	pathPointer, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, err
+	}
+	var freeBytesAvailable, totalBytes, totalFreeBytes uint64
+	err = windows.GetDiskFreeSpaceEx(pathPointer, &freeBytesAvailable, &totalBytes, &totalFreeBytes)
+	if err != nil {
+		return 0, errors.Wrapf(err, "couldn't query the volume of %s", path)
+	}
+	return freeBytesAvailable, nil
+}
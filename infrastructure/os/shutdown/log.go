@@ -0,0 +1,9 @@
+package shutdown
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/util/panics"
+)
+
+var log = logger.RegisterSubSystem("SHDN")
+var spawn = panics.GoroutineWrapperFunc(log)
@@ -0,0 +1,163 @@
+package shutdown
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/util/panics"
+	"github.com/pkg/errors"
+)
+
+// Phase is a stage of the shutdown. The hooks of a phase run only once all
+// the hooks of the previous phases returned or timed out.
+type Phase int
+
+const (
+	// PhaseStopAcceptingWork stops the listeners and the connections, so
+	// that no new work, such as blocks, transactions or RPC requests, is
+	// accepted
+	PhaseStopAcceptingWork Phase = iota
+
+	// PhaseDrain waits for the work that was already accepted, such as the
+	// blocks that are being processed and the events they raised, to finish
+	PhaseDrain
+
+	// PhaseCloseDatabase closes the database, which syncs the writes that
+	// weren't synced yet
+	PhaseCloseDatabase
+
+	phaseCount = iota
+)
+
+var phaseDescriptions = [phaseCount]string{
+	"stopping to accept work",
+	"draining the work in progress",
+	"closing the database",
+}
+
+func (phase Phase) String() string {
+	if phase < 0 || phase >= phaseCount {
+		return fmt.Sprintf("phase %d", int(phase))
+	}
+	return phaseDescriptions[phase]
+}
+
+type hook struct {
+	phase   Phase
+	name    string
+	timeout time.Duration
+	run     func() error
+}
+
+// Coordinator runs the shutdown hooks of kaspad's components, phase by
+// phase, so that the database is closed only after the components that
+// write to it stopped, while still being closed if one of them hangs
+type Coordinator struct {
+	mutex  sync.Mutex
+	hooks  []*hook
+	hasRun bool
+
+	// exit is called if the last phase times out. It's replaced in tests.
+	exit func(reason string)
+}
+
+// NewCoordinator returns a new Coordinator without any hooks
+func NewCoordinator() *Coordinator {
+	return &Coordinator{
+		exit: func(reason string) {
+			panics.Exit(log, reason)
+		},
+	}
+}
+
+// Register registers a hook that shuts down the named component in the
+// given phase. The hooks of a phase run one after another, in the order
+// they were registered. A hook that doesn't return within its timeout is
+// abandoned, and the shutdown moves on without it.
+func (c *Coordinator) Register(phase Phase, name string, timeout time.Duration, run func() error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if phase < 0 || phase >= phaseCount {
+		panic(errors.Errorf("invalid shutdown phase %d", phase))
+	}
+	if c.hasRun {
+		panic(errors.Errorf("shutdown hook %s was registered after the shutdown ran", name))
+	}
+	c.hooks = append(c.hooks, &hook{phase: phase, name: name, timeout: timeout, run: run})
+}
+
+// Run runs the registered hooks phase by phase, and logs the progress of
+// the shutdown. Hooks that time out are abandoned, so that the database is
+// closed even if a component hangs. If a hook of the last phase times out,
+// there's nothing left to wait for, so the process exits. Run returns an
+// error if any hook failed or timed out. Only the first call to Run runs the
+// hooks, and later calls return nil.
+func (c *Coordinator) Run() error {
+	c.mutex.Lock()
+	if c.hasRun {
+		c.mutex.Unlock()
+		return nil
+	}
+	c.hasRun = true
+	hooks := c.hooks
+	c.mutex.Unlock()
+
+	shutdownStart := time.Now()
+	failedHookCount := 0
+	for phase := Phase(0); phase < phaseCount; phase++ {
+		var phaseHooks []*hook
+		for _, hook := range hooks {
+			if hook.phase == phase {
+				phaseHooks = append(phaseHooks, hook)
+			}
+		}
+		if len(phaseHooks) == 0 {
+			continue
+		}
+
+		log.Infof("Shutdown phase %d/%d: %s", phase+1, phaseCount, phase)
+		for _, hook := range phaseHooks {
+			isTimedOut, err := runHook(hook)
+			if isTimedOut && phase == phaseCount-1 {
+				c.exit(fmt.Sprintf("%s timed out during shutdown after %s", hook.name, hook.timeout))
+				return err
+			}
+			if err != nil {
+				failedHookCount++
+			}
+		}
+	}
+	log.Infof("Shutdown took %s", time.Since(shutdownStart))
+
+	if failedHookCount > 0 {
+		return errors.Errorf("%d of %d shutdown hooks failed or timed out", failedHookCount, len(hooks))
+	}
+	return nil
+}
+
+// runHook runs the given hook and waits until it returns or times out
+func runHook(hook *hook) (isTimedOut bool, err error) {
+	log.Infof("Shutting down %s", hook.name)
+	hookStart := time.Now()
+	result := make(chan error, 1)
+	spawn("shutdown hook "+hook.name, func() {
+		result <- hook.run()
+	})
+
+	timer := time.NewTimer(hook.timeout)
+	defer timer.Stop()
+	select {
+	case err := <-result:
+		if err != nil {
+			log.Errorf("Error shutting down %s: %s", hook.name, err)
+			return false, errors.Wrapf(err, "error shutting down %s", hook.name)
+		}
+		log.Debugf("Shut down %s in %s", hook.name, time.Since(hookStart))
+		return false, nil
+	case <-timer.C:
+		log.Criticalf("Shutting down %s timed out after %s. Moving on without it", hook.name, hook.timeout)
+		return true, errors.Errorf("shutting down %s timed out after %s", hook.name, hook.timeout)
+	}
+}
@@ -0,0 +1,101 @@
+package shutdown
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestCoordinatorOrder(t *testing.T) {
+	coordinator := NewCoordinator()
+
+	var callsLock sync.Mutex
+	var calls []string
+	register := func(phase Phase, name string) {
+		coordinator.Register(phase, name, time.Second, func() error {
+			callsLock.Lock()
+			defer callsLock.Unlock()
+			calls = append(calls, name)
+			return nil
+		})
+	}
+	register(PhaseCloseDatabase, "database")
+	register(PhaseDrain, "protocol flows")
+	register(PhaseStopAcceptingWork, "net adapter")
+	register(PhaseDrain, "consensus events")
+	register(PhaseStopAcceptingWork, "connection manager")
+
+	err := coordinator.Run()
+	if err != nil {
+		t.Fatalf("Run: %s", err)
+	}
+	expectedCalls := []string{"net adapter", "connection manager", "protocol flows", "consensus events", "database"}
+	if strings.Join(calls, ",") != strings.Join(expectedCalls, ",") {
+		t.Fatalf("Unexpected order of hooks %v. Want: %v", calls, expectedCalls)
+	}
+
+	// Only the first call to Run runs the hooks
+	err = coordinator.Run()
+	if err != nil {
+		t.Fatalf("Run: %s", err)
+	}
+	if len(calls) != len(expectedCalls) {
+		t.Fatalf("The hooks ran more than once")
+	}
+}
+
+func TestCoordinatorFailures(t *testing.T) {
+	coordinator := NewCoordinator()
+	coordinator.exit = func(reason string) {
+		t.Fatalf("Unexpected exit: %s", reason)
+	}
+
+	hang := make(chan struct{})
+	defer close(hang)
+	isDatabaseClosed := false
+	coordinator.Register(PhaseStopAcceptingWork, "failing server", time.Second, func() error {
+		return errors.New("failed")
+	})
+	coordinator.Register(PhaseDrain, "hanging flows", 100*time.Millisecond, func() error {
+		<-hang
+		return nil
+	})
+	coordinator.Register(PhaseCloseDatabase, "database", time.Second, func() error {
+		isDatabaseClosed = true
+		return nil
+	})
+
+	err := coordinator.Run()
+	if err == nil || !strings.Contains(err.Error(), "2 of 3") {
+		t.Fatalf("Expected two of the hooks to fail, but got: %v", err)
+	}
+	if !isDatabaseClosed {
+		t.Fatalf("Expected the database to be closed despite the failures")
+	}
+}
+
+func TestCoordinatorLastPhaseTimeout(t *testing.T) {
+	coordinator := NewCoordinator()
+	var exitReason string
+	coordinator.exit = func(reason string) {
+		exitReason = reason
+	}
+
+	hang := make(chan struct{})
+	defer close(hang)
+	coordinator.Register(PhaseCloseDatabase, "database", 100*time.Millisecond, func() error {
+		<-hang
+		return nil
+	})
+
+	err := coordinator.Run()
+	if err == nil {
+		t.Fatalf("Expected the timeout to be returned")
+	}
+	if !strings.Contains(exitReason, "database timed out") {
+		t.Fatalf("Expected an exit because the database timed out, but got: %q", exitReason)
+	}
+}
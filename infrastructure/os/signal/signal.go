@@ -17,6 +17,10 @@ var ShutdownRequestChannel = make(chan struct{})
 // shutdown. This may be modified during init depending on the platform.
 var interruptSignals = []os.Signal{os.Interrupt}
 
+// reloadSignals defines the signals that request the configuration to be
+// reloaded. This may be modified during init depending on the platform.
+var reloadSignals []os.Signal
+
 // InterruptListener listens for OS Signals such as SIGINT (Ctrl+C) and shutdown
 // requests from shutdownRequestChannel. It returns a channel that is closed
 // when either signal is received.
@@ -69,3 +73,38 @@ func InterruptRequested(interrupted <-chan struct{}) bool {
 
 	return false
 }
+
+// ReloadListener listens for OS signals that request the configuration to be
+// reloaded, such as SIGHUP. It returns a channel that receives a value for
+// each of them, until stop is called. No signals are received on platforms
+// that have none.
+func ReloadListener() (reloadRequested <-chan struct{}, stop func()) {
+	c := make(chan struct{}, 1)
+	if len(reloadSignals) == 0 {
+		return c, func() {}
+	}
+
+	reloadChannel := make(chan os.Signal, 1)
+	signal.Notify(reloadChannel, reloadSignals...)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case sig := <-reloadChannel:
+				kasdLog.Infof("Received signal (%s). Reloading the configuration...", sig)
+				// Requests that arrive while one is pending are merged
+				select {
+				case c <- struct{}{}:
+				default:
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return c, func() {
+		signal.Stop(reloadChannel)
+		close(done)
+	}
+}
@@ -0,0 +1,86 @@
+// Package systemd implements the service side of the systemd notification
+// protocol, which lets a service of Type=notify report when it's ready and
+// when it's stopping, and send the pings a service with WatchdogSec set must
+// send to not be considered hung.
+//
+// See sd_notify(3) and sd_watchdog_enabled(3) for the details of the
+// protocol.
+package systemd
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// The states Notify may send. A status line, in the form "STATUS=...", may
+// be sent on its own or after any of them, separated by a newline.
+const (
+	// StateReady tells the service manager that the service finished
+	// starting up
+	StateReady = "READY=1"
+
+	// StateStopping tells the service manager that the service is
+	// shutting down
+	StateStopping = "STOPPING=1"
+
+	// StateWatchdog is the watchdog ping
+	StateWatchdog = "WATCHDOG=1"
+)
+
+// Notify sends state to the service manager through the socket in the
+// NOTIFY_SOCKET environment variable. It returns false without an error if
+// the variable isn't set, which means the process isn't run by a service
+// manager that expects notifications.
+func Notify(state string) (sent bool, err error) {
+	socketPath := os.Getenv("NOTIFY_SOCKET")
+	if socketPath == "" {
+		return false, nil
+	}
+	// Paths that start with '@' are of sockets in the abstract namespace,
+	// which the net package handles as is
+	connection, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socketPath, Net: "unixgram"})
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	defer connection.Close()
+
+	_, err = connection.Write([]byte(state))
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return true, nil
+}
+
+// WatchdogInterval returns how often watchdog pings should be sent, which is
+// half of the watchdog timeout in the WATCHDOG_USEC environment variable, as
+// the protocol recommends. It returns 0 if the watchdog isn't enabled, or if
+// it's enabled for a different process than this one, according to the
+// WATCHDOG_PID environment variable.
+func WatchdogInterval() (time.Duration, error) {
+	timeoutString := os.Getenv("WATCHDOG_USEC")
+	if timeoutString == "" {
+		return 0, nil
+	}
+	timeoutMicroseconds, err := strconv.ParseUint(timeoutString, 10, 63)
+	if err != nil || timeoutMicroseconds == 0 {
+		return 0, errors.Errorf("WATCHDOG_USEC is %q, which isn't a positive number of microseconds",
+			timeoutString)
+	}
+
+	pidString := os.Getenv("WATCHDOG_PID")
+	if pidString != "" {
+		pid, err := strconv.Atoi(pidString)
+		if err != nil {
+			return 0, errors.Errorf("WATCHDOG_PID is %q, which isn't a process ID", pidString)
+		}
+		if pid != os.Getpid() {
+			return 0, nil
+		}
+	}
+
+	return time.Duration(timeoutMicroseconds) * time.Microsecond / 2, nil
+}
@@ -0,0 +1,92 @@
+package systemd
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestNotify(t *testing.T) {
+	t.Setenv("NOTIFY_SOCKET", "")
+	sent, err := Notify(StateReady)
+	if err != nil {
+		t.Fatalf("Notify: %s", err)
+	}
+	if sent {
+		t.Fatalf("Notify unexpectedly sent a notification without NOTIFY_SOCKET")
+	}
+
+	socketPath := filepath.Join(t.TempDir(), "notify.socket")
+	listener, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socketPath, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("ListenUnixgram: %s", err)
+	}
+	defer listener.Close()
+	t.Setenv("NOTIFY_SOCKET", socketPath)
+
+	state := StateStopping + "\nSTATUS=Shutting down"
+	sent, err = Notify(state)
+	if err != nil {
+		t.Fatalf("Notify: %s", err)
+	}
+	if !sent {
+		t.Fatalf("Notify didn't send the notification")
+	}
+	err = listener.SetReadDeadline(time.Now().Add(time.Second))
+	if err != nil {
+		t.Fatalf("SetReadDeadline: %s", err)
+	}
+	buffer := make([]byte, 256)
+	n, err := listener.Read(buffer)
+	if err != nil {
+		t.Fatalf("Read: %s", err)
+	}
+	if string(buffer[:n]) != state {
+		t.Fatalf("Expected the notification %q, but got %q", state, buffer[:n])
+	}
+
+	t.Setenv("NOTIFY_SOCKET", filepath.Join(t.TempDir(), "missing.socket"))
+	_, err = Notify(StateReady)
+	if err == nil {
+		t.Fatalf("Notify unexpectedly succeeded without a listening socket")
+	}
+}
+
+func TestWatchdogInterval(t *testing.T) {
+	tests := []struct {
+		name             string
+		timeout          string
+		pid              string
+		expectedInterval time.Duration
+		expectsError     bool
+	}{
+		{name: "disabled"},
+		{name: "enabled", timeout: "30000000", expectedInterval: 15 * time.Second},
+		{name: "enabled for this process", timeout: "30000000", pid: strconv.Itoa(os.Getpid()),
+			expectedInterval: 15 * time.Second},
+		{name: "enabled for another process", timeout: "30000000", pid: strconv.Itoa(os.Getpid() + 1)},
+		{name: "zero timeout", timeout: "0", expectsError: true},
+		{name: "malformed timeout", timeout: "30s", expectsError: true},
+		{name: "malformed pid", timeout: "30000000", pid: "self", expectsError: true},
+	}
+	for _, test := range tests {
+		t.Setenv("WATCHDOG_USEC", test.timeout)
+		t.Setenv("WATCHDOG_PID", test.pid)
+		interval, err := WatchdogInterval()
+		if test.expectsError {
+			if err == nil {
+				t.Fatalf("%s: expected an error", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: WatchdogInterval: %s", test.name, err)
+		}
+		if interval != test.expectedInterval {
+			t.Fatalf("%s: expected an interval of %s, but got %s", test.name, test.expectedInterval, interval)
+		}
+	}
+}
@@ -13,6 +13,11 @@ import (
 	"github.com/pkg/errors"
 )
 
+// serviceStateChangeTimeout is how long the service commands wait for the
+// service to start or stop. It's long enough for kaspad to go through all
+// the phases of its shutdown.
+const serviceStateChangeTimeout = 2 * time.Minute
+
 // performServiceCommand attempts to run one of the supported service commands
 // provided on the command line via the service command flag. An appropriate
 // error is returned if an invalid command is specified.
@@ -70,10 +75,12 @@ func (s *Service) installService() error {
 		return errors.Errorf("service %s already exists", s.description.Name)
 	}
 
-	// Install the service.
+	// Install the service, such that it starts whenever the computer
+	// starts.
 	service, err = serviceManager.CreateService(s.description.Name, exePath, mgr.Config{
 		DisplayName: s.description.DisplayName,
 		Description: s.description.Description,
+		StartType:   mgr.StartAutomatic,
 	})
 	if err != nil {
 		return err
@@ -114,7 +121,8 @@ func (s *Service) removeService() error {
 	return service.Delete()
 }
 
-// startService attempts to Start the kaspad service.
+// startService attempts to Start the kaspad service, and waits for up to
+// serviceStateChangeTimeout for it to be running.
 func (s *Service) startService() error {
 	// Connect to the windows service manager.
 	serviceManager, err := mgr.Connect()
@@ -134,12 +142,16 @@ func (s *Service) startService() error {
 		return errors.Errorf("could not start service: %s", err)
 	}
 
-	return nil
+	status, err := service.Query()
+	if err != nil {
+		return errors.Errorf("could not retrieve service status: %s", err)
+	}
+	return waitForServiceState(service, status, svc.Running)
 }
 
 // controlService allows commands which change the status of the service. It
-// also waits for up to 10 seconds for the service to change to the passed
-// state.
+// also waits for up to serviceStateChangeTimeout for the service to change to
+// the passed state.
 func (s *Service) controlService(c svc.Cmd, to svc.State) error {
 	// Connect to the windows service manager.
 	serviceManager, err := mgr.Connect()
@@ -154,19 +166,31 @@ func (s *Service) controlService(c svc.Cmd, to svc.State) error {
 	}
 	defer service.Close()
 
+	// Send the control message.
 	status, err := service.Control(c)
 	if err != nil {
 		return errors.Errorf("could not send control=%d: %s", c, err)
 	}
 
-	// Send the control message.
-	timeout := time.Now().Add(10 * time.Second)
+	return waitForServiceState(service, status, to)
+}
+
+// waitForServiceState waits for up to serviceStateChangeTimeout for the
+// given service, whose current status is status, to change to the passed
+// state. It fails early if the service stops while it's expected to run.
+func waitForServiceState(service *mgr.Service, status svc.Status, to svc.State) error {
+	timeout := time.Now().Add(serviceStateChangeTimeout)
 	for status.State != to {
+		if to == svc.Running && status.State == svc.Stopped {
+			return errors.Errorf("service stopped while starting. " +
+				"See the event log and the logs of kaspad for the reason")
+		}
 		if timeout.Before(time.Now()) {
 			return errors.Errorf("timeout waiting for service to go "+
 				"to state=%d", to)
 		}
 		time.Sleep(300 * time.Millisecond)
+		var err error
 		status, err = service.Query()
 		if err != nil {
 			return errors.Errorf("could not retrieve service "+
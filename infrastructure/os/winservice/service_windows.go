@@ -6,6 +6,7 @@ package winservice
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/btcsuite/winsvc/eventlog"
 	"github.com/btcsuite/winsvc/svc"
@@ -14,6 +15,17 @@ import (
 	"github.com/kaspanet/kaspad/version"
 )
 
+const (
+	// serviceWaitHint is how long the service control manager is told to
+	// wait for progress while kaspad is starting up or shutting down,
+	// before it considers kaspad hung
+	serviceWaitHint = 30 * time.Second
+
+	// serviceCheckPointInterval is how often progress is reported to the
+	// service control manager while kaspad is starting up or shutting down
+	serviceCheckPointInterval = 10 * time.Second
+)
+
 // Service houses the main service handler which handles all service
 // updates and launching the application's main.
 type Service struct {
@@ -31,7 +43,7 @@ func newService(main MainFunc, description *ServiceDescription, cfg *config.Conf
 	}
 }
 
-// Start starts the srevice
+// Start starts the service
 func (s *Service) Start() error {
 	elog, err := eventlog.Open(s.description.Name)
 	if err != nil {
@@ -40,7 +52,7 @@ func (s *Service) Start() error {
 	s.eventLog = elog
 	defer s.eventLog.Close()
 
-	err = svc.Run(s.description.Name, &Service{})
+	err = svc.Run(s.description.Name, s)
 	if err != nil {
 		s.eventLog.Error(1, fmt.Sprintf("Service start failed: %s", err))
 		return err
@@ -54,14 +66,19 @@ func (s *Service) Start() error {
 // long-running kaspadMain (which is the real meat of kaspad), handles service
 // change requests, and notifies the service control manager of changes.
 func (s *Service) Execute(args []string, r <-chan svc.ChangeRequest, changes chan<- svc.Status) (bool, uint32) {
-	// Service start is pending.
 	const cmdsAccepted = svc.AcceptStop | svc.AcceptShutdown
-	changes <- svc.Status{State: svc.StartPending}
-
-	// Start kaspadMain in a separate goroutine so the service can start
-	// quickly. Shutdown (along with a potential error) is reported via
-	// doneChan. startedChan is notified once kaspad is started so this can
-	// be properly logged
+	waitHint := uint32(serviceWaitHint / time.Millisecond)
+
+	// Service start is pending until kaspadMain reports that kaspad is
+	// started, which may take a while since the database is opened and
+	// migrated first.
+	status := svc.Status{State: svc.StartPending, WaitHint: waitHint}
+	changes <- status
+
+	// Start kaspadMain in a separate goroutine so that service requests
+	// are handled while it runs. Shutdown (along with a potential error)
+	// is reported via doneChan. startedChan is notified once kaspad is
+	// started.
 	doneChan := make(chan error)
 	startedChan := make(chan struct{})
 	spawn("kaspadMain-windows", func() {
@@ -69,8 +86,13 @@ func (s *Service) Execute(args []string, r <-chan svc.ChangeRequest, changes cha
 		doneChan <- err
 	})
 
-	// Service is now started.
-	changes <- svc.Status{State: svc.Running, Accepts: cmdsAccepted}
+	// The service control manager is told regularly that progress is made
+	// while kaspad is starting up or shutting down, so that it doesn't
+	// consider kaspad hung.
+	checkPointTicker := time.NewTicker(serviceCheckPointInterval)
+	defer checkPointTicker.Stop()
+
+	var err error
 loop:
 	for {
 		select {
@@ -80,9 +102,14 @@ loop:
 				changes <- c.CurrentStatus
 
 			case svc.Stop, svc.Shutdown:
+				if status.State == svc.StopPending {
+					continue
+				}
 				// Service stop is pending. Don't accept any
 				// more commands while pending.
-				changes <- svc.Status{State: svc.StopPending}
+				status = svc.Status{State: svc.StopPending, WaitHint: waitHint}
+				changes <- status
+				s.eventLog.Info(1, fmt.Sprintf("%s is shutting down", s.description.DisplayName))
 
 				// Signal the main function to exit.
 				signal.ShutdownRequestChannel <- struct{}{}
@@ -92,19 +119,34 @@ loop:
 					"request #%d.", c))
 			}
 
+		case <-checkPointTicker.C:
+			if status.State == svc.StartPending || status.State == svc.StopPending {
+				status.CheckPoint++
+				changes <- status
+			}
+
 		case <-startedChan:
+			// Service is now started.
+			if status.State == svc.StartPending {
+				status = svc.Status{State: svc.Running, Accepts: cmdsAccepted}
+				changes <- status
+			}
 			s.logServiceStart()
 
-		case err := <-doneChan:
-			if err != nil {
-				s.eventLog.Error(1, err.Error())
-			}
+		case err = <-doneChan:
 			break loop
 		}
 	}
 
-	// Service is now stopped.
+	// Service is now stopped. A failure is reported with a service
+	// specific exit code, so that the service control manager treats the
+	// service as failed and applies its recovery actions.
 	changes <- svc.Status{State: svc.Stopped}
+	if err != nil {
+		s.eventLog.Error(1, fmt.Sprintf("%s stopped with an error: %s", s.description.DisplayName, err))
+		return true, 1
+	}
+	s.eventLog.Info(1, fmt.Sprintf("%s stopped", s.description.DisplayName))
 	return false, 0
 }
 
@@ -116,4 +158,5 @@ func (s *Service) logServiceStart() {
 	message += fmt.Sprintf("Configuration file: %s\n", s.cfg.ConfigFile)
 	message += fmt.Sprintf("Application directory: %s\n", s.cfg.AppDir)
 	message += fmt.Sprintf("Logs directory: %s\n", s.cfg.LogDir)
+	s.eventLog.Info(1, message)
 }
@@ -0,0 +1,49 @@
+package tracing
+
+import "strconv"
+
+// Attribute is a key and value that describe a span, such as the hash of the
+// block it processes
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// String returns a string attribute
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Stringer returns a string attribute whose value is value.String(). It's
+// only called if the span is recorded.
+func Stringer(key string, value interface{ String() string }) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int returns an integer attribute
+func Int(key string, value int64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Bool returns a boolean attribute
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// otlpValue returns the value of the attribute in the JSON encoding of OTLP.
+// 64 bit integers are encoded as strings, as protobuf's JSON mapping
+// requires.
+func (a Attribute) otlpValue() map[string]interface{} {
+	switch value := a.Value.(type) {
+	case string:
+		return map[string]interface{}{"stringValue": value}
+	case interface{ String() string }:
+		return map[string]interface{}{"stringValue": value.String()}
+	case int64:
+		return map[string]interface{}{"intValue": strconv.FormatInt(value, 10)}
+	case bool:
+		return map[string]interface{}{"boolValue": value}
+	default:
+		return map[string]interface{}{"stringValue": ""}
+	}
+}
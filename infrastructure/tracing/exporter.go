@@ -0,0 +1,239 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// tracesPath is the path of the OTLP/HTTP endpoint that receives spans
+	tracesPath = "/v1/traces"
+
+	// queueSize is how many ended spans may wait for export. Spans that
+	// end while the queue is full are dropped.
+	queueSize = 4096
+
+	// batchSize is the most spans that are posted in one request
+	batchSize = 512
+
+	// exportInterval is how often the queued spans are posted, if fewer
+	// than batchSize of them are queued
+	exportInterval = 5 * time.Second
+
+	// exportTimeout is how long posting a batch may take
+	exportTimeout = 10 * time.Second
+
+	// instrumentationScope names the instrumentation the spans come from
+	instrumentationScope = "github.com/kaspanet/kaspad"
+)
+
+// exporter posts ended spans in batches to an OTLP/HTTP endpoint, in the
+// JSON encoding of OTLP
+type exporter struct {
+	url            string
+	client         *http.Client
+	resource       map[string]interface{}
+	queue          chan *endedSpan
+	stopChan       chan struct{}
+	doneChan       chan struct{}
+	droppedCount   uint64
+	exportFailures uint64
+}
+
+func newExporter(config *Config) (*exporter, error) {
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid tracing endpoint %s", config.Endpoint)
+	}
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return nil, errors.Errorf("the tracing endpoint %s must be an http or https URL", config.Endpoint)
+	}
+	if !strings.HasSuffix(endpoint.Path, tracesPath) {
+		endpoint.Path = strings.TrimSuffix(endpoint.Path, "/") + tracesPath
+	}
+
+	resourceAttributes := []Attribute{String("service.name", config.ServiceName)}
+	if config.ServiceVersion != "" {
+		resourceAttributes = append(resourceAttributes, String("service.version", config.ServiceVersion))
+	}
+	return &exporter{
+		url:      endpoint.String(),
+		client:   &http.Client{Timeout: exportTimeout},
+		resource: map[string]interface{}{"attributes": otlpAttributes(resourceAttributes)},
+		queue:    make(chan *endedSpan, queueSize),
+		stopChan: make(chan struct{}),
+		doneChan: make(chan struct{}),
+	}, nil
+}
+
+func (e *exporter) start() {
+	spawn("tracing.exporter.exportLoop", e.exportLoop)
+}
+
+// stop exports the queued spans and stops the export loop
+func (e *exporter) stop(timeout time.Duration) error {
+	close(e.stopChan)
+	select {
+	case <-e.doneChan:
+		return nil
+	case <-time.After(timeout):
+		return errors.Errorf("exporting the remaining spans took longer than %s", timeout)
+	}
+}
+
+// enqueue queues span for export, or drops it if the queue is full, so that
+// tracing never blocks the work it traces
+func (e *exporter) enqueue(span *endedSpan) {
+	select {
+	case e.queue <- span:
+	default:
+		atomic.AddUint64(&e.droppedCount, 1)
+	}
+}
+
+func (e *exporter) exportLoop() {
+	defer close(e.doneChan)
+
+	ticker := time.NewTicker(exportInterval)
+	defer ticker.Stop()
+
+	batch := make([]*endedSpan, 0, batchSize)
+	for {
+		select {
+		case span := <-e.queue:
+			batch = append(batch, span)
+			if len(batch) < batchSize {
+				continue
+			}
+		case <-ticker.C:
+		case <-e.stopChan:
+			// The spans that are still queued are exported before
+			// stopping
+			for len(e.queue) > 0 {
+				batch = append(batch, <-e.queue)
+				if len(batch) == batchSize {
+					e.exportAndLog(batch)
+					batch = batch[:0]
+				}
+			}
+			e.exportAndLog(batch)
+			return
+		}
+		e.exportAndLog(batch)
+		batch = batch[:0]
+	}
+}
+
+func (e *exporter) exportAndLog(batch []*endedSpan) {
+	if len(batch) == 0 {
+		return
+	}
+	droppedCount := atomic.SwapUint64(&e.droppedCount, 0)
+	if droppedCount > 0 {
+		log.Warnf("Dropped %d spans, since they ended faster than they could be exported", droppedCount)
+	}
+
+	err := e.export(batch)
+	if err != nil {
+		// Only the first failure and every 100th one are logged, so that
+		// an unreachable collector doesn't flood the log
+		if e.exportFailures%100 == 0 {
+			log.Warnf("Couldn't export %d spans to %s: %s", len(batch), e.url, err)
+		}
+		e.exportFailures++
+		return
+	}
+	e.exportFailures = 0
+}
+
+func (e *exporter) export(batch []*endedSpan) error {
+	body, err := json.Marshal(e.request(batch))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := e.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	// The body is drained so that the connection can be reused
+	_, _ = io.Copy(ioutil.Discard, response.Body)
+	if response.StatusCode/100 != 2 {
+		return errors.Errorf("the collector responded with %s", response.Status)
+	}
+	return nil
+}
+
+// request returns an ExportTraceServiceRequest of the given spans, as
+// defined by the OTLP protobuf definitions, in their JSON encoding
+func (e *exporter) request(batch []*endedSpan) map[string]interface{} {
+	spans := make([]map[string]interface{}, len(batch))
+	for i, span := range batch {
+		spans[i] = span.otlp()
+	}
+	return map[string]interface{}{
+		"resourceSpans": []map[string]interface{}{{
+			"resource": e.resource,
+			"scopeSpans": []map[string]interface{}{{
+				"scope": map[string]interface{}{"name": instrumentationScope},
+				"spans": spans,
+			}},
+		}},
+	}
+}
+
+const (
+	// spanKindInternal is the OTLP SpanKind of spans of work that's done
+	// within kaspad
+	spanKindInternal = 1
+
+	// statusCodeError is the OTLP status code of failed spans
+	statusCodeError = 2
+)
+
+func (s *endedSpan) otlp() map[string]interface{} {
+	otlpSpan := map[string]interface{}{
+		"traceId":           s.traceID.String(),
+		"spanId":            s.spanID.String(),
+		"name":              s.name,
+		"kind":              spanKindInternal,
+		"startTimeUnixNano": strconv.FormatInt(s.startTime.UnixNano(), 10),
+		"endTimeUnixNano":   strconv.FormatInt(s.endTime.UnixNano(), 10),
+		"attributes":        otlpAttributes(s.attributes),
+	}
+	if s.hasParent {
+		otlpSpan["parentSpanId"] = s.parentSpanID.String()
+	}
+	if s.errorMessage != "" {
+		otlpSpan["status"] = map[string]interface{}{"code": statusCodeError, "message": s.errorMessage}
+	}
+	return otlpSpan
+}
+
+func otlpAttributes(attributes []Attribute) []map[string]interface{} {
+	otlpAttributes := make([]map[string]interface{}, len(attributes))
+	for i, attribute := range attributes {
+		otlpAttributes[i] = map[string]interface{}{"key": attribute.Key, "value": attribute.otlpValue()}
+	}
+	return otlpAttributes
+}
@@ -0,0 +1,9 @@
+package tracing
+
+import (
+	"github.com/kaspanet/kaspad/infrastructure/logger"
+	"github.com/kaspanet/kaspad/util/panics"
+)
+
+var log = logger.RegisterSubSystem("TRAC")
+var spawn = panics.GoroutineWrapperFunc(log)
@@ -0,0 +1,269 @@
+// Package tracing records spans of the work kaspad does, such as processing a
+// relayed block or handling an RPC request, and exports them to an
+// OpenTelemetry collector over OTLP/HTTP.
+//
+// Tracing is disabled until Start is called. While it's disabled, or when a
+// trace isn't sampled, the functions that start spans return a nil *Span,
+// and all the methods of a nil *Span do nothing. This lets the hot paths be
+// instrumented unconditionally.
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"math"
+	mathrand "math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Config configures the exporting and sampling of the traces
+type Config struct {
+	// Endpoint is the URL of the OTLP/HTTP endpoint the spans are posted
+	// to, such as http://localhost:4318. The /v1/traces path is appended
+	// to it unless it already ends with it.
+	Endpoint string
+
+	// SampleRate is the fraction, between 0 and 1, of the traces that are
+	// recorded. The decision is made when the root span of a trace is
+	// started, and applies to all of its descendants.
+	SampleRate float64
+
+	// ServiceName is reported as the service.name resource attribute
+	ServiceName string
+
+	// ServiceVersion is reported as the service.version resource attribute
+	ServiceVersion string
+}
+
+// tracer holds the *tracerState that spans are started with. It's nil while
+// tracing is disabled.
+var tracer atomic.Value
+
+type tracerState struct {
+	exporter       *exporter
+	sampleRate     float64
+	randomLock     sync.Mutex
+	samplingRandom *mathrand.Rand
+}
+
+// Start enables tracing with the given config. The spans are exported until
+// Stop is called.
+func Start(config *Config) error {
+	if config.SampleRate < 0 || config.SampleRate > 1 || math.IsNaN(config.SampleRate) {
+		return errors.Errorf("the sample rate must be between 0 and 1, but got %f", config.SampleRate)
+	}
+	exporter, err := newExporter(config)
+	if err != nil {
+		return err
+	}
+	state := &tracerState{
+		exporter:       exporter,
+		sampleRate:     config.SampleRate,
+		samplingRandom: mathrand.New(mathrand.NewSource(time.Now().UnixNano())),
+	}
+	tracer.Store(state)
+	exporter.start()
+	log.Infof("Exporting traces to %s, sampling %g of them", exporter.url, config.SampleRate)
+	return nil
+}
+
+// Stop disables tracing, and exports the spans that ended and weren't
+// exported yet, waiting up to timeout for them to be sent
+func Stop(timeout time.Duration) error {
+	state := currentTracer()
+	if state == nil {
+		return nil
+	}
+	tracer.Store((*tracerState)(nil))
+	return state.exporter.stop(timeout)
+}
+
+// IsEnabled returns whether tracing is enabled
+func IsEnabled() bool {
+	return currentTracer() != nil
+}
+
+func currentTracer() *tracerState {
+	state, _ := tracer.Load().(*tracerState)
+	return state
+}
+
+func (t *tracerState) isSampled() bool {
+	if t.sampleRate >= 1 {
+		return true
+	}
+	t.randomLock.Lock()
+	defer t.randomLock.Unlock()
+	return t.samplingRandom.Float64() < t.sampleRate
+}
+
+// TraceID identifies a trace
+type TraceID [16]byte
+
+// String returns the trace ID as hex, as it appears in OTLP and in the W3C
+// traceparent header
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// SpanID identifies a span within a trace
+type SpanID [8]byte
+
+// String returns the span ID as hex
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// Span is a timed operation within a trace, such as validating a block. A
+// nil *Span is a span that isn't recorded.
+type Span struct {
+	tracer       *tracerState
+	traceID      TraceID
+	spanID       SpanID
+	parentSpanID SpanID
+	hasParent    bool
+	name         string
+	startTime    time.Time
+
+	lock         sync.Mutex
+	attributes   []Attribute
+	errorMessage string
+	hasEnded     bool
+}
+
+// StartSpan starts the root span of a new trace. It returns nil if tracing
+// is disabled or the trace isn't sampled.
+func StartSpan(name string, attributes ...Attribute) *Span {
+	state := currentTracer()
+	if state == nil || !state.isSampled() {
+		return nil
+	}
+	span := &Span{
+		tracer:     state,
+		name:       name,
+		startTime:  time.Now(),
+		attributes: attributes,
+	}
+	randomID(span.traceID[:])
+	randomID(span.spanID[:])
+	return span
+}
+
+// StartChild starts a span of the same trace as s, whose parent is s
+func (s *Span) StartChild(name string, attributes ...Attribute) *Span {
+	if s == nil {
+		return nil
+	}
+	child := &Span{
+		tracer:       s.tracer,
+		traceID:      s.traceID,
+		parentSpanID: s.spanID,
+		hasParent:    true,
+		name:         name,
+		startTime:    time.Now(),
+		attributes:   attributes,
+	}
+	randomID(child.spanID[:])
+	return child
+}
+
+// TraceID returns the ID of the trace of s, or a zero ID if s is nil
+func (s *Span) TraceID() TraceID {
+	if s == nil {
+		return TraceID{}
+	}
+	return s.traceID
+}
+
+// SetAttributes adds the given attributes to s, replacing the values of
+// attributes it already has with the same keys
+func (s *Span) SetAttributes(attributes ...Attribute) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, attribute := range attributes {
+		replaced := false
+		for i := range s.attributes {
+			if s.attributes[i].Key == attribute.Key {
+				s.attributes[i] = attribute
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			s.attributes = append(s.attributes, attribute)
+		}
+	}
+}
+
+// RecordError marks s as failed with err. A nil err is ignored.
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.errorMessage = err.Error()
+}
+
+// End ends s and queues it for export. Only the first call has any effect.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	endTime := time.Now()
+
+	s.lock.Lock()
+	if s.hasEnded {
+		s.lock.Unlock()
+		return
+	}
+	s.hasEnded = true
+	ended := &endedSpan{
+		traceID:      s.traceID,
+		spanID:       s.spanID,
+		parentSpanID: s.parentSpanID,
+		hasParent:    s.hasParent,
+		name:         s.name,
+		startTime:    s.startTime,
+		endTime:      endTime,
+		attributes:   s.attributes,
+		errorMessage: s.errorMessage,
+	}
+	s.lock.Unlock()
+
+	s.tracer.exporter.enqueue(ended)
+}
+
+// EndWithError records err, if it isn't nil, and ends s
+func (s *Span) EndWithError(err error) {
+	s.RecordError(err)
+	s.End()
+}
+
+// endedSpan is the immutable copy of a span that's exported
+type endedSpan struct {
+	traceID      TraceID
+	spanID       SpanID
+	parentSpanID SpanID
+	hasParent    bool
+	name         string
+	startTime    time.Time
+	endTime      time.Time
+	attributes   []Attribute
+	errorMessage string
+}
+
+func randomID(id []byte) {
+	// crypto/rand never fails on the supported platforms, and an ID that
+	// isn't random only risks a collision between traces
+	_, _ = rand.Read(id)
+}
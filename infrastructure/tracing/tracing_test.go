@@ -0,0 +1,153 @@
+package tracing
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type collector struct {
+	server *httptest.Server
+	lock   sync.Mutex
+	paths  []string
+	spans  []map[string]interface{}
+}
+
+func newCollector(t *testing.T) *collector {
+	c := &collector{}
+	c.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("ReadAll: %s", err)
+			return
+		}
+		var request struct {
+			ResourceSpans []struct {
+				ScopeSpans []struct {
+					Spans []map[string]interface{} `json:"spans"`
+				} `json:"scopeSpans"`
+			} `json:"resourceSpans"`
+		}
+		err = json.Unmarshal(body, &request)
+		if err != nil {
+			t.Errorf("Unmarshal: %s", err)
+			return
+		}
+
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		c.paths = append(c.paths, r.URL.Path)
+		for _, resourceSpans := range request.ResourceSpans {
+			for _, scopeSpans := range resourceSpans.ScopeSpans {
+				c.spans = append(c.spans, scopeSpans.Spans...)
+			}
+		}
+	}))
+	t.Cleanup(c.server.Close)
+	return c
+}
+
+func startTracing(t *testing.T, endpoint string, sampleRate float64) {
+	err := Start(&Config{Endpoint: endpoint, SampleRate: sampleRate, ServiceName: "kaspad-test"})
+	if err != nil {
+		t.Fatalf("Start: %+v", err)
+	}
+	t.Cleanup(func() { _ = Stop(time.Second) })
+}
+
+func TestSpansAreExported(t *testing.T) {
+	collector := newCollector(t)
+	startTracing(t, collector.server.URL, 1)
+
+	root := StartSpan("root", String("block.hash", "abc"))
+	child := root.StartChild("child", Int("count", 3))
+	child.EndWithError(errors.New("failed"))
+	root.SetAttributes(Bool("isOK", true))
+	root.End()
+	root.End()
+
+	err := Stop(time.Second)
+	if err != nil {
+		t.Fatalf("Stop: %+v", err)
+	}
+	if IsEnabled() {
+		t.Fatalf("Expected tracing to be disabled after Stop")
+	}
+
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	if len(collector.paths) == 0 || collector.paths[0] != tracesPath {
+		t.Fatalf("Expected the spans to be posted to %s, but got %v", tracesPath, collector.paths)
+	}
+	if len(collector.spans) != 2 {
+		t.Fatalf("Expected 2 spans, but got %d", len(collector.spans))
+	}
+	exportedChild, exportedRoot := collector.spans[0], collector.spans[1]
+	if exportedRoot["name"] != "root" || exportedChild["name"] != "child" {
+		t.Fatalf("Unexpected span names %s and %s", exportedRoot["name"], exportedChild["name"])
+	}
+	if exportedChild["traceId"] != root.TraceID().String() || exportedRoot["traceId"] != root.TraceID().String() {
+		t.Fatalf("Expected both spans to be of trace %s", root.TraceID())
+	}
+	if exportedChild["parentSpanId"] != exportedRoot["spanId"] {
+		t.Fatalf("Expected the parent of the child to be %s, but got %s",
+			exportedRoot["spanId"], exportedChild["parentSpanId"])
+	}
+	if _, ok := exportedRoot["parentSpanId"]; ok {
+		t.Fatalf("Expected the root span not to have a parent")
+	}
+	status, ok := exportedChild["status"].(map[string]interface{})
+	if !ok || status["message"] != "failed" {
+		t.Fatalf("Expected the child to have failed, but got status %v", exportedChild["status"])
+	}
+	if attributes := exportedRoot["attributes"].([]interface{}); len(attributes) != 2 {
+		t.Fatalf("Expected the root span to have 2 attributes, but got %v", attributes)
+	}
+}
+
+func TestSampling(t *testing.T) {
+	collector := newCollector(t)
+	startTracing(t, collector.server.URL, 0)
+
+	span := StartSpan("unsampled")
+	if span != nil {
+		t.Fatalf("Expected no trace to be sampled with a sample rate of 0")
+	}
+	// The methods of an unsampled span do nothing
+	span.StartChild("child").EndWithError(errors.New("failed"))
+	span.End()
+}
+
+func TestDisabled(t *testing.T) {
+	if StartSpan("disabled") != nil {
+		t.Fatalf("Expected no span to be started while tracing is disabled")
+	}
+	err := Stop(time.Second)
+	if err != nil {
+		t.Fatalf("Stop: %+v", err)
+	}
+}
+
+func TestStartErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{name: "negative sample rate", config: Config{Endpoint: "http://localhost:4318", SampleRate: -0.1}},
+		{name: "sample rate above 1", config: Config{Endpoint: "http://localhost:4318", SampleRate: 1.1}},
+		{name: "unsupported scheme", config: Config{Endpoint: "grpc://localhost:4317", SampleRate: 1}},
+	}
+	for _, test := range tests {
+		err := Start(&test.config)
+		if err == nil {
+			_ = Stop(time.Second)
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
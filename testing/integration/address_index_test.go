@@ -0,0 +1,131 @@
+package integration
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddressIndex(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		utxoIndex:               true,
+		addressIndex:            true,
+	})
+	defer teardown()
+
+	// Mine enough blocks for the first coinbase to mature
+	const blockAmountToMine = 101
+	for i := 0; i < blockAmountToMine; i++ {
+		mineNextBlock(t, harness)
+	}
+
+	// Page through all the accepted transactions of the mining address
+	const limit = 7
+	seenTransactionIDs := make(map[string]struct{})
+	previousDAAScore := ^uint64(0)
+	cursor := ""
+	for {
+		response, err := harness.rpcClient.SearchRawTransactions(miningAddress1, limit, cursor, false)
+		if err != nil {
+			t.Fatalf("SearchRawTransactions: %s", err)
+		}
+		if len(response.Entries) > limit {
+			t.Fatalf("Got %d entries, which is more than the limit %d", len(response.Entries), limit)
+		}
+		for _, entry := range response.Entries {
+			if entry.AcceptingBlockHash == "" || entry.Transaction.VerboseData.BlockHash == "" {
+				t.Fatalf("Missing the blocks of accepted transaction %s", entry.Transaction.VerboseData.TransactionID)
+			}
+			if entry.AcceptingDAAScore > previousDAAScore {
+				t.Fatalf("Transactions aren't ordered from newest to oldest")
+			}
+			previousDAAScore = entry.AcceptingDAAScore
+
+			if _, ok := seenTransactionIDs[entry.Transaction.VerboseData.TransactionID]; ok {
+				t.Fatalf("Transaction %s was returned twice", entry.Transaction.VerboseData.TransactionID)
+			}
+			seenTransactionIDs[entry.Transaction.VerboseData.TransactionID] = struct{}{}
+		}
+		if response.NextCursor == "" {
+			break
+		}
+		cursor = response.NextCursor
+	}
+	if len(seenTransactionIDs) < blockAmountToMine-2 {
+		t.Fatalf("Expected at least %d coinbase transactions, got %d", blockAmountToMine-2, len(seenTransactionIDs))
+	}
+
+	// Spend the oldest UTXO, and make sure the transaction is found in the mempool
+	utxosByAddressesResponse, err := harness.rpcClient.GetUTXOsByAddresses([]string{miningAddress1})
+	if err != nil {
+		t.Fatalf("Failed to get UTXOs: %s", err)
+	}
+	spentEntry := utxosByAddressesResponse.Entries[0]
+	for _, entry := range utxosByAddressesResponse.Entries {
+		if entry.UTXOEntry.BlockDAAScore < spentEntry.UTXOEntry.BlockDAAScore {
+			spentEntry = entry
+		}
+	}
+	transactionID, err := harness.rpcClient.SubmitTransaction(buildTransactionForUTXOIndexTest(t, spentEntry), false)
+	if err != nil {
+		t.Fatalf("Error submitting transaction: %s", err)
+	}
+
+	response, err := harness.rpcClient.SearchRawTransactions(miningAddress1, 1, "", true)
+	if err != nil {
+		t.Fatalf("SearchRawTransactions: %s", err)
+	}
+	if len(response.Entries) != 2 {
+		t.Fatalf("Expected a mempool transaction and an accepted transaction, got %d entries", len(response.Entries))
+	}
+	mempoolEntry := response.Entries[0]
+	if mempoolEntry.Transaction.VerboseData.TransactionID != transactionID.TransactionID ||
+		mempoolEntry.AcceptingBlockHash != "" {
+
+		t.Fatalf("Unexpected mempool entry %+v", mempoolEntry)
+	}
+
+	// Mempool transactions are only returned along with the first page
+	response, err = harness.rpcClient.SearchRawTransactions(miningAddress1, 1, response.NextCursor, true)
+	if err != nil {
+		t.Fatalf("SearchRawTransactions: %s", err)
+	}
+	for _, entry := range response.Entries {
+		if entry.AcceptingBlockHash == "" {
+			t.Fatalf("Unexpected mempool transaction in the second page")
+		}
+	}
+
+	// Transactions are indexed once a chain block accepts them, which happens when the block
+	// that contains them is merged. The index is updated asynchronously, so it's polled.
+	mineNextBlock(t, harness)
+	mineNextBlock(t, harness)
+	deadline := time.Now().Add(defaultTimeout)
+	for !isTransactionIndexed(t, harness, transactionID.TransactionID) {
+		if time.Now().After(deadline) {
+			t.Fatalf("The spending transaction %s was not indexed", transactionID.TransactionID)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	_, err = harness.rpcClient.SearchRawTransactions(miningAddress1, 0, "invalid", false)
+	if err == nil {
+		t.Fatalf("Expected an error for an invalid cursor")
+	}
+}
+
+func isTransactionIndexed(t *testing.T, harness *appHarness, transactionID string) bool {
+	response, err := harness.rpcClient.SearchRawTransactions(miningAddress1, 0, "", false)
+	if err != nil {
+		t.Fatalf("SearchRawTransactions: %s", err)
+	}
+	for _, entry := range response.Entries {
+		if entry.Transaction.VerboseData.TransactionID == transactionID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package integration
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/merkle"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/mining"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+	"github.com/kaspanet/kaspad/domain/miningmanager"
+)
+
+func TestAuxCommitmentProof(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	blockWithoutCommitment := mineNextBlock(t, harness)
+	_, err := harness.rpcClient.GetAuxCommitmentProof(consensushashing.BlockHash(blockWithoutCommitment).String())
+	if err == nil {
+		t.Fatalf("Expected an error when getting the proof of a block without an aux commitment")
+	}
+
+	auxCommitment := externalapi.NewDomainHashFromByteArray(&[externalapi.DomainHashSize]byte{0xaa, 0xbb})
+	blockTemplate, err := harness.rpcClient.GetBlockTemplateWithAuxCommitment(harness.miningAddress, "integration", auxCommitment)
+	if err != nil {
+		t.Fatalf("Error getting block template: %+v", err)
+	}
+	block, err := appmessage.RPCBlockToDomainBlock(blockTemplate.Block)
+	if err != nil {
+		t.Fatalf("Error converting block: %s", err)
+	}
+	rd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	mining.SolveBlock(block, rd)
+	_, err = harness.rpcClient.SubmitBlock(block)
+	if err != nil {
+		t.Fatalf("Error submitting block: %s", err)
+	}
+	blockHash := consensushashing.BlockHash(block)
+
+	proof, err := harness.rpcClient.GetAuxCommitmentProof(blockHash.String())
+	if err != nil {
+		t.Fatalf("Error getting aux commitment proof: %+v", err)
+	}
+	if proof.AuxCommitment != auxCommitment.String() {
+		t.Fatalf("Expected aux commitment %s but got %s", auxCommitment, proof.AuxCommitment)
+	}
+
+	// Verify the proof using only its contents
+	proofBlock, err := appmessage.RPCBlockToDomainBlock(&appmessage.RPCBlock{Header: proof.Header})
+	if err != nil {
+		t.Fatalf("Error converting header: %s", err)
+	}
+	if !consensushashing.BlockHash(proofBlock).Equal(blockHash) {
+		t.Fatalf("The header in the proof doesn't hash to the block hash")
+	}
+	coinbaseTransaction, err := appmessage.RPCTransactionToDomainTransaction(proof.CoinbaseTransaction)
+	if err != nil {
+		t.Fatalf("Error converting coinbase transaction: %s", err)
+	}
+	committed, ok := miningmanager.ExtractAuxCommitment(coinbaseTransaction.Payload)
+	if !ok || !committed.Equal(auxCommitment) {
+		t.Fatalf("The coinbase transaction in the proof doesn't embed the aux commitment")
+	}
+	merkleBranch := make([]*externalapi.DomainHash, len(proof.MerkleBranch))
+	for i, hashString := range proof.MerkleBranch {
+		merkleBranch[i], err = externalapi.NewDomainHashFromString(hashString)
+		if err != nil {
+			t.Fatalf("Error parsing merkle branch hash: %s", err)
+		}
+	}
+	root := merkle.CalculateMerkleRootFromBranch(consensushashing.TransactionHash(coinbaseTransaction),
+		transactionhelper.CoinbaseTransactionIndex, merkleBranch)
+	if !root.Equal(proofBlock.Header.HashMerkleRoot()) {
+		t.Fatalf("The merkle branch in the proof doesn't lead to the hash merkle root")
+	}
+}
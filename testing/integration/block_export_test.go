@@ -0,0 +1,66 @@
+package integration
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestBlockExportImport(t *testing.T) {
+	exporter, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	const blockCount = 20
+	for i := 0; i < blockCount; i++ {
+		mineNextBlock(t, exporter)
+	}
+
+	exportPath := filepath.Join(randomDirectory(t), "blocks")
+	exportResponse, err := exporter.rpcClient.ExportBlocks(exportPath, "", "")
+	if err != nil {
+		t.Fatalf("Error exporting the blocks: %s", err)
+	}
+	exporterInfo, err := exporter.rpcClient.GetBlockDAGInfo()
+	if err != nil {
+		t.Fatalf("Error getting the exporter's DAG info: %s", err)
+	}
+	if exportResponse.LowHash != exporterInfo.PruningPointHash {
+		t.Fatalf("Unexpected low hash of the export. Want: %s, got: %s",
+			exporterInfo.PruningPointHash, exportResponse.LowHash)
+	}
+	if exportResponse.HighHash != exporterInfo.TipHashes[0] {
+		t.Fatalf("Unexpected high hash of the export. Want: %s, got: %s",
+			exporterInfo.TipHashes[0], exportResponse.HighHash)
+	}
+	if exportResponse.BlockCount != blockCount {
+		t.Fatalf("Unexpected amount of exported blocks. Want: %d, got: %d", blockCount, exportResponse.BlockCount)
+	}
+
+	// The importer isn't connected to the exporter, so it can
+	// only get the exporter's blocks from the export file
+	importer, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress2,
+		rpcAddress:              rpcAddress2,
+		miningAddress:           miningAddress2,
+		miningAddressPrivateKey: miningAddress2PrivateKey,
+		importBlocks:            exportPath,
+	})
+	defer teardown()
+
+	importerInfo, err := importer.rpcClient.GetBlockDAGInfo()
+	if err != nil {
+		t.Fatalf("Error getting the importer's DAG info: %s", err)
+	}
+	if importerInfo.BlockCount != exporterInfo.BlockCount {
+		t.Fatalf("Unexpected amount of blocks after the import. Want: %d, got: %d",
+			exporterInfo.BlockCount, importerInfo.BlockCount)
+	}
+	if importerInfo.TipHashes[0] != exportResponse.HighHash {
+		t.Fatalf("Unexpected tip after the import. Want: %s, got: %s",
+			exportResponse.HighHash, importerInfo.TipHashes[0])
+	}
+}
@@ -0,0 +1,87 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+)
+
+func waitForBlockSubmissionStatus(t *testing.T, harness *appHarness, submissionID string, expectedStatus string) {
+	const timeout = 10 * time.Second
+	deadline := time.Now().Add(timeout)
+	for {
+		response, err := harness.rpcClient.GetBlockSubmissionStatus(submissionID)
+		if err != nil {
+			t.Fatalf("Error getting block submission status: %+v", err)
+		}
+		if response.Status == expectedStatus {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for block submission %s to be %s. Last status: %s (%s)",
+				submissionID, expectedStatus, response.Status, response.RejectReason)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestAsyncBlockSubmission(t *testing.T) {
+	harnesses, teardown := setupHarnesses(t, []*harnessParams{
+		{
+			p2pAddress:              p2pAddress1,
+			rpcAddress:              rpcAddress1,
+			miningAddress:           miningAddress1,
+			miningAddressPrivateKey: miningAddress1PrivateKey,
+		},
+		{
+			p2pAddress:              p2pAddress2,
+			rpcAddress:              rpcAddress2,
+			miningAddress:           miningAddress2,
+			miningAddressPrivateKey: miningAddress2PrivateKey,
+		},
+	})
+	defer teardown()
+	submitter, miner := harnesses[0], harnesses[1]
+
+	_, err := submitter.rpcClient.GetBlockSubmissionStatus("unknown")
+	if err == nil {
+		t.Fatalf("Expected an error when getting the status of an unknown submission")
+	}
+
+	// The harnesses are not connected, so blocks mined by one of them are new to the other
+	parent := mineNextBlock(t, miner)
+	child := mineNextBlock(t, miner)
+
+	childSubmissionID, err := submitter.rpcClient.SubmitBlockAsync(child)
+	if err != nil {
+		t.Fatalf("Error submitting block: %+v", err)
+	}
+	if childSubmissionID != consensushashing.BlockHash(child).String() {
+		t.Fatalf("Unexpected submission ID %s", childSubmissionID)
+	}
+	waitForBlockSubmissionStatus(t, submitter, childSubmissionID, appmessage.BlockSubmissionStatusOrphan)
+
+	parentSubmissionID, err := submitter.rpcClient.SubmitBlockAsync(parent)
+	if err != nil {
+		t.Fatalf("Error submitting block: %+v", err)
+	}
+	waitForBlockSubmissionStatus(t, submitter, parentSubmissionID, appmessage.BlockSubmissionStatusAccepted)
+	waitForBlockSubmissionStatus(t, submitter, childSubmissionID, appmessage.BlockSubmissionStatusAccepted)
+
+	// A block that was already accepted is not processed again
+	resubmissionID, err := submitter.rpcClient.SubmitBlockAsync(parent)
+	if err != nil {
+		t.Fatalf("Error resubmitting block: %+v", err)
+	}
+	waitForBlockSubmissionStatus(t, submitter, resubmissionID, appmessage.BlockSubmissionStatusAccepted)
+
+	invalidBlock := mineNextBlock(t, miner)
+	invalidBlock.Transactions = invalidBlock.Transactions[:0]
+	invalidSubmissionID, err := submitter.rpcClient.SubmitBlockAsync(invalidBlock)
+	if err != nil {
+		t.Fatalf("Error submitting block: %+v", err)
+	}
+	waitForBlockSubmissionStatus(t, submitter, invalidSubmissionID, appmessage.BlockSubmissionStatusRejected)
+}
@@ -0,0 +1,84 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/blockheader"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+)
+
+func TestBlockTemplateProposal(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	for i := 0; i < 3; i++ {
+		mineNextBlock(t, harness)
+	}
+
+	blockTemplate, err := harness.rpcClient.GetBlockTemplate(harness.miningAddress, "integration")
+	if err != nil {
+		t.Fatalf("Error getting block template: %+v", err)
+	}
+	proposal, err := appmessage.RPCBlockToDomainBlock(blockTemplate.Block)
+	if err != nil {
+		t.Fatalf("Error converting block: %s", err)
+	}
+
+	// The template is proposed as is, without solving it
+	response, err := harness.rpcClient.ProposeBlockTemplate(proposal)
+	if err != nil {
+		t.Fatalf("Error proposing block template: %+v", err)
+	}
+	if !response.ProposalAccepted {
+		t.Fatalf("Expected the proposal to be accepted, but it was rejected: %s", response.ProposalRejectMessage)
+	}
+
+	_, err = harness.rpcClient.GetBlock(consensushashing.BlockHash(proposal).String(), false)
+	if err == nil {
+		t.Fatalf("Expected the proposal block not to be added to the DAG")
+	}
+
+	badUTXOCommitmentProposal := proposal.Clone()
+	header := badUTXOCommitmentProposal.Header
+	badUTXOCommitmentProposal.Header = blockheader.NewImmutableBlockHeader(header.Version(), header.Parents(),
+		header.HashMerkleRoot(), header.AcceptedIDMerkleRoot(), &externalapi.DomainHash{}, header.TimeInMilliseconds(),
+		header.Bits(), header.Nonce(), header.DAAScore(), header.BlueScore(), header.BlueWork(), header.PruningPoint())
+	expectProposalRejected(t, harness, badUTXOCommitmentProposal, ruleerrors.ErrBadUTXOCommitment)
+
+	badMerkleRootProposal := proposal.Clone()
+	badMerkleRootProposal.Transactions[0].Payload = append(badMerkleRootProposal.Transactions[0].Payload, 0)
+	expectProposalRejected(t, harness, badMerkleRootProposal, ruleerrors.ErrBadMerkleRoot)
+
+	// A rejected proposal isn't marked as invalid, so the original proposal is still accepted
+	response, err = harness.rpcClient.ProposeBlockTemplate(proposal)
+	if err != nil {
+		t.Fatalf("Error proposing block template: %+v", err)
+	}
+	if !response.ProposalAccepted {
+		t.Fatalf("Expected the proposal to be accepted, but it was rejected: %s", response.ProposalRejectMessage)
+	}
+}
+
+func expectProposalRejected(t *testing.T, harness *appHarness, proposal *externalapi.DomainBlock,
+	expectedReason ruleerrors.RuleError) {
+
+	response, err := harness.rpcClient.ProposeBlockTemplate(proposal)
+	if err != nil {
+		t.Fatalf("Error proposing block template: %+v", err)
+	}
+	if response.ProposalAccepted {
+		t.Fatalf("Expected the proposal to be rejected with %s, but it was accepted", expectedReason.Name())
+	}
+	if response.ProposalRejectReason != expectedReason.Name() {
+		t.Fatalf("Expected the proposal to be rejected with %s, but it was rejected with %s (%s)",
+			expectedReason.Name(), response.ProposalRejectReason, response.ProposalRejectMessage)
+	}
+}
@@ -22,6 +22,10 @@ const (
 	rpcAddress4 = "127.0.0.1:12348"
 	rpcAddress5 = "127.0.0.1:12349"
 
+	apiAddress1 = "127.0.0.1:12355"
+
+	restAddress1 = "127.0.0.1:12365"
+
 	miningAddress1           = "kaspasim:qqqqnc0pxg7qw3qkc7l6sge8kfhsvvyt7mkw8uamtndqup27ftnd6c769gn66"
 	miningAddress1PrivateKey = "0d81045b0deb2af36a25403c2154c87aa82d89dd337b575bae27ce7f5de53cee"
 
@@ -39,7 +43,28 @@ func setConfig(t *testing.T, harness *appHarness, protocolVersion uint32) {
 	harness.config.AppDir = randomDirectory(t)
 	harness.config.Listeners = []string{harness.p2pAddress}
 	harness.config.RPCListeners = []string{harness.rpcAddress}
+	if harness.apiAddress != "" {
+		harness.config.APIListeners = []string{harness.apiAddress}
+	}
+	if harness.restAddress != "" {
+		harness.config.RESTListeners = []string{harness.restAddress}
+	}
 	harness.config.UTXOIndex = harness.utxoIndex
+	harness.config.AddressIndex = harness.addressIndex
+	harness.config.DbMetrics = harness.databaseMetrics
+	harness.config.ImportBlocks = harness.importBlocks
+	harness.config.CompactAfterPruning = harness.compactAfterPruning
+	harness.config.RPCAuth = harness.rpcAuth
+	harness.config.RPCUnixSocket = harness.rpcUnixSocket
+	if harness.rpcTLS != nil {
+		harness.config.RPCCert = harness.rpcTLS.certFile
+		harness.config.RPCKey = harness.rpcTLS.keyFile
+		harness.config.RPCClientCA = harness.rpcTLS.clientCAFile
+		harness.config.RPCCertAuth = harness.rpcTLS.certAuth
+	}
+	harness.config.ValidatedSubnetworks = harness.validatedSubnetworks
+	harness.config.IndexedSubnetworks = harness.indexedSubnetworks
+	harness.config.RelayedSubnetworks = harness.relayedSubnetworks
 	harness.config.AllowSubmitBlockWhenNotSynced = true
 	if protocolVersion != 0 {
 		harness.config.ProtocolVersion = protocolVersion
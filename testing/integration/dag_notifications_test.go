@@ -0,0 +1,117 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+)
+
+func TestDAGNotifications(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	onNewTipChan := make(chan *appmessage.NewTipNotificationMessage, 10)
+	err := harness.rpcClient.RegisterForNewTipNotifications(func(notification *appmessage.NewTipNotificationMessage) {
+		onNewTipChan <- notification
+	})
+	if err != nil {
+		t.Fatalf("Failed to register for new tip notifications: %s", err)
+	}
+
+	onChainChangedChan := make(chan *appmessage.VirtualSelectedParentChainChangedNotificationMessage, 10)
+	err = harness.rpcClient.RegisterForVirtualSelectedParentChainChangedNotificationsWithAcceptanceData(
+		func(notification *appmessage.VirtualSelectedParentChainChangedNotificationMessage) {
+			onChainChangedChan <- notification
+		})
+	if err != nil {
+		t.Fatalf("Failed to register for virtual selected parent chain changed notifications: %s", err)
+	}
+
+	// Finality point advanced notifications require mining a whole finality
+	// window, so we only make sure that subscribing and unsubscribing work
+	err = harness.rpcClient.RegisterForFinalityPointAdvancedNotifications(
+		func(notification *appmessage.FinalityPointAdvancedNotificationMessage) {
+			t.Errorf("Unexpected finality point advanced notification: %+v", notification)
+		})
+	if err != nil {
+		t.Fatalf("Failed to register for finality point advanced notifications: %s", err)
+	}
+	err = harness.rpcClient.UnregisterFromFinalityPointAdvancedNotifications()
+	if err != nil {
+		t.Fatalf("Failed to unregister from finality point advanced notifications: %s", err)
+	}
+
+	firstBlock := mineNextBlock(t, harness)
+	firstBlockHash := consensushashing.BlockHash(firstBlock).String()
+
+	newTipNotification := <-onNewTipChan
+	if newTipNotification.TipHash != firstBlockHash {
+		t.Fatalf("Unexpected tip hash. Want: %s, got: %s", firstBlockHash, newTipNotification.TipHash)
+	}
+	if len(newTipNotification.TipHashes) != 1 || newTipNotification.TipHashes[0] != firstBlockHash {
+		t.Fatalf("Unexpected tip hashes %s", newTipNotification.TipHashes)
+	}
+	<-onChainChangedChan
+
+	// The second block merges the first one, so its acceptance data includes
+	// the first block's coinbase transaction
+	secondBlock := mineNextBlock(t, harness)
+	secondBlockHash := consensushashing.BlockHash(secondBlock).String()
+	<-onNewTipChan
+
+	chainChangedNotification := <-onChainChangedChan
+	if len(chainChangedNotification.AcceptedTransactionIDs) != 0 {
+		t.Fatalf("Unexpectedly got accepted transaction IDs although they were not requested")
+	}
+	if len(chainChangedNotification.AcceptanceData) != 1 {
+		t.Fatalf("Unexpected length of AcceptanceData. Want: %d, got: %d",
+			1, len(chainChangedNotification.AcceptanceData))
+	}
+	chainBlockAcceptanceData := chainChangedNotification.AcceptanceData[0]
+	if chainBlockAcceptanceData.AcceptingBlockHash != secondBlockHash {
+		t.Fatalf("Unexpected accepting block hash. Want: %s, got: %s",
+			secondBlockHash, chainBlockAcceptanceData.AcceptingBlockHash)
+	}
+	if len(chainBlockAcceptanceData.MergedBlocks) != 1 {
+		t.Fatalf("Unexpected amount of merged blocks. Want: %d, got: %d",
+			1, len(chainBlockAcceptanceData.MergedBlocks))
+	}
+	mergedBlock := chainBlockAcceptanceData.MergedBlocks[0]
+	if mergedBlock.BlockHash != firstBlockHash {
+		t.Fatalf("Unexpected merged block hash. Want: %s, got: %s", firstBlockHash, mergedBlock.BlockHash)
+	}
+	firstBlockCoinbaseID := consensushashing.TransactionID(
+		firstBlock.Transactions[transactionhelper.CoinbaseTransactionIndex]).String()
+	if len(mergedBlock.AcceptedTransactionIDs) != 1 || mergedBlock.AcceptedTransactionIDs[0] != firstBlockCoinbaseID {
+		t.Fatalf("Unexpected accepted transaction IDs %s", mergedBlock.AcceptedTransactionIDs)
+	}
+	if len(mergedBlock.RejectedTransactionIDs) != 0 {
+		t.Fatalf("Unexpected rejected transaction IDs %s", mergedBlock.RejectedTransactionIDs)
+	}
+
+	err = harness.rpcClient.UnregisterFromNewTipNotifications()
+	if err != nil {
+		t.Fatalf("Failed to unregister from new tip notifications: %s", err)
+	}
+	err = harness.rpcClient.UnregisterFromVirtualSelectedParentChainChangedNotifications()
+	if err != nil {
+		t.Fatalf("Failed to unregister from virtual selected parent chain changed notifications: %s", err)
+	}
+
+	mineNextBlock(t, harness)
+	select {
+	case notification := <-onNewTipChan:
+		t.Fatalf("Unexpected new tip notification after unregistering: %+v", notification)
+	case notification := <-onChainChangedChan:
+		t.Fatalf("Unexpected chain changed notification after unregistering: %+v", notification)
+	case <-time.After(time.Second):
+	}
+}
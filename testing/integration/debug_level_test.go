@@ -0,0 +1,57 @@
+package integration
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+func TestDebugLevel(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	findLevel := func(response *appmessage.DebugLevelResponseMessage, subsystem string) string {
+		for _, subsystemLogLevel := range response.SubsystemLogLevels {
+			if subsystemLogLevel.Subsystem == subsystem {
+				return subsystemLogLevel.Level
+			}
+		}
+		t.Fatalf("Subsystem %s is missing from the response", subsystem)
+		return ""
+	}
+
+	response, err := harness.rpcClient.DebugLevel("")
+	if err != nil {
+		t.Fatalf("Error getting the log levels: %s", err)
+	}
+	originalLevel := findLevel(response, "PROT")
+	otherLevel := findLevel(response, "RPCS")
+	defer func() {
+		_, err := harness.rpcClient.DebugLevel("PROT=" + originalLevel)
+		if err != nil {
+			t.Fatalf("Error restoring the log level: %s", err)
+		}
+	}()
+
+	response, err = harness.rpcClient.DebugLevel("PROT=critical")
+	if err != nil {
+		t.Fatalf("Error setting the log level: %s", err)
+	}
+	if findLevel(response, "PROT") != "critical" {
+		t.Fatalf("Unexpected PROT level %s", findLevel(response, "PROT"))
+	}
+	if findLevel(response, "RPCS") != otherLevel {
+		t.Fatalf("The level of RPCS changed to %s", findLevel(response, "RPCS"))
+	}
+
+	_, err = harness.rpcClient.DebugLevel("NOSUCHSUBSYSTEM=debug")
+	if err == nil || !strings.Contains(err.Error(), "NOSUCHSUBSYSTEM") {
+		t.Fatalf("Expected an unknown subsystem to be rejected, but got: %v", err)
+	}
+}
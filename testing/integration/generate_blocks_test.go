@@ -0,0 +1,66 @@
+package integration
+
+import (
+	"testing"
+)
+
+func TestGenerateBlocks(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	_, err := harness.rpcClient.GenerateBlocks(harness.miningAddress, 0, nil, 0)
+	if err == nil {
+		t.Fatalf("Expected an error when generating 0 blocks")
+	}
+
+	chainResponse, err := harness.rpcClient.GenerateBlocks(harness.miningAddress, 3, nil, 0)
+	if err != nil {
+		t.Fatalf("Error generating blocks: %+v", err)
+	}
+	if len(chainResponse.BlockHashes) != 3 {
+		t.Fatalf("Expected 3 generated blocks, but got %d", len(chainResponse.BlockHashes))
+	}
+
+	// Generate a side chain over the first generated block, with explicit timestamps
+	forkPoint := chainResponse.BlockHashes[0]
+	forkPointResponse, err := harness.rpcClient.GetBlock(forkPoint, false)
+	if err != nil {
+		t.Fatalf("Error getting block: %+v", err)
+	}
+	timestamp := forkPointResponse.Block.Header.Timestamp + 1
+	sideChainResponse, err := harness.rpcClient.GenerateBlocks(harness.miningAddress, 2, []string{forkPoint}, timestamp)
+	if err != nil {
+		t.Fatalf("Error generating blocks: %+v", err)
+	}
+	if len(sideChainResponse.BlockHashes) != 2 {
+		t.Fatalf("Expected 2 generated blocks, but got %d", len(sideChainResponse.BlockHashes))
+	}
+
+	expectedParent := forkPoint
+	for i, blockHash := range sideChainResponse.BlockHashes {
+		blockResponse, err := harness.rpcClient.GetBlock(blockHash, false)
+		if err != nil {
+			t.Fatalf("Error getting block: %+v", err)
+		}
+		header := blockResponse.Block.Header
+		parents := header.Parents[0].ParentHashes
+		if len(parents) != 1 || parents[0] != expectedParent {
+			t.Fatalf("Expected generated block %d to have %s as its only parent, but got %s", i, expectedParent, parents)
+		}
+		expectedTimestamp := timestamp + int64(i)*harness.config.NetParams().TargetTimePerBlock.Milliseconds()
+		if header.Timestamp != expectedTimestamp {
+			t.Fatalf("Expected generated block %d to have timestamp %d, but got %d", i, expectedTimestamp, header.Timestamp)
+		}
+		expectedParent = blockHash
+	}
+
+	_, err = harness.rpcClient.GenerateBlocks(harness.miningAddress, 1, []string{"unknown"}, 0)
+	if err == nil {
+		t.Fatalf("Expected an error when generating a block over an unknown parent")
+	}
+}
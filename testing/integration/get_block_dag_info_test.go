@@ -0,0 +1,54 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+)
+
+func TestGetBlockDAGInfo(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	block := mineNextBlock(t, harness)
+	blockHash := consensushashing.BlockHash(block).String()
+
+	dagInfo, err := harness.rpcClient.GetBlockDAGInfo()
+	if err != nil {
+		t.Fatalf("Error from GetBlockDAGInfo: %s", err)
+	}
+	if dagInfo.VirtualSelectedParentHash != blockHash {
+		t.Fatalf("Unexpected virtual selected parent. Want: %s, got: %s",
+			blockHash, dagInfo.VirtualSelectedParentHash)
+	}
+	if dagInfo.HeadersSelectedTipHash != blockHash {
+		t.Fatalf("Unexpected headers selected tip. Want: %s, got: %s", blockHash, dagInfo.HeadersSelectedTipHash)
+	}
+	if dagInfo.VirtualSelectedParentTimestamp != block.Header.TimeInMilliseconds() {
+		t.Fatalf("Unexpected virtual selected parent timestamp. Want: %d, got: %d",
+			block.Header.TimeInMilliseconds(), dagInfo.VirtualSelectedParentTimestamp)
+	}
+	if dagInfo.VirtualBlueScore != block.Header.BlueScore()+1 {
+		t.Fatalf("Unexpected virtual blue score. Want: %d, got: %d",
+			block.Header.BlueScore()+1, dagInfo.VirtualBlueScore)
+	}
+	if dagInfo.FinalityPointHash == "" || dagInfo.PruningPointHash == "" {
+		t.Fatalf("Expected the finality and pruning points to be set")
+	}
+	// The headers selected tip is the virtual selected parent, so the sync
+	// is complete, even though there are no peers to be synced with
+	if dagInfo.SyncProgress != 1 {
+		t.Fatalf("Unexpected sync progress. Want: 1, got: %f", dagInfo.SyncProgress)
+	}
+	if dagInfo.IsSynced || dagInfo.ConnectedPeerCount != 0 {
+		t.Fatalf("Expected a node without peers not to be synced")
+	}
+	if dagInfo.IsArchival {
+		t.Fatalf("Expected the node not to be archival")
+	}
+}
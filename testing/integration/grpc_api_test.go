@@ -0,0 +1,99 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGRPCAPI(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		apiAddress:              apiAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	connection, err := grpc.DialContext(ctx, apiAddress1, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		t.Fatalf("Error connecting to the gRPC API: %s", err)
+	}
+	defer connection.Close()
+	client := protowire.NewAPIClient(connection)
+
+	getInfoResponse, err := client.GetInfo(ctx, &protowire.GetInfoRequestMessage{})
+	if err != nil {
+		t.Fatalf("Error from GetInfo: %s", err)
+	}
+	if getInfoResponse.Error != nil {
+		t.Fatalf("Unexpected error in GetInfo response: %s", getInfoResponse.Error.Message)
+	}
+
+	// Errors of requests are reported in their responses, exactly like in the RPC message stream
+	getBlockResponse, err := client.GetBlock(ctx, &protowire.GetBlockRequestMessage{Hash: "invalid"})
+	if err != nil {
+		t.Fatalf("Error from GetBlock: %s", err)
+	}
+	if getBlockResponse.Error == nil {
+		t.Fatalf("Expected an error in the response of GetBlock with an invalid hash")
+	}
+
+	// Errors of subscriptions end the stream
+	utxosChangedStream, err := client.NotifyUtxosChanged(ctx, &protowire.NotifyUtxosChangedRequestMessage{})
+	if err != nil {
+		t.Fatalf("Error from NotifyUtxosChanged: %s", err)
+	}
+	_, err = utxosChangedStream.Recv()
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("Expected a FailedPrecondition error when subscribing to UTXO changes without "+
+			"a UTXO index, but got: %v", err)
+	}
+
+	newTipCtx, cancelNewTip := context.WithCancel(ctx)
+	newTipStream, err := client.NotifyNewTip(newTipCtx, &protowire.NotifyNewTipRequestMessage{})
+	if err != nil {
+		t.Fatalf("Error from NotifyNewTip: %s", err)
+	}
+	// The header of a subscription is sent once it's in effect
+	_, err = newTipStream.Header()
+	if err != nil {
+		t.Fatalf("Error waiting for the new tip subscription: %s", err)
+	}
+
+	block := mineNextBlock(t, harness)
+	blockHash := consensushashing.BlockHash(block).String()
+
+	newTipNotification, err := newTipStream.Recv()
+	if err != nil {
+		t.Fatalf("Error receiving a new tip notification: %s", err)
+	}
+	if newTipNotification.TipHash != blockHash {
+		t.Fatalf("Unexpected tip hash. Want: %s, got: %s", blockHash, newTipNotification.TipHash)
+	}
+
+	cancelNewTip()
+	_, err = newTipStream.Recv()
+	if status.Code(err) != codes.Canceled {
+		t.Fatalf("Expected the new tip stream to be canceled, but got: %v", err)
+	}
+
+	getBlockResponse, err = client.GetBlock(ctx, &protowire.GetBlockRequestMessage{Hash: blockHash, IncludeTransactions: true})
+	if err != nil {
+		t.Fatalf("Error from GetBlock: %s", err)
+	}
+	if getBlockResponse.Error != nil {
+		t.Fatalf("Unexpected error in GetBlock response: %s", getBlockResponse.Error.Message)
+	}
+	if getBlockResponse.Block.VerboseData.Hash != blockHash {
+		t.Fatalf("Unexpected block hash. Want: %s, got: %s", blockHash, getBlockResponse.Block.VerboseData.Hash)
+	}
+}
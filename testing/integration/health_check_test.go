@@ -0,0 +1,48 @@
+package integration
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHealthCheck(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		restAddress:             restAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	mineNextBlock(t, harness)
+
+	// The harness isn't connected to any peers, so it's healthy but not ready
+	healthCheck, err := harness.rpcClient.HealthCheck()
+	if err != nil {
+		t.Fatalf("Error from HealthCheck: %s", err)
+	}
+	if !healthCheck.IsHealthy || !healthCheck.IsDatabaseResponsive {
+		t.Fatalf("Expected the node to be healthy, but got failed checks: %s", healthCheck.FailedChecks)
+	}
+	if !healthCheck.IsSynced {
+		t.Fatalf("Expected the node to be synced right after mining a block, but its sync age is %dms",
+			healthCheck.SyncAge)
+	}
+	if healthCheck.IsReady {
+		t.Fatalf("Expected the node not to be ready without peers")
+	}
+	if healthCheck.ConnectedPeerCount != 0 || len(healthCheck.FailedChecks) != 1 ||
+		!strings.Contains(healthCheck.FailedChecks[0], "peers") {
+
+		t.Fatalf("Expected only the peer count check to fail, but got: %s", healthCheck.FailedChecks)
+	}
+
+	healthResponse, _ := restGet(t, "/health", nil)
+	expectStatus(t, healthResponse, http.StatusOK)
+	expectCacheControl(t, healthResponse, "no-cache")
+
+	readyResponse, _ := restGet(t, "/ready", nil)
+	expectStatus(t, readyResponse, http.StatusServiceUnavailable)
+}
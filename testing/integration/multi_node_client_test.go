@@ -0,0 +1,132 @@
+package integration
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/mining"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient"
+)
+
+func TestMultiNodeClient(t *testing.T) {
+	harness1, teardown1 := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	isHarness1TornDown := false
+	defer func() {
+		if !isHarness1TornDown {
+			teardown1()
+		}
+	}()
+	harness2, teardown2 := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress2,
+		rpcAddress:              rpcAddress2,
+		miningAddress:           miningAddress2,
+		miningAddressPrivateKey: miningAddress2PrivateKey,
+	})
+	defer teardown2()
+
+	client, err := rpcclient.NewMultiNodeClient([]string{rpcAddress1, rpcAddress2})
+	if err != nil {
+		t.Fatalf("Error creating multi-node client: %s", err)
+	}
+	defer client.Close()
+	client.SetHealthCheckInterval(100 * time.Millisecond)
+
+	if len(client.HealthyNodes()) != 2 {
+		t.Fatalf("Unexpected healthy nodes. Want both nodes, got: %s", client.HealthyNodes())
+	}
+	if client.PrimaryNode() != rpcAddress1 {
+		t.Fatalf("Unexpected primary node. Want: %s, got: %s", rpcAddress1, client.PrimaryNode())
+	}
+
+	// Reads are spread between the healthy nodes
+	readAddresses := make(map[string]struct{})
+	for i := 0; i < 2; i++ {
+		err := client.Read(func(nodeClient *rpcclient.RPCClient) error {
+			_, err := nodeClient.GetInfo()
+			if err != nil {
+				return err
+			}
+			readAddresses[nodeClient.Address()] = struct{}{}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Error reading: %s", err)
+		}
+	}
+	if len(readAddresses) != 2 {
+		t.Fatalf("Expected reads to be sent to both nodes, but they were sent to: %v", readAddresses)
+	}
+
+	// Broadcasts are sent to all the healthy nodes
+	blockTemplate, err := harness2.rpcClient.GetBlockTemplate(harness2.miningAddress, "")
+	if err != nil {
+		t.Fatalf("Error getting block template: %s", err)
+	}
+	block, err := appmessage.RPCBlockToDomainBlock(blockTemplate.Block)
+	if err != nil {
+		t.Fatalf("Error converting block: %s", err)
+	}
+	mining.SolveBlock(block, rand.New(rand.NewSource(time.Now().UnixNano())))
+	_, err = client.SubmitBlock(block)
+	if err != nil {
+		t.Fatalf("Error submitting block: %s", err)
+	}
+	for _, harness := range []*appHarness{harness1, harness2} {
+		_, err := harness.rpcClient.GetBlock(consensushashing.BlockHash(block).String(), false)
+		if err != nil {
+			t.Fatalf("Expected the broadcast block to be submitted to %s: %s", harness.rpcAddress, err)
+		}
+	}
+
+	onBlockAddedChan := make(chan *appmessage.BlockAddedNotificationMessage, 10)
+	err = client.Subscribe(func(nodeClient *rpcclient.RPCClient) error {
+		return nodeClient.RegisterForBlockAddedNotifications(func(notification *appmessage.BlockAddedNotificationMessage) {
+			onBlockAddedChan <- notification
+		})
+	})
+	if err != nil {
+		t.Fatalf("Error subscribing: %s", err)
+	}
+	onFailoverChan := make(chan string, 1)
+	client.SetOnFailoverHandler(func(rpcAddress string) {
+		onFailoverChan <- rpcAddress
+	})
+	expectBlockAddedNotifications(t, onBlockAddedChan,
+		[]string{consensushashing.BlockHash(mineNextBlock(t, harness1)).String()}, 0)
+
+	// Once the primary node dies, the subscriptions are moved to the other node
+	teardown1()
+	isHarness1TornDown = true
+	select {
+	case rpcAddress := <-onFailoverChan:
+		if rpcAddress != rpcAddress2 {
+			t.Fatalf("Unexpected new primary node. Want: %s, got: %s", rpcAddress2, rpcAddress)
+		}
+	case <-time.After(defaultTimeout):
+		t.Fatalf("Timed out waiting for the failover")
+	}
+	if len(client.HealthyNodes()) != 1 || client.HealthyNodes()[0] != rpcAddress2 {
+		t.Fatalf("Unexpected healthy nodes. Want: [%s], got: %s", rpcAddress2, client.HealthyNodes())
+	}
+	expectBlockAddedNotifications(t, onBlockAddedChan,
+		[]string{consensushashing.BlockHash(mineNextBlock(t, harness2)).String()}, 0)
+
+	err = client.Read(func(nodeClient *rpcclient.RPCClient) error {
+		if nodeClient.Address() != rpcAddress2 {
+			t.Fatalf("Unexpected read from %s", nodeClient.Address())
+		}
+		_, err := nodeClient.GetInfo()
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Error reading: %s", err)
+	}
+}
@@ -0,0 +1,100 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+)
+
+func TestNewTransactionsNotifications(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	// skip the first block because it's paying to genesis script
+	mineNextBlock(t, harness)
+	// use the second block to get money to pay with
+	secondBlock := mineNextBlock(t, harness)
+	// Mine BlockCoinbaseMaturity more blocks for our money to mature
+	for i := uint64(0); i < harness.config.ActiveNetParams.BlockCoinbaseMaturity; i++ {
+		mineNextBlock(t, harness)
+	}
+
+	// The transaction pays miningAddress2 the whole coinbase, minus a fee
+	coinbase := secondBlock.Transactions[transactionhelper.CoinbaseTransactionIndex]
+	msgTx := generateTx(t, coinbase, harness, &appHarness{miningAddress: miningAddress2})
+	domainTransaction := appmessage.MsgTxToDomainTransaction(msgTx)
+	transactionID := consensushashing.TransactionID(domainTransaction).String()
+	amount := msgTx.TxOut[0].Value
+
+	matchingChan := registerForNewTransactions(t, harness.rpcClient, []string{miningAddress2}, "", amount)
+	notMatchingFilters := []struct {
+		name          string
+		addresses     []string
+		subnetworkID  string
+		minimumAmount uint64
+	}{
+		{name: "other address", addresses: []string{miningAddress3}},
+		{name: "other subnetwork", subnetworkID: "0100000000000000000000000000000000000000"},
+		{name: "higher amount", addresses: []string{miningAddress2}, minimumAmount: amount + 1},
+	}
+	notMatchingChans := make([]chan *appmessage.NewTransactionsNotificationMessage, len(notMatchingFilters))
+	for i, filter := range notMatchingFilters {
+		rpcClient, err := newTestRPCClient(rpcAddress1)
+		if err != nil {
+			t.Fatalf("Error creating RPC client: %s", err)
+		}
+		defer rpcClient.Close()
+		notMatchingChans[i] = registerForNewTransactions(t, rpcClient,
+			filter.addresses, filter.subnetworkID, filter.minimumAmount)
+	}
+
+	rpcTransaction := appmessage.DomainTransactionToRPCTransaction(domainTransaction)
+	_, err := harness.rpcClient.SubmitTransaction(rpcTransaction, false)
+	if err != nil {
+		t.Fatalf("Error submitting transaction: %s", err)
+	}
+
+	select {
+	case notification := <-matchingChan:
+		if len(notification.Transactions) != 1 ||
+			notification.Transactions[0].VerboseData.TransactionID != transactionID {
+
+			t.Fatalf("Expected a notification about transaction %s, but got: %+v", transactionID, notification.Transactions)
+		}
+	case <-time.After(defaultTimeout):
+		t.Fatalf("Timeout waiting for a new transactions notification")
+	}
+
+	// Notifications to all listeners are sent together, so by now
+	// the listeners whose filters don't match would've gotten theirs
+	const notificationGracePeriod = time.Second
+	for i, notMatchingChan := range notMatchingChans {
+		select {
+		case notification := <-notMatchingChan:
+			t.Fatalf("%s: unexpected notification: %+v", notMatchingFilters[i].name, notification.Transactions)
+		case <-time.After(notificationGracePeriod):
+		}
+	}
+}
+
+func registerForNewTransactions(t *testing.T, rpcClient *testRPCClient, addresses []string, subnetworkID string,
+	minimumAmount uint64) chan *appmessage.NewTransactionsNotificationMessage {
+
+	notificationChan := make(chan *appmessage.NewTransactionsNotificationMessage, 1)
+	err := rpcClient.RegisterForNewTransactionsNotifications(addresses, subnetworkID, minimumAmount,
+		func(notification *appmessage.NewTransactionsNotificationMessage) {
+			notificationChan <- notification
+		})
+	if err != nil {
+		t.Fatalf("Error registering for new transactions notifications: %s", err)
+	}
+	return notificationChan
+}
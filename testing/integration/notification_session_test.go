@@ -0,0 +1,109 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+)
+
+func TestNotificationSession(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	mineBlocks := func(amount int) []string {
+		blockHashes := make([]string, amount)
+		for i := range blockHashes {
+			blockHashes[i] = consensushashing.BlockHash(mineNextBlock(t, harness)).String()
+		}
+		return blockHashes
+	}
+
+	client, err := newTestRPCClient(rpcAddress1)
+	if err != nil {
+		t.Fatalf("Error creating RPC client: %s", err)
+	}
+	onBlockAddedChan := registerForBlockAddedNotifications(t, client)
+	session, err := client.StartNotificationSession()
+	if err != nil {
+		t.Fatalf("Error starting notification session: %s", err)
+	}
+	expectBlockAddedNotifications(t, onBlockAddedChan, mineBlocks(2), 1)
+
+	// The notifications of blocks mined while the client is disconnected
+	// are kept in its session
+	client.Close()
+	missedBlockHashes := mineBlocks(3)
+
+	resumedClient, err := newTestRPCClient(rpcAddress1)
+	if err != nil {
+		t.Fatalf("Error creating RPC client: %s", err)
+	}
+	defer resumedClient.Close()
+	resumedOnBlockAddedChan := registerForBlockAddedNotifications(t, resumedClient)
+
+	_, err = resumedClient.ResumeNotificationSession("unknown", 0)
+	if err == nil {
+		t.Fatalf("Expected resuming an unknown session to fail")
+	}
+	_, err = resumedClient.ResumeNotificationSession(session.SessionID, 6)
+	if err == nil {
+		t.Fatalf("Expected resuming from a sequence that was never sent to fail")
+	}
+
+	resumeResponse, err := resumedClient.ResumeNotificationSession(session.SessionID, 2)
+	if err != nil {
+		t.Fatalf("Error resuming notification session: %s", err)
+	}
+	if resumeResponse.ReplayedCount != 3 {
+		t.Fatalf("Unexpected amount of replayed notifications. Want: 3, got: %d", resumeResponse.ReplayedCount)
+	}
+	expectBlockAddedNotifications(t, resumedOnBlockAddedChan, missedBlockHashes, 3)
+
+	// The session carries on over the new connection, and its notifications
+	// are sent only once
+	expectBlockAddedNotifications(t, resumedOnBlockAddedChan, mineBlocks(1), 6)
+	select {
+	case notification := <-resumedOnBlockAddedChan:
+		t.Fatalf("Unexpected notification with sequence %d", notification.NotificationSequence())
+	case <-time.After(time.Second):
+	}
+}
+
+func registerForBlockAddedNotifications(t *testing.T, client *testRPCClient) chan *appmessage.BlockAddedNotificationMessage {
+	onBlockAddedChan := make(chan *appmessage.BlockAddedNotificationMessage, 10)
+	err := client.RegisterForBlockAddedNotifications(func(notification *appmessage.BlockAddedNotificationMessage) {
+		onBlockAddedChan <- notification
+	})
+	if err != nil {
+		t.Fatalf("Error from RegisterForBlockAddedNotifications: %s", err)
+	}
+	return onBlockAddedChan
+}
+
+func expectBlockAddedNotifications(t *testing.T, onBlockAddedChan chan *appmessage.BlockAddedNotificationMessage,
+	blockHashes []string, firstSequence uint64) {
+
+	for i, blockHash := range blockHashes {
+		var notification *appmessage.BlockAddedNotificationMessage
+		select {
+		case notification = <-onBlockAddedChan:
+		case <-time.After(defaultTimeout):
+			t.Fatalf("Timed out waiting for the notification of block %s", blockHash)
+		}
+		if notification.Block.VerboseData.Hash != blockHash {
+			t.Fatalf("Unexpected block in notification %d. Want: %s, got: %s",
+				i, blockHash, notification.Block.VerboseData.Hash)
+		}
+		if notification.NotificationSequence() != firstSequence+uint64(i) {
+			t.Fatalf("Unexpected sequence of notification %d. Want: %d, got: %d",
+				i, firstSequence+uint64(i), notification.NotificationSequence())
+		}
+	}
+}
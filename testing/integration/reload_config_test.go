@@ -0,0 +1,87 @@
+package integration
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcauth"
+)
+
+func TestReloadConfig(t *testing.T) {
+	const (
+		adminPassword    = "admin password"
+		explorerPassword = "explorer password"
+	)
+	adminRPCAuth, err := rpcauth.Generate("admin", adminPassword, nil)
+	if err != nil {
+		t.Fatalf("Error generating rpcauth: %s", err)
+	}
+	explorerRPCAuth, err := rpcauth.Generate("explorer", explorerPassword, []string{"getInfo"})
+	if err != nil {
+		t.Fatalf("Error generating rpcauth: %s", err)
+	}
+
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		rpcAuth:                 []string{adminRPCAuth},
+		rpcUser:                 "admin",
+		rpcPassword:             adminPassword,
+	})
+	defer teardown()
+
+	configFile := filepath.Join(t.TempDir(), "kaspad.conf")
+	harness.config.ConfigFile = configFile
+	writeConfigFile := func(lines ...string) {
+		content := "[Application Options]\n" + strings.Join(lines, "\n") + "\n"
+		err := ioutil.WriteFile(configFile, []byte(content), 0600)
+		if err != nil {
+			t.Fatalf("WriteFile: %s", err)
+		}
+	}
+
+	_, err = newTestRPCClientWithCredentials(rpcAddress1, "explorer", explorerPassword)
+	if err == nil {
+		t.Fatalf("Expected connecting as explorer to fail before the config is reloaded")
+	}
+
+	writeConfigFile("rpcauth="+adminRPCAuth, "rpcauth="+explorerRPCAuth)
+	response, err := harness.rpcClient.ReloadConfig()
+	if err != nil {
+		t.Fatalf("Error from ReloadConfig: %s", err)
+	}
+	// The harness' config doesn't go through the same parsing as the
+	// config file, so other options may be reported as changed as well
+	isRPCAuthChanged := false
+	for _, changedOption := range response.ChangedOptions {
+		isRPCAuthChanged = isRPCAuthChanged || changedOption == "rpcauth"
+	}
+	if !isRPCAuthChanged {
+		t.Fatalf("Unexpected changed options %v", response.ChangedOptions)
+	}
+	explorerClient, err := newTestRPCClientWithCredentials(rpcAddress1, "explorer", explorerPassword)
+	if err != nil {
+		t.Fatalf("Error connecting as explorer: %s", err)
+	}
+	defer explorerClient.Close()
+	_, err = explorerClient.GetInfo()
+	if err != nil {
+		t.Fatalf("Error from GetInfo as explorer: %s", err)
+	}
+
+	// An invalid config keeps the current one, including its valid options
+	writeConfigFile("rpcauth="+adminRPCAuth, "banduration=1ms")
+	_, err = harness.rpcClient.ReloadConfig()
+	if err == nil || !strings.Contains(err.Error(), "banduration") {
+		t.Fatalf("Expected ReloadConfig to reject the ban duration, but got: %v", err)
+	}
+	secondExplorerClient, err := newTestRPCClientWithCredentials(rpcAddress1, "explorer", explorerPassword)
+	if err != nil {
+		t.Fatalf("Error connecting as explorer after a failed reload: %s", err)
+	}
+	secondExplorerClient.Close()
+}
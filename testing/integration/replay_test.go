@@ -0,0 +1,59 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/testing/replay"
+)
+
+func TestReplayRecordFromRPC(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	const blockCount = 20
+	for i := 0; i < blockCount; i++ {
+		mineNextBlock(t, harness)
+	}
+
+	consensusConfig := &consensus.Config{Params: *harness.config.ActiveNetParams}
+	fixture, err := replay.RecordFromRPC(harness.rpcClient.RPCClient, consensusConfig, 0)
+	if err != nil {
+		t.Fatalf("Error recording the fixture: %+v", err)
+	}
+	if len(fixture.Blocks) != blockCount {
+		t.Fatalf("Unexpected amount of recorded blocks. Want: %d, got: %d", blockCount, len(fixture.Blocks))
+	}
+
+	dagInfo, err := harness.rpcClient.GetBlockDAGInfo()
+	if err != nil {
+		t.Fatalf("Error getting the DAG info: %s", err)
+	}
+	if fixture.Expected.VirtualSelectedParent.String() != dagInfo.TipHashes[0] {
+		t.Fatalf("Unexpected virtual selected parent of the replay. Want: %s, got: %s",
+			dagInfo.TipHashes[0], fixture.Expected.VirtualSelectedParent)
+	}
+	if fixture.Expected.VirtualDAAScore != dagInfo.VirtualDAAScore {
+		t.Fatalf("Unexpected virtual DAA score of the replay. Want: %d, got: %d",
+			dagInfo.VirtualDAAScore, fixture.Expected.VirtualDAAScore)
+	}
+
+	_, err = replay.Replay(fixture, consensusConfig)
+	if err != nil {
+		t.Fatalf("Error replaying the fixture: %+v", err)
+	}
+
+	const maxBlocks = 5
+	partialFixture, err := replay.RecordFromRPC(harness.rpcClient.RPCClient, consensusConfig, maxBlocks)
+	if err != nil {
+		t.Fatalf("Error recording the partial fixture: %+v", err)
+	}
+	if len(partialFixture.Blocks) != maxBlocks {
+		t.Fatalf("Unexpected amount of recorded blocks. Want: %d, got: %d", maxBlocks, len(partialFixture.Blocks))
+	}
+}
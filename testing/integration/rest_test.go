@@ -0,0 +1,172 @@
+package integration
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestREST(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		restAddress:             restAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	block := mineNextBlock(t, harness)
+	blockHash := consensushashing.BlockHash(block).String()
+	coinbaseID := consensushashing.TransactionID(block.Transactions[transactionhelper.CoinbaseTransactionIndex]).String()
+
+	// The selected tip may change at any moment, so it must be revalidated
+	tipResponse, tipBody := restGet(t, "/tip", nil)
+	expectStatus(t, tipResponse, http.StatusOK)
+	expectCacheControl(t, tipResponse, "no-cache")
+	var tip struct {
+		SelectedTipHash string `json:"selectedTipHash"`
+	}
+	err := json.Unmarshal(tipBody, &tip)
+	if err != nil {
+		t.Fatalf("Error parsing the tip: %s", err)
+	}
+	if tip.SelectedTipHash != blockHash {
+		t.Fatalf("Unexpected selected tip hash. Want: %s, got: %s", blockHash, tip.SelectedTipHash)
+	}
+	notModifiedResponse, _ := restGet(t, "/tip", map[string]string{"If-None-Match": tipResponse.Header.Get("ETag")})
+	expectStatus(t, notModifiedResponse, http.StatusNotModified)
+
+	// Blocks requested by their hash never change
+	blockResponse, blockBody := restGet(t, "/block/"+blockHash+".json", nil)
+	expectStatus(t, blockResponse, http.StatusOK)
+	expectCacheControl(t, blockResponse, "public, max-age=31536000, immutable")
+	var rpcBlock protowire.RpcBlock
+	err = protojson.Unmarshal(blockBody, &rpcBlock)
+	if err != nil {
+		t.Fatalf("Error parsing the block: %s", err)
+	}
+	if rpcBlock.VerboseData.Hash != blockHash {
+		t.Fatalf("Unexpected block hash. Want: %s, got: %s", blockHash, rpcBlock.VerboseData.Hash)
+	}
+
+	// Large responses are gzipped for clients that accept it, under an ETag of their own
+	gzippedBlockResponse, gzippedBlockBody := restGet(t, "/block/"+blockHash+".json",
+		map[string]string{"Accept-Encoding": "deflate, gzip;q=0.8"})
+	expectStatus(t, gzippedBlockResponse, http.StatusOK)
+	if gzippedBlockResponse.Header.Get("Content-Encoding") != "gzip" {
+		t.Fatalf("Expected a gzipped block, but got Content-Encoding: %s",
+			gzippedBlockResponse.Header.Get("Content-Encoding"))
+	}
+	identityBlockResponse, _ := restGet(t, "/block/"+blockHash+".json", map[string]string{"Accept-Encoding": "identity"})
+	if identityBlockResponse.Header.Get("Content-Encoding") != "" {
+		t.Fatalf("Expected a block that isn't gzipped for the identity encoding")
+	}
+	if gzippedBlockResponse.Header.Get("ETag") == identityBlockResponse.Header.Get("ETag") {
+		t.Fatalf("Expected the gzipped block to have a different ETag")
+	}
+	gzipReader, err := gzip.NewReader(bytes.NewReader(gzippedBlockBody))
+	if err != nil {
+		t.Fatalf("Error reading the gzipped block: %s", err)
+	}
+	gunzippedBlockBody, err := io.ReadAll(gzipReader)
+	if err != nil {
+		t.Fatalf("Error gunzipping the block: %s", err)
+	}
+	if !bytes.Equal(gunzippedBlockBody, blockBody) {
+		t.Fatalf("The gunzipped block is different from the identity block")
+	}
+	refusedGzipResponse, _ := restGet(t, "/block/"+blockHash+".json", map[string]string{"Accept-Encoding": "gzip;q=0, *"})
+	if refusedGzipResponse.Header.Get("Content-Encoding") != "" {
+		t.Fatalf("Expected a block that isn't gzipped when gzip is refused")
+	}
+
+	rawBlockResponse, rawBlockBody := restGet(t, "/block/"+blockHash+".bin", nil)
+	expectStatus(t, rawBlockResponse, http.StatusOK)
+	var blockMessage protowire.BlockMessage
+	err = proto.Unmarshal(rawBlockBody, &blockMessage)
+	if err != nil {
+		t.Fatalf("Error parsing the raw block: %s", err)
+	}
+	appMessage, err := (&protowire.KaspadMessage{Payload: &protowire.KaspadMessage_Block{Block: &blockMessage}}).ToAppMessage()
+	if err != nil {
+		t.Fatalf("Error converting the raw block: %s", err)
+	}
+	rawBlockHash := consensushashing.BlockHash(appmessage.MsgBlockToDomainBlock(appMessage.(*appmessage.MsgBlock))).String()
+	if rawBlockHash != blockHash {
+		t.Fatalf("Unexpected raw block hash. Want: %s, got: %s", blockHash, rawBlockHash)
+	}
+
+	transactionResponse, _ := restGet(t, "/tx/"+coinbaseID+"?block="+blockHash, nil)
+	expectStatus(t, transactionResponse, http.StatusOK)
+	expectCacheControl(t, transactionResponse, "public, max-age=31536000, immutable")
+
+	// Without a block, transactions are only looked up in the mempool
+	mempoolTransactionResponse, _ := restGet(t, "/tx/"+coinbaseID, nil)
+	expectStatus(t, mempoolTransactionResponse, http.StatusNotFound)
+
+	headersResponse, headersBody := restGet(t, "/headers?start="+blockHash+"&count=1", nil)
+	expectStatus(t, headersResponse, http.StatusOK)
+	var headers protowire.GetBlocksResponseMessage
+	err = protojson.Unmarshal(headersBody, &headers)
+	if err != nil {
+		t.Fatalf("Error parsing the headers: %s", err)
+	}
+	if len(headers.Blocks) != 1 || headers.Blocks[0].VerboseData.Hash != blockHash {
+		t.Fatalf("Unexpected headers %s", headers.BlockHashes)
+	}
+
+	invalidBlockResponse, _ := restGet(t, "/block/invalid", nil)
+	expectStatus(t, invalidBlockResponse, http.StatusBadRequest)
+
+	// The UTXO index is disabled in this harness
+	utxosResponse, _ := restGet(t, "/utxos/"+miningAddress1, nil)
+	expectStatus(t, utxosResponse, http.StatusServiceUnavailable)
+}
+
+func restGet(t *testing.T, path string, header map[string]string) (*http.Response, []byte) {
+	request, err := http.NewRequest(http.MethodGet, "http://"+restAddress1+path, nil)
+	if err != nil {
+		t.Fatalf("Error creating a request for %s: %s", path, err)
+	}
+	for key, value := range header {
+		request.Header.Set(key, value)
+	}
+	client := http.Client{Timeout: defaultTimeout}
+	response, err := client.Do(request)
+	if err != nil {
+		t.Fatalf("Error requesting %s: %s", path, err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("Error reading the response of %s: %s", path, err)
+	}
+	return response, body
+}
+
+func expectStatus(t *testing.T, response *http.Response, expectedStatusCode int) {
+	if response.StatusCode != expectedStatusCode {
+		t.Fatalf("Unexpected status code for %s. Want: %d, got: %d",
+			response.Request.URL, expectedStatusCode, response.StatusCode)
+	}
+}
+
+func expectCacheControl(t *testing.T, response *http.Response, expectedCacheControl string) {
+	cacheControl := response.Header.Get("Cache-Control")
+	if cacheControl != expectedCacheControl {
+		t.Fatalf("Unexpected Cache-Control for %s. Want: %s, got: %s",
+			response.Request.URL, expectedCacheControl, cacheControl)
+	}
+}
@@ -0,0 +1,107 @@
+package integration
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/network/netadapter/server/grpcserver/protowire"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcauth"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient/grpcclient"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestRPCAuth(t *testing.T) {
+	const (
+		adminPassword    = "admin password"
+		explorerPassword = "explorer password"
+	)
+	adminRPCAuth, err := rpcauth.Generate("admin", adminPassword, nil)
+	if err != nil {
+		t.Fatalf("Error generating rpcauth: %s", err)
+	}
+	explorerRPCAuth, err := rpcauth.Generate("explorer", explorerPassword, []string{"getInfo", "getBlockDagInfo"})
+	if err != nil {
+		t.Fatalf("Error generating rpcauth: %s", err)
+	}
+
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		apiAddress:              apiAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		rpcAuth:                 []string{adminRPCAuth, explorerRPCAuth},
+		rpcUser:                 "admin",
+		rpcPassword:             adminPassword,
+	})
+	defer teardown()
+
+	// The harness' client authenticates as admin, who may call all methods
+	mineNextBlock(t, harness)
+
+	_, err = rpcclient.NewRPCClientWithCredentials(rpcAddress1, "admin", "wrong password")
+	if err == nil {
+		t.Fatalf("Expected connecting with a wrong password to fail")
+	}
+
+	unauthenticatedClient, err := grpcclient.Connect(rpcAddress1)
+	if err != nil {
+		t.Fatalf("Error connecting: %s", err)
+	}
+	defer unauthenticatedClient.Close()
+	_, err = unauthenticatedClient.PostAppMessage(appmessage.NewGetInfoRequestMessage())
+	if status.Code(errors.Cause(err)) != codes.Unauthenticated {
+		t.Fatalf("Expected an Unauthenticated error without credentials, but got: %v", err)
+	}
+
+	explorerClient, err := newTestRPCClientWithCredentials(rpcAddress1, "explorer", explorerPassword)
+	if err != nil {
+		t.Fatalf("Error connecting as explorer: %s", err)
+	}
+	defer explorerClient.Close()
+	_, err = explorerClient.GetBlockDAGInfo()
+	if err != nil {
+		t.Fatalf("Error from GetBlockDAGInfo: %s", err)
+	}
+	// Forbidden requests get an error response, and don't affect the connection
+	_, err = explorerClient.GetBlockTemplate(miningAddress1, "")
+	if err == nil || !strings.Contains(err.Error(), "not allowed") {
+		t.Fatalf("Expected GetBlockTemplate to be forbidden for explorer, but got: %v", err)
+	}
+	_, err = explorerClient.GetInfo()
+	if err != nil {
+		t.Fatalf("Error from GetInfo after a forbidden request: %s", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	connection, err := grpc.DialContext(ctx, apiAddress1, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		t.Fatalf("Error connecting to the gRPC API: %s", err)
+	}
+	defer connection.Close()
+	apiClient := protowire.NewAPIClient(connection)
+
+	_, err = apiClient.GetInfo(ctx, &protowire.GetInfoRequestMessage{})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("Expected an Unauthenticated error from the gRPC API without credentials, but got: %v", err)
+	}
+
+	explorerCtx := metadata.AppendToOutgoingContext(ctx,
+		rpcauth.AuthorizationMetadataKey, rpcauth.BasicAuthorization("explorer", explorerPassword))
+	_, err = apiClient.GetInfo(explorerCtx, &protowire.GetInfoRequestMessage{})
+	if err != nil {
+		t.Fatalf("Error from GetInfo as explorer: %s", err)
+	}
+	_, err = apiClient.GetMempoolEntries(explorerCtx, &protowire.GetMempoolEntriesRequestMessage{})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("Expected a PermissionDenied error from GetMempoolEntries as explorer, but got: %v", err)
+	}
+}
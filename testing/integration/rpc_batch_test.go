@@ -0,0 +1,80 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient"
+	"github.com/pkg/errors"
+)
+
+func TestRPCClientBatch(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	const blockCount = 5
+	blockHashes := make([]string, blockCount)
+	for i := range blockHashes {
+		blockHashes[i] = consensushashing.BlockHash(mineNextBlock(t, harness)).String()
+	}
+
+	batch := harness.rpcClient.NewBatch()
+	// More calls than are sent before their responses are received
+	const callCount = 250
+	getBlockFutures := make([]*rpcclient.GetBlockFuture, callCount)
+	for i := range getBlockFutures {
+		getBlockFutures[i] = batch.GetBlock(blockHashes[i%blockCount], false)
+	}
+	getBlocksFuture := batch.GetBlocks(blockHashes[0], false, false)
+	unknownBlockFuture := batch.GetBlock("0000000000000000000000000000000000000000000000000000000000000000", false)
+
+	_, err := getBlocksFuture.Receive()
+	if err == nil {
+		t.Fatalf("Expected receiving the result of a call of a batch that wasn't sent to fail")
+	}
+
+	err = batch.Send()
+	if err != nil {
+		t.Fatalf("Error sending batch: %s", err)
+	}
+	for i, getBlockFuture := range getBlockFutures {
+		getBlockResponse, err := getBlockFuture.Receive()
+		if err != nil {
+			t.Fatalf("Error getting block of call %d: %s", i, err)
+		}
+		if getBlockResponse.Block.VerboseData.Hash != blockHashes[i%blockCount] {
+			t.Fatalf("Unexpected block of call %d. Want: %s, got: %s",
+				i, blockHashes[i%blockCount], getBlockResponse.Block.VerboseData.Hash)
+		}
+	}
+	getBlocksResponse, err := getBlocksFuture.Receive()
+	if err != nil {
+		t.Fatalf("Error getting blocks: %s", err)
+	}
+	if len(getBlocksResponse.BlockHashes) != blockCount {
+		t.Fatalf("Unexpected amount of blocks. Want: %d, got: %d", blockCount, len(getBlocksResponse.BlockHashes))
+	}
+	_, err = unknownBlockFuture.Receive()
+	if !errors.Is(err, rpcclient.ErrRPC) {
+		t.Fatalf("Expected getting an unknown block to return an RPC error, but got: %v", err)
+	}
+
+	err = batch.Send()
+	if err == nil {
+		t.Fatalf("Expected sending a batch twice to fail")
+	}
+
+	// The calls that come after a batch receive their own responses
+	getBlockResponse, err := harness.rpcClient.GetBlock(blockHashes[1], false)
+	if err != nil {
+		t.Fatalf("Error getting block: %s", err)
+	}
+	if getBlockResponse.Block.VerboseData.Hash != blockHashes[1] {
+		t.Fatalf("Unexpected block. Want: %s, got: %s", blockHashes[1], getBlockResponse.Block.VerboseData.Hash)
+	}
+}
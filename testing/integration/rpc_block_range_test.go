@@ -0,0 +1,65 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient"
+)
+
+func TestDownloadChainBlocks(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	genesisHash := consensushashing.BlockHash(harness.config.NetParams().GenesisBlock).String()
+	const blockCount = 10
+	blockHashes := make([]string, blockCount)
+	for i := range blockHashes {
+		blockHashes[i] = consensushashing.BlockHash(mineNextBlock(t, harness)).String()
+	}
+
+	download := func(options *rpcclient.BlockRangeOptions) []string {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+		defer cancel()
+
+		var downloadedHashes []string
+		for result := range harness.rpcClient.DownloadChainBlocks(ctx, genesisHash, options) {
+			if result.Err != nil {
+				t.Fatalf("Error downloading blocks: %s", result.Err)
+			}
+			downloadedHashes = append(downloadedHashes, result.Block.VerboseData.Hash)
+		}
+		return downloadedHashes
+	}
+	checkHashes := func(name string, want []string, got []string) {
+		if len(got) != len(want) {
+			t.Fatalf("%s: unexpected amount of blocks. Want: %d, got: %d", name, len(want), len(got))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("%s: unexpected block %d. Want: %s, got: %s", name, i, want[i], got[i])
+			}
+		}
+	}
+
+	// More pages and more blocks than are downloaded at the same time
+	checkHashes("whole chain", blockHashes, download(&rpcclient.BlockRangeOptions{
+		Concurrency: 3,
+		PageSize:    4,
+	}))
+	checkHashes("end hash", blockHashes[:6], download(&rpcclient.BlockRangeOptions{
+		EndHash:  blockHashes[5],
+		PageSize: 4,
+	}))
+	// The blue score of a chain block mined over the genesis is its height
+	checkHashes("blue score range", blockHashes[2:7], download(&rpcclient.BlockRangeOptions{
+		MinBlueScore: 3,
+		MaxBlueScore: 7,
+	}))
+}
@@ -0,0 +1,51 @@
+package integration
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCaptureProfile(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	response, err := harness.rpcClient.CaptureProfile("goroutine", 0, true)
+	if err != nil {
+		t.Fatalf("Error capturing a goroutine dump: %s", err)
+	}
+	expectedDirectory := filepath.Join(harness.config.AppDir, "profiles")
+	if filepath.Dir(response.FilePath) != expectedDirectory {
+		t.Fatalf("Unexpected file path %s. Expected a file in %s", response.FilePath, expectedDirectory)
+	}
+	fileContent, err := ioutil.ReadFile(response.FilePath)
+	if err != nil {
+		t.Fatalf("ReadFile: %s", err)
+	}
+	if !bytes.Equal(response.Data, fileContent) {
+		t.Fatalf("The returned data differs from the content of %s", response.FilePath)
+	}
+	if !strings.Contains(string(response.Data), "HandleCaptureProfile") {
+		t.Fatalf("The goroutine dump doesn't contain the stack of the RPC handler")
+	}
+
+	response, err = harness.rpcClient.CaptureProfile("cpu", 1, false)
+	if err != nil {
+		t.Fatalf("Error capturing a CPU profile: %s", err)
+	}
+	if len(response.Data) != 0 {
+		t.Fatalf("Expected no data when it isn't requested")
+	}
+
+	_, err = harness.rpcClient.CaptureProfile("cpu", 0, false)
+	if err == nil || !strings.Contains(err.Error(), "duration") {
+		t.Fatalf("Expected a CPU profile without a duration to fail, but got: %v", err)
+	}
+}
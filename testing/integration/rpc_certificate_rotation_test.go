@@ -0,0 +1,120 @@
+package integration
+
+import (
+	"bytes"
+	"crypto/tls"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcauth"
+)
+
+func TestRPCCertificateRotation(t *testing.T) {
+	directory := randomDirectory(t)
+	writeCertificate := func(name string, validFor time.Duration, parent *tls.Certificate) *tls.Certificate {
+		certificatePEM, keyPEM, err := rpcauth.GenerateCertificate(&rpcauth.CertificateOptions{
+			CommonName: name,
+			Hosts:      []string{"localhost", "127.0.0.1"},
+			ValidFor:   validFor,
+			KeyType:    rpcauth.KeyTypeECDSAP256,
+		}, parent)
+		if err != nil {
+			t.Fatalf("GenerateCertificate: %s", err)
+		}
+		err = ioutil.WriteFile(filepath.Join(directory, name+".cert"), certificatePEM, 0600)
+		if err != nil {
+			t.Fatalf("WriteFile: %s", err)
+		}
+		err = ioutil.WriteFile(filepath.Join(directory, name+".key"), keyPEM, 0600)
+		if err != nil {
+			t.Fatalf("WriteFile: %s", err)
+		}
+		keyPair, err := tls.X509KeyPair(certificatePEM, keyPEM)
+		if err != nil {
+			t.Fatalf("X509KeyPair: %s", err)
+		}
+		return &keyPair
+	}
+	caKeyPair := writeCertificate("ca", 2*time.Hour, nil)
+	firstServerKeyPair := writeCertificate("server", time.Hour, caKeyPair)
+	writeCertificate("admin", time.Hour, caKeyPair)
+
+	// clientTLSConfig returns the TLS configuration of an admin client,
+	// which records the certificate the server presents in
+	// serverCertificate
+	clientTLSConfig := func(serverCertificate *[]byte) *tls.Config {
+		tlsConfig, err := rpcauth.ClientTLSConfig(filepath.Join(directory, "ca.cert"),
+			filepath.Join(directory, "admin.cert"), filepath.Join(directory, "admin.key"))
+		if err != nil {
+			t.Fatalf("ClientTLSConfig: %s", err)
+		}
+		tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			*serverCertificate = state.PeerCertificates[0].Raw
+			return nil
+		}
+		return tlsConfig
+	}
+
+	var harnessServerCertificate []byte
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		rpcTLS: &rpcTLSParams{
+			certFile:     filepath.Join(directory, "server.cert"),
+			keyFile:      filepath.Join(directory, "server.key"),
+			clientCAFile: filepath.Join(directory, "ca.cert"),
+			clientConfig: clientTLSConfig(&harnessServerCertificate),
+		},
+	})
+	defer teardown()
+	harness.config.ConfigFile = filepath.Join(directory, "kaspad.conf")
+
+	if !bytes.Equal(harnessServerCertificate, firstServerKeyPair.Certificate[0]) {
+		t.Fatalf("Expected the server to present its first certificate")
+	}
+
+	secondServerKeyPair := writeCertificate("server", time.Hour, caKeyPair)
+	response, err := harness.rpcClient.ReloadConfig()
+	if err != nil {
+		t.Fatalf("Error from ReloadConfig: %s", err)
+	}
+	isRPCCertChanged := false
+	for _, changedOption := range response.ChangedOptions {
+		isRPCCertChanged = isRPCCertChanged || changedOption == "rpccert"
+	}
+	if !isRPCCertChanged {
+		t.Fatalf("Unexpected changed options %v", response.ChangedOptions)
+	}
+
+	// The client that connected before the rotation stays connected
+	_, err = harness.rpcClient.GetInfo()
+	if err != nil {
+		t.Fatalf("Error from GetInfo after the rotation: %s", err)
+	}
+
+	var newServerCertificate []byte
+	newClient, err := newTestRPCClientWithTLS(rpcAddress1, "", "", clientTLSConfig(&newServerCertificate))
+	if err != nil {
+		t.Fatalf("Error connecting after the rotation: %s", err)
+	}
+	defer newClient.Close()
+	if !bytes.Equal(newServerCertificate, secondServerKeyPair.Certificate[0]) {
+		t.Fatalf("Expected new connections to be presented the rotated certificate")
+	}
+
+	// A reload without changes to the files doesn't report the
+	// certificate as changed
+	response, err = harness.rpcClient.ReloadConfig()
+	if err != nil {
+		t.Fatalf("Error from ReloadConfig: %s", err)
+	}
+	for _, changedOption := range response.ChangedOptions {
+		if changedOption == "rpccert" {
+			t.Fatalf("Expected the certificate not to be reported as changed again")
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/pkg/errors"
+)
+
+func TestRPCClientContext(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	firstBlockHash := consensushashing.BlockHash(mineNextBlock(t, harness)).String()
+	secondBlockHash := consensushashing.BlockHash(mineNextBlock(t, harness)).String()
+
+	client, err := newTestRPCClient(rpcAddress1)
+	if err != nil {
+		t.Fatalf("Error creating RPC client: %s", err)
+	}
+	defer client.Close()
+
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.GetInfoContext(canceledCtx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected a request with a canceled context to return context.Canceled, but got: %v", err)
+	}
+
+	// The responses of requests that were given up on are discarded, so
+	// that they aren't received by the requests that come after them
+	for i := 0; i < 10; i++ {
+		expiredCtx, cancel := context.WithTimeout(context.Background(), 0)
+		_, err = client.GetBlockContext(expiredCtx, firstBlockHash, false)
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Expected a request with an expired context to return context.DeadlineExceeded, but got: %v", err)
+		}
+
+		getBlockResponse, err := client.GetBlockContext(context.Background(), secondBlockHash, false)
+		if err != nil {
+			t.Fatalf("Error getting block: %s", err)
+		}
+		if getBlockResponse.Block.VerboseData.Hash != secondBlockHash {
+			t.Fatalf("Unexpected block. Want: %s, got: %s", secondBlockHash, getBlockResponse.Block.VerboseData.Hash)
+		}
+	}
+
+	type getBlockResult struct {
+		response *appmessage.GetBlockResponseMessage
+		err      error
+	}
+	getBlockResultChan := make(chan getBlockResult, 1)
+	client.GetBlockAsync(context.Background(), firstBlockHash, false,
+		func(response *appmessage.GetBlockResponseMessage, err error) {
+			getBlockResultChan <- getBlockResult{response: response, err: err}
+		})
+	select {
+	case result := <-getBlockResultChan:
+		if result.err != nil {
+			t.Fatalf("Error getting block asynchronously: %s", result.err)
+		}
+		if result.response.Block.VerboseData.Hash != firstBlockHash {
+			t.Fatalf("Unexpected block. Want: %s, got: %s", firstBlockHash, result.response.Block.VerboseData.Hash)
+		}
+	case <-time.After(defaultTimeout):
+		t.Fatalf("Timed out waiting for GetBlockAsync")
+	}
+}
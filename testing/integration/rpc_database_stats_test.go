@@ -0,0 +1,264 @@
+package integration
+
+import (
+	"math/rand"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+)
+
+func TestDatabaseStats(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	for i := 0; i < 10; i++ {
+		mineNextBlock(t, harness)
+	}
+
+	getDatabaseStatsResponse, err := harness.rpcClient.GetDatabaseStats(true, 0, false)
+	if err != nil {
+		t.Fatalf("Error getting database stats: %s", err)
+	}
+	if getDatabaseStatsResponse.Backend != harness.config.DbType {
+		t.Fatalf("Unexpected backend. Want: %s, got: %s", harness.config.DbType, getDatabaseStatsResponse.Backend)
+	}
+	foundBlockHeaders := false
+	for _, bucket := range getDatabaseStatsResponse.Buckets {
+		if strings.HasSuffix(bucket.Bucket, "/block-headers") && bucket.KeyCount > 10 {
+			foundBlockHeaders = true
+		}
+	}
+	if !foundBlockHeaders {
+		t.Fatalf("Expected the bucket stats to include the block headers of the mined blocks, but got: %+v",
+			getDatabaseStatsResponse.Buckets)
+	}
+	foundBlockCache := false
+	for _, cache := range getDatabaseStatsResponse.Caches {
+		if cache.Name == "blocks" {
+			foundBlockCache = true
+			if cache.Capacity != uint64(harness.config.BlockCacheSize) {
+				t.Fatalf("Unexpected block cache capacity. Want: %d, got: %d",
+					harness.config.BlockCacheSize, cache.Capacity)
+			}
+			if cache.Hits == 0 {
+				t.Fatalf("Expected the block cache to have hits after mining blocks, but got: %+v", cache)
+			}
+		}
+	}
+	if !foundBlockCache {
+		t.Fatalf("Expected the cache stats to include the block cache, but got: %+v",
+			getDatabaseStatsResponse.Caches)
+	}
+
+	_, err = harness.rpcClient.CompactDatabase()
+	if err != nil {
+		t.Fatalf("Error compacting the database: %s", err)
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		getDatabaseStatsResponse, err = harness.rpcClient.GetDatabaseStats(false, 0, false)
+		if err != nil {
+			t.Fatalf("Error getting database stats: %s", err)
+		}
+		if !getDatabaseStatsResponse.IsCompacting && getDatabaseStatsResponse.LastCompactionTime != 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for the database compaction to finish")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// Compacting the database writes everything that was in memory into its levels
+	if len(getDatabaseStatsResponse.Levels) == 0 || getDatabaseStatsResponse.DiskSize == 0 {
+		t.Fatalf("Expected the database stats to include levels after compacting, but got: %+v",
+			getDatabaseStatsResponse)
+	}
+	if len(getDatabaseStatsResponse.Buckets) != 0 {
+		t.Fatalf("Expected the database stats to include no buckets, but got %d", len(getDatabaseStatsResponse.Buckets))
+	}
+}
+
+func TestDatabaseOperationMetrics(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		databaseMetrics:         true,
+	})
+	defer teardown()
+
+	for i := 0; i < 10; i++ {
+		mineNextBlock(t, harness)
+	}
+
+	getDatabaseStatsResponse, err := harness.rpcClient.GetDatabaseStats(false, 0, true)
+	if err != nil {
+		t.Fatalf("Error getting database stats: %s", err)
+	}
+	histogramLength := len(getDatabaseStatsResponse.LatencyHistogramBounds) + 1
+	foundBlockHeaderWrites := false
+	foundCommits := false
+	for _, operation := range getDatabaseStatsResponse.Operations {
+		if len(operation.LatencyHistogram) != histogramLength {
+			t.Fatalf("Unexpected latency histogram length. Want: %d, got: %d",
+				histogramLength, len(operation.LatencyHistogram))
+		}
+		histogramCount := uint64(0)
+		for _, count := range operation.LatencyHistogram {
+			histogramCount += count
+		}
+		if histogramCount != operation.Count {
+			t.Fatalf("The latency histogram of %s on %s counts %d operations, but there were %d",
+				operation.Operation, operation.Bucket, histogramCount, operation.Count)
+		}
+		if strings.HasSuffix(operation.Bucket, "/block-headers") && operation.Operation == "put" &&
+			operation.Count >= 10 && operation.Bytes > 0 {
+			foundBlockHeaderWrites = true
+		}
+		if operation.Operation == "commit" && operation.Count > 0 {
+			foundCommits = true
+		}
+	}
+	if !foundBlockHeaderWrites || !foundCommits {
+		t.Fatalf("Expected the operation metrics to include the writes of the mined blocks, but got: %+v",
+			getDatabaseStatsResponse.Operations)
+	}
+
+	// Operation metrics are only included when they're requested
+	getDatabaseStatsResponse, err = harness.rpcClient.GetDatabaseStats(false, 0, false)
+	if err != nil {
+		t.Fatalf("Error getting database stats: %s", err)
+	}
+	if len(getDatabaseStatsResponse.Operations) != 0 {
+		t.Fatalf("Expected no operation metrics, but got %d", len(getDatabaseStatsResponse.Operations))
+	}
+}
+
+func TestBackupDatabase(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	for i := 0; i < 10; i++ {
+		mineNextBlock(t, harness)
+	}
+
+	backupDirectory := filepath.Join(t.TempDir(), "backup")
+	_, err := harness.rpcClient.BackupDatabase(backupDirectory)
+	if err != nil {
+		t.Fatalf("Error backing the database up: %s", err)
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	var getDatabaseStatsResponse *appmessage.GetDatabaseStatsResponseMessage
+	for {
+		getDatabaseStatsResponse, err = harness.rpcClient.GetDatabaseStats(false, 0, false)
+		if err != nil {
+			t.Fatalf("Error getting database stats: %s", err)
+		}
+		if !getDatabaseStatsResponse.IsBackingUp && getDatabaseStatsResponse.LastBackupTime != 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for the database backup to finish")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if getDatabaseStatsResponse.LastBackupError != "" {
+		t.Fatalf("Error backing the database up: %s", getDatabaseStatsResponse.LastBackupError)
+	}
+	if getDatabaseStatsResponse.LastBackupDirectory != backupDirectory {
+		t.Fatalf("Unexpected backup directory. Want: %s, got: %s",
+			backupDirectory, getDatabaseStatsResponse.LastBackupDirectory)
+	}
+
+	// A second backup into the same directory is refused
+	_, err = harness.rpcClient.BackupDatabase(backupDirectory)
+	if err == nil {
+		t.Fatalf("Expected backing up into an existing directory to fail")
+	}
+
+	backup, err := ldb.NewLevelDB(backupDirectory, 8)
+	if err != nil {
+		t.Fatalf("Error opening the backup: %s", err)
+	}
+	defer backup.Close()
+	bucketStats, err := database.CollectBucketStats(backup, database.MakeBucket(nil), 2)
+	if err != nil {
+		t.Fatalf("Error reading the backup: %s", err)
+	}
+	foundBlocks := false
+	for _, stats := range bucketStats {
+		if strings.HasSuffix(string(stats.Bucket.Path()), "/blocks/") && stats.KeyCount > 10 {
+			foundBlocks = true
+		}
+	}
+	if !foundBlocks {
+		t.Fatalf("Expected the backup to include the mined blocks")
+	}
+}
+
+func TestCompactAfterPruning(t *testing.T) {
+	// Use the parameters of TestIBDWithPruning, so that
+	// the pruning point moves after a few dozen blocks
+	overrideDAGParams := dagconfig.SimnetParams
+	overrideDAGParams.TargetTimePerBlock = time.Minute
+	overrideDAGParams.FinalityDuration = 2 * overrideDAGParams.TargetTimePerBlock
+	overrideDAGParams.K = 0
+	overrideDAGParams.PruningProofM = 20
+
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		overrideDAGParams:       &overrideDAGParams,
+		compactAfterPruning:     true,
+	})
+	defer teardown()
+
+	rd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for i := 0; ; i++ {
+		if i == 200 {
+			t.Fatalf("The pruning point didn't move after %d blocks", i)
+		}
+		mineNextBlockWithMockTimestamps(t, harness, rd)
+		dagInfo, err := harness.rpcClient.GetBlockDAGInfo()
+		if err != nil {
+			t.Fatalf("Error getting the DAG info: %s", err)
+		}
+		if dagInfo.PruningPointHash != overrideDAGParams.GenesisHash.String() {
+			break
+		}
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		getDatabaseStatsResponse, err := harness.rpcClient.GetDatabaseStats(false, 0, false)
+		if err != nil {
+			t.Fatalf("Error getting database stats: %s", err)
+		}
+		if !getDatabaseStatsResponse.IsCompacting && getDatabaseStatsResponse.LastCompactionTime != 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for the database to be compacted after pruning")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
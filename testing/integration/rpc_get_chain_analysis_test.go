@@ -0,0 +1,64 @@
+package integration
+
+import (
+	"testing"
+)
+
+func TestGetChainAnalysis(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	const blockCount = 10
+	for i := 0; i < blockCount; i++ {
+		mineNextBlock(t, harness)
+	}
+
+	// A single miner builds a chain, so every chain block merges only its
+	// selected parent
+	response, err := harness.rpcClient.GetChainAnalysis(0, 0)
+	if err != nil {
+		t.Fatalf("Error getting the chain analysis: %s", err)
+	}
+	if response.LowBlueScore != 1 || response.HighBlueScore != blockCount {
+		t.Fatalf("Expected the window [1, %d] to be analyzed, but got [%d, %d]",
+			blockCount, response.LowBlueScore, response.HighBlueScore)
+	}
+	if response.ChainBlockCount != blockCount || response.BlockCount != blockCount {
+		t.Fatalf("Expected %d chain blocks and blocks, but got %d chain blocks and %d blocks",
+			blockCount, response.ChainBlockCount, response.BlockCount)
+	}
+	if response.RedBlockCount != 0 || response.RedRate != 0 {
+		t.Fatalf("Expected no red blocks, but got %d", response.RedBlockCount)
+	}
+	if response.ParallelismFactor != 1 {
+		t.Fatalf("Expected a parallelism factor of 1, but got %f", response.ParallelismFactor)
+	}
+	// The genesis, which is merged by the first block, has no parents
+	expectedAverageParentCount := float64(blockCount-1) / blockCount
+	if response.AverageParentCount != expectedAverageParentCount {
+		t.Fatalf("Expected an average parent count of %f, but got %f",
+			expectedAverageParentCount, response.AverageParentCount)
+	}
+	if response.OrphanLatency.Count != 0 {
+		t.Fatalf("Expected no orphans, but got %d", response.OrphanLatency.Count)
+	}
+
+	response, err = harness.rpcClient.GetChainAnalysis(3, 5)
+	if err != nil {
+		t.Fatalf("Error getting the chain analysis: %s", err)
+	}
+	if response.ChainBlockCount != 3 || response.AverageParentCount != 1 {
+		t.Fatalf("Expected 3 chain blocks with 1 parent each, but got %d chain blocks with %f parents on average",
+			response.ChainBlockCount, response.AverageParentCount)
+	}
+
+	_, err = harness.rpcClient.GetChainAnalysis(5, 3)
+	if err == nil {
+		t.Fatalf("Expected an error for a window whose low blue score is above its high blue score")
+	}
+}
@@ -0,0 +1,66 @@
+package integration
+
+import (
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+)
+
+func TestGetFinalityPoint(t *testing.T) {
+	overrideDAGParams := dagconfig.SimnetParams
+
+	// This is done to make a finality depth of 10 blocks
+	overrideDAGParams.FinalityDuration = 10 * overrideDAGParams.TargetTimePerBlock
+	finalityDepth := overrideDAGParams.FinalityDepth()
+
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		overrideDAGParams:       &overrideDAGParams,
+	})
+	defer teardown()
+
+	getFinalityPointResponse, err := harness.rpcClient.GetFinalityPoint()
+	if err != nil {
+		t.Fatalf("Error getting finality point: %s", err)
+	}
+	if getFinalityPointResponse.FinalityPointHash != overrideDAGParams.GenesisHash.String() {
+		t.Fatalf("Unexpected finality point before mining. Want: %s, got: %s",
+			overrideDAGParams.GenesisHash, getFinalityPointResponse.FinalityPointHash)
+	}
+
+	blockHashes := make(map[string]struct{})
+	for i := uint64(0); i < 3*finalityDepth; i++ {
+		blockHashes[consensushashing.BlockHash(mineNextBlock(t, harness)).String()] = struct{}{}
+	}
+
+	getFinalityPointResponse, err = harness.rpcClient.GetFinalityPoint()
+	if err != nil {
+		t.Fatalf("Error getting finality point: %s", err)
+	}
+	if _, ok := blockHashes[getFinalityPointResponse.FinalityPointHash]; !ok {
+		t.Fatalf("Expected the finality point to be one of the mined blocks, but got: %s",
+			getFinalityPointResponse.FinalityPointHash)
+	}
+	getBlockResponse, err := harness.rpcClient.GetBlock(getFinalityPointResponse.FinalityPointHash, false)
+	if err != nil {
+		t.Fatalf("Error getting finality point block: %s", err)
+	}
+	if getBlockResponse.Block.VerboseData.BlueScore != getFinalityPointResponse.BlueScore {
+		t.Fatalf("Unexpected finality point blue score. Want: %d, got: %d",
+			getBlockResponse.Block.VerboseData.BlueScore, getFinalityPointResponse.BlueScore)
+	}
+
+	virtualBlueScoreResponse, err := harness.rpcClient.GetVirtualSelectedParentBlueScore()
+	if err != nil {
+		t.Fatalf("Error getting virtual selected parent blue score: %s", err)
+	}
+	if getFinalityPointResponse.BlueScore+finalityDepth > virtualBlueScoreResponse.BlueScore+1 {
+		t.Fatalf("Expected the finality point to be at least %d blocks below the virtual, but its blue score "+
+			"is %d and the virtual selected parent's is %d",
+			finalityDepth, getFinalityPointResponse.BlueScore, virtualBlueScoreResponse.BlueScore)
+	}
+}
@@ -0,0 +1,89 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/indexes"
+)
+
+func TestIndexRebuildAndDrop(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		addressIndex:            true,
+	})
+	defer teardown()
+
+	for i := 0; i < 10; i++ {
+		mineNextBlock(t, harness)
+	}
+
+	_, err := harness.rpcClient.RebuildIndex(indexes.AddressIndexName)
+	if err != nil {
+		t.Fatalf("Error rebuilding the address index: %s", err)
+	}
+	addressIndexStatus := waitForIndexOperation(t, harness, indexes.AddressIndexName)
+	if !addressIndexStatus.IsEnabled || addressIndexStatus.Operation != indexes.OperationRebuild ||
+		addressIndexStatus.OperationError != "" {
+		t.Fatalf("Unexpected address index status after rebuilding it: %+v", addressIndexStatus)
+	}
+	if addressIndexStatus.Total == 0 || addressIndexStatus.Processed != addressIndexStatus.Total {
+		t.Fatalf("Expected the rebuild to process all the chain blocks, but got: %+v", addressIndexStatus)
+	}
+	searchRawTransactionsResponse, err := harness.rpcClient.SearchRawTransactions(miningAddress1, 100, "", false)
+	if err != nil {
+		t.Fatalf("SearchRawTransactions: %s", err)
+	}
+	if len(searchRawTransactionsResponse.Entries) == 0 {
+		t.Fatalf("Expected the rebuilt address index to include the coinbase transactions of the mining address")
+	}
+
+	// Only indexes that aren't enabled may be dropped, and only enabled ones may be rebuilt
+	_, err = harness.rpcClient.DropIndex(indexes.AddressIndexName)
+	if err == nil {
+		t.Fatalf("Expected dropping an enabled index to fail")
+	}
+	_, err = harness.rpcClient.RebuildIndex(indexes.UTXOIndexName)
+	if err == nil {
+		t.Fatalf("Expected rebuilding an index that isn't enabled to fail")
+	}
+	_, err = harness.rpcClient.DropIndex("unknownindex")
+	if err == nil {
+		t.Fatalf("Expected dropping an unknown index to fail")
+	}
+
+	_, err = harness.rpcClient.DropIndex(indexes.UTXOIndexName)
+	if err != nil {
+		t.Fatalf("Error dropping the UTXO index: %s", err)
+	}
+	utxoIndexStatus := waitForIndexOperation(t, harness, indexes.UTXOIndexName)
+	if utxoIndexStatus.IsEnabled || utxoIndexStatus.Operation != indexes.OperationDrop ||
+		utxoIndexStatus.OperationError != "" {
+		t.Fatalf("Unexpected UTXO index status after dropping it: %+v", utxoIndexStatus)
+	}
+}
+
+// waitForIndexOperation waits for the operation that's running on
+// the given index to finish, and returns the status of the index
+func waitForIndexOperation(t *testing.T, harness *appHarness, name string) *appmessage.IndexStatus {
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		getIndexStatusResponse, err := harness.rpcClient.GetIndexStatus()
+		if err != nil {
+			t.Fatalf("Error getting the index status: %s", err)
+		}
+		for _, status := range getIndexStatusResponse.Indexes {
+			if status.Name == name && !status.IsRunning && status.EndTime != 0 {
+				return status
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for the operation on the %s index to finish", name)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,102 @@
+package integration
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient"
+	"github.com/pkg/errors"
+)
+
+func TestRPCClientInterceptors(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	blockHash := consensushashing.BlockHash(mineNextBlock(t, harness)).String()
+
+	client, err := newTestRPCClient(rpcAddress1)
+	if err != nil {
+		t.Fatalf("Error creating RPC client: %s", err)
+	}
+	defer client.Close()
+
+	var calls []string
+	recordingInterceptor := func(name string) rpcclient.Interceptor {
+		return func(ctx context.Context, request appmessage.Message,
+			next rpcclient.RequestHandler) (appmessage.Message, error) {
+
+			calls = append(calls, name+" "+request.Command().String())
+			response, err := next(ctx, request)
+			if err != nil {
+				return nil, err
+			}
+			calls = append(calls, name+" "+response.Command().String())
+			return response, nil
+		}
+	}
+	client.AddInterceptor(recordingInterceptor("outer"))
+
+	// An interceptor that retries requests that fail once
+	client.AddInterceptor(func(ctx context.Context, request appmessage.Message,
+		next rpcclient.RequestHandler) (appmessage.Message, error) {
+
+		response, err := next(ctx, request)
+		if err != nil {
+			return next(ctx, request)
+		}
+		return response, nil
+	})
+	client.AddInterceptor(recordingInterceptor("inner"))
+
+	// An interceptor that fails the first request
+	errTransient := errors.New("transient error")
+	attempts := 0
+	client.AddInterceptor(func(ctx context.Context, request appmessage.Message,
+		next rpcclient.RequestHandler) (appmessage.Message, error) {
+
+		attempts++
+		if attempts == 1 {
+			return nil, errTransient
+		}
+		return next(ctx, request)
+	})
+
+	// An interceptor that changes requests
+	client.AddInterceptor(func(ctx context.Context, request appmessage.Message,
+		next rpcclient.RequestHandler) (appmessage.Message, error) {
+
+		if getBlockRequest, ok := request.(*appmessage.GetBlockRequestMessage); ok {
+			getBlockRequest.IncludeTransactions = true
+		}
+		return next(ctx, request)
+	})
+
+	getBlockResponse, err := client.GetBlock(blockHash, false)
+	if err != nil {
+		t.Fatalf("Error getting block: %s", err)
+	}
+	if len(getBlockResponse.Block.Transactions) == 0 {
+		t.Fatalf("Expected the interceptor to make the request include transactions")
+	}
+	if attempts != 2 {
+		t.Fatalf("Unexpected amount of attempts. Want: 2, got: %d", attempts)
+	}
+	expectedCalls := []string{
+		"outer " + appmessage.CmdGetBlockRequestMessage.String(),
+		"inner " + appmessage.CmdGetBlockRequestMessage.String(),
+		"inner " + appmessage.CmdGetBlockRequestMessage.String(),
+		"inner " + appmessage.CmdGetBlockResponseMessage.String(),
+		"outer " + appmessage.CmdGetBlockResponseMessage.String(),
+	}
+	if !reflect.DeepEqual(calls, expectedCalls) {
+		t.Fatalf("Unexpected interceptor calls. Want: %s, got: %s", expectedCalls, calls)
+	}
+}
@@ -0,0 +1,152 @@
+package integration
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcauth"
+)
+
+func TestRPCMutualTLS(t *testing.T) {
+	directory := randomDirectory(t)
+	caCertificate, caKey := writeTestCertificate(t, directory, "ca", nil, nil)
+	writeTestCertificate(t, directory, "server", caCertificate, caKey)
+	writeTestCertificate(t, directory, "admin", caCertificate, caKey)
+	writeTestCertificate(t, directory, "explorer", caCertificate, caKey)
+	writeTestCertificate(t, directory, "stranger", caCertificate, caKey)
+	// rogue is a self-signed certificate with the common name of an allowed client
+	rogueDirectory := randomDirectory(t)
+	rogueCACertificate, rogueCAKey := writeTestCertificate(t, rogueDirectory, "ca", nil, nil)
+	writeTestCertificate(t, rogueDirectory, "admin", rogueCACertificate, rogueCAKey)
+
+	clientTLSConfig := func(certificateDirectory string, name string) *tls.Config {
+		certFile, keyFile := "", ""
+		if name != "" {
+			certFile = filepath.Join(certificateDirectory, name+".cert")
+			keyFile = filepath.Join(certificateDirectory, name+".key")
+		}
+		tlsConfig, err := rpcauth.ClientTLSConfig(filepath.Join(directory, "ca.cert"), certFile, keyFile)
+		if err != nil {
+			t.Fatalf("Error loading the client TLS configuration of %s: %s", name, err)
+		}
+		return tlsConfig
+	}
+
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		rpcTLS: &rpcTLSParams{
+			certFile:     filepath.Join(directory, "server.cert"),
+			keyFile:      filepath.Join(directory, "server.key"),
+			clientCAFile: filepath.Join(directory, "ca.cert"),
+			certAuth:     []string{"admin", "explorer:getInfo,getBlockDagInfo"},
+			clientConfig: clientTLSConfig(directory, "admin"),
+		},
+	})
+	defer teardown()
+
+	// The harness' client presents the admin certificate, which may call all methods
+	mineNextBlock(t, harness)
+
+	explorerClient, err := newTestRPCClientWithTLS(rpcAddress1, "", "", clientTLSConfig(directory, "explorer"))
+	if err != nil {
+		t.Fatalf("Error connecting as explorer: %s", err)
+	}
+	defer explorerClient.Close()
+	_, err = explorerClient.GetBlockDAGInfo()
+	if err != nil {
+		t.Fatalf("Error from GetBlockDAGInfo: %s", err)
+	}
+	_, err = explorerClient.GetBlockTemplate(miningAddress1, "")
+	if err == nil || !strings.Contains(err.Error(), "not allowed") {
+		t.Fatalf("Expected GetBlockTemplate to be forbidden for explorer, but got: %v", err)
+	}
+
+	failingConnections := []struct {
+		name      string
+		tlsConfig *tls.Config
+	}{
+		{name: "unlisted common name", tlsConfig: clientTLSConfig(directory, "stranger")},
+		{name: "certificate signed by another CA", tlsConfig: clientTLSConfig(rogueDirectory, "admin")},
+		{name: "no client certificate", tlsConfig: clientTLSConfig(directory, "")},
+		{name: "no TLS"},
+	}
+	for _, test := range failingConnections {
+		client, err := newTestRPCClientWithTLS(rpcAddress1, "", "", test.tlsConfig)
+		if err == nil {
+			client.Close()
+			t.Fatalf("%s: expected connecting to fail", test.name)
+		}
+	}
+}
+
+// writeTestCertificate writes <name>.cert and <name>.key to the given
+// directory, for a certificate with the common name name that is signed by
+// parent. If parent is nil, it writes a self-signed CA certificate.
+func writeTestCertificate(t *testing.T, directory string, name string,
+	parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Error generating key: %s", err)
+	}
+	serialNumber, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatalf("Error generating serial number: %s", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+		parent, parentKey = template, key
+	}
+
+	certificateBytes, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("Error creating certificate: %s", err)
+	}
+	certificate, err := x509.ParseCertificate(certificateBytes)
+	if err != nil {
+		t.Fatalf("Error parsing certificate: %s", err)
+	}
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Error marshalling key: %s", err)
+	}
+
+	err = ioutil.WriteFile(filepath.Join(directory, name+".cert"),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificateBytes}), 0600)
+	if err != nil {
+		t.Fatalf("Error writing certificate: %s", err)
+	}
+	err = ioutil.WriteFile(filepath.Join(directory, name+".key"),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600)
+	if err != nil {
+		t.Fatalf("Error writing key: %s", err)
+	}
+	return certificate, key
+}
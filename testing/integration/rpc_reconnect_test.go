@@ -0,0 +1,68 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+)
+
+func TestRPCClientReconnect(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	mineBlock := func() []string {
+		return []string{consensushashing.BlockHash(mineNextBlock(t, harness)).String()}
+	}
+
+	client, err := newTestRPCClient(rpcAddress1)
+	if err != nil {
+		t.Fatalf("Error creating RPC client: %s", err)
+	}
+	defer client.Close()
+
+	onNotificationsMissedChan := make(chan struct{}, 10)
+	client.SetOnNotificationsMissedHandler(func() {
+		onNotificationsMissedChan <- struct{}{}
+	})
+	onBlockAddedChan := registerForBlockAddedNotifications(t, client)
+
+	// Without a notification session, the client can't know whether it
+	// missed notifications while it was disconnected
+	err = client.Reconnect()
+	if err != nil {
+		t.Fatalf("Error reconnecting: %s", err)
+	}
+	select {
+	case <-onNotificationsMissedChan:
+	case <-time.After(defaultTimeout):
+		t.Fatalf("Timed out waiting for the notifications missed handler")
+	}
+	expectBlockAddedNotifications(t, onBlockAddedChan, mineBlock(), 0)
+
+	_, err = client.StartNotificationSession()
+	if err != nil {
+		t.Fatalf("Error starting notification session: %s", err)
+	}
+	expectBlockAddedNotifications(t, onBlockAddedChan, mineBlock(), 1)
+
+	// With a notification session, the session is resumed after reconnecting,
+	// so no notification is missed or handled twice
+	err = client.Reconnect()
+	if err != nil {
+		t.Fatalf("Error reconnecting: %s", err)
+	}
+	expectBlockAddedNotifications(t, onBlockAddedChan, mineBlock(), 2)
+	select {
+	case notification := <-onBlockAddedChan:
+		t.Fatalf("Unexpected notification with sequence %d", notification.NotificationSequence())
+	case <-onNotificationsMissedChan:
+		t.Fatalf("Unexpected call to the notifications missed handler")
+	case <-time.After(time.Second):
+	}
+}
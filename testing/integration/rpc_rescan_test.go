@@ -0,0 +1,125 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+func TestRescan(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	const blockCount = 10
+	for i := 0; i < blockCount; i++ {
+		mineNextBlock(t, harness)
+	}
+	selectedTipHashResponse, err := harness.rpcClient.GetSelectedTipHash()
+	if err != nil {
+		t.Fatalf("Error getting the selected tip hash: %s", err)
+	}
+
+	rescan := func(startBlueScore uint64, addresses []string) (*appmessage.RescanResponseMessage,
+		[]*appmessage.RescanProgressNotificationMessage) {
+
+		var notifications []*appmessage.RescanProgressNotificationMessage
+		response, err := harness.rpcClient.Rescan(startBlueScore, addresses, nil,
+			func(notification *appmessage.RescanProgressNotificationMessage) {
+				notifications = append(notifications, notification)
+			})
+		if err != nil {
+			t.Fatalf("Error rescanning: %s", err)
+		}
+		lastNotification := notifications[len(notifications)-1]
+		if !lastNotification.IsFinished {
+			t.Fatalf("Expected the last notification to finish the rescan")
+		}
+		if lastNotification.CheckpointBlueScore != blockCount ||
+			lastNotification.CheckpointBlockHash != selectedTipHashResponse.SelectedTipHash {
+			t.Fatalf("Expected the rescan to finish at the selected tip %s with blue score %d, but it "+
+				"finished at %s with blue score %d", selectedTipHashResponse.SelectedTipHash, blockCount,
+				lastNotification.CheckpointBlockHash, lastNotification.CheckpointBlueScore)
+		}
+		return response, notifications
+	}
+
+	// The first block pays to the genesis' script, and every other block
+	// pays to the mining address in the coinbase of the block that merges it
+	response, notifications := rescan(0, []string{miningAddress1})
+	if response.StartBlueScore != 1 {
+		t.Fatalf("Expected the rescan to start above the pruning point, at blue score 1, "+
+			"but it started at %d", response.StartBlueScore)
+	}
+	matchedTransactionIDs := make(map[string]struct{})
+	for _, notification := range notifications {
+		for _, match := range notification.Matches {
+			transactionID := match.Transaction.VerboseData.TransactionID
+			if _, ok := matchedTransactionIDs[transactionID]; ok {
+				t.Fatalf("Transaction %s was matched more than once", transactionID)
+			}
+			matchedTransactionIDs[transactionID] = struct{}{}
+
+			paysMiningAddress := false
+			for _, output := range match.Transaction.Outputs {
+				if output.VerboseData.ScriptPublicKeyAddress == miningAddress1 {
+					paysMiningAddress = true
+				}
+			}
+			if !paysMiningAddress {
+				t.Fatalf("Transaction %s doesn't pay the mining address", transactionID)
+			}
+		}
+	}
+	if len(matchedTransactionIDs) != blockCount-2 {
+		t.Fatalf("Expected the coinbase transactions of %d blocks to match, but got %d",
+			blockCount-2, len(matchedTransactionIDs))
+	}
+
+	// The rescan's job stops right after the rescan's last notification
+	// is sent
+	start := time.Now()
+	for {
+		getJobsResponse, err := harness.rpcClient.GetJobs([]uint64{response.JobID})
+		if err != nil {
+			t.Fatalf("GetJobs: %s", err)
+		}
+		job := getJobsResponse.Jobs[0]
+		if job.State != appmessage.RPCJobStateRunning {
+			if job.Kind != appmessage.RPCJobKindRescan || job.State != appmessage.RPCJobStateCompleted ||
+				job.RescanResult == nil || job.RescanResult.CheckpointBlockHash != selectedTipHashResponse.SelectedTipHash {
+
+				t.Fatalf("Unexpected rescan job: %+v", job)
+			}
+			break
+		}
+		if time.Since(start) > defaultTimeout {
+			t.Fatalf("Timed out waiting for the rescan job to stop")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Nothing pays to an address that isn't mined to
+	_, notifications = rescan(0, []string{miningAddress3})
+	for _, notification := range notifications {
+		if len(notification.Matches) != 0 {
+			t.Fatalf("Expected no matches, but got %d", len(notification.Matches))
+		}
+	}
+
+	// Nothing is left to scan above the selected tip
+	_, notifications = rescan(blockCount+1, []string{miningAddress1})
+	if len(notifications) != 1 || len(notifications[0].Matches) != 0 {
+		t.Fatalf("Expected a single notification without matches, but got %d notifications", len(notifications))
+	}
+
+	_, err = harness.rpcClient.Rescan(0, nil, nil, func(*appmessage.RescanProgressNotificationMessage) {})
+	if err == nil {
+		t.Fatalf("Expected a rescan without addresses or outpoints to fail")
+	}
+}
@@ -0,0 +1,101 @@
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient"
+)
+
+func TestRPCClientRetryPolicy(t *testing.T) {
+	harnessParams := &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	}
+	_, teardown := setupHarness(t, harnessParams)
+	isTornDown := false
+	defer func() {
+		if !isTornDown {
+			teardown()
+		}
+	}()
+
+	client, err := newTestRPCClient(rpcAddress1)
+	if err != nil {
+		t.Fatalf("Error creating RPC client: %s", err)
+	}
+	defer client.Close()
+
+	// The client gives up reconnecting once its reconnect policy doesn't retry the error
+	failedReconnectsChan := make(chan error, 10)
+	client.SetReconnectPolicy(&rpcclient.RetryPolicy{
+		MaxRetries: rpcclient.UnlimitedRetries,
+		IsRetryable: func(err error) bool {
+			failedReconnectsChan <- err
+			return false
+		},
+	})
+	teardown()
+	isTornDown = true
+	select {
+	case <-failedReconnectsChan:
+	case <-time.After(defaultTimeout):
+		t.Fatalf("Timed out waiting for the failed reconnect")
+	}
+	select {
+	case err := <-failedReconnectsChan:
+		t.Fatalf("Unexpected reconnect attempt after the client gave up: %s", err)
+	case <-time.After(time.Second):
+	}
+
+	// Requests aren't retried by default
+	_, err = client.GetInfo()
+	if err == nil {
+		t.Fatalf("Expected a request to a node that's down to fail")
+	}
+	if !rpcclient.IsTransientError(err) {
+		t.Fatalf("Expected the error of a request to a node that's down to be transient, but got: %s", err)
+	}
+
+	_, teardown = setupHarness(t, harnessParams)
+	isTornDown = false
+	client.SetReconnectPolicy(&rpcclient.RetryPolicy{
+		MaxRetries: rpcclient.UnlimitedRetries,
+		Backoff:    rpcclient.ConstantBackoff(50 * time.Millisecond),
+	})
+	err = client.Reconnect()
+	if err != nil {
+		t.Fatalf("Error reconnecting: %s", err)
+	}
+
+	// A request whose retry policy retries transient errors succeeds once the node is back up
+	teardown()
+	isTornDown = true
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	retryCtx := rpcclient.WithRetryPolicy(ctx, &rpcclient.RetryPolicy{
+		MaxRetries:  rpcclient.UnlimitedRetries,
+		Backoff:     rpcclient.ExponentialBackoff(10*time.Millisecond, 100*time.Millisecond),
+		IsRetryable: rpcclient.IsTransientError,
+	})
+	getInfoErrChan := make(chan error, 1)
+	client.GetInfoAsync(retryCtx, func(_ *appmessage.GetInfoResponseMessage, err error) {
+		getInfoErrChan <- err
+	})
+	time.Sleep(time.Second)
+
+	_, teardown = setupHarness(t, harnessParams)
+	isTornDown = false
+	select {
+	case err := <-getInfoErrChan:
+		if err != nil {
+			t.Fatalf("Error getting info with retries: %s", err)
+		}
+	case <-time.After(defaultTimeout):
+		t.Fatalf("Timed out waiting for the retried request")
+	}
+}
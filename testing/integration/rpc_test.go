@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"crypto/tls"
 	"github.com/kaspanet/kaspad/infrastructure/config"
 	"runtime"
 	"testing"
@@ -16,7 +17,17 @@ type testRPCClient struct {
 }
 
 func newTestRPCClient(rpcAddress string) (*testRPCClient, error) {
-	rpcClient, err := rpcclient.NewRPCClient(rpcAddress)
+	return newTestRPCClientWithCredentials(rpcAddress, "", "")
+}
+
+func newTestRPCClientWithCredentials(rpcAddress string, rpcUser string, rpcPassword string) (*testRPCClient, error) {
+	return newTestRPCClientWithTLS(rpcAddress, rpcUser, rpcPassword, nil)
+}
+
+func newTestRPCClientWithTLS(rpcAddress string, rpcUser string, rpcPassword string,
+	tlsConfig *tls.Config) (*testRPCClient, error) {
+
+	rpcClient, err := rpcclient.NewRPCClientWithTLS(rpcAddress, rpcUser, rpcPassword, tlsConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,67 @@
+package integration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcauth"
+)
+
+func TestRPCUnixSocket(t *testing.T) {
+	const adminPassword = "admin password"
+	adminRPCAuth, err := rpcauth.Generate("admin", adminPassword, nil)
+	if err != nil {
+		t.Fatalf("Error generating rpcauth: %s", err)
+	}
+	socketPath := filepath.Join(randomDirectory(t), "kaspad.sock")
+
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		rpcAuth:                 []string{adminRPCAuth},
+		rpcUser:                 "admin",
+		rpcPassword:             adminPassword,
+		rpcUnixSocket:           socketPath,
+	})
+	defer teardown()
+
+	fileInfo, err := os.Stat(socketPath)
+	if err != nil {
+		t.Fatalf("Error getting the socket's file info: %s", err)
+	}
+	if fileInfo.Mode()&os.ModeSocket == 0 {
+		t.Fatalf("Expected %s to be a socket, but its mode is %s", socketPath, fileInfo.Mode())
+	}
+	if fileInfo.Mode().Perm() != 0600 {
+		t.Fatalf("Unexpected socket permissions. Want: %s, got: %s", os.FileMode(0600), fileInfo.Mode().Perm())
+	}
+
+	// Clients connecting through the socket don't authenticate, even though
+	// rpcauth is set, and may call all methods
+	socketClient, err := newTestRPCClient("unix:" + socketPath)
+	if err != nil {
+		t.Fatalf("Error connecting through the unix socket: %s", err)
+	}
+	defer socketClient.Close()
+
+	_, err = socketClient.GetInfo()
+	if err != nil {
+		t.Fatalf("Error from GetInfo through the unix socket: %s", err)
+	}
+	template, err := socketClient.GetBlockTemplate(harness.miningAddress, "")
+	if err != nil {
+		t.Fatalf("Error from GetBlockTemplate through the unix socket: %s", err)
+	}
+	if template.Block == nil {
+		t.Fatalf("Expected GetBlockTemplate through the unix socket to return a block")
+	}
+
+	// TCP clients must still authenticate
+	_, err = newTestRPCClient(rpcAddress1)
+	if err == nil {
+		t.Fatalf("Expected connecting over TCP without credentials to fail")
+	}
+}
@@ -103,4 +103,67 @@ func TestVirtualSelectedParentChain(t *testing.T) {
 		t.Fatalf("Unexpected last added chain block. Want: %s, got: %s",
 			chain2TipHashString, lastAddedChainBlock)
 	}
+
+	// Make sure that the removed chain blocks come with the acceptance data
+	// that they no longer hold
+	chainWithAcceptanceData, err := kaspad1.rpcClient.GetVirtualSelectedParentChainFromBlockPage(
+		chain1TipHashString, false, true, 0)
+	if err != nil {
+		t.Fatalf("GetVirtualSelectedParentChainFromBlockPage failed: %s", err)
+	}
+	if len(chainWithAcceptanceData.RemovedAcceptanceData) != blockAmountToMine {
+		t.Fatalf("Unexpected length of RemovedAcceptanceData. Want: %d, got: %d",
+			blockAmountToMine, len(chainWithAcceptanceData.RemovedAcceptanceData))
+	}
+	for i, removedAcceptanceData := range chainWithAcceptanceData.RemovedAcceptanceData {
+		if removedAcceptanceData.AcceptingBlockHash != chainWithAcceptanceData.RemovedChainBlockHashes[i] {
+			t.Fatalf("Unexpected accepting block of RemovedAcceptanceData[%d]. Want: %s, got: %s",
+				i, chainWithAcceptanceData.RemovedChainBlockHashes[i], removedAcceptanceData.AcceptingBlockHash)
+		}
+	}
+
+	// Page through the chain from the genesis and make sure that the
+	// pages add up to the whole chain
+	genesisHashString := consensushashing.BlockHash(kaspad1.config.NetParams().GenesisBlock).String()
+	wholeChain, err := kaspad1.rpcClient.GetVirtualSelectedParentChainFromBlock(genesisHashString, false)
+	if err != nil {
+		t.Fatalf("GetVirtualSelectedParentChainFromBlock failed: %s", err)
+	}
+	if wholeChain.NextStartHash != "" {
+		t.Fatalf("Unexpected NextStartHash for an unlimited request: %s", wholeChain.NextStartHash)
+	}
+	const pageLimit = 4
+	var pagedChain []string
+	startHash := genesisHashString
+	for startHash != "" {
+		page, err := kaspad1.rpcClient.GetVirtualSelectedParentChainFromBlockPage(startHash, false, true, pageLimit)
+		if err != nil {
+			t.Fatalf("GetVirtualSelectedParentChainFromBlockPage failed: %s", err)
+		}
+		if len(page.AddedChainBlockHashes) > pageLimit {
+			t.Fatalf("Got %d added chain blocks in a page limited to %d", len(page.AddedChainBlockHashes), pageLimit)
+		}
+		if len(page.AcceptanceData) != len(page.AddedChainBlockHashes) {
+			t.Fatalf("Unexpected length of AcceptanceData. Want: %d, got: %d",
+				len(page.AddedChainBlockHashes), len(page.AcceptanceData))
+		}
+		for i, acceptanceData := range page.AcceptanceData {
+			if acceptanceData.AcceptingBlockHash != page.AddedChainBlockHashes[i] {
+				t.Fatalf("Unexpected accepting block of AcceptanceData[%d]. Want: %s, got: %s",
+					i, page.AddedChainBlockHashes[i], acceptanceData.AcceptingBlockHash)
+			}
+		}
+		pagedChain = append(pagedChain, page.AddedChainBlockHashes...)
+		startHash = page.NextStartHash
+	}
+	if len(pagedChain) != len(wholeChain.AddedChainBlockHashes) {
+		t.Fatalf("Unexpected length of the paged chain. Want: %d, got: %d",
+			len(wholeChain.AddedChainBlockHashes), len(pagedChain))
+	}
+	for i, blockHash := range pagedChain {
+		if blockHash != wholeChain.AddedChainBlockHashes[i] {
+			t.Fatalf("Unexpected block %d in the paged chain. Want: %s, got: %s",
+				i, wholeChain.AddedChainBlockHashes[i], blockHash)
+		}
+	}
 }
@@ -0,0 +1,112 @@
+package integration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+)
+
+func TestSetBan(t *testing.T) {
+	harnesses, teardown := setupHarnesses(t, []*harnessParams{
+		{
+			p2pAddress:              p2pAddress1,
+			rpcAddress:              rpcAddress1,
+			miningAddress:           miningAddress1,
+			miningAddressPrivateKey: miningAddress1PrivateKey,
+		},
+		{
+			p2pAddress:              p2pAddress2,
+			rpcAddress:              rpcAddress2,
+			miningAddress:           miningAddress2,
+			miningAddressPrivateKey: miningAddress2PrivateKey,
+		},
+	})
+	defer teardown()
+	appHarness1, appHarness2 := harnesses[0], harnesses[1]
+
+	connect(t, appHarness1, appHarness2)
+	mineNextBlock(t, appHarness2)
+
+	connectedPeerInfo, err := appHarness1.rpcClient.GetConnectedPeerInfo()
+	if err != nil {
+		t.Fatalf("Error getting connected peer info: %s", err)
+	}
+	if len(connectedPeerInfo.Infos) != 1 {
+		t.Fatalf("Unexpected amount of connected peers. Want: 1, got: %d", len(connectedPeerInfo.Infos))
+	}
+	peerInfo := connectedPeerInfo.Infos[0]
+	if peerInfo.NetGroup == "" {
+		t.Errorf("Expected the peer's net group to be set")
+	}
+	if peerInfo.ProtocolVersion == 0 {
+		t.Errorf("Expected the peer's protocol version to be set")
+	}
+	var versionStatistics *appmessage.PeerMessageStatistics
+	for _, statistics := range peerInfo.MessageStatistics {
+		if statistics.Command == appmessage.CmdVersion.String() {
+			versionStatistics = statistics
+		}
+	}
+	if versionStatistics == nil {
+		t.Fatalf("Expected the message statistics to contain the version handshake")
+	}
+	if versionStatistics.MessagesSent != 1 || versionStatistics.MessagesReceived != 1 ||
+		versionStatistics.BytesSent == 0 || versionStatistics.BytesReceived == 0 {
+
+		t.Errorf("Unexpected version message statistics: %+v", versionStatistics)
+	}
+
+	// Both harnesses listen on localhost, so this bans appHarness2 as well
+	_, err = appHarness1.rpcClient.SetBan("127.0.0.0/8", 60, false)
+	if err != nil {
+		t.Fatalf("Error banning subnet: %s", err)
+	}
+	_, err = appHarness1.rpcClient.SetBan("1.2.3.4", 0, false)
+	if err != nil {
+		t.Fatalf("Error banning IP: %s", err)
+	}
+	_, err = appHarness1.rpcClient.SetBan("not a subnet", 0, false)
+	if err == nil {
+		t.Fatalf("Expected banning an invalid subnet to fail")
+	}
+
+	listBannedResponse, err := appHarness1.rpcClient.ListBanned()
+	if err != nil {
+		t.Fatalf("Error listing bans: %s", err)
+	}
+	if len(listBannedResponse.BannedSubnets) != 2 {
+		t.Fatalf("Unexpected amount of bans. Want: 2, got: %d", len(listBannedResponse.BannedSubnets))
+	}
+	if listBannedResponse.BannedSubnets[0].Subnet != "1.2.3.4/32" ||
+		listBannedResponse.BannedSubnets[1].Subnet != "127.0.0.0/8" {
+
+		t.Fatalf("Unexpected bans: %s, %s",
+			listBannedResponse.BannedSubnets[0].Subnet, listBannedResponse.BannedSubnets[1].Subnet)
+	}
+	subnetBan := listBannedResponse.BannedSubnets[1]
+	if subnetBan.BannedUntil-subnetBan.BannedAt != time.Minute.Milliseconds() {
+		t.Fatalf("Unexpected ban duration. Want: %d, got: %d",
+			time.Minute.Milliseconds(), subnetBan.BannedUntil-subnetBan.BannedAt)
+	}
+
+	_, err = appHarness1.rpcClient.SetBan("127.0.0.0/8", 0, true)
+	if err != nil {
+		t.Fatalf("Error unbanning subnet: %s", err)
+	}
+	_, err = appHarness1.rpcClient.SetBan("127.0.0.0/8", 0, true)
+	if err == nil {
+		t.Fatalf("Expected unbanning a subnet that isn't banned to fail")
+	}
+	_, err = appHarness1.rpcClient.ClearBanned()
+	if err != nil {
+		t.Fatalf("Error clearing bans: %s", err)
+	}
+	listBannedResponse, err = appHarness1.rpcClient.ListBanned()
+	if err != nil {
+		t.Fatalf("Error listing bans: %s", err)
+	}
+	if len(listBannedResponse.BannedSubnets) != 0 {
+		t.Fatalf("Expected no bans after ClearBanned, but got %d", len(listBannedResponse.BannedSubnets))
+	}
+}
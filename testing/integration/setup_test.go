@@ -1,14 +1,17 @@
 package integration
 
 import (
+	"crypto/tls"
 	"path/filepath"
 	"testing"
 
+	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
 	"github.com/kaspanet/kaspad/domain/dagconfig"
 
 	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
 
 	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/metricsdb"
 
 	"github.com/kaspanet/kaspad/app"
 	"github.com/kaspanet/kaspad/infrastructure/config"
@@ -19,22 +22,78 @@ type appHarness struct {
 	rpcClient               *testRPCClient
 	p2pAddress              string
 	rpcAddress              string
+	apiAddress              string
+	restAddress             string
 	miningAddress           string
 	miningAddressPrivateKey string
 	config                  *config.Config
 	database                database.Database
 	utxoIndex               bool
+	addressIndex            bool
+	databaseMetrics         bool
+	importBlocks            string
+	compactAfterPruning     bool
 	overrideDAGParams       *dagconfig.Params
+	rpcAuth                 []string
+	rpcUser                 string
+	rpcPassword             string
+	rpcUnixSocket           string
+	rpcTLS                  *rpcTLSParams
+	validatedSubnetworks    subnetworks.Filter
+	indexedSubnetworks      subnetworks.Filter
+	relayedSubnetworks      subnetworks.Filter
 }
 
 type harnessParams struct {
 	p2pAddress              string
 	rpcAddress              string
+	apiAddress              string
+	restAddress             string
 	miningAddress           string
 	miningAddressPrivateKey string
 	utxoIndex               bool
+	addressIndex            bool
 	overrideDAGParams       *dagconfig.Params
 	protocolVersion         uint32
+
+	// databaseMetrics is the --dbmetrics value of the node
+	databaseMetrics bool
+
+	// importBlocks is the --importblocks value of the node
+	importBlocks string
+
+	// compactAfterPruning is the --compactafterpruning value of the node
+	compactAfterPruning bool
+
+	// rpcAuth are the --rpcauth values of the node. The harness' RPC
+	// client authenticates with rpcUser and rpcPassword
+	rpcAuth     []string
+	rpcUser     string
+	rpcPassword string
+
+	// rpcUnixSocket is the --rpcunixsocket value of the node
+	rpcUnixSocket string
+
+	// rpcTLS makes the node require RPC client certificates. It's nil if
+	// RPC isn't served over TLS.
+	rpcTLS *rpcTLSParams
+
+	// validatedSubnetworks, indexedSubnetworks and relayedSubnetworks are the
+	// --validatesubnetwork, --indexsubnetwork and --relaysubnetwork values of
+	// the node. They're nil if the node serves every subnetwork
+	validatedSubnetworks subnetworks.Filter
+	indexedSubnetworks   subnetworks.Filter
+	relayedSubnetworks   subnetworks.Filter
+}
+
+// rpcTLSParams are the RPC TLS options of a node, and the TLS
+// configuration that the harness' RPC client connects with
+type rpcTLSParams struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+	certAuth     []string
+	clientConfig *tls.Config
 }
 
 // setupHarness creates a single appHarness with given parameters
@@ -42,10 +101,24 @@ func setupHarness(t *testing.T, params *harnessParams) (harness *appHarness, tea
 	harness = &appHarness{
 		p2pAddress:              params.p2pAddress,
 		rpcAddress:              params.rpcAddress,
+		apiAddress:              params.apiAddress,
+		restAddress:             params.restAddress,
 		miningAddress:           params.miningAddress,
 		miningAddressPrivateKey: params.miningAddressPrivateKey,
 		utxoIndex:               params.utxoIndex,
+		addressIndex:            params.addressIndex,
+		databaseMetrics:         params.databaseMetrics,
+		importBlocks:            params.importBlocks,
+		compactAfterPruning:     params.compactAfterPruning,
 		overrideDAGParams:       params.overrideDAGParams,
+		rpcAuth:                 params.rpcAuth,
+		rpcUser:                 params.rpcUser,
+		rpcPassword:             params.rpcPassword,
+		rpcUnixSocket:           params.rpcUnixSocket,
+		rpcTLS:                  params.rpcTLS,
+		validatedSubnetworks:    params.validatedSubnetworks,
+		indexedSubnetworks:      params.indexedSubnetworks,
+		relayedSubnetworks:      params.relayedSubnetworks,
 	}
 
 	setConfig(t, harness, params.protocolVersion)
@@ -101,8 +174,13 @@ func standardSetup(t *testing.T) (appHarness1, appHarness2, appHarness3 *appHarn
 }
 
 func setRPCClient(t *testing.T, harness *appHarness) {
+	var clientTLSConfig *tls.Config
+	if harness.rpcTLS != nil {
+		clientTLSConfig = harness.rpcTLS.clientConfig
+	}
 	var err error
-	harness.rpcClient, err = newTestRPCClient(harness.rpcAddress)
+	harness.rpcClient, err = newTestRPCClientWithTLS(harness.rpcAddress, harness.rpcUser, harness.rpcPassword,
+		clientTLSConfig)
 	if err != nil {
 		t.Fatalf("Error getting RPC client %+v", err)
 	}
@@ -132,6 +210,11 @@ func setDatabaseContext(t *testing.T, harness *appHarness) {
 	if err != nil {
 		t.Fatalf("Error openning database: %+v", err)
 	}
+	if harness.config.DbMetrics {
+		// Every store of every consensus instance is recorded
+		// separately, as kaspad does
+		harness.database = metricsdb.New(harness.database, 2)
+	}
 }
 
 func openDB(cfg *config.Config) (database.Database, error) {
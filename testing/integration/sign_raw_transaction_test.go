@@ -0,0 +1,103 @@
+package integration
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/constants"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+)
+
+func TestSignRawTransaction(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	// skip the first block because it's paying to genesis script
+	mineNextBlock(t, harness)
+	// use the second block to get money to pay with
+	secondBlock := mineNextBlock(t, harness)
+	// Mine BlockCoinbaseMaturity more blocks for our money to mature
+	for i := uint64(0); i < harness.config.ActiveNetParams.BlockCoinbaseMaturity; i++ {
+		mineNextBlock(t, harness)
+	}
+
+	coinbase := secondBlock.Transactions[transactionhelper.CoinbaseTransactionIndex]
+	coinbaseOutput := coinbase.Outputs[0]
+	outpoint := &appmessage.RPCOutpoint{
+		TransactionID: consensushashing.TransactionID(coinbase).String(),
+		Index:         0,
+	}
+	unsignedTransaction := &appmessage.RPCTransaction{
+		Version: constants.MaxTransactionVersion,
+		Inputs: []*appmessage.RPCTransactionInput{{
+			PreviousOutpoint: outpoint,
+			SigOpCount:       1,
+		}},
+		Outputs: []*appmessage.RPCTransactionOutput{{
+			Amount: coinbaseOutput.Value - 1000,
+			ScriptPublicKey: &appmessage.RPCScriptPublicKey{
+				Script:  hex.EncodeToString(coinbaseOutput.ScriptPublicKey.Script),
+				Version: coinbaseOutput.ScriptPublicKey.Version,
+			},
+		}},
+		SubnetworkID: "0000000000000000000000000000000000000000",
+	}
+	previousOutputs := []*appmessage.SignRawTransactionPreviousOutput{{
+		Outpoint: outpoint,
+		UTXOEntry: &appmessage.RPCUTXOEntry{
+			Amount: coinbaseOutput.Value,
+			ScriptPublicKey: &appmessage.RPCScriptPublicKey{
+				Script:  hex.EncodeToString(coinbaseOutput.ScriptPublicKey.Script),
+				Version: coinbaseOutput.ScriptPublicKey.Version,
+			},
+			IsCoinbase: true,
+		},
+	}}
+
+	// A key that doesn't match the previous output can't sign the input
+	wrongKeyResponse, err := harness.rpcClient.SignRawTransaction(unsignedTransaction,
+		[]string{miningAddress3PrivateKey}, previousOutputs)
+	if err != nil {
+		t.Fatalf("Error from SignRawTransaction: %s", err)
+	}
+	if wrongKeyResponse.IsComplete || len(wrongKeyResponse.InputErrors) != 1 ||
+		wrongKeyResponse.InputErrors[0].InputIndex != 0 {
+
+		t.Fatalf("Expected signing with the wrong key to fail for input 0, but got: %+v", wrongKeyResponse.InputErrors)
+	}
+
+	// Without its previous output, the input can't be signed either
+	missingPreviousOutputResponse, err := harness.rpcClient.SignRawTransaction(unsignedTransaction,
+		[]string{miningAddress1PrivateKey}, nil)
+	if err != nil {
+		t.Fatalf("Error from SignRawTransaction: %s", err)
+	}
+	if missingPreviousOutputResponse.IsComplete || len(missingPreviousOutputResponse.InputErrors) != 1 {
+		t.Fatalf("Expected signing without the previous output to fail, but got: %+v",
+			missingPreviousOutputResponse.InputErrors)
+	}
+
+	signResponse, err := harness.rpcClient.SignRawTransaction(unsignedTransaction,
+		[]string{miningAddress3PrivateKey, miningAddress1PrivateKey}, previousOutputs)
+	if err != nil {
+		t.Fatalf("Error from SignRawTransaction: %s", err)
+	}
+	if !signResponse.IsComplete || len(signResponse.InputErrors) != 0 {
+		t.Fatalf("Expected the transaction to be completely signed, but got: %+v", signResponse.InputErrors)
+	}
+	if signResponse.Transaction.Inputs[0].SignatureScript == "" {
+		t.Fatalf("Expected the input to have a signature script")
+	}
+
+	_, err = harness.rpcClient.SubmitTransaction(signResponse.Transaction, false)
+	if err != nil {
+		t.Fatalf("Error submitting the signed transaction: %s", err)
+	}
+}
@@ -0,0 +1,101 @@
+package integration
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/subnetworks"
+)
+
+func TestSubnetworkRoles(t *testing.T) {
+	firstSubnetworkID := &externalapi.DomainSubnetworkID{100}
+	secondSubnetworkID := &externalapi.DomainSubnetworkID{101}
+	unservedSubnetworkID := &externalapi.DomainSubnetworkID{102}
+
+	harnesses, teardown := setupHarnesses(t, []*harnessParams{
+		{
+			p2pAddress:              p2pAddress1,
+			rpcAddress:              rpcAddress1,
+			miningAddress:           miningAddress1,
+			miningAddressPrivateKey: miningAddress1PrivateKey,
+			addressIndex:            true,
+			validatedSubnetworks: subnetworks.NewFilter(
+				[]*externalapi.DomainSubnetworkID{firstSubnetworkID, secondSubnetworkID}),
+			indexedSubnetworks: subnetworks.NewFilter([]*externalapi.DomainSubnetworkID{firstSubnetworkID}),
+			relayedSubnetworks: subnetworks.NewFilter([]*externalapi.DomainSubnetworkID{secondSubnetworkID}),
+		},
+		{
+			p2pAddress:              p2pAddress2,
+			rpcAddress:              rpcAddress2,
+			miningAddress:           miningAddress2,
+			miningAddressPrivateKey: miningAddress2PrivateKey,
+		},
+	})
+	defer teardown()
+	partialNode, fullNode := harnesses[0], harnesses[1]
+
+	connect(t, partialNode, fullNode)
+
+	expectedRoles := &appmessage.RPCSubnetworkRoles{
+		ValidatedSubnetworkIDs: []string{firstSubnetworkID.String(), secondSubnetworkID.String()},
+		IndexedSubnetworkIDs:   []string{firstSubnetworkID.String()},
+		RelayedSubnetworkIDs:   []string{secondSubnetworkID.String()},
+	}
+
+	// Each node reports its own roles
+	partialNodeInfo, err := partialNode.rpcClient.GetInfo()
+	if err != nil {
+		t.Fatalf("GetInfo: %+v", err)
+	}
+	if !reflect.DeepEqual(partialNodeInfo.SubnetworkRoles, expectedRoles) {
+		t.Fatalf("Unexpected subnetwork roles. Want: %+v, got: %+v", expectedRoles, partialNodeInfo.SubnetworkRoles)
+	}
+	fullNodeInfo, err := fullNode.rpcClient.GetInfo()
+	if err != nil {
+		t.Fatalf("GetInfo: %+v", err)
+	}
+	if fullNodeInfo.SubnetworkRoles != nil {
+		t.Fatalf("Expected a node that serves every subnetwork to report no subnetwork roles, but got %+v",
+			fullNodeInfo.SubnetworkRoles)
+	}
+
+	// The roles are advertised to peers in the version message
+	connectedPeerInfo, err := fullNode.rpcClient.GetConnectedPeerInfo()
+	if err != nil {
+		t.Fatalf("GetConnectedPeerInfo: %+v", err)
+	}
+	if len(connectedPeerInfo.Infos) != 1 {
+		t.Fatalf("Expected 1 connected peer, but got %d", len(connectedPeerInfo.Infos))
+	}
+	if !reflect.DeepEqual(connectedPeerInfo.Infos[0].SubnetworkRoles, expectedRoles) {
+		t.Fatalf("Unexpected peer subnetwork roles. Want: %+v, got: %+v",
+			expectedRoles, connectedPeerInfo.Infos[0].SubnetworkRoles)
+	}
+	connectedPeerInfo, err = partialNode.rpcClient.GetConnectedPeerInfo()
+	if err != nil {
+		t.Fatalf("GetConnectedPeerInfo: %+v", err)
+	}
+	if len(connectedPeerInfo.Infos) != 1 || connectedPeerInfo.Infos[0].SubnetworkRoles != nil {
+		t.Fatalf("Expected the full node to advertise no subnetwork roles, but got %+v", connectedPeerInfo.Infos)
+	}
+
+	// Queries for a subnetwork that the node doesn't validate are rejected
+	_, err = partialNode.rpcClient.GetSubnetwork(unservedSubnetworkID.String())
+	if err == nil || !strings.Contains(err.Error(), "doesn't validate") {
+		t.Fatalf("Expected GetSubnetwork of an unvalidated subnetwork to fail, but got %+v", err)
+	}
+	err = partialNode.rpcClient.RegisterForNewTransactionsNotifications(nil, unservedSubnetworkID.String(), 0,
+		func(*appmessage.NewTransactionsNotificationMessage) {})
+	if err == nil || !strings.Contains(err.Error(), "doesn't validate") {
+		t.Fatalf("Expected RegisterForNewTransactionsNotifications of an unvalidated subnetwork to fail, "+
+			"but got %+v", err)
+	}
+	err = partialNode.rpcClient.RegisterForNewTransactionsNotifications(nil, firstSubnetworkID.String(), 0,
+		func(*appmessage.NewTransactionsNotificationMessage) {})
+	if err != nil {
+		t.Fatalf("RegisterForNewTransactionsNotifications: %+v", err)
+	}
+}
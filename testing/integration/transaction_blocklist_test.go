@@ -0,0 +1,99 @@
+package integration
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+)
+
+func TestTransactionBlocklist(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	// skip the first block because it's paying to genesis script
+	mineNextBlock(t, harness)
+	// use the second block to get money to pay with
+	secondBlock := mineNextBlock(t, harness)
+	for i := uint64(0); i < harness.config.ActiveNetParams.BlockCoinbaseMaturity; i++ {
+		mineNextBlock(t, harness)
+	}
+
+	msgTx := generateTx(t, secondBlock.Transactions[transactionhelper.CoinbaseTransactionIndex], harness, harness)
+	domainTransaction := appmessage.MsgTxToDomainTransaction(msgTx)
+	transactionID := consensushashing.TransactionID(domainTransaction).String()
+	rpcTransaction := appmessage.DomainTransactionToRPCTransaction(domainTransaction)
+
+	transactionIDEntry := &appmessage.TransactionBlocklistEntry{TransactionID: transactionID, Reason: "test"}
+	addResponse, err := harness.rpcClient.AddTransactionBlocklistEntries(
+		[]*appmessage.TransactionBlocklistEntry{transactionIDEntry})
+	if err != nil {
+		t.Fatalf("Error adding a blocklist entry: %s", err)
+	}
+	if len(addResponse.RemovedTransactionIDs) != 0 {
+		t.Fatalf("Expected no transactions to be removed from the empty mempool, but got %s",
+			addResponse.RemovedTransactionIDs)
+	}
+	_, err = harness.rpcClient.SubmitTransaction(rpcTransaction, false)
+	if err == nil || !strings.Contains(err.Error(), "blocklisted") {
+		t.Fatalf("Expected submitting a blocklisted transaction to fail, but got %v", err)
+	}
+
+	getResponse, err := harness.rpcClient.GetTransactionBlocklist()
+	if err != nil {
+		t.Fatalf("Error getting the blocklist: %s", err)
+	}
+	if len(getResponse.Entries) != 1 || getResponse.Entries[0].TransactionID != transactionID ||
+		getResponse.Entries[0].Reason != transactionIDEntry.Reason || getResponse.Entries[0].AddedAt == 0 {
+		t.Fatalf("Unexpected blocklist %+v", getResponse.Entries)
+	}
+
+	removeResponse, err := harness.rpcClient.RemoveTransactionBlocklistEntries(
+		[]*appmessage.TransactionBlocklistEntry{transactionIDEntry})
+	if err != nil {
+		t.Fatalf("Error removing a blocklist entry: %s", err)
+	}
+	if removeResponse.RemovedCount != 1 {
+		t.Fatalf("Expected 1 entry to be removed, but got %d", removeResponse.RemovedCount)
+	}
+	_, err = harness.rpcClient.SubmitTransaction(rpcTransaction, false)
+	if err != nil {
+		t.Fatalf("Error submitting a transaction that's no longer blocklisted: %s", err)
+	}
+
+	// Blocklisting the script public key the transaction pays to removes it from the mempool
+	scriptPublicKey := domainTransaction.Outputs[0].ScriptPublicKey
+	scriptPublicKeyEntry := &appmessage.TransactionBlocklistEntry{
+		ScriptPublicKey: &appmessage.RPCScriptPublicKey{
+			Version: scriptPublicKey.Version,
+			Script:  hex.EncodeToString(scriptPublicKey.Script),
+		},
+	}
+	addResponse, err = harness.rpcClient.AddTransactionBlocklistEntries(
+		[]*appmessage.TransactionBlocklistEntry{scriptPublicKeyEntry})
+	if err != nil {
+		t.Fatalf("Error adding a blocklist entry: %s", err)
+	}
+	if len(addResponse.RemovedTransactionIDs) != 1 || addResponse.RemovedTransactionIDs[0] != transactionID {
+		t.Fatalf("Expected %s to be removed from the mempool, but got %s", transactionID,
+			addResponse.RemovedTransactionIDs)
+	}
+	_, err = harness.rpcClient.GetMempoolEntry(transactionID, true, false)
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Fatalf("Expected the blocklisted transaction not to be found in the mempool, but got %v", err)
+	}
+
+	_, err = harness.rpcClient.AddTransactionBlocklistEntries(
+		[]*appmessage.TransactionBlocklistEntry{{Reason: "neither a transaction ID nor a script public key"}})
+	if err == nil {
+		t.Fatalf("Expected adding an empty blocklist entry to fail")
+	}
+}
@@ -3,6 +3,7 @@ package integration
 import (
 	"encoding/hex"
 	"testing"
+	"time"
 
 	"github.com/kaspanet/kaspad/domain/consensus/utils/utxo"
 
@@ -226,3 +227,98 @@ func buildTransactionForUTXOIndexTest(t *testing.T, entry *appmessage.UTXOsByAdd
 	domainTransaction := appmessage.MsgTxToDomainTransaction(msgTx)
 	return appmessage.DomainTransactionToRPCTransaction(domainTransaction)
 }
+
+func TestUTXOIndexMempoolNotifications(t *testing.T) {
+	harnessParams := &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+		utxoIndex:               true,
+	}
+	kaspad, teardown := setupHarness(t, harnessParams)
+	defer teardown()
+
+	// Mine enough blocks for the first coinbase to mature
+	const blockAmountToMine = 101
+	for i := 0; i < blockAmountToMine; i++ {
+		mineNextBlock(t, kaspad)
+	}
+	utxosByAddressesResponse, err := kaspad.rpcClient.GetUTXOsByAddresses([]string{miningAddress1})
+	if err != nil {
+		t.Fatalf("Failed to get UTXOs: %s", err)
+	}
+	spentEntry := utxosByAddressesResponse.Entries[0]
+	for _, entry := range utxosByAddressesResponse.Entries {
+		if entry.UTXOEntry.BlockDAAScore < spentEntry.UTXOEntry.BlockDAAScore {
+			spentEntry = entry
+		}
+	}
+
+	// Register without mempool changes, and then include them without reconnecting
+	onUTXOsChangedChan := make(chan *appmessage.UTXOsChangedNotificationMessage, 10)
+	err = kaspad.rpcClient.RegisterForUTXOsChangedNotifications([]string{miningAddress1}, func(
+		notification *appmessage.UTXOsChangedNotificationMessage) {
+
+		onUTXOsChangedChan <- notification
+	})
+	if err != nil {
+		t.Fatalf("Failed to register for UTXO change notifications: %s", err)
+	}
+	err = kaspad.rpcClient.ModifyUTXOsChangedNotifications([]string{miningAddress1}, true)
+	if err != nil {
+		t.Fatalf("Failed to modify the UTXO change notifications: %s", err)
+	}
+
+	rpcTransaction := buildTransactionForUTXOIndexTest(t, spentEntry)
+	_, err = kaspad.rpcClient.SubmitTransaction(rpcTransaction, false)
+	if err != nil {
+		t.Fatalf("Error submitting transaction: %s", err)
+	}
+
+	// The transaction is only in the mempool, so only mempool changes are expected
+	notification := receiveUTXOsChangedNotification(t, onUTXOsChangedChan)
+	if len(notification.Added) != 0 || len(notification.Removed) != 0 {
+		t.Fatalf("Unexpected accepted UTXO changes for a mempool transaction")
+	}
+	if len(notification.MempoolRemoved) != 1 || *notification.MempoolRemoved[0].Outpoint != *spentEntry.Outpoint {
+		t.Fatalf("Unexpected mempool removed UTXOs: %+v", notification.MempoolRemoved)
+	}
+	if len(notification.MempoolAdded) != 1 || notification.MempoolAdded[0].UTXOEntry.BlockDAAScore != 0 {
+		t.Fatalf("Unexpected mempool added UTXOs: %+v", notification.MempoolAdded)
+	}
+
+	// Once the transaction is accepted, the same UTXO is reported as removed
+	mineNextBlock(t, kaspad)
+	notification = receiveUTXOsChangedNotification(t, onUTXOsChangedChan)
+	if len(notification.MempoolAdded) != 0 || len(notification.MempoolRemoved) != 0 {
+		t.Fatalf("Unexpected mempool UTXO changes for an accepted transaction")
+	}
+	if len(notification.Removed) != 1 || *notification.Removed[0].Outpoint != *spentEntry.Outpoint {
+		t.Fatalf("Unexpected removed UTXOs: %+v", notification.Removed)
+	}
+
+	// Removing the last address stops the notifications entirely
+	err = kaspad.rpcClient.StopNotifyingUTXOsChanged([]string{miningAddress1})
+	if err != nil {
+		t.Fatalf("Failed to stop the UTXO change notifications: %s", err)
+	}
+	mineNextBlock(t, kaspad)
+	select {
+	case notification := <-onUTXOsChangedChan:
+		t.Fatalf("Unexpected UTXO change notification after unsubscribing: %+v", notification)
+	case <-time.After(time.Second):
+	}
+}
+
+func receiveUTXOsChangedNotification(t *testing.T,
+	onUTXOsChangedChan chan *appmessage.UTXOsChangedNotificationMessage) *appmessage.UTXOsChangedNotificationMessage {
+
+	select {
+	case notification := <-onUTXOsChangedChan:
+		return notification
+	case <-time.After(defaultTimeout):
+		t.Fatalf("Timed out waiting for a UTXO change notification")
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package integration
+
+import (
+	"io/ioutil"
+	"math/rand"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+)
+
+func TestUTXOSetSnapshot(t *testing.T) {
+	// Use the parameters of TestIBDWithPruning, so that the syncer's
+	// pruning point moves and the syncee syncs with a headers proof
+	overrideDAGParams := dagconfig.SimnetParams
+	overrideDAGParams.TargetTimePerBlock = time.Minute
+	overrideDAGParams.FinalityDuration = 2 * overrideDAGParams.TargetTimePerBlock
+	overrideDAGParams.K = 0
+	overrideDAGParams.PruningProofM = 20
+
+	harnesses, teardown := setupHarnesses(t, []*harnessParams{
+		{
+			p2pAddress:              p2pAddress1,
+			rpcAddress:              rpcAddress1,
+			miningAddress:           miningAddress1,
+			miningAddressPrivateKey: miningAddress1PrivateKey,
+			overrideDAGParams:       &overrideDAGParams,
+			utxoIndex:               true,
+		},
+		{
+			p2pAddress:              p2pAddress2,
+			rpcAddress:              rpcAddress2,
+			miningAddress:           miningAddress2,
+			miningAddressPrivateKey: miningAddress2PrivateKey,
+			overrideDAGParams:       &overrideDAGParams,
+			utxoIndex:               true,
+		},
+	})
+	defer teardown()
+	syncer, syncee := harnesses[0], harnesses[1]
+
+	rd := rand.New(rand.NewSource(time.Now().UnixNano()))
+	for i := 0; i < 100; i++ {
+		mineNextBlockWithMockTimestamps(t, syncer, rd)
+	}
+
+	snapshotPath := filepath.Join(randomDirectory(t), "utxoset")
+	dumpResponse, err := syncer.rpcClient.DumpUTXOSet(snapshotPath, false)
+	if err != nil {
+		t.Fatalf("Error dumping the UTXO set: %s", err)
+	}
+	syncerInfo, err := syncer.rpcClient.GetBlockDAGInfo()
+	if err != nil {
+		t.Fatalf("Error getting the syncer's DAG info: %s", err)
+	}
+	if dumpResponse.PruningPointHash != syncerInfo.PruningPointHash {
+		t.Fatalf("Unexpected pruning point of the dumped UTXO set. Want: %s, got: %s",
+			syncerInfo.PruningPointHash, dumpResponse.PruningPointHash)
+	}
+	if dumpResponse.UTXOCount == 0 {
+		t.Fatalf("Expected the dumped UTXO set to contain the coinbase outputs")
+	}
+	_, err = syncer.rpcClient.DumpUTXOSet(snapshotPath, false)
+	if err == nil {
+		t.Fatalf("Expected dumping the UTXO set over an existing file to fail")
+	}
+
+	// Dump the UTXO set again as a job, and check that its result
+	// matches the one of the call that waited for it
+	stoppedJobs := make(chan *appmessage.RPCJob, 1)
+	err = syncer.rpcClient.RegisterForJobProgressNotifications(func(notification *appmessage.JobProgressNotificationMessage) {
+		if notification.Job.State != appmessage.RPCJobStateRunning {
+			stoppedJobs <- notification.Job
+		}
+	})
+	if err != nil {
+		t.Fatalf("RegisterForJobProgressNotifications: %s", err)
+	}
+	jobResponse, err := syncer.rpcClient.DumpUTXOSet(filepath.Join(randomDirectory(t), "utxoset"), true)
+	if err != nil {
+		t.Fatalf("Error starting a UTXO set dump job: %s", err)
+	}
+	if jobResponse.JobID == 0 || jobResponse.UTXOCount != 0 {
+		t.Fatalf("Unexpected response of a UTXO set dump job: %+v", jobResponse)
+	}
+	var stoppedJob *appmessage.RPCJob
+	select {
+	case stoppedJob = <-stoppedJobs:
+	case <-time.After(defaultTimeout):
+		t.Fatalf("Timed out waiting for the UTXO set dump job to stop")
+	}
+	getJobsResponse, err := syncer.rpcClient.GetJobs([]uint64{jobResponse.JobID})
+	if err != nil {
+		t.Fatalf("GetJobs: %s", err)
+	}
+	if !reflect.DeepEqual(getJobsResponse.Jobs, []*appmessage.RPCJob{stoppedJob}) {
+		t.Fatalf("Unexpected jobs. Want: %+v, got: %+v", stoppedJob, getJobsResponse.Jobs)
+	}
+	if stoppedJob.ID != jobResponse.JobID || stoppedJob.Kind != appmessage.RPCJobKindDumpUTXOSet ||
+		stoppedJob.State != appmessage.RPCJobStateCompleted || stoppedJob.Processed != dumpResponse.UTXOCount {
+
+		t.Fatalf("Unexpected stopped job: %+v", stoppedJob)
+	}
+	if stoppedJob.UTXOSetResult == nil || stoppedJob.UTXOSetResult.PruningPointHash != dumpResponse.PruningPointHash ||
+		stoppedJob.UTXOSetResult.UTXOCount != dumpResponse.UTXOCount {
+
+		t.Fatalf("Unexpected result of the UTXO set dump job: %+v", stoppedJob.UTXOSetResult)
+	}
+	_, err = syncer.rpcClient.GetJobs([]uint64{jobResponse.JobID + 1})
+	if err == nil {
+		t.Fatalf("Expected getting an unknown job to fail")
+	}
+
+	snapshotBytes, err := ioutil.ReadFile(snapshotPath)
+	if err != nil {
+		t.Fatalf("Error reading the dumped UTXO set: %s", err)
+	}
+	snapshotBytes[len(snapshotBytes)-1]++
+	tamperedSnapshotPath := snapshotPath + ".tampered"
+	err = ioutil.WriteFile(tamperedSnapshotPath, snapshotBytes, 0600)
+	if err != nil {
+		t.Fatalf("Error writing the tampered UTXO set: %s", err)
+	}
+	_, err = syncee.rpcClient.ImportUTXOSet(tamperedSnapshotPath, false)
+	if err == nil {
+		t.Fatalf("Expected importing a tampered UTXO set to fail")
+	}
+
+	importResponse, err := syncee.rpcClient.ImportUTXOSet(snapshotPath, false)
+	if err != nil {
+		t.Fatalf("Error importing the UTXO set: %s", err)
+	}
+	if importResponse.PruningPointHash != dumpResponse.PruningPointHash ||
+		importResponse.UTXOCommitment != dumpResponse.UTXOCommitment ||
+		importResponse.UTXOCount != dumpResponse.UTXOCount {
+
+		t.Fatalf("Unexpected import response. Want: %+v, got: %+v", dumpResponse, importResponse)
+	}
+
+	utxoSetOverridden := make(chan struct{})
+	err = syncee.rpcClient.RegisterPruningPointUTXOSetNotifications(func() {
+		close(utxoSetOverridden)
+	})
+	if err != nil {
+		t.Fatalf("RegisterPruningPointUTXOSetNotifications: %+v", err)
+	}
+
+	connect(t, syncer, syncee)
+	select {
+	case <-utxoSetOverridden:
+	case <-time.After(defaultTimeout):
+		t.Fatalf("Timed out waiting for the syncee to import the pruning point UTXO set")
+	}
+
+	start := time.Now()
+	for {
+		syncerInfo, err := syncer.rpcClient.GetBlockDAGInfo()
+		if err != nil {
+			t.Fatalf("Error getting the syncer's DAG info: %s", err)
+		}
+		synceeInfo, err := syncee.rpcClient.GetBlockDAGInfo()
+		if err != nil {
+			t.Fatalf("Error getting the syncee's DAG info: %s", err)
+		}
+		if reflect.DeepEqual(syncerInfo.TipHashes, synceeInfo.TipHashes) {
+			if synceeInfo.PruningPointHash != dumpResponse.PruningPointHash {
+				t.Fatalf("Unexpected syncee pruning point. Want: %s, got: %s",
+					dumpResponse.PruningPointHash, synceeInfo.PruningPointHash)
+			}
+			break
+		}
+		if time.Since(start) > defaultTimeout {
+			t.Fatalf("Timed out waiting for IBD to finish")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// The syncee's UTXO index is rebuilt from the imported UTXO set
+	syncerBalance, err := syncer.rpcClient.GetBalanceByAddress(miningAddress1)
+	if err != nil {
+		t.Fatalf("Error getting the syncer's balance: %s", err)
+	}
+	synceeBalance, err := syncee.rpcClient.GetBalanceByAddress(miningAddress1)
+	if err != nil {
+		t.Fatalf("Error getting the syncee's balance: %s", err)
+	}
+	if synceeBalance.Balance != syncerBalance.Balance {
+		t.Fatalf("Unexpected syncee balance. Want: %d, got: %d", syncerBalance.Balance, synceeBalance.Balance)
+	}
+}
@@ -0,0 +1,107 @@
+package integration
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/pow"
+)
+
+func solveWork(t *testing.T, work *appmessage.RPCWork) uint64 {
+	prePowHash, err := externalapi.NewDomainHashFromString(work.PrePowHash)
+	if err != nil {
+		t.Fatalf("Error parsing pre-PoW hash: %s", err)
+	}
+	target, ok := new(big.Int).SetString(work.Target, 16)
+	if !ok {
+		t.Fatalf("Error parsing target %s", work.Target)
+	}
+
+	state := pow.NewStateFromPrePowHash(prePowHash, work.Timestamp, target)
+	for !state.CheckProofOfWork() {
+		state.IncrementNonce()
+	}
+	return state.Nonce
+}
+
+func TestGetAndSubmitWork(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	getWorkResponse, err := harness.rpcClient.GetWork(harness.miningAddress, "integration")
+	if err != nil {
+		t.Fatalf("Error getting work: %+v", err)
+	}
+	work := getWorkResponse.Work
+
+	// A nonce that doesn't satisfy the target should be rejected
+	unsolvedNonce := solveWork(t, work)
+	for {
+		unsolvedNonce++
+		prePowHash, _ := externalapi.NewDomainHashFromString(work.PrePowHash)
+		target, _ := new(big.Int).SetString(work.Target, 16)
+		state := pow.NewStateFromPrePowHash(prePowHash, work.Timestamp, target)
+		state.Nonce = unsolvedNonce
+		if !state.CheckProofOfWork() {
+			break
+		}
+	}
+	_, err = harness.rpcClient.SubmitWork(work.ID, unsolvedNonce)
+	if err == nil {
+		t.Fatalf("Expected an error when submitting a nonce that doesn't satisfy the target")
+	}
+
+	_, err = harness.rpcClient.SubmitWork("unknown", 0)
+	if err == nil {
+		t.Fatalf("Expected an error when submitting unknown work")
+	}
+
+	submitWorkResponse, err := harness.rpcClient.SubmitWork(work.ID, solveWork(t, work))
+	if err != nil {
+		t.Fatalf("Error submitting work: %+v", err)
+	}
+
+	_, err = harness.rpcClient.GetBlock(submitWorkResponse.BlockHash, false)
+	if err != nil {
+		t.Fatalf("Error getting the block of the submitted work: %+v", err)
+	}
+}
+
+func TestWorkNotifications(t *testing.T) {
+	harness, teardown := setupHarness(t, &harnessParams{
+		p2pAddress:              p2pAddress1,
+		rpcAddress:              rpcAddress1,
+		miningAddress:           miningAddress1,
+		miningAddressPrivateKey: miningAddress1PrivateKey,
+	})
+	defer teardown()
+
+	workChan := make(chan *appmessage.RPCWork, 10)
+	err := harness.rpcClient.RegisterForWorkNotifications(harness.miningAddress, "integration",
+		func(notification *appmessage.WorkNotificationMessage) {
+			workChan <- notification.Work
+		})
+	if err != nil {
+		t.Fatalf("Error registering for work notifications: %+v", err)
+	}
+
+	mineNextBlock(t, harness)
+
+	select {
+	case work := <-workChan:
+		_, err := harness.rpcClient.SubmitWork(work.ID, solveWork(t, work))
+		if err != nil {
+			t.Fatalf("Error submitting streamed work: %+v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("Timed out waiting for a work notification")
+	}
+}
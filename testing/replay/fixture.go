@@ -0,0 +1,316 @@
+package replay
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"os"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/kaspanet/kaspad/domain/consensus/database/serialization"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool"
+	"github.com/pkg/errors"
+)
+
+// fileMagic identifies fixture files
+var fileMagic = [4]byte{'K', 'R', 'P', 'L'}
+
+const fileVersion uint16 = 1
+
+// maxSerializedObjectSize, maxObjectCount and maxNetworkNameLength protect
+// against allocating huge buffers when reading a corrupt fixture file
+const (
+	maxSerializedObjectSize = 1 << 24
+	maxObjectCount          = 1 << 24
+	maxNetworkNameLength    = 1 << 8
+)
+
+// WriteFile writes the fixture to filePath, replacing the file if it exists.
+// The fixture is written gzip-compressed: the blocks and transactions are
+// serialized like they're stored in the database, and the expected outcome
+// follows them.
+func WriteFile(filePath string, fixture *Fixture) error {
+	// Write to a temporary file so that a failed write never leaves a
+	// partial fixture behind
+	temporaryFilePath := filePath + ".tmp"
+	file, err := os.OpenFile(temporaryFilePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	isSuccessful := false
+	defer func() {
+		if !isSuccessful {
+			file.Close()
+			os.Remove(temporaryFilePath)
+		}
+	}()
+
+	bufferedWriter := bufio.NewWriter(file)
+	err = Write(bufferedWriter, fixture)
+	if err != nil {
+		return err
+	}
+	err = bufferedWriter.Flush()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	err = file.Close()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	err = os.Rename(temporaryFilePath, filePath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	isSuccessful = true
+	return nil
+}
+
+// ReadFile reads the fixture file at filePath
+func ReadFile(filePath string) (*Fixture, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer file.Close()
+
+	fixture, err := Read(bufio.NewReader(file))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading the fixture %s", filePath)
+	}
+	return fixture, nil
+}
+
+// Write writes the fixture to writer in the format of WriteFile
+func Write(writer io.Writer, fixture *Fixture) error {
+	gzipWriter := gzip.NewWriter(writer)
+	fixtureWriter := &fixtureWriter{writer: gzipWriter}
+
+	fixtureWriter.write(fileMagic[:])
+	fixtureWriter.writeUint(fileVersion)
+	fixtureWriter.writeUint(uint16(len(fixture.Network)))
+	fixtureWriter.write([]byte(fixture.Network))
+	fixtureWriter.write(fixture.GenesisHash.ByteSlice())
+
+	fixtureWriter.writeUint(uint32(len(fixture.Blocks)))
+	for _, block := range fixture.Blocks {
+		fixtureWriter.writeMessage(serialization.DomainBlockToDbBlock(block))
+	}
+	fixtureWriter.writeUint(uint32(len(fixture.Transactions)))
+	for _, transaction := range fixture.Transactions {
+		fixtureWriter.writeMessage(serialization.DomainTransactionToDbTransaction(transaction))
+	}
+
+	hasExpectedOutcome := fixture.Expected != nil
+	fixtureWriter.writeUint(hasExpectedOutcome)
+	if hasExpectedOutcome {
+		expected := fixture.Expected
+		fixtureWriter.writeUint(uint32(len(expected.BlockStatuses)))
+		for _, blockStatus := range expected.BlockStatuses {
+			fixtureWriter.writeUint(uint8(blockStatus))
+		}
+		fixtureWriter.write(expected.VirtualSelectedParent.ByteSlice())
+		fixtureWriter.writeUint(expected.VirtualBlueScore)
+		fixtureWriter.writeUint(expected.VirtualDAAScore)
+		fixtureWriter.writeUint(expected.AcceptedTransactionCount)
+		fixtureWriter.write(expected.AcceptanceDigest.ByteSlice())
+		fixtureWriter.writeUint(uint32(len(expected.TransactionVerdicts)))
+		for _, verdict := range expected.TransactionVerdicts {
+			fixtureWriter.writeUint(uint8(verdict))
+		}
+	}
+	if fixtureWriter.err != nil {
+		return fixtureWriter.err
+	}
+	return errors.WithStack(gzipWriter.Close())
+}
+
+// Read reads a fixture from reader in the format of WriteFile
+func Read(reader io.Reader) (*Fixture, error) {
+	gzipReader, err := gzip.NewReader(reader)
+	if err != nil {
+		return nil, errors.Wrap(err, "not a fixture file")
+	}
+	defer gzipReader.Close()
+	fixtureReader := &fixtureReader{reader: gzipReader}
+
+	var magic [4]byte
+	fixtureReader.read(magic[:])
+	if fixtureReader.err == nil && magic != fileMagic {
+		return nil, errors.New("not a fixture file")
+	}
+	var version uint16
+	fixtureReader.readUint(&version)
+	if fixtureReader.err == nil && version != fileVersion {
+		return nil, errors.Errorf("unsupported fixture version %d", version)
+	}
+
+	fixture := &Fixture{}
+	var networkNameLength uint16
+	fixtureReader.readUint(&networkNameLength)
+	if networkNameLength > maxNetworkNameLength {
+		return nil, errors.Errorf("network name of %d bytes is too long", networkNameLength)
+	}
+	networkName := make([]byte, networkNameLength)
+	fixtureReader.read(networkName)
+	fixture.Network = string(networkName)
+	fixture.GenesisHash = fixtureReader.readHash()
+
+	blockCount := fixtureReader.readCount()
+	fixture.Blocks = make([]*externalapi.DomainBlock, 0, blockCount)
+	for i := uint32(0); i < blockCount && fixtureReader.err == nil; i++ {
+		dbBlock := &serialization.DbBlock{}
+		fixtureReader.readMessage(dbBlock)
+		if fixtureReader.err != nil {
+			break
+		}
+		block, err := serialization.DbBlockToDomainBlock(dbBlock)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error deserializing block %d", i)
+		}
+		fixture.Blocks = append(fixture.Blocks, block)
+	}
+	transactionCount := fixtureReader.readCount()
+	fixture.Transactions = make([]*externalapi.DomainTransaction, 0, transactionCount)
+	for i := uint32(0); i < transactionCount && fixtureReader.err == nil; i++ {
+		dbTransaction := &serialization.DbTransaction{}
+		fixtureReader.readMessage(dbTransaction)
+		if fixtureReader.err != nil {
+			break
+		}
+		transaction, err := serialization.DbTransactionToDomainTransaction(dbTransaction)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error deserializing transaction %d", i)
+		}
+		fixture.Transactions = append(fixture.Transactions, transaction)
+	}
+
+	var hasExpectedOutcome bool
+	fixtureReader.readUint(&hasExpectedOutcome)
+	if hasExpectedOutcome {
+		expected := &Outcome{}
+		blockStatusCount := fixtureReader.readCount()
+		blockStatuses := make([]uint8, blockStatusCount)
+		fixtureReader.readUint(blockStatuses)
+		expected.BlockStatuses = make([]externalapi.BlockStatus, len(blockStatuses))
+		for i, blockStatus := range blockStatuses {
+			expected.BlockStatuses[i] = externalapi.BlockStatus(blockStatus)
+		}
+		expected.VirtualSelectedParent = fixtureReader.readHash()
+		fixtureReader.readUint(&expected.VirtualBlueScore)
+		fixtureReader.readUint(&expected.VirtualDAAScore)
+		fixtureReader.readUint(&expected.AcceptedTransactionCount)
+		expected.AcceptanceDigest = fixtureReader.readHash()
+		verdictCount := fixtureReader.readCount()
+		verdicts := make([]uint8, verdictCount)
+		fixtureReader.readUint(verdicts)
+		expected.TransactionVerdicts = make([]mempool.RejectCode, len(verdicts))
+		for i, verdict := range verdicts {
+			expected.TransactionVerdicts[i] = mempool.RejectCode(verdict)
+		}
+		fixture.Expected = expected
+	}
+	if fixtureReader.err != nil {
+		return nil, fixtureReader.err
+	}
+	return fixture, nil
+}
+
+// fixtureWriter writes the fields of a fixture, and keeps the first error
+// that occurs so that it's checked once after all the fields are written
+type fixtureWriter struct {
+	writer io.Writer
+	err    error
+}
+
+func (w *fixtureWriter) write(data []byte) {
+	if w.err != nil {
+		return
+	}
+	_, err := w.writer.Write(data)
+	w.err = errors.WithStack(err)
+}
+
+func (w *fixtureWriter) writeUint(value interface{}) {
+	if w.err != nil {
+		return
+	}
+	w.err = errors.WithStack(binary.Write(w.writer, binary.LittleEndian, value))
+}
+
+func (w *fixtureWriter) writeMessage(message proto.Message) {
+	if w.err != nil {
+		return
+	}
+	serializedMessage, err := proto.Marshal(message)
+	if err != nil {
+		w.err = errors.WithStack(err)
+		return
+	}
+	w.writeUint(uint32(len(serializedMessage)))
+	w.write(serializedMessage)
+}
+
+// fixtureReader reads the fields of a fixture, and keeps the first error
+// that occurs. Once an error occurs, the remaining reads return zero values
+type fixtureReader struct {
+	reader io.Reader
+	err    error
+}
+
+func (r *fixtureReader) read(data []byte) {
+	if r.err != nil {
+		return
+	}
+	_, err := io.ReadFull(r.reader, data)
+	if err != nil {
+		r.err = errors.WithStack(io.ErrUnexpectedEOF)
+	}
+}
+
+func (r *fixtureReader) readUint(value interface{}) {
+	if r.err != nil {
+		return
+	}
+	err := binary.Read(r.reader, binary.LittleEndian, value)
+	if err != nil {
+		r.err = errors.WithStack(io.ErrUnexpectedEOF)
+	}
+}
+
+func (r *fixtureReader) readCount() uint32 {
+	var count uint32
+	r.readUint(&count)
+	if r.err == nil && count > maxObjectCount {
+		r.err = errors.Errorf("count %d is too big", count)
+	}
+	if r.err != nil {
+		return 0
+	}
+	return count
+}
+
+func (r *fixtureReader) readHash() *externalapi.DomainHash {
+	var hashBytes [externalapi.DomainHashSize]byte
+	r.read(hashBytes[:])
+	return externalapi.NewDomainHashFromByteArray(&hashBytes)
+}
+
+func (r *fixtureReader) readMessage(message proto.Message) {
+	var serializedMessageLength uint32
+	r.readUint(&serializedMessageLength)
+	if r.err == nil && serializedMessageLength > maxSerializedObjectSize {
+		r.err = errors.Errorf("object of %d bytes is too big", serializedMessageLength)
+	}
+	if r.err != nil {
+		return
+	}
+	serializedMessage := make([]byte, serializedMessageLength)
+	r.read(serializedMessage)
+	if r.err != nil {
+		return
+	}
+	r.err = errors.WithStack(proto.Unmarshal(serializedMessage, message))
+}
@@ -0,0 +1,190 @@
+package replay
+
+import (
+	"github.com/kaspanet/kaspad/app/appmessage"
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/infrastructure/network/rpcclient"
+	"github.com/pkg/errors"
+)
+
+// hashesChunkSize is the amount of block hashes that are read from
+// consensus at a time. It MUST be >= MergeSetSizeLimit + 1
+const hashesChunkSize = 1 << 10
+
+// Record returns a fixture of the blocks in the past of highHash in the
+// given consensus, including highHash, and of the given transactions. The
+// expected outcome of the fixture is set by replaying it with config, which
+// must be the config of the recorded consensus.
+func Record(source externalapi.Consensus, config *consensus.Config, highHash *externalapi.DomainHash,
+	transactions []*externalapi.DomainTransaction) (*Fixture, error) {
+
+	fixture := &Fixture{
+		Network:      config.Name,
+		GenesisHash:  config.GenesisHash,
+		Transactions: transactions,
+	}
+	for lowHash := config.GenesisHash; !lowHash.Equal(highHash); {
+		blockHashes, actualHighHash, err := source.GetHashesBetween(lowHash, highHash, hashesChunkSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, blockHash := range blockHashes {
+			block, found, err := source.GetBlock(blockHash)
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				return nil, errors.Errorf("the body of block %s is missing", blockHash)
+			}
+			fixture.Blocks = append(fixture.Blocks, block)
+		}
+		lowHash = actualHighHash
+	}
+
+	return fixture, setExpectedOutcome(fixture, config)
+}
+
+// RecordFromRPC returns a fixture of the blocks of the node that client is
+// connected to, and of the transactions in its mempool. The node must have
+// the bodies of all its blocks since the genesis, so a node of a network
+// whose early blocks were pruned must be an archival node.
+//
+// If maxBlocks isn't zero, only the first maxBlocks blocks, in topological
+// order, are recorded. The expected outcome of the fixture is set by
+// replaying it with config, which must be the config of the node's network.
+func RecordFromRPC(client *rpcclient.RPCClient, config *consensus.Config, maxBlocks int) (*Fixture, error) {
+	fixture := &Fixture{
+		Network:     config.Name,
+		GenesisHash: config.GenesisHash,
+	}
+
+	recordedBlockHashes := map[externalapi.DomainHash]struct{}{*config.GenesisHash: {}}
+	lowHash := ""
+	for {
+		response, err := client.GetBlocks(lowHash, true, true)
+		if err != nil {
+			return nil, err
+		}
+		for _, rpcBlock := range response.Blocks {
+			block, err := appmessage.RPCBlockToDomainBlock(rpcBlock)
+			if err != nil {
+				return nil, err
+			}
+			blockHash := consensushashing.BlockHash(block)
+			if _, ok := recordedBlockHashes[*blockHash]; ok {
+				continue
+			}
+			if len(block.Transactions) == 0 {
+				return nil, errors.Errorf("the body of block %s is missing", blockHash)
+			}
+			recordedBlockHashes[*blockHash] = struct{}{}
+			fixture.Blocks = append(fixture.Blocks, block)
+		}
+		if response.NextLowHash == "" || (maxBlocks > 0 && len(fixture.Blocks) >= maxBlocks) {
+			break
+		}
+		lowHash = response.NextLowHash
+	}
+
+	// The anticone of the virtual selected parent is returned after the
+	// rest of the blocks, so the blocks are sorted before they're cut,
+	// which keeps the parents of every recorded block in the fixture
+	fixture.Blocks = sortBlocksTopologically(fixture.Blocks)
+	if maxBlocks > 0 && len(fixture.Blocks) > maxBlocks {
+		fixture.Blocks = fixture.Blocks[:maxBlocks]
+	} else {
+		mempoolEntries, err := client.GetMempoolEntries(false, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range mempoolEntries.Entries {
+			transaction, err := appmessage.RPCTransactionToDomainTransaction(entry.Transaction)
+			if err != nil {
+				return nil, err
+			}
+			fixture.Transactions = append(fixture.Transactions, transaction)
+		}
+		fixture.Transactions = sortTransactionsTopologically(fixture.Transactions)
+	}
+
+	return fixture, setExpectedOutcome(fixture, config)
+}
+
+func setExpectedOutcome(fixture *Fixture, config *consensus.Config) error {
+	outcome, err := Replay(fixture, config)
+	if err != nil {
+		return err
+	}
+	fixture.Expected = outcome
+	return nil
+}
+
+// sortBlocksTopologically returns the given blocks ordered such that every
+// block comes after its parents, keeping the given order where it allows
+func sortBlocksTopologically(blocks []*externalapi.DomainBlock) []*externalapi.DomainBlock {
+	indexesByHash := make(map[externalapi.DomainHash]int, len(blocks))
+	for i, block := range blocks {
+		indexesByHash[*consensushashing.BlockHash(block)] = i
+	}
+	sortedIndexes := sortTopologically(len(blocks), func(i int) []int {
+		var parentIndexes []int
+		for _, parentHash := range blocks[i].Header.DirectParents() {
+			if parentIndex, ok := indexesByHash[*parentHash]; ok {
+				parentIndexes = append(parentIndexes, parentIndex)
+			}
+		}
+		return parentIndexes
+	})
+	sortedBlocks := make([]*externalapi.DomainBlock, len(blocks))
+	for i, index := range sortedIndexes {
+		sortedBlocks[i] = blocks[index]
+	}
+	return sortedBlocks
+}
+
+// sortTransactionsTopologically returns the given transactions ordered such
+// that every transaction comes after the transactions it spends
+func sortTransactionsTopologically(transactions []*externalapi.DomainTransaction) []*externalapi.DomainTransaction {
+	indexesByID := make(map[externalapi.DomainTransactionID]int, len(transactions))
+	for i, transaction := range transactions {
+		indexesByID[*consensushashing.TransactionID(transaction)] = i
+	}
+	sortedIndexes := sortTopologically(len(transactions), func(i int) []int {
+		var parentIndexes []int
+		for _, input := range transactions[i].Inputs {
+			if parentIndex, ok := indexesByID[input.PreviousOutpoint.TransactionID]; ok {
+				parentIndexes = append(parentIndexes, parentIndex)
+			}
+		}
+		return parentIndexes
+	})
+	sortedTransactions := make([]*externalapi.DomainTransaction, len(transactions))
+	for i, index := range sortedIndexes {
+		sortedTransactions[i] = transactions[index]
+	}
+	return sortedTransactions
+}
+
+// sortTopologically returns the indexes of count items ordered such that
+// every item comes after the items that parentIndexes returns for it
+func sortTopologically(count int, parentIndexes func(i int) []int) []int {
+	sortedIndexes := make([]int, 0, count)
+	isVisited := make([]bool, count)
+	var visit func(i int)
+	visit = func(i int) {
+		if isVisited[i] {
+			return
+		}
+		isVisited[i] = true
+		for _, parentIndex := range parentIndexes(i) {
+			visit(parentIndex)
+		}
+		sortedIndexes = append(sortedIndexes, i)
+	}
+	for i := 0; i < count; i++ {
+		visit(i)
+	}
+	return sortedIndexes
+}
@@ -0,0 +1,281 @@
+// Package replay records real network blocks and transactions into
+// fixtures, and replays them deterministically through the full validation
+// pipeline of a new consensus and mempool, so that consensus and performance
+// changes are tested against realistic data rather than hand-built blocks.
+//
+// Replaying starts from an empty consensus, so a fixture holds every block
+// from the genesis of its network up to the block it was recorded at.
+// Recording a network whose early blocks were pruned therefore requires an
+// archival node.
+//
+// The outcome of replaying a fixture is computed once, when the fixture is
+// recorded, and is stored along with it. Replay reports any later change in
+// the outcome as ErrOutcomeMismatch.
+package replay
+
+import (
+	"fmt"
+
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/consensusreference"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/domain/miningmanager"
+	"github.com/kaspanet/kaspad/domain/miningmanager/mempool"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+)
+
+// Accepted is the verdict of the mempool on a transaction that it accepted
+const Accepted mempool.RejectCode = 0
+
+// ErrOutcomeMismatch indicates that replaying a fixture resulted in a
+// different outcome than the one it was recorded with
+var ErrOutcomeMismatch = errors.New("the outcome of the replay doesn't match the recorded outcome")
+
+// Fixture is a recording of the blocks of a network, and of transactions
+// that weren't in any block yet when it was recorded
+type Fixture struct {
+	Network     string
+	GenesisHash *externalapi.DomainHash
+
+	// Blocks are the blocks of the fixture, in topological order. The
+	// genesis isn't included
+	Blocks []*externalapi.DomainBlock
+
+	// Transactions are validated by the mempool after all the blocks are
+	// inserted, in this order
+	Transactions []*externalapi.DomainTransaction
+
+	// Expected is the outcome that replaying the fixture resulted in when
+	// it was recorded
+	Expected *Outcome
+}
+
+// Outcome is the result of replaying a fixture
+type Outcome struct {
+	// BlockStatuses are the statuses of the blocks of the fixture after
+	// all of them are inserted, in the order of the blocks. Blocks that
+	// failed validation have StatusInvalid
+	BlockStatuses []externalapi.BlockStatus
+
+	VirtualSelectedParent *externalapi.DomainHash
+	VirtualBlueScore      uint64
+	VirtualDAAScore       uint64
+
+	// AcceptedTransactionCount is the number of transactions that the
+	// virtual selected parent chain accepted, and AcceptanceDigest is a hash
+	// of their IDs along with the chain blocks that accepted them
+	AcceptedTransactionCount uint64
+	AcceptanceDigest         *externalapi.DomainHash
+
+	// TransactionVerdicts are the verdicts of the mempool on the
+	// transactions of the fixture, in the order of the transactions
+	TransactionVerdicts []mempool.RejectCode
+}
+
+// DefaultConsensusConfig returns the consensus config of the standard
+// network that the fixture was recorded on
+func DefaultConsensusConfig(fixture *Fixture) (*consensus.Config, error) {
+	for _, params := range []*dagconfig.Params{&dagconfig.MainnetParams, &dagconfig.TestnetParams,
+		&dagconfig.SimnetParams, &dagconfig.DevnetParams} {
+
+		if params.Name == fixture.Network && params.GenesisHash.Equal(fixture.GenesisHash) {
+			return &consensus.Config{Params: *params}, nil
+		}
+	}
+	return nil, errors.Errorf("the fixture wasn't recorded on a standard network: "+
+		"network %s with the genesis %s", fixture.Network, fixture.GenesisHash)
+}
+
+// Replay inserts the blocks of the fixture into a new consensus created with
+// config, in the order they were recorded, and then validates its
+// transactions with a new mempool. It returns the outcome of the replay, and
+// ErrOutcomeMismatch if the fixture was recorded with a different outcome.
+func Replay(fixture *Fixture, config *consensus.Config) (*Outcome, error) {
+	if config.Name != fixture.Network || !config.GenesisHash.Equal(fixture.GenesisHash) {
+		return nil, errors.Errorf("the fixture was recorded on network %s with the genesis %s, "+
+			"but the config is of network %s with the genesis %s",
+			fixture.Network, fixture.GenesisHash, config.Name, config.GenesisHash)
+	}
+
+	factory := consensus.NewFactory()
+	testConsensus, teardown, err := factory.NewTestConsensus(config, "replay")
+	if err != nil {
+		return nil, err
+	}
+	defer teardown(false)
+
+	outcome := &Outcome{
+		BlockStatuses:       make([]externalapi.BlockStatus, len(fixture.Blocks)),
+		TransactionVerdicts: make([]mempool.RejectCode, len(fixture.Transactions)),
+	}
+
+	isInvalid := make([]bool, len(fixture.Blocks))
+	for i, block := range fixture.Blocks {
+		err := testConsensus.ValidateAndInsertBlock(block, true)
+		if err != nil {
+			if !errors.As(err, &ruleerrors.RuleError{}) {
+				return nil, err
+			}
+			isInvalid[i] = true
+		}
+	}
+	for i, block := range fixture.Blocks {
+		if isInvalid[i] {
+			outcome.BlockStatuses[i] = externalapi.StatusInvalid
+			continue
+		}
+		blockInfo, err := testConsensus.GetBlockInfo(consensushashing.BlockHash(block))
+		if err != nil {
+			return nil, err
+		}
+		outcome.BlockStatuses[i] = blockInfo.BlockStatus
+	}
+
+	virtualInfo, err := testConsensus.GetVirtualInfo()
+	if err != nil {
+		return nil, err
+	}
+	outcome.VirtualSelectedParent, err = testConsensus.GetVirtualSelectedParent()
+	if err != nil {
+		return nil, err
+	}
+	outcome.VirtualBlueScore = virtualInfo.BlueScore
+	outcome.VirtualDAAScore = virtualInfo.DAAScore
+	outcome.AcceptedTransactionCount, outcome.AcceptanceDigest, err = digestAcceptance(testConsensus, config.GenesisHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var consensusInstance externalapi.Consensus = testConsensus
+	consensusPointer := &consensusInstance
+	miningManager := miningmanager.NewFactory().NewMiningManager(consensusreference.NewConsensusReference(&consensusPointer),
+		&config.Params, mempool.DefaultConfig(&config.Params))
+	for i, transaction := range fixture.Transactions {
+		outcome.TransactionVerdicts[i], err = mempoolVerdict(miningManager, transaction.Clone())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if fixture.Expected != nil {
+		err = compareOutcomes(fixture, fixture.Expected, outcome)
+		if err != nil {
+			return outcome, err
+		}
+	}
+	return outcome, nil
+}
+
+// digestAcceptance hashes the IDs of the transactions that the virtual
+// selected parent chain accepted, in the order they were accepted
+func digestAcceptance(consensus externalapi.Consensus, genesisHash *externalapi.DomainHash) (
+	acceptedTransactionCount uint64, digest *externalapi.DomainHash, err error) {
+
+	chain, err := consensus.GetVirtualSelectedParentChainFromBlock(genesisHash)
+	if err != nil {
+		return 0, nil, err
+	}
+	hasher, err := blake2b.New256(nil)
+	if err != nil {
+		return 0, nil, errors.WithStack(err)
+	}
+	for _, chainBlockHash := range chain.Added {
+		acceptanceData, err := consensus.GetBlockAcceptanceData(chainBlockHash)
+		if err != nil {
+			return 0, nil, err
+		}
+		hasher.Write(chainBlockHash.ByteSlice())
+		for _, blockAcceptanceData := range acceptanceData {
+			for _, transactionAcceptanceData := range blockAcceptanceData.TransactionAcceptanceData {
+				if !transactionAcceptanceData.IsAccepted {
+					continue
+				}
+				hasher.Write(consensushashing.TransactionID(transactionAcceptanceData.Transaction).ByteSlice())
+				acceptedTransactionCount++
+			}
+		}
+	}
+	digest, err = externalapi.NewDomainHashFromByteSlice(hasher.Sum(nil))
+	if err != nil {
+		return 0, nil, err
+	}
+	return acceptedTransactionCount, digest, nil
+}
+
+func mempoolVerdict(miningManager miningmanager.MiningManager, transaction *externalapi.DomainTransaction) (
+	mempool.RejectCode, error) {
+
+	_, err := miningManager.ValidateAndInsertTransaction(transaction, false, false)
+	if err == nil {
+		return Accepted, nil
+	}
+	txRuleError := &mempool.TxRuleError{}
+	if errors.As(err, txRuleError) {
+		return txRuleError.RejectCode, nil
+	}
+	if errors.As(err, &mempool.RuleError{}) {
+		return mempool.RejectInvalid, nil
+	}
+	return 0, err
+}
+
+// compareOutcomes returns ErrOutcomeMismatch, along with the first
+// difference between the outcomes, if they're different
+func compareOutcomes(fixture *Fixture, expected *Outcome, actual *Outcome) error {
+	mismatch := func(format string, args ...interface{}) error {
+		return errors.Wrap(ErrOutcomeMismatch, fmt.Sprintf(format, args...))
+	}
+
+	if len(expected.BlockStatuses) != len(actual.BlockStatuses) {
+		return mismatch("expected %d block statuses, but got %d",
+			len(expected.BlockStatuses), len(actual.BlockStatuses))
+	}
+	for i, expectedStatus := range expected.BlockStatuses {
+		if actual.BlockStatuses[i] != expectedStatus {
+			return mismatch("block %s: expected status %s, but got %s",
+				consensushashing.BlockHash(fixture.Blocks[i]), expectedStatus, actual.BlockStatuses[i])
+		}
+	}
+	if !actual.VirtualSelectedParent.Equal(expected.VirtualSelectedParent) {
+		return mismatch("expected the virtual selected parent %s, but got %s",
+			expected.VirtualSelectedParent, actual.VirtualSelectedParent)
+	}
+	if actual.VirtualBlueScore != expected.VirtualBlueScore {
+		return mismatch("expected the virtual blue score %d, but got %d",
+			expected.VirtualBlueScore, actual.VirtualBlueScore)
+	}
+	if actual.VirtualDAAScore != expected.VirtualDAAScore {
+		return mismatch("expected the virtual DAA score %d, but got %d",
+			expected.VirtualDAAScore, actual.VirtualDAAScore)
+	}
+	if actual.AcceptedTransactionCount != expected.AcceptedTransactionCount ||
+		!actual.AcceptanceDigest.Equal(expected.AcceptanceDigest) {
+
+		return mismatch("expected the chain to accept %d transactions with the digest %s, "+
+			"but it accepted %d with the digest %s", expected.AcceptedTransactionCount,
+			expected.AcceptanceDigest, actual.AcceptedTransactionCount, actual.AcceptanceDigest)
+	}
+	if len(expected.TransactionVerdicts) != len(actual.TransactionVerdicts) {
+		return mismatch("expected %d transaction verdicts, but got %d",
+			len(expected.TransactionVerdicts), len(actual.TransactionVerdicts))
+	}
+	for i, expectedVerdict := range expected.TransactionVerdicts {
+		if actual.TransactionVerdicts[i] != expectedVerdict {
+			return mismatch("transaction %s: expected the verdict %s, but got %s",
+				consensushashing.TransactionID(fixture.Transactions[i]),
+				verdictString(expectedVerdict), verdictString(actual.TransactionVerdicts[i]))
+		}
+	}
+	return nil
+}
+
+func verdictString(verdict mempool.RejectCode) string {
+	if verdict == Accepted {
+		return "ACCEPTED"
+	}
+	return verdict.String()
+}
@@ -0,0 +1,265 @@
+package replay_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/testutils"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/transactionhelper"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/testing/replay"
+	"github.com/pkg/errors"
+)
+
+// recordTestFixture builds a DAG with a merged side branch and blocks that
+// spend the coinbases of their parents, and records it along with
+// transactions that the mempool accepts and rejects
+func recordTestFixture(t testing.TB) (*replay.Fixture, *consensus.Config) {
+	consensusConfig := &consensus.Config{Params: dagconfig.SimnetParams}
+	consensusConfig.SkipProofOfWork = true
+	consensusConfig.BlockCoinbaseMaturity = 0
+
+	factory := consensus.NewFactory()
+	testConsensus, teardown, err := factory.NewTestConsensus(consensusConfig, "recordTestFixture")
+	if err != nil {
+		t.Fatalf("Error setting up consensus: %+v", err)
+	}
+	defer teardown(false)
+
+	getCoinbaseTransaction := func(blockHash *externalapi.DomainHash) *externalapi.DomainTransaction {
+		block, _, err := testConsensus.GetBlock(blockHash)
+		if err != nil {
+			t.Fatalf("GetBlock: %+v", err)
+		}
+		return block.Transactions[transactionhelper.CoinbaseTransactionIndex]
+	}
+
+	tipHash := consensusConfig.GenesisHash
+	var sideHash *externalapi.DomainHash
+	for i := 0; i < 20; i++ {
+		parentHashes := []*externalapi.DomainHash{tipHash}
+		if i == 5 {
+			parentHashes = append(parentHashes, sideHash)
+		}
+		var transactions []*externalapi.DomainTransaction
+		if i >= 2 {
+			transaction, err := testutils.CreateTransaction(getCoinbaseTransaction(tipHash), 1)
+			if err != nil {
+				t.Fatalf("CreateTransaction: %+v", err)
+			}
+			transactions = append(transactions, transaction)
+		}
+		newTipHash, _, err := testConsensus.AddBlock(parentHashes, nil, transactions)
+		if err != nil {
+			t.Fatalf("AddBlock: %+v", err)
+		}
+		if i == 2 {
+			sideHash, _, err = testConsensus.AddBlock([]*externalapi.DomainHash{tipHash}, nil, nil)
+			if err != nil {
+				t.Fatalf("AddBlock: %+v", err)
+			}
+		}
+		tipHash = newTipHash
+	}
+
+	// The mempool accepts the first transaction and the one that spends it,
+	// and rejects the transaction that spends the same output as the first
+	const fee = 10000
+	acceptedTransaction, err := testutils.CreateTransaction(getCoinbaseTransaction(tipHash), fee)
+	if err != nil {
+		t.Fatalf("CreateTransaction: %+v", err)
+	}
+	childTransaction, err := testutils.CreateTransaction(acceptedTransaction, fee)
+	if err != nil {
+		t.Fatalf("CreateTransaction: %+v", err)
+	}
+	doubleSpendTransaction, err := testutils.CreateTransaction(getCoinbaseTransaction(tipHash), fee+1)
+	if err != nil {
+		t.Fatalf("CreateTransaction: %+v", err)
+	}
+	transactions := []*externalapi.DomainTransaction{acceptedTransaction, childTransaction, doubleSpendTransaction}
+
+	fixture, err := replay.Record(testConsensus, consensusConfig, tipHash, transactions)
+	if err != nil {
+		t.Fatalf("Record: %+v", err)
+	}
+	return fixture, consensusConfig
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	fixture, consensusConfig := recordTestFixture(t)
+
+	if len(fixture.Blocks) != 21 {
+		t.Fatalf("Unexpected amount of recorded blocks. Want: %d, got: %d", 21, len(fixture.Blocks))
+	}
+	for i, blockStatus := range fixture.Expected.BlockStatuses {
+		if blockStatus == externalapi.StatusInvalid || blockStatus == externalapi.StatusDisqualifiedFromChain {
+			t.Fatalf("Unexpected status of block %d: %s", i, blockStatus)
+		}
+	}
+	if fixture.Expected.AcceptedTransactionCount <= uint64(len(fixture.Blocks)) {
+		t.Fatalf("The chain accepted only %d transactions, which doesn't include the spending transactions",
+			fixture.Expected.AcceptedTransactionCount)
+	}
+	verdicts := fixture.Expected.TransactionVerdicts
+	if len(verdicts) != 3 || verdicts[0] != replay.Accepted || verdicts[1] != replay.Accepted ||
+		verdicts[2] == replay.Accepted {
+
+		t.Fatalf("Unexpected transaction verdicts: %v", verdicts)
+	}
+
+	filePath := filepath.Join(t.TempDir(), "test.fixture")
+	err := replay.WriteFile(filePath, fixture)
+	if err != nil {
+		t.Fatalf("WriteFile: %+v", err)
+	}
+	readFixture, err := replay.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("ReadFile: %+v", err)
+	}
+	if len(readFixture.Blocks) != len(fixture.Blocks) || len(readFixture.Transactions) != len(fixture.Transactions) {
+		t.Fatalf("The read fixture has %d blocks and %d transactions, but %d blocks and %d transactions "+
+			"were written", len(readFixture.Blocks), len(readFixture.Transactions), len(fixture.Blocks),
+			len(fixture.Transactions))
+	}
+	for i, block := range fixture.Blocks {
+		if !readFixture.Blocks[i].Equal(block) {
+			t.Fatalf("Block %d of the read fixture is different than the written one", i)
+		}
+	}
+
+	outcome, err := replay.Replay(readFixture, consensusConfig)
+	if err != nil {
+		t.Fatalf("Replay: %+v", err)
+	}
+	if !outcome.AcceptanceDigest.Equal(fixture.Expected.AcceptanceDigest) {
+		t.Fatalf("Unexpected acceptance digest. Want: %s, got: %s",
+			fixture.Expected.AcceptanceDigest, outcome.AcceptanceDigest)
+	}
+
+	_, err = replay.Replay(readFixture, &consensus.Config{Params: dagconfig.MainnetParams})
+	if err == nil {
+		t.Fatalf("Replay unexpectedly accepted the config of another network")
+	}
+}
+
+func TestReplayMismatch(t *testing.T) {
+	fixture, consensusConfig := recordTestFixture(t)
+
+	changedExpected := *fixture.Expected
+	changedExpected.VirtualBlueScore++
+	changedFixture := *fixture
+	changedFixture.Expected = &changedExpected
+	_, err := replay.Replay(&changedFixture, consensusConfig)
+	if !errors.Is(err, replay.ErrOutcomeMismatch) {
+		t.Fatalf("Unexpected error for a changed virtual blue score. Want: %s, got: %+v",
+			replay.ErrOutcomeMismatch, err)
+	}
+
+	// Without its fourth block, the children of the block are rejected for
+	// missing a parent, so the replay can't reach the recorded virtual
+	changedFixture = *fixture
+	changedFixture.Blocks = append(append([]*externalapi.DomainBlock{}, fixture.Blocks[:3]...), fixture.Blocks[4:]...)
+	changedExpected = *fixture.Expected
+	changedExpected.BlockStatuses = append(append([]externalapi.BlockStatus{}, fixture.Expected.BlockStatuses[:3]...),
+		fixture.Expected.BlockStatuses[4:]...)
+	changedFixture.Expected = &changedExpected
+	outcome, err := replay.Replay(&changedFixture, consensusConfig)
+	if !errors.Is(err, replay.ErrOutcomeMismatch) {
+		t.Fatalf("Unexpected error for a missing block. Want: %s, got: %+v", replay.ErrOutcomeMismatch, err)
+	}
+	if outcome.BlockStatuses[3] != externalapi.StatusInvalid {
+		t.Fatalf("Unexpected status of the child of the missing block. Want: %s, got: %s",
+			externalapi.StatusInvalid, outcome.BlockStatuses[3])
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	fixture, _ := recordTestFixture(t)
+
+	buffer := &bytes.Buffer{}
+	err := replay.Write(buffer, fixture)
+	if err != nil {
+		t.Fatalf("Write: %+v", err)
+	}
+	serializedFixture := buffer.Bytes()
+
+	_, err = replay.Read(bytes.NewReader([]byte("not a fixture")))
+	if err == nil {
+		t.Fatalf("Read unexpectedly accepted data that isn't a fixture")
+	}
+	_, err = replay.Read(bytes.NewReader(serializedFixture[:len(serializedFixture)/2]))
+	if err == nil {
+		t.Fatalf("Read unexpectedly accepted a truncated fixture")
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Fatalf("Unexpected error for a truncated fixture. Want: %s, got: %+v", io.ErrUnexpectedEOF, err)
+	}
+	_, err = replay.ReadFile(filepath.Join(t.TempDir(), "missing.fixture"))
+	if err == nil {
+		t.Fatalf("ReadFile unexpectedly succeeded for a missing file")
+	}
+}
+
+// TestRecordedFixtures replays the fixtures in the testdata directory, which
+// are recorded from the standard networks with replay.RecordFromRPC
+func TestRecordedFixtures(t *testing.T) {
+	filePaths, err := filepath.Glob(filepath.Join("testdata", "*.fixture"))
+	if err != nil {
+		t.Fatalf("Glob: %+v", err)
+	}
+	if len(filePaths) == 0 {
+		t.Skip("There are no recorded fixtures in testdata")
+	}
+	for _, filePath := range filePaths {
+		filePath := filePath
+		t.Run(filepath.Base(filePath), func(t *testing.T) {
+			fixture, err := replay.ReadFile(filePath)
+			if err != nil {
+				t.Fatalf("ReadFile: %+v", err)
+			}
+			consensusConfig, err := replay.DefaultConsensusConfig(fixture)
+			if err != nil {
+				t.Fatalf("DefaultConsensusConfig: %+v", err)
+			}
+			_, err = replay.Replay(fixture, consensusConfig)
+			if err != nil {
+				t.Fatalf("Replay: %+v", err)
+			}
+		})
+	}
+}
+
+// BenchmarkReplay replays testdata/benchmark.fixture if it exists, and
+// otherwise a fixture that it records
+func BenchmarkReplay(b *testing.B) {
+	var fixture *replay.Fixture
+	var consensusConfig *consensus.Config
+	benchmarkFixturePath := filepath.Join("testdata", "benchmark.fixture")
+	if _, err := os.Stat(benchmarkFixturePath); err == nil {
+		fixture, err = replay.ReadFile(benchmarkFixturePath)
+		if err != nil {
+			b.Fatalf("ReadFile: %+v", err)
+		}
+		consensusConfig, err = replay.DefaultConsensusConfig(fixture)
+		if err != nil {
+			b.Fatalf("DefaultConsensusConfig: %+v", err)
+		}
+	} else {
+		fixture, consensusConfig = recordTestFixture(b)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := replay.Replay(fixture, consensusConfig)
+		if err != nil {
+			b.Fatalf("Replay: %+v", err)
+		}
+	}
+	b.ReportMetric(float64(len(fixture.Blocks)), "blocks/op")
+}
@@ -0,0 +1,99 @@
+package simulation
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+)
+
+// WaitForConvergence waits until the given nodes, or all the nodes of the
+// simulation if none are given, have the same DAG tips and the same virtual
+// selected parent
+func (s *Simulation) WaitForConvergence(timeout time.Duration, nodes ...*Node) error {
+	if len(nodes) == 0 {
+		nodes = s.Nodes()
+	}
+	return waitFor(timeout, func() (bool, string, error) {
+		return haveConverged(nodes)
+	})
+}
+
+// RequireConvergence fails t if the given nodes, or all the nodes of the
+// simulation if none are given, don't converge within timeout
+func (s *Simulation) RequireConvergence(t testing.TB, timeout time.Duration, nodes ...*Node) {
+	t.Helper()
+
+	err := s.WaitForConvergence(timeout, nodes...)
+	if err != nil {
+		t.Fatalf("The nodes didn't converge: %s", err)
+	}
+}
+
+// WaitForBlock waits until the given nodes, or all the nodes of the
+// simulation if none are given, have the block of blockHash
+func (s *Simulation) WaitForBlock(timeout time.Duration, blockHash *externalapi.DomainHash, nodes ...*Node) error {
+	if len(nodes) == 0 {
+		nodes = s.Nodes()
+	}
+	return waitFor(timeout, func() (bool, string, error) {
+		for _, node := range nodes {
+			blockInfo, err := node.Domain().Consensus().GetBlockInfo(blockHash)
+			if err != nil {
+				return false, "", err
+			}
+			if !blockInfo.HasBody() {
+				return false, fmt.Sprintf("%s doesn't have block %s", node.name, blockHash), nil
+			}
+		}
+		return true, "", nil
+	})
+}
+
+func haveConverged(nodes []*Node) (isMet bool, reason string, err error) {
+	if len(nodes) == 0 {
+		return true, "", nil
+	}
+	firstTips, firstSelectedParent, err := dagState(nodes[0])
+	if err != nil {
+		return false, "", err
+	}
+	for _, node := range nodes[1:] {
+		tips, selectedParent, err := dagState(node)
+		if err != nil {
+			return false, "", err
+		}
+		if !externalapi.HashesEqual(sortedHashes(tips), sortedHashes(firstTips)) {
+			return false, fmt.Sprintf("the tips of %s are %s, but the tips of %s are %s",
+				nodes[0].name, firstTips, node.name, tips), nil
+		}
+		if !selectedParent.Equal(firstSelectedParent) {
+			return false, fmt.Sprintf("the virtual selected parent of %s is %s, but that of %s is %s",
+				nodes[0].name, firstSelectedParent, node.name, selectedParent), nil
+		}
+	}
+	return true, "", nil
+}
+
+func dagState(node *Node) (tips []*externalapi.DomainHash, virtualSelectedParent *externalapi.DomainHash, err error) {
+	consensus := node.Domain().Consensus()
+	tips, err = consensus.Tips()
+	if err != nil {
+		return nil, nil, err
+	}
+	virtualSelectedParent, err = consensus.GetVirtualSelectedParent()
+	if err != nil {
+		return nil, nil, err
+	}
+	return tips, virtualSelectedParent, nil
+}
+
+func sortedHashes(hashes []*externalapi.DomainHash) []*externalapi.DomainHash {
+	sorted := externalapi.CloneHashes(hashes)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Less(sorted[j])
+	})
+	return sorted
+}
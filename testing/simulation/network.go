@@ -0,0 +1,376 @@
+package simulation
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// network is an in-memory transport that implements both server.Dialer and
+// server.ListenerFactory. It delays the data that is written to its
+// connections by the latency of their link, and refuses and cuts connections
+// between partitioned addresses.
+type network struct {
+	lock          sync.Mutex
+	random        *rand.Rand
+	listeners     map[string]*listener
+	connections   map[*connection]struct{}
+	nextLocalPort int
+
+	// latencies maps each link to its latency. Links that aren't in it
+	// have defaultLatency.
+	latencies      map[link]time.Duration
+	defaultLatency time.Duration
+	jitter         time.Duration
+
+	// partitions maps each IP to the partition it belongs to. IPs that
+	// aren't in it belong to partition 0.
+	partitions map[string]int
+}
+
+// link is an unordered pair of IPs
+type link struct {
+	a, b string
+}
+
+func newLink(a, b net.IP) link {
+	aString, bString := a.String(), b.String()
+	if aString > bString {
+		aString, bString = bString, aString
+	}
+	return link{a: aString, b: bString}
+}
+
+func newNetwork(random *rand.Rand) *network {
+	return &network{
+		random:        random,
+		listeners:     make(map[string]*listener),
+		connections:   make(map[*connection]struct{}),
+		nextLocalPort: 40000,
+		latencies:     make(map[link]time.Duration),
+		partitions:    make(map[string]int),
+	}
+}
+
+func (n *network) Listen(_ context.Context, _, address string) (net.Listener, error) {
+	tcpAddress, err := net.ResolveTCPAddr("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if _, ok := n.listeners[address]; ok {
+		return nil, errors.Errorf("%s is already listened on", address)
+	}
+	listener := &listener{
+		network:     n,
+		address:     tcpAddress,
+		connections: make(chan net.Conn),
+		closed:      make(chan struct{}),
+	}
+	n.listeners[address] = listener
+	return listener, nil
+}
+
+// DialContext connects to the listener of address. The local address of the
+// connection has the IP the dialing node listens on, so that partitions
+// apply to it, and a port that's unique in the network.
+func (n *network) DialContext(ctx context.Context, _, address string) (net.Conn, error) {
+	localIP, ok := ctx.Value(localIPKey{}).(net.IP)
+	if !ok {
+		return nil, errors.Errorf("the dialing node of %s is unknown", address)
+	}
+
+	n.lock.Lock()
+	listener, ok := n.listeners[address]
+	if !ok {
+		n.lock.Unlock()
+		return nil, errors.Errorf("nothing listens on %s", address)
+	}
+	if n.partitions[localIP.String()] != n.partitions[listener.address.IP.String()] {
+		n.lock.Unlock()
+		return nil, errors.Errorf("%s is in another partition than %s", address, localIP)
+	}
+	localAddress := &net.TCPAddr{IP: localIP, Port: n.nextLocalPort}
+	n.nextLocalPort++
+	n.lock.Unlock()
+
+	clientSide, serverSide := n.newConnectionPair(localAddress, listener.address)
+	select {
+	case listener.connections <- serverSide:
+		return clientSide, nil
+	case <-listener.closed:
+	case <-ctx.Done():
+	}
+	clientSide.Close()
+	serverSide.Close()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return nil, errors.Errorf("the listener of %s is closed", address)
+}
+
+// dialer dials from a single node. It tells the network which IP the
+// connections it opens come from.
+type dialer struct {
+	network *network
+	localIP net.IP
+}
+
+type localIPKey struct{}
+
+func (d *dialer) DialContext(ctx context.Context, networkName, address string) (net.Conn, error) {
+	return d.network.DialContext(context.WithValue(ctx, localIPKey{}, d.localIP), networkName, address)
+}
+
+func (n *network) newConnectionPair(clientAddress, serverAddress *net.TCPAddr) (clientSide, serverSide *connection) {
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+	linkOfPair := newLink(clientAddress.IP, serverAddress.IP)
+	clientSide = newConnection(n, linkOfPair, clientAddress, serverAddress, clientReader, clientWriter)
+	serverSide = newConnection(n, linkOfPair, serverAddress, clientAddress, serverReader, serverWriter)
+	clientSide.peer, serverSide.peer = serverSide, clientSide
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.connections[clientSide] = struct{}{}
+	n.connections[serverSide] = struct{}{}
+	return clientSide, serverSide
+}
+
+// delay returns how long data that's written now to a connection of the
+// given link takes to arrive
+func (n *network) delay(linkOfConnection link) time.Duration {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	latency, ok := n.latencies[linkOfConnection]
+	if !ok {
+		latency = n.defaultLatency
+	}
+	if n.jitter > 0 {
+		latency += time.Duration(n.random.Int63n(int64(n.jitter)))
+	}
+	return latency
+}
+
+func (n *network) setLatency(a, b net.IP, latency time.Duration) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.latencies[newLink(a, b)] = latency
+}
+
+func (n *network) setDefaultLatency(latency, jitter time.Duration) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.defaultLatency = latency
+	n.jitter = jitter
+}
+
+// partition places every group of IPs in a partition of its own, and cuts
+// the connections between partitions. IPs that aren't in any group are
+// placed together in another partition.
+func (n *network) partition(groups [][]net.IP) {
+	n.lock.Lock()
+	n.partitions = make(map[string]int)
+	for i, group := range groups {
+		for _, ip := range group {
+			n.partitions[ip.String()] = i + 1
+		}
+	}
+	var cutConnections []*connection
+	for connection := range n.connections {
+		if n.partitions[connection.linkOfConnection.a] != n.partitions[connection.linkOfConnection.b] {
+			cutConnections = append(cutConnections, connection)
+		}
+	}
+	n.lock.Unlock()
+
+	for _, connection := range cutConnections {
+		connection.Close()
+	}
+}
+
+// isReachable returns whether the IPs are in the same partition
+func (n *network) isReachable(a, b string) bool {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	return n.partitions[a] == n.partitions[b]
+}
+
+func (n *network) removeConnection(connection *connection) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	delete(n.connections, connection)
+}
+
+type listener struct {
+	network     *network
+	address     *net.TCPAddr
+	connections chan net.Conn
+	closed      chan struct{}
+	closeOnce   sync.Once
+}
+
+func (l *listener) Accept() (net.Conn, error) {
+	select {
+	case connection := <-l.connections:
+		return connection, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *listener) Close() error {
+	l.closeOnce.Do(func() {
+		close(l.closed)
+		l.network.lock.Lock()
+		delete(l.network.listeners, l.address.String())
+		l.network.lock.Unlock()
+	})
+	return nil
+}
+
+func (l *listener) Addr() net.Addr {
+	return l.address
+}
+
+// delivery is data that was written to a connection, and arrives at its peer
+// at deliveryTime
+type delivery struct {
+	data         []byte
+	deliveryTime time.Time
+}
+
+// connection is one side of an in-memory connection. Writes return at once,
+// and the written data arrives at the other side after the latency of the
+// link, in the order it was written. Connections report TCP addresses, since
+// peers are told apart by them.
+type connection struct {
+	network          *network
+	linkOfConnection link
+	localAddress     *net.TCPAddr
+	remoteAddress    *net.TCPAddr
+	reader           *io.PipeReader
+	writer           *io.PipeWriter
+	peer             *connection
+
+	deliveries       chan delivery
+	lastDeliveryTime time.Time
+	writeLock        sync.Mutex
+	closed           chan struct{}
+	closeOnce        sync.Once
+}
+
+// deliveriesBufferSize is the number of writes that may be in flight on a
+// connection before Write blocks
+const deliveriesBufferSize = 1000
+
+func newConnection(network *network, linkOfConnection link, localAddress, remoteAddress *net.TCPAddr,
+	reader *io.PipeReader, writer *io.PipeWriter) *connection {
+
+	connection := &connection{
+		network:          network,
+		linkOfConnection: linkOfConnection,
+		localAddress:     localAddress,
+		remoteAddress:    remoteAddress,
+		reader:           reader,
+		writer:           writer,
+		deliveries:       make(chan delivery, deliveriesBufferSize),
+		closed:           make(chan struct{}),
+	}
+	go connection.deliver()
+	return connection
+}
+
+func (c *connection) deliver() {
+	for {
+		select {
+		case delivery := <-c.deliveries:
+			select {
+			case <-time.After(time.Until(delivery.deliveryTime)):
+			case <-c.closed:
+				return
+			}
+			_, err := c.writer.Write(delivery.data)
+			if err != nil {
+				c.Close()
+				return
+			}
+		case <-c.closed:
+			return
+		}
+	}
+}
+
+func (c *connection) Read(data []byte) (int, error) {
+	return c.reader.Read(data)
+}
+
+func (c *connection) Write(data []byte) (int, error) {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	// Data never overtakes data that was written before it
+	deliveryTime := time.Now().Add(c.network.delay(c.linkOfConnection))
+	if deliveryTime.Before(c.lastDeliveryTime) {
+		deliveryTime = c.lastDeliveryTime
+	}
+	c.lastDeliveryTime = deliveryTime
+
+	dataCopy := make([]byte, len(data))
+	copy(dataCopy, data)
+	select {
+	case c.deliveries <- delivery{data: dataCopy, deliveryTime: deliveryTime}:
+		return len(data), nil
+	case <-c.closed:
+		return 0, net.ErrClosed
+	}
+}
+
+// Close closes both sides of the connection
+func (c *connection) Close() error {
+	isClosedNow := false
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		c.reader.Close()
+		c.writer.Close()
+		c.network.removeConnection(c)
+		isClosedNow = true
+	})
+	if isClosedNow {
+		c.peer.Close()
+	}
+	return nil
+}
+
+func (c *connection) LocalAddr() net.Addr {
+	return c.localAddress
+}
+
+func (c *connection) RemoteAddr() net.Addr {
+	return c.remoteAddress
+}
+
+func (c *connection) SetDeadline(time.Time) error {
+	return nil
+}
+
+func (c *connection) SetReadDeadline(time.Time) error {
+	return nil
+}
+
+func (c *connection) SetWriteDeadline(time.Time) error {
+	return nil
+}
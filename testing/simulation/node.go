@@ -0,0 +1,198 @@
+package simulation
+
+import (
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/app"
+	"github.com/kaspanet/kaspad/domain"
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/mining"
+	"github.com/kaspanet/kaspad/infrastructure/config"
+	"github.com/kaspanet/kaspad/infrastructure/db/database"
+	"github.com/kaspanet/kaspad/infrastructure/db/database/ldb"
+	"github.com/kaspanet/kaspad/util/mstime"
+	"github.com/pkg/errors"
+)
+
+// p2pPort is the port every node listens on. Nodes are told apart by their
+// IPs.
+const p2pPort = 16111
+
+// Node is a kaspad instance that runs in the process of the simulation, and
+// is connected to the other nodes over the simulation's in-memory network
+type Node struct {
+	name     string
+	ip       net.IP
+	appDir   string
+	config   *config.Config
+	database database.Database
+	app      *app.ComponentManager
+	random   *rand.Rand
+
+	clockSkew     time.Duration
+	clockSkewLock sync.Mutex
+	miningLock    sync.Mutex
+}
+
+func newNode(simulation *Simulation, name string, ip net.IP, random *rand.Rand) (*Node, error) {
+	appDir, err := ioutil.TempDir("", "simulation-"+name)
+	if err != nil {
+		return nil, err
+	}
+	node := &Node{
+		name:   name,
+		ip:     ip,
+		appDir: appDir,
+		random: random,
+	}
+
+	cfg := config.DefaultConfig()
+	cfg.ActiveNetParams = simulation.params
+	cfg.AppDir = appDir
+	cfg.Listeners = []string{node.Address()}
+	cfg.DisableRPC = true
+	cfg.RPCListeners = nil
+	cfg.TargetOutboundPeers = 0
+	cfg.DisableDNSSeed = true
+	cfg.AllowSubmitBlockWhenNotSynced = true
+	node.config = cfg
+
+	node.database, err = ldb.NewLevelDB(filepath.Join(appDir, "db"), 8)
+	if err != nil {
+		os.RemoveAll(appDir)
+		return nil, err
+	}
+	node.app, err = app.NewComponentManagerWithTransport(cfg, node.database, make(chan struct{}),
+		&dialer{network: simulation.network, localIP: ip}, simulation.network)
+	if err != nil {
+		node.database.Close()
+		os.RemoveAll(appDir)
+		return nil, err
+	}
+	return node, nil
+}
+
+// Name returns the name the node was added to the simulation with
+func (n *Node) Name() string {
+	return n.name
+}
+
+// Address returns the P2P address the node listens on in the simulation's
+// network
+func (n *Node) Address() string {
+	return net.JoinHostPort(n.ip.String(), strconv.Itoa(p2pPort))
+}
+
+// Domain returns the domain of the node
+func (n *Node) Domain() domain.Domain {
+	return n.app.Domain()
+}
+
+// App returns the ComponentManager of the node
+func (n *Node) App() *app.ComponentManager {
+	return n.app
+}
+
+// SetClockSkew sets how far the clock of the node is ahead of the clock of
+// the process, or behind it if clockSkew is negative.
+//
+// All the nodes of a simulation share the clock of the process, so the skew
+// applies to the timestamps of the blocks the node mines. Those are what a
+// skewed clock changes in the view of other nodes. Since the node validates
+// its blocks with the clock of the process too, a block that's further in the
+// future than the timestamp deviation tolerance allows is rejected by the
+// node that mined it as well.
+func (n *Node) SetClockSkew(clockSkew time.Duration) {
+	n.clockSkewLock.Lock()
+	defer n.clockSkewLock.Unlock()
+
+	n.clockSkew = clockSkew
+}
+
+func (n *Node) clockSkewValue() time.Duration {
+	n.clockSkewLock.Lock()
+	defer n.clockSkewLock.Unlock()
+
+	return n.clockSkew
+}
+
+// MineBlock mines a block on top of the virtual of the node, adds it to the
+// node's DAG and relays it to the node's peers
+func (n *Node) MineBlock() (*externalapi.DomainBlock, error) {
+	n.miningLock.Lock()
+	defer n.miningLock.Unlock()
+
+	coinbaseData := &externalapi.DomainCoinbaseData{
+		ScriptPublicKey: &externalapi.ScriptPublicKey{Script: nil, Version: 0},
+		ExtraData:       []byte(n.name),
+	}
+	template, _, err := n.Domain().MiningManager().GetBlockTemplate(coinbaseData)
+	if err != nil {
+		return nil, err
+	}
+
+	header := template.Header.ToMutable()
+	clockSkew := n.clockSkewValue()
+	if clockSkew != 0 {
+		virtualInfo, err := n.Domain().Consensus().GetVirtualInfo()
+		if err != nil {
+			return nil, err
+		}
+		// Like a template, a skewed block is never earlier than the
+		// past median time allows
+		timestamp := mstime.Now().UnixMilliseconds() + clockSkew.Milliseconds()
+		if timestamp <= virtualInfo.PastMedianTime {
+			timestamp = virtualInfo.PastMedianTime + 1
+		}
+		header.SetTimeInMilliseconds(timestamp)
+	}
+	block := &externalapi.DomainBlock{
+		Header:       header.ToImmutable(),
+		Transactions: template.Transactions,
+	}
+	mining.SolveBlock(block, n.random)
+
+	err = n.app.ProtocolManager().AddBlock(block)
+	if err != nil {
+		return nil, errors.Wrapf(err, "%s couldn't add the block it mined", n.name)
+	}
+	return block, nil
+}
+
+// isConnectedTo returns whether the node has an outbound connection to the
+// given node, which both nodes have completed the handshake of
+func (n *Node) isConnectedTo(other *Node) bool {
+	hasOutbound := false
+	for _, peer := range n.app.ProtocolManager().Peers() {
+		if peer.IsOutbound() && peer.Address() == other.Address() {
+			hasOutbound = true
+			break
+		}
+	}
+	if !hasOutbound {
+		return false
+	}
+	for _, peer := range other.app.ProtocolManager().Peers() {
+		peerIP, _, err := net.SplitHostPort(peer.Address())
+		if err == nil && !peer.IsOutbound() && peerIP == n.ip.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *Node) stop() error {
+	n.app.Stop()
+	err := n.database.Close()
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(n.appDir)
+}
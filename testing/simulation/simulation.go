@@ -0,0 +1,206 @@
+// Package simulation runs several kaspad nodes in a single process, connected
+// over an in-memory network whose latency and partitions are controlled by
+// the test, so that syncing and relaying can be tested deterministically.
+//
+// The randomness of a simulation, such as the jitter of its latency and the
+// nonces of the blocks its nodes mine, is drawn from the seed it's created
+// with. Goroutine scheduling isn't controlled, so tests should wait for the
+// state they expect with WaitForConvergence and WaitForConnections rather
+// than with fixed sleeps.
+package simulation
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/pkg/errors"
+)
+
+// pollInterval is how often the Wait functions check for the state they
+// wait for
+const pollInterval = 10 * time.Millisecond
+
+// disconnectionTimeout is how long nodes may take to notice that the
+// connections a partition cut are closed
+const disconnectionTimeout = 10 * time.Second
+
+// Simulation is a set of in-process nodes that are connected over an
+// in-memory network
+type Simulation struct {
+	params  *dagconfig.Params
+	random  *rand.Rand
+	network *network
+
+	nodes []*Node
+	links map[[2]*Node]struct{}
+	lock  sync.Mutex
+}
+
+// New creates a simulation whose nodes run with the given DAG params. All
+// its randomness is drawn from seed.
+func New(params *dagconfig.Params, seed int64) *Simulation {
+	random := rand.New(rand.NewSource(seed))
+	return &Simulation{
+		params:  params,
+		random:  random,
+		network: newNetwork(rand.New(rand.NewSource(random.Int63()))),
+		links:   make(map[[2]*Node]struct{}),
+	}
+}
+
+// AddNode creates a node with the given name and starts it. The node isn't
+// connected to any other node until Connect is called.
+func (s *Simulation) AddNode(name string) (*Node, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, node := range s.nodes {
+		if node.name == name {
+			return nil, errors.Errorf("a node named %s already exists", name)
+		}
+	}
+	// Nodes get consecutive IPs, so that their addresses are the same in
+	// every run
+	index := len(s.nodes) + 1
+	ip := net.IPv4(10, 0, byte(index/256), byte(index%256))
+	node, err := newNode(s, name, ip, rand.New(rand.NewSource(s.random.Int63())))
+	if err != nil {
+		return nil, err
+	}
+	node.app.Start()
+	s.nodes = append(s.nodes, node)
+	return node, nil
+}
+
+// Nodes returns the nodes of the simulation, in the order they were added
+func (s *Simulation) Nodes() []*Node {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	nodes := make([]*Node, len(s.nodes))
+	copy(nodes, s.nodes)
+	return nodes
+}
+
+// Connect makes from connect to to. The connection is permanent, so from
+// keeps retrying it, and Heal restores it at once after a partition cuts it.
+func (s *Simulation) Connect(from, to *Node) {
+	s.lock.Lock()
+	s.links[[2]*Node{from, to}] = struct{}{}
+	s.lock.Unlock()
+
+	from.app.ConnectionManager().AddConnectionRequest(to.Address(), true)
+}
+
+// SetLatency sets the latency of the link between a and b, in both
+// directions. It applies to the data that's written from now on.
+func (s *Simulation) SetLatency(a, b *Node, latency time.Duration) {
+	s.network.setLatency(a.ip, b.ip, latency)
+}
+
+// SetDefaultLatency sets the latency of the links that SetLatency wasn't
+// called for. Every write is further delayed by a random duration of up to
+// jitter.
+func (s *Simulation) SetDefaultLatency(latency, jitter time.Duration) {
+	s.network.setDefaultLatency(latency, jitter)
+}
+
+// Partition splits the network so that only nodes within the same group can
+// reach each other, and disconnects the nodes of different groups. Nodes that
+// aren't in any group can reach each other, but no node in a group. It
+// returns once every node has noticed its disconnections.
+func (s *Simulation) Partition(groups ...[]*Node) error {
+	ipGroups := make([][]net.IP, len(groups))
+	for i, group := range groups {
+		for _, node := range group {
+			ipGroups[i] = append(ipGroups[i], node.ip)
+		}
+	}
+	s.network.partition(ipGroups)
+
+	return waitFor(disconnectionTimeout, func() (bool, string, error) {
+		for _, node := range s.Nodes() {
+			for _, peer := range node.app.ProtocolManager().Peers() {
+				peerIP, _, err := net.SplitHostPort(peer.Address())
+				if err != nil {
+					return false, "", err
+				}
+				if !s.network.isReachable(node.ip.String(), peerIP) {
+					return false, fmt.Sprintf("%s is still connected to %s", node.name, peer.Address()), nil
+				}
+			}
+		}
+		return true, "", nil
+	})
+}
+
+// Heal removes the partitions of the network and reconnects the links that
+// were made with Connect, without waiting for their retry delays
+func (s *Simulation) Heal() {
+	s.network.partition(nil)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for link := range s.links {
+		from, to := link[0], link[1]
+		if !from.isConnectedTo(to) {
+			from.app.ConnectionManager().AddConnectionRequest(to.Address(), true)
+		}
+	}
+}
+
+// WaitForConnections waits until every link that was made with Connect is
+// connected
+func (s *Simulation) WaitForConnections(timeout time.Duration) error {
+	return waitFor(timeout, func() (bool, string, error) {
+		s.lock.Lock()
+		defer s.lock.Unlock()
+
+		for link := range s.links {
+			from, to := link[0], link[1]
+			if !from.isConnectedTo(to) {
+				return false, fmt.Sprintf("%s isn't connected to %s", from.name, to.name), nil
+			}
+		}
+		return true, "", nil
+	})
+}
+
+// Stop stops all the nodes of the simulation and removes their data
+func (s *Simulation) Stop() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, node := range s.nodes {
+		err := node.stop()
+		if err != nil {
+			return errors.Wrapf(err, "couldn't stop %s", node.name)
+		}
+	}
+	s.nodes = nil
+	return nil
+}
+
+// waitFor polls condition until it's met, or returns an error that includes
+// the reason condition last gave for not being met once timeout passes
+func waitFor(timeout time.Duration, condition func() (isMet bool, reason string, err error)) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		isMet, reason, err := condition()
+		if err != nil {
+			return err
+		}
+		if isMet {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return errors.Errorf("timed out after %s: %s", timeout, reason)
+		}
+		time.Sleep(pollInterval)
+	}
+}
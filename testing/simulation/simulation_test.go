@@ -0,0 +1,135 @@
+package simulation
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kaspanet/kaspad/domain/consensus/ruleerrors"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/consensushashing"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+)
+
+const timeout = 30 * time.Second
+
+func newTestSimulation(t *testing.T, nodeNames ...string) (*Simulation, []*Node) {
+	params := dagconfig.SimnetParams
+	simulation := New(&params, 1)
+	t.Cleanup(func() {
+		err := simulation.Stop()
+		if err != nil {
+			t.Errorf("Stop: %+v", err)
+		}
+	})
+
+	nodes := make([]*Node, len(nodeNames))
+	for i, name := range nodeNames {
+		var err error
+		nodes[i], err = simulation.AddNode(name)
+		if err != nil {
+			t.Fatalf("AddNode: %+v", err)
+		}
+	}
+	return simulation, nodes
+}
+
+func mineBlocks(t *testing.T, node *Node, count int) {
+	for i := 0; i < count; i++ {
+		_, err := node.MineBlock()
+		if err != nil {
+			t.Fatalf("MineBlock: %+v", err)
+		}
+	}
+}
+
+func TestRelayWithLatency(t *testing.T) {
+	simulation, nodes := newTestSimulation(t, "a", "b", "c")
+	a, b, c := nodes[0], nodes[1], nodes[2]
+	simulation.SetDefaultLatency(20*time.Millisecond, 10*time.Millisecond)
+	simulation.SetLatency(b, c, 100*time.Millisecond)
+
+	simulation.Connect(a, b)
+	simulation.Connect(b, c)
+	err := simulation.WaitForConnections(timeout)
+	if err != nil {
+		t.Fatalf("WaitForConnections: %+v", err)
+	}
+
+	block, err := a.MineBlock()
+	if err != nil {
+		t.Fatalf("MineBlock: %+v", err)
+	}
+	// c is only connected to a through b
+	err = simulation.WaitForBlock(timeout, consensushashing.BlockHash(block), c)
+	if err != nil {
+		t.Fatalf("WaitForBlock: %+v", err)
+	}
+
+	mineBlocks(t, c, 3)
+	simulation.RequireConvergence(t, timeout)
+}
+
+func TestPartitionAndHeal(t *testing.T) {
+	simulation, nodes := newTestSimulation(t, "a", "b", "c", "d")
+	a, b, c, d := nodes[0], nodes[1], nodes[2], nodes[3]
+
+	simulation.Connect(a, b)
+	simulation.Connect(b, c)
+	simulation.Connect(c, d)
+	err := simulation.WaitForConnections(timeout)
+	if err != nil {
+		t.Fatalf("WaitForConnections: %+v", err)
+	}
+	mineBlocks(t, a, 2)
+	simulation.RequireConvergence(t, timeout)
+
+	err = simulation.Partition([]*Node{a, b}, []*Node{c, d})
+	if err != nil {
+		t.Fatalf("Partition: %+v", err)
+	}
+	mineBlocks(t, a, 3)
+	mineBlocks(t, d, 2)
+	simulation.RequireConvergence(t, timeout, a, b)
+	simulation.RequireConvergence(t, timeout, c, d)
+
+	err = simulation.WaitForConvergence(time.Second)
+	if err == nil {
+		t.Fatalf("Expected the partitions not to converge")
+	}
+
+	simulation.Heal()
+	err = simulation.WaitForConnections(timeout)
+	if err != nil {
+		t.Fatalf("WaitForConnections: %+v", err)
+	}
+	// The tips of each side are only relayed once a block is mined on top
+	// of them
+	mineBlocks(t, b, 1)
+	mineBlocks(t, c, 1)
+	simulation.RequireConvergence(t, timeout)
+}
+
+func TestClockSkew(t *testing.T) {
+	simulation, nodes := newTestSimulation(t, "a", "b")
+	a, b := nodes[0], nodes[1]
+	simulation.Connect(a, b)
+	err := simulation.WaitForConnections(timeout)
+	if err != nil {
+		t.Fatalf("WaitForConnections: %+v", err)
+	}
+
+	// The timestamps of simnet blocks may be up to 132ms in the future
+	a.SetClockSkew(100 * time.Millisecond)
+	mineBlocks(t, a, 3)
+	simulation.RequireConvergence(t, timeout)
+
+	a.SetClockSkew(time.Minute)
+	_, err = a.MineBlock()
+	if !errors.Is(err, ruleerrors.ErrTimeTooMuchInTheFuture) {
+		t.Fatalf("Expected a block a minute ahead to be rejected, but got: %v", err)
+	}
+
+	a.SetClockSkew(-time.Minute)
+	mineBlocks(t, a, 1)
+	simulation.RequireConvergence(t, timeout)
+}
@@ -128,17 +128,74 @@ type Address interface {
 // If any expectedPrefix except Bech32PrefixUnknown is passed, it is compared to the
 // prefix extracted from the address, and if the two do not match - an error is returned
 func DecodeAddress(addr string, expectedPrefix Bech32Prefix) (Address, error) {
+	decoder := addressDecoder{expectedPrefix: expectedPrefix}
+	return decoder.decode(addr)
+}
+
+// DecodeAddresses decodes the string encodings of many addresses, like
+// DecodeAddress decodes each of them. The returned addresses are in the
+// order of addrs, with nil for the addresses that couldn't be decoded.
+//
+// If all the addresses are decoded, the returned errors are nil. Otherwise,
+// the errors are in the order of addrs, with nil for the addresses that
+// were decoded.
+func DecodeAddresses(addrs []string, expectedPrefix Bech32Prefix) ([]Address, []error) {
+	addresses := make([]Address, len(addrs))
+	var errs []error
+	decoder := addressDecoder{expectedPrefix: expectedPrefix}
+	for i, addr := range addrs {
+		address, err := decoder.decode(addr)
+		if err != nil {
+			if errs == nil {
+				errs = make([]error, len(addrs))
+			}
+			errs[i] = err
+			continue
+		}
+		addresses[i] = address
+	}
+	return addresses, errs
+}
+
+// DecodeAddressWithErrorPositions decodes an address like DecodeAddress.
+// If the address isn't valid because some of its characters are wrong, it
+// also returns the positions in addr of the characters that are most likely
+// wrong, as located by bech32.LocateErrors, so that wallets can point the
+// user at them. The positions are nil if they can't be located.
+func DecodeAddressWithErrorPositions(addr string, expectedPrefix Bech32Prefix) (Address, []int, error) {
+	address, err := DecodeAddress(addr, expectedPrefix)
+	if err != nil {
+		return nil, bech32.LocateErrors(addr), err
+	}
+	return address, nil, nil
+}
+
+// addressDecoder decodes addresses, and remembers the last prefix that it
+// parsed, since addresses that are decoded together usually share it
+type addressDecoder struct {
+	expectedPrefix Bech32Prefix
+
+	lastPrefixString string
+	lastPrefix       Bech32Prefix
+}
+
+func (d *addressDecoder) decode(addr string) (Address, error) {
 	prefixString, decoded, version, err := bech32.Decode(addr)
 	if err != nil {
 		return nil, errors.Errorf("decoded address is of unknown format: %s", err)
 	}
 
-	prefix, err := ParsePrefix(prefixString)
-	if err != nil {
-		return nil, errors.Errorf("decoded address's prefix could not be parsed: %s", err)
+	prefix := d.lastPrefix
+	if prefixString != d.lastPrefixString || prefix == Bech32PrefixUnknown {
+		prefix, err = ParsePrefix(prefixString)
+		if err != nil {
+			return nil, errors.Errorf("decoded address's prefix could not be parsed: %s", err)
+		}
+		d.lastPrefixString = prefixString
+		d.lastPrefix = prefix
 	}
-	if expectedPrefix != Bech32PrefixUnknown && expectedPrefix != prefix {
-		return nil, errors.Errorf("decoded address is of wrong network. Expected %s but got %s", expectedPrefix,
+	if d.expectedPrefix != Bech32PrefixUnknown && d.expectedPrefix != prefix {
+		return nil, errors.Errorf("decoded address is of wrong network. Expected %s but got %s", d.expectedPrefix,
 			prefix)
 	}
 
@@ -462,3 +462,107 @@ func TestPrefixToString(t *testing.T) {
 		}
 	}
 }
+
+func TestDecodeAddresses(t *testing.T) {
+	addrs := []string{
+		"kaspa:qr35ennsep3hxfe7lnz5ee7j5jgmkjswsn35ennsep3hxfe7ln35cdv0dy335",
+		"kaspa:qq80qvqs0lfxuzmt7sz3909ze6camq9d4t35ennsep3hxfe7ln35cvfqgz3z8",
+		"kaspatest:qputx94qseratdmjs0j395mq8u03er0x3l35ennsep3hxfe7ln35ckquw528z",
+		"kaspa:qr35ennsep3hxfe7lnz5ee7j5jgmkjswsn35ennsep3hxfe7ln35cdv0dy336",
+		"KASPA:QR35ENNSEP3HXFE7LNZ5EE7J5JGMKJSWSN35ENNSEP3HXFE7LN35CDV0DY335",
+		"kaspa:Qr35ennsep3hxfe7lnz5ee7j5jgmkjswsn35ennsep3hxfe7ln35cdv0dy335",
+		"",
+	}
+
+	for _, expectedPrefix := range []util.Bech32Prefix{util.Bech32PrefixUnknown, util.Bech32PrefixKaspa} {
+		addresses, errs := util.DecodeAddresses(addrs, expectedPrefix)
+		if len(addresses) != len(addrs) || len(errs) != len(addrs) {
+			t.Fatalf("DecodeAddresses returned %d addresses and %d errors for %d addresses",
+				len(addresses), len(errs), len(addrs))
+		}
+		for i, addr := range addrs {
+			expectedAddress, expectedErr := util.DecodeAddress(addr, expectedPrefix)
+			if (errs[i] == nil) != (expectedErr == nil) {
+				t.Fatalf("%s: DecodeAddresses returned the error %v, but DecodeAddress returned %v",
+					addr, errs[i], expectedErr)
+			}
+			if expectedErr != nil {
+				if addresses[i] != nil {
+					t.Fatalf("%s: DecodeAddresses returned an address along with an error", addr)
+				}
+				continue
+			}
+			if !reflect.DeepEqual(addresses[i], expectedAddress) {
+				t.Fatalf("%s: DecodeAddresses returned %v, but DecodeAddress returned %v",
+					addr, addresses[i], expectedAddress)
+			}
+		}
+	}
+
+	addresses, errs := util.DecodeAddresses(addrs[:2], util.Bech32PrefixKaspa)
+	if errs != nil {
+		t.Fatalf("DecodeAddresses returned errors for valid addresses: %v", errs)
+	}
+	if len(addresses) != 2 {
+		t.Fatalf("DecodeAddresses returned %d addresses for 2 addresses", len(addresses))
+	}
+}
+
+func BenchmarkDecodeAddresses(b *testing.B) {
+	addrs := make([]string, 1000)
+	for i := range addrs {
+		publicKey := make([]byte, util.PublicKeySize)
+		publicKey[0], publicKey[1] = byte(i), byte(i>>8)
+		address, err := util.NewAddressPublicKey(publicKey, util.Bech32PrefixKaspa)
+		if err != nil {
+			b.Fatalf("NewAddressPublicKey: %s", err)
+		}
+		addrs[i] = address.EncodeAddress()
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, errs := util.DecodeAddresses(addrs, util.Bech32PrefixKaspa)
+		if errs != nil {
+			b.Fatalf("DecodeAddresses: %v", errs)
+		}
+	}
+}
+
+func TestDecodeAddressWithErrorPositions(t *testing.T) {
+	const addr = "kaspa:qr35ennsep3hxfe7lnz5ee7j5jgmkjswsn35ennsep3hxfe7ln35cdv0dy335"
+
+	address, positions, err := util.DecodeAddressWithErrorPositions(addr, util.Bech32PrefixKaspa)
+	if err != nil {
+		t.Fatalf("DecodeAddressWithErrorPositions: %s", err)
+	}
+	if address.String() != addr || positions != nil {
+		t.Fatalf("DecodeAddressWithErrorPositions returned %s and the error positions %v", address, positions)
+	}
+
+	tests := []struct {
+		name      string
+		addr      string
+		prefix    util.Bech32Prefix
+		positions []int
+	}{
+		{"one typo", "kaspa:qr35ennsep3hxfe7lnz5ee7j5jgmkjswsn35ennsep3hxfe7ln35cdv0dy336", util.Bech32PrefixKaspa,
+			[]int{66}},
+		{"two typos", "kaspa:qr35ennsep3hxfe8lnz5ee7j5jgmkjswsn35ennsep3hxfe7ln35cdv0dy345", util.Bech32PrefixKaspa,
+			[]int{21, 65}},
+		{"invalid character", "kaspa:qr35ennsep3hxfe7lnz5ee7j5jgmkjswsn35ennsep3hxfe7ln35cdv0dy33b",
+			util.Bech32PrefixKaspa, []int{66}},
+		{"wrong network", addr, util.Bech32PrefixKaspaTest, nil},
+	}
+	for _, test := range tests {
+		address, positions, err := util.DecodeAddressWithErrorPositions(test.addr, test.prefix)
+		if err == nil {
+			t.Errorf("%s: DecodeAddressWithErrorPositions unexpectedly decoded %s", test.name, address)
+			continue
+		}
+		if !reflect.DeepEqual(positions, test.positions) {
+			t.Errorf("%s: expected the error positions %v, but got %v", test.name, test.positions, positions)
+		}
+	}
+}
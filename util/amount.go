@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 	"math"
 	"strconv"
+	"strings"
 )
 
 // AmountUnit describes a method of converting an Amount to something
@@ -105,9 +106,117 @@ func (a Amount) ToKAS() float64 {
 // string for a given unit. The conversion will succeed for any unit,
 // however, known units will be formated with an appended label describing
 // the units with SI notation, or "Sompi" for the base unit.
+//
+// The amount is formatted exactly, without trailing zeros in its fraction,
+// and is always written with a '.' decimal separator, so that ParseAmount
+// returns the same amount for it.
 func (a Amount) Format(u AmountUnit) string {
-	units := " " + u.String()
-	return strconv.FormatFloat(a.ToUnit(u), 'f', -int(u+8), 64) + units
+	return formatSompi(uint64(a), int(u+8)) + " " + u.String()
+}
+
+// formatSompi formats an amount of sompi as a decimal number that has
+// decimals digits after the decimal point, before trailing zeros are trimmed
+func formatSompi(sompi uint64, decimals int) string {
+	digits := strconv.FormatUint(sompi, 10)
+	if decimals <= 0 {
+		if sompi == 0 {
+			return digits
+		}
+		return digits + strings.Repeat("0", -decimals)
+	}
+
+	if len(digits) <= decimals {
+		digits = strings.Repeat("0", decimals-len(digits)+1) + digits
+	}
+	integerPart := digits[:len(digits)-decimals]
+	fractionPart := strings.TrimRight(digits[len(digits)-decimals:], "0")
+	if fractionPart == "" {
+		return integerPart
+	}
+	return integerPart + "." + fractionPart
+}
+
+// ParseAmountUnit returns the unit that the given string names. The names
+// are the ones returned by AmountUnit.String, and "uKAS" for AmountMicroKAS.
+func ParseAmountUnit(s string) (AmountUnit, error) {
+	for _, u := range []AmountUnit{AmountMegaKAS, AmountKiloKAS, AmountKAS, AmountMilliKAS, AmountMicroKAS, AmountSompi} {
+		if s == u.String() {
+			return u, nil
+		}
+	}
+	if s == "uKAS" {
+		return AmountMicroKAS, nil
+	}
+	return 0, errors.Errorf("unknown amount unit %s", s)
+}
+
+// ParseAmount parses a decimal number of the given unit, such as one that
+// Format returns, into an Amount. The number may be followed by the label
+// of the unit. It must be written with ASCII digits and an optional '.'
+// decimal separator, without a sign, an exponent or digit grouping, so that
+// it's parsed the same regardless of locale.
+//
+// ParseAmount doesn't round: it returns an error if the number is more
+// precise than a sompi, or if it's too big to be represented by an Amount.
+func ParseAmount(s string, u AmountUnit) (Amount, error) {
+	number := strings.TrimSpace(s)
+	if separatorIndex := strings.IndexByte(number, ' '); separatorIndex != -1 {
+		label := strings.TrimSpace(number[separatorIndex+1:])
+		if label != u.String() {
+			labelUnit, err := ParseAmountUnit(label)
+			if err != nil || labelUnit != u {
+				return 0, errors.Errorf("amount %q isn't in %s", s, u)
+			}
+		}
+		number = number[:separatorIndex]
+	}
+
+	integerPart, fractionPart, hasFraction := strings.Cut(number, ".")
+	if !isDecimalDigits(integerPart) || (hasFraction && !isDecimalDigits(fractionPart)) {
+		return 0, errors.Errorf("amount %q isn't a decimal number", s)
+	}
+
+	// The number is the integer of all its digits, times 10 to the power of
+	// the amount of decimals in a unit minus the amount of digits after the
+	// decimal point
+	digits := integerPart + fractionPart
+	exponent := int(u+8) - len(fractionPart)
+	if exponent < 0 {
+		trimmedDigits := strings.TrimRight(digits, "0")
+		switch {
+		case trimmedDigits == "":
+			digits = "0"
+		case len(digits)-len(trimmedDigits) < -exponent:
+			return 0, errors.Errorf("amount %q is more precise than a sompi", s)
+		default:
+			digits = digits[:len(digits)+exponent]
+		}
+		exponent = 0
+	}
+
+	sompi, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil {
+		return 0, errors.Errorf("amount %q is too big", s)
+	}
+	for i := 0; i < exponent && sompi != 0; i++ {
+		if sompi > math.MaxUint64/10 {
+			return 0, errors.Errorf("amount %q is too big", s)
+		}
+		sompi *= 10
+	}
+	return Amount(sompi), nil
+}
+
+func isDecimalDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
 }
 
 // String is the equivalent of calling Format with AmountKAS.
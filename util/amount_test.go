@@ -254,3 +254,95 @@ func TestAmountMulF64(t *testing.T) {
 		}
 	}
 }
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		unit     AmountUnit
+		valid    bool
+		expected Amount
+	}{
+		{name: "KAS", s: "444333.222111", unit: AmountKAS, valid: true, expected: 44433322211100},
+		{name: "KAS with label", s: "444333.222111 KAS", unit: AmountKAS, valid: true, expected: 44433322211100},
+		{name: "kKAS", s: "444.333222111 kKAS", unit: AmountKiloKAS, valid: true, expected: 44433322211100},
+		{name: "MKAS", s: "29000", unit: AmountMegaKAS, valid: true, expected: Amount(constants.MaxSompi)},
+		{name: "mKAS", s: "1.5", unit: AmountMilliKAS, valid: true, expected: 150000},
+		{name: "μKAS", s: "2.5 μKAS", unit: AmountMicroKAS, valid: true, expected: 250},
+		{name: "uKAS", s: "2.5 uKAS", unit: AmountMicroKAS, valid: true, expected: 250},
+		{name: "sompi", s: "12345 Sompi", unit: AmountSompi, valid: true, expected: 12345},
+		{name: "sompi with zero fraction", s: "12345.000", unit: AmountSompi, valid: true, expected: 12345},
+		{name: "one sompi", s: "0.00000001", unit: AmountKAS, valid: true, expected: 1},
+		{name: "trailing zeros", s: "1.100000000000", unit: AmountKAS, valid: true, expected: 110000000},
+		{name: "leading zeros", s: "0001.1", unit: AmountKAS, valid: true, expected: 110000000},
+		{name: "zero", s: "0", unit: AmountKAS, valid: true, expected: 0},
+		{name: "zero of a unit smaller than a sompi", s: "0", unit: AmountUnit(-10), valid: true, expected: 0},
+		{name: "unit smaller than a sompi", s: "1200", unit: AmountUnit(-10), valid: true, expected: 12},
+		{name: "max uint64", s: "184467440737.09551615", unit: AmountKAS, valid: true, expected: math.MaxUint64},
+		{name: "surrounding whitespace", s: " 1.5 KAS\n", unit: AmountKAS, valid: true, expected: 150000000},
+		{name: "more precise than a sompi", s: "0.000000001", unit: AmountKAS, valid: false},
+		{name: "unit smaller than a sompi not a multiple", s: "1234", unit: AmountUnit(-10), valid: false},
+		{name: "too big", s: "184467440737.09551616", unit: AmountKAS, valid: false},
+		{name: "too big after scaling", s: "18446744073709551615", unit: AmountKAS, valid: false},
+		{name: "wrong label", s: "1.5 mKAS", unit: AmountKAS, valid: false},
+		{name: "unknown label", s: "1.5 BTC", unit: AmountKAS, valid: false},
+		{name: "negative", s: "-1", unit: AmountKAS, valid: false},
+		{name: "plus sign", s: "+1", unit: AmountKAS, valid: false},
+		{name: "exponent", s: "1e8", unit: AmountSompi, valid: false},
+		{name: "comma decimal separator", s: "1,5", unit: AmountKAS, valid: false},
+		{name: "digit grouping", s: "1,000.5", unit: AmountKAS, valid: false},
+		{name: "two decimal points", s: "1.5.1", unit: AmountKAS, valid: false},
+		{name: "no integer part", s: ".5", unit: AmountKAS, valid: false},
+		{name: "no fraction", s: "5.", unit: AmountKAS, valid: false},
+		{name: "empty", s: "", unit: AmountKAS, valid: false},
+		{name: "non-ASCII digits", s: "١", unit: AmountKAS, valid: false},
+	}
+
+	for _, test := range tests {
+		a, err := ParseAmount(test.s, test.unit)
+		switch {
+		case test.valid && err != nil:
+			t.Errorf("%v: Positive test ParseAmount failed with: %v", test.name, err)
+			continue
+		case !test.valid && err == nil:
+			t.Errorf("%v: Negative test ParseAmount succeeded (value %v)", test.name, a)
+			continue
+		}
+		if a != test.expected {
+			t.Errorf("%v: Parsed amount %v does not match expected %v", test.name, a, test.expected)
+		}
+	}
+}
+
+func TestAmountFormatParseRoundTrip(t *testing.T) {
+	units := []AmountUnit{AmountMegaKAS, AmountKiloKAS, AmountKAS, AmountMilliKAS, AmountMicroKAS, AmountSompi,
+		AmountUnit(-1), AmountUnit(-10)}
+	amounts := []Amount{0, 1, 10, 99999999, 100000000, 123456789012345678, Amount(constants.MaxSompi),
+		math.MaxUint64}
+
+	for _, unit := range units {
+		parsedUnit, err := ParseAmountUnit(unit.String())
+		if err == nil && parsedUnit != unit {
+			t.Errorf("ParseAmountUnit(%q) returned %v", unit.String(), parsedUnit)
+		}
+		for _, amount := range amounts {
+			s := amount.Format(unit)
+			parsedAmount, err := ParseAmount(s, unit)
+			if err != nil {
+				t.Errorf("ParseAmount(%q, %v) failed with: %v", s, unit, err)
+				continue
+			}
+			if parsedAmount != amount {
+				t.Errorf("ParseAmount(%q, %v) returned %d, but the formatted amount is %d", s, unit,
+					parsedAmount, amount)
+			}
+		}
+	}
+
+	// Amounts above 2^53 sompi can't be represented by a float64, so
+	// formatting them used to round away their last digits
+	s := Amount(123456789012345678).Format(AmountKAS)
+	if s != "1234567890.12345678 KAS" {
+		t.Errorf("Unexpected formatting of an amount that isn't representable by a float64: %s", s)
+	}
+}
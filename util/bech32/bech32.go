@@ -71,15 +71,20 @@ func decode(encoded string) (string, []byte, error) {
 	}
 
 	// The characters must be either all lowercase or all uppercase.
-	lower := strings.ToLower(encoded)
-	upper := strings.ToUpper(encoded)
-	if encoded != lower && encoded != upper {
+	// This is checked without building a copy of the string, which
+	// strings.ToLower doesn't do below if the string is already lowercase.
+	hasLower, hasUpper := false, false
+	for i := 0; i < len(encoded); i++ {
+		hasLower = hasLower || (encoded[i] >= 'a' && encoded[i] <= 'z')
+		hasUpper = hasUpper || (encoded[i] >= 'A' && encoded[i] <= 'Z')
+	}
+	if hasLower && hasUpper {
 		return "", nil, errors.Errorf("string not all lowercase or all " +
 			"uppercase")
 	}
 
 	// We'll work with the lowercase string from now on.
-	encoded = lower
+	encoded = strings.ToLower(encoded)
 
 	// The string is invalid if the last ':' is non-existent, it is the
 	// first character of the string (no human-readable part) or one of the
@@ -282,14 +287,20 @@ func ints(payload []byte) []int {
 func polyMod(values []int) int {
 	checksum := 1
 	for _, value := range values {
-		topBits := checksum >> 35
-		checksum = ((checksum & 0x07ffffffff) << 5) ^ value
-		for i := 0; i < len(generator); i++ {
-			if ((topBits >> uint(i)) & 1) == 1 {
-				checksum ^= generator[i]
-			}
-		}
+		checksum = polyModStep(checksum, value)
 	}
 
 	return checksum ^ 1
 }
+
+// polyModStep feeds a single value into the checksum generator.
+func polyModStep(checksum int, value int) int {
+	topBits := checksum >> 35
+	checksum = ((checksum & 0x07ffffffff) << 5) ^ value
+	for i := 0; i < len(generator); i++ {
+		if ((topBits >> uint(i)) & 1) == 1 {
+			checksum ^= generator[i]
+		}
+	}
+	return checksum
+}
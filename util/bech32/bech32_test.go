@@ -5,8 +5,13 @@
 package bech32_test
 
 import (
-	"github.com/kaspanet/kaspad/util/bech32"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
+
+	"github.com/kaspanet/kaspad/util/bech32"
 )
 
 var checkEncodingStringTests = []struct {
@@ -58,3 +63,75 @@ func TestDecodeError(t *testing.T) {
 		t.Errorf("decode unexpectedly succeeded")
 	}
 }
+
+func TestLocateErrors(t *testing.T) {
+	const charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+	random := rand.New(rand.NewSource(0))
+
+	// substitute replaces the characters of encoded at the given positions
+	// with other characters of the charset
+	substitute := func(encoded string, positions []int) string {
+		substituted := []byte(encoded)
+		for _, position := range positions {
+			for substituted[position] == encoded[position] {
+				substituted[position] = charset[random.Intn(len(charset))]
+			}
+		}
+		return string(substituted)
+	}
+
+	for i := 0; i < 200; i++ {
+		payload := make([]byte, 32)
+		random.Read(payload)
+		encoded := bech32.Encode("kaspa", payload, 0)
+		dataStart := len("kaspa:")
+
+		if positions := bech32.LocateErrors(encoded); positions != nil {
+			t.Fatalf("LocateErrors(%s) located %v in a valid string", encoded, positions)
+		}
+
+		for errorCount := 1; errorCount <= 3; errorCount++ {
+			positions := random.Perm(len(encoded) - dataStart)[:errorCount]
+			for j := range positions {
+				positions[j] += dataStart
+			}
+			sort.Ints(positions)
+			substituted := substitute(encoded, positions)
+
+			located := bech32.LocateErrors(substituted)
+			if errorCount > bech32.MaxLocatedErrors {
+				if located != nil {
+					t.Fatalf("LocateErrors(%s) located %v, but there are %d wrong characters at %v",
+						substituted, located, errorCount, positions)
+				}
+				continue
+			}
+			if !reflect.DeepEqual(located, positions) {
+				t.Fatalf("LocateErrors(%s): expected %v, but got %v", substituted, positions, located)
+			}
+			if located := bech32.LocateErrors(strings.ToUpper(substituted)); !reflect.DeepEqual(located, positions) {
+				t.Fatalf("LocateErrors(%s): expected %v, but got %v",
+					strings.ToUpper(substituted), positions, located)
+			}
+		}
+	}
+
+	tests := []struct {
+		name     string
+		encoded  string
+		expected []int
+	}{
+		{"invalid characters", "kaspa:qr35ennsep3hxfe7lnz5ee7j5jgmkjswsn35ennsep3hxfe7ln35cdv0dy33b", []int{66}},
+		{"two invalid characters", "kaspa:qr35ennsep3hxfe7lnz5ee7j5jgmkjswsn35ennsep3hxfe7ln35cdv0dyi3o", []int{64, 66}},
+		{"mixed case", "kaspa:Qr35ennsep3hxfe7lnz5ee7j5jgmkjswsn35ennsep3hxfe7ln35cdv0dy336", nil},
+		{"no separator", "kaspaqr35ennsep3hxfe7lnz5ee7j5jgmkjswsn35ennsep3hxfe7ln35cdv0dy336", nil},
+		{"too short", "kaspa:qr35", nil},
+		{"wrong prefix", "kaspb:qr35ennsep3hxfe7lnz5ee7j5jgmkjswsn35ennsep3hxfe7ln35cdv0dy335", nil},
+	}
+	for _, test := range tests {
+		located := bech32.LocateErrors(test.encoded)
+		if !reflect.DeepEqual(located, test.expected) {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expected, located)
+		}
+	}
+}
@@ -4,5 +4,9 @@ Package bech32 provides a Go implementation of the bech32 format.
 Bech32 strings consist of a prefix, followed by the separator :,
 then a checksummed data part encoded using the 32 characters
 "qpzry9x8gf2tvdw0s3jn54khce6mua7l".
+
+When a string fails to decode because some of its characters are wrong,
+LocateErrors uses the checksum to find up to two of them, so that users can
+be shown where their typo is.
 */
 package bech32
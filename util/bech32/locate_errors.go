@@ -0,0 +1,124 @@
+package bech32
+
+import (
+	"sort"
+	"strings"
+)
+
+// MaxLocatedErrors is the maximum amount of wrong characters that
+// LocateErrors can locate in a string.
+const MaxLocatedErrors = 2
+
+// LocateErrors returns the positions in encoded of the characters that are
+// most likely wrong, in ascending order, if encoded isn't a valid bech32
+// string because of them. The positions are byte indexes into encoded.
+//
+// Characters that aren't part of the charset are always located. Otherwise,
+// the checksum locates up to MaxLocatedErrors wrong characters after the
+// separator, and only if no other characters of the same amount would make
+// encoded valid. The prefix is assumed to be correct.
+//
+// LocateErrors returns nil if encoded is valid, if its format is invalid
+// in a way that isn't caused by specific characters, or if the wrong
+// characters can't be located.
+func LocateErrors(encoded string) []int {
+	if len(encoded) < checksumLength+2 {
+		return nil
+	}
+	hasLower, hasUpper := false, false
+	for i := 0; i < len(encoded); i++ {
+		if encoded[i] < 33 || encoded[i] > 126 {
+			return nil
+		}
+		hasLower = hasLower || (encoded[i] >= 'a' && encoded[i] <= 'z')
+		hasUpper = hasUpper || (encoded[i] >= 'A' && encoded[i] <= 'Z')
+	}
+	if hasLower && hasUpper {
+		return nil
+	}
+	encoded = strings.ToLower(encoded)
+	colonIndex := strings.LastIndexByte(encoded, ':')
+	if colonIndex < 1 || colonIndex+checksumLength+1 > len(encoded) {
+		return nil
+	}
+	prefix := encoded[:colonIndex]
+	data := encoded[colonIndex+1:]
+
+	var invalidCharacterPositions []int
+	for i := 0; i < len(data); i++ {
+		if strings.IndexByte(charset, data[i]) < 0 {
+			invalidCharacterPositions = append(invalidCharacterPositions, colonIndex+1+i)
+		}
+	}
+	if len(invalidCharacterPositions) > 0 {
+		return invalidCharacterPositions
+	}
+
+	decoded, _ := decodeFromBase32(data)
+	values := append(prefixToUint5Array(prefix), 0)
+	values = append(values, ints(decoded)...)
+	syndrome := polyMod(values)
+	if syndrome == 0 {
+		return nil
+	}
+
+	dataPositions := locateErrorsBySyndrome(syndrome, len(data))
+	if dataPositions == nil {
+		return nil
+	}
+	positions := make([]int, len(dataPositions))
+	for i, dataPosition := range dataPositions {
+		positions[i] = colonIndex + 1 + dataPosition
+	}
+	return positions
+}
+
+// errorLocation is a wrong character: its position in the data part, and
+// the XOR of its value with the correct value
+type errorLocation struct {
+	position int
+	value    int
+}
+
+// locateErrorsBySyndrome returns the positions in a data part of dataLength
+// characters of the characters that changed it such that its checksum
+// results in syndrome, or nil if they can't be located.
+//
+// polyMod is linear in its values, apart from its constant initial state,
+// so changing the value at some position changes its result by the XOR of
+// that change alone, independently of the rest of the data. The syndrome of
+// a string is therefore the XOR of the contributions of its wrong characters.
+func locateErrorsBySyndrome(syndrome int, dataLength int) []int {
+	// contributions maps the contribution of each possible wrong character
+	// to that character
+	contributions := make(map[int]errorLocation, dataLength*(len(charset)-1))
+	for value := 1; value < len(charset); value++ {
+		// The contribution of a value that's followed by n values is the
+		// result of feeding it, and then n zeros, into the checksum
+		// generator starting from a zero state
+		contribution := value
+		for position := dataLength - 1; position >= 0; position-- {
+			if contribution == syndrome {
+				return []int{position}
+			}
+			contributions[contribution] = errorLocation{position: position, value: value}
+			contribution = polyModStep(contribution, 0)
+		}
+	}
+
+	var located []int
+	for contribution, first := range contributions {
+		second, ok := contributions[syndrome^contribution]
+		if !ok || second.position <= first.position {
+			continue
+		}
+		if located != nil {
+			// More than one pair of characters results in the syndrome,
+			// so there's no way to tell which one is wrong
+			return nil
+		}
+		located = []int{first.position, second.position}
+	}
+	sort.Ints(located)
+	return located
+}
@@ -6,6 +6,8 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+
+	"github.com/kaspanet/kaspad/domain/dagconfig"
 )
 
 func TestBIP32SpecVectors(t *testing.T) {
@@ -61,7 +63,7 @@ func TestBIP32SpecVectors(t *testing.T) {
 		},
 		{
 			seed:    "000102030405060708090a0b0c0d0e0f",
-			version: KaspaMainnetPrivate,
+			version: dagconfig.MainnetParams.HDPrivateKeyID,
 			paths: []testPath{
 				{
 					path:               "m",
@@ -133,7 +135,7 @@ func TestBIP32SpecVectors(t *testing.T) {
 		},
 		{
 			seed:    "fffcf9f6f3f0edeae7e4e1dedbd8d5d2cfccc9c6c3c0bdbab7b4b1aeaba8a5a29f9c999693908d8a8784817e7b7875726f6c696663605d5a5754514e4b484542",
-			version: KaspaMainnetPrivate,
+			version: dagconfig.MainnetParams.HDPrivateKeyID,
 			paths: []testPath{
 				{
 					path:               "m",
@@ -185,7 +187,7 @@ func TestBIP32SpecVectors(t *testing.T) {
 		},
 		{
 			seed:    "4b381541583be4423346c643850da4b320e46a87ae3d2a4e6da11eba819cd4acba45d239319ac14f863b8d5ab5a0d0c64d2e8a1e7d1457df2e5a3c51c73235be",
-			version: KaspaMainnetPrivate,
+			version: dagconfig.MainnetParams.HDPrivateKeyID,
 			paths: []testPath{
 				{
 					path:               "m",
@@ -201,7 +203,7 @@ func TestBIP32SpecVectors(t *testing.T) {
 		},
 		{
 			seed:    "4b381541583be4423346c643850da4b320e46a87ae3d2a4e6da11eba819cd4acba45d239319ac14f863b8d5ab5a0d0c64d2e8a1e7d1457df2e5a3c51c73235be",
-			version: KaspaTestnetPrivate,
+			version: dagconfig.TestnetParams.HDPrivateKeyID,
 			paths: []testPath{
 				{
 					path:               "m",
@@ -217,7 +219,7 @@ func TestBIP32SpecVectors(t *testing.T) {
 		},
 		{
 			seed:    "4b381541583be4423346c643850da4b320e46a87ae3d2a4e6da11eba819cd4acba45d239319ac14f863b8d5ab5a0d0c64d2e8a1e7d1457df2e5a3c51c73235be",
-			version: KaspaDevnetPrivate,
+			version: dagconfig.DevnetParams.HDPrivateKeyID,
 			paths: []testPath{
 				{
 					path:               "m",
@@ -233,7 +235,7 @@ func TestBIP32SpecVectors(t *testing.T) {
 		},
 		{
 			seed:    "4b381541583be4423346c643850da4b320e46a87ae3d2a4e6da11eba819cd4acba45d239319ac14f863b8d5ab5a0d0c64d2e8a1e7d1457df2e5a3c51c73235be",
-			version: KaspaSimnetPrivate,
+			version: dagconfig.SimnetParams.HDPrivateKeyID,
 			paths: []testPath{
 				{
 					path:               "m",
@@ -309,7 +311,7 @@ func TestExtendedKey_DeriveFromPath(t *testing.T) {
 		t.Fatalf("GenerateSeed: %+v", err)
 	}
 
-	master, err := NewMaster(seed, KaspaMainnetPrivate)
+	master, err := NewMaster(seed, dagconfig.MainnetParams.HDPrivateKeyID)
 	if err != nil {
 		t.Fatalf("GenerateSeed: %+v", err)
 	}
@@ -6,7 +6,33 @@ import (
 	"github.com/pkg/errors"
 )
 
-const hardenedIndexStart = 0x80000000
+const (
+	hardenedIndexStart = 0x80000000
+
+	// maxDepth is the maximum depth of an extended key, since the depth is
+	// serialized in a single byte
+	maxDepth = 255
+)
+
+var (
+	// ErrDeriveHardFromPublic indicates an attempt to derive a hardened
+	// child from a public extended key
+	ErrDeriveHardFromPublic = errors.New("cannot derive a hardened key from a public key")
+
+	// ErrDeriveBeyondMaxDepth indicates an attempt to derive a child of an
+	// extended key whose depth is 255
+	ErrDeriveBeyondMaxDepth = errors.New("cannot derive a key with more than 255 indices in its path")
+
+	// ErrInvalidChild indicates that the child at the requested index falls
+	// outside of the valid range of secp256k1 keys. The caller should skip
+	// this index and derive the child at the next one
+	ErrInvalidChild = errors.New("the extended key at this index is invalid")
+
+	// ErrUnusableSeed indicates that the master key of the given seed falls
+	// outside of the valid range of secp256k1 private keys. The caller
+	// should generate another seed
+	ErrUnusableSeed = errors.New("unusable seed")
+)
 
 // NewMaster returns a new extended private key based on the given seed and version
 func NewMaster(seed []byte, version [4]byte) (*ExtendedKey, error) {
@@ -20,7 +46,7 @@ func NewMaster(seed []byte, version [4]byte) (*ExtendedKey, error) {
 
 	privateKey, err := secp256k1.DeserializeECDSAPrivateKeyFromSlice(iL[:])
 	if err != nil {
-		return nil, err
+		return nil, errors.Wrap(ErrUnusableSeed, err.Error())
 	}
 
 	return &ExtendedKey{
@@ -39,6 +65,10 @@ func isHardened(i uint32) bool {
 
 // Child return the i'th derived child of extKey.
 func (extKey *ExtendedKey) Child(i uint32) (*ExtendedKey, error) {
+	if extKey.Depth == maxDepth {
+		return nil, ErrDeriveBeyondMaxDepth
+	}
+
 	I, err := extKey.calcI(i)
 	if err != nil {
 		return nil, err
@@ -64,7 +94,7 @@ func (extKey *ExtendedKey) Child(i uint32) (*ExtendedKey, error) {
 	if extKey.privateKey != nil {
 		childExt.privateKey, err = privateKeyAdd(extKey.privateKey, iL)
 		if err != nil {
-			return nil, err
+			return nil, errors.Wrap(ErrInvalidChild, err.Error())
 		}
 	} else {
 		publicKey, err := extKey.PublicKey()
@@ -74,7 +104,7 @@ func (extKey *ExtendedKey) Child(i uint32) (*ExtendedKey, error) {
 
 		childExt.publicKey, err = pointAdd(publicKey, iL)
 		if err != nil {
-			return nil, err
+			return nil, errors.Wrap(ErrInvalidChild, err.Error())
 		}
 	}
 
@@ -110,7 +140,7 @@ func privateKeyAdd(k *secp256k1.ECDSAPrivateKey, tweak [32]byte) (*secp256k1.ECD
 
 func (extKey *ExtendedKey) calcI(i uint32) ([]byte, error) {
 	if isHardened(i) && !extKey.IsPrivate() {
-		return nil, errors.Errorf("Cannot calculate hardened child for public key")
+		return nil, ErrDeriveHardFromPublic
 	}
 
 	mac := newHMACWriter(extKey.ChainCode[:])
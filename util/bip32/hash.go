@@ -43,7 +43,7 @@ func validateChecksum(data []byte) error {
 	checksum := data[len(data)-checkSumLen:]
 	expectedChecksum := calcChecksum(data[:len(data)-checkSumLen])
 	if !bytes.Equal(expectedChecksum, checksum) {
-		return errors.Errorf("expected checksum %x but got %x", expectedChecksum, checksum)
+		return errors.Wrapf(ErrBadChecksum, "expected checksum %x but got %x", expectedChecksum, checksum)
 	}
 
 	return nil
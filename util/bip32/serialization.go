@@ -3,7 +3,7 @@ package bip32
 import (
 	"encoding/binary"
 	"github.com/kaspanet/go-secp256k1"
-	"github.com/kaspanet/kaspad/cmd/kaspawallet/libkaspawallet/bip32/base58"
+	"github.com/kaspanet/kaspad/util/bip32/base58"
 	"github.com/pkg/errors"
 )
 
@@ -17,6 +17,16 @@ const (
 	checkSumLen                 = 4
 )
 
+var (
+	// ErrInvalidKeyLen indicates that a serialized extended key doesn't
+	// have the expected length
+	ErrInvalidKeyLen = errors.New("the provided serialized extended key length is invalid")
+
+	// ErrBadChecksum indicates that the checksum of a serialized extended
+	// key doesn't match its payload
+	ErrBadChecksum = errors.New("bad extended key checksum")
+)
+
 const extendedKeySerializationLen = versionSerializationLen +
 	depthSerializationLen +
 	fingerprintSerializationLen +
@@ -33,7 +43,7 @@ func DeserializeExtendedKey(extKeyString string) (*ExtendedKey, error) {
 
 func deserializeExtendedPrivateKey(serialized []byte) (*ExtendedKey, error) {
 	if len(serialized) != extendedKeySerializationLen {
-		return nil, errors.Errorf("key length must be %d bytes but got %d", extendedKeySerializationLen, len(serialized))
+		return nil, errors.Wrapf(ErrInvalidKeyLen, "key length must be %d bytes but got %d", extendedKeySerializationLen, len(serialized))
 	}
 
 	err := validateChecksum(serialized)
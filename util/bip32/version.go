@@ -0,0 +1,49 @@
+package bip32
+
+import (
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/pkg/errors"
+)
+
+// BitcoinMainnetPrivate is the version that is used for
+// bitcoin mainnet bip32 private extended keys.
+// Ecnodes to xprv in base58.
+var BitcoinMainnetPrivate = [4]byte{
+	0x04,
+	0x88,
+	0xad,
+	0xe4,
+}
+
+// BitcoinMainnetPublic is the version that is used for
+// bitcoin mainnet bip32 public extended keys.
+// Ecnodes to xpub in base58.
+var BitcoinMainnetPublic = [4]byte{
+	0x04,
+	0x88,
+	0xb2,
+	0x1e,
+}
+
+func toPublicVersion(version [4]byte) ([4]byte, error) {
+	if version == BitcoinMainnetPrivate {
+		return BitcoinMainnetPublic, nil
+	}
+
+	// The versions of kaspa extended keys are the HD key IDs of the networks
+	// registered in dagconfig
+	publicVersion, err := dagconfig.HDPrivateKeyToPublicKeyID(version)
+	if err != nil {
+		return [4]byte{}, errors.Wrapf(err, "unknown version %x", version)
+	}
+	return publicVersion, nil
+}
+
+func isPrivateVersion(version [4]byte) bool {
+	if version == BitcoinMainnetPrivate {
+		return true
+	}
+
+	_, err := dagconfig.HDPrivateKeyToPublicKeyID(version)
+	return err == nil
+}
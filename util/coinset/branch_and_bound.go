@@ -0,0 +1,127 @@
+package coinset
+
+import "github.com/kaspanet/kaspad/util"
+
+// defaultBranchAndBoundMaxTries is the number of selections that
+// BranchAndBoundCoinSelector tries when its MaxTries is zero
+const defaultBranchAndBoundMaxTries = 100000
+
+// BranchAndBoundCoinSelector is a CoinSelector that searches for coins that
+// pay the target value exactly, or with an excess of at most CostOfChange,
+// so that the transaction needs no change output. The excess is left to the
+// miner as an additional fee.
+//
+// The search is a depth-first search over including and excluding each coin,
+// from the largest to the smallest, which prunes selections that fall short
+// of or overshoot the target. It returns the selection with the least excess
+// that it finds, and ErrNoSelectionAvailable if it finds none, in which case
+// the caller usually falls back to a selector that creates change, such as
+// LargestFirstCoinSelector.
+type BranchAndBoundCoinSelector struct {
+	// CostOfChange is the maximum excess over the target value, which is
+	// usually the fee of adding a change output and of later spending it.
+	CostOfChange util.Amount
+
+	// MaxTries is the maximum number of selections to try. Zero means
+	// defaultBranchAndBoundMaxTries.
+	MaxTries int
+
+	// MaxInputs is the maximum number of coins to select. Zero means no
+	// limit.
+	MaxInputs int
+}
+
+// CoinSelect selects coins whose total value is between targetValue and
+// targetValue plus the CostOfChange of the selector.
+func (s *BranchAndBoundCoinSelector) CoinSelect(targetValue util.Amount, coins []Coin) ([]Coin, error) {
+	if TotalValue(coins) < targetValue {
+		return nil, ErrInsufficientFunds
+	}
+
+	maxTries := s.MaxTries
+	if maxTries == 0 {
+		maxTries = defaultBranchAndBoundMaxTries
+	}
+	search := &branchAndBoundSearch{
+		coins:       sortByValueDescending(coins),
+		targetValue: targetValue,
+		maxValue:    targetValue + s.CostOfChange,
+		maxInputs:   s.MaxInputs,
+		triesLeft:   maxTries,
+	}
+	if search.maxValue < targetValue {
+		search.maxValue = util.Amount(^uint64(0))
+	}
+
+	// remainingValues[i] is the total value of the coins from i onwards,
+	// which bounds what the rest of a selection can add
+	search.remainingValues = make([]util.Amount, len(search.coins)+1)
+	for i := len(search.coins) - 1; i >= 0; i-- {
+		search.remainingValues[i] = search.remainingValues[i+1] + search.coins[i].Value()
+	}
+
+	search.search(0, 0)
+	if search.bestSelection == nil {
+		return nil, ErrNoSelectionAvailable
+	}
+	selectedCoins := make([]Coin, len(search.bestSelection))
+	for i, coinIndex := range search.bestSelection {
+		selectedCoins[i] = search.coins[coinIndex]
+	}
+	return selectedCoins, nil
+}
+
+type branchAndBoundSearch struct {
+	coins           []Coin
+	remainingValues []util.Amount
+	targetValue     util.Amount
+	maxValue        util.Amount
+	maxInputs       int
+	triesLeft       int
+
+	selection     []int
+	bestSelection []int
+	bestExcess    util.Amount
+}
+
+// search tries including and excluding the coin at index, and then the
+// coins after it, in the selection that is currently worth selectedValue
+func (s *branchAndBoundSearch) search(index int, selectedValue util.Amount) {
+	if s.triesLeft == 0 {
+		return
+	}
+	s.triesLeft--
+
+	if selectedValue > s.maxValue || selectedValue+s.remainingValues[index] < s.targetValue {
+		return
+	}
+	if selectedValue >= s.targetValue {
+		excess := selectedValue - s.targetValue
+		if s.bestSelection == nil || excess < s.bestExcess ||
+			(excess == s.bestExcess && len(s.selection) < len(s.bestSelection)) {
+
+			s.bestSelection = append([]int(nil), s.selection...)
+			s.bestExcess = excess
+		}
+		return
+	}
+	if index == len(s.coins) || (s.maxInputs > 0 && len(s.selection) == s.maxInputs) {
+		return
+	}
+
+	s.selection = append(s.selection, index)
+	s.search(index+1, selectedValue+s.coins[index].Value())
+	s.selection = s.selection[:len(s.selection)-1]
+	if s.bestSelection != nil && s.bestExcess == 0 {
+		return
+	}
+
+	// Excluding a coin and then including one of the same value results
+	// in the same totals that were just searched, so such coins are
+	// skipped together
+	nextIndex := index + 1
+	for nextIndex < len(s.coins) && s.coins[nextIndex].Value() == s.coins[index].Value() {
+		nextIndex++
+	}
+	s.search(nextIndex, selectedValue)
+}
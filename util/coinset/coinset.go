@@ -0,0 +1,134 @@
+// Package coinset provides strategies for selecting which coins, that is
+// unspent transaction outputs, a transaction spends to pay a target value.
+//
+// A CoinSelector selects from coins that implement the Coin interface. NewCoin
+// wraps an outpoint and its UTXO entry as a Coin, and other types, such as the
+// coins of a wallet, may implement Coin directly. The target value passed to
+// a CoinSelector should include the fee of the transaction.
+package coinset
+
+import (
+	"sort"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrInsufficientFunds is returned when the total value of the coins is
+	// less than the target value.
+	ErrInsufficientFunds = errors.New("insufficient funds")
+
+	// ErrNoSelectionAvailable is returned when the coins are worth the
+	// target value, but the selector can't select them under its
+	// constraints.
+	ErrNoSelectionAvailable = errors.New("no coin selection possible")
+)
+
+// Coin is an unspent transaction output that can be selected.
+type Coin interface {
+	Outpoint() *externalapi.DomainOutpoint
+	Value() util.Amount
+	ScriptPublicKey() *externalapi.ScriptPublicKey
+
+	// BlockDAAScore is the DAA score of the block that accepted the
+	// transaction of the coin.
+	BlockDAAScore() uint64
+	IsCoinbase() bool
+}
+
+// CoinSelector is an interface that wraps the CoinSelect method.
+//
+// CoinSelect selects coins whose total value is at least targetValue. The
+// coins it selects are in the order they should be spent in. It returns
+// ErrInsufficientFunds if the coins aren't worth targetValue, and
+// ErrNoSelectionAvailable if they are, but it can't select them.
+type CoinSelector interface {
+	CoinSelect(targetValue util.Amount, coins []Coin) ([]Coin, error)
+}
+
+type utxoEntryCoin struct {
+	outpoint  *externalapi.DomainOutpoint
+	utxoEntry externalapi.UTXOEntry
+}
+
+// NewCoin returns a Coin of the given outpoint and its UTXO entry.
+func NewCoin(outpoint *externalapi.DomainOutpoint, utxoEntry externalapi.UTXOEntry) Coin {
+	return &utxoEntryCoin{
+		outpoint:  outpoint,
+		utxoEntry: utxoEntry,
+	}
+}
+
+func (c *utxoEntryCoin) Outpoint() *externalapi.DomainOutpoint {
+	return c.outpoint
+}
+
+func (c *utxoEntryCoin) Value() util.Amount {
+	return util.Amount(c.utxoEntry.Amount())
+}
+
+func (c *utxoEntryCoin) ScriptPublicKey() *externalapi.ScriptPublicKey {
+	return c.utxoEntry.ScriptPublicKey()
+}
+
+func (c *utxoEntryCoin) BlockDAAScore() uint64 {
+	return c.utxoEntry.BlockDAAScore()
+}
+
+func (c *utxoEntryCoin) IsCoinbase() bool {
+	return c.utxoEntry.IsCoinbase()
+}
+
+// TotalValue returns the total value of the given coins.
+func TotalValue(coins []Coin) util.Amount {
+	var totalValue util.Amount
+	for _, coin := range coins {
+		totalValue += coin.Value()
+	}
+	return totalValue
+}
+
+// Confirmations returns the number of confirmations of the coin, measured in
+// DAA score, when the virtual has the given DAA score.
+func Confirmations(coin Coin, virtualDAAScore uint64) uint64 {
+	if coin.BlockDAAScore() > virtualDAAScore {
+		return 0
+	}
+	return virtualDAAScore - coin.BlockDAAScore()
+}
+
+// FilterSpendable returns the coins that have at least minConfirmations
+// confirmations when the virtual has the given DAA score, excluding coinbase
+// coins that haven't matured yet, in their original order.
+func FilterSpendable(coins []Coin, virtualDAAScore uint64, coinbaseMaturity uint64,
+	minConfirmations uint64) []Coin {
+
+	var spendableCoins []Coin
+	for _, coin := range coins {
+		confirmations := Confirmations(coin, virtualDAAScore)
+		if confirmations < minConfirmations {
+			continue
+		}
+		if coin.IsCoinbase() && confirmations <= coinbaseMaturity {
+			continue
+		}
+		spendableCoins = append(spendableCoins, coin)
+	}
+	return spendableCoins
+}
+
+// sortByValueDescending returns a copy of the coins sorted by descending
+// value, and by ascending DAA score for coins of the same value, so that
+// the older of two equal coins is spent first.
+func sortByValueDescending(coins []Coin) []Coin {
+	sortedCoins := append([]Coin(nil), coins...)
+	sort.SliceStable(sortedCoins, func(i, j int) bool {
+		if sortedCoins[i].Value() != sortedCoins[j].Value() {
+			return sortedCoins[i].Value() > sortedCoins[j].Value()
+		}
+		return sortedCoins[i].BlockDAAScore() < sortedCoins[j].BlockDAAScore()
+	})
+	return sortedCoins
+}
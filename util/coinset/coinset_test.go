@@ -0,0 +1,225 @@
+package coinset_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/model/externalapi"
+	"github.com/kaspanet/kaspad/domain/consensus/utils/utxo"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/coinset"
+	"github.com/pkg/errors"
+)
+
+// newTestCoins returns coins of the given values, whose outpoints have the
+// index of the coin and whose DAA scores ascend with it
+func newTestCoins(values ...util.Amount) []coinset.Coin {
+	coins := make([]coinset.Coin, len(values))
+	for i, value := range values {
+		outpoint := externalapi.NewDomainOutpoint(
+			externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{1}), uint32(i))
+		utxoEntry := utxo.NewUTXOEntry(uint64(value), &externalapi.ScriptPublicKey{}, false, uint64(i))
+		coins[i] = coinset.NewCoin(outpoint, utxoEntry)
+	}
+	return coins
+}
+
+func coinIndexes(coins []coinset.Coin) []int {
+	indexes := make([]int, len(coins))
+	for i, coin := range coins {
+		indexes[i] = int(coin.Outpoint().Index)
+	}
+	return indexes
+}
+
+func equalIndexes(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNewCoin(t *testing.T) {
+	outpoint := externalapi.NewDomainOutpoint(
+		externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{1}), 2)
+	scriptPublicKey := &externalapi.ScriptPublicKey{Script: []byte{1, 2, 3}, Version: 0}
+	coin := coinset.NewCoin(outpoint, utxo.NewUTXOEntry(1000, scriptPublicKey, true, 30))
+
+	if !coin.Outpoint().Equal(outpoint) || coin.Value() != 1000 || !coin.ScriptPublicKey().Equal(scriptPublicKey) ||
+		coin.BlockDAAScore() != 30 || !coin.IsCoinbase() {
+
+		t.Fatalf("The coin doesn't match its UTXO entry")
+	}
+	if confirmations := coinset.Confirmations(coin, 100); confirmations != 70 {
+		t.Fatalf("Unexpected confirmations. Want: %d, got: %d", 70, confirmations)
+	}
+	if confirmations := coinset.Confirmations(coin, 10); confirmations != 0 {
+		t.Fatalf("Unexpected confirmations of a coin above the virtual. Want: %d, got: %d", 0, confirmations)
+	}
+}
+
+func TestFilterSpendable(t *testing.T) {
+	transactionID := externalapi.NewDomainTransactionIDFromByteArray(&[externalapi.DomainHashSize]byte{1})
+	newCoin := func(index uint32, isCoinbase bool, blockDAAScore uint64) coinset.Coin {
+		return coinset.NewCoin(externalapi.NewDomainOutpoint(transactionID, index),
+			utxo.NewUTXOEntry(1000, &externalapi.ScriptPublicKey{}, isCoinbase, blockDAAScore))
+	}
+	coins := []coinset.Coin{
+		newCoin(0, false, 100),
+		newCoin(1, false, 95),
+		newCoin(2, true, 50),
+		newCoin(3, true, 10),
+		newCoin(4, false, 0),
+	}
+
+	spendableCoins := coinset.FilterSpendable(coins, 100, 50, 0)
+	if !equalIndexes(coinIndexes(spendableCoins), []int{0, 1, 3, 4}) {
+		t.Fatalf("Unexpected spendable coins: %v", coinIndexes(spendableCoins))
+	}
+	spendableCoins = coinset.FilterSpendable(coins, 100, 50, 10)
+	if !equalIndexes(coinIndexes(spendableCoins), []int{3, 4}) {
+		t.Fatalf("Unexpected spendable coins with 10 confirmations: %v", coinIndexes(spendableCoins))
+	}
+}
+
+func TestLargestFirstCoinSelector(t *testing.T) {
+	tests := []struct {
+		name        string
+		values      []util.Amount
+		targetValue util.Amount
+		maxInputs   int
+		expected    []int
+		expectedErr error
+	}{
+		{"single coin", []util.Amount{1, 5, 3}, 4, 0, []int{1}, nil},
+		{"several coins", []util.Amount{1, 5, 3}, 7, 0, []int{1, 2}, nil},
+		{"all coins", []util.Amount{1, 5, 3}, 9, 0, []int{1, 2, 0}, nil},
+		{"older coin of equal value first", []util.Amount{5, 5, 5}, 5, 0, []int{0}, nil},
+		{"zero target", []util.Amount{1, 5, 3}, 0, 0, []int{}, nil},
+		{"insufficient funds", []util.Amount{1, 5, 3}, 10, 0, nil, coinset.ErrInsufficientFunds},
+		{"max inputs", []util.Amount{1, 5, 3}, 9, 2, nil, coinset.ErrNoSelectionAvailable},
+		{"within max inputs", []util.Amount{1, 5, 3}, 8, 2, []int{1, 2}, nil},
+	}
+
+	for _, test := range tests {
+		selector := &coinset.LargestFirstCoinSelector{MaxInputs: test.maxInputs}
+		selectedCoins, err := selector.CoinSelect(test.targetValue, newTestCoins(test.values...))
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: expected the error %v, but got %v", test.name, test.expectedErr, err)
+			continue
+		}
+		if err == nil && !equalIndexes(coinIndexes(selectedCoins), test.expected) {
+			t.Errorf("%s: expected the coins %v, but got %v", test.name, test.expected, coinIndexes(selectedCoins))
+		}
+	}
+}
+
+func TestBranchAndBoundCoinSelector(t *testing.T) {
+	tests := []struct {
+		name         string
+		values       []util.Amount
+		targetValue  util.Amount
+		costOfChange util.Amount
+		maxInputs    int
+		expected     []int
+		expectedErr  error
+	}{
+		{"exact match", []util.Amount{10, 7, 4, 2, 1}, 13, 0, 0, []int{0, 3, 4}, nil},
+		{"exact match of two coins", []util.Amount{6, 5, 4, 3, 2}, 9, 0, 0, []int{0, 3}, nil},
+		{"excess within the cost of change", []util.Amount{10, 8, 6}, 13, 2, 0, []int{1, 2}, nil},
+		{"least excess", []util.Amount{20, 15, 11}, 25, 10, 0, []int{1, 2}, nil},
+		{"equal values", []util.Amount{3, 3, 3, 3, 3, 3}, 12, 0, 0, []int{0, 1, 2, 3}, nil},
+		{"no match", []util.Amount{10, 8, 6}, 13, 0, 0, nil, coinset.ErrNoSelectionAvailable},
+		{"max inputs", []util.Amount{10, 7, 4, 2, 1}, 13, 0, 2, nil, coinset.ErrNoSelectionAvailable},
+		{"insufficient funds", []util.Amount{10, 8, 6}, 25, 0, 0, nil, coinset.ErrInsufficientFunds},
+	}
+
+	for _, test := range tests {
+		selector := &coinset.BranchAndBoundCoinSelector{CostOfChange: test.costOfChange, MaxInputs: test.maxInputs}
+		selectedCoins, err := selector.CoinSelect(test.targetValue, newTestCoins(test.values...))
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: expected the error %v, but got %v", test.name, test.expectedErr, err)
+			continue
+		}
+		if err == nil && !equalIndexes(coinIndexes(selectedCoins), test.expected) {
+			t.Errorf("%s: expected the coins %v, but got %v", test.name, test.expected, coinIndexes(selectedCoins))
+		}
+	}
+
+	// The search gives up after MaxTries, even though an exact match exists
+	values := make([]util.Amount, 40)
+	for i := range values {
+		values[i] = util.Amount(1000 + 2*i)
+	}
+	values = append(values, 1)
+	selector := &coinset.BranchAndBoundCoinSelector{MaxTries: 10}
+	_, err := selector.CoinSelect(1001, newTestCoins(values...))
+	if !errors.Is(err, coinset.ErrNoSelectionAvailable) {
+		t.Fatalf("Unexpected error after MaxTries. Want: %v, got: %v", coinset.ErrNoSelectionAvailable, err)
+	}
+}
+
+func TestRandomImproveCoinSelector(t *testing.T) {
+	values := make([]util.Amount, 50)
+	for i := range values {
+		values[i] = util.Amount(100 * (i%10 + 1))
+	}
+	coins := newTestCoins(values...)
+
+	const targetValue = 1000
+	for seed := int64(0); seed < 100; seed++ {
+		selector := &coinset.RandomImproveCoinSelector{Rand: rand.New(rand.NewSource(seed))}
+		selectedCoins, err := selector.CoinSelect(targetValue, coins)
+		if err != nil {
+			t.Fatalf("Seed %d: CoinSelect: %+v", seed, err)
+		}
+
+		isSelected := make(map[uint32]bool)
+		for _, coin := range selectedCoins {
+			if isSelected[coin.Outpoint().Index] {
+				t.Fatalf("Seed %d: coin %d was selected twice", seed, coin.Outpoint().Index)
+			}
+			isSelected[coin.Outpoint().Index] = true
+		}
+
+		// Every coin but the last one of the first phase is needed to
+		// reach the target value, so the total may only exceed three times
+		// the target value by the first phase alone
+		selectedValue := coinset.TotalValue(selectedCoins)
+		if selectedValue < targetValue {
+			t.Fatalf("Seed %d: the selected coins are worth %d, which is less than the target value",
+				seed, selectedValue)
+		}
+		firstPhaseValue := util.Amount(0)
+		for _, coin := range selectedCoins {
+			firstPhaseValue += coin.Value()
+			if firstPhaseValue >= targetValue {
+				break
+			}
+		}
+		if selectedValue > 3*targetValue && selectedValue != firstPhaseValue {
+			t.Fatalf("Seed %d: the selected coins are worth %d, which is more than three times "+
+				"the target value", seed, selectedValue)
+		}
+	}
+
+	selector := &coinset.RandomImproveCoinSelector{Rand: rand.New(rand.NewSource(0))}
+	_, err := selector.CoinSelect(coinset.TotalValue(coins)+1, coins)
+	if !errors.Is(err, coinset.ErrInsufficientFunds) {
+		t.Fatalf("Unexpected error. Want: %v, got: %v", coinset.ErrInsufficientFunds, err)
+	}
+	selector = &coinset.RandomImproveCoinSelector{Rand: rand.New(rand.NewSource(0)), MaxInputs: 2}
+	_, err = selector.CoinSelect(coinset.TotalValue(coins), coins)
+	if !errors.Is(err, coinset.ErrNoSelectionAvailable) {
+		t.Fatalf("Unexpected error. Want: %v, got: %v", coinset.ErrNoSelectionAvailable, err)
+	}
+	selectedCoins, err := selector.CoinSelect(targetValue, coins)
+	if err == nil && len(selectedCoins) > 2 {
+		t.Fatalf("The selector selected %d coins, but MaxInputs is 2", len(selectedCoins))
+	}
+}
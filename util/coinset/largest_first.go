@@ -0,0 +1,33 @@
+package coinset
+
+import "github.com/kaspanet/kaspad/util"
+
+// LargestFirstCoinSelector is a CoinSelector that selects the coins of the
+// largest value first, which results in the fewest inputs.
+type LargestFirstCoinSelector struct {
+	// MaxInputs is the maximum number of coins to select. Zero means no
+	// limit.
+	MaxInputs int
+}
+
+// CoinSelect selects the coins of the largest value until they are worth
+// targetValue.
+func (s *LargestFirstCoinSelector) CoinSelect(targetValue util.Amount, coins []Coin) ([]Coin, error) {
+	if TotalValue(coins) < targetValue {
+		return nil, ErrInsufficientFunds
+	}
+
+	var selectedCoins []Coin
+	var selectedValue util.Amount
+	for _, coin := range sortByValueDescending(coins) {
+		if selectedValue >= targetValue {
+			break
+		}
+		if s.MaxInputs > 0 && len(selectedCoins) == s.MaxInputs {
+			return nil, ErrNoSelectionAvailable
+		}
+		selectedCoins = append(selectedCoins, coin)
+		selectedValue += coin.Value()
+	}
+	return selectedCoins, nil
+}
@@ -0,0 +1,83 @@
+package coinset
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/kaspanet/kaspad/util"
+)
+
+// RandomImproveCoinSelector is a CoinSelector that selects random coins until
+// they are worth the target value, and then keeps adding random coins as long
+// as they bring the total closer to twice the target value, without
+// exceeding three times the target value.
+//
+// The resulting change is of about the same value as the payment, which
+// keeps the coins of a wallet suitable for future payments of similar
+// values, and the random selection makes it harder to link the coins of a
+// wallet together.
+type RandomImproveCoinSelector struct {
+	// Rand is the source of randomness of the selection. If it's nil, a
+	// source seeded with the current time is used.
+	Rand *rand.Rand
+
+	// MaxInputs is the maximum number of coins to select. Zero means no
+	// limit.
+	MaxInputs int
+}
+
+// CoinSelect selects random coins whose total value is at least targetValue.
+func (s *RandomImproveCoinSelector) CoinSelect(targetValue util.Amount, coins []Coin) ([]Coin, error) {
+	if TotalValue(coins) < targetValue {
+		return nil, ErrInsufficientFunds
+	}
+	random := s.Rand
+	if random == nil {
+		random = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	order := random.Perm(len(coins))
+	var selectedCoins []Coin
+	var selectedValue util.Amount
+	next := 0
+	for ; selectedValue < targetValue; next++ {
+		if s.MaxInputs > 0 && len(selectedCoins) == s.MaxInputs {
+			return nil, ErrNoSelectionAvailable
+		}
+		coin := coins[order[next]]
+		selectedCoins = append(selectedCoins, coin)
+		selectedValue += coin.Value()
+	}
+
+	idealValue := saturatingMultiply(targetValue, 2)
+	maxValue := saturatingMultiply(targetValue, 3)
+	for ; next < len(coins); next++ {
+		if s.MaxInputs > 0 && len(selectedCoins) == s.MaxInputs {
+			break
+		}
+		coin := coins[order[next]]
+		improvedValue := selectedValue + coin.Value()
+		if improvedValue < selectedValue || improvedValue > maxValue ||
+			distance(improvedValue, idealValue) >= distance(selectedValue, idealValue) {
+
+			break
+		}
+		selectedCoins = append(selectedCoins, coin)
+		selectedValue = improvedValue
+	}
+	return selectedCoins, nil
+}
+
+func saturatingMultiply(value util.Amount, multiplier uint64) util.Amount {
+	if uint64(value) > ^uint64(0)/multiplier {
+		return util.Amount(^uint64(0))
+	}
+	return value * util.Amount(multiplier)
+}
+
+func distance(a, b util.Amount) util.Amount {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
@@ -77,6 +77,35 @@ func ExampleAmount_unitConversions() {
 	// Sompi to Sompi: 44433322211100 Sompi
 }
 
+func ExampleParseAmount() {
+	amount, err := util.ParseAmount("444333.222111 KAS", util.AmountKAS)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println("KAS to Sompi:", uint64(amount))
+
+	unit, err := util.ParseAmountUnit("mKAS")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	amount, err = util.ParseAmount("1.5", unit)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println("MilliKAS to Sompi:", uint64(amount))
+
+	_, err = util.ParseAmount("0.000000001", util.AmountKAS)
+	fmt.Println(err)
+
+	// Output:
+	// KAS to Sompi: 44433322211100
+	// MilliKAS to Sompi: 150000
+	// amount "0.000000001" is more precise than a sompi
+}
+
 // This example demonstrates how to convert the compact "bits" in a block header
 // which represent the target difficulty to a big integer and display it using
 // the typical hex notation.
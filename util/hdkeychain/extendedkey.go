@@ -0,0 +1,341 @@
+package hdkeychain
+
+// References:
+//   [BIP32]: BIP0032 - Hierarchical Deterministic Wallets
+//   https://github.com/bitcoin/bips/blob/master/bip-0032.mediawiki
+
+import (
+	"crypto/rand"
+
+	"github.com/kaspanet/go-secp256k1"
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/util"
+	"github.com/kaspanet/kaspad/util/bip32"
+	"github.com/pkg/errors"
+)
+
+const (
+	// RecommendedSeedLen is the recommended length in bytes for a seed
+	// to a master node.
+	RecommendedSeedLen = 32 // 256 bits
+
+	// HardenedKeyStart is the index at which a hardened key starts. Each
+	// extended key has 2^31 normal child keys and 2^31 hardened child keys.
+	// Thus the range for normal child keys is [0, 2^31 - 1] and the range
+	// for hardened child keys is [2^31, 2^32 - 1].
+	HardenedKeyStart = 0x80000000 // 2^31
+
+	// MinSeedBytes is the minimum number of bytes allowed for a seed to
+	// a master node.
+	MinSeedBytes = 16 // 128 bits
+
+	// MaxSeedBytes is the maximum number of bytes allowed for a seed to
+	// a master node.
+	MaxSeedBytes = 64 // 512 bits
+)
+
+var (
+	// ErrDeriveHardFromPublic describes an error in which the caller
+	// attempted to derive a hardened extended key from a public key.
+	ErrDeriveHardFromPublic = bip32.ErrDeriveHardFromPublic
+
+	// ErrDeriveBeyondMaxDepth describes an error in which the caller
+	// has attempted to derive more than 255 keys from a root key.
+	ErrDeriveBeyondMaxDepth = bip32.ErrDeriveBeyondMaxDepth
+
+	// ErrNotPrivExtKey describes an error in which the caller attempted
+	// to extract a private key from a public extended key.
+	ErrNotPrivExtKey = errors.New("unable to create private keys from a " +
+		"public extended key")
+
+	// ErrInvalidChild describes an error in which the child at a specific
+	// index is invalid due to the derived key falling outside of the valid
+	// range for secp256k1 private keys. This error indicates the caller
+	// should simply ignore the invalid child extended key at this index and
+	// increment to the next index.
+	ErrInvalidChild = bip32.ErrInvalidChild
+
+	// ErrUnusableSeed describes an error in which the provided seed is not
+	// usable due to the derived key falling outside of the valid range for
+	// secp256k1 private keys. This error indicates the caller must choose
+	// another seed.
+	ErrUnusableSeed = bip32.ErrUnusableSeed
+
+	// ErrInvalidSeedLen describes an error in which the provided seed or
+	// seed length is not in the allowed range.
+	ErrInvalidSeedLen = errors.Errorf("seed length must be between %d and %d "+
+		"bits", MinSeedBytes*8, MaxSeedBytes*8)
+
+	// ErrBadChecksum describes an error in which the checksum encoded with
+	// a serialized extended key does not match the calculated value.
+	ErrBadChecksum = bip32.ErrBadChecksum
+
+	// ErrInvalidKeyLen describes an error in which the provided serialized
+	// key is not the expected length.
+	ErrInvalidKeyLen = bip32.ErrInvalidKeyLen
+)
+
+// ExtendedKey houses all the information needed to support a hierarchical
+// deterministic extended key. See the package overview documentation for
+// more details on how to use extended keys.
+//
+// The keys are derived and serialized by the util/bip32 package, which
+// kaspawallet uses as well, so that both produce the same keys.
+type ExtendedKey struct {
+	key *bip32.ExtendedKey
+}
+
+// IsPrivate returns whether or not the extended key is a private extended key.
+//
+// A private extended key can be used to derive both hardened and non-hardened
+// child private and public extended keys. A public extended key can only be
+// used to derive non-hardened child public extended keys.
+func (k *ExtendedKey) IsPrivate() bool {
+	return k.key.IsPrivate()
+}
+
+// Depth returns the current derivation level with respect to the root.
+//
+// The root key has depth zero, and the field has a maximum of 255 due to
+// how depth is serialized.
+func (k *ExtendedKey) Depth() uint8 {
+	return k.key.Depth
+}
+
+// Version returns the extended key's hardened derivation version. This can be
+// used to identify the extended key's type.
+func (k *ExtendedKey) Version() [4]byte {
+	return k.key.Version
+}
+
+// ParentFingerprint returns a fingerprint of the parent extended key from which
+// this one was derived.
+func (k *ExtendedKey) ParentFingerprint() [4]byte {
+	return k.key.ParentFingerprint
+}
+
+// ChildIndex returns the index at which the child extended key was derived.
+//
+// Extended keys with depth 0 indicate the master node, so the index is 0.
+func (k *ExtendedKey) ChildIndex() uint32 {
+	return k.key.ChildNumber
+}
+
+// ChainCode returns the chain code part of this extended key.
+//
+// It is identical for both public and private extended keys.
+func (k *ExtendedKey) ChainCode() []byte {
+	return append([]byte{}, k.key.ChainCode[:]...)
+}
+
+// Child returns a derived child extended key at the given index.
+//
+// When this extended key is a private extended key (as determined by the
+// IsPrivate function), a private extended key will be derived. Otherwise, the
+// derived extended key will also be a public extended key.
+//
+// When the index is greater than or equal to the HardenedKeyStart constant, the
+// derived extended key will be a hardened extended key. It is only possible to
+// derive a hardened extended key from a private extended key. Consequently,
+// this function will return ErrDeriveHardFromPublic if a hardened child
+// extended key is requested from a public extended key.
+//
+// A hardened extended key is useful since, as previously mentioned, it requires
+// a parent private extended key to derive. In other words, normal child
+// extended public keys can be derived from a parent public extended key (no
+// knowledge of the parent private key) whereas hardened extended keys may not
+// be.
+//
+// NOTE: There is an extremely small chance (< 1 in 2^127) the specific child
+// index does not derive to a usable child. The ErrInvalidChild error will be
+// returned if this should occur, and the caller is expected to ignore the
+// invalid child and simply increment to the next index.
+func (k *ExtendedKey) Child(i uint32) (*ExtendedKey, error) {
+	child, err := k.key.Child(i)
+	if err != nil {
+		return nil, err
+	}
+	return &ExtendedKey{key: child}, nil
+}
+
+// DeriveFromPath returns the descendant extended key of the given derivation
+// path, such as m/44'/111111'/0'/0/0. The path must start at m, which is
+// this extended key. Hardened indexes are marked with ', and M may be used
+// instead of m to derive the public extended key of the descendant.
+func (k *ExtendedKey) DeriveFromPath(path string) (*ExtendedKey, error) {
+	descendant, err := k.key.DeriveFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+	return &ExtendedKey{key: descendant}, nil
+}
+
+// Neuter returns a new extended public key from this extended private key. The
+// same extended key will be returned unaltered if it is already an extended
+// public key.
+//
+// As the name implies, an extended public key does not have access to the
+// private key, so it is not capable of signing transactions or deriving
+// child extended private keys. However, it is capable of deriving further
+// child extended public keys.
+func (k *ExtendedKey) Neuter() (*ExtendedKey, error) {
+	// Already an extended public key.
+	if !k.key.IsPrivate() {
+		return k, nil
+	}
+
+	public, err := k.key.Public()
+	if err != nil {
+		return nil, err
+	}
+	return &ExtendedKey{key: public}, nil
+}
+
+// ECDSAPublicKey converts the extended key to an ECDSA public key and returns it.
+func (k *ExtendedKey) ECDSAPublicKey() (*secp256k1.ECDSAPublicKey, error) {
+	publicKey, err := k.key.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// The key is copied, so that tweaking it doesn't change the extended key
+	publicKeyCopy := *publicKey
+	return &publicKeyCopy, nil
+}
+
+// SchnorrPublicKey converts the extended key to a schnorr public key, which
+// is the x-only form of its ECDSA public key, and returns it.
+func (k *ExtendedKey) SchnorrPublicKey() (*secp256k1.SchnorrPublicKey, error) {
+	publicKey, err := k.ECDSAPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	return publicKey.ToSchnorr()
+}
+
+// ECDSAPrivateKey converts the extended key to an ECDSA private key and returns
+// it. As you might imagine this is only possible if the extended key is a
+// private extended key (as determined by the IsPrivate function). The
+// ErrNotPrivExtKey error will be returned if this function is called on a
+// public extended key.
+func (k *ExtendedKey) ECDSAPrivateKey() (*secp256k1.ECDSAPrivateKey, error) {
+	if !k.key.IsPrivate() {
+		return nil, ErrNotPrivExtKey
+	}
+
+	// The key is copied, so that tweaking it doesn't change the extended key
+	privateKeyCopy := *k.key.PrivateKey()
+	return &privateKeyCopy, nil
+}
+
+// SchnorrKeyPair converts the extended key to a schnorr key pair, which signs
+// for the schnorr public key returned by SchnorrPublicKey, and returns it. The
+// ErrNotPrivExtKey error will be returned if this function is called on a
+// public extended key.
+func (k *ExtendedKey) SchnorrKeyPair() (*secp256k1.SchnorrKeyPair, error) {
+	if !k.key.IsPrivate() {
+		return nil, ErrNotPrivExtKey
+	}
+	return secp256k1.DeserializeSchnorrPrivateKeyFromSlice(k.key.PrivateKey().Serialize()[:])
+}
+
+// Address converts the extended key to a pay-to-pubkey address of its schnorr
+// public key, for the network of the given params, and returns it.
+func (k *ExtendedKey) Address(params *dagconfig.Params) (*util.AddressPublicKey, error) {
+	publicKey, err := k.SchnorrPublicKey()
+	if err != nil {
+		return nil, err
+	}
+	serializedPublicKey, err := publicKey.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	return util.NewAddressPublicKey(serializedPublicKey[:], params.Prefix)
+}
+
+// AddressECDSA converts the extended key to a pay-to-pubkey address of its
+// ECDSA public key, for the network of the given params, and returns it.
+func (k *ExtendedKey) AddressECDSA(params *dagconfig.Params) (*util.AddressPublicKeyECDSA, error) {
+	publicKey, err := k.key.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	serializedPublicKey, err := publicKey.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	return util.NewAddressPublicKeyECDSA(serializedPublicKey[:], params.Prefix)
+}
+
+// String returns the extended key as a human-readable base58-encoded string.
+func (k *ExtendedKey) String() string {
+	return k.key.String()
+}
+
+// IsForNet returns whether or not the extended key is associated with the
+// passed kaspa network.
+func (k *ExtendedKey) IsForNet(params *dagconfig.Params) bool {
+	return k.key.Version == params.HDPrivateKeyID || k.key.Version == params.HDPublicKeyID
+}
+
+// SetNet associates the extended key, and any child keys yet to be derived
+// from it, with the passed network.
+func (k *ExtendedKey) SetNet(params *dagconfig.Params) {
+	if k.key.IsPrivate() {
+		k.key.Version = params.HDPrivateKeyID
+	} else {
+		k.key.Version = params.HDPublicKeyID
+	}
+}
+
+// NewMaster creates a new master node for use in creating a hierarchical
+// deterministic key chain. The seed must be between 128 and 512 bits and
+// should be generated by a cryptographically secure random generation source.
+//
+// NOTE: There is an extremely small chance (< 1 in 2^127) the provided seed
+// will derive to an unusable secret key. The ErrUnusableSeed error will be
+// returned if this should occur, so the caller must check for it and generate
+// a new seed accordingly.
+func NewMaster(seed []byte, params *dagconfig.Params) (*ExtendedKey, error) {
+	// Per [BIP32], the seed must be in range [MinSeedBytes, MaxSeedBytes].
+	if len(seed) < MinSeedBytes || len(seed) > MaxSeedBytes {
+		return nil, ErrInvalidSeedLen
+	}
+
+	master, err := bip32.NewMaster(seed, params.HDPrivateKeyID)
+	if err != nil {
+		return nil, err
+	}
+	return &ExtendedKey{key: master}, nil
+}
+
+// NewKeyFromString returns a new extended key instance from a base58-encoded
+// extended key.
+func NewKeyFromString(key string) (*ExtendedKey, error) {
+	extendedKey, err := bip32.DeserializeExtendedKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return &ExtendedKey{key: extendedKey}, nil
+}
+
+// GenerateSeed returns a cryptographically secure random seed that can be used
+// as the input for the NewMaster function to generate a new master node.
+//
+// The length is in bytes and it must be between 16 and 64 (128 to 512 bits).
+// The recommended length is 32 (256 bits) as defined by the RecommendedSeedLen
+// constant.
+func GenerateSeed(length uint8) ([]byte, error) {
+	// Per [BIP32], the seed must be in range [MinSeedBytes, MaxSeedBytes].
+	if length < MinSeedBytes || length > MaxSeedBytes {
+		return nil, ErrInvalidSeedLen
+	}
+
+	buf := make([]byte, length)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
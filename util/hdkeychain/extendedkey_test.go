@@ -0,0 +1,312 @@
+package hdkeychain_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/util/hdkeychain"
+	"github.com/pkg/errors"
+)
+
+// TestBIP32Vectors tests the BIP32 test vectors, re-encoded with the
+// extended key magics of the kaspa mainnet. The test vectors are the ones of
+// https://github.com/bitcoin/bips/blob/master/bip-0032.mediawiki#Test_Vectors
+func TestBIP32Vectors(t *testing.T) {
+	type testPath struct {
+		path               string
+		extendedPublicKey  string
+		extendedPrivateKey string
+	}
+
+	tests := []struct {
+		seed  string
+		paths []testPath
+	}{
+		{
+			seed: "000102030405060708090a0b0c0d0e0f",
+			paths: []testPath{
+				{
+					path:               "m",
+					extendedPublicKey:  "kpub2C2CKMtB3F5r4LEGRnS3o73omeQB3KJ5QfAzC5R3t9bpChBEZNitvn92JYeCTMtnR7oE1im7DhsxGqV72JErXFG9G3YnTHRnZPkGZLFE6PZ",
+					extendedPrivateKey: "kprv5y2qurMHCsXYqr9oKku3Ry75DcZgdraE3SFPPh1SKp4qKtr61qQeNypYTGztwUUiVauHWmjxaQXeUKHxj4QCuDG4ULpZHkvBoH9XX19ynXm",
+				},
+				{
+					path:               "m/0'",
+					extendedPublicKey:  "kpub2EHcK5Be8WCqCwMydYJgg99v6TxXRPn66GbtAAoArLo6ZyUQycFz3vVS5pCuCfoKRL5nsxJXxLx3FETEyKyEb8isTgM3NbL15KsprxXRXYP",
+					extendedPrivateKey: "kprv61JFuZekJ8eXzTHWXWmgK1DBYS831w4Ej3gHMnPZJ1G7hB9GS4wjW8AxEYMEMBrgCdnyt54pxmNXC5KgNegPhHLaYDVhXid5WHnNxE7Nir6",
+				},
+				{
+					path:               "m/0'/1",
+					extendedPublicKey:  "kpub2GTjWrjXXD5u3PQRMoCZGt3a9qwdRRWP2bGikSZynybJoWyYhQgJ1VPfVtfUccWfP3hqfNke4wSWqYC4Sf98GnYoktBtrELGi4Qc9xmGTUP",
+					extendedPrivateKey: "kprv63UP7MCdgqXbpuKxFmfYuk6qbp791xnXfNM7x4ANEe4KvieQ9sN3Th5BebYHx7dieiYfgtfG3UKwL1quVzUNUSq23zTRbUPwB66kV2rWPC8",
+				},
+				{
+					path:               "m/0'/1/2'",
+					extendedPublicKey:  "kpub2K51ZPZPE5wJuZCWcPbvdt5iNzp9gy6NN8WPzms8xqxkDNAfWAWiuvwb3urK4UwyjZoaGkjFSt1VHsLM9kgfLEheLnA2wBPxRkKkFDqc9zP",
+					extendedPrivateKey: "kprv665f9t2VPiP1h583WN4vGk8ypxyfHWNWzuaoCPTXQWRmLZqWxdCUN8d7CdkuvM9DABa4HMcBTt9qZDaf61PZbYGgQc1ykQdsnMqy7fTCNrm",
+				},
+				{
+					path:               "m/0'/1/2'/2",
+					extendedPublicKey:  "kpub2MJQPpgLQZcHz2gEJep1XPF2Tp6tKZQZocPhFjPcHHXMaTo2ZwD67WQWjEqhUH6iCsvkQmDCVcubrHgMF47s3qAuFZiDmNHnSSEbPpuRWiZ",
+					extendedPrivateKey: "kprv68K3zK9SaC3zmYbmCdH1AFJHunGPv6giSPU6TLyziwzNhfTt2PtqZi62sxANP1YeDyhkuGqkNhc12QV7HRvunvrior75JVTawLK8d8zN34Z",
+				},
+				{
+					path:               "m/0'/1/2'/2/1000000000",
+					extendedPublicKey:  "kpub2P2AsWHaXgzVWNTgRCNjq6F2G3gC94DbbrnFW1mkVMurHbCR6MTkNcZaN4keKYBRgaDHv7912pcCSi5NLuchu6L2878JZqsRFPrWduDKq9i",
+					extendedPrivateKey: "kprv6A2pTzkghKSCHtPDKAqjTxJHi1qhjbVkEdrehdN8w2NsQnsGYp9VppF6WowaHvfiqP71gdphDk982aVUpVwdutWG9LsJRQDJDfsVNMbtSap",
+				},
+			},
+		},
+		{
+			seed: "fffcf9f6f3f0edeae7e4e1dedbd8d5d2cfccc9c6c3c0bdbab7b4b1aeaba8a5a29f9c999693908d8a8784817e7b7875726f6c696663605d5a5754514e4b484542",
+			paths: []testPath{
+				{
+					path:               "m",
+					extendedPublicKey:  "kpub2C2CKMtB3F5r3wjbXwWLFJma1qYbiwYu6ExiV29srXigVgCRjXVqMgJceejBAcFkKg31vPRGcnPCzdDL9VA1fAG67ykFHmvSsmRNqKZg1po",
+					extendedPrivateKey: "kprv5y2qurMHCsXYqTf8RuyKtApqToi7KUq3j237gdkGJCBhcssHBzBaosz8oLmx7z2ojdeiG4CQrWZqZr24mUnuWaapvktoS6pvNXmkszbHsFE",
+				},
+				{
+					path:               "m/0",
+					extendedPublicKey:  "kpub2FHwb5a8XFuvaDKtfitDK7B6NoHrRv3BeQi5eqBKvwaeBeeQJnquWWssE7h4xhGBXzXBncR21sEB9ne22drRzkvNQ2UvC84q1FY3GVzjZr1",
+					extendedPrivateKey: "kprv62JbBa3EgtMdMjFRZhMCwyEMpmTN2TKLHBnUrSmiNc3fJrKFmFXexiZPNr3km3se9HtYA4c9HfyxvMetKmHxSokDvwJrpazfVwgKFEAdr1L",
+				},
+				{
+					path:               "m/0/2147483647'",
+					extendedPublicKey:  "kpub2GSzqgbeuA62k5Y56AsrnSremYWkyQCsjZncaE66agM2dwsrvgGDiafTqVwBiRsHKWSjSTGdK5empTWMoYLYiuNzw76yYrKqsdoe7KSjW9n",
+					extendedPrivateKey: "kprv63TeSB4m4nXjXbTbz9LrRJuvDWgGZwV2NLs1mqgV2Lp3m9YiP8wyAnLyzCXjVJc83XDRw5onLgV5MbPf48u627BnMfYCb6ivHj1r1gJwAAq",
+				},
+				{
+					path:               "m/0/2147483647'/1",
+					extendedPublicKey:  "kpub2KFyFhab4oPDqhDD9q2RkPnt75PG5b8941HURHkRtZhUJmk2EBnvcV3qgJ8KWJZZuguHH6MrxCxbuFmNiSmVzEquXPJpmPm3oQUbMkjZU7h",
+					extendedPrivateKey: "kprv66GcrC3hERpvdD8k3oVRPFr9Z3Ymg8QHgnMscuLpLEAVRyQsgeUg4gjMpzjMX1opMUa8gNtAkEAHgJAp72RU2b15VS51SChJmXSaVHSHVgJ",
+				},
+				{
+					path:               "m/0/2147483647'/1/2147483646'",
+					extendedPublicKey:  "kpub2LS1AfWwgCLw8eSotJqy7uV51ord8Zke5i1Mx1SqjKxim84xKriw91QwJxFphg61s8Yv5bRZzpHTYtvmQKt1hbYMoHdKKgrTfdZAtem6FS7",
+					extendedPrivateKey: "kprv67Sem9z3qpndvANLnHJxkmYLTn28j72niV5m9d3EAzRjtKjonKQgbD6TThTk9SC6u3rpzCfA8bjsVRGBcyKxiRgFKNcKaQiw77T6Z6V751r",
+				},
+				{
+					path:               "m/0/2147483647'/1/2147483646'/2",
+					extendedPublicKey:  "kpub2Mo386jTCNfAsudhcNyf6es3QsPjNtfijsdFMnoLN7pJqKQXVVehKaMwPML6qFSiPBm9MWvytXJT3KzGERZv1rPwSTTQG49CLvkMZGaHgA1",
+					extendedPrivateKey: "kprv68ogibCZN16sfRZEWMSejWvJrqZEyRwsNeheZQPionHKxX5NwxLSmn3TY78kJTHAwMiZGxHyahaZXy9hMHhBmQQy8E7pdpreoUnedk17vmK",
+				},
+			},
+		},
+	}
+
+	for i, test := range tests {
+		seed, err := hex.DecodeString(test.seed)
+		if err != nil {
+			t.Fatalf("test #%d: DecodeString: %s", i, err)
+		}
+		master, err := hdkeychain.NewMaster(seed, &dagconfig.MainnetParams)
+		if err != nil {
+			t.Fatalf("test #%d: NewMaster: %s", i, err)
+		}
+		for _, path := range test.paths {
+			extendedPrivateKey, err := master.DeriveFromPath(path.path)
+			if err != nil {
+				t.Fatalf("test #%d: %s: DeriveFromPath: %s", i, path.path, err)
+			}
+			if extendedPrivateKey.String() != path.extendedPrivateKey {
+				t.Fatalf("test #%d: %s: expected the extended private key %s, but got %s",
+					i, path.path, path.extendedPrivateKey, extendedPrivateKey)
+			}
+			extendedPublicKey, err := extendedPrivateKey.Neuter()
+			if err != nil {
+				t.Fatalf("test #%d: %s: Neuter: %s", i, path.path, err)
+			}
+			if extendedPublicKey.String() != path.extendedPublicKey {
+				t.Fatalf("test #%d: %s: expected the extended public key %s, but got %s",
+					i, path.path, path.extendedPublicKey, extendedPublicKey)
+			}
+
+			// Both extended keys must survive a serialization round trip
+			for _, serialized := range []string{path.extendedPrivateKey, path.extendedPublicKey} {
+				deserialized, err := hdkeychain.NewKeyFromString(serialized)
+				if err != nil {
+					t.Fatalf("test #%d: %s: NewKeyFromString: %s", i, path.path, err)
+				}
+				if deserialized.String() != serialized {
+					t.Fatalf("test #%d: %s: expected %s to survive a round trip, but got %s",
+						i, path.path, serialized, deserialized)
+				}
+				if !deserialized.IsForNet(&dagconfig.MainnetParams) {
+					t.Fatalf("test #%d: %s: expected %s to be for mainnet", i, path.path, serialized)
+				}
+			}
+		}
+	}
+}
+
+func TestPublicDerivation(t *testing.T) {
+	seed, err := hdkeychain.GenerateSeed(hdkeychain.RecommendedSeedLen)
+	if err != nil {
+		t.Fatalf("GenerateSeed: %s", err)
+	}
+	master, err := hdkeychain.NewMaster(seed, &dagconfig.MainnetParams)
+	if err != nil {
+		t.Fatalf("NewMaster: %s", err)
+	}
+	account, err := master.DeriveFromPath("m/44'/111111'/0'")
+	if err != nil {
+		t.Fatalf("DeriveFromPath: %s", err)
+	}
+	accountPublicKey, err := account.Neuter()
+	if err != nil {
+		t.Fatalf("Neuter: %s", err)
+	}
+
+	// Non-hardened children of the public key must be the public keys of the
+	// children of the private key
+	for _, path := range []string{"m/0/0", "m/1/5", "m/0/2147483647"} {
+		privateChild, err := account.DeriveFromPath(path)
+		if err != nil {
+			t.Fatalf("%s: DeriveFromPath: %s", path, err)
+		}
+		expectedPublicChild, err := privateChild.Neuter()
+		if err != nil {
+			t.Fatalf("%s: Neuter: %s", path, err)
+		}
+		publicChild, err := accountPublicKey.DeriveFromPath(path)
+		if err != nil {
+			t.Fatalf("%s: DeriveFromPath: %s", path, err)
+		}
+		if publicChild.IsPrivate() {
+			t.Fatalf("%s: expected a public extended key", path)
+		}
+		if publicChild.String() != expectedPublicChild.String() {
+			t.Fatalf("%s: expected the public child %s, but got %s", path, expectedPublicChild, publicChild)
+		}
+
+		address, err := publicChild.Address(&dagconfig.MainnetParams)
+		if err != nil {
+			t.Fatalf("%s: Address: %s", path, err)
+		}
+		expectedAddress, err := privateChild.Address(&dagconfig.MainnetParams)
+		if err != nil {
+			t.Fatalf("%s: Address: %s", path, err)
+		}
+		if address.EncodeAddress() != expectedAddress.EncodeAddress() {
+			t.Fatalf("%s: expected the address %s, but got %s", path, expectedAddress, address)
+		}
+	}
+
+	_, err = accountPublicKey.Child(hdkeychain.HardenedKeyStart)
+	if !errors.Is(err, hdkeychain.ErrDeriveHardFromPublic) {
+		t.Fatalf("Expected ErrDeriveHardFromPublic, but got %v", err)
+	}
+	_, err = accountPublicKey.ECDSAPrivateKey()
+	if !errors.Is(err, hdkeychain.ErrNotPrivExtKey) {
+		t.Fatalf("Expected ErrNotPrivExtKey, but got %v", err)
+	}
+}
+
+func TestNetworks(t *testing.T) {
+	seed := make([]byte, hdkeychain.RecommendedSeedLen)
+	tests := []struct {
+		params                  *dagconfig.Params
+		extendedPrivateKeyStart string
+		extendedPublicKeyStart  string
+	}{
+		{&dagconfig.MainnetParams, "kprv", "kpub"},
+		{&dagconfig.TestnetParams, "ktrv", "ktub"},
+		{&dagconfig.SimnetParams, "ksrv", "ksub"},
+		{&dagconfig.DevnetParams, "kdrv", "kdub"},
+	}
+	for _, test := range tests {
+		master, err := hdkeychain.NewMaster(seed, test.params)
+		if err != nil {
+			t.Fatalf("%s: NewMaster: %s", test.params.Name, err)
+		}
+		if !strings.HasPrefix(master.String(), test.extendedPrivateKeyStart) {
+			t.Fatalf("%s: expected the extended private key to start with %s, but got %s",
+				test.params.Name, test.extendedPrivateKeyStart, master)
+		}
+		extendedPublicKey, err := master.Neuter()
+		if err != nil {
+			t.Fatalf("%s: Neuter: %s", test.params.Name, err)
+		}
+		if !strings.HasPrefix(extendedPublicKey.String(), test.extendedPublicKeyStart) {
+			t.Fatalf("%s: expected the extended public key to start with %s, but got %s",
+				test.params.Name, test.extendedPublicKeyStart, extendedPublicKey)
+		}
+
+		address, err := master.Address(test.params)
+		if err != nil {
+			t.Fatalf("%s: Address: %s", test.params.Name, err)
+		}
+		if !address.IsForPrefix(test.params.Prefix) {
+			t.Fatalf("%s: expected the address %s to be for the prefix %s",
+				test.params.Name, address, test.params.Prefix)
+		}
+
+		for _, otherTest := range tests {
+			isForNet := master.IsForNet(otherTest.params)
+			if isForNet != (otherTest.params == test.params) {
+				t.Fatalf("%s: IsForNet(%s) unexpectedly returned %t",
+					test.params.Name, otherTest.params.Name, isForNet)
+			}
+		}
+	}
+}
+
+// TestRegisteredNetwork ensures that the extended keys of a network that is
+// registered in dagconfig are neutered according to its HD key IDs.
+func TestRegisteredNetwork(t *testing.T) {
+	params := dagconfig.MainnetParams
+	params.Name = "custom"
+	params.Net = 0xfeedbeef
+	params.HDPrivateKeyID = [4]byte{0x01, 0x02, 0x03, 0x04}
+	params.HDPublicKeyID = [4]byte{0x05, 0x06, 0x07, 0x08}
+
+	master, err := hdkeychain.NewMaster(make([]byte, hdkeychain.RecommendedSeedLen), &params)
+	if err != nil {
+		t.Fatalf("NewMaster: %s", err)
+	}
+	_, err = master.Neuter()
+	if err == nil {
+		t.Fatalf("Neuter unexpectedly succeeded for an unregistered network")
+	}
+
+	err = dagconfig.Register(&params)
+	if err != nil {
+		t.Fatalf("Register: %s", err)
+	}
+	extendedPublicKey, err := master.Neuter()
+	if err != nil {
+		t.Fatalf("Neuter: %s", err)
+	}
+	if !extendedPublicKey.IsForNet(&params) {
+		t.Fatalf("Expected the extended public key to be for the registered network")
+	}
+}
+
+func TestNewKeyFromStringErrors(t *testing.T) {
+	const validKey = "kprv5y2qurMHCsXYqr9oKku3Ry75DcZgdraE3SFPPh1SKp4qKtr61qQeNypYTGztwUUiVauHWmjxaQXeUKHxj4QCuDG4ULpZHkvBoH9XX19ynXm"
+	tests := []struct {
+		name        string
+		key         string
+		expectedErr error
+	}{
+		{"too short", validKey[:len(validKey)-2], hdkeychain.ErrInvalidKeyLen},
+		{"bad checksum", validKey[:len(validKey)-1] + "n", hdkeychain.ErrBadChecksum},
+	}
+	for _, test := range tests {
+		_, err := hdkeychain.NewKeyFromString(test.key)
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: expected %v, but got %v", test.name, test.expectedErr, err)
+		}
+	}
+
+	_, err := hdkeychain.NewMaster(make([]byte, hdkeychain.MinSeedBytes-1), &dagconfig.MainnetParams)
+	if !errors.Is(err, hdkeychain.ErrInvalidSeedLen) {
+		t.Errorf("Expected ErrInvalidSeedLen, but got %v", err)
+	}
+	_, err = hdkeychain.GenerateSeed(hdkeychain.MaxSeedBytes + 1)
+	if !errors.Is(err, hdkeychain.ErrInvalidSeedLen) {
+		t.Errorf("Expected ErrInvalidSeedLen, but got %v", err)
+	}
+}
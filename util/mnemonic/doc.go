@@ -0,0 +1,41 @@
+/*
+Package mnemonic provides an API for BIP0039 mnemonic sentences, which encode
+the seeds of hierarchical deterministic wallets as lists of English words.
+
+# Overview
+
+A mnemonic sentence is created from a random entropy of 128 to 256 bits. The
+entropy is followed by a checksum taken from its SHA256 hash, and the result is
+split into groups of 11 bits, each of which selects a word of the BIP0039
+English wordlist. A sentence therefore has 12, 15, 18, 21 or 24 words.
+
+The encoding is done by github.com/tyler-smith/go-bip39, which kaspawallet
+uses as well. This package adds typed errors, and the derivation of the
+master extended key of a sentence.
+
+# Creating a Mnemonic
+
+NewEntropy returns a cryptographically random entropy of a given size, and
+NewMnemonic encodes an entropy as a mnemonic sentence. Generate does both.
+
+# Restoring a Mnemonic
+
+Validate checks that all the words of a sentence are in the wordlist and that
+its checksum is correct. EntropyFromMnemonic returns the entropy that a sentence
+encodes.
+
+# Seeds and Extended Keys
+
+NewSeed stretches a mnemonic sentence and an optional passphrase into the
+64-byte seed of a wallet. Different passphrases result in unrelated seeds, so
+a passphrase that is forgotten can't be recovered from the sentence.
+
+The seed is used to create the master node of a wallet with
+hdkeychain.NewMaster. NewMasterKey does this in one step, so that a wallet can
+go from a mnemonic sentence to its addresses:
+
+	master, err := mnemonic.NewMasterKey(sentence, passphrase, &dagconfig.MainnetParams)
+	key, err := master.DeriveFromPath("m/44'/111111'/0'/0/0")
+	address, err := key.Address(&dagconfig.MainnetParams)
+*/
+package mnemonic
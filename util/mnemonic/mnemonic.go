@@ -0,0 +1,177 @@
+package mnemonic
+
+// References:
+//   [BIP39]: BIP0039 - Mnemonic code for generating deterministic keys
+//   https://github.com/bitcoin/bips/blob/master/bip-0039.mediawiki
+
+import (
+	"strings"
+
+	"github.com/kaspanet/kaspad/domain/dagconfig"
+	"github.com/kaspanet/kaspad/util/hdkeychain"
+	"github.com/pkg/errors"
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// MinEntropyBits is the minimum size of the entropy of a mnemonic
+	// sentence, which encodes it in 12 words.
+	MinEntropyBits = 128
+
+	// MaxEntropyBits is the maximum size of the entropy of a mnemonic
+	// sentence, which encodes it in 24 words.
+	MaxEntropyBits = 256
+
+	// RecommendedEntropyBits is the recommended size of the entropy of a
+	// mnemonic sentence.
+	RecommendedEntropyBits = MaxEntropyBits
+
+	// SeedLen is the length in bytes of the seed that a mnemonic sentence
+	// is stretched into.
+	SeedLen = 64
+
+	// entropyBitsMultiple is the number of bits that the size of an entropy
+	// must be a multiple of. Every 32 bits of entropy add one bit of
+	// checksum, and three words to the sentence.
+	entropyBitsMultiple = 32
+)
+
+var (
+	// ErrInvalidEntropyLen describes an error in which the provided entropy
+	// or entropy size isn't between MinEntropyBits and MaxEntropyBits, or
+	// isn't a multiple of 32 bits.
+	ErrInvalidEntropyLen = errors.Errorf("entropy size must be between %d and %d "+
+		"bits, and a multiple of %d bits", MinEntropyBits, MaxEntropyBits, entropyBitsMultiple)
+
+	// ErrInvalidWordCount describes an error in which a mnemonic sentence
+	// doesn't have 12, 15, 18, 21 or 24 words.
+	ErrInvalidWordCount = errors.New("the number of words of a mnemonic " +
+		"sentence must be 12, 15, 18, 21 or 24")
+
+	// ErrUnknownWord describes an error in which a word of a mnemonic
+	// sentence isn't in the wordlist.
+	ErrUnknownWord = errors.New("unknown mnemonic word")
+
+	// ErrBadChecksum describes an error in which the checksum of a mnemonic
+	// sentence doesn't match its entropy, which usually means that one of
+	// its words is wrong.
+	ErrBadChecksum = errors.New("bad mnemonic checksum")
+)
+
+// NewEntropy returns a cryptographically random entropy of the given number
+// of bits, for use with NewMnemonic.
+func NewEntropy(bitSize int) ([]byte, error) {
+	if !isValidEntropyBits(bitSize) {
+		return nil, ErrInvalidEntropyLen
+	}
+
+	entropy, err := bip39.NewEntropy(bitSize)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return entropy, nil
+}
+
+// Generate returns a new mnemonic sentence of a cryptographically random
+// entropy of the given number of bits.
+func Generate(bitSize int) (string, error) {
+	entropy, err := NewEntropy(bitSize)
+	if err != nil {
+		return "", err
+	}
+	return NewMnemonic(entropy)
+}
+
+// NewMnemonic returns the mnemonic sentence that encodes the given entropy.
+// The words of the sentence are separated by single spaces.
+func NewMnemonic(entropy []byte) (string, error) {
+	if !isValidEntropyBits(len(entropy) * 8) {
+		return "", ErrInvalidEntropyLen
+	}
+
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return mnemonic, nil
+}
+
+// EntropyFromMnemonic returns the entropy that the given mnemonic sentence
+// encodes. It returns an error if the sentence isn't valid.
+func EntropyFromMnemonic(mnemonic string) ([]byte, error) {
+	// The word count and the words are checked before bip39 decodes the
+	// sentence, so that the returned error tells what's wrong with it
+	words := strings.Fields(mnemonic)
+	if len(words)%3 != 0 || len(words) < 12 || len(words) > 24 {
+		return nil, ErrInvalidWordCount
+	}
+	for i, word := range words {
+		if !IsWord(word) {
+			return nil, errors.Wrapf(ErrUnknownWord, "word %d (%q)", i+1, word)
+		}
+	}
+
+	entropy, err := bip39.EntropyFromMnemonic(strings.Join(words, " "))
+	if err != nil {
+		if errors.Is(err, bip39.ErrChecksumIncorrect) {
+			return nil, ErrBadChecksum
+		}
+		return nil, errors.WithStack(err)
+	}
+	return entropy, nil
+}
+
+// Validate returns an error if the given mnemonic sentence has a wrong number
+// of words, a word that isn't in the wordlist, or a bad checksum.
+func Validate(mnemonic string) error {
+	_, err := EntropyFromMnemonic(mnemonic)
+	return err
+}
+
+// NewSeed returns the seed of the given mnemonic sentence and passphrase, for
+// use with hdkeychain.NewMaster. The passphrase may be empty. It returns an
+// error if the sentence isn't valid.
+//
+// The words of the sentence are separated by single spaces, and both the
+// sentence and the passphrase are normalized to Unicode NFKD before they're
+// stretched into the seed, as BIP0039 requires. This way a passphrase that
+// isn't ASCII results in the same seed however it was typed.
+func NewSeed(mnemonic, passphrase string) ([]byte, error) {
+	err := Validate(mnemonic)
+	if err != nil {
+		return nil, err
+	}
+
+	sentence := norm.NFKD.String(strings.Join(strings.Fields(mnemonic), " "))
+	seed, err := bip39.NewSeedWithErrorChecking(sentence, norm.NFKD.String(passphrase))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return seed, nil
+}
+
+// NewMasterKey returns the master extended key of the given mnemonic sentence
+// and passphrase, for the network of the given params.
+func NewMasterKey(mnemonic, passphrase string, params *dagconfig.Params) (*hdkeychain.ExtendedKey, error) {
+	seed, err := NewSeed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return hdkeychain.NewMaster(seed, params)
+}
+
+// WordList returns a copy of the BIP0039 English wordlist.
+func WordList() []string {
+	return append([]string(nil), bip39.GetWordList()...)
+}
+
+// IsWord returns whether the given word is in the wordlist.
+func IsWord(word string) bool {
+	_, ok := bip39.GetWordIndex(word)
+	return ok
+}
+
+func isValidEntropyBits(bitSize int) bool {
+	return bitSize >= MinEntropyBits && bitSize <= MaxEntropyBits && bitSize%entropyBitsMultiple == 0
+}
@@ -0,0 +1,59 @@
+package mstime
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the current time. Components that depend on the current time,
+// such as the validation of block timestamps, are given a Clock rather than
+// calling Now, so that tests can control the time they see.
+type Clock interface {
+	Now() Time
+}
+
+// SystemClock is the Clock of the local system time
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() Time {
+	return Now()
+}
+
+// ManualClock is a Clock that only moves when it's set or advanced. It's
+// meant for tests.
+type ManualClock struct {
+	lock sync.Mutex
+	now  Time
+}
+
+// NewManualClock returns a ManualClock that's set to now
+func NewManualClock(now Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+// Now returns the time the clock is set to
+func (c *ManualClock) Now() Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.now
+}
+
+// Set sets the clock to now
+func (c *ManualClock) Set(now Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.now = now
+}
+
+// Advance moves the clock forward by d, or backward if d is negative.
+// It panics if d has a precision greater than one millisecond.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.now = c.now.Add(d)
+}
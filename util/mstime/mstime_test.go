@@ -72,3 +72,26 @@ func TestAdd(t *testing.T) {
 	}()
 	UnixMilliseconds(100).Add(time.Nanosecond)
 }
+
+func TestManualClock(t *testing.T) {
+	start := UnixMilliseconds(1_000_000)
+	clock := NewManualClock(start)
+	if got := clock.Now().UnixMilliseconds(); got != 1_000_000 {
+		t.Fatalf("expected the clock to be set to 1000000 but got %d", got)
+	}
+
+	clock.Advance(1500 * time.Millisecond)
+	if got := clock.Now().UnixMilliseconds(); got != 1_001_500 {
+		t.Fatalf("expected the clock to be advanced to 1001500 but got %d", got)
+	}
+
+	clock.Advance(-time.Second)
+	if got := clock.Now().UnixMilliseconds(); got != 1_000_500 {
+		t.Fatalf("expected the clock to be moved back to 1000500 but got %d", got)
+	}
+
+	clock.Set(start)
+	if got := clock.Now().UnixMilliseconds(); got != 1_000_000 {
+		t.Fatalf("expected the clock to be set back to 1000000 but got %d", got)
+	}
+}
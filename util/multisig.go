@@ -0,0 +1,144 @@
+package util
+
+import (
+	"github.com/pkg/errors"
+)
+
+// The opcodes of multisig redeem scripts. They're duplicated from txscript,
+// which depends on this package.
+const (
+	op1                  = 0x51
+	op16                 = 0x60
+	opData1              = 0x01
+	opCheckMultiSigECDSA = 0xa9
+	opCheckMultiSig      = 0xae
+)
+
+// MaxMultisigPublicKeys is the maximum number of public keys in a multisig
+// redeem script, as enforced by OP_CHECKMULTISIG
+const MaxMultisigPublicKeys = 20
+
+// MultisigRedeemScript returns the redeem script of a multisig that requires
+// requiredSigs signatures of the given public keys, in their order. The public
+// keys must either all be 32-byte schnorr public keys, or all be 33-byte
+// ECDSA public keys, which are checked by OP_CHECKMULTISIG and
+// OP_CHECKMULTISIGECDSA respectively.
+func MultisigRedeemScript(requiredSigs int, publicKeys [][]byte) ([]byte, error) {
+	if len(publicKeys) == 0 || len(publicKeys) > MaxMultisigPublicKeys {
+		return nil, errors.Errorf("a multisig must have between 1 and %d public keys, but got %d",
+			MaxMultisigPublicKeys, len(publicKeys))
+	}
+	if requiredSigs < 1 || requiredSigs > len(publicKeys) {
+		return nil, errors.Errorf("a multisig of %d public keys must require between 1 and %d signatures, "+
+			"but got %d", len(publicKeys), len(publicKeys), requiredSigs)
+	}
+
+	publicKeySize := len(publicKeys[0])
+	if publicKeySize != PublicKeySize && publicKeySize != PublicKeySizeECDSA {
+		return nil, errors.Errorf("public keys must be %d or %d bytes, but got %d bytes",
+			PublicKeySize, PublicKeySizeECDSA, publicKeySize)
+	}
+	script := make([]byte, 0, 2*2+len(publicKeys)*(1+publicKeySize)+1)
+	script = appendSmallInt(script, requiredSigs)
+	for i, publicKey := range publicKeys {
+		if len(publicKey) != publicKeySize {
+			return nil, errors.Errorf("public key %d is %d bytes, unlike the first one which is %d bytes",
+				i, len(publicKey), publicKeySize)
+		}
+		script = append(script, byte(publicKeySize))
+		script = append(script, publicKey...)
+	}
+	script = appendSmallInt(script, len(publicKeys))
+	if publicKeySize == PublicKeySizeECDSA {
+		script = append(script, opCheckMultiSigECDSA)
+	} else {
+		script = append(script, opCheckMultiSig)
+	}
+	return script, nil
+}
+
+// appendSmallInt appends the canonical push of the given number, which
+// must be between 1 and MaxMultisigPublicKeys, to the given script
+func appendSmallInt(script []byte, number int) []byte {
+	if number <= 16 {
+		return append(script, byte(op1-1+number))
+	}
+	return append(script, opData1, byte(number))
+}
+
+// ParseMultisigRedeemScript returns the number of required signatures and the
+// public keys, in their order, of the given multisig redeem script. It's the
+// inverse of MultisigRedeemScript.
+func ParseMultisigRedeemScript(redeemScript []byte) (requiredSigs int, publicKeys [][]byte, err error) {
+	script := redeemScript
+	requiredSigs, script, err = parseSmallInt(script)
+	if err != nil {
+		return 0, nil, errors.Wrapf(err, "invalid required signature count")
+	}
+	if len(script) == 0 {
+		return 0, nil, errors.New("the redeem script ends before its public keys")
+	}
+	publicKeySize := int(script[0])
+	if publicKeySize != PublicKeySize && publicKeySize != PublicKeySizeECDSA {
+		return 0, nil, errors.Errorf("the redeem script doesn't push a public key of %d or %d bytes",
+			PublicKeySize, PublicKeySizeECDSA)
+	}
+	for len(script) > 0 && int(script[0]) == publicKeySize {
+		if len(script) < 1+publicKeySize {
+			return 0, nil, errors.Errorf("public key %d is truncated", len(publicKeys))
+		}
+		publicKey := make([]byte, publicKeySize)
+		copy(publicKey, script[1:1+publicKeySize])
+		publicKeys = append(publicKeys, publicKey)
+		script = script[1+publicKeySize:]
+	}
+
+	publicKeyCount, script, err := parseSmallInt(script)
+	if err != nil {
+		return 0, nil, errors.Wrapf(err, "invalid public key count")
+	}
+	if publicKeyCount != len(publicKeys) {
+		return 0, nil, errors.Errorf("the redeem script has %d public keys, but declares %d",
+			len(publicKeys), publicKeyCount)
+	}
+	expectedOpcode := byte(opCheckMultiSig)
+	if publicKeySize == PublicKeySizeECDSA {
+		expectedOpcode = opCheckMultiSigECDSA
+	}
+	if len(script) != 1 || script[0] != expectedOpcode {
+		return 0, nil, errors.Errorf("the redeem script doesn't end with the multisig opcode of "+
+			"%d-byte public keys", publicKeySize)
+	}
+	if requiredSigs > publicKeyCount {
+		return 0, nil, errors.Errorf("the redeem script requires %d signatures of only %d public keys",
+			requiredSigs, publicKeyCount)
+	}
+	return requiredSigs, publicKeys, nil
+}
+
+// parseSmallInt parses a number pushed by appendSmallInt from the
+// start of the given script, and returns the rest of the script
+func parseSmallInt(script []byte) (number int, rest []byte, err error) {
+	if len(script) == 0 {
+		return 0, nil, errors.New("the redeem script ends before a number")
+	}
+	if script[0] >= op1 && script[0] <= op16 {
+		return int(script[0]-op1) + 1, script[1:], nil
+	}
+	if script[0] == opData1 && len(script) >= 2 && script[1] > 16 && script[1] <= MaxMultisigPublicKeys {
+		return int(script[1]), script[2:], nil
+	}
+	return 0, nil, errors.Errorf("the redeem script doesn't push a number between 1 and %d",
+		MaxMultisigPublicKeys)
+}
+
+// NewAddressMultisig returns the pay-to-script-hash address of the multisig
+// redeem script that requires requiredSigs signatures of the given public
+// keys. See MultisigRedeemScript.
+func NewAddressMultisig(requiredSigs int, publicKeys [][]byte, prefix Bech32Prefix) (*AddressScriptHash, error) {
+	redeemScript, err := MultisigRedeemScript(requiredSigs, publicKeys)
+	if err != nil {
+		return nil, err
+	}
+	return NewAddressScriptHash(redeemScript, prefix)
+}
@@ -0,0 +1,152 @@
+package util_test
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/kaspanet/kaspad/domain/consensus/utils/txscript"
+	"github.com/kaspanet/kaspad/util"
+)
+
+func multisigTestPublicKeys(count int, size int) [][]byte {
+	publicKeys := make([][]byte, count)
+	for i := range publicKeys {
+		publicKeys[i] = bytes.Repeat([]byte{byte(i + 1)}, size)
+	}
+	return publicKeys
+}
+
+func TestMultisigRedeemScript(t *testing.T) {
+	tests := []struct {
+		name           string
+		requiredSigs   int
+		publicKeyCount int
+		publicKeySize  int
+		opcode         byte
+	}{
+		{"schnorr 2 of 3", 2, 3, util.PublicKeySize, txscript.OpCheckMultiSig},
+		{"ecdsa 2 of 3", 2, 3, util.PublicKeySizeECDSA, txscript.OpCheckMultiSigECDSA},
+		{"schnorr 1 of 1", 1, 1, util.PublicKeySize, txscript.OpCheckMultiSig},
+		{"schnorr 16 of 16", 16, 16, util.PublicKeySize, txscript.OpCheckMultiSig},
+		{"schnorr 17 of 20", 17, 20, util.PublicKeySize, txscript.OpCheckMultiSig},
+		{"ecdsa 3 of 20", 3, 20, util.PublicKeySizeECDSA, txscript.OpCheckMultiSigECDSA},
+	}
+
+	for _, test := range tests {
+		publicKeys := multisigTestPublicKeys(test.publicKeyCount, test.publicKeySize)
+
+		// The redeem script must be the one kaspawallet builds with txscript
+		scriptBuilder := txscript.NewScriptBuilder()
+		scriptBuilder.AddInt64(int64(test.requiredSigs))
+		for _, publicKey := range publicKeys {
+			scriptBuilder.AddData(publicKey)
+		}
+		scriptBuilder.AddInt64(int64(test.publicKeyCount))
+		scriptBuilder.AddOp(test.opcode)
+		expectedScript, err := scriptBuilder.Script()
+		if err != nil {
+			t.Fatalf("%s: Script: %s", test.name, err)
+		}
+
+		redeemScript, err := util.MultisigRedeemScript(test.requiredSigs, publicKeys)
+		if err != nil {
+			t.Fatalf("%s: MultisigRedeemScript: %s", test.name, err)
+		}
+		if !bytes.Equal(redeemScript, expectedScript) {
+			t.Fatalf("%s: expected the redeem script %x, but got %x", test.name, expectedScript, redeemScript)
+		}
+
+		requiredSigs, parsedPublicKeys, err := util.ParseMultisigRedeemScript(redeemScript)
+		if err != nil {
+			t.Fatalf("%s: ParseMultisigRedeemScript: %s", test.name, err)
+		}
+		if requiredSigs != test.requiredSigs || !reflect.DeepEqual(parsedPublicKeys, publicKeys) {
+			t.Fatalf("%s: expected %d required signatures of %x, but got %d of %x", test.name,
+				test.requiredSigs, publicKeys, requiredSigs, parsedPublicKeys)
+		}
+
+		address, err := util.NewAddressMultisig(test.requiredSigs, publicKeys, util.Bech32PrefixKaspa)
+		if err != nil {
+			t.Fatalf("%s: NewAddressMultisig: %s", test.name, err)
+		}
+		expectedAddress, err := util.NewAddressScriptHash(expectedScript, util.Bech32PrefixKaspa)
+		if err != nil {
+			t.Fatalf("%s: NewAddressScriptHash: %s", test.name, err)
+		}
+		if address.EncodeAddress() != expectedAddress.EncodeAddress() {
+			t.Fatalf("%s: expected the address %s, but got %s", test.name, expectedAddress, address)
+		}
+		scriptPublicKey, err := txscript.PayToAddrScript(address)
+		if err != nil {
+			t.Fatalf("%s: PayToAddrScript: %s", test.name, err)
+		}
+		if txscript.GetScriptClass(scriptPublicKey.Script) != txscript.ScriptHashTy {
+			t.Fatalf("%s: expected the address to pay to a script hash", test.name)
+		}
+	}
+}
+
+func TestMultisigRedeemScriptErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		requiredSigs int
+		publicKeys   [][]byte
+	}{
+		{"no public keys", 1, nil},
+		{"too many public keys", 1, multisigTestPublicKeys(util.MaxMultisigPublicKeys+1, util.PublicKeySize)},
+		{"no required signatures", 0, multisigTestPublicKeys(2, util.PublicKeySize)},
+		{"more required signatures than public keys", 3, multisigTestPublicKeys(2, util.PublicKeySize)},
+		{"invalid public key size", 1, multisigTestPublicKeys(2, 20)},
+		{"mixed public key sizes", 1, append(multisigTestPublicKeys(1, util.PublicKeySize),
+			multisigTestPublicKeys(1, util.PublicKeySizeECDSA)...)},
+	}
+	for _, test := range tests {
+		_, err := util.MultisigRedeemScript(test.requiredSigs, test.publicKeys)
+		if err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+		_, err = util.NewAddressMultisig(test.requiredSigs, test.publicKeys, util.Bech32PrefixKaspa)
+		if err == nil {
+			t.Errorf("%s: expected NewAddressMultisig to fail", test.name)
+		}
+	}
+}
+
+func TestParseMultisigRedeemScriptErrors(t *testing.T) {
+	validScript, err := util.MultisigRedeemScript(2, multisigTestPublicKeys(3, util.PublicKeySize))
+	if err != nil {
+		t.Fatalf("MultisigRedeemScript: %s", err)
+	}
+	// The public key count of the script declares 2 public keys instead of 3
+	wrongCountScript := append([]byte{}, validScript...)
+	wrongCountScript[len(wrongCountScript)-2] = txscript.Op2
+	// The script uses the opcode of ECDSA public keys for schnorr public keys
+	wrongOpcodeScript := append([]byte{}, validScript...)
+	wrongOpcodeScript[len(wrongOpcodeScript)-1] = txscript.OpCheckMultiSigECDSA
+	// The script requires 3 signatures of 2 public keys
+	tooManySigsScript, err := util.MultisigRedeemScript(2, multisigTestPublicKeys(2, util.PublicKeySize))
+	if err != nil {
+		t.Fatalf("MultisigRedeemScript: %s", err)
+	}
+	tooManySigsScript[0] = txscript.Op3
+
+	tests := []struct {
+		name   string
+		script []byte
+	}{
+		{"empty", nil},
+		{"truncated public key", validScript[:20]},
+		{"missing opcode", validScript[:len(validScript)-1]},
+		{"trailing data", append(append([]byte{}, validScript...), txscript.OpTrue)},
+		{"wrong public key count", wrongCountScript},
+		{"wrong opcode", wrongOpcodeScript},
+		{"too many required signatures", tooManySigsScript},
+	}
+	for _, test := range tests {
+		_, _, err := util.ParseMultisigRedeemScript(test.script)
+		if err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
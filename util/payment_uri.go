@@ -0,0 +1,145 @@
+package util
+
+// References:
+//   [BIP21]: BIP0021 - URI Scheme
+//   https://github.com/bitcoin/bips/blob/master/bip-0021.mediawiki
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// The names of the query parameters of a payment URI.
+const (
+	paymentURIAmountParameter  = "amount"
+	paymentURILabelParameter   = "label"
+	paymentURIMessageParameter = "message"
+
+	// paymentURIRequiredParameterPrefix is the prefix of parameters that a
+	// payment URI may only be used by clients that understand them.
+	paymentURIRequiredParameterPrefix = "req-"
+)
+
+// PaymentURI is a request for payment to an address, in the form of a
+// [BIP21]-style URI. The scheme of the URI is the prefix of the address,
+// so that the address itself is a valid URI:
+//
+//	kaspa:qr35ennsep3hxfe7lnz5ee7j5jgmkjswsn35ennsep3hxfe7ln35cdv0dy335?amount=1.5&label=Coffee
+type PaymentURI struct {
+	Address Address
+
+	// Amount is the requested amount. Zero means that the URI doesn't
+	// request a specific amount.
+	Amount Amount
+
+	// Label is the name of the recipient, and Message describes what the
+	// payment is for. They are empty if the URI doesn't include them.
+	Label   string
+	Message string
+
+	// OtherParameters are the parameters of the URI that aren't amount,
+	// label or message. Parameters that start with "req-" are never
+	// included, since ParsePaymentURI rejects URIs with them.
+	OtherParameters map[string]string
+}
+
+// ParsePaymentURI parses a payment URI. The address of the URI must be valid,
+// and if expectedPrefix isn't Bech32PrefixUnknown, it must be of that prefix.
+//
+// The amount of the URI is in KAS and is parsed like ParseAmount parses it.
+// URIs with parameters that start with "req-", which per [BIP21] mark
+// parameters that the URI can't be used without, are rejected.
+func ParsePaymentURI(uri string, expectedPrefix Bech32Prefix) (*PaymentURI, error) {
+	addressPart, query, _ := strings.Cut(uri, "?")
+
+	// The scheme is case insensitive, but the address is decoded only if
+	// all its characters have the same case
+	scheme, addressData, _ := strings.Cut(addressPart, ":")
+	if addressData == strings.ToUpper(addressData) {
+		addressPart = strings.ToUpper(scheme) + ":" + addressData
+	} else {
+		addressPart = strings.ToLower(scheme) + ":" + addressData
+	}
+	address, err := DecodeAddress(addressPart, expectedPrefix)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid address in payment URI")
+	}
+
+	parameters, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, errors.Errorf("invalid query in payment URI: %s", err)
+	}
+	paymentURI := &PaymentURI{Address: address}
+	for name, values := range parameters {
+		if len(values) != 1 {
+			return nil, errors.Errorf("parameter %s appears %d times in payment URI", name, len(values))
+		}
+		value := values[0]
+		switch {
+		case name == paymentURIAmountParameter:
+			// Unlike ParseAmount, the amount of a payment URI is a plain
+			// number, without a unit label or surrounding whitespace
+			if strings.TrimSpace(value) != value || strings.Contains(value, " ") {
+				return nil, errors.Errorf("invalid amount in payment URI: %q", value)
+			}
+			paymentURI.Amount, err = ParseAmount(value, AmountKAS)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid amount in payment URI")
+			}
+		case name == paymentURILabelParameter:
+			paymentURI.Label = value
+		case name == paymentURIMessageParameter:
+			paymentURI.Message = value
+		case strings.HasPrefix(name, paymentURIRequiredParameterPrefix):
+			return nil, errors.Errorf("payment URI requires the unsupported parameter %s", name)
+		default:
+			if paymentURI.OtherParameters == nil {
+				paymentURI.OtherParameters = make(map[string]string)
+			}
+			paymentURI.OtherParameters[name] = value
+		}
+	}
+	return paymentURI, nil
+}
+
+// String returns the payment URI as a string, which ParsePaymentURI parses
+// back into the same payment URI. The amount, label and message come first,
+// followed by the other parameters in alphabetical order.
+func (p *PaymentURI) String() string {
+	var parameters []string
+	addParameter := func(name, value string) {
+		parameters = append(parameters, escapePaymentURIComponent(name)+"="+escapePaymentURIComponent(value))
+	}
+	if p.Amount != 0 {
+		addParameter(paymentURIAmountParameter, formatSompi(uint64(p.Amount), int(AmountKAS+8)))
+	}
+	if p.Label != "" {
+		addParameter(paymentURILabelParameter, p.Label)
+	}
+	if p.Message != "" {
+		addParameter(paymentURIMessageParameter, p.Message)
+	}
+	otherParameterNames := make([]string, 0, len(p.OtherParameters))
+	for name := range p.OtherParameters {
+		otherParameterNames = append(otherParameterNames, name)
+	}
+	sort.Strings(otherParameterNames)
+	for _, name := range otherParameterNames {
+		addParameter(name, p.OtherParameters[name])
+	}
+
+	uri := p.Address.EncodeAddress()
+	if len(parameters) > 0 {
+		uri += "?" + strings.Join(parameters, "&")
+	}
+	return uri
+}
+
+// escapePaymentURIComponent percent-encodes s for a query of a payment URI.
+// Spaces are encoded as %20 rather than '+', which not all wallets decode.
+func escapePaymentURIComponent(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
@@ -0,0 +1,218 @@
+package util_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kaspanet/kaspad/util"
+)
+
+const (
+	paymentURITestAddress     = "kaspa:qr35ennsep3hxfe7lnz5ee7j5jgmkjswsn35ennsep3hxfe7ln35cdv0dy335"
+	paymentURITestnetAddress  = "kaspatest:qputx94qseratdmjs0j395mq8u03er0x3l35ennsep3hxfe7ln35ckquw528z"
+	paymentURITestP2SHAddress = "kaspa:qq80qvqs0lfxuzmt7sz3909ze6camq9d4t35ennsep3hxfe7ln35cvfqgz3z8"
+)
+
+func TestParsePaymentURI(t *testing.T) {
+	tests := []struct {
+		name            string
+		uri             string
+		prefix          util.Bech32Prefix
+		valid           bool
+		address         string
+		amount          util.Amount
+		label           string
+		message         string
+		otherParameters map[string]string
+	}{
+		{
+			name:    "address only",
+			uri:     paymentURITestAddress,
+			prefix:  util.Bech32PrefixKaspa,
+			valid:   true,
+			address: paymentURITestAddress,
+		},
+		{
+			name:    "all parameters",
+			uri:     paymentURITestAddress + "?amount=1.5&label=Coffee%20Shop&message=Order%20%2342",
+			prefix:  util.Bech32PrefixKaspa,
+			valid:   true,
+			address: paymentURITestAddress,
+			amount:  150000000,
+			label:   "Coffee Shop",
+			message: "Order #42",
+		},
+		{
+			name:    "plus as space",
+			uri:     paymentURITestAddress + "?label=Coffee+Shop",
+			prefix:  util.Bech32PrefixKaspa,
+			valid:   true,
+			address: paymentURITestAddress,
+			label:   "Coffee Shop",
+		},
+		{
+			name:            "other parameters",
+			uri:             paymentURITestP2SHAddress + "?amount=0.00000001&invoice=abc",
+			prefix:          util.Bech32PrefixKaspa,
+			valid:           true,
+			address:         paymentURITestP2SHAddress,
+			amount:          1,
+			otherParameters: map[string]string{"invoice": "abc"},
+		},
+		{
+			name:    "any prefix",
+			uri:     paymentURITestnetAddress + "?amount=20",
+			prefix:  util.Bech32PrefixUnknown,
+			valid:   true,
+			address: paymentURITestnetAddress,
+			amount:  2000000000,
+		},
+		{
+			name:    "uppercase",
+			uri:     strings.ToUpper(paymentURITestAddress) + "?amount=1",
+			prefix:  util.Bech32PrefixKaspa,
+			valid:   true,
+			address: paymentURITestAddress,
+			amount:  100000000,
+		},
+		{
+			name:    "uppercase scheme",
+			uri:     "KASPA" + strings.TrimPrefix(paymentURITestAddress, "kaspa"),
+			prefix:  util.Bech32PrefixKaspa,
+			valid:   true,
+			address: paymentURITestAddress,
+		},
+		{
+			name:   "wrong prefix",
+			uri:    paymentURITestnetAddress,
+			prefix: util.Bech32PrefixKaspa,
+			valid:  false,
+		},
+		{
+			name:   "bad checksum",
+			uri:    paymentURITestAddress[:len(paymentURITestAddress)-1] + "6",
+			prefix: util.Bech32PrefixKaspa,
+			valid:  false,
+		},
+		{
+			name:   "no address",
+			uri:    "kaspa:?amount=1",
+			prefix: util.Bech32PrefixKaspa,
+			valid:  false,
+		},
+		{
+			name:   "invalid amount",
+			uri:    paymentURITestAddress + "?amount=1,5",
+			prefix: util.Bech32PrefixKaspa,
+			valid:  false,
+		},
+		{
+			name:   "amount with a unit label",
+			uri:    paymentURITestAddress + "?amount=1%20KAS",
+			prefix: util.Bech32PrefixKaspa,
+			valid:  false,
+		},
+		{
+			name:   "amount more precise than a sompi",
+			uri:    paymentURITestAddress + "?amount=0.000000001",
+			prefix: util.Bech32PrefixKaspa,
+			valid:  false,
+		},
+		{
+			name:   "duplicate parameter",
+			uri:    paymentURITestAddress + "?amount=1&amount=2",
+			prefix: util.Bech32PrefixKaspa,
+			valid:  false,
+		},
+		{
+			name:   "required parameter",
+			uri:    paymentURITestAddress + "?req-somethingyoudontunderstand=50",
+			prefix: util.Bech32PrefixKaspa,
+			valid:  false,
+		},
+		{
+			name:   "invalid escape",
+			uri:    paymentURITestAddress + "?label=%zz",
+			prefix: util.Bech32PrefixKaspa,
+			valid:  false,
+		},
+	}
+
+	for _, test := range tests {
+		paymentURI, err := util.ParsePaymentURI(test.uri, test.prefix)
+		if !test.valid {
+			if err == nil {
+				t.Errorf("%s: ParsePaymentURI unexpectedly succeeded", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: ParsePaymentURI: %s", test.name, err)
+			continue
+		}
+		if paymentURI.Address.String() != test.address {
+			t.Errorf("%s: expected the address %s, but got %s", test.name, test.address, paymentURI.Address)
+		}
+		if paymentURI.Amount != test.amount {
+			t.Errorf("%s: expected the amount %d, but got %d", test.name, test.amount, paymentURI.Amount)
+		}
+		if paymentURI.Label != test.label || paymentURI.Message != test.message {
+			t.Errorf("%s: expected the label %q and message %q, but got %q and %q", test.name,
+				test.label, test.message, paymentURI.Label, paymentURI.Message)
+		}
+		if !reflect.DeepEqual(paymentURI.OtherParameters, test.otherParameters) {
+			t.Errorf("%s: expected the other parameters %v, but got %v", test.name,
+				test.otherParameters, paymentURI.OtherParameters)
+		}
+	}
+}
+
+func TestPaymentURIString(t *testing.T) {
+	address, err := util.DecodeAddress(paymentURITestAddress, util.Bech32PrefixKaspa)
+	if err != nil {
+		t.Fatalf("DecodeAddress: %s", err)
+	}
+
+	tests := []struct {
+		name       string
+		paymentURI *util.PaymentURI
+		expected   string
+	}{
+		{
+			name:       "address only",
+			paymentURI: &util.PaymentURI{Address: address},
+			expected:   paymentURITestAddress,
+		},
+		{
+			name: "all parameters",
+			paymentURI: &util.PaymentURI{
+				Address:         address,
+				Amount:          123456789012,
+				Label:           "Coffee Shop",
+				Message:         "Order #42 & 50% off+tax",
+				OtherParameters: map[string]string{"z": "1", "a": "2"},
+			},
+			expected: paymentURITestAddress + "?amount=1234.56789012&label=Coffee%20Shop&" +
+				"message=Order%20%2342%20%26%2050%25%20off%2Btax&a=2&z=1",
+		},
+	}
+
+	for _, test := range tests {
+		uri := test.paymentURI.String()
+		if uri != test.expected {
+			t.Errorf("%s: expected the URI %s, but got %s", test.name, test.expected, uri)
+			continue
+		}
+
+		parsedPaymentURI, err := util.ParsePaymentURI(uri, util.Bech32PrefixKaspa)
+		if err != nil {
+			t.Errorf("%s: ParsePaymentURI: %s", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(parsedPaymentURI, test.paymentURI) {
+			t.Errorf("%s: expected the parsed payment URI %+v, but got %+v", test.name,
+				test.paymentURI, parsedPaymentURI)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package profiling
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// The types of profiles Capture can capture
+const (
+	ProfileTypeCPU       = "cpu"
+	ProfileTypeHeap      = "heap"
+	ProfileTypeGoroutine = "goroutine"
+	ProfileTypeTrace     = "trace"
+)
+
+// MaxCaptureDuration is the longest duration a CPU profile or an execution
+// trace may be captured for
+const MaxCaptureDuration = 5 * time.Minute
+
+// Capture captures a profile of the given type into a new file in the given
+// directory, which is created if it doesn't exist, and returns the path of
+// the file.
+//
+// CPU profiles and execution traces are captured for the given duration,
+// and Capture blocks until it passes. Only one of each may be captured at
+// a time. Heap profiles and goroutine dumps are snapshots, so they ignore
+// the duration. Goroutine dumps are written as text, in the same format as
+// the stack traces of a panic. The rest are written in the formats that
+// `go tool pprof` and `go tool trace` read.
+func Capture(profileType string, duration time.Duration, directory string) (filePath string, err error) {
+	var extension string
+	switch profileType {
+	case ProfileTypeCPU, ProfileTypeTrace:
+		if duration <= 0 || duration > MaxCaptureDuration {
+			return "", errors.Errorf("the duration of a %s profile must be positive and at most %s, but got %s",
+				profileType, MaxCaptureDuration, duration)
+		}
+		extension = "pprof"
+		if profileType == ProfileTypeTrace {
+			extension = "trace"
+		}
+	case ProfileTypeHeap:
+		extension = "pprof"
+	case ProfileTypeGoroutine:
+		extension = "txt"
+	default:
+		return "", errors.Errorf("unknown profile type %q. The profile type must be one of {%s, %s, %s, %s}",
+			profileType, ProfileTypeCPU, ProfileTypeHeap, ProfileTypeGoroutine, ProfileTypeTrace)
+	}
+
+	err = os.MkdirAll(directory, 0700)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	// The file name uses a custom time format for compliance with file
+	// name rules on all OSes
+	fileName := fmt.Sprintf("%s-%s.%s", profileType, time.Now().Format("01-02-2006T15.04.05.000"), extension)
+	filePath = filepath.Join(directory, fileName)
+	file, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	defer func() {
+		closeErr := file.Close()
+		if err == nil && closeErr != nil {
+			err = errors.WithStack(closeErr)
+		}
+		if err != nil {
+			_ = os.Remove(filePath)
+		}
+	}()
+
+	switch profileType {
+	case ProfileTypeCPU:
+		err = pprof.StartCPUProfile(file)
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+		time.Sleep(duration)
+		pprof.StopCPUProfile()
+	case ProfileTypeTrace:
+		err = trace.Start(file)
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+		time.Sleep(duration)
+		trace.Stop()
+	case ProfileTypeHeap:
+		// Collect garbage first, so that the profile is up to date
+		runtime.GC()
+		err = pprof.Lookup("heap").WriteTo(file, 0)
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+	case ProfileTypeGoroutine:
+		err = pprof.Lookup("goroutine").WriteTo(file, 2)
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+	}
+	return filePath, nil
+}
@@ -0,0 +1,90 @@
+package profiling
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCapture(t *testing.T) {
+	directory := filepath.Join(t.TempDir(), "profiles")
+
+	tests := []struct {
+		profileType       string
+		duration          time.Duration
+		expectedExtension string
+	}{
+		{profileType: ProfileTypeCPU, duration: 100 * time.Millisecond, expectedExtension: ".pprof"},
+		{profileType: ProfileTypeHeap, expectedExtension: ".pprof"},
+		{profileType: ProfileTypeGoroutine, expectedExtension: ".txt"},
+		{profileType: ProfileTypeTrace, duration: 100 * time.Millisecond, expectedExtension: ".trace"},
+	}
+	for _, test := range tests {
+		filePath, err := Capture(test.profileType, test.duration, directory)
+		if err != nil {
+			t.Fatalf("Capture %s: %s", test.profileType, err)
+		}
+		if filepath.Dir(filePath) != directory || filepath.Ext(filePath) != test.expectedExtension ||
+			!strings.HasPrefix(filepath.Base(filePath), test.profileType+"-") {
+			t.Fatalf("Unexpected file path %s for a %s profile", filePath, test.profileType)
+		}
+		content, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			t.Fatalf("ReadFile: %s", err)
+		}
+		if len(content) == 0 {
+			t.Fatalf("The %s profile is empty", test.profileType)
+		}
+		if test.profileType == ProfileTypeGoroutine && !strings.Contains(string(content), "TestCapture") {
+			t.Fatalf("The goroutine dump doesn't contain the stack of the test")
+		}
+	}
+}
+
+func TestCaptureErrors(t *testing.T) {
+	directory := t.TempDir()
+
+	tests := []struct {
+		name        string
+		profileType string
+		duration    time.Duration
+	}{
+		{name: "unknown type", profileType: "mutex"},
+		{name: "CPU profile without a duration", profileType: ProfileTypeCPU},
+		{name: "trace longer than the maximum", profileType: ProfileTypeTrace, duration: MaxCaptureDuration + time.Second},
+	}
+	for _, test := range tests {
+		_, err := Capture(test.profileType, test.duration, directory)
+		if err == nil {
+			t.Fatalf("%s: expected an error", test.name)
+		}
+	}
+	files, err := ioutil.ReadDir(directory)
+	if err != nil {
+		t.Fatalf("ReadDir: %s", err)
+	}
+	if len(files) != 0 {
+		t.Fatalf("Expected failed captures to leave no files, but found %d", len(files))
+	}
+}
+
+func TestCaptureConcurrentCPUProfiles(t *testing.T) {
+	directory := t.TempDir()
+
+	firstErr := make(chan error)
+	go func() {
+		_, err := Capture(ProfileTypeCPU, time.Second, directory)
+		firstErr <- err
+	}()
+	time.Sleep(200 * time.Millisecond)
+	_, err := Capture(ProfileTypeCPU, 100*time.Millisecond, directory)
+	if err == nil {
+		t.Fatalf("Expected a concurrent CPU profile to fail")
+	}
+	err = <-firstErr
+	if err != nil {
+		t.Fatalf("Capture: %s", err)
+	}
+}